@@ -10,14 +10,30 @@ type ClusterTemplate struct {
 	Variables          []TemplateVariable `json:"variables"`
 	Labels             map[string]string  `json:"labels"`
 	Annotations        map[string]string  `json:"annotations"`
+
+	// Version is the tag of the artifact the template was published from.
+	// ProviderVersions are the management cluster provider versions it
+	// works with by provider label, and Incompatibilities the installed
+	// providers outside them.
+	Version           string            `json:"version,omitempty"`
+	ProviderVersions  map[string]string `json:"provider_versions,omitempty"`
+	Incompatibilities []string          `json:"incompatibilities,omitempty"`
+
+	// PublishedFrom is where publish_cluster_template applied the template
+	// from; CatalogTemplate and CatalogVersion are set when that was a
+	// catalog.
+	PublishedFrom   string `json:"published_from,omitempty"`
+	CatalogTemplate string `json:"catalog_template,omitempty"`
+	CatalogVersion  string `json:"catalog_version,omitempty"`
 }
 
 // TemplateVariable describes a variable that can be set when creating a cluster from a template.
 type TemplateVariable struct {
-	Name        string      `json:"name"`
-	Required    bool        `json:"required"`
-	Type        string      `json:"type"`
-	Default     interface{} `json:"default,omitempty"`
-	Description string      `json:"description"`
-	Example     interface{} `json:"example,omitempty"`
+	Name        string        `json:"name"`
+	Required    bool          `json:"required"`
+	Type        string        `json:"type"`
+	Default     interface{}   `json:"default,omitempty"`
+	Description string        `json:"description"`
+	Example     interface{}   `json:"example,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
 }
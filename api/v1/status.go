@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClusterStatus is the status of a cluster reported by the cluster tools. It
+// is normalized from the Cluster API phase of the cluster, so every tool
+// reports the same value for the same cluster.
+type ClusterStatus string
+
+const (
+	// ClusterStatusPending is a cluster whose infrastructure has not started
+	// provisioning yet.
+	ClusterStatusPending ClusterStatus = "Pending"
+	// ClusterStatusProvisioning is a cluster whose infrastructure or control
+	// plane is being provisioned.
+	ClusterStatusProvisioning ClusterStatus = "Provisioning"
+	// ClusterStatusReady is a cluster in the Cluster API Provisioned phase.
+	ClusterStatusReady ClusterStatus = "Ready"
+	// ClusterStatusDeleting is a cluster being deleted.
+	ClusterStatusDeleting ClusterStatus = "Deleting"
+	// ClusterStatusFailed is a cluster that failed and needs intervention.
+	ClusterStatusFailed ClusterStatus = "Failed"
+	// ClusterStatusQueued is a cluster create_cluster holds back until fewer
+	// clusters are provisioning; it does not exist yet.
+	ClusterStatusQueued ClusterStatus = "Queued"
+	// ClusterStatusUnknown is a cluster whose phase is not reported or not
+	// recognized.
+	ClusterStatusUnknown ClusterStatus = "Unknown"
+)
+
+// ClusterStatuses lists every cluster status.
+func ClusterStatuses() []ClusterStatus {
+	return []ClusterStatus{
+		ClusterStatusPending,
+		ClusterStatusProvisioning,
+		ClusterStatusReady,
+		ClusterStatusDeleting,
+		ClusterStatusFailed,
+		ClusterStatusQueued,
+		ClusterStatusUnknown,
+	}
+}
+
+// ClusterStatusFromPhase converts a Cluster API phase to a cluster status.
+func ClusterStatusFromPhase(phase string) ClusterStatus {
+	switch strings.ToLower(phase) {
+	case "pending":
+		return ClusterStatusPending
+	case "provisioning":
+		return ClusterStatusProvisioning
+	case "provisioned":
+		return ClusterStatusReady
+	case "deleting":
+		return ClusterStatusDeleting
+	case "failed":
+		return ClusterStatusFailed
+	default:
+		return ClusterStatusUnknown
+	}
+}
+
+// ParseClusterStatus parses a cluster status given in a filter, ignoring
+// case. Cluster API phases are accepted too, so "Provisioned" is Ready.
+func ParseClusterStatus(s string) (ClusterStatus, error) {
+	for _, status := range ClusterStatuses() {
+		if strings.EqualFold(s, string(status)) {
+			return status, nil
+		}
+	}
+	if status := ClusterStatusFromPhase(s); status != ClusterStatusUnknown {
+		return status, nil
+	}
+
+	names := make([]string, 0, len(ClusterStatuses()))
+	for _, status := range ClusterStatuses() {
+		names = append(names, string(status))
+	}
+	return "", fmt.Errorf("unknown cluster status %q, must be one of: %s", s, strings.Join(names, ", "))
+}
+
+// Valid reports whether s is one of the cluster statuses.
+func (s ClusterStatus) Valid() bool {
+	for _, status := range ClusterStatuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
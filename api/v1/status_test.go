@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterStatusFromPhase(t *testing.T) {
+	tests := []struct {
+		phase string
+		want  ClusterStatus
+	}{
+		{phase: "Pending", want: ClusterStatusPending},
+		{phase: "Provisioning", want: ClusterStatusProvisioning},
+		{phase: "Provisioned", want: ClusterStatusReady},
+		{phase: "provisioned", want: ClusterStatusReady},
+		{phase: "Deleting", want: ClusterStatusDeleting},
+		{phase: "Failed", want: ClusterStatusFailed},
+		{phase: "", want: ClusterStatusUnknown},
+		{phase: "Mystery", want: ClusterStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.phase, func(t *testing.T) {
+			status := ClusterStatusFromPhase(tt.phase)
+			assert.Equal(t, tt.want, status)
+			assert.True(t, status.Valid())
+		})
+	}
+}
+
+func TestParseClusterStatus(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ClusterStatus
+		wantErr bool
+	}{
+		{input: "Ready", want: ClusterStatusReady},
+		{input: "ready", want: ClusterStatusReady},
+		{input: "Provisioned", want: ClusterStatusReady},
+		{input: "QUEUED", want: ClusterStatusQueued},
+		{input: "Unknown", want: ClusterStatusUnknown},
+		{input: "running", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			status, err := ParseClusterStatus(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "must be one of")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
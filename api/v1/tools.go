@@ -1,22 +1,70 @@
 package v1
 
 // ListClustersInput defines the parameters for the list_clusters tool.
-type ListClustersInput struct{}
+type ListClustersInput struct {
+	// Status lists only the clusters with this status, see ParseClusterStatus.
+	Status string `json:"status,omitempty"`
+	// Environment lists only the clusters of this environment, see ListEnvironmentsOutput.
+	Environment string `json:"environment,omitempty"`
+	// SinceToken lists only the clusters changed since the call that returned
+	// this token as next_token, see ListClustersOutput.
+	SinceToken string `json:"since_token,omitempty"`
+}
 
 // ListClustersOutput defines the response for the list_clusters tool.
 type ListClustersOutput struct {
-	Clusters []ClusterSummary `json:"clusters"`
+	Clusters    []ClusterSummary `json:"clusters"`
+	LastUpdated string           `json:"last_updated"` // oldest time the summaries may date from
+
+	// Incremental is set when Clusters holds only the clusters added or
+	// changed since the call that returned the since_token, and Removed the
+	// clusters deleted since. Otherwise the list is complete, e.g. because
+	// the token expired.
+	Incremental bool     `json:"incremental,omitempty"`
+	Removed     []string `json:"removed,omitempty"`    // namespace/name of each removed cluster
+	NextToken   string   `json:"next_token,omitempty"` // since_token of the next call
 }
 
 // ClusterSummary provides basic information about a cluster.
 type ClusterSummary struct {
-	Name              string `json:"name"`
-	Namespace         string `json:"namespace"`
-	Provider          string `json:"provider"`
-	KubernetesVersion string `json:"kubernetes_version"`
-	Status            string `json:"status"`
-	CreatedAt         string `json:"created_at"`
-	NodeCount         int    `json:"node_count"`
+	Name              string        `json:"name"`
+	Namespace         string        `json:"namespace"`
+	Provider          string        `json:"provider"`
+	Region            string        `json:"region,omitempty"`
+	Environment       string        `json:"environment,omitempty"` // value of the environment label
+	Endpoint          string        `json:"endpoint,omitempty"`
+	KubernetesVersion string        `json:"kubernetes_version"`
+	Status            ClusterStatus `json:"status"`
+	CreatedAt         string        `json:"created_at"`
+	Age               string        `json:"age,omitempty"`
+	NodeCount         int           `json:"node_count"`       // desired nodes, control plane and workers
+	ReadyNodeCount    int           `json:"ready_node_count"` // nodes reported ready
+	LastUpdated       string        `json:"last_updated,omitempty"`
+	ResourceVersion   string        `json:"resource_version,omitempty"` // of the Cluster object
+
+	Advisory *VersionAdvisory `json:"advisory,omitempty"` // support status of the Kubernetes version
+
+	// Warnings describe data that could not be retrieved, such as node
+	// counts, so zero values are not mistaken for real ones.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// VersionAdvisory is the support status of a Kubernetes version: the end of
+// life of its minor release and the CVEs affecting it.
+type VersionAdvisory struct {
+	Release   string          `json:"release"` // minor release, e.g. 1.31
+	EndOfLife string          `json:"end_of_life,omitempty"`
+	Status    string          `json:"status"` // supported, end-of-life-soon, end-of-life or unknown
+	CVEs      []KubernetesCVE `json:"cves"`
+	UpgradeTo string          `json:"upgrade_to,omitempty"` // release to upgrade to, if an upgrade is needed
+}
+
+// KubernetesCVE is a vulnerability affecting a Kubernetes version.
+type KubernetesCVE struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	FixedIn  string `json:"fixed_in,omitempty"` // empty if the minor release has no fix
 }
 
 // GetClusterInput defines the parameters for the get_cluster tool.
@@ -27,6 +75,9 @@ type GetClusterInput struct {
 // GetClusterOutput defines the response for the get_cluster tool.
 type GetClusterOutput struct {
 	Cluster ClusterDetails `json:"cluster"`
+	// Warnings describe details that could not be retrieved and are left
+	// out or incomplete, such as node pools.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ClusterDetails provides detailed information about a cluster.
@@ -36,20 +87,107 @@ type ClusterDetails struct {
 	Provider          string                 `json:"provider"`
 	Region            string                 `json:"region"`
 	KubernetesVersion string                 `json:"kubernetes_version"`
-	Status            string                 `json:"status"`
+	Status            ClusterStatus          `json:"status"`
 	CreatedAt         string                 `json:"created_at"`
 	Endpoint          string                 `json:"endpoint"`
 	NodePools         []NodePool             `json:"node_pools"`
+	ControlPlane      *ControlPlaneStatus    `json:"control_plane,omitempty"`
 	Conditions        []ClusterCondition     `json:"conditions"`
 	InfrastructureRef map[string]interface{} `json:"infrastructure_ref"`
+	CloudTags         *CloudTags             `json:"cloud_tags,omitempty"`
+	Identity          *ClusterIdentity       `json:"identity,omitempty"`
+	Bastion           *BastionHost           `json:"bastion,omitempty"`
+	ProviderStatus    *ProviderStatus        `json:"provider_status,omitempty"`
+}
+
+// ProviderStatus reports the status of a cluster's infrastructure in the
+// same fields for every provider.
+type ProviderStatus struct {
+	Provider            string `json:"provider"`
+	Region              string `json:"region,omitempty"`
+	InfrastructureReady bool   `json:"infrastructure_ready"`
+	NetworkReady        bool   `json:"network_ready"` // e.g. the VPC and its subnets
+	// ControlPlaneLoadBalancer is the address of the load balancer in front
+	// of the API servers.
+	ControlPlaneLoadBalancer string             `json:"control_plane_load_balancer,omitempty"`
+	FailureDomains           []string           `json:"failure_domains,omitempty"`
+	CloudResources           []CloudResourceRef `json:"cloud_resources,omitempty"`
+	// Extras holds the status only the cluster's provider reports.
+	Extras map[string]interface{} `json:"extras,omitempty"`
+}
+
+// CloudResourceRef identifies a cloud resource of a cluster.
+type CloudResourceRef struct {
+	Type   string `json:"type"` // e.g. vpc or subnet
+	ID     string `json:"id"`
+	Region string `json:"region,omitempty"`
+}
+
+// CloudTags reports the tags applied to a cluster's cloud resources.
+type CloudTags struct {
+	Tags map[string]string `json:"tags"`
+	// Source is "infrastructure" when the tags were read from the
+	// infrastructure cluster, or "variables" when only the requested
+	// cloudTags variable is known.
+	Source string `json:"source"`
+}
+
+// BastionHost reports the bastion host in front of a cluster's nodes.
+type BastionHost struct {
+	// Address is the address to SSH to, the public IP when the bastion has
+	// one and otherwise the private IP.
+	Address    string `json:"address,omitempty"`
+	PublicIP   string `json:"public_ip,omitempty"`
+	PrivateIP  string `json:"private_ip,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+	State      string `json:"state,omitempty"` // e.g. pending, running
+	// Source is "infrastructure" when the bastion was read from the
+	// infrastructure cluster, or "variables" when only the requested
+	// bastionEnabled variable is known.
+	Source string `json:"source"`
+}
+
+// ClusterIdentity reports the CAPA identity a cluster's AWS resources are
+// managed with, which determines the AWS account they live in.
+type ClusterIdentity struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// RoleARN is the role assumed by an AWSClusterRoleIdentity.
+	RoleARN string `json:"role_arn,omitempty"`
+	// SourceIdentity is the identity an AWSClusterRoleIdentity assumes its
+	// role from.
+	SourceIdentity string `json:"source_identity,omitempty"`
+	// Source is "infrastructure" when the identity was read from the
+	// infrastructure cluster, or "variables" when only the requested
+	// identityRef variable is known.
+	Source string `json:"source"`
+}
+
+// ControlPlaneStatus represents the control plane of a cluster.
+// Managed control planes (EKS, AKS, GKE) have no replica counts.
+type ControlPlaneStatus struct {
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Managed       bool   `json:"managed"`
+	Replicas      int    `json:"replicas"`
+	ReadyReplicas int    `json:"ready_replicas"`
+	Ready         bool   `json:"ready"`
+	Message       string `json:"message,omitempty"`
 }
 
 // NodePool represents a group of nodes in a cluster.
 type NodePool struct {
 	Name          string `json:"name"`
+	Kind          string `json:"kind"` // MachineDeployment or MachinePool
 	Replicas      int    `json:"replicas"`
 	ReadyReplicas int    `json:"ready_replicas"`
 	MachineType   string `json:"machine_type"`
+	Phase         string `json:"phase,omitempty"`
+
+	// Spot is set for node pools running on spot capacity, with the number of
+	// their Machines whose instances were reclaimed by a spot interruption.
+	Spot              bool `json:"spot,omitempty"`
+	SpotInterruptions int  `json:"spot_interruptions,omitempty"`
 }
 
 // ClusterCondition represents a condition of a cluster.
@@ -67,13 +205,40 @@ type CreateClusterInput struct {
 	TemplateName      string                 `json:"template_name" validate:"required"`
 	KubernetesVersion string                 `json:"kubernetes_version" validate:"required"`
 	Variables         map[string]interface{} `json:"variables,omitempty"`
+	Preset            string                 `json:"preset,omitempty"`
+	// TemplateVersion pins the version of the cluster template the cluster
+	// must be created from; creation fails if another version is installed.
+	TemplateVersion string `json:"template_version,omitempty"`
+	// DryRun runs every check of the creation without creating the cluster.
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 // CreateClusterOutput defines the response for the create_cluster tool.
 type CreateClusterOutput struct {
-	ClusterName string `json:"cluster_name"`
-	Status      string `json:"status"`
-	Message     string `json:"message"`
+	ClusterName string        `json:"cluster_name"`
+	Status      ClusterStatus `json:"status"`
+	Message     string        `json:"message"`
+	Warnings    []string      `json:"warnings,omitempty"`
+	// NodeImage is the image resolved for the template's amiID variable
+	// when the caller did not set it.
+	NodeImage string `json:"node_image,omitempty"`
+	// OperationID is the async operation of a creation queued until fewer
+	// clusters are provisioning.
+	OperationID string `json:"operation_id,omitempty"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+}
+
+// ListPresetsOutput defines the response for the list_presets tool.
+type ListPresetsOutput struct {
+	Presets []VariablePreset `json:"presets"`
+}
+
+// VariablePreset is a named set of template variables create_cluster can
+// start from.
+type VariablePreset struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Variables   map[string]interface{} `json:"variables"`
 }
 
 // DeleteClusterInput defines the parameters for the delete_cluster tool.
@@ -85,6 +250,213 @@ type DeleteClusterInput struct {
 type DeleteClusterOutput struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
+	// Remaining lists the resources still holding back the deletion when it
+	// did not complete in time.
+	Remaining []BlockingResource `json:"remaining,omitempty"`
+	// DeleteAfter is when a soft-deleted cluster is torn down, in RFC 3339
+	// format, if deletions are retained.
+	DeleteAfter string   `json:"delete_after,omitempty"`
+	Warnings    []string `json:"warnings,omitempty"` // e.g. clusters depending on the deleted cluster
+}
+
+// UndeleteClusterInput defines the parameters for the undelete_cluster tool.
+type UndeleteClusterInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// UndeleteClusterOutput defines the response for the undelete_cluster tool.
+type UndeleteClusterOutput struct {
+	ClusterName string `json:"cluster_name"`
+	Paused      bool   `json:"paused"` // the cluster was paused before it was deleted and stays paused
+	Message     string `json:"message"`
+}
+
+// PreviewDeleteClusterInput defines the parameters for the
+// preview_delete_cluster tool.
+type PreviewDeleteClusterInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// PreviewDeleteClusterOutput defines the response for the
+// preview_delete_cluster tool: what deleting a cluster destroys, to confirm
+// with the user before calling delete_cluster.
+type PreviewDeleteClusterOutput struct {
+	ClusterName       string                 `json:"cluster_name"`
+	Namespace         string                 `json:"namespace"`
+	Provider          string                 `json:"provider"`
+	Region            string                 `json:"region,omitempty"`
+	Machines          DeletionMachines       `json:"machines"`
+	CloudResources    []CloudResourceRef     `json:"cloud_resources,omitempty"`    // from the provider status
+	PersistentVolumes []DeletionVolume       `json:"persistent_volumes,omitempty"` // of the workload cluster
+	LoadBalancers     []DeletionLoadBalancer `json:"load_balancers,omitempty"`     // LoadBalancer Services of the workload cluster
+	Dependents        []string               `json:"dependents,omitempty"`         // clusters depending on the cluster
+	// RetentionWindow is how long the cluster is kept paused before it is
+	// torn down, if deletions are retained.
+	RetentionWindow string   `json:"retention_window,omitempty"`
+	Warnings        []string `json:"warnings,omitempty"` // e.g. parts of the impact that could not be determined
+	Message         string   `json:"message"`
+}
+
+// DeletionMachines counts the machines destroyed with a cluster.
+type DeletionMachines struct {
+	ControlPlane int            `json:"control_plane"`
+	Workers      int            `json:"workers"`
+	NodePools    map[string]int `json:"node_pools,omitempty"` // workers by node pool
+}
+
+// DeletionVolume is a persistent volume of a workload cluster whose data is
+// lost or orphaned with the cluster, depending on its reclaim policy.
+type DeletionVolume struct {
+	Name          string `json:"name"`
+	Claim         string `json:"claim,omitempty"` // namespace/name of the bound claim
+	Capacity      string `json:"capacity,omitempty"`
+	StorageClass  string `json:"storage_class,omitempty"`
+	ReclaimPolicy string `json:"reclaim_policy,omitempty"`
+}
+
+// DeletionLoadBalancer is a load balancer a LoadBalancer Service of a
+// workload cluster provisioned.
+type DeletionLoadBalancer struct {
+	Service   string   `json:"service"` // namespace/name
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// GetClusterDependenciesInput defines the parameters for the
+// get_cluster_dependencies tool.
+type GetClusterDependenciesInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// GetClusterDependenciesOutput defines the response for the
+// get_cluster_dependencies tool. Dependencies are declared with the
+// capi-mcp.io/depends-on annotation of the depending cluster.
+type GetClusterDependenciesOutput struct {
+	ClusterName string              `json:"cluster_name"`
+	DependsOn   []ClusterDependency `json:"depends_on"` // clusters this cluster depends on
+	Dependents  []ClusterDependency `json:"dependents"` // clusters depending on this cluster
+	Warnings    []string            `json:"warnings,omitempty"`
+}
+
+// ClusterDependency is a cluster at one end of a dependency.
+type ClusterDependency struct {
+	ClusterName string        `json:"cluster_name"`
+	Status      ClusterStatus `json:"status,omitempty"`
+	Missing     bool          `json:"missing,omitempty"` // the cluster does not exist
+}
+
+// ExportInventoryInput defines the parameters for the export_inventory tool.
+type ExportInventoryInput struct {
+	Format string `json:"format"` // json (default) or csv
+}
+
+// ExportInventoryOutput defines the response for the export_inventory tool.
+// The report is in Clusters for the json format and in CSV for csv.
+type ExportInventoryOutput struct {
+	Format      string           `json:"format"`
+	GeneratedAt string           `json:"generated_at"`
+	Clusters    []InventoryEntry `json:"clusters,omitempty"`
+	CSV         string           `json:"csv,omitempty"`
+	Message     string           `json:"message"`
+	Warnings    []string         `json:"warnings,omitempty"` // data of clusters that could not be retrieved
+}
+
+// InventoryEntry is a cluster in a fleet inventory report.
+type InventoryEntry struct {
+	Name              string        `json:"name"`
+	Namespace         string        `json:"namespace"`
+	Provider          string        `json:"provider"`
+	Region            string        `json:"region"`
+	KubernetesVersion string        `json:"kubernetes_version"`
+	Status            ClusterStatus `json:"status"`
+	NodeCount         int           `json:"node_count"`
+	ReadyNodeCount    int           `json:"ready_node_count"`
+	CreatedAt         string        `json:"created_at"`
+	AgeDays           int           `json:"age_days"`
+	// Estimated cost in the currency of the configured instance prices,
+	// omitted when an instance type of the cluster has no price.
+	HourlyCost  *float64          `json:"hourly_cost,omitempty"`
+	MonthlyCost *float64          `json:"monthly_cost,omitempty"`
+	Owners      map[string]string `json:"owners,omitempty"` // owner labels of the cluster
+}
+
+// ListEnvironmentsOutput defines the response for the list_environments
+// tool. Environments group clusters by the value of the environment label,
+// e.g. dev, staging or prod.
+type ListEnvironmentsOutput struct {
+	Label        string        `json:"label"` // the environment label, e.g. environment
+	Environments []Environment `json:"environments"`
+	Unassigned   []string      `json:"unassigned,omitempty"` // clusters without the environment label
+	LastUpdated  string        `json:"last_updated"`
+}
+
+// Environment is a group of clusters sharing the value of the environment
+// label.
+type Environment struct {
+	Name               string                `json:"name"`
+	LabelSelector      string                `json:"label_selector"` // selects the environment, e.g. in bulk_upgrade
+	Clusters           []string              `json:"clusters"`
+	Statuses           map[ClusterStatus]int `json:"statuses"` // number of clusters per status
+	KubernetesVersions []string              `json:"kubernetes_versions"`
+	NodeCount          int                   `json:"node_count"`
+	ReadyNodeCount     int                   `json:"ready_node_count"`
+}
+
+// ScanOrphanedCloudResourcesInput defines the parameters for the scan_orphaned_cloud_resources tool.
+type ScanOrphanedCloudResourcesInput struct {
+	Provider string `json:"provider"` // defaults to aws
+	Region   string `json:"region"`   // defaults to the provider's region
+}
+
+// ScanOrphanedCloudResourcesOutput defines the response for the scan_orphaned_cloud_resources tool.
+type ScanOrphanedCloudResourcesOutput struct {
+	Provider  string                  `json:"provider"`
+	Region    string                  `json:"region,omitempty"`
+	Clusters  []string                `json:"clusters"` // the unknown clusters owning the resources
+	Resources []OrphanedCloudResource `json:"resources"`
+	Message   string                  `json:"message"`
+}
+
+// OrphanedCloudResource is a cloud resource owned by a cluster the management
+// cluster does not know about.
+type OrphanedCloudResource struct {
+	Type        string `json:"type"` // vpc, security-group, instance or load-balancer
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Region      string `json:"region"`
+	ClusterName string `json:"cluster_name"`
+	State       string `json:"state,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// ForceDeleteClusterInput defines the parameters for the force_delete_cluster tool.
+type ForceDeleteClusterInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+	// RemoveFinalizers, with the ConfirmationToken of a report, removes the
+	// finalizers of the blocking resources; otherwise they are only reported.
+	RemoveFinalizers  bool   `json:"remove_finalizers,omitempty"`
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+}
+
+// ForceDeleteClusterOutput defines the response for the force_delete_cluster tool.
+type ForceDeleteClusterOutput struct {
+	ClusterName       string             `json:"cluster_name"`
+	DeletingSince     string             `json:"deleting_since"`
+	Blockers          []BlockingResource `json:"blockers"`
+	ConfirmationToken string             `json:"confirmation_token,omitempty"` // confirms removing the finalizers
+	FinalizersRemoved bool               `json:"finalizers_removed"`
+	Message           string             `json:"message"`
+}
+
+// BlockingResource is a resource holding back the deletion of a cluster.
+type BlockingResource struct {
+	Kind       string   `json:"kind"`
+	APIVersion string   `json:"api_version"`
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Deleting   bool     `json:"deleting"`
+	Finalizers []string `json:"finalizers,omitempty"`
+	Reason     string   `json:"reason,omitempty"` // the failing condition, e.g. why teardown is stuck
+	Error      string   `json:"error,omitempty"`  // why its finalizers could not be removed
 }
 
 // ScaleClusterInput defines the parameters for the scale_cluster tool.
@@ -100,6 +472,38 @@ type ScaleClusterOutput struct {
 	Message     string `json:"message"`
 	OldReplicas int    `json:"old_replicas"`
 	NewReplicas int    `json:"new_replicas"`
+	Spot        bool   `json:"spot,omitempty"`
+}
+
+// SpotMarketOptions requests spot (preemptible) capacity for a node pool.
+type SpotMarketOptions struct {
+	// MaxPrice is the maximum hourly price in USD. Empty caps the price at the
+	// on-demand price.
+	MaxPrice string `json:"maxPrice,omitempty"`
+
+	// AllocationStrategy selects how spot capacity is allocated, e.g.
+	// capacity-optimized or lowest-price. Empty uses the provider's default.
+	AllocationStrategy string `json:"allocationStrategy,omitempty"`
+}
+
+// CreateNodePoolInput defines the parameters for the create_node_pool tool.
+type CreateNodePoolInput struct {
+	ClusterName  string                 `json:"cluster_name" validate:"required"`
+	NodePoolName string                 `json:"node_pool_name" validate:"required"`
+	Class        string                 `json:"class,omitempty"`
+	Replicas     int                    `json:"replicas" validate:"gte=0"`
+	InstanceType string                 `json:"instance_type,omitempty"`
+	GPUCount     int                    `json:"gpu_count,omitempty"`
+	Spot         *SpotMarketOptions     `json:"spot,omitempty"`
+	Variables    map[string]interface{} `json:"variables,omitempty"`
+}
+
+// CreateNodePoolOutput defines the response for the create_node_pool tool.
+type CreateNodePoolOutput struct {
+	NodePoolName string `json:"node_pool_name"`
+	Class        string `json:"class"`
+	Status       string `json:"status"`
+	Message      string `json:"message"`
 }
 
 // GetClusterKubeconfigInput defines the parameters for the get_cluster_kubeconfig tool.
@@ -112,6 +516,47 @@ type GetClusterKubeconfigOutput struct {
 	Kubeconfig string `json:"kubeconfig"`
 }
 
+// CreateTemporaryAccessInput defines the parameters for the
+// create_temporary_access tool.
+type CreateTemporaryAccessInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+	Namespace   string `json:"namespace" validate:"required"` // the namespace in the workload cluster access is granted to
+	User        string `json:"user" validate:"required"`      // the human operator the access is for
+	Role        string `json:"role,omitempty"`                // view (default), edit or admin
+	Duration    string `json:"duration,omitempty"`            // e.g. 2h; default 1h
+	Reason      string `json:"reason,omitempty"`
+}
+
+// CreateTemporaryAccessOutput defines the response for the
+// create_temporary_access tool.
+type CreateTemporaryAccessOutput struct {
+	AccessID    string `json:"access_id"` // revoke with revoke_temporary_access
+	ClusterName string `json:"cluster_name"`
+	Namespace   string `json:"namespace"`
+	User        string `json:"user"`
+	Role        string `json:"role"`
+	ExpiresAt   string `json:"expires_at"`
+	Kubeconfig  string `json:"kubeconfig"`
+	Message     string `json:"message"`
+}
+
+// RevokeTemporaryAccessInput defines the parameters for the
+// revoke_temporary_access tool.
+type RevokeTemporaryAccessInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+	AccessID    string `json:"access_id" validate:"required"`
+}
+
+// RevokeTemporaryAccessOutput defines the response for the
+// revoke_temporary_access tool.
+type RevokeTemporaryAccessOutput struct {
+	AccessID    string `json:"access_id"`
+	ClusterName string `json:"cluster_name"`
+	Namespace   string `json:"namespace"`
+	User        string `json:"user"`
+	Message     string `json:"message"`
+}
+
 // GetClusterNodesInput defines the parameters for the get_cluster_nodes tool.
 type GetClusterNodesInput struct {
 	ClusterName string `json:"cluster_name" validate:"required"`
@@ -133,4 +578,1019 @@ type NodeInfo struct {
 	InstanceType     string            `json:"instance_type"`
 	AvailabilityZone string            `json:"availability_zone"`
 	Labels           map[string]string `json:"labels"`
+	Accelerators     []NodeAccelerator `json:"accelerators,omitempty"`
+	CreatedAt        string            `json:"created_at"`
+}
+
+// NodeAccelerator describes the GPUs or other accelerators a node advertises
+// through a device plugin extended resource.
+type NodeAccelerator struct {
+	Resource    string `json:"resource"`
+	Capacity    int64  `json:"capacity"`
+	Allocatable int64  `json:"allocatable"`
+	Product     string `json:"product,omitempty"`
+}
+
+// GetAutoscalerStatusInput defines the parameters for the get_autoscaler_status tool.
+type GetAutoscalerStatusInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// GetAutoscalerStatusOutput defines the response for the get_autoscaler_status tool.
+type GetAutoscalerStatusOutput struct {
+	Installed        bool                       `json:"installed"`
+	AutoscalerStatus string                     `json:"autoscaler_status,omitempty"`
+	LastUpdated      string                     `json:"last_updated,omitempty"`
+	ClusterWide      AutoscalerActivity         `json:"cluster_wide"`
+	NodePools        []AutoscalerNodePoolStatus `json:"node_pools"`
+	Message          string                     `json:"message,omitempty"`
+}
+
+// AutoscalerActivity summarizes the health and scaling activity reported by cluster-autoscaler.
+type AutoscalerActivity struct {
+	Health    string `json:"health"`
+	ScaleUp   string `json:"scale_up"`
+	ScaleDown string `json:"scale_down"`
+}
+
+// AutoscalerNodePoolStatus describes cluster-autoscaler activity for a single node pool.
+type AutoscalerNodePoolStatus struct {
+	Name                string   `json:"name"`
+	Kind                string   `json:"kind,omitempty"`
+	Health              string   `json:"health"`
+	ScaleUp             string   `json:"scale_up"`
+	ScaleDown           string   `json:"scale_down"`
+	ReadyNodes          int      `json:"ready_nodes"`
+	TargetSize          int      `json:"target_size"`
+	MinSize             int      `json:"min_size"`
+	MaxSize             int      `json:"max_size"`
+	ScaleDownCandidates int      `json:"scale_down_candidates"`
+	Blockers            []string `json:"blockers,omitempty"`
+}
+
+// GetManagementClusterInfoInput defines the parameters for the get_management_cluster_info tool.
+type GetManagementClusterInfoInput struct{}
+
+// GetManagementClusterInfoOutput defines the response for the get_management_cluster_info tool.
+type GetManagementClusterInfoOutput struct {
+	CoreVersion      string               `json:"core_version"`
+	ContractVersions []string             `json:"contract_versions"`
+	Providers        []ManagementProvider `json:"providers"`
+	CertManager      CertManagerStatus    `json:"cert_manager"`
+}
+
+// ManagementProvider represents a CAPI provider installed on the management cluster.
+type ManagementProvider struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Version   string `json:"version"`
+	Namespace string `json:"namespace"`
+	Ready     bool   `json:"ready"`
+}
+
+// CertManagerStatus represents the cert-manager installation on the management cluster.
+type CertManagerStatus struct {
+	Installed bool   `json:"installed"`
+	Version   string `json:"version,omitempty"`
+	Ready     bool   `json:"ready"`
+	Message   string `json:"message,omitempty"`
+}
+
+// UpgradeManagementProvidersInput defines the parameters for the upgrade_management_providers tool.
+type UpgradeManagementProvidersInput struct {
+	Apply                   bool     `json:"apply"`
+	Contract                string   `json:"contract,omitempty"`
+	CoreProvider            string   `json:"core_provider,omitempty"`
+	InfrastructureProviders []string `json:"infrastructure_providers,omitempty"`
+}
+
+// UpgradeManagementProvidersOutput defines the response for the upgrade_management_providers tool.
+type UpgradeManagementProvidersOutput struct {
+	Contract  string            `json:"contract"`
+	Providers []ProviderUpgrade `json:"providers"`
+	Applied   bool              `json:"applied"`
+	Message   string            `json:"message"`
+	// Warnings name the cluster templates the upgrade leaves incompatible
+	// with the providers.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ProviderUpgrade represents the planned upgrade of a single management cluster provider.
+type ProviderUpgrade struct {
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace"`
+	Type           string `json:"type"`
+	CurrentVersion string `json:"current_version"`
+	NextVersion    string `json:"next_version"`
+}
+
+// CreateTenantInput defines the parameters for the create_tenant tool.
+type CreateTenantInput struct {
+	TenantName     string   `json:"tenant_name" validate:"required"`
+	Groups         []string `json:"groups" validate:"required"`
+	ClusterClasses []string `json:"cluster_classes,omitempty"`
+	MaxClusters    int      `json:"max_clusters,omitempty"`
+}
+
+// CreateTenantOutput defines the response for the create_tenant tool.
+type CreateTenantOutput struct {
+	Namespace      string   `json:"namespace"`
+	Created        []string `json:"created"`
+	Existing       []string `json:"existing"`
+	ClusterClasses []string `json:"cluster_classes"`
+	Message        string   `json:"message"`
+}
+
+// ListOperationsInput defines the parameters for the list_operations tool.
+type ListOperationsInput struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+	Since       string `json:"since,omitempty"` // RFC 3339 timestamp
+	Until       string `json:"until,omitempty"` // RFC 3339 timestamp
+	Limit       int    `json:"limit,omitempty"`
+}
+
+// ListOperationsOutput defines the response for the list_operations tool.
+type ListOperationsOutput struct {
+	Operations []Operation `json:"operations"`
+}
+
+// GetToolUsageInput defines the parameters for the get_tool_usage tool.
+type GetToolUsageInput struct {
+	Identity string `json:"identity,omitempty"`
+	Tool     string `json:"tool,omitempty"`
+	Since    string `json:"since,omitempty"` // RFC 3339 timestamp
+	Until    string `json:"until,omitempty"` // RFC 3339 timestamp
+}
+
+// GetToolUsageOutput defines the response for the get_tool_usage tool.
+type GetToolUsageOutput struct {
+	Since      string          `json:"since"` // start of the first hour counted
+	Until      string          `json:"until"`
+	Identities []IdentityUsage `json:"identities"` // most calls first
+}
+
+// IdentityUsage is the aggregated tool calls of an identity.
+type IdentityUsage struct {
+	Identity    string           `json:"identity"`
+	Calls       int64            `json:"calls"`
+	Failures    int64            `json:"failures"`
+	FailureRate float64          `json:"failure_rate"`
+	Mutations   int64            `json:"mutations"`        // successful calls that changed a cluster
+	Errors      map[string]int64 `json:"errors,omitempty"` // failures by error code
+	Tools       []ToolUsage      `json:"tools"`            // most calls first
+}
+
+// ToolUsage is the aggregated calls of a tool by an identity.
+type ToolUsage struct {
+	Tool        string           `json:"tool"`
+	Calls       int64            `json:"calls"`
+	Failures    int64            `json:"failures"`
+	FailureRate float64          `json:"failure_rate"`
+	Mutations   int64            `json:"mutations"`
+	Errors      map[string]int64 `json:"errors,omitempty"`
+}
+
+// SetToolEnabledInput defines the parameters for the disable_tool and
+// enable_tool tools.
+type SetToolEnabledInput struct {
+	Tool   string `json:"tool"`
+	Reason string `json:"reason,omitempty"` // disable_tool only
+}
+
+// SetToolEnabledOutput defines the response for the disable_tool and
+// enable_tool tools.
+type SetToolEnabledOutput struct {
+	Tool     string         `json:"tool"`
+	Enabled  bool           `json:"enabled"`
+	Changed  bool           `json:"changed"` // false if the tool already was in the requested state
+	Disabled []DisabledTool `json:"disabled"`
+	Message  string         `json:"message"`
+}
+
+// ListDisabledToolsOutput defines the response for the list_disabled_tools
+// tool.
+type ListDisabledToolsOutput struct {
+	Disabled []DisabledTool `json:"disabled"`
+}
+
+// DisabledTool is a tool disabled at runtime.
+type DisabledTool struct {
+	Tool       string `json:"tool"`
+	Reason     string `json:"reason,omitempty"`
+	DisabledAt string `json:"disabled_at"` // RFC 3339 timestamp
+}
+
+// StartMaintenanceInput defines the parameters for the start_maintenance
+// tool.
+type StartMaintenanceInput struct {
+	Until    string `json:"until,omitempty"`    // RFC 3339 timestamp
+	Duration string `json:"duration,omitempty"` // Go duration, e.g. 30m; alternative to Until
+	Reason   string `json:"reason,omitempty"`
+}
+
+// MaintenanceOutput defines the response for the start_maintenance and
+// end_maintenance tools.
+type MaintenanceOutput struct {
+	Active  bool   `json:"active"`
+	Since   string `json:"since,omitempty"`
+	Until   string `json:"until,omitempty"` // empty if the end is not planned
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetServerInfoOutput defines the response for the get_server_info tool.
+type GetServerInfoOutput struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	BuildDate string `json:"build_date"`
+	// APIVersion is the output schema version tools answer in by default;
+	// APIVersions lists those a call can ask for with apiVersion.
+	APIVersion  string   `json:"api_version"`
+	APIVersions []string `json:"api_versions"`
+	Providers   []string `json:"providers"` // the infrastructure providers clusters can be created with
+	Tools       []string `json:"tools"`     // the tools enabled for the caller
+	// Locale is the language of error messages of calls asking for none;
+	// Locales lists those a call can ask for with the capi-mcp.io/locale
+	// request metadata.
+	Locale  string       `json:"locale"`
+	Locales []string     `json:"locales"`
+	Policy  ServerPolicy `json:"policy"`
+}
+
+// ServerPolicy describes the policies that hold back the mutating tools.
+type ServerPolicy struct {
+	// ReadOnly is set during a maintenance window, when mutating tools are
+	// unavailable and read-only tools keep working.
+	ReadOnly    bool               `json:"read_only"`
+	Maintenance *MaintenanceOutput `json:"maintenance,omitempty"`
+	// DryRunDefault reports whether the DryRunTools only report their
+	// changes unless asked to apply them; they apply them unless called with
+	// dryRun true.
+	DryRunDefault bool     `json:"dry_run_default"`
+	DryRunTools   []string `json:"dry_run_tools"`
+	// AdmissionPolicy is set if mutating calls are evaluated against an
+	// admission policy; AdmissionFailOpen if they are admitted when it
+	// cannot be evaluated.
+	AdmissionPolicy   bool `json:"admission_policy"`
+	AdmissionFailOpen bool `json:"admission_fail_open,omitempty"`
+	// Approvals is set if high-risk operations need a second identity's
+	// approval, on clusters of ApprovalEnvironments or of all environments.
+	Approvals            bool     `json:"approvals"`
+	ApprovalEnvironments []string `json:"approval_environments,omitempty"`
+	// Namespaces are the namespaces the caller may use, all if empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// Approval is a two-party approval of a high-risk operation, such as the
+// deletion of a production cluster. The identity requesting the operation
+// cannot approve it.
+type Approval struct {
+	ID          string `json:"id"`
+	Tool        string `json:"tool"`
+	Namespace   string `json:"namespace,omitempty"`
+	ClusterName string `json:"cluster_name"`
+	Status      string `json:"status"` // pending or approved
+	RequestedBy string `json:"requested_by"`
+	RequestedAt string `json:"requested_at"`
+	ApprovedBy  string `json:"approved_by,omitempty"`
+	ApprovedAt  string `json:"approved_at,omitempty"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// ApproveOperationInput defines the parameters for the approve_operation
+// tool.
+type ApproveOperationInput struct {
+	ApprovalID string `json:"approval_id" validate:"required"`
+}
+
+// ApproveOperationOutput defines the response for the approve_operation
+// tool.
+type ApproveOperationOutput struct {
+	Approval Approval `json:"approval"`
+	Message  string   `json:"message"`
+}
+
+// Operation is a recorded operation performed through the server.
+type Operation struct {
+	ID            string            `json:"id"`
+	Tool          string            `json:"tool"`
+	ClusterName   string            `json:"cluster_name,omitempty"`
+	Namespace     string            `json:"namespace,omitempty"`
+	Identity      string            `json:"identity"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Outcome       string            `json:"outcome"`
+	Error         string            `json:"error,omitempty"`
+	StartedAt     string            `json:"started_at"`
+	DurationMs    int64             `json:"duration_ms"`
+	Parameters    map[string]string `json:"parameters,omitempty"`
+}
+
+// GetRecentChangesInput defines the parameters for the get_recent_changes tool.
+type GetRecentChangesInput struct {
+	Since       string `json:"since" validate:"required"` // RFC 3339 timestamp
+	ClusterName string `json:"cluster_name,omitempty"`
+}
+
+// GetRecentChangesOutput defines the response for the get_recent_changes tool.
+type GetRecentChangesOutput struct {
+	Since   string          `json:"since"`
+	Until   string          `json:"until"`
+	Changes []ClusterChange `json:"changes"` // oldest first
+	Message string          `json:"message"`
+}
+
+// ClusterChange is a cluster lifecycle event: created, deleted, scaled,
+// upgraded or failed.
+type ClusterChange struct {
+	Type        string `json:"type"`
+	ClusterName string `json:"cluster_name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Time        string `json:"time"`
+	Source      string `json:"source"` // "operation" or "cluster"
+	Tool        string `json:"tool,omitempty"`
+	Identity    string `json:"identity,omitempty"`
+	Summary     string `json:"summary"`
+}
+
+// RollbackOperationInput defines the parameters for the rollback_operation tool.
+type RollbackOperationInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// RollbackOperationOutput defines the response for the rollback_operation tool.
+type RollbackOperationOutput struct {
+	RolledBack Operation `json:"rolled_back"`
+	Message    string    `json:"message"`
+}
+
+// DiffClusterStateInput defines the parameters for the diff_cluster_state tool.
+// From and To each accept a snapshot ID or an RFC 3339 time, which selects the
+// latest snapshot taken at or before that time.
+type DiffClusterStateInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+	From        string `json:"from,omitempty"` // default: the snapshot preceding To
+	To          string `json:"to,omitempty"`   // default: the current state
+}
+
+// DiffClusterStateOutput defines the response for the diff_cluster_state tool.
+type DiffClusterStateOutput struct {
+	ClusterName string             `json:"cluster_name"`
+	From        *ClusterStateRef   `json:"from,omitempty"`
+	To          ClusterStateRef    `json:"to"`
+	Changes     []ClusterStateDiff `json:"changes"`
+	Diff        string             `json:"diff"` // human-readable, one change per line
+	Message     string             `json:"message"`
+}
+
+// ClusterStateRef identifies a captured cluster state.
+type ClusterStateRef struct {
+	SnapshotID string `json:"snapshot_id"`
+	TakenAt    string `json:"taken_at"`
+	Current    bool   `json:"current,omitempty"`
+}
+
+// ClusterStateDiff is a single difference between two cluster states.
+type ClusterStateDiff struct {
+	Object string      `json:"object"` // Kind/name
+	Path   string      `json:"path,omitempty"`
+	Change string      `json:"change"` // added, removed or modified
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+}
+
+// ChunkedOutput replaces a tool result too large to return in one message.
+// The result is held by the server until ExpiresAt and can be read whole from
+// ResourceURI or in chunks with the get_output_chunk tool.
+type ChunkedOutput struct {
+	PayloadID   string `json:"payload_id"`
+	TotalChunks int    `json:"total_chunks"`
+	TotalBytes  int    `json:"total_bytes"`
+	ResourceURI string `json:"resource_uri"`
+	ExpiresAt   string `json:"expires_at"`
+	Message     string `json:"message"`
+}
+
+// GetOutputChunkInput defines the parameters for the get_output_chunk tool.
+type GetOutputChunkInput struct {
+	PayloadID string `json:"payload_id" validate:"required"`
+	Chunk     int    `json:"chunk"` // zero-based
+}
+
+// GetOutputChunkOutput defines the response for the get_output_chunk tool.
+type GetOutputChunkOutput struct {
+	PayloadID   string `json:"payload_id"`
+	Chunk       int    `json:"chunk"`
+	TotalChunks int    `json:"total_chunks"`
+	Data        string `json:"data"`
+}
+
+// ProbeClusterAPIInput defines the parameters for the probe_cluster_api tool.
+type ProbeClusterAPIInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// ProbeClusterAPIOutput defines the response for the probe_cluster_api tool.
+type ProbeClusterAPIOutput struct {
+	ClusterName   string        `json:"cluster_name"`
+	Reachable     bool          `json:"reachable"`
+	ServerVersion string        `json:"server_version,omitempty"`
+	LatencyMs     int64         `json:"latency_ms"` // round trip of a version request
+	Ready         bool          `json:"ready"`      // all /readyz checks passed
+	ReadyzChecks  []HealthCheck `json:"readyz_checks"`
+	Addons        []AddonHealth `json:"addons"`
+	Healthy       bool          `json:"healthy"`
+	Message       string        `json:"message"`
+}
+
+// HealthCheck is a single API server health check.
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// AddonHealth reports the health of a core cluster addon.
+type AddonHealth struct {
+	Name      string `json:"name"` // coredns, kube-proxy or cni
+	Component string `json:"component,omitempty"`
+	Kind      string `json:"kind,omitempty"` // Deployment or DaemonSet
+	Namespace string `json:"namespace,omitempty"`
+	Installed bool   `json:"installed"`
+	Healthy   bool   `json:"healthy"`
+	Ready     int    `json:"ready"`
+	Desired   int    `json:"desired"`
+	Message   string `json:"message,omitempty"`
+}
+
+// GetClusterComponentVersionsInput defines the parameters for the get_cluster_component_versions tool.
+type GetClusterComponentVersionsInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// GetClusterComponentVersionsOutput defines the response for the get_cluster_component_versions tool.
+type GetClusterComponentVersionsOutput struct {
+	ClusterName   string             `json:"cluster_name"`
+	ServerVersion string             `json:"server_version"`
+	Nodes         []NodeVersions     `json:"nodes"`
+	ControlPlane  []ComponentVersion `json:"control_plane"` // empty for control planes managed by the provider
+	Addons        []ComponentVersion `json:"addons"`
+	Findings      []VersionFinding   `json:"findings"`
+	Advisory      *VersionAdvisory   `json:"advisory,omitempty"` // support status of the API server version
+	Message       string             `json:"message"`
+}
+
+// NodeVersions are the versions of the software running a node.
+type NodeVersions struct {
+	Name             string `json:"name"`
+	Role             string `json:"role"` // control-plane or worker
+	KubeletVersion   string `json:"kubelet_version"`
+	ContainerRuntime string `json:"container_runtime"`
+	KernelVersion    string `json:"kernel_version"`
+	OSImage          string `json:"os_image"`
+}
+
+// ComponentVersion is the version of a container of a cluster component.
+type ComponentVersion struct {
+	Name      string `json:"name"`
+	Category  string `json:"category"` // control-plane, cni, csi, dns, proxy or addon
+	Kind      string `json:"kind"`     // Pod, Deployment or DaemonSet
+	Namespace string `json:"namespace"`
+	Node      string `json:"node,omitempty"` // the node of a control plane Pod
+	Container string `json:"container"`
+	Image     string `json:"image"`
+	Version   string `json:"version,omitempty"` // the image tag
+}
+
+// VersionFinding is a version skew or support problem.
+type VersionFinding struct {
+	Severity  string `json:"severity"` // warning or critical
+	Component string `json:"component"`
+	Message   string `json:"message"`
+}
+
+// InstallCNIInput defines the parameters for the install_cni tool.
+type InstallCNIInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+	Plugin      string `json:"plugin" validate:"required"` // calico or cilium
+	Method      string `json:"method,omitempty"`           // helm or manifest, defaults to helm when CAAPH is installed
+	Version     string `json:"version,omitempty"`
+}
+
+// InstallCNIOutput defines the response for the install_cni tool.
+type InstallCNIOutput struct {
+	ClusterName string   `json:"cluster_name"`
+	Plugin      string   `json:"plugin"`
+	Method      string   `json:"method"`
+	Version     string   `json:"version"`
+	Resources   []string `json:"resources"` // management cluster objects delivering the plugin
+	Message     string   `json:"message"`
+}
+
+// GetWorkloadResourceInput defines the parameters for the get_workload_resource tool.
+type GetWorkloadResourceInput struct {
+	ClusterName       string `json:"cluster_name" validate:"required"`
+	APIVersion        string `json:"api_version,omitempty"` // e.g. v1 or apps/v1, inferred from the kind if empty
+	Kind              string `json:"kind" validate:"required"`
+	ResourceNamespace string `json:"resource_namespace,omitempty"` // namespace in the workload cluster
+	Name              string `json:"name,omitempty"`
+	LabelSelector     string `json:"label_selector,omitempty"`
+}
+
+// GetWorkloadResourceOutput defines the response for the get_workload_resource tool.
+type GetWorkloadResourceOutput struct {
+	ClusterName string                   `json:"cluster_name"`
+	APIVersion  string                   `json:"api_version"`
+	Kind        string                   `json:"kind"`
+	Items       []map[string]interface{} `json:"items"`
+	Count       int                      `json:"count"`
+	Truncated   bool                     `json:"truncated"` // more items matched than were returned
+	Message     string                   `json:"message"`
+}
+
+// GetWorkloadPodLogsInput defines the parameters for the get_workload_pod_logs tool.
+type GetWorkloadPodLogsInput struct {
+	ClusterName  string `json:"cluster_name" validate:"required"`
+	PodNamespace string `json:"pod_namespace" validate:"required"` // namespace in the workload cluster
+	Pod          string `json:"pod" validate:"required"`
+	Container    string `json:"container,omitempty"` // required for pods with several containers
+	TailLines    int    `json:"tail_lines,omitempty"`
+	Previous     bool   `json:"previous,omitempty"` // logs of the previous, crashed instance
+}
+
+// GetWorkloadPodLogsOutput defines the response for the get_workload_pod_logs tool.
+type GetWorkloadPodLogsOutput struct {
+	ClusterName  string `json:"cluster_name"`
+	PodNamespace string `json:"pod_namespace"`
+	Pod          string `json:"pod"`
+	Container    string `json:"container"`
+	Previous     bool   `json:"previous"`
+	TailLines    int    `json:"tail_lines"`
+	Restarts     int    `json:"restarts"`
+	State        string `json:"state,omitempty"` // e.g. running or waiting: CrashLoopBackOff
+	Logs         string `json:"logs"`
+	Message      string `json:"message"`
+}
+
+// RunNodeDiagnosticInput defines the parameters for the run_node_diagnostic tool.
+type RunNodeDiagnosticInput struct {
+	ClusterName string   `json:"cluster_name" validate:"required"`
+	NodeName    string   `json:"node_name" validate:"required"`
+	Checks      []string `json:"checks,omitempty"` // disk, kubelet, network, runtime; all by default
+}
+
+// RunNodeDiagnosticOutput defines the response for the run_node_diagnostic tool.
+type RunNodeDiagnosticOutput struct {
+	ClusterName string             `json:"cluster_name"`
+	NodeName    string             `json:"node_name"`
+	Pod         string             `json:"pod"` // the debug pod, deleted once done
+	Completed   bool               `json:"completed"`
+	Results     []DiagnosticResult `json:"results"`
+	Message     string             `json:"message"`
+}
+
+// DiagnosticResult is the output of a single node diagnostic check.
+type DiagnosticResult struct {
+	Check  string `json:"check"`
+	Output string `json:"output"`
+}
+
+// RotateProviderCredentialsInput defines the parameters for the rotate_provider_credentials tool.
+type RotateProviderCredentialsInput struct {
+	Provider    string            `json:"provider" validate:"required"` // aws or azure
+	Credentials map[string]string `json:"credentials" validate:"required"`
+}
+
+// RotateProviderCredentialsOutput defines the response for the rotate_provider_credentials tool.
+type RotateProviderCredentialsOutput struct {
+	Provider             string   `json:"provider"`
+	Secret               string   `json:"secret"`   // namespace/name of the updated credentials secret
+	Identity             string   `json:"identity"` // the identity the new credentials were verified as
+	RestartedDeployments []string `json:"restarted_deployments"`
+	Message              string   `json:"message"`
+}
+
+// ResolveNodeImageInput defines the parameters for the resolve_node_image tool.
+type ResolveNodeImageInput struct {
+	Provider          string `json:"provider"` // defaults to aws
+	KubernetesVersion string `json:"kubernetes_version" validate:"required"`
+	Region            string `json:"region"`
+	OS                string `json:"os"`
+	Lookup            string `json:"lookup"` // capa (image-builder naming) or eks
+}
+
+// ResolveNodeImageOutput defines the response for the resolve_node_image tool.
+type ResolveNodeImageOutput struct {
+	Provider          string `json:"provider"`
+	ImageID           string `json:"image_id"`
+	Name              string `json:"name"`
+	Region            string `json:"region"`
+	OS                string `json:"os"`
+	KubernetesVersion string `json:"kubernetes_version"`
+	Lookup            string `json:"lookup"`
+	CreatedAt         string `json:"created_at,omitempty"`
+	Message           string `json:"message"`
+}
+
+// ConfigureEtcdBackupInput defines the parameters for the configure_etcd_backup tool.
+type ConfigureEtcdBackupInput struct {
+	ClusterName           string `json:"cluster_name" validate:"required"`
+	Schedule              string `json:"schedule"`                // cron schedule, every 6 hours by default
+	Retention             int    `json:"retention"`               // snapshots kept, 7 by default
+	PersistentVolumeClaim string `json:"persistent_volume_claim"` // claim in kube-system to store snapshots on; the node's disk by default
+	Suspend               bool   `json:"suspend"`
+}
+
+// ConfigureEtcdBackupOutput defines the response for the configure_etcd_backup tool.
+type ConfigureEtcdBackupOutput struct {
+	ClusterName string `json:"cluster_name"`
+	CronJob     string `json:"cron_job"` // namespace/name in the workload cluster
+	Schedule    string `json:"schedule"`
+	Retention   int    `json:"retention"`
+	Destination string `json:"destination"`
+	Suspended   bool   `json:"suspended"`
+	Created     bool   `json:"created"` // false when an existing schedule was updated
+	Message     string `json:"message"`
+}
+
+// ListEtcdBackupsInput defines the parameters for the list_etcd_backups tool.
+type ListEtcdBackupsInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+}
+
+// ListEtcdBackupsOutput defines the response for the list_etcd_backups tool.
+type ListEtcdBackupsOutput struct {
+	ClusterName      string       `json:"cluster_name"`
+	Configured       bool         `json:"configured"`
+	Schedule         string       `json:"schedule,omitempty"`
+	Retention        int          `json:"retention,omitempty"`
+	Destination      string       `json:"destination,omitempty"`
+	Suspended        bool         `json:"suspended"`
+	LastScheduleTime string       `json:"last_schedule_time,omitempty"`
+	Backups          []EtcdBackup `json:"backups"` // newest first
+	Message          string       `json:"message"`
+}
+
+// EtcdBackup is a single run of a workload cluster's etcd backup schedule.
+type EtcdBackup struct {
+	Job         string `json:"job"`
+	Status      string `json:"status"` // Succeeded, Failed or Running
+	Node        string `json:"node,omitempty"`
+	Snapshot    string `json:"snapshot,omitempty"` // file name of the snapshot in the destination
+	SizeBytes   int64  `json:"size_bytes,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+// RestoreClusterInput defines the parameters for the restore_cluster tool.
+type RestoreClusterInput struct {
+	ClusterName        string   `json:"cluster_name" validate:"required"`   // the cluster to create
+	SourceCluster      string   `json:"source_cluster" validate:"required"` // the cluster whose snapshot is restored
+	Snapshot           string   `json:"snapshot"`                           // snapshot ID or RFC 3339 time; the latest by default
+	VeleroBackup       string   `json:"velero_backup"`                      // workloads are not restored without one
+	VeleroNamespace    string   `json:"velero_namespace"`                   // defaults to velero
+	IncludedNamespaces []string `json:"included_namespaces,omitempty"`      // all namespaces in the backup by default
+}
+
+// RestoreClusterOutput defines the response for the restore_cluster tool.
+type RestoreClusterOutput struct {
+	ClusterName     string       `json:"cluster_name"`
+	SourceCluster   string       `json:"source_cluster"`
+	SnapshotID      string       `json:"snapshot_id"`
+	SnapshotTakenAt string       `json:"snapshot_taken_at"`
+	OperationID     string       `json:"operation_id"` // poll with get_operation_status
+	Stages          []AsyncStage `json:"stages"`
+	Message         string       `json:"message"`
+}
+
+// GetOperationStatusInput defines the parameters for the get_operation_status tool.
+type GetOperationStatusInput struct {
+	OperationID string `json:"operation_id" validate:"required"`
+}
+
+// GetOperationStatusOutput defines the response for the get_operation_status tool.
+type GetOperationStatusOutput struct {
+	OperationID string       `json:"operation_id"`
+	Kind        string       `json:"kind"`
+	ClusterName string       `json:"cluster_name,omitempty"`
+	State       string       `json:"state"` // pending, running, succeeded or failed
+	Stages      []AsyncStage `json:"stages"`
+	Error       string       `json:"error,omitempty"`
+	CreatedAt   string       `json:"created_at"`
+	UpdatedAt   string       `json:"updated_at"`
+	Message     string       `json:"message"`
+}
+
+// AsyncStage is a stage of a long-running operation.
+type AsyncStage struct {
+	Name        string `json:"name"`
+	State       string `json:"state"` // pending, running, succeeded, failed or skipped
+	Message     string `json:"message,omitempty"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+}
+
+// RunConformanceInput defines the parameters for the run_conformance tool.
+type RunConformanceInput struct {
+	ClusterName string `json:"cluster_name" validate:"required"`
+	// Mode is the Sonobuoy mode: quick (default), non-disruptive-conformance
+	// or certified-conformance.
+	Mode string `json:"mode,omitempty"`
+}
+
+// RunConformanceOutput defines the response for the run_conformance tool.
+type RunConformanceOutput struct {
+	ClusterName string       `json:"cluster_name"`
+	Mode        string       `json:"mode"`
+	OperationID string       `json:"operation_id"` // poll with get_operation_status
+	Stages      []AsyncStage `json:"stages"`
+	Message     string       `json:"message"`
+}
+
+// SmokeTestClusterInput defines the parameters for the smoke_test_cluster tool.
+type SmokeTestClusterInput struct {
+	ClusterName  string   `json:"cluster_name" validate:"required"`
+	Checks       []string `json:"checks,omitempty"`        // scheduling, dns, storage; all by default
+	StorageClass string   `json:"storage_class,omitempty"` // the cluster's default StorageClass if empty
+}
+
+// SmokeTestClusterOutput defines the response for the smoke_test_cluster tool.
+type SmokeTestClusterOutput struct {
+	ClusterName string           `json:"cluster_name"`
+	Namespace   string           `json:"namespace"` // the workload cluster namespace of the test workload
+	Passed      bool             `json:"passed"`
+	Checks      []SmokeTestCheck `json:"checks"`
+	CleanedUp   bool             `json:"cleaned_up"`
+	Message     string           `json:"message"`
+}
+
+// SmokeTestCheck is the result of a single smoke test check.
+type SmokeTestCheck struct {
+	Check      string `json:"check"`
+	Passed     bool   `json:"passed"`
+	Message    string `json:"message"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// ValidateClusterTemplateInput defines the parameters for the validate_cluster_template tool.
+// Exactly one of TemplateName and Manifest is set.
+type ValidateClusterTemplateInput struct {
+	TemplateName string `json:"template_name,omitempty"` // an existing ClusterClass
+	Manifest     string `json:"manifest,omitempty"`      // ClusterClass YAML
+}
+
+// ValidateClusterTemplateOutput defines the response for the validate_cluster_template tool.
+type ValidateClusterTemplateOutput struct {
+	TemplateName string          `json:"template_name"`
+	Valid        bool            `json:"valid"` // no issue has severity error
+	Issues       []TemplateIssue `json:"issues"`
+	Message      string          `json:"message"`
+}
+
+// TemplateIssue is a problem found in a cluster template.
+type TemplateIssue struct {
+	Severity string `json:"severity"` // error or warning
+	Check    string `json:"check"`    // structure, variables, patches or templates
+	Field    string `json:"field"`    // path of the offending field, e.g. spec.variables[0].schema
+	Message  string `json:"message"`
+}
+
+// PublishClusterTemplateInput defines the parameters for the publish_cluster_template tool.
+// Exactly one of Manifest, Artifact and CatalogTemplate is set.
+type PublishClusterTemplateInput struct {
+	Manifest        string `json:"manifest,omitempty"`         // ClusterClass and template YAML
+	Artifact        string `json:"artifact,omitempty"`         // OCI reference, e.g. ghcr.io/org/templates:v1.2.0
+	CatalogTemplate string `json:"catalog_template,omitempty"` // name of a template in the configured catalogs
+	Version         string `json:"version,omitempty"`          // of the catalog template, default the latest
+	DryRun          bool   `json:"dry_run,omitempty"`
+}
+
+// PublishClusterTemplateOutput defines the response for the publish_cluster_template tool.
+type PublishClusterTemplateOutput struct {
+	TemplateName string             `json:"template_name"`
+	Source       string             `json:"source"` // manifest, or the artifact reference with its digest
+	DryRun       bool               `json:"dry_run"`
+	Objects      []PublishedObject  `json:"objects"`
+	Changes      []ClusterStateDiff `json:"changes"`
+	Diff         string             `json:"diff"` // human-readable, one change per line
+	Issues       []TemplateIssue    `json:"issues,omitempty"`
+	Warnings     []string           `json:"warnings,omitempty"`
+	Applied      bool               `json:"applied"`
+	Message      string             `json:"message"`
+}
+
+// ListClusterTemplatesOutput defines the response for the list_cluster_templates tool.
+type ListClusterTemplatesOutput struct {
+	Templates []ClusterTemplate `json:"templates"` // ClusterClasses in the namespace
+	Catalog   []CatalogTemplate `json:"catalog"`   // templates available from the configured catalogs
+	Warnings  []string          `json:"warnings,omitempty"`
+}
+
+// CatalogTemplate is a cluster template available from an OCI catalog, a
+// repository whose tags are the versions of the template.
+type CatalogTemplate struct {
+	Name              string   `json:"name"`
+	Repository        string   `json:"repository"`
+	Versions          []string `json:"versions"` // newest first
+	LatestVersion     string   `json:"latest_version,omitempty"`
+	InstalledVersions []string `json:"installed_versions,omitempty"`
+}
+
+// PublishedObject is an object of a published cluster template.
+type PublishedObject struct {
+	Object string `json:"object"` // Kind/name
+	Action string `json:"action"` // create, update or unchanged
+}
+
+// UpdateClusterVariablesInput defines the parameters for the update_cluster_variables tool.
+type UpdateClusterVariablesInput struct {
+	ClusterName string                 `json:"cluster_name"`
+	Variables   map[string]interface{} `json:"variables,omitempty"` // topology variables to set
+	Unset       []string               `json:"unset,omitempty"`     // topology variables to remove
+	DryRun      bool                   `json:"dry_run,omitempty"`
+}
+
+// UpdateClusterVariablesOutput defines the response for the update_cluster_variables tool.
+type UpdateClusterVariablesOutput struct {
+	ClusterName string             `json:"cluster_name"`
+	DryRun      bool               `json:"dry_run"`
+	Changes     []ClusterStateDiff `json:"changes"`
+	Diff        string             `json:"diff"` // human-readable, one change per line
+	Rollouts    []MachineRollout   `json:"rollouts"`
+	Warnings    []string           `json:"warnings,omitempty"`
+	Applied     bool               `json:"applied"`
+	Message     string             `json:"message"`
+
+	// PreviousVariables holds the values the changed variables had before,
+	// null for those that were not set, so that the update can be rolled back.
+	PreviousVariables map[string]interface{} `json:"previous_variables,omitempty"`
+}
+
+// MachineRollout is a group of machines that a variable change replaces,
+// because a ClusterClass patch reading the variable changes their templates.
+type MachineRollout struct {
+	Target    string   `json:"target"`    // ControlPlane, MachineDeployment/<name> or MachinePool/<name>
+	Variables []string `json:"variables"` // the changed variables its templates read
+	Machines  []string `json:"machines"`
+}
+
+// CheckTemplateRotationInput defines the parameters for the check_template_rotation tool.
+type CheckTemplateRotationInput struct {
+	ClusterName string `json:"cluster_name,omitempty"` // default: every cluster in the namespace
+}
+
+// CheckTemplateRotationOutput defines the response for the check_template_rotation tool.
+type CheckTemplateRotationOutput struct {
+	Clusters []TemplateRotation `json:"clusters"` // the requested cluster, or every stale cluster
+	Checked  int                `json:"checked"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// TemplateRotation reports whether a cluster runs the current generation of
+// its cluster template and machine templates.
+type TemplateRotation struct {
+	ClusterName      string                     `json:"cluster_name"`
+	Template         string                     `json:"template,omitempty"`
+	Stale            bool                       `json:"stale"`
+	Tracked          bool                       `json:"tracked"` // template generations were recorded at creation
+	ChangedTemplates []TemplateGenerationChange `json:"changed_templates,omitempty"`
+	Targets          []StaleTemplateTarget      `json:"targets,omitempty"`
+}
+
+// TemplateGenerationChange is a template changed since a cluster was created
+// or last refreshed.
+type TemplateGenerationChange struct {
+	Object             string `json:"object"` // Kind/name
+	RecordedGeneration int64  `json:"recorded_generation"`
+	Generation         int64  `json:"generation"` // 0 if the template was deleted
+}
+
+// StaleTemplateTarget is a control plane or node pool whose machines do not
+// reflect its current templates.
+type StaleTemplateTarget struct {
+	Target        string   `json:"target"` // ControlPlane or MachineDeployment/<name>
+	Reasons       []string `json:"reasons"`
+	StaleMachines []string `json:"stale_machines,omitempty"`
+}
+
+// RefreshClusterTemplatesInput defines the parameters for the refresh_cluster_templates tool.
+type RefreshClusterTemplatesInput struct {
+	ClusterName string `json:"cluster_name"`
+	DryRun      bool   `json:"dry_run,omitempty"`
+}
+
+// RefreshClusterTemplatesOutput defines the response for the refresh_cluster_templates tool.
+type RefreshClusterTemplatesOutput struct {
+	ClusterName string           `json:"cluster_name"`
+	DryRun      bool             `json:"dry_run"`
+	Rotation    TemplateRotation `json:"rotation"`   // the state before the refresh
+	RolledOut   []string         `json:"rolled_out"` // targets a rollout was started for
+	Applied     bool             `json:"applied"`
+	Message     string           `json:"message"`
+}
+
+// RolloutInput defines the parameters for the pause_rollout, resume_rollout,
+// restart_rollout and undo_rollout tools.
+type RolloutInput struct {
+	ClusterName string `json:"cluster_name"`
+	Target      string `json:"target"`                // ControlPlane or MachineDeployment/<name>
+	ToRevision  int64  `json:"to_revision,omitempty"` // undo_rollout only; 0 is the previous revision
+	DryRun      bool   `json:"dry_run,omitempty"`
+}
+
+// RolloutOutput defines the response for the rollout tools.
+type RolloutOutput struct {
+	ClusterName string   `json:"cluster_name"`
+	Action      string   `json:"action"` // pause, resume, restart or undo
+	Target      string   `json:"target"`
+	DryRun      bool     `json:"dry_run"`
+	Paused      bool     `json:"paused"`             // whether the target's rollouts are paused afterwards
+	Revision    int64    `json:"revision,omitempty"` // the revision undo_rollout rolls back to
+	Machines    []string `json:"machines,omitempty"` // machines a restart or undo replaces
+	Applied     bool     `json:"applied"`
+	Message     string   `json:"message"`
+}
+
+// CleanupOrphanedResourcesInput defines the parameters for the cleanup_orphaned_resources tool.
+type CleanupOrphanedResourcesInput struct {
+	// ConfirmationToken deletes the resources reported with this token;
+	// without it the resources are only reported.
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+}
+
+// CleanupOrphanedResourcesOutput defines the response for the cleanup_orphaned_resources tool.
+type CleanupOrphanedResourcesOutput struct {
+	Resources         []OrphanedResource `json:"resources"`
+	ConfirmationToken string             `json:"confirmation_token,omitempty"` // confirms deleting the resources
+	Deleted           bool               `json:"deleted"`
+	Message           string             `json:"message"`
+}
+
+// OrphanedResource is a resource whose owning cluster no longer exists.
+type OrphanedResource struct {
+	Kind        string   `json:"kind"`
+	APIVersion  string   `json:"api_version"`
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	ClusterName string   `json:"cluster_name"`
+	CreatedAt   string   `json:"created_at"`
+	Deleting    bool     `json:"deleting,omitempty"`
+	Finalizers  []string `json:"finalizers,omitempty"`
+	Error       string   `json:"error,omitempty"` // why the resource could not be deleted
+}
+
+// BulkScaleInput defines the parameters for the bulk_scale tool.
+type BulkScaleInput struct {
+	LabelSelector string `json:"label_selector"`        // selects the clusters, e.g. env=prod,region=eu
+	Environment   string `json:"environment,omitempty"` // selects the clusters of an environment, and of the label selector if set
+	NodePoolName  string `json:"node_pool_name"`
+	Replicas      int    `json:"replicas"`
+	Concurrency   int    `json:"concurrency,omitempty"`  // clusters changed at once; 0 is the default
+	MaxFailures   int    `json:"max_failures,omitempty"` // stop starting clusters after this many failures; 0 never stops
+	// Canary is the number of clusters changed first; the others are only
+	// changed once these pass the health gates. 0 changes all clusters alike.
+	Canary      int      `json:"canary,omitempty"`
+	CanaryWait  string   `json:"canary_wait,omitempty"`  // how long the canaries must keep passing the health gates, e.g. 10m
+	HealthGates []string `json:"health_gates,omitempty"` // provisioned, nodes or api; default provisioned and nodes
+	DryRun      bool     `json:"dry_run,omitempty"`
+}
+
+// BulkUpgradeInput defines the parameters for the bulk_upgrade tool.
+type BulkUpgradeInput struct {
+	LabelSelector     string   `json:"label_selector"`
+	Environment       string   `json:"environment,omitempty"`
+	KubernetesVersion string   `json:"kubernetes_version"`
+	Concurrency       int      `json:"concurrency,omitempty"`
+	MaxFailures       int      `json:"max_failures,omitempty"`
+	Canary            int      `json:"canary,omitempty"`
+	CanaryWait        string   `json:"canary_wait,omitempty"`
+	HealthGates       []string `json:"health_gates,omitempty"`
+	DryRun            bool     `json:"dry_run,omitempty"`
+}
+
+// BulkOperationOutput defines the response for the bulk_scale and
+// bulk_upgrade tools.
+type BulkOperationOutput struct {
+	Action        string              `json:"action"`         // scale or upgrade
+	LabelSelector string              `json:"label_selector"` // the selector of the clusters, including the environment
+	DryRun        bool                `json:"dry_run"`
+	OperationID   string              `json:"operation_id,omitempty"` // poll with get_operation_status
+	Concurrency   int                 `json:"concurrency"`
+	Clusters      []BulkClusterResult `json:"clusters"`
+	HealthGates   []string            `json:"health_gates,omitempty"` // the gates the canary clusters must pass
+	Planned       int                 `json:"planned"`                // clusters the operation changes
+	Skipped       int                 `json:"skipped"`
+	Message       string              `json:"message"`
+}
+
+// BulkClusterResult is the planned change to one cluster selected by a bulk
+// operation.
+type BulkClusterResult struct {
+	ClusterName string `json:"cluster_name"`
+	Change      string `json:"change,omitempty"` // e.g. md-0: 3 -> 5 replicas
+	Canary      bool   `json:"canary,omitempty"` // changed before the other clusters
+	Skipped     bool   `json:"skipped,omitempty"`
+	Reason      string `json:"reason,omitempty"` // why the cluster is skipped
+	// Warnings describe risks of the change, such as clusters depending on
+	// the cluster.
+	Warnings []string `json:"warnings,omitempty"`
 }
@@ -0,0 +1,134 @@
+package v2
+
+import (
+	v1 "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// Conditions of a Cluster API cluster the readiness fields are derived from.
+const (
+	conditionControlPlaneReady   = "ControlPlaneReady"
+	conditionInfrastructureReady = "InfrastructureReady"
+)
+
+// FromV1ListClusters converts a v1 list_clusters response.
+func FromV1ListClusters(in *v1.ListClustersOutput) *ListClustersOutput {
+	out := &ListClustersOutput{
+		Clusters:    make([]ClusterSummary, 0, len(in.Clusters)),
+		LastUpdated: in.LastUpdated,
+		Incremental: in.Incremental,
+		Removed:     in.Removed,
+		NextToken:   in.NextToken,
+	}
+	for _, summary := range in.Clusters {
+		out.Clusters = append(out.Clusters, ClusterSummary{
+			Name:              summary.Name,
+			Namespace:         summary.Namespace,
+			Provider:          summary.Provider,
+			Region:            summary.Region,
+			Environment:       summary.Environment,
+			Endpoint:          summary.Endpoint,
+			KubernetesVersion: summary.KubernetesVersion,
+			Status:            summary.Status,
+			CreationTimestamp: summary.CreatedAt,
+			Age:               summary.Age,
+			NodeCount:         summary.NodeCount,
+			ReadyNodeCount:    summary.ReadyNodeCount,
+			LastUpdated:       summary.LastUpdated,
+			ResourceVersion:   summary.ResourceVersion,
+			Advisory:          fromV1Advisory(summary.Advisory),
+			Warnings:          summary.Warnings,
+		})
+	}
+	return out
+}
+
+// FromV1GetCluster converts a v1 get_cluster response. The node count and
+// readiness, which v1 leaves to the caller to work out, are derived from the
+// node pools, the control plane and the cluster conditions.
+func FromV1GetCluster(in *v1.GetClusterOutput) *GetClusterOutput {
+	cluster := in.Cluster
+	details := ClusterDetails{
+		Name:                cluster.Name,
+		Namespace:           cluster.Namespace,
+		Provider:            cluster.Provider,
+		Region:              cluster.Region,
+		KubernetesVersion:   cluster.KubernetesVersion,
+		Status:              cluster.Status,
+		CreationTimestamp:   cluster.CreatedAt,
+		Endpoint:            cluster.Endpoint,
+		ControlPlaneReady:   conditionTrue(cluster.Conditions, conditionControlPlaneReady),
+		InfrastructureReady: conditionTrue(cluster.Conditions, conditionInfrastructureReady),
+		NodePools:           make([]NodePool, 0, len(cluster.NodePools)),
+		ControlPlane:        (*ControlPlaneStatus)(cluster.ControlPlane),
+		Conditions:          make([]ClusterCondition, 0, len(cluster.Conditions)),
+		InfrastructureRef:   cluster.InfrastructureRef,
+		CloudTags:           (*CloudTags)(cluster.CloudTags),
+		Identity:            (*ClusterIdentity)(cluster.Identity),
+		Bastion:             (*BastionHost)(cluster.Bastion),
+	}
+	for _, pool := range cluster.NodePools {
+		details.NodePools = append(details.NodePools, NodePool(pool))
+		details.NodeCount += pool.Replicas
+	}
+	if cluster.ControlPlane != nil {
+		details.NodeCount += cluster.ControlPlane.Replicas
+		// The control plane object is more current than the condition the
+		// Cluster mirrors from it.
+		details.ControlPlaneReady = cluster.ControlPlane.Ready
+	}
+	for _, condition := range cluster.Conditions {
+		details.Conditions = append(details.Conditions, ClusterCondition(condition))
+	}
+
+	return &GetClusterOutput{Cluster: details, Warnings: in.Warnings}
+}
+
+// FromV1GetClusterNodes converts a v1 get_cluster_nodes response.
+func FromV1GetClusterNodes(in *v1.GetClusterNodesOutput) *GetClusterNodesOutput {
+	out := &GetClusterNodesOutput{Nodes: make([]Node, 0, len(in.Nodes))}
+	for _, node := range in.Nodes {
+		converted := Node{
+			Name:              node.Name,
+			Status:            node.Status,
+			Roles:             node.Roles,
+			Version:           node.KubeletVersion,
+			InternalIP:        node.InternalIP,
+			ExternalIP:        node.ExternalIP,
+			InstanceType:      node.InstanceType,
+			AvailabilityZone:  node.AvailabilityZone,
+			Labels:            node.Labels,
+			CreationTimestamp: node.CreatedAt,
+		}
+		for _, accelerator := range node.Accelerators {
+			converted.Accelerators = append(converted.Accelerators, NodeAccelerator(accelerator))
+		}
+		out.Nodes = append(out.Nodes, converted)
+	}
+	return out
+}
+
+func fromV1Advisory(in *v1.VersionAdvisory) *VersionAdvisory {
+	if in == nil {
+		return nil
+	}
+	out := &VersionAdvisory{
+		Release:   in.Release,
+		EndOfLife: in.EndOfLife,
+		Status:    in.Status,
+		CVEs:      make([]KubernetesCVE, 0, len(in.CVEs)),
+		UpgradeTo: in.UpgradeTo,
+	}
+	for _, cve := range in.CVEs {
+		out.CVEs = append(out.CVEs, KubernetesCVE(cve))
+	}
+	return out
+}
+
+func conditionTrue(conditions []v1.ClusterCondition, conditionType string) bool {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status == "True"
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package v2
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func TestFromV1ListClusters(t *testing.T) {
+	out := FromV1ListClusters(&v1.ListClustersOutput{
+		Clusters: []v1.ClusterSummary{{
+			Name:              "test-cluster",
+			KubernetesVersion: "v1.31.0",
+			Status:            v1.ClusterStatusReady,
+			CreatedAt:         "2025-07-01T12:00:00Z",
+			NodeCount:         3,
+			Advisory: &v1.VersionAdvisory{
+				Release: "1.31",
+				Status:  "supported",
+				CVEs:    []v1.KubernetesCVE{{ID: "CVE-2025-0001", FixedIn: "v1.31.2"}},
+			},
+		}},
+		LastUpdated: "2025-07-01T12:05:00Z",
+	})
+
+	data, err := json.Marshal(out)
+	require.NoError(t, err)
+
+	var fields struct {
+		Clusters    []map[string]interface{} `json:"clusters"`
+		LastUpdated string                   `json:"lastUpdated"`
+	}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "2025-07-01T12:05:00Z", fields.LastUpdated)
+	require.Len(t, fields.Clusters, 1)
+
+	cluster := fields.Clusters[0]
+	assert.Equal(t, "v1.31.0", cluster["kubernetesVersion"])
+	assert.Equal(t, "Ready", cluster["status"])
+	assert.Equal(t, "2025-07-01T12:00:00Z", cluster["creationTimestamp"])
+	assert.EqualValues(t, 3, cluster["nodeCount"])
+	assert.Equal(t, "v1.31.2", cluster["advisory"].(map[string]interface{})["cves"].([]interface{})[0].(map[string]interface{})["fixedIn"])
+}
+
+func TestFromV1GetCluster(t *testing.T) {
+	tests := []struct {
+		name                    string
+		cluster                 v1.ClusterDetails
+		wantNodeCount           int
+		wantControlPlaneReady   bool
+		wantInfrastructureReady bool
+	}{
+		{
+			name: "ready",
+			cluster: v1.ClusterDetails{
+				NodePools:    []v1.NodePool{{Name: "workers", Replicas: 2}, {Name: "gpu", Replicas: 1}},
+				ControlPlane: &v1.ControlPlaneStatus{Replicas: 3, Ready: true},
+				Conditions: []v1.ClusterCondition{
+					{Type: "InfrastructureReady", Status: "True"},
+					{Type: "ControlPlaneReady", Status: "False"},
+				},
+			},
+			wantNodeCount:           6,
+			wantControlPlaneReady:   true,
+			wantInfrastructureReady: true,
+		},
+		{
+			name: "conditions only",
+			cluster: v1.ClusterDetails{
+				Conditions: []v1.ClusterCondition{
+					{Type: "InfrastructureReady", Status: "False"},
+					{Type: "ControlPlaneReady", Status: "True"},
+				},
+			},
+			wantControlPlaneReady: true,
+		},
+		{
+			name: "nothing reported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := FromV1GetCluster(&v1.GetClusterOutput{Cluster: tt.cluster})
+			assert.Equal(t, tt.wantNodeCount, out.Cluster.NodeCount)
+			assert.Equal(t, tt.wantControlPlaneReady, out.Cluster.ControlPlaneReady)
+			assert.Equal(t, tt.wantInfrastructureReady, out.Cluster.InfrastructureReady)
+			assert.Len(t, out.Cluster.NodePools, len(tt.cluster.NodePools))
+			assert.Len(t, out.Cluster.Conditions, len(tt.cluster.Conditions))
+		})
+	}
+}
+
+func TestFromV1GetClusterNodes(t *testing.T) {
+	out := FromV1GetClusterNodes(&v1.GetClusterNodesOutput{
+		Nodes: []v1.NodeInfo{{
+			Name:           "node-1",
+			Status:         "Ready",
+			Roles:          []string{"worker"},
+			KubeletVersion: "v1.31.0",
+			InternalIP:     "10.0.1.10",
+			Accelerators:   []v1.NodeAccelerator{{Resource: "nvidia.com/gpu", Capacity: 1, Allocatable: 1}},
+			CreatedAt:      "2025-07-01T12:00:00Z",
+		}},
+	})
+
+	data, err := json.Marshal(out.Nodes[0])
+	require.NoError(t, err)
+
+	var node map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &node))
+	assert.Equal(t, "v1.31.0", node["version"])
+	assert.Equal(t, "10.0.1.10", node["internalIP"])
+	assert.Equal(t, "2025-07-01T12:00:00Z", node["creationTimestamp"])
+	assert.Len(t, node["accelerators"], 1)
+}
@@ -0,0 +1,174 @@
+// Package v2 defines version 2 of the output schema of the cluster tools.
+// Every field is named in camelCase, creation times are reported as
+// creationTimestamp, and the readiness of a cluster is summarized in
+// controlPlaneReady and infrastructureReady. Version 1 outputs are converted
+// with the FromV1 functions, so the services keep producing one format.
+package v2
+
+import (
+	v1 "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// Version is the name of this output schema version.
+const Version = "v2"
+
+// ListClustersOutput defines the response for the list_clusters tool.
+type ListClustersOutput struct {
+	Clusters    []ClusterSummary `json:"clusters"`
+	LastUpdated string           `json:"lastUpdated"` // oldest time the summaries may date from
+	Incremental bool             `json:"incremental,omitempty"`
+	Removed     []string         `json:"removed,omitempty"`
+	NextToken   string           `json:"nextToken,omitempty"`
+}
+
+// ClusterSummary provides basic information about a cluster.
+type ClusterSummary struct {
+	Name              string           `json:"name"`
+	Namespace         string           `json:"namespace"`
+	Provider          string           `json:"provider"`
+	Region            string           `json:"region,omitempty"`
+	Environment       string           `json:"environment,omitempty"`
+	Endpoint          string           `json:"endpoint,omitempty"`
+	KubernetesVersion string           `json:"kubernetesVersion"`
+	Status            v1.ClusterStatus `json:"status"`
+	CreationTimestamp string           `json:"creationTimestamp"`
+	Age               string           `json:"age,omitempty"`
+	NodeCount         int              `json:"nodeCount"`      // desired nodes, control plane and workers
+	ReadyNodeCount    int              `json:"readyNodeCount"` // nodes reported ready
+	LastUpdated       string           `json:"lastUpdated,omitempty"`
+	ResourceVersion   string           `json:"resourceVersion,omitempty"`
+	Advisory          *VersionAdvisory `json:"advisory,omitempty"`
+	Warnings          []string         `json:"warnings,omitempty"`
+}
+
+// VersionAdvisory is the support status of a Kubernetes version.
+type VersionAdvisory struct {
+	Release   string          `json:"release"`
+	EndOfLife string          `json:"endOfLife,omitempty"`
+	Status    string          `json:"status"`
+	CVEs      []KubernetesCVE `json:"cves"`
+	UpgradeTo string          `json:"upgradeTo,omitempty"`
+}
+
+// KubernetesCVE is a vulnerability affecting a Kubernetes version.
+type KubernetesCVE struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	FixedIn  string `json:"fixedIn,omitempty"`
+}
+
+// GetClusterOutput defines the response for the get_cluster tool.
+type GetClusterOutput struct {
+	Cluster  ClusterDetails `json:"cluster"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+// ClusterDetails provides detailed information about a cluster.
+type ClusterDetails struct {
+	Name              string           `json:"name"`
+	Namespace         string           `json:"namespace"`
+	Provider          string           `json:"provider"`
+	Region            string           `json:"region"`
+	KubernetesVersion string           `json:"kubernetesVersion"`
+	Status            v1.ClusterStatus `json:"status"`
+	CreationTimestamp string           `json:"creationTimestamp"`
+	Endpoint          string           `json:"endpoint"`
+	// NodeCount is the desired number of nodes, control plane and workers.
+	NodeCount           int                    `json:"nodeCount"`
+	ControlPlaneReady   bool                   `json:"controlPlaneReady"`
+	InfrastructureReady bool                   `json:"infrastructureReady"`
+	NodePools           []NodePool             `json:"nodePools"`
+	ControlPlane        *ControlPlaneStatus    `json:"controlPlane,omitempty"`
+	Conditions          []ClusterCondition     `json:"conditions"`
+	InfrastructureRef   map[string]interface{} `json:"infrastructureRef"`
+	CloudTags           *CloudTags             `json:"cloudTags,omitempty"`
+	Identity            *ClusterIdentity       `json:"identity,omitempty"`
+	Bastion             *BastionHost           `json:"bastion,omitempty"`
+}
+
+// CloudTags reports the tags applied to a cluster's cloud resources.
+type CloudTags struct {
+	Tags   map[string]string `json:"tags"`
+	Source string            `json:"source"`
+}
+
+// BastionHost reports the bastion host in front of a cluster's nodes.
+type BastionHost struct {
+	Address    string `json:"address,omitempty"`
+	PublicIP   string `json:"publicIP,omitempty"`
+	PrivateIP  string `json:"privateIP,omitempty"`
+	InstanceID string `json:"instanceID,omitempty"`
+	State      string `json:"state,omitempty"`
+	Source     string `json:"source"`
+}
+
+// ClusterIdentity reports the CAPA identity a cluster's AWS resources are
+// managed with.
+type ClusterIdentity struct {
+	Kind           string `json:"kind"`
+	Name           string `json:"name"`
+	RoleARN        string `json:"roleARN,omitempty"`
+	SourceIdentity string `json:"sourceIdentity,omitempty"`
+	Source         string `json:"source"`
+}
+
+// ControlPlaneStatus represents the control plane of a cluster.
+type ControlPlaneStatus struct {
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Managed       bool   `json:"managed"`
+	Replicas      int    `json:"replicas"`
+	ReadyReplicas int    `json:"readyReplicas"`
+	Ready         bool   `json:"ready"`
+	Message       string `json:"message,omitempty"`
+}
+
+// NodePool represents a group of nodes in a cluster.
+type NodePool struct {
+	Name              string `json:"name"`
+	Kind              string `json:"kind"`
+	Replicas          int    `json:"replicas"`
+	ReadyReplicas     int    `json:"readyReplicas"`
+	MachineType       string `json:"machineType"`
+	Phase             string `json:"phase,omitempty"`
+	Spot              bool   `json:"spot,omitempty"`
+	SpotInterruptions int    `json:"spotInterruptions,omitempty"`
+}
+
+// ClusterCondition represents a condition of a cluster.
+type ClusterCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	LastTransitionTime string `json:"lastTransitionTime"`
+	Reason             string `json:"reason"`
+	Message            string `json:"message"`
+}
+
+// GetClusterNodesOutput defines the response for the get_cluster_nodes tool.
+type GetClusterNodesOutput struct {
+	Nodes []Node `json:"nodes"`
+}
+
+// Node provides information about a node of a cluster.
+type Node struct {
+	Name              string            `json:"name"`
+	Status            string            `json:"status"`
+	Roles             []string          `json:"roles"`
+	Version           string            `json:"version"` // kubelet version
+	InternalIP        string            `json:"internalIP"`
+	ExternalIP        string            `json:"externalIP,omitempty"`
+	InstanceType      string            `json:"instanceType"`
+	AvailabilityZone  string            `json:"availabilityZone"`
+	Labels            map[string]string `json:"labels"`
+	Accelerators      []NodeAccelerator `json:"accelerators,omitempty"`
+	CreationTimestamp string            `json:"creationTimestamp"`
+}
+
+// NodeAccelerator describes the GPUs or other accelerators a node advertises.
+type NodeAccelerator struct {
+	Resource    string `json:"resource"`
+	Capacity    int64  `json:"capacity"`
+	Allocatable int64  `json:"allocatable"`
+	Product     string `json:"product,omitempty"`
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// restPathPrefix prefixes the paths of the server's REST API.
+const restPathPrefix = "/api/v1"
+
+// client calls the REST API of the server, which runs the same tool handlers,
+// policies and approvals as MCP tool calls.
+type client struct {
+	server string
+	apiKey string
+	locale string // Accept-Language of the calls, if set
+	http   *http.Client
+}
+
+// apiError is the error of a failed call, as the REST API reports it.
+type apiError struct {
+	Status        int                    `json:"-"`
+	CorrelationID string                 `json:"-"`
+	Code          string                 `json:"code"`
+	Message       string                 `json:"message"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+}
+
+func (e *apiError) Error() string {
+	message := e.Message
+	if e.Code != "" {
+		message = e.Code + ": " + message
+	}
+	if e.CorrelationID != "" && !strings.Contains(message, e.CorrelationID) {
+		message += " (correlation ID: " + e.CorrelationID + ")"
+	}
+	return message
+}
+
+// call sends a request to a path below the REST API prefix and decodes the
+// JSON response into out, unless out is nil.
+func (c *client) call(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := strings.TrimSuffix(c.server, "/") + restPathPrefix + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if c.locale != "" {
+		req.Header.Set("Accept-Language", c.locale)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", c.server, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		failure := &apiError{Status: resp.StatusCode, CorrelationID: resp.Header.Get("X-Correlation-ID")}
+		var decoded struct {
+			Error *apiError `json:"error"`
+		}
+		if json.Unmarshal(data, &decoded) == nil && decoded.Error != nil {
+			failure.Code = decoded.Error.Code
+			failure.Message = decoded.Error.Message
+			failure.Details = decoded.Error.Details
+		} else {
+			failure.Message = fmt.Sprintf("%s: %s", resp.Status, bytes.TrimSpace(data))
+		}
+		return failure
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// cli holds what the commands share.
+type cli struct {
+	client    *client
+	namespace string
+	json      bool
+	stdout    io.Writer
+}
+
+// commands are the CLI commands by name. Each gets the arguments after its
+// name.
+var commands = map[string]func(ctx context.Context, c *cli, args []string) error{
+	"list":   listClusters,
+	"get":    getCluster,
+	"create": createCluster,
+	"scale":  scaleCluster,
+	"delete": deleteCluster,
+}
+
+func listClusters(ctx context.Context, c *cli, args []string) error {
+	flags := commandFlags("list")
+	status := flags.String("status", "", "list only clusters with this status, e.g. Ready")
+	environment := flags.String("environment", "", "list only clusters of this environment")
+	if err := flags.Parse(args); err != nil {
+		return usageError(fmt.Sprintf("list: %v", err))
+	}
+	if flags.NArg() > 0 {
+		return usageError("list takes no arguments")
+	}
+
+	query := c.query()
+	if *status != "" {
+		query.Set("status", *status)
+	}
+	if *environment != "" {
+		query.Set("environment", *environment)
+	}
+	var output api.ListClustersOutput
+	if err := c.client.call(ctx, http.MethodGet, "/clusters", query, nil, &output); err != nil {
+		return err
+	}
+	if c.json {
+		return c.print(output)
+	}
+
+	table := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "NAMESPACE\tNAME\tSTATUS\tVERSION\tNODES\tPROVIDER\tENVIRONMENT")
+	for _, cluster := range output.Clusters {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%d/%d\t%s\t%s\n", cluster.Namespace, cluster.Name, cluster.Status,
+			cluster.KubernetesVersion, cluster.ReadyNodeCount, cluster.NodeCount, cluster.Provider, cluster.Environment)
+	}
+	return table.Flush()
+}
+
+func getCluster(ctx context.Context, c *cli, args []string) error {
+	flags := commandFlags("get")
+	name, err := parseWithName(flags, args)
+	if err != nil {
+		return err
+	}
+
+	var output api.GetClusterOutput
+	if err := c.client.call(ctx, http.MethodGet, "/clusters/"+url.PathEscape(name), c.query(), nil, &output); err != nil {
+		return err
+	}
+	if c.json {
+		return c.print(output)
+	}
+
+	cluster := output.Cluster
+	fmt.Fprintf(c.stdout, "Name:        %s/%s\nStatus:      %s\nVersion:     %s\nProvider:    %s %s\nEndpoint:    %s\nCreated:     %s\n",
+		cluster.Namespace, cluster.Name, cluster.Status, cluster.KubernetesVersion, cluster.Provider, cluster.Region, cluster.Endpoint, cluster.CreatedAt)
+	if len(cluster.NodePools) > 0 {
+		fmt.Fprintln(c.stdout, "Node pools:")
+		for _, pool := range cluster.NodePools {
+			fmt.Fprintf(c.stdout, "  %s: %d/%d ready\n", pool.Name, pool.ReadyReplicas, pool.Replicas)
+		}
+	}
+	printWarnings(c.stdout, output.Warnings)
+	return nil
+}
+
+func createCluster(ctx context.Context, c *cli, args []string) error {
+	flags := commandFlags("create")
+	template := flags.String("template", "", "name of the ClusterClass to create the cluster from (required)")
+	kubernetesVersion := flags.String("kubernetes-version", "", "Kubernetes version of the cluster, e.g. v1.31.0 (required)")
+	preset := flags.String("preset", "", "name of a variable preset")
+	templateVersion := flags.String("template-version", "", "version of the template the cluster must be created from")
+	dryRun := flags.Bool("dry-run", false, "only check that the cluster could be created")
+	variables := variablesFlag{}
+	flags.Var(variables, "var", "template variable as name=value, repeatable; values are parsed as JSON when possible")
+	name, err := parseWithName(flags, args)
+	if err != nil {
+		return err
+	}
+	if *template == "" {
+		return usageError("create requires -template")
+	}
+	if *kubernetesVersion == "" {
+		return usageError("create requires -kubernetes-version")
+	}
+
+	body := map[string]interface{}{"clusterName": name, "templateName": *template, "kubernetesVersion": *kubernetesVersion}
+	if len(variables) > 0 {
+		body["variables"] = map[string]interface{}(variables)
+	}
+	if *preset != "" {
+		body["preset"] = *preset
+	}
+	if *templateVersion != "" {
+		body["templateVersion"] = *templateVersion
+	}
+	if *dryRun {
+		body["dryRun"] = true
+	}
+	var output api.CreateClusterOutput
+	if err := c.client.call(ctx, http.MethodPost, "/clusters", c.query(), body, &output); err != nil {
+		return err
+	}
+	if c.json {
+		return c.print(output)
+	}
+	fmt.Fprintf(c.stdout, "%s: %s\n", output.Status, output.Message)
+	if output.OperationID != "" {
+		fmt.Fprintf(c.stdout, "Operation: %s\n", output.OperationID)
+	}
+	printWarnings(c.stdout, output.Warnings)
+	return nil
+}
+
+func scaleCluster(ctx context.Context, c *cli, args []string) error {
+	flags := commandFlags("scale")
+	nodePool := flags.String("node-pool", "", "name of the node pool to scale (required)")
+	replicas := flags.Int("replicas", -1, "number of nodes (required)")
+	name, err := parseWithName(flags, args)
+	if err != nil {
+		return err
+	}
+	if *nodePool == "" || *replicas < 0 {
+		return usageError("scale requires -node-pool and -replicas")
+	}
+
+	body := map[string]interface{}{"nodePoolName": *nodePool, "replicas": *replicas}
+	var output api.ScaleClusterOutput
+	if err := c.client.call(ctx, http.MethodPost, "/clusters/"+url.PathEscape(name)+"/scale", c.query(), body, &output); err != nil {
+		return err
+	}
+	if c.json {
+		return c.print(output)
+	}
+	fmt.Fprintf(c.stdout, "%s: %s (%d -> %d nodes)\n", output.Status, output.Message, output.OldReplicas, output.NewReplicas)
+	return nil
+}
+
+func deleteCluster(ctx context.Context, c *cli, args []string) error {
+	flags := commandFlags("delete")
+	approvalID := flags.String("approval-id", "", "ID of the approval of the deletion, when approvals are required")
+	name, err := parseWithName(flags, args)
+	if err != nil {
+		return err
+	}
+
+	query := c.query()
+	if *approvalID != "" {
+		query.Set("approvalId", *approvalID)
+	}
+	var output api.DeleteClusterOutput
+	if err := c.client.call(ctx, http.MethodDelete, "/clusters/"+url.PathEscape(name), query, nil, &output); err != nil {
+		return err
+	}
+	if c.json {
+		return c.print(output)
+	}
+	fmt.Fprintf(c.stdout, "%s: %s\n", output.Status, output.Message)
+	for _, remaining := range output.Remaining {
+		fmt.Fprintf(c.stdout, "Remaining: %s %s\n", remaining.Kind, remaining.Name)
+	}
+	printWarnings(c.stdout, output.Warnings)
+	return nil
+}
+
+// query returns the query parameters every call takes.
+func (c *cli) query() url.Values {
+	query := url.Values{}
+	if c.namespace != "" {
+		query.Set("namespace", c.namespace)
+	}
+	return query
+}
+
+func commandFlags(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// parseWithName parses the flags of a command taking a cluster name, which
+// may come before or after the flags.
+func parseWithName(flags *flag.FlagSet, args []string) (string, error) {
+	if err := flags.Parse(args); err != nil {
+		return "", usageError(fmt.Sprintf("%s: %v", flags.Name(), err))
+	}
+	if flags.NArg() == 0 {
+		return "", usageError(flags.Name() + " requires a cluster name")
+	}
+	name := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return "", usageError(fmt.Sprintf("%s: %v", flags.Name(), err))
+	}
+	if flags.NArg() > 0 {
+		return "", usageError(fmt.Sprintf("%s takes a single cluster name, got %s", flags.Name(), strings.Join(append([]string{name}, flags.Args()...), " ")))
+	}
+	return name, nil
+}
+
+// variablesFlag collects template variables given as name=value.
+type variablesFlag map[string]interface{}
+
+func (v variablesFlag) String() string {
+	return ""
+}
+
+func (v variablesFlag) Set(value string) error {
+	name, raw, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("variable %q must be name=value", value)
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		parsed = raw
+	}
+	v[name] = parsed
+	return nil
+}
+
+func printWarnings(w io.Writer, warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+}
@@ -0,0 +1,109 @@
+// Command capi-mcp-cli lists, creates, scales and deletes workload clusters
+// through the REST API of the CAPI MCP server. Scripts using it go through the
+// same policies, approvals, maintenance windows and audit trail as agents,
+// rather than around them with kubectl.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const usage = `Usage: capi-mcp-cli [flags] <command> [command flags] [cluster]
+
+Commands:
+  list      List clusters (-status, -environment)
+  get       Show a cluster
+  create    Create a cluster (-template, -preset, -template-version, -var name=value)
+  scale     Scale a node pool (-node-pool, -replicas)
+  delete    Delete a cluster (-approval-id)
+
+Flags:
+`
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the CLI and returns its exit code: 0 on success, 1 when a call
+// fails and 2 on usage errors.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("capi-mcp-cli", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+	server := flags.String("server", envOr("CAPI_MCP_SERVER", "http://localhost:8080"), "URL of the server ($CAPI_MCP_SERVER)")
+	apiKey := flags.String("api-key", os.Getenv("CAPI_MCP_API_KEY"), "API key of the caller's identity ($CAPI_MCP_API_KEY)")
+	locale := flags.String("locale", os.Getenv("CAPI_MCP_LOCALE"), "language of error messages, e.g. de ($CAPI_MCP_LOCALE)")
+	namespace := flags.String("namespace", "", "namespace of the clusters (default: the identity's namespace)")
+	output := flags.String("o", "table", "output format: table or json")
+	timeout := flags.Duration("timeout", time.Minute, "timeout of each call")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "invalid output format %q: must be table or json\n", *output)
+		return 2
+	}
+
+	cli := &cli{
+		client:    &client{server: *server, apiKey: *apiKey, locale: *locale, http: &http.Client{Timeout: *timeout}},
+		namespace: *namespace,
+		json:      *output == "json",
+		stdout:    stdout,
+	}
+	command, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return 2
+	}
+
+	if err := command(ctx, cli, flags.Args()[1:]); err != nil {
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// usageError is a command line that does not make sense.
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// print writes a response as indented JSON.
+func (c *cli) print(response interface{}) error {
+	data, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.stdout, string(data))
+	return err
+}
+
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+	"github.com/capi-mcp/capi-mcp-server/pkg/tools"
+)
+
+func TestRun(t *testing.T) {
+	var requests []*http.Request
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		var body map[string]interface{}
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		bodies = append(bodies, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/clusters":
+			io.WriteString(w, `{"clusters": [{"name": "prod-a", "namespace": "team-a", "status": "Ready", "kubernetes_version": "v1.31.2", "node_count": 3, "ready_node_count": 3, "provider": "aws"}], "last_updated": "2026-03-02T10:00:00Z"}`)
+		case "POST /api/v1/clusters":
+			w.WriteHeader(http.StatusAccepted)
+			io.WriteString(w, `{"cluster_name": "prod-b", "status": "Provisioning", "message": "Cluster creation initiated"}`)
+		case "DELETE /api/v1/clusters/prod-a":
+			w.Header().Set("X-Correlation-ID", "c0ffee")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			io.WriteString(w, `{"error": {"code": "PRECONDITION_FAILED", "message": "delete_cluster of cluster 'prod-a' requires the approval of a second identity", "details": {"approval_id": "1a2b3c4d"}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), append([]string{"-server", server.URL, "-api-key", "secret", "-namespace", "team-a"}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	t.Run("list", func(t *testing.T) {
+		code, stdout, _ := run("list", "-status", "Ready")
+		require.Equal(t, 0, code)
+		assert.Contains(t, stdout, "prod-a")
+		assert.Contains(t, stdout, "3/3")
+
+		request := requests[len(requests)-1]
+		assert.Equal(t, "Bearer secret", request.Header.Get("Authorization"))
+		assert.Equal(t, "Ready", request.URL.Query().Get("status"))
+		assert.Equal(t, "team-a", request.URL.Query().Get("namespace"))
+		assert.Empty(t, request.Header.Get("Accept-Language"))
+
+		code, _, _ = run("-locale", "de", "list")
+		require.Equal(t, 0, code)
+		assert.Equal(t, "de", requests[len(requests)-1].Header.Get("Accept-Language"))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		code, stdout, _ := run("-o", "json", "create", "prod-b", "-template", "aws-standard", "-kubernetes-version", "v1.31.0", "-var", "workerCount=3", "-var", "region=eu-west-1")
+		require.Equal(t, 0, code)
+		assert.Contains(t, stdout, `"status": "Provisioning"`)
+		assert.Equal(t, map[string]interface{}{
+			"clusterName":       "prod-b",
+			"templateName":      "aws-standard",
+			"kubernetesVersion": "v1.31.0",
+			"variables":         map[string]interface{}{"workerCount": float64(3), "region": "eu-west-1"},
+		}, bodies[len(bodies)-1])
+
+		code, _, _ = run("create", "prod-b", "-template", "aws-standard", "-kubernetes-version", "v1.31.0", "-dry-run")
+		require.Equal(t, 0, code)
+		assert.Equal(t, true, bodies[len(bodies)-1]["dryRun"])
+	})
+
+	t.Run("failed call", func(t *testing.T) {
+		code, _, stderr := run("delete", "prod-a")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "PRECONDITION_FAILED: delete_cluster of cluster 'prod-a' requires the approval")
+		assert.Contains(t, stderr, "correlation ID: c0ffee")
+	})
+
+	t.Run("usage errors", func(t *testing.T) {
+		tests := []struct {
+			name string
+			args []string
+		}{
+			{name: "no command"},
+			{name: "unknown command", args: []string{"upgrade", "prod-a"}},
+			{name: "missing cluster name", args: []string{"delete"}},
+			{name: "missing template", args: []string{"create", "prod-b", "-kubernetes-version", "v1.31.0"}},
+			{name: "missing kubernetes version", args: []string{"create", "prod-b", "-template", "aws"}},
+			{name: "invalid variable", args: []string{"create", "prod-b", "-template", "aws", "-kubernetes-version", "v1.31.0", "-var", "region"}},
+			{name: "missing replicas", args: []string{"scale", "prod-a", "-node-pool", "workers"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				sent := len(requests)
+				code, _, _ := run(tt.args...)
+				assert.Equal(t, 2, code)
+				assert.Len(t, requests, sent, "no call is made")
+			})
+		}
+	})
+}
+
+// TestRun_Server runs commands against the REST API of the server, whose
+// answers the fixtures above only imitate.
+func TestRun_Server(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	replicas := int32(3)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Namespace: "team-a"}},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "team-a", Labels: map[string]string{clusterv1.ClusterNameLabel: "prod-a"}},
+			Spec:       clusterv1.MachineDeploymentSpec{ClusterName: "prod-a", Replicas: &replicas},
+		},
+	).Build()
+
+	logger := logging.NewLogger(slog.LevelError, "text")
+	clusterService := service.NewEnhancedClusterService(kube.NewClientFromClient(fakeClient, "team-a"), logger, provider.NewProviderManager())
+	server := httptest.NewServer(tools.NewEnhancedProvider(mcp.NewServer("capi-mcp-server", "test", nil), logger, clusterService).RESTHandler())
+	defer server.Close()
+
+	t.Run("scale", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-server", server.URL, "-api-key", "secret", "scale", "prod-a", "-node-pool", "workers", "-replicas", "5"}, &stdout, &stderr)
+		require.Equal(t, 0, code, stderr.String())
+		assert.Equal(t, "scaling: Scaling node pool 'workers' from 3 to 5 replicas (3 -> 5 nodes)\n", stdout.String())
+	})
+}
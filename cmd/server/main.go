@@ -8,10 +8,12 @@ import (
 	"syscall"
 
 	"github.com/capi-mcp/capi-mcp-server/internal/config"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
 	"github.com/capi-mcp/capi-mcp-server/internal/server"
 )
 
 func main() {
+	// Log to stdout until the configured sinks are set up
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
@@ -20,6 +22,22 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	rootLogger, closeLogs, err := logging.NewLoggerWithOptions(server.LoggingOptions(cfg), nil)
+	if err != nil {
+		logger.Error("failed to configure logging", "error", err)
+		os.Exit(1)
+	}
+	defer closeLogs()
+	server.RouteKubernetesLogs(rootLogger)
+	logger = rootLogger.Logger
+	slog.SetDefault(logger)
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -28,21 +46,17 @@ func main() {
 		cancel()
 	}()
 
-	cfg, err := config.Load()
-	if err != nil {
-		logger.Error("failed to load configuration", "error", err)
-		os.Exit(1)
-	}
-
 	srv, err := server.New(cfg, logger)
 	if err != nil {
 		logger.Error("failed to create server", "error", err)
+		closeLogs()
 		os.Exit(1)
 	}
 
 	logger.Info("starting CAPI MCP server", "version", cfg.Version)
 	if err := srv.Run(ctx); err != nil {
 		logger.Error("server error", "error", err)
+		closeLogs()
 		os.Exit(1)
 	}
 
@@ -0,0 +1,197 @@
+// Package accounting aggregates the tool calls of each identity over time:
+// how often each tool was called, how many calls failed and how many changed
+// a cluster. Operators query it for chargeback and to spot identities that
+// misbehave, such as an agent retrying a denied call in a loop.
+package accounting
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// BucketSize is the granularity calls are aggregated at, and so the
+// precision of the time range of a usage query.
+const BucketSize = time.Hour
+
+// Metrics exports the tool calls of each identity.
+type Metrics interface {
+	IncIdentityToolCalls(identity, tool, status string)
+	IncIdentityClusterMutations(identity, tool string)
+}
+
+// Counts are aggregated tool calls.
+type Counts struct {
+	Calls     int64
+	Failures  int64
+	Mutations int64            // successful calls that changed a cluster
+	Errors    map[string]int64 // failures by error code
+}
+
+// add adds other to c.
+func (c *Counts) add(other *Counts) {
+	c.Calls += other.Calls
+	c.Failures += other.Failures
+	c.Mutations += other.Mutations
+	for code, n := range other.Errors {
+		if c.Errors == nil {
+			c.Errors = map[string]int64{}
+		}
+		c.Errors[code] += n
+	}
+}
+
+// Usage is the aggregated tool calls of an identity.
+type Usage struct {
+	Identity string
+	Counts
+	Tools map[string]Counts
+}
+
+// Filter selects the calls to aggregate. Zero values match everything.
+type Filter struct {
+	Identity string
+	Tool     string
+	Since    time.Time
+	Until    time.Time
+}
+
+type bucketKey struct {
+	start    time.Time
+	identity string
+	tool     string
+}
+
+// Ledger holds the tool calls of each identity in hourly buckets for a
+// retention period. It lives in memory: usage from before a restart is only
+// kept by the exported metrics. A Ledger is safe for concurrent use.
+type Ledger struct {
+	retention time.Duration
+	metrics   Metrics
+	now       func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*Counts
+	pruned  time.Time // start of the bucket buckets were last pruned in
+}
+
+// NewLedger creates a ledger keeping calls for retention and exporting them
+// to metrics, if not nil.
+func NewLedger(retention time.Duration, metrics Metrics) *Ledger {
+	return &Ledger{
+		retention: retention,
+		metrics:   metrics,
+		now:       time.Now,
+		buckets:   make(map[bucketKey]*Counts),
+	}
+}
+
+// Retention returns how long the ledger keeps calls.
+func (l *Ledger) Retention() time.Duration {
+	return l.retention
+}
+
+// RecordCall records a tool call of an identity. errorCode is empty for
+// calls that succeeded.
+func (l *Ledger) RecordCall(identity, tool, errorCode string) {
+	status := "success"
+	if errorCode != "" {
+		status = "error"
+	}
+	if l.metrics != nil {
+		l.metrics.IncIdentityToolCalls(identity, tool, status)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	counts := l.bucket(identity, tool)
+	counts.Calls++
+	if errorCode != "" {
+		counts.Failures++
+		if counts.Errors == nil {
+			counts.Errors = map[string]int64{}
+		}
+		counts.Errors[errorCode]++
+	}
+}
+
+// RecordMutation records a successful tool call of an identity that changed
+// a cluster. The call itself is recorded with RecordCall.
+func (l *Ledger) RecordMutation(identity, tool string) {
+	if l.metrics != nil {
+		l.metrics.IncIdentityClusterMutations(identity, tool)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bucket(identity, tool).Mutations++
+}
+
+// bucket returns the counts of the current bucket of an identity and tool,
+// pruning buckets past the retention period when a new bucket starts.
+func (l *Ledger) bucket(identity, tool string) *Counts {
+	start := l.now().Truncate(BucketSize)
+	if start.After(l.pruned) {
+		for key := range l.buckets {
+			if l.expired(key, start) {
+				delete(l.buckets, key)
+			}
+		}
+		l.pruned = start
+	}
+
+	key := bucketKey{start: start, identity: identity, tool: tool}
+	counts, ok := l.buckets[key]
+	if !ok {
+		counts = &Counts{}
+		l.buckets[key] = counts
+	}
+	return counts
+}
+
+// expired returns whether a bucket ended before the retention period of the
+// bucket starting at start.
+func (l *Ledger) expired(key bucketKey, start time.Time) bool {
+	return !key.start.Add(BucketSize).After(start.Add(-l.retention))
+}
+
+// Usage returns the calls matching the filter per identity, the identity
+// with the most calls first. Buckets overlapping the time range are counted
+// whole.
+func (l *Ledger) Usage(filter Filter) []Usage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := l.now().Truncate(BucketSize)
+	usage := map[string]*Usage{}
+	for key, counts := range l.buckets {
+		if l.expired(key, start) ||
+			(filter.Identity != "" && key.identity != filter.Identity) ||
+			(filter.Tool != "" && key.tool != filter.Tool) ||
+			(!filter.Since.IsZero() && !key.start.Add(BucketSize).After(filter.Since)) ||
+			(!filter.Until.IsZero() && key.start.After(filter.Until)) {
+			continue
+		}
+		identity, ok := usage[key.identity]
+		if !ok {
+			identity = &Usage{Identity: key.identity, Tools: map[string]Counts{}}
+			usage[key.identity] = identity
+		}
+		identity.add(counts)
+		tool := identity.Tools[key.tool]
+		tool.add(counts)
+		identity.Tools[key.tool] = tool
+	}
+
+	result := make([]Usage, 0, len(usage))
+	for _, identity := range usage {
+		result = append(result, *identity)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Calls != result[j].Calls {
+			return result[i].Calls > result[j].Calls
+		}
+		return result[i].Identity < result[j].Identity
+	})
+	return result
+}
@@ -0,0 +1,120 @@
+package accounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedMetrics struct {
+	calls     map[string]int
+	mutations map[string]int
+}
+
+func (m *recordedMetrics) IncIdentityToolCalls(identity, tool, status string) {
+	m.calls[identity+"/"+tool+"/"+status]++
+}
+
+func (m *recordedMetrics) IncIdentityClusterMutations(identity, tool string) {
+	m.mutations[identity+"/"+tool]++
+}
+
+func TestLedger_Usage(t *testing.T) {
+	metrics := &recordedMetrics{calls: map[string]int{}, mutations: map[string]int{}}
+	ledger := NewLedger(24*time.Hour, metrics)
+	now := time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)
+	ledger.now = func() time.Time { return now }
+
+	ledger.RecordCall("ci", "create_cluster", "")
+	ledger.RecordMutation("ci", "create_cluster")
+	ledger.RecordCall("ci", "get_cluster", "")
+	ledger.RecordCall("ci", "get_cluster", "NOT_FOUND")
+	now = now.Add(2 * time.Hour)
+	ledger.RecordCall("ops", "delete_cluster", "FORBIDDEN")
+	ledger.RecordCall("ops", "delete_cluster", "FORBIDDEN")
+	ledger.RecordCall("ops", "delete_cluster", "FORBIDDEN")
+
+	assert.Equal(t, map[string]int{
+		"ci/create_cluster/success": 1,
+		"ci/get_cluster/success":    1,
+		"ci/get_cluster/error":      1,
+		"ops/delete_cluster/error":  3,
+	}, metrics.calls)
+	assert.Equal(t, map[string]int{"ci/create_cluster": 1}, metrics.mutations)
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []Usage
+	}{
+		{
+			name: "all, ties by identity",
+			expected: []Usage{
+				{
+					Identity: "ci",
+					Counts:   Counts{Calls: 3, Failures: 1, Mutations: 1, Errors: map[string]int64{"NOT_FOUND": 1}},
+					Tools: map[string]Counts{
+						"create_cluster": {Calls: 1, Mutations: 1},
+						"get_cluster":    {Calls: 2, Failures: 1, Errors: map[string]int64{"NOT_FOUND": 1}},
+					},
+				},
+				{
+					Identity: "ops",
+					Counts:   Counts{Calls: 3, Failures: 3, Errors: map[string]int64{"FORBIDDEN": 3}},
+					Tools: map[string]Counts{
+						"delete_cluster": {Calls: 3, Failures: 3, Errors: map[string]int64{"FORBIDDEN": 3}},
+					},
+				},
+			},
+		},
+		{
+			name:   "identity and tool",
+			filter: Filter{Identity: "ci", Tool: "create_cluster"},
+			expected: []Usage{{
+				Identity: "ci",
+				Counts:   Counts{Calls: 1, Mutations: 1},
+				Tools:    map[string]Counts{"create_cluster": {Calls: 1, Mutations: 1}},
+			}},
+		},
+		{
+			name:   "since counts the overlapping bucket",
+			filter: Filter{Since: time.Date(2026, 3, 2, 12, 45, 0, 0, time.UTC)},
+			expected: []Usage{{
+				Identity: "ops",
+				Counts:   Counts{Calls: 3, Failures: 3, Errors: map[string]int64{"FORBIDDEN": 3}},
+				Tools: map[string]Counts{
+					"delete_cluster": {Calls: 3, Failures: 3, Errors: map[string]int64{"FORBIDDEN": 3}},
+				},
+			}},
+		},
+		{
+			name:     "until",
+			filter:   Filter{Until: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)},
+			expected: []Usage{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ledger.Usage(tt.filter))
+		})
+	}
+}
+
+func TestLedger_Retention(t *testing.T) {
+	ledger := NewLedger(3*time.Hour, nil)
+	now := time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)
+	ledger.now = func() time.Time { return now }
+
+	ledger.RecordCall("ci", "get_cluster", "")
+	now = now.Add(3 * time.Hour)
+	ledger.RecordCall("ci", "get_cluster", "")
+	require.Len(t, ledger.Usage(Filter{}), 1)
+	assert.Equal(t, int64(2), ledger.Usage(Filter{})[0].Calls)
+
+	now = now.Add(time.Hour)
+	ledger.RecordCall("ci", "get_cluster", "")
+	assert.Equal(t, int64(2), ledger.Usage(Filter{})[0].Calls)
+}
@@ -0,0 +1,341 @@
+// Package advisory reports the support status of Kubernetes releases: when
+// each minor release reaches its end of life and the critical CVEs fixed in
+// its patch releases. A dataset is bundled with the server and can be
+// refreshed from a URL, so new advisories are picked up without a release.
+package advisory
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Support statuses of a Kubernetes release.
+const (
+	StatusSupported     = "supported"
+	StatusEndOfLifeSoon = "end-of-life-soon"
+	StatusEndOfLife     = "end-of-life"
+	StatusUnknown       = "unknown" // the release is not in the dataset
+)
+
+// EndOfLifeWarning is how long before its end of life a release is reported
+// as ending soon.
+const EndOfLifeWarning = 90 * 24 * time.Hour
+
+//go:embed kubernetes.json
+var bundled []byte
+
+// Dataset lists the Kubernetes minor releases with their end of life and CVEs.
+type Dataset struct {
+	UpdatedAt string             `json:"updatedAt"` // YYYY-MM-DD
+	Releases  map[string]Release `json:"releases"`  // by minor release, e.g. "1.31"
+}
+
+// Release is the support information of a minor release.
+type Release struct {
+	EndOfLife string `json:"endOfLife"` // YYYY-MM-DD
+	CVEs      []CVE  `json:"cves"`
+}
+
+// CVE is a vulnerability affecting a minor release.
+type CVE struct {
+	ID       string `json:"id"`
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	FixedIn  string `json:"fixedIn"` // first patch release of the minor with the fix; empty if none
+}
+
+// Advisory is the support status of a Kubernetes version.
+type Advisory struct {
+	Version   string
+	Release   string // minor release of the version, e.g. "1.31"
+	EndOfLife string
+	Status    string
+	CVEs      []CVE // CVEs affecting the version
+
+	// UpgradeTo is the release to upgrade to: the oldest supported minor
+	// release once the version's release is at or near its end of life or
+	// has CVEs without a fix, otherwise the patch release fixing its CVEs.
+	// It is empty when no upgrade is needed.
+	UpgradeTo string
+}
+
+// Source fetches the latest dataset.
+type Source interface {
+	Fetch(ctx context.Context) (*Dataset, error)
+}
+
+// Advisor checks Kubernetes versions against the bundled dataset, or the
+// latest one fetched from a Source.
+type Advisor struct {
+	source    Source
+	cacheFile string
+
+	mu          sync.RWMutex
+	dataset     *Dataset
+	refreshedAt time.Time
+}
+
+// New creates an advisor serving the bundled dataset. A nil source keeps it
+// offline. If cacheFile is set, the last fetched dataset is loaded from it
+// unless it is older than the bundled one, and saved to it after each
+// refresh.
+func New(source Source, cacheFile string) *Advisor {
+	dataset, err := parseDataset(bundled)
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled advisory dataset: %v", err))
+	}
+	a := &Advisor{source: source, cacheFile: cacheFile, dataset: dataset}
+	if cacheFile != "" {
+		// A missing or unreadable cache only means starting from the bundled dataset
+		_ = a.loadCache()
+	}
+	return a
+}
+
+// Refresh fetches the latest dataset from the source.
+func (a *Advisor) Refresh(ctx context.Context) error {
+	if a.source == nil {
+		return nil
+	}
+
+	dataset, err := a.source.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch advisories: %w", err)
+	}
+	if err := dataset.validate(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.dataset = dataset
+	a.refreshedAt = time.Now()
+	a.mu.Unlock()
+
+	if a.cacheFile != "" {
+		return a.saveCache()
+	}
+	return nil
+}
+
+// Run refreshes the dataset every interval until ctx is cancelled, reporting
+// failed refreshes to onError.
+func (a *Advisor) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	if a.source == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.Refresh(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// UpdatedAt returns the date of the dataset being served.
+func (a *Advisor) UpdatedAt() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.dataset.UpdatedAt
+}
+
+// Check returns the advisory for a Kubernetes version such as "v1.31.2". It
+// returns false if the version cannot be parsed.
+func (a *Advisor) Check(kubernetesVersion string, now time.Time) (Advisory, bool) {
+	v, err := version.ParseGeneric(kubernetesVersion)
+	if err != nil {
+		return Advisory{}, false
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	advisory := Advisory{
+		Version: kubernetesVersion,
+		Release: minorRelease(v),
+		Status:  StatusUnknown,
+		CVEs:    []CVE{},
+	}
+	release, ok := a.dataset.Releases[advisory.Release]
+	if !ok {
+		return advisory, true
+	}
+	advisory.EndOfLife = release.EndOfLife
+	advisory.Status = releaseStatus(release, now)
+
+	unfixed := false
+	for _, cve := range release.CVEs {
+		if cve.FixedIn == "" {
+			unfixed = true
+			advisory.CVEs = append(advisory.CVEs, cve)
+			continue
+		}
+		fixed, err := version.ParseGeneric(cve.FixedIn)
+		if err != nil || !v.LessThan(fixed) {
+			continue
+		}
+		advisory.CVEs = append(advisory.CVEs, cve)
+		if upgradeTo, err := version.ParseGeneric(advisory.UpgradeTo); err != nil || upgradeTo.LessThan(fixed) {
+			advisory.UpgradeTo = cve.FixedIn
+		}
+	}
+
+	if unfixed || advisory.Status == StatusEndOfLife || advisory.Status == StatusEndOfLifeSoon {
+		advisory.UpgradeTo = a.oldestSupportedRelease(v, now)
+	}
+	return advisory, true
+}
+
+// oldestSupportedRelease returns the oldest supported minor release newer
+// than v, or an empty string if the dataset has none.
+func (a *Advisor) oldestSupportedRelease(v *version.Version, now time.Time) string {
+	var candidates []*version.Version
+	for name, release := range a.dataset.Releases {
+		candidate, err := version.ParseGeneric(name)
+		if err != nil || candidate.Major() != v.Major() || candidate.Minor() <= v.Minor() {
+			continue
+		}
+		if releaseStatus(release, now) == StatusSupported {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].LessThan(candidates[j]) })
+	return minorRelease(candidates[0])
+}
+
+func releaseStatus(release Release, now time.Time) string {
+	endOfLife, err := time.Parse(time.DateOnly, release.EndOfLife)
+	if err != nil {
+		return StatusUnknown
+	}
+	switch {
+	case !now.Before(endOfLife):
+		return StatusEndOfLife
+	case endOfLife.Sub(now) < EndOfLifeWarning:
+		return StatusEndOfLifeSoon
+	default:
+		return StatusSupported
+	}
+}
+
+// minorRelease returns the minor release of a version, e.g. "1.31".
+func minorRelease(v *version.Version) string {
+	return fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+}
+
+func parseDataset(data []byte) (*Dataset, error) {
+	var dataset Dataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return nil, fmt.Errorf("failed to parse advisories: %w", err)
+	}
+	if err := dataset.validate(); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+// validate rejects datasets that would silently hide advisories: those
+// without releases or with dates that cannot be parsed.
+func (d *Dataset) validate() error {
+	if len(d.Releases) == 0 {
+		return fmt.Errorf("advisory dataset has no releases")
+	}
+	for name, release := range d.Releases {
+		if _, err := time.Parse(time.DateOnly, release.EndOfLife); err != nil {
+			return fmt.Errorf("release %s has an invalid end of life %q", name, release.EndOfLife)
+		}
+	}
+	return nil
+}
+
+func (a *Advisor) loadCache() error {
+	data, err := os.ReadFile(a.cacheFile)
+	if err != nil {
+		return err
+	}
+	dataset, err := parseDataset(data)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// A server upgrade may bundle a newer dataset than the one last fetched
+	if dataset.UpdatedAt < a.dataset.UpdatedAt {
+		return nil
+	}
+	a.dataset = dataset
+	return nil
+}
+
+func (a *Advisor) saveCache() error {
+	a.mu.RLock()
+	data, err := json.Marshal(a.dataset)
+	a.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode advisory cache: %w", err)
+	}
+	// Write to a temporary file first so a crash never leaves a partial cache
+	tmp := a.cacheFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write advisory cache: %w", err)
+	}
+	if err := os.Rename(tmp, a.cacheFile); err != nil {
+		return fmt.Errorf("failed to write advisory cache: %w", err)
+	}
+	return nil
+}
+
+// HTTPSource fetches the dataset as JSON from a URL, in the format of the
+// bundled kubernetes.json.
+type HTTPSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSource creates a source fetching the dataset from url.
+func NewHTTPSource(url string) *HTTPSource {
+	return &HTTPSource{url: url, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Fetch downloads and parses the dataset.
+func (s *HTTPSource) Fetch(ctx context.Context) (*Dataset, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", s.url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, err
+	}
+	return parseDataset(data)
+}
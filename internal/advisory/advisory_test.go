@@ -0,0 +1,161 @@
+package advisory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	dataset *Dataset
+	err     error
+}
+
+func (f *fakeSource) Fetch(ctx context.Context) (*Dataset, error) {
+	return f.dataset, f.err
+}
+
+func testDataset(updatedAt string) *Dataset {
+	return &Dataset{
+		UpdatedAt: updatedAt,
+		Releases: map[string]Release{
+			"1.29": {EndOfLife: "2025-02-28", CVEs: []CVE{{ID: "CVE-2024-0001", Severity: "high", FixedIn: ""}}},
+			"1.30": {EndOfLife: "2025-06-28", CVEs: []CVE{
+				{ID: "CVE-2024-0002", Severity: "high", FixedIn: "1.30.3"},
+				{ID: "CVE-2024-0003", Severity: "critical", FixedIn: "1.30.5"},
+			}},
+			"1.31": {EndOfLife: "2025-10-28"},
+			"1.32": {EndOfLife: "2026-02-28"},
+		},
+	}
+}
+
+func TestAdvisor_Check(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC)
+	advisor := New(&fakeSource{dataset: testDataset("2025-04-01")}, "")
+	require.NoError(t, advisor.Refresh(context.Background()))
+
+	tests := []struct {
+		name      string
+		version   string
+		status    string
+		cves      []string
+		upgradeTo string
+	}{
+		{name: "supported", version: "v1.31.2", status: StatusSupported},
+		{name: "patch release fixes some CVEs", version: "v1.30.4", status: StatusEndOfLifeSoon, cves: []string{"CVE-2024-0003"}, upgradeTo: "1.31"},
+		{name: "end of life with unfixed CVE", version: "v1.29.9", status: StatusEndOfLife, cves: []string{"CVE-2024-0001"}, upgradeTo: "1.31"},
+		{name: "release not in the dataset", version: "1.36.0", status: StatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advice, ok := advisor.Check(tt.version, now)
+			require.True(t, ok)
+
+			assert.Equal(t, tt.status, advice.Status)
+			var cves []string
+			for _, cve := range advice.CVEs {
+				cves = append(cves, cve.ID)
+			}
+			assert.Equal(t, tt.cves, cves)
+			assert.Equal(t, tt.upgradeTo, advice.UpgradeTo)
+		})
+	}
+
+	t.Run("upgrade to the patch release fixing every CVE", func(t *testing.T) {
+		advice, ok := advisor.Check("v1.30.2", now.AddDate(-1, 0, 0))
+		require.True(t, ok)
+		assert.Equal(t, StatusSupported, advice.Status)
+		assert.Len(t, advice.CVEs, 2)
+		assert.Equal(t, "1.30.5", advice.UpgradeTo)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		_, ok := advisor.Check("", now)
+		assert.False(t, ok)
+	})
+}
+
+func TestAdvisor_Bundled(t *testing.T) {
+	advisor := New(nil, "")
+	require.NoError(t, advisor.Refresh(context.Background()))
+
+	advice, ok := advisor.Check("v1.30.2", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.Equal(t, "2025-06-28", advice.EndOfLife)
+	assert.Equal(t, "1.30.3", advice.UpgradeTo)
+}
+
+func TestAdvisor_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves to and loads from the cache", func(t *testing.T) {
+		cacheFile := filepath.Join(t.TempDir(), "advisories.json")
+		advisor := New(&fakeSource{dataset: testDataset("2099-01-01")}, cacheFile)
+		require.NoError(t, advisor.Refresh(ctx))
+
+		restarted := New(nil, cacheFile)
+		assert.Equal(t, "2099-01-01", restarted.UpdatedAt())
+	})
+
+	t.Run("cache older than the bundled dataset is ignored", func(t *testing.T) {
+		cacheFile := filepath.Join(t.TempDir(), "advisories.json")
+		data, err := json.Marshal(testDataset("2000-01-01"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(cacheFile, data, 0o644))
+
+		advisor := New(nil, cacheFile)
+		assert.NotEqual(t, "2000-01-01", advisor.UpdatedAt())
+	})
+
+	t.Run("failed refresh keeps the dataset", func(t *testing.T) {
+		advisor := New(&fakeSource{err: errors.New("unreachable")}, "")
+		updatedAt := advisor.UpdatedAt()
+
+		assert.Error(t, advisor.Refresh(ctx))
+		assert.Equal(t, updatedAt, advisor.UpdatedAt())
+	})
+
+	t.Run("invalid dataset is rejected", func(t *testing.T) {
+		advisor := New(&fakeSource{dataset: &Dataset{UpdatedAt: "2099-01-01"}}, "")
+
+		assert.Error(t, advisor.Refresh(ctx))
+		assert.NotEqual(t, "2099-01-01", advisor.UpdatedAt())
+	})
+}
+
+func TestHTTPSource_Fetch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid dataset", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(testDataset("2025-04-01"))
+		}))
+		defer server.Close()
+
+		dataset, err := NewHTTPSource(server.URL).Fetch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "2025-04-01", dataset.UpdatedAt)
+		assert.Len(t, dataset.Releases, 4)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPSource(server.URL).Fetch(ctx)
+		assert.Error(t, err)
+	})
+}
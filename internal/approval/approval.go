@@ -0,0 +1,91 @@
+// Package approval forwards the approval requests of high-risk operations to
+// the tools approvers already work in, Slack and PagerDuty, and learns from
+// there when a request is approved.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Request is the pending approval of a high-risk operation.
+type Request struct {
+	ID          string `json:"id"`
+	Tool        string `json:"tool"`
+	Namespace   string `json:"namespace,omitempty"`
+	ClusterName string `json:"cluster_name"`
+	RequestedBy string `json:"requested_by"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+// summary describes the operation a request is for.
+func (r Request) summary() string {
+	cluster := r.ClusterName
+	if r.Namespace != "" {
+		cluster = r.Namespace + "/" + r.ClusterName
+	}
+	return fmt.Sprintf("%s requests approval of %s on cluster %s", r.RequestedBy, r.Tool, cluster)
+}
+
+// Notifier forwards approval requests to approvers.
+type Notifier interface {
+	Notify(ctx context.Context, request Request) error
+}
+
+// Poller asks an integration which forwarded requests were approved there.
+type Poller interface {
+	// Approved returns the approvers of the requests approved since they
+	// were forwarded, by request ID.
+	Approved(ctx context.Context, requests []Request) (map[string]string, error)
+}
+
+// Notifiers forwards approval requests to each of several notifiers.
+type Notifiers []Notifier
+
+// Notify forwards a request to every notifier, even if some fail.
+func (n Notifiers) Notify(ctx context.Context, request Request) error {
+	var errs []error
+	for _, notifier := range n {
+		if err := notifier.Notify(ctx, request); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postJSON posts a JSON document and fails unless the response status is
+// one of the accepted ones.
+func postJSON(ctx context.Context, client *http.Client, url string, document interface{}, header http.Header, accepted ...int) error {
+	body, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	for _, status := range accepted {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s returned %s: %s", req.URL.Host, resp.Status, bytes.TrimSpace(data))
+}
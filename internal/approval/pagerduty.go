@@ -0,0 +1,132 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+	// PagerDutyAPIURL is the PagerDuty REST API.
+	PagerDutyAPIURL = "https://api.pagerduty.com"
+
+	// pagerDutyDedupKeyPrefix prefixes the incident key of the incident of
+	// an approval request with the approval ID.
+	pagerDutyDedupKeyPrefix = "capi-mcp-approval-"
+)
+
+// PagerDutyNotifier creates a PagerDuty incident for each approval request
+// through the Events API. The responder approves a request by resolving its
+// incident, which the notifier learns by polling the REST API.
+type PagerDutyNotifier struct {
+	routingKey string
+	apiToken   string
+	eventsURL  string
+	apiURL     string
+	client     *http.Client
+}
+
+// NewPagerDutyNotifier creates a notifier triggering incidents with the
+// routing key of a PagerDuty Events API v2 integration. Without an API
+// token, incidents are only created and never approve requests.
+func NewPagerDutyNotifier(routingKey, apiToken string, timeout time.Duration) *PagerDutyNotifier {
+	return &PagerDutyNotifier{
+		routingKey: routingKey,
+		apiToken:   apiToken,
+		eventsURL:  PagerDutyEventsURL,
+		apiURL:     PagerDutyAPIURL,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify triggers an incident for an approval request.
+func (n *PagerDutyNotifier) Notify(ctx context.Context, request Request) error {
+	event := map[string]interface{}{
+		"routing_key":  n.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    pagerDutyDedupKeyPrefix + request.ID,
+		"payload": map[string]interface{}{
+			"summary":        request.summary() + "; resolve this incident to approve it",
+			"source":         "capi-mcp-server",
+			"severity":       "warning",
+			"component":      request.ClusterName,
+			"custom_details": request,
+		},
+	}
+	if err := postJSON(ctx, n.client, n.eventsURL, event, nil, http.StatusAccepted); err != nil {
+		return fmt.Errorf("failed to create PagerDuty incident: %w", err)
+	}
+	return nil
+}
+
+// Approved returns the requests whose incidents were resolved, with the
+// PagerDuty user who resolved each as pagerduty:<name>.
+func (n *PagerDutyNotifier) Approved(ctx context.Context, requests []Request) (map[string]string, error) {
+	approved := make(map[string]string)
+	if n.apiToken == "" {
+		return approved, nil
+	}
+
+	for _, request := range requests {
+		resolver, resolved, err := n.resolvedBy(ctx, pagerDutyDedupKeyPrefix+request.ID)
+		if err != nil {
+			return approved, err
+		}
+		if resolved {
+			approved[request.ID] = "pagerduty:" + resolver
+		}
+	}
+	return approved, nil
+}
+
+// resolvedBy looks up whether the incident with an incident key is resolved
+// and by whom.
+func (n *PagerDutyNotifier) resolvedBy(ctx context.Context, incidentKey string) (string, bool, error) {
+	query := url.Values{"incident_key": {incidentKey}, "statuses[]": {"resolved"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.apiURL+"/incidents?"+query.Encode(), nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create PagerDuty request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+n.apiToken)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list PagerDuty incidents: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read PagerDuty incidents: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("PagerDuty returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	var response struct {
+		Incidents []struct {
+			Status             string `json:"status"`
+			LastStatusChangeBy struct {
+				Summary string `json:"summary"`
+			} `json:"last_status_change_by"`
+		} `json:"incidents"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", false, fmt.Errorf("failed to parse PagerDuty incidents: %w", err)
+	}
+	for _, incident := range response.Incidents {
+		if incident.Status == "resolved" {
+			return incident.LastStatusChangeBy.Summary, true, nil
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,66 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagerDutyNotifier_Notify(t *testing.T) {
+	var event map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewPagerDutyNotifier("routing-key", "", time.Second)
+	notifier.eventsURL = server.URL
+	require.NoError(t, notifier.Notify(context.Background(), testRequest))
+	assert.Equal(t, "routing-key", event["routing_key"])
+	assert.Equal(t, "trigger", event["event_action"])
+	assert.Equal(t, "capi-mcp-approval-abc12345", event["dedup_key"])
+	assert.Contains(t, event["payload"].(map[string]interface{})["summary"], "resolve this incident to approve it")
+}
+
+func TestPagerDutyNotifier_Approved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Token token=api-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "resolved", r.URL.Query().Get("statuses[]"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("incident_key") {
+		case "capi-mcp-approval-abc12345":
+			_, _ = w.Write([]byte(`{"incidents": [{"status": "resolved", "last_status_change_by": {"summary": "Bob Jones"}}]}`))
+		case "capi-mcp-approval-failing":
+			http.Error(w, `{"error": {"message": "Unauthorized"}}`, http.StatusUnauthorized)
+		default:
+			_, _ = w.Write([]byte(`{"incidents": []}`))
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewPagerDutyNotifier("routing-key", "api-token", time.Second)
+	notifier.apiURL = server.URL
+	ctx := context.Background()
+
+	approved, err := notifier.Approved(ctx, []Request{testRequest, {ID: "def67890"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"abc12345": "pagerduty:Bob Jones"}, approved)
+
+	_, err = notifier.Approved(ctx, []Request{{ID: "failing"}})
+	assert.ErrorContains(t, err, "401")
+
+	t.Run("without an API token", func(t *testing.T) {
+		notifier := NewPagerDutyNotifier("routing-key", "", time.Second)
+		notifier.apiURL = server.URL
+		approved, err := notifier.Approved(ctx, []Request{testRequest})
+		require.NoError(t, err)
+		assert.Empty(t, approved)
+	})
+}
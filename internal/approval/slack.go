@@ -0,0 +1,184 @@
+package approval
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// slackApproveAction is the action ID of the Approve button of approval
+// request messages.
+const slackApproveAction = "approve_operation"
+
+// slackMaxClockSkew is how old a Slack request may be, limiting the replay
+// of captured requests.
+const slackMaxClockSkew = 5 * time.Minute
+
+// SlackNotifier posts approval requests with an Approve button to a Slack
+// channel through an incoming webhook of a Slack app. Clicks on the button
+// are handled by a SlackCallbackHandler at the app's interactivity request
+// URL.
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a notifier posting to a Slack incoming webhook
+// URL.
+func NewSlackNotifier(webhookURL string, timeout time.Duration) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts an approval request to the channel.
+func (n *SlackNotifier) Notify(ctx context.Context, request Request) error {
+	details := fmt.Sprintf("*Approval ID:* `%s`\n*Expires:* %s\nApprove here or with `approve_operation`; %s then calls %s again with the approval ID.",
+		request.ID, request.ExpiresAt, request.RequestedBy, request.Tool)
+	message := map[string]interface{}{
+		"text": request.summary(),
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]string{"type": "mrkdwn", "text": "*" + request.summary() + "*\n" + details},
+			},
+			map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					map[string]interface{}{
+						"type":      "button",
+						"action_id": slackApproveAction,
+						"style":     "danger",
+						"text":      map[string]string{"type": "plain_text", "text": "Approve"},
+						"value":     request.ID,
+					},
+				},
+			},
+		},
+	}
+	if err := postJSON(ctx, n.client, n.webhookURL, message, nil, http.StatusOK); err != nil {
+		return fmt.Errorf("failed to post approval request to Slack: %w", err)
+	}
+	return nil
+}
+
+// SlackCallbackHandler handles the interactivity requests Slack sends when
+// the Approve button of an approval request is clicked. Requests are
+// authenticated with the signing secret of the Slack app, and the request is
+// approved on behalf of the Slack user as slack:<username>.
+type SlackCallbackHandler struct {
+	signingSecret string
+	approve       func(ctx context.Context, id, approver string) error
+	client        *http.Client
+	now           func() time.Time
+}
+
+// NewSlackCallbackHandler creates a handler approving requests with approve.
+func NewSlackCallbackHandler(signingSecret string, approve func(ctx context.Context, id, approver string) error) *SlackCallbackHandler {
+	return &SlackCallbackHandler{
+		signingSecret: signingSecret,
+		approve:       approve,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		now:           time.Now,
+	}
+}
+
+// slackInteraction is the part of a Slack block_actions payload the handler
+// reads.
+type slackInteraction struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	ResponseURL string `json:"response_url"`
+}
+
+func (h *SlackCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	if err := h.verify(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	var interaction slackInteraction
+	if err := json.Unmarshal([]byte(form.Get("payload")), &interaction); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	approver := interaction.User.Username
+	if approver == "" {
+		approver = interaction.User.ID
+	}
+	for _, action := range interaction.Actions {
+		if interaction.Type != "block_actions" || action.ActionID != slackApproveAction {
+			continue
+		}
+		text := fmt.Sprintf("Approval `%s` approved by %s", action.Value, approver)
+		if err := h.approve(r.Context(), action.Value, "slack:"+approver); err != nil {
+			text = fmt.Sprintf("Approval `%s` could not be approved by %s: %s", action.Value, approver, err)
+		}
+		h.respond(r.Context(), interaction.ResponseURL, text)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks the Slack request signature, an HMAC-SHA256 of the version,
+// timestamp and body keyed with the signing secret.
+func (h *SlackCallbackHandler) verify(header http.Header, body []byte) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("missing request timestamp")
+	}
+	if age := h.now().Sub(time.Unix(seconds, 0)); age > slackMaxClockSkew || age < -slackMaxClockSkew {
+		return fmt.Errorf("request timestamp is too far from the current time")
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.signingSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("invalid request signature")
+	}
+	return nil
+}
+
+// respond replaces the approval request message with the outcome of the
+// click. Failures are ignored; the approval itself is what matters.
+func (h *SlackCallbackHandler) respond(ctx context.Context, responseURL, text string) {
+	if responseURL == "" {
+		return
+	}
+	_ = postJSON(ctx, h.client, responseURL, map[string]interface{}{
+		"replace_original": true,
+		"text":             text,
+	}, nil, http.StatusOK)
+}
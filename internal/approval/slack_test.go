@@ -0,0 +1,121 @@
+package approval
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRequest = Request{
+	ID:          "abc12345",
+	Tool:        "delete_cluster",
+	Namespace:   "team-a",
+	ClusterName: "prod-a",
+	RequestedBy: "alice",
+	ExpiresAt:   "2026-03-02T11:00:00Z",
+}
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var message map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&message))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	require.NoError(t, NewSlackNotifier(server.URL, time.Second).Notify(context.Background(), testRequest))
+	assert.Equal(t, "alice requests approval of delete_cluster on cluster team-a/prod-a", message["text"])
+	blocks := message["blocks"].([]interface{})
+	button := blocks[1].(map[string]interface{})["elements"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, slackApproveAction, button["action_id"])
+	assert.Equal(t, "abc12345", button["value"])
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	err := NewSlackNotifier(failing.URL, time.Second).Notify(context.Background(), testRequest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid_token")
+}
+
+func TestSlackCallbackHandler(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	var responses []string
+	responseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		responses = append(responses, string(body))
+	}))
+	defer responseServer.Close()
+
+	var approved []string
+	handler := NewSlackCallbackHandler("signing-secret", func(ctx context.Context, id, approver string) error {
+		if id != "abc12345" {
+			return fmt.Errorf("approval '%s' not found", id)
+		}
+		approved = append(approved, id+" "+approver)
+		return nil
+	})
+	handler.now = func() time.Time { return now }
+
+	newRequest := func(approvalID, secret string, sentAt time.Time) *http.Request {
+		payload := fmt.Sprintf(`{"type":"block_actions","user":{"id":"U1","username":"bob"},"actions":[{"action_id":%q,"value":%q}],"response_url":%q}`,
+			slackApproveAction, approvalID, responseServer.URL)
+		body := url.Values{"payload": {payload}}.Encode()
+		timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+
+		req := httptest.NewRequest(http.MethodPost, "/approvals/slack", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+		req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+		return req
+	}
+
+	tests := []struct {
+		name         string
+		req          *http.Request
+		wantStatus   int
+		wantApproved []string
+		wantResponse string
+	}{
+		{name: "approved", req: newRequest("abc12345", "signing-secret", now), wantStatus: http.StatusOK,
+			wantApproved: []string{"abc12345 slack:bob"}, wantResponse: "approved by bob"},
+		{name: "unknown approval", req: newRequest("unknown", "signing-secret", now), wantStatus: http.StatusOK,
+			wantResponse: "could not be approved by bob"},
+		{name: "wrong secret", req: newRequest("abc12345", "guessed", now), wantStatus: http.StatusUnauthorized},
+		{name: "replayed", req: newRequest("abc12345", "signing-secret", now.Add(-time.Hour)), wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approved, responses = nil, nil
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, tt.req)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, tt.wantApproved, approved)
+			if tt.wantResponse != "" {
+				require.Len(t, responses, 1)
+				assert.Contains(t, responses[0], tt.wantResponse)
+			} else {
+				assert.Empty(t, responses)
+			}
+		})
+	}
+}
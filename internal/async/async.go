@@ -0,0 +1,252 @@
+// Package async persists the progress of long-running operations that outlive
+// the tool call starting them, such as cluster restores, so callers can poll
+// their stages.
+package async
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// States of operations and their stages.
+const (
+	StatePending   = "pending"
+	StateRunning   = "running"
+	StateSucceeded = "succeeded"
+	StateFailed    = "failed"
+	StateSkipped   = "skipped"
+)
+
+const (
+	// operationLabel marks ConfigMaps holding async operations.
+	operationLabel = "capi-mcp.io/async-operation"
+
+	// idLabel records the ID of the operation a ConfigMap holds.
+	idLabel = "capi-mcp.io/async-operation-id"
+
+	// kindLabel records the kind of operation, e.g. restore-cluster.
+	kindLabel = "capi-mcp.io/async-operation-kind"
+
+	// clusterLabel records the cluster an operation targets.
+	clusterLabel = "capi-mcp.io/cluster"
+
+	// operationKey is the ConfigMap data key holding the JSON-encoded operation.
+	operationKey = "operation"
+)
+
+// Operation is a long-running operation carried out in stages.
+type Operation struct {
+	ID          string            `json:"id"`
+	Kind        string            `json:"kind"`
+	ClusterName string            `json:"clusterName,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	State       string            `json:"state"`
+	Stages      []Stage           `json:"stages"`
+	Error       string            `json:"error,omitempty"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// Stage is a step of an operation.
+type Stage struct {
+	Name        string    `json:"name"`
+	State       string    `json:"state"`
+	Message     string    `json:"message,omitempty"`
+	StartedAt   time.Time `json:"startedAt,omitzero"`
+	CompletedAt time.Time `json:"completedAt,omitzero"`
+}
+
+// Done reports whether an operation has finished, successfully or not.
+func (op Operation) Done() bool {
+	return op.State == StateSucceeded || op.State == StateFailed
+}
+
+// Filter selects operations to list. Zero values match everything.
+type Filter struct {
+	Kind        string
+	ClusterName string
+	Namespace   string
+}
+
+// Store persists async operations.
+type Store interface {
+	// Save persists an operation, replacing any earlier state of it and
+	// assigning an ID if it has none.
+	Save(ctx context.Context, op Operation) (Operation, error)
+
+	// Get returns an operation by ID.
+	Get(ctx context.Context, id string) (Operation, bool, error)
+
+	// List returns the operations matching the filter, newest first.
+	List(ctx context.Context, filter Filter) ([]Operation, error)
+}
+
+// ConfigMapStore stores each operation as a labelled ConfigMap in a single
+// namespace so the progress of operations survives server restarts.
+type ConfigMapStore struct {
+	kubeClient *kube.Client
+	namespace  string
+	maxEntries int
+}
+
+// NewConfigMapStore creates a store in the given namespace that keeps at most
+// maxEntries operations, pruning the oldest finished ones. Zero keeps all.
+func NewConfigMapStore(kubeClient *kube.Client, namespace string, maxEntries int) *ConfigMapStore {
+	return &ConfigMapStore{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		maxEntries: maxEntries,
+	}
+}
+
+// Save persists an operation.
+func (s *ConfigMapStore) Save(ctx context.Context, op Operation) (Operation, error) {
+	if op.ID == "" {
+		op.ID = uuid.New().String()
+	}
+	if op.CreatedAt.IsZero() {
+		op.CreatedAt = time.Now().UTC()
+	}
+	op.UpdatedAt = time.Now().UTC()
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return op, fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	_, sources, err := s.list(ctx, map[string]string{operationLabel: "true", idLabel: op.ID})
+	if err != nil {
+		return op, err
+	}
+	if len(sources) > 0 {
+		sources[0].Data = map[string]string{operationKey: string(data)}
+		if err := s.kubeClient.UpdateObject(ctx, sources[0]); err != nil {
+			return op, fmt.Errorf("failed to update operation: %w", err)
+		}
+		return op, nil
+	}
+
+	labels := map[string]string{
+		operationLabel: "true",
+		idLabel:        op.ID,
+		kindLabel:      strings.ReplaceAll(op.Kind, "_", "-"),
+	}
+	if op.ClusterName != "" {
+		labels[clusterLabel] = op.ClusterName
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capi-mcp-async-" + op.ID,
+			Namespace: s.namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{operationKey: string(data)},
+	}
+	if err := s.kubeClient.CreateObject(ctx, configMap); err != nil {
+		return op, fmt.Errorf("failed to save operation: %w", err)
+	}
+
+	return op, s.prune(ctx)
+}
+
+// Get returns an operation by ID.
+func (s *ConfigMapStore) Get(ctx context.Context, id string) (Operation, bool, error) {
+	operations, _, err := s.list(ctx, map[string]string{operationLabel: "true", idLabel: id})
+	if err != nil || len(operations) == 0 {
+		return Operation{}, false, err
+	}
+	return operations[0], true, nil
+}
+
+// List returns the operations matching the filter, newest first.
+func (s *ConfigMapStore) List(ctx context.Context, filter Filter) ([]Operation, error) {
+	labels := map[string]string{operationLabel: "true"}
+	if filter.Kind != "" {
+		labels[kindLabel] = strings.ReplaceAll(filter.Kind, "_", "-")
+	}
+	if filter.ClusterName != "" {
+		labels[clusterLabel] = filter.ClusterName
+	}
+
+	operations, _, err := s.list(ctx, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]Operation, 0, len(operations))
+	for _, op := range operations {
+		if filter.Namespace == "" || op.Namespace == filter.Namespace {
+			matched = append(matched, op)
+		}
+	}
+	return matched, nil
+}
+
+// list returns decoded operations and their ConfigMaps, newest first.
+// ConfigMaps that cannot be decoded are skipped.
+func (s *ConfigMapStore) list(ctx context.Context, labels map[string]string) ([]Operation, []*corev1.ConfigMap, error) {
+	configMaps, err := s.kubeClient.ListConfigMaps(ctx, s.namespace, labels)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+
+	entries := make([]entry, 0, len(configMaps.Items))
+	for i := range configMaps.Items {
+		var op Operation
+		if err := json.Unmarshal([]byte(configMaps.Items[i].Data[operationKey]), &op); err != nil {
+			continue
+		}
+		entries = append(entries, entry{op, &configMaps.Items[i]})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].operation.CreatedAt.After(entries[j].operation.CreatedAt)
+	})
+
+	operations := make([]Operation, len(entries))
+	sources := make([]*corev1.ConfigMap, len(entries))
+	for i, e := range entries {
+		operations[i] = e.operation
+		sources[i] = e.source
+	}
+	return operations, sources, nil
+}
+
+// prune deletes the oldest finished operations beyond maxEntries. Operations
+// still in progress are never pruned.
+func (s *ConfigMapStore) prune(ctx context.Context) error {
+	if s.maxEntries <= 0 {
+		return nil
+	}
+
+	operations, sources, err := s.list(ctx, map[string]string{operationLabel: "true"})
+	if err != nil {
+		return err
+	}
+	for i := s.maxEntries; i < len(sources); i++ {
+		if !operations[i].Done() {
+			continue
+		}
+		if err := s.kubeClient.DeleteObject(ctx, sources[i]); err != nil {
+			return fmt.Errorf("failed to prune operations: %w", err)
+		}
+	}
+	return nil
+}
+
+// entry pairs a decoded operation with the ConfigMap it was read from.
+type entry struct {
+	operation Operation
+	source    *corev1.ConfigMap
+}
@@ -0,0 +1,72 @@
+package async
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := NewConfigMapStore(kube.NewClientFromClient(fakeClient, "default"), "capi-system", 2)
+
+	running, err := store.Save(ctx, Operation{
+		Kind:        "restore_cluster",
+		ClusterName: "alpha",
+		Namespace:   "team-a",
+		State:       StateRunning,
+		Stages:      []Stage{{Name: "provision", State: StateRunning}},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, running.ID)
+	assert.False(t, running.CreatedAt.IsZero())
+
+	t.Run("updates an operation in place", func(t *testing.T) {
+		running.Stages[0].State = StateSucceeded
+		running.State = StateSucceeded
+		_, err := store.Save(ctx, running)
+		require.NoError(t, err)
+
+		got, found, err := store.Get(ctx, running.ID)
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, StateSucceeded, got.State)
+		assert.Equal(t, StateSucceeded, got.Stages[0].State)
+		assert.True(t, running.CreatedAt.Equal(got.CreatedAt))
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		_, found, err := store.Get(ctx, "missing")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("filters and prunes finished operations", func(t *testing.T) {
+		pending, err := store.Save(ctx, Operation{Kind: "restore_cluster", ClusterName: "beta", Namespace: "team-b", State: StatePending})
+		require.NoError(t, err)
+		_, err = store.Save(ctx, Operation{Kind: "restore_cluster", ClusterName: "gamma", Namespace: "team-a", State: StateFailed})
+		require.NoError(t, err)
+
+		operations, err := store.List(ctx, Filter{Kind: "restore_cluster"})
+		require.NoError(t, err)
+		require.Len(t, operations, 2)
+		assert.Equal(t, "gamma", operations[0].ClusterName)
+		assert.Equal(t, pending.ID, operations[1].ID)
+
+		operations, err = store.List(ctx, Filter{Namespace: "team-b"})
+		require.NoError(t, err)
+		require.Len(t, operations, 1)
+		assert.Equal(t, "beta", operations[0].ClusterName)
+	})
+}
@@ -0,0 +1,162 @@
+// Package auth maps API keys to identities and decides which namespaces an
+// identity may operate in.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/config"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// AdminIdentityName is the identity authenticated by the server API_KEY.
+const AdminIdentityName = "admin"
+
+// ToolAdminIdentityName is the identity authenticated by ADMIN_API_KEY.
+const ToolAdminIdentityName = "tool-admin"
+
+// Identity is an authenticated caller.
+type Identity struct {
+	Name   string
+	Groups []string
+
+	// DefaultNamespace is used when a request does not name a namespace.
+	DefaultNamespace string
+
+	// Namespaces lists the namespaces the identity may access. The admin
+	// identity has no restriction and leaves this empty.
+	Namespaces []string
+
+	unrestricted bool
+	toolAdmin    bool
+}
+
+// Unrestricted reports whether the identity may access every namespace.
+func (i *Identity) Unrestricted() bool {
+	return i.unrestricted
+}
+
+// ToolAdmin reports whether the identity may disable and re-enable tools. The
+// tool admin has no access to clusters.
+func (i *Identity) ToolAdmin() bool {
+	return i.toolAdmin
+}
+
+// ResolveNamespace returns the namespace a request should operate in, using
+// the identity default when none is requested. Namespaces outside the
+// identity's mapping are denied.
+func (i *Identity) ResolveNamespace(requested string) (string, error) {
+	if requested == "" {
+		return i.DefaultNamespace, nil
+	}
+	if i.Unrestricted() {
+		return requested, nil
+	}
+	for _, namespace := range i.Namespaces {
+		if namespace == requested {
+			return requested, nil
+		}
+	}
+	return "", errors.New(errors.CodeForbidden,
+		fmt.Sprintf("identity '%s' is not permitted to access namespace '%s'", i.Name, requested))
+}
+
+// identityKey is the context key for the identity making a request.
+type identityKey struct{}
+
+// ContextWithIdentity returns a context carrying the identity making a
+// request.
+func ContextWithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the identity set with ContextWithIdentity, or
+// nil if there is none.
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
+
+// Authenticator resolves API keys to identities.
+type Authenticator struct {
+	keys       [][]byte
+	identities []*Identity
+}
+
+// NewAuthenticator builds an authenticator from the server configuration. The
+// API_KEY authenticates the unrestricted admin identity; each configured
+// identity is confined to the namespaces its name and groups map to. The
+// ADMIN_API_KEY, if set, authenticates the tool admin.
+func NewAuthenticator(cfg *config.Config) *Authenticator {
+	a := &Authenticator{}
+	a.add(cfg.APIKey, &Identity{
+		Name:             AdminIdentityName,
+		DefaultNamespace: cfg.KubeNamespace,
+		unrestricted:     true,
+	})
+
+	for _, identityConfig := range cfg.Identities {
+		identity := &Identity{
+			Name:   identityConfig.Name,
+			Groups: identityConfig.Groups,
+		}
+		identity.Namespaces = mappedNamespaces(identityConfig, cfg.NamespaceMappings)
+		if len(identity.Namespaces) == 0 {
+			// Unmapped identities keep working in the server namespace only
+			identity.Namespaces = []string{cfg.KubeNamespace}
+		}
+		identity.DefaultNamespace = identity.Namespaces[0]
+		a.add(identityConfig.APIKey, identity)
+	}
+
+	if cfg.AdminAPIKey != "" {
+		a.add(cfg.AdminAPIKey, &Identity{
+			Name:      ToolAdminIdentityName,
+			toolAdmin: true,
+		})
+	}
+	return a
+}
+
+// Authenticate returns the identity for an API key, or nil if the key is unknown.
+func (a *Authenticator) Authenticate(apiKey string) *Identity {
+	var match *Identity
+	for i, key := range a.keys {
+		// Compare against every key so timing does not reveal which one matched
+		if subtle.ConstantTimeCompare(key, []byte(apiKey)) == 1 {
+			match = a.identities[i]
+		}
+	}
+	return match
+}
+
+// Identities returns all identities known to the authenticator, admin first.
+func (a *Authenticator) Identities() []*Identity {
+	return a.identities
+}
+
+func (a *Authenticator) add(apiKey string, identity *Identity) {
+	a.keys = append(a.keys, []byte(apiKey))
+	a.identities = append(a.identities, identity)
+}
+
+// mappedNamespaces returns the namespaces an identity maps to: its own user
+// mapping first, then the mappings of its groups in order, without duplicates.
+func mappedNamespaces(identity config.IdentityConfig, mappings config.NamespaceMappings) []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	add := func(namespace string) {
+		if namespace != "" && !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+	}
+
+	add(mappings.Users[identity.Name])
+	for _, group := range identity.Groups {
+		add(mappings.Groups[group])
+	}
+	return namespaces
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/config"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		APIKey:        "admin-key",
+		KubeNamespace: "default",
+		Identities: []config.IdentityConfig{
+			{Name: "team-a-agent", APIKey: "team-a-key", Groups: []string{"team-a", "shared"}},
+			{Name: "ops-agent", APIKey: "ops-key", Groups: []string{"team-a"}},
+			{Name: "unmapped-agent", APIKey: "unmapped-key"},
+		},
+		NamespaceMappings: config.NamespaceMappings{
+			Users:  map[string]string{"ops-agent": "ops"},
+			Groups: map[string]string{"team-a": "team-a", "shared": "shared"},
+		},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	authenticator := NewAuthenticator(testConfig())
+
+	tests := []struct {
+		name             string
+		apiKey           string
+		wantIdentity     string
+		wantDefault      string
+		wantNamespaces   []string
+		wantUnrestricted bool
+	}{
+		{
+			name:             "admin key",
+			apiKey:           "admin-key",
+			wantIdentity:     AdminIdentityName,
+			wantDefault:      "default",
+			wantUnrestricted: true,
+		},
+		{
+			name:           "group mappings",
+			apiKey:         "team-a-key",
+			wantIdentity:   "team-a-agent",
+			wantDefault:    "team-a",
+			wantNamespaces: []string{"team-a", "shared"},
+		},
+		{
+			name:           "user mapping takes precedence",
+			apiKey:         "ops-key",
+			wantIdentity:   "ops-agent",
+			wantDefault:    "ops",
+			wantNamespaces: []string{"ops", "team-a"},
+		},
+		{
+			name:           "unmapped identity uses server namespace",
+			apiKey:         "unmapped-key",
+			wantIdentity:   "unmapped-agent",
+			wantDefault:    "default",
+			wantNamespaces: []string{"default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := authenticator.Authenticate(tt.apiKey)
+			require.NotNil(t, identity)
+			assert.Equal(t, tt.wantIdentity, identity.Name)
+			assert.Equal(t, tt.wantDefault, identity.DefaultNamespace)
+			assert.Equal(t, tt.wantNamespaces, identity.Namespaces)
+			assert.Equal(t, tt.wantUnrestricted, identity.Unrestricted())
+		})
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		assert.Nil(t, authenticator.Authenticate("wrong-key"))
+		assert.Nil(t, authenticator.Authenticate(""))
+	})
+
+	assert.Len(t, authenticator.Identities(), 4)
+
+	t.Run("tool admin key", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.AdminAPIKey = "tool-admin-key"
+		authenticator := NewAuthenticator(cfg)
+
+		identity := authenticator.Authenticate("tool-admin-key")
+		require.NotNil(t, identity)
+		assert.Equal(t, ToolAdminIdentityName, identity.Name)
+		assert.True(t, identity.ToolAdmin())
+		assert.False(t, identity.Unrestricted())
+		assert.False(t, authenticator.Authenticate("admin-key").ToolAdmin())
+		assert.Len(t, authenticator.Identities(), 5)
+	})
+}
+
+func TestResolveNamespace(t *testing.T) {
+	authenticator := NewAuthenticator(testConfig())
+	admin := authenticator.Authenticate("admin-key")
+	teamA := authenticator.Authenticate("team-a-key")
+
+	t.Run("default namespace when omitted", func(t *testing.T) {
+		namespace, err := teamA.ResolveNamespace("")
+		require.NoError(t, err)
+		assert.Equal(t, "team-a", namespace)
+	})
+
+	t.Run("mapped namespace", func(t *testing.T) {
+		namespace, err := teamA.ResolveNamespace("shared")
+		require.NoError(t, err)
+		assert.Equal(t, "shared", namespace)
+	})
+
+	t.Run("cross-namespace access denied", func(t *testing.T) {
+		_, err := teamA.ResolveNamespace("ops")
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+	})
+
+	t.Run("admin is unrestricted", func(t *testing.T) {
+		namespace, err := admin.ResolveNamespace("ops")
+		require.NoError(t, err)
+		assert.Equal(t, "ops", namespace)
+	})
+}
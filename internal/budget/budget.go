@@ -0,0 +1,54 @@
+// Package budget shares the deadline of a tool call between the operations it
+// makes. The tool layer sets the overall budget of each call with WithBudget;
+// Kubernetes API calls, provider validation and workload cluster queries then
+// take their timeout from what is left of it with Sub or Share, rather than a
+// fixed timeout that could outlast the caller's patience.
+package budget
+
+import (
+	"context"
+	"time"
+)
+
+// Reserve is the fraction of the remaining budget Sub leaves to the steps
+// after a sub-operation, so a call whose sub-operation runs out of time can
+// still report why.
+const Reserve = 0.1
+
+// WithBudget returns a context that expires after budget, the overall
+// deadline of a tool call. A non-positive budget sets no deadline, so
+// sub-operations fall back to their own limits.
+func WithBudget(ctx context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	if budget <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, budget)
+}
+
+// Remaining returns the time left before the deadline of ctx. It returns
+// false if ctx has no deadline.
+func Remaining(ctx context.Context) (time.Duration, bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
+// Sub returns a context for a sub-operation expiring after limit, or earlier
+// if less than that is left of the budget once the Reserve is set aside.
+func Sub(ctx context.Context, limit time.Duration) (context.Context, context.CancelFunc) {
+	return Share(ctx, 1-Reserve, limit)
+}
+
+// Share returns a context for a sub-operation expiring after limit, or after
+// fraction of the remaining budget if that is sooner. Steps that precede the
+// main operation of a call, such as validation, take a small share so that
+// enough is left for it.
+func Share(ctx context.Context, fraction float64, limit time.Duration) (context.Context, context.CancelFunc) {
+	timeout := limit
+	if remaining, ok := Remaining(ctx); ok {
+		timeout = min(timeout, time.Duration(float64(remaining)*fraction))
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,69 @@
+package budget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBudget(t *testing.T) {
+	t.Run("sets the deadline", func(t *testing.T) {
+		ctx, cancel := WithBudget(context.Background(), time.Minute)
+		defer cancel()
+
+		remaining, ok := Remaining(ctx)
+		require.True(t, ok)
+		assert.InDelta(t, time.Minute, remaining, float64(time.Second))
+	})
+
+	t.Run("no budget sets no deadline", func(t *testing.T) {
+		ctx, cancel := WithBudget(context.Background(), 0)
+		defer cancel()
+
+		_, ok := Remaining(ctx)
+		assert.False(t, ok)
+	})
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   time.Duration
+		fraction float64
+		limit    time.Duration
+		want     time.Duration
+	}{
+		{name: "limit without budget", fraction: 0.5, limit: 30 * time.Second, want: 30 * time.Second},
+		{name: "limit within budget", budget: 10 * time.Minute, fraction: 0.5, limit: 30 * time.Second, want: 30 * time.Second},
+		{name: "share of a short budget", budget: 40 * time.Second, fraction: 0.25, limit: 30 * time.Second, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := WithBudget(context.Background(), tt.budget)
+			defer cancel()
+
+			sub, subCancel := Share(ctx, tt.fraction, tt.limit)
+			defer subCancel()
+
+			remaining, ok := Remaining(sub)
+			require.True(t, ok)
+			assert.InDelta(t, tt.want, remaining, float64(time.Second))
+		})
+	}
+}
+
+func TestSub(t *testing.T) {
+	ctx, cancel := WithBudget(context.Background(), 20*time.Second)
+	defer cancel()
+
+	sub, subCancel := Sub(ctx, time.Minute)
+	defer subCancel()
+
+	remaining, ok := Remaining(sub)
+	require.True(t, ok)
+	assert.InDelta(t, 18*time.Second, remaining, float64(time.Second))
+}
@@ -1,12 +1,32 @@
 package config
 
 import (
+	"crypto/x509"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
 )
 
+// minOutputChunkSize is the smallest chunk size tool results may be split into.
+const minOutputChunkSize = 1024
+
+// faultVerbs are the verbs of the management cluster API calls faults can be
+// injected into.
+var faultVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
 // Config holds the server configuration.
 type Config struct {
 	// Server configuration
@@ -17,41 +37,548 @@ type Config struct {
 	// Authentication
 	APIKey string `json:"-"`
 
+	// AdminAPIKey authenticates the tool admin, who may disable and re-enable
+	// tools and start and end maintenance mode at runtime. It grants no
+	// access to clusters. Empty disables it.
+	AdminAPIKey string `json:"-"`
+
+	// Identity mapping. IdentityConfigFile points to a YAML file defining additional
+	// API keys for named identities and the namespaces identities and groups map to.
+	IdentityConfigFile string            `json:"identity_config_file"`
+	Identities         []IdentityConfig  `json:"-"`
+	NamespaceMappings  NamespaceMappings `json:"namespace_mappings"`
+
+	// Variable presets. PresetsFile points to a YAML file defining named sets
+	// of template variables create_cluster callers can start from.
+	PresetsFile string                  `json:"presets_file"`
+	Presets     map[string]PresetConfig `json:"-"`
+
+	// Default variables applied to every create_cluster request. Files in
+	// DefaultVariablesOverridesDir named after a namespace (<namespace>.yaml)
+	// add defaults for clusters in that namespace, applied after the
+	// organization-wide ones.
+	DefaultVariablesFile         string                              `json:"default_variables_file"`
+	DefaultVariablesOverridesDir string                              `json:"default_variables_overrides_dir"`
+	DefaultVariables             []DefaultVariablesConfig            `json:"-"`
+	NamespaceDefaultVariables    map[string][]DefaultVariablesConfig `json:"-"`
+
+	// Region policy. RegionPolicyFile points to a YAML file restricting the
+	// regions clusters may be created in.
+	RegionPolicyFile string              `json:"region_policy_file"`
+	RegionPolicy     *RegionPolicyConfig `json:"-"`
+
 	// Kubernetes configuration
 	KubeConfigPath string `json:"kubeconfig_path"`
 	KubeNamespace  string `json:"kube_namespace"`
 
+	// SimulationEnabled replaces the management cluster with an in-memory
+	// one whose clusters advance a phase every SimulationStepInterval, for
+	// demos and client development. KubeConfigPath is ignored.
+	SimulationEnabled      bool          `json:"simulation_enabled"`
+	SimulationStepInterval time.Duration `json:"simulation_step_interval"`
+
+	// Fault injection for resilience testing. When FaultInjectionEnabled is
+	// set, management cluster API calls are delayed by up to FaultLatency and
+	// fail with a server error at FaultErrorRate (0 to 1), limited to
+	// FaultVerbs and FaultKinds when set.
+	FaultInjectionEnabled bool          `json:"fault_injection_enabled"`
+	FaultLatency          time.Duration `json:"fault_latency"`
+	FaultErrorRate        float64       `json:"fault_error_rate"`
+	FaultVerbs            []string      `json:"fault_verbs"`
+	FaultKinds            []string      `json:"fault_kinds"`
+
+	// Restricted network configuration for the management and workload
+	// cluster clients. CABundleFile is a PEM file of certificates trusted in
+	// addition to each cluster's CA, loaded into CABundle.
+	HTTPProxy    string `json:"http_proxy"`
+	HTTPSProxy   string `json:"https_proxy"`
+	NoProxy      string `json:"no_proxy"`
+	CABundleFile string `json:"ca_bundle_file"`
+	CABundle     []byte `json:"-"`
+
 	// CAPI configuration
 	ClusterTimeout time.Duration `json:"cluster_timeout"`
 
+	// ToolCallTimeout is the budget of each tool call; the Kubernetes API
+	// calls, provider validation and workload cluster queries of a call take
+	// their timeouts from what is left of it. 0 sets no overall deadline.
+	ToolCallTimeout time.Duration `json:"tool_call_timeout"`
+
+	// OutputAPIVersion is the output schema version list_clusters,
+	// get_cluster and get_cluster_nodes answer in unless a call asks for
+	// another: "v2" (camelCase) or "v1" (snake_case).
+	OutputAPIVersion string `json:"output_api_version"`
+
+	// WaitStrategy selects how long-running operations wait for cluster state
+	// changes: "watch" (with polling fallback) or "poll".
+	WaitStrategy     string        `json:"wait_strategy"`
+	WaitPollInterval time.Duration `json:"wait_poll_interval"`
+
 	// Provider configuration
 	Providers map[string]map[string]string `json:"providers"`
 
+	// ProviderCapabilityCacheTTL is how long provider regions, instance types
+	// and Kubernetes versions are cached; 0 disables the cache.
+	ProviderCapabilityCacheTTL time.Duration `json:"provider_capability_cache_ttl"`
+
+	// AWS region and instance type catalog. When enabled, the catalog is
+	// refreshed from the EC2 API every AWSCatalogRefreshInterval and cached in
+	// AWSCatalogCacheFile; built-in lists are used until the first refresh.
+	AWSCatalogEnabled         bool          `json:"aws_catalog_enabled"`
+	AWSCatalogRefreshInterval time.Duration `json:"aws_catalog_refresh_interval"`
+	AWSCatalogCacheFile       string        `json:"aws_catalog_cache_file"`
+
+	// Kubernetes version advisories. A dataset of end of life dates and CVEs
+	// is bundled; when VersionAdvisoriesURL is set, it is refreshed from there
+	// every VersionAdvisoriesRefreshInterval and cached in
+	// VersionAdvisoriesCacheFile.
+	VersionAdvisoriesURL             string        `json:"version_advisories_url"`
+	VersionAdvisoriesRefreshInterval time.Duration `json:"version_advisories_refresh_interval"`
+	VersionAdvisoriesCacheFile       string        `json:"version_advisories_cache_file"`
+
+	// Management cluster provider upgrades. Applying upgrades rewrites the CAPI
+	// controllers on the management cluster, so it must be explicitly enabled.
+	EnableProviderUpgrades bool   `json:"enable_provider_upgrades"`
+	ClusterctlPath         string `json:"clusterctl_path"`
+
+	// Operation history and cluster snapshots, stored as ConfigMaps in KubeNamespace.
+	HistoryEnabled      bool `json:"history_enabled"`
+	HistoryMaxEntries   int  `json:"history_max_entries"`
+	SnapshotsPerCluster int  `json:"snapshots_per_cluster"`
+
+	// AsyncOperationMaxEntries bounds the long-running operations, such as
+	// restores, whose progress is kept in KubeNamespace.
+	AsyncOperationMaxEntries int `json:"async_operation_max_entries"`
+
+	// ToolAccountingRetention is how long the tool calls of each identity are
+	// kept in memory for get_tool_usage. Zero disables tool accounting.
+	ToolAccountingRetention time.Duration `json:"tool_accounting_retention"`
+
+	// Maintenance mode at startup. While it is active, mutating tools fail
+	// with a hint to retry after MaintenanceUntil, or a few minutes if it is
+	// zero, and read tools keep working.
+	MaintenanceMode   bool      `json:"maintenance_mode"`
+	MaintenanceUntil  time.Time `json:"maintenance_until"`
+	MaintenanceReason string    `json:"maintenance_reason"`
+
+	// Two-party approvals of high-risk operations. When enabled,
+	// delete_cluster and get_cluster_kubeconfig on clusters of
+	// ApprovalEnvironments, or of all clusters if it is empty, run only once
+	// a second identity approved them within ApprovalTTL.
+	ApprovalsEnabled     bool          `json:"approvals_enabled"`
+	ApprovalEnvironments []string      `json:"approval_environments"`
+	ApprovalTTL          time.Duration `json:"approval_ttl"`
+
+	// Integrations forwarding pending approvals to approvers.
+	// ApprovalSlackWebhookURL posts them to a Slack channel, whose Approve
+	// buttons call /approvals/slack signed with ApprovalSlackSigningSecret.
+	// ApprovalPagerDutyRoutingKey creates PagerDuty incidents; with
+	// ApprovalPagerDutyAPIToken, resolving an incident approves its request,
+	// as polled every ApprovalPollInterval.
+	ApprovalSlackWebhookURL     string        `json:"-"`
+	ApprovalSlackSigningSecret  string        `json:"-"`
+	ApprovalPagerDutyRoutingKey string        `json:"-"`
+	ApprovalPagerDutyAPIToken   string        `json:"-"`
+	ApprovalPollInterval        time.Duration `json:"approval_poll_interval"`
+
+	// Background cluster status index for large fleets. When enabled,
+	// list_clusters serves summaries no older than StatusIndexMaxStaleness.
+	// The index is refreshed every StatusIndexRefreshInterval, half of
+	// StatusIndexMaxStaleness by default.
+	StatusIndexEnabled         bool          `json:"status_index_enabled"`
+	StatusIndexMaxStaleness    time.Duration `json:"status_index_max_staleness"`
+	StatusIndexRefreshInterval time.Duration `json:"status_index_refresh_interval"`
+	StatusIndexBatchSize       int           `json:"status_index_batch_size"`
+	StatusIndexQPS             int           `json:"status_index_qps"`
+
+	// ListClustersConcurrency bounds how many clusters list_clusters counts
+	// the nodes of at once when not served from the status index.
+	ListClustersConcurrency int `json:"list_clusters_concurrency"`
+
+	// ListClustersCacheEnabled reads the Machines, MachineDeployments and
+	// MachinePools node counts come from through an informer cache of all
+	// namespaces instead of listing them for each cluster.
+	ListClustersCacheEnabled bool `json:"list_clusters_cache_enabled"`
+
+	// Workload cluster health metrics. When enabled, the API availability and
+	// node readiness of every Provisioned cluster are scraped through its
+	// kubeconfig every WorkloadMetricsInterval and exported as
+	// capi_mcp_workload_* metrics.
+	WorkloadMetricsEnabled     bool          `json:"workload_metrics_enabled"`
+	WorkloadMetricsInterval    time.Duration `json:"workload_metrics_interval"`
+	WorkloadMetricsConcurrency int           `json:"workload_metrics_concurrency"`
+
+	// Readiness gate. When enabled, a Provisioned cluster is only reported
+	// Ready once its workload API server answers and it passes the
+	// ReadinessGateChecks ("workers": a Ready worker node, "cni": a healthy
+	// CNI plugin). Results are reused for ReadinessGateCacheTTL.
+	ReadinessGateEnabled  bool          `json:"readiness_gate_enabled"`
+	ReadinessGateChecks   []string      `json:"readiness_gate_checks"`
+	ReadinessGateCacheTTL time.Duration `json:"readiness_gate_cache_ttl"`
+
+	// Conformance testing. When enabled, run_conformance launches Sonobuoy
+	// runs from SonobuoyPath against workload clusters and follows them for
+	// up to ConformanceTimeout.
+	ConformanceEnabled bool          `json:"conformance_enabled"`
+	SonobuoyPath       string        `json:"sonobuoy_path"`
+	ConformanceTimeout time.Duration `json:"conformance_timeout"`
+
+	// CIDROverlapPolicy selects how create_cluster handles network CIDRs that
+	// overlap existing clusters in the same region: "block", "warn" or "ignore".
+	CIDROverlapPolicy string `json:"cidr_overlap_policy"`
+
+	// Instance types clusters may use, as path.Match patterns such as "m6i.*"
+	// or "*.metal", and the total vCPUs and memory a cluster's nodes may add
+	// up to. Zero maximums mean no limit.
+	AllowedInstanceTypes []string `json:"allowed_instance_types"`
+	DeniedInstanceTypes  []string `json:"denied_instance_types"`
+	MaxClusterVCPUs      int      `json:"max_cluster_vcpus"`
+	MaxClusterMemoryGiB  int      `json:"max_cluster_memory_gib"`
+
+	// Names of clusters that may be neither created nor deleted, such as the
+	// management cluster or production clusters, as path.Match patterns such
+	// as "prod-*".
+	ProtectedClusterNames []string `json:"protected_cluster_names"`
+
+	// DeletionRetention soft-deletes clusters: delete_cluster pauses a
+	// cluster and tears it down only once the retention window has passed,
+	// until when undelete_cluster can cancel the deletion. Zero deletes
+	// clusters immediately.
+	DeletionRetention time.Duration `json:"deletion_retention"`
+
+	// Limits on the number of clusters provisioning at once, across all
+	// namespaces and per namespace. Zero means no limit. create_cluster calls
+	// over a limit fail, or wait in the async operation queue when
+	// QueueClusterCreation is set.
+	MaxProvisioningClusters             int  `json:"max_provisioning_clusters"`
+	MaxProvisioningClustersPerNamespace int  `json:"max_provisioning_clusters_per_namespace"`
+	QueueClusterCreation                bool `json:"queue_cluster_creation"`
+
+	// Fleet inventory reports. InstanceHourlyPrices maps instance types to
+	// their hourly price, from which export_inventory estimates the cost of
+	// each cluster; clusters with unpriced instance types have no estimate.
+	// InventoryOwnerLabels are the cluster labels reported as its owners.
+	InstanceHourlyPrices map[string]float64 `json:"instance_hourly_prices"`
+	InventoryOwnerLabels []string           `json:"inventory_owner_labels"`
+
+	// EnvironmentLabel is the cluster label whose value names the environment,
+	// such as dev, staging or prod, the cluster belongs to.
+	EnvironmentLabel string `json:"environment_label"`
+
+	// Admission policy evaluated before mutating tool calls. PolicyOPAURL is
+	// the OPA data API URL of the policy decision; if the policy cannot be
+	// evaluated within PolicyTimeout, calls are denied unless PolicyFailOpen.
+	PolicyOPAURL   string        `json:"policy_opa_url"`
+	PolicyTimeout  time.Duration `json:"policy_timeout"`
+	PolicyFailOpen bool          `json:"policy_fail_open"`
+
+	// ElicitationEnabled lets create_cluster ask the client for required
+	// template variables missing from a request through MCP sampling.
+	ElicitationEnabled bool `json:"elicitation_enabled"`
+
+	// Tool results larger than OutputChunkSize bytes are held for
+	// OutputPayloadTTL and delivered as a resource and chunks rather than in
+	// a single message. Zero disables chunking.
+	OutputChunkSize  int           `json:"output_chunk_size"`
+	OutputPayloadTTL time.Duration `json:"output_payload_ttl"`
+
+	// ToolResultCacheTTL is how long the results of read tools such as
+	// list_clusters and get_cluster are reused for identical calls by the
+	// same identity. Zero disables the cache.
+	ToolResultCacheTTL time.Duration `json:"tool_result_cache_ttl"`
+
+	// RESTAPIEnabled serves the cluster tools as a REST API below /api/v1,
+	// described by an OpenAPI document at /api/v1/openapi.json.
+	RESTAPIEnabled bool `json:"rest_api_enabled"`
+
+	// EventStreamEnabled serves cluster lifecycle events and operation
+	// progress as a WebSocket stream at /events. Subscribers that fall
+	// behind by more than EventStreamBufferSize events are disconnected.
+	EventStreamEnabled    bool `json:"event_stream_enabled"`
+	EventStreamBufferSize int  `json:"event_stream_buffer_size"`
+
+	// NodeDiagnosticsEnabled allows run_node_diagnostic to launch privileged
+	// debug pods with NodeDiagnosticImage on workload cluster nodes.
+	NodeDiagnosticsEnabled bool   `json:"node_diagnostics_enabled"`
+	NodeDiagnosticImage    string `json:"node_diagnostic_image"`
+
+	// EtcdBackupImage provides etcdctl to configure_etcd_backup Jobs and
+	// EtcdBackupToolsImage the shell that names and prunes snapshots.
+	EtcdBackupImage      string `json:"etcd_backup_image"`
+	EtcdBackupToolsImage string `json:"etcd_backup_tools_image"`
+
+	// SmokeTestImage runs the smoke_test_cluster workload and must provide
+	// a shell, httpd and nslookup.
+	SmokeTestImage string `json:"smoke_test_image"`
+
+	// TemporaryAccessMaxDuration is the longest create_temporary_access
+	// grants access to a workload cluster namespace for.
+	TemporaryAccessMaxDuration time.Duration `json:"temporary_access_max_duration"`
+
+	// TemplateRegistries are the registry hosts, or patterns such as
+	// *.example.com, publish_cluster_template pulls OCI artifacts from; empty
+	// disables artifacts. TemplateRegistryAuthFile is a Docker config file
+	// with their credentials and TemplateRegistriesPlainHTTP the hosts reached
+	// over HTTP.
+	TemplateRegistries          []string `json:"template_registries"`
+	TemplateRegistryAuthFile    string   `json:"template_registry_auth_file"`
+	TemplateRegistriesPlainHTTP []string `json:"template_registries_plain_http"`
+
+	// TemplateCatalogs are OCI repositories, each holding the versions of a
+	// cluster template as tags, that list_cluster_templates offers and
+	// publish_cluster_template installs from. Their registries are allowed
+	// in addition to TemplateRegistries.
+	TemplateCatalogs []string `json:"template_catalogs"`
+
+	// Template compatibility. TemplateCompatibilityFile points to a YAML file
+	// declaring the Kubernetes and provider versions cluster templates, or
+	// some versions of them, work with, in addition to their annotations.
+	TemplateCompatibilityFile string                        `json:"template_compatibility_file"`
+	TemplateCompatibility     []TemplateCompatibilityConfig `json:"-"`
+
+	// Locale is the language of user-facing error messages for callers that
+	// ask for none. MessageCatalogFile points to a YAML file adding locales or
+	// overriding messages of the bundled ones; Messages holds them by locale.
+	Locale             string                       `json:"locale"`
+	MessageCatalogFile string                       `json:"message_catalog_file"`
+	Messages           map[string]map[string]string `json:"-"`
+
 	// Observability
-	LogLevel    string `json:"log_level"`
-	MetricsPort int    `json:"metrics_port"`
-	EnablePprof bool   `json:"enable_pprof"`
+	LogLevel string `json:"log_level"`
+
+	// Log sinks. LogSinks lists the destinations (stdout, stderr, file, syslog,
+	// otlp); LogComponentLevels overrides LogLevel per component (server,
+	// cluster-service, tools, kube).
+	LogFormat          string            `json:"log_format"`
+	LogSinks           []string          `json:"log_sinks"`
+	LogFile            string            `json:"log_file"`
+	LogSyslogAddress   string            `json:"log_syslog_address"`
+	LogOTLPEndpoint    string            `json:"log_otlp_endpoint"`
+	LogComponentLevels map[string]string `json:"log_component_levels"`
+	MetricsPort        int               `json:"metrics_port"`
+	EnablePprof        bool              `json:"enable_pprof"`
+
+	// MetricsClusterLabelLimit bounds how many clusters are labelled on
+	// operation metrics; further clusters share the "_other" label.
+	MetricsClusterLabelLimit int `json:"metrics_cluster_label_limit"`
 
 	// Version information
 	Version   string `json:"version"`
 	BuildDate string `json:"build_date"`
 }
 
+// IdentityConfig defines an authenticated identity and the API key it uses.
+type IdentityConfig struct {
+	Name   string   `json:"name"`
+	APIKey string   `json:"apiKey"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// NamespaceMappings maps identity names and group names to namespaces.
+type NamespaceMappings struct {
+	Users  map[string]string `json:"users,omitempty"`
+	Groups map[string]string `json:"groups,omitempty"`
+}
+
+// identityConfigFile is the on-disk format of IDENTITY_CONFIG_FILE.
+type identityConfigFile struct {
+	Identities        []IdentityConfig  `json:"identities"`
+	NamespaceMappings NamespaceMappings `json:"namespaceMappings"`
+}
+
+// PresetConfig defines a named set of template variables.
+type PresetConfig struct {
+	Description string                 `json:"description,omitempty"`
+	Variables   map[string]interface{} `json:"variables"`
+}
+
+// presetsConfigFile is the on-disk format of VARIABLE_PRESETS_FILE.
+type presetsConfigFile struct {
+	Presets map[string]PresetConfig `json:"presets"`
+}
+
+// DefaultVariablesConfig defines variables applied to the clusters created
+// for a provider and template; an empty provider or template matches any.
+// Variables are defaults the caller may override, Enforced variables may not
+// be changed.
+type DefaultVariablesConfig struct {
+	Provider  string                 `json:"provider,omitempty"`
+	Template  string                 `json:"template,omitempty"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	Enforced  map[string]interface{} `json:"enforced,omitempty"`
+}
+
+// defaultVariablesFile is the on-disk format of DEFAULT_VARIABLES_FILE and
+// the namespace override files.
+type defaultVariablesFile struct {
+	Defaults []DefaultVariablesConfig `json:"defaults"`
+}
+
+// RegionRuleConfig restricts regions to those matching one of AllowedRegions,
+// if any is set, and none of DeniedRegions.
+type RegionRuleConfig struct {
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+	DeniedRegions  []string `json:"deniedRegions,omitempty"`
+}
+
+// RegionPolicyConfig is the on-disk format of REGION_POLICY_FILE: an
+// organization-wide rule and rules per namespace, identity and group.
+type RegionPolicyConfig struct {
+	RegionRuleConfig
+	Namespaces map[string]RegionRuleConfig `json:"namespaces,omitempty"`
+	Identities map[string]RegionRuleConfig `json:"identities,omitempty"`
+	Groups     map[string]RegionRuleConfig `json:"groups,omitempty"`
+}
+
+// TemplateCompatibilityConfig declares the Kubernetes versions and provider
+// versions, by provider label such as infrastructure-aws, that the versions
+// of a cluster template matching Versions work with; an empty Versions
+// matches every version of the template.
+type TemplateCompatibilityConfig struct {
+	Template           string            `json:"template"`
+	Versions           string            `json:"versions,omitempty"`
+	KubernetesVersions string            `json:"kubernetesVersions,omitempty"`
+	Providers          map[string]string `json:"providers,omitempty"`
+}
+
+// templateCompatibilityFile is the on-disk format of TEMPLATE_COMPATIBILITY_FILE.
+type templateCompatibilityFile struct {
+	Templates []TemplateCompatibilityConfig `json:"templates"`
+}
+
 // Load loads configuration from environment variables.
 func Load() (*Config, error) {
 	cfg := &Config{
 		// Default values
-		ServerPort:     getEnvInt("SERVER_PORT", 8080),
-		ServerTimeout:  getEnvDuration("SERVER_TIMEOUT", 30*time.Second),
-		ShutdownGrace:  getEnvDuration("SHUTDOWN_GRACE", 30*time.Second),
-		KubeNamespace:  getEnv("KUBE_NAMESPACE", "default"),
-		ClusterTimeout: getEnvDuration("CLUSTER_TIMEOUT", 10*time.Minute),
-		LogLevel:       getEnv("LOG_LEVEL", "info"),
-		MetricsPort:    getEnvInt("METRICS_PORT", 9090),
-		EnablePprof:    getEnvBool("ENABLE_PPROF", false),
-		Version:        getEnv("VERSION", "dev"),
-		BuildDate:      getEnv("BUILD_DATE", "unknown"),
-		Providers:      make(map[string]map[string]string),
+		ServerPort:       getEnvInt("SERVER_PORT", 8080),
+		ServerTimeout:    getEnvDuration("SERVER_TIMEOUT", 30*time.Second),
+		ShutdownGrace:    getEnvDuration("SHUTDOWN_GRACE", 30*time.Second),
+		KubeNamespace:    getEnv("KUBE_NAMESPACE", "default"),
+		ClusterTimeout:   getEnvDuration("CLUSTER_TIMEOUT", 10*time.Minute),
+		ToolCallTimeout:  getEnvDuration("TOOL_CALL_TIMEOUT", 15*time.Minute),
+		OutputAPIVersion: getEnv("OUTPUT_API_VERSION", "v2"),
+		WaitStrategy:     getEnv("WAIT_STRATEGY", "watch"),
+		WaitPollInterval: getEnvDuration("WAIT_POLL_INTERVAL", 10*time.Second),
+		LogLevel:         getEnv("LOG_LEVEL", "info"),
+		LogFormat:        getEnv("LOG_FORMAT", "json"),
+		LogFile:          getEnv("LOG_FILE", ""),
+		LogSyslogAddress: getEnv("LOG_SYSLOG_ADDRESS", ""),
+		LogOTLPEndpoint:  getEnv("LOG_OTLP_ENDPOINT", ""),
+		MetricsPort:      getEnvInt("METRICS_PORT", 9090),
+		EnablePprof:      getEnvBool("ENABLE_PPROF", false),
+		Version:          getEnv("VERSION", "dev"),
+		BuildDate:        getEnv("BUILD_DATE", "unknown"),
+		Providers:        make(map[string]map[string]string),
+
+		MetricsClusterLabelLimit:   getEnvInt("METRICS_CLUSTER_LABEL_LIMIT", 500),
+		ProviderCapabilityCacheTTL: getEnvDuration("PROVIDER_CAPABILITY_CACHE_TTL", 5*time.Minute),
+
+		AWSCatalogEnabled:         getEnvBool("AWS_CATALOG_ENABLED", false),
+		AWSCatalogRefreshInterval: getEnvDuration("AWS_CATALOG_REFRESH_INTERVAL", 24*time.Hour),
+		AWSCatalogCacheFile:       getEnv("AWS_CATALOG_CACHE_FILE", ""),
+
+		VersionAdvisoriesURL:             getEnv("VERSION_ADVISORIES_URL", ""),
+		VersionAdvisoriesRefreshInterval: getEnvDuration("VERSION_ADVISORIES_REFRESH_INTERVAL", 24*time.Hour),
+		VersionAdvisoriesCacheFile:       getEnv("VERSION_ADVISORIES_CACHE_FILE", ""),
+
+		EnableProviderUpgrades:   getEnvBool("ENABLE_PROVIDER_UPGRADES", false),
+		ClusterctlPath:           getEnv("CLUSTERCTL_PATH", "clusterctl"),
+		HistoryEnabled:           getEnvBool("HISTORY_ENABLED", true),
+		HistoryMaxEntries:        getEnvInt("HISTORY_MAX_ENTRIES", 1000),
+		SnapshotsPerCluster:      getEnvInt("SNAPSHOTS_PER_CLUSTER", 30),
+		AsyncOperationMaxEntries: getEnvInt("ASYNC_OPERATION_MAX_ENTRIES", 100),
+		ToolAccountingRetention:  getEnvDuration("TOOL_ACCOUNTING_RETENTION", 30*24*time.Hour),
+		MaintenanceMode:          getEnvBool("MAINTENANCE_MODE", false),
+		MaintenanceReason:        getEnv("MAINTENANCE_REASON", ""),
+
+		ApprovalsEnabled:     getEnvBool("APPROVALS_ENABLED", false),
+		ApprovalEnvironments: getEnvList("APPROVAL_ENVIRONMENTS", nil),
+		ApprovalTTL:          getEnvDuration("APPROVAL_TTL", time.Hour),
+
+		ApprovalSlackWebhookURL:     getEnv("APPROVAL_SLACK_WEBHOOK_URL", ""),
+		ApprovalSlackSigningSecret:  getEnv("APPROVAL_SLACK_SIGNING_SECRET", ""),
+		ApprovalPagerDutyRoutingKey: getEnv("APPROVAL_PAGERDUTY_ROUTING_KEY", ""),
+		ApprovalPagerDutyAPIToken:   getEnv("APPROVAL_PAGERDUTY_API_TOKEN", ""),
+		ApprovalPollInterval:        getEnvDuration("APPROVAL_POLL_INTERVAL", 30*time.Second),
+
+		StatusIndexEnabled:         getEnvBool("STATUS_INDEX_ENABLED", false),
+		StatusIndexMaxStaleness:    getEnvDuration("STATUS_INDEX_MAX_STALENESS", time.Minute),
+		StatusIndexRefreshInterval: getEnvDuration("STATUS_INDEX_REFRESH_INTERVAL", 0),
+		StatusIndexBatchSize:       getEnvInt("STATUS_INDEX_BATCH_SIZE", 50),
+		StatusIndexQPS:             getEnvInt("STATUS_INDEX_QPS", 20),
+		ListClustersConcurrency:    getEnvInt("LIST_CLUSTERS_CONCURRENCY", 10),
+		ListClustersCacheEnabled:   getEnvBool("LIST_CLUSTERS_CACHE_ENABLED", true),
+
+		WorkloadMetricsEnabled:     getEnvBool("WORKLOAD_METRICS_ENABLED", false),
+		WorkloadMetricsInterval:    getEnvDuration("WORKLOAD_METRICS_INTERVAL", time.Minute),
+		WorkloadMetricsConcurrency: getEnvInt("WORKLOAD_METRICS_CONCURRENCY", 10),
+
+		ReadinessGateEnabled:  getEnvBool("READINESS_GATE_ENABLED", false),
+		ReadinessGateChecks:   getEnvList("READINESS_GATE_CHECKS", []string{"workers", "cni"}),
+		ReadinessGateCacheTTL: getEnvDuration("READINESS_GATE_CACHE_TTL", 30*time.Second),
+
+		ConformanceEnabled: getEnvBool("CONFORMANCE_ENABLED", false),
+		SonobuoyPath:       getEnv("SONOBUOY_PATH", "sonobuoy"),
+		ConformanceTimeout: getEnvDuration("CONFORMANCE_TIMEOUT", 3*time.Hour),
+
+		CIDROverlapPolicy: getEnv("CIDR_OVERLAP_POLICY", "block"),
+
+		AllowedInstanceTypes: getEnvList("ALLOWED_INSTANCE_TYPES", nil),
+		DeniedInstanceTypes:  getEnvList("DENIED_INSTANCE_TYPES", nil),
+		MaxClusterVCPUs:      getEnvInt("MAX_CLUSTER_VCPUS", 0),
+		MaxClusterMemoryGiB:  getEnvInt("MAX_CLUSTER_MEMORY_GIB", 0),
+
+		ProtectedClusterNames: getEnvList("PROTECTED_CLUSTER_NAMES", nil),
+		DeletionRetention:     getEnvDuration("DELETION_RETENTION", 0),
+
+		MaxProvisioningClusters:             getEnvInt("MAX_PROVISIONING_CLUSTERS", 0),
+		MaxProvisioningClustersPerNamespace: getEnvInt("MAX_PROVISIONING_CLUSTERS_PER_NAMESPACE", 0),
+		QueueClusterCreation:                getEnvBool("QUEUE_CLUSTER_CREATION", false),
+
+		InventoryOwnerLabels: getEnvList("INVENTORY_OWNER_LABELS", []string{"owner", "team", "cost-center"}),
+		EnvironmentLabel:     getEnv("ENVIRONMENT_LABEL", "environment"),
+
+		PolicyOPAURL:   getEnv("POLICY_OPA_URL", ""),
+		PolicyTimeout:  getEnvDuration("POLICY_TIMEOUT", 5*time.Second),
+		PolicyFailOpen: getEnvBool("POLICY_FAIL_OPEN", false),
+
+		ElicitationEnabled: getEnvBool("ELICITATION_ENABLED", false),
+
+		OutputChunkSize:  getEnvInt("OUTPUT_CHUNK_SIZE", 0),
+		OutputPayloadTTL: getEnvDuration("OUTPUT_PAYLOAD_TTL", 15*time.Minute),
+
+		ToolResultCacheTTL: getEnvDuration("TOOL_RESULT_CACHE_TTL", 0),
+
+		RESTAPIEnabled: getEnvBool("REST_API_ENABLED", false),
+
+		EventStreamEnabled:    getEnvBool("EVENT_STREAM_ENABLED", false),
+		EventStreamBufferSize: getEnvInt("EVENT_STREAM_BUFFER_SIZE", 256),
+
+		SimulationEnabled:      getEnvBool("SIMULATION_ENABLED", false),
+		SimulationStepInterval: getEnvDuration("SIMULATION_STEP_INTERVAL", 10*time.Second),
+
+		FaultInjectionEnabled: getEnvBool("FAULT_INJECTION_ENABLED", false),
+		FaultLatency:          getEnvDuration("FAULT_LATENCY", 0),
+		FaultErrorRate:        getEnvFloat("FAULT_ERROR_RATE", 0),
+		FaultVerbs:            getEnvList("FAULT_VERBS", nil),
+		FaultKinds:            getEnvList("FAULT_KINDS", nil),
+
+		NodeDiagnosticsEnabled: getEnvBool("NODE_DIAGNOSTICS_ENABLED", false),
+		NodeDiagnosticImage:    getEnv("NODE_DIAGNOSTIC_IMAGE", "busybox:1.36"),
+
+		EtcdBackupImage:      getEnv("ETCD_BACKUP_IMAGE", "registry.k8s.io/etcd:3.5.16-0"),
+		EtcdBackupToolsImage: getEnv("ETCD_BACKUP_TOOLS_IMAGE", "busybox:1.36"),
+
+		SmokeTestImage: getEnv("SMOKE_TEST_IMAGE", "busybox:1.36"),
+
+		TemporaryAccessMaxDuration: getEnvDuration("TEMPORARY_ACCESS_MAX_DURATION", 8*time.Hour),
+
+		TemplateRegistries:          getEnvList("TEMPLATE_REGISTRIES", nil),
+		TemplateRegistryAuthFile:    getEnv("TEMPLATE_REGISTRY_AUTH_FILE", ""),
+		TemplateRegistriesPlainHTTP: getEnvList("TEMPLATE_REGISTRIES_PLAIN_HTTP", nil),
+		TemplateCatalogs:            getEnvList("TEMPLATE_CATALOGS", nil),
+
+		Locale: getEnv("LOCALE", i18n.DefaultLocale),
 	}
 
 	// Required configuration
@@ -60,13 +587,590 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("API_KEY environment variable is required")
 	}
 	cfg.APIKey = apiKey
+	cfg.AdminAPIKey = os.Getenv("ADMIN_API_KEY")
+	if cfg.AdminAPIKey == cfg.APIKey {
+		return nil, fmt.Errorf("ADMIN_API_KEY must differ from API_KEY")
+	}
+
+	if until := getEnv("MAINTENANCE_UNTIL", ""); until != "" {
+		parsed, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, fmt.Errorf("MAINTENANCE_UNTIL must be an RFC 3339 timestamp, got %q", until)
+		}
+		cfg.MaintenanceUntil = parsed
+	}
+	if cfg.ApprovalTTL <= 0 {
+		return nil, fmt.Errorf("APPROVAL_TTL must be positive")
+	}
+	if cfg.ApprovalSlackWebhookURL != "" && !strings.HasPrefix(cfg.ApprovalSlackWebhookURL, "https://") {
+		return nil, fmt.Errorf("APPROVAL_SLACK_WEBHOOK_URL must be an https URL")
+	}
+	if cfg.ApprovalPagerDutyAPIToken != "" && cfg.ApprovalPagerDutyRoutingKey == "" {
+		return nil, fmt.Errorf("APPROVAL_PAGERDUTY_API_TOKEN requires APPROVAL_PAGERDUTY_ROUTING_KEY")
+	}
+	if cfg.ApprovalPollInterval <= 0 {
+		return nil, fmt.Errorf("APPROVAL_POLL_INTERVAL must be positive")
+	}
 
 	// Kubernetes configuration
 	cfg.KubeConfigPath = getEnv("KUBECONFIG", "")
+	if cfg.SimulationStepInterval <= 0 {
+		return nil, fmt.Errorf("SIMULATION_STEP_INTERVAL must be positive")
+	}
+	if cfg.FaultLatency < 0 {
+		return nil, fmt.Errorf("FAULT_LATENCY must not be negative")
+	}
+	if cfg.FaultErrorRate < 0 || cfg.FaultErrorRate > 1 {
+		return nil, fmt.Errorf("FAULT_ERROR_RATE must be between 0 and 1, got %g", cfg.FaultErrorRate)
+	}
+	for _, verb := range cfg.FaultVerbs {
+		if !slices.Contains(faultVerbs, verb) {
+			return nil, fmt.Errorf("FAULT_VERBS: unknown verb %q, expected one of %s", verb, strings.Join(faultVerbs, ", "))
+		}
+	}
+
+	if err := cfg.loadNetworkConfig(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadLogConfig(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadInstancePrices(); err != nil {
+		return nil, err
+	}
+
+	cfg.IdentityConfigFile = getEnv("IDENTITY_CONFIG_FILE", "")
+	if cfg.IdentityConfigFile != "" {
+		if err := cfg.loadIdentityConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.PresetsFile = getEnv("VARIABLE_PRESETS_FILE", "")
+	if cfg.PresetsFile != "" {
+		if err := cfg.loadPresets(); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.DefaultVariablesFile = getEnv("DEFAULT_VARIABLES_FILE", "")
+	cfg.DefaultVariablesOverridesDir = getEnv("DEFAULT_VARIABLES_OVERRIDES_DIR", "")
+	if err := cfg.loadDefaultVariables(); err != nil {
+		return nil, err
+	}
+
+	cfg.RegionPolicyFile = getEnv("REGION_POLICY_FILE", "")
+	if cfg.RegionPolicyFile != "" {
+		if err := cfg.loadRegionPolicy(); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.TemplateCompatibilityFile = getEnv("TEMPLATE_COMPATIBILITY_FILE", "")
+	if cfg.TemplateCompatibilityFile != "" {
+		if err := cfg.loadTemplateCompatibility(); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.MessageCatalogFile = getEnv("MESSAGE_CATALOG_FILE", "")
+	if err := cfg.loadMessageCatalog(); err != nil {
+		return nil, err
+	}
+
+	if cfg.WaitStrategy != "watch" && cfg.WaitStrategy != "poll" {
+		return nil, fmt.Errorf("WAIT_STRATEGY must be \"watch\" or \"poll\", got %q", cfg.WaitStrategy)
+	}
+	if cfg.WaitPollInterval <= 0 {
+		return nil, fmt.Errorf("WAIT_POLL_INTERVAL must be positive")
+	}
+	if cfg.ToolCallTimeout < 0 {
+		return nil, fmt.Errorf("TOOL_CALL_TIMEOUT cannot be negative")
+	}
+	if cfg.OutputAPIVersion != "v1" && cfg.OutputAPIVersion != "v2" {
+		return nil, fmt.Errorf("OUTPUT_API_VERSION must be \"v1\" or \"v2\", got %q", cfg.OutputAPIVersion)
+	}
+	if cfg.HistoryMaxEntries < 0 {
+		return nil, fmt.Errorf("HISTORY_MAX_ENTRIES cannot be negative")
+	}
+	if cfg.SnapshotsPerCluster < 0 {
+		return nil, fmt.Errorf("SNAPSHOTS_PER_CLUSTER cannot be negative")
+	}
+	if cfg.AsyncOperationMaxEntries < 0 {
+		return nil, fmt.Errorf("ASYNC_OPERATION_MAX_ENTRIES cannot be negative")
+	}
+	if cfg.ToolAccountingRetention < 0 {
+		return nil, fmt.Errorf("TOOL_ACCOUNTING_RETENTION cannot be negative")
+	}
+	for _, pattern := range slices.Concat(cfg.AllowedInstanceTypes, cfg.DeniedInstanceTypes) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid instance type pattern %q", pattern)
+		}
+	}
+	if cfg.MaxClusterVCPUs < 0 {
+		return nil, fmt.Errorf("MAX_CLUSTER_VCPUS cannot be negative")
+	}
+	if cfg.MaxClusterMemoryGiB < 0 {
+		return nil, fmt.Errorf("MAX_CLUSTER_MEMORY_GIB cannot be negative")
+	}
+	for _, pattern := range cfg.ProtectedClusterNames {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid protected cluster name pattern %q", pattern)
+		}
+	}
+	if cfg.DeletionRetention < 0 {
+		return nil, fmt.Errorf("DELETION_RETENTION cannot be negative")
+	}
+	if cfg.MaxProvisioningClusters < 0 {
+		return nil, fmt.Errorf("MAX_PROVISIONING_CLUSTERS cannot be negative")
+	}
+	if cfg.MaxProvisioningClustersPerNamespace < 0 {
+		return nil, fmt.Errorf("MAX_PROVISIONING_CLUSTERS_PER_NAMESPACE cannot be negative")
+	}
+	if cfg.StatusIndexEnabled {
+		if cfg.StatusIndexMaxStaleness <= 0 {
+			return nil, fmt.Errorf("STATUS_INDEX_MAX_STALENESS must be positive")
+		}
+		if cfg.StatusIndexRefreshInterval == 0 {
+			cfg.StatusIndexRefreshInterval = cfg.StatusIndexMaxStaleness / 2
+		}
+		if cfg.StatusIndexRefreshInterval <= 0 {
+			return nil, fmt.Errorf("STATUS_INDEX_REFRESH_INTERVAL must be positive")
+		}
+		// The index dates from the start of a refresh, so it is at least one
+		// interval old before the next refresh completes
+		if cfg.StatusIndexRefreshInterval >= cfg.StatusIndexMaxStaleness {
+			return nil, fmt.Errorf("STATUS_INDEX_REFRESH_INTERVAL (%s) must be shorter than STATUS_INDEX_MAX_STALENESS (%s), or list_clusters never serves the index",
+				cfg.StatusIndexRefreshInterval, cfg.StatusIndexMaxStaleness)
+		}
+		if cfg.StatusIndexBatchSize <= 0 {
+			return nil, fmt.Errorf("STATUS_INDEX_BATCH_SIZE must be positive")
+		}
+		if cfg.StatusIndexQPS <= 0 {
+			return nil, fmt.Errorf("STATUS_INDEX_QPS must be positive")
+		}
+	}
+	if errs := k8svalidation.IsQualifiedName(cfg.EnvironmentLabel); len(errs) > 0 {
+		return nil, fmt.Errorf("ENVIRONMENT_LABEL is not a valid label key: %s", strings.Join(errs, "; "))
+	}
+	if cfg.ListClustersConcurrency <= 0 {
+		return nil, fmt.Errorf("LIST_CLUSTERS_CONCURRENCY must be positive")
+	}
+	if cfg.MetricsClusterLabelLimit < 0 {
+		return nil, fmt.Errorf("METRICS_CLUSTER_LABEL_LIMIT cannot be negative")
+	}
+	if cfg.WorkloadMetricsEnabled {
+		if cfg.WorkloadMetricsInterval <= 0 {
+			return nil, fmt.Errorf("WORKLOAD_METRICS_INTERVAL must be positive")
+		}
+		if cfg.WorkloadMetricsConcurrency <= 0 {
+			return nil, fmt.Errorf("WORKLOAD_METRICS_CONCURRENCY must be positive")
+		}
+	}
+	for _, check := range cfg.ReadinessGateChecks {
+		if check != "workers" && check != "cni" {
+			return nil, fmt.Errorf("READINESS_GATE_CHECKS must list \"workers\" or \"cni\", got %q", check)
+		}
+	}
+	if cfg.ReadinessGateCacheTTL < 0 {
+		return nil, fmt.Errorf("READINESS_GATE_CACHE_TTL cannot be negative")
+	}
+	if cfg.ConformanceEnabled && cfg.ConformanceTimeout <= 0 {
+		return nil, fmt.Errorf("CONFORMANCE_TIMEOUT must be positive")
+	}
+	if cfg.ProviderCapabilityCacheTTL < 0 {
+		return nil, fmt.Errorf("PROVIDER_CAPABILITY_CACHE_TTL cannot be negative")
+	}
+	if cfg.AWSCatalogEnabled && cfg.AWSCatalogRefreshInterval <= 0 {
+		return nil, fmt.Errorf("AWS_CATALOG_REFRESH_INTERVAL must be positive")
+	}
+	if cfg.VersionAdvisoriesURL != "" && cfg.VersionAdvisoriesRefreshInterval <= 0 {
+		return nil, fmt.Errorf("VERSION_ADVISORIES_REFRESH_INTERVAL must be positive")
+	}
+	switch cfg.CIDROverlapPolicy {
+	case "block", "warn", "ignore":
+	default:
+		return nil, fmt.Errorf("CIDR_OVERLAP_POLICY must be \"block\", \"warn\" or \"ignore\", got %q", cfg.CIDROverlapPolicy)
+	}
+	if cfg.PolicyOPAURL != "" {
+		if !strings.HasPrefix(cfg.PolicyOPAURL, "http://") && !strings.HasPrefix(cfg.PolicyOPAURL, "https://") {
+			return nil, fmt.Errorf("POLICY_OPA_URL must be an http or https URL, got %q", cfg.PolicyOPAURL)
+		}
+		if cfg.PolicyTimeout <= 0 {
+			return nil, fmt.Errorf("POLICY_TIMEOUT must be positive")
+		}
+	}
+	if cfg.OutputChunkSize != 0 {
+		if cfg.OutputChunkSize < minOutputChunkSize {
+			return nil, fmt.Errorf("OUTPUT_CHUNK_SIZE must be 0 or at least %d bytes, got %d", minOutputChunkSize, cfg.OutputChunkSize)
+		}
+		if cfg.OutputPayloadTTL <= 0 {
+			return nil, fmt.Errorf("OUTPUT_PAYLOAD_TTL must be positive")
+		}
+	}
+	if cfg.ToolResultCacheTTL < 0 || cfg.ToolResultCacheTTL > 5*time.Minute {
+		return nil, fmt.Errorf("TOOL_RESULT_CACHE_TTL must be between 0 and 5m")
+	}
+	if cfg.EventStreamBufferSize < 1 {
+		return nil, fmt.Errorf("EVENT_STREAM_BUFFER_SIZE must be positive, got %d", cfg.EventStreamBufferSize)
+	}
+	if cfg.NodeDiagnosticsEnabled && cfg.NodeDiagnosticImage == "" {
+		return nil, fmt.Errorf("NODE_DIAGNOSTIC_IMAGE is required when NODE_DIAGNOSTICS_ENABLED is set")
+	}
+	if cfg.EtcdBackupImage == "" || cfg.EtcdBackupToolsImage == "" {
+		return nil, fmt.Errorf("ETCD_BACKUP_IMAGE and ETCD_BACKUP_TOOLS_IMAGE cannot be empty")
+	}
+	if cfg.SmokeTestImage == "" {
+		return nil, fmt.Errorf("SMOKE_TEST_IMAGE cannot be empty")
+	}
+	if cfg.TemporaryAccessMaxDuration < 10*time.Minute {
+		return nil, fmt.Errorf("TEMPORARY_ACCESS_MAX_DURATION must be at least 10m")
+	}
+	if cfg.TemplateRegistryAuthFile != "" && len(cfg.TemplateRegistries) == 0 && len(cfg.TemplateCatalogs) == 0 {
+		return nil, fmt.Errorf("TEMPLATE_REGISTRY_AUTH_FILE requires TEMPLATE_REGISTRIES or TEMPLATE_CATALOGS")
+	}
+	catalogNames := map[string]bool{}
+	for _, catalog := range cfg.TemplateCatalogs {
+		repository, err := oci.ParseRepository(catalog)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TEMPLATE_CATALOGS entry: %w", err)
+		}
+		name := path.Base(repository.Repository)
+		if catalogNames[name] {
+			return nil, fmt.Errorf("TEMPLATE_CATALOGS has more than one repository named %s", name)
+		}
+		catalogNames[name] = true
+	}
+	for _, pattern := range cfg.TemplateRegistries {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid TEMPLATE_REGISTRIES pattern %q: %w", pattern, err)
+		}
+	}
 
 	return cfg, nil
 }
 
+// loadNetworkConfig reads the proxy settings, accepting the lowercase
+// variable names as well, and loads the CA bundle.
+func (c *Config) loadNetworkConfig() error {
+	c.HTTPProxy = getEnv("HTTP_PROXY", os.Getenv("http_proxy"))
+	c.HTTPSProxy = getEnv("HTTPS_PROXY", os.Getenv("https_proxy"))
+	c.NoProxy = getEnv("NO_PROXY", os.Getenv("no_proxy"))
+	c.CABundleFile = getEnv("CA_BUNDLE_FILE", "")
+
+	for name, proxy := range map[string]string{"HTTP_PROXY": c.HTTPProxy, "HTTPS_PROXY": c.HTTPSProxy} {
+		if proxy == "" {
+			continue
+		}
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Host == "" {
+			return fmt.Errorf("%s must be a proxy URL, e.g. http://proxy.example.com:3128, got %q", name, proxy)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("%s must use the http, https or socks5 scheme, got %q", name, proxy)
+		}
+	}
+
+	if c.CABundleFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.CABundleFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CA_BUNDLE_FILE: %w", err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return fmt.Errorf("CA_BUNDLE_FILE %q contains no PEM certificates", c.CABundleFile)
+	}
+	c.CABundle = data
+	return nil
+}
+
+// loadLogConfig parses and validates the log sink and level configuration.
+func (c *Config) loadLogConfig() error {
+	if !isLogLevel(c.LogLevel) {
+		return fmt.Errorf("LOG_LEVEL must be debug, info, warn or error, got %q", c.LogLevel)
+	}
+	if c.LogFormat != "json" && c.LogFormat != "text" {
+		return fmt.Errorf("LOG_FORMAT must be \"json\" or \"text\", got %q", c.LogFormat)
+	}
+
+	c.LogSinks = nil
+	for _, sink := range getEnvList("LOG_SINKS", []string{"stdout"}) {
+		switch sink {
+		case "stdout", "stderr", "syslog":
+		case "file":
+			if c.LogFile == "" {
+				return fmt.Errorf("LOG_FILE is required for the file log sink")
+			}
+		case "otlp":
+			if c.LogOTLPEndpoint == "" {
+				return fmt.Errorf("LOG_OTLP_ENDPOINT is required for the otlp log sink")
+			}
+		default:
+			return fmt.Errorf("LOG_SINKS: unknown sink %q", sink)
+		}
+		c.LogSinks = append(c.LogSinks, sink)
+	}
+
+	c.LogComponentLevels = make(map[string]string)
+	for _, entry := range getEnvList("LOG_COMPONENT_LEVELS", nil) {
+		component, level, ok := strings.Cut(entry, "=")
+		if !ok || component == "" || !isLogLevel(level) {
+			return fmt.Errorf("LOG_COMPONENT_LEVELS: invalid entry %q, expected component=level", entry)
+		}
+		c.LogComponentLevels[component] = level
+	}
+	return nil
+}
+
+// loadInstancePrices parses INSTANCE_HOURLY_PRICES, a list of
+// instanceType=price entries such as "m5.large=0.096".
+func (c *Config) loadInstancePrices() error {
+	c.InstanceHourlyPrices = make(map[string]float64)
+	for _, entry := range getEnvList("INSTANCE_HOURLY_PRICES", nil) {
+		instanceType, value, ok := strings.Cut(entry, "=")
+		price, err := strconv.ParseFloat(value, 64)
+		if !ok || instanceType == "" || err != nil || price < 0 {
+			return fmt.Errorf("INSTANCE_HOURLY_PRICES: invalid entry %q, expected instanceType=price", entry)
+		}
+		c.InstanceHourlyPrices[instanceType] = price
+	}
+	return nil
+}
+
+// isLogLevel reports whether level is a log level name.
+func isLogLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "warning", "error":
+		return true
+	}
+	return false
+}
+
+// loadIdentityConfig reads and validates the identity configuration file.
+func (c *Config) loadIdentityConfig() error {
+	data, err := os.ReadFile(c.IdentityConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read IDENTITY_CONFIG_FILE: %w", err)
+	}
+
+	var file identityConfigFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return fmt.Errorf("failed to parse IDENTITY_CONFIG_FILE: %w", err)
+	}
+
+	names := make(map[string]bool)
+	keys := map[string]bool{c.APIKey: true, c.AdminAPIKey: c.AdminAPIKey != ""}
+	for _, identity := range file.Identities {
+		if identity.Name == "" {
+			return fmt.Errorf("IDENTITY_CONFIG_FILE: identity name is required")
+		}
+		if names[identity.Name] {
+			return fmt.Errorf("IDENTITY_CONFIG_FILE: duplicate identity %q", identity.Name)
+		}
+		if identity.APIKey == "" {
+			return fmt.Errorf("IDENTITY_CONFIG_FILE: identity %q has no apiKey", identity.Name)
+		}
+		if keys[identity.APIKey] {
+			return fmt.Errorf("IDENTITY_CONFIG_FILE: identity %q reuses an API key", identity.Name)
+		}
+		names[identity.Name] = true
+		keys[identity.APIKey] = true
+	}
+
+	c.Identities = file.Identities
+	c.NamespaceMappings = file.NamespaceMappings
+	return nil
+}
+
+// loadPresets reads and validates the variable presets file.
+func (c *Config) loadPresets() error {
+	data, err := os.ReadFile(c.PresetsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read VARIABLE_PRESETS_FILE: %w", err)
+	}
+
+	var file presetsConfigFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return fmt.Errorf("failed to parse VARIABLE_PRESETS_FILE: %w", err)
+	}
+
+	for name, preset := range file.Presets {
+		if name == "" {
+			return fmt.Errorf("VARIABLE_PRESETS_FILE: preset name is required")
+		}
+		if len(preset.Variables) == 0 {
+			return fmt.Errorf("VARIABLE_PRESETS_FILE: preset %q has no variables", name)
+		}
+	}
+
+	c.Presets = file.Presets
+	return nil
+}
+
+// loadDefaultVariables reads and validates the default variables file and the
+// namespace override files.
+func (c *Config) loadDefaultVariables() error {
+	if c.DefaultVariablesFile != "" {
+		defaults, err := readDefaultVariables(c.DefaultVariablesFile)
+		if err != nil {
+			return fmt.Errorf("DEFAULT_VARIABLES_FILE: %w", err)
+		}
+		c.DefaultVariables = defaults
+	}
+
+	if c.DefaultVariablesOverridesDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(c.DefaultVariablesOverridesDir)
+	if err != nil {
+		return fmt.Errorf("failed to read DEFAULT_VARIABLES_OVERRIDES_DIR: %w", err)
+	}
+	c.NamespaceDefaultVariables = make(map[string][]DefaultVariablesConfig)
+	for _, entry := range entries {
+		namespace, ok := strings.CutSuffix(entry.Name(), ".yaml")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		defaults, err := readDefaultVariables(filepath.Join(c.DefaultVariablesOverridesDir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("DEFAULT_VARIABLES_OVERRIDES_DIR: %w", err)
+		}
+		c.NamespaceDefaultVariables[namespace] = defaults
+	}
+	return nil
+}
+
+// readDefaultVariables reads a file of default variables.
+func readDefaultVariables(path string) ([]DefaultVariablesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file defaultVariablesFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for i, defaults := range file.Defaults {
+		if len(defaults.Variables) == 0 && len(defaults.Enforced) == 0 {
+			return nil, fmt.Errorf("%s: defaults entry %d has no variables", path, i)
+		}
+	}
+	return file.Defaults, nil
+}
+
+// loadRegionPolicy reads and validates the region policy file.
+func (c *Config) loadRegionPolicy() error {
+	data, err := os.ReadFile(c.RegionPolicyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read REGION_POLICY_FILE: %w", err)
+	}
+
+	var policy RegionPolicyConfig
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return fmt.Errorf("failed to parse REGION_POLICY_FILE: %w", err)
+	}
+
+	rules := []RegionRuleConfig{policy.RegionRuleConfig}
+	for _, scoped := range []map[string]RegionRuleConfig{policy.Namespaces, policy.Identities, policy.Groups} {
+		for _, rule := range scoped {
+			rules = append(rules, rule)
+		}
+	}
+	for _, rule := range rules {
+		for _, pattern := range slices.Concat(rule.AllowedRegions, rule.DeniedRegions) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("REGION_POLICY_FILE: invalid region pattern %q", pattern)
+			}
+		}
+	}
+
+	c.RegionPolicy = &policy
+	return nil
+}
+
+// loadTemplateCompatibility reads and validates the template compatibility
+// file.
+func (c *Config) loadTemplateCompatibility() error {
+	data, err := os.ReadFile(c.TemplateCompatibilityFile)
+	if err != nil {
+		return fmt.Errorf("failed to read TEMPLATE_COMPATIBILITY_FILE: %w", err)
+	}
+
+	var file templateCompatibilityFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return fmt.Errorf("failed to parse TEMPLATE_COMPATIBILITY_FILE: %w", err)
+	}
+
+	for i, entry := range file.Templates {
+		if entry.Template == "" {
+			return fmt.Errorf("TEMPLATE_COMPATIBILITY_FILE: template of entry %d is required", i)
+		}
+		if entry.KubernetesVersions == "" && len(entry.Providers) == 0 {
+			return fmt.Errorf("TEMPLATE_COMPATIBILITY_FILE: entry %d for template %q declares no kubernetesVersions or providers", i, entry.Template)
+		}
+		constraints := []string{entry.Versions, entry.KubernetesVersions}
+		for _, constraint := range entry.Providers {
+			constraints = append(constraints, constraint)
+		}
+		for _, constraint := range constraints {
+			if err := validation.ValidateVersionConstraint(constraint); err != nil {
+				return fmt.Errorf("TEMPLATE_COMPATIBILITY_FILE: template %q: %w", entry.Template, err)
+			}
+		}
+	}
+
+	c.TemplateCompatibility = file.Templates
+	return nil
+}
+
+// messageCatalogFile is the format of MessageCatalogFile.
+type messageCatalogFile struct {
+	Messages map[string]map[string]string `json:"messages"`
+}
+
+// loadMessageCatalog reads the message catalog file, if any, and checks that
+// the locale is bundled or defined by the file.
+func (c *Config) loadMessageCatalog() error {
+	if c.MessageCatalogFile != "" {
+		data, err := os.ReadFile(c.MessageCatalogFile)
+		if err != nil {
+			return fmt.Errorf("failed to read MESSAGE_CATALOG_FILE: %w", err)
+		}
+
+		var file messageCatalogFile
+		if err := yaml.UnmarshalStrict(data, &file); err != nil {
+			return fmt.Errorf("failed to parse MESSAGE_CATALOG_FILE: %w", err)
+		}
+		for locale, messages := range file.Messages {
+			for key, message := range messages {
+				if strings.TrimSpace(message) == "" {
+					return fmt.Errorf("MESSAGE_CATALOG_FILE: message %q of locale %q is empty", key, locale)
+				}
+			}
+		}
+		c.Messages = file.Messages
+	}
+
+	catalog, err := i18n.NewCatalog(i18n.DefaultLocale)
+	if err != nil {
+		return err
+	}
+	for locale, messages := range c.Messages {
+		catalog.Add(locale, messages)
+	}
+	if err := catalog.SetDefaultLocale(c.Locale); err != nil {
+		return fmt.Errorf("LOCALE: %w", err)
+	}
+	return nil
+}
+
 // getEnv gets an environment variable with a default value.
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -75,6 +1179,22 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList gets a comma-separated list environment variable with a default value.
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvInt gets an integer environment variable with a default value.
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
@@ -85,6 +1205,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat gets a floating-point environment variable with a default value.
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 // getEnvBool gets a boolean environment variable with a default value.
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
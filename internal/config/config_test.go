@@ -1,7 +1,15 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -82,18 +90,1079 @@ func TestLoad(t *testing.T) {
 				assert.Equal(t, 10*time.Minute, cfg.ClusterTimeout) // Default value
 			},
 		},
+		{
+			name: "default wait strategy",
+			envVars: map[string]string{
+				"API_KEY": "test-key",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "watch", cfg.WaitStrategy)
+				assert.Equal(t, 10*time.Second, cfg.WaitPollInterval)
+				assert.Equal(t, 15*time.Minute, cfg.ToolCallTimeout)
+				assert.Equal(t, "v2", cfg.OutputAPIVersion)
+				assert.False(t, cfg.EnableProviderUpgrades)
+				assert.Equal(t, "clusterctl", cfg.ClusterctlPath)
+				assert.True(t, cfg.HistoryEnabled)
+				assert.Equal(t, 1000, cfg.HistoryMaxEntries)
+				assert.Equal(t, 30, cfg.SnapshotsPerCluster)
+				assert.Equal(t, 100, cfg.AsyncOperationMaxEntries)
+				assert.Equal(t, 30*24*time.Hour, cfg.ToolAccountingRetention)
+				assert.False(t, cfg.MaintenanceMode)
+				assert.True(t, cfg.MaintenanceUntil.IsZero())
+			},
+		},
+		{
+			name: "poll wait strategy",
+			envVars: map[string]string{
+				"API_KEY":            "test-key",
+				"WAIT_STRATEGY":      "poll",
+				"WAIT_POLL_INTERVAL": "2s",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "poll", cfg.WaitStrategy)
+				assert.Equal(t, 2*time.Second, cfg.WaitPollInterval)
+			},
+		},
+		{
+			name: "negative tool call timeout",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"TOOL_CALL_TIMEOUT": "-1s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "v1 output API version",
+			envVars: map[string]string{
+				"API_KEY":            "test-key",
+				"OUTPUT_API_VERSION": "v1",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "v1", cfg.OutputAPIVersion)
+			},
+		},
+		{
+			name: "invalid output API version",
+			envVars: map[string]string{
+				"API_KEY":            "test-key",
+				"OUTPUT_API_VERSION": "v3",
+			},
+			wantErr: true,
+		},
+		{
+			name: "provider upgrades enabled",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"ENABLE_PROVIDER_UPGRADES": "true",
+				"CLUSTERCTL_PATH":          "/usr/local/bin/clusterctl",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.EnableProviderUpgrades)
+				assert.Equal(t, "/usr/local/bin/clusterctl", cfg.ClusterctlPath)
+			},
+		},
+		{
+			name: "operation history disabled",
+			envVars: map[string]string{
+				"API_KEY":         "test-key",
+				"HISTORY_ENABLED": "false",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.False(t, cfg.HistoryEnabled)
+			},
+		},
+		{
+			name: "negative history size",
+			envVars: map[string]string{
+				"API_KEY":             "test-key",
+				"HISTORY_MAX_ENTRIES": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative async operation limit",
+			envVars: map[string]string{
+				"API_KEY":                     "test-key",
+				"ASYNC_OPERATION_MAX_ENTRIES": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "admin API key",
+			envVars: map[string]string{
+				"API_KEY":       "test-key",
+				"ADMIN_API_KEY": "admin-key",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "admin-key", cfg.AdminAPIKey)
+			},
+		},
+		{
+			name: "admin API key reusing the server API key",
+			envVars: map[string]string{
+				"API_KEY":       "test-key",
+				"ADMIN_API_KEY": "test-key",
+			},
+			wantErr: true,
+		},
+		{
+			name: "maintenance mode",
+			envVars: map[string]string{
+				"API_KEY":            "test-key",
+				"MAINTENANCE_MODE":   "true",
+				"MAINTENANCE_UNTIL":  "2026-03-02T11:00:00Z",
+				"MAINTENANCE_REASON": "management cluster upgrade",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.MaintenanceMode)
+				assert.Equal(t, time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC), cfg.MaintenanceUntil)
+				assert.Equal(t, "management cluster upgrade", cfg.MaintenanceReason)
+			},
+		},
+		{
+			name: "invalid maintenance end",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"MAINTENANCE_MODE":  "true",
+				"MAINTENANCE_UNTIL": "tomorrow",
+			},
+			wantErr: true,
+		},
+		{
+			name: "approvals",
+			envVars: map[string]string{
+				"API_KEY":               "test-key",
+				"APPROVALS_ENABLED":     "true",
+				"APPROVAL_ENVIRONMENTS": "prod,staging",
+				"APPROVAL_TTL":          "30m",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.ApprovalsEnabled)
+				assert.Equal(t, []string{"prod", "staging"}, cfg.ApprovalEnvironments)
+				assert.Equal(t, 30*time.Minute, cfg.ApprovalTTL)
+			},
+		},
+		{
+			name: "approval integrations",
+			envVars: map[string]string{
+				"API_KEY":                        "test-key",
+				"APPROVALS_ENABLED":              "true",
+				"APPROVAL_SLACK_WEBHOOK_URL":     "https://hooks.slack.com/services/T000/B000/XXXX",
+				"APPROVAL_SLACK_SIGNING_SECRET":  "signing-secret",
+				"APPROVAL_PAGERDUTY_ROUTING_KEY": "routing-key",
+				"APPROVAL_PAGERDUTY_API_TOKEN":   "api-token",
+				"APPROVAL_POLL_INTERVAL":         "1m",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://hooks.slack.com/services/T000/B000/XXXX", cfg.ApprovalSlackWebhookURL)
+				assert.Equal(t, "signing-secret", cfg.ApprovalSlackSigningSecret)
+				assert.Equal(t, "routing-key", cfg.ApprovalPagerDutyRoutingKey)
+				assert.Equal(t, "api-token", cfg.ApprovalPagerDutyAPIToken)
+				assert.Equal(t, time.Minute, cfg.ApprovalPollInterval)
+			},
+		},
+		{
+			name: "slack webhook over http",
+			envVars: map[string]string{
+				"API_KEY":                    "test-key",
+				"APPROVAL_SLACK_WEBHOOK_URL": "http://hooks.slack.com/services/T000/B000/XXXX",
+			},
+			wantErr: true,
+		},
+		{
+			name: "pagerduty api token without routing key",
+			envVars: map[string]string{
+				"API_KEY":                      "test-key",
+				"APPROVAL_PAGERDUTY_API_TOKEN": "api-token",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid approval ttl",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"APPROVALS_ENABLED": "true",
+				"APPROVAL_TTL":      "0s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative tool accounting retention",
+			envVars: map[string]string{
+				"API_KEY":                   "test-key",
+				"TOOL_ACCOUNTING_RETENTION": "-1h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "instance type limits",
+			envVars: map[string]string{
+				"API_KEY":                "test-key",
+				"ALLOWED_INSTANCE_TYPES": "t3.*,m6i.*",
+				"DENIED_INSTANCE_TYPES":  "*.metal",
+				"MAX_CLUSTER_VCPUS":      "256",
+				"MAX_CLUSTER_MEMORY_GIB": "1024",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"t3.*", "m6i.*"}, cfg.AllowedInstanceTypes)
+				assert.Equal(t, []string{"*.metal"}, cfg.DeniedInstanceTypes)
+				assert.Equal(t, 256, cfg.MaxClusterVCPUs)
+				assert.Equal(t, 1024, cfg.MaxClusterMemoryGiB)
+			},
+		},
+		{
+			name: "invalid instance type pattern",
+			envVars: map[string]string{
+				"API_KEY":                "test-key",
+				"ALLOWED_INSTANCE_TYPES": "m6i.[",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative vCPU limit",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"MAX_CLUSTER_VCPUS": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "protected cluster names",
+			envVars: map[string]string{
+				"API_KEY":                 "test-key",
+				"PROTECTED_CLUSTER_NAMES": "management, prod-*",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"management", "prod-*"}, cfg.ProtectedClusterNames)
+			},
+		},
+		{
+			name: "deletion retention",
+			envVars: map[string]string{
+				"API_KEY":            "test-key",
+				"DELETION_RETENTION": "72h",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 72*time.Hour, cfg.DeletionRetention)
+			},
+		},
+		{
+			name: "negative deletion retention",
+			envVars: map[string]string{
+				"API_KEY":            "test-key",
+				"DELETION_RETENTION": "-1h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid protected cluster name pattern",
+			envVars: map[string]string{
+				"API_KEY":                 "test-key",
+				"PROTECTED_CLUSTER_NAMES": "prod-[",
+			},
+			wantErr: true,
+		},
+		{
+			name: "provisioning cluster limits",
+			envVars: map[string]string{
+				"API_KEY":                   "test-key",
+				"MAX_PROVISIONING_CLUSTERS": "10",
+				"MAX_PROVISIONING_CLUSTERS_PER_NAMESPACE": "3",
+				"QUEUE_CLUSTER_CREATION":                  "true",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 10, cfg.MaxProvisioningClusters)
+				assert.Equal(t, 3, cfg.MaxProvisioningClustersPerNamespace)
+				assert.True(t, cfg.QueueClusterCreation)
+			},
+		},
+		{
+			name: "negative provisioning cluster limit",
+			envVars: map[string]string{
+				"API_KEY":                   "test-key",
+				"MAX_PROVISIONING_CLUSTERS": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "inventory pricing and owner labels",
+			envVars: map[string]string{
+				"API_KEY":                "test-key",
+				"INSTANCE_HOURLY_PRICES": "m5.large=0.096, m5.xlarge=0.192",
+				"INVENTORY_OWNER_LABELS": "team",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, map[string]float64{"m5.large": 0.096, "m5.xlarge": 0.192}, cfg.InstanceHourlyPrices)
+				assert.Equal(t, []string{"team"}, cfg.InventoryOwnerLabels)
+			},
+		},
+		{
+			name: "environment label",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"ENVIRONMENT_LABEL": "example.com/stage",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "example.com/stage", cfg.EnvironmentLabel)
+			},
+		},
+		{
+			name: "invalid environment label",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"ENVIRONMENT_LABEL": "not a label",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid instance price",
+			envVars: map[string]string{
+				"API_KEY":                "test-key",
+				"INSTANCE_HOURLY_PRICES": "m5.large=cheap",
+			},
+			wantErr: true,
+		},
+		{
+			name: "status index enabled",
+			envVars: map[string]string{
+				"API_KEY":                    "test-key",
+				"STATUS_INDEX_ENABLED":       "true",
+				"STATUS_INDEX_MAX_STALENESS": "2m",
+				"STATUS_INDEX_BATCH_SIZE":    "100",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.StatusIndexEnabled)
+				assert.Equal(t, 2*time.Minute, cfg.StatusIndexMaxStaleness)
+				assert.Equal(t, time.Minute, cfg.StatusIndexRefreshInterval)
+				assert.Equal(t, 100, cfg.StatusIndexBatchSize)
+				assert.Equal(t, 20, cfg.StatusIndexQPS)
+			},
+		},
+		{
+			name: "status index refresh interval",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"STATUS_INDEX_ENABLED":          "true",
+				"STATUS_INDEX_MAX_STALENESS":    "2m",
+				"STATUS_INDEX_REFRESH_INTERVAL": "30s",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 30*time.Second, cfg.StatusIndexRefreshInterval)
+			},
+		},
+		{
+			name: "status index refresh interval not shorter than max staleness",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"STATUS_INDEX_ENABLED":          "true",
+				"STATUS_INDEX_MAX_STALENESS":    "1m",
+				"STATUS_INDEX_REFRESH_INTERVAL": "1m",
+			},
+			wantErr: true,
+		},
+		{
+			name: "status index with zero QPS",
+			envVars: map[string]string{
+				"API_KEY":              "test-key",
+				"STATUS_INDEX_ENABLED": "true",
+				"STATUS_INDEX_QPS":     "0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "workload metrics enabled",
+			envVars: map[string]string{
+				"API_KEY":                   "test-key",
+				"WORKLOAD_METRICS_ENABLED":  "true",
+				"WORKLOAD_METRICS_INTERVAL": "30s",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.WorkloadMetricsEnabled)
+				assert.Equal(t, 30*time.Second, cfg.WorkloadMetricsInterval)
+				assert.Equal(t, 10, cfg.WorkloadMetricsConcurrency)
+			},
+		},
+		{
+			name: "readiness gate",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"READINESS_GATE_ENABLED":   "true",
+				"READINESS_GATE_CHECKS":    "workers",
+				"READINESS_GATE_CACHE_TTL": "1m",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.ReadinessGateEnabled)
+				assert.Equal(t, []string{"workers"}, cfg.ReadinessGateChecks)
+				assert.Equal(t, time.Minute, cfg.ReadinessGateCacheTTL)
+			},
+		},
+		{
+			name: "unknown readiness gate check",
+			envVars: map[string]string{
+				"API_KEY":                "test-key",
+				"READINESS_GATE_ENABLED": "true",
+				"READINESS_GATE_CHECKS":  "workers,dns",
+			},
+			wantErr: true,
+		},
+		{
+			name: "conformance",
+			envVars: map[string]string{
+				"API_KEY":             "test-key",
+				"CONFORMANCE_ENABLED": "true",
+				"SONOBUOY_PATH":       "/usr/local/bin/sonobuoy",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.ConformanceEnabled)
+				assert.Equal(t, "/usr/local/bin/sonobuoy", cfg.SonobuoyPath)
+				assert.Equal(t, 3*time.Hour, cfg.ConformanceTimeout)
+			},
+		},
+		{
+			name: "zero conformance timeout",
+			envVars: map[string]string{
+				"API_KEY":             "test-key",
+				"CONFORMANCE_ENABLED": "true",
+				"CONFORMANCE_TIMEOUT": "0s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "list clusters cache disabled",
+			envVars: map[string]string{
+				"API_KEY":                     "test-key",
+				"LIST_CLUSTERS_CACHE_ENABLED": "false",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.False(t, cfg.ListClustersCacheEnabled)
+				assert.Equal(t, 10, cfg.ListClustersConcurrency)
+			},
+		},
+		{
+			name: "zero list clusters concurrency",
+			envVars: map[string]string{
+				"API_KEY":                   "test-key",
+				"LIST_CLUSTERS_CONCURRENCY": "0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative metrics cluster label limit",
+			envVars: map[string]string{
+				"API_KEY":                     "test-key",
+				"METRICS_CLUSTER_LABEL_LIMIT": "-1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "provider capability cache TTL",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"PROVIDER_CAPABILITY_CACHE_TTL": "0",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, time.Duration(0), cfg.ProviderCapabilityCacheTTL)
+			},
+		},
+		{
+			name: "negative provider capability cache TTL",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"PROVIDER_CAPABILITY_CACHE_TTL": "-1m",
+			},
+			wantErr: true,
+		},
+		{
+			name: "workload metrics with zero concurrency",
+			envVars: map[string]string{
+				"API_KEY":                      "test-key",
+				"WORKLOAD_METRICS_ENABLED":     "true",
+				"WORKLOAD_METRICS_CONCURRENCY": "0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "log sinks and component levels",
+			envVars: map[string]string{
+				"API_KEY":              "test-key",
+				"LOG_SINKS":            "stdout, file,otlp",
+				"LOG_FILE":             "/var/log/capi-mcp.log",
+				"LOG_OTLP_ENDPOINT":    "http://otel-collector:4318/v1/logs",
+				"LOG_COMPONENT_LEVELS": "kube=warn,tools=debug",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"stdout", "file", "otlp"}, cfg.LogSinks)
+				assert.Equal(t, map[string]string{"kube": "warn", "tools": "debug"}, cfg.LogComponentLevels)
+				assert.Equal(t, "json", cfg.LogFormat)
+			},
+		},
+		{
+			name: "file sink without path",
+			envVars: map[string]string{
+				"API_KEY":   "test-key",
+				"LOG_SINKS": "file",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown log sink",
+			envVars: map[string]string{
+				"API_KEY":   "test-key",
+				"LOG_SINKS": "kafka",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid component level",
+			envVars: map[string]string{
+				"API_KEY":              "test-key",
+				"LOG_COMPONENT_LEVELS": "kube=verbose",
+			},
+			wantErr: true,
+		},
+		{
+			name: "AWS catalog enabled",
+			envVars: map[string]string{
+				"API_KEY":                "test-key",
+				"AWS_CATALOG_ENABLED":    "true",
+				"AWS_CATALOG_CACHE_FILE": "/var/cache/capi-mcp/aws-catalog.json",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.AWSCatalogEnabled)
+				assert.Equal(t, 24*time.Hour, cfg.AWSCatalogRefreshInterval)
+				assert.Equal(t, "/var/cache/capi-mcp/aws-catalog.json", cfg.AWSCatalogCacheFile)
+			},
+		},
+		{
+			name: "version advisories refreshed from a URL",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"VERSION_ADVISORIES_URL":        "https://example.com/kubernetes-advisories.json",
+				"VERSION_ADVISORIES_CACHE_FILE": "/var/cache/capi-mcp/advisories.json",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://example.com/kubernetes-advisories.json", cfg.VersionAdvisoriesURL)
+				assert.Equal(t, 24*time.Hour, cfg.VersionAdvisoriesRefreshInterval)
+				assert.Equal(t, "/var/cache/capi-mcp/advisories.json", cfg.VersionAdvisoriesCacheFile)
+			},
+		},
+		{
+			name: "invalid version advisories refresh interval",
+			envVars: map[string]string{
+				"API_KEY":                             "test-key",
+				"VERSION_ADVISORIES_URL":              "https://example.com/kubernetes-advisories.json",
+				"VERSION_ADVISORIES_REFRESH_INTERVAL": "0s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid CIDR overlap policy",
+			envVars: map[string]string{
+				"API_KEY":             "test-key",
+				"CIDR_OVERLAP_POLICY": "allow",
+			},
+			wantErr: true,
+		},
+		{
+			name: "admission policy",
+			envVars: map[string]string{
+				"API_KEY":          "test-key",
+				"POLICY_OPA_URL":   "http://opa:8181/v1/data/capi_mcp/deny",
+				"POLICY_FAIL_OPEN": "true",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "http://opa:8181/v1/data/capi_mcp/deny", cfg.PolicyOPAURL)
+				assert.Equal(t, 5*time.Second, cfg.PolicyTimeout)
+				assert.True(t, cfg.PolicyFailOpen)
+			},
+		},
+		{
+			name: "elicitation enabled",
+			envVars: map[string]string{
+				"API_KEY":             "test-key",
+				"ELICITATION_ENABLED": "true",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.ElicitationEnabled)
+			},
+		},
+		{
+			name: "output chunking",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"OUTPUT_CHUNK_SIZE": "65536",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 65536, cfg.OutputChunkSize)
+				assert.Equal(t, 15*time.Minute, cfg.OutputPayloadTTL)
+			},
+		},
+		{
+			name: "tool result cache",
+			envVars: map[string]string{
+				"API_KEY":               "test-key",
+				"TOOL_RESULT_CACHE_TTL": "10s",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 10*time.Second, cfg.ToolResultCacheTTL)
+			},
+		},
+		{
+			name: "tool result cache ttl too long",
+			envVars: map[string]string{
+				"API_KEY":               "test-key",
+				"TOOL_RESULT_CACHE_TTL": "1h",
+			},
+			wantErr: true,
+		},
+		{
+			name: "REST API enabled",
+			envVars: map[string]string{
+				"API_KEY":          "test-key",
+				"REST_API_ENABLED": "true",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.RESTAPIEnabled)
+			},
+		},
+		{
+			name: "event stream enabled",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"EVENT_STREAM_ENABLED":     "true",
+				"EVENT_STREAM_BUFFER_SIZE": "64",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.EventStreamEnabled)
+				assert.Equal(t, 64, cfg.EventStreamBufferSize)
+			},
+		},
+		{
+			name: "invalid event stream buffer size",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"EVENT_STREAM_BUFFER_SIZE": "0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "simulation enabled",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"SIMULATION_ENABLED":       "true",
+				"SIMULATION_STEP_INTERVAL": "2s",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.SimulationEnabled)
+				assert.Equal(t, 2*time.Second, cfg.SimulationStepInterval)
+			},
+		},
+		{
+			name: "invalid simulation step interval",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"SIMULATION_STEP_INTERVAL": "0s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fault injection enabled",
+			envVars: map[string]string{
+				"API_KEY":                 "test-key",
+				"FAULT_INJECTION_ENABLED": "true",
+				"FAULT_LATENCY":           "500ms",
+				"FAULT_ERROR_RATE":        "0.2",
+				"FAULT_VERBS":             "update, patch",
+				"FAULT_KINDS":             "MachineDeployment",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.FaultInjectionEnabled)
+				assert.Equal(t, 500*time.Millisecond, cfg.FaultLatency)
+				assert.Equal(t, 0.2, cfg.FaultErrorRate)
+				assert.Equal(t, []string{"update", "patch"}, cfg.FaultVerbs)
+				assert.Equal(t, []string{"MachineDeployment"}, cfg.FaultKinds)
+			},
+		},
+		{
+			name: "invalid fault error rate",
+			envVars: map[string]string{
+				"API_KEY":          "test-key",
+				"FAULT_ERROR_RATE": "2",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid fault verb",
+			envVars: map[string]string{
+				"API_KEY":     "test-key",
+				"FAULT_VERBS": "get,explode",
+			},
+			wantErr: true,
+		},
+		{
+			name: "node diagnostics enabled",
+			envVars: map[string]string{
+				"API_KEY":                  "test-key",
+				"NODE_DIAGNOSTICS_ENABLED": "true",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.NodeDiagnosticsEnabled)
+				assert.Equal(t, "busybox:1.36", cfg.NodeDiagnosticImage)
+			},
+		},
+		{
+			name: "etcd backup images",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"ETCD_BACKUP_IMAGE": "mirror.example.com/etcd:3.5.16-0",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "mirror.example.com/etcd:3.5.16-0", cfg.EtcdBackupImage)
+				assert.Equal(t, "busybox:1.36", cfg.EtcdBackupToolsImage)
+			},
+		},
+		{
+			name: "smoke test image",
+			envVars: map[string]string{
+				"API_KEY":          "test-key",
+				"SMOKE_TEST_IMAGE": "mirror.example.com/busybox:1.36",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "mirror.example.com/busybox:1.36", cfg.SmokeTestImage)
+			},
+		},
+		{
+			name: "temporary access max duration",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"TEMPORARY_ACCESS_MAX_DURATION": "2h",
+			},
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 2*time.Hour, cfg.TemporaryAccessMaxDuration)
+			},
+		},
+		{
+			name: "temporary access max duration too short",
+			envVars: map[string]string{
+				"API_KEY":                       "test-key",
+				"TEMPORARY_ACCESS_MAX_DURATION": "5m",
+			},
+			wantErr: true,
+		},
+		{
+			name: "template registries",
+			envVars: map[string]string{
+				"API_KEY":                        "test-key",
+				"TEMPLATE_REGISTRIES":            "ghcr.io,*.example.com",
+				"TEMPLATE_REGISTRY_AUTH_FILE":    "/etc/capi-mcp/registry-auth.json",
+				"TEMPLATE_REGISTRIES_PLAIN_HTTP": "registry.example.com:5000",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"ghcr.io", "*.example.com"}, cfg.TemplateRegistries)
+				assert.Equal(t, "/etc/capi-mcp/registry-auth.json", cfg.TemplateRegistryAuthFile)
+				assert.Equal(t, []string{"registry.example.com:5000"}, cfg.TemplateRegistriesPlainHTTP)
+			},
+		},
+		{
+			name: "template catalogs",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"TEMPLATE_CATALOGS": "ghcr.io/org/cluster-templates/aws-dev,registry.example.com/aws-prod",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"ghcr.io/org/cluster-templates/aws-dev", "registry.example.com/aws-prod"}, cfg.TemplateCatalogs)
+			},
+		},
+		{
+			name: "template catalog with tag",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"TEMPLATE_CATALOGS": "ghcr.io/org/cluster-templates/aws-dev:v1.0.0",
+			},
+			wantErr: true,
+		},
+		{
+			name: "template catalogs with the same name",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"TEMPLATE_CATALOGS": "ghcr.io/org/aws-dev,ghcr.io/other/aws-dev",
+			},
+			wantErr: true,
+		},
+		{
+			name: "template registry auth file without registries",
+			envVars: map[string]string{
+				"API_KEY":                     "test-key",
+				"TEMPLATE_REGISTRY_AUTH_FILE": "/etc/capi-mcp/registry-auth.json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid template registry pattern",
+			envVars: map[string]string{
+				"API_KEY":             "test-key",
+				"TEMPLATE_REGISTRIES": "[ghcr.io",
+			},
+			wantErr: true,
+		},
+		{
+			name: "output chunk size too small",
+			envVars: map[string]string{
+				"API_KEY":           "test-key",
+				"OUTPUT_CHUNK_SIZE": "100",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid admission policy URL",
+			envVars: map[string]string{
+				"API_KEY":        "test-key",
+				"POLICY_OPA_URL": "opa:8181",
+			},
+			wantErr: true,
+		},
+		{
+			name: "proxy configuration",
+			envVars: map[string]string{
+				"API_KEY":     "test-key",
+				"https_proxy": "http://proxy.example.com:3128",
+				"NO_PROXY":    "10.0.0.0/8,.cluster.local",
+			},
+			wantErr: false,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "http://proxy.example.com:3128", cfg.HTTPSProxy)
+				assert.Equal(t, "10.0.0.0/8,.cluster.local", cfg.NoProxy)
+				assert.Empty(t, cfg.HTTPProxy)
+			},
+		},
+		{
+			name: "invalid proxy URL",
+			envVars: map[string]string{
+				"API_KEY":     "test-key",
+				"HTTPS_PROXY": "proxy.example.com:3128",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid wait strategy",
+			envVars: map[string]string{
+				"API_KEY":       "test-key",
+				"WAIT_STRATEGY": "sleep",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Clear environment
+			clearEnv()
+
+			// Set test environment variables
+			for key, value := range tt.envVars {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, cfg)
+
+			if tt.checks != nil {
+				tt.checks(t, cfg)
+			}
+		})
+	}
+}
+
+func TestLoadIdentityConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+		checks  func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "identities and namespace mappings",
+			content: `
+identities:
+- name: team-a-agent
+  apiKey: team-a-key
+  groups: ["team-a"]
+namespaceMappings:
+  users:
+    ops-agent: ops
+  groups:
+    team-a: team-a
+`,
+			checks: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.Identities, 1)
+				assert.Equal(t, "team-a-agent", cfg.Identities[0].Name)
+				assert.Equal(t, "team-a-key", cfg.Identities[0].APIKey)
+				assert.Equal(t, []string{"team-a"}, cfg.Identities[0].Groups)
+				assert.Equal(t, "ops", cfg.NamespaceMappings.Users["ops-agent"])
+				assert.Equal(t, "team-a", cfg.NamespaceMappings.Groups["team-a"])
+			},
+		},
+		{
+			name: "identity without API key",
+			content: `
+identities:
+- name: team-a-agent
+`,
+			wantErr: true,
+		},
+		{
+			name: "identity reusing the server API key",
+			content: `
+identities:
+- name: team-a-agent
+  apiKey: test-key
+`,
+			wantErr: true,
+		},
+		{
+			name: "identity reusing the admin API key",
+			content: `
+identities:
+- name: team-a-agent
+  apiKey: admin-key
+`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate identity",
+			content: `
+identities:
+- name: team-a-agent
+  apiKey: key-1
+- name: team-a-agent
+  apiKey: key-2
+`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "identity: []\n",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Clear environment
 			clearEnv()
 
-			// Set test environment variables
-			for key, value := range tt.envVars {
-				t.Setenv(key, value)
+			path := filepath.Join(t.TempDir(), "identities.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			t.Setenv("API_KEY", "test-key")
+			t.Setenv("ADMIN_API_KEY", "admin-key")
+			t.Setenv("IDENTITY_CONFIG_FILE", path)
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
 
+			require.NoError(t, err)
+			if tt.checks != nil {
+				tt.checks(t, cfg)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		clearEnv()
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("IDENTITY_CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+		checks  func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "presets",
+			content: `
+presets:
+  small-dev:
+    description: Single control plane node for development
+    variables:
+      nodeCount: 1
+      workerInstanceType: t3.medium
+  prod-ha:
+    variables:
+      nodeCount: 5
+      cloudTags:
+        environment: production
+`,
+			checks: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.Presets, 2)
+				assert.Equal(t, "Single control plane node for development", cfg.Presets["small-dev"].Description)
+				assert.Equal(t, "t3.medium", cfg.Presets["small-dev"].Variables["workerInstanceType"])
+				assert.Equal(t, map[string]interface{}{"environment": "production"}, cfg.Presets["prod-ha"].Variables["cloudTags"])
+			},
+		},
+		{
+			name: "preset without variables",
+			content: `
+presets:
+  empty: {}
+`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "preset: {}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+
+			path := filepath.Join(t.TempDir(), "presets.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			t.Setenv("API_KEY", "test-key")
+			t.Setenv("VARIABLE_PRESETS_FILE", path)
+
 			cfg, err := Load()
 
 			if tt.wantErr {
@@ -102,8 +1171,315 @@ func TestLoad(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			require.NotNil(t, cfg)
+			if tt.checks != nil {
+				tt.checks(t, cfg)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		clearEnv()
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("VARIABLE_PRESETS_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadDefaultVariables(t *testing.T) {
+	writeFile := func(t *testing.T, path, content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	t.Run("organization defaults and namespace overrides", func(t *testing.T) {
+		clearEnv()
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "defaults.yaml"), `
+defaults:
+- provider: aws
+  variables:
+    region: us-west-2
+  enforced:
+    cloudTags:
+      cost-center: "1234"
+`)
+		overrides := filepath.Join(dir, "namespaces")
+		require.NoError(t, os.Mkdir(overrides, 0o700))
+		writeFile(t, filepath.Join(overrides, "team-a.yaml"), `
+defaults:
+- template: aws-standard
+  variables:
+    sshKeyName: team-a
+`)
+		writeFile(t, filepath.Join(overrides, "README.md"), "not a defaults file")
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("DEFAULT_VARIABLES_FILE", filepath.Join(dir, "defaults.yaml"))
+		t.Setenv("DEFAULT_VARIABLES_OVERRIDES_DIR", overrides)
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		require.Len(t, cfg.DefaultVariables, 1)
+		assert.Equal(t, "aws", cfg.DefaultVariables[0].Provider)
+		assert.Equal(t, "us-west-2", cfg.DefaultVariables[0].Variables["region"])
+		assert.Equal(t, map[string]interface{}{"cost-center": "1234"}, cfg.DefaultVariables[0].Enforced["cloudTags"])
+		require.Len(t, cfg.NamespaceDefaultVariables, 1)
+		require.Len(t, cfg.NamespaceDefaultVariables["team-a"], 1)
+		assert.Equal(t, "aws-standard", cfg.NamespaceDefaultVariables["team-a"][0].Template)
+	})
+
+	t.Run("entry without variables", func(t *testing.T) {
+		clearEnv()
+		path := filepath.Join(t.TempDir(), "defaults.yaml")
+		writeFile(t, path, "defaults:\n- provider: aws\n")
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("DEFAULT_VARIABLES_FILE", path)
+
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid override file", func(t *testing.T) {
+		clearEnv()
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "team-a.yaml"), "default: []\n")
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("DEFAULT_VARIABLES_OVERRIDES_DIR", dir)
+
+		_, err := Load()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing overrides directory", func(t *testing.T) {
+		clearEnv()
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("DEFAULT_VARIABLES_OVERRIDES_DIR", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadRegionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+		checks  func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "region policy",
+			content: `
+deniedRegions: ["cn-*"]
+namespaces:
+  team-eu:
+    allowedRegions: ["eu-*"]
+identities:
+  eu-agent:
+    allowedRegions: [eu-central-1]
+groups:
+  contractors:
+    deniedRegions: [eu-west-1]
+`,
+			checks: func(t *testing.T, cfg *Config) {
+				require.NotNil(t, cfg.RegionPolicy)
+				assert.Equal(t, []string{"cn-*"}, cfg.RegionPolicy.DeniedRegions)
+				assert.Equal(t, []string{"eu-*"}, cfg.RegionPolicy.Namespaces["team-eu"].AllowedRegions)
+				assert.Equal(t, []string{"eu-central-1"}, cfg.RegionPolicy.Identities["eu-agent"].AllowedRegions)
+				assert.Equal(t, []string{"eu-west-1"}, cfg.RegionPolicy.Groups["contractors"].DeniedRegions)
+			},
+		},
+		{
+			name:    "invalid pattern",
+			content: "allowedRegions: [\"eu-[\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "allowed: [eu-west-1]\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+
+			path := filepath.Join(t.TempDir(), "regions.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			t.Setenv("API_KEY", "test-key")
+			t.Setenv("REGION_POLICY_FILE", path)
 
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checks != nil {
+				tt.checks(t, cfg)
+			}
+		})
+	}
+}
+
+func TestLoadTemplateCompatibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+		checks  func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "compatibility matrix",
+			content: `
+templates:
+- template: aws-dev
+  versions: ">=v1.2.0 <v2.0"
+  kubernetesVersions: ">=v1.29.0 <v1.32"
+  providers:
+    infrastructure-aws: ">=v2.4.0 <v3.0"
+- template: aws-prod
+  kubernetesVersions: ">=v1.30.0"
+`,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []TemplateCompatibilityConfig{
+					{
+						Template:           "aws-dev",
+						Versions:           ">=v1.2.0 <v2.0",
+						KubernetesVersions: ">=v1.29.0 <v1.32",
+						Providers:          map[string]string{"infrastructure-aws": ">=v2.4.0 <v3.0"},
+					},
+					{Template: "aws-prod", KubernetesVersions: ">=v1.30.0"},
+				}, cfg.TemplateCompatibility)
+			},
+		},
+		{
+			name:    "missing template",
+			content: "templates:\n- kubernetesVersions: \">=v1.30.0\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "no constraints",
+			content: "templates:\n- template: aws-dev\n  versions: v1.2.0\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid constraint",
+			content: "templates:\n- template: aws-dev\n  providers:\n    infrastructure-aws: \">=two\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "templates:\n- template: aws-dev\n  kubernetes: \">=v1.30.0\"\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+
+			path := filepath.Join(t.TempDir(), "compatibility.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			t.Setenv("API_KEY", "test-key")
+			t.Setenv("TEMPLATE_COMPATIBILITY_FILE", path)
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.checks != nil {
+				tt.checks(t, cfg)
+			}
+		})
+	}
+}
+
+func TestLoadMessageCatalog(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		content string
+		wantErr bool
+		checks  func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "default locale",
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "en", cfg.Locale)
+				assert.Empty(t, cfg.Messages)
+			},
+		},
+		{
+			name:   "bundled locale",
+			locale: "de",
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "de", cfg.Locale)
+			},
+		},
+		{
+			name:    "unknown locale",
+			locale:  "fr",
+			wantErr: true,
+		},
+		{
+			name:   "locale defined by the catalog file",
+			locale: "fr",
+			content: `
+messages:
+  fr:
+    validation.cluster_name.empty: "le nom du cluster est obligatoire"
+  de:
+    remediation.NOT_FOUND: "Bitte prüfen Sie den Namen."
+`,
+			checks: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "fr", cfg.Locale)
+				assert.Equal(t, map[string]map[string]string{
+					"fr": {"validation.cluster_name.empty": "le nom du cluster est obligatoire"},
+					"de": {"remediation.NOT_FOUND": "Bitte prüfen Sie den Namen."},
+				}, cfg.Messages)
+			},
+		},
+		{
+			name:    "empty message",
+			content: "messages:\n  de:\n    validation.cluster_name.empty: \"\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			content: "locales:\n  de: {}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			t.Setenv("API_KEY", "test-key")
+			if tt.locale != "" {
+				t.Setenv("LOCALE", tt.locale)
+			}
+			if tt.content != "" {
+				path := filepath.Join(t.TempDir(), "messages.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+				t.Setenv("MESSAGE_CATALOG_FILE", path)
+			}
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
 			if tt.checks != nil {
 				tt.checks(t, cfg)
 			}
@@ -111,6 +1487,60 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadCABundle(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "proxy-ca"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	tests := []struct {
+		name    string
+		content []byte
+		wantErr bool
+	}{
+		{name: "PEM certificate", content: bundle},
+		{name: "no certificates", content: []byte("not a certificate\n"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+
+			path := filepath.Join(t.TempDir(), "ca.pem")
+			require.NoError(t, os.WriteFile(path, tt.content, 0o600))
+			t.Setenv("API_KEY", "test-key")
+			t.Setenv("CA_BUNDLE_FILE", path)
+
+			cfg, err := Load()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.content, cfg.CABundle)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		clearEnv()
+		t.Setenv("API_KEY", "test-key")
+		t.Setenv("CA_BUNDLE_FILE", filepath.Join(t.TempDir(), "missing.pem"))
+
+		_, err := Load()
+		assert.Error(t, err)
+	})
+}
+
 func TestGetEnvFunctions(t *testing.T) {
 	t.Run("getEnv", func(t *testing.T) {
 		t.Setenv("TEST_STRING", "test-value")
@@ -151,9 +1581,32 @@ func TestGetEnvFunctions(t *testing.T) {
 
 func clearEnv() {
 	envVars := []string{
-		"API_KEY", "SERVER_PORT", "SERVER_TIMEOUT", "SHUTDOWN_GRACE",
+		"API_KEY", "ADMIN_API_KEY", "SERVER_PORT", "SERVER_TIMEOUT", "SHUTDOWN_GRACE",
 		"KUBE_NAMESPACE", "KUBECONFIG", "CLUSTER_TIMEOUT", "LOG_LEVEL",
 		"METRICS_PORT", "ENABLE_PPROF", "VERSION", "BUILD_DATE",
+		"WAIT_STRATEGY", "WAIT_POLL_INTERVAL", "TOOL_CALL_TIMEOUT", "OUTPUT_API_VERSION", "ENABLE_PROVIDER_UPGRADES", "CLUSTERCTL_PATH",
+		"IDENTITY_CONFIG_FILE", "HISTORY_ENABLED", "HISTORY_MAX_ENTRIES", "SNAPSHOTS_PER_CLUSTER", "TOOL_ACCOUNTING_RETENTION",
+		"MAINTENANCE_MODE", "MAINTENANCE_UNTIL", "MAINTENANCE_REASON",
+		"APPROVALS_ENABLED", "APPROVAL_ENVIRONMENTS", "APPROVAL_TTL", "APPROVAL_SLACK_WEBHOOK_URL",
+		"APPROVAL_SLACK_SIGNING_SECRET", "APPROVAL_PAGERDUTY_ROUTING_KEY", "APPROVAL_PAGERDUTY_API_TOKEN", "APPROVAL_POLL_INTERVAL",
+		"LOG_FORMAT", "LOG_SINKS", "LOG_FILE", "LOG_SYSLOG_ADDRESS", "LOG_OTLP_ENDPOINT", "LOG_COMPONENT_LEVELS",
+		"STATUS_INDEX_ENABLED", "STATUS_INDEX_MAX_STALENESS", "STATUS_INDEX_REFRESH_INTERVAL", "STATUS_INDEX_BATCH_SIZE", "STATUS_INDEX_QPS", "LIST_CLUSTERS_CONCURRENCY", "LIST_CLUSTERS_CACHE_ENABLED",
+		"WORKLOAD_METRICS_ENABLED", "WORKLOAD_METRICS_INTERVAL", "WORKLOAD_METRICS_CONCURRENCY",
+		"READINESS_GATE_ENABLED", "READINESS_GATE_CHECKS", "READINESS_GATE_CACHE_TTL", "CONFORMANCE_ENABLED", "SONOBUOY_PATH", "CONFORMANCE_TIMEOUT", "METRICS_CLUSTER_LABEL_LIMIT",
+		"PROVIDER_CAPABILITY_CACHE_TTL",
+		"CIDR_OVERLAP_POLICY", "AWS_CATALOG_ENABLED", "AWS_CATALOG_REFRESH_INTERVAL", "AWS_CATALOG_CACHE_FILE",
+		"VERSION_ADVISORIES_URL", "VERSION_ADVISORIES_REFRESH_INTERVAL", "VERSION_ADVISORIES_CACHE_FILE",
+		"POLICY_OPA_URL", "POLICY_TIMEOUT", "POLICY_FAIL_OPEN", "ELICITATION_ENABLED",
+		"OUTPUT_CHUNK_SIZE", "OUTPUT_PAYLOAD_TTL", "TOOL_RESULT_CACHE_TTL", "REST_API_ENABLED", "EVENT_STREAM_ENABLED", "EVENT_STREAM_BUFFER_SIZE", "SIMULATION_ENABLED", "SIMULATION_STEP_INTERVAL",
+		"FAULT_INJECTION_ENABLED", "FAULT_LATENCY", "FAULT_ERROR_RATE", "FAULT_VERBS", "FAULT_KINDS", "NODE_DIAGNOSTICS_ENABLED", "NODE_DIAGNOSTIC_IMAGE",
+		"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy", "CA_BUNDLE_FILE",
+		"ETCD_BACKUP_IMAGE", "ETCD_BACKUP_TOOLS_IMAGE", "SMOKE_TEST_IMAGE", "TEMPORARY_ACCESS_MAX_DURATION", "ASYNC_OPERATION_MAX_ENTRIES",
+		"VARIABLE_PRESETS_FILE", "DEFAULT_VARIABLES_FILE", "DEFAULT_VARIABLES_OVERRIDES_DIR",
+		"REGION_POLICY_FILE", "ALLOWED_INSTANCE_TYPES", "DENIED_INSTANCE_TYPES", "MAX_CLUSTER_VCPUS",
+		"MAX_CLUSTER_MEMORY_GIB", "PROTECTED_CLUSTER_NAMES", "DELETION_RETENTION", "MAX_PROVISIONING_CLUSTERS", "MAX_PROVISIONING_CLUSTERS_PER_NAMESPACE",
+		"QUEUE_CLUSTER_CREATION", "INSTANCE_HOURLY_PRICES", "INVENTORY_OWNER_LABELS",
+		"TEMPLATE_REGISTRIES", "TEMPLATE_REGISTRY_AUTH_FILE", "TEMPLATE_REGISTRIES_PLAIN_HTTP",
+		"TEMPLATE_CATALOGS", "TEMPLATE_COMPATIBILITY_FILE", "LOCALE", "MESSAGE_CATALOG_FILE",
 	}
 
 	for _, key := range envVars {
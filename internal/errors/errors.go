@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -57,6 +58,7 @@ const (
 	CodeForbidden          ErrorCode = "FORBIDDEN"
 	CodeValidationFailed   ErrorCode = "VALIDATION_FAILED"
 	CodePreconditionFailed ErrorCode = "PRECONDITION_FAILED"
+	CodeRateLimited        ErrorCode = "RATE_LIMITED"
 
 	// Server errors (5xx equivalent)
 	CodeInternal           ErrorCode = "INTERNAL_ERROR"
@@ -76,6 +78,12 @@ type Error struct {
 	Message string
 	Details map[string]interface{}
 	Cause   error
+
+	// MessageKey identifies Message in the message catalog, so that it can
+	// be given to users in their language; MessageArgs are the arguments of
+	// the catalog message. Message stays the English text.
+	MessageKey  string
+	MessageArgs []interface{}
 }
 
 // Error implements the error interface
@@ -148,6 +156,13 @@ func (e *Error) WithDetails(key string, value interface{}) *Error {
 	return e
 }
 
+// WithMessageKey sets the catalog key and arguments of the error message
+func (e *Error) WithMessageKey(key string, args ...interface{}) *Error {
+	e.MessageKey = key
+	e.MessageArgs = args
+	return e
+}
+
 // WithDetailsMap adds multiple details to the error
 func (e *Error) WithDetailsMap(details map[string]interface{}) *Error {
 	if e.Details == nil {
@@ -283,6 +298,35 @@ func GetErrorCode(err error) ErrorCode {
 	}
 }
 
+// HTTPStatus returns the HTTP status code for the code of an error, for
+// callers of the REST API.
+func HTTPStatus(err error) int {
+	switch GetErrorCode(err) {
+	case CodeInvalidInput, CodeValidationFailed, CodeProviderValidation:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeAlreadyExists:
+		return http.StatusConflict
+	case CodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case CodeRateLimited, CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case CodeProviderError, CodeKubernetesAPI, CodeDependencyFailure, CodeWorkloadCluster:
+		return http.StatusBadGateway
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case CodeTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // GetUserMessage returns a user-friendly error message that doesn't expose internal details
 func GetUserMessage(err error) string {
 	if err == nil {
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 )
 
@@ -73,6 +74,23 @@ func TestError_WithDetailsMap(t *testing.T) {
 	}
 }
 
+func TestError_WithMessageKey(t *testing.T) {
+	err := New(CodeInvalidInput, "'mars-1' is not a valid AWS region format").
+		WithMessageKey("validation.aws_region.invalid", "mars-1")
+
+	if err.MessageKey != "validation.aws_region.invalid" {
+		t.Errorf("Expected message key 'validation.aws_region.invalid', got %q", err.MessageKey)
+	}
+
+	if len(err.MessageArgs) != 1 || err.MessageArgs[0] != "mars-1" {
+		t.Errorf("Expected message args [mars-1], got %v", err.MessageArgs)
+	}
+
+	if err.Error() != "INVALID_INPUT: 'mars-1' is not a valid AWS region format" {
+		t.Errorf("Expected the English message to be kept, got %q", err.Error())
+	}
+}
+
 func TestGetErrorCode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -105,6 +123,29 @@ func TestGetErrorCode(t *testing.T) {
 	}
 }
 
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "invalid input", err: New(CodeInvalidInput, "bad"), expected: http.StatusBadRequest},
+		{name: "not found", err: Wrap(ErrNotFound, CodeNotFound, "missing"), expected: http.StatusNotFound},
+		{name: "approval pending", err: New(CodePreconditionFailed, "pending"), expected: http.StatusPreconditionFailed},
+		{name: "kubernetes API", err: New(CodeKubernetesAPI, "down"), expected: http.StatusBadGateway},
+		{name: "timeout", err: New(CodeTimeout, "slow"), expected: http.StatusGatewayTimeout},
+		{name: "standard error", err: errors.New("standard error"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatus(tt.err); got != tt.expected {
+				t.Errorf("HTTPStatus() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetUserMessage(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+const (
+	// minWatchRetry and maxWatchRetry bound the wait before reopening a
+	// cluster watch that failed.
+	minWatchRetry = time.Second
+	maxWatchRetry = time.Minute
+)
+
+// ClusterSource lists and watches the clusters in every namespace.
+// *kube.Client is one.
+type ClusterSource interface {
+	ListAllClusters(ctx context.Context) (*clusterv1.ClusterList, error)
+	WatchAllClusters(ctx context.Context) (watch.Interface, error)
+}
+
+// WatchClusters publishes the lifecycle events of the clusters in every
+// namespace until ctx is cancelled. Clusters that exist when it starts are
+// not reported. Whenever the watch ends it lists the clusters again,
+// publishing the changes it missed, and reopens it.
+func (b *Broker) WatchClusters(ctx context.Context, source ClusterSource, logger *logging.Logger) {
+	logger = logger.WithOperation("WatchClusters")
+	logger.Info("Publishing cluster lifecycle events")
+
+	tracker := &phaseTracker{broker: b}
+	retry := minWatchRetry
+	for {
+		err := tracker.follow(ctx, source)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			// The API server ends watches after a while
+			retry = minWatchRetry
+			continue
+		}
+
+		logger.WithError(err).Warn("Cluster watch failed, retrying", "retry_in", retry)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+		retry = min(retry*2, maxWatchRetry)
+	}
+}
+
+// phaseTracker publishes the changes of the phases of clusters.
+type phaseTracker struct {
+	broker *Broker
+
+	// phases holds the last known phase of each cluster; nil until the
+	// clusters were first listed.
+	phases map[types.NamespacedName]string
+}
+
+// follow opens a watch, lists the clusters to catch up, and publishes the
+// changes the watch reports until it ends.
+func (t *phaseTracker) follow(ctx context.Context, source ClusterSource) error {
+	// Open the watch before listing so no change is missed
+	w, err := source.WatchAllClusters(ctx)
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	clusters, err := source.ListAllClusters(ctx)
+	if err != nil {
+		return err
+	}
+	t.resync(clusters.Items)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			if event.Type == watch.Error {
+				return fmt.Errorf("cluster watch failed: %w", apierrors.FromObject(event.Object))
+			}
+			cluster, ok := event.Object.(*clusterv1.Cluster)
+			if !ok {
+				continue
+			}
+			if event.Type == watch.Deleted {
+				t.remove(types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name})
+				continue
+			}
+			t.observe(cluster)
+		}
+	}
+}
+
+// resync records the phases of the listed clusters. After the first listing
+// it publishes the changes since the last known phases, including the
+// clusters deleted in between.
+func (t *phaseTracker) resync(clusters []clusterv1.Cluster) {
+	if t.phases == nil {
+		t.phases = make(map[types.NamespacedName]string, len(clusters))
+		for i := range clusters {
+			t.phases[types.NamespacedName{Namespace: clusters[i].Namespace, Name: clusters[i].Name}] = clusters[i].Status.Phase
+		}
+		return
+	}
+
+	listed := make(map[types.NamespacedName]bool, len(clusters))
+	for i := range clusters {
+		listed[types.NamespacedName{Namespace: clusters[i].Namespace, Name: clusters[i].Name}] = true
+		t.observe(&clusters[i])
+	}
+	for key := range t.phases {
+		if !listed[key] {
+			t.remove(key)
+		}
+	}
+}
+
+// observe publishes a cluster that is new or entered another phase.
+func (t *phaseTracker) observe(cluster *clusterv1.Cluster) {
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	phase := cluster.Status.Phase
+	previous, known := t.phases[key]
+	t.phases[key] = phase
+
+	switch {
+	case !known:
+		t.publish(ClusterAdded, key, &Cluster{Phase: phase})
+	case previous != phase:
+		t.publish(ClusterPhaseChanged, key, &Cluster{Phase: phase, PreviousPhase: previous})
+	}
+}
+
+// remove publishes the deletion of a known cluster.
+func (t *phaseTracker) remove(key types.NamespacedName) {
+	previous, known := t.phases[key]
+	if !known {
+		return
+	}
+	delete(t.phases, key)
+	t.publish(ClusterDeleted, key, &Cluster{PreviousPhase: previous})
+}
+
+func (t *phaseTracker) publish(eventType string, key types.NamespacedName, cluster *Cluster) {
+	t.broker.Publish(Event{
+		Type:        eventType,
+		Namespace:   key.Namespace,
+		ClusterName: key.Name,
+		Cluster:     cluster,
+	})
+}
@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+// listNotifier signals each listing of the clusters.
+type listNotifier struct {
+	ClusterSource
+	listed chan struct{}
+}
+
+func (n *listNotifier) ListAllClusters(ctx context.Context) (*clusterv1.ClusterList, error) {
+	clusters, err := n.ClusterSource.ListAllClusters(ctx)
+	n.listed <- struct{}{}
+	return clusters, err
+}
+
+func newCluster(namespace, name string, phase clusterv1.ClusterPhase) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status:     clusterv1.ClusterStatus{Phase: string(phase)},
+	}
+}
+
+func TestBroker_WatchClusters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	existing := newCluster("team-a", "existing", clusterv1.ClusterPhaseProvisioning)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+
+	broker := NewBroker(16)
+	subscription := broker.Subscribe(Filter{})
+	defer subscription.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	source := &listNotifier{ClusterSource: kube.NewClientFromClient(fakeClient, "default"), listed: make(chan struct{}, 1)}
+	go broker.WatchClusters(ctx, source, logging.NewLogger(slog.LevelError, "text"))
+	<-source.listed
+
+	next := func() Event {
+		select {
+		case event := <-subscription.Events():
+			return event
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no event published")
+			return Event{}
+		}
+	}
+
+	require.NoError(t, fakeClient.Create(ctx, newCluster("team-b", "new", clusterv1.ClusterPhasePending)))
+	event := next()
+	assert.Equal(t, ClusterAdded, event.Type)
+	assert.Equal(t, "team-b", event.Namespace)
+	assert.Equal(t, "new", event.ClusterName)
+	assert.Equal(t, &Cluster{Phase: "Pending"}, event.Cluster)
+
+	updated := existing.DeepCopy()
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(updated), updated))
+	updated.Status.Phase = string(clusterv1.ClusterPhaseProvisioned)
+	require.NoError(t, fakeClient.Update(ctx, updated))
+	event = next()
+	assert.Equal(t, ClusterPhaseChanged, event.Type)
+	assert.Equal(t, &Cluster{Phase: "Provisioned", PreviousPhase: "Provisioning"}, event.Cluster)
+
+	// Other changes of a cluster are not published
+	updated.Labels = map[string]string{"env": "prod"}
+	require.NoError(t, fakeClient.Update(ctx, updated))
+	require.NoError(t, fakeClient.Delete(ctx, updated))
+	event = next()
+	assert.Equal(t, ClusterDeleted, event.Type)
+	assert.Equal(t, "existing", event.ClusterName)
+	assert.Equal(t, &Cluster{PreviousPhase: "Provisioned"}, event.Cluster)
+}
+
+func TestPhaseTracker_Resync(t *testing.T) {
+	broker := NewBroker(16)
+	subscription := broker.Subscribe(Filter{})
+	defer subscription.Close()
+	tracker := &phaseTracker{broker: broker}
+
+	tracker.resync([]clusterv1.Cluster{
+		*newCluster("team-a", "kept", clusterv1.ClusterPhaseProvisioned),
+		*newCluster("team-a", "gone", clusterv1.ClusterPhaseDeleting),
+		*newCluster("team-a", "growing", clusterv1.ClusterPhaseProvisioning),
+	})
+	assert.Empty(t, subscription.Events(), "clusters of the first listing are not reported")
+
+	// Changes missed while the watch was closed are published
+	tracker.resync([]clusterv1.Cluster{
+		*newCluster("team-a", "kept", clusterv1.ClusterPhaseProvisioned),
+		*newCluster("team-a", "growing", clusterv1.ClusterPhaseProvisioned),
+		*newCluster("team-b", "added", clusterv1.ClusterPhasePending),
+	})
+	var published []string
+	for len(subscription.Events()) > 0 {
+		event := <-subscription.Events()
+		published = append(published, event.Type+" "+event.Namespace+"/"+event.ClusterName)
+	}
+	assert.ElementsMatch(t, []string{
+		"cluster.phase_changed team-a/growing",
+		"cluster.added team-b/added",
+		"cluster.deleted team-a/gone",
+	}, published)
+}
@@ -0,0 +1,198 @@
+// Package events broadcasts cluster lifecycle events and the progress of
+// operations to subscribers, such as dashboards following the event stream
+// of the server.
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Types of events.
+const (
+	// ClusterAdded reports a cluster that was created.
+	ClusterAdded = "cluster.added"
+	// ClusterPhaseChanged reports a cluster that entered another phase, e.g.
+	// Provisioned after Provisioning.
+	ClusterPhaseChanged = "cluster.phase_changed"
+	// ClusterDeleted reports a cluster that is gone.
+	ClusterDeleted = "cluster.deleted"
+	// OperationProgress reports a stage of a long-running operation, such as
+	// a restore, starting, progressing or finishing.
+	OperationProgress = "operation.progress"
+	// OperationCompleted reports a tool call on a cluster that finished,
+	// successfully or not.
+	OperationCompleted = "operation.completed"
+	// Heartbeat is sent on idle streams so that clients and proxies keep
+	// them open.
+	Heartbeat = "heartbeat"
+	// Overflow is the last event of a stream whose client fell too far
+	// behind. Clients reconnect and resynchronize, e.g. with list_clusters.
+	Overflow = "overflow"
+)
+
+// DefaultBufferSize is the number of events a subscriber may fall behind by
+// default before its subscription is ended.
+const DefaultBufferSize = 256
+
+// Event is a change of a cluster or an operation.
+type Event struct {
+	Type        string     `json:"type"`
+	Time        time.Time  `json:"time"`
+	Namespace   string     `json:"namespace,omitempty"`
+	ClusterName string     `json:"clusterName,omitempty"`
+	Cluster     *Cluster   `json:"cluster,omitempty"`
+	Operation   *Operation `json:"operation,omitempty"`
+}
+
+// Cluster is the state of a cluster an event reports.
+type Cluster struct {
+	Phase         string `json:"phase,omitempty"`
+	PreviousPhase string `json:"previousPhase,omitempty"`
+}
+
+// Operation is the state of an operation an event reports.
+type Operation struct {
+	// ID identifies long-running operations for get_operation_status.
+	ID string `json:"id,omitempty"`
+	// Tool is the tool called, or the kind of a long-running operation.
+	Tool          string  `json:"tool"`
+	Identity      string  `json:"identity,omitempty"`
+	CorrelationID string  `json:"correlationId,omitempty"`
+	State         string  `json:"state"`
+	Error         string  `json:"error,omitempty"`
+	DurationMs    int64   `json:"durationMs,omitempty"`
+	Stages        []Stage `json:"stages,omitempty"`
+}
+
+// Stage is the state of a stage of a long-running operation.
+type Stage struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// Filter selects the events of a subscription. Zero values match everything.
+type Filter struct {
+	// Namespaces limits events to clusters and operations in these
+	// namespaces.
+	Namespaces  []string
+	ClusterName string
+}
+
+// matches reports whether an event passes the filter.
+func (f Filter) matches(event Event) bool {
+	if len(f.Namespaces) > 0 && !slices.Contains(f.Namespaces, event.Namespace) {
+		return false
+	}
+	return f.ClusterName == "" || f.ClusterName == event.ClusterName
+}
+
+// Broker passes published events to the subscriptions they match. It is safe
+// for concurrent use.
+type Broker struct {
+	bufferSize int
+
+	mu            sync.Mutex
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewBroker creates a broker whose subscribers may fall behind by up to
+// bufferSize events. A non-positive bufferSize uses DefaultBufferSize.
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Broker{
+		bufferSize:    bufferSize,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish passes an event to every subscription it matches, stamping it with
+// the current time if it has none. It never blocks: a subscription that fell
+// behind by more than the buffer size is ended as overflowed, so its client
+// reconnects rather than silently missing events.
+func (b *Broker) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for subscription := range b.subscriptions {
+		if !subscription.filter.matches(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.overflowed = true
+			b.closeLocked(subscription)
+		}
+	}
+}
+
+// Subscribe starts a subscription to the events matching filter. Callers
+// close it when done.
+func (b *Broker) Subscribe(filter Filter) *Subscription {
+	subscription := &Subscription{
+		broker: b,
+		filter: filter,
+		events: make(chan Event, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+// Subscribers returns the number of open subscriptions.
+func (b *Broker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscriptions)
+}
+
+// closeLocked ends a subscription. The caller holds the lock.
+func (b *Broker) closeLocked(subscription *Subscription) {
+	if _, ok := b.subscriptions[subscription]; !ok {
+		return
+	}
+	delete(b.subscriptions, subscription)
+	close(subscription.events)
+}
+
+// Subscription receives the events matching its filter.
+type Subscription struct {
+	broker *Broker
+	filter Filter
+	events chan Event
+
+	// overflowed is set, under the broker's lock, when the subscription was
+	// ended because it fell behind.
+	overflowed bool
+}
+
+// Events returns the channel events are delivered on. It is closed when the
+// subscription ends.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Overflowed reports whether the subscription was ended because its
+// receiver fell behind.
+func (s *Subscription) Overflowed() bool {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.overflowed
+}
+
+// Close ends the subscription. Closing it again has no effect.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.closeLocked(s)
+}
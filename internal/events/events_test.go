@@ -0,0 +1,57 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroker(t *testing.T) {
+	broker := NewBroker(2)
+
+	all := broker.Subscribe(Filter{})
+	teamA := broker.Subscribe(Filter{Namespaces: []string{"team-a"}})
+	prod := broker.Subscribe(Filter{ClusterName: "prod"})
+	assert.Equal(t, 3, broker.Subscribers())
+
+	broker.Publish(Event{Type: ClusterAdded, Namespace: "team-a", ClusterName: "prod"})
+	broker.Publish(Event{Type: ClusterAdded, Namespace: "team-b", ClusterName: "dev"})
+
+	received := func(subscription *Subscription) []string {
+		var clusters []string
+		for len(subscription.Events()) > 0 {
+			event := <-subscription.Events()
+			assert.False(t, event.Time.IsZero(), "published events are stamped")
+			clusters = append(clusters, event.Namespace+"/"+event.ClusterName)
+		}
+		return clusters
+	}
+	assert.Equal(t, []string{"team-a/prod", "team-b/dev"}, received(all))
+	assert.Equal(t, []string{"team-a/prod"}, received(teamA))
+	assert.Equal(t, []string{"team-a/prod"}, received(prod))
+
+	t.Run("overflow", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			broker.Publish(Event{Type: ClusterDeleted, Namespace: "team-b", ClusterName: "dev"})
+		}
+		assert.True(t, all.Overflowed())
+		assert.Len(t, all.Events(), 2, "events received before the overflow are kept")
+		<-all.Events()
+		<-all.Events()
+		_, open := <-all.Events()
+		assert.False(t, open)
+
+		assert.False(t, teamA.Overflowed())
+		assert.Equal(t, 2, broker.Subscribers())
+	})
+
+	t.Run("close", func(t *testing.T) {
+		teamA.Close()
+		teamA.Close()
+		_, open := <-teamA.Events()
+		require.False(t, open)
+		assert.False(t, teamA.Overflowed())
+		assert.Equal(t, 1, broker.Subscribers())
+	})
+}
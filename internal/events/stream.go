@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// HeartbeatInterval is how often idle streams send a heartbeat.
+	HeartbeatInterval = 30 * time.Second
+
+	// streamWriteTimeout bounds sending an event to a client.
+	streamWriteTimeout = 10 * time.Second
+)
+
+// ServeStream upgrades a request to a WebSocket connection and sends the
+// events of a subscription over it as JSON text messages, and a heartbeat
+// after HeartbeatInterval without events, until the client disconnects or the
+// subscription ends. The stream is read-only: messages from the client are
+// discarded. The subscription is closed when ServeStream returns.
+func ServeStream(w http.ResponseWriter, r *http.Request, subscription *Subscription) {
+	defer subscription.Close()
+
+	if _, ok := w.(http.Hijacker); !ok {
+		http.Error(w, "the event stream needs a WebSocket connection", http.StatusInternalServerError)
+		return
+	}
+	server := websocket.Server{
+		// Clients authenticate with their API key rather than by origin
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			stream(r.Context(), conn, subscription, HeartbeatInterval)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// stream sends events to a connection until the client disconnects, the
+// subscription ends or ctx is cancelled.
+func stream(ctx context.Context, conn *websocket.Conn, subscription *Subscription, heartbeat time.Duration) {
+	// The server's read timeout would otherwise end idle streams
+	_ = conn.SetReadDeadline(time.Time{})
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		var discarded []byte
+		for {
+			if err := websocket.Message.Receive(conn, &discarded); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-disconnected:
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				if subscription.Overflowed() {
+					_ = send(conn, Event{Type: Overflow, Time: time.Now().UTC()})
+				}
+				return
+			}
+			if send(conn, event) != nil {
+				return
+			}
+			ticker.Reset(heartbeat)
+		case <-ticker.C:
+			if send(conn, Event{Type: Heartbeat, Time: time.Now().UTC()}) != nil {
+				return
+			}
+		}
+	}
+}
+
+// send writes an event as a JSON text message.
+func send(conn *websocket.Conn, event Event) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(conn, event)
+}
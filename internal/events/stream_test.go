@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// dialStream serves the subscriptions started by subscribe over a test server
+// and connects to it.
+func dialStream(t *testing.T, subscribe func() *Subscription) *websocket.Conn {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeStream(w, r, subscribe())
+	}))
+	t.Cleanup(server.Close)
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	return conn
+}
+
+func TestServeStream(t *testing.T) {
+	t.Run("events", func(t *testing.T) {
+		broker := NewBroker(16)
+		conn := dialStream(t, func() *Subscription { return broker.Subscribe(Filter{Namespaces: []string{"team-a"}}) })
+		require.Eventually(t, func() bool { return broker.Subscribers() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+		broker.Publish(Event{Type: ClusterAdded, Namespace: "team-b", ClusterName: "other"})
+		broker.Publish(Event{Type: ClusterAdded, Namespace: "team-a", ClusterName: "prod-a", Cluster: &Cluster{Phase: "Pending"}})
+		var event Event
+		require.NoError(t, websocket.JSON.Receive(conn, &event))
+		assert.Equal(t, ClusterAdded, event.Type)
+		assert.Equal(t, "prod-a", event.ClusterName)
+		assert.Equal(t, &Cluster{Phase: "Pending"}, event.Cluster)
+		assert.False(t, event.Time.IsZero())
+
+		// Disconnecting ends the subscription
+		require.NoError(t, conn.Close())
+		assert.Eventually(t, func() bool { return broker.Subscribers() == 0 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		broker := NewBroker(1)
+		subscription := broker.Subscribe(Filter{})
+		broker.Publish(Event{Type: ClusterAdded, ClusterName: "a"})
+		broker.Publish(Event{Type: ClusterAdded, ClusterName: "b"})
+		conn := dialStream(t, func() *Subscription { return subscription })
+
+		var event Event
+		require.NoError(t, websocket.JSON.Receive(conn, &event))
+		assert.Equal(t, "a", event.ClusterName)
+		require.NoError(t, websocket.JSON.Receive(conn, &event))
+		assert.Equal(t, Overflow, event.Type)
+	})
+
+	t.Run("not a WebSocket connection", func(t *testing.T) {
+		broker := NewBroker(1)
+		recorder := httptest.NewRecorder()
+		ServeStream(recorder, httptest.NewRequest(http.MethodGet, "/events", nil), broker.Subscribe(Filter{}))
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Zero(t, broker.Subscribers())
+	})
+}
+
+func TestStream_Heartbeat(t *testing.T) {
+	broker := NewBroker(1)
+	subscription := broker.Subscribe(Filter{})
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		stream(context.Background(), conn, subscription, 10*time.Millisecond)
+	}))
+	defer server.Close()
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http"), "", server.URL)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var event Event
+	require.NoError(t, websocket.JSON.Receive(conn, &event))
+	assert.Equal(t, Heartbeat, event.Type)
+}
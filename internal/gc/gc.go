@@ -0,0 +1,235 @@
+// Package gc finds Cluster API resources left behind by clusters that no
+// longer exist, typically after a failed or interrupted deletion, and the
+// resources holding back the deletion of a cluster, so they can be reviewed
+// and removed.
+package gc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// DefaultMinAge is how old a resource must be before it is considered
+// orphaned, so resources created just before their Cluster are not collected.
+const DefaultMinAge = 10 * time.Minute
+
+// DefaultKinds are the kinds of resources checked for orphans, in the order
+// they are deleted: owners before the resources they own.
+var DefaultKinds = []schema.GroupVersionKind{
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeployment"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachinePool"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineHealthCheck"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineSet"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Machine"},
+	{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Kind: "KubeadmControlPlane"},
+	{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSManagedControlPlane"},
+	{Group: "bootstrap.cluster.x-k8s.io", Version: "v1beta1", Kind: "KubeadmConfig"},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSCluster"},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSManagedCluster"},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSMachinePool"},
+	{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSMachine"},
+	{Group: "", Version: "v1", Kind: "Secret"},
+}
+
+// Resource is a resource whose owning Cluster no longer exists.
+type Resource struct {
+	APIVersion  string
+	Kind        string
+	Namespace   string
+	Name        string
+	ClusterName string
+	CreatedAt   time.Time
+	// Deleting is set for resources already being deleted, which are usually
+	// held back by finalizers.
+	Deleting   bool
+	Finalizers []string
+	// Reason is the failing condition of the resource, if it reports one,
+	// e.g. why its teardown is stuck.
+	Reason string
+}
+
+// key identifies a resource in confirmation tokens.
+func (r Resource) key() string {
+	return strings.Join([]string{r.APIVersion, r.Kind, r.Namespace, r.Name}, "/")
+}
+
+// Collector finds and deletes orphaned resources.
+type Collector struct {
+	kubeClient *kube.Client
+	kinds      []schema.GroupVersionKind
+	minAge     time.Duration
+}
+
+// NewCollector creates a collector checking the given kinds for resources
+// older than minAge.
+func NewCollector(kubeClient *kube.Client, kinds []schema.GroupVersionKind, minAge time.Duration) *Collector {
+	return &Collector{
+		kubeClient: kubeClient,
+		kinds:      kinds,
+		minAge:     minAge,
+	}
+}
+
+// Find returns the resources in a namespace labelled with the name of a
+// Cluster that does not exist. Kinds whose CRDs are not installed are
+// skipped.
+func (c *Collector) Find(ctx context.Context, namespace string) ([]Resource, error) {
+	clusters, err := c.kubeClient.ListClusters(kube.ContextWithNamespace(ctx, namespace))
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		existing[cluster.Name] = true
+	}
+
+	cutoff := time.Now().Add(-c.minAge)
+	return c.list(ctx, namespace, func(obj *unstructured.Unstructured) bool {
+		return !existing[obj.GetLabels()[clusterv1.ClusterNameLabel]] && !obj.GetCreationTimestamp().Time.After(cutoff)
+	})
+}
+
+// Blocking returns the resources of a cluster that hold back its deletion:
+// those with finalizers, which their controllers remove only once they have
+// torn down what the resources manage.
+func (c *Collector) Blocking(ctx context.Context, namespace, clusterName string) ([]Resource, error) {
+	return c.list(ctx, namespace, func(obj *unstructured.Unstructured) bool {
+		return obj.GetLabels()[clusterv1.ClusterNameLabel] == clusterName && len(obj.GetFinalizers()) > 0
+	})
+}
+
+// list returns the resources labelled with a cluster name that match keep.
+// Kinds whose CRDs are not installed are skipped.
+func (c *Collector) list(ctx context.Context, namespace string, keep func(obj *unstructured.Unstructured) bool) ([]Resource, error) {
+	var resources []Resource
+	for _, gvk := range c.kinds {
+		list, err := c.kubeClient.ListLabelledObjects(ctx, gvk, namespace, clusterv1.ClusterNameLabel)
+		if err != nil {
+			if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for i := range list.Items {
+			if keep(&list.Items[i]) {
+				resources = append(resources, NewResource(&list.Items[i]))
+			}
+		}
+	}
+	return resources, nil
+}
+
+// NewResource describes an object.
+func NewResource(obj *unstructured.Unstructured) Resource {
+	return Resource{
+		APIVersion:  obj.GetAPIVersion(),
+		Kind:        obj.GetKind(),
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		ClusterName: obj.GetLabels()[clusterv1.ClusterNameLabel],
+		CreatedAt:   obj.GetCreationTimestamp().Time,
+		Deleting:    obj.GetDeletionTimestamp() != nil,
+		Finalizers:  obj.GetFinalizers(),
+		Reason:      failingCondition(obj),
+	}
+}
+
+// failingCondition describes the condition explaining why an object is not
+// ready, preferring conditions reporting a failure, e.g. "LoadBalancerReady:
+// DeletionFailed: ...", over Ready. It returns "" if no condition is failing.
+func failingCondition(obj *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+
+	var failing string
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok || condition["status"] != "False" {
+			continue
+		}
+		parts := make([]string, 0, 3)
+		for _, key := range []string{"type", "reason", "message"} {
+			if value, ok := condition[key].(string); ok && value != "" {
+				parts = append(parts, value)
+			}
+		}
+		description := strings.Join(parts, ": ")
+
+		if reason, _ := condition["reason"].(string); strings.Contains(reason, "Failed") {
+			return description
+		}
+		if failing == "" || condition["type"] == "Ready" {
+			failing = description
+		}
+	}
+	return failing
+}
+
+// Delete deletes a resource. A resource already gone is not an error.
+func (c *Collector) Delete(ctx context.Context, resource Resource) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(resource.APIVersion)
+	obj.SetKind(resource.Kind)
+	obj.SetNamespace(resource.Namespace)
+	obj.SetName(resource.Name)
+	if err := c.kubeClient.DeleteObject(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// ForceDelete deletes a resource and removes its finalizers, so it is gone
+// without its controller tearing down what it manages.
+func (c *Collector) ForceDelete(ctx context.Context, resource Resource) error {
+	if err := c.Delete(ctx, resource); err != nil {
+		return err
+	}
+
+	gvk := schema.FromAPIVersionAndKind(resource.APIVersion, resource.Kind)
+	obj, err := c.kubeClient.GetObject(ctx, gvk, resource.Namespace, resource.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(obj.GetFinalizers()) == 0 {
+		return nil
+	}
+	obj.SetFinalizers(nil)
+	if err := c.kubeClient.UpdateObject(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// Token returns a confirmation token for a set of resources. Deletions are
+// confirmed with the token of the resources that were reviewed, so nothing
+// found since is deleted without being reviewed.
+func Token(resources []Resource) string {
+	keys := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		keys = append(keys, resource.key())
+	}
+	sort.Strings(keys)
+
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// String describes a resource, e.g. "MachineDeployment default/prod-md-0".
+func (r Resource) String() string {
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
@@ -0,0 +1,155 @@
+package gc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	meta := func(name, clusterName string, created metav1.Time) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{clusterv1.ClusterNameLabel: clusterName},
+			CreationTimestamp: created,
+		}
+	}
+	awsMachine := &unstructured.Unstructured{}
+	awsMachine.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	awsMachine.SetKind("AWSMachine")
+	awsMachine.SetNamespace("default")
+	awsMachine.SetName("gone-control-plane-abc12")
+	awsMachine.SetLabels(map[string]string{clusterv1.ClusterNameLabel: "gone"})
+	awsMachine.SetCreationTimestamp(old)
+	awsMachine.SetFinalizers([]string{"awsmachine.infrastructure.cluster.x-k8s.io"})
+
+	objects := []client.Object{
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "default"}},
+		&clusterv1.MachineDeployment{ObjectMeta: meta("live-md-0", "live", old)},
+		&clusterv1.MachineDeployment{ObjectMeta: meta("gone-md-0", "gone", old)},
+		&clusterv1.MachineDeployment{ObjectMeta: meta("new-md-0", "new", metav1.Now())},
+		&corev1.Secret{ObjectMeta: meta("gone-kubeconfig", "gone", old)},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "default", CreationTimestamp: old}},
+		awsMachine,
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	collector := NewCollector(kube.NewClientFromClient(fakeClient, "default"), DefaultKinds, DefaultMinAge)
+
+	orphans, err := collector.Find(ctx, "default")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(orphans))
+	for _, orphan := range orphans {
+		names = append(names, orphan.String())
+		assert.Equal(t, "gone", orphan.ClusterName)
+	}
+	assert.Equal(t, []string{
+		"MachineDeployment default/gone-md-0",
+		"AWSMachine default/gone-control-plane-abc12",
+		"Secret default/gone-kubeconfig",
+	}, names)
+	assert.Equal(t, []string{"awsmachine.infrastructure.cluster.x-k8s.io"}, orphans[1].Finalizers)
+
+	t.Run("token identifies the set of resources", func(t *testing.T) {
+		assert.Equal(t, Token(orphans), Token([]Resource{orphans[2], orphans[0], orphans[1]}))
+		assert.NotEqual(t, Token(orphans), Token(orphans[:2]))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for _, orphan := range orphans {
+			require.NoError(t, collector.Delete(ctx, orphan))
+		}
+		// Already deleted resources are not errors
+		require.NoError(t, collector.Delete(ctx, orphans[0]))
+
+		// Resources with finalizers remain until they are removed
+		orphans, err := collector.Find(ctx, "default")
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "AWSMachine default/gone-control-plane-abc12", orphans[0].String())
+		assert.True(t, orphans[0].Deleting)
+
+		var md clusterv1.MachineDeployment
+		assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "live-md-0"}, &md))
+	})
+}
+
+func TestCollector_Blocking(t *testing.T) {
+	ctx := context.Background()
+	deleting := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	awsCluster := &unstructured.Unstructured{}
+	awsCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	awsCluster.SetKind("AWSCluster")
+	awsCluster.SetNamespace("default")
+	awsCluster.SetName("stuck-abc12")
+	awsCluster.SetLabels(map[string]string{clusterv1.ClusterNameLabel: "stuck"})
+	awsCluster.SetFinalizers([]string{"awscluster.infrastructure.cluster.x-k8s.io"})
+	awsCluster.SetDeletionTimestamp(&deleting)
+	awsCluster.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "Deleting"},
+			map[string]interface{}{
+				"type": "VpcReady", "status": "False", "reason": "DeletionFailed",
+				"message": "DependencyViolation: the vpc has dependencies and cannot be deleted",
+			},
+		},
+	}
+
+	objects := []client.Object{
+		awsCluster,
+		&clusterv1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "stuck-md-0", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "stuck"},
+		}},
+		&clusterv1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{
+			Name: "other-md-0", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "other"},
+			Finalizers: []string{"cluster.x-k8s.io/machinedeployment"},
+		}},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	collector := NewCollector(kube.NewClientFromClient(fakeClient, "default"), DefaultKinds, DefaultMinAge)
+
+	blockers, err := collector.Blocking(ctx, "default", "stuck")
+	require.NoError(t, err)
+	require.Len(t, blockers, 1)
+	assert.Equal(t, "AWSCluster default/stuck-abc12", blockers[0].String())
+	assert.True(t, blockers[0].Deleting)
+	assert.Equal(t, "VpcReady: DeletionFailed: DependencyViolation: the vpc has dependencies and cannot be deleted", blockers[0].Reason)
+
+	t.Run("force delete", func(t *testing.T) {
+		require.NoError(t, collector.ForceDelete(ctx, blockers[0]))
+		// Already deleted resources are not errors
+		require.NoError(t, collector.ForceDelete(ctx, blockers[0]))
+
+		blockers, err := collector.Blocking(ctx, "default", "stuck")
+		require.NoError(t, err)
+		assert.Empty(t, blockers)
+
+		var md clusterv1.MachineDeployment
+		assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "other-md-0"}, &md))
+	})
+}
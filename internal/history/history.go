@@ -0,0 +1,228 @@
+// Package history records the operations performed through the server so they
+// can be audited, listed and, where possible, rolled back.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// OutcomeSucceeded marks an operation that completed without error.
+	OutcomeSucceeded = "succeeded"
+
+	// OutcomeFailed marks an operation that returned an error.
+	OutcomeFailed = "failed"
+
+	// historyLabel marks ConfigMaps holding operation records.
+	historyLabel = "capi-mcp.io/operation-history"
+
+	// clusterLabel records the cluster an operation targeted.
+	clusterLabel = "capi-mcp.io/cluster"
+
+	// toolLabel records the tool that performed an operation.
+	toolLabel = "capi-mcp.io/tool"
+
+	// operationKey is the ConfigMap data key holding the JSON-encoded operation.
+	operationKey = "operation"
+)
+
+// Operation is a single recorded operation.
+type Operation struct {
+	ID            string            `json:"id"`
+	Tool          string            `json:"tool"`
+	ClusterName   string            `json:"clusterName,omitempty"`
+	Namespace     string            `json:"namespace,omitempty"`
+	Identity      string            `json:"identity"`
+	CorrelationID string            `json:"correlationId,omitempty"`
+	Outcome       string            `json:"outcome"`
+	Error         string            `json:"error,omitempty"`
+	StartedAt     time.Time         `json:"startedAt"`
+	Duration      time.Duration     `json:"duration"`
+	Parameters    map[string]string `json:"parameters,omitempty"`
+}
+
+// Filter selects operations to list. Zero values match everything.
+type Filter struct {
+	ClusterName string
+	Namespaces  []string
+	Since       time.Time
+	Until       time.Time
+	Limit       int
+}
+
+// Store persists operation records.
+type Store interface {
+	// Record persists an operation, assigning an ID if it has none.
+	Record(ctx context.Context, op Operation) error
+
+	// List returns the operations matching the filter, newest first.
+	List(ctx context.Context, filter Filter) ([]Operation, error)
+}
+
+// ConfigMapStore stores each operation as a labelled ConfigMap in a single
+// namespace so the history survives server restarts without extra CRDs.
+type ConfigMapStore struct {
+	kubeClient *kube.Client
+	namespace  string
+	maxEntries int
+}
+
+// NewConfigMapStore creates a store in the given namespace that keeps at most
+// maxEntries operations, pruning the oldest. A maxEntries of zero keeps all.
+func NewConfigMapStore(kubeClient *kube.Client, namespace string, maxEntries int) *ConfigMapStore {
+	return &ConfigMapStore{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		maxEntries: maxEntries,
+	}
+}
+
+// Record persists an operation.
+func (s *ConfigMapStore) Record(ctx context.Context, op Operation) error {
+	if op.ID == "" {
+		op.ID = uuid.New().String()
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	labels := map[string]string{
+		historyLabel: "true",
+		toolLabel:    strings.ReplaceAll(op.Tool, "_", "-"),
+	}
+	if op.ClusterName != "" {
+		labels[clusterLabel] = op.ClusterName
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capi-mcp-operation-" + op.ID,
+			Namespace: s.namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{operationKey: string(data)},
+	}
+	if err := s.kubeClient.CreateObject(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to record operation: %w", err)
+	}
+
+	return s.prune(ctx)
+}
+
+// List returns the operations matching the filter, newest first.
+func (s *ConfigMapStore) List(ctx context.Context, filter Filter) ([]Operation, error) {
+	labels := map[string]string{historyLabel: "true"}
+	if filter.ClusterName != "" {
+		labels[clusterLabel] = filter.ClusterName
+	}
+
+	operations, _, err := s.list(ctx, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]Operation, 0, len(operations))
+	for _, op := range operations {
+		if filter.matches(op) {
+			matched = append(matched, op)
+		}
+	}
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, nil
+}
+
+// list returns decoded operations and their ConfigMaps, newest first.
+// ConfigMaps that cannot be decoded are skipped.
+func (s *ConfigMapStore) list(ctx context.Context, labels map[string]string) ([]Operation, []*corev1.ConfigMap, error) {
+	configMaps, err := s.kubeClient.ListConfigMaps(ctx, s.namespace, labels)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list operations: %w", err)
+	}
+
+	operations := make([]Operation, 0, len(configMaps.Items))
+	sources := make([]*corev1.ConfigMap, 0, len(configMaps.Items))
+	for i := range configMaps.Items {
+		var op Operation
+		if err := json.Unmarshal([]byte(configMaps.Items[i].Data[operationKey]), &op); err != nil {
+			continue
+		}
+		operations = append(operations, op)
+		sources = append(sources, &configMaps.Items[i])
+	}
+
+	sort.Sort(byNewest{operations, sources})
+	return operations, sources, nil
+}
+
+// prune deletes the oldest operations beyond maxEntries.
+func (s *ConfigMapStore) prune(ctx context.Context) error {
+	if s.maxEntries <= 0 {
+		return nil
+	}
+
+	_, sources, err := s.list(ctx, map[string]string{historyLabel: "true"})
+	if err != nil {
+		return err
+	}
+	for i := s.maxEntries; i < len(sources); i++ {
+		if err := s.kubeClient.DeleteObject(ctx, sources[i]); err != nil {
+			return fmt.Errorf("failed to prune operation history: %w", err)
+		}
+	}
+	return nil
+}
+
+// matches reports whether an operation satisfies the filter.
+func (f Filter) matches(op Operation) bool {
+	if f.ClusterName != "" && op.ClusterName != f.ClusterName {
+		return false
+	}
+	if !f.Since.IsZero() && op.StartedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && op.StartedAt.After(f.Until) {
+		return false
+	}
+	if len(f.Namespaces) > 0 {
+		for _, namespace := range f.Namespaces {
+			if op.Namespace == namespace {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// byNewest sorts operations and their ConfigMaps by start time, newest first.
+type byNewest struct {
+	operations []Operation
+	sources    []*corev1.ConfigMap
+}
+
+func (b byNewest) Len() int { return len(b.operations) }
+
+func (b byNewest) Less(i, j int) bool {
+	return b.operations[i].StartedAt.After(b.operations[j].StartedAt)
+}
+
+func (b byNewest) Swap(i, j int) {
+	b.operations[i], b.operations[j] = b.operations[j], b.operations[i]
+	b.sources[i], b.sources[j] = b.sources[j], b.sources[i]
+}
@@ -0,0 +1,119 @@
+package history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func setupTestStore(t *testing.T, maxEntries int) (*ConfigMapStore, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	return NewConfigMapStore(kube.NewClientFromClient(fakeClient, "default"), "capi-system", maxEntries), fakeClient
+}
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	store, fakeClient := setupTestStore(t, 0)
+	operations := []Operation{
+		{Tool: "create_cluster", ClusterName: "alpha", Namespace: "team-a", Identity: "admin", Outcome: OutcomeSucceeded, StartedAt: base},
+		{Tool: "scale_cluster", ClusterName: "alpha", Namespace: "team-a", Identity: "admin", Outcome: OutcomeSucceeded, StartedAt: base.Add(time.Hour),
+			Parameters: map[string]string{"nodePoolName": "workers", "replicas": "5", "oldReplicas": "3"}},
+		{Tool: "delete_cluster", ClusterName: "beta", Namespace: "team-b", Identity: "team-b-agent", Outcome: OutcomeFailed, Error: "cluster not found", StartedAt: base.Add(2 * time.Hour)},
+	}
+	for _, op := range operations {
+		require.NoError(t, store.Record(ctx, op))
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	require.NoError(t, fakeClient.List(ctx, configMaps, client.InNamespace("capi-system")))
+	assert.Len(t, configMaps.Items, 3)
+
+	tests := []struct {
+		name      string
+		filter    Filter
+		wantTools []string
+	}{
+		{
+			name:      "all operations newest first",
+			filter:    Filter{},
+			wantTools: []string{"delete_cluster", "scale_cluster", "create_cluster"},
+		},
+		{
+			name:      "by cluster",
+			filter:    Filter{ClusterName: "alpha"},
+			wantTools: []string{"scale_cluster", "create_cluster"},
+		},
+		{
+			name:      "by time range",
+			filter:    Filter{Since: base.Add(30 * time.Minute), Until: base.Add(90 * time.Minute)},
+			wantTools: []string{"scale_cluster"},
+		},
+		{
+			name:      "by namespace",
+			filter:    Filter{Namespaces: []string{"team-b"}},
+			wantTools: []string{"delete_cluster"},
+		},
+		{
+			name:      "limit",
+			filter:    Filter{Limit: 1},
+			wantTools: []string{"delete_cluster"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.List(ctx, tt.filter)
+			require.NoError(t, err)
+
+			tools := make([]string, 0, len(result))
+			for _, op := range result {
+				assert.NotEmpty(t, op.ID)
+				tools = append(tools, op.Tool)
+			}
+			assert.Equal(t, tt.wantTools, tools)
+		})
+	}
+
+	t.Run("round trips operation fields", func(t *testing.T) {
+		result, err := store.List(ctx, Filter{ClusterName: "alpha", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "3", result[0].Parameters["oldReplicas"])
+		assert.True(t, base.Add(time.Hour).Equal(result[0].StartedAt))
+	})
+}
+
+func TestConfigMapStorePrune(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	store, _ := setupTestStore(t, 2)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, store.Record(ctx, Operation{
+			Tool:      "scale_cluster",
+			StartedAt: base.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+
+	result, err := store.List(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.True(t, base.Add(3*time.Minute).Equal(result[0].StartedAt))
+	assert.True(t, base.Add(2*time.Minute).Equal(result[1].StartedAt))
+}
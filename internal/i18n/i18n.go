@@ -0,0 +1,218 @@
+// Package i18n gives user-facing error messages in the language of the user.
+// Errors carry a catalog key and arguments next to their English message (see
+// errors.Error.MessageKey); the catalog formats the message of the key in the
+// requested locale, and adds remediation text for each error code. Error codes
+// are never translated, so clients keep handling errors by code.
+//
+// Catalog messages are fmt format strings taking the arguments of the error in
+// order; translations may reorder them with explicit argument indexes, e.g.
+// %[2]s. A locale is bundled for English, German and Spanish, and operators
+// can add locales or override messages.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// DefaultLocale is the locale of the English messages errors are created
+// with, and the last fallback for messages missing from other locales.
+const DefaultLocale = "en"
+
+// remediationPrefix prefixes the keys of the remediation text of error codes,
+// e.g. remediation.NOT_FOUND.
+const remediationPrefix = "remediation."
+
+//go:embed locales/*.json
+var bundled embed.FS
+
+// Catalog holds the messages of each locale.
+type Catalog struct {
+	defaultLocale string
+	messages      map[string]map[string]string // by locale, then key
+}
+
+// NewCatalog creates a catalog of the bundled locales, answering in
+// defaultLocale when no other locale is requested.
+func NewCatalog(defaultLocale string) (*Catalog, error) {
+	c := &Catalog{defaultLocale: DefaultLocale, messages: make(map[string]map[string]string)}
+
+	entries, err := bundled.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundled locales: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := bundled.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundled locale %s: %w", entry.Name(), err)
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("failed to parse bundled locale %s: %w", entry.Name(), err)
+		}
+		c.Add(strings.TrimSuffix(entry.Name(), ".json"), messages)
+	}
+
+	if err := c.SetDefaultLocale(defaultLocale); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Add adds messages to a locale, replacing the messages with the same keys.
+func (c *Catalog) Add(locale string, messages map[string]string) {
+	locale = normalize(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]string)
+	}
+	for key, message := range messages {
+		c.messages[locale][key] = message
+	}
+}
+
+// SetDefaultLocale sets the locale used when no other locale is requested.
+func (c *Catalog) SetDefaultLocale(locale string) error {
+	locale = normalize(locale)
+	if _, ok := c.messages[locale]; !ok {
+		return fmt.Errorf("unknown locale %q, expected one of %s", locale, strings.Join(c.Locales(), ", "))
+	}
+	c.defaultLocale = locale
+	return nil
+}
+
+// DefaultLocale returns the locale used when no other locale is requested.
+func (c *Catalog) DefaultLocale() string {
+	return c.defaultLocale
+}
+
+// Locales returns the locales of the catalog.
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Match returns the locale of the catalog best matching a list of language
+// tags in the format of the Accept-Language header, e.g. "de-CH, de;q=0.9,
+// en;q=0.5". A tag matches its locale, or the locale of its language. The
+// default locale is returned when no tag matches.
+func (c *Catalog) Match(tags string) string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var candidates []weighted
+	for _, part := range strings.Split(tags, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if tag = normalize(tag); tag != "" && tag != "*" && q > 0 {
+			candidates = append(candidates, weighted{tag: tag, q: q})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, candidate := range candidates {
+		if _, ok := c.messages[candidate.tag]; ok {
+			return candidate.tag
+		}
+		language, _, _ := strings.Cut(candidate.tag, "-")
+		if _, ok := c.messages[language]; ok {
+			return language
+		}
+	}
+	return c.defaultLocale
+}
+
+// Message formats the message of a key in a locale, falling back to the
+// language of the locale, the default locale and English in turn. Arguments
+// that are errors are replaced by their localized message, and lists of errors
+// by their numbered localized messages, one per line. It reports false when
+// no locale has the key.
+func (c *Catalog) Message(locale, key string, args ...interface{}) (string, bool) {
+	format, ok := c.lookup(locale, key)
+	if !ok {
+		return "", false
+	}
+
+	localized := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case []error:
+			lines := make([]string, len(arg))
+			for j, err := range arg {
+				lines[j] = fmt.Sprintf("%d. %s: %s", j+1, errors.GetErrorCode(err), c.Localize(locale, err))
+			}
+			localized[i] = strings.Join(lines, "\n")
+		case error:
+			localized[i] = c.Localize(locale, arg)
+		default:
+			localized[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, localized...), true
+}
+
+// Localize returns the user message of an error in a locale: the catalog
+// message of its key, or errors.GetUserMessage when it has none or the key is
+// not in the catalog.
+func (c *Catalog) Localize(locale string, err error) string {
+	var e *errors.Error
+	if stderrors.As(err, &e) && e.MessageKey != "" {
+		if message, ok := c.Message(locale, e.MessageKey, e.MessageArgs...); ok {
+			return message
+		}
+	}
+	return errors.GetUserMessage(err)
+}
+
+// Remediation returns the remediation text of an error code in a locale, or
+// "" if the catalog has none.
+func (c *Catalog) Remediation(locale string, code errors.ErrorCode) string {
+	message, _ := c.lookup(locale, remediationPrefix+string(code))
+	return message
+}
+
+// lookup returns the message format of a key in a locale or its fallbacks.
+func (c *Catalog) lookup(locale, key string) (string, bool) {
+	locale = normalize(locale)
+	language, _, _ := strings.Cut(locale, "-")
+	for _, candidate := range []string{locale, language, c.defaultLocale, DefaultLocale} {
+		if format, ok := c.messages[candidate][key]; ok {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// normalize returns a language tag in lowercase with hyphens, e.g. pt_BR
+// becomes pt-br.
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
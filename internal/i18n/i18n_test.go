@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"encoding/json"
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+var formatVerb = regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+
+func TestBundledLocales(t *testing.T) {
+	readLocale := func(t *testing.T, name string) map[string]string {
+		data, err := bundled.ReadFile(path.Join("locales", name))
+		require.NoError(t, err)
+		var messages map[string]string
+		require.NoError(t, json.Unmarshal(data, &messages))
+		return messages
+	}
+	english := readLocale(t, "en.json")
+
+	entries, err := bundled.ReadDir("locales")
+	require.NoError(t, err)
+	for _, entry := range entries {
+		t.Run(entry.Name(), func(t *testing.T) {
+			messages := readLocale(t, entry.Name())
+			for key, message := range english {
+				translated, ok := messages[key]
+				if assert.True(t, ok, "missing %s", key) {
+					assert.Len(t, formatVerb.FindAllString(translated, -1), len(formatVerb.FindAllString(message, -1)),
+						"arguments of %s", key)
+				}
+			}
+			for key := range messages {
+				assert.Contains(t, english, key, "%s is not an English message", key)
+			}
+		})
+	}
+}
+
+func TestNewCatalog(t *testing.T) {
+	catalog, err := NewCatalog("de")
+	require.NoError(t, err)
+	assert.Equal(t, "de", catalog.DefaultLocale())
+	assert.Equal(t, []string{"de", "en", "es"}, catalog.Locales())
+
+	_, err = NewCatalog("xx")
+	assert.ErrorContains(t, err, `unknown locale "xx"`)
+}
+
+func TestCatalog_Match(t *testing.T) {
+	catalog, err := NewCatalog(DefaultLocale)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		tags     string
+		expected string
+	}{
+		{name: "empty", tags: "", expected: "en"},
+		{name: "exact", tags: "de", expected: "de"},
+		{name: "region falls back to language", tags: "es-MX", expected: "es"},
+		{name: "underscore and case", tags: "DE_at", expected: "de"},
+		{name: "highest quality first", tags: "en;q=0.5, es;q=0.9", expected: "es"},
+		{name: "unknown skipped", tags: "ja, de;q=0.8", expected: "de"},
+		{name: "rejected language", tags: "de;q=0, es;q=0.1", expected: "es"},
+		{name: "wildcard", tags: "*", expected: "en"},
+		{name: "no match", tags: "ja, zh-CN", expected: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, catalog.Match(tt.tags))
+		})
+	}
+}
+
+func TestCatalog_Localize(t *testing.T) {
+	catalog, err := NewCatalog(DefaultLocale)
+	require.NoError(t, err)
+	catalog.Add("de", map[string]string{"validation.cluster_name.empty": "Bitte einen Clusternamen angeben"})
+	catalog.Add("fr", map[string]string{"validation.required": "%s est obligatoire"})
+
+	validator := validation.NewValidator()
+	invalidCluster := validator.ValidateCreateClusterInput(map[string]interface{}{
+		"clusterName":       "Invalid_Name",
+		"kubernetesVersion": "1.31",
+	})
+	require.Error(t, invalidCluster)
+
+	tests := []struct {
+		name     string
+		locale   string
+		err      error
+		expected string
+	}{
+		{
+			name:     "default locale keeps the English message",
+			locale:   "en",
+			err:      invalidCluster,
+			expected: errors.GetUserMessage(invalidCluster),
+		},
+		{
+			name:   "combined errors are localized one by one",
+			locale: "es",
+			err:    invalidCluster,
+			expected: "Varios errores de validación:\n" +
+				"1. INVALID_INPUT: el nombre del clúster debe contener solo caracteres alfanuméricos en minúscula o '-', y debe empezar y terminar con un carácter alfanumérico\n" +
+				"2. INVALID_INPUT: templateName es obligatorio y debe ser una cadena\n" +
+				"3. INVALID_INPUT: la versión de Kubernetes debe tener el formato 'vX.Y.Z' (p. ej., v1.28.0)",
+		},
+		{
+			name:     "arguments",
+			locale:   "de",
+			err:      validator.ValidateAWSRegion("mars"),
+			expected: "'mars' ist kein gültiges AWS-Regionsformat - verwenden Sie ein Format wie 'us-west-2' oder 'eu-central-1'",
+		},
+		{
+			name:     "overridden message",
+			locale:   "de",
+			err:      validator.ValidateClusterName(""),
+			expected: "Bitte einen Clusternamen angeben",
+		},
+		{
+			name:     "added locale",
+			locale:   "fr-CA",
+			err:      errors.New(errors.CodeInvalidInput, "clusterName is required and must be a string").WithMessageKey("validation.required", "clusterName"),
+			expected: "clusterName est obligatoire",
+		},
+		{
+			name:     "message missing from the locale falls back to English",
+			locale:   "fr",
+			err:      validator.ValidateClusterName(""),
+			expected: "cluster name cannot be empty",
+		},
+		{
+			name:     "unknown key",
+			locale:   "de",
+			err:      errors.New(errors.CodeNotFound, "cluster 'a' not found").WithMessageKey("cluster.not_found"),
+			expected: "cluster 'a' not found",
+		},
+		{
+			name:     "error without a key",
+			locale:   "de",
+			err:      errors.New(errors.CodeNotFound, "cluster 'a' not found"),
+			expected: "cluster 'a' not found",
+		},
+		{
+			name:     "wrapping error message",
+			locale:   "de",
+			err:      errors.Wrap(validator.ValidateClusterName(""), errors.CodeInvalidInput, "invalid input parameters"),
+			expected: "invalid input parameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, catalog.Localize(tt.locale, tt.err))
+		})
+	}
+}
+
+func TestCatalog_Remediation(t *testing.T) {
+	catalog, err := NewCatalog("es")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Elija otro nombre o use el recurso existente.", catalog.Remediation("", errors.CodeAlreadyExists))
+	assert.Equal(t, "Choose another name, or use the existing resource.", catalog.Remediation("en-GB", errors.CodeAlreadyExists))
+	assert.Empty(t, catalog.Remediation("en", errors.ErrorCode("UNKNOWN")))
+}
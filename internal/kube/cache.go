@@ -0,0 +1,111 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// cachedKinds are the kinds node counts are read from, which StartCache
+// keeps in an informer cache.
+var cachedKinds = []client.Object{
+	&clusterv1.Machine{},
+	&clusterv1.MachineDeployment{},
+	&expv1.MachinePool{},
+}
+
+// listCache is a synced informer cache and the list kinds it serves.
+type listCache struct {
+	reader client.Reader
+	lists  map[schema.GroupVersionKind]bool
+}
+
+// StartCache starts an informer cache of the Machines, MachineDeployments and
+// MachinePools of all namespaces, and serves the lists of those kinds made
+// through Cached from it once it has synced. It blocks until then; the
+// informers run until ctx is cancelled. A management cluster without the
+// MachinePool CRD has its MachinePools listed from the API server.
+func (c *Client) StartCache(ctx context.Context) error {
+	if c.config == nil {
+		return fmt.Errorf("the informer cache needs a client created from a REST config")
+	}
+
+	informers, err := cache.New(c.config, cache.Options{
+		Scheme:                      c.client.Scheme(),
+		ReaderFailOnMissingInformer: true,
+		DefaultTransform:            cache.TransformStripManagedFields(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create informer cache: %w", err)
+	}
+
+	lists := make(map[schema.GroupVersionKind]bool, len(cachedKinds))
+	for _, obj := range cachedKinds {
+		if _, err := informers.GetInformer(ctx, obj); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("failed to create informer for %T: %w", obj, err)
+		}
+		gvk, err := apiutil.GVKForObject(obj, c.client.Scheme())
+		if err != nil {
+			return err
+		}
+		gvk.Kind += "List"
+		lists[gvk] = true
+	}
+
+	started := make(chan error, 1)
+	go func() { started <- informers.Start(ctx) }()
+	if !informers.WaitForCacheSync(ctx) {
+		select {
+		case err := <-started:
+			if err != nil {
+				return fmt.Errorf("failed to start informer cache: %w", err)
+			}
+		default:
+		}
+		return fmt.Errorf("informer cache did not sync: %w", ctx.Err())
+	}
+
+	c.cache.Store(&listCache{reader: informers, lists: lists})
+	return nil
+}
+
+// Cached returns a client that lists Machines, MachineDeployments and
+// MachinePools from the informer cache once StartCache has synced it, or the
+// client itself before. Cached lists may lag behind the API server, so only
+// reads that tolerate this, such as node counts, should use it.
+func (c *Client) Cached() *Client {
+	lists := c.cache.Load()
+	if lists == nil {
+		return c
+	}
+	return &Client{
+		client:          cachedClient{WithWatch: c.client, cache: lists},
+		namespace:       c.namespace,
+		network:         c.network,
+		workloadClients: c.workloadClients,
+	}
+}
+
+// cachedClient serves the lists of the kinds in its cache from it and every
+// other call from the API server.
+type cachedClient struct {
+	client.WithWatch
+	cache *listCache
+}
+
+func (c cachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if gvk, err := apiutil.GVKForObject(list, c.Scheme()); err == nil && c.cache.lists[gvk] {
+		return c.cache.reader.List(ctx, list, opts...)
+	}
+	return c.WithWatch.List(ctx, list, opts...)
+}
@@ -0,0 +1,57 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClientCached(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+
+	machineDeployment := func(replicas int32) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "workers",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "prod"},
+			},
+			Spec: clusterv1.MachineDeploymentSpec{ClusterName: "prod", Replicas: int32Ptr(replicas)},
+		}
+	}
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "test-namespace"}}
+
+	live := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machineDeployment(3)).Build()
+	cached := fake.NewClientBuilder().WithScheme(scheme).WithObjects(machineDeployment(2)).Build()
+	c := NewClientFromClient(live, "test-namespace")
+
+	// Before the cache has synced, lists are read from the API server
+	assert.Same(t, c, c.Cached())
+
+	c.cache.Store(&listCache{
+		reader: cached,
+		lists:  map[schema.GroupVersionKind]bool{clusterv1.GroupVersion.WithKind("MachineDeploymentList"): true},
+	})
+
+	mds, err := c.Cached().ListMachineDeployments(ctx, "prod")
+	require.NoError(t, err)
+	require.Len(t, mds.Items, 1)
+	assert.Equal(t, int32(2), *mds.Items[0].Spec.Replicas, "cached kinds are listed from the cache")
+
+	clusters, err := c.Cached().ListClusters(ctx)
+	require.NoError(t, err)
+	assert.Len(t, clusters.Items, 1, "other kinds are listed from the API server")
+
+	mds, err = c.ListMachineDeployments(ctx, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *mds.Items[0].Spec.Replicas, "the client itself keeps reading the API server")
+}
@@ -3,13 +3,21 @@ package kube
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -20,14 +28,38 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Client wraps controller-runtime client for CAPI operations.
+// ClusterctlProviderListGVK identifies the clusterctl provider inventory list type.
+var ClusterctlProviderListGVK = schema.GroupVersionKind{
+	Group:   "clusterctl.cluster.x-k8s.io",
+	Version: "v1alpha3",
+	Kind:    "ProviderList",
+}
+
+// Client wraps controller-runtime client for CAPI operations. The wrapped
+// client is injectable, so tests can run it against a fake client or an
+// envtest API server.
 type Client struct {
-	client    client.Client
+	client    client.WithWatch
 	namespace string
+	network   NetworkOptions
+
+	// config is the REST config of the client, nil for injected clients.
+	config *rest.Config
+	// cache serves node count lists once StartCache has synced it.
+	cache atomic.Pointer[listCache]
+
+	// workloadClients builds workload cluster clients; nil builds them with
+	// NewWorkloadClientFromKubeconfig.
+	workloadClients WorkloadClientFactory
 }
 
-// NewClient creates a new CAPI client wrapper.
-func NewClient(kubeconfig string, namespace string) (*Client, error) {
+// WorkloadClientFactory builds the client of a workload cluster from its
+// kubeconfig.
+type WorkloadClientFactory func(kubeconfig []byte, network NetworkOptions) (*WorkloadClient, error)
+
+// NewClient creates a new CAPI client wrapper. The network options apply to
+// the management cluster and to workload cluster clients built from it.
+func NewClient(kubeconfig string, namespace string, network NetworkOptions) (*Client, error) {
 	// Create the client configuration
 	var config *rest.Config
 	var err error
@@ -46,7 +78,41 @@ func NewClient(kubeconfig string, namespace string) (*Client, error) {
 		}
 	}
 
-	// Create a new scheme and add CAPI types
+	return NewClientFromConfig(config, namespace, network)
+}
+
+// NewClientFromConfig creates a CAPI client wrapper for the API server of a
+// REST config, such as that of an envtest environment.
+func NewClientFromConfig(config *rest.Config, namespace string, network NetworkOptions) (*Client, error) {
+	// Leave the caller's config untouched
+	config = rest.CopyConfig(config)
+	withCorrelationID(config)
+	if err := network.apply(config); err != nil {
+		return nil, err
+	}
+
+	sch, err := NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the client with watch support so callers can wait on events instead of polling
+	c, err := client.NewWithWatch(config, client.Options{Scheme: sch})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return &Client{
+		client:    c,
+		namespace: namespace,
+		network:   network,
+		config:    config,
+	}, nil
+}
+
+// NewScheme returns a scheme with the Kubernetes and CAPI types the client
+// works with.
+func NewScheme() (*runtime.Scheme, error) {
 	sch := runtime.NewScheme()
 	if err := scheme.AddToScheme(sch); err != nil {
 		return nil, fmt.Errorf("failed to add Kubernetes types to scheme: %w", err)
@@ -63,59 +129,151 @@ func NewClient(kubeconfig string, namespace string) (*Client, error) {
 	if err := expv1.AddToScheme(sch); err != nil {
 		return nil, fmt.Errorf("failed to add experimental types to scheme: %w", err)
 	}
-
-	// Create the client
-	c, err := client.New(config, client.Options{Scheme: sch})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+	if err := apiextensionsv1.AddToScheme(sch); err != nil {
+		return nil, fmt.Errorf("failed to add apiextensions types to scheme: %w", err)
 	}
+	return sch, nil
+}
 
+// NewClientFromClient wraps an existing controller-runtime client.
+// This is primarily useful for injecting fake clients in tests.
+func NewClientFromClient(c client.WithWatch, namespace string) *Client {
 	return &Client{
 		client:    c,
 		namespace: namespace,
-	}, nil
+	}
+}
+
+// Network returns the network options workload cluster clients should use.
+func (c *Client) Network() NetworkOptions {
+	return c.network
+}
+
+// SetWorkloadClientFactory replaces how clients of workload clusters are
+// built, e.g. to serve simulated workload clusters.
+func (c *Client) SetWorkloadClientFactory(factory WorkloadClientFactory) {
+	c.workloadClients = factory
+}
+
+// WorkloadClient builds the client of the workload cluster a kubeconfig
+// grants access to.
+func (c *Client) WorkloadClient(kubeconfig []byte) (*WorkloadClient, error) {
+	if c.workloadClients != nil {
+		return c.workloadClients(kubeconfig, c.network)
+	}
+	return NewWorkloadClientFromKubeconfig(kubeconfig, c.network)
+}
+
+// namespaceKey is the context key for a per-request namespace override.
+type namespaceKey struct{}
+
+// ContextWithNamespace returns a context in which namespaced client operations
+// target the given namespace instead of the client default.
+func ContextWithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace set with ContextWithNamespace, if any.
+func NamespaceFromContext(ctx context.Context) (string, bool) {
+	namespace, ok := ctx.Value(namespaceKey{}).(string)
+	return namespace, ok && namespace != ""
+}
+
+// Namespace returns the namespace operations using ctx target.
+func (c *Client) Namespace(ctx context.Context) string {
+	if namespace, ok := NamespaceFromContext(ctx); ok {
+		return namespace
+	}
+	return c.namespace
 }
 
 // ListClusters returns all clusters in the namespace.
 func (c *Client) ListClusters(ctx context.Context) (*clusterv1.ClusterList, error) {
 	clusters := &clusterv1.ClusterList{}
-	if err := c.client.List(ctx, clusters, client.InNamespace(c.namespace)); err != nil {
+	if err := c.client.List(ctx, clusters, client.InNamespace(c.Namespace(ctx))); err != nil {
 		return nil, fmt.Errorf("failed to list clusters: %w", err)
 	}
 	return clusters, nil
 }
 
+// ListClustersMatching returns the clusters in the namespace whose labels
+// match the selector.
+func (c *Client) ListClustersMatching(ctx context.Context, selector labels.Selector) (*clusterv1.ClusterList, error) {
+	clusters := &clusterv1.ClusterList{}
+	if err := c.client.List(ctx, clusters, client.InNamespace(c.Namespace(ctx)), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list clusters matching %q: %w", selector.String(), err)
+	}
+	return clusters, nil
+}
+
+// ListAllClusters returns the clusters in every namespace.
+func (c *Client) ListAllClusters(ctx context.Context) (*clusterv1.ClusterList, error) {
+	clusters := &clusterv1.ClusterList{}
+	if err := c.client.List(ctx, clusters); err != nil {
+		return nil, fmt.Errorf("failed to list clusters in all namespaces: %w", err)
+	}
+	return clusters, nil
+}
+
 // GetClusterByName retrieves a cluster by name.
 func (c *Client) GetClusterByName(ctx context.Context, name string) (*clusterv1.Cluster, error) {
 	cluster := &clusterv1.Cluster{}
 	key := types.NamespacedName{
-		Namespace: c.namespace,
+		Namespace: c.Namespace(ctx),
 		Name:      name,
 	}
 	if err := c.client.Get(ctx, key, cluster); err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, fmt.Errorf("cluster %s not found", name)
+			return nil, fmt.Errorf("cluster %s not found: %w", name, err)
 		}
 		return nil, fmt.Errorf("failed to get cluster: %w", err)
 	}
 	return cluster, nil
 }
 
+// WatchClusters opens a watch on the clusters in the namespace.
+// Callers are responsible for stopping the returned watch.
+func (c *Client) WatchClusters(ctx context.Context) (watch.Interface, error) {
+	w, err := c.client.Watch(ctx, &clusterv1.ClusterList{}, client.InNamespace(c.Namespace(ctx)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch clusters: %w", err)
+	}
+	return w, nil
+}
+
+// WatchAllClusters opens a watch on the clusters in every namespace.
+// Callers are responsible for stopping the returned watch.
+func (c *Client) WatchAllClusters(ctx context.Context) (watch.Interface, error) {
+	w, err := c.client.Watch(ctx, &clusterv1.ClusterList{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch clusters in all namespaces: %w", err)
+	}
+	return w, nil
+}
+
 // CreateCluster creates a new cluster.
 func (c *Client) CreateCluster(ctx context.Context, cluster *clusterv1.Cluster) error {
-	cluster.Namespace = c.namespace
+	cluster.Namespace = c.Namespace(ctx)
 	if err := c.client.Create(ctx, cluster); err != nil {
 		return fmt.Errorf("failed to create cluster: %w", err)
 	}
 	return nil
 }
 
+// UpdateCluster updates a cluster.
+func (c *Client) UpdateCluster(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if err := c.client.Update(ctx, cluster); err != nil {
+		return fmt.Errorf("failed to update cluster: %w", err)
+	}
+	return nil
+}
+
 // DeleteCluster deletes a cluster.
 func (c *Client) DeleteCluster(ctx context.Context, name string) error {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
-			Namespace: c.namespace,
+			Namespace: c.Namespace(ctx),
 		},
 	}
 	if err := c.client.Delete(ctx, cluster); err != nil {
@@ -132,7 +290,7 @@ func (c *Client) GetMachineDeployment(ctx context.Context, clusterName, mdName s
 	// List all MachineDeployments for the cluster
 	mdList := &clusterv1.MachineDeploymentList{}
 	if err := c.client.List(ctx, mdList,
-		client.InNamespace(c.namespace),
+		client.InNamespace(c.Namespace(ctx)),
 		client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName},
 	); err != nil {
 		return nil, fmt.Errorf("failed to list machine deployments: %w", err)
@@ -145,7 +303,7 @@ func (c *Client) GetMachineDeployment(ctx context.Context, clusterName, mdName s
 		}
 	}
 
-	return nil, fmt.Errorf("machine deployment %s not found in cluster %s", mdName, clusterName)
+	return nil, apierrors.NewNotFound(clusterv1.GroupVersion.WithResource("machinedeployments").GroupResource(), mdName)
 }
 
 // UpdateMachineDeployment updates a MachineDeployment.
@@ -159,7 +317,7 @@ func (c *Client) UpdateMachineDeployment(ctx context.Context, md *clusterv1.Mach
 // ListMachineDeployments lists all MachineDeployments for a cluster.
 func (c *Client) ListMachineDeployments(ctx context.Context, clusterName string) (*clusterv1.MachineDeploymentList, error) {
 	mdList := &clusterv1.MachineDeploymentList{}
-	if err := c.client.List(ctx, mdList, client.InNamespace(c.namespace), client.MatchingLabels{
+	if err := c.client.List(ctx, mdList, client.InNamespace(c.Namespace(ctx)), client.MatchingLabels{
 		clusterv1.ClusterNameLabel: clusterName,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to list machine deployments: %w", err)
@@ -167,6 +325,104 @@ func (c *Client) ListMachineDeployments(ctx context.Context, clusterName string)
 	return mdList, nil
 }
 
+// ListClusterMachines lists all Machines of a cluster, of its control plane
+// and its node pools.
+func (c *Client) ListClusterMachines(ctx context.Context, clusterName string) (*clusterv1.MachineList, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := c.client.List(ctx, machineList,
+		client.InNamespace(c.Namespace(ctx)),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list machines: %w", err)
+	}
+	return machineList, nil
+}
+
+// ListControlPlaneMachines lists the control plane Machines for a cluster.
+// Clusters with a managed control plane have none.
+func (c *Client) ListControlPlaneMachines(ctx context.Context, clusterName string) (*clusterv1.MachineList, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := c.client.List(ctx, machineList,
+		client.InNamespace(c.Namespace(ctx)),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName},
+		client.HasLabels{clusterv1.MachineControlPlaneLabel},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list control plane machines: %w", err)
+	}
+	return machineList, nil
+}
+
+// ListMachineDeploymentMachines lists the Machines of a MachineDeployment.
+func (c *Client) ListMachineDeploymentMachines(ctx context.Context, clusterName, mdName string) (*clusterv1.MachineList, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := c.client.List(ctx, machineList,
+		client.InNamespace(c.Namespace(ctx)),
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel:           clusterName,
+			clusterv1.MachineDeploymentNameLabel: mdName,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list machine deployment machines: %w", err)
+	}
+	return machineList, nil
+}
+
+// ListMachineSets lists the MachineSets of a MachineDeployment, one for each
+// of its revisions that has not been cleaned up yet.
+func (c *Client) ListMachineSets(ctx context.Context, clusterName, mdName string) (*clusterv1.MachineSetList, error) {
+	msList := &clusterv1.MachineSetList{}
+	if err := c.client.List(ctx, msList,
+		client.InNamespace(c.Namespace(ctx)),
+		client.MatchingLabels{
+			clusterv1.ClusterNameLabel:           clusterName,
+			clusterv1.MachineDeploymentNameLabel: mdName,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list machine sets: %w", err)
+	}
+	return msList, nil
+}
+
+// ListMachinePools lists all MachinePools for a cluster.
+// MachinePool is an experimental CAPI feature, so a management cluster without
+// the MachinePool CRD is treated as having no pools.
+func (c *Client) ListMachinePools(ctx context.Context, clusterName string) (*expv1.MachinePoolList, error) {
+	mpList := &expv1.MachinePoolList{}
+	if err := c.client.List(ctx, mpList, client.InNamespace(c.Namespace(ctx)), client.MatchingLabels{
+		clusterv1.ClusterNameLabel: clusterName,
+	}); err != nil {
+		if meta.IsNoMatchError(err) {
+			return mpList, nil
+		}
+		return nil, fmt.Errorf("failed to list machine pools: %w", err)
+	}
+	return mpList, nil
+}
+
+// GetMachinePool retrieves a MachinePool by cluster and name.
+func (c *Client) GetMachinePool(ctx context.Context, clusterName, mpName string) (*expv1.MachinePool, error) {
+	mpList, err := c.ListMachinePools(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mp := range mpList.Items {
+		if mp.Name == mpName {
+			return &mp, nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(expv1.GroupVersion.WithResource("machinepools").GroupResource(), mpName)
+}
+
+// UpdateMachinePool updates a MachinePool.
+func (c *Client) UpdateMachinePool(ctx context.Context, mp *expv1.MachinePool) error {
+	if err := c.client.Update(ctx, mp); err != nil {
+		return fmt.Errorf("failed to update machine pool: %w", err)
+	}
+	return nil
+}
+
 // GetKubeconfigSecret retrieves the kubeconfig secret for a cluster.
 func (c *Client) GetKubeconfigSecret(ctx context.Context, clusterName string) (*corev1.Secret, error) {
 	// The kubeconfig secret name follows the pattern: <cluster-name>-kubeconfig
@@ -174,7 +430,7 @@ func (c *Client) GetKubeconfigSecret(ctx context.Context, clusterName string) (*
 
 	secret := &corev1.Secret{}
 	key := types.NamespacedName{
-		Namespace: c.namespace,
+		Namespace: c.Namespace(ctx),
 		Name:      secretName,
 	}
 
@@ -188,10 +444,164 @@ func (c *Client) GetKubeconfigSecret(ctx context.Context, clusterName string) (*
 	return secret, nil
 }
 
+// GetSecret retrieves a secret by name from the client namespace.
+func (c *Client) GetSecret(ctx context.Context, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{
+		Namespace: c.Namespace(ctx),
+		Name:      name,
+	}
+	if err := c.client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	return secret, nil
+}
+
+// GetSecretInNamespace retrieves a secret by namespace and name, for secrets
+// outside the client namespace such as provider credentials.
+func (c *Client) GetSecretInNamespace(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+	return secret, nil
+}
+
+// GetControlPlane retrieves the control plane object referenced by a cluster.
+// The object is returned as unstructured because the control plane kind depends
+// on the provider (e.g. KubeadmControlPlane, AWSManagedControlPlane).
+func (c *Client) GetControlPlane(ctx context.Context, cluster *clusterv1.Cluster) (*unstructured.Unstructured, error) {
+	ref := cluster.Spec.ControlPlaneRef
+	if ref == nil {
+		return nil, fmt.Errorf("cluster %s has no control plane reference", cluster.Name)
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cluster.Namespace
+	}
+
+	controlPlane := &unstructured.Unstructured{}
+	controlPlane.SetAPIVersion(ref.APIVersion)
+	controlPlane.SetKind(ref.Kind)
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      ref.Name,
+	}
+	if err := c.client.Get(ctx, key, controlPlane); err != nil {
+		return nil, fmt.Errorf("failed to get control plane %s %s: %w", ref.Kind, ref.Name, err)
+	}
+	return controlPlane, nil
+}
+
+// ListProviderDeployments lists the controller Deployments of installed CAPI
+// providers across all namespaces, identified by the provider label.
+func (c *Client) ListProviderDeployments(ctx context.Context) (*appsv1.DeploymentList, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := c.client.List(ctx, deployments, client.HasLabels{clusterv1.ProviderNameLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list provider deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// ListDeployments lists the Deployments in a namespace.
+func (c *Client) ListDeployments(ctx context.Context, namespace string) (*appsv1.DeploymentList, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := c.client.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list deployments in %s: %w", namespace, err)
+	}
+	return deployments, nil
+}
+
+// ListClusterctlProviders lists the clusterctl provider inventory across all
+// namespaces. Management clusters not initialized with clusterctl have no
+// inventory, which is reported as an empty list.
+func (c *Client) ListClusterctlProviders(ctx context.Context) (*unstructured.UnstructuredList, error) {
+	providers := &unstructured.UnstructuredList{}
+	providers.SetGroupVersionKind(ClusterctlProviderListGVK)
+	if err := c.client.List(ctx, providers); err != nil {
+		if meta.IsNoMatchError(err) {
+			return providers, nil
+		}
+		return nil, fmt.Errorf("failed to list clusterctl providers: %w", err)
+	}
+	return providers, nil
+}
+
+// GetCustomResourceDefinition retrieves a CustomResourceDefinition by name.
+func (c *Client) GetCustomResourceDefinition(ctx context.Context, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+		return nil, fmt.Errorf("failed to get custom resource definition %s: %w", name, err)
+	}
+	return crd, nil
+}
+
+// CreateObject creates an arbitrary object in the namespace set on the object.
+// It is used for resources outside the client namespace, such as tenant namespaces
+// and their RBAC. Errors preserve the API status so callers can detect conflicts.
+func (c *Client) CreateObject(ctx context.Context, obj client.Object) error {
+	if err := c.client.Create(ctx, obj); err != nil {
+		return fmt.Errorf("failed to create %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// UpdateObject updates an arbitrary object, such as a Secret or Deployment of
+// a provider outside the client namespace.
+func (c *Client) UpdateObject(ctx context.Context, obj client.Object) error {
+	if err := c.client.Update(ctx, obj); err != nil {
+		return fmt.Errorf("failed to update %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// DeleteObject deletes an arbitrary object.
+func (c *Client) DeleteObject(ctx context.Context, obj client.Object) error {
+	if err := c.client.Delete(ctx, obj); err != nil {
+		return fmt.Errorf("failed to delete %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// ListConfigMaps lists the ConfigMaps in a namespace matching the given labels.
+func (c *Client) ListConfigMaps(ctx context.Context, namespace string, labels map[string]string) (*corev1.ConfigMapList, error) {
+	configMaps := &corev1.ConfigMapList{}
+	if err := c.client.List(ctx, configMaps, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, fmt.Errorf("failed to list configmaps in %s: %w", namespace, err)
+	}
+	return configMaps, nil
+}
+
+// GetObject retrieves an arbitrary object by GroupVersionKind, namespace and name.
+func (c *Client) GetObject(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	}
+	if err := c.client.Get(ctx, key, obj); err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", gvk.Kind, name, err)
+	}
+	return obj, nil
+}
+
+// ListLabelledObjects lists arbitrary objects of a GroupVersionKind in a
+// namespace that carry the given label, whatever its value.
+func (c *Client) ListLabelledObjects(ctx context.Context, gvk schema.GroupVersionKind, namespace, label string) (*unstructured.UnstructuredList, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.client.List(ctx, list, client.InNamespace(namespace), client.HasLabels{label}); err != nil {
+		return nil, fmt.Errorf("failed to list %s in %s: %w", gvk.Kind, namespace, err)
+	}
+	return list, nil
+}
+
 // ListClusterClasses returns all ClusterClass resources in the namespace.
 func (c *Client) ListClusterClasses(ctx context.Context) (*clusterv1.ClusterClassList, error) {
 	clusterClasses := &clusterv1.ClusterClassList{}
-	if err := c.client.List(ctx, clusterClasses, client.InNamespace(c.namespace)); err != nil {
+	if err := c.client.List(ctx, clusterClasses, client.InNamespace(c.Namespace(ctx))); err != nil {
 		return nil, fmt.Errorf("failed to list cluster classes: %w", err)
 	}
 	return clusterClasses, nil
@@ -206,12 +616,12 @@ func (c *Client) GetClusterClass(ctx context.Context, name string) (*clusterv1.C
 
 	clusterClass := &clusterv1.ClusterClass{}
 	key := types.NamespacedName{
-		Namespace: c.namespace,
+		Namespace: c.Namespace(ctx),
 		Name:      name,
 	}
 	if err := c.client.Get(ctx, key, clusterClass); err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, fmt.Errorf("cluster class %s not found", name)
+			return nil, fmt.Errorf("cluster class %s not found: %w", name, err)
 		}
 		return nil, fmt.Errorf("failed to get cluster class: %w", err)
 	}
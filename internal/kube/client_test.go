@@ -8,10 +8,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -72,6 +77,54 @@ func TestListClusters(t *testing.T) {
 	assert.Contains(t, names, "cluster-2")
 }
 
+func TestListClustersMatching(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+
+	newCluster := func(name, namespace string, labels map[string]string) *clusterv1.Cluster {
+		return &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newCluster("prod-1", "test-namespace", map[string]string{"env": "prod"}),
+			newCluster("prod-2", "test-namespace", map[string]string{"env": "prod", "region": "eu"}),
+			newCluster("dev-1", "test-namespace", map[string]string{"env": "dev"}),
+			newCluster("prod-other", "other-namespace", map[string]string{"env": "prod"}),
+		).
+		Build()
+
+	c := &Client{
+		client:    fakeClient,
+		namespace: "test-namespace",
+	}
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{selector: "env=prod", want: []string{"prod-1", "prod-2"}},
+		{selector: "env=prod,region=eu", want: []string{"prod-2"}},
+		{selector: "env!=prod", want: []string{"dev-1"}},
+		{selector: "team=payments", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := labels.Parse(tt.selector)
+			require.NoError(t, err)
+
+			clusters, err := c.ListClustersMatching(context.Background(), selector)
+			require.NoError(t, err)
+			var names []string
+			for _, cluster := range clusters.Items {
+				names = append(names, cluster.Name)
+			}
+			assert.ElementsMatch(t, tt.want, names)
+		})
+	}
+}
+
 func TestGetClusterByName(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, clusterv1.AddToScheme(scheme))
@@ -112,6 +165,15 @@ func TestGetClusterByName(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not found")
 	})
+
+	t.Run("namespace from context", func(t *testing.T) {
+		_, err := c.GetClusterByName(ContextWithNamespace(ctx, "other-namespace"), "test-cluster")
+		assert.True(t, apierrors.IsNotFound(err))
+
+		result, err := c.GetClusterByName(ContextWithNamespace(ctx, "test-namespace"), "test-cluster")
+		require.NoError(t, err)
+		assert.Equal(t, "test-cluster", result.Name)
+	})
 }
 
 func TestCreateCluster(t *testing.T) {
@@ -283,6 +345,173 @@ func TestUpdateMachineDeployment(t *testing.T) {
 	assert.Equal(t, int32(5), *updated.Spec.Replicas)
 }
 
+func TestListControlPlaneMachines(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+
+	newMachine := func(name, clusterName string, controlPlane bool) *clusterv1.Machine {
+		labels := map[string]string{clusterv1.ClusterNameLabel: clusterName}
+		if controlPlane {
+			labels[clusterv1.MachineControlPlaneLabel] = ""
+		}
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    labels,
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newMachine("cp-0", "test-cluster", true),
+			newMachine("cp-1", "test-cluster", true),
+			newMachine("worker-0", "test-cluster", false),
+			newMachine("other-cp-0", "other-cluster", true),
+		).
+		Build()
+
+	c := &Client{
+		client:    fakeClient,
+		namespace: "test-namespace",
+	}
+
+	machines, err := c.ListControlPlaneMachines(context.Background(), "test-cluster")
+	require.NoError(t, err)
+	assert.Len(t, machines.Items, 2)
+}
+
+func TestListMachineSets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+
+	newMachineSet := func(name, clusterName, mdName string) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:           clusterName,
+					clusterv1.MachineDeploymentNameLabel: mdName,
+				},
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newMachineSet("md-0-a", "test-cluster", "md-0"),
+			newMachineSet("md-0-b", "test-cluster", "md-0"),
+			newMachineSet("md-1-a", "test-cluster", "md-1"),
+			newMachineSet("other-md-0-a", "other-cluster", "md-0"),
+		).
+		Build()
+
+	c := &Client{
+		client:    fakeClient,
+		namespace: "test-namespace",
+	}
+
+	machineSets, err := c.ListMachineSets(context.Background(), "test-cluster", "md-0")
+	require.NoError(t, err)
+	assert.Len(t, machineSets.Items, 2)
+}
+
+func TestMachinePools(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, expv1.AddToScheme(scheme))
+
+	mp := &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-mp",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: "test-cluster",
+			},
+		},
+		Spec: expv1.MachinePoolSpec{
+			ClusterName: "test-cluster",
+			Replicas:    int32Ptr(2),
+		},
+	}
+	otherMP := &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-mp",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: "other-cluster",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(mp, otherMP).
+		Build()
+
+	c := &Client{
+		client:    fakeClient,
+		namespace: "test-namespace",
+	}
+
+	ctx := context.Background()
+
+	t.Run("list machine pools", func(t *testing.T) {
+		list, err := c.ListMachinePools(ctx, "test-cluster")
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "worker-mp", list.Items[0].Name)
+	})
+
+	t.Run("get existing machine pool", func(t *testing.T) {
+		result, err := c.GetMachinePool(ctx, "test-cluster", "worker-mp")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), *result.Spec.Replicas)
+	})
+
+	t.Run("get non-existent machine pool", func(t *testing.T) {
+		_, err := c.GetMachinePool(ctx, "test-cluster", "non-existent")
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("update machine pool", func(t *testing.T) {
+		result, err := c.GetMachinePool(ctx, "test-cluster", "worker-mp")
+		require.NoError(t, err)
+
+		result.Spec.Replicas = int32Ptr(4)
+		require.NoError(t, c.UpdateMachinePool(ctx, result))
+
+		updated := &expv1.MachinePool{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "test-namespace", Name: "worker-mp"}, updated))
+		assert.Equal(t, int32(4), *updated.Spec.Replicas)
+	})
+}
+
+func TestListMachinePoolsWithoutCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, expv1.AddToScheme(scheme))
+
+	// An empty REST mapper behaves like a management cluster without the MachinePool CRD.
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(meta.NewDefaultRESTMapper(nil)).
+		Build()
+
+	c := &Client{
+		client:    fakeClient,
+		namespace: "test-namespace",
+	}
+
+	list, err := c.ListMachinePools(context.Background(), "test-cluster")
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+}
+
 func TestGetKubeconfigSecret(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, corev1.AddToScheme(scheme))
@@ -323,6 +552,55 @@ func TestGetKubeconfigSecret(t *testing.T) {
 	})
 }
 
+func TestGetControlPlane(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+
+	controlPlane := &unstructured.Unstructured{}
+	controlPlane.SetAPIVersion("controlplane.cluster.x-k8s.io/v1beta2")
+	controlPlane.SetKind("AWSManagedControlPlane")
+	controlPlane.SetName("eks-control-plane")
+	controlPlane.SetNamespace("test-namespace")
+	require.NoError(t, unstructured.SetNestedField(controlPlane.Object, true, "status", "ready"))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(controlPlane).
+		Build()
+
+	c := &Client{
+		client:    fakeClient,
+		namespace: "test-namespace",
+	}
+
+	ctx := context.Background()
+
+	t.Run("existing control plane", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "eks", Namespace: "test-namespace"},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneRef: &corev1.ObjectReference{
+					APIVersion: "controlplane.cluster.x-k8s.io/v1beta2",
+					Kind:       "AWSManagedControlPlane",
+					Name:       "eks-control-plane",
+				},
+			},
+		}
+
+		result, err := c.GetControlPlane(ctx, cluster)
+		require.NoError(t, err)
+		ready, _, _ := unstructured.NestedBool(result.Object, "status", "ready")
+		assert.True(t, ready)
+	})
+
+	t.Run("missing control plane reference", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "no-ref"}}
+
+		_, err := c.GetControlPlane(ctx, cluster)
+		assert.Error(t, err)
+	})
+}
+
 func TestHelperFunctions(t *testing.T) {
 	t.Run("IsClusterReady", func(t *testing.T) {
 		readyCluster := &clusterv1.Cluster{
@@ -0,0 +1,44 @@
+package kube
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+// correlationUserAgentToken is appended to the User-Agent of API requests made
+// on behalf of a tool call, so they can be found in API server audit logs.
+const correlationUserAgentToken = "correlation-id"
+
+// withCorrelationID configures config to tag requests whose context carries a
+// correlation ID (see logging.ContextWithCorrelationID).
+func withCorrelationID(config *rest.Config) {
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &correlationRoundTripper{next: rt}
+	})
+}
+
+// correlationRoundTripper appends the request's correlation ID to its User-Agent.
+type correlationRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *correlationRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	correlationID := logging.GetCorrelationID(req.Context())
+	if correlationID == "" {
+		return rt.next.RoundTrip(req)
+	}
+
+	// The client-go User-Agent is set by an outer round tripper; requests must
+	// not be modified in place
+	req = req.Clone(req.Context())
+	userAgent := req.Header.Get("User-Agent")
+	if userAgent == "" {
+		userAgent = rest.DefaultKubernetesUserAgent()
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("%s %s/%s", userAgent, correlationUserAgentToken, correlationID))
+	return rt.next.RoundTrip(req)
+}
@@ -0,0 +1,58 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestCorrelationIDUserAgent(t *testing.T) {
+	userAgents := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"NodeList","apiVersion":"v1","items":[]}`)
+	}))
+	defer server.Close()
+
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`, server.URL)
+
+	client, err := NewWorkloadClientFromKubeconfig([]byte(kubeconfig), NetworkOptions{})
+	require.NoError(t, err)
+
+	t.Run("appends the correlation ID", func(t *testing.T) {
+		ctx := logging.ContextWithCorrelationID(context.Background(), "abc-123")
+		_, err := client.ListNodes(ctx)
+		require.NoError(t, err)
+
+		userAgent := <-userAgents
+		assert.True(t, strings.HasSuffix(userAgent, " correlation-id/abc-123"), userAgent)
+		assert.Greater(t, len(userAgent), len(" correlation-id/abc-123"))
+	})
+
+	t.Run("leaves requests without a correlation ID unchanged", func(t *testing.T) {
+		_, err := client.ListNodes(context.Background())
+		require.NoError(t, err)
+
+		assert.NotContains(t, <-userAgents, correlationUserAgentToken)
+	})
+}
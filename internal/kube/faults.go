@@ -0,0 +1,164 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// faultVerbs lists the verbs of the calls faults can be injected into.
+var faultVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// injectedFault is the message of the errors of injected faults.
+const injectedFault = "injected fault"
+
+// FaultOptions configures the faults injected into the calls of the
+// management cluster API, to exercise error handling in resilience tests.
+type FaultOptions struct {
+	// Latency is the maximum random delay added to each call.
+	Latency time.Duration
+	// ErrorRate is the probability, from 0 to 1, that a call fails with a
+	// server error (internal error, service unavailable, too many requests
+	// or timeout) instead of reaching the API server.
+	ErrorRate float64
+	// Verbs limits faults to the calls with these verbs (see faultVerbs);
+	// all calls when empty.
+	Verbs []string
+	// Kinds limits faults to the calls on these kinds, e.g.
+	// MachineDeployment, so that operations spanning several kinds fail
+	// partway; all kinds when empty.
+	Kinds []string
+}
+
+// InjectFaults makes the calls of the client fail or slow down at random as
+// the options configure. It is meant for resilience testing only.
+func (c *Client) InjectFaults(options FaultOptions) error {
+	if options.ErrorRate < 0 || options.ErrorRate > 1 {
+		return fmt.Errorf("fault error rate must be between 0 and 1, got %g", options.ErrorRate)
+	}
+	for _, verb := range options.Verbs {
+		if !slices.Contains(faultVerbs, verb) {
+			return fmt.Errorf("unknown fault verb %q, expected one of %s", verb, strings.Join(faultVerbs, ", "))
+		}
+	}
+	faults := &faultInjector{options: options, scheme: c.client.Scheme()}
+	c.client = interceptor.NewClient(c.client, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := faults.inject(ctx, "get", obj); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := faults.inject(ctx, "list", list); err != nil {
+				return err
+			}
+			return c.List(ctx, list, opts...)
+		},
+		Watch: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+			if err := faults.inject(ctx, "watch", list); err != nil {
+				return nil, err
+			}
+			return c.Watch(ctx, list, opts...)
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if err := faults.inject(ctx, "create", obj); err != nil {
+				return err
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if err := faults.inject(ctx, "update", obj); err != nil {
+				return err
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if err := faults.inject(ctx, "patch", obj); err != nil {
+				return err
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if err := faults.inject(ctx, "delete", obj); err != nil {
+				return err
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+		DeleteAllOf: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteAllOfOption) error {
+			if err := faults.inject(ctx, "delete", obj); err != nil {
+				return err
+			}
+			return c.DeleteAllOf(ctx, obj, opts...)
+		},
+	})
+	return nil
+}
+
+// IsInjectedFault reports whether an error is that of an injected fault.
+func IsInjectedFault(err error) bool {
+	var status apierrors.APIStatus
+	return errors.As(err, &status) && strings.Contains(status.Status().Message, injectedFault)
+}
+
+// faultInjector decides on the faults of calls.
+type faultInjector struct {
+	options FaultOptions
+	scheme  *runtime.Scheme
+}
+
+// inject delays a call and returns the error it fails with, if any.
+func (f *faultInjector) inject(ctx context.Context, verb string, obj runtime.Object) error {
+	if !f.applies(verb, obj) {
+		return nil
+	}
+
+	if f.options.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rand.N(f.options.Latency)):
+		}
+	}
+	if f.options.ErrorRate == 0 || rand.Float64() >= f.options.ErrorRate {
+		return nil
+	}
+
+	switch rand.IntN(4) {
+	case 0:
+		return apierrors.NewInternalError(errors.New(injectedFault))
+	case 1:
+		return apierrors.NewServiceUnavailable(injectedFault)
+	case 2:
+		return apierrors.NewTooManyRequests(injectedFault, 1)
+	default:
+		return apierrors.NewTimeoutError(injectedFault, 1)
+	}
+}
+
+// applies reports whether faults are injected into a call.
+func (f *faultInjector) applies(verb string, obj runtime.Object) bool {
+	if len(f.options.Verbs) > 0 && !slices.Contains(f.options.Verbs, verb) {
+		return false
+	}
+	if len(f.options.Kinds) == 0 {
+		return true
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, f.scheme)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(f.options.Kinds, strings.TrimSuffix(gvk.Kind, "List"))
+}
@@ -0,0 +1,82 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClient_InjectFaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	newClient := func() *Client {
+		return NewClientFromClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Namespace: "default"}},
+			&clusterv1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{
+				Name:      "prod-a-md-0",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "prod-a"},
+			}},
+		).Build(), "default")
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		options        FaultOptions
+		wantClusterErr bool
+		wantPoolErr    bool
+	}{
+		{name: "no errors", options: FaultOptions{ErrorRate: 0}},
+		{name: "every call fails", options: FaultOptions{ErrorRate: 1}, wantClusterErr: true, wantPoolErr: true},
+		{name: "verbs", options: FaultOptions{ErrorRate: 1, Verbs: []string{"get"}}, wantClusterErr: true},
+		{name: "kinds", options: FaultOptions{ErrorRate: 1, Kinds: []string{"MachineDeployment"}}, wantPoolErr: true},
+		{name: "other verbs and kinds", options: FaultOptions{ErrorRate: 1, Verbs: []string{"delete"}, Kinds: []string{"Cluster"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient()
+			require.NoError(t, c.InjectFaults(tt.options))
+
+			_, err := c.GetClusterByName(ctx, "prod-a")
+			if tt.wantClusterErr {
+				require.Error(t, err)
+				assert.True(t, IsInjectedFault(err))
+				assert.True(t, apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err) ||
+					apierrors.IsTooManyRequests(err) || apierrors.IsTimeout(err), "server error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			_, err = c.ListMachineDeployments(ctx, "prod-a")
+			if tt.wantPoolErr {
+				assert.True(t, IsInjectedFault(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("latency", func(t *testing.T) {
+		c := newClient()
+		require.NoError(t, c.InjectFaults(FaultOptions{Latency: time.Hour}))
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := c.GetClusterByName(timeoutCtx, "prod-a")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		assert.Error(t, newClient().InjectFaults(FaultOptions{ErrorRate: 1.5}))
+		assert.Error(t, newClient().InjectFaults(FaultOptions{Verbs: []string{"explode"}}))
+	})
+}
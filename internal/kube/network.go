@@ -0,0 +1,61 @@
+package kube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+	"k8s.io/client-go/rest"
+)
+
+// NetworkOptions configures how clients reach Kubernetes API servers from
+// restricted networks. The zero value uses the client-go defaults.
+type NetworkOptions struct {
+	// HTTPProxy, HTTPSProxy and NoProxy select the proxy for API server
+	// requests, with the semantics of the HTTP_PROXY, HTTPS_PROXY and
+	// NO_PROXY environment variables.
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+
+	// CABundle holds PEM certificates trusted in addition to the cluster CA
+	// of a kubeconfig, e.g. that of a TLS-intercepting proxy.
+	CABundle []byte
+}
+
+// apply configures a REST config to use the proxy and CA bundle.
+func (o NetworkOptions) apply(config *rest.Config) error {
+	if o.HTTPProxy != "" || o.HTTPSProxy != "" {
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  o.HTTPProxy,
+			HTTPSProxy: o.HTTPSProxy,
+			NoProxy:    o.NoProxy,
+		}).ProxyFunc()
+		config.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	if len(o.CABundle) == 0 || config.Insecure {
+		return nil
+	}
+	// CAData takes precedence over CAFile, so the cluster CA must be carried over
+	caData := config.CAData
+	if len(caData) == 0 && config.CAFile != "" {
+		data, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read cluster CA: %w", err)
+		}
+		caData = data
+	}
+	if len(caData) == 0 {
+		// Without a cluster CA the system roots are trusted, which setting
+		// CAData would replace; add the bundle to them with SSL_CERT_FILE
+		return nil
+	}
+	config.CAData = append(append(append([]byte{}, caData...), '\n'), o.CABundle...)
+	config.CAFile = ""
+	return nil
+}
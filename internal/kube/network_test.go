@@ -0,0 +1,72 @@
+package kube
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestNetworkOptions_Apply(t *testing.T) {
+	clusterCA := []byte("-----BEGIN CERTIFICATE-----\ncluster\n-----END CERTIFICATE-----\n")
+	proxyCA := []byte("-----BEGIN CERTIFICATE-----\nproxy\n-----END CERTIFICATE-----\n")
+
+	t.Run("proxy", func(t *testing.T) {
+		config := &rest.Config{Host: "https://api.example.com:6443"}
+		options := NetworkOptions{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: "10.0.0.0/8,.internal"}
+		require.NoError(t, options.apply(config))
+		require.NotNil(t, config.Proxy)
+
+		for host, want := range map[string]string{
+			"https://api.example.com:6443":     "http://proxy.example.com:3128",
+			"https://10.1.2.3:6443":            "",
+			"https://api.prod.internal:6443":   "",
+			"https://api.other.example.com:80": "http://proxy.example.com:3128",
+		} {
+			req, err := http.NewRequest(http.MethodGet, host, nil)
+			require.NoError(t, err)
+			proxy, err := config.Proxy(req)
+			require.NoError(t, err)
+			if want == "" {
+				assert.Nil(t, proxy, host)
+			} else {
+				require.NotNil(t, proxy, host)
+				assert.Equal(t, want, proxy.String())
+			}
+		}
+	})
+
+	t.Run("no options", func(t *testing.T) {
+		config := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: clusterCA}}
+		require.NoError(t, NetworkOptions{}.apply(config))
+		assert.Nil(t, config.Proxy)
+		assert.Equal(t, clusterCA, config.CAData)
+	})
+
+	t.Run("CA bundle with cluster CA data", func(t *testing.T) {
+		config := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAData: clusterCA}}
+		require.NoError(t, NetworkOptions{CABundle: proxyCA}.apply(config))
+		assert.Contains(t, string(config.CAData), "cluster")
+		assert.Contains(t, string(config.CAData), "proxy")
+	})
+
+	t.Run("CA bundle with cluster CA file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.crt")
+		require.NoError(t, os.WriteFile(path, clusterCA, 0o600))
+		config := &rest.Config{TLSClientConfig: rest.TLSClientConfig{CAFile: path}}
+		require.NoError(t, NetworkOptions{CABundle: proxyCA}.apply(config))
+		assert.Empty(t, config.CAFile)
+		assert.Contains(t, string(config.CAData), "cluster")
+		assert.Contains(t, string(config.CAData), "proxy")
+	})
+
+	t.Run("CA bundle with insecure cluster", func(t *testing.T) {
+		config := &rest.Config{TLSClientConfig: rest.TLSClientConfig{Insecure: true}}
+		require.NoError(t, NetworkOptions{CABundle: proxyCA}.apply(config))
+		assert.Empty(t, config.CAData)
+	})
+}
@@ -4,24 +4,36 @@ import (
 	"context"
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 // WorkloadClient represents a client for a workload cluster.
 type WorkloadClient struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
+	dynamic   dynamic.Interface
 }
 
 // NewWorkloadClientFromKubeconfig creates a new workload cluster client from kubeconfig data.
-func NewWorkloadClientFromKubeconfig(kubeconfigData []byte) (*WorkloadClient, error) {
+func NewWorkloadClientFromKubeconfig(kubeconfigData []byte, network NetworkOptions) (*WorkloadClient, error) {
 	// Parse the kubeconfig
 	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
 	}
+	withCorrelationID(config)
+	if err := network.apply(config); err != nil {
+		return nil, err
+	}
 
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(config)
@@ -29,11 +41,34 @@ func NewWorkloadClientFromKubeconfig(kubeconfigData []byte) (*WorkloadClient, er
 		return nil, fmt.Errorf("failed to create clientset: %w", err)
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	return &WorkloadClient{
 		clientset: clientset,
+		dynamic:   dynamicClient,
 	}, nil
 }
 
+// NewWorkloadClient creates a workload cluster client from an existing clientset.
+// This is primarily useful for injecting fake clientsets in tests.
+func NewWorkloadClient(clientset kubernetes.Interface) *WorkloadClient {
+	return &WorkloadClient{
+		clientset: clientset,
+	}
+}
+
+// NewWorkloadClientWithDynamic creates a workload cluster client from an
+// existing clientset and dynamic client, for reading arbitrary resources.
+func NewWorkloadClientWithDynamic(clientset kubernetes.Interface, dynamicClient dynamic.Interface) *WorkloadClient {
+	return &WorkloadClient{
+		clientset: clientset,
+		dynamic:   dynamicClient,
+	}
+}
+
 // ListNodes returns all nodes in the workload cluster.
 func (w *WorkloadClient) ListNodes(ctx context.Context) (*corev1.NodeList, error) {
 	nodes, err := w.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
@@ -43,6 +78,342 @@ func (w *WorkloadClient) ListNodes(ctx context.Context) (*corev1.NodeList, error
 	return nodes, nil
 }
 
+// GetConfigMap retrieves a ConfigMap from the workload cluster.
+func (w *WorkloadClient) GetConfigMap(ctx context.Context, namespace, name string) (*corev1.ConfigMap, error) {
+	configMap, err := w.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config map %s/%s: %w", namespace, name, err)
+	}
+	return configMap, nil
+}
+
+// ServerVersion returns the Kubernetes version reported by the workload
+// cluster's API server.
+func (w *WorkloadClient) ServerVersion() (string, error) {
+	version, err := w.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+// Readyz returns the verbose output of the API server's /readyz endpoint, one
+// line per check such as "[+]etcd ok" or "[-]etcd failed: reason withheld".
+// The output is returned along with the error when the API server is not
+// ready.
+func (w *WorkloadClient) Readyz(ctx context.Context) (string, error) {
+	restClient := w.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		return "", fmt.Errorf("readyz is not available for this client")
+	}
+	body, err := restClient.Get().AbsPath("/readyz").Param("verbose", "").Do(ctx).Raw()
+	if err != nil {
+		return string(body), fmt.Errorf("readyz check failed: %w", err)
+	}
+	return string(body), nil
+}
+
+// GetDeployment retrieves a Deployment from the workload cluster.
+func (w *WorkloadClient) GetDeployment(ctx context.Context, namespace, name string) (*appsv1.Deployment, error) {
+	deployment, err := w.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+	}
+	return deployment, nil
+}
+
+// ListDeployments returns the Deployments in a namespace of the workload cluster.
+func (w *WorkloadClient) ListDeployments(ctx context.Context, namespace string) (*appsv1.DeploymentList, error) {
+	deployments, err := w.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments in %s: %w", namespace, err)
+	}
+	return deployments, nil
+}
+
+// CreateDeployment creates a Deployment in the workload cluster.
+func (w *WorkloadClient) CreateDeployment(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	created, err := w.clientset.AppsV1().Deployments(deployment.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+	}
+	return created, nil
+}
+
+// ListDaemonSets returns the DaemonSets in every namespace of the workload cluster.
+func (w *WorkloadClient) ListDaemonSets(ctx context.Context) (*appsv1.DaemonSetList, error) {
+	daemonSets, err := w.clientset.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	return daemonSets, nil
+}
+
+// ListPersistentVolumes returns the PersistentVolumes of the workload cluster.
+func (w *WorkloadClient) ListPersistentVolumes(ctx context.Context) (*corev1.PersistentVolumeList, error) {
+	volumes, err := w.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
+	}
+	return volumes, nil
+}
+
+// ListServices returns the Services in every namespace of the workload cluster.
+func (w *WorkloadClient) ListServices(ctx context.Context) (*corev1.ServiceList, error) {
+	services, err := w.clientset.CoreV1().Services("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	return services, nil
+}
+
+// GetPodLogs returns the last tailLines lines, at most limitBytes bytes, of
+// the logs of a pod container. With previous set it returns the logs of the
+// container's previous instance, which is what explains a crash loop.
+func (w *WorkloadClient) GetPodLogs(ctx context.Context, namespace, pod, container string, tailLines, limitBytes int64, previous bool) (string, error) {
+	options := &corev1.PodLogOptions{
+		Container:  container,
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+		Previous:   previous,
+	}
+	logs, err := w.clientset.CoreV1().Pods(namespace).GetLogs(pod, options).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs of pod %s/%s: %w", namespace, pod, err)
+	}
+	return string(logs), nil
+}
+
+// GetPod retrieves a Pod from the workload cluster.
+func (w *WorkloadClient) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	pod, err := w.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+	}
+	return pod, nil
+}
+
+// CreatePod creates a Pod in the workload cluster.
+func (w *WorkloadClient) CreatePod(ctx context.Context, pod *corev1.Pod) (*corev1.Pod, error) {
+	created, err := w.clientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return created, nil
+}
+
+// DeletePod deletes a Pod from the workload cluster without a grace period.
+func (w *WorkloadClient) DeletePod(ctx context.Context, namespace, name string) error {
+	gracePeriod := int64(0)
+	if err := w.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}); err != nil {
+		return fmt.Errorf("failed to delete pod %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// CreateNamespace creates a Namespace in the workload cluster.
+func (w *WorkloadClient) CreateNamespace(ctx context.Context, namespace *corev1.Namespace) (*corev1.Namespace, error) {
+	created, err := w.clientset.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace %s: %w", namespace.Name, err)
+	}
+	return created, nil
+}
+
+// DeleteNamespace deletes a Namespace and everything in it from the workload
+// cluster. Deletion completes in the background.
+func (w *WorkloadClient) DeleteNamespace(ctx context.Context, name string) error {
+	if err := w.clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// CreateService creates a Service in the workload cluster.
+func (w *WorkloadClient) CreateService(ctx context.Context, service *corev1.Service) (*corev1.Service, error) {
+	created, err := w.clientset.CoreV1().Services(service.Namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service %s/%s: %w", service.Namespace, service.Name, err)
+	}
+	return created, nil
+}
+
+// CreatePersistentVolumeClaim creates a PersistentVolumeClaim in the workload cluster.
+func (w *WorkloadClient) CreatePersistentVolumeClaim(ctx context.Context, claim *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	created, err := w.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create persistentvolumeclaim %s/%s: %w", claim.Namespace, claim.Name, err)
+	}
+	return created, nil
+}
+
+// GetPersistentVolumeClaim retrieves a PersistentVolumeClaim from the workload cluster.
+func (w *WorkloadClient) GetPersistentVolumeClaim(ctx context.Context, namespace, name string) (*corev1.PersistentVolumeClaim, error) {
+	claim, err := w.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persistentvolumeclaim %s/%s: %w", namespace, name, err)
+	}
+	return claim, nil
+}
+
+// GetNode retrieves a Node from the workload cluster.
+func (w *WorkloadClient) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	node, err := w.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+	return node, nil
+}
+
+// ListPods lists the Pods in a namespace of the workload cluster matching a
+// label selector.
+func (w *WorkloadClient) ListPods(ctx context.Context, namespace, labelSelector string) (*corev1.PodList, error) {
+	pods, err := w.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in %s: %w", namespace, err)
+	}
+	return pods, nil
+}
+
+// GetCronJob retrieves a CronJob from the workload cluster.
+func (w *WorkloadClient) GetCronJob(ctx context.Context, namespace, name string) (*batchv1.CronJob, error) {
+	cronJob, err := w.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cronjob %s/%s: %w", namespace, name, err)
+	}
+	return cronJob, nil
+}
+
+// CreateCronJob creates a CronJob in the workload cluster.
+func (w *WorkloadClient) CreateCronJob(ctx context.Context, cronJob *batchv1.CronJob) (*batchv1.CronJob, error) {
+	created, err := w.clientset.BatchV1().CronJobs(cronJob.Namespace).Create(ctx, cronJob, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cronjob %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	return created, nil
+}
+
+// UpdateCronJob updates a CronJob in the workload cluster.
+func (w *WorkloadClient) UpdateCronJob(ctx context.Context, cronJob *batchv1.CronJob) (*batchv1.CronJob, error) {
+	updated, err := w.clientset.BatchV1().CronJobs(cronJob.Namespace).Update(ctx, cronJob, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update cronjob %s/%s: %w", cronJob.Namespace, cronJob.Name, err)
+	}
+	return updated, nil
+}
+
+// ListJobs lists the Jobs in a namespace of the workload cluster matching a
+// label selector.
+func (w *WorkloadClient) ListJobs(ctx context.Context, namespace, labelSelector string) (*batchv1.JobList, error) {
+	jobs, err := w.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs in %s: %w", namespace, err)
+	}
+	return jobs, nil
+}
+
+// CreateServiceAccount creates a ServiceAccount in the workload cluster.
+func (w *WorkloadClient) CreateServiceAccount(ctx context.Context, serviceAccount *corev1.ServiceAccount) (*corev1.ServiceAccount, error) {
+	created, err := w.clientset.CoreV1().ServiceAccounts(serviceAccount.Namespace).Create(ctx, serviceAccount, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create serviceaccount %s/%s: %w", serviceAccount.Namespace, serviceAccount.Name, err)
+	}
+	return created, nil
+}
+
+// ListServiceAccounts lists the ServiceAccounts in a namespace of the
+// workload cluster, or in all namespaces if namespace is empty, matching a
+// label selector.
+func (w *WorkloadClient) ListServiceAccounts(ctx context.Context, namespace, labelSelector string) (*corev1.ServiceAccountList, error) {
+	serviceAccounts, err := w.clientset.CoreV1().ServiceAccounts(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serviceaccounts: %w", err)
+	}
+	return serviceAccounts, nil
+}
+
+// DeleteServiceAccount deletes a ServiceAccount from the workload cluster.
+// The tokens issued for it stop being valid.
+func (w *WorkloadClient) DeleteServiceAccount(ctx context.Context, namespace, name string) error {
+	if err := w.clientset.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete serviceaccount %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// CreateServiceAccountToken issues a token for a ServiceAccount of the
+// workload cluster that expires after the given number of seconds.
+func (w *WorkloadClient) CreateServiceAccountToken(ctx context.Context, namespace, name string, expirationSeconds int64) (*authenticationv1.TokenRequest, error) {
+	request := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds},
+	}
+	token, err := w.clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, request, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token for serviceaccount %s/%s: %w", namespace, name, err)
+	}
+	return token, nil
+}
+
+// CreateRoleBinding creates a RoleBinding in the workload cluster.
+func (w *WorkloadClient) CreateRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
+	created, err := w.clientset.RbacV1().RoleBindings(roleBinding.Namespace).Create(ctx, roleBinding, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rolebinding %s/%s: %w", roleBinding.Namespace, roleBinding.Name, err)
+	}
+	return created, nil
+}
+
+// DeleteRoleBinding deletes a RoleBinding from the workload cluster.
+func (w *WorkloadClient) DeleteRoleBinding(ctx context.Context, namespace, name string) error {
+	if err := w.clientset.RbacV1().RoleBindings(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		return fmt.Errorf("failed to delete rolebinding %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// GetResource retrieves an arbitrary resource from the workload cluster. The
+// namespace is ignored for cluster-scoped resources.
+func (w *WorkloadClient) GetResource(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	if w.dynamic == nil {
+		return nil, fmt.Errorf("dynamic client not available for this workload cluster")
+	}
+	obj, err := w.dynamic.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", gvr.Resource, name, err)
+	}
+	return obj, nil
+}
+
+// CreateResource creates an arbitrary resource in the workload cluster. The
+// namespace is ignored for cluster-scoped resources.
+func (w *WorkloadClient) CreateResource(ctx context.Context, gvr schema.GroupVersionResource, namespace string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if w.dynamic == nil {
+		return nil, fmt.Errorf("dynamic client not available for this workload cluster")
+	}
+	created, err := w.dynamic.Resource(gvr).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s %s: %w", gvr.Resource, obj.GetName(), err)
+	}
+	return created, nil
+}
+
+// ListResources lists arbitrary resources in the workload cluster matching a
+// label selector, returning at most limit items. An empty namespace lists
+// across all namespaces.
+func (w *WorkloadClient) ListResources(ctx context.Context, gvr schema.GroupVersionResource, namespace, labelSelector string, limit int64) (*unstructured.UnstructuredList, error) {
+	if w.dynamic == nil {
+		return nil, fmt.Errorf("dynamic client not available for this workload cluster")
+	}
+	list, err := w.dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	return list, nil
+}
+
 // GetClusterInfo returns basic information about the workload cluster.
 func (w *WorkloadClient) GetClusterInfo(ctx context.Context) (*ClusterInfo, error) {
 	// Get server version
@@ -1,10 +1,25 @@
 package kube
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestNewWorkloadClientFromKubeconfig(t *testing.T) {
@@ -31,24 +46,51 @@ users:
 	invalidKubeconfig := `invalid yaml content`
 
 	t.Run("valid kubeconfig", func(t *testing.T) {
-		client, err := NewWorkloadClientFromKubeconfig([]byte(validKubeconfig))
+		client, err := NewWorkloadClientFromKubeconfig([]byte(validKubeconfig), NetworkOptions{})
 		require.NoError(t, err)
 		assert.NotNil(t, client)
 		assert.NotNil(t, client.clientset)
 	})
 
 	t.Run("invalid kubeconfig", func(t *testing.T) {
-		_, err := NewWorkloadClientFromKubeconfig([]byte(invalidKubeconfig))
+		_, err := NewWorkloadClientFromKubeconfig([]byte(invalidKubeconfig), NetworkOptions{})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to parse kubeconfig")
 	})
 
 	t.Run("empty kubeconfig", func(t *testing.T) {
-		_, err := NewWorkloadClientFromKubeconfig([]byte(""))
+		_, err := NewWorkloadClientFromKubeconfig([]byte(""), NetworkOptions{})
 		assert.Error(t, err)
 	})
 }
 
+func TestGetConfigMap(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-autoscaler-status",
+			Namespace: "kube-system",
+		},
+		Data: map[string]string{
+			"status": "autoscalerStatus: Running",
+		},
+	}
+
+	client := NewWorkloadClient(fake.NewSimpleClientset(configMap))
+	ctx := context.Background()
+
+	t.Run("existing config map", func(t *testing.T) {
+		result, err := client.GetConfigMap(ctx, "kube-system", "cluster-autoscaler-status")
+		require.NoError(t, err)
+		assert.Equal(t, "autoscalerStatus: Running", result.Data["status"])
+	})
+
+	t.Run("missing config map", func(t *testing.T) {
+		_, err := client.GetConfigMap(ctx, "kube-system", "does-not-exist")
+		require.Error(t, err)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
 func TestClusterInfo(t *testing.T) {
 	clusterInfo := &ClusterInfo{
 		KubernetesVersion: "v1.31.0",
@@ -61,3 +103,185 @@ func TestClusterInfo(t *testing.T) {
 
 // Note: Testing ListNodes and GetClusterInfo would require a real or mocked Kubernetes API server
 // These would be better tested in integration tests
+
+func TestGetDeploymentAndListDaemonSets(t *testing.T) {
+	client := NewWorkloadClient(fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "calico-node", Namespace: "calico-system"}},
+	))
+	ctx := context.Background()
+
+	deployment, err := client.GetDeployment(ctx, "kube-system", "coredns")
+	require.NoError(t, err)
+	assert.Equal(t, "coredns", deployment.Name)
+
+	_, err = client.GetDeployment(ctx, "kube-system", "metrics-server")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	deployments, err := client.ListDeployments(ctx, "kube-system")
+	require.NoError(t, err)
+	assert.Len(t, deployments.Items, 1)
+
+	daemonSets, err := client.ListDaemonSets(ctx)
+	require.NoError(t, err)
+	assert.Len(t, daemonSets.Items, 2)
+}
+
+func TestGetAndListResources(t *testing.T) {
+	pods := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	client := NewWorkloadClientWithDynamic(fake.NewSimpleClientset(), dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns-1", Namespace: "kube-system", Labels: map[string]string{"k8s-app": "kube-dns"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "calico-node-1", Namespace: "calico-system"}},
+	))
+	ctx := context.Background()
+
+	pod, err := client.GetResource(ctx, pods, "kube-system", "coredns-1")
+	require.NoError(t, err)
+	assert.Equal(t, "coredns-1", pod.GetName())
+
+	_, err = client.GetResource(ctx, pods, "kube-system", "missing")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	list, err := client.ListResources(ctx, pods, "", "k8s-app=kube-dns", 0)
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "coredns-1", list.Items[0].GetName())
+
+	created, err := client.CreateResource(ctx, pods, "default", &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "created", "namespace": "default"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "created", created.GetName())
+
+	_, err = NewWorkloadClient(fake.NewSimpleClientset()).ListResources(ctx, pods, "", "", 0)
+	assert.Error(t, err)
+}
+
+func TestGetPodAndLogs(t *testing.T) {
+	client := NewWorkloadClient(fake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns-1", Namespace: "kube-system"}},
+	))
+	ctx := context.Background()
+
+	pod, err := client.GetPod(ctx, "kube-system", "coredns-1")
+	require.NoError(t, err)
+	assert.Equal(t, "coredns-1", pod.Name)
+
+	logs, err := client.GetPodLogs(ctx, "kube-system", "coredns-1", "coredns", 100, 1024, false)
+	require.NoError(t, err)
+	assert.NotEmpty(t, logs)
+}
+
+func TestCreateAndDeletePod(t *testing.T) {
+	client := NewWorkloadClient(fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	))
+	ctx := context.Background()
+
+	node, err := client.GetNode(ctx, "node-1")
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", node.Name)
+
+	_, err = client.CreatePod(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "kube-system"}})
+	require.NoError(t, err)
+	require.NoError(t, client.DeletePod(ctx, "kube-system", "debug"))
+
+	_, err = client.GetPod(ctx, "kube-system", "debug")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestSmokeTestResources(t *testing.T) {
+	client := NewWorkloadClient(fake.NewSimpleClientset())
+	ctx := context.Background()
+
+	_, err := client.CreateNamespace(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "smoke"}})
+	require.NoError(t, err)
+	_, err = client.CreateDeployment(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "smoke"}})
+	require.NoError(t, err)
+	_, err = client.CreateService(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "smoke"}})
+	require.NoError(t, err)
+	_, err = client.CreatePersistentVolumeClaim(ctx, &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "smoke"}})
+	require.NoError(t, err)
+
+	claim, err := client.GetPersistentVolumeClaim(ctx, "smoke", "data")
+	require.NoError(t, err)
+	assert.Equal(t, "data", claim.Name)
+	_, err = client.GetDeployment(ctx, "smoke", "web")
+	require.NoError(t, err)
+
+	require.NoError(t, client.DeleteNamespace(ctx, "smoke"))
+	assert.True(t, apierrors.IsNotFound(client.DeleteNamespace(ctx, "smoke")))
+}
+
+func TestServiceAccountAccess(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		request := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		request.Status.Token = "token"
+		return true, request, nil
+	})
+	client := NewWorkloadClient(clientset)
+	ctx := context.Background()
+
+	labels := map[string]string{"capi-mcp.io/temporary-access": "abc"}
+	_, err := client.CreateServiceAccount(ctx, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "access", Namespace: "team-a", Labels: labels}})
+	require.NoError(t, err)
+	_, err = client.CreateRoleBinding(ctx, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "access", Namespace: "team-a"}})
+	require.NoError(t, err)
+
+	token, err := client.CreateServiceAccountToken(ctx, "team-a", "access", 600)
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Status.Token)
+	assert.Equal(t, int64(600), *token.Spec.ExpirationSeconds)
+
+	serviceAccounts, err := client.ListServiceAccounts(ctx, "", "capi-mcp.io/temporary-access=abc")
+	require.NoError(t, err)
+	require.Len(t, serviceAccounts.Items, 1)
+
+	require.NoError(t, client.DeleteRoleBinding(ctx, "team-a", "access"))
+	require.NoError(t, client.DeleteServiceAccount(ctx, "team-a", "access"))
+	assert.True(t, apierrors.IsNotFound(client.DeleteServiceAccount(ctx, "team-a", "access")))
+}
+
+func TestCronJobsAndJobs(t *testing.T) {
+	labels := map[string]string{"app.kubernetes.io/name": "etcd-backup"}
+	client := NewWorkloadClient(fake.NewSimpleClientset(
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup-1", Namespace: "kube-system", Labels: labels}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kube-system"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "backup-1-abcde", Namespace: "kube-system", Labels: labels}},
+	))
+	ctx := context.Background()
+
+	_, err := client.GetCronJob(ctx, "kube-system", "backup")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "kube-system"},
+		Spec:       batchv1.CronJobSpec{Schedule: "0 * * * *"},
+	}
+	_, err = client.CreateCronJob(ctx, cronJob)
+	require.NoError(t, err)
+
+	cronJob.Spec.Schedule = "0 0 * * *"
+	_, err = client.UpdateCronJob(ctx, cronJob)
+	require.NoError(t, err)
+
+	got, err := client.GetCronJob(ctx, "kube-system", "backup")
+	require.NoError(t, err)
+	assert.Equal(t, "0 0 * * *", got.Spec.Schedule)
+
+	jobs, err := client.ListJobs(ctx, "kube-system", "app.kubernetes.io/name=etcd-backup")
+	require.NoError(t, err)
+	require.Len(t, jobs.Items, 1)
+	assert.Equal(t, "backup-1", jobs.Items[0].Name)
+
+	pods, err := client.ListPods(ctx, "kube-system", "app.kubernetes.io/name=etcd-backup")
+	require.NoError(t, err)
+	assert.Len(t, pods.Items, 1)
+}
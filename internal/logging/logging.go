@@ -3,21 +3,25 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Common log field keys
 const (
 	// Context fields
-	FieldRequestID = "request_id"
-	FieldTraceID   = "trace_id"
-	FieldUserID    = "user_id"
-	FieldOperation = "operation"
-	FieldComponent = "component"
+	FieldRequestID     = "request_id"
+	FieldCorrelationID = "correlation_id"
+	FieldTraceID       = "trace_id"
+	FieldUserID        = "user_id"
+	FieldOperation     = "operation"
+	FieldComponent     = "component"
 
 	// Resource fields
 	FieldClusterName      = "cluster_name"
@@ -73,9 +77,10 @@ type MetricsCollector interface {
 type contextKey string
 
 const (
-	loggerKey    contextKey = "logger"
-	requestIDKey contextKey = "request_id"
-	traceIDKey   contextKey = "trace_id"
+	loggerKey        contextKey = "logger"
+	requestIDKey     contextKey = "request_id"
+	correlationIDKey contextKey = "correlation_id"
+	traceIDKey       contextKey = "trace_id"
 )
 
 // NewLogger creates a new logger with the specified configuration
@@ -85,9 +90,15 @@ func NewLogger(level slog.Level, format string) *Logger {
 
 // NewLoggerWithMetrics creates a new logger with metrics collection
 func NewLoggerWithMetrics(level slog.Level, format string, metricsCollector MetricsCollector) *Logger {
-	var handler slog.Handler
+	return &Logger{
+		Logger:           slog.New(newFormatHandler(os.Stdout, format, handlerOptions(level))),
+		metricsCollector: metricsCollector,
+	}
+}
 
-	opts := &slog.HandlerOptions{
+// handlerOptions returns the handler options shared by all log sinks.
+func handlerOptions(level slog.Leveler) *slog.HandlerOptions {
+	return &slog.HandlerOptions{
 		Level: level,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			// Customize time format
@@ -105,17 +116,15 @@ func NewLoggerWithMetrics(level slog.Level, format string, metricsCollector Metr
 			return a
 		},
 	}
+}
 
+// newFormatHandler returns a JSON or text handler writing to w.
+func newFormatHandler(w io.Writer, format string, opts *slog.HandlerOptions) slog.Handler {
 	switch strings.ToLower(format) {
 	case "json":
-		handler = slog.NewJSONHandler(os.Stdout, opts)
+		return slog.NewJSONHandler(w, opts)
 	default:
-		handler = slog.NewTextHandler(os.Stdout, opts)
-	}
-
-	return &Logger{
-		Logger:           slog.New(handler),
-		metricsCollector: metricsCollector,
+		return slog.NewTextHandler(w, opts)
 	}
 }
 
@@ -128,6 +137,11 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 		attrs = append(attrs, slog.String(FieldRequestID, requestID))
 	}
 
+	// Add correlation ID if present
+	if correlationID := GetCorrelationID(ctx); correlationID != "" {
+		attrs = append(attrs, slog.String(FieldCorrelationID, correlationID))
+	}
+
 	// Add trace ID if present
 	if traceID := GetTraceID(ctx); traceID != "" {
 		attrs = append(attrs, slog.String(FieldTraceID, traceID))
@@ -148,10 +162,15 @@ func (l *Logger) WithContext(ctx context.Context) *Logger {
 	return l
 }
 
-// WithComponent returns a logger for a specific component
+// WithComponent returns a logger for a specific component. Loggers created
+// with NewLoggerWithOptions apply the component's configured level, if any.
 func (l *Logger) WithComponent(component string) *Logger {
+	handler := l.Logger.Handler()
+	if filter, ok := handler.(*levelFilter); ok {
+		handler = filter.forComponent(component)
+	}
 	return &Logger{
-		Logger:           l.Logger.With(slog.String(FieldComponent, component)),
+		Logger:           slog.New(handler.WithAttrs([]slog.Attr{slog.String(FieldComponent, component)})),
 		metricsCollector: l.metricsCollector,
 	}
 }
@@ -387,6 +406,25 @@ func GetRequestID(ctx context.Context) string {
 	return ""
 }
 
+// NewCorrelationID generates an ID correlating a tool call across logs,
+// Kubernetes API requests and the tool result.
+func NewCorrelationID() string {
+	return uuid.NewString()
+}
+
+// ContextWithCorrelationID adds a correlation ID to the context
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// GetCorrelationID retrieves the correlation ID from context
+func GetCorrelationID(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
 // ContextWithTraceID adds a trace ID to the context
 func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
 	return context.WithValue(ctx, traceIDKey, traceID)
@@ -127,6 +127,44 @@ func TestLogger_WithComponent(t *testing.T) {
 	}
 }
 
+func TestLogger_WithContext_CorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLogger(slog.LevelDebug, "json")
+	logger.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	correlationID := NewCorrelationID()
+	ctx := ContextWithCorrelationID(context.Background(), correlationID)
+	logger.WithContext(ctx).Info("test message")
+
+	// Parse the JSON output
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("Failed to parse log output as JSON: %v", err)
+	}
+
+	if logEntry[FieldCorrelationID] != correlationID {
+		t.Errorf("Expected correlation_id %q, got %v", correlationID, logEntry[FieldCorrelationID])
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	if got := GetCorrelationID(context.Background()); got != "" {
+		t.Errorf("GetCorrelationID() = %q for context without correlation ID", got)
+	}
+
+	first, second := NewCorrelationID(), NewCorrelationID()
+	if first == "" || first == second {
+		t.Errorf("NewCorrelationID() returned %q and %q, want distinct non-empty IDs", first, second)
+	}
+
+	ctx := ContextWithCorrelationID(context.Background(), first)
+	if got := GetCorrelationID(ctx); got != first {
+		t.Errorf("GetCorrelationID() = %q, want %q", got, first)
+	}
+}
+
 func TestLogger_WithOperation(t *testing.T) {
 	var buf bytes.Buffer
 	logger := NewLogger(slog.LevelDebug, "json")
@@ -397,6 +435,7 @@ func TestFieldConstants(t *testing.T) {
 		"FieldClusterName":      FieldClusterName,
 		"FieldClusterNamespace": FieldClusterNamespace,
 		"FieldRequestID":        FieldRequestID,
+		"FieldCorrelationID":    FieldCorrelationID,
 		"FieldUserAgent":        FieldUserAgent,
 		"FieldRemoteAddr":       FieldRemoteAddr,
 		"FieldStatusCode":       FieldStatusCode,
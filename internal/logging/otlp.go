@@ -0,0 +1,310 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// otlpBatchSize is the number of records that triggers an export.
+	otlpBatchSize = 512
+
+	// otlpFlushInterval is the longest a record waits before being exported.
+	otlpFlushInterval = 5 * time.Second
+
+	// otlpMaxQueued bounds the records held while the collector is unreachable;
+	// older records are dropped beyond it.
+	otlpMaxQueued = 8 * otlpBatchSize
+)
+
+// otlpExporter batches log records and exports them to an OTLP/HTTP logs
+// endpoint using the JSON encoding.
+type otlpExporter struct {
+	endpoint string
+	client   *http.Client
+	resource otlpResource
+
+	mu      sync.Mutex
+	records []otlpLogRecord
+	dropped int
+
+	flush     chan struct{}
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// newOTLPExporter creates an exporter and starts its background flush loop.
+func newOTLPExporter(endpoint, serviceName, serviceVersion string) *otlpExporter {
+	e := &otlpExporter{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		resource: otlpResource{Attributes: []otlpKeyValue{
+			otlpString("service.name", serviceName),
+			otlpString("service.version", serviceVersion),
+		}},
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// enqueue adds a record to the next batch.
+func (e *otlpExporter) enqueue(record otlpLogRecord) {
+	e.mu.Lock()
+	if len(e.records) >= otlpMaxQueued {
+		e.records = e.records[1:]
+		e.dropped++
+	}
+	e.records = append(e.records, record)
+	full := len(e.records) >= otlpBatchSize
+	e.mu.Unlock()
+
+	if full {
+		select {
+		case e.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *otlpExporter) run() {
+	defer close(e.stopped)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.done:
+			e.export()
+			return
+		case <-e.flush:
+		case <-ticker.C:
+		}
+		e.export()
+	}
+}
+
+// export sends the queued records, keeping them for the next attempt on failure.
+func (e *otlpExporter) export() {
+	e.mu.Lock()
+	records := e.records
+	dropped := e.dropped
+	e.records = nil
+	e.dropped = 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		// The exporter cannot log through itself
+		fmt.Fprintf(os.Stderr, "otlp log export: dropped %d records while the collector was unreachable\n", dropped)
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	if err := e.send(records); err != nil {
+		fmt.Fprintf(os.Stderr, "otlp log export: %v\n", err)
+		e.mu.Lock()
+		e.records = append(records, e.records...)
+		if excess := len(e.records) - otlpMaxQueued; excess > 0 {
+			e.records = e.records[excess:]
+			e.dropped += excess
+		}
+		e.mu.Unlock()
+	}
+}
+
+func (e *otlpExporter) send(records []otlpLogRecord) error {
+	body, err := json.Marshal(otlpExportRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource: e.resource,
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlpScope{Name: "github.com/capi-mcp/capi-mcp-server"},
+			LogRecords: records,
+		}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("failed to encode records: %w", err)
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to send records: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Close exports the remaining records and stops the exporter.
+func (e *otlpExporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.done)
+		<-e.stopped
+	})
+	return nil
+}
+
+// otlpHandler converts slog records to OTLP log records.
+type otlpHandler struct {
+	exporter *otlpExporter
+	level    slog.Level
+	attrs    []otlpKeyValue
+	prefix   string // group prefix for attribute keys
+}
+
+func newOTLPHandler(exporter *otlpExporter, level slog.Level) *otlpHandler {
+	return &otlpHandler{exporter: exporter, level: level}
+}
+
+func (h *otlpHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *otlpHandler) Handle(_ context.Context, r slog.Record) error {
+	attrs := append([]otlpKeyValue(nil), h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = appendOTLPAttr(attrs, h.prefix, a)
+		return true
+	})
+
+	record := otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(r.Time.UnixNano(), 10),
+		SeverityNumber: otlpSeverity(r.Level),
+		SeverityText:   r.Level.String(),
+		Body:           otlpAnyValue{StringValue: ptr(r.Message)},
+		Attributes:     attrs,
+	}
+	h.exporter.enqueue(record)
+	return nil
+}
+
+func (h *otlpHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.attrs = append([]otlpKeyValue(nil), h.attrs...)
+	for _, a := range attrs {
+		handler.attrs = appendOTLPAttr(handler.attrs, h.prefix, a)
+	}
+	return &handler
+}
+
+func (h *otlpHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.prefix = h.prefix + name + "."
+	return &handler
+}
+
+// otlpSeverity maps a slog level to an OTLP severity number, where DEBUG is 5,
+// INFO 9, WARN 13 and ERROR 17.
+func otlpSeverity(level slog.Level) int {
+	return min(max(int(level)+9, 1), 24)
+}
+
+// appendOTLPAttr appends a slog attribute, flattening groups into dotted keys.
+func appendOTLPAttr(attrs []otlpKeyValue, prefix string, a slog.Attr) []otlpKeyValue {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = prefix + a.Key + "."
+		}
+		for _, member := range a.Value.Group() {
+			attrs = appendOTLPAttr(attrs, groupPrefix, member)
+		}
+		return attrs
+	}
+
+	return append(attrs, otlpKeyValue{Key: prefix + a.Key, Value: otlpValue(a.Value)})
+}
+
+// otlpValue converts a slog value to an OTLP value.
+func otlpValue(v slog.Value) otlpAnyValue {
+	switch v.Kind() {
+	case slog.KindBool:
+		return otlpAnyValue{BoolValue: ptr(v.Bool())}
+	case slog.KindInt64:
+		return otlpAnyValue{IntValue: ptr(strconv.FormatInt(v.Int64(), 10))}
+	case slog.KindUint64:
+		return otlpAnyValue{IntValue: ptr(strconv.FormatUint(v.Uint64(), 10))}
+	case slog.KindFloat64:
+		return otlpAnyValue{DoubleValue: ptr(v.Float64())}
+	case slog.KindDuration:
+		return otlpAnyValue{StringValue: ptr(v.Duration().String())}
+	case slog.KindTime:
+		return otlpAnyValue{StringValue: ptr(v.Time().Format(time.RFC3339Nano))}
+	default:
+		return otlpAnyValue{StringValue: ptr(v.String())}
+	}
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: ptr(value)}}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// OTLP/HTTP JSON encoding of ExportLogsServiceRequest.
+
+type otlpExportRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
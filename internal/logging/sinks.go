@@ -0,0 +1,254 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Log sinks accepted in Options.Sinks.
+const (
+	SinkStdout = "stdout"
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+	SinkSyslog = "syslog"
+	SinkOTLP   = "otlp"
+)
+
+// Options configures where logs are written and at which levels.
+type Options struct {
+	// Level is the minimum level for loggers without a component level.
+	Level slog.Level
+
+	// Format is "json" or "text". It applies to all sinks except OTLP.
+	Format string
+
+	// Sinks lists the destinations to write logs to. Empty means stdout.
+	Sinks []string
+
+	// FilePath is the file the file sink appends to.
+	FilePath string
+
+	// SyslogAddress is the syslog server for the syslog sink, as
+	// "network://host:port" (for example "udp://logs:514"). Empty uses the
+	// local syslog daemon.
+	SyslogAddress string
+
+	// OTLPEndpoint is the OTLP/HTTP logs endpoint for the otlp sink, for
+	// example "http://otel-collector:4318/v1/logs".
+	OTLPEndpoint string
+
+	// ServiceName and ServiceVersion identify the server in syslog and OTLP records.
+	ServiceName    string
+	ServiceVersion string
+
+	// ComponentLevels overrides Level for loggers created with WithComponent.
+	ComponentLevels map[string]slog.Level
+}
+
+// NewLoggerWithOptions creates a logger writing to the configured sinks with
+// per-component levels. The returned function flushes and closes the sinks.
+func NewLoggerWithOptions(opts Options, metricsCollector MetricsCollector) (*Logger, func() error, error) {
+	// Sinks accept the most verbose configured level; levelFilter applies the
+	// level of each logger's component
+	minLevel := opts.Level
+	for _, level := range opts.ComponentLevels {
+		minLevel = min(minLevel, level)
+	}
+	handlerOpts := handlerOptions(minLevel)
+
+	sinks := opts.Sinks
+	if len(sinks) == 0 {
+		sinks = []string{SinkStdout}
+	}
+
+	var handlers []slog.Handler
+	var closers []func() error
+	closeAll := func() error {
+		var errs []error
+		for _, closeSink := range closers {
+			errs = append(errs, closeSink())
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, sink := range sinks {
+		switch sink {
+		case SinkStdout:
+			handlers = append(handlers, newFormatHandler(os.Stdout, opts.Format, handlerOpts))
+		case SinkStderr:
+			handlers = append(handlers, newFormatHandler(os.Stderr, opts.Format, handlerOpts))
+		case SinkFile:
+			if opts.FilePath == "" {
+				closeAll()
+				return nil, nil, fmt.Errorf("file log sink requires a file path")
+			}
+			file, err := os.OpenFile(opts.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+			}
+			handlers = append(handlers, newFormatHandler(file, opts.Format, handlerOpts))
+			closers = append(closers, file.Close)
+		case SinkSyslog:
+			handler, closeSyslog, err := newSyslogHandler(opts.SyslogAddress, opts.ServiceName, opts.Format, handlerOpts)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("failed to connect to syslog: %w", err)
+			}
+			handlers = append(handlers, handler)
+			closers = append(closers, closeSyslog)
+		case SinkOTLP:
+			if opts.OTLPEndpoint == "" {
+				closeAll()
+				return nil, nil, fmt.Errorf("otlp log sink requires an endpoint")
+			}
+			exporter := newOTLPExporter(opts.OTLPEndpoint, opts.ServiceName, opts.ServiceVersion)
+			handlers = append(handlers, newOTLPHandler(exporter, minLevel))
+			closers = append(closers, exporter.Close)
+		default:
+			closeAll()
+			return nil, nil, fmt.Errorf("unknown log sink %q", sink)
+		}
+	}
+
+	var handler slog.Handler = multiHandler(handlers)
+	if len(handlers) == 1 {
+		handler = handlers[0]
+	}
+
+	logger := &Logger{
+		Logger: slog.New(&levelFilter{
+			Handler:    handler,
+			level:      opts.Level,
+			components: opts.ComponentLevels,
+		}),
+		metricsCollector: metricsCollector,
+	}
+	return logger, closeAll, nil
+}
+
+// levelFilter drops records below the level of the logger's component.
+type levelFilter struct {
+	slog.Handler
+	level      slog.Level
+	components map[string]slog.Level
+}
+
+// forComponent returns a filter applying the component's level, if configured.
+func (f *levelFilter) forComponent(component string) *levelFilter {
+	level, ok := f.components[component]
+	if !ok {
+		return f
+	}
+	return &levelFilter{Handler: f.Handler, level: level, components: f.components}
+}
+
+func (f *levelFilter) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= f.level && f.Handler.Enabled(ctx, level)
+}
+
+func (f *levelFilter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelFilter{Handler: f.Handler.WithAttrs(attrs), level: f.level, components: f.components}
+}
+
+func (f *levelFilter) WithGroup(name string) slog.Handler {
+	return &levelFilter{Handler: f.Handler.WithGroup(name), level: f.level, components: f.components}
+}
+
+// multiHandler writes each record to every handler that accepts its level.
+type multiHandler []slog.Handler
+
+func (m multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range m {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(multiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (m multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make(multiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
+
+// severityHandler dispatches records to one of four handlers by level, so a
+// sink such as syslog can record each message with its own severity.
+type severityHandler struct {
+	debug, info, warn, err slog.Handler
+}
+
+// newSeverityHandler creates a severityHandler formatting records for the
+// writer of each severity.
+func newSeverityHandler(debugW, infoW, warnW, errW io.Writer, format string, opts *slog.HandlerOptions) *severityHandler {
+	return &severityHandler{
+		debug: newFormatHandler(debugW, format, opts),
+		info:  newFormatHandler(infoW, format, opts),
+		warn:  newFormatHandler(warnW, format, opts),
+		err:   newFormatHandler(errW, format, opts),
+	}
+}
+
+func (h *severityHandler) handler(level slog.Level) slog.Handler {
+	switch {
+	case level >= slog.LevelError:
+		return h.err
+	case level >= slog.LevelWarn:
+		return h.warn
+	case level >= slog.LevelInfo:
+		return h.info
+	default:
+		return h.debug
+	}
+}
+
+func (h *severityHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler(level).Enabled(ctx, level)
+}
+
+func (h *severityHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler(r.Level).Handle(ctx, r)
+}
+
+func (h *severityHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &severityHandler{
+		debug: h.debug.WithAttrs(attrs),
+		info:  h.info.WithAttrs(attrs),
+		warn:  h.warn.WithAttrs(attrs),
+		err:   h.err.WithAttrs(attrs),
+	}
+}
+
+func (h *severityHandler) WithGroup(name string) slog.Handler {
+	return &severityHandler{
+		debug: h.debug.WithGroup(name),
+		info:  h.info.WithGroup(name),
+		warn:  h.warn.WithGroup(name),
+		err:   h.err.WithGroup(name),
+	}
+}
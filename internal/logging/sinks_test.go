@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewLoggerWithOptions_ComponentLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	logger, closeLogs, err := NewLoggerWithOptions(Options{
+		Level:    slog.LevelInfo,
+		Format:   "json",
+		Sinks:    []string{SinkFile},
+		FilePath: path,
+		ComponentLevels: map[string]slog.Level{
+			"kube":  slog.LevelWarn,
+			"tools": slog.LevelDebug,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewLoggerWithOptions() error = %v", err)
+	}
+
+	logger.Debug("root debug")
+	logger.Info("root info")
+	logger.WithComponent("kube").Info("kube info")
+	logger.WithComponent("kube").Warn("kube warn")
+	logger.WithComponent("tools").WithOperation("op").Debug("tools debug")
+
+	if err := closeLogs(); err != nil {
+		t.Fatalf("close error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	var messages []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Failed to parse log output as JSON: %v", err)
+		}
+		messages = append(messages, entry["msg"].(string))
+	}
+
+	expected := []string{"root info", "kube warn", "tools debug"}
+	if strings.Join(messages, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected messages %v, got %v", expected, messages)
+	}
+}
+
+func TestNewLoggerWithOptions_OTLP(t *testing.T) {
+	var mu sync.Mutex
+	var requests []otlpExportRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var request otlpExportRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			t.Errorf("Failed to parse OTLP request: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	logger, closeLogs, err := NewLoggerWithOptions(Options{
+		Level:          slog.LevelInfo,
+		Sinks:          []string{SinkOTLP},
+		OTLPEndpoint:   server.URL,
+		ServiceName:    "capi-mcp-server",
+		ServiceVersion: "test",
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewLoggerWithOptions() error = %v", err)
+	}
+
+	logger.WithComponent("tools").WithGroup("request").Warn("tool failed", "attempts", 3)
+	if err := closeLogs(); err != nil {
+		t.Fatalf("close error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 export request, got %d", len(requests))
+	}
+
+	records := requests[0].ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 log record, got %d", len(records))
+	}
+
+	record := records[0]
+	if *record.Body.StringValue != "tool failed" {
+		t.Errorf("Expected body 'tool failed', got %q", *record.Body.StringValue)
+	}
+	if record.SeverityNumber != 13 {
+		t.Errorf("Expected severity 13 for WARN, got %d", record.SeverityNumber)
+	}
+
+	attrs := make(map[string]otlpAnyValue)
+	for _, kv := range record.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if v, ok := attrs[FieldComponent]; !ok || *v.StringValue != "tools" {
+		t.Errorf("Expected component attribute 'tools', got %v", attrs)
+	}
+	if v, ok := attrs["request.attempts"]; !ok || *v.IntValue != "3" {
+		t.Errorf("Expected grouped attribute request.attempts=3, got %v", attrs)
+	}
+}
+
+func TestNewLoggerWithOptions_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{name: "unknown sink", opts: Options{Sinks: []string{"kafka"}}},
+		{name: "file sink without path", opts: Options{Sinks: []string{SinkFile}}},
+		{name: "otlp sink without endpoint", opts: Options{Sinks: []string{SinkOTLP}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := NewLoggerWithOptions(tt.opts, nil); err == nil {
+				t.Error("NewLoggerWithOptions() expected an error")
+			}
+		})
+	}
+}
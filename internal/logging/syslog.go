@@ -0,0 +1,45 @@
+//go:build !windows && !plan9
+
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"log/syslog"
+	"strings"
+)
+
+// newSyslogHandler creates a handler writing to syslog at the address, given
+// as "network://host:port", or to the local syslog daemon if address is empty.
+func newSyslogHandler(address, tag, format string, opts *slog.HandlerOptions) (slog.Handler, func() error, error) {
+	var network, raddr string
+	if address != "" {
+		network, raddr, _ = strings.Cut(address, "://")
+	}
+
+	w, err := syslog.Dial(network, raddr, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handler := newSeverityHandler(
+		syslogWriter(w.Debug),
+		syslogWriter(w.Info),
+		syslogWriter(w.Warning),
+		syslogWriter(w.Err),
+		format, opts,
+	)
+	return handler, w.Close, nil
+}
+
+// syslogWriter writes each formatted record as a syslog message of one severity.
+type syslogWriter func(message string) error
+
+var _ io.Writer = syslogWriter(nil)
+
+func (w syslogWriter) Write(p []byte) (int, error) {
+	if err := w(strings.TrimSuffix(string(p), "\n")); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// newSyslogHandler reports that syslog is not available on this platform.
+func newSyslogHandler(address, tag, format string, opts *slog.HandlerOptions) (slog.Handler, func() error, error) {
+	return nil, nil, fmt.Errorf("syslog is not supported on this platform")
+}
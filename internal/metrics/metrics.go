@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -24,6 +25,24 @@ const (
 	LabelCluster   = "cluster"
 	LabelNamespace = "namespace"
 	LabelErrorCode = "error_code"
+	LabelTemplate  = "template"
+	LabelOutcome   = "outcome"
+	LabelQuery     = "query"
+	LabelResult    = "result"
+	LabelIdentity  = "identity"
+
+	// OtherClusterLabel replaces the cluster label of clusters beyond the
+	// cluster label limit.
+	OtherClusterLabel = "_other"
+
+	// DefaultClusterLabelLimit is how many namespace and cluster pairs are
+	// labelled on operation metrics before further clusters are recorded as
+	// OtherClusterLabel.
+	DefaultClusterLabelLimit = 500
+
+	// Exemplar label names, linking observations to traces and logs
+	exemplarTraceID       = "trace_id"
+	exemplarCorrelationID = "correlation_id"
 )
 
 // Collector holds all Prometheus metrics
@@ -38,6 +57,10 @@ type Collector struct {
 	toolExecutionDuration *prometheus.HistogramVec
 	toolErrors            *prometheus.CounterVec
 
+	// Per-identity tool usage, for chargeback and abuse detection
+	identityToolCalls        *prometheus.CounterVec
+	identityClusterMutations *prometheus.CounterVec
+
 	// Kubernetes API metrics
 	kubernetesAPICallsTotal   *prometheus.CounterVec
 	kubernetesAPICallDuration *prometheus.HistogramVec
@@ -47,11 +70,29 @@ type Collector struct {
 	providerOperationsTotal   *prometheus.CounterVec
 	providerOperationDuration *prometheus.HistogramVec
 	providerErrors            *prometheus.CounterVec
+	providerCapabilityCache   *prometheus.CounterVec
 
 	// Cluster metrics
 	clustersTotal     *prometheus.GaugeVec
 	clusterOperations *prometheus.CounterVec
 
+	// Cluster lifecycle metrics
+	clusterProvisioningDuration *prometheus.HistogramVec
+	clusterDeletionDuration     *prometheus.HistogramVec
+
+	// Per-cluster tool operation metrics, with bounded cluster label cardinality
+	clusterOperationDuration *prometheus.HistogramVec
+	clusterLabelLimit        int
+	clusterLabelsMu          sync.Mutex
+	clusterLabels            map[string]bool // namespace/cluster pairs labelled so far
+
+	// Workload cluster health metrics, scraped through each cluster's kubeconfig
+	workloadAPIUp          *prometheus.GaugeVec
+	workloadAPILatency     *prometheus.GaugeVec
+	workloadNodes          *prometheus.GaugeVec
+	workloadNodesReady     *prometheus.GaugeVec
+	workloadNodeReadyRatio *prometheus.GaugeVec
+
 	// System metrics
 	serverInfo *prometheus.GaugeVec
 	buildInfo  *prometheus.GaugeVec
@@ -65,6 +106,9 @@ func NewCollector() *Collector {
 // NewCollectorWithRegisterer creates a new metrics collector with a custom registerer
 func NewCollectorWithRegisterer(registerer prometheus.Registerer) *Collector {
 	c := &Collector{
+		clusterLabelLimit: DefaultClusterLabelLimit,
+		clusterLabels:     make(map[string]bool),
+
 		// Request metrics
 		requestsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -117,6 +161,23 @@ func NewCollectorWithRegisterer(registerer prometheus.Registerer) *Collector {
 			[]string{LabelTool, LabelErrorCode},
 		),
 
+		// Per-identity tool usage metrics
+		identityToolCalls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricPrefix + "identity_tool_calls_total",
+				Help: "Total number of tool calls by identity",
+			},
+			[]string{LabelIdentity, LabelTool, LabelStatus},
+		),
+
+		identityClusterMutations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricPrefix + "identity_cluster_mutations_total",
+				Help: "Total number of successful tool calls changing a cluster by identity",
+			},
+			[]string{LabelIdentity, LabelTool},
+		),
+
 		// Kubernetes API metrics
 		kubernetesAPICallsTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -169,6 +230,14 @@ func NewCollectorWithRegisterer(registerer prometheus.Registerer) *Collector {
 			[]string{LabelProvider, LabelOperation, LabelErrorCode},
 		),
 
+		providerCapabilityCache: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: metricPrefix + "provider_capability_cache_requests_total",
+				Help: "Total number of provider capability queries by cache result (hit or miss)",
+			},
+			[]string{LabelProvider, LabelQuery, LabelResult},
+		),
+
 		// Cluster metrics
 		clustersTotal: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -186,6 +255,75 @@ func NewCollectorWithRegisterer(registerer prometheus.Registerer) *Collector {
 			[]string{LabelOperation, LabelProvider, LabelStatus},
 		),
 
+		// Cluster lifecycle metrics
+		clusterProvisioningDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    metricPrefix + "cluster_provisioning_duration_seconds",
+				Help:    "Time from the create_cluster call to the cluster reaching the Provisioned or Failed phase in seconds",
+				Buckets: []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600},
+			},
+			[]string{LabelProvider, LabelTemplate, LabelOutcome},
+		),
+
+		clusterDeletionDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    metricPrefix + "cluster_deletion_duration_seconds",
+				Help:    "Time from the delete_cluster call to the cluster being removed in seconds",
+				Buckets: []float64{30, 60, 120, 300, 600, 900, 1200, 1800},
+			},
+			[]string{LabelProvider, LabelTemplate},
+		),
+
+		clusterOperationDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    metricPrefix + "cluster_operation_duration_seconds",
+				Help:    "Duration of tool operations on clusters in seconds, with exemplars linking to traces",
+				Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 900},
+			},
+			[]string{LabelTool, LabelNamespace, LabelCluster, LabelStatus},
+		),
+
+		// Workload cluster health metrics
+		workloadAPIUp: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricPrefix + "workload_api_up",
+				Help: "Whether the workload cluster API server is reachable (1) or not (0)",
+			},
+			[]string{LabelCluster, LabelNamespace},
+		),
+
+		workloadAPILatency: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricPrefix + "workload_api_latency_seconds",
+				Help: "Latency of the last request to the workload cluster API server in seconds",
+			},
+			[]string{LabelCluster, LabelNamespace},
+		),
+
+		workloadNodes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricPrefix + "workload_nodes",
+				Help: "Number of nodes registered in the workload cluster",
+			},
+			[]string{LabelCluster, LabelNamespace},
+		),
+
+		workloadNodesReady: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricPrefix + "workload_nodes_ready",
+				Help: "Number of Ready nodes in the workload cluster",
+			},
+			[]string{LabelCluster, LabelNamespace},
+		),
+
+		workloadNodeReadyRatio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: metricPrefix + "workload_node_ready_ratio",
+				Help: "Fraction of the nodes of the workload cluster that are Ready",
+			},
+			[]string{LabelCluster, LabelNamespace},
+		),
+
 		// System metrics
 		serverInfo: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
@@ -212,14 +350,25 @@ func NewCollectorWithRegisterer(registerer prometheus.Registerer) *Collector {
 		c.toolInvocationsTotal,
 		c.toolExecutionDuration,
 		c.toolErrors,
+		c.identityToolCalls,
+		c.identityClusterMutations,
 		c.kubernetesAPICallsTotal,
 		c.kubernetesAPICallDuration,
 		c.kubernetesAPIErrors,
 		c.providerOperationsTotal,
 		c.providerOperationDuration,
 		c.providerErrors,
+		c.providerCapabilityCache,
 		c.clustersTotal,
 		c.clusterOperations,
+		c.clusterProvisioningDuration,
+		c.clusterDeletionDuration,
+		c.clusterOperationDuration,
+		c.workloadAPIUp,
+		c.workloadAPILatency,
+		c.workloadNodes,
+		c.workloadNodesReady,
+		c.workloadNodeReadyRatio,
 		c.serverInfo,
 		c.buildInfo,
 	)
@@ -266,6 +415,18 @@ func (c *Collector) IncToolErrors(tool, errorCode string) {
 	c.toolErrors.WithLabelValues(tool, errorCode).Inc()
 }
 
+// Per-identity tool usage methods
+
+// IncIdentityToolCalls increments the tool call counter of an identity
+func (c *Collector) IncIdentityToolCalls(identity, tool, status string) {
+	c.identityToolCalls.WithLabelValues(identity, tool, status).Inc()
+}
+
+// IncIdentityClusterMutations increments the cluster mutation counter of an identity
+func (c *Collector) IncIdentityClusterMutations(identity, tool string) {
+	c.identityClusterMutations.WithLabelValues(identity, tool).Inc()
+}
+
 // Kubernetes API metrics methods
 
 // IncKubernetesAPICalls increments Kubernetes API call counter
@@ -300,6 +461,11 @@ func (c *Collector) IncProviderErrors(provider, operation, errorCode string) {
 	c.providerErrors.WithLabelValues(provider, operation, errorCode).Inc()
 }
 
+// IncProviderCapabilityCache increments the provider capability cache counter
+func (c *Collector) IncProviderCapabilityCache(provider, query, result string) {
+	c.providerCapabilityCache.WithLabelValues(provider, query, result).Inc()
+}
+
 // Cluster metrics methods
 
 // SetClustersTotal sets the total number of clusters
@@ -312,6 +478,109 @@ func (c *Collector) IncClusterOperations(operation, provider, status string) {
 	c.clusterOperations.WithLabelValues(operation, provider, status).Inc()
 }
 
+// ObserveClusterProvisioningDuration records how long a cluster took to provision
+func (c *Collector) ObserveClusterProvisioningDuration(provider, template, outcome string, duration time.Duration) {
+	c.clusterProvisioningDuration.WithLabelValues(provider, template, outcome).Observe(duration.Seconds())
+}
+
+// ObserveClusterDeletionDuration records how long a cluster took to delete
+func (c *Collector) ObserveClusterDeletionDuration(provider, template string, duration time.Duration) {
+	c.clusterDeletionDuration.WithLabelValues(provider, template).Observe(duration.Seconds())
+}
+
+// SetClusterLabelLimit bounds how many namespace and cluster pairs are
+// labelled on operation metrics; further clusters are recorded as
+// OtherClusterLabel. A limit of zero records every cluster as OtherClusterLabel.
+func (c *Collector) SetClusterLabelLimit(limit int) {
+	c.clusterLabelsMu.Lock()
+	defer c.clusterLabelsMu.Unlock()
+	c.clusterLabelLimit = limit
+}
+
+// ObserveClusterOperation records the duration of a tool operation on a
+// cluster. The trace and correlation IDs of the call, when set, are attached
+// as an exemplar so dashboards can drill into the trace or logs of slow calls.
+func (c *Collector) ObserveClusterOperation(tool, namespace, cluster, status string, duration time.Duration, traceID, correlationID string) {
+	observer := c.clusterOperationDuration.WithLabelValues(tool, namespace, c.clusterLabel(namespace, cluster), status)
+
+	exemplar := prometheus.Labels{}
+	if traceID != "" {
+		exemplar[exemplarTraceID] = traceID
+	}
+	if correlationID != "" {
+		exemplar[exemplarCorrelationID] = correlationID
+	}
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && len(exemplar) > 0 {
+		exemplarObserver.ObserveWithExemplar(duration.Seconds(), exemplar)
+		return
+	}
+	observer.Observe(duration.Seconds())
+}
+
+// clusterLabel returns the cluster label value of a cluster, keeping the
+// number of labelled clusters within the limit.
+func (c *Collector) clusterLabel(namespace, cluster string) string {
+	if cluster == "" {
+		return ""
+	}
+
+	c.clusterLabelsMu.Lock()
+	defer c.clusterLabelsMu.Unlock()
+
+	key := namespace + "/" + cluster
+	if c.clusterLabels[key] {
+		return cluster
+	}
+	if len(c.clusterLabels) >= c.clusterLabelLimit {
+		return OtherClusterLabel
+	}
+	c.clusterLabels[key] = true
+	return cluster
+}
+
+// Workload cluster health metrics methods
+
+// SetWorkloadAPIHealth records whether a workload cluster API server is
+// reachable and the latency of the request checking it. The latency of an unreachable API server
+// is not recorded.
+func (c *Collector) SetWorkloadAPIHealth(cluster, namespace string, up bool, latency time.Duration) {
+	if !up {
+		c.workloadAPIUp.WithLabelValues(cluster, namespace).Set(0)
+		c.workloadAPILatency.DeleteLabelValues(cluster, namespace)
+		return
+	}
+	c.workloadAPIUp.WithLabelValues(cluster, namespace).Set(1)
+	c.workloadAPILatency.WithLabelValues(cluster, namespace).Set(latency.Seconds())
+}
+
+// SetWorkloadNodes records the nodes of a workload cluster and how many are
+// Ready. The ready ratio of a cluster without nodes is not recorded.
+func (c *Collector) SetWorkloadNodes(cluster, namespace string, nodes, ready int) {
+	c.workloadNodes.WithLabelValues(cluster, namespace).Set(float64(nodes))
+	c.workloadNodesReady.WithLabelValues(cluster, namespace).Set(float64(ready))
+	if nodes == 0 {
+		c.workloadNodeReadyRatio.DeleteLabelValues(cluster, namespace)
+		return
+	}
+	c.workloadNodeReadyRatio.WithLabelValues(cluster, namespace).Set(float64(ready) / float64(nodes))
+}
+
+// DeleteWorkloadNodes removes the node metrics of a workload cluster, e.g.
+// while its nodes cannot be listed.
+func (c *Collector) DeleteWorkloadNodes(cluster, namespace string) {
+	c.workloadNodes.DeleteLabelValues(cluster, namespace)
+	c.workloadNodesReady.DeleteLabelValues(cluster, namespace)
+	c.workloadNodeReadyRatio.DeleteLabelValues(cluster, namespace)
+}
+
+// DeleteWorkloadHealth removes all health metrics of a workload cluster, once
+// it is deleted or no longer provisioned.
+func (c *Collector) DeleteWorkloadHealth(cluster, namespace string) {
+	c.workloadAPIUp.DeleteLabelValues(cluster, namespace)
+	c.workloadAPILatency.DeleteLabelValues(cluster, namespace)
+	c.DeleteWorkloadNodes(cluster, namespace)
+}
+
 // System metrics methods
 
 // SetServerInfo sets server information
@@ -427,7 +696,11 @@ func (m *MetricsMiddleware) WrapProviderOperation(provider, operation string, fn
 // StartMetricsServer starts the Prometheus metrics HTTP server
 func StartMetricsServer(ctx context.Context, addr string, logger *slog.Logger) error {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	// OpenMetrics is negotiated so exemplars are exposed to scrapers that support them
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
 
 	// Add health check endpoint
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -80,6 +80,24 @@ func TestCollector_ToolMetrics(t *testing.T) {
 	}
 }
 
+func TestCollector_IdentityToolMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	collector := NewCollectorWithRegisterer(reg)
+
+	collector.IncIdentityToolCalls("ci", "create_cluster", "success")
+	collector.IncIdentityToolCalls("ci", "create_cluster", "error")
+	collector.IncIdentityToolCalls("ci", "create_cluster", "error")
+	collector.IncIdentityClusterMutations("ci", "create_cluster")
+
+	if value := testutil.ToFloat64(collector.identityToolCalls.WithLabelValues("ci", "create_cluster", "error")); value != 2 {
+		t.Errorf("Expected identity_tool_calls_total to be 2, got %f", value)
+	}
+
+	if value := testutil.ToFloat64(collector.identityClusterMutations.WithLabelValues("ci", "create_cluster")); value != 1 {
+		t.Errorf("Expected identity_cluster_mutations_total to be 1, got %f", value)
+	}
+}
+
 func TestCollector_KubernetesMetrics(t *testing.T) {
 	// Create isolated registry
 	reg := prometheus.NewRegistry()
@@ -111,6 +129,8 @@ func TestCollector_ProviderMetrics(t *testing.T) {
 	collector.IncProviderOperations("aws", "create_instance", "success")
 	collector.ObserveProviderOperationDuration("aws", "create_instance", 30*time.Second)
 	collector.IncProviderErrors("aws", "delete_instance", "NOT_FOUND")
+	collector.IncProviderCapabilityCache("aws", "regions", "hit")
+	collector.IncProviderCapabilityCache("aws", "regions", "hit")
 
 	// Verify values
 	if value := testutil.ToFloat64(collector.providerOperationsTotal.WithLabelValues("aws", "create_instance", "success")); value != 1 {
@@ -120,6 +140,10 @@ func TestCollector_ProviderMetrics(t *testing.T) {
 	if value := testutil.ToFloat64(collector.providerErrors.WithLabelValues("aws", "delete_instance", "NOT_FOUND")); value != 1 {
 		t.Errorf("Expected provider_errors_total to be 1, got %f", value)
 	}
+
+	if value := testutil.ToFloat64(collector.providerCapabilityCache.WithLabelValues("aws", "regions", "hit")); value != 2 {
+		t.Errorf("Expected provider_capability_cache_requests_total to be 2, got %f", value)
+	}
 }
 
 func TestCollector_ClusterMetrics(t *testing.T) {
@@ -142,6 +166,101 @@ func TestCollector_ClusterMetrics(t *testing.T) {
 	}
 }
 
+func TestCollector_ClusterLifecycleMetrics(t *testing.T) {
+	// Create isolated registry
+	reg := prometheus.NewRegistry()
+
+	collector := NewCollectorWithRegisterer(reg)
+
+	collector.ObserveClusterProvisioningDuration("aws", "aws-cluster-class", "provisioned", 10*time.Minute)
+	collector.ObserveClusterProvisioningDuration("aws", "aws-cluster-class", "provisioned", 12*time.Minute)
+	collector.ObserveClusterDeletionDuration("aws", "aws-cluster-class", 5*time.Minute)
+
+	if count := testutil.CollectAndCount(collector.clusterProvisioningDuration); count != 1 {
+		t.Errorf("Expected 1 cluster_provisioning_duration_seconds series, got %d", count)
+	}
+
+	if count := testutil.CollectAndCount(collector.clusterDeletionDuration); count != 1 {
+		t.Errorf("Expected 1 cluster_deletion_duration_seconds series, got %d", count)
+	}
+}
+
+func TestCollector_ClusterOperationMetrics(t *testing.T) {
+	// Create isolated registry
+	reg := prometheus.NewRegistry()
+
+	collector := NewCollectorWithRegisterer(reg)
+	collector.SetClusterLabelLimit(1)
+
+	collector.ObserveClusterOperation("scale_cluster", "team-a", "prod", "success", 2*time.Second, "4bf92f3577b34da6a3ce929d0e0e4736", "corr-1")
+	collector.ObserveClusterOperation("scale_cluster", "team-a", "prod", "success", time.Second, "", "corr-2")
+	collector.ObserveClusterOperation("scale_cluster", "team-a", "dev", "success", time.Second, "", "corr-3")
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	clusters := map[string]bool{}
+	exemplars := map[string]bool{}
+	for _, family := range families {
+		if family.GetName() != "capi_mcp_cluster_operation_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == LabelCluster {
+					clusters[label.GetValue()] = true
+				}
+			}
+			for _, bucket := range metric.GetHistogram().GetBucket() {
+				for _, label := range bucket.GetExemplar().GetLabel() {
+					exemplars[label.GetName()+"="+label.GetValue()] = true
+				}
+			}
+		}
+	}
+
+	if len(clusters) != 2 || !clusters["prod"] || !clusters[OtherClusterLabel] {
+		t.Errorf("Expected clusters prod and %s beyond the limit, got %v", OtherClusterLabel, clusters)
+	}
+	if !exemplars["trace_id=4bf92f3577b34da6a3ce929d0e0e4736"] {
+		t.Errorf("Expected an exemplar with the trace ID, got %v", exemplars)
+	}
+	if !exemplars["correlation_id=corr-3"] {
+		t.Errorf("Expected an exemplar with the correlation ID, got %v", exemplars)
+	}
+}
+
+func TestCollector_WorkloadHealthMetrics(t *testing.T) {
+	// Create isolated registry
+	reg := prometheus.NewRegistry()
+
+	collector := NewCollectorWithRegisterer(reg)
+
+	collector.SetWorkloadAPIHealth("prod", "team-a", true, 50*time.Millisecond)
+	collector.SetWorkloadNodes("prod", "team-a", 4, 3)
+	collector.SetWorkloadAPIHealth("dev", "team-a", false, 0)
+
+	if value := testutil.ToFloat64(collector.workloadNodeReadyRatio.WithLabelValues("prod", "team-a")); value != 0.75 {
+		t.Errorf("Expected workload_node_ready_ratio to be 0.75, got %f", value)
+	}
+	if value := testutil.ToFloat64(collector.workloadAPIUp.WithLabelValues("dev", "team-a")); value != 0 {
+		t.Errorf("Expected workload_api_up to be 0, got %f", value)
+	}
+	if count := testutil.CollectAndCount(collector.workloadAPILatency); count != 1 {
+		t.Errorf("Expected 1 workload_api_latency_seconds series, got %d", count)
+	}
+
+	collector.DeleteWorkloadHealth("prod", "team-a")
+	if count := testutil.CollectAndCount(collector.workloadNodes); count != 0 {
+		t.Errorf("Expected no workload_nodes series, got %d", count)
+	}
+	if count := testutil.CollectAndCount(collector.workloadAPIUp); count != 1 {
+		t.Errorf("Expected 1 workload_api_up series, got %d", count)
+	}
+}
+
 func TestTimer(t *testing.T) {
 	timer := NewTimer()
 
@@ -1,10 +1,14 @@
 package middleware
 
 import (
+	"bufio"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"net/http"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,6 +30,11 @@ func RequestLogger(logger *logging.Logger) func(http.Handler) http.Handler {
 			// Add request ID to context
 			ctx := logging.ContextWithRequestID(r.Context(), requestID)
 
+			// Continue the caller's trace, if any, so logs and metric exemplars link to it
+			if traceID := traceIDFromHeader(r.Header.Get("traceparent")); traceID != "" {
+				ctx = logging.ContextWithTraceID(ctx, traceID)
+			}
+
 			// Add logger to context
 			reqLogger := logger.WithContext(ctx)
 			ctx = logging.ContextWithLogger(ctx, reqLogger)
@@ -80,6 +89,21 @@ func ErrorHandler(logger *logging.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// traceIDFromHeader returns the trace ID of a W3C Trace Context traceparent
+// header such as "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+// or an empty string if the header is missing or malformed.
+func traceIDFromHeader(traceparent string) string {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ""
+	}
+	traceID := strings.ToLower(parts[1])
+	if _, err := hex.DecodeString(traceID); err != nil || traceID == strings.Repeat("0", 32) {
+		return ""
+	}
+	return traceID
+}
+
 // MCPErrorHandler wraps MCP handlers with error handling
 // Note: Simplified due to MCP SDK compatibility issues
 func MCPErrorHandler(logger *logging.Logger, handler interface{}) interface{} {
@@ -128,6 +152,17 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Hijack lets WebSocket handlers take over the connection.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	rw.statusCode = http.StatusSwitchingProtocols
+	rw.headerWritten = true
+	return hijacker.Hijack()
+}
+
 // RequestTimeout adds a timeout to requests
 func RequestTimeout(timeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,547 @@
+// Package oci pulls artifacts, such as bundles of cluster templates, from OCI
+// registries using the distribution API. It understands image manifests
+// whose layers are either single files named by their title annotation or
+// tar archives of files, and authenticates with the bearer token and basic
+// schemes registries use, taking credentials from a Docker config file.
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Media types of the manifests Pull accepts.
+const (
+	MediaTypeImageManifest  = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeImageIndex     = "application/vnd.oci.image.index.v1+json"
+)
+
+// titleAnnotation names the file a layer holds.
+const titleAnnotation = "org.opencontainers.image.title"
+
+// Size limits of what is pulled, so a hostile registry cannot exhaust memory.
+const (
+	maxManifestBytes = 4 << 20
+	maxArtifactBytes = 16 << 20
+	maxTagListBytes  = 4 << 20
+)
+
+// maxTagPages bounds how many pages of tags ListTags follows.
+const maxTagPages = 20
+
+// ErrRegistryNotAllowed is returned for references to registries outside the
+// configured allowlist.
+var ErrRegistryNotAllowed = errors.New("registry is not allowed")
+
+// Reference identifies an artifact in a registry, by tag or digest.
+type Reference struct {
+	Registry   string // host, with a port if any
+	Repository string
+	Tag        string
+	Digest     string // sha256:..., takes precedence over the tag
+}
+
+// ParseReference parses a reference such as
+// ghcr.io/org/templates:v1.2.0 or registry.example.com:5000/templates@sha256:....
+// The registry must be explicit; the tag defaults to latest.
+func ParseReference(ref string) (Reference, error) {
+	ref = strings.TrimPrefix(ref, "oci://")
+	registry, remainder, ok := strings.Cut(ref, "/")
+	if !ok || remainder == "" || (!strings.ContainsAny(registry, ".:") && registry != "localhost") {
+		return Reference{}, fmt.Errorf("reference %q must start with a registry host, e.g. ghcr.io/org/repository:tag", ref)
+	}
+
+	parsed := Reference{Registry: registry}
+	if repository, digest, ok := strings.Cut(remainder, "@"); ok {
+		if !strings.HasPrefix(digest, "sha256:") || len(digest) != len("sha256:")+64 {
+			return Reference{}, fmt.Errorf("reference %q has an invalid digest, want sha256:<64 hex digits>", ref)
+		}
+		parsed.Digest = digest
+		remainder = repository
+	}
+	if i := strings.LastIndex(remainder, ":"); i > strings.LastIndex(remainder, "/") {
+		parsed.Tag = remainder[i+1:]
+		remainder = remainder[:i]
+	}
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = "latest"
+	}
+	if remainder == "" || remainder != strings.ToLower(remainder) || strings.Contains(remainder, "//") {
+		return Reference{}, fmt.Errorf("reference %q has an invalid repository", ref)
+	}
+	parsed.Repository = remainder
+	return parsed, nil
+}
+
+// ParseRepository parses a repository reference without a tag or digest,
+// such as ghcr.io/org/cluster-templates/aws.
+func ParseRepository(repository string) (Reference, error) {
+	ref, err := ParseReference(repository)
+	if err != nil {
+		return Reference{}, err
+	}
+	if ref.Digest != "" || strings.TrimPrefix(repository, "oci://") != ref.Registry+"/"+ref.Repository {
+		return Reference{}, fmt.Errorf("repository %q must not have a tag or digest", repository)
+	}
+	ref.Tag = ""
+	return ref, nil
+}
+
+// String formats the reference as registry/repository[:tag][@digest].
+func (r Reference) String() string {
+	ref := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		ref += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		ref += "@" + r.Digest
+	}
+	return ref
+}
+
+// Artifact is a pulled artifact.
+type Artifact struct {
+	Reference string
+	Digest    string // of the manifest, identifying exactly what was pulled
+	Files     []File
+}
+
+// File is a file of an artifact.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Options configures a Client.
+type Options struct {
+	// AllowedRegistries restricts the registries artifacts may be pulled
+	// from, as host patterns such as "ghcr.io" or "*.example.com". Empty
+	// allows any registry.
+	AllowedRegistries []string
+
+	// PlainHTTPRegistries are registry hosts reached over HTTP instead of
+	// HTTPS, such as a local test registry.
+	PlainHTTPRegistries []string
+
+	// AuthFile is a Docker config file whose "auths" entries hold the
+	// credentials of each registry. Empty pulls anonymously.
+	AuthFile string
+}
+
+// Client pulls artifacts from OCI registries.
+type Client struct {
+	http      *http.Client
+	allowed   []string
+	plainHTTP []string
+	auths     map[string]credentials
+
+	mu     sync.Mutex
+	tokens map[string]string // bearer tokens by registry and scope
+}
+
+// credentials are the username and password of a registry.
+type credentials struct {
+	username string
+	password string
+}
+
+// NewClient creates a client, reading credentials from the auth file if one
+// is configured.
+func NewClient(options Options) (*Client, error) {
+	auths, err := loadAuthFile(options.AuthFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		http:      &http.Client{Timeout: 2 * time.Minute},
+		allowed:   options.AllowedRegistries,
+		plainHTTP: options.PlainHTTPRegistries,
+		auths:     auths,
+		tokens:    map[string]string{},
+	}, nil
+}
+
+// Allowed reports whether artifacts may be pulled from a registry.
+func (c *Client) Allowed(registry string) bool {
+	if len(c.allowed) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(c.allowed, func(pattern string) bool {
+		matched, _ := path.Match(pattern, registry)
+		return matched
+	})
+}
+
+// Pull downloads the manifest of an artifact and the files of its layers.
+func (c *Client) Pull(ctx context.Context, ref Reference) (*Artifact, error) {
+	if !c.Allowed(ref.Registry) {
+		return nil, fmt.Errorf("%w: %s", ErrRegistryNotAllowed, ref.Registry)
+	}
+
+	target := ref.Tag
+	if ref.Digest != "" {
+		target = ref.Digest
+	}
+	data, header, err := c.get(ctx, ref, "manifests/"+target, maxManifestBytes,
+		strings.Join([]string{MediaTypeImageManifest, MediaTypeDockerManifest, MediaTypeImageIndex}, ", "))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest of %s: %w", ref, err)
+	}
+	digest := sha256Digest(data)
+	if ref.Digest != "" && digest != ref.Digest {
+		return nil, fmt.Errorf("manifest of %s has digest %s", ref, digest)
+	}
+
+	var manifest struct {
+		MediaType string `json:"mediaType"`
+		Layers    []struct {
+			MediaType   string            `json:"mediaType"`
+			Digest      string            `json:"digest"`
+			Size        int64             `json:"size"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("manifest of %s is invalid: %w", ref, err)
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = header.Get("Content-Type")
+	}
+	if manifest.MediaType == MediaTypeImageIndex {
+		return nil, fmt.Errorf("%s is an image index; reference a single artifact manifest instead", ref)
+	}
+
+	artifact := &Artifact{Reference: ref.String(), Digest: digest}
+	var total int64
+	for _, layer := range manifest.Layers {
+		total += layer.Size
+		if total > maxArtifactBytes {
+			return nil, fmt.Errorf("%s is larger than %d bytes", ref, maxArtifactBytes)
+		}
+		blob, _, err := c.get(ctx, ref, "blobs/"+layer.Digest, layer.Size, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get layer %s of %s: %w", layer.Digest, ref, err)
+		}
+		if sha256Digest(blob) != layer.Digest {
+			return nil, fmt.Errorf("layer %s of %s does not match its digest", layer.Digest, ref)
+		}
+
+		if strings.Contains(layer.MediaType, "tar") {
+			files, err := untar(blob, strings.HasSuffix(layer.MediaType, "gzip"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract layer %s of %s: %w", layer.Digest, ref, err)
+			}
+			artifact.Files = append(artifact.Files, files...)
+			continue
+		}
+		name := layer.Annotations[titleAnnotation]
+		if name == "" {
+			name = layer.Digest
+		}
+		artifact.Files = append(artifact.Files, File{Name: name, Data: blob})
+	}
+	return artifact, nil
+}
+
+// ListTags lists the tags of a repository, following the registry's
+// pagination.
+func (c *Client) ListTags(ctx context.Context, repository Reference) ([]string, error) {
+	if !c.Allowed(repository.Registry) {
+		return nil, fmt.Errorf("%w: %s", ErrRegistryNotAllowed, repository.Registry)
+	}
+
+	var tags []string
+	resource := "tags/list"
+	for page := 0; resource != ""; page++ {
+		if page == maxTagPages {
+			return nil, fmt.Errorf("%s has more than %d pages of tags", repository.Registry+"/"+repository.Repository, maxTagPages)
+		}
+		data, header, err := c.get(ctx, repository, resource, maxTagListBytes, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s: %w", repository.Registry+"/"+repository.Repository, err)
+		}
+		var list struct {
+			Tags []string `json:"tags"`
+		}
+		if err := json.Unmarshal(data, &list); err != nil {
+			return nil, fmt.Errorf("tags of %s are invalid: %w", repository.Registry+"/"+repository.Repository, err)
+		}
+		tags = append(tags, list.Tags...)
+		resource = nextPage(header.Get("Link"), repository.Repository)
+	}
+	return tags, nil
+}
+
+// nextPage extracts the resource of the next page from a Link header such as
+// </v2/org/repo/tags/list?last=v1.2.0&n=100>; rel="next".
+func nextPage(link, repository string) string {
+	target, params, ok := strings.Cut(link, ";")
+	if !ok || !strings.Contains(strings.ReplaceAll(params, " ", ""), `rel="next"`) {
+		return ""
+	}
+	target = strings.Trim(strings.TrimSpace(target), "<>")
+	if parsed, err := url.Parse(target); err == nil {
+		target = parsed.RequestURI()
+	}
+	return strings.TrimPrefix(target, "/v2/"+repository+"/")
+}
+
+// get fetches a path under the repository's /v2 endpoint, authenticating
+// when the registry challenges the request.
+func (c *Client) get(ctx context.Context, ref Reference, resource string, limit int64, accept string) ([]byte, http.Header, error) {
+	scheme := "https"
+	if slices.Contains(c.plainHTTP, ref.Registry) {
+		scheme = "http"
+	}
+	endpoint := fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Repository, resource)
+	scope := fmt.Sprintf("repository:%s:pull", ref.Repository)
+
+	var resp *http.Response
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		c.authorize(req, ref.Registry, scope)
+
+		resp, err = c.http.Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			break
+		}
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(ctx, ref.Registry, scope, challenge); err != nil {
+			return nil, nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("GET %s returned %s", endpoint, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, nil, fmt.Errorf("GET %s returned more than %d bytes", endpoint, limit)
+	}
+	return data, resp.Header, nil
+}
+
+// authorize adds the bearer token or basic credentials of a registry to a
+// request.
+func (c *Client) authorize(req *http.Request, registry, scope string) {
+	c.mu.Lock()
+	token, ok := c.tokens[registry+" "+scope]
+	c.mu.Unlock()
+	switch {
+	case ok && token != "":
+		req.Header.Set("Authorization", "Bearer "+token)
+	case ok:
+		if creds, found := c.auths[registry]; found {
+			req.SetBasicAuth(creds.username, creds.password)
+		}
+	}
+}
+
+// authenticate answers a registry's WWW-Authenticate challenge: it fetches a
+// bearer token from the realm named by a Bearer challenge, or switches to
+// basic credentials for a Basic one.
+func (c *Client) authenticate(ctx context.Context, registry, scope, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	creds, hasCreds := c.auths[registry]
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCreds {
+			return fmt.Errorf("registry %s requires credentials", registry)
+		}
+		c.mu.Lock()
+		c.tokens[registry+" "+scope] = ""
+		c.mu.Unlock()
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("registry %s requires unsupported authentication %q", registry, challenge)
+	}
+
+	attributes := parseChallenge(params)
+	realm, err := url.Parse(attributes["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("registry %s sent an invalid token realm %q", registry, attributes["realm"])
+	}
+	query := realm.Query()
+	if service := attributes["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if hasCreds {
+		req.SetBasicAuth(creds.username, creds.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get a token for registry %s: %w", registry, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get a token for registry %s: %s", registry, resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode the token of registry %s: %w", registry, err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return fmt.Errorf("registry %s returned an empty token", registry)
+	}
+
+	c.mu.Lock()
+	c.tokens[registry+" "+scope] = token.Token
+	c.mu.Unlock()
+	return nil
+}
+
+// parseChallenge parses the comma separated key="value" attributes of a
+// WWW-Authenticate challenge.
+func parseChallenge(params string) map[string]string {
+	attributes := map[string]string{}
+	for params != "" {
+		var pair string
+		// Values are quoted and may contain commas
+		key, rest, ok := strings.Cut(params, "=")
+		if !ok {
+			break
+		}
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				break
+			}
+			pair, params = rest[1:end+1], strings.TrimPrefix(rest[end+2:], ",")
+		} else {
+			pair, params, _ = strings.Cut(rest, ",")
+		}
+		attributes[strings.ToLower(strings.TrimSpace(key))] = pair
+		params = strings.TrimSpace(params)
+	}
+	return attributes
+}
+
+// untar extracts the regular files of a tar archive, optionally gzipped.
+func untar(data []byte, gzipped bool) ([]File, error) {
+	var reader io.Reader = bytes.NewReader(data)
+	if gzipped {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	var files []File
+	var total int64
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		total += header.Size
+		if total > maxArtifactBytes {
+			return nil, fmt.Errorf("archive is larger than %d bytes", maxArtifactBytes)
+		}
+		content, err := io.ReadAll(io.LimitReader(archive, header.Size))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Name: path.Clean(header.Name), Data: content})
+	}
+}
+
+// loadAuthFile reads the registry credentials of a Docker config file.
+func loadAuthFile(file string) (map[string]credentials, error) {
+	auths := map[string]credentials{}
+	if file == "" {
+		return auths, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry auth file: %w", err)
+	}
+
+	var config struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse registry auth file %s: %w", file, err)
+	}
+	for registry, entry := range config.Auths {
+		creds := credentials{username: entry.Username, password: entry.Password}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("registry auth file %s has invalid credentials for %s: %w", file, registry, err)
+			}
+			creds.username, creds.password, _ = strings.Cut(string(decoded), ":")
+		}
+		// Entries may be keyed by URL, e.g. https://index.docker.io/v1/
+		host := strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		auths[host] = creds
+	}
+	return auths, nil
+}
+
+// sha256Digest returns the OCI digest of data.
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,309 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRegistry serves artifacts of one repository, requiring a bearer token
+// issued to the credentials user:secret.
+type testRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte // by tag and digest
+	blobs     map[string][]byte // by digest
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	registry := &testRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	registry.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			user, password, ok := r.BasicAuth()
+			if !ok || user != "user" || password != "secret" || !strings.HasSuffix(r.URL.Query().Get("scope"), ":pull") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "pull-token"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate",
+				fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:templates:pull"`, registry.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/v2/templates/tags/list":
+			// Two tags per page
+			tags := slices.Sorted(maps.Keys(registry.manifests))
+			tags = slices.DeleteFunc(tags, func(tag string) bool { return strings.HasPrefix(tag, "sha256:") })
+			if last := r.URL.Query().Get("last"); last != "" {
+				tags = tags[slices.Index(tags, last)+1:]
+			}
+			if len(tags) > 2 {
+				tags = tags[:2]
+				w.Header().Set("Link", fmt.Sprintf(`</v2/templates/tags/list?last=%s&n=2>; rel="next"`, tags[1]))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": "templates", "tags": tags})
+		case strings.HasPrefix(r.URL.Path, "/v2/templates/manifests/"):
+			manifest, ok := registry.manifests[strings.TrimPrefix(r.URL.Path, "/v2/templates/manifests/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", MediaTypeImageManifest)
+			_, _ = w.Write(manifest)
+		case strings.HasPrefix(r.URL.Path, "/v2/templates/blobs/"):
+			blob, ok := registry.blobs[strings.TrimPrefix(r.URL.Path, "/v2/templates/blobs/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(blob)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(registry.server.Close)
+	return registry
+}
+
+func (r *testRegistry) host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// push stores an artifact with the given layers under a tag and returns the
+// digest of its manifest.
+func (r *testRegistry) push(t *testing.T, tag string, layers ...map[string]interface{}) string {
+	for _, layer := range layers {
+		data := layer["data"].([]byte)
+		delete(layer, "data")
+		layer["digest"] = sha256Digest(data)
+		layer["size"] = len(data)
+		r.blobs[sha256Digest(data)] = data
+	}
+	manifest, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     MediaTypeImageManifest,
+		"layers":        layers,
+	})
+	require.NoError(t, err)
+	r.manifests[tag] = manifest
+	r.manifests[sha256Digest(manifest)] = manifest
+	return sha256Digest(manifest)
+}
+
+func writeAuthFile(t *testing.T, registry, auth string) string {
+	file := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf(`{"auths":{%q:{"auth":%q}}}`, registry, auth)), 0o600))
+	return file
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	require.NoError(t, archive.WriteHeader(&tar.Header{Name: "bundle/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	for name, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestClient_Pull(t *testing.T) {
+	ctx := context.Background()
+	registry := newTestRegistry(t)
+	digest := registry.push(t, "v1.0.0",
+		map[string]interface{}{
+			"mediaType":   "application/vnd.oci.image.layer.v1.tar+gzip",
+			"data":        tarGz(t, map[string]string{"bundle/templates.yaml": "kind: AWSMachineTemplate\n"}),
+			"annotations": map[string]string{},
+		},
+		map[string]interface{}{
+			"mediaType":   "application/yaml",
+			"data":        []byte("kind: ClusterClass\n"),
+			"annotations": map[string]string{titleAnnotation: "clusterclass.yaml"},
+		},
+	)
+
+	client, err := NewClient(Options{
+		PlainHTTPRegistries: []string{registry.host()},
+		AuthFile:            writeAuthFile(t, "https://"+registry.host()+"/v1/", "dXNlcjpzZWNyZXQ="), // user:secret
+	})
+	require.NoError(t, err)
+
+	t.Run("by tag", func(t *testing.T) {
+		ref, err := ParseReference(registry.host() + "/templates:v1.0.0")
+		require.NoError(t, err)
+
+		artifact, err := client.Pull(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, digest, artifact.Digest)
+		assert.Equal(t, []File{
+			{Name: "bundle/templates.yaml", Data: []byte("kind: AWSMachineTemplate\n")},
+			{Name: "clusterclass.yaml", Data: []byte("kind: ClusterClass\n")},
+		}, artifact.Files)
+	})
+
+	t.Run("by digest", func(t *testing.T) {
+		artifact, err := client.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Digest: digest})
+		require.NoError(t, err)
+		assert.Len(t, artifact.Files, 2)
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		other := registry.push(t, "v2.0.0", map[string]interface{}{"mediaType": "application/yaml", "data": []byte("kind: ClusterClass\n")})
+		registry.manifests[other] = registry.manifests[digest]
+
+		_, err := client.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Digest: other})
+		assert.ErrorContains(t, err, "has digest "+digest)
+	})
+
+	t.Run("corrupt layer", func(t *testing.T) {
+		registry.push(t, "corrupt", map[string]interface{}{"mediaType": "application/yaml", "data": []byte("kind: ClusterClass\n")})
+		registry.blobs[sha256Digest([]byte("kind: ClusterClass\n"))] = []byte("kind: Secret\n")
+		defer func() { registry.blobs[sha256Digest([]byte("kind: ClusterClass\n"))] = []byte("kind: ClusterClass\n") }()
+
+		_, err := client.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Tag: "corrupt"})
+		assert.ErrorContains(t, err, "does not match its digest")
+	})
+
+	t.Run("image index", func(t *testing.T) {
+		registry.manifests["multi"] = []byte(`{"schemaVersion":2,"mediaType":"` + MediaTypeImageIndex + `","manifests":[]}`)
+
+		_, err := client.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Tag: "multi"})
+		assert.ErrorContains(t, err, "is an image index")
+	})
+
+	t.Run("missing tag", func(t *testing.T) {
+		_, err := client.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Tag: "v9"})
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+
+	t.Run("without credentials", func(t *testing.T) {
+		anonymous, err := NewClient(Options{PlainHTTPRegistries: []string{registry.host()}})
+		require.NoError(t, err)
+
+		_, err = anonymous.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Tag: "v1.0.0"})
+		assert.ErrorContains(t, err, "failed to get a token")
+	})
+
+	t.Run("registry not allowed", func(t *testing.T) {
+		restricted, err := NewClient(Options{AllowedRegistries: []string{"ghcr.io", "*.example.com"}})
+		require.NoError(t, err)
+		assert.True(t, restricted.Allowed("registry.example.com"))
+
+		_, err = restricted.Pull(ctx, Reference{Registry: registry.host(), Repository: "templates", Tag: "v1.0.0"})
+		assert.ErrorIs(t, err, ErrRegistryNotAllowed)
+	})
+}
+
+func TestClient_ListTags(t *testing.T) {
+	ctx := context.Background()
+	registry := newTestRegistry(t)
+	for _, tag := range []string{"v1.0.0", "v1.1.0", "v1.2.0", "v2.0.0", "latest"} {
+		registry.push(t, tag, map[string]interface{}{"mediaType": "application/yaml", "data": []byte("kind: ClusterClass\n")})
+	}
+
+	client, err := NewClient(Options{
+		PlainHTTPRegistries: []string{registry.host()},
+		AuthFile:            writeAuthFile(t, registry.host(), "dXNlcjpzZWNyZXQ="),
+	})
+	require.NoError(t, err)
+
+	repository, err := ParseRepository(registry.host() + "/templates")
+	require.NoError(t, err)
+	tags, err := client.ListTags(ctx, repository)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"latest", "v1.0.0", "v1.1.0", "v1.2.0", "v2.0.0"}, tags)
+
+	_, err = client.ListTags(ctx, Reference{Registry: registry.host(), Repository: "missing"})
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestParseRepository(t *testing.T) {
+	repository, err := ParseRepository("ghcr.io/org/cluster-templates/aws")
+	require.NoError(t, err)
+	assert.Equal(t, Reference{Registry: "ghcr.io", Repository: "org/cluster-templates/aws"}, repository)
+
+	for _, invalid := range []string{"ghcr.io/org/aws:v1", "ghcr.io/org/aws@sha256:" + strings.Repeat("a", 64), "org/aws"} {
+		_, err := ParseRepository(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNextPage(t *testing.T) {
+	assert.Equal(t, "tags/list?last=v1&n=2", nextPage(`</v2/org/repo/tags/list?last=v1&n=2>; rel="next"`, "org/repo"))
+	assert.Equal(t, "tags/list?last=v1", nextPage(`<https://ghcr.io/v2/org/repo/tags/list?last=v1>; rel="next"`, "org/repo"))
+	assert.Empty(t, nextPage("", "org/repo"))
+	assert.Empty(t, nextPage(`</v2/org/repo/tags/list?last=v1>; rel="prev"`, "org/repo"))
+}
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		ref     string
+		want    Reference
+		wantErr bool
+	}{
+		{ref: "ghcr.io/org/templates:v1.2.0", want: Reference{Registry: "ghcr.io", Repository: "org/templates", Tag: "v1.2.0"}},
+		{ref: "oci://ghcr.io/org/templates", want: Reference{Registry: "ghcr.io", Repository: "org/templates", Tag: "latest"}},
+		{ref: "localhost:5000/templates@" + digest, want: Reference{Registry: "localhost:5000", Repository: "templates", Digest: digest}},
+		{ref: "localhost/templates:v1@" + digest, want: Reference{Registry: "localhost", Repository: "templates", Tag: "v1", Digest: digest}},
+		{ref: "org/templates:v1", wantErr: true},
+		{ref: "ghcr.io", wantErr: true},
+		{ref: "ghcr.io/Org/templates", wantErr: true},
+		{ref: "ghcr.io/org/templates@sha256:abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			ref, err := ParseReference(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ref)
+			assert.Equal(t, strings.TrimPrefix(tt.ref, "oci://"), strings.TrimSuffix(ref.String(), ":latest"))
+		})
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:a:pull,push",
+	}, parseChallenge(`realm="https://auth.example.com/token",service="registry.example.com",scope="repository:a:pull,push"`))
+	assert.Equal(t, map[string]string{"realm": "registry"}, parseChallenge(`Realm=registry`))
+}
+
+func TestLoadAuthFile(t *testing.T) {
+	auths, err := loadAuthFile(writeAuthFile(t, "ghcr.io", "dXNlcjpzZWNyZXQ="))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]credentials{"ghcr.io": {username: "user", password: "secret"}}, auths)
+
+	_, err = loadAuthFile(writeAuthFile(t, "ghcr.io", "not base64"))
+	assert.Error(t, err)
+	_, err = loadAuthFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
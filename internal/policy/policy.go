@@ -0,0 +1,136 @@
+// Package policy evaluates admission policies for mutating tool calls, so
+// platform teams can enforce rules such as minimum control plane sizes for
+// production clusters without changing the server.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Input is the document a policy is evaluated against.
+type Input struct {
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments"`
+	Namespace string                 `json:"namespace,omitempty"`
+	Identity  Identity               `json:"identity"`
+}
+
+// Identity describes the caller of a tool.
+type Identity struct {
+	Name         string   `json:"name"`
+	Groups       []string `json:"groups,omitempty"`
+	Namespaces   []string `json:"namespaces,omitempty"`
+	Unrestricted bool     `json:"unrestricted"`
+}
+
+// Decision is the outcome of a policy evaluation.
+type Decision struct {
+	Allowed bool
+	// Reasons explains a denial.
+	Reasons []string
+}
+
+// Evaluator evaluates admission policies.
+type Evaluator interface {
+	Evaluate(ctx context.Context, input Input) (*Decision, error)
+}
+
+// OPAEvaluator evaluates policies with an Open Policy Agent server through its
+// data API. The queried document may be:
+//   - a set of denial messages, as produced by `deny contains msg if { ... }`;
+//     the call is allowed when it is empty,
+//   - a boolean, allowing the call when true, or
+//   - an object with a boolean "allow" and optional "deny" messages.
+type OPAEvaluator struct {
+	url    string
+	client *http.Client
+}
+
+// NewOPAEvaluator creates an evaluator querying the OPA data API at url, e.g.
+// http://opa:8181/v1/data/capi_mcp/deny.
+func NewOPAEvaluator(url string, timeout time.Duration) *OPAEvaluator {
+	return &OPAEvaluator{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Evaluate queries OPA for the decision on input.
+func (e *OPAEvaluator) Evaluate(ctx context.Context, input Input) (*Decision, error) {
+	body, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy input: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create policy request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("policy request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("policy server returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse policy response: %w", err)
+	}
+	if len(response.Result) == 0 {
+		// OPA omits the result when the queried document is undefined, which
+		// usually means the policy path is wrong
+		return nil, fmt.Errorf("policy decision is undefined at %s", e.url)
+	}
+	return parseDecision(response.Result)
+}
+
+// parseDecision interprets an OPA result document.
+func parseDecision(result json.RawMessage) (*Decision, error) {
+	var allowed bool
+	if err := json.Unmarshal(result, &allowed); err == nil {
+		decision := &Decision{Allowed: allowed}
+		if !allowed {
+			decision.Reasons = []string{"denied by policy"}
+		}
+		return decision, nil
+	}
+
+	var denials []string
+	if err := json.Unmarshal(result, &denials); err == nil {
+		return &Decision{Allowed: len(denials) == 0, Reasons: denials}, nil
+	}
+
+	var object struct {
+		Allow *bool    `json:"allow"`
+		Deny  []string `json:"deny"`
+	}
+	if err := json.Unmarshal(result, &object); err != nil || (object.Allow == nil && object.Deny == nil) {
+		return nil, fmt.Errorf("unsupported policy decision %s: expected a boolean, a list of denial messages or an object with allow and deny", result)
+	}
+	decision := &Decision{Allowed: len(object.Deny) == 0, Reasons: object.Deny}
+	if object.Allow != nil && !*object.Allow {
+		decision.Allowed = false
+		if len(decision.Reasons) == 0 {
+			decision.Reasons = []string{"denied by policy"}
+		}
+	}
+	return decision, nil
+}
@@ -0,0 +1,119 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOPAEvaluator_Evaluate(t *testing.T) {
+	input := Input{
+		Tool: "create_cluster",
+		Arguments: map[string]interface{}{
+			"clusterName": "prod-east",
+			"variables":   map[string]interface{}{"controlPlaneReplicas": float64(1)},
+		},
+		Namespace: "team-a",
+		Identity:  Identity{Name: "alice", Groups: []string{"platform"}},
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		want     *Decision
+		wantErr  string
+	}{
+		{
+			name:     "empty deny set",
+			response: `{"result": []}`,
+			want:     &Decision{Allowed: true, Reasons: []string{}},
+		},
+		{
+			name:     "deny set",
+			response: `{"result": ["prod clusters must have at least 3 control plane replicas"]}`,
+			want:     &Decision{Allowed: false, Reasons: []string{"prod clusters must have at least 3 control plane replicas"}},
+		},
+		{
+			name:     "boolean allow",
+			response: `{"result": true}`,
+			want:     &Decision{Allowed: true},
+		},
+		{
+			name:     "boolean deny",
+			response: `{"result": false}`,
+			want:     &Decision{Allowed: false, Reasons: []string{"denied by policy"}},
+		},
+		{
+			name:     "object with deny messages",
+			response: `{"result": {"allow": false, "deny": ["tenant quota exceeded"]}}`,
+			want:     &Decision{Allowed: false, Reasons: []string{"tenant quota exceeded"}},
+		},
+		{
+			name:     "object allow",
+			response: `{"result": {"allow": true}}`,
+			want:     &Decision{Allowed: true},
+		},
+		{
+			name:     "undefined decision",
+			response: `{}`,
+			wantErr:  "undefined",
+		},
+		{
+			name:     "unsupported decision",
+			response: `{"result": {"verdict": "yes"}}`,
+			wantErr:  "unsupported policy decision",
+		},
+		{
+			name:     "server error",
+			status:   http.StatusInternalServerError,
+			response: `{"code": "internal_error"}`,
+			wantErr:  "500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+
+				var body struct {
+					Input Input `json:"input"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, input, body.Input)
+
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			decision, err := NewOPAEvaluator(server.URL+"/v1/data/capi_mcp/deny", time.Second).Evaluate(context.Background(), input)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, decision)
+		})
+	}
+}
+
+func TestOPAEvaluator_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	_, err := NewOPAEvaluator(url, time.Second).Evaluate(context.Background(), Input{Tool: "delete_cluster"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "policy request failed")
+}
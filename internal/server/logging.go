@@ -0,0 +1,39 @@
+package server
+
+import (
+	"log/slog"
+
+	"k8s.io/klog/v2"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/config"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+// ComponentKube is the log component of Kubernetes client library output.
+const ComponentKube = "kube"
+
+// LoggingOptions returns the log sink and level options configured in cfg.
+func LoggingOptions(cfg *config.Config) logging.Options {
+	levels := make(map[string]slog.Level, len(cfg.LogComponentLevels))
+	for component, level := range cfg.LogComponentLevels {
+		levels[component] = logging.ParseLevel(level)
+	}
+
+	return logging.Options{
+		Level:           logging.ParseLevel(cfg.LogLevel),
+		Format:          cfg.LogFormat,
+		Sinks:           cfg.LogSinks,
+		FilePath:        cfg.LogFile,
+		SyslogAddress:   cfg.LogSyslogAddress,
+		OTLPEndpoint:    cfg.LogOTLPEndpoint,
+		ServiceName:     "capi-mcp-server",
+		ServiceVersion:  cfg.Version,
+		ComponentLevels: levels,
+	}
+}
+
+// RouteKubernetesLogs sends client-go's klog output to logger under the kube
+// component, so it reaches the configured sinks at the kube component level.
+func RouteKubernetesLogs(logger *logging.Logger) {
+	klog.SetSlogLogger(logger.WithComponent(ComponentKube).Logger)
+}
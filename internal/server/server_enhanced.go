@@ -2,30 +2,84 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/capi-mcp/capi-mcp-server/internal/accounting"
+	"github.com/capi-mcp/capi-mcp-server/internal/advisory"
+	"github.com/capi-mcp/capi-mcp-server/internal/approval"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
 	"github.com/capi-mcp/capi-mcp-server/internal/config"
 	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/events"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
 	"github.com/capi-mcp/capi-mcp-server/internal/kube"
 	"github.com/capi-mcp/capi-mcp-server/internal/logging"
 	"github.com/capi-mcp/capi-mcp-server/internal/metrics"
 	"github.com/capi-mcp/capi-mcp-server/internal/middleware"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+	"github.com/capi-mcp/capi-mcp-server/internal/policy"
 	"github.com/capi-mcp/capi-mcp-server/internal/service"
+	"github.com/capi-mcp/capi-mcp-server/internal/simulation"
+	"github.com/capi-mcp/capi-mcp-server/internal/snapshot"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
 	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
 	"github.com/capi-mcp/capi-mcp-server/pkg/provider/aws"
 	"github.com/capi-mcp/capi-mcp-server/pkg/tools"
 )
 
+// approvalNotifyTimeout bounds the requests forwarding approval requests to
+// Slack and PagerDuty.
+const approvalNotifyTimeout = 10 * time.Second
+
 // EnhancedServer represents the CAPI MCP server with enhanced error handling and logging.
 type EnhancedServer struct {
 	config           *config.Config
 	logger           *logging.Logger
 	mcpServer        *mcp.Server
 	metricsCollector *metrics.Collector
+	authenticator    *auth.Authenticator
+
+	// closeLogs flushes and closes the log sinks.
+	closeLogs func() error
+
+	// identityServers holds the MCP server for each identity, with tools scoped
+	// to that identity's namespaces. The admin identity uses mcpServer.
+	identityServers map[*auth.Identity]*mcp.Server
+
+	// restHandlers holds the REST API handler for each identity, when
+	// RESTAPIEnabled is set. Tool admins have none.
+	restHandlers map[*auth.Identity]http.Handler
+
+	// clusterService is kept to run its background status refresher.
+	clusterService *service.EnhancedClusterService
+
+	// awsCatalog is refreshed in the background when AWSCatalogEnabled is set.
+	awsCatalog *aws.Catalog
+
+	// advisor is refreshed in the background when VersionAdvisoriesURL is set.
+	advisor *advisory.Advisor
+
+	// approvals of high-risk operations, when ApprovalsEnabled is set, and
+	// the PagerDuty integration polled for the approvals given there.
+	approvals       *tools.Approvals
+	approvalsPoller approval.Poller
+
+	// events broadcasts cluster lifecycle events and operation progress when
+	// EventStreamEnabled is set, following the clusters of eventSource.
+	events      *events.Broker
+	eventSource events.ClusterSource
+
+	// simulator stands in for the management cluster when SimulationEnabled
+	// is set.
+	simulator *simulation.Simulator
 }
 
 // NewEnhanced creates a new server instance with enhanced error handling and logging.
@@ -39,19 +93,27 @@ func NewEnhanced(cfg *config.Config) (*EnhancedServer, error) {
 
 	// Set server information metrics
 	metricsCollector.SetServerInfo(cfg.Version, cfg.BuildDate, "go1.24")
+	metricsCollector.SetClusterLabelLimit(cfg.MetricsClusterLabelLimit)
 
 	// Create logger from config with metrics integration
-	logLevel := logging.ParseLevel(cfg.LogLevel)
-	logger := logging.NewLoggerWithMetrics(logLevel, "json", metricsCollector).WithComponent("server")
+	rootLogger, closeLogs, err := logging.NewLoggerWithOptions(LoggingOptions(cfg), metricsCollector)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to configure logging")
+	}
+	RouteKubernetesLogs(rootLogger)
+	logger := rootLogger.WithComponent("server")
 
 	logger.Info("Initializing CAPI MCP Server",
 		"version", cfg.Version,
 		"log_level", cfg.LogLevel,
+		"log_sinks", cfg.LogSinks,
 		"metrics_port", cfg.MetricsPort,
 	)
 
 	// Create MCP server instance with metadata
-	mcpServer := mcp.NewServer("capi-mcp-server", cfg.Version, nil)
+	mcpServer := mcp.NewServer(tools.ServerName, cfg.Version, &mcp.ServerOptions{
+		Instructions: tools.ServerInstructions(cfg.Version),
+	})
 
 	// Create server instance
 	s := &EnhancedServer{
@@ -59,11 +121,16 @@ func NewEnhanced(cfg *config.Config) (*EnhancedServer, error) {
 		metricsCollector: metricsCollector,
 		logger:           logger,
 		mcpServer:        mcpServer,
+		authenticator:    auth.NewAuthenticator(cfg),
+		closeLogs:        closeLogs,
+		identityServers:  make(map[*auth.Identity]*mcp.Server),
+		restHandlers:     make(map[*auth.Identity]http.Handler),
 	}
 
 	// Register capabilities
 	if err := s.registerCapabilities(); err != nil {
 		logger.WithError(err).Error("Failed to register capabilities")
+		closeLogs()
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to register capabilities")
 	}
 
@@ -73,34 +140,22 @@ func NewEnhanced(cfg *config.Config) (*EnhancedServer, error) {
 
 // Run starts the server and blocks until the context is cancelled.
 func (s *EnhancedServer) Run(ctx context.Context) error {
+	startedAt := time.Now()
 	s.logger.Info("Starting CAPI MCP server",
 		"port", s.config.ServerPort,
 		"metrics_port", s.config.MetricsPort,
 		"shutdown_grace", s.config.ShutdownGrace,
 	)
 
-	// Create health check handler
-	mux := http.NewServeMux()
-	mux.HandleFunc("/health", s.handleHealth)
-	mux.HandleFunc("/ready", s.handleReady)
-
-	// Create MCP handler with authentication
-	mcpHandler := mcp.NewStreamableHTTPHandler(s.authenticateRequest, nil)
-	mux.Handle("/", mcpHandler)
-
-	// Build middleware chain
-	handler := middleware.RequestLogger(s.logger)(
-		middleware.ErrorHandler(s.logger)(
-			middleware.RequestTimeout(30 * time.Second)(
-				middleware.CORS([]string{"*"})(mux),
-			),
-		),
-	)
+	// Work outliving the tool calls starting it stops when the server shuts down
+	if s.clusterService != nil {
+		s.clusterService.SetBackgroundContext(ctx)
+	}
 
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:           fmt.Sprintf(":%d", s.config.ServerPort),
-		Handler:        handler,
+		Handler:        s.Handler(),
 		ReadTimeout:    30 * time.Second,
 		WriteTimeout:   30 * time.Second,
 		IdleTimeout:    120 * time.Second,
@@ -127,6 +182,64 @@ func (s *EnhancedServer) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Fail the async operations the previous server instance left unfinished
+	if s.clusterService != nil {
+		go func() {
+			if err := s.clusterService.FailInterruptedOperations(ctx, startedAt); err != nil {
+				s.logger.WithError(err).Warn("Failed to mark interrupted async operations as failed")
+			}
+		}()
+	}
+
+	// Start the informer cache node counts are read from, if enabled
+	if s.clusterService != nil {
+		go s.clusterService.RunNodeCountCache(ctx)
+	}
+
+	// Start the background cluster status refresher, if enabled
+	if s.clusterService != nil {
+		go s.clusterService.RunStatusRefresher(ctx)
+	}
+
+	// Start deleting soft-deleted clusters after their retention window, if enabled
+	if s.clusterService != nil {
+		go s.clusterService.RunDeletionReaper(ctx)
+	}
+
+	// Start scraping workload cluster health for the metrics server, if enabled
+	if s.clusterService != nil {
+		go s.clusterService.RunWorkloadHealthCollector(ctx)
+	}
+
+	// Start refreshing the AWS region and instance type catalog, if enabled
+	if s.config.AWSCatalogEnabled && s.awsCatalog != nil {
+		go s.awsCatalog.Run(ctx, s.config.AWSCatalogRefreshInterval, func(err error) {
+			s.logger.WithError(err).Warn("Failed to refresh AWS catalog, using cached or built-in values")
+		})
+	}
+
+	// Start refreshing the Kubernetes version advisories, if configured
+	if s.config.VersionAdvisoriesURL != "" && s.advisor != nil {
+		go s.advisor.Run(ctx, s.config.VersionAdvisoriesRefreshInterval, func(err error) {
+			s.logger.WithError(err).Warn("Failed to refresh Kubernetes version advisories, using cached or bundled values")
+		})
+	}
+
+	// Start advancing the simulated clusters, if enabled
+	if s.simulator != nil {
+		go s.simulator.Run(ctx, s.logger.WithComponent("simulation"))
+	}
+
+	// Start publishing cluster lifecycle events, if enabled
+	if s.events != nil && s.eventSource != nil {
+		go s.events.WatchClusters(ctx, s.eventSource, s.logger.WithComponent("events"))
+	}
+
+	// Start polling PagerDuty for the approvals given there, if configured
+	if s.approvals != nil && s.approvalsPoller != nil {
+		go s.approvals.Run(ctx, s.approvalsPoller, s.config.ApprovalPollInterval, s.logger)
+	}
+
 	// Wait for shutdown signal or error
 	select {
 	case err := <-serverErr:
@@ -149,12 +262,71 @@ func (s *EnhancedServer) Run(ctx context.Context) error {
 		}
 
 		s.logger.Info("Server shutdown completed")
+		if err := s.closeLogs(); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to close log sinks")
+		}
 		return nil
 	}
 }
 
+// Handler returns the HTTP handler serving the MCP endpoint, health checks
+// and the optional REST API and event stream, e.g. to serve the server
+// in-process in tests.
+func (s *EnhancedServer) Handler() http.Handler {
+	// Create health check handler
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/ready", s.handleReady)
+
+	// Accept approvals given with the Approve buttons of Slack messages
+	if s.approvals != nil && s.config.ApprovalSlackSigningSecret != "" {
+		mux.Handle("/approvals/slack", approval.NewSlackCallbackHandler(s.config.ApprovalSlackSigningSecret, s.approveFromIntegration))
+	}
+
+	// Serve the cluster tools as a REST API, if enabled
+	if s.config.RESTAPIEnabled {
+		mux.HandleFunc("GET "+tools.RESTPathPrefix+"/openapi.json", s.handleOpenAPISpec)
+		mux.HandleFunc(tools.RESTPathPrefix+"/", s.handleREST)
+	}
+
+	// Create MCP handler with authentication
+	mcpHandler := mcp.NewStreamableHTTPHandler(s.authenticateRequest, nil)
+	mux.Handle("/", mcpHandler)
+
+	// Build middleware chain
+	handler := middleware.RequestLogger(s.logger)(
+		middleware.ErrorHandler(s.logger)(
+			middleware.RequestTimeout(30 * time.Second)(
+				middleware.CORS([]string{"*"})(mux),
+			),
+		),
+	)
+
+	// Stream events to dashboards, if enabled. Streams outlive the request
+	// timeout, so they bypass it.
+	if s.events != nil {
+		streamMux := http.NewServeMux()
+		streamMux.Handle("GET /events", middleware.RequestLogger(s.logger)(
+			middleware.ErrorHandler(s.logger)(http.HandlerFunc(s.handleEvents)),
+		))
+		streamMux.Handle("/", handler)
+		handler = streamMux
+	}
+	return handler
+}
+
 // authenticateRequest verifies the API key and returns the MCP server if valid
 func (s *EnhancedServer) authenticateRequest(r *http.Request) *mcp.Server {
+	identity := s.authenticateIdentity(r)
+	if identity == nil {
+		return nil
+	}
+	return s.identityServers[identity]
+}
+
+// authenticateIdentity verifies the API key of a request and returns the
+// caller's identity, or nil if the key is missing or invalid.
+func (s *EnhancedServer) authenticateIdentity(r *http.Request) *auth.Identity {
 	// Get request logger
 	reqLogger := logging.LoggerFromContext(r.Context())
 
@@ -174,16 +346,17 @@ func (s *EnhancedServer) authenticateRequest(r *http.Request) *mcp.Server {
 
 	apiKey := authHeader[len(bearerPrefix):]
 
-	// Validate API key
-	if apiKey != s.config.APIKey {
+	// Validate API key and resolve the caller's identity
+	identity := s.authenticator.Authenticate(apiKey)
+	if identity == nil {
 		reqLogger.Warn("Invalid API key",
 			"provided_key_prefix", logging.MaskSensitive(apiKey, 4),
 		)
 		return nil
 	}
 
-	reqLogger.Debug("Authentication successful")
-	return s.mcpServer
+	reqLogger.Debug("Authentication successful", "identity", identity.Name)
+	return identity
 }
 
 // registerCapabilities registers all tools and resources with the MCP server.
@@ -192,6 +365,7 @@ func (s *EnhancedServer) registerCapabilities() error {
 
 	// Create provider manager and register providers
 	providerManager := provider.NewProviderManager()
+	providerManager.SetCapabilityCache(s.config.ProviderCapabilityCacheTTL, s.metricsCollector)
 
 	// Register AWS provider
 	awsRegion := s.config.Providers["aws"]["region"]
@@ -199,6 +373,36 @@ func (s *EnhancedServer) registerCapabilities() error {
 		awsRegion = "us-west-2" // Default region
 	}
 	awsProvider := aws.NewAWSProvider(awsRegion)
+	var catalogSource aws.CatalogSource
+	if s.config.AWSCatalogEnabled {
+		source, err := aws.NewEC2CatalogSource(context.Background(), awsRegion)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to create AWS catalog source")
+		}
+		catalogSource = source
+	}
+	s.awsCatalog = aws.NewCatalog(catalogSource, s.config.AWSCatalogCacheFile)
+	awsProvider.SetCatalog(s.awsCatalog)
+	if imageSource, err := aws.NewEC2ImageSource(context.Background(), awsRegion); err != nil {
+		s.logger.WithError(err).Warn("AWS node image lookup unavailable")
+	} else {
+		awsProvider.SetImageSource(imageSource)
+	}
+	if keyPairSource, err := aws.NewEC2KeyPairSource(context.Background(), awsRegion); err != nil {
+		s.logger.WithError(err).Warn("AWS SSH key pair checks unavailable")
+	} else {
+		awsProvider.SetKeyPairSource(keyPairSource)
+	}
+	if instanceTypeSource, err := aws.NewEC2InstanceTypeSource(context.Background(), awsRegion); err != nil {
+		s.logger.WithError(err).Warn("AWS instance type lookups unavailable")
+	} else {
+		awsProvider.SetInstanceTypeSource(instanceTypeSource)
+	}
+	if cloudResourceSource, err := aws.NewEC2CloudResourceSource(context.Background(), awsRegion); err != nil {
+		s.logger.WithError(err).Warn("AWS cloud resource scans unavailable")
+	} else {
+		awsProvider.SetCloudResourceSource(cloudResourceSource)
+	}
 	providerManager.RegisterProvider(awsProvider)
 	s.logger.Info("Registered provider", "provider", "aws", "region", awsRegion)
 
@@ -206,9 +410,21 @@ func (s *EnhancedServer) registerCapabilities() error {
 	var kubeClient *kube.Client
 	var err error
 
-	if s.config.KubeConfigPath != "" {
+	if s.config.SimulationEnabled {
+		s.logger.Warn("Simulating the management cluster, no clusters are provisioned", "step_interval", s.config.SimulationStepInterval)
+		s.simulator, err = simulation.New(s.config.KubeNamespace, s.config.SimulationStepInterval)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to create simulated management cluster")
+		}
+		kubeClient = s.simulator.Client()
+	} else if s.config.KubeConfigPath != "" {
 		s.logger.Info("Creating Kubernetes client", "kubeconfig", s.config.KubeConfigPath)
-		kubeClient, err = kube.NewClient(s.config.KubeConfigPath, s.config.KubeNamespace)
+		kubeClient, err = kube.NewClient(s.config.KubeConfigPath, s.config.KubeNamespace, kube.NetworkOptions{
+			HTTPProxy:  s.config.HTTPProxy,
+			HTTPSProxy: s.config.HTTPSProxy,
+			NoProxy:    s.config.NoProxy,
+			CABundle:   s.config.CABundle,
+		})
 		if err != nil {
 			return errors.Wrap(err, errors.CodeInternal, "failed to create Kubernetes client")
 		}
@@ -217,29 +433,279 @@ func (s *EnhancedServer) registerCapabilities() error {
 		s.logger.Warn("No kubeconfig specified, running in stub mode")
 	}
 
+	if kubeClient != nil && s.config.FaultInjectionEnabled {
+		s.logger.Warn("Injecting faults into Kubernetes API calls",
+			"latency", s.config.FaultLatency,
+			"error_rate", s.config.FaultErrorRate,
+			"verbs", s.config.FaultVerbs,
+			"kinds", s.config.FaultKinds,
+		)
+		if err := kubeClient.InjectFaults(kube.FaultOptions{
+			Latency:   s.config.FaultLatency,
+			ErrorRate: s.config.FaultErrorRate,
+			Verbs:     s.config.FaultVerbs,
+			Kinds:     s.config.FaultKinds,
+		}); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to inject faults into the Kubernetes client")
+		}
+	}
+
 	// Create enhanced cluster service
 	clusterService := service.NewEnhancedClusterService(kubeClient, s.logger, providerManager)
+	clusterService.SetWaitStrategy(service.WaitStrategy(s.config.WaitStrategy), s.config.WaitPollInterval)
+	clusterService.SetProviderUpgradeOptions(service.ProviderUpgradeOptions{
+		Enabled:        s.config.EnableProviderUpgrades,
+		ClusterctlPath: s.config.ClusterctlPath,
+		Kubeconfig:     s.config.KubeConfigPath,
+	})
+	clusterService.SetLifecycleMetrics(s.metricsCollector, s.config.ClusterTimeout)
+	if s.config.WorkloadMetricsEnabled {
+		clusterService.SetWorkloadHealthMetrics(s.metricsCollector, service.WorkloadHealthOptions{
+			Interval:    s.config.WorkloadMetricsInterval,
+			Concurrency: s.config.WorkloadMetricsConcurrency,
+		})
+	}
+	if s.config.ReadinessGateEnabled {
+		clusterService.SetReadinessGate(service.ReadinessGate{
+			Enabled:  true,
+			Workers:  slices.Contains(s.config.ReadinessGateChecks, service.ReadinessCheckWorkers),
+			CNI:      slices.Contains(s.config.ReadinessGateChecks, service.ReadinessCheckCNI),
+			CacheTTL: s.config.ReadinessGateCacheTTL,
+		})
+	}
+	if s.config.ConformanceEnabled {
+		clusterService.SetConformanceOptions(service.ConformanceOptions{
+			SonobuoyPath: s.config.SonobuoyPath,
+			Timeout:      s.config.ConformanceTimeout,
+		})
+	}
+	clusterService.SetCIDROverlapPolicy(service.CIDROverlapPolicy(s.config.CIDROverlapPolicy))
+	if s.config.NodeDiagnosticsEnabled {
+		clusterService.SetNodeDiagnostics(s.config.NodeDiagnosticImage)
+	}
+	clusterService.SetEtcdBackupImages(s.config.EtcdBackupImage, s.config.EtcdBackupToolsImage)
+	clusterService.SetSmokeTestImage(s.config.SmokeTestImage)
+	clusterService.SetTemporaryAccessMaxDuration(s.config.TemporaryAccessMaxDuration)
+	if len(s.config.TemplateRegistries) > 0 || len(s.config.TemplateCatalogs) > 0 {
+		allowed := slices.Clone(s.config.TemplateRegistries)
+		catalogs := make([]oci.Reference, 0, len(s.config.TemplateCatalogs))
+		for _, catalog := range s.config.TemplateCatalogs {
+			// Validated when the configuration was loaded
+			repository, _ := oci.ParseRepository(catalog)
+			catalogs = append(catalogs, repository)
+			allowed = append(allowed, repository.Registry)
+		}
+		registry, err := oci.NewClient(oci.Options{
+			AllowedRegistries:   allowed,
+			PlainHTTPRegistries: s.config.TemplateRegistriesPlainHTTP,
+			AuthFile:            s.config.TemplateRegistryAuthFile,
+		})
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to create template registry client")
+		}
+		clusterService.SetTemplateRegistry(registry)
+		clusterService.SetTemplateCatalogs(catalogs)
+	}
+	if len(s.config.TemplateCompatibility) > 0 {
+		matrix := make([]service.TemplateCompatibility, 0, len(s.config.TemplateCompatibility))
+		for _, entry := range s.config.TemplateCompatibility {
+			matrix = append(matrix, service.TemplateCompatibility{
+				Template:           entry.Template,
+				Versions:           entry.Versions,
+				KubernetesVersions: entry.KubernetesVersions,
+				Providers:          entry.Providers,
+			})
+		}
+		clusterService.SetTemplateCompatibility(matrix)
+	}
+	if len(s.config.Presets) > 0 {
+		presets := make(map[string]service.VariablePreset, len(s.config.Presets))
+		for name, preset := range s.config.Presets {
+			presets[name] = service.VariablePreset{Description: preset.Description, Variables: preset.Variables}
+		}
+		clusterService.SetVariablePresets(presets)
+	}
+	if len(s.config.DefaultVariables) > 0 || len(s.config.NamespaceDefaultVariables) > 0 {
+		namespaceDefaults := make(map[string][]service.VariableDefaults, len(s.config.NamespaceDefaultVariables))
+		for namespace, defaults := range s.config.NamespaceDefaultVariables {
+			namespaceDefaults[namespace] = variableDefaults(defaults)
+		}
+		clusterService.SetVariableDefaults(variableDefaults(s.config.DefaultVariables), namespaceDefaults)
+	}
+	if s.config.RegionPolicy != nil {
+		clusterService.SetRegionPolicy(regionPolicy(s.config.RegionPolicy))
+	}
+	clusterService.SetInstanceTypeLimits(service.InstanceTypeLimits{
+		Policy: validation.InstanceTypePolicy{
+			Allowed: s.config.AllowedInstanceTypes,
+			Denied:  s.config.DeniedInstanceTypes,
+		},
+		MaxVCPUs:     s.config.MaxClusterVCPUs,
+		MaxMemoryMiB: int64(s.config.MaxClusterMemoryGiB) * 1024,
+	})
+	clusterService.SetProtectedClusterNames(s.config.ProtectedClusterNames)
+	clusterService.SetDeletionRetention(s.config.DeletionRetention)
+	clusterService.SetCreationLimits(service.CreationLimits{
+		MaxProvisioning:             s.config.MaxProvisioningClusters,
+		MaxProvisioningPerNamespace: s.config.MaxProvisioningClustersPerNamespace,
+		Queue:                       s.config.QueueClusterCreation,
+	})
+	clusterService.SetInventoryOptions(service.InventoryOptions{
+		HourlyPrices: s.config.InstanceHourlyPrices,
+		OwnerLabels:  s.config.InventoryOwnerLabels,
+	})
+	var advisorySource advisory.Source
+	if s.config.VersionAdvisoriesURL != "" {
+		advisorySource = advisory.NewHTTPSource(s.config.VersionAdvisoriesURL)
+	}
+	s.advisor = advisory.New(advisorySource, s.config.VersionAdvisoriesCacheFile)
+	clusterService.SetVersionAdvisor(s.advisor)
+	clusterService.SetListConcurrency(s.config.ListClustersConcurrency)
+	// The simulated management cluster has no API server to watch
+	clusterService.SetNodeCountCache(s.config.ListClustersCacheEnabled && !s.config.SimulationEnabled)
+	clusterService.SetEnvironmentLabel(s.config.EnvironmentLabel)
+	clusterService.SetCredentialVerifier("aws", func(ctx context.Context, credentials map[string]string) (string, error) {
+		return aws.VerifyCredentials(ctx, credentials["accessKeyId"], credentials["secretAccessKey"], credentials["sessionToken"], credentials["region"])
+	})
+	if kubeClient != nil && s.config.HistoryEnabled {
+		clusterService.SetOperationHistory(history.NewConfigMapStore(kubeClient, s.config.KubeNamespace, s.config.HistoryMaxEntries))
+		clusterService.SetSnapshotStore(snapshot.NewConfigMapStore(kubeClient, s.config.KubeNamespace, s.config.SnapshotsPerCluster))
+		s.logger.Info("Operation history enabled", "namespace", s.config.KubeNamespace, "max_entries", s.config.HistoryMaxEntries)
+	}
+	if kubeClient != nil {
+		clusterService.SetAsyncOperationStore(async.NewConfigMapStore(kubeClient, s.config.KubeNamespace, s.config.AsyncOperationMaxEntries))
+	}
+	if s.config.ToolAccountingRetention > 0 {
+		clusterService.SetToolAccounting(accounting.NewLedger(s.config.ToolAccountingRetention, s.metricsCollector))
+	}
+	if kubeClient != nil && s.config.StatusIndexEnabled {
+		clusterService.SetStatusIndexOptions(service.StatusIndexOptions{
+			Enabled:         true,
+			MaxStaleness:    s.config.StatusIndexMaxStaleness,
+			RefreshInterval: s.config.StatusIndexRefreshInterval,
+			BatchSize:       s.config.StatusIndexBatchSize,
+			QPS:             s.config.StatusIndexQPS,
+		})
+	}
+	if s.config.EventStreamEnabled {
+		s.events = events.NewBroker(s.config.EventStreamBufferSize)
+		clusterService.SetEventBroker(s.events)
+		if kubeClient != nil {
+			s.eventSource = kubeClient
+		}
+		s.logger.Info("Event stream enabled", "buffer_size", s.config.EventStreamBufferSize)
+	}
+	s.clusterService = clusterService
+
+	var admissionPolicy policy.Evaluator
+	if s.config.PolicyOPAURL != "" {
+		admissionPolicy = policy.NewOPAEvaluator(s.config.PolicyOPAURL, s.config.PolicyTimeout)
+		s.logger.Info("Admission policy enabled", "url", s.config.PolicyOPAURL, "fail_open", s.config.PolicyFailOpen)
+	}
 
-	// Create enhanced tool provider with comprehensive error handling
-	toolProvider := tools.NewEnhancedProvider(s.mcpServer, s.logger, clusterService)
+	// User-facing error messages are localized from the bundled catalog and
+	// the messages of the catalog file
+	messages, err := i18n.NewCatalog(i18n.DefaultLocale)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to load the message catalog")
+	}
+	for locale, overrides := range s.config.Messages {
+		messages.Add(locale, overrides)
+	}
+	if err := messages.SetDefaultLocale(s.config.Locale); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, "failed to set the default locale")
+	}
 
-	// Register tools with error handling wrapper
+	// Register tools on a separate MCP server per identity so each session's
+	// tools are scoped to the namespaces its identity maps to
 	s.logger.Info("Registering MCP tools")
-	if err := toolProvider.RegisterTools(); err != nil {
-		return errors.Wrap(err, errors.CodeInternal, "failed to register tools")
+	var toolProvider *tools.EnhancedProvider
+	toolSwitch := tools.NewToolSwitch()
+	maintenance := tools.NewMaintenance()
+	if s.config.MaintenanceMode {
+		maintenance.Start(s.config.MaintenanceUntil, s.config.MaintenanceReason)
+		s.logger.Warn("Starting in maintenance mode", "until", s.config.MaintenanceUntil, "reason", s.config.MaintenanceReason)
+	}
+	var approvals *tools.Approvals
+	if s.config.ApprovalsEnabled {
+		approvals = tools.NewApprovals(s.config.ApprovalTTL, s.config.ApprovalEnvironments)
+		var notifiers approval.Notifiers
+		if s.config.ApprovalSlackWebhookURL != "" {
+			notifiers = append(notifiers, approval.NewSlackNotifier(s.config.ApprovalSlackWebhookURL, approvalNotifyTimeout))
+		}
+		if s.config.ApprovalPagerDutyRoutingKey != "" {
+			pagerDuty := approval.NewPagerDutyNotifier(s.config.ApprovalPagerDutyRoutingKey, s.config.ApprovalPagerDutyAPIToken, approvalNotifyTimeout)
+			notifiers = append(notifiers, pagerDuty)
+			if s.config.ApprovalPagerDutyAPIToken != "" {
+				s.approvalsPoller = pagerDuty
+			}
+		}
+		if len(notifiers) > 0 {
+			approvals.SetNotifier(notifiers)
+		}
+		s.approvals = approvals
+		s.logger.Info("Approvals of high-risk operations enabled", "environments", s.config.ApprovalEnvironments, "ttl", s.config.ApprovalTTL,
+			"slack", s.config.ApprovalSlackWebhookURL != "", "pagerduty", s.config.ApprovalPagerDutyRoutingKey != "")
+	}
+	for _, identity := range s.authenticator.Identities() {
+		mcpServer := s.mcpServer
+		if !identity.Unrestricted() {
+			mcpServer = mcp.NewServer(tools.ServerName, s.config.Version, &mcp.ServerOptions{
+				Instructions: tools.ServerInstructions(s.config.Version),
+			})
+		}
+
+		// Create enhanced tool provider with comprehensive error handling
+		toolProvider = tools.NewEnhancedProvider(mcpServer, s.logger, clusterService)
+		toolProvider.SetIdentity(identity)
+		toolProvider.SetServerInfo(tools.ServerInfo{
+			Version:   s.config.Version,
+			BuildDate: s.config.BuildDate,
+			Providers: providerManager.ListProviders(),
+		})
+		toolProvider.SetAWSCatalog(s.awsCatalog)
+		toolProvider.SetOperationMetrics(s.metricsCollector)
+		toolProvider.SetCallTimeout(s.config.ToolCallTimeout)
+		toolProvider.SetOutputVersion(s.config.OutputAPIVersion)
+		toolProvider.SetMessageCatalog(messages)
+		if admissionPolicy != nil {
+			toolProvider.SetAdmissionPolicy(admissionPolicy, s.config.PolicyFailOpen)
+		}
+		toolProvider.SetElicitation(s.config.ElicitationEnabled)
+		toolProvider.SetOutputChunking(s.config.OutputChunkSize, s.config.OutputPayloadTTL)
+		toolProvider.SetResultCache(s.config.ToolResultCacheTTL)
+		toolProvider.SetToolSwitch(toolSwitch)
+		toolProvider.SetMaintenance(maintenance)
+		if approvals != nil {
+			toolProvider.SetApprovals(approvals)
+		}
+		if identity.ToolAdmin() {
+			// The tool admin only controls which tools are available
+			if err := toolProvider.RegisterToolAdminTools(); err != nil {
+				return errors.Wrap(err, errors.CodeInternal, "failed to register tool admin tools")
+			}
+			s.identityServers[identity] = mcpServer
+			s.logger.Info("Registered tool admin", "identity", identity.Name)
+			continue
+		}
+		if err := toolProvider.RegisterTools(); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to register tools")
+		}
+		s.identityServers[identity] = mcpServer
+		if s.config.RESTAPIEnabled {
+			s.restHandlers[identity] = toolProvider.RESTHandler()
+		}
+
+		s.logger.Info("Registered identity",
+			"identity", identity.Name,
+			"default_namespace", identity.DefaultNamespace,
+			"namespaces", identity.Namespaces,
+		)
 	}
 
 	// Log registered tools
 	s.logger.Info("MCP tools registered successfully",
-		"tools", []string{
-			"list_clusters",
-			"get_cluster",
-			"create_cluster",
-			"delete_cluster",
-			"scale_cluster",
-			"get_cluster_kubeconfig",
-			"get_cluster_nodes",
-		},
+		"tools", toolProvider.GetSupportedTools(),
 	)
 
 	// TODO: Register resources
@@ -274,6 +740,100 @@ func (s *EnhancedServer) handleReady(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, `{"status":"ready","version":"%s"}`, s.config.Version)
 }
 
+// handleOpenAPISpec serves the OpenAPI document of the REST API. It needs no
+// API key, so that API clients can be generated from it.
+func (s *EnhancedServer) handleOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	reqLogger := logging.LoggerFromContext(r.Context())
+	spec, err := tools.OpenAPISpec(s.config.Version)
+	if err != nil {
+		reqLogger.WithError(err).Error("Failed to generate the OpenAPI document")
+		http.Error(w, "failed to generate the OpenAPI document", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(spec); err != nil {
+		reqLogger.WithError(err).Warn("Failed to write the OpenAPI document")
+	}
+}
+
+// handleREST passes an authenticated REST API call to the REST handler of
+// the caller's identity.
+func (s *EnhancedServer) handleREST(w http.ResponseWriter, r *http.Request) {
+	identity := s.authenticateIdentity(r)
+	if identity == nil {
+		writeJSONError(w, r, http.StatusUnauthorized, errors.CodeUnauthorized, "a valid API key is required")
+		return
+	}
+	handler, ok := s.restHandlers[identity]
+	if !ok {
+		writeJSONError(w, r, http.StatusForbidden, errors.CodeForbidden, fmt.Sprintf("identity '%s' cannot use the REST API", identity.Name))
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// handleEvents streams the events of the namespaces the caller may access
+// over a WebSocket connection, optionally only those of the namespace and
+// cluster named by the query parameters. Browsers, which cannot set headers
+// on WebSocket requests, pass the API key as the access_token parameter.
+func (s *EnhancedServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if r.Header.Get("Authorization") == "" && query.Get("access_token") != "" {
+		r.Header.Set("Authorization", "Bearer "+query.Get("access_token"))
+	}
+	identity := s.authenticateIdentity(r)
+	if identity == nil {
+		writeJSONError(w, r, http.StatusUnauthorized, errors.CodeUnauthorized, "a valid API key is required")
+		return
+	}
+	if identity.ToolAdmin() {
+		writeJSONError(w, r, http.StatusForbidden, errors.CodeForbidden, fmt.Sprintf("identity '%s' cannot follow cluster events", identity.Name))
+		return
+	}
+
+	filter := events.Filter{ClusterName: query.Get("cluster")}
+	if !identity.Unrestricted() {
+		filter.Namespaces = identity.Namespaces
+	}
+	if namespace := query.Get("namespace"); namespace != "" {
+		if !identity.Unrestricted() && !slices.Contains(identity.Namespaces, namespace) {
+			writeJSONError(w, r, http.StatusForbidden, errors.CodeForbidden, fmt.Sprintf("identity '%s' cannot access namespace '%s'", identity.Name, namespace))
+			return
+		}
+		filter.Namespaces = []string{namespace}
+	}
+
+	logging.LoggerFromContext(r.Context()).Info("Event stream opened", "identity", identity.Name, "subscribers", s.events.Subscribers()+1)
+	events.ServeStream(w, r, s.events.Subscribe(filter))
+}
+
+// writeJSONError answers a REST API call with an error in the format of the
+// REST handlers.
+func writeJSONError(w http.ResponseWriter, r *http.Request, status int, code errors.ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"code": string(code), "message": message},
+	})
+	if err != nil {
+		logging.LoggerFromContext(r.Context()).WithError(err).Warn("Failed to write error response", "code", code)
+	}
+}
+
+// approveFromIntegration approves a pending approval on behalf of an approver
+// in Slack.
+func (s *EnhancedServer) approveFromIntegration(ctx context.Context, id, approver string) error {
+	approved, err := s.approvals.Approve(id, approver)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to approve operation", "approval_id", id, "approved_by", approver)
+		return err
+	}
+	s.logger.WithContext(ctx).WithCluster(approved.ClusterName, approved.Namespace).Warn("Operation approved",
+		"tool", approved.Tool, "approval_id", approved.ID, "requested_by", approved.RequestedBy, "approved_by", approved.ApprovedBy)
+	return nil
+}
+
 // startMetricsServer starts the Prometheus metrics server
 func (s *EnhancedServer) startMetricsServer(ctx context.Context) error {
 	if s.config.MetricsPort == 0 {
@@ -291,3 +851,38 @@ func (s *EnhancedServer) startMetricsServer(ctx context.Context) error {
 	// Start metrics server - this will block until context is cancelled
 	return metrics.StartMetricsServer(ctx, metricsAddr, s.logger.Logger)
 }
+
+// variableDefaults converts configured default variables for the cluster
+// service.
+func variableDefaults(defaults []config.DefaultVariablesConfig) []service.VariableDefaults {
+	converted := make([]service.VariableDefaults, 0, len(defaults))
+	for _, entry := range defaults {
+		converted = append(converted, service.VariableDefaults{
+			Provider:  entry.Provider,
+			Template:  entry.Template,
+			Variables: entry.Variables,
+			Enforced:  entry.Enforced,
+		})
+	}
+	return converted
+}
+
+// regionPolicy converts the configured region policy for the cluster service.
+func regionPolicy(policy *config.RegionPolicyConfig) *validation.RegionPolicy {
+	rule := func(rule config.RegionRuleConfig) validation.RegionRule {
+		return validation.RegionRule{Allowed: rule.AllowedRegions, Denied: rule.DeniedRegions}
+	}
+	rules := func(scoped map[string]config.RegionRuleConfig) map[string]validation.RegionRule {
+		converted := make(map[string]validation.RegionRule, len(scoped))
+		for name, scopedRule := range scoped {
+			converted[name] = rule(scopedRule)
+		}
+		return converted
+	}
+	return &validation.RegionPolicy{
+		Default:    rule(policy.RegionRuleConfig),
+		Namespaces: rules(policy.Namespaces),
+		Identities: rules(policy.Identities),
+		Groups:     rules(policy.Groups),
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// GPUCountVariable is the topology variable setting the number of GPUs per
+// worker node, passed through to the ClusterClass templates.
+const GPUCountVariable = "gpuCount"
+
+// acceleratorResources are the extended resources advertised by the device
+// plugins of common GPU and accelerator vendors.
+var acceleratorResources = []corev1.ResourceName{
+	"nvidia.com/gpu",
+	"amd.com/gpu",
+	"aws.amazon.com/neuron",
+	"aws.amazon.com/neuroncore",
+	"habana.ai/gaudi",
+}
+
+// acceleratorProductLabels are node labels naming the accelerator model, set
+// by NVIDIA GPU feature discovery and by EKS respectively.
+var acceleratorProductLabels = []string{
+	"nvidia.com/gpu.product",
+	"k8s.amazonaws.com/accelerator",
+}
+
+// nodeAccelerators returns the accelerators a node advertises, sorted by
+// resource name. Nodes without a device plugin report none.
+func nodeAccelerators(node *corev1.Node) []api.NodeAccelerator {
+	var product string
+	for _, label := range acceleratorProductLabels {
+		if value := node.Labels[label]; value != "" {
+			product = value
+			break
+		}
+	}
+
+	var accelerators []api.NodeAccelerator
+	for _, resource := range acceleratorResources {
+		capacity, ok := node.Status.Capacity[resource]
+		if !ok || capacity.IsZero() {
+			continue
+		}
+		allocatable := node.Status.Allocatable[resource]
+		accelerators = append(accelerators, api.NodeAccelerator{
+			Resource:    string(resource),
+			Capacity:    capacity.Value(),
+			Allocatable: allocatable.Value(),
+			Product:     product,
+		})
+	}
+
+	sort.Slice(accelerators, func(i, j int) bool {
+		return accelerators[i].Resource < accelerators[j].Resource
+	})
+	return accelerators
+}
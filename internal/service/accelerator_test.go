@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func TestNodeAccelerators(t *testing.T) {
+	tests := []struct {
+		name string
+		node *corev1.Node
+		want []api.NodeAccelerator
+	}{
+		{
+			name: "no accelerators",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+				},
+			},
+		},
+		{
+			name: "NVIDIA GPUs with product label",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"nvidia.com/gpu.product": "NVIDIA-A10G"},
+				},
+				Status: corev1.NodeStatus{
+					Capacity:    corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("4")},
+					Allocatable: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("3")},
+				},
+			},
+			want: []api.NodeAccelerator{
+				{Resource: "nvidia.com/gpu", Capacity: 4, Allocatable: 3, Product: "NVIDIA-A10G"},
+			},
+		},
+		{
+			name: "Neuron devices and cores",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{
+						"aws.amazon.com/neuroncore": resource.MustParse("32"),
+						"aws.amazon.com/neuron":     resource.MustParse("16"),
+					},
+					Allocatable: corev1.ResourceList{
+						"aws.amazon.com/neuroncore": resource.MustParse("32"),
+						"aws.amazon.com/neuron":     resource.MustParse("16"),
+					},
+				},
+			},
+			want: []api.NodeAccelerator{
+				{Resource: "aws.amazon.com/neuron", Capacity: 16, Allocatable: 16},
+				{Resource: "aws.amazon.com/neuroncore", Capacity: 32, Allocatable: 32},
+			},
+		},
+		{
+			name: "zero capacity is ignored",
+			node: &corev1.Node{
+				Status: corev1.NodeStatus{
+					Capacity: corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("0")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nodeAccelerators(tt.node))
+		})
+	}
+}
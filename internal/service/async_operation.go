@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// SetAsyncOperationStore configures the store the progress of long-running
+// operations is kept in. Without a store, operations that run in the
+// background, such as restore_cluster, are unavailable.
+func (s *EnhancedClusterService) SetAsyncOperationStore(store async.Store) {
+	s.asyncOperations = store
+}
+
+// GetOperationStatus reports the state and stages of a long-running operation.
+// When namespaces is non-empty only operations in those namespaces are found.
+func (s *EnhancedClusterService) GetOperationStatus(ctx context.Context, input api.GetOperationStatusInput, namespaces []string) (*api.GetOperationStatusOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetOperationStatus")
+	logger.Debug("Getting operation status", "operation_id", input.OperationID)
+
+	if input.OperationID == "" {
+		err := errors.New(errors.CodeInvalidInput, "operation ID is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.asyncOperations == nil {
+		err := errors.New(errors.CodeUnavailable, "async operations are not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	getCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	op, found, err := s.asyncOperations.Get(getCtx, input.OperationID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get operation")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get operation")
+	}
+	// Operations of other tenants are reported as missing rather than forbidden
+	if !found || (len(namespaces) > 0 && !slices.Contains(namespaces, op.Namespace)) {
+		return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("operation '%s' not found", input.OperationID))
+	}
+
+	output := &api.GetOperationStatusOutput{
+		OperationID: op.ID,
+		Kind:        op.Kind,
+		ClusterName: op.ClusterName,
+		State:       op.State,
+		Stages:      toAPIStages(op.Stages),
+		Error:       op.Error,
+		CreatedAt:   op.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   op.UpdatedAt.Format(time.RFC3339),
+	}
+	output.Message = fmt.Sprintf("Operation %s is %s", op.Kind, op.State)
+	for _, stage := range op.Stages {
+		if stage.State == async.StateRunning || stage.State == async.StateFailed {
+			output.Message += fmt.Sprintf(", stage '%s' %s", stage.Name, stage.State)
+			if stage.Message != "" {
+				output.Message += ": " + stage.Message
+			}
+			break
+		}
+	}
+	return output, nil
+}
+
+// errInterrupted is the error of operations the server stopped carrying out.
+var errInterrupted = errors.New(errors.CodeUnavailable, "interrupted: the server stopped before the operation finished")
+
+// FailInterruptedOperations marks the operations that were still pending or
+// running when the server last stopped as failed, since the work carrying them
+// out stopped with it. Only operations created before startedAt, when the
+// server started, are considered. It does nothing when async operations are
+// not enabled.
+func (s *EnhancedClusterService) FailInterruptedOperations(ctx context.Context, startedAt time.Time) error {
+	if s.asyncOperations == nil {
+		return nil
+	}
+	logger := s.logger.WithContext(ctx).WithOperation("FailInterruptedOperations")
+
+	operations, err := s.asyncOperations.List(ctx, async.Filter{})
+	if err != nil {
+		return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list operations")
+	}
+	for _, op := range operations {
+		if op.Done() || !op.CreatedAt.Before(startedAt) {
+			continue
+		}
+
+		run := &asyncRun{s: s, op: op}
+		run.mu.Lock()
+		for i := range run.op.Stages {
+			switch stage := &run.op.Stages[i]; stage.State {
+			case async.StateRunning:
+				markStageFailed(stage, errInterrupted)
+			case async.StatePending:
+				stage.State = async.StateSkipped
+				stage.Message = errInterrupted.Error()
+			}
+		}
+		run.op.State = async.StateFailed
+		run.op.Error = errInterrupted.Error()
+		run.save(ctx)
+		run.mu.Unlock()
+		logger.Warn("Marked interrupted operation as failed", "operation_id", op.ID, "kind", op.Kind)
+	}
+	return nil
+}
+
+// asyncRun tracks a long-running operation through its stages, persisting
+// each transition so it can be polled with get_operation_status. Persisting
+// is best effort: failures are logged and never interrupt the operation.
+// Stages may progress concurrently, as they do in bulk operations.
+type asyncRun struct {
+	s *EnhancedClusterService
+
+	mu sync.Mutex
+	op async.Operation
+}
+
+// startAsyncOperation saves a new pending operation with the given stages.
+func (s *EnhancedClusterService) startAsyncOperation(ctx context.Context, kind, clusterName string, parameters map[string]string, stages ...string) (*asyncRun, error) {
+	op := async.Operation{
+		Kind:        kind,
+		ClusterName: clusterName,
+		Namespace:   s.kubeClient.Namespace(ctx),
+		State:       async.StatePending,
+		Parameters:  parameters,
+	}
+	for _, name := range stages {
+		op.Stages = append(op.Stages, async.Stage{Name: name, State: async.StatePending})
+	}
+
+	op, err := s.asyncOperations.Save(ctx, op)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to save operation")
+	}
+	s.publishOperationProgress(op)
+	return &asyncRun{s: s, op: op}, nil
+}
+
+// begin marks a stage, and the operation, as running.
+func (r *asyncRun) begin(ctx context.Context, name string) {
+	r.update(ctx, name, func(op *async.Operation, stage *async.Stage) {
+		op.State = async.StateRunning
+		stage.State = async.StateRunning
+		stage.StartedAt = time.Now().UTC()
+	})
+}
+
+// progress updates the message of a running stage.
+func (r *asyncRun) progress(ctx context.Context, name, message string) {
+	r.update(ctx, name, func(_ *async.Operation, stage *async.Stage) {
+		stage.Message = message
+	})
+}
+
+// complete marks a stage as succeeded.
+func (r *asyncRun) complete(ctx context.Context, name, message string) {
+	r.update(ctx, name, func(_ *async.Operation, stage *async.Stage) {
+		stage.State = async.StateSucceeded
+		stage.Message = message
+		stage.CompletedAt = time.Now().UTC()
+	})
+}
+
+// skip marks a stage as skipped.
+func (r *asyncRun) skip(ctx context.Context, name, message string) {
+	r.update(ctx, name, func(_ *async.Operation, stage *async.Stage) {
+		stage.State = async.StateSkipped
+		stage.Message = message
+	})
+}
+
+// fail marks a stage, and the operation, as failed.
+func (r *asyncRun) fail(ctx context.Context, name string, err error) {
+	r.update(ctx, name, func(op *async.Operation, stage *async.Stage) {
+		op.State = async.StateFailed
+		op.Error = err.Error()
+		markStageFailed(stage, err)
+	})
+}
+
+// failStage marks a stage as failed while the rest of the operation goes on.
+func (r *asyncRun) failStage(ctx context.Context, name string, err error) {
+	r.update(ctx, name, func(_ *async.Operation, stage *async.Stage) {
+		markStageFailed(stage, err)
+	})
+}
+
+// succeed marks the operation as succeeded.
+func (r *asyncRun) succeed(ctx context.Context) {
+	r.finish(ctx, async.StateSucceeded, "")
+}
+
+// abort marks the operation as failed after its stages have finished.
+func (r *asyncRun) abort(ctx context.Context, err error) {
+	r.finish(ctx, async.StateFailed, err.Error())
+}
+
+// finish sets the final state of the operation and saves it.
+func (r *asyncRun) finish(ctx context.Context, state, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.op.State = state
+	r.op.Error = message
+	r.save(ctx)
+}
+
+// update changes the operation and one of its stages and saves the operation.
+func (r *asyncRun) update(ctx context.Context, name string, change func(op *async.Operation, stage *async.Stage)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.op.Stages {
+		if r.op.Stages[i].Name == name {
+			change(&r.op, &r.op.Stages[i])
+		}
+	}
+	r.save(ctx)
+}
+
+// markStageFailed records an error on a stage.
+func markStageFailed(stage *async.Stage, err error) {
+	stage.State = async.StateFailed
+	stage.Message = err.Error()
+	stage.CompletedAt = time.Now().UTC()
+}
+
+// save persists and publishes the operation, even when its context was
+// cancelled. The caller holds the lock.
+func (r *asyncRun) save(ctx context.Context) {
+	r.s.publishOperationProgress(r.op)
+
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	op, err := r.s.asyncOperations.Save(saveCtx, r.op)
+	if err != nil {
+		r.s.logger.WithContext(ctx).WithError(err).Warn("Failed to save operation progress",
+			"operation_id", r.op.ID,
+			"kind", r.op.Kind,
+		)
+		return
+	}
+	r.op = op
+}
+
+// toAPIStages converts operation stages to their API representation.
+func toAPIStages(stages []async.Stage) []api.AsyncStage {
+	converted := make([]api.AsyncStage, 0, len(stages))
+	for _, stage := range stages {
+		apiStage := api.AsyncStage{
+			Name:    stage.Name,
+			State:   stage.State,
+			Message: stage.Message,
+		}
+		if !stage.StartedAt.IsZero() {
+			apiStage.StartedAt = stage.StartedAt.Format(time.RFC3339)
+		}
+		if !stage.CompletedAt.IsZero() {
+			apiStage.CompletedAt = stage.CompletedAt.Format(time.RFC3339)
+		}
+		converted = append(converted, apiStage)
+	}
+	return converted
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestEnhancedClusterService_GetOperationStatus(t *testing.T) {
+	ctx := context.Background()
+
+	svc, _ := setupEnhancedTestService(t)
+	_, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: "abc"}, nil)
+	assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+
+	svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	run, err := svc.startAsyncOperation(kube.ContextWithNamespace(ctx, "team-a"), "restore_cluster", "prod", nil, "provision", "restore-workloads")
+	require.NoError(t, err)
+	run.begin(ctx, "provision")
+	run.progress(ctx, "provision", "waiting for control plane")
+
+	tests := []struct {
+		name       string
+		id         string
+		namespaces []string
+		code       errors.ErrorCode
+	}{
+		{name: "missing ID", code: errors.CodeInvalidInput},
+		{name: "unknown operation", id: "missing", code: errors.CodeNotFound},
+		{name: "other tenant's operation", id: run.op.ID, namespaces: []string{"team-b"}, code: errors.CodeNotFound},
+		{name: "own operation", id: run.op.ID, namespaces: []string{"team-a"}},
+		{name: "unrestricted", id: run.op.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: tt.id}, tt.namespaces)
+			if tt.code != "" {
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, async.StateRunning, output.State)
+			assert.Equal(t, "prod", output.ClusterName)
+			require.Len(t, output.Stages, 2)
+			assert.NotEmpty(t, output.Stages[0].StartedAt)
+			assert.Equal(t, async.StatePending, output.Stages[1].State)
+			assert.Equal(t, "Operation restore_cluster is running, stage 'provision' running: waiting for control plane", output.Message)
+		})
+	}
+
+	t.Run("failure is reported", func(t *testing.T) {
+		run.fail(ctx, "provision", fmt.Errorf("quota exceeded"))
+
+		output, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: run.op.ID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, async.StateFailed, output.State)
+		assert.Equal(t, "quota exceeded", output.Error)
+		assert.NotEmpty(t, output.Stages[0].CompletedAt)
+	})
+}
+
+func TestEnhancedClusterService_FailInterruptedOperations(t *testing.T) {
+	ctx := context.Background()
+
+	svc, _ := setupEnhancedTestService(t)
+	require.NoError(t, svc.FailInterruptedOperations(ctx, time.Now()), "nothing to do without async operations")
+
+	svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	interrupted, err := svc.startAsyncOperation(ctx, "restore_cluster", "prod", nil, "provision", "restore-workloads")
+	require.NoError(t, err)
+	interrupted.begin(ctx, "provision")
+	finished, err := svc.startAsyncOperation(ctx, "restore_cluster", "staging", nil, "provision")
+	require.NoError(t, err)
+	finished.begin(ctx, "provision")
+	finished.complete(ctx, "provision", "done")
+	finished.succeed(ctx)
+
+	startedAt := time.Now()
+	current, err := svc.startAsyncOperation(ctx, "restore_cluster", "dev", nil, "provision")
+	require.NoError(t, err)
+	current.begin(ctx, "provision")
+
+	require.NoError(t, svc.FailInterruptedOperations(ctx, startedAt))
+
+	op, _, err := svc.asyncOperations.Get(ctx, interrupted.op.ID)
+	require.NoError(t, err)
+	assert.Equal(t, async.StateFailed, op.State)
+	assert.Equal(t, errInterrupted.Error(), op.Error)
+	assert.Equal(t, async.StateFailed, op.Stages[0].State)
+	assert.False(t, op.Stages[0].CompletedAt.IsZero())
+	assert.Equal(t, async.StateSkipped, op.Stages[1].State)
+
+	op, _, err = svc.asyncOperations.Get(ctx, finished.op.ID)
+	require.NoError(t, err)
+	assert.Equal(t, async.StateSucceeded, op.State, "finished operations are left alone")
+
+	op, _, err = svc.asyncOperations.Get(ctx, current.op.ID)
+	require.NoError(t, err)
+	assert.Equal(t, async.StateRunning, op.State, "operations of the current server are left alone")
+}
+
+func TestEnhancedClusterService_GoBackground(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+	background, stop := context.WithCancel(context.Background())
+	svc.SetBackgroundContext(background)
+
+	request, cancelRequest := context.WithCancel(kube.ContextWithNamespace(context.Background(), "team-a"))
+	started := make(chan context.Context)
+	svc.goBackground(request, func(ctx context.Context) { started <- ctx })
+	ctx := <-started
+
+	cancelRequest()
+	assert.NoError(t, ctx.Err(), "the work outlives the call starting it")
+	assert.Equal(t, "team-a", svc.kubeClient.Namespace(ctx), "the work keeps the call's values")
+
+	stop()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled, "the work stops with the server")
+}
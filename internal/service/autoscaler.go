@@ -0,0 +1,349 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// autoscalerStatusNamespace is the namespace cluster-autoscaler writes its status ConfigMap to.
+	autoscalerStatusNamespace = "kube-system"
+
+	// autoscalerStatusConfigMap is the name of the cluster-autoscaler status ConfigMap.
+	autoscalerStatusConfigMap = "cluster-autoscaler-status"
+
+	// autoscalerStatusKey is the ConfigMap data key holding the status report.
+	autoscalerStatusKey = "status"
+
+	// legacyAutoscalerStatusPrefix identifies the human-readable status format
+	// written by cluster-autoscaler releases prior to v1.30.
+	legacyAutoscalerStatusPrefix = "Cluster-autoscaler status at"
+)
+
+// autoscalerCountRegex matches key=value counters in the legacy status format.
+var autoscalerCountRegex = regexp.MustCompile(`(\w+)=(\d+)`)
+
+// GetAutoscalerStatus reads the cluster-autoscaler status ConfigMap from a workload
+// cluster and summarizes scaling activity and blockers per node pool.
+func (s *EnhancedClusterService) GetAutoscalerStatus(ctx context.Context, input api.GetAutoscalerStatusInput) (*api.GetAutoscalerStatusOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetAutoscalerStatus").WithCluster(input.ClusterName, "")
+	logger.Debug("Getting cluster-autoscaler status")
+
+	// Validate input
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	statusCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(statusCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	configMap, err := workloadClient.GetConfigMap(statusCtx, autoscalerStatusNamespace, autoscalerStatusConfigMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("cluster-autoscaler status ConfigMap not found")
+			return &api.GetAutoscalerStatusOutput{
+				Installed: false,
+				NodePools: []api.AutoscalerNodePoolStatus{},
+				Message:   "cluster-autoscaler does not appear to be running in this cluster (status ConfigMap not found)",
+			}, nil
+		}
+
+		logger.WithError(err).Error("Failed to get cluster-autoscaler status ConfigMap")
+		if errors.IsTimeout(err) {
+			return nil, errors.Wrap(err, errors.CodeTimeout, "timeout reading cluster-autoscaler status")
+		}
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to read cluster-autoscaler status")
+	}
+
+	output, err := parseAutoscalerStatus(configMap.Data[autoscalerStatusKey])
+	if err != nil {
+		logger.WithError(err).Error("Failed to parse cluster-autoscaler status")
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to parse cluster-autoscaler status")
+	}
+
+	logger.Info("Retrieved cluster-autoscaler status successfully", "node_pools", len(output.NodePools))
+	return output, nil
+}
+
+// newWorkloadClient builds a client for the named workload cluster using its kubeconfig secret.
+func (s *EnhancedClusterService) newWorkloadClient(ctx context.Context, clusterName string) (*kube.WorkloadClient, error) {
+	kubeconfigOutput, err := s.GetClusterKubeconfig(ctx, api.GetClusterKubeconfigInput{
+		ClusterName: clusterName,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeDependencyFailure, "failed to get kubeconfig")
+	}
+
+	workloadClient, err := s.kubeClient.WorkloadClient([]byte(kubeconfigOutput.Kubeconfig))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create workload cluster client")
+	}
+
+	return workloadClient, nil
+}
+
+// autoscalerStatusReport mirrors the structured status format written by cluster-autoscaler v1.30+.
+type autoscalerStatusReport struct {
+	Time             string `json:"time"`
+	AutoscalerStatus string `json:"autoscalerStatus"`
+	Message          string `json:"message"`
+	ClusterWide      struct {
+		Health    autoscalerCondition `json:"health"`
+		ScaleUp   autoscalerCondition `json:"scaleUp"`
+		ScaleDown autoscalerCondition `json:"scaleDown"`
+	} `json:"clusterWide"`
+	NodeGroups []struct {
+		Name      string              `json:"name"`
+		Health    autoscalerCondition `json:"health"`
+		ScaleUp   autoscalerCondition `json:"scaleUp"`
+		ScaleDown autoscalerCondition `json:"scaleDown"`
+	} `json:"nodeGroups"`
+}
+
+// autoscalerCondition captures the subset of condition fields used for summaries.
+type autoscalerCondition struct {
+	Status              string `json:"status"`
+	CloudProviderTarget int    `json:"cloudProviderTarget"`
+	MinSize             int    `json:"minSize"`
+	MaxSize             int    `json:"maxSize"`
+	Candidates          int    `json:"candidates"`
+	NodeCounts          struct {
+		Registered struct {
+			Total int `json:"total"`
+			Ready int `json:"ready"`
+		} `json:"registered"`
+		LongUnregistered int `json:"longUnregistered"`
+	} `json:"nodeCounts"`
+	BackoffInfo struct {
+		ErrorCode    string `json:"errorCode"`
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"backoffInfo"`
+}
+
+// parseAutoscalerStatus converts the raw status ConfigMap contents into an API summary.
+// Both the structured (v1.30+) and legacy human-readable formats are supported.
+func parseAutoscalerStatus(raw string) (*api.GetAutoscalerStatusOutput, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, fmt.Errorf("status ConfigMap has no %q data", autoscalerStatusKey)
+	}
+
+	if strings.HasPrefix(raw, legacyAutoscalerStatusPrefix) {
+		return parseLegacyAutoscalerStatus(raw), nil
+	}
+
+	var report autoscalerStatusReport
+	if err := yaml.Unmarshal([]byte(raw), &report); err != nil {
+		return nil, fmt.Errorf("failed to decode status report: %w", err)
+	}
+
+	output := &api.GetAutoscalerStatusOutput{
+		Installed:        true,
+		AutoscalerStatus: report.AutoscalerStatus,
+		LastUpdated:      report.Time,
+		Message:          report.Message,
+		ClusterWide: api.AutoscalerActivity{
+			Health:    report.ClusterWide.Health.Status,
+			ScaleUp:   report.ClusterWide.ScaleUp.Status,
+			ScaleDown: report.ClusterWide.ScaleDown.Status,
+		},
+		NodePools: make([]api.AutoscalerNodePoolStatus, 0, len(report.NodeGroups)),
+	}
+
+	for _, group := range report.NodeGroups {
+		kind, name := splitNodeGroupName(group.Name)
+		pool := api.AutoscalerNodePoolStatus{
+			Name:                name,
+			Kind:                kind,
+			Health:              group.Health.Status,
+			ScaleUp:             group.ScaleUp.Status,
+			ScaleDown:           group.ScaleDown.Status,
+			ReadyNodes:          group.Health.NodeCounts.Registered.Ready,
+			TargetSize:          group.Health.CloudProviderTarget,
+			MinSize:             group.Health.MinSize,
+			MaxSize:             group.Health.MaxSize,
+			ScaleDownCandidates: group.ScaleDown.Candidates,
+		}
+
+		backoff := group.ScaleUp.BackoffInfo.ErrorMessage
+		if backoff == "" {
+			backoff = group.ScaleUp.BackoffInfo.ErrorCode
+		}
+		pool.Blockers = autoscalerBlockers(pool, backoff, group.Health.NodeCounts.LongUnregistered)
+
+		output.NodePools = append(output.NodePools, pool)
+	}
+
+	return output, nil
+}
+
+// parseLegacyAutoscalerStatus parses the indented text report written by
+// cluster-autoscaler releases prior to v1.30.
+func parseLegacyAutoscalerStatus(raw string) *api.GetAutoscalerStatusOutput {
+	output := &api.GetAutoscalerStatusOutput{
+		Installed:        true,
+		AutoscalerStatus: "Running",
+		NodePools:        []api.AutoscalerNodePoolStatus{},
+	}
+
+	var (
+		inNodeGroups     bool
+		current          *api.AutoscalerNodePoolStatus
+		longUnregistered int
+	)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Blockers = autoscalerBlockers(*current, "", longUnregistered)
+		output.NodePools = append(output.NodePools, *current)
+		current = nil
+		longUnregistered = 0
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, legacyAutoscalerStatusPrefix):
+			output.LastUpdated = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(line, legacyAutoscalerStatusPrefix)), ":")
+			continue
+		case line == "Cluster-wide:":
+			inNodeGroups = false
+			continue
+		case line == "NodeGroups:":
+			inNodeGroups = true
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		status, _, _ := strings.Cut(value, " ")
+		counts := parseAutoscalerCounts(value)
+
+		if !inNodeGroups {
+			switch key {
+			case "Health":
+				output.ClusterWide.Health = status
+			case "ScaleUp":
+				output.ClusterWide.ScaleUp = status
+			case "ScaleDown":
+				output.ClusterWide.ScaleDown = status
+			}
+			continue
+		}
+
+		if key == "Name" {
+			flush()
+			kind, name := splitNodeGroupName(value)
+			current = &api.AutoscalerNodePoolStatus{Name: name, Kind: kind}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "Health":
+			current.Health = status
+			current.ReadyNodes = counts["ready"]
+			current.TargetSize = counts["cloudProviderTarget"]
+			current.MinSize = counts["minSize"]
+			current.MaxSize = counts["maxSize"]
+			longUnregistered = counts["longUnregistered"]
+		case "ScaleUp":
+			current.ScaleUp = status
+		case "ScaleDown":
+			current.ScaleDown = status
+			current.ScaleDownCandidates = counts["candidates"]
+		}
+	}
+	flush()
+
+	return output
+}
+
+// parseAutoscalerCounts extracts key=value counters from a legacy status line.
+func parseAutoscalerCounts(value string) map[string]int {
+	counts := make(map[string]int)
+	for _, match := range autoscalerCountRegex.FindAllStringSubmatch(value, -1) {
+		if n, err := strconv.Atoi(match[2]); err == nil {
+			counts[match[1]] = n
+		}
+	}
+	return counts
+}
+
+// splitNodeGroupName splits a cluster-autoscaler node group ID of the form
+// "<Kind>/<namespace>/<name>" (as used by the Cluster API provider) into kind and name.
+func splitNodeGroupName(id string) (string, string) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 3 {
+		return parts[0], parts[2]
+	}
+	return "", id
+}
+
+// autoscalerBlockers lists conditions that prevent a node pool from scaling as requested.
+func autoscalerBlockers(pool api.AutoscalerNodePoolStatus, backoffReason string, longUnregistered int) []string {
+	var blockers []string
+
+	if pool.Health != "" && pool.Health != "Healthy" {
+		blockers = append(blockers, fmt.Sprintf("node pool health is %s", pool.Health))
+	}
+
+	if pool.ScaleUp == "Backoff" {
+		if backoffReason != "" {
+			blockers = append(blockers, fmt.Sprintf("scale-up is backing off: %s", backoffReason))
+		} else {
+			blockers = append(blockers, "scale-up is backing off after recent failures")
+		}
+	}
+
+	if pool.MaxSize > 0 && pool.TargetSize >= pool.MaxSize {
+		blockers = append(blockers, fmt.Sprintf("node pool is at its maximum size (%d); scale-up is not possible", pool.MaxSize))
+	}
+
+	if pool.ScaleDownCandidates > 0 && pool.TargetSize <= pool.MinSize {
+		blockers = append(blockers, fmt.Sprintf("node pool is at its minimum size (%d); scale-down candidates cannot be removed", pool.MinSize))
+	}
+
+	if longUnregistered > 0 {
+		blockers = append(blockers, fmt.Sprintf("%d node(s) have failed to register with the cluster", longUnregistered))
+	}
+
+	return blockers
+}
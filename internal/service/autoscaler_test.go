@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAutoscalerStatus(t *testing.T) {
+	t.Run("structured format", func(t *testing.T) {
+		raw := `time: 2025-01-15 10:00:00.000000000 +0000 UTC
+autoscalerStatus: Running
+clusterWide:
+  health:
+    status: Healthy
+  scaleUp:
+    status: InProgress
+  scaleDown:
+    status: NoCandidates
+nodeGroups:
+- name: MachineDeployment/default/workers-a
+  health:
+    status: Healthy
+    nodeCounts:
+      registered:
+        total: 5
+        ready: 5
+    cloudProviderTarget: 5
+    minSize: 1
+    maxSize: 5
+  scaleUp:
+    status: Backoff
+    backoffInfo:
+      errorCode: OutOfResource
+      errorMessage: insufficient capacity
+  scaleDown:
+    status: NoCandidates
+- name: MachineDeployment/default/workers-b
+  health:
+    status: Healthy
+    nodeCounts:
+      registered:
+        total: 2
+        ready: 2
+    cloudProviderTarget: 2
+    minSize: 1
+    maxSize: 10
+  scaleUp:
+    status: NoActivity
+  scaleDown:
+    status: CandidatesPresent
+    candidates: 1
+`
+
+		output, err := parseAutoscalerStatus(raw)
+		require.NoError(t, err)
+
+		assert.True(t, output.Installed)
+		assert.Equal(t, "Running", output.AutoscalerStatus)
+		assert.Equal(t, "Healthy", output.ClusterWide.Health)
+		assert.Equal(t, "InProgress", output.ClusterWide.ScaleUp)
+		require.Len(t, output.NodePools, 2)
+
+		poolA := output.NodePools[0]
+		assert.Equal(t, "workers-a", poolA.Name)
+		assert.Equal(t, "MachineDeployment", poolA.Kind)
+		assert.Equal(t, 5, poolA.ReadyNodes)
+		assert.Equal(t, "Backoff", poolA.ScaleUp)
+		require.Len(t, poolA.Blockers, 2)
+		assert.Contains(t, poolA.Blockers[0], "insufficient capacity")
+		assert.Contains(t, poolA.Blockers[1], "maximum size")
+
+		poolB := output.NodePools[1]
+		assert.Equal(t, "workers-b", poolB.Name)
+		assert.Equal(t, 1, poolB.ScaleDownCandidates)
+		assert.Empty(t, poolB.Blockers)
+	})
+
+	t.Run("legacy format", func(t *testing.T) {
+		raw := `Cluster-autoscaler status at 2023-06-01 08:00:00.000000000 +0000 UTC:
+Cluster-wide:
+  Health:      Healthy (ready=3 unready=0 notStarted=0 longNotStarted=0 registered=3 longUnregistered=0)
+               LastProbeTime:      2023-06-01 08:00:00.000000000 +0000 UTC
+  ScaleUp:     NoActivity (ready=3 registered=3)
+  ScaleDown:   CandidatesPresent (candidates=1)
+
+NodeGroups:
+  Name:        MachineDeployment/default/workers
+  Health:      Unhealthy (ready=1 unready=1 notStarted=0 longNotStarted=0 registered=2 longUnregistered=1 cloudProviderTarget=2 (minSize=2, maxSize=4))
+  ScaleUp:     NoActivity (ready=1 cloudProviderTarget=2)
+  ScaleDown:   CandidatesPresent (candidates=1)
+`
+
+		output, err := parseAutoscalerStatus(raw)
+		require.NoError(t, err)
+
+		assert.True(t, output.Installed)
+		assert.Equal(t, "2023-06-01 08:00:00.000000000 +0000 UTC", output.LastUpdated)
+		assert.Equal(t, "Healthy", output.ClusterWide.Health)
+		assert.Equal(t, "CandidatesPresent", output.ClusterWide.ScaleDown)
+		require.Len(t, output.NodePools, 1)
+
+		pool := output.NodePools[0]
+		assert.Equal(t, "workers", pool.Name)
+		assert.Equal(t, "Unhealthy", pool.Health)
+		assert.Equal(t, 1, pool.ReadyNodes)
+		assert.Equal(t, 2, pool.TargetSize)
+		assert.Equal(t, 2, pool.MinSize)
+		assert.Equal(t, 4, pool.MaxSize)
+		assert.Len(t, pool.Blockers, 3)
+	})
+
+	t.Run("empty status", func(t *testing.T) {
+		_, err := parseAutoscalerStatus("")
+		assert.Error(t, err)
+	})
+}
+
+func TestSplitNodeGroupName(t *testing.T) {
+	kind, name := splitNodeGroupName("MachinePool/capi-system/pool-0")
+	assert.Equal(t, "MachinePool", kind)
+	assert.Equal(t, "pool-0", name)
+
+	kind, name = splitNodeGroupName("custom-group")
+	assert.Empty(t, kind)
+	assert.Equal(t, "custom-group", name)
+}
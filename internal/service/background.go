@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+)
+
+// SetBackgroundContext sets the context of the work that outlives the tool
+// calls starting it, such as restores and bulk operations. It is the server's
+// context, so that this work stops when the server shuts down. It must be
+// called before the service serves any call.
+func (s *EnhancedClusterService) SetBackgroundContext(ctx context.Context) {
+	s.background = ctx
+}
+
+// goBackground runs work that outlives the tool call starting it. The work
+// gets the values of the call's context, such as its namespace and logger
+// fields, but is cancelled with the background context instead of the call.
+func (s *EnhancedClusterService) goBackground(ctx context.Context, work func(ctx context.Context)) {
+	go work(requestValuesContext{Context: s.background, values: ctx})
+}
+
+// requestValuesContext is the background context carrying the values of the
+// request that started the work.
+type requestValuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c requestValuesContext) Value(key any) any {
+	if value := c.values.Value(key); value != nil {
+		return value
+	}
+	return c.Context.Value(key)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// getBastion returns the bastion host of a cluster with one enabled. It is
+// read from the infrastructure cluster, which CAPA reports the bastion
+// instance on, falling back to the bastionEnabled variable while the
+// infrastructure cluster is unavailable.
+func (s *EnhancedClusterService) getBastion(ctx context.Context, cluster *clusterv1.Cluster) *api.BastionHost {
+	if clusterProvider(cluster) == "aws" && cluster.Spec.InfrastructureRef != nil {
+		ref := cluster.Spec.InfrastructureRef
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cluster.Namespace
+		}
+		infraCluster, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		if err == nil {
+			if enabled, _, _ := unstructured.NestedBool(infraCluster.Object, "spec", "bastion", "enabled"); !enabled {
+				return nil
+			}
+			bastion := &api.BastionHost{Source: "infrastructure"}
+			bastion.PublicIP, _, _ = unstructured.NestedString(infraCluster.Object, "status", "bastion", "publicIp")
+			bastion.PrivateIP, _, _ = unstructured.NestedString(infraCluster.Object, "status", "bastion", "privateIp")
+			bastion.InstanceID, _, _ = unstructured.NestedString(infraCluster.Object, "status", "bastion", "id")
+			bastion.State, _, _ = unstructured.NestedString(infraCluster.Object, "status", "bastion", "instanceState")
+			bastion.Address = bastion.PublicIP
+			if bastion.Address == "" {
+				bastion.Address = bastion.PrivateIP
+			}
+			return bastion
+		}
+		s.logger.WithContext(ctx).WithError(err).Debug("Failed to get infrastructure cluster",
+			logging.FieldClusterName, cluster.Name,
+		)
+	}
+
+	if bastionRequested(cluster) {
+		return &api.BastionHost{Source: "variables"}
+	}
+	return nil
+}
+
+// bastionRequested reports whether the bastionEnabled topology variable of a
+// cluster is true.
+func bastionRequested(cluster *clusterv1.Cluster) bool {
+	if cluster.Spec.Topology == nil {
+		return false
+	}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		if variable.Name != validation.BastionEnabledVariable {
+			continue
+		}
+		var enabled bool
+		return json.Unmarshal(variable.Value.Raw, &enabled) == nil && enabled
+	}
+	return false
+}
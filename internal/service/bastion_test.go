@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func TestEnhancedClusterService_GetBastion(t *testing.T) {
+	ctx := context.Background()
+
+	newAWSCluster := func(name string, spec, status map[string]interface{}) *unstructured.Unstructured {
+		awsCluster := &unstructured.Unstructured{}
+		awsCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+		awsCluster.SetKind("AWSCluster")
+		awsCluster.SetName(name)
+		awsCluster.SetNamespace(testNamespace)
+		awsCluster.Object["spec"] = spec
+		if status != nil {
+			awsCluster.Object["status"] = status
+		}
+		return awsCluster
+	}
+
+	withInfrastructureRef := func(cluster *clusterv1.Cluster, name string) *clusterv1.Cluster {
+		cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+			Kind:       "AWSCluster",
+			Name:       name,
+		}
+		return cluster
+	}
+
+	withBastionVariable := func(cluster *clusterv1.Cluster) *clusterv1.Cluster {
+		cluster.Spec.Topology.Variables = append(cluster.Spec.Topology.Variables, clusterv1.ClusterVariable{
+			Name: "bastionEnabled", Value: apiextensionsv1.JSON{Raw: []byte(`true`)},
+		})
+		return cluster
+	}
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		objects []client.Object
+		want    *api.BastionHost
+	}{
+		{
+			name:    "running bastion with public IP",
+			cluster: withInfrastructureRef(createTestCluster("public", testNamespace, clusterv1.ClusterPhaseProvisioned), "public-abc12"),
+			objects: []client.Object{newAWSCluster("public-abc12",
+				map[string]interface{}{"bastion": map[string]interface{}{"enabled": true}},
+				map[string]interface{}{"bastion": map[string]interface{}{
+					"id": "i-0123", "instanceState": "running", "publicIp": "203.0.113.10", "privateIp": "10.0.1.5",
+				}},
+			)},
+			want: &api.BastionHost{
+				Address:    "203.0.113.10",
+				PublicIP:   "203.0.113.10",
+				PrivateIP:  "10.0.1.5",
+				InstanceID: "i-0123",
+				State:      "running",
+				Source:     "infrastructure",
+			},
+		},
+		{
+			name:    "private bastion",
+			cluster: withInfrastructureRef(createTestCluster("private", testNamespace, clusterv1.ClusterPhaseProvisioned), "private-abc12"),
+			objects: []client.Object{newAWSCluster("private-abc12",
+				map[string]interface{}{"bastion": map[string]interface{}{"enabled": true}},
+				map[string]interface{}{"bastion": map[string]interface{}{"id": "i-0456", "instanceState": "running", "privateIp": "10.0.1.6"}},
+			)},
+			want: &api.BastionHost{Address: "10.0.1.6", PrivateIP: "10.0.1.6", InstanceID: "i-0456", State: "running", Source: "infrastructure"},
+		},
+		{
+			name:    "bastion disabled",
+			cluster: withInfrastructureRef(createTestCluster("plain", testNamespace, clusterv1.ClusterPhaseProvisioned), "plain-abc12"),
+			objects: []client.Object{newAWSCluster("plain-abc12", map[string]interface{}{}, nil)},
+		},
+		{
+			name:    "infrastructure cluster not created yet",
+			cluster: withBastionVariable(withInfrastructureRef(createTestCluster("pending", testNamespace, clusterv1.ClusterPhaseProvisioning), "pending-abc12")),
+			want:    &api.BastionHost{Source: "variables"},
+		},
+		{
+			name:    "no bastion",
+			cluster: createTestCluster("none", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, tt.objects...)
+			assert.Equal(t, tt.want, svc.getBastion(ctx, tt.cluster))
+		})
+	}
+}
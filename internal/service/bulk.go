@@ -0,0 +1,776 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+const (
+	// BulkScaleKind and BulkUpgradeKind are the kinds of async operations bulk
+	// operations run as. Each selected cluster is a stage.
+	BulkScaleKind   = "bulk_scale"
+	BulkUpgradeKind = "bulk_upgrade"
+
+	// defaultBulkConcurrency is the number of clusters a bulk operation
+	// changes at once unless told otherwise.
+	defaultBulkConcurrency = 5
+
+	// maxBulkConcurrency bounds the requested concurrency.
+	maxBulkConcurrency = 20
+
+	// bulkStageCanaryGates is the stage of a bulk operation evaluating the
+	// health gates of its canary clusters. Cluster names cannot contain a
+	// colon, so it never clashes with the stage of a cluster.
+	bulkStageCanaryGates = "canary:health-gates"
+)
+
+// Health gates the canary clusters of a bulk operation must pass before the
+// operation continues with the other clusters.
+const (
+	HealthGateProvisioned = "provisioned" // the cluster is Provisioned with its control plane and infrastructure ready
+	HealthGateNodes       = "nodes"       // every MachineDeployment has all its replicas ready
+	HealthGateAPI         = "api"         // the workload API server, CoreDNS, kube-proxy and the CNI plugin are healthy
+)
+
+// defaultHealthGates are the health gates of canary clusters unless told
+// otherwise. They are checked from the management cluster alone.
+var defaultHealthGates = []string{HealthGateProvisioned, HealthGateNodes}
+
+// bulkAction changes one cluster of a bulk operation, reporting progress as
+// it goes, and describes the result.
+type bulkAction func(ctx context.Context, clusterName string, progress func(message string)) (string, error)
+
+// bulkCanary is the canary strategy of a bulk operation: the first clusters
+// are changed alone and must pass the health gates, and keep passing them
+// for wait, before the other clusters are changed.
+type bulkCanary struct {
+	clusters int
+	wait     time.Duration
+	gates    []string
+}
+
+// bulkPlan is how a started bulk operation changes its clusters.
+type bulkPlan struct {
+	kind        string
+	planned     []string
+	concurrency int
+	maxFailures int
+	canary      bulkCanary
+	action      bulkAction
+}
+
+// BulkScale scales a node pool of every cluster matching a label selector.
+// Clusters without the node pool, already at the replica count, paused or
+// being deleted are skipped. A dry run reports the plan; otherwise the
+// clusters are scaled in the background with bounded concurrency as an async
+// operation with a stage per cluster.
+func (s *EnhancedClusterService) BulkScale(ctx context.Context, input api.BulkScaleInput) (*api.BulkOperationOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("BulkScale")
+	logger.Info("Scaling clusters", "label_selector", input.LabelSelector, "environment", input.Environment, "node_pool", input.NodePoolName, "target_replicas", input.Replicas)
+
+	if input.NodePoolName == "" {
+		err := errors.New(errors.CodeInvalidInput, "node pool name is required").WithDetails("field", "nodePoolName")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Replicas < 0 {
+		err := errors.New(errors.CodeInvalidInput, "replica count cannot be negative").WithDetails("field", "replicas")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	canary, err := parseBulkCanary(input.Canary, input.CanaryWait, input.HealthGates)
+	if err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	clusters, output, err := s.selectBulkClusters(ctx, "scale", input.LabelSelector, input.Environment, input.Concurrency, input.MaxFailures, input.DryRun)
+	if err != nil {
+		logger.WithError(err).Error("Failed to select clusters")
+		return nil, err
+	}
+
+	planCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+	nodePools := make(map[string]string, len(clusters))
+	for i := range clusters {
+		result, nodePool := s.planBulkScale(planCtx, &clusters[i], input)
+		output.Clusters = append(output.Clusters, result)
+		nodePools[result.ClusterName] = nodePool
+	}
+
+	return s.startBulkOperation(ctx, BulkScaleKind, output, map[string]string{
+		"labelSelector": output.LabelSelector,
+		"nodePoolName":  input.NodePoolName,
+		"replicas":      fmt.Sprintf("%d", input.Replicas),
+	}, input.MaxFailures, canary, func(ctx context.Context, clusterName string, _ func(string)) (string, error) {
+		scaled, err := s.ScaleCluster(ctx, api.ScaleClusterInput{
+			ClusterName:  clusterName,
+			NodePoolName: nodePools[clusterName],
+			Replicas:     input.Replicas,
+		})
+		if err != nil {
+			return "", err
+		}
+		return scaled.Message, nil
+	})
+}
+
+// BulkUpgrade upgrades every cluster matching a label selector to a
+// Kubernetes version by setting the version of its topology. Clusters not
+// managed by a ClusterClass, already at the version, that would be
+// downgraded or skip a minor version, or whose template or provider does
+// not support the version are skipped. Each cluster's stage completes once
+// its control plane and all MachineDeployments run the new version. Clusters
+// that other clusters depend on are upgraded with a warning.
+func (s *EnhancedClusterService) BulkUpgrade(ctx context.Context, input api.BulkUpgradeInput) (*api.BulkOperationOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("BulkUpgrade")
+	logger.Info("Upgrading clusters", "label_selector", input.LabelSelector, "environment", input.Environment, "kubernetes_version", input.KubernetesVersion)
+
+	if err := validation.NewValidator().ValidateKubernetesVersion(input.KubernetesVersion); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	canary, err := parseBulkCanary(input.Canary, input.CanaryWait, input.HealthGates)
+	if err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	clusters, output, err := s.selectBulkClusters(ctx, "upgrade", input.LabelSelector, input.Environment, input.Concurrency, input.MaxFailures, input.DryRun)
+	if err != nil {
+		logger.WithError(err).Error("Failed to select clusters")
+		return nil, err
+	}
+
+	planCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+	dependents, err := s.listClusterDependents(planCtx)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to find the clusters depending on the selected clusters")
+	}
+	for i := range clusters {
+		result := s.planBulkUpgrade(planCtx, &clusters[i], input.KubernetesVersion)
+		if names := dependents[result.ClusterName]; !result.Skipped && len(names) > 0 {
+			result.Warnings = append(result.Warnings, dependentsWarning(result.ClusterName, names))
+		}
+		output.Clusters = append(output.Clusters, result)
+	}
+
+	timeout := s.lifecycleTimeout
+	if timeout <= 0 {
+		timeout = defaultLifecycleTimeout
+	}
+	return s.startBulkOperation(ctx, BulkUpgradeKind, output, map[string]string{
+		"labelSelector":     output.LabelSelector,
+		"kubernetesVersion": input.KubernetesVersion,
+	}, input.MaxFailures, canary, func(ctx context.Context, clusterName string, progress func(string)) (string, error) {
+		upgradeCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if err := s.upgradeCluster(upgradeCtx, clusterName, input.KubernetesVersion, progress); err != nil {
+			if upgradeCtx.Err() != nil {
+				return "", errors.Wrap(err, errors.CodeTimeout, fmt.Sprintf("cluster was not upgraded within %s", timeout))
+			}
+			return "", err
+		}
+		return fmt.Sprintf("upgraded to %s", input.KubernetesVersion), nil
+	})
+}
+
+// parseBulkCanary validates the canary strategy of a bulk operation. Without
+// canary clusters there is no canary wait or health gates.
+func parseBulkCanary(clusters int, wait string, gates []string) (bulkCanary, error) {
+	if clusters < 0 {
+		return bulkCanary{}, errors.New(errors.CodeInvalidInput, "canary cannot be negative").WithDetails("field", "canary")
+	}
+	if clusters == 0 {
+		if wait != "" || len(gates) > 0 {
+			return bulkCanary{}, errors.New(errors.CodeInvalidInput, "canary wait and health gates require canary clusters").
+				WithDetails("field", "canary")
+		}
+		return bulkCanary{}, nil
+	}
+
+	canary := bulkCanary{clusters: clusters, gates: defaultHealthGates}
+	if wait != "" {
+		duration, err := time.ParseDuration(wait)
+		if err != nil || duration < 0 {
+			return bulkCanary{}, errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("invalid canary wait '%s': expected a duration such as 10m", wait)).WithDetails("field", "canaryWait")
+		}
+		canary.wait = duration
+	}
+	if len(gates) > 0 {
+		canary.gates = nil
+		for _, gate := range gates {
+			if !slices.Contains([]string{HealthGateProvisioned, HealthGateNodes, HealthGateAPI}, gate) {
+				return bulkCanary{}, errors.New(errors.CodeInvalidInput,
+					fmt.Sprintf("unknown health gate '%s': expected %s, %s or %s", gate, HealthGateProvisioned, HealthGateNodes, HealthGateAPI)).
+					WithDetails("field", "healthGates")
+			}
+			if !slices.Contains(canary.gates, gate) {
+				canary.gates = append(canary.gates, gate)
+			}
+		}
+	}
+	return canary, nil
+}
+
+// selectBulkClusters validates the common parameters of a bulk operation and
+// returns the clusters matching the selector, ordered by name.
+func (s *EnhancedClusterService) selectBulkClusters(ctx context.Context, action, labelSelector, environment string, concurrency, maxFailures int, dryRun bool) ([]clusterv1.Cluster, *api.BulkOperationOutput, error) {
+	labelSelector, err := s.environmentSelector(labelSelector, environment)
+	if err != nil {
+		return nil, nil, err
+	}
+	// An empty selector matches every cluster; fleet-wide changes must be asked for explicitly
+	if strings.TrimSpace(labelSelector) == "" {
+		return nil, nil, errors.New(errors.CodeInvalidInput, "label selector or environment is required").WithDetails("field", "labelSelector")
+	}
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid label selector").WithDetails("field", "labelSelector")
+	}
+	if concurrency == 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	if concurrency < 0 || concurrency > maxBulkConcurrency {
+		return nil, nil, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("concurrency must be between 1 and %d", maxBulkConcurrency)).WithDetails("field", "concurrency")
+	}
+	if maxFailures < 0 {
+		return nil, nil, errors.New(errors.CodeInvalidInput, "max failures cannot be negative").WithDetails("field", "maxFailures")
+	}
+
+	if s.kubeClient == nil {
+		return nil, nil, errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+	}
+	if !dryRun && s.asyncOperations == nil {
+		return nil, nil, errors.New(errors.CodeUnavailable, "async operations are not enabled; bulk operations can only be dry run")
+	}
+
+	listCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+	clusters, err := s.kubeClient.ListClustersMatching(listCtx, selector)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
+	}
+	if len(clusters.Items) == 0 {
+		return nil, nil, errors.New(errors.CodeNotFound, fmt.Sprintf("no clusters match label selector '%s'", labelSelector))
+	}
+	slices.SortFunc(clusters.Items, func(a, b clusterv1.Cluster) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return clusters.Items, &api.BulkOperationOutput{
+		Action:        action,
+		LabelSelector: labelSelector,
+		DryRun:        dryRun,
+		Concurrency:   concurrency,
+		Clusters:      []api.BulkClusterResult{},
+	}, nil
+}
+
+// bulkSkipReason returns why a cluster cannot be changed by any bulk
+// operation, or an empty string.
+func bulkSkipReason(cluster *clusterv1.Cluster) string {
+	switch {
+	case !cluster.DeletionTimestamp.IsZero():
+		return "cluster is being deleted"
+	case cluster.Spec.Paused:
+		return "cluster is paused"
+	}
+	return ""
+}
+
+// planBulkScale describes the change bulk_scale makes to a cluster and
+// returns the name of the node pool to scale.
+func (s *EnhancedClusterService) planBulkScale(ctx context.Context, cluster *clusterv1.Cluster, input api.BulkScaleInput) (api.BulkClusterResult, string) {
+	result := api.BulkClusterResult{ClusterName: cluster.Name}
+	if reason := bulkSkipReason(cluster); reason != "" {
+		return skipBulkCluster(result, reason), ""
+	}
+
+	nodePool, pool, err := s.getBulkNodePool(ctx, cluster.Name, input.NodePoolName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return skipBulkCluster(result, fmt.Sprintf("node pool '%s' not found", input.NodePoolName)), ""
+		}
+		return skipBulkCluster(result, fmt.Sprintf("failed to get node pool: %v", err)), ""
+	}
+	replicas := 0
+	if pool.replicas != nil {
+		replicas = int(*pool.replicas)
+	}
+	if replicas == input.Replicas {
+		return skipBulkCluster(result, fmt.Sprintf("node pool '%s' already has %d replicas", nodePool, replicas)), ""
+	}
+	result.Change = fmt.Sprintf("%s: %d -> %d replicas", nodePool, replicas, input.Replicas)
+	return result, nodePool
+}
+
+// getBulkNodePool looks up a node pool of a cluster by its name or, as the
+// names of generated MachineDeployments differ between clusters, by its
+// topology name. It returns the node pool's name with the node pool.
+func (s *EnhancedClusterService) getBulkNodePool(ctx context.Context, clusterName, name string) (string, *scalableNodePool, error) {
+	pool, err := s.getScalableNodePool(ctx, clusterName, name)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return name, pool, err
+	}
+
+	mds, listErr := s.kubeClient.ListMachineDeployments(ctx, clusterName)
+	if listErr != nil {
+		return "", nil, listErr
+	}
+	for _, md := range mds.Items {
+		if md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] == name {
+			pool, err := s.getScalableNodePool(ctx, clusterName, md.Name)
+			return md.Name, pool, err
+		}
+	}
+	return "", nil, err
+}
+
+// planBulkUpgrade describes the change bulk_upgrade makes to a cluster.
+// Clusters are upgraded one minor version at a time, as Kubernetes requires.
+func (s *EnhancedClusterService) planBulkUpgrade(ctx context.Context, cluster *clusterv1.Cluster, kubernetesVersion string) api.BulkClusterResult {
+	result := api.BulkClusterResult{ClusterName: cluster.Name}
+	if reason := bulkSkipReason(cluster); reason != "" {
+		return skipBulkCluster(result, reason)
+	}
+	if cluster.Spec.Topology == nil {
+		return skipBulkCluster(result, "cluster is not managed by a ClusterClass")
+	}
+
+	current := cluster.Spec.Topology.Version
+	if current == kubernetesVersion {
+		return skipBulkCluster(result, fmt.Sprintf("already at %s", kubernetesVersion))
+	}
+	from, fromErr := version.ParseSemantic(current)
+	to, toErr := version.ParseSemantic(kubernetesVersion)
+	switch {
+	case fromErr != nil || toErr != nil:
+		return skipBulkCluster(result, fmt.Sprintf("cannot compare version %s with %s", current, kubernetesVersion))
+	case to.LessThan(from):
+		return skipBulkCluster(result, fmt.Sprintf("would downgrade from %s", current))
+	case to.Major() != from.Major() || to.Minor() > from.Minor()+1:
+		return skipBulkCluster(result, fmt.Sprintf("would skip a minor version from %s; upgrade one minor version at a time", current))
+	}
+
+	clusterClass, err := s.kubeClient.GetClusterClass(ctx, cluster.Spec.Topology.Class)
+	if err != nil {
+		return skipBulkCluster(result, fmt.Sprintf("failed to get cluster template '%s': %v", cluster.Spec.Topology.Class, err))
+	}
+	if err := s.validateKubernetesVersionSupport(ctx, kubernetesVersion, clusterClass, clusterProvider(cluster)); err != nil {
+		return skipBulkCluster(result, errors.GetUserMessage(err))
+	}
+
+	result.Change = fmt.Sprintf("%s -> %s", current, kubernetesVersion)
+	return result
+}
+
+// skipBulkCluster marks a cluster as skipped by a bulk operation.
+func skipBulkCluster(result api.BulkClusterResult, reason string) api.BulkClusterResult {
+	result.Skipped = true
+	result.Reason = reason
+	return result
+}
+
+// startBulkOperation completes the output of a bulk operation and, unless it
+// is a dry run, starts changing the planned clusters in the background. With a
+// canary strategy, the first planned clusters are the canaries.
+func (s *EnhancedClusterService) startBulkOperation(ctx context.Context, kind string, output *api.BulkOperationOutput, parameters map[string]string, maxFailures int, canary bulkCanary, action bulkAction) (*api.BulkOperationOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation(kind)
+
+	var planned []string
+	for i, result := range output.Clusters {
+		if result.Skipped {
+			output.Skipped++
+			continue
+		}
+		output.Clusters[i].Canary = len(planned) < canary.clusters
+		planned = append(planned, result.ClusterName)
+	}
+	output.Planned = len(planned)
+	canary.clusters = min(canary.clusters, len(planned))
+	if canary.clusters > 0 {
+		output.HealthGates = canary.gates
+	}
+
+	if output.DryRun || len(planned) == 0 {
+		output.Message = fmt.Sprintf("Would %s %d of %d matching clusters", output.Action, output.Planned, len(output.Clusters))
+		if !output.DryRun {
+			output.Message = fmt.Sprintf("None of the %d matching clusters need to %s", len(output.Clusters), output.Action)
+		} else if canary.clusters > 0 {
+			output.Message += fmt.Sprintf(", the first %d as canaries", canary.clusters)
+		}
+		return output, nil
+	}
+
+	// The canaries come first, then their health gates, then the others
+	stages := slices.Clone(planned[:canary.clusters])
+	if canary.clusters > 0 {
+		stages = append(stages, bulkStageCanaryGates)
+		parameters["canary"] = fmt.Sprintf("%d", canary.clusters)
+		parameters["healthGates"] = strings.Join(canary.gates, ",")
+		if canary.wait > 0 {
+			parameters["canaryWait"] = canary.wait.String()
+		}
+	}
+	for _, result := range output.Clusters {
+		if !result.Canary {
+			stages = append(stages, result.ClusterName)
+		}
+	}
+
+	parameters["concurrency"] = fmt.Sprintf("%d", output.Concurrency)
+	run, err := s.startAsyncOperation(ctx, kind, "", parameters, stages...)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start bulk operation")
+		return nil, err
+	}
+	for _, result := range output.Clusters {
+		if result.Skipped {
+			run.skip(ctx, result.ClusterName, result.Reason)
+		}
+	}
+	output.OperationID = run.op.ID
+	output.Message = fmt.Sprintf("%s %d of %d matching clusters, %d at a time", bulkActionVerb(output.Action), output.Planned, len(output.Clusters), output.Concurrency)
+	if canary.clusters > 0 {
+		output.Message += fmt.Sprintf(", starting with %d canaries that must pass the health gates (%s)", canary.clusters, strings.Join(canary.gates, ", "))
+	}
+	output.Message += "; poll get_operation_status with operation " + run.op.ID
+
+	plan := bulkPlan{
+		kind:        kind,
+		planned:     planned,
+		concurrency: output.Concurrency,
+		maxFailures: maxFailures,
+		canary:      canary,
+		action:      action,
+	}
+	s.goBackground(ctx, func(ctx context.Context) { s.runBulkOperation(ctx, run, plan) })
+
+	logger.Info("Started bulk operation", "operation_id", output.OperationID, "planned", output.Planned, "skipped", output.Skipped, "canary", canary.clusters)
+	return output, nil
+}
+
+// runBulkOperation changes the planned clusters of a bulk operation, at most
+// concurrency at a time. A cluster that fails fails only its own stage; once
+// maxFailures clusters have failed, the clusters not yet started are skipped.
+// With a canary strategy the operation is aborted, leaving the other clusters
+// unchanged, if a canary fails or does not pass the health gates.
+func (s *EnhancedClusterService) runBulkOperation(ctx context.Context, run *asyncRun, plan bulkPlan) {
+	logger := s.logger.WithContext(ctx).WithOperation(plan.kind)
+
+	canaries, rest := plan.planned[:plan.canary.clusters], plan.planned[plan.canary.clusters:]
+	if len(canaries) > 0 {
+		failed, _ := s.changeBulkClusters(ctx, run, plan, canaries, 0)
+		var err error
+		if failed > 0 {
+			err = fmt.Errorf("%d of %d canary clusters failed", failed, len(canaries))
+			run.skip(ctx, bulkStageCanaryGates, "canary clusters failed")
+		} else {
+			err = s.checkCanaryHealth(ctx, run, plan.canary, canaries)
+		}
+		if err != nil {
+			reason := "not started, the canary was aborted"
+			if ctx.Err() != nil {
+				reason, err = errInterrupted.Error(), errInterrupted
+			}
+			for _, clusterName := range rest {
+				run.skip(ctx, clusterName, reason)
+			}
+			err = fmt.Errorf("aborted after the canary: %w", err)
+			logger.WithError(err).Warn("Bulk operation aborted", "operation_id", run.op.ID)
+			run.abort(ctx, err)
+			return
+		}
+	}
+
+	failed, stopped := s.changeBulkClusters(ctx, run, plan, rest, 0)
+	if ctx.Err() != nil {
+		logger.Warn("Bulk operation interrupted", "operation_id", run.op.ID, "failed", failed, "not_started", stopped)
+		run.abort(ctx, errInterrupted)
+		return
+	}
+	if failed > 0 {
+		err := fmt.Errorf("%d of %d clusters failed", failed, len(plan.planned))
+		if stopped > 0 {
+			err = fmt.Errorf("%w, %d not started", err, stopped)
+		}
+		logger.WithError(err).Warn("Bulk operation finished with failures", "operation_id", run.op.ID)
+		run.abort(ctx, err)
+		return
+	}
+	run.succeed(ctx)
+	logger.Info("Bulk operation finished", "operation_id", run.op.ID, "clusters", len(plan.planned))
+}
+
+// changeBulkClusters changes clusters of a bulk operation, at most
+// concurrency at a time, after failed clusters have already failed. It
+// returns how many clusters failed and how many were not started.
+func (s *EnhancedClusterService) changeBulkClusters(ctx context.Context, run *asyncRun, plan bulkPlan, clusters []string, failed int) (int, int) {
+	logger := s.logger.WithContext(ctx).WithOperation(plan.kind)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		stopped  int
+		inFlight = make(chan struct{}, plan.concurrency)
+	)
+	for _, clusterName := range clusters {
+		inFlight <- struct{}{}
+
+		mu.Lock()
+		stop := plan.maxFailures > 0 && failed >= plan.maxFailures
+		mu.Unlock()
+		if stop {
+			<-inFlight
+			stopped++
+			run.skip(ctx, clusterName, fmt.Sprintf("not started after %d clusters failed", plan.maxFailures))
+			continue
+		}
+		if ctx.Err() != nil {
+			<-inFlight
+			stopped++
+			run.skip(ctx, clusterName, errInterrupted.Error())
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+
+			run.begin(ctx, clusterName)
+			message, err := plan.action(ctx, clusterName, func(message string) {
+				run.progress(ctx, clusterName, message)
+			})
+			if err != nil {
+				logger.WithError(err).Warn("Cluster of bulk operation failed", "cluster", clusterName)
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				run.failStage(ctx, clusterName, err)
+				return
+			}
+			run.complete(ctx, clusterName, message)
+		}()
+	}
+	wg.Wait()
+	return failed, stopped
+}
+
+// checkCanaryHealth waits for the canary clusters to pass the health gates
+// within the cluster timeout, then, after the canary wait, checks that they
+// still pass.
+func (s *EnhancedClusterService) checkCanaryHealth(ctx context.Context, run *asyncRun, canary bulkCanary, clusters []string) error {
+	run.begin(ctx, bulkStageCanaryGates)
+
+	timeout := s.lifecycleTimeout
+	if timeout <= 0 {
+		timeout = defaultLifecycleTimeout
+	}
+	gateCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		problems := s.healthGateProblems(gateCtx, clusters, canary.gates)
+		if len(problems) == 0 {
+			break
+		}
+		run.progress(ctx, bulkStageCanaryGates, strings.Join(problems, "; "))
+
+		select {
+		case <-gateCtx.Done():
+			err := fmt.Errorf("health gates did not pass within %s: %s", timeout, strings.Join(problems, "; "))
+			run.failStage(ctx, bulkStageCanaryGates, err)
+			return err
+		case <-ticker.C:
+		}
+	}
+
+	if canary.wait > 0 {
+		run.progress(ctx, bulkStageCanaryGates, fmt.Sprintf("health gates passed, checking again after %s", canary.wait))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(canary.wait):
+		}
+		if problems := s.healthGateProblems(ctx, clusters, canary.gates); len(problems) > 0 {
+			err := fmt.Errorf("health gates failed %s after the canary: %s", canary.wait, strings.Join(problems, "; "))
+			run.failStage(ctx, bulkStageCanaryGates, err)
+			return err
+		}
+	}
+
+	run.complete(ctx, bulkStageCanaryGates, fmt.Sprintf("%d canary clusters passed the health gates (%s)", len(clusters), strings.Join(canary.gates, ", ")))
+	return nil
+}
+
+// healthGateProblems checks clusters against health gates and returns the
+// checks that failed.
+func (s *EnhancedClusterService) healthGateProblems(ctx context.Context, clusters []string, gates []string) []string {
+	var problems []string
+	for _, clusterName := range clusters {
+		cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: failed to get cluster: %v", clusterName, err))
+			continue
+		}
+
+		if slices.Contains(gates, HealthGateProvisioned) {
+			switch {
+			case cluster.Status.Phase != string(clusterv1.ClusterPhaseProvisioned):
+				problems = append(problems, fmt.Sprintf("%s: phase is %s", clusterName, cluster.Status.Phase))
+			case !cluster.Status.InfrastructureReady:
+				problems = append(problems, fmt.Sprintf("%s: infrastructure is not ready", clusterName))
+			case !cluster.Status.ControlPlaneReady:
+				problems = append(problems, fmt.Sprintf("%s: control plane is not ready", clusterName))
+			}
+		}
+
+		if slices.Contains(gates, HealthGateNodes) {
+			mds, err := s.kubeClient.ListMachineDeployments(ctx, clusterName)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: failed to list machine deployments: %v", clusterName, err))
+			} else {
+				for _, md := range mds.Items {
+					replicas := md.Status.Replicas
+					if md.Spec.Replicas != nil {
+						replicas = *md.Spec.Replicas
+					}
+					if md.Status.ReadyReplicas < replicas {
+						problems = append(problems, fmt.Sprintf("%s: node pool '%s' has %d of %d replicas ready",
+							clusterName, md.Name, md.Status.ReadyReplicas, replicas))
+					}
+				}
+			}
+		}
+
+		if slices.Contains(gates, HealthGateAPI) {
+			probeCtx, cancel := context.WithTimeout(ctx, readinessGateTimeout)
+			workloadClient, err := s.newWorkloadClient(probeCtx, clusterName)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", clusterName, errors.GetUserMessage(err)))
+			} else if probe := probeWorkloadCluster(probeCtx, workloadClient); !probe.Healthy {
+				problems = append(problems, fmt.Sprintf("%s: %s", clusterName, probe.Message))
+			}
+			cancel()
+		}
+	}
+	return problems
+}
+
+// bulkActionVerb returns the progressive form of a bulk action.
+func bulkActionVerb(action string) string {
+	if action == "scale" {
+		return "Scaling"
+	}
+	return "Upgrading"
+}
+
+// upgradeCluster sets the Kubernetes version of a cluster's topology and
+// waits until the control plane and every MachineDeployment run it.
+func (s *EnhancedClusterService) upgradeCluster(ctx context.Context, clusterName, kubernetesVersion string, progress func(message string)) error {
+	cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", clusterName))
+		}
+		return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if cluster.Spec.Topology == nil {
+		return errors.New(errors.CodePreconditionFailed, fmt.Sprintf("cluster '%s' is not managed by a ClusterClass", clusterName))
+	}
+	if cluster.Spec.Topology.Version != kubernetesVersion {
+		cluster.Spec.Topology.Version = kubernetesVersion
+		if err := s.kubeClient.UpdateCluster(ctx, cluster); err != nil {
+			if apierrors.IsConflict(err) {
+				return errors.Wrap(err, errors.CodePreconditionFailed, "cluster was modified concurrently")
+			}
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to update cluster")
+		}
+	}
+	progress(fmt.Sprintf("topology version set to %s", kubernetesVersion))
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		pending, err := s.pendingUpgrade(ctx, cluster, kubernetesVersion)
+		if err == nil {
+			if pending == "" {
+				return nil
+			}
+			progress(pending)
+		}
+		// Keep trying on transient errors
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("%s: %w", pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// pendingUpgrade describes what still has to roll out for a cluster to run a
+// Kubernetes version, or returns an empty string once it does.
+func (s *EnhancedClusterService) pendingUpgrade(ctx context.Context, cluster *clusterv1.Cluster, kubernetesVersion string) (string, error) {
+	if cluster.Spec.ControlPlaneRef != nil {
+		controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+		if err != nil {
+			return "", errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get control plane")
+		}
+		current, _, _ := unstructured.NestedString(controlPlane.Object, "status", "version")
+		if current != kubernetesVersion {
+			return fmt.Sprintf("waiting for the control plane to run %s", kubernetesVersion), nil
+		}
+		replicas, _, _ := unstructured.NestedInt64(controlPlane.Object, "status", "replicas")
+		updatedReplicas, _, _ := unstructured.NestedInt64(controlPlane.Object, "status", "updatedReplicas")
+		if updatedReplicas < replicas {
+			return fmt.Sprintf("control plane: %d of %d machines updated", updatedReplicas, replicas), nil
+		}
+	}
+
+	mds, err := s.kubeClient.ListMachineDeployments(ctx, cluster.Name)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployments")
+	}
+	for _, md := range mds.Items {
+		if md.Spec.Template.Spec.Version == nil || *md.Spec.Template.Spec.Version != kubernetesVersion {
+			return fmt.Sprintf("waiting for node pool '%s' to run %s", md.Name, kubernetesVersion), nil
+		}
+		replicas := md.Status.Replicas
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+		if md.Status.UpdatedReplicas < replicas || md.Status.Replicas > md.Status.UpdatedReplicas {
+			return fmt.Sprintf("node pool '%s': %d of %d machines updated", md.Name, md.Status.UpdatedReplicas, replicas), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// createTestFleetCluster returns a cluster labelled env=prod at a version.
+func createTestFleetCluster(name, kubernetesVersion string) *clusterv1.Cluster {
+	cluster := createTestCluster(name, testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Labels["env"] = "prod"
+	cluster.Spec.Topology.Version = kubernetesVersion
+	return cluster
+}
+
+// withTopologyName labels a MachineDeployment with its name in the cluster topology.
+func withTopologyName(md *clusterv1.MachineDeployment, name string) *clusterv1.MachineDeployment {
+	md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = name
+	return md
+}
+
+func TestEnhancedClusterService_BulkValidation(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t, createTestFleetCluster("prod-a", "v1.31.0"))
+
+	tests := []struct {
+		name  string
+		input api.BulkScaleInput
+		code  errors.ErrorCode
+	}{
+		{name: "missing selector", input: api.BulkScaleInput{NodePoolName: "md-0", DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "invalid selector", input: api.BulkScaleInput{LabelSelector: "env in (prod", NodePoolName: "md-0", DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "missing node pool", input: api.BulkScaleInput{LabelSelector: "env=prod", DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "concurrency too high", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", Concurrency: 50, DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "negative max failures", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", MaxFailures: -1, DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "no matching clusters", input: api.BulkScaleInput{LabelSelector: "env=staging", NodePoolName: "md-0", DryRun: true}, code: errors.CodeNotFound},
+		{name: "no clusters in environment", input: api.BulkScaleInput{LabelSelector: "env=prod", Environment: "prod", NodePoolName: "md-0", DryRun: true}, code: errors.CodeNotFound},
+		{name: "negative canary", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", Canary: -1, DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "health gates without canary", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", HealthGates: []string{"nodes"}, DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "unknown health gate", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", Canary: 1, HealthGates: []string{"vibes"}, DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "invalid canary wait", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", Canary: 1, CanaryWait: "soon", DryRun: true}, code: errors.CodeInvalidInput},
+		{name: "async operations disabled", input: api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0"}, code: errors.CodeUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.BulkScale(ctx, tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+
+	_, err := svc.BulkUpgrade(ctx, api.BulkUpgradeInput{LabelSelector: "env=prod", KubernetesVersion: "latest", DryRun: true})
+	assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+}
+
+func TestEnhancedClusterService_BulkScale(t *testing.T) {
+	ctx := context.Background()
+	paused := createTestFleetCluster("prod-d", "v1.31.0")
+	paused.Spec.Paused = true
+	dev := createTestCluster("dev-a", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	svc, fakeClient := setupEnhancedTestService(t,
+		createTestFleetCluster("prod-a", "v1.31.0"),
+		createTestFleetCluster("prod-b", "v1.31.0"),
+		createTestFleetCluster("prod-c", "v1.31.0"),
+		paused,
+		dev,
+		withTopologyName(createTestMachineDeployment("prod-a-md-0-x7k2p", testNamespace, "prod-a", 2), "md-0"),
+		withTopologyName(createTestMachineDeployment("prod-b-md-0-q9z4m", testNamespace, "prod-b", 4), "md-0"),
+		createTestMachineDeployment("md-0", testNamespace, "prod-c", 3),
+		withTopologyName(createTestMachineDeployment("prod-d-md-0-h2w8r", testNamespace, "prod-d", 2), "md-0"),
+		withTopologyName(createTestMachineDeployment("dev-a-md-0-c5n1v", testNamespace, "dev-a", 2), "md-0"),
+	)
+
+	input := api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", Replicas: 4, DryRun: true}
+	output, err := svc.BulkScale(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, []api.BulkClusterResult{
+		{ClusterName: "prod-a", Change: "prod-a-md-0-x7k2p: 2 -> 4 replicas"},
+		{ClusterName: "prod-b", Skipped: true, Reason: "node pool 'prod-b-md-0-q9z4m' already has 4 replicas"},
+		{ClusterName: "prod-c", Change: "md-0: 3 -> 4 replicas"},
+		{ClusterName: "prod-d", Skipped: true, Reason: "cluster is paused"},
+	}, output.Clusters)
+	assert.Equal(t, 2, output.Planned)
+	assert.Equal(t, 2, output.Skipped)
+	assert.Equal(t, defaultBulkConcurrency, output.Concurrency)
+	assert.Empty(t, output.OperationID)
+
+	svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	input.DryRun = false
+	output, err = svc.BulkScale(ctx, input)
+	require.NoError(t, err)
+	require.NotEmpty(t, output.OperationID)
+
+	require.Eventually(t, func() bool {
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		return err == nil && status.State == async.StateSucceeded
+	}, 5*time.Second, 20*time.Millisecond)
+
+	status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, BulkScaleKind, status.Kind)
+	require.Len(t, status.Stages, 4)
+	for i, state := range []string{async.StateSucceeded, async.StateSkipped, async.StateSucceeded, async.StateSkipped} {
+		assert.Equal(t, state, status.Stages[i].State, status.Stages[i].Name)
+	}
+
+	for _, name := range []string{"prod-a-md-0-x7k2p", "md-0", "dev-a-md-0-c5n1v"} {
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: name}, md))
+		want := int32(4)
+		if name == "dev-a-md-0-c5n1v" {
+			want = 2
+		}
+		assert.Equal(t, want, *md.Spec.Replicas, name)
+	}
+}
+
+func TestEnhancedClusterService_BulkUpgrade(t *testing.T) {
+	ctx := context.Background()
+	dependent := createTestFleetCluster("prod-b", "v1.31.0")
+	dependent.Annotations = map[string]string{DependsOnAnnotation: "prod-a"}
+	svc, fakeClient := setupEnhancedTestService(t,
+		createTestClusterClass("aws-cluster-class"),
+		createTestFleetCluster("prod-a", "v1.30.5"),
+		dependent,
+		createTestFleetCluster("prod-c", "v1.29.9"),
+		createTestMachineDeployment("prod-a-md-0", testNamespace, "prod-a", 2),
+	)
+	svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+
+	input := api.BulkUpgradeInput{LabelSelector: "env=prod", KubernetesVersion: "v1.31.0", Concurrency: 2, DryRun: true}
+	output, err := svc.BulkUpgrade(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, []api.BulkClusterResult{
+		{ClusterName: "prod-a", Change: "v1.30.5 -> v1.31.0", Warnings: []string{"clusters depend on cluster 'prod-a': prod-b"}},
+		{ClusterName: "prod-b", Skipped: true, Reason: "already at v1.31.0"},
+		{ClusterName: "prod-c", Skipped: true, Reason: "would skip a minor version from v1.29.9; upgrade one minor version at a time"},
+	}, output.Clusters)
+
+	t.Run("downgrades are skipped", func(t *testing.T) {
+		output, err := svc.BulkUpgrade(ctx, api.BulkUpgradeInput{LabelSelector: "env=prod", KubernetesVersion: "v1.30.5", DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, "would downgrade from v1.31.0", output.Clusters[1].Reason)
+	})
+
+	svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	input.DryRun = false
+	output, err = svc.BulkUpgrade(ctx, input)
+	require.NoError(t, err)
+	require.NotEmpty(t, output.OperationID)
+
+	// The stage waits for the node pool to roll out the new version
+	require.Eventually(t, func() bool {
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		return err == nil && status.Stages[0].Message == "waiting for node pool 'prod-a-md-0' to run v1.31.0"
+	}, 5*time.Second, 20*time.Millisecond)
+
+	cluster := &clusterv1.Cluster{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "prod-a"}, cluster))
+	assert.Equal(t, "v1.31.0", cluster.Spec.Topology.Version)
+
+	md := &clusterv1.MachineDeployment{}
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "prod-a-md-0"}, md))
+	upgraded := "v1.31.0"
+	md.Spec.Template.Spec.Version = &upgraded
+	require.NoError(t, fakeClient.Update(ctx, md))
+
+	require.Eventually(t, func() bool {
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		return err == nil && status.State == async.StateSucceeded
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestEnhancedClusterService_BulkCanary(t *testing.T) {
+	ctx := context.Background()
+	objects := []client.Object{}
+	for _, name := range []string{"prod-a", "prod-b", "prod-c"} {
+		objects = append(objects,
+			createTestFleetCluster(name, "v1.31.0"),
+			withTopologyName(createTestMachineDeployment(name+"-md-0", testNamespace, name, 2), "md-0"),
+		)
+	}
+	input := api.BulkScaleInput{LabelSelector: "env=prod", NodePoolName: "md-0", Replicas: 3, Canary: 1}
+
+	t.Run("dry run marks the canaries", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, objects...)
+		input := input
+		input.DryRun = true
+
+		output, err := svc.BulkScale(ctx, input)
+		require.NoError(t, err)
+		assert.True(t, output.Clusters[0].Canary)
+		assert.False(t, output.Clusters[1].Canary)
+		assert.Equal(t, []string{HealthGateProvisioned, HealthGateNodes}, output.HealthGates)
+		assert.Equal(t, "Would scale 3 of 3 matching clusters, the first 1 as canaries", output.Message)
+	})
+
+	t.Run("continues once the canaries pass", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, objects...)
+		svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+
+		output, err := svc.BulkScale(ctx, input)
+		require.NoError(t, err)
+
+		// The canary's new machines become ready
+		require.Eventually(t, func() bool {
+			status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+			return err == nil && status.Stages[1].Message == "prod-a: node pool 'prod-a-md-0' has 0 of 3 replicas ready"
+		}, 5*time.Second, 20*time.Millisecond)
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "prod-a-md-0"}, md))
+		md.Status.ReadyReplicas = 3
+		require.NoError(t, fakeClient.Update(ctx, md))
+
+		require.Eventually(t, func() bool {
+			status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+			return err == nil && status.State == async.StateSucceeded
+		}, 5*time.Second, 20*time.Millisecond)
+
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		require.NoError(t, err)
+		var names []string
+		for _, stage := range status.Stages {
+			names = append(names, stage.Name)
+			assert.Equal(t, async.StateSucceeded, stage.State, stage.Name)
+		}
+		assert.Equal(t, []string{"prod-a", bulkStageCanaryGates, "prod-b", "prod-c"}, names)
+	})
+
+	t.Run("aborts when the canaries fail the health gates", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, objects...)
+		svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+		svc.SetLifecycleMetrics(nil, 100*time.Millisecond)
+
+		output, err := svc.BulkScale(ctx, input)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+			return err == nil && status.State == async.StateFailed
+		}, 5*time.Second, 20*time.Millisecond)
+
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		require.NoError(t, err)
+		assert.Contains(t, status.Error, "aborted after the canary: health gates did not pass within 100ms")
+		assert.Equal(t, async.StateFailed, status.Stages[1].State)
+		for _, stage := range status.Stages[2:] {
+			assert.Equal(t, async.StateSkipped, stage.State, stage.Name)
+		}
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "prod-b-md-0"}, md))
+		assert.Equal(t, int32(2), *md.Spec.Replicas)
+	})
+}
+
+func TestEnhancedClusterService_RunBulkOperation(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+	svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	clusters := []string{"c1", "c2", "c3", "c4", "c5", "c6"}
+
+	t.Run("bounded concurrency", func(t *testing.T) {
+		run, err := svc.startAsyncOperation(ctx, BulkScaleKind, "", nil, clusters...)
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		running, peak := 0, 0
+		plan := bulkPlan{kind: BulkScaleKind, planned: clusters, concurrency: 2}
+		plan.action = func(ctx context.Context, clusterName string, progress func(string)) (string, error) {
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+			progress("scaling")
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+			if clusterName == "c3" {
+				return "", fmt.Errorf("quota exceeded")
+			}
+			return "scaled", nil
+		}
+		svc.runBulkOperation(ctx, run, plan)
+
+		assert.Equal(t, 2, peak)
+		assert.Equal(t, async.StateFailed, run.op.State)
+		assert.Equal(t, "1 of 6 clusters failed", run.op.Error)
+		assert.Equal(t, async.StateFailed, run.op.Stages[2].State)
+		assert.Equal(t, "quota exceeded", run.op.Stages[2].Message)
+		assert.Equal(t, async.StateSucceeded, run.op.Stages[5].State)
+	})
+
+	t.Run("stops after max failures", func(t *testing.T) {
+		run, err := svc.startAsyncOperation(ctx, BulkScaleKind, "", nil, clusters...)
+		require.NoError(t, err)
+
+		svc.runBulkOperation(ctx, run, bulkPlan{
+			kind:        BulkScaleKind,
+			planned:     clusters,
+			concurrency: 1,
+			maxFailures: 2,
+			action: func(ctx context.Context, clusterName string, _ func(string)) (string, error) {
+				return "", fmt.Errorf("quota exceeded")
+			},
+		})
+
+		assert.Equal(t, "2 of 6 clusters failed, 4 not started", run.op.Error)
+		for _, stage := range run.op.Stages[2:] {
+			assert.Equal(t, async.StateSkipped, stage.State, stage.Name)
+			assert.Equal(t, "not started after 2 clusters failed", stage.Message)
+		}
+	})
+
+	t.Run("interrupted when the server stops", func(t *testing.T) {
+		run, err := svc.startAsyncOperation(ctx, BulkScaleKind, "", nil, clusters...)
+		require.NoError(t, err)
+
+		serverCtx, stop := context.WithCancel(ctx)
+		svc.runBulkOperation(serverCtx, run, bulkPlan{
+			kind:        BulkScaleKind,
+			planned:     clusters,
+			concurrency: 1,
+			action: func(ctx context.Context, clusterName string, _ func(string)) (string, error) {
+				if clusterName == "c2" {
+					stop()
+				}
+				return "scaled", nil
+			},
+		})
+
+		assert.Equal(t, async.StateFailed, run.op.State)
+		assert.Equal(t, errInterrupted.Error(), run.op.Error)
+		assert.Equal(t, async.StateSucceeded, run.op.Stages[1].State)
+		for _, stage := range run.op.Stages[2:] {
+			assert.Equal(t, async.StateSkipped, stage.State, stage.Name)
+		}
+	})
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+// Cluster change types reported by get_recent_changes.
+const (
+	ChangeCreated    = "created"
+	ChangeDeleted    = "deleted"
+	ChangeScaled     = "scaled"
+	ChangeUpgraded   = "upgraded"
+	ChangeConfigured = "configured"
+	ChangeFailed     = "failed"
+)
+
+// changeTypes maps the tools recorded in the operation history to the change
+// they make when they succeed.
+var changeTypes = map[string]string{
+	"create_cluster":               ChangeCreated,
+	"delete_cluster":               ChangeDeleted,
+	"undelete_cluster":             ChangeConfigured,
+	"scale_cluster":                ChangeScaled,
+	"create_node_pool":             ChangeScaled,
+	"install_cni":                  ChangeConfigured,
+	"update_cluster_variables":     ChangeConfigured,
+	"refresh_cluster_templates":    ChangeConfigured,
+	"pause_rollout":                ChangeConfigured,
+	"resume_rollout":               ChangeConfigured,
+	"restart_rollout":              ChangeConfigured,
+	"undo_rollout":                 ChangeConfigured,
+	RollbackTool:                   ChangeScaled,
+	"upgrade_management_providers": ChangeUpgraded,
+}
+
+// GetRecentChanges returns a digest of the cluster lifecycle changes since a
+// given time, oldest first, so agents without state can catch up at the start
+// of a session. Changes are drawn from the operation history and from the
+// current state of the clusters, which also covers changes made outside the
+// server. When namespaces is non-empty only changes in those namespaces are
+// returned.
+func (s *EnhancedClusterService) GetRecentChanges(ctx context.Context, input api.GetRecentChangesInput, namespaces []string) (*api.GetRecentChangesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetRecentChanges")
+	logger.Info("Getting recent changes", "since", input.Since, "cluster", input.ClusterName)
+
+	if input.Since == "" {
+		err := errors.New(errors.CodeInvalidInput, "since is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	since, err := time.Parse(time.RFC3339, input.Since)
+	if err != nil {
+		err = errors.New(errors.CodeInvalidInput, fmt.Sprintf("since must be an RFC 3339 timestamp, got '%s'", input.Since))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	until := time.Now()
+
+	listCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	var changes []api.ClusterChange
+	if s.history != nil {
+		operations, err := s.history.List(listCtx, history.Filter{
+			ClusterName: input.ClusterName,
+			Namespaces:  namespaces,
+			Since:       since,
+			Limit:       maxListedOperations,
+		})
+		if err != nil {
+			logger.WithError(err).Error("Failed to list operations")
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list operations")
+		}
+		for _, op := range operations {
+			if change, ok := operationChange(op); ok {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	clusters, err := s.kubeClient.ListAllClusters(listCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list clusters")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
+	}
+	recorded := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		recorded[change.Type+"/"+change.Namespace+"/"+change.ClusterName] = true
+	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if input.ClusterName != "" && cluster.Name != input.ClusterName {
+			continue
+		}
+		if len(namespaces) > 0 && !slices.Contains(namespaces, cluster.Namespace) {
+			continue
+		}
+		for _, change := range clusterChanges(cluster, since) {
+			// Operations recorded by the server describe the change in more detail
+			if !recorded[change.Type+"/"+change.Namespace+"/"+change.ClusterName] {
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time < changes[j].Time
+	})
+
+	output := &api.GetRecentChangesOutput{
+		Since:   since.UTC().Format(time.RFC3339),
+		Until:   until.UTC().Format(time.RFC3339),
+		Changes: changes,
+		Message: changesMessage(changes, since),
+	}
+	if output.Changes == nil {
+		output.Changes = []api.ClusterChange{}
+	}
+	if s.history == nil {
+		output.Message += " Operation history is not enabled, so only changes visible in the current cluster state are reported."
+	}
+
+	logger.Info("Got recent changes", "count", len(output.Changes))
+	return output, nil
+}
+
+// operationChange converts a recorded operation to the change it made. Failed
+// operations are reported as failures whatever the tool.
+func operationChange(op history.Operation) (api.ClusterChange, bool) {
+	changeType, ok := changeTypes[op.Tool]
+	if !ok {
+		return api.ClusterChange{}, false
+	}
+
+	target := op.ClusterName
+	if target == "" {
+		target = "the management cluster"
+	}
+	summary := fmt.Sprintf("%s on %s", op.Tool, target)
+	if params := formatParameters(op.Parameters); params != "" {
+		summary += " (" + params + ")"
+	}
+	if op.Outcome == history.OutcomeFailed {
+		changeType = ChangeFailed
+		summary += " failed"
+		if op.Error != "" {
+			summary += ": " + op.Error
+		}
+	}
+
+	return api.ClusterChange{
+		Type:        changeType,
+		ClusterName: op.ClusterName,
+		Namespace:   op.Namespace,
+		Time:        op.StartedAt.UTC().Format(time.RFC3339),
+		Source:      "operation",
+		Tool:        op.Tool,
+		Identity:    op.Identity,
+		Summary:     summary,
+	}, true
+}
+
+// clusterChanges returns the changes visible in a cluster's current state that
+// happened at or after since: its creation and a failure reported by its
+// status.
+func clusterChanges(cluster *clusterv1.Cluster, since time.Time) []api.ClusterChange {
+	var changes []api.ClusterChange
+
+	if created := cluster.CreationTimestamp.Time; !created.IsZero() && !created.Before(since) {
+		changes = append(changes, api.ClusterChange{
+			Type:        ChangeCreated,
+			ClusterName: cluster.Name,
+			Namespace:   cluster.Namespace,
+			Time:        created.UTC().Format(time.RFC3339),
+			Source:      "cluster",
+			Summary:     fmt.Sprintf("cluster %s was created", cluster.Name),
+		})
+	}
+
+	if clusterv1.ClusterPhase(cluster.Status.Phase) == clusterv1.ClusterPhaseFailed || cluster.Status.FailureReason != nil {
+		// The Ready condition transitions when the cluster fails
+		var failedAt time.Time
+		for _, cond := range cluster.Status.Conditions {
+			if cond.Type == clusterv1.ReadyCondition {
+				failedAt = cond.LastTransitionTime.Time
+			}
+		}
+		if !failedAt.IsZero() && !failedAt.Before(since) {
+			summary := fmt.Sprintf("cluster %s failed", cluster.Name)
+			if cluster.Status.FailureMessage != nil {
+				summary += ": " + *cluster.Status.FailureMessage
+			}
+			changes = append(changes, api.ClusterChange{
+				Type:        ChangeFailed,
+				ClusterName: cluster.Name,
+				Namespace:   cluster.Namespace,
+				Time:        failedAt.UTC().Format(time.RFC3339),
+				Source:      "cluster",
+				Summary:     summary,
+			})
+		}
+	}
+
+	return changes
+}
+
+// changesMessage summarizes changes by type.
+func changesMessage(changes []api.ClusterChange, since time.Time) string {
+	if len(changes) == 0 {
+		return fmt.Sprintf("No cluster changes since %s.", since.UTC().Format(time.RFC3339))
+	}
+
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.Type]++
+	}
+	parts := make([]string, 0, len(counts))
+	for _, changeType := range []string{ChangeCreated, ChangeScaled, ChangeUpgraded, ChangeConfigured, ChangeDeleted, ChangeFailed} {
+		if counts[changeType] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[changeType], changeType))
+		}
+	}
+	return fmt.Sprintf("%d cluster changes since %s: %s.", len(changes), since.UTC().Format(time.RFC3339), strings.Join(parts, ", "))
+}
+
+// formatParameters formats operation parameters as sorted key=value pairs.
+func formatParameters(parameters map[string]string) string {
+	pairs := make([]string, 0, len(parameters))
+	for _, key := range sortedKeys(parameters, nil) {
+		pairs = append(pairs, key+"="+parameters[key])
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+func TestEnhancedClusterService_GetRecentChanges(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	// Created before the digest window, failed within it
+	failed := createTestCluster("failed-cluster", testNamespace, clusterv1.ClusterPhaseFailed)
+	failed.CreationTimestamp = metav1.NewTime(base.Add(-24 * time.Hour))
+	failureMessage := "failed to create VPC"
+	failed.Status.FailureMessage = &failureMessage
+	failed.Status.Conditions = clusterv1.Conditions{{
+		Type:               clusterv1.ReadyCondition,
+		Status:             "False",
+		LastTransitionTime: metav1.NewTime(base.Add(2 * time.Hour)),
+	}}
+
+	// Created outside the server within the window
+	external := createTestCluster("external-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	external.CreationTimestamp = metav1.NewTime(base.Add(3 * time.Hour))
+
+	// Created through the server within the window
+	recorded := createTestCluster("recorded-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	recorded.CreationTimestamp = metav1.NewTime(base.Add(30 * time.Minute))
+
+	other := createTestCluster("other-cluster", "team-b", clusterv1.ClusterPhaseProvisioned)
+	other.CreationTimestamp = metav1.NewTime(base.Add(time.Hour))
+
+	t.Run("without operation history", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, failed, external, other)
+
+		output, err := svc.GetRecentChanges(ctx, api.GetRecentChangesInput{Since: base.Format(time.RFC3339)}, nil)
+		require.NoError(t, err)
+		require.Len(t, output.Changes, 3)
+		assert.Equal(t, "2025-07-01T12:00:00Z", output.Since)
+		assert.Contains(t, output.Message, "Operation history is not enabled")
+
+		assert.Equal(t, "other-cluster", output.Changes[0].ClusterName)
+		assert.Equal(t, ChangeCreated, output.Changes[0].Type)
+
+		assert.Equal(t, ChangeFailed, output.Changes[1].Type)
+		assert.Equal(t, "failed-cluster", output.Changes[1].ClusterName)
+		assert.Equal(t, "2025-07-01T14:00:00Z", output.Changes[1].Time)
+		assert.Contains(t, output.Changes[1].Summary, "failed to create VPC")
+
+		assert.Equal(t, "external-cluster", output.Changes[2].ClusterName)
+		assert.Equal(t, "cluster", output.Changes[2].Source)
+	})
+
+	svc, _ := setupEnhancedTestService(t, failed, external, recorded, other)
+	svc.SetOperationHistory(history.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "create_cluster",
+		ClusterName: "recorded-cluster",
+		Namespace:   testNamespace,
+		Identity:    "platform-agent",
+		Outcome:     history.OutcomeSucceeded,
+		StartedAt:   base.Add(25 * time.Minute),
+		Parameters:  map[string]string{"template": "aws-cluster-class"},
+	})
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "scale_cluster",
+		ClusterName: "recorded-cluster",
+		Namespace:   testNamespace,
+		Outcome:     history.OutcomeSucceeded,
+		StartedAt:   base.Add(4 * time.Hour),
+		Parameters:  map[string]string{"replicas": "5"},
+	})
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "scale_cluster",
+		ClusterName: "recorded-cluster",
+		Namespace:   testNamespace,
+		Outcome:     history.OutcomeFailed,
+		Error:       "quota exceeded",
+		StartedAt:   base.Add(5 * time.Hour),
+	})
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "get_cluster",
+		ClusterName: "recorded-cluster",
+		Namespace:   testNamespace,
+		Outcome:     history.OutcomeSucceeded,
+		StartedAt:   base.Add(6 * time.Hour),
+	})
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "delete_cluster",
+		ClusterName: "old-cluster",
+		Namespace:   testNamespace,
+		Outcome:     history.OutcomeSucceeded,
+		StartedAt:   base.Add(-time.Hour),
+	})
+
+	t.Run("merges operations and cluster state", func(t *testing.T) {
+		output, err := svc.GetRecentChanges(ctx, api.GetRecentChangesInput{
+			Since:       base.Format(time.RFC3339),
+			ClusterName: "recorded-cluster",
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, output.Changes, 3)
+
+		created := output.Changes[0]
+		assert.Equal(t, ChangeCreated, created.Type)
+		assert.Equal(t, "operation", created.Source)
+		assert.Equal(t, "platform-agent", created.Identity)
+		assert.Equal(t, "2025-07-01T12:25:00Z", created.Time)
+
+		assert.Equal(t, ChangeScaled, output.Changes[1].Type)
+		assert.Contains(t, output.Changes[1].Summary, "replicas=5")
+
+		assert.Equal(t, ChangeFailed, output.Changes[2].Type)
+		assert.Equal(t, "scale_cluster", output.Changes[2].Tool)
+		assert.Contains(t, output.Changes[2].Summary, "quota exceeded")
+
+		assert.Equal(t, "3 cluster changes since 2025-07-01T12:00:00Z: 1 created, 1 scaled, 1 failed.", output.Message)
+	})
+
+	t.Run("filters by namespace", func(t *testing.T) {
+		output, err := svc.GetRecentChanges(ctx, api.GetRecentChangesInput{Since: base.Format(time.RFC3339)}, []string{"team-b"})
+		require.NoError(t, err)
+		require.Len(t, output.Changes, 1)
+		assert.Equal(t, "other-cluster", output.Changes[0].ClusterName)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		output, err := svc.GetRecentChanges(ctx, api.GetRecentChangesInput{Since: base.Add(48 * time.Hour).Format(time.RFC3339)}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, output.Changes)
+		assert.Equal(t, "No cluster changes since 2025-07-03T12:00:00Z.", output.Message)
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		for _, since := range []string{"", "yesterday"} {
+			_, err := svc.GetRecentChanges(ctx, api.GetRecentChangesInput{Since: since}, nil)
+			require.Error(t, err)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		}
+	})
+}
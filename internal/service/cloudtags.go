@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+const (
+	// CloudTagsVariable is the topology variable holding the tags to apply to
+	// a cluster's cloud resources, propagated to additionalTags by the
+	// ClusterClass.
+	CloudTagsVariable = "cloudTags"
+
+	// capaClusterTagPrefix prefixes the tag CAPA adds to every resource it
+	// manages for a cluster, with the value "owned".
+	capaClusterTagPrefix = "sigs.k8s.io/cluster-api-provider-aws/cluster/"
+)
+
+// getCloudTags returns the tags applied to a cluster's cloud resources. They
+// are read from the infrastructure cluster's additionalTags, falling back to
+// the cloudTags variable while the infrastructure cluster is unavailable.
+func (s *EnhancedClusterService) getCloudTags(ctx context.Context, cluster *clusterv1.Cluster) *api.CloudTags {
+	if ref := cluster.Spec.InfrastructureRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cluster.Namespace
+		}
+		infraCluster, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		if err == nil {
+			tags, _, _ := unstructured.NestedStringMap(infraCluster.Object, "spec", "additionalTags")
+			if tags == nil {
+				tags = map[string]string{}
+			}
+			if clusterProvider(cluster) == "aws" {
+				tags[capaClusterTagPrefix+cluster.Name] = "owned"
+			}
+			return &api.CloudTags{Tags: tags, Source: "infrastructure"}
+		}
+		s.logger.WithContext(ctx).WithError(err).Debug("Failed to get infrastructure cluster",
+			logging.FieldClusterName, cluster.Name,
+		)
+	}
+
+	if tags := requestedCloudTags(cluster); tags != nil {
+		return &api.CloudTags{Tags: tags, Source: "variables"}
+	}
+	return nil
+}
+
+// requestedCloudTags returns the cloudTags topology variable of a cluster.
+func requestedCloudTags(cluster *clusterv1.Cluster) map[string]string {
+	if cluster.Spec.Topology == nil {
+		return nil
+	}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		if variable.Name != CloudTagsVariable {
+			continue
+		}
+		var tags map[string]string
+		if err := json.Unmarshal(variable.Value.Raw, &tags); err == nil && len(tags) > 0 {
+			return tags
+		}
+	}
+	return nil
+}
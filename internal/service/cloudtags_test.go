@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func TestEnhancedClusterService_GetCloudTags(t *testing.T) {
+	ctx := context.Background()
+
+	awsCluster := &unstructured.Unstructured{}
+	awsCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	awsCluster.SetKind("AWSCluster")
+	awsCluster.SetName("tagged-abc12")
+	awsCluster.SetNamespace(testNamespace)
+	awsCluster.Object["spec"] = map[string]interface{}{
+		"additionalTags": map[string]interface{}{"cost-center": "1234"},
+	}
+
+	withInfrastructureRef := func(cluster *clusterv1.Cluster) *clusterv1.Cluster {
+		cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+			Kind:       "AWSCluster",
+			Name:       "tagged-abc12",
+		}
+		return cluster
+	}
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		objects []client.Object
+		want    *api.CloudTags
+	}{
+		{
+			name: "read from infrastructure cluster",
+			cluster: withInfrastructureRef(withTopologyVariables(createTestCluster("tagged", testNamespace, clusterv1.ClusterPhaseProvisioned),
+				map[string]string{"region": "us-west-2"})),
+			objects: []client.Object{awsCluster},
+			want: &api.CloudTags{
+				Tags: map[string]string{
+					"cost-center": "1234",
+					"sigs.k8s.io/cluster-api-provider-aws/cluster/tagged": "owned",
+				},
+				Source: "infrastructure",
+			},
+		},
+		{
+			name: "infrastructure cluster not created yet",
+			cluster: func() *clusterv1.Cluster {
+				cluster := withInfrastructureRef(createTestCluster("tagged", testNamespace, clusterv1.ClusterPhaseProvisioning))
+				cluster.Spec.Topology.Variables = []clusterv1.ClusterVariable{
+					{Name: CloudTagsVariable, Value: apiextensionsv1.JSON{Raw: []byte(`{"team":"platform"}`)}},
+				}
+				return cluster
+			}(),
+			want: &api.CloudTags{Tags: map[string]string{"team": "platform"}, Source: "variables"},
+		},
+		{
+			name:    "no tags",
+			cluster: createTestCluster("untagged", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, tt.objects...)
+			assert.Equal(t, tt.want, svc.getCloudTags(ctx, tt.cluster))
+		})
+	}
+
+	t.Run("get cluster", func(t *testing.T) {
+		cluster := withInfrastructureRef(createTestCluster("tagged", testNamespace, clusterv1.ClusterPhaseProvisioned))
+		svc, _ := setupEnhancedTestService(t, cluster, awsCluster)
+
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "tagged"})
+		require.NoError(t, err)
+		require.NotNil(t, output.Cluster.CloudTags)
+		assert.Equal(t, "1234", output.Cluster.CloudTags.Tags["cost-center"])
+	})
+}
@@ -14,6 +14,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
 	"github.com/capi-mcp/capi-mcp-server/internal/kube"
 	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
 )
@@ -41,23 +42,21 @@ func (s *ClusterService) ListClusters(ctx context.Context) (*api.ListClustersOut
 		return nil, fmt.Errorf("failed to list clusters: %w", err)
 	}
 
+	now := time.Now()
 	summaries := make([]api.ClusterSummary, 0, len(clusters.Items))
 	for _, cluster := range clusters.Items {
 		summary := api.ClusterSummary{
 			Name:              cluster.Name,
 			Namespace:         cluster.Namespace,
-			Status:            string(cluster.Status.Phase),
+			Provider:          clusterProvider(&cluster),
+			Region:            clusterRegion(&cluster),
+			Endpoint:          clusterEndpoint(&cluster),
+			Status:            api.ClusterStatusFromPhase(cluster.Status.Phase),
 			CreatedAt:         cluster.CreationTimestamp.Format(time.RFC3339),
+			Age:               clusterAge(&cluster, now),
 			KubernetesVersion: cluster.Spec.Topology.Version,
 		}
 
-		// Determine provider from labels or annotations
-		if provider, ok := cluster.Labels["cluster.x-k8s.io/provider"]; ok {
-			summary.Provider = provider
-		} else {
-			summary.Provider = "unknown"
-		}
-
 		// Get node count (approximate from MachineDeployments)
 		summary.NodeCount = s.estimateNodeCount(&cluster)
 
@@ -79,23 +78,14 @@ func (s *ClusterService) GetCluster(ctx context.Context, input api.GetClusterInp
 	details := api.ClusterDetails{
 		Name:              cluster.Name,
 		Namespace:         cluster.Namespace,
-		Status:            string(cluster.Status.Phase),
+		Status:            api.ClusterStatusFromPhase(cluster.Status.Phase),
 		CreatedAt:         cluster.CreationTimestamp.Format(time.RFC3339),
 		KubernetesVersion: cluster.Spec.Topology.Version,
 		Endpoint:          cluster.Spec.ControlPlaneEndpoint.Host,
 	}
 
-	// Determine provider
-	if provider, ok := cluster.Labels["cluster.x-k8s.io/provider"]; ok {
-		details.Provider = provider
-	} else {
-		details.Provider = "unknown"
-	}
-
-	// Determine region (AWS-specific)
-	if region, ok := cluster.Labels["topology.cluster.x-k8s.io/region"]; ok {
-		details.Region = region
-	}
+	details.Provider = clusterProvider(cluster)
+	details.Region = clusterRegion(cluster)
 
 	// Convert conditions
 	details.Conditions = make([]api.ClusterCondition, 0, len(cluster.Status.Conditions))
@@ -183,6 +173,15 @@ func (s *ClusterService) CreateCluster(ctx context.Context, input api.CreateClus
 		cluster.Spec.Topology.Variables = variables
 	}
 
+	if input.DryRun {
+		return &api.CreateClusterOutput{
+			ClusterName: input.ClusterName,
+			Status:      api.ClusterStatusPending,
+			Message:     fmt.Sprintf("Dry run: cluster '%s' would be created from template '%s'", input.ClusterName, input.TemplateName),
+			DryRun:      true,
+		}, nil
+	}
+
 	// Create the cluster (skip if no kube client for testing)
 	if s.kubeClient != nil {
 		if err := s.kubeClient.CreateCluster(ctx, cluster); err != nil {
@@ -192,7 +191,7 @@ func (s *ClusterService) CreateCluster(ctx context.Context, input api.CreateClus
 		s.logger.Info("cluster creation initiated", "cluster", input.ClusterName)
 
 		// Wait for cluster to be ready
-		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+		waitCtx, cancel := budget.Sub(ctx, 10*time.Minute)
 		defer cancel()
 
 		err := s.kubeClient.WaitForClusterReady(waitCtx, input.ClusterName, 10*time.Minute)
@@ -200,7 +199,7 @@ func (s *ClusterService) CreateCluster(ctx context.Context, input api.CreateClus
 			s.logger.Error("cluster creation failed or timed out", "cluster", input.ClusterName, "error", err)
 			return &api.CreateClusterOutput{
 				ClusterName: input.ClusterName,
-				Status:      "failed",
+				Status:      api.ClusterStatusFailed,
 				Message:     fmt.Sprintf("Cluster creation failed: %v", err),
 			}, nil
 		}
@@ -213,7 +212,7 @@ func (s *ClusterService) CreateCluster(ctx context.Context, input api.CreateClus
 
 	return &api.CreateClusterOutput{
 		ClusterName: input.ClusterName,
-		Status:      "provisioned",
+		Status:      api.ClusterStatusReady,
 		Message:     "Cluster created successfully",
 	}, nil
 }
@@ -234,7 +233,7 @@ func (s *ClusterService) DeleteCluster(ctx context.Context, input api.DeleteClus
 	s.logger.Info("cluster deletion initiated", "cluster", input.ClusterName)
 
 	// Wait for cluster to be deleted
-	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	waitCtx, cancel := budget.Sub(ctx, 10*time.Minute)
 	defer cancel()
 
 	err = s.kubeClient.WaitForClusterDeleted(waitCtx, input.ClusterName, 10*time.Minute)
@@ -324,7 +323,7 @@ func (s *ClusterService) GetClusterNodes(ctx context.Context, input api.GetClust
 	}
 
 	// Create workload client
-	workloadClient, err := kube.NewWorkloadClientFromKubeconfig([]byte(kubeconfigOutput.Kubeconfig))
+	workloadClient, err := s.kubeClient.WorkloadClient([]byte(kubeconfigOutput.Kubeconfig))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workload client: %w", err)
 	}
@@ -344,6 +343,7 @@ func (s *ClusterService) GetClusterNodes(ctx context.Context, input api.GetClust
 			Roles:          getNodeRoles(&node),
 			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
 			Labels:         node.Labels,
+			CreatedAt:      node.CreationTimestamp.Format(time.RFC3339),
 		}
 
 		// Get addresses
@@ -386,6 +386,11 @@ func (s *ClusterService) estimateNodeCount(cluster *clusterv1.Cluster) int {
 				count += int(*md.Replicas)
 			}
 		}
+		for _, mp := range cluster.Spec.Topology.Workers.MachinePools {
+			if mp.Replicas != nil {
+				count += int(*mp.Replicas)
+			}
+		}
 		return count
 	}
 	return 0
@@ -4,27 +4,116 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
 	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/accounting"
+	"github.com/capi-mcp/capi-mcp-server/internal/advisory"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
 	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/events"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
 	"github.com/capi-mcp/capi-mcp-server/internal/kube"
 	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/snapshot"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
 	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
 )
 
+// KubernetesVersionsAnnotation declares the Kubernetes versions a ClusterClass
+// supports as a constraint such as ">=v1.28.0 <v1.32"; create_cluster rejects
+// versions outside it.
+const KubernetesVersionsAnnotation = "capi-mcp.io/kubernetes-versions"
+
+// Provider validation may call the provider's API, so it is bounded to a
+// share of the tool call's budget that leaves time for creating the cluster.
+const (
+	providerValidationShare   = 0.25
+	providerValidationTimeout = 30 * time.Second
+)
+
+// defaultListConcurrency is how many clusters are summarized at once when
+// listing clusters, unless configured otherwise.
+const defaultListConcurrency = 10
+
 // EnhancedClusterService handles CAPI cluster operations with enhanced error handling and logging.
 type EnhancedClusterService struct {
 	kubeClient      *kube.Client
 	logger          *logging.Logger
 	providerManager *provider.ProviderManager
+	waitStrategy    WaitStrategy
+	pollInterval    time.Duration
+	upgradeOptions  ProviderUpgradeOptions
+	runClusterctl   clusterctlRunner
+	fetchManifest   manifestFetcher
+	history         history.Store
+	accounting      *accounting.Ledger
+	snapshots       snapshot.Store
+	statusIndex     *statusIndex
+
+	lifecycleMetrics LifecycleMetrics
+	lifecycleTimeout time.Duration
+
+	cidrOverlapPolicy CIDROverlapPolicy
+
+	diagnosticImage     string
+	credentialVerifiers map[string]CredentialVerifier
+
+	etcdBackupImage      string
+	etcdBackupToolsImage string
+
+	smokeTestImage string
+
+	temporaryAccessMaxDuration time.Duration
+
+	asyncOperations async.Store
+	events          *events.Broker
+	background      context.Context
+
+	presets                   map[string]VariablePreset
+	variableDefaults          []VariableDefaults
+	namespaceVariableDefaults map[string][]VariableDefaults
+	regionPolicy              *validation.RegionPolicy
+	instanceTypeLimits        InstanceTypeLimits
+	protectedClusterNames     validation.ProtectedClusterNames
+	deletionRetention         time.Duration
+
+	creationLimits CreationLimits
+	creationMu     sync.Mutex
+
+	inventory        InventoryOptions
+	environmentLabel string
+
+	advisor *advisory.Advisor
+
+	listConcurrency int
+	nodeCountCache  bool
+
+	workloadHealthMetrics WorkloadHealthMetrics
+	workloadHealthOptions WorkloadHealthOptions
+
+	readinessGate      ReadinessGate
+	readiness          *readinessCache
+	conformanceOptions ConformanceOptions
+	runSonobuoy        sonobuoyRunner
+
+	templateRegistry      templateRegistry
+	templateCatalogs      []templateCatalog
+	templateCompatibility []TemplateCompatibility
 }
 
 // NewEnhancedClusterService creates a new cluster service with enhanced features.
@@ -33,6 +122,12 @@ func NewEnhancedClusterService(kubeClient *kube.Client, logger *logging.Logger,
 		kubeClient:      kubeClient,
 		logger:          logger.WithComponent("cluster-service"),
 		providerManager: providerManager,
+		waitStrategy:    WaitStrategyWatch,
+		pollInterval:    defaultPollInterval,
+		fetchManifest:   fetchManifestHTTP,
+		advisor:         advisory.New(nil, ""),
+		listConcurrency: defaultListConcurrency,
+		background:      context.Background(),
 	}
 }
 
@@ -47,8 +142,14 @@ func (s *EnhancedClusterService) ListClusters(ctx context.Context) (*api.ListClu
 		return &api.ListClustersOutput{Clusters: []api.ClusterSummary{}}, nil
 	}
 
+	// Serve from the background status index while it is within its staleness bound
+	if output, ok := s.indexedClusters(s.kubeClient.Namespace(ctx)); ok {
+		logger.Debug("Listed clusters from status index", "count", len(output.Clusters), "last_updated", output.LastUpdated)
+		return output, nil
+	}
+
 	// List clusters with timeout
-	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	listCtx, cancel := budget.Sub(ctx, 30*time.Second)
 	defer cancel()
 
 	clusters, err := s.kubeClient.ListClusters(listCtx)
@@ -68,38 +169,124 @@ func (s *EnhancedClusterService) ListClusters(ctx context.Context) (*api.ListClu
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
 	}
 
-	summaries := make([]api.ClusterSummary, 0, len(clusters.Items))
-	for _, cluster := range clusters.Items {
-		summary := api.ClusterSummary{
-			Name:              cluster.Name,
-			Namespace:         cluster.Namespace,
-			Status:            s.normalizeClusterStatus(cluster.Status.Phase),
-			CreatedAt:         cluster.CreationTimestamp.Format(time.RFC3339),
-			KubernetesVersion: "",
-			NodeCount:         0,
+	now := time.Now()
+	summaries, err := s.summarizeClusters(listCtx, clusters.Items, now)
+	if err != nil {
+		logger.WithError(err).Error("Failed to summarize clusters")
+		if errors.IsTimeout(err) {
+			return nil, errors.Wrap(err, errors.CodeTimeout, "timeout counting cluster nodes")
 		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to summarize clusters")
+	}
 
-		// Extract Kubernetes version safely
-		if cluster.Spec.Topology != nil {
-			summary.KubernetesVersion = cluster.Spec.Topology.Version
-		}
+	logger.Info("Listed clusters successfully", "count", len(summaries))
+	return &api.ListClustersOutput{Clusters: summaries, LastUpdated: now.UTC().Format(time.RFC3339)}, nil
+}
 
-		// Count nodes by listing MachineDeployments
-		nodeCount, err := s.getClusterNodeCount(listCtx, cluster.Name, cluster.Namespace)
-		if err != nil {
-			logger.WithError(err).Warn("Failed to get node count for cluster",
-				logging.FieldClusterName, cluster.Name,
-			)
-			// Continue without node count
-		} else {
-			summary.NodeCount = int(nodeCount)
+// summarizeCluster builds the list_clusters summary of a cluster, including
+// its node counts. Failing to count nodes is logged and leaves the counts at zero.
+func (s *EnhancedClusterService) summarizeCluster(ctx context.Context, cluster *clusterv1.Cluster, now time.Time) api.ClusterSummary {
+	summary := api.ClusterSummary{
+		Name:              cluster.Name,
+		Namespace:         cluster.Namespace,
+		Provider:          clusterProvider(cluster),
+		Region:            clusterRegion(cluster),
+		Environment:       s.clusterEnvironment(cluster),
+		Endpoint:          clusterEndpoint(cluster),
+		CreatedAt:         cluster.CreationTimestamp.Format(time.RFC3339),
+		Age:               clusterAge(cluster, now),
+		KubernetesVersion: "",
+		NodeCount:         0,
+		LastUpdated:       now.UTC().Format(time.RFC3339),
+		ResourceVersion:   cluster.ResourceVersion,
+	}
+
+	// Extract Kubernetes version safely
+	if cluster.Spec.Topology != nil {
+		summary.KubernetesVersion = cluster.Spec.Topology.Version
+	}
+	if advice, ok := s.advisor.Check(summary.KubernetesVersion, now); ok {
+		summary.Advisory = toAPIVersionAdvisory(advice)
+	}
+
+	// Count nodes from control plane Machines, MachineDeployments and MachinePools
+	nodeCount, err := s.getClusterNodeCount(ctx, cluster.Name, cluster.Namespace)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get node count for cluster",
+			logging.FieldClusterName, cluster.Name,
+		)
+		// Continue without node count
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf("node counts unavailable: %v", err))
+	} else {
+		summary.NodeCount = int(nodeCount.Desired)
+		summary.ReadyNodeCount = int(nodeCount.Ready)
+	}
+
+	status, statusWarnings := s.clusterStatus(ctx, cluster)
+	summary.Status = status
+	summary.Warnings = append(summary.Warnings, statusWarnings...)
+	summary.Warnings = append(summary.Warnings, pendingDeletionWarning(cluster)...)
+
+	return summary
+}
+
+// summarizeClusters summarizes clusters in order, counting the nodes of up to
+// listConcurrency clusters at once so that listing large fleets is not
+// bounded by the round trips of each cluster in turn. It stops starting
+// summaries once ctx is done and returns its error.
+func (s *EnhancedClusterService) summarizeClusters(ctx context.Context, clusters []clusterv1.Cluster, now time.Time) ([]api.ClusterSummary, error) {
+	summaries := make([]api.ClusterSummary, len(clusters))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(s.listConcurrency, 1))
+
+	for i := range clusters {
+		if groupCtx.Err() != nil {
+			break
 		}
+		group.Go(func() error {
+			summaries[i] = s.summarizeCluster(groupCtx, &clusters[i], now)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// SetListConcurrency sets how many clusters list_clusters counts the nodes of
+// at once.
+func (s *EnhancedClusterService) SetListConcurrency(concurrency int) {
+	s.listConcurrency = concurrency
+}
 
-		summaries = append(summaries, summary)
+// SetNodeCountCache sets whether RunNodeCountCache starts the informer cache
+// node counts are read from.
+func (s *EnhancedClusterService) SetNodeCountCache(enabled bool) {
+	s.nodeCountCache = enabled
+}
+
+// RunNodeCountCache starts the informer cache node counts are read from, which
+// runs until ctx is cancelled. It returns immediately when the cache is
+// disabled. Until the cache has synced, or if it fails to start, nodes are
+// counted from the API server.
+func (s *EnhancedClusterService) RunNodeCountCache(ctx context.Context) {
+	if !s.nodeCountCache || s.kubeClient == nil {
+		return
 	}
 
-	logger.Info("Listed clusters successfully", "count", len(summaries))
-	return &api.ListClustersOutput{Clusters: summaries}, nil
+	logger := s.logger.WithContext(ctx).WithOperation("NodeCountCache")
+	started := time.Now()
+	if err := s.kubeClient.StartCache(ctx); err != nil {
+		if ctx.Err() == nil {
+			logger.WithError(err).Warn("Failed to start the node count cache, counting nodes from the API server")
+		}
+		return
+	}
+	logger.Info("Node count cache synced", "duration", time.Since(started))
 }
 
 // GetCluster returns detailed information about a specific cluster.
@@ -122,7 +309,7 @@ func (s *EnhancedClusterService) GetCluster(ctx context.Context, input api.GetCl
 	}
 
 	// Get cluster with timeout
-	getCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	getCtx, cancel := budget.Sub(ctx, 30*time.Second)
 	defer cancel()
 
 	cluster, err := s.kubeClient.GetClusterByName(getCtx, input.ClusterName)
@@ -140,7 +327,14 @@ func (s *EnhancedClusterService) GetCluster(ctx context.Context, input api.GetCl
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
 	}
 
-	// Build response
+	// Build response, reporting the details that could not be retrieved
+	nodePools, poolWarnings := s.getNodePools(getCtx, cluster)
+	controlPlane, controlPlaneWarnings := s.getControlPlaneStatus(getCtx, cluster)
+	status, statusWarnings := s.clusterStatus(getCtx, cluster)
+	providerStatus, err := s.getProviderStatus(getCtx, cluster)
+	if err != nil {
+		statusWarnings = append(statusWarnings, fmt.Sprintf("provider status unavailable: %v", err))
+	}
 	output := &api.GetClusterOutput{
 		Cluster: api.ClusterDetails{
 			Name:              cluster.Name,
@@ -148,27 +342,34 @@ func (s *EnhancedClusterService) GetCluster(ctx context.Context, input api.GetCl
 			Provider:          s.getProvider(cluster),
 			Region:            s.getRegion(cluster),
 			KubernetesVersion: s.getKubernetesVersion(cluster),
-			Status:            s.normalizeClusterStatus(cluster.Status.Phase),
+			Status:            status,
 			CreatedAt:         cluster.CreationTimestamp.Format(time.RFC3339),
 			Endpoint:          s.getEndpoint(cluster),
-			NodePools:         s.getNodePools(getCtx, cluster),
+			NodePools:         nodePools,
+			ControlPlane:      controlPlane,
 			Conditions:        s.getConditions(cluster),
 			InfrastructureRef: s.getInfrastructureRef(cluster),
+			CloudTags:         s.getCloudTags(getCtx, cluster),
+			Identity:          s.getIdentity(getCtx, cluster),
+			Bastion:           s.getBastion(getCtx, cluster),
+			ProviderStatus:    providerStatus,
 		},
+		Warnings: slices.Concat(poolWarnings, controlPlaneWarnings, statusWarnings, pendingDeletionWarning(cluster)),
 	}
 
-	// Provider-specific status can be included in the InfrastructureRef field if needed
-
 	logger.Info("Retrieved cluster successfully")
 	return output, nil
 }
 
 // CreateCluster creates a new cluster from a template.
 func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.CreateClusterInput) (*api.CreateClusterOutput, error) {
+	startedAt := time.Now()
 	logger := s.logger.WithContext(ctx).WithOperation("CreateCluster").WithCluster(input.ClusterName, "")
 	logger.Info("Creating new cluster",
 		"template", input.TemplateName,
 		"kubernetes_version", input.KubernetesVersion,
+		"preset", input.Preset,
+		"dry_run", input.DryRun,
 	)
 
 	// Validate input
@@ -176,6 +377,20 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 		logger.WithError(err).Error("Invalid input")
 		return nil, err
 	}
+	if err := s.protectedClusterNames.ValidateClusterName("creation", input.ClusterName); err != nil {
+		logger.WithError(err).Error("Cluster name is protected")
+		return nil, err
+	}
+
+	// Expand the preset before anything looks at the variables
+	if input.Preset != "" {
+		variables, err := s.ApplyPreset(input.Preset, input.Variables)
+		if err != nil {
+			logger.WithError(err).Error("Invalid preset")
+			return nil, err
+		}
+		input.Variables = variables
+	}
 
 	// Check if kube client is available
 	if s.kubeClient == nil {
@@ -184,12 +399,35 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 		return nil, err
 	}
 
-	// Extract provider name and validate with provider
+	// Extract provider name, apply the server's default variables for it and
+	// validate with provider
 	providerName := s.extractProviderName(input.Variables, input.TemplateName)
+	variables, err := s.applyVariableDefaults(s.kubeClient.Namespace(ctx), providerName, input.TemplateName, input.Variables)
+	if err != nil {
+		logger.WithError(err).Error("Default variables not satisfied")
+		return nil, err
+	}
+	input.Variables = variables
+
+	// Enforce the region policy before anything is looked up in the region
+	region, _ := input.Variables[regionVariable].(string)
+	if err := s.checkRegionPolicy(ctx, region); err != nil {
+		logger.WithError(err).Error("Region not permitted", "region", region)
+		return nil, err
+	}
+
+	if err := s.checkInstanceTypes(input.Variables); err != nil {
+		logger.WithError(err).Error("Instance type not permitted")
+		return nil, err
+	}
+
 	if s.providerManager != nil {
 		if prov, exists := s.providerManager.GetProvider(providerName); exists {
 			logger.Debug("Validating cluster configuration with provider", "provider", providerName)
-			if err := prov.ValidateClusterConfig(ctx, input.Variables); err != nil {
+			validateCtx, cancel := budget.Share(ctx, providerValidationShare, providerValidationTimeout)
+			err := prov.ValidateClusterConfig(validateCtx, input.Variables)
+			cancel()
+			if err != nil {
 				logger.WithError(err).Error("Provider validation failed")
 				return nil, errors.Wrap(err, errors.CodeProviderValidation, "provider validation failed")
 			}
@@ -206,6 +444,65 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
 	}
 
+	// Resolve the node image the template expects rather than requiring
+	// callers to hardcode image IDs
+	nodeImage, err := s.resolveNodeImageVariable(ctx, clusterClass, input, providerName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to resolve node image")
+		return nil, err
+	}
+	if nodeImage != "" {
+		// Leave the caller's variables untouched
+		input.Variables = maps.Clone(input.Variables)
+		if input.Variables == nil {
+			input.Variables = make(map[string]interface{})
+		}
+		input.Variables[NodeImageVariable] = nodeImage
+		logger.Info("Resolved node image", "image", nodeImage)
+	}
+
+	// Report every missing required variable at once rather than letting the
+	// topology webhook reject them one by one
+	if missing := missingRequiredVariables(clusterClass, input.Variables); len(missing) > 0 {
+		err := missingVariablesError(input.TemplateName, missing)
+		logger.WithError(err).Error("Missing required template variables")
+		return nil, err
+	}
+
+	// Keep the cluster within the configured size limits
+	if err := s.checkClusterSize(ctx, providerName, region, newClusterNodeGroups(clusterClass, input.Variables)); err != nil {
+		logger.WithError(err).Error("Cluster size limit exceeded")
+		return nil, err
+	}
+
+	// Check the AWS identity the cluster is to be managed with
+	if err := s.checkIdentityRef(ctx, input.Variables, s.kubeClient.Namespace(ctx)); err != nil {
+		logger.WithError(err).Error("Invalid cluster identity")
+		return nil, err
+	}
+
+	// Reject Kubernetes versions the template or provider cannot provision
+	if err := s.validateKubernetesVersionSupport(ctx, input.KubernetesVersion, clusterClass, providerName); err != nil {
+		logger.WithError(err).Error("Unsupported Kubernetes version")
+		return nil, err
+	}
+
+	// Hold the template to the requested version and the installed providers
+	if err := s.checkTemplateCompatibility(ctx, clusterClass, input.TemplateVersion); err != nil {
+		logger.WithError(err).Error("Incompatible cluster template")
+		return nil, err
+	}
+
+	// Check requested network CIDRs against existing clusters in the region
+	warnings, err := s.checkCIDROverlaps(ctx, input.ClusterName, input.Variables, providerName)
+	if err != nil {
+		logger.WithError(err).Error("Network CIDR overlap check failed")
+		return nil, err
+	}
+	for _, warning := range warnings {
+		logger.Warn("Network CIDR overlap", "overlap", warning)
+	}
+
 	// Check if cluster already exists
 	existingCluster, err := s.kubeClient.GetClusterByName(ctx, input.ClusterName)
 	if err == nil && existingCluster != nil {
@@ -214,11 +511,44 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 		return nil, err
 	}
 
+	if input.DryRun {
+		logger.Info("Dry run of cluster creation")
+		return &api.CreateClusterOutput{
+			ClusterName: input.ClusterName,
+			Status:      api.ClusterStatusPending,
+			Message: fmt.Sprintf("Dry run: cluster '%s' would be created from template '%s' with Kubernetes %s",
+				input.ClusterName, input.TemplateName, input.KubernetesVersion),
+			Warnings:  warnings,
+			NodeImage: nodeImage,
+			DryRun:    true,
+		}, nil
+	}
+
 	// Create cluster resource
 	cluster := s.buildClusterResource(input, clusterClass)
+	s.recordTemplateGenerations(ctx, cluster, clusterClass)
+
+	// Keep the number of clusters provisioning at once within the limits
+	release, err := s.reserveCreationSlot(ctx, s.kubeClient.Namespace(ctx))
+	if errors.GetErrorCode(err) == errors.CodeRateLimited && s.creationLimits.Queue && s.asyncOperations != nil {
+		logger.Info("Queueing cluster creation", "reason", errors.GetUserMessage(err))
+		output, err := s.queueClusterCreation(ctx, cluster, input, providerName, err)
+		if err != nil {
+			logger.WithError(err).Error("Failed to queue cluster creation")
+			return nil, err
+		}
+		output.Warnings = warnings
+		output.NodeImage = nodeImage
+		return output, nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("Cluster creation limit reached")
+		return nil, err
+	}
 
 	logger.Info("Creating cluster resource in Kubernetes")
 	err = s.kubeClient.CreateCluster(ctx, cluster)
+	release()
 	if err != nil {
 		logger.WithError(err).Error("Failed to create cluster resource")
 
@@ -228,6 +558,7 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to create cluster")
 	}
+	s.trackProvisioning(ctx, cluster.Name, providerName, input.TemplateName, startedAt)
 
 	// Wait for initial status
 	logger.Debug("Waiting for cluster initial status")
@@ -238,10 +569,13 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 		finalCluster = cluster
 	}
 
+	status, statusWarnings := s.clusterStatus(ctx, finalCluster)
 	output := &api.CreateClusterOutput{
 		ClusterName: finalCluster.Name,
-		Status:      s.normalizeClusterStatus(finalCluster.Status.Phase),
+		Status:      status,
 		Message:     fmt.Sprintf("Cluster '%s' creation initiated successfully", input.ClusterName),
+		Warnings:    append(warnings, statusWarnings...),
+		NodeImage:   nodeImage,
 	}
 
 	logger.Info("Cluster created successfully",
@@ -254,27 +588,6 @@ func (s *EnhancedClusterService) CreateCluster(ctx context.Context, input api.Cr
 
 // Helper methods
 
-// normalizeClusterStatus converts CAPI phase to a consistent status string
-func (s *EnhancedClusterService) normalizeClusterStatus(phase string) string {
-	if phase == "" {
-		return "Unknown"
-	}
-
-	// Normalize common phases
-	switch strings.ToLower(phase) {
-	case "provisioning":
-		return "Provisioning"
-	case "provisioned":
-		return "Ready"
-	case "failed":
-		return "Failed"
-	case "deleting":
-		return "Deleting"
-	default:
-		return phase
-	}
-}
-
 // validateCreateClusterInput validates the create cluster input
 func (s *EnhancedClusterService) validateCreateClusterInput(input api.CreateClusterInput) error {
 	if input.ClusterName == "" {
@@ -297,6 +610,49 @@ func (s *EnhancedClusterService) validateCreateClusterInput(input api.CreateClus
 	return nil
 }
 
+// validateKubernetesVersionSupport checks a requested Kubernetes version against
+// the ranges declared by the ClusterClass and the compatibility matrix, and the
+// versions the provider supports.
+func (s *EnhancedClusterService) validateKubernetesVersionSupport(ctx context.Context, kubernetesVersion string, clusterClass *clusterv1.ClusterClass, providerName string) error {
+	validator := validation.NewValidator()
+	if err := validator.ValidateKubernetesVersion(kubernetesVersion); err != nil {
+		return err
+	}
+
+	requirements, err := s.templateRequirements(clusterClass)
+	if err != nil {
+		return err
+	}
+	for _, constraint := range requirements.kubernetes {
+		if err := validator.ValidateKubernetesVersionRange(kubernetesVersion, constraint); err != nil {
+			return errors.Wrap(err, errors.GetErrorCode(err),
+				fmt.Sprintf("cluster template '%s' does not support kubernetes version %s (supported: %s)", clusterClass.Name, kubernetesVersion, constraint))
+		}
+	}
+
+	if s.providerManager == nil {
+		return nil
+	}
+	if _, exists := s.providerManager.GetProvider(providerName); !exists {
+		return nil
+	}
+
+	versionsCtx, cancel := budget.Share(ctx, providerValidationShare, providerValidationTimeout)
+	defer cancel()
+	supported, err := s.providerManager.GetSupportedKubernetesVersions(versionsCtx, providerName)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeProviderError, "failed to get provider supported kubernetes versions")
+	}
+	if len(supported) == 0 {
+		return nil
+	}
+	if err := validator.ValidateKubernetesVersionSupported(kubernetesVersion, supported); err != nil {
+		return errors.Wrap(err, errors.CodeInvalidInput,
+			fmt.Sprintf("provider '%s' cannot provision kubernetes version %s: %s", providerName, kubernetesVersion, errors.GetUserMessage(err)))
+	}
+	return nil
+}
+
 // isValidClusterName checks if the cluster name is a valid DNS subdomain
 func isValidClusterName(name string) bool {
 	if len(name) == 0 || len(name) > 63 {
@@ -322,24 +678,59 @@ func isAlphaNumeric(ch byte) bool {
 	return (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9')
 }
 
-// getClusterNodeCount counts the total nodes in a cluster
-func (s *EnhancedClusterService) getClusterNodeCount(ctx context.Context, clusterName, namespace string) (int32, error) {
-	machineDeployments, err := s.kubeClient.ListMachineDeployments(ctx, clusterName)
+// nodeCounts holds the desired and ready node counts for a cluster.
+type nodeCounts struct {
+	Desired int32
+	Ready   int32
+}
+
+// getClusterNodeCount counts the desired and ready nodes in a cluster from
+// control plane Machines, MachineDeployments and MachinePools, read from the
+// informer cache once it has synced.
+func (s *EnhancedClusterService) getClusterNodeCount(ctx context.Context, clusterName, namespace string) (nodeCounts, error) {
+	var counts nodeCounts
+	ctx = kube.ContextWithNamespace(ctx, namespace)
+	kubeClient := s.kubeClient.Cached()
+
+	controlPlaneMachines, err := kubeClient.ListControlPlaneMachines(ctx, clusterName)
 	if err != nil {
-		return 0, err
+		return counts, err
+	}
+	for _, machine := range controlPlaneMachines.Items {
+		if machine.DeletionTimestamp != nil {
+			continue
+		}
+		counts.Desired++
+		if isMachineReady(&machine) {
+			counts.Ready++
+		}
 	}
 
-	var totalNodes int32
+	machineDeployments, err := kubeClient.ListMachineDeployments(ctx, clusterName)
+	if err != nil {
+		return counts, err
+	}
 	for _, md := range machineDeployments.Items {
-		if md.Spec.Replicas != nil {
-			totalNodes += *md.Spec.Replicas
-		}
+		counts.Desired += ptrValue(md.Spec.Replicas)
+		counts.Ready += md.Status.ReadyReplicas
+	}
+
+	machinePools, err := kubeClient.ListMachinePools(ctx, clusterName)
+	if err != nil {
+		return counts, err
+	}
+	for _, mp := range machinePools.Items {
+		counts.Desired += ptrValue(mp.Spec.Replicas)
+		counts.Ready += mp.Status.ReadyReplicas
 	}
 
-	// Add control plane nodes (assuming single control plane for now)
-	totalNodes += 1
+	return counts, nil
+}
 
-	return totalNodes, nil
+// isMachineReady reports whether a Machine has joined the cluster as a running node.
+func isMachineReady(machine *clusterv1.Machine) bool {
+	return machine.Status.NodeRef != nil &&
+		machine.Status.Phase == string(clusterv1.MachinePhaseRunning)
 }
 
 // DeleteCluster deletes a cluster with enhanced error handling.
@@ -353,6 +744,10 @@ func (s *EnhancedClusterService) DeleteCluster(ctx context.Context, input api.De
 		logger.WithError(err).Error("Invalid input")
 		return nil, err
 	}
+	if err := s.protectedClusterNames.ValidateClusterName("deletion", input.ClusterName); err != nil {
+		logger.WithError(err).Error("Cluster name is protected")
+		return nil, err
+	}
 
 	// Check if kube client is available
 	if s.kubeClient == nil {
@@ -362,7 +757,7 @@ func (s *EnhancedClusterService) DeleteCluster(ctx context.Context, input api.De
 	}
 
 	// Check if cluster exists first
-	deleteCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	deleteCtx, cancel := budget.Sub(ctx, 30*time.Second)
 	defer cancel()
 
 	cluster, err := s.kubeClient.GetClusterByName(deleteCtx, input.ClusterName)
@@ -374,8 +769,28 @@ func (s *EnhancedClusterService) DeleteCluster(ctx context.Context, input api.De
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to verify cluster exists")
 	}
 
+	// Deleting a cluster others depend on is allowed, but reported
+	var warnings []string
+	if dependents, err := s.listClusterDependents(deleteCtx); err != nil {
+		logger.WithError(err).Warn("Failed to find the clusters depending on the cluster")
+	} else if names := dependents[cluster.Name]; len(names) > 0 {
+		warnings = append(warnings, dependentsWarning(cluster.Name, names))
+		logger.Warn("Deleting a cluster other clusters depend on", "dependents", names)
+	}
+
+	// Pause the cluster until the retention window ends, if deletions are
+	// retained, so undelete_cluster can still cancel the deletion
+	if s.deletionRetention > 0 && cluster.DeletionTimestamp == nil {
+		return s.softDeleteCluster(deleteCtx, cluster, warnings)
+	}
+	if err := s.resumeForDeletion(deleteCtx, cluster); err != nil {
+		logger.WithError(err).Error("Failed to resume soft-deleted cluster")
+		return nil, err
+	}
+
 	// Delete the cluster
 	logger.Info("Deleting cluster resource from Kubernetes")
+	startedAt := time.Now()
 	if err := s.kubeClient.DeleteCluster(deleteCtx, input.ClusterName); err != nil {
 		logger.WithError(err).Error("Failed to delete cluster resource")
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to delete cluster")
@@ -383,23 +798,25 @@ func (s *EnhancedClusterService) DeleteCluster(ctx context.Context, input api.De
 
 	// Wait for deletion to complete (with timeout)
 	logger.Debug("Waiting for cluster deletion to complete")
-	waitCtx, waitCancel := context.WithTimeout(ctx, 10*time.Minute)
+	waitCtx, waitCancel := budget.Sub(ctx, 10*time.Minute)
 	defer waitCancel()
 
 	err = s.waitForClusterDeleted(waitCtx, input.ClusterName, cluster.Namespace)
 	if err != nil {
 		logger.WithError(err).Warn("Failed to wait for cluster deletion completion")
+		s.trackDeletion(ctx, cluster, startedAt)
 		// Return success anyway since deletion was initiated
-		return &api.DeleteClusterOutput{
-			Status:  "deleting",
-			Message: fmt.Sprintf("Cluster '%s' deletion initiated (may still be in progress)", input.ClusterName),
-		}, nil
+		output := s.pendingDeletion(ctx, cluster)
+		output.Warnings = warnings
+		return output, nil
 	}
 
+	s.observeDeletion(cluster, startedAt)
 	logger.Info("Cluster deleted successfully")
 	return &api.DeleteClusterOutput{
-		Status:  "deleted",
-		Message: fmt.Sprintf("Cluster '%s' deleted successfully", input.ClusterName),
+		Status:   "deleted",
+		Message:  fmt.Sprintf("Cluster '%s' deleted successfully", input.ClusterName),
+		Warnings: warnings,
 	}, nil
 }
 
@@ -437,13 +854,13 @@ func (s *EnhancedClusterService) ScaleCluster(ctx context.Context, input api.Sca
 		return nil, err
 	}
 
-	// Get MachineDeployment with timeout
-	scaleCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	// Resolve the node pool with timeout; it may be a MachineDeployment or a MachinePool
+	scaleCtx, cancel := budget.Sub(ctx, 30*time.Second)
 	defer cancel()
 
-	md, err := s.kubeClient.GetMachineDeployment(scaleCtx, input.ClusterName, input.NodePoolName)
+	pool, err := s.getScalableNodePool(scaleCtx, input.ClusterName, input.NodePoolName)
 	if err != nil {
-		logger.WithError(err).Error("Failed to get MachineDeployment")
+		logger.WithError(err).Error("Failed to get node pool")
 		if apierrors.IsNotFound(err) {
 			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("node pool '%s' not found in cluster '%s'", input.NodePoolName, input.ClusterName))
 		}
@@ -452,8 +869,8 @@ func (s *EnhancedClusterService) ScaleCluster(ctx context.Context, input api.Sca
 
 	// Get current replica count
 	oldReplicas := int32(0)
-	if md.Spec.Replicas != nil {
-		oldReplicas = *md.Spec.Replicas
+	if pool.replicas != nil {
+		oldReplicas = *pool.replicas
 	}
 
 	// Check for overflow before converting
@@ -472,28 +889,85 @@ func (s *EnhancedClusterService) ScaleCluster(ctx context.Context, input api.Sca
 			Message:     fmt.Sprintf("Node pool '%s' already has %d replicas", input.NodePoolName, input.Replicas),
 			OldReplicas: int(oldReplicas),
 			NewReplicas: input.Replicas,
+			Spot:        pool.spot,
 		}, nil
 	}
 
-	// Update replica count
-	md.Spec.Replicas = &newReplicas
+	if newReplicas > oldReplicas {
+		if err := s.checkScaledClusterSize(scaleCtx, input.ClusterName, pool.kind, input.NodePoolName, newReplicas); err != nil {
+			logger.WithError(err).Error("Cluster size limit exceeded")
+			return nil, err
+		}
+	}
 
-	logger.Info("Updating MachineDeployment replica count",
+	logger.Info("Updating node pool replica count",
+		"kind", pool.kind,
 		"old_replicas", oldReplicas,
 		"new_replicas", newReplicas,
 	)
 
-	if err := s.kubeClient.UpdateMachineDeployment(scaleCtx, md); err != nil {
-		logger.WithError(err).Error("Failed to update MachineDeployment")
+	if err := pool.scale(scaleCtx, newReplicas); err != nil {
+		logger.WithError(err).Error("Failed to update node pool", "kind", pool.kind)
 		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to scale node pool")
 	}
 
+	message := fmt.Sprintf("Scaling node pool '%s' from %d to %d replicas", input.NodePoolName, oldReplicas, newReplicas)
+	if pool.spot && newReplicas > oldReplicas {
+		message += "; new spot instances are launched only while spot capacity is available"
+	}
+
 	logger.Info("Cluster scaling initiated successfully")
 	return &api.ScaleClusterOutput{
 		Status:      "scaling",
-		Message:     fmt.Sprintf("Scaling node pool '%s' from %d to %d replicas", input.NodePoolName, oldReplicas, newReplicas),
+		Message:     message,
 		OldReplicas: int(oldReplicas),
 		NewReplicas: input.Replicas,
+		Spot:        pool.spot,
+	}, nil
+}
+
+// scalableNodePool abstracts over the CAPI resources that back a node pool.
+type scalableNodePool struct {
+	kind     string
+	replicas *int32
+	spot     bool
+	scale    func(ctx context.Context, replicas int32) error
+}
+
+// getScalableNodePool looks up a node pool by name, checking MachineDeployments
+// first and falling back to MachinePools.
+func (s *EnhancedClusterService) getScalableNodePool(ctx context.Context, clusterName, name string) (*scalableNodePool, error) {
+	md, err := s.kubeClient.GetMachineDeployment(ctx, clusterName, name)
+	if err == nil {
+		pool := &scalableNodePool{
+			kind:     "MachineDeployment",
+			replicas: md.Spec.Replicas,
+			scale: func(ctx context.Context, replicas int32) error {
+				md.Spec.Replicas = &replicas
+				return s.kubeClient.UpdateMachineDeployment(ctx, md)
+			},
+		}
+		// The spot flag is informational, so a failed cluster lookup is not fatal
+		if cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName); err == nil {
+			pool.spot = isSpotMachineDeployment(cluster, md)
+		}
+		return pool, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	mp, err := s.kubeClient.GetMachinePool(ctx, clusterName, name)
+	if err != nil {
+		return nil, err
+	}
+	return &scalableNodePool{
+		kind:     "MachinePool",
+		replicas: mp.Spec.Replicas,
+		scale: func(ctx context.Context, replicas int32) error {
+			mp.Spec.Replicas = &replicas
+			return s.kubeClient.UpdateMachinePool(ctx, mp)
+		},
 	}, nil
 }
 
@@ -517,10 +991,10 @@ func (s *EnhancedClusterService) GetClusterKubeconfig(ctx context.Context, input
 	}
 
 	// Get kubeconfig secret with timeout
-	kubeconfigCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	kubeconfigCtx, cancel := budget.Sub(ctx, 30*time.Second)
 	defer cancel()
 
-	secret, err := s.kubeClient.GetKubeconfigSecret(kubeconfigCtx, input.ClusterName)
+	secret, err := s.getKubeconfigSecret(kubeconfigCtx, input.ClusterName)
 	if err != nil {
 		logger.WithError(err).Error("Failed to get kubeconfig secret")
 		if apierrors.IsNotFound(err) {
@@ -550,6 +1024,35 @@ func (s *EnhancedClusterService) GetClusterKubeconfig(ctx context.Context, input
 	}, nil
 }
 
+// getKubeconfigSecret finds the kubeconfig secret for a cluster. Managed control
+// planes publish kubeconfigs under provider-specific names, so those are tried
+// first when the cluster's control plane is managed.
+func (s *EnhancedClusterService) getKubeconfigSecret(ctx context.Context, clusterName string) (*corev1.Secret, error) {
+	secretNames := []string{clusterName + "-kubeconfig"}
+
+	cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Debug("Failed to get cluster, using default kubeconfig secret name",
+			logging.FieldClusterName, clusterName,
+		)
+	} else if managed := s.getManagedControlPlaneProvider(cluster); managed != nil {
+		secretNames = managed.GetKubeconfigSecretNames(clusterName)
+	}
+
+	var lastErr error
+	for _, name := range secretNames {
+		secret, err := s.kubeClient.GetSecret(ctx, name)
+		if err == nil {
+			return secret, nil
+		}
+		lastErr = err
+		if !apierrors.IsNotFound(err) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
 // GetClusterNodes retrieves nodes from a workload cluster with enhanced error handling.
 func (s *EnhancedClusterService) GetClusterNodes(ctx context.Context, input api.GetClusterNodesInput) (*api.GetClusterNodesOutput, error) {
 	logger := s.logger.WithContext(ctx).WithOperation("GetClusterNodes").WithCluster(input.ClusterName, "")
@@ -570,7 +1073,7 @@ func (s *EnhancedClusterService) GetClusterNodes(ctx context.Context, input api.
 	}
 
 	// Get kubeconfig first
-	nodesCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	nodesCtx, cancel := budget.Sub(ctx, 2*time.Minute)
 	defer cancel()
 
 	kubeconfigOutput, err := s.GetClusterKubeconfig(nodesCtx, api.GetClusterKubeconfigInput{
@@ -582,7 +1085,7 @@ func (s *EnhancedClusterService) GetClusterNodes(ctx context.Context, input api.
 	}
 
 	// Create workload client
-	workloadClient, err := kube.NewWorkloadClientFromKubeconfig([]byte(kubeconfigOutput.Kubeconfig))
+	workloadClient, err := s.kubeClient.WorkloadClient([]byte(kubeconfigOutput.Kubeconfig))
 	if err != nil {
 		logger.WithError(err).Error("Failed to create workload client")
 		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create workload cluster client")
@@ -611,6 +1114,8 @@ func (s *EnhancedClusterService) GetClusterNodes(ctx context.Context, input api.
 			Roles:          s.getNodeRoles(&node),
 			KubeletVersion: node.Status.NodeInfo.KubeletVersion,
 			Labels:         node.Labels,
+			Accelerators:   nodeAccelerators(&node),
+			CreatedAt:      node.CreationTimestamp.Format(time.RFC3339),
 		}
 
 		// Get addresses
@@ -699,54 +1204,6 @@ func (s *EnhancedClusterService) extractProviderName(variables map[string]interf
 	return "aws"
 }
 
-// waitForClusterPhase waits for a cluster to reach a specific phase
-func (s *EnhancedClusterService) waitForClusterPhase(ctx context.Context, clusterName, namespace string, timeout time.Duration) (*clusterv1.Cluster, error) {
-	waitCtx, cancel := context.WithTimeout(ctx, timeout)
-	defer cancel()
-
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-waitCtx.Done():
-			return nil, waitCtx.Err()
-		case <-ticker.C:
-			cluster, err := s.kubeClient.GetClusterByName(waitCtx, clusterName)
-			if err != nil {
-				continue // Keep trying
-			}
-
-			// Return cluster regardless of phase after initial creation
-			if cluster.Status.Phase != "" {
-				return cluster, nil
-			}
-		}
-	}
-}
-
-// waitForClusterDeleted waits for a cluster to be fully deleted
-func (s *EnhancedClusterService) waitForClusterDeleted(ctx context.Context, clusterName, namespace string) error {
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			_, err := s.kubeClient.GetClusterByName(ctx, clusterName)
-			if apierrors.IsNotFound(err) {
-				return nil // Successfully deleted
-			}
-			if err != nil {
-				continue // Keep trying on other errors
-			}
-			// Cluster still exists, continue waiting
-		}
-	}
-}
-
 // buildClusterResource builds a CAPI Cluster resource from the input
 func (s *EnhancedClusterService) buildClusterResource(input api.CreateClusterInput, clusterClass *clusterv1.ClusterClass) *clusterv1.Cluster {
 	cluster := &clusterv1.Cluster{
@@ -764,6 +1221,11 @@ func (s *EnhancedClusterService) buildClusterResource(input api.CreateClusterInp
 		},
 	}
 
+	// Pin the cluster to the template version it was created from
+	if version := templateVersion(clusterClass); version != "" {
+		cluster.Annotations = map[string]string{templateVersionAnnotation: version}
+	}
+
 	// Add variables if provided
 	if len(input.Variables) > 0 {
 		variables := make([]clusterv1.ClusterVariable, 0, len(input.Variables))
@@ -801,65 +1263,206 @@ func (s *EnhancedClusterService) getKubernetesVersion(cluster *clusterv1.Cluster
 	return ""
 }
 
-// getControlPlaneReplicas safely extracts control plane replica count
-func (s *EnhancedClusterService) getControlPlaneReplicas(cluster *clusterv1.Cluster) int32 {
-	// Default to 1 for single control plane
-	// In a real implementation, we would check the control plane spec
-	return 1
+// getControlPlaneReplicas safely extracts the desired replica count from a
+// machine-based control plane such as KubeadmControlPlane. Managed control
+// planes have no replicas and are handled by getControlPlaneStatus.
+func (s *EnhancedClusterService) getControlPlaneReplicas(controlPlane *unstructured.Unstructured) int32 {
+	replicas, found, err := unstructured.NestedInt64(controlPlane.Object, "spec", "replicas")
+	if err != nil || !found {
+		// KubeadmControlPlane defaults to a single replica
+		return 1
+	}
+	return int32(replicas)
+}
+
+// getControlPlaneStatus summarizes the control plane referenced by the cluster,
+// delegating to provider hooks for managed control planes. It returns warnings
+// describing the details that could not be retrieved.
+func (s *EnhancedClusterService) getControlPlaneStatus(ctx context.Context, cluster *clusterv1.Cluster) (*api.ControlPlaneStatus, []string) {
+	ref := cluster.Spec.ControlPlaneRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	status := &api.ControlPlaneStatus{
+		Kind:  ref.Kind,
+		Name:  ref.Name,
+		Ready: cluster.Status.ControlPlaneReady,
+	}
+
+	controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get control plane",
+			logging.FieldClusterName, cluster.Name,
+		)
+		status.Message = "control plane details unavailable"
+		return status, []string{fmt.Sprintf("control plane replicas unavailable: %v", err)}
+	}
+
+	if managed := s.getManagedControlPlaneProvider(cluster); managed != nil {
+		status.Managed = true
+		ready, message, err := managed.GetManagedControlPlaneStatus(ctx, controlPlane)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to interpret managed control plane status",
+				logging.FieldClusterName, cluster.Name,
+			)
+			return status, []string{fmt.Sprintf("managed control plane status unavailable: %v", err)}
+		}
+		status.Ready = ready
+		status.Message = message
+		return status, nil
+	}
+
+	readyReplicas, _, _ := unstructured.NestedInt64(controlPlane.Object, "status", "readyReplicas")
+	status.Replicas = int(s.getControlPlaneReplicas(controlPlane))
+	status.ReadyReplicas = int(readyReplicas)
+	status.Ready = status.Ready && status.ReadyReplicas >= status.Replicas
+	return status, nil
+}
+
+// getManagedControlPlaneProvider returns the provider hooks for a cluster whose
+// control plane is hosted by the cloud provider, or nil for machine-based control planes.
+func (s *EnhancedClusterService) getManagedControlPlaneProvider(cluster *clusterv1.Cluster) provider.ManagedControlPlaneProvider {
+	if s.providerManager == nil || cluster.Spec.ControlPlaneRef == nil {
+		return nil
+	}
+
+	prov, exists := s.providerManager.GetProvider(s.getProvider(cluster))
+	if !exists {
+		return nil
+	}
+
+	managed, ok := prov.(provider.ManagedControlPlaneProvider)
+	if !ok || !managed.IsManagedControlPlane(cluster.Spec.ControlPlaneRef.Kind) {
+		return nil
+	}
+	return managed
 }
 
-// getProviderStatus gets provider-specific status information
-func (s *EnhancedClusterService) getProviderStatus(ctx context.Context, cluster *clusterv1.Cluster) (map[string]interface{}, error) {
+// getProviderStatus gets the normalized status of a cluster's infrastructure
+// from its provider, or nil if the provider is not registered. The
+// infrastructure cluster is passed to the provider when it can be read.
+func (s *EnhancedClusterService) getProviderStatus(ctx context.Context, cluster *clusterv1.Cluster) (*api.ProviderStatus, error) {
 	if s.providerManager == nil {
 		return nil, nil
 	}
+	prov, exists := s.providerManager.GetProvider(s.getProvider(cluster))
+	if !exists {
+		return nil, nil
+	}
 
-	// Determine provider from cluster
-	providerName := "aws" // Default for now
-	if provider, ok := cluster.Labels["cluster.x-k8s.io/provider"]; ok {
-		providerName = provider
+	var infraCluster *unstructured.Unstructured
+	if ref := cluster.Spec.InfrastructureRef; ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cluster.Namespace
+		}
+		obj, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		if err == nil {
+			infraCluster = obj
+		} else {
+			s.logger.WithContext(ctx).WithError(err).Debug("Failed to get infrastructure cluster",
+				logging.FieldClusterName, cluster.Name,
+			)
+		}
 	}
 
-	// Get provider-specific status
-	if prov, exists := s.providerManager.GetProvider(providerName); exists {
-		return prov.GetProviderSpecificStatus(ctx, cluster)
+	status, err := prov.GetProviderSpecificStatus(ctx, cluster, infraCluster)
+	if err != nil || status == nil {
+		return nil, err
 	}
 
-	return nil, nil
+	output := &api.ProviderStatus{
+		Provider:                 status.Provider,
+		Region:                   status.Region,
+		InfrastructureReady:      status.InfrastructureReady,
+		NetworkReady:             status.NetworkReady,
+		ControlPlaneLoadBalancer: status.ControlPlaneLoadBalancer,
+		FailureDomains:           status.FailureDomains,
+		Extras:                   status.Extras,
+	}
+	for _, resource := range status.CloudResources {
+		output.CloudResources = append(output.CloudResources, api.CloudResourceRef{
+			Type:   resource.Type,
+			ID:     resource.ID,
+			Region: resource.Region,
+		})
+	}
+	return output, nil
 }
 
 // Helper methods for ClusterDetails
 
 func (s *EnhancedClusterService) getProvider(cluster *clusterv1.Cluster) string {
-	if cluster.Spec.InfrastructureRef != nil {
-		if cluster.Spec.InfrastructureRef.Kind == "AWSCluster" {
-			return "aws"
-		}
-	}
-	return "unknown"
+	return clusterProvider(cluster)
 }
 
 func (s *EnhancedClusterService) getRegion(cluster *clusterv1.Cluster) string {
-	// Try to extract region from infrastructure reference
-	if cluster.Spec.InfrastructureRef != nil {
-		// This would typically require looking up the infrastructure resource
-		// For now, return a placeholder
-		return "us-west-2" // Default region
-	}
-	return ""
+	return clusterRegion(cluster)
 }
 
 func (s *EnhancedClusterService) getEndpoint(cluster *clusterv1.Cluster) string {
-	if cluster.Spec.ControlPlaneEndpoint.Host != "" {
-		return fmt.Sprintf("https://%s:%d", cluster.Spec.ControlPlaneEndpoint.Host, cluster.Spec.ControlPlaneEndpoint.Port)
+	return clusterEndpoint(cluster)
+}
+
+// getNodePools lists the MachineDeployments and MachinePools of a cluster. It
+// returns warnings describing the node pools that could not be listed.
+func (s *EnhancedClusterService) getNodePools(ctx context.Context, cluster *clusterv1.Cluster) ([]api.NodePool, []string) {
+	logger := s.logger.WithContext(ctx).WithCluster(cluster.Name, cluster.Namespace)
+	nodePools := []api.NodePool{}
+	var warnings []string
+
+	machineDeployments, err := s.kubeClient.ListMachineDeployments(ctx, cluster.Name)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to list MachineDeployments")
+		warnings = append(warnings, fmt.Sprintf("MachineDeployment node pools unavailable: %v", err))
+	} else {
+		for i := range machineDeployments.Items {
+			md := &machineDeployments.Items[i]
+			nodePool := api.NodePool{
+				Name:          md.Name,
+				Kind:          "MachineDeployment",
+				Replicas:      int(ptrValue(md.Spec.Replicas)),
+				ReadyReplicas: int(md.Status.ReadyReplicas),
+				Phase:         md.Status.Phase,
+				Spot:          isSpotMachineDeployment(cluster, md),
+			}
+			if nodePool.Spot {
+				interruptions, err := s.countSpotInterruptions(ctx, cluster.Name, md.Name)
+				if err != nil {
+					logger.WithError(err).Warn("Failed to count spot interruptions", "machine_deployment", md.Name)
+					warnings = append(warnings, fmt.Sprintf("spot interruptions of node pool '%s' unavailable: %v", md.Name, err))
+				}
+				nodePool.SpotInterruptions = interruptions
+			}
+			nodePools = append(nodePools, nodePool)
+		}
 	}
-	return ""
+
+	machinePools, err := s.kubeClient.ListMachinePools(ctx, cluster.Name)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to list MachinePools")
+		warnings = append(warnings, fmt.Sprintf("MachinePool node pools unavailable: %v", err))
+	} else {
+		for _, mp := range machinePools.Items {
+			nodePools = append(nodePools, api.NodePool{
+				Name:          mp.Name,
+				Kind:          "MachinePool",
+				Replicas:      int(ptrValue(mp.Spec.Replicas)),
+				ReadyReplicas: int(mp.Status.ReadyReplicas),
+				Phase:         mp.Status.Phase,
+			})
+		}
+	}
+
+	return nodePools, warnings
 }
 
-func (s *EnhancedClusterService) getNodePools(ctx context.Context, cluster *clusterv1.Cluster) []api.NodePool {
-	// This would typically query MachineDeployments
-	// For now, return empty array as a placeholder
-	return []api.NodePool{}
+func ptrValue(v *int32) int32 {
+	if v == nil {
+		return 0
+	}
+	return *v
 }
 
 func (s *EnhancedClusterService) getConditions(cluster *clusterv1.Cluster) []api.ClusterCondition {
@@ -0,0 +1,568 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider/aws"
+)
+
+const testNamespace = "default"
+
+func setupEnhancedTestService(t *testing.T, objects ...client.Object) (*EnhancedClusterService, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, expv1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		Build()
+
+	providerManager := provider.NewProviderManager()
+	providerManager.RegisterProvider(aws.NewAWSProvider("us-west-2"))
+
+	logger := logging.NewLogger(slog.LevelError, "text")
+	svc := NewEnhancedClusterService(kube.NewClientFromClient(fakeClient, testNamespace), logger, providerManager)
+	return svc, fakeClient
+}
+
+func createTestMachinePool(name, namespace, clusterName string, replicas int32) *expv1.MachinePool {
+	return &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: clusterName,
+			},
+		},
+		Spec: expv1.MachinePoolSpec{
+			ClusterName: clusterName,
+			Replicas:    &replicas,
+		},
+		Status: expv1.MachinePoolStatus{
+			ReadyReplicas: replicas,
+			Phase:         string(expv1.MachinePoolPhaseRunning),
+		},
+	}
+}
+
+func TestEnhancedClusterService_MachinePools(t *testing.T) {
+	cluster := createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	md := createTestMachineDeployment("md-0", testNamespace, "test-cluster", 2)
+	mp := createTestMachinePool("mp-0", testNamespace, "test-cluster", 3)
+
+	t.Run("node pools include machine pools", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, cluster, md, mp)
+
+		pools, warnings := svc.getNodePools(context.Background(), cluster)
+		assert.Empty(t, warnings)
+		require.Len(t, pools, 2)
+		assert.Equal(t, "MachineDeployment", pools[0].Kind)
+		assert.Equal(t, "md-0", pools[0].Name)
+		assert.Equal(t, "MachinePool", pools[1].Kind)
+		assert.Equal(t, "mp-0", pools[1].Name)
+		assert.Equal(t, 3, pools[1].Replicas)
+		assert.Equal(t, 3, pools[1].ReadyReplicas)
+	})
+
+	t.Run("node count includes machine pools", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, cluster, md, mp)
+
+		counts, err := svc.getClusterNodeCount(context.Background(), "test-cluster", testNamespace)
+		require.NoError(t, err)
+		// 2 MachineDeployment + 3 MachinePool, no control plane Machines
+		assert.Equal(t, int32(5), counts.Desired)
+		assert.Equal(t, int32(3), counts.Ready)
+	})
+
+	t.Run("scale machine pool", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, cluster, md, mp)
+
+		output, err := svc.ScaleCluster(context.Background(), api.ScaleClusterInput{
+			ClusterName:  "test-cluster",
+			NodePoolName: "mp-0",
+			Replicas:     5,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "scaling", output.Status)
+		assert.Equal(t, 3, output.OldReplicas)
+
+		updated := &expv1.MachinePool{}
+		require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: "mp-0"}, updated))
+		assert.Equal(t, int32(5), *updated.Spec.Replicas)
+	})
+
+	t.Run("scale unknown node pool", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, cluster, md, mp)
+
+		_, err := svc.ScaleCluster(context.Background(), api.ScaleClusterInput{
+			ClusterName:  "test-cluster",
+			NodePoolName: "missing",
+			Replicas:     1,
+		})
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func createTestControlPlane(kind, name string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	controlPlane := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   spec,
+		"status": status,
+	}}
+	controlPlane.SetAPIVersion("controlplane.cluster.x-k8s.io/v1beta1")
+	controlPlane.SetKind(kind)
+	controlPlane.SetName(name)
+	controlPlane.SetNamespace(testNamespace)
+	return controlPlane
+}
+
+func withControlPlane(cluster *clusterv1.Cluster, infraKind, controlPlaneKind, controlPlaneName string) *clusterv1.Cluster {
+	cluster.Spec.InfrastructureRef = &corev1.ObjectReference{Kind: infraKind, Name: cluster.Name}
+	cluster.Spec.ControlPlaneRef = &corev1.ObjectReference{
+		APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+		Kind:       controlPlaneKind,
+		Name:       controlPlaneName,
+	}
+	return cluster
+}
+
+func TestEnhancedClusterService_ControlPlaneStatus(t *testing.T) {
+	t.Run("kubeadm control plane", func(t *testing.T) {
+		cluster := withControlPlane(createTestCluster("kcp-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			"AWSCluster", "KubeadmControlPlane", "kcp-cluster-control-plane")
+		controlPlane := createTestControlPlane("KubeadmControlPlane", "kcp-cluster-control-plane",
+			map[string]interface{}{"replicas": int64(3)},
+			map[string]interface{}{"readyReplicas": int64(2)},
+		)
+		svc, _ := setupEnhancedTestService(t, cluster, controlPlane)
+
+		status, warnings := svc.getControlPlaneStatus(context.Background(), cluster)
+		assert.Empty(t, warnings)
+		require.NotNil(t, status)
+		assert.False(t, status.Managed)
+		assert.Equal(t, 3, status.Replicas)
+		assert.Equal(t, 2, status.ReadyReplicas)
+		assert.False(t, status.Ready)
+	})
+
+	t.Run("managed control plane", func(t *testing.T) {
+		cluster := withControlPlane(createTestCluster("eks-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			"AWSManagedCluster", "AWSManagedControlPlane", "eks-cluster-control-plane")
+		controlPlane := createTestControlPlane("AWSManagedControlPlane", "eks-cluster-control-plane",
+			map[string]interface{}{},
+			map[string]interface{}{"ready": true},
+		)
+		svc, _ := setupEnhancedTestService(t, cluster, controlPlane)
+
+		status, warnings := svc.getControlPlaneStatus(context.Background(), cluster)
+		assert.Empty(t, warnings)
+		require.NotNil(t, status)
+		assert.True(t, status.Managed)
+		assert.Equal(t, 0, status.Replicas)
+		assert.True(t, status.Ready)
+		assert.Contains(t, status.Message, "is ready")
+	})
+
+	t.Run("no control plane reference", func(t *testing.T) {
+		cluster := createTestCluster("bare-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+		svc, _ := setupEnhancedTestService(t, cluster)
+
+		status, _ := svc.getControlPlaneStatus(context.Background(), cluster)
+		assert.Nil(t, status)
+	})
+}
+
+func TestEnhancedClusterService_ProviderStatus(t *testing.T) {
+	cluster := createTestCluster("aws-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+		Kind:       "AWSCluster",
+		Name:       "aws-cluster",
+	}
+	cluster.Status.InfrastructureReady = true
+	awsCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"network": map[string]interface{}{
+				"vpc":     map[string]interface{}{"id": "vpc-0a1b2c3d"},
+				"subnets": []interface{}{map[string]interface{}{"id": "subnet-0aaa1111"}},
+			},
+		},
+		"status": map[string]interface{}{
+			"networkStatus": map[string]interface{}{
+				"apiServerElb": map[string]interface{}{"name": "aws-cluster-apiserver", "dnsName": "aws-cluster-apiserver.elb.amazonaws.com"},
+			},
+			"bastion": map[string]interface{}{"id": "i-0ccc3333", "publicIp": "203.0.113.10"},
+		},
+	}}
+	awsCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	awsCluster.SetKind("AWSCluster")
+	awsCluster.SetName("aws-cluster")
+	awsCluster.SetNamespace(testNamespace)
+
+	t.Run("from the infrastructure cluster", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, cluster, awsCluster)
+
+		output, err := svc.GetCluster(context.Background(), api.GetClusterInput{ClusterName: "aws-cluster"})
+		require.NoError(t, err)
+		status := output.Cluster.ProviderStatus
+		require.NotNil(t, status)
+		assert.Equal(t, "aws", status.Provider)
+		assert.True(t, status.NetworkReady)
+		assert.Equal(t, "aws-cluster-apiserver.elb.amazonaws.com", status.ControlPlaneLoadBalancer)
+		assert.Equal(t, []api.CloudResourceRef{
+			{Type: "vpc", ID: "vpc-0a1b2c3d", Region: "us-west-2"},
+			{Type: "subnet", ID: "subnet-0aaa1111", Region: "us-west-2"},
+			{Type: "load-balancer", ID: "aws-cluster-apiserver", Region: "us-west-2"},
+			{Type: "instance", ID: "i-0ccc3333", Region: "us-west-2"},
+		}, status.CloudResources)
+		assert.Equal(t, "203.0.113.10", status.Extras["bastionIp"])
+	})
+
+	t.Run("infrastructure cluster unavailable", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, cluster)
+
+		output, err := svc.GetCluster(context.Background(), api.GetClusterInput{ClusterName: "aws-cluster"})
+		require.NoError(t, err)
+		status := output.Cluster.ProviderStatus
+		require.NotNil(t, status)
+		assert.True(t, status.InfrastructureReady)
+		assert.Empty(t, status.CloudResources)
+	})
+}
+
+func TestEnhancedClusterService_GetClusterKubeconfig(t *testing.T) {
+	t.Run("managed control plane prefers user kubeconfig", func(t *testing.T) {
+		cluster := withControlPlane(createTestCluster("eks-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			"AWSManagedCluster", "AWSManagedControlPlane", "eks-cluster-control-plane")
+		userSecret := createTestKubeconfigSecret("eks-cluster", testNamespace)
+		userSecret.Name = "eks-cluster-user-kubeconfig"
+		userSecret.Data["value"] = []byte("user-kubeconfig")
+		tokenSecret := createTestKubeconfigSecret("eks-cluster", testNamespace)
+		svc, _ := setupEnhancedTestService(t, cluster, userSecret, tokenSecret)
+
+		output, err := svc.GetClusterKubeconfig(context.Background(), api.GetClusterKubeconfigInput{ClusterName: "eks-cluster"})
+		require.NoError(t, err)
+		assert.Equal(t, "user-kubeconfig", output.Kubeconfig)
+	})
+
+	t.Run("kubeadm control plane uses default secret", func(t *testing.T) {
+		cluster := withControlPlane(createTestCluster("kcp-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			"AWSCluster", "KubeadmControlPlane", "kcp-cluster-control-plane")
+		svc, _ := setupEnhancedTestService(t, cluster, createTestKubeconfigSecret("kcp-cluster", testNamespace))
+
+		output, err := svc.GetClusterKubeconfig(context.Background(), api.GetClusterKubeconfigInput{ClusterName: "kcp-cluster"})
+		require.NoError(t, err)
+		assert.Contains(t, output.Kubeconfig, "kind: Config")
+	})
+
+	t.Run("missing kubeconfig", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+
+		_, err := svc.GetClusterKubeconfig(context.Background(), api.GetClusterKubeconfigInput{ClusterName: "missing"})
+		require.Error(t, err)
+		assert.True(t, errors.IsNotFound(err))
+	})
+}
+
+func createTestControlPlaneMachine(name, clusterName string, ready bool) *clusterv1.Machine {
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:         clusterName,
+				clusterv1.MachineControlPlaneLabel: "",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: clusterName,
+		},
+		Status: clusterv1.MachineStatus{
+			Phase: string(clusterv1.MachinePhaseProvisioning),
+		},
+	}
+	if ready {
+		machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+		machine.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: name}
+	}
+	return machine
+}
+
+func TestEnhancedClusterService_NodeCounts(t *testing.T) {
+	md := createTestMachineDeployment("md-0", testNamespace, "test-cluster", 3)
+	md.Status.ReadyReplicas = 2
+
+	t.Run("counts control plane machines and worker status", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t,
+			createTestControlPlaneMachine("cp-0", "test-cluster", true),
+			createTestControlPlaneMachine("cp-1", "test-cluster", true),
+			createTestControlPlaneMachine("cp-2", "test-cluster", false),
+			md,
+		)
+
+		counts, err := svc.getClusterNodeCount(context.Background(), "test-cluster", testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, int32(6), counts.Desired)
+		assert.Equal(t, int32(4), counts.Ready)
+	})
+
+	t.Run("list clusters reports desired and ready counts", func(t *testing.T) {
+		cluster := createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+		svc, _ := setupEnhancedTestService(t, cluster,
+			createTestControlPlaneMachine("cp-0", "test-cluster", true),
+			md,
+		)
+
+		output, err := svc.ListClusters(context.Background())
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, 4, output.Clusters[0].NodeCount)
+		assert.Equal(t, 3, output.Clusters[0].ReadyNodeCount)
+	})
+
+	t.Run("list clusters counts nodes of many clusters concurrently", func(t *testing.T) {
+		var objects []client.Object
+		for i := range 25 {
+			name := fmt.Sprintf("cluster-%02d", i)
+			objects = append(objects,
+				createTestCluster(name, testNamespace, clusterv1.ClusterPhaseProvisioned),
+				createTestMachineDeployment(name+"-md-0", testNamespace, name, int32(i%5+1)),
+			)
+		}
+		svc, fakeClient := setupEnhancedTestService(t, objects...)
+		svc.SetListConcurrency(4)
+
+		// Count the clusters whose MachineDeployments are being listed at once
+		var inFlight, maxInFlight atomic.Int32
+		svc.kubeClient = kube.NewClientFromClient(interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*clusterv1.MachineDeploymentList); ok {
+					current := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						peak := maxInFlight.Load()
+						if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}), testNamespace)
+
+		output, err := svc.ListClusters(context.Background())
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 25)
+		for i, summary := range output.Clusters {
+			assert.Equal(t, fmt.Sprintf("cluster-%02d", i), summary.Name)
+			assert.Equal(t, i%5+1, summary.NodeCount)
+		}
+		assert.Equal(t, int32(4), maxInFlight.Load(), "nodes of up to 4 clusters are counted at once")
+	})
+
+	t.Run("list clusters stops counting nodes once its context is done", func(t *testing.T) {
+		var objects []client.Object
+		for i := range 25 {
+			objects = append(objects, createTestCluster(fmt.Sprintf("cluster-%02d", i), testNamespace, clusterv1.ClusterPhaseProvisioned))
+		}
+		svc, fakeClient := setupEnhancedTestService(t, objects...)
+		svc.SetListConcurrency(2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var counted atomic.Int32
+		svc.kubeClient = kube.NewClientFromClient(interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*clusterv1.MachineDeploymentList); ok && counted.Add(1) == 2 {
+					cancel()
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}), testNamespace)
+
+		_, err := svc.ListClusters(ctx)
+		require.Error(t, err)
+		assert.Less(t, counted.Load(), int32(25), "no summaries are started after cancellation")
+	})
+}
+
+func TestEnhancedClusterService_PartialFailureWarnings(t *testing.T) {
+	// Without the MachinePool types in the scheme, listing MachinePools fails
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			createTestMachineDeployment("md-0", testNamespace, "test-cluster", 2),
+		).
+		Build()
+	svc := NewEnhancedClusterService(kube.NewClientFromClient(fakeClient, testNamespace), logging.NewLogger(slog.LevelError, "text"), nil)
+	ctx := context.Background()
+
+	t.Run("list clusters reports missing node counts", func(t *testing.T) {
+		output, err := svc.ListClusters(ctx)
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, 0, output.Clusters[0].NodeCount)
+		require.Len(t, output.Clusters[0].Warnings, 1)
+		assert.Contains(t, output.Clusters[0].Warnings[0], "node counts unavailable")
+	})
+
+	t.Run("get cluster reports missing node pools", func(t *testing.T) {
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "test-cluster"})
+		require.NoError(t, err)
+		require.Len(t, output.Cluster.NodePools, 1)
+		assert.Equal(t, "md-0", output.Cluster.NodePools[0].Name)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "MachinePool node pools unavailable")
+	})
+}
+
+func TestEnhancedClusterService_ListClustersAdvisory(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t, createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned))
+
+	output, err := svc.ListClusters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, output.Clusters, 1)
+
+	advisory := output.Clusters[0].Advisory
+	require.NotNil(t, advisory)
+	assert.Equal(t, "1.31", advisory.Release)
+	assert.Equal(t, "2025-10-28", advisory.EndOfLife)
+	assert.NotEmpty(t, advisory.Status)
+}
+
+func TestEnhancedClusterService_ValidateKubernetesVersionSupport(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+
+	ranged := createTestClusterClass("aws-ranged")
+	ranged.Annotations = map[string]string{KubernetesVersionsAnnotation: ">=v1.29 <v1.32"}
+	unranged := createTestClusterClass("aws-unranged")
+
+	tests := []struct {
+		name         string
+		version      string
+		clusterClass *clusterv1.ClusterClass
+		wantErr      string
+	}{
+		{name: "within template and provider support", version: "v1.30.2", clusterClass: ranged},
+		{name: "below template range", version: "v1.28.14", clusterClass: ranged, wantErr: "does not support"},
+		{name: "above template range", version: "v1.32.0", clusterClass: ranged, wantErr: "does not support"},
+		{name: "unsupported by provider", version: "v1.27.3", clusterClass: unranged, wantErr: "cannot provision"},
+		{name: "invalid format", version: "1.30", clusterClass: unranged, wantErr: "format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.validateKubernetesVersionSupport(ctx, tt.version, tt.clusterClass, "aws")
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+			assert.Contains(t, errors.GetUserMessage(err), tt.wantErr)
+		})
+	}
+}
+
+func TestEnhancedClusterService_CreateCluster_DryRun(t *testing.T) {
+	ctx := context.Background()
+	svc, fakeClient := setupEnhancedTestService(t, createTestClusterClass("aws-standard"),
+		createTestCluster("existing-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned))
+
+	tests := []struct {
+		name        string
+		input       api.CreateClusterInput
+		wantErrCode errors.ErrorCode
+	}{
+		{
+			name: "valid cluster",
+			input: api.CreateClusterInput{
+				ClusterName:       "new-cluster",
+				TemplateName:      "aws-standard",
+				KubernetesVersion: "v1.31.0",
+				Variables:         map[string]interface{}{"region": "us-west-2"},
+				DryRun:            true,
+			},
+		},
+		{
+			name: "unsupported Kubernetes version",
+			input: api.CreateClusterInput{
+				ClusterName:       "new-cluster",
+				TemplateName:      "aws-standard",
+				KubernetesVersion: "v1.27.3",
+				Variables:         map[string]interface{}{"region": "us-west-2"},
+				DryRun:            true,
+			},
+			wantErrCode: errors.CodeInvalidInput,
+		},
+		{
+			name: "existing cluster",
+			input: api.CreateClusterInput{
+				ClusterName:       "existing-cluster",
+				TemplateName:      "aws-standard",
+				KubernetesVersion: "v1.31.0",
+				Variables:         map[string]interface{}{"region": "us-west-2"},
+				DryRun:            true,
+			},
+			wantErrCode: errors.CodeAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := svc.CreateCluster(ctx, tt.input)
+			if tt.wantErrCode != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErrCode, errors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, output.DryRun)
+			assert.Equal(t, api.ClusterStatusPending, output.Status)
+			assert.Contains(t, output.Message, "Dry run")
+
+			err = fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: tt.input.ClusterName}, &clusterv1.Cluster{})
+			assert.True(t, apierrors.IsNotFound(err), "dry run must not create the cluster")
+		})
+	}
+}
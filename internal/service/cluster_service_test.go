@@ -123,6 +123,30 @@ func TestClusterService_ListClusters(t *testing.T) {
 		assert.Equal(t, 5, count)
 	})
 
+	t.Run("estimateNodeCount with machine pools", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{
+			Spec: clusterv1.ClusterSpec{
+				Topology: &clusterv1.Topology{
+					Workers: &clusterv1.WorkersTopology{
+						MachineDeployments: []clusterv1.MachineDeploymentTopology{
+							{
+								Replicas: func(i int32) *int32 { return &i }(2),
+							},
+						},
+						MachinePools: []clusterv1.MachinePoolTopology{
+							{
+								Replicas: func(i int32) *int32 { return &i }(4),
+							},
+						},
+					},
+				},
+			},
+		}
+
+		count := service.estimateNodeCount(cluster)
+		assert.Equal(t, 6, count)
+	})
+
 	t.Run("estimateNodeCount with nil workers", func(t *testing.T) {
 		cluster := &clusterv1.Cluster{
 			Spec: clusterv1.ClusterSpec{
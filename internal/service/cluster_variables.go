@@ -0,0 +1,524 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// Targets of ClusterClass patches. All but the infrastructure cluster create
+// machines.
+const (
+	patchTargetInfrastructure    = "InfrastructureCluster"
+	patchTargetControlPlane      = "ControlPlane"
+	patchTargetMachineDeployment = "MachineDeployment"
+	patchTargetMachinePool       = "MachinePool"
+)
+
+// UpdateClusterVariables sets and removes topology variables of a cluster
+// managed by a ClusterClass, such as its instance type or a feature flag.
+// Values are checked against the variable schemas of the ClusterClass, and
+// the response previews which machines roll because a patch reading a
+// changed variable changes their templates.
+func (s *EnhancedClusterService) UpdateClusterVariables(ctx context.Context, input api.UpdateClusterVariablesInput) (*api.UpdateClusterVariablesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("UpdateClusterVariables").WithCluster(input.ClusterName, "")
+	logger.Info("Updating cluster variables",
+		"variables", len(input.Variables),
+		"unset", len(input.Unset),
+		"dry_run", input.DryRun,
+	)
+
+	if err := validateUpdateClusterVariablesInput(input); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	updateCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(updateCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if cluster.Spec.Topology == nil {
+		return nil, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' is not managed by a ClusterClass, so it has no topology variables", input.ClusterName))
+	}
+
+	templateName := cluster.Spec.Topology.Class
+	clusterClass, err := s.kubeClient.GetClusterClass(updateCtx, templateName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get ClusterClass")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster template '%s' not found", templateName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+	}
+
+	if problems := clusterVariableProblems(clusterClass, input); len(problems) > 0 {
+		err := errors.New(errors.CodeValidationFailed,
+			fmt.Sprintf("variables do not match the schema of cluster template '%s': %s", templateName, strings.Join(problems, "; "))).
+			WithDetails("problems", problems)
+		logger.WithError(err).Warn("Variables failed schema validation")
+		return nil, err
+	}
+
+	// Validate the new values against the server's policies and the provider,
+	// in the cluster's region
+	providerName := clusterProvider(cluster)
+	if _, err := s.applyVariableDefaults(s.kubeClient.Namespace(ctx), providerName, templateName, input.Variables); err != nil {
+		logger.WithError(err).Error("Variables conflict with the server's default variables")
+		return nil, err
+	}
+	if err := s.checkInstanceTypes(input.Variables); err != nil {
+		logger.WithError(err).Error("Instance type not permitted")
+		return nil, err
+	}
+	if s.providerManager != nil && len(input.Variables) > 0 {
+		if prov, exists := s.providerManager.GetProvider(providerName); exists {
+			variables := maps.Clone(input.Variables)
+			if _, ok := variables[regionVariable]; !ok {
+				if region := clusterRegion(cluster); region != "" {
+					variables[regionVariable] = region
+				}
+			}
+			if err := prov.ValidateClusterConfig(updateCtx, variables); err != nil {
+				logger.WithError(err).Error("Provider validation failed")
+				return nil, errors.Wrap(err, errors.CodeProviderValidation, "provider validation failed")
+			}
+		}
+	}
+
+	current := map[string]interface{}{}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		current[variable.Name] = rawJSONValue(variable.Value.Raw)
+	}
+	updated := maps.Clone(current)
+	for _, name := range input.Unset {
+		delete(updated, name)
+	}
+	for name, value := range input.Variables {
+		// Values are compared as they are stored, in JSON
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInvalidInput, fmt.Sprintf("invalid value of variable %s", name))
+		}
+		updated[name] = rawJSONValue(raw)
+	}
+
+	object := "Cluster/" + cluster.Name
+	output := &api.UpdateClusterVariablesOutput{
+		ClusterName: cluster.Name,
+		DryRun:      input.DryRun,
+		Changes:     []api.ClusterStateDiff{},
+		Rollouts:    []api.MachineRollout{},
+	}
+	var changed []string
+	for _, name := range sortedKeys(current, updated) {
+		count := len(output.Changes)
+		output.Changes = diffValues(output.Changes, object, "spec.topology.variables."+name, current[name], updated[name])
+		if len(output.Changes) > count {
+			changed = append(changed, name)
+		}
+	}
+	output.Diff = formatStateDiff(output.Changes)
+	if len(changed) == 0 {
+		output.Message = fmt.Sprintf("Cluster '%s' already has these variables, nothing to change", cluster.Name)
+		logger.Info("Cluster variables unchanged")
+		return output, nil
+	}
+	output.PreviousVariables = make(map[string]interface{}, len(changed))
+	for _, name := range changed {
+		output.PreviousVariables[name] = current[name]
+	}
+
+	output.Rollouts, output.Warnings, err = s.previewVariableRollouts(updateCtx, cluster, clusterClass, changed)
+	if err != nil {
+		logger.WithError(err).Error("Failed to preview machine rollouts")
+		return nil, err
+	}
+	machines := 0
+	for _, rollout := range output.Rollouts {
+		machines += len(rollout.Machines)
+	}
+
+	if input.DryRun {
+		output.Message = fmt.Sprintf("Dry run: updating %d variable(s) of cluster '%s' would roll %d machine(s)", len(changed), cluster.Name, machines)
+		logger.Info("Dry run of cluster variable update", "changes", len(output.Changes))
+		return output, nil
+	}
+
+	variables, err := toClusterVariables(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid cluster variables")
+	}
+	cluster.Spec.Topology.Variables = variables
+	if err := s.kubeClient.UpdateCluster(updateCtx, cluster); err != nil {
+		logger.WithError(err).Error("Failed to update cluster topology")
+		if apierrors.IsConflict(err) {
+			return nil, errors.Wrap(err, errors.CodePreconditionFailed, "cluster was modified concurrently, retry the request")
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to update cluster variables")
+	}
+
+	output.Applied = true
+	output.Message = fmt.Sprintf("Updated %d variable(s) of cluster '%s'; %d machine(s) will roll", len(changed), cluster.Name, machines)
+	logger.Info("Cluster variables updated", "variables", changed, "machines", machines)
+	return output, nil
+}
+
+// validateUpdateClusterVariablesInput validates the update_cluster_variables parameters.
+func validateUpdateClusterVariablesInput(input api.UpdateClusterVariablesInput) error {
+	validator := validation.NewValidator()
+	if err := validator.ValidateClusterName(input.ClusterName); err != nil {
+		return err
+	}
+	if len(input.Variables) == 0 && len(input.Unset) == 0 {
+		return errors.New(errors.CodeInvalidInput, "at least one variable to set or unset is required").
+			WithDetails("field", "variables")
+	}
+	for _, name := range input.Unset {
+		if _, ok := input.Variables[name]; ok {
+			return errors.New(errors.CodeInvalidInput, fmt.Sprintf("variable %s cannot be both set and unset", name)).
+				WithDetails("field", "unset")
+		}
+	}
+	if len(input.Variables) > 0 {
+		return validator.ValidateClusterVariables(input.Variables)
+	}
+	return nil
+}
+
+// clusterVariableProblems checks variables to set against the variables a
+// ClusterClass declares and their schemas, and that variables to unset are
+// not required without a default.
+func clusterVariableProblems(clusterClass *clusterv1.ClusterClass, input api.UpdateClusterVariablesInput) []string {
+	declared := make(map[string]clusterv1.ClusterClassVariable, len(clusterClass.Spec.Variables))
+	for _, variable := range clusterClass.Spec.Variables {
+		declared[variable.Name] = variable
+	}
+
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(input.Variables)) {
+		variable, ok := declared[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a variable of the cluster template", name))
+			continue
+		}
+		// Round trip the value so that numbers are compared as JSON numbers
+		var value interface{}
+		if raw, err := json.Marshal(input.Variables[name]); err == nil {
+			value = rawJSONValue(raw)
+		}
+		problems = append(problems, variableValueProblems(name, value, variable.Schema.OpenAPIV3Schema)...)
+	}
+	for _, name := range input.Unset {
+		variable, ok := declared[name]
+		if ok && variable.Required && variable.Schema.OpenAPIV3Schema.Default == nil {
+			problems = append(problems, fmt.Sprintf("%s is required and has no default, so it cannot be unset", name))
+		}
+	}
+	return problems
+}
+
+// variableValueProblems checks a decoded JSON value against a variable schema
+// and the schemas nested in it, describing each violation by its field.
+func variableValueProblems(field string, value interface{}, schema clusterv1.JSONSchemaProps) []string {
+	if schema.Type == "" {
+		return nil
+	}
+	if !jsonValueHasType(value, schema.Type) {
+		return []string{fmt.Sprintf("%s must be of type %s", field, schema.Type)}
+	}
+
+	var problems []string
+	if len(schema.Enum) > 0 {
+		encoded, _ := json.Marshal(value)
+		if !slices.ContainsFunc(schema.Enum, func(allowed apiextensionsv1.JSON) bool {
+			return slices.Equal(compactJSON(allowed.Raw), encoded)
+		}) {
+			allowed := make([]string, 0, len(schema.Enum))
+			for _, value := range schema.Enum {
+				allowed = append(allowed, string(compactJSON(value.Raw)))
+			}
+			problems = append(problems, fmt.Sprintf("%s must be one of %s", field, strings.Join(allowed, ", ")))
+		}
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := int64(len([]rune(value)))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			problems = append(problems, fmt.Sprintf("%s must be at least %d characters long", field, *schema.MinLength))
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			problems = append(problems, fmt.Sprintf("%s must be at most %d characters long", field, *schema.MaxLength))
+		}
+		if schema.Pattern != "" {
+			if pattern, err := regexp.Compile(schema.Pattern); err == nil && !pattern.MatchString(value) {
+				problems = append(problems, fmt.Sprintf("%s must match the pattern %q", field, schema.Pattern))
+			}
+		}
+	case float64:
+		if schema.Minimum != nil {
+			minimum := float64(*schema.Minimum)
+			if value < minimum || schema.ExclusiveMinimum && value == minimum {
+				problems = append(problems, fmt.Sprintf("%s must be greater than %s%d", field, orEqual(!schema.ExclusiveMinimum), *schema.Minimum))
+			}
+		}
+		if schema.Maximum != nil {
+			maximum := float64(*schema.Maximum)
+			if value > maximum || schema.ExclusiveMaximum && value == maximum {
+				problems = append(problems, fmt.Sprintf("%s must be less than %s%d", field, orEqual(!schema.ExclusiveMaximum), *schema.Maximum))
+			}
+		}
+	case []interface{}:
+		count := int64(len(value))
+		if schema.MinItems != nil && count < *schema.MinItems {
+			problems = append(problems, fmt.Sprintf("%s must have at least %d item(s)", field, *schema.MinItems))
+		}
+		if schema.MaxItems != nil && count > *schema.MaxItems {
+			problems = append(problems, fmt.Sprintf("%s must have at most %d item(s)", field, *schema.MaxItems))
+		}
+		seen := map[string]bool{}
+		for i, item := range value {
+			if schema.UniqueItems {
+				encoded, _ := json.Marshal(item)
+				if seen[string(encoded)] {
+					problems = append(problems, fmt.Sprintf("%s[%d] duplicates an earlier item", field, i))
+				}
+				seen[string(encoded)] = true
+			}
+			if schema.Items != nil {
+				problems = append(problems, variableValueProblems(fmt.Sprintf("%s[%d]", field, i), item, *schema.Items)...)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is required", field, name))
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(value)) {
+			property, ok := schema.Properties[name]
+			switch {
+			case ok:
+				problems = append(problems, variableValueProblems(field+"."+name, value[name], property)...)
+			case schema.AdditionalProperties != nil:
+				problems = append(problems, variableValueProblems(field+"."+name, value[name], *schema.AdditionalProperties)...)
+			case !schema.XPreserveUnknownFields:
+				problems = append(problems, fmt.Sprintf("%s.%s is not a declared property", field, name))
+			}
+		}
+	}
+	return problems
+}
+
+func orEqual(inclusive bool) string {
+	if inclusive {
+		return "or equal to "
+	}
+	return ""
+}
+
+// patchReads are the variables read by the patches of a ClusterClass, by the
+// target whose templates they change: InfrastructureCluster, ControlPlane, or
+// a MachineDeployment or MachinePool class such as
+// MachineDeployment/default-worker.
+type patchReads struct {
+	targets  map[string]map[string]bool
+	external bool // external patches read variables that cannot be seen
+}
+
+// clusterClassPatchReads collects the variables each ClusterClass patch reads
+// by the targets of its definitions. A variable read by enabledIf affects
+// every definition of its patch.
+func clusterClassPatchReads(spec *clusterv1.ClusterClassSpec) patchReads {
+	reads := patchReads{targets: map[string]map[string]bool{}}
+	add := func(selector clusterv1.PatchSelector, names []string) {
+		var targets []string
+		match := selector.MatchResources
+		if match.InfrastructureCluster {
+			targets = append(targets, patchTargetInfrastructure)
+		}
+		if match.ControlPlane {
+			targets = append(targets, patchTargetControlPlane)
+		}
+		if match.MachineDeploymentClass != nil {
+			for _, class := range spec.Workers.MachineDeployments {
+				if slices.ContainsFunc(match.MachineDeploymentClass.Names, func(name string) bool {
+					matched, _ := path.Match(name, class.Class)
+					return matched
+				}) {
+					targets = append(targets, patchTargetMachineDeployment+"/"+class.Class)
+				}
+			}
+		}
+		if match.MachinePoolClass != nil {
+			for _, class := range spec.Workers.MachinePools {
+				if slices.ContainsFunc(match.MachinePoolClass.Names, func(name string) bool {
+					matched, _ := path.Match(name, class.Class)
+					return matched
+				}) {
+					targets = append(targets, patchTargetMachinePool+"/"+class.Class)
+				}
+			}
+		}
+		for _, target := range targets {
+			if reads.targets[target] == nil {
+				reads.targets[target] = map[string]bool{}
+			}
+			for _, name := range names {
+				reads.targets[target][name] = true
+			}
+		}
+	}
+
+	for _, patch := range spec.Patches {
+		if patch.External != nil {
+			reads.external = true
+		}
+		var enabledIf []string
+		if patch.EnabledIf != nil {
+			enabledIf = patchVariables(*patch.EnabledIf, true)
+		}
+		for _, definition := range patch.Definitions {
+			names := slices.Clone(enabledIf)
+			for _, jsonPatch := range definition.JSONPatches {
+				switch valueFrom := jsonPatch.ValueFrom; {
+				case valueFrom == nil:
+				case valueFrom.Variable != nil:
+					names = append(names, patchVariables(*valueFrom.Variable, false)...)
+				case valueFrom.Template != nil:
+					names = append(names, patchVariables(*valueFrom.Template, true)...)
+				}
+			}
+			add(definition.Selector, names)
+		}
+	}
+	return reads
+}
+
+// variablesRead returns the changed variables a target reads, except those
+// the target overrides, which the cluster-level values do not reach.
+func (r patchReads) variablesRead(target string, changed []string, overrides []clusterv1.ClusterVariable) []string {
+	var names []string
+	for _, name := range changed {
+		if r.targets[target][name] && !slices.ContainsFunc(overrides, func(v clusterv1.ClusterVariable) bool { return v.Name == name }) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// previewVariableRollouts lists the machines that roll when the variables
+// changed are updated: those of the control plane and of the node pools
+// whose templates are changed by a patch reading one of the variables.
+func (s *EnhancedClusterService) previewVariableRollouts(ctx context.Context, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass, changed []string) ([]api.MachineRollout, []string, error) {
+	reads := clusterClassPatchReads(&clusterClass.Spec)
+	rollouts := []api.MachineRollout{}
+	var warnings []string
+
+	machineNames := func(machines *clusterv1.MachineList) []string {
+		names := make([]string, 0, len(machines.Items))
+		for _, machine := range machines.Items {
+			names = append(names, machine.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	if names := reads.variablesRead(patchTargetControlPlane, changed, nil); len(names) > 0 {
+		machines, err := s.kubeClient.ListControlPlaneMachines(ctx, cluster.Name)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list control plane machines")
+		}
+		rollouts = append(rollouts, api.MachineRollout{Target: patchTargetControlPlane, Variables: names, Machines: machineNames(machines)})
+	}
+
+	workers := cluster.Spec.Topology.Workers
+	if workers != nil && len(workers.MachineDeployments) > 0 {
+		mds, err := s.kubeClient.ListMachineDeployments(ctx, cluster.Name)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployments")
+		}
+		for _, topology := range workers.MachineDeployments {
+			var overrides []clusterv1.ClusterVariable
+			if topology.Variables != nil {
+				overrides = topology.Variables.Overrides
+			}
+			names := reads.variablesRead(patchTargetMachineDeployment+"/"+topology.Class, changed, overrides)
+			if len(names) == 0 {
+				continue
+			}
+			rollout := api.MachineRollout{Target: patchTargetMachineDeployment + "/" + topology.Name, Variables: names, Machines: []string{}}
+			for _, md := range mds.Items {
+				if md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] != topology.Name {
+					continue
+				}
+				machines, err := s.kubeClient.ListMachineDeploymentMachines(ctx, cluster.Name, md.Name)
+				if err != nil {
+					return nil, nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployment machines")
+				}
+				rollout.Machines = append(rollout.Machines, machineNames(machines)...)
+			}
+			rollouts = append(rollouts, rollout)
+		}
+	}
+	if workers != nil {
+		// Machine pools replace their instances through the provider rather
+		// than as Machines, so only the pool is listed
+		for _, topology := range workers.MachinePools {
+			var overrides []clusterv1.ClusterVariable
+			if topology.Variables != nil {
+				overrides = topology.Variables.Overrides
+			}
+			if names := reads.variablesRead(patchTargetMachinePool+"/"+topology.Class, changed, overrides); len(names) > 0 {
+				rollouts = append(rollouts, api.MachineRollout{Target: patchTargetMachinePool + "/" + topology.Name, Variables: names, Machines: []string{}})
+			}
+		}
+	}
+
+	if reads.external {
+		warnings = append(warnings, fmt.Sprintf(
+			"cluster template '%s' uses external patches, which may roll other machines when these variables change", clusterClass.Name))
+		return rollouts, warnings, nil
+	}
+	for _, name := range changed {
+		read := false
+		for _, variables := range reads.targets {
+			read = read || variables[name]
+		}
+		if !read {
+			warnings = append(warnings, fmt.Sprintf("variable %s is not read by any patch of cluster template '%s', so changing it has no effect", name, clusterClass.Name))
+		}
+	}
+	return rollouts, warnings, nil
+}
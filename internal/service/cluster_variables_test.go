@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// testVariablesClusterClass patches the region into the infrastructure
+// cluster, the instance type into workers and the audit flag into the
+// control plane.
+const testVariablesClusterClass = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: ClusterClass
+metadata:
+  name: aws-dev
+spec:
+  infrastructure:
+    ref: {apiVersion: infrastructure.cluster.x-k8s.io/v1beta2, kind: AWSClusterTemplate, name: aws-dev-cluster}
+  controlPlane:
+    ref: {apiVersion: controlplane.cluster.x-k8s.io/v1beta1, kind: KubeadmControlPlaneTemplate, name: aws-dev-control-plane}
+  workers:
+    machineDeployments:
+    - class: default-worker
+      template:
+        bootstrap:
+          ref: {apiVersion: bootstrap.cluster.x-k8s.io/v1beta1, kind: KubeadmConfigTemplate, name: aws-dev-worker}
+        infrastructure:
+          ref: {apiVersion: infrastructure.cluster.x-k8s.io/v1beta2, kind: AWSMachineTemplate, name: aws-dev-worker}
+  variables:
+  - name: region
+    required: true
+    schema:
+      openAPIV3Schema: {type: string}
+  - name: instanceType
+    required: true
+    schema:
+      openAPIV3Schema: {type: string, default: m5.large, enum: [m5.large, m5.xlarge]}
+  - name: auditLogging
+    schema:
+      openAPIV3Schema:
+        type: object
+        required: [enabled]
+        properties:
+          enabled: {type: boolean}
+          maxAgeDays: {type: integer, minimum: 1, maximum: 90}
+  - name: owner
+    schema:
+      openAPIV3Schema: {type: string, pattern: "^[a-z]+$", maxLength: 10}
+  patches:
+  - name: region
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+        kind: AWSClusterTemplate
+        matchResources: {infrastructureCluster: true}
+      jsonPatches:
+      - {op: add, path: /spec/template/spec/region, valueFrom: {variable: region}}
+  - name: instanceType
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+        kind: AWSMachineTemplate
+        matchResources:
+          machineDeploymentClass: {names: ["*-worker"]}
+      jsonPatches:
+      - {op: replace, path: /spec/template/spec/instanceType, valueFrom: {variable: instanceType}}
+  - name: auditLogging
+    enabledIf: "{{ .auditLogging.enabled }}"
+    definitions:
+    - selector:
+        apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+        kind: KubeadmControlPlaneTemplate
+        matchResources: {controlPlane: true}
+      jsonPatches:
+      - {op: add, path: /spec/template/spec/kubeadmConfigSpec/clusterConfiguration/apiServer/extraArgs/audit-log-path, value: /var/log/audit.log}
+`
+
+func setupClusterVariablesTest(t *testing.T) (*EnhancedClusterService, client.Client) {
+	t.Helper()
+	var clusterClass clusterv1.ClusterClass
+	require.NoError(t, yaml.Unmarshal([]byte(testVariablesClusterClass), &clusterClass))
+	clusterClass.Namespace = testNamespace
+
+	cluster := createTestCluster("dev", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Spec.Topology.Class = "aws-dev"
+	cluster.Spec.Topology.Variables = []clusterv1.ClusterVariable{
+		{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"m5.large"`)}},
+		{Name: "owner", Value: apiextensionsv1.JSON{Raw: []byte(`"platform"`)}},
+		{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west-2"`)}},
+	}
+	cluster.Spec.Topology.Workers = &clusterv1.WorkersTopology{
+		MachineDeployments: []clusterv1.MachineDeploymentTopology{
+			{Class: "default-worker", Name: "md-0"},
+			{Class: "default-worker", Name: "md-1", Variables: &clusterv1.MachineDeploymentVariables{
+				Overrides: []clusterv1.ClusterVariable{{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"m5.xlarge"`)}}},
+			}},
+		},
+	}
+
+	md := createTestMachineDeployment("dev-md-0-x7k2p", testNamespace, "dev", 2)
+	md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = "md-0"
+	return setupEnhancedTestService(t,
+		&clusterClass, cluster, md,
+		createTestMachine("dev-md-0-x7k2p-b", "dev", "dev-md-0-x7k2p"),
+		createTestMachine("dev-md-0-x7k2p-a", "dev", "dev-md-0-x7k2p"),
+		createTestControlPlaneMachine("dev-control-plane-1", "dev", true),
+	)
+}
+
+func TestEnhancedClusterService_UpdateClusterVariables(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run previews worker rollouts", func(t *testing.T) {
+		svc, _ := setupClusterVariablesTest(t)
+		output, err := svc.UpdateClusterVariables(ctx, api.UpdateClusterVariablesInput{
+			ClusterName: "dev",
+			Variables:   map[string]interface{}{"instanceType": "m5.xlarge"},
+			DryRun:      true,
+		})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Equal(t, `Cluster/dev: spec.topology.variables.instanceType changed from "m5.large" to "m5.xlarge"`, output.Diff)
+		assert.Equal(t, []api.MachineRollout{{
+			Target:    "MachineDeployment/md-0",
+			Variables: []string{"instanceType"},
+			Machines:  []string{"dev-md-0-x7k2p-a", "dev-md-0-x7k2p-b"},
+		}}, output.Rollouts)
+		assert.Empty(t, output.Warnings)
+		assert.Contains(t, output.Message, "would roll 2 machine(s)")
+	})
+
+	t.Run("feature flag rolls the control plane", func(t *testing.T) {
+		svc, fakeClient := setupClusterVariablesTest(t)
+		output, err := svc.UpdateClusterVariables(ctx, api.UpdateClusterVariablesInput{
+			ClusterName: "dev",
+			Variables:   map[string]interface{}{"auditLogging": map[string]interface{}{"enabled": true, "maxAgeDays": 30}},
+			Unset:       []string{"owner"},
+		})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.Equal(t, "Cluster/dev: spec.topology.variables.auditLogging set to {\"enabled\":true,\"maxAgeDays\":30}\n"+
+			"Cluster/dev: spec.topology.variables.owner removed (was \"platform\")", output.Diff)
+		assert.Equal(t, []api.MachineRollout{{
+			Target:    "ControlPlane",
+			Variables: []string{"auditLogging"},
+			Machines:  []string{"dev-control-plane-1"},
+		}}, output.Rollouts)
+		assert.Equal(t, []string{"variable owner is not read by any patch of cluster template 'aws-dev', so changing it has no effect"}, output.Warnings)
+
+		var cluster clusterv1.Cluster
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev"}, &cluster))
+		variables := map[string]interface{}{}
+		for _, variable := range cluster.Spec.Topology.Variables {
+			var value interface{}
+			require.NoError(t, json.Unmarshal(variable.Value.Raw, &value))
+			variables[variable.Name] = value
+		}
+		assert.Equal(t, map[string]interface{}{
+			"auditLogging": map[string]interface{}{"enabled": true, "maxAgeDays": float64(30)},
+			"instanceType": "m5.large",
+			"region":       "us-west-2",
+		}, variables)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		svc, _ := setupClusterVariablesTest(t)
+		output, err := svc.UpdateClusterVariables(ctx, api.UpdateClusterVariablesInput{
+			ClusterName: "dev",
+			Variables:   map[string]interface{}{"region": "us-west-2"},
+		})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Empty(t, output.Changes)
+		assert.Contains(t, output.Message, "nothing to change")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		svc, _ := setupClusterVariablesTest(t)
+		tests := []struct {
+			name    string
+			input   api.UpdateClusterVariablesInput
+			code    errors.ErrorCode
+			message string
+		}{
+			{name: "no variables", input: api.UpdateClusterVariablesInput{ClusterName: "dev"}, code: errors.CodeInvalidInput},
+			{
+				name:  "set and unset",
+				input: api.UpdateClusterVariablesInput{ClusterName: "dev", Variables: map[string]interface{}{"owner": "ops"}, Unset: []string{"owner"}},
+				code:  errors.CodeInvalidInput,
+			},
+			{
+				name:  "unknown cluster",
+				input: api.UpdateClusterVariablesInput{ClusterName: "prod", Variables: map[string]interface{}{"owner": "ops"}},
+				code:  errors.CodeNotFound,
+			},
+			{
+				name:    "undeclared variable",
+				input:   api.UpdateClusterVariablesInput{ClusterName: "dev", Variables: map[string]interface{}{"zone": "a"}},
+				code:    errors.CodeValidationFailed,
+				message: "zone is not a variable of the cluster template",
+			},
+			{
+				name:    "not an enum value",
+				input:   api.UpdateClusterVariablesInput{ClusterName: "dev", Variables: map[string]interface{}{"instanceType": "t3.micro"}},
+				code:    errors.CodeValidationFailed,
+				message: `instanceType must be one of "m5.large", "m5.xlarge"`,
+			},
+			{
+				name:    "unset required variable",
+				input:   api.UpdateClusterVariablesInput{ClusterName: "dev", Unset: []string{"region"}},
+				code:    errors.CodeValidationFailed,
+				message: "region is required and has no default",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.UpdateClusterVariables(ctx, tt.input)
+				require.Error(t, err)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+				assert.Contains(t, errors.GetUserMessage(err), tt.message)
+			})
+		}
+	})
+}
+
+func TestVariableValueProblems(t *testing.T) {
+	maximum, minItems := int64(90), int64(1)
+	schema := clusterv1.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"enabled"},
+		Properties: map[string]clusterv1.JSONSchemaProps{
+			"enabled":    {Type: "boolean"},
+			"maxAgeDays": {Type: "integer", Maximum: &maximum},
+			"paths": {
+				Type: "array", MinItems: &minItems, UniqueItems: true,
+				Items: &clusterv1.JSONSchemaProps{Type: "string", Pattern: "^/"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []string
+	}{
+		{name: "valid", value: map[string]interface{}{"enabled": true, "maxAgeDays": float64(90), "paths": []interface{}{"/var"}}},
+		{name: "wrong type", value: "yes", expected: []string{"audit must be of type object"}},
+		{name: "missing required property", value: map[string]interface{}{}, expected: []string{"audit.enabled is required"}},
+		{
+			name:     "undeclared property",
+			value:    map[string]interface{}{"enabled": true, "level": "debug"},
+			expected: []string{"audit.level is not a declared property"},
+		},
+		{
+			name:     "above maximum",
+			value:    map[string]interface{}{"enabled": true, "maxAgeDays": float64(91)},
+			expected: []string{"audit.maxAgeDays must be less than or equal to 90"},
+		},
+		{
+			name:     "not an integer",
+			value:    map[string]interface{}{"enabled": true, "maxAgeDays": 1.5},
+			expected: []string{"audit.maxAgeDays must be of type integer"},
+		},
+		{
+			name:  "invalid items",
+			value: map[string]interface{}{"enabled": true, "paths": []interface{}{"/var", "tmp", "/var"}},
+			expected: []string{
+				`audit.paths[1] must match the pattern "^/"`,
+				"audit.paths[2] duplicates an earlier item",
+			},
+		},
+		{
+			name:     "too few items",
+			value:    map[string]interface{}{"enabled": true, "paths": []interface{}{}},
+			expected: []string{"audit.paths must have at least 1 item(s)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, variableValueProblems("audit", tt.value, schema))
+		})
+	}
+}
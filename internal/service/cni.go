@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// CNIMethodHelm installs the CNI plugin with a HelmChartProxy of the
+	// Cluster API Add-on Provider for Helm (CAAPH).
+	CNIMethodHelm = "helm"
+
+	// CNIMethodManifest installs the CNI plugin with a ClusterResourceSet
+	// applying the upstream manifest.
+	CNIMethodManifest = "manifest"
+
+	// helmChartProxyCRDName is the CRD installed with CAAPH.
+	helmChartProxyCRDName = "helmchartproxies.addons.cluster.x-k8s.io"
+
+	// clusterNameLabel selects a single cluster for the addon resources.
+	clusterNameLabel = "cluster.x-k8s.io/cluster-name"
+
+	// maxManifestSize keeps CNI manifests within the ConfigMap size limit.
+	maxManifestSize = 1 << 20
+)
+
+// cniVersionPattern matches the plugin versions accepted by install_cni.
+var cniVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+$`)
+
+// cniPlugin describes how a CNI plugin is delivered to workload clusters.
+type cniPlugin struct {
+	defaultVersion string
+	repoURL        string
+	chartName      string
+	namespace      string
+	valuesTemplate string
+	manifestURL    string // format string taking the version; empty if Helm is required
+}
+
+// cniPlugins lists the CNI plugins install_cni can install.
+var cniPlugins = map[string]cniPlugin{
+	"calico": {
+		defaultVersion: "v3.28.2",
+		repoURL:        "https://docs.tigera.io/calico/charts",
+		chartName:      "tigera-operator",
+		namespace:      "tigera-operator",
+		valuesTemplate: `installation:
+  cni:
+    type: Calico
+  calicoNetwork:
+    bgp: Disabled
+    ipPools:{{range $i, $cidr := .Cluster.spec.clusterNetwork.pods.cidrBlocks }}
+    - cidr: {{ $cidr }}
+      encapsulation: VXLAN{{end}}
+`,
+		manifestURL: "https://raw.githubusercontent.com/projectcalico/calico/%s/manifests/calico.yaml",
+	},
+	"cilium": {
+		defaultVersion: "1.16.3",
+		repoURL:        "https://helm.cilium.io/",
+		chartName:      "cilium",
+		namespace:      "kube-system",
+		valuesTemplate: `ipam:
+  mode: kubernetes
+`,
+	},
+}
+
+// manifestFetcher downloads a manifest from a URL.
+type manifestFetcher func(ctx context.Context, url string) ([]byte, error)
+
+// fetchManifestHTTP downloads a manifest over HTTP, refusing manifests too
+// large for a ConfigMap.
+func fetchManifestHTTP(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestSize {
+		return nil, fmt.Errorf("manifest %s is larger than %d bytes", url, maxManifestSize)
+	}
+	return data, nil
+}
+
+// InstallCNI installs a CNI plugin into a workload cluster, so that its nodes
+// become Ready. The plugin is delivered from the management cluster, either
+// with a CAAPH HelmChartProxy or with a ClusterResourceSet applying the
+// upstream manifest, and both select the cluster by its cluster-name label.
+func (s *EnhancedClusterService) InstallCNI(ctx context.Context, input api.InstallCNIInput) (*api.InstallCNIOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("InstallCNI").WithCluster(input.ClusterName, "")
+	logger.Info("Installing CNI plugin", "plugin", input.Plugin, "method", input.Method)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	plugin, ok := cniPlugins[input.Plugin]
+	if !ok {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("unsupported CNI plugin '%s', must be one of: %v", input.Plugin, sortedKeys(cniPlugins, nil)))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Method != "" && input.Method != CNIMethodHelm && input.Method != CNIMethodManifest {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("unsupported method '%s', must be %s or %s", input.Method, CNIMethodHelm, CNIMethodManifest))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	version := input.Version
+	if version == "" {
+		version = plugin.defaultVersion
+	}
+	if !cniVersionPattern.MatchString(version) {
+		err := errors.New(errors.CodeInvalidInput, fmt.Sprintf("invalid %s version '%s'", input.Plugin, version))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	installCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(installCtx, input.ClusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+
+	// A reachable cluster that already runs a CNI plugin must not get a second one
+	if workloadClient, err := s.newWorkloadClient(installCtx, input.ClusterName); err == nil {
+		if cni, err := detectCNI(installCtx, workloadClient); err == nil && cni.Installed {
+			err := errors.New(errors.CodeAlreadyExists,
+				fmt.Sprintf("cluster '%s' already runs the %s CNI plugin", input.ClusterName, cni.Component))
+			logger.WithError(err).Warn("CNI plugin already installed")
+			return nil, err
+		}
+	}
+
+	caaphInstalled := false
+	if _, err := s.kubeClient.GetCustomResourceDefinition(installCtx, helmChartProxyCRDName); err == nil {
+		caaphInstalled = true
+	}
+	method := input.Method
+	if method == "" {
+		method = CNIMethodManifest
+		if caaphInstalled || plugin.manifestURL == "" {
+			method = CNIMethodHelm
+		}
+	}
+	if method == CNIMethodHelm && !caaphInstalled {
+		err := errors.New(errors.CodePreconditionFailed,
+			"the Cluster API Add-on Provider for Helm (CAAPH) is not installed on the management cluster")
+		logger.WithError(err).Error("Cannot install CNI plugin with Helm")
+		return nil, err
+	}
+	if method == CNIMethodManifest && plugin.manifestURL == "" {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("%s can only be installed with Helm", input.Plugin))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	var objects []client.Object
+	if method == CNIMethodHelm {
+		objects = []client.Object{buildHelmChartProxy(cluster.Name, cluster.Namespace, input.Plugin, version, plugin)}
+	} else {
+		url := fmt.Sprintf(plugin.manifestURL, version)
+		manifest, err := s.fetchManifest(installCtx, url)
+		if err != nil {
+			logger.WithError(err).Error("Failed to download CNI manifest", "url", url)
+			return nil, errors.Wrap(err, errors.CodeDependencyFailure, fmt.Sprintf("failed to download %s manifest", input.Plugin))
+		}
+		objects = buildCNIResourceSet(cluster.Name, cluster.Namespace, input.Plugin, string(manifest))
+	}
+
+	// Addon resources select the cluster by its cluster-name label
+	if cluster.Labels[clusterNameLabel] != cluster.Name {
+		if cluster.Labels == nil {
+			cluster.Labels = map[string]string{}
+		}
+		cluster.Labels[clusterNameLabel] = cluster.Name
+		if err := s.kubeClient.UpdateCluster(installCtx, cluster); err != nil {
+			logger.WithError(err).Error("Failed to label cluster")
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to label cluster")
+		}
+	}
+
+	output := &api.InstallCNIOutput{
+		ClusterName: input.ClusterName,
+		Plugin:      input.Plugin,
+		Method:      method,
+		Version:     version,
+		Resources:   []string{},
+	}
+	for _, obj := range objects {
+		ref := fmt.Sprintf("%s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+		if err := s.kubeClient.CreateObject(installCtx, obj); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				err := errors.New(errors.CodeAlreadyExists,
+					fmt.Sprintf("%s already exists - the CNI plugin is already being installed", ref))
+				logger.WithError(err).Warn("CNI plugin already requested")
+				return nil, err
+			}
+			logger.WithError(err).Error("Failed to create CNI resource", "resource", ref)
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to create %s", ref))
+		}
+		output.Resources = append(output.Resources, ref)
+	}
+
+	output.Message = fmt.Sprintf("Installing %s %s into cluster '%s' with %s. Nodes become Ready once the plugin is running - check with probe_cluster_api.",
+		input.Plugin, version, input.ClusterName, cniMethodDescription(method))
+	logger.Info("CNI plugin install requested", "plugin", input.Plugin, "method", method, "version", version)
+	return output, nil
+}
+
+// cniMethodDescription names the mechanism behind an install method.
+func cniMethodDescription(method string) string {
+	if method == CNIMethodHelm {
+		return "a CAAPH HelmChartProxy"
+	}
+	return "a ClusterResourceSet"
+}
+
+// buildHelmChartProxy builds the CAAPH HelmChartProxy installing a CNI plugin
+// chart into a single cluster.
+func buildHelmChartProxy(clusterName, namespace, pluginName, version string, plugin cniPlugin) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "addons.cluster.x-k8s.io/v1alpha1",
+		"kind":       "HelmChartProxy",
+		"metadata": map[string]interface{}{
+			"name":      clusterName + "-" + pluginName,
+			"namespace": namespace,
+			"labels": map[string]interface{}{
+				clusterNameLabel: clusterName,
+			},
+		},
+		"spec": map[string]interface{}{
+			"clusterSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					clusterNameLabel: clusterName,
+				},
+			},
+			"repoURL":        plugin.repoURL,
+			"chartName":      plugin.chartName,
+			"version":        version,
+			"namespace":      plugin.namespace,
+			"releaseName":    pluginName,
+			"valuesTemplate": plugin.valuesTemplate,
+			"options":        map[string]interface{}{"install": map[string]interface{}{"createNamespace": true}},
+		},
+	}}
+}
+
+// buildCNIResourceSet builds the ConfigMap holding a CNI manifest and the
+// ClusterResourceSet applying it to a single cluster.
+func buildCNIResourceSet(clusterName, namespace, pluginName, manifest string) []client.Object {
+	name := clusterName + "-" + pluginName
+	labels := map[string]string{clusterNameLabel: clusterName}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Data:       map[string]string{pluginName + ".yaml": manifest},
+	}
+	resourceSet := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "addons.cluster.x-k8s.io/v1beta1",
+		"kind":       "ClusterResourceSet",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"labels": map[string]interface{}{
+				clusterNameLabel: clusterName,
+			},
+		},
+		"spec": map[string]interface{}{
+			"clusterSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					clusterNameLabel: clusterName,
+				},
+			},
+			"strategy": "ApplyOnce",
+			"resources": []interface{}{
+				map[string]interface{}{"kind": "ConfigMap", "name": name},
+			},
+		},
+	}}
+	return []client.Object{configMap, resourceSet}
+}
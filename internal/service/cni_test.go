@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_InstallCNI(t *testing.T) {
+	ctx := context.Background()
+	caaph := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: helmChartProxyCRDName}}
+
+	t.Run("helm with CAAPH installed", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, createTestCluster("cni-cluster", testNamespace, "Provisioned"), caaph)
+
+		output, err := svc.InstallCNI(ctx, api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "cilium"})
+		require.NoError(t, err)
+		assert.Equal(t, CNIMethodHelm, output.Method)
+		assert.Equal(t, "1.16.3", output.Version)
+		assert.Equal(t, []string{"HelmChartProxy/cni-cluster-cilium"}, output.Resources)
+
+		proxy := &unstructured.Unstructured{}
+		proxy.SetGroupVersionKind(schema.GroupVersionKind{Group: "addons.cluster.x-k8s.io", Version: "v1alpha1", Kind: "HelmChartProxy"})
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "cni-cluster-cilium"}, proxy))
+		chart, _, _ := unstructured.NestedString(proxy.Object, "spec", "chartName")
+		assert.Equal(t, "cilium", chart)
+		selector, _, _ := unstructured.NestedStringMap(proxy.Object, "spec", "clusterSelector", "matchLabels")
+		assert.Equal(t, map[string]string{clusterNameLabel: "cni-cluster"}, selector)
+
+		cluster := &clusterv1.Cluster{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "cni-cluster"}, cluster))
+		assert.Equal(t, "cni-cluster", cluster.Labels[clusterNameLabel])
+
+		_, err = svc.InstallCNI(ctx, api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "cilium"})
+		assert.Equal(t, errors.CodeAlreadyExists, errors.GetErrorCode(err))
+	})
+
+	t.Run("manifest without CAAPH", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, createTestCluster("cni-cluster", testNamespace, "Provisioned"))
+		var fetched string
+		svc.fetchManifest = func(ctx context.Context, url string) ([]byte, error) {
+			fetched = url
+			return []byte("kind: DaemonSet"), nil
+		}
+
+		output, err := svc.InstallCNI(ctx, api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "calico", Version: "v3.29.0"})
+		require.NoError(t, err)
+		assert.Equal(t, CNIMethodManifest, output.Method)
+		assert.Equal(t, "https://raw.githubusercontent.com/projectcalico/calico/v3.29.0/manifests/calico.yaml", fetched)
+		assert.Equal(t, []string{"ConfigMap/cni-cluster-calico", "ClusterResourceSet/cni-cluster-calico"}, output.Resources)
+
+		configMap := &corev1.ConfigMap{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "cni-cluster-calico"}, configMap))
+		assert.Equal(t, "kind: DaemonSet", configMap.Data["calico.yaml"])
+	})
+
+	t.Run("manifest download failure", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, createTestCluster("cni-cluster", testNamespace, "Provisioned"))
+		svc.fetchManifest = func(ctx context.Context, url string) ([]byte, error) {
+			return nil, fmt.Errorf("connection refused")
+		}
+
+		_, err := svc.InstallCNI(ctx, api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "calico"})
+		assert.Equal(t, errors.CodeDependencyFailure, errors.GetErrorCode(err))
+	})
+
+	tests := []struct {
+		name  string
+		input api.InstallCNIInput
+		code  errors.ErrorCode
+	}{
+		{name: "missing cluster name", input: api.InstallCNIInput{Plugin: "calico"}, code: errors.CodeInvalidInput},
+		{name: "unsupported plugin", input: api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "flannel"}, code: errors.CodeInvalidInput},
+		{name: "unsupported method", input: api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "calico", Method: "kubectl"}, code: errors.CodeInvalidInput},
+		{name: "invalid version", input: api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "calico", Version: "latest"}, code: errors.CodeInvalidInput},
+		{name: "cluster not found", input: api.InstallCNIInput{ClusterName: "missing", Plugin: "calico"}, code: errors.CodeNotFound},
+		{name: "helm without CAAPH", input: api.InstallCNIInput{ClusterName: "cni-cluster", Plugin: "cilium"}, code: errors.CodePreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, createTestCluster("cni-cluster", testNamespace, "Provisioned"))
+
+			_, err := svc.InstallCNI(ctx, tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/advisory"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// maxKubeletSkew is how many minor releases a kubelet may be older than the
+// API server.
+const maxKubeletSkew = 3
+
+// controlPlaneComponents are the kubeadm static Pods reported as control
+// plane components.
+var controlPlaneComponents = []string{"kube-apiserver", "kube-controller-manager", "kube-scheduler", "etcd"}
+
+// SetVersionAdvisor configures the advisor Kubernetes versions are checked
+// against for end of life and CVEs, replacing the bundled dataset.
+func (s *EnhancedClusterService) SetVersionAdvisor(advisor *advisory.Advisor) {
+	s.advisor = advisor
+}
+
+// GetClusterComponentVersions reports the versions of the software in a
+// workload cluster: the kubelet and container runtime of each node, the
+// control plane components and the addons in kube-system, along with version
+// skew and releases at or near their end of life.
+func (s *EnhancedClusterService) GetClusterComponentVersions(ctx context.Context, input api.GetClusterComponentVersionsInput) (*api.GetClusterComponentVersionsOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetClusterComponentVersions").WithCluster(input.ClusterName, "")
+	logger.Debug("Getting cluster component versions")
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	versionsCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(versionsCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := componentVersions(versionsCtx, workloadClient, s.advisor, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("Failed to read component versions")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to read the component versions of the workload cluster")
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Got cluster component versions", "server_version", output.ServerVersion, "findings", len(output.Findings))
+	return output, nil
+}
+
+// componentVersions reads the versions of the nodes, control plane and addons
+// of a workload cluster and checks them against the API server version and
+// the advisories of their releases.
+func componentVersions(ctx context.Context, workloadClient *kube.WorkloadClient, advisor *advisory.Advisor, now time.Time) (*api.GetClusterComponentVersionsOutput, error) {
+	serverVersion, err := workloadClient.ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	output := &api.GetClusterComponentVersionsOutput{
+		ServerVersion: serverVersion,
+		Nodes:         []api.NodeVersions{},
+		ControlPlane:  []api.ComponentVersion{},
+		Addons:        []api.ComponentVersion{},
+		Findings:      []api.VersionFinding{},
+	}
+
+	nodes, err := workloadClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Items {
+		role := "worker"
+		if _, ok := node.Labels["node-role.kubernetes.io/control-plane"]; ok {
+			role = "control-plane"
+		}
+		output.Nodes = append(output.Nodes, api.NodeVersions{
+			Name:             node.Name,
+			Role:             role,
+			KubeletVersion:   node.Status.NodeInfo.KubeletVersion,
+			ContainerRuntime: node.Status.NodeInfo.ContainerRuntimeVersion,
+			KernelVersion:    node.Status.NodeInfo.KernelVersion,
+			OSImage:          node.Status.NodeInfo.OSImage,
+		})
+	}
+
+	// kubeadm runs the control plane as static Pods; a managed control plane
+	// has none
+	pods, err := workloadClient.ListPods(ctx, "kube-system", "tier=control-plane")
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		component := pod.Labels["component"]
+		if !slices.Contains(controlPlaneComponents, component) {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			output.ControlPlane = append(output.ControlPlane, api.ComponentVersion{
+				Name:      component,
+				Category:  "control-plane",
+				Kind:      "Pod",
+				Namespace: pod.Namespace,
+				Node:      pod.Spec.NodeName,
+				Container: container.Name,
+				Image:     container.Image,
+				Version:   containerImageTag(container.Image),
+			})
+		}
+	}
+
+	daemonSets, err := workloadClient.ListDaemonSets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ds := range daemonSets.Items {
+		// CNI plugins may run outside kube-system, e.g. in calico-system
+		if _, cni := cniDaemonSets[ds.Name]; ds.Namespace != "kube-system" && !cni {
+			continue
+		}
+		output.Addons = append(output.Addons, addonVersions("DaemonSet", ds.Namespace, ds.Name, ds.Spec.Template.Spec)...)
+	}
+	deployments, err := workloadClient.ListDeployments(ctx, "kube-system")
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		output.Addons = append(output.Addons, addonVersions("Deployment", deployment.Namespace, deployment.Name, deployment.Spec.Template.Spec)...)
+	}
+
+	output.Findings = versionFindings(output, advisor, now)
+	if serverAdvisory, ok := advisor.Check(serverVersion, now); ok {
+		output.Advisory = toAPIVersionAdvisory(serverAdvisory)
+	}
+	output.Message = fmt.Sprintf("API server %s, %d nodes, %d control plane components and %d addon containers",
+		serverVersion, len(output.Nodes), len(output.ControlPlane), len(output.Addons))
+	if len(output.Findings) > 0 {
+		output.Message += fmt.Sprintf("; %d findings", len(output.Findings))
+	}
+	return output, nil
+}
+
+// addonVersions returns the versions of the containers of an addon workload.
+func addonVersions(kind, namespace, name string, spec corev1.PodSpec) []api.ComponentVersion {
+	versions := make([]api.ComponentVersion, 0, len(spec.Containers))
+	for _, container := range spec.Containers {
+		versions = append(versions, api.ComponentVersion{
+			Name:      name,
+			Category:  addonCategory(name),
+			Kind:      kind,
+			Namespace: namespace,
+			Container: container.Name,
+			Image:     container.Image,
+			Version:   containerImageTag(container.Image),
+		})
+	}
+	return versions
+}
+
+// addonCategory classifies an addon by the name of its workload.
+func addonCategory(name string) string {
+	switch {
+	case cniDaemonSets[name] != "":
+		return "cni"
+	case strings.Contains(name, "csi"):
+		return "csi"
+	case name == "coredns" || name == "kube-dns":
+		return "dns"
+	case name == "kube-proxy":
+		return "proxy"
+	default:
+		return "addon"
+	}
+}
+
+// versionFindings checks the kubelets and control plane components against
+// the version skew policy, and the Kubernetes versions in use against their
+// advisories.
+func versionFindings(output *api.GetClusterComponentVersionsOutput, advisor *advisory.Advisor, now time.Time) []api.VersionFinding {
+	findings := []api.VersionFinding{}
+	server, err := version.ParseGeneric(output.ServerVersion)
+	if err != nil {
+		return findings
+	}
+	kubeletVersions := map[string]bool{}
+	for _, node := range output.Nodes {
+		kubelet, err := version.ParseGeneric(node.KubeletVersion)
+		if err != nil {
+			continue
+		}
+		kubeletVersions[node.KubeletVersion] = true
+
+		component := "kubelet on " + node.Name
+		switch skew := int(server.Minor()) - int(kubelet.Minor()); {
+		case skew < 0:
+			findings = append(findings, api.VersionFinding{Severity: "critical", Component: component,
+				Message: fmt.Sprintf("kubelet %s is newer than the API server %s, which is not supported", node.KubeletVersion, output.ServerVersion)})
+		case skew > maxKubeletSkew:
+			findings = append(findings, api.VersionFinding{Severity: "critical", Component: component,
+				Message: fmt.Sprintf("kubelet %s is %d minor releases behind the API server %s, more than the %d supported",
+					node.KubeletVersion, skew, output.ServerVersion, maxKubeletSkew)})
+		}
+	}
+	if len(kubeletVersions) > 1 {
+		versions := make([]string, 0, len(kubeletVersions))
+		for v := range kubeletVersions {
+			versions = append(versions, v)
+		}
+		slices.Sort(versions)
+		findings = append(findings, api.VersionFinding{Severity: "warning", Component: "kubelet",
+			Message: fmt.Sprintf("nodes run %d kubelet versions (%s); expected only during an upgrade", len(versions), strings.Join(versions, ", "))})
+	}
+
+	for _, component := range slices.Concat(output.ControlPlane, output.Addons) {
+		if component.Name == "etcd" || (component.Category != "control-plane" && component.Category != "proxy") {
+			continue
+		}
+		componentVersion, err := version.ParseGeneric(component.Version)
+		if err != nil {
+			continue
+		}
+		if componentVersion.Major() != server.Major() || componentVersion.Minor() != server.Minor() {
+			name := component.Name
+			if component.Node != "" {
+				name += " on " + component.Node
+			}
+			findings = append(findings, api.VersionFinding{Severity: "warning", Component: name,
+				Message: fmt.Sprintf("%s runs %s, a different minor release from the API server %s", component.Name, component.Version, output.ServerVersion)})
+		}
+	}
+
+	versions := []string{output.ServerVersion}
+	for v := range kubeletVersions {
+		if v != output.ServerVersion {
+			versions = append(versions, v)
+		}
+	}
+	slices.Sort(versions[1:])
+	releases := map[string]bool{}
+	for _, v := range versions {
+		advice, ok := advisor.Check(v, now)
+		if !ok {
+			continue
+		}
+		if !releases[advice.Release] {
+			releases[advice.Release] = true
+			switch advice.Status {
+			case advisory.StatusEndOfLife:
+				findings = append(findings, api.VersionFinding{Severity: "critical", Component: "kubernetes " + advice.Release,
+					Message: fmt.Sprintf("Kubernetes %s reached its end of life on %s and no longer receives fixes%s",
+						advice.Release, advice.EndOfLife, upgradeHint(advice))})
+			case advisory.StatusEndOfLifeSoon:
+				findings = append(findings, api.VersionFinding{Severity: "warning", Component: "kubernetes " + advice.Release,
+					Message: fmt.Sprintf("Kubernetes %s reaches its end of life on %s%s", advice.Release, advice.EndOfLife, upgradeHint(advice))})
+			}
+		}
+		if len(advice.CVEs) > 0 {
+			ids := make([]string, 0, len(advice.CVEs))
+			for _, cve := range advice.CVEs {
+				ids = append(ids, fmt.Sprintf("%s (%s)", cve.ID, cve.Severity))
+			}
+			findings = append(findings, api.VersionFinding{Severity: "critical", Component: "kubernetes " + v,
+				Message: fmt.Sprintf("Kubernetes %s is affected by %s%s", v, strings.Join(ids, ", "), upgradeHint(advice))})
+		}
+	}
+	return findings
+}
+
+// upgradeHint suggests the release an advisory recommends upgrading to.
+func upgradeHint(advice advisory.Advisory) string {
+	if advice.UpgradeTo == "" {
+		return ""
+	}
+	return "; upgrade to " + advice.UpgradeTo
+}
+
+// toAPIVersionAdvisory converts an advisory to its API representation.
+func toAPIVersionAdvisory(advice advisory.Advisory) *api.VersionAdvisory {
+	cves := make([]api.KubernetesCVE, 0, len(advice.CVEs))
+	for _, cve := range advice.CVEs {
+		cves = append(cves, api.KubernetesCVE{ID: cve.ID, Severity: cve.Severity, Summary: cve.Summary, FixedIn: cve.FixedIn})
+	}
+	return &api.VersionAdvisory{
+		Release:   advice.Release,
+		EndOfLife: advice.EndOfLife,
+		Status:    advice.Status,
+		CVEs:      cves,
+		UpgradeTo: advice.UpgradeTo,
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/advisory"
+)
+
+func TestComponentVersions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.August, 15, 0, 0, 0, 0, time.UTC)
+
+	node := func(name, kubelet string, controlPlane bool) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+			Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion:          kubelet,
+				ContainerRuntimeVersion: "containerd://1.7.22",
+			}},
+		}
+		if controlPlane {
+			node.Labels["node-role.kubernetes.io/control-plane"] = ""
+		}
+		return node
+	}
+	staticPod := func(component, nodeName, image string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      component + "-" + nodeName,
+				Namespace: "kube-system",
+				Labels:    map[string]string{"tier": "control-plane", "component": component},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: component, Image: image}},
+			},
+		}
+	}
+	daemonSet := func(name, namespace, image string) *appsv1.DaemonSet {
+		ds := createTestDaemonSet(name, namespace, 1, 1)
+		ds.Spec.Template.Spec.Containers = []corev1.Container{{Name: name, Image: image}}
+		return ds
+	}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		findings []api.VersionFinding
+	}{
+		{
+			name: "consistent cluster",
+			objects: []runtime.Object{
+				node("cp-1", "v1.31.2", true),
+				node("worker-1", "v1.31.2", false),
+				staticPod("kube-apiserver", "cp-1", "registry.k8s.io/kube-apiserver:v1.31.2"),
+				staticPod("etcd", "cp-1", "registry.k8s.io/etcd:3.5.15-0"),
+				daemonSet("kube-proxy", "kube-system", "registry.k8s.io/kube-proxy:v1.31.2"),
+				daemonSet("calico-node", "calico-system", "docker.io/calico/node:v3.28.1"),
+				createTestAddonDeployment("coredns", "kube-system", 2, 2),
+			},
+			findings: []api.VersionFinding{
+				{Severity: "warning", Component: "kubernetes 1.31", Message: "Kubernetes 1.31 reaches its end of life on 2025-10-28; upgrade to 1.32"},
+			},
+		},
+		{
+			name: "skewed kubelets and control plane",
+			objects: []runtime.Object{
+				node("cp-1", "v1.31.2", true),
+				node("worker-1", "v1.32.0", false),
+				node("worker-2", "v1.27.4", false),
+				staticPod("kube-scheduler", "cp-1", "registry.k8s.io/kube-scheduler:v1.30.5"),
+			},
+			findings: []api.VersionFinding{
+				{Severity: "critical", Component: "kubelet on worker-1", Message: "kubelet v1.32.0 is newer than the API server v1.31.2, which is not supported"},
+				{Severity: "critical", Component: "kubelet on worker-2", Message: "kubelet v1.27.4 is 4 minor releases behind the API server v1.31.2, more than the 3 supported"},
+				{Severity: "warning", Component: "kubelet", Message: "nodes run 3 kubelet versions (v1.27.4, v1.31.2, v1.32.0); expected only during an upgrade"},
+				{Severity: "warning", Component: "kube-scheduler on cp-1", Message: "kube-scheduler runs v1.30.5, a different minor release from the API server v1.31.2"},
+				{Severity: "warning", Component: "kubernetes 1.31", Message: "Kubernetes 1.31 reaches its end of life on 2025-10-28; upgrade to 1.32"},
+				{Severity: "critical", Component: "kubernetes 1.27", Message: "Kubernetes 1.27 reached its end of life on 2024-06-28 and no longer receives fixes; upgrade to 1.32"},
+				{Severity: "critical", Component: "kubernetes v1.27.4", Message: "Kubernetes v1.27.4 is affected by CVE-2023-3676 (high), CVE-2023-3955 (high), CVE-2023-5528 (high), CVE-2024-10220 (high); upgrade to 1.32"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := componentVersions(ctx, newTestWorkloadClient(tt.objects...), advisory.New(nil, ""), now)
+			require.NoError(t, err)
+
+			assert.Equal(t, "v1.31.2", output.ServerVersion)
+			assert.Equal(t, tt.findings, output.Findings)
+			require.NotNil(t, output.Advisory)
+			assert.Equal(t, advisory.StatusEndOfLifeSoon, output.Advisory.Status)
+		})
+	}
+
+	t.Run("inventory", func(t *testing.T) {
+		output, err := componentVersions(ctx, newTestWorkloadClient(
+			node("cp-1", "v1.31.2", true),
+			staticPod("kube-apiserver", "cp-1", "registry.k8s.io/kube-apiserver:v1.31.2"),
+			daemonSet("calico-node", "calico-system", "docker.io/calico/node:v3.28.1"),
+			daemonSet("fluent-bit", "logging", "fluent/fluent-bit:3.1"),
+		), advisory.New(nil, ""), now)
+		require.NoError(t, err)
+
+		require.Len(t, output.Nodes, 1)
+		assert.Equal(t, "control-plane", output.Nodes[0].Role)
+		assert.Equal(t, "containerd://1.7.22", output.Nodes[0].ContainerRuntime)
+
+		require.Len(t, output.ControlPlane, 1)
+		assert.Equal(t, "kube-apiserver", output.ControlPlane[0].Name)
+		assert.Equal(t, "cp-1", output.ControlPlane[0].Node)
+		assert.Equal(t, "v1.31.2", output.ControlPlane[0].Version)
+
+		require.Len(t, output.Addons, 1, "only addons in kube-system and CNI plugins are reported")
+		assert.Equal(t, "cni", output.Addons[0].Category)
+		assert.Equal(t, "v3.28.1", output.Addons[0].Version)
+	})
+}
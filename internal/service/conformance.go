@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// RunConformanceKind is the kind of async operation conformance runs run as.
+	RunConformanceKind = "run_conformance"
+
+	// Stages of a conformance run.
+	conformanceStageRun     = "run"
+	conformanceStageResults = "results"
+
+	// defaultConformanceMode is the Sonobuoy mode of runs that do not ask
+	// for one: a single test, enough to show the cluster works.
+	defaultConformanceMode = "quick"
+
+	// defaultConformanceTimeout bounds a run when no timeout is configured;
+	// certified-conformance runs take one to two hours.
+	defaultConformanceTimeout = 3 * time.Hour
+
+	// defaultConformancePollInterval is how often the progress of a run is
+	// checked when no interval is configured.
+	defaultConformancePollInterval = 30 * time.Second
+
+	// sonobuoyLaunchTimeout bounds `sonobuoy run`, which only creates the
+	// aggregator in the workload cluster.
+	sonobuoyLaunchTimeout = 2 * time.Minute
+
+	// sonobuoyCommandTimeout bounds `sonobuoy status` and `sonobuoy delete`.
+	sonobuoyCommandTimeout = 5 * time.Minute
+
+	// maxReportedFailures caps the failed tests listed in the results.
+	maxReportedFailures = 10
+)
+
+// conformanceModes are the Sonobuoy modes run_conformance accepts.
+var conformanceModes = []string{"quick", "non-disruptive-conformance", "certified-conformance"}
+
+// ConformanceOptions configures how run_conformance runs Sonobuoy.
+type ConformanceOptions struct {
+	// SonobuoyPath is the sonobuoy binary.
+	SonobuoyPath string
+
+	// Timeout bounds a run, from its launch until its results are in.
+	Timeout time.Duration
+
+	// PollInterval is how often the progress of a run is checked.
+	PollInterval time.Duration
+}
+
+// sonobuoyRunner runs sonobuoy against the workload cluster of a kubeconfig
+// with the given arguments and returns its combined output.
+type sonobuoyRunner func(ctx context.Context, kubeconfig []byte, args ...string) ([]byte, error)
+
+// SetConformanceOptions enables run_conformance, running the sonobuoy binary
+// against workload clusters with their kubeconfig.
+func (s *EnhancedClusterService) SetConformanceOptions(opts ConformanceOptions) {
+	if opts.SonobuoyPath == "" {
+		opts.SonobuoyPath = "sonobuoy"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultConformanceTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultConformancePollInterval
+	}
+	s.conformanceOptions = opts
+	s.runSonobuoy = func(ctx context.Context, kubeconfig []byte, args ...string) ([]byte, error) {
+		file, err := os.CreateTemp("", "capi-mcp-sonobuoy-*.kubeconfig")
+		if err != nil {
+			return nil, fmt.Errorf("failed to write kubeconfig: %w", err)
+		}
+		defer os.Remove(file.Name())
+		_, err = file.Write(kubeconfig)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to write kubeconfig: %w", err)
+		}
+
+		// #nosec G204 -- binary path comes from server configuration and arguments are validated
+		cmd := exec.CommandContext(ctx, opts.SonobuoyPath, append(args, "--kubeconfig", file.Name())...)
+		return cmd.CombinedOutput()
+	}
+}
+
+// RunConformance launches a Sonobuoy run against a Provisioned workload
+// cluster and follows it in the background as an async operation: the run
+// stage reports the progress of the tests, and the results stage the number
+// of tests that passed and failed. The run is deleted from the cluster once
+// its results are in.
+func (s *EnhancedClusterService) RunConformance(ctx context.Context, input api.RunConformanceInput) (*api.RunConformanceOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RunConformance").WithCluster(input.ClusterName, "")
+	logger.Info("Running conformance tests", "mode", input.Mode)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Mode == "" {
+		input.Mode = defaultConformanceMode
+	}
+	if !slices.Contains(conformanceModes, input.Mode) {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("mode must be one of: %s", strings.Join(conformanceModes, ", "))).
+			WithDetails("field", "mode")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.runSonobuoy == nil {
+		err := errors.New(errors.CodeUnavailable, "conformance runs are not enabled on this server").
+			WithDetails("hint", "set CONFORMANCE_ENABLED=true and install sonobuoy")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.asyncOperations == nil {
+		err := errors.New(errors.CodeUnavailable, "async operations are not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	launchCtx, cancel := budget.Sub(ctx, sonobuoyLaunchTimeout)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(launchCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if status := api.ClusterStatusFromPhase(cluster.Status.Phase); status != api.ClusterStatusReady {
+		err := errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' is %s; conformance tests need a provisioned cluster", input.ClusterName, status))
+		logger.WithError(err).Error("Cluster not provisioned")
+		return nil, err
+	}
+
+	kubeconfig, err := s.GetClusterKubeconfig(launchCtx, api.GetClusterKubeconfigInput{ClusterName: input.ClusterName})
+	if err != nil {
+		logger.WithError(err).Error("Failed to get kubeconfig")
+		return nil, err
+	}
+
+	launchOutput, err := s.runSonobuoy(launchCtx, []byte(kubeconfig.Kubeconfig), "run", "--mode", input.Mode)
+	if err != nil {
+		logger.WithError(err).Error("sonobuoy run failed")
+		return nil, sonobuoyError(launchCtx, err, launchOutput, "failed to launch conformance tests")
+	}
+
+	run, err := s.startAsyncOperation(launchCtx, RunConformanceKind, input.ClusterName, map[string]string{
+		"mode": input.Mode,
+	}, conformanceStageRun, conformanceStageResults)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start conformance operation")
+		s.goBackground(ctx, func(ctx context.Context) {
+			s.deleteConformanceRun(ctx, input.ClusterName, []byte(kubeconfig.Kubeconfig))
+		})
+		return nil, err
+	}
+	run.begin(launchCtx, conformanceStageRun)
+
+	s.goBackground(ctx, func(ctx context.Context) {
+		s.followConformance(ctx, run, input.ClusterName, []byte(kubeconfig.Kubeconfig))
+	})
+
+	output := &api.RunConformanceOutput{
+		ClusterName: input.ClusterName,
+		Mode:        input.Mode,
+		OperationID: run.op.ID,
+		Stages:      toAPIStages(run.op.Stages),
+		Message: fmt.Sprintf("Launched a %s Sonobuoy run against cluster '%s'; poll get_operation_status with operation %s",
+			input.Mode, input.ClusterName, run.op.ID),
+	}
+
+	logger.Info("Launched conformance tests", "operation_id", run.op.ID)
+	return output, nil
+}
+
+// followConformance polls a Sonobuoy run until it finishes, records its
+// results and deletes it from the workload cluster.
+func (s *EnhancedClusterService) followConformance(ctx context.Context, run *asyncRun, clusterName string, kubeconfig []byte) {
+	logger := s.logger.WithContext(ctx).WithOperation("RunConformance").WithCluster(clusterName, "")
+	defer s.deleteConformanceRun(ctx, clusterName, kubeconfig)
+
+	runCtx, cancel := context.WithTimeout(ctx, s.conformanceOptions.Timeout)
+	defer cancel()
+
+	var status *sonobuoyStatus
+	for {
+		select {
+		case <-runCtx.Done():
+			if ctx.Err() != nil {
+				run.fail(ctx, conformanceStageRun, errInterrupted)
+				return
+			}
+			err := errors.New(errors.CodeTimeout, fmt.Sprintf("conformance tests did not finish within %s", s.conformanceOptions.Timeout))
+			logger.WithError(err).Warn("Conformance run timed out")
+			run.fail(ctx, conformanceStageRun, err)
+			return
+		case <-time.After(s.conformanceOptions.PollInterval):
+		}
+
+		statusCtx, statusCancel := context.WithTimeout(runCtx, sonobuoyCommandTimeout)
+		output, err := s.runSonobuoy(statusCtx, kubeconfig, "status", "--json")
+		statusCancel()
+		if err == nil {
+			status, err = parseSonobuoyStatus(output)
+		}
+		if err != nil {
+			// The aggregator may be restarting; keep polling until the timeout
+			logger.WithError(err).Debug("Failed to get conformance run status")
+			run.progress(ctx, conformanceStageRun, fmt.Sprintf("failed to get status: %v", err))
+			continue
+		}
+		if status.done() {
+			break
+		}
+		run.progress(ctx, conformanceStageRun, status.progressMessage())
+	}
+
+	if status.Status == sonobuoyStatusFailed {
+		err := errors.New(errors.CodeDependencyFailure, "the Sonobuoy run failed: "+status.progressMessage())
+		logger.WithError(err).Warn("Conformance run failed")
+		run.fail(ctx, conformanceStageRun, err)
+		return
+	}
+	run.complete(ctx, conformanceStageRun, status.progressMessage())
+
+	run.begin(ctx, conformanceStageResults)
+	summary, failures := status.results()
+	if len(failures) > 0 {
+		err := errors.New(errors.CodeDependencyFailure, fmt.Sprintf("%s; failed tests: %s", summary, strings.Join(failures, "; ")))
+		logger.WithError(err).Warn("Conformance tests failed")
+		run.fail(ctx, conformanceStageResults, err)
+		return
+	}
+	run.complete(ctx, conformanceStageResults, summary)
+	run.succeed(ctx)
+	logger.Info("Conformance tests passed", "operation_id", run.op.ID, "results", summary)
+}
+
+// deleteConformanceRun removes the Sonobuoy namespace and cluster-scoped
+// resources of a run from a workload cluster. It does so even when ctx was
+// cancelled, as when the server is stopping.
+func (s *EnhancedClusterService) deleteConformanceRun(ctx context.Context, clusterName string, kubeconfig []byte) {
+	deleteCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sonobuoyCommandTimeout)
+	defer cancel()
+
+	if output, err := s.runSonobuoy(deleteCtx, kubeconfig, "delete", "--wait"); err != nil {
+		s.logger.WithContext(ctx).WithOperation("RunConformance").WithCluster(clusterName, "").WithError(err).
+			Warn("Failed to delete conformance run", "output", lastLines(output, 5))
+	}
+}
+
+// sonobuoyError converts a failed sonobuoy invocation to an API error.
+func sonobuoyError(ctx context.Context, err error, output []byte, message string) error {
+	if stderrors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errors.Wrap(err, errors.CodeTimeout, message)
+	}
+	if stderrors.Is(err, exec.ErrNotFound) || stderrors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, errors.CodeUnavailable, "sonobuoy binary not found").
+			WithDetails("hint", "install sonobuoy or set SONOBUOY_PATH")
+	}
+	return errors.Wrap(err, errors.CodeDependencyFailure, message).
+		WithDetails("output", lastLines(output, 20))
+}
+
+// States of a Sonobuoy run reported by `sonobuoy status`.
+const (
+	sonobuoyStatusComplete = "complete"
+	sonobuoyStatusFailed   = "failed"
+)
+
+// sonobuoyStatus is the output of `sonobuoy status --json`.
+type sonobuoyStatus struct {
+	Status  string                 `json:"status"`
+	Plugins []sonobuoyPluginStatus `json:"plugins"`
+}
+
+// sonobuoyPluginStatus is the status of a Sonobuoy plugin on a node, or
+// "global" for plugins running once per cluster such as e2e.
+type sonobuoyPluginStatus struct {
+	Plugin       string            `json:"plugin"`
+	Node         string            `json:"node"`
+	Status       string            `json:"status"`
+	ResultStatus string            `json:"result-status"`
+	ResultCounts map[string]int    `json:"result-counts"`
+	Progress     *sonobuoyProgress `json:"progress"`
+}
+
+// sonobuoyProgress is the progress a plugin reports while it runs.
+type sonobuoyProgress struct {
+	Total     int      `json:"total"`
+	Completed int      `json:"completed"`
+	Failures  []string `json:"failures"`
+}
+
+// parseSonobuoyStatus parses the output of `sonobuoy status --json`.
+func parseSonobuoyStatus(output []byte) (*sonobuoyStatus, error) {
+	var status sonobuoyStatus
+	if err := json.Unmarshal(output, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse sonobuoy status: %w", err)
+	}
+	return &status, nil
+}
+
+// done reports whether a run has finished, successfully or not.
+func (st *sonobuoyStatus) done() bool {
+	return st.Status == sonobuoyStatusComplete || st.Status == sonobuoyStatusFailed
+}
+
+// progressMessage summarizes the state of each plugin, with the number of
+// tests completed for plugins reporting progress.
+func (st *sonobuoyStatus) progressMessage() string {
+	parts := make([]string, 0, len(st.Plugins))
+	for _, plugin := range st.Plugins {
+		name := plugin.Plugin
+		if plugin.Node != "" && plugin.Node != "global" {
+			name += "/" + plugin.Node
+		}
+		part := fmt.Sprintf("%s: %s", name, plugin.Status)
+		if plugin.Progress != nil && plugin.Progress.Total > 0 {
+			part += fmt.Sprintf(", %d of %d tests", plugin.Progress.Completed, plugin.Progress.Total)
+			if failed := len(plugin.Progress.Failures); failed > 0 {
+				part += fmt.Sprintf(", %d failed", failed)
+			}
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return "run " + st.Status
+	}
+	return strings.Join(parts, "; ")
+}
+
+// results summarizes the result counts of a finished run, e.g. "e2e passed:
+// 1 passed", and lists up to maxReportedFailures failed tests. A plugin that
+// failed without reporting its failed tests is listed by name.
+func (st *sonobuoyStatus) results() (string, []string) {
+	parts := make([]string, 0, len(st.Plugins))
+	var failures []string
+	for _, plugin := range st.Plugins {
+		counts := make([]string, 0, len(plugin.ResultCounts))
+		for result, count := range plugin.ResultCounts {
+			counts = append(counts, fmt.Sprintf("%d %s", count, result))
+		}
+		sort.Strings(counts)
+
+		part := fmt.Sprintf("%s %s", plugin.Plugin, plugin.ResultStatus)
+		if plugin.Node != "" && plugin.Node != "global" {
+			part = fmt.Sprintf("%s/%s %s", plugin.Plugin, plugin.Node, plugin.ResultStatus)
+		}
+		if len(counts) > 0 {
+			part += ": " + strings.Join(counts, ", ")
+		}
+		parts = append(parts, part)
+
+		if plugin.ResultStatus != sonobuoyStatusFailed {
+			continue
+		}
+		if plugin.Progress != nil && len(plugin.Progress.Failures) > 0 {
+			failures = append(failures, plugin.Progress.Failures...)
+		} else {
+			failures = append(failures, part)
+		}
+	}
+	if len(failures) > maxReportedFailures {
+		failures = append(failures[:maxReportedFailures], fmt.Sprintf("and %d more", len(failures)-maxReportedFailures))
+	}
+	return strings.Join(parts, "; "), failures
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	testSonobuoyRunning = `{"plugins":[{"plugin":"e2e","node":"global","status":"running","result-status":"","progress":{"total":380,"completed":120,"failures":[]}}],"status":"running"}`
+	testSonobuoyPassed  = `{"plugins":[{"plugin":"e2e","node":"global","status":"complete","result-status":"passed","result-counts":{"passed":380},"progress":{"total":380,"completed":380}}],"status":"complete"}`
+	testSonobuoyFailed  = `{"plugins":[{"plugin":"e2e","node":"global","status":"complete","result-status":"failed","result-counts":{"failed":1,"passed":379},"progress":{"total":380,"completed":380,"failures":["[sig-network] DNS should provide DNS for services"]}}],"status":"complete"}`
+)
+
+// fakeSonobuoy records invocations and answers status calls from a script,
+// repeating its last entry.
+type fakeSonobuoy struct {
+	mu       sync.Mutex
+	calls    []string
+	statuses []string
+	runErr   error
+}
+
+func (f *fakeSonobuoy) run(ctx context.Context, kubeconfig []byte, args ...string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, strings.Join(args, " "))
+
+	switch args[0] {
+	case "run":
+		return []byte("error: namespace sonobuoy already exists"), f.runErr
+	case "status":
+		status := f.statuses[0]
+		if len(f.statuses) > 1 {
+			f.statuses = f.statuses[1:]
+		}
+		return []byte(status), nil
+	}
+	return nil, nil
+}
+
+func (f *fakeSonobuoy) invocations() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func TestSonobuoyStatus(t *testing.T) {
+	running, err := parseSonobuoyStatus([]byte(testSonobuoyRunning))
+	require.NoError(t, err)
+	assert.False(t, running.done())
+	assert.Equal(t, "e2e: running, 120 of 380 tests", running.progressMessage())
+
+	passed, err := parseSonobuoyStatus([]byte(testSonobuoyPassed))
+	require.NoError(t, err)
+	assert.True(t, passed.done())
+	summary, failures := passed.results()
+	assert.Equal(t, "e2e passed: 380 passed", summary)
+	assert.Empty(t, failures)
+
+	failed, err := parseSonobuoyStatus([]byte(testSonobuoyFailed))
+	require.NoError(t, err)
+	summary, failures = failed.results()
+	assert.Equal(t, "e2e failed: 1 failed, 379 passed", summary)
+	assert.Equal(t, []string{"[sig-network] DNS should provide DNS for services"}, failures)
+
+	_, err = parseSonobuoyStatus([]byte("error: no sonobuoy run"))
+	assert.Error(t, err)
+}
+
+func TestEnhancedClusterService_RunConformance(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, statuses ...string) (*EnhancedClusterService, *fakeSonobuoy) {
+		svc, _ := setupEnhancedTestService(t,
+			createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			createTestKubeconfigSecret("prod", testNamespace),
+			createTestCluster("new", testNamespace, clusterv1.ClusterPhaseProvisioning),
+		)
+		svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetConformanceOptions(ConformanceOptions{Timeout: time.Second, PollInterval: 10 * time.Millisecond})
+
+		fake := &fakeSonobuoy{statuses: statuses}
+		svc.runSonobuoy = fake.run
+		return svc, fake
+	}
+
+	waitForOperation := func(t *testing.T, svc *EnhancedClusterService, id string) *api.GetOperationStatusOutput {
+		t.Helper()
+		var status *api.GetOperationStatusOutput
+		require.Eventually(t, func() bool {
+			var err error
+			status, err = svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: id}, nil)
+			return err == nil && (status.State == async.StateSucceeded || status.State == async.StateFailed)
+		}, 5*time.Second, 10*time.Millisecond)
+		return status
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input api.RunConformanceInput
+			code  errors.ErrorCode
+		}{
+			{name: "missing cluster", input: api.RunConformanceInput{}, code: errors.CodeInvalidInput},
+			{name: "unknown mode", input: api.RunConformanceInput{ClusterName: "prod", Mode: "thorough"}, code: errors.CodeInvalidInput},
+			{name: "cluster not found", input: api.RunConformanceInput{ClusterName: "missing"}, code: errors.CodeNotFound},
+			{name: "cluster provisioning", input: api.RunConformanceInput{ClusterName: "new"}, code: errors.CodePreconditionFailed},
+		}
+
+		svc, _ := setup(t, testSonobuoyPassed)
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.RunConformance(ctx, tt.input)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			})
+		}
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+		_, err := svc.RunConformance(ctx, api.RunConformanceInput{ClusterName: "prod"})
+		assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+	})
+
+	t.Run("passed", func(t *testing.T) {
+		svc, fake := setup(t, testSonobuoyRunning, testSonobuoyPassed)
+
+		output, err := svc.RunConformance(ctx, api.RunConformanceInput{ClusterName: "prod"})
+		require.NoError(t, err)
+		assert.Equal(t, "quick", output.Mode)
+		require.NotEmpty(t, output.OperationID)
+
+		status := waitForOperation(t, svc, output.OperationID)
+		assert.Equal(t, async.StateSucceeded, status.State)
+		require.Len(t, status.Stages, 2)
+		assert.Equal(t, "e2e passed: 380 passed", status.Stages[1].Message)
+
+		require.Eventually(t, func() bool {
+			calls := fake.invocations()
+			return calls[len(calls)-1] == "delete --wait"
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, "run --mode quick", fake.invocations()[0])
+	})
+
+	t.Run("failed tests", func(t *testing.T) {
+		svc, _ := setup(t, testSonobuoyFailed)
+
+		output, err := svc.RunConformance(ctx, api.RunConformanceInput{ClusterName: "prod", Mode: "certified-conformance"})
+		require.NoError(t, err)
+
+		status := waitForOperation(t, svc, output.OperationID)
+		assert.Equal(t, async.StateFailed, status.State)
+		assert.Contains(t, status.Error, "DNS should provide DNS for services")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		svc, _ := setup(t, testSonobuoyRunning)
+		svc.conformanceOptions.Timeout = 50 * time.Millisecond
+
+		output, err := svc.RunConformance(ctx, api.RunConformanceInput{ClusterName: "prod"})
+		require.NoError(t, err)
+
+		status := waitForOperation(t, svc, output.OperationID)
+		assert.Equal(t, async.StateFailed, status.State)
+		assert.Contains(t, status.Error, "did not finish")
+	})
+
+	t.Run("interrupted when the server stops", func(t *testing.T) {
+		svc, fake := setup(t, testSonobuoyRunning)
+		serverCtx, stop := context.WithCancel(ctx)
+		svc.SetBackgroundContext(serverCtx)
+
+		output, err := svc.RunConformance(ctx, api.RunConformanceInput{ClusterName: "prod"})
+		require.NoError(t, err)
+		stop()
+
+		status := waitForOperation(t, svc, output.OperationID)
+		assert.Equal(t, errInterrupted.Error(), status.Error)
+		require.Eventually(t, func() bool {
+			calls := fake.invocations()
+			return calls[len(calls)-1] == "delete --wait"
+		}, 5*time.Second, 10*time.Millisecond, "the run is still deleted")
+	})
+
+	t.Run("launch fails", func(t *testing.T) {
+		svc, fake := setup(t, testSonobuoyPassed)
+		fake.runErr = fmt.Errorf("exit status 1")
+
+		_, err := svc.RunConformance(ctx, api.RunConformanceInput{ClusterName: "prod"})
+		assert.Equal(t, errors.CodeDependencyFailure, errors.GetErrorCode(err))
+	})
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// CreateClusterKind is the kind of async operation tracking a queued
+	// create_cluster call.
+	CreateClusterKind = "create_cluster"
+
+	// Stages of a queued cluster creation.
+	createStageQueued = "queued"
+	createStageCreate = "create"
+)
+
+// CreationLimits caps the number of clusters provisioning at once, protecting
+// cloud quotas from bursts of create_cluster calls.
+type CreationLimits struct {
+	// MaxProvisioning and MaxProvisioningPerNamespace limit the clusters
+	// provisioning across all namespaces and in each namespace. Zero means
+	// no limit.
+	MaxProvisioning             int
+	MaxProvisioningPerNamespace int
+
+	// Queue makes create_cluster calls over a limit wait as async operations
+	// rather than fail with CodeRateLimited.
+	Queue bool
+}
+
+// SetCreationLimits configures the limits on clusters provisioning at once.
+func (s *EnhancedClusterService) SetCreationLimits(limits CreationLimits) {
+	s.creationLimits = limits
+}
+
+// reserveCreationSlot checks that another cluster may start provisioning in a
+// namespace. On success the caller must call release once the cluster
+// resource is created, so concurrent calls count it.
+func (s *EnhancedClusterService) reserveCreationSlot(ctx context.Context, namespace string) (release func(), err error) {
+	limits := s.creationLimits
+	if limits.MaxProvisioning == 0 && limits.MaxProvisioningPerNamespace == 0 {
+		return func() {}, nil
+	}
+
+	s.creationMu.Lock()
+	clusters, err := s.kubeClient.ListAllClusters(ctx)
+	if err != nil {
+		s.creationMu.Unlock()
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to count provisioning clusters")
+	}
+
+	total, inNamespace := 0, 0
+	for i := range clusters.Items {
+		if !isProvisioning(&clusters.Items[i]) {
+			continue
+		}
+		total++
+		if clusters.Items[i].Namespace == namespace {
+			inNamespace++
+		}
+	}
+
+	switch {
+	case limits.MaxProvisioning > 0 && total >= limits.MaxProvisioning:
+		s.creationMu.Unlock()
+		return nil, errors.New(errors.CodeRateLimited,
+			fmt.Sprintf("%d clusters are already provisioning, the limit of the server; retry once one of them is provisioned", total)).
+			WithDetails("provisioning", total)
+	case limits.MaxProvisioningPerNamespace > 0 && inNamespace >= limits.MaxProvisioningPerNamespace:
+		s.creationMu.Unlock()
+		return nil, errors.New(errors.CodeRateLimited,
+			fmt.Sprintf("%d clusters are already provisioning in namespace '%s', the limit per namespace; retry once one of them is provisioned", inNamespace, namespace)).
+			WithDetails("provisioning", inNamespace)
+	}
+	return s.creationMu.Unlock, nil
+}
+
+// isProvisioning reports whether a cluster is still being provisioned.
+func isProvisioning(cluster *clusterv1.Cluster) bool {
+	if !cluster.DeletionTimestamp.IsZero() {
+		return false
+	}
+	switch clusterv1.ClusterPhase(cluster.Status.Phase) {
+	case clusterv1.ClusterPhaseProvisioned, clusterv1.ClusterPhaseFailed, clusterv1.ClusterPhaseDeleting:
+		return false
+	default:
+		return true
+	}
+}
+
+// queueClusterCreation starts an async operation that creates a cluster once
+// the creation limits allow it.
+func (s *EnhancedClusterService) queueClusterCreation(ctx context.Context, cluster *clusterv1.Cluster, input api.CreateClusterInput, providerName string, limited error) (*api.CreateClusterOutput, error) {
+	run, err := s.startAsyncOperation(ctx, CreateClusterKind, cluster.Name, map[string]string{
+		"templateName":      input.TemplateName,
+		"kubernetesVersion": input.KubernetesVersion,
+	}, createStageQueued, createStageCreate)
+	if err != nil {
+		return nil, err
+	}
+	run.begin(ctx, createStageQueued)
+	run.progress(ctx, createStageQueued, errors.GetUserMessage(limited))
+
+	s.goBackground(ctx, func(ctx context.Context) {
+		s.runQueuedCreation(ctx, run, cluster, providerName, input.TemplateName)
+	})
+
+	return &api.CreateClusterOutput{
+		ClusterName: cluster.Name,
+		Status:      api.ClusterStatusQueued,
+		Message: fmt.Sprintf("Cluster '%s' is queued: %s; poll get_operation_status with operation %s",
+			cluster.Name, errors.GetUserMessage(limited), run.op.ID),
+		OperationID: run.op.ID,
+	}, nil
+}
+
+// runQueuedCreation waits for the creation limits to allow a queued cluster
+// and creates it. Clusters still queued after the lifecycle timeout are
+// given up on.
+func (s *EnhancedClusterService) runQueuedCreation(ctx context.Context, run *asyncRun, cluster *clusterv1.Cluster, providerName, templateName string) {
+	logger := s.logger.WithContext(ctx).WithOperation("CreateCluster").WithCluster(cluster.Name, "")
+
+	timeout := s.lifecycleTimeout
+	if timeout <= 0 {
+		timeout = defaultLifecycleTimeout
+	}
+	queueCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-queueCtx.Done():
+			if ctx.Err() != nil {
+				run.fail(ctx, createStageQueued, errInterrupted)
+				return
+			}
+			err := errors.New(errors.CodeTimeout, fmt.Sprintf("cluster was still queued after %s", timeout))
+			logger.WithError(err).Error("Queued cluster creation timed out")
+			run.fail(ctx, createStageQueued, err)
+			return
+		case <-ticker.C:
+		}
+
+		release, err := s.reserveCreationSlot(queueCtx, s.kubeClient.Namespace(ctx))
+		if errors.GetErrorCode(err) == errors.CodeRateLimited {
+			run.progress(ctx, createStageQueued, errors.GetUserMessage(err))
+			continue
+		}
+		if err != nil {
+			logger.WithError(err).Warn("Failed to check creation limits")
+			continue
+		}
+
+		run.complete(ctx, createStageQueued, "")
+		run.begin(ctx, createStageCreate)
+		startedAt := time.Now()
+		err = s.kubeClient.CreateCluster(queueCtx, cluster)
+		release()
+		if err != nil {
+			logger.WithError(err).Error("Failed to create queued cluster")
+			run.fail(ctx, createStageCreate, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to create cluster"))
+			return
+		}
+		s.trackProvisioning(ctx, cluster.Name, providerName, templateName, startedAt)
+
+		logger.Info("Created queued cluster", "operation_id", run.op.ID)
+		run.complete(ctx, createStageCreate, fmt.Sprintf("Cluster '%s' creation initiated; follow its progress with get_cluster", cluster.Name))
+		run.succeed(ctx)
+		return
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_CreationLimits(t *testing.T) {
+	ctx := context.Background()
+
+	input := api.CreateClusterInput{
+		ClusterName:       "new-cluster",
+		TemplateName:      "aws-standard",
+		KubernetesVersion: "v1.31.0",
+		Variables:         map[string]interface{}{"region": "us-west-2", "osFamily": "ubuntu"},
+	}
+	objects := func() []client.Object {
+		return []client.Object{
+			createTestClusterClassWithVariables("aws-standard"),
+			createTestCluster("provisioning", testNamespace, clusterv1.ClusterPhaseProvisioning),
+			createTestCluster("provisioned", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			createTestCluster("other-team", "team-b", clusterv1.ClusterPhasePending),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		limits      CreationLimits
+		expectError string
+	}{
+		{
+			name:        "server limit reached",
+			limits:      CreationLimits{MaxProvisioning: 2},
+			expectError: "2 clusters are already provisioning, the limit of the server",
+		},
+		{
+			name:        "namespace limit reached",
+			limits:      CreationLimits{MaxProvisioningPerNamespace: 1},
+			expectError: "1 clusters are already provisioning in namespace 'default'",
+		},
+		{
+			name:        "queue without async operations",
+			limits:      CreationLimits{MaxProvisioning: 1, Queue: true},
+			expectError: "the limit of the server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, objects()...)
+			svc.SetCreationLimits(tt.limits)
+
+			_, err := svc.CreateCluster(ctx, input)
+			require.Error(t, err)
+			assert.Equal(t, errors.CodeRateLimited, errors.GetErrorCode(err))
+			assert.Contains(t, err.Error(), tt.expectError)
+		})
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, objects()...)
+		svc.SetCreationLimits(CreationLimits{MaxProvisioning: 3, MaxProvisioningPerNamespace: 2})
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+
+		createCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		_, err := svc.CreateCluster(createCtx, input)
+		require.NoError(t, err)
+
+		var cluster clusterv1.Cluster
+		assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "new-cluster"}, &cluster))
+	})
+
+	t.Run("queued until a cluster is provisioned", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, objects()...)
+		svc.SetCreationLimits(CreationLimits{MaxProvisioningPerNamespace: 1, Queue: true})
+		svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+
+		output, err := svc.CreateCluster(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, api.ClusterStatusQueued, output.Status)
+		require.NotEmpty(t, output.OperationID)
+
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, async.StateRunning, status.State)
+		assert.Contains(t, status.Message, "stage 'queued' running")
+
+		var provisioning clusterv1.Cluster
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "provisioning"}, &provisioning))
+		provisioning.Status.Phase = string(clusterv1.ClusterPhaseProvisioned)
+		require.NoError(t, fakeClient.Update(ctx, &provisioning))
+
+		require.Eventually(t, func() bool {
+			status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+			return err == nil && status.State == async.StateSucceeded
+		}, 5*time.Second, 20*time.Millisecond)
+
+		var cluster clusterv1.Cluster
+		assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "new-cluster"}, &cluster))
+	})
+
+	t.Run("queued creation is interrupted when the server stops", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, objects()...)
+		svc.SetCreationLimits(CreationLimits{MaxProvisioningPerNamespace: 1, Queue: true})
+		svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+		serverCtx, stop := context.WithCancel(ctx)
+		svc.SetBackgroundContext(serverCtx)
+
+		output, err := svc.CreateCluster(ctx, input)
+		require.NoError(t, err)
+		stop()
+
+		require.Eventually(t, func() bool {
+			status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+			return err == nil && status.State == async.StateFailed && status.Error == errInterrupted.Error()
+		}, 5*time.Second, 20*time.Millisecond)
+
+		var cluster clusterv1.Cluster
+		assert.Error(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "new-cluster"}, &cluster))
+	})
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// credentialRotationTimeout bounds a rotation, including the rollout of
+	// the provider controllers with the new credentials.
+	credentialRotationTimeout = 5 * time.Minute
+
+	// restartedAtAnnotation is the pod template annotation kubectl rollout
+	// restart sets to roll a Deployment's pods.
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// awsProfileKey is the key of the CAPA bootstrap credentials secret
+	// holding an AWS credentials profile, as written by clusterawsadm.
+	awsProfileKey = "credentials"
+)
+
+// CredentialVerifier checks provider credentials with the provider's API and
+// returns the identity they belong to.
+type CredentialVerifier func(ctx context.Context, credentials map[string]string) (string, error)
+
+// providerCredentials describes the credentials secret of an infrastructure
+// provider and the credential fields it holds.
+type providerCredentials struct {
+	namespace string
+	secret    string
+	required  []string
+	optional  []string
+	encode    func(secret *corev1.Secret, credentials map[string]string)
+}
+
+// providerCredentialSecrets lists the providers whose credentials
+// rotate_provider_credentials can rotate.
+var providerCredentialSecrets = map[string]providerCredentials{
+	"aws": {
+		namespace: "capa-system",
+		secret:    "capa-manager-bootstrap-credentials",
+		required:  []string{"accessKeyId", "secretAccessKey"},
+		optional:  []string{"sessionToken", "region"},
+		encode:    encodeAWSCredentials,
+	},
+	"azure": {
+		namespace: "capz-system",
+		secret:    "capz-manager-bootstrap-credentials",
+		required:  []string{"clientId", "clientSecret"},
+		optional:  []string{"tenantId", "subscriptionId"},
+		encode:    encodeAzureCredentials,
+	},
+}
+
+// SetCredentialVerifier sets how credentials for a provider are checked before
+// rotate_provider_credentials stores them. Credentials of providers without a
+// verifier are only verified by the provider controllers rolling out with them.
+func (s *EnhancedClusterService) SetCredentialVerifier(provider string, verifier CredentialVerifier) {
+	if s.credentialVerifiers == nil {
+		s.credentialVerifiers = make(map[string]CredentialVerifier)
+	}
+	s.credentialVerifiers[provider] = verifier
+}
+
+// RotateProviderCredentials replaces the credentials an infrastructure
+// provider (CAPA or CAPZ) uses on the management cluster. The new credentials
+// are checked with the provider's API first, then written to the provider's
+// credentials secret, and the provider controllers are restarted to pick them
+// up. If the controllers do not become available again, the previous
+// credentials are restored.
+func (s *EnhancedClusterService) RotateProviderCredentials(ctx context.Context, input api.RotateProviderCredentialsInput) (*api.RotateProviderCredentialsOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RotateProviderCredentials")
+	logger.Info("Rotating provider credentials", "provider", input.Provider)
+
+	target, ok := providerCredentialSecrets[input.Provider]
+	if !ok {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("unsupported provider '%s', must be one of: %s", input.Provider, strings.Join(sortedKeys(providerCredentialSecrets, nil), ", ")))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if err := validateCredentialFields(target, input.Credentials); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	rotateCtx, cancel := budget.Sub(ctx, credentialRotationTimeout)
+	defer cancel()
+
+	secret, err := s.kubeClient.GetSecretInNamespace(rotateCtx, target.namespace, target.secret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.New(errors.CodePreconditionFailed,
+				fmt.Sprintf("credentials secret %s/%s not found - is the %s provider installed?", target.namespace, target.secret, input.Provider))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get credentials secret")
+	}
+
+	output := &api.RotateProviderCredentialsOutput{
+		Provider:             input.Provider,
+		Secret:               target.namespace + "/" + target.secret,
+		RestartedDeployments: []string{},
+	}
+	if verify, ok := s.credentialVerifiers[input.Provider]; ok {
+		identity, err := verify(rotateCtx, input.Credentials)
+		if err != nil {
+			logger.WithError(err).Error("New credentials failed verification")
+			return nil, errors.Wrap(err, errors.CodeProviderValidation, "the new credentials failed verification, nothing was changed")
+		}
+		output.Identity = identity
+	}
+
+	previous := maps.Clone(secret.Data)
+	target.encode(secret, input.Credentials)
+	if err := s.kubeClient.UpdateObject(rotateCtx, secret); err != nil {
+		logger.WithError(err).Error("Failed to update credentials secret")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to update credentials secret")
+	}
+	logger.Info("Updated credentials secret", "secret", output.Secret)
+
+	restarted, err := s.restartDeployments(rotateCtx, target.namespace)
+	output.RestartedDeployments = restarted
+	if err == nil {
+		err = s.waitForDeploymentsAvailable(rotateCtx, target.namespace)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Provider controllers did not roll out with the new credentials, restoring the previous ones")
+		if restoreErr := s.restoreCredentials(target, previous); restoreErr != nil {
+			logger.WithError(restoreErr).Error("Failed to restore previous credentials")
+			return nil, errors.Wrap(err, errors.CodeDependencyFailure,
+				fmt.Sprintf("provider controllers did not become available and restoring the previous credentials failed: %v", restoreErr))
+		}
+		return nil, errors.Wrap(err, errors.CodeDependencyFailure,
+			"provider controllers did not become available with the new credentials; the previous credentials were restored")
+	}
+
+	if s.providerManager != nil {
+		// Regions and instance types may differ for the new account
+		s.providerManager.InvalidateCapabilities(input.Provider)
+	}
+
+	output.Message = fmt.Sprintf("Rotated %s credentials in %s and restarted %d controller deployment(s), which are available again",
+		input.Provider, output.Secret, len(restarted))
+	if output.Identity != "" {
+		output.Message += fmt.Sprintf("; the new credentials belong to %s", output.Identity)
+	}
+	logger.Info("Rotated provider credentials", "provider", input.Provider, "restarted", restarted)
+	return output, nil
+}
+
+// validateCredentialFields checks that all required credential fields are set
+// and that no unknown ones are.
+func validateCredentialFields(target providerCredentials, credentials map[string]string) error {
+	for _, field := range target.required {
+		if credentials[field] == "" {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("credential '%s' is required, expected: %s", field, strings.Join(slices.Concat(target.required, target.optional), ", ")))
+		}
+	}
+	for field := range credentials {
+		if !slices.Contains(target.required, field) && !slices.Contains(target.optional, field) {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("unknown credential '%s', expected: %s", field, strings.Join(slices.Concat(target.required, target.optional), ", ")))
+		}
+	}
+	return nil
+}
+
+// restartDeployments restarts every Deployment in a namespace, as kubectl
+// rollout restart does, and returns their names.
+func (s *EnhancedClusterService) restartDeployments(ctx context.Context, namespace string) ([]string, error) {
+	deployments, err := s.kubeClient.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list provider deployments")
+	}
+
+	restarted := make([]string, 0, len(deployments.Items))
+	restartedAt := time.Now().UTC().Format(time.RFC3339)
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Spec.Template.Annotations == nil {
+			deployment.Spec.Template.Annotations = map[string]string{}
+		}
+		deployment.Spec.Template.Annotations[restartedAtAnnotation] = restartedAt
+		if err := s.kubeClient.UpdateObject(ctx, deployment); err != nil {
+			return restarted, errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to restart deployment %s", deployment.Name))
+		}
+		restarted = append(restarted, deployment.Name)
+	}
+	return restarted, nil
+}
+
+// waitForDeploymentsAvailable waits until every Deployment in a namespace has
+// rolled out and all its replicas are available.
+func (s *EnhancedClusterService) waitForDeploymentsAvailable(ctx context.Context, namespace string) error {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		deployments, err := s.kubeClient.ListDeployments(ctx, namespace)
+		if err == nil && deploymentsRolledOut(deployments.Items) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), errors.CodeTimeout, fmt.Sprintf("timed out waiting for the deployments in %s to roll out", namespace))
+		case <-ticker.C:
+		}
+	}
+}
+
+// deploymentsRolledOut reports whether all Deployments have rolled out their
+// latest pod template and have all replicas available.
+func deploymentsRolledOut(deployments []appsv1.Deployment) bool {
+	for i := range deployments {
+		deployment := &deployments[i]
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ObservedGeneration < deployment.Generation ||
+			deployment.Status.UpdatedReplicas < replicas ||
+			deployment.Status.AvailableReplicas < replicas {
+			return false
+		}
+	}
+	return true
+}
+
+// restoreCredentials writes back the previous contents of a credentials
+// secret and restarts the provider controllers again. It runs on a fresh
+// context since the rotation's may have expired.
+func (s *EnhancedClusterService) restoreCredentials(target providerCredentials, previous map[string][]byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	secret, err := s.kubeClient.GetSecretInNamespace(ctx, target.namespace, target.secret)
+	if err != nil {
+		return err
+	}
+	secret.Data = previous
+	if err := s.kubeClient.UpdateObject(ctx, secret); err != nil {
+		return err
+	}
+	_, err = s.restartDeployments(ctx, target.namespace)
+	return err
+}
+
+// encodeAWSCredentials writes AWS credentials to the CAPA bootstrap secret,
+// either as the credentials profile clusterawsadm writes or, for secrets
+// created with separate keys, as AccessKeyID and SecretAccessKey.
+func encodeAWSCredentials(secret *corev1.Secret, credentials map[string]string) {
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if _, ok := secret.Data["AccessKeyID"]; ok {
+		secret.Data["AccessKeyID"] = []byte(credentials["accessKeyId"])
+		secret.Data["SecretAccessKey"] = []byte(credentials["secretAccessKey"])
+		if credentials["sessionToken"] != "" {
+			secret.Data["SessionToken"] = []byte(credentials["sessionToken"])
+		} else {
+			delete(secret.Data, "SessionToken")
+		}
+		return
+	}
+
+	// Keep the region of the existing profile unless a new one is given
+	region := credentials["region"]
+	if region == "" {
+		for _, line := range strings.Split(string(secret.Data[awsProfileKey]), "\n") {
+			if key, value, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) == "region" {
+				region = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	var profile strings.Builder
+	profile.WriteString("[default]\n")
+	fmt.Fprintf(&profile, "aws_access_key_id = %s\n", credentials["accessKeyId"])
+	fmt.Fprintf(&profile, "aws_secret_access_key = %s\n", credentials["secretAccessKey"])
+	if region != "" {
+		fmt.Fprintf(&profile, "region = %s\n", region)
+	}
+	if credentials["sessionToken"] != "" {
+		fmt.Fprintf(&profile, "aws_session_token = %s\n", credentials["sessionToken"])
+	}
+	secret.Data[awsProfileKey] = []byte(profile.String())
+}
+
+// encodeAzureCredentials writes Azure service principal credentials to the
+// CAPZ bootstrap secret, keeping fields that are not given.
+func encodeAzureCredentials(secret *corev1.Secret, credentials map[string]string) {
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	keys := map[string]string{
+		"clientId":       "client-id",
+		"clientSecret":   "client-secret",
+		"tenantId":       "tenant-id",
+		"subscriptionId": "subscription-id",
+	}
+	for field, key := range keys {
+		if value := credentials[field]; value != "" {
+			secret.Data[key] = []byte(value)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func createTestCredentialsSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "capa-manager-bootstrap-credentials", Namespace: "capa-system"},
+		Data:       data,
+	}
+}
+
+func createTestControllerDeployment(name, namespace string, available int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{UpdatedReplicas: available, AvailableReplicas: available},
+	}
+}
+
+func TestEnhancedClusterService_RotateProviderCredentials(t *testing.T) {
+	ctx := context.Background()
+	profile := []byte("[default]\naws_access_key_id = OLDKEY\naws_secret_access_key = OLDSECRET\nregion = eu-west-1\n")
+	newCredentials := map[string]string{"accessKeyId": "NEWKEY", "secretAccessKey": "NEWSECRET"}
+
+	t.Run("rotates and restarts controllers", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t,
+			createTestCredentialsSecret(map[string][]byte{awsProfileKey: profile}),
+			createTestControllerDeployment("capa-controller-manager", "capa-system", 1),
+		)
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+		svc.SetCredentialVerifier("aws", func(ctx context.Context, credentials map[string]string) (string, error) {
+			return "arn:aws:iam::123456789012:user/capa", nil
+		})
+
+		output, err := svc.RotateProviderCredentials(ctx, api.RotateProviderCredentialsInput{Provider: "aws", Credentials: newCredentials})
+		require.NoError(t, err)
+		assert.Equal(t, "capa-system/capa-manager-bootstrap-credentials", output.Secret)
+		assert.Equal(t, "arn:aws:iam::123456789012:user/capa", output.Identity)
+		assert.Equal(t, []string{"capa-controller-manager"}, output.RestartedDeployments)
+
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "capa-system", Name: "capa-manager-bootstrap-credentials"}, secret))
+		assert.Equal(t, "[default]\naws_access_key_id = NEWKEY\naws_secret_access_key = NEWSECRET\nregion = eu-west-1\n", string(secret.Data[awsProfileKey]))
+
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "capa-system", Name: "capa-controller-manager"}, deployment))
+		assert.NotEmpty(t, deployment.Spec.Template.Annotations[restartedAtAnnotation])
+	})
+
+	t.Run("verification failure changes nothing", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, createTestCredentialsSecret(map[string][]byte{awsProfileKey: profile}))
+		svc.SetCredentialVerifier("aws", func(ctx context.Context, credentials map[string]string) (string, error) {
+			return "", fmt.Errorf("InvalidClientTokenId")
+		})
+
+		_, err := svc.RotateProviderCredentials(ctx, api.RotateProviderCredentialsInput{Provider: "aws", Credentials: newCredentials})
+		assert.Equal(t, errors.CodeProviderValidation, errors.GetErrorCode(err))
+
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "capa-system", Name: "capa-manager-bootstrap-credentials"}, secret))
+		assert.Equal(t, profile, secret.Data[awsProfileKey])
+	})
+
+	t.Run("restores previous credentials when controllers stay unavailable", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t,
+			createTestCredentialsSecret(map[string][]byte{awsProfileKey: profile}),
+			createTestControllerDeployment("capa-controller-manager", "capa-system", 0),
+		)
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+		rotateCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		_, err := svc.RotateProviderCredentials(rotateCtx, api.RotateProviderCredentialsInput{Provider: "aws", Credentials: newCredentials})
+		assert.Equal(t, errors.CodeDependencyFailure, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "previous credentials were restored")
+
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "capa-system", Name: "capa-manager-bootstrap-credentials"}, secret))
+		assert.Equal(t, profile, secret.Data[awsProfileKey])
+	})
+
+	tests := []struct {
+		name  string
+		input api.RotateProviderCredentialsInput
+		code  errors.ErrorCode
+	}{
+		{name: "unsupported provider", input: api.RotateProviderCredentialsInput{Provider: "gcp", Credentials: newCredentials}, code: errors.CodeInvalidInput},
+		{name: "missing secret key", input: api.RotateProviderCredentialsInput{Provider: "aws", Credentials: map[string]string{"accessKeyId": "NEWKEY"}}, code: errors.CodeInvalidInput},
+		{name: "unknown field", input: api.RotateProviderCredentialsInput{Provider: "aws", Credentials: map[string]string{"accessKeyId": "A", "secretAccessKey": "B", "password": "C"}}, code: errors.CodeInvalidInput},
+		{name: "provider not installed", input: api.RotateProviderCredentialsInput{Provider: "azure", Credentials: map[string]string{"clientId": "A", "clientSecret": "B"}}, code: errors.CodePreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t)
+
+			_, err := svc.RotateProviderCredentials(ctx, tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestEncodeCredentials(t *testing.T) {
+	t.Run("aws separate keys", func(t *testing.T) {
+		secret := createTestCredentialsSecret(map[string][]byte{"AccessKeyID": []byte("OLD"), "SecretAccessKey": []byte("OLD"), "SessionToken": []byte("OLD")})
+		encodeAWSCredentials(secret, map[string]string{"accessKeyId": "NEWKEY", "secretAccessKey": "NEWSECRET"})
+
+		assert.Equal(t, map[string][]byte{"AccessKeyID": []byte("NEWKEY"), "SecretAccessKey": []byte("NEWSECRET")}, secret.Data)
+	})
+
+	t.Run("aws profile with session token", func(t *testing.T) {
+		secret := createTestCredentialsSecret(nil)
+		encodeAWSCredentials(secret, map[string]string{"accessKeyId": "K", "secretAccessKey": "S", "sessionToken": "T", "region": "us-west-2"})
+
+		assert.Equal(t, "[default]\naws_access_key_id = K\naws_secret_access_key = S\nregion = us-west-2\naws_session_token = T\n", string(secret.Data[awsProfileKey]))
+	})
+
+	t.Run("azure keeps unchanged fields", func(t *testing.T) {
+		secret := createTestCredentialsSecret(map[string][]byte{"tenant-id": []byte("tenant"), "client-id": []byte("old")})
+		encodeAzureCredentials(secret, map[string]string{"clientId": "new", "clientSecret": "secret"})
+
+		assert.Equal(t, map[string][]byte{"tenant-id": []byte("tenant"), "client-id": []byte("new"), "client-secret": []byte("secret")}, secret.Data)
+	})
+}
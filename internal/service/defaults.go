@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// VariableDefaults are variables applied to the clusters created for a
+// provider and template; an empty Provider or Template matches any. Callers
+// may override Variables but not Enforced.
+type VariableDefaults struct {
+	Provider  string
+	Template  string
+	Variables map[string]interface{}
+	Enforced  map[string]interface{}
+}
+
+// SetVariableDefaults configures the default variables applied to every new
+// cluster, followed by those for clusters in a namespace.
+func (s *EnhancedClusterService) SetVariableDefaults(defaults []VariableDefaults, namespaceDefaults map[string][]VariableDefaults) {
+	s.variableDefaults = defaults
+	s.namespaceVariableDefaults = namespaceDefaults
+}
+
+// applyVariableDefaults returns variables with the defaults matching a new
+// cluster applied, later entries overriding earlier ones. Defaults fill in
+// variables the caller did not set, merging objects such as cloudTags key by
+// key; a caller setting an enforced variable to another value is rejected.
+func (s *EnhancedClusterService) applyVariableDefaults(namespace, providerName, templateName string, variables map[string]interface{}) (map[string]interface{}, error) {
+	var defaults, enforced map[string]interface{}
+	matched := false
+	for _, entry := range slices.Concat(s.variableDefaults, s.namespaceVariableDefaults[namespace]) {
+		if (entry.Provider != "" && entry.Provider != providerName) || (entry.Template != "" && entry.Template != templateName) {
+			continue
+		}
+		defaults = mergeVariables(defaults, entry.Variables)
+		enforced = mergeVariables(enforced, entry.Enforced)
+		matched = true
+	}
+	if !matched {
+		return variables, nil
+	}
+
+	if name := conflictingVariable(enforced, variables); name != "" {
+		return nil, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("variable %s is enforced by the server's default variables and cannot be changed", name)).
+			WithDetails("field", name)
+	}
+
+	merged := mergeVariables(mergeVariables(defaults, variables), enforced)
+	if err := validation.NewValidator().ValidateClusterVariables(merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// conflictingVariable returns the name of the first variable set to a value
+// other than the enforced one, following nested objects with dotted names.
+func conflictingVariable(enforced, variables map[string]interface{}) string {
+	for _, name := range slices.Sorted(maps.Keys(enforced)) {
+		value, ok := variables[name]
+		if !ok {
+			continue
+		}
+		enforcedObject, enforcedIsObject := enforced[name].(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		if enforcedIsObject && isObject {
+			if key := conflictingVariable(enforcedObject, object); key != "" {
+				return name + "." + key
+			}
+			continue
+		}
+		if !reflect.DeepEqual(value, enforced[name]) {
+			return name
+		}
+	}
+	return ""
+}
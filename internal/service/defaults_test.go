@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func testVariableDefaults() ([]VariableDefaults, map[string][]VariableDefaults) {
+	return []VariableDefaults{
+		{
+			Provider:  "aws",
+			Variables: map[string]interface{}{"region": "us-west-2", "sshKeyName": "platform"},
+			Enforced:  map[string]interface{}{"cloudTags": map[string]interface{}{"cost-center": "1234"}},
+		},
+		{
+			Template:  "aws-gpu",
+			Variables: map[string]interface{}{"gpuCount": float64(1)},
+		},
+		{
+			Provider: "azure",
+			Enforced: map[string]interface{}{"region": "westeurope"},
+		},
+	}, map[string][]VariableDefaults{
+		"team-a": {{Variables: map[string]interface{}{"sshKeyName": "team-a"}}},
+	}
+}
+
+func TestEnhancedClusterService_ApplyVariableDefaults(t *testing.T) {
+	tests := []struct {
+		name        string
+		namespace   string
+		provider    string
+		template    string
+		variables   map[string]interface{}
+		want        map[string]interface{}
+		expectError string
+	}{
+		{
+			name:     "provider defaults",
+			provider: "aws",
+			template: "aws-standard",
+			want: map[string]interface{}{
+				"region":     "us-west-2",
+				"sshKeyName": "platform",
+				"cloudTags":  map[string]interface{}{"cost-center": "1234"},
+			},
+		},
+		{
+			name:      "caller overrides defaults and adds tags",
+			provider:  "aws",
+			template:  "aws-standard",
+			variables: map[string]interface{}{"region": "eu-west-1", "cloudTags": map[string]interface{}{"team": "payments"}},
+			want: map[string]interface{}{
+				"region":     "eu-west-1",
+				"sshKeyName": "platform",
+				"cloudTags":  map[string]interface{}{"cost-center": "1234", "team": "payments"},
+			},
+		},
+		{
+			name:     "template defaults",
+			provider: "aws",
+			template: "aws-gpu",
+			want: map[string]interface{}{
+				"region":     "us-west-2",
+				"sshKeyName": "platform",
+				"gpuCount":   float64(1),
+				"cloudTags":  map[string]interface{}{"cost-center": "1234"},
+			},
+		},
+		{
+			name:      "namespace overrides organization defaults",
+			namespace: "team-a",
+			provider:  "aws",
+			template:  "aws-standard",
+			want: map[string]interface{}{
+				"region":     "us-west-2",
+				"sshKeyName": "team-a",
+				"cloudTags":  map[string]interface{}{"cost-center": "1234"},
+			},
+		},
+		{
+			name:      "enforced value kept",
+			provider:  "aws",
+			template:  "aws-standard",
+			variables: map[string]interface{}{"cloudTags": map[string]interface{}{"cost-center": "1234"}},
+			want: map[string]interface{}{
+				"region":     "us-west-2",
+				"sshKeyName": "platform",
+				"cloudTags":  map[string]interface{}{"cost-center": "1234"},
+			},
+		},
+		{
+			name:        "enforced value changed",
+			provider:    "aws",
+			template:    "aws-standard",
+			variables:   map[string]interface{}{"cloudTags": map[string]interface{}{"cost-center": "9999"}},
+			expectError: "variable cloudTags.cost-center is enforced",
+		},
+		{
+			name:        "enforced scalar changed",
+			provider:    "azure",
+			template:    "azure-standard",
+			variables:   map[string]interface{}{"region": "eastus"},
+			expectError: "variable region is enforced",
+		},
+		{
+			name:      "no matching defaults",
+			provider:  "gcp",
+			template:  "gcp-standard",
+			variables: map[string]interface{}{"region": "us-central1"},
+			want:      map[string]interface{}{"region": "us-central1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t)
+			svc.SetVariableDefaults(testVariableDefaults())
+
+			got, err := svc.applyVariableDefaults(tt.namespace, tt.provider, tt.template, tt.variables)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEnhancedClusterService_VariableDefaults(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t, createTestClusterClassWithVariables("aws-standard"))
+	svc.SetVariableDefaults(testVariableDefaults())
+
+	t.Run("defaults are not missing", func(t *testing.T) {
+		missing, err := svc.MissingClusterVariables(ctx, "aws-standard", nil)
+		require.NoError(t, err)
+		require.Len(t, missing, 1)
+		assert.Equal(t, "osFamily", missing[0].Name)
+	})
+
+	t.Run("create_cluster rejects changes to enforced variables", func(t *testing.T) {
+		_, err := svc.CreateCluster(ctx, api.CreateClusterInput{
+			ClusterName:       "new-cluster",
+			TemplateName:      "aws-standard",
+			KubernetesVersion: "v1.31.0",
+			Variables:         map[string]interface{}{"osFamily": "ubuntu", "cloudTags": map[string]interface{}{"cost-center": "0"}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "cloudTags.cost-center")
+	})
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// PreviewDeleteCluster reports what deleting a cluster destroys: its
+// machines, the cloud resources its provider reports, and the persistent
+// volumes and load balancers of its workload cluster. It changes nothing, so
+// the impact can be confirmed with the user before calling delete_cluster.
+// Parts of the impact that cannot be determined are reported as warnings.
+func (s *EnhancedClusterService) PreviewDeleteCluster(ctx context.Context, input api.PreviewDeleteClusterInput) (*api.PreviewDeleteClusterOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("PreviewDeleteCluster").WithCluster(input.ClusterName, "")
+	logger.Info("Previewing cluster deletion")
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	previewCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(previewCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+
+	output := &api.PreviewDeleteClusterOutput{
+		ClusterName: cluster.Name,
+		Namespace:   cluster.Namespace,
+		Provider:    clusterProvider(cluster),
+		Region:      clusterRegion(cluster),
+	}
+	if err := s.protectedClusterNames.ValidateClusterName("deletion", cluster.Name); err != nil {
+		output.Warnings = append(output.Warnings, "delete_cluster will be refused: "+errors.GetUserMessage(err))
+	}
+	if cluster.DeletionTimestamp != nil {
+		output.Warnings = append(output.Warnings, "the cluster is already being deleted")
+	}
+	output.Warnings = append(output.Warnings, pendingDeletionWarning(cluster)...)
+
+	if machines, err := s.deletionMachines(previewCtx, cluster.Name); err != nil {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("machine counts unavailable: %v", err))
+	} else {
+		output.Machines = machines
+	}
+
+	if status, err := s.getProviderStatus(previewCtx, cluster); err != nil {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("cloud resources unavailable: %v", err))
+	} else if status != nil {
+		output.CloudResources = status.CloudResources
+	}
+
+	if workloadClient, err := s.newWorkloadClient(previewCtx, cluster.Name); err != nil {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("persistent volumes and load balancers unavailable: %v", err))
+	} else {
+		var warnings []string
+		output.PersistentVolumes, output.LoadBalancers, warnings = workloadDeletionImpact(previewCtx, workloadClient)
+		output.Warnings = append(output.Warnings, warnings...)
+	}
+
+	if dependents, err := s.listClusterDependents(previewCtx); err != nil {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("dependent clusters unavailable: %v", err))
+	} else {
+		output.Dependents = dependents[cluster.Name]
+	}
+
+	if s.deletionRetention > 0 {
+		output.RetentionWindow = s.deletionRetention.String()
+	}
+	output.Message = deletionImpactMessage(output)
+
+	logger.Info("Previewed cluster deletion",
+		"machines", output.Machines.ControlPlane+output.Machines.Workers,
+		"persistent_volumes", len(output.PersistentVolumes),
+		"load_balancers", len(output.LoadBalancers),
+	)
+	return output, nil
+}
+
+// deletionMachines counts the machines of a cluster. Machines of a
+// MachinePool are counted by the pool's replicas, as not every provider
+// creates Machines for them.
+func (s *EnhancedClusterService) deletionMachines(ctx context.Context, clusterName string) (api.DeletionMachines, error) {
+	counts := api.DeletionMachines{NodePools: map[string]int{}}
+
+	machines, err := s.kubeClient.ListClusterMachines(ctx, clusterName)
+	if err != nil {
+		return counts, err
+	}
+	for _, machine := range machines.Items {
+		if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+			counts.ControlPlane++
+			continue
+		}
+		if machine.Labels[clusterv1.MachinePoolNameLabel] != "" {
+			continue
+		}
+		counts.Workers++
+		if pool := machine.Labels[clusterv1.MachineDeploymentNameLabel]; pool != "" {
+			counts.NodePools[pool]++
+		}
+	}
+
+	pools, err := s.kubeClient.ListMachinePools(ctx, clusterName)
+	if err != nil {
+		return counts, err
+	}
+	for _, pool := range pools.Items {
+		counts.Workers += int(pool.Status.Replicas)
+		counts.NodePools[pool.Name] += int(pool.Status.Replicas)
+	}
+
+	if len(counts.NodePools) == 0 {
+		counts.NodePools = nil
+	}
+	return counts, nil
+}
+
+// workloadDeletionImpact lists the persistent volumes and the load balancers
+// of a workload cluster, with warnings for those that could not be listed.
+func workloadDeletionImpact(ctx context.Context, workloadClient *kube.WorkloadClient) ([]api.DeletionVolume, []api.DeletionLoadBalancer, []string) {
+	var warnings []string
+
+	var volumes []api.DeletionVolume
+	if list, err := workloadClient.ListPersistentVolumes(ctx); err != nil {
+		warnings = append(warnings, fmt.Sprintf("persistent volumes unavailable: %v", err))
+	} else {
+		for _, pv := range list.Items {
+			volume := api.DeletionVolume{
+				Name:          pv.Name,
+				StorageClass:  pv.Spec.StorageClassName,
+				ReclaimPolicy: string(pv.Spec.PersistentVolumeReclaimPolicy),
+			}
+			if pv.Spec.ClaimRef != nil {
+				volume.Claim = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+			}
+			if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+				volume.Capacity = capacity.String()
+			}
+			volumes = append(volumes, volume)
+		}
+		sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	}
+
+	var loadBalancers []api.DeletionLoadBalancer
+	if list, err := workloadClient.ListServices(ctx); err != nil {
+		warnings = append(warnings, fmt.Sprintf("load balancers unavailable: %v", err))
+	} else {
+		for _, service := range list.Items {
+			if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+				continue
+			}
+			loadBalancer := api.DeletionLoadBalancer{Service: service.Namespace + "/" + service.Name}
+			for _, ingress := range service.Status.LoadBalancer.Ingress {
+				if ingress.Hostname != "" {
+					loadBalancer.Addresses = append(loadBalancer.Addresses, ingress.Hostname)
+				} else if ingress.IP != "" {
+					loadBalancer.Addresses = append(loadBalancer.Addresses, ingress.IP)
+				}
+			}
+			loadBalancers = append(loadBalancers, loadBalancer)
+		}
+		sort.Slice(loadBalancers, func(i, j int) bool { return loadBalancers[i].Service < loadBalancers[j].Service })
+	}
+
+	return volumes, loadBalancers, warnings
+}
+
+// deletionImpactMessage summarizes the impact of deleting a cluster.
+func deletionImpactMessage(output *api.PreviewDeleteClusterOutput) string {
+	message := fmt.Sprintf("Deleting cluster '%s' destroys machines: %d control plane, %d workers; cloud resources: %d; persistent volumes: %d; load balancers: %d",
+		output.ClusterName, output.Machines.ControlPlane, output.Machines.Workers,
+		len(output.CloudResources), len(output.PersistentVolumes), len(output.LoadBalancers))
+	if len(output.Dependents) > 0 {
+		message += fmt.Sprintf("; dependent clusters: %s", strings.Join(output.Dependents, ", "))
+	}
+	if output.RetentionWindow != "" {
+		message += fmt.Sprintf(". The cluster is paused for the %s retention window first", output.RetentionWindow)
+	}
+	return message + ". Confirm with the user before calling delete_cluster"
+}
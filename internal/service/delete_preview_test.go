@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+func TestWorkloadDeletionImpact(t *testing.T) {
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1a2b"},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity:                      corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+			StorageClassName:              "gp3",
+			PersistentVolumeReclaimPolicy: corev1.PersistentVolumeReclaimDelete,
+			ClaimRef:                      &corev1.ObjectReference{Namespace: "shop", Name: "data-postgres-0"},
+		},
+	}
+	loadBalancer := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "ingress-nginx"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+			Ingress: []corev1.LoadBalancerIngress{{Hostname: "a1b2.elb.us-west-2.amazonaws.com"}},
+		}},
+	}
+	clusterIP := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "shop"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+	}
+
+	volumes, loadBalancers, warnings := workloadDeletionImpact(context.Background(), newTestWorkloadClient(volume, loadBalancer, clusterIP))
+	assert.Empty(t, warnings)
+	assert.Equal(t, []api.DeletionVolume{{
+		Name:          "pvc-1a2b",
+		Claim:         "shop/data-postgres-0",
+		Capacity:      "20Gi",
+		StorageClass:  "gp3",
+		ReclaimPolicy: "Delete",
+	}}, volumes)
+	assert.Equal(t, []api.DeletionLoadBalancer{{
+		Service:   "ingress-nginx/ingress",
+		Addresses: []string{"a1b2.elb.us-west-2.amazonaws.com"},
+	}}, loadBalancers)
+}
+
+func TestEnhancedClusterService_PreviewDeleteCluster(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := createTestCluster("prod-a", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+		Kind:       "AWSCluster",
+		Name:       "prod-a",
+	}
+	awsCluster := &unstructured.Unstructured{}
+	awsCluster.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	awsCluster.SetKind("AWSCluster")
+	awsCluster.SetName("prod-a")
+	awsCluster.SetNamespace(testNamespace)
+	awsCluster.Object["spec"] = map[string]interface{}{
+		"network": map[string]interface{}{"vpc": map[string]interface{}{"id": "vpc-0a1b2c3d"}},
+	}
+	pool := createTestMachinePool("mp-0", testNamespace, "prod-a", 3)
+	pool.Status.Replicas = 3
+
+	svc, _ := setupEnhancedTestService(t,
+		cluster,
+		awsCluster,
+		createTestControlPlaneMachine("prod-a-cp-1", "prod-a", true),
+		createTestControlPlaneMachine("prod-a-cp-2", "prod-a", true),
+		createTestMachine("prod-a-md-0-1", "prod-a", "md-0"),
+		createTestMachine("prod-a-md-0-2", "prod-a", "md-0"),
+		pool,
+		createTestKubeconfigSecret("prod-a", testNamespace),
+	)
+	svc.SetProtectedClusterNames(validation.ProtectedClusterNames{"prod-*"})
+	svc.SetDeletionRetention(24 * time.Hour)
+	svc.kubeClient.SetWorkloadClientFactory(func([]byte, kube.NetworkOptions) (*kube.WorkloadClient, error) {
+		return newTestWorkloadClient(&corev1.PersistentVolume{ObjectMeta: metav1.ObjectMeta{Name: "pvc-1a2b"}}), nil
+	})
+
+	output, err := svc.PreviewDeleteCluster(ctx, api.PreviewDeleteClusterInput{ClusterName: "prod-a"})
+	require.NoError(t, err)
+	assert.Equal(t, api.DeletionMachines{ControlPlane: 2, Workers: 5, NodePools: map[string]int{"md-0": 2, "mp-0": 3}}, output.Machines)
+	assert.Equal(t, []api.CloudResourceRef{{Type: "vpc", ID: "vpc-0a1b2c3d", Region: "us-west-2"}}, output.CloudResources)
+	require.Len(t, output.PersistentVolumes, 1)
+	assert.Empty(t, output.LoadBalancers)
+	assert.Equal(t, "24h0m0s", output.RetentionWindow)
+	assert.Contains(t, output.Warnings, "delete_cluster will be refused: cluster name 'prod-a' is protected (prod-*); deletion is not permitted")
+	assert.Equal(t, "Deleting cluster 'prod-a' destroys machines: 2 control plane, 5 workers; cloud resources: 1; persistent volumes: 1; load balancers: 0. The cluster is paused for the 24h0m0s retention window first. Confirm with the user before calling delete_cluster", output.Message)
+
+	t.Run("unreachable workload cluster", func(t *testing.T) {
+		svc.kubeClient.SetWorkloadClientFactory(func([]byte, kube.NetworkOptions) (*kube.WorkloadClient, error) {
+			return nil, fmt.Errorf("connection refused")
+		})
+		svc.SetProtectedClusterNames(nil)
+
+		output, err := svc.PreviewDeleteCluster(ctx, api.PreviewDeleteClusterInput{ClusterName: "prod-a"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, output.Machines.ControlPlane)
+		assert.Empty(t, output.PersistentVolumes)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "persistent volumes and load balancers unavailable")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := svc.PreviewDeleteCluster(ctx, api.PreviewDeleteClusterInput{ClusterName: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
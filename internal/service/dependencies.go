@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// DependsOnAnnotation declares the clusters a cluster depends on, as a
+// comma-separated list of cluster names in its namespace, e.g. shared-dns,vault.
+// Deleting or upgrading a cluster others depend on is reported with a warning.
+const DependsOnAnnotation = "capi-mcp.io/depends-on"
+
+// clusterDependencies returns the names of the clusters a cluster declares it
+// depends on, in the order declared and without duplicates.
+func clusterDependencies(cluster *clusterv1.Cluster) []string {
+	var dependencies []string
+	for _, name := range strings.Split(cluster.Annotations[DependsOnAnnotation], ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || name == cluster.Name || slices.Contains(dependencies, name) {
+			continue
+		}
+		dependencies = append(dependencies, name)
+	}
+	return dependencies
+}
+
+// clusterDependents maps the name of each cluster others depend on to the
+// sorted names of the clusters depending on it.
+func clusterDependents(clusters []clusterv1.Cluster) map[string][]string {
+	dependents := make(map[string][]string)
+	for i := range clusters {
+		for _, dependency := range clusterDependencies(&clusters[i]) {
+			dependents[dependency] = append(dependents[dependency], clusters[i].Name)
+		}
+	}
+	for _, names := range dependents {
+		slices.Sort(names)
+	}
+	return dependents
+}
+
+// listClusterDependents lists the clusters in the caller's namespace and maps
+// the clusters others depend on to their dependents.
+func (s *EnhancedClusterService) listClusterDependents(ctx context.Context) (map[string][]string, error) {
+	clusters, err := s.kubeClient.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clusterDependents(clusters.Items), nil
+}
+
+// dependentsWarning describes the clusters depending on a cluster an
+// operation affects.
+func dependentsWarning(clusterName string, dependents []string) string {
+	return fmt.Sprintf("clusters depend on cluster '%s': %s", clusterName, strings.Join(dependents, ", "))
+}
+
+// GetClusterDependencies reports the clusters a cluster depends on and the
+// clusters depending on it, as declared by DependsOnAnnotation, with their
+// status.
+func (s *EnhancedClusterService) GetClusterDependencies(ctx context.Context, input api.GetClusterDependenciesInput) (*api.GetClusterDependenciesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetClusterDependencies").WithCluster(input.ClusterName, "")
+	logger.Debug("Getting cluster dependencies")
+
+	if input.ClusterName == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "cluster name is required")
+	}
+	if s.kubeClient == nil {
+		return nil, errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+	}
+
+	getCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(getCtx, input.ClusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	clusters, err := s.kubeClient.ListClusters(getCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
+	}
+	byName := make(map[string]*clusterv1.Cluster, len(clusters.Items))
+	for i := range clusters.Items {
+		byName[clusters.Items[i].Name] = &clusters.Items[i]
+	}
+
+	output := &api.GetClusterDependenciesOutput{
+		ClusterName: cluster.Name,
+		DependsOn:   []api.ClusterDependency{},
+		Dependents:  []api.ClusterDependency{},
+	}
+	for _, name := range clusterDependencies(cluster) {
+		dependency := api.ClusterDependency{ClusterName: name}
+		if other, ok := byName[name]; ok {
+			dependency.Status, _ = s.clusterStatus(getCtx, other)
+			if dependency.Status != api.ClusterStatusReady {
+				output.Warnings = append(output.Warnings, fmt.Sprintf("depends on cluster '%s', which is %s", name, dependency.Status))
+			}
+		} else {
+			dependency.Missing = true
+			output.Warnings = append(output.Warnings, fmt.Sprintf("depends on cluster '%s', which does not exist", name))
+		}
+		output.DependsOn = append(output.DependsOn, dependency)
+	}
+	for _, name := range clusterDependents(clusters.Items)[cluster.Name] {
+		status, _ := s.clusterStatus(getCtx, byName[name])
+		output.Dependents = append(output.Dependents, api.ClusterDependency{ClusterName: name, Status: status})
+	}
+
+	logger.Info("Got cluster dependencies", "depends_on", len(output.DependsOn), "dependents", len(output.Dependents))
+	return output, nil
+}
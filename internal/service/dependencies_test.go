@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// withDependencies annotates a cluster with the clusters it depends on.
+func withDependencies(cluster *clusterv1.Cluster, dependsOn string) *clusterv1.Cluster {
+	cluster.Annotations = map[string]string{DependsOnAnnotation: dependsOn}
+	return cluster
+}
+
+func TestClusterDependencies(t *testing.T) {
+	tests := []struct {
+		name      string
+		dependsOn string
+		want      []string
+	}{
+		{name: "none"},
+		{name: "list", dependsOn: "vault, shared-dns", want: []string{"vault", "shared-dns"}},
+		{name: "duplicates and blanks", dependsOn: "vault,,vault , ", want: []string{"vault"}},
+		{name: "itself", dependsOn: "app,vault", want: []string{"vault"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := withDependencies(createTestCluster("app", testNamespace, clusterv1.ClusterPhaseProvisioned), tt.dependsOn)
+			assert.Equal(t, tt.want, clusterDependencies(cluster))
+		})
+	}
+}
+
+func TestEnhancedClusterService_GetClusterDependencies(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t,
+		createTestCluster("vault", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		withDependencies(createTestCluster("app-b", testNamespace, clusterv1.ClusterPhaseProvisioning), "vault"),
+		withDependencies(createTestCluster("app-a", testNamespace, clusterv1.ClusterPhaseProvisioned), "vault,shared-dns"),
+	)
+
+	t.Run("dependents", func(t *testing.T) {
+		output, err := svc.GetClusterDependencies(ctx, api.GetClusterDependenciesInput{ClusterName: "vault"})
+		require.NoError(t, err)
+		assert.Empty(t, output.DependsOn)
+		assert.Equal(t, []api.ClusterDependency{
+			{ClusterName: "app-a", Status: api.ClusterStatusReady},
+			{ClusterName: "app-b", Status: api.ClusterStatusProvisioning},
+		}, output.Dependents)
+		assert.Empty(t, output.Warnings)
+	})
+
+	t.Run("dependencies", func(t *testing.T) {
+		output, err := svc.GetClusterDependencies(ctx, api.GetClusterDependenciesInput{ClusterName: "app-a"})
+		require.NoError(t, err)
+		assert.Equal(t, []api.ClusterDependency{
+			{ClusterName: "vault", Status: api.ClusterStatusReady},
+			{ClusterName: "shared-dns", Missing: true},
+		}, output.DependsOn)
+		assert.Empty(t, output.Dependents)
+		assert.Equal(t, []string{"depends on cluster 'shared-dns', which does not exist"}, output.Warnings)
+	})
+
+	t.Run("dependency not ready", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t,
+			createTestCluster("vault", testNamespace, clusterv1.ClusterPhaseProvisioning),
+			withDependencies(createTestCluster("app", testNamespace, clusterv1.ClusterPhaseProvisioned), "vault"),
+		)
+		output, err := svc.GetClusterDependencies(ctx, api.GetClusterDependenciesInput{ClusterName: "app"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"depends on cluster 'vault', which is Provisioning"}, output.Warnings)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := svc.GetClusterDependencies(ctx, api.GetClusterDependenciesInput{ClusterName: "missing"})
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
+
+func TestEnhancedClusterService_DeleteClusterDependents(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t,
+		createTestCluster("vault", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		withDependencies(createTestCluster("app", testNamespace, clusterv1.ClusterPhaseProvisioned), "vault"),
+	)
+
+	output, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "vault"})
+	require.NoError(t, err)
+	assert.Equal(t, "deleted", output.Status)
+	assert.Equal(t, []string{"clusters depend on cluster 'vault': app"}, output.Warnings)
+
+	output, err = svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "app"})
+	require.NoError(t, err)
+	assert.Empty(t, output.Warnings)
+}
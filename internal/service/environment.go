@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// DefaultEnvironmentLabel is the cluster label whose value names the
+// environment of a cluster unless configured otherwise.
+const DefaultEnvironmentLabel = "environment"
+
+// SetEnvironmentLabel sets the cluster label whose value names the
+// environment, such as dev, staging or prod, a cluster belongs to.
+func (s *EnhancedClusterService) SetEnvironmentLabel(label string) {
+	s.environmentLabel = label
+}
+
+// EnvironmentLabel returns the cluster label whose value names the
+// environment of a cluster.
+func (s *EnhancedClusterService) EnvironmentLabel() string {
+	if s.environmentLabel == "" {
+		return DefaultEnvironmentLabel
+	}
+	return s.environmentLabel
+}
+
+// clusterEnvironment returns the environment of a cluster, or an empty string
+// if it has none.
+func (s *EnhancedClusterService) clusterEnvironment(cluster *clusterv1.Cluster) string {
+	return cluster.Labels[s.EnvironmentLabel()]
+}
+
+// GetClusterEnvironment returns the environment of a cluster in the caller's
+// namespace, or an empty string if it has none.
+func (s *EnhancedClusterService) GetClusterEnvironment(ctx context.Context, clusterName string) (string, error) {
+	if s.kubeClient == nil {
+		return "", errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+	}
+
+	getCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(getCtx, clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", clusterName))
+		}
+		return "", errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	return s.clusterEnvironment(cluster), nil
+}
+
+// environmentSelector returns the label selector of the clusters of an
+// environment that also match labelSelector. Without an environment,
+// labelSelector is returned as is.
+func (s *EnhancedClusterService) environmentSelector(labelSelector, environment string) (string, error) {
+	if environment == "" {
+		return labelSelector, nil
+	}
+	if errs := k8svalidation.IsValidLabelValue(environment); len(errs) > 0 {
+		return "", errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("invalid environment '%s': %s", environment, strings.Join(errs, "; "))).WithDetails("field", "environment")
+	}
+
+	selector := labels.Set{s.EnvironmentLabel(): environment}.String()
+	if strings.TrimSpace(labelSelector) != "" {
+		selector = labelSelector + "," + selector
+	}
+	return selector, nil
+}
+
+// ListEnvironments groups the clusters in the caller's namespace into
+// environments by the value of the environment label, with the status,
+// Kubernetes versions and node counts of each. Clusters without the label
+// are reported as unassigned.
+func (s *EnhancedClusterService) ListEnvironments(ctx context.Context) (*api.ListEnvironmentsOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ListEnvironments")
+	logger.Debug("Listing environments", "label", s.EnvironmentLabel())
+
+	clusters, err := s.ListClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &api.ListEnvironmentsOutput{
+		Label:        s.EnvironmentLabel(),
+		Environments: []api.Environment{},
+		LastUpdated:  clusters.LastUpdated,
+	}
+	environments := make(map[string]*api.Environment)
+	for _, cluster := range clusters.Clusters {
+		if cluster.Environment == "" {
+			output.Unassigned = append(output.Unassigned, cluster.Name)
+			continue
+		}
+
+		environment, ok := environments[cluster.Environment]
+		if !ok {
+			environment = &api.Environment{
+				Name:               cluster.Environment,
+				LabelSelector:      labels.Set{s.EnvironmentLabel(): cluster.Environment}.String(),
+				Statuses:           make(map[api.ClusterStatus]int),
+				KubernetesVersions: []string{},
+			}
+			environments[cluster.Environment] = environment
+		}
+		environment.Clusters = append(environment.Clusters, cluster.Name)
+		environment.Statuses[cluster.Status]++
+		if cluster.KubernetesVersion != "" && !slices.Contains(environment.KubernetesVersions, cluster.KubernetesVersion) {
+			environment.KubernetesVersions = append(environment.KubernetesVersions, cluster.KubernetesVersion)
+		}
+		environment.NodeCount += cluster.NodeCount
+		environment.ReadyNodeCount += cluster.ReadyNodeCount
+	}
+
+	for _, environment := range environments {
+		slices.Sort(environment.Clusters)
+		slices.Sort(environment.KubernetesVersions)
+		output.Environments = append(output.Environments, *environment)
+	}
+	slices.SortFunc(output.Environments, func(a, b api.Environment) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.Sort(output.Unassigned)
+
+	logger.Info("Listed environments", "count", len(output.Environments), "unassigned", len(output.Unassigned))
+	return output, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_ListEnvironments(t *testing.T) {
+	ctx := context.Background()
+
+	newCluster := func(name, environment, kubernetesVersion string, phase clusterv1.ClusterPhase) *clusterv1.Cluster {
+		cluster := createTestCluster(name, testNamespace, phase)
+		if environment != "" {
+			cluster.Labels["stage"] = environment
+		}
+		cluster.Spec.Topology.Version = kubernetesVersion
+		return cluster
+	}
+	svc, _ := setupEnhancedTestService(t,
+		newCluster("prod-b", "prod", "v1.31.0", clusterv1.ClusterPhaseProvisioned),
+		newCluster("prod-a", "prod", "v1.30.5", clusterv1.ClusterPhaseProvisioned),
+		newCluster("dev-a", "dev", "v1.31.0", clusterv1.ClusterPhaseProvisioning),
+		newCluster("sandbox", "", "v1.31.0", clusterv1.ClusterPhaseProvisioned),
+		createTestMachineDeployment("prod-a-md-0", testNamespace, "prod-a", 3),
+	)
+	svc.SetEnvironmentLabel("stage")
+
+	output, err := svc.ListEnvironments(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "stage", output.Label)
+	assert.Equal(t, []string{"sandbox"}, output.Unassigned)
+	assert.Equal(t, []api.Environment{
+		{
+			Name:               "dev",
+			LabelSelector:      "stage=dev",
+			Clusters:           []string{"dev-a"},
+			Statuses:           map[api.ClusterStatus]int{api.ClusterStatusProvisioning: 1},
+			KubernetesVersions: []string{"v1.31.0"},
+		},
+		{
+			Name:               "prod",
+			LabelSelector:      "stage=prod",
+			Clusters:           []string{"prod-a", "prod-b"},
+			Statuses:           map[api.ClusterStatus]int{api.ClusterStatusReady: 2},
+			KubernetesVersions: []string{"v1.30.5", "v1.31.0"},
+			NodeCount:          3,
+		},
+	}, output.Environments)
+
+	clusters, err := svc.ListClusters(ctx)
+	require.NoError(t, err)
+	for _, cluster := range clusters.Clusters {
+		if cluster.Name == "dev-a" {
+			assert.Equal(t, "dev", cluster.Environment)
+		}
+	}
+}
+
+func TestEnhancedClusterService_EnvironmentSelector(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+
+	tests := []struct {
+		name          string
+		labelSelector string
+		environment   string
+		want          string
+		wantErr       bool
+	}{
+		{name: "label selector only", labelSelector: "region=eu", want: "region=eu"},
+		{name: "environment only", environment: "prod", want: "environment=prod"},
+		{name: "both", labelSelector: "region=eu", environment: "prod", want: "region=eu,environment=prod"},
+		{name: "neither"},
+		{name: "invalid environment", environment: "prod,env!=dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := svc.environmentSelector(tt.labelSelector, tt.environment)
+			if tt.wantErr {
+				assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, selector)
+		})
+	}
+}
+
+func TestEnhancedClusterService_GetClusterEnvironment(t *testing.T) {
+	ctx := context.Background()
+	prod := createTestCluster("prod-a", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	prod.Labels[DefaultEnvironmentLabel] = "prod"
+	svc, _ := setupEnhancedTestService(t, prod, createTestCluster("sandbox", testNamespace, clusterv1.ClusterPhaseProvisioned))
+
+	environment, err := svc.GetClusterEnvironment(ctx, "prod-a")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", environment)
+
+	environment, err = svc.GetClusterEnvironment(ctx, "sandbox")
+	require.NoError(t, err)
+	assert.Empty(t, environment)
+
+	_, err = svc.GetClusterEnvironment(ctx, "missing")
+	assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+}
@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+const (
+	// etcdBackupNamespace is the workload cluster namespace the backup CronJob runs in.
+	etcdBackupNamespace = "kube-system"
+
+	// etcdBackupName names the backup CronJob and labels the Jobs and Pods it creates.
+	etcdBackupName = "capi-mcp-etcd-backup"
+
+	// etcdBackupSelector selects the Jobs and Pods of the backup CronJob.
+	etcdBackupSelector = "app.kubernetes.io/name=etcd-backup"
+
+	// etcdBackupHostPath is where snapshots are kept on control plane nodes
+	// unless a persistent volume claim is given.
+	etcdBackupHostPath = "/var/lib/etcd-backups"
+
+	// etcdBackupContainer is the container that names, reports and prunes snapshots.
+	etcdBackupContainer = "rotate"
+
+	// etcdBackupMarker prefixes the log line reporting a saved snapshot.
+	etcdBackupMarker = "snapshot "
+
+	// defaultEtcdBackupSchedule takes a snapshot every six hours.
+	defaultEtcdBackupSchedule = "0 */6 * * *"
+
+	// defaultEtcdBackupRetention is the number of snapshots kept by default.
+	defaultEtcdBackupRetention = 7
+
+	// maxEtcdBackupRetention bounds the number of snapshots kept.
+	maxEtcdBackupRetention = 30
+
+	// etcdBackupDeadline bounds how long a single backup may run.
+	etcdBackupDeadline = 10 * time.Minute
+)
+
+// SetEtcdBackupImages sets the images of the etcd backup Jobs: etcdImage
+// provides etcdctl to take the snapshot, toolsImage a shell to name and prune
+// snapshots. Etcd images ship without a shell, hence the two.
+func (s *EnhancedClusterService) SetEtcdBackupImages(etcdImage, toolsImage string) {
+	s.etcdBackupImage = etcdImage
+	s.etcdBackupToolsImage = toolsImage
+}
+
+// ConfigureEtcdBackup schedules periodic etcd snapshots of a workload cluster
+// with a self-managed, stacked etcd control plane. A CronJob in the workload
+// cluster runs on a control plane node, saves a snapshot of the local etcd
+// member and prunes the oldest ones beyond the retention. Configuring a
+// cluster that is already backed up updates its schedule.
+func (s *EnhancedClusterService) ConfigureEtcdBackup(ctx context.Context, input api.ConfigureEtcdBackupInput) (*api.ConfigureEtcdBackupOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ConfigureEtcdBackup").WithCluster(input.ClusterName, "")
+	logger.Info("Configuring etcd backup", "schedule", input.Schedule, "retention", input.Retention, "suspend", input.Suspend)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Schedule == "" {
+		input.Schedule = defaultEtcdBackupSchedule
+	}
+	if err := validateCronSchedule(input.Schedule); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Retention == 0 {
+		input.Retention = defaultEtcdBackupRetention
+	}
+	if input.Retention < 1 || input.Retention > maxEtcdBackupRetention {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("retention must be between 1 and %d, got %d", maxEtcdBackupRetention, input.Retention))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.PersistentVolumeClaim != "" {
+		if err := validation.NewValidator().ValidateDNSName(input.PersistentVolumeClaim); err != nil {
+			err := errors.Wrap(err, errors.CodeInvalidInput, "invalid persistent volume claim name")
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+	if s.etcdBackupImage == "" || s.etcdBackupToolsImage == "" {
+		err := errors.New(errors.CodeUnavailable, "etcd backup images are not configured")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	configureCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(configureCtx, input.ClusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if err := s.checkEtcdBackupSupported(configureCtx, cluster); err != nil {
+		logger.WithError(err).Warn("Etcd backup not supported")
+		return nil, err
+	}
+
+	workloadClient, err := s.newWorkloadClient(configureCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := applyEtcdBackup(configureCtx, workloadClient, buildEtcdBackupCronJob(s.etcdBackupImage, s.etcdBackupToolsImage, input))
+	if err != nil {
+		logger.WithError(err).Error("Failed to configure etcd backup")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Configured etcd backup", "schedule", output.Schedule, "retention", output.Retention, "created", output.Created)
+	return output, nil
+}
+
+// ListEtcdBackups reports the etcd backup schedule of a workload cluster and
+// its recent runs, with the snapshot each successful run saved.
+func (s *EnhancedClusterService) ListEtcdBackups(ctx context.Context, input api.ListEtcdBackupsInput) (*api.ListEtcdBackupsOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ListEtcdBackups").WithCluster(input.ClusterName, "")
+	logger.Debug("Listing etcd backups")
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	listCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(listCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := listEtcdBackups(listCtx, workloadClient)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list etcd backups")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Listed etcd backups", "configured", output.Configured, "count", len(output.Backups))
+	return output, nil
+}
+
+// checkEtcdBackupSupported rejects clusters whose etcd the backup CronJob
+// cannot reach: managed control planes, whose etcd is the cloud provider's,
+// and kubeadm control planes using an external etcd cluster, which is backed
+// up wherever it is operated.
+func (s *EnhancedClusterService) checkEtcdBackupSupported(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if cluster.Spec.ControlPlaneRef == nil {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' has no control plane yet", cluster.Name))
+	}
+	if s.getManagedControlPlaneProvider(cluster) != nil {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' has a managed %s control plane, whose etcd is backed up by the cloud provider",
+				cluster.Name, cluster.Spec.ControlPlaneRef.Kind))
+	}
+
+	controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get control plane")
+	}
+	if _, external, _ := unstructured.NestedMap(controlPlane.Object,
+		"spec", "kubeadmConfigSpec", "clusterConfiguration", "etcd", "external"); external {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' uses an external etcd cluster, back it up where it is operated", cluster.Name))
+	}
+	return nil
+}
+
+// validateCronSchedule checks a schedule is a five field cron expression or
+// one of the predefined schedules such as @daily. The workload cluster's API
+// server validates the fields themselves.
+func validateCronSchedule(schedule string) error {
+	if strings.HasPrefix(schedule, "@") {
+		switch schedule {
+		case "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly":
+			return nil
+		}
+		return errors.New(errors.CodeInvalidInput, fmt.Sprintf("unknown predefined schedule '%s'", schedule))
+	}
+	if fields := strings.Fields(schedule); len(fields) != 5 {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("schedule '%s' must have five fields (minute hour day-of-month month day-of-week)", schedule))
+	}
+	return nil
+}
+
+// buildEtcdBackupCronJob builds the backup CronJob. Its Jobs run on a control
+// plane node on the host network: an init container saves a snapshot of the
+// local etcd member with the API server's etcd client certificate, then the
+// rotate container names it after the time it was taken, reports it and
+// deletes the oldest snapshots beyond the retention. Job history is kept for
+// as many runs as snapshots so that list_etcd_backups can report them.
+func buildEtcdBackupCronJob(etcdImage, toolsImage string, input api.ConfigureEtcdBackupInput) *batchv1.CronJob {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "etcd-backup",
+		"app.kubernetes.io/managed-by": "capi-mcp-server",
+	}
+	retention := int32(input.Retention)
+	failedHistory := int32(3)
+	backoffLimit := int32(2)
+	deadline := int64(etcdBackupDeadline.Seconds())
+	directory := corev1.HostPathDirectory
+	directoryOrCreate := corev1.HostPathDirectoryOrCreate
+
+	backups := corev1.VolumeSource{
+		HostPath: &corev1.HostPathVolumeSource{Path: etcdBackupHostPath, Type: &directoryOrCreate},
+	}
+	if input.PersistentVolumeClaim != "" {
+		backups = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: input.PersistentVolumeClaim},
+		}
+	}
+
+	script := `set -e
+name="etcd-snapshot-$(date -u +%Y%m%dT%H%M%SZ).db"
+mv /backups/.snapshot.part "/backups/$name"
+echo "` + etcdBackupMarker + `$name $(stat -c %s "/backups/$name")"
+ls -1 /backups/etcd-snapshot-*.db | sort -r | tail -n +$((RETENTION + 1)) | xargs -r rm -f`
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      etcdBackupName,
+			Namespace: etcdBackupNamespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   input.Schedule,
+			Suspend:                    &input.Suspend,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: &retention,
+			FailedJobsHistoryLimit:     &failedHistory,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					BackoffLimit:          &backoffLimit,
+					ActiveDeadlineSeconds: &deadline,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							HostNetwork:   true,
+							NodeSelector:  map[string]string{"node-role.kubernetes.io/control-plane": ""},
+							Tolerations: []corev1.Toleration{{
+								Key:      "node-role.kubernetes.io/control-plane",
+								Operator: corev1.TolerationOpExists,
+								Effect:   corev1.TaintEffectNoSchedule,
+							}},
+							InitContainers: []corev1.Container{{
+								Name:  "snapshot",
+								Image: etcdImage,
+								Command: []string{
+									"etcdctl",
+									"--endpoints=https://127.0.0.1:2379",
+									"--cacert=/etc/kubernetes/pki/etcd/ca.crt",
+									"--cert=/etc/kubernetes/pki/apiserver-etcd-client.crt",
+									"--key=/etc/kubernetes/pki/apiserver-etcd-client.key",
+									"snapshot", "save", "/backups/.snapshot.part",
+								},
+								Env: []corev1.EnvVar{{Name: "ETCDCTL_API", Value: "3"}},
+								VolumeMounts: []corev1.VolumeMount{
+									{Name: "pki", MountPath: "/etc/kubernetes/pki", ReadOnly: true},
+									{Name: "backups", MountPath: "/backups"},
+								},
+							}},
+							Containers: []corev1.Container{{
+								Name:         etcdBackupContainer,
+								Image:        toolsImage,
+								Command:      []string{"sh", "-c", script},
+								Env:          []corev1.EnvVar{{Name: "RETENTION", Value: strconv.Itoa(input.Retention)}},
+								VolumeMounts: []corev1.VolumeMount{{Name: "backups", MountPath: "/backups"}},
+							}},
+							Volumes: []corev1.Volume{
+								{
+									Name: "pki",
+									VolumeSource: corev1.VolumeSource{
+										HostPath: &corev1.HostPathVolumeSource{Path: "/etc/kubernetes/pki", Type: &directory},
+									},
+								},
+								{Name: "backups", VolumeSource: backups},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// applyEtcdBackup creates the backup CronJob, or updates the spec of the one
+// a previous call created.
+func applyEtcdBackup(ctx context.Context, workloadClient *kube.WorkloadClient, cronJob *batchv1.CronJob) (*api.ConfigureEtcdBackupOutput, error) {
+	created := false
+	existing, err := workloadClient.GetCronJob(ctx, cronJob.Namespace, cronJob.Name)
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := workloadClient.CreateCronJob(ctx, cronJob); err != nil {
+			return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to create etcd backup cronjob")
+		}
+		created = true
+	case err != nil:
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to get etcd backup cronjob")
+	default:
+		existing.Labels = cronJob.Labels
+		existing.Spec = cronJob.Spec
+		if _, err := workloadClient.UpdateCronJob(ctx, existing); err != nil {
+			return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to update etcd backup cronjob")
+		}
+	}
+
+	output := &api.ConfigureEtcdBackupOutput{
+		CronJob:     cronJob.Namespace + "/" + cronJob.Name,
+		Schedule:    cronJob.Spec.Schedule,
+		Retention:   int(*cronJob.Spec.SuccessfulJobsHistoryLimit),
+		Destination: etcdBackupDestination(cronJob),
+		Suspended:   *cronJob.Spec.Suspend,
+		Created:     created,
+	}
+	verb := "Scheduled"
+	if !created {
+		verb = "Updated the schedule of"
+	}
+	output.Message = fmt.Sprintf("%s etcd snapshots on '%s', keeping the last %d in %s",
+		verb, output.Schedule, output.Retention, output.Destination)
+	if output.Suspended {
+		output.Message += "; backups are suspended until configured with suspend unset"
+	}
+	if strings.HasPrefix(output.Destination, "hostPath:") {
+		output.Message += "; snapshots stay on the control plane node that took them, " +
+			"copy them off the cluster or use a persistent volume claim to survive losing the node"
+	}
+	return output, nil
+}
+
+// listEtcdBackups reads the backup CronJob and its Jobs, newest first, taking
+// the node and snapshot of each from its Pod.
+func listEtcdBackups(ctx context.Context, workloadClient *kube.WorkloadClient) (*api.ListEtcdBackupsOutput, error) {
+	output := &api.ListEtcdBackupsOutput{Backups: []api.EtcdBackup{}}
+
+	cronJob, err := workloadClient.GetCronJob(ctx, etcdBackupNamespace, etcdBackupName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			output.Message = "Etcd backups are not configured, use configure_etcd_backup to schedule them"
+			return output, nil
+		}
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to get etcd backup cronjob")
+	}
+	output.Configured = true
+	output.Schedule = cronJob.Spec.Schedule
+	output.Destination = etcdBackupDestination(cronJob)
+	output.Suspended = cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+	if cronJob.Spec.SuccessfulJobsHistoryLimit != nil {
+		output.Retention = int(*cronJob.Spec.SuccessfulJobsHistoryLimit)
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		output.LastScheduleTime = cronJob.Status.LastScheduleTime.Format(time.RFC3339)
+	}
+
+	jobs, err := workloadClient.ListJobs(ctx, etcdBackupNamespace, etcdBackupSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to list etcd backup jobs")
+	}
+	pods, err := workloadClient.ListPods(ctx, etcdBackupNamespace, etcdBackupSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to list etcd backup pods")
+	}
+
+	sort.Slice(jobs.Items, func(i, j int) bool {
+		return jobs.Items[j].CreationTimestamp.Before(&jobs.Items[i].CreationTimestamp)
+	})
+	succeeded := 0
+	for _, job := range jobs.Items {
+		backup := api.EtcdBackup{Job: job.Name, Status: etcdBackupStatus(&job)}
+		if job.Status.StartTime != nil {
+			backup.StartedAt = job.Status.StartTime.Format(time.RFC3339)
+		}
+		if job.Status.CompletionTime != nil {
+			backup.CompletedAt = job.Status.CompletionTime.Format(time.RFC3339)
+		}
+		if pod := etcdBackupPod(pods.Items, job.Name); pod != nil {
+			backup.Node = pod.Spec.NodeName
+			if pod.Status.Phase == corev1.PodSucceeded {
+				logs, err := workloadClient.GetPodLogs(ctx, pod.Namespace, pod.Name, etcdBackupContainer, 10, 4096, false)
+				if err == nil {
+					backup.Snapshot, backup.SizeBytes = parseEtcdBackupLogs(logs)
+				}
+			}
+		}
+		if backup.Status == "Succeeded" {
+			succeeded++
+		}
+		output.Backups = append(output.Backups, backup)
+	}
+
+	output.Message = fmt.Sprintf("%d of the last %d etcd backup(s) succeeded, scheduled on '%s' into %s",
+		succeeded, len(output.Backups), output.Schedule, output.Destination)
+	if output.Suspended {
+		output.Message += "; backups are suspended"
+	}
+	return output, nil
+}
+
+// etcdBackupStatus summarizes a backup Job as Succeeded, Failed or Running.
+func etcdBackupStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Succeeded"
+		case batchv1.JobFailed:
+			return "Failed"
+		}
+	}
+	return "Running"
+}
+
+// etcdBackupPod returns the Pod of a backup Job that ran to completion, or
+// else its latest attempt.
+func etcdBackupPod(pods []corev1.Pod, jobName string) *corev1.Pod {
+	var found *corev1.Pod
+	for i := range pods {
+		owner := metav1.GetControllerOf(&pods[i])
+		if owner == nil || owner.Kind != "Job" || owner.Name != jobName {
+			continue
+		}
+		if pods[i].Status.Phase == corev1.PodSucceeded {
+			return &pods[i]
+		}
+		if found == nil || found.CreationTimestamp.Before(&pods[i].CreationTimestamp) {
+			found = &pods[i]
+		}
+	}
+	return found
+}
+
+// etcdBackupDestination describes where the CronJob stores snapshots.
+func etcdBackupDestination(cronJob *batchv1.CronJob) string {
+	for _, volume := range cronJob.Spec.JobTemplate.Spec.Template.Spec.Volumes {
+		if volume.Name != "backups" {
+			continue
+		}
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			return "persistentVolumeClaim:" + volume.PersistentVolumeClaim.ClaimName
+		case volume.HostPath != nil:
+			return "hostPath:" + volume.HostPath.Path
+		}
+	}
+	return ""
+}
+
+// parseEtcdBackupLogs returns the snapshot name and size reported by the
+// rotate container.
+func parseEtcdBackupLogs(logs string) (string, int64) {
+	for _, line := range strings.Split(logs, "\n") {
+		report, ok := strings.CutPrefix(line, etcdBackupMarker)
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(report)
+		if len(fields) != 2 {
+			continue
+		}
+		size, _ := strconv.ParseInt(fields[1], 10, 64)
+		return fields[0], size
+	}
+	return "", 0
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestBuildEtcdBackupCronJob(t *testing.T) {
+	cronJob := buildEtcdBackupCronJob("registry.k8s.io/etcd:3.5.16-0", "busybox:1.36", api.ConfigureEtcdBackupInput{
+		Schedule:  "0 2 * * *",
+		Retention: 5,
+	})
+
+	assert.Equal(t, etcdBackupName, cronJob.Name)
+	assert.Equal(t, etcdBackupNamespace, cronJob.Namespace)
+	assert.Equal(t, "0 2 * * *", cronJob.Spec.Schedule)
+	assert.Equal(t, batchv1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+	assert.Equal(t, int32(5), *cronJob.Spec.SuccessfulJobsHistoryLimit)
+	assert.False(t, *cronJob.Spec.Suspend)
+
+	pod := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	assert.True(t, pod.HostNetwork)
+	assert.Contains(t, pod.NodeSelector, "node-role.kubernetes.io/control-plane")
+	require.Len(t, pod.InitContainers, 1)
+	assert.Equal(t, "registry.k8s.io/etcd:3.5.16-0", pod.InitContainers[0].Image)
+	assert.Contains(t, pod.InitContainers[0].Command, "--endpoints=https://127.0.0.1:2379")
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, etcdBackupContainer, pod.Containers[0].Name)
+	assert.Equal(t, []corev1.EnvVar{{Name: "RETENTION", Value: "5"}}, pod.Containers[0].Env)
+	assert.Equal(t, "hostPath:"+etcdBackupHostPath, etcdBackupDestination(cronJob))
+
+	cronJob = buildEtcdBackupCronJob("etcd", "busybox", api.ConfigureEtcdBackupInput{
+		Schedule:              "@daily",
+		Retention:             3,
+		PersistentVolumeClaim: "etcd-backups",
+		Suspend:               true,
+	})
+	assert.True(t, *cronJob.Spec.Suspend)
+	assert.Equal(t, "persistentVolumeClaim:etcd-backups", etcdBackupDestination(cronJob))
+}
+
+func TestApplyEtcdBackup(t *testing.T) {
+	ctx := context.Background()
+	workloadClient := newTestWorkloadClient()
+
+	output, err := applyEtcdBackup(ctx, workloadClient, buildEtcdBackupCronJob("etcd", "busybox", api.ConfigureEtcdBackupInput{
+		Schedule:  defaultEtcdBackupSchedule,
+		Retention: defaultEtcdBackupRetention,
+	}))
+	require.NoError(t, err)
+	assert.True(t, output.Created)
+	assert.Equal(t, "kube-system/"+etcdBackupName, output.CronJob)
+	assert.Contains(t, output.Message, "control plane node")
+
+	output, err = applyEtcdBackup(ctx, workloadClient, buildEtcdBackupCronJob("etcd", "busybox", api.ConfigureEtcdBackupInput{
+		Schedule:              "0 1 * * *",
+		Retention:             10,
+		PersistentVolumeClaim: "etcd-backups",
+	}))
+	require.NoError(t, err)
+	assert.False(t, output.Created)
+	assert.Equal(t, 10, output.Retention)
+	assert.NotContains(t, output.Message, "control plane node")
+
+	cronJob, err := workloadClient.GetCronJob(ctx, etcdBackupNamespace, etcdBackupName)
+	require.NoError(t, err)
+	assert.Equal(t, "0 1 * * *", cronJob.Spec.Schedule)
+	assert.Equal(t, int32(10), *cronJob.Spec.SuccessfulJobsHistoryLimit)
+}
+
+func TestListEtcdBackups(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not configured", func(t *testing.T) {
+		output, err := listEtcdBackups(ctx, newTestWorkloadClient())
+		require.NoError(t, err)
+		assert.False(t, output.Configured)
+		assert.Empty(t, output.Backups)
+		assert.Contains(t, output.Message, "configure_etcd_backup")
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cronJob := buildEtcdBackupCronJob("etcd", "busybox", api.ConfigureEtcdBackupInput{Schedule: "@daily", Retention: 7})
+		lastSchedule := metav1.NewTime(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+		cronJob.Status.LastScheduleTime = &lastSchedule
+
+		objects := []runtime.Object{cronJob}
+		for i, status := range []batchv1.JobConditionType{batchv1.JobComplete, batchv1.JobFailed, ""} {
+			created := metav1.NewTime(lastSchedule.Add(-time.Duration(2-i) * 24 * time.Hour))
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+				Name:              "backup-" + string(rune('a'+i)),
+				Namespace:         etcdBackupNamespace,
+				Labels:            cronJob.Spec.JobTemplate.Labels,
+				CreationTimestamp: created,
+			}}
+			job.Status.StartTime = &created
+			if status != "" {
+				job.Status.Conditions = []batchv1.JobCondition{{Type: status, Status: corev1.ConditionTrue}}
+			}
+			controller := true
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            job.Name + "-pod",
+					Namespace:       etcdBackupNamespace,
+					Labels:          cronJob.Spec.JobTemplate.Labels,
+					OwnerReferences: []metav1.OwnerReference{{Kind: "Job", Name: job.Name, Controller: &controller}},
+				},
+				Spec:   corev1.PodSpec{NodeName: "cp-1"},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+			objects = append(objects, job, pod)
+		}
+
+		output, err := listEtcdBackups(ctx, newTestWorkloadClient(objects...))
+		require.NoError(t, err)
+		assert.True(t, output.Configured)
+		assert.Equal(t, "@daily", output.Schedule)
+		assert.Equal(t, 7, output.Retention)
+		assert.Equal(t, "2026-03-02T00:00:00Z", output.LastScheduleTime)
+
+		require.Len(t, output.Backups, 3)
+		assert.Equal(t, "backup-c", output.Backups[0].Job)
+		assert.Equal(t, "Running", output.Backups[0].Status)
+		assert.Equal(t, "Failed", output.Backups[1].Status)
+		assert.Equal(t, "Succeeded", output.Backups[2].Status)
+		assert.Equal(t, "cp-1", output.Backups[2].Node)
+		assert.Contains(t, output.Message, "1 of the last 3")
+	})
+}
+
+func TestParseEtcdBackupLogs(t *testing.T) {
+	snapshot, size := parseEtcdBackupLogs("snapshot etcd-snapshot-20260302T000000Z.db 4198432\n")
+	assert.Equal(t, "etcd-snapshot-20260302T000000Z.db", snapshot)
+	assert.Equal(t, int64(4198432), size)
+
+	snapshot, size = parseEtcdBackupLogs("mv: can't rename '/backups/.snapshot.part'\n")
+	assert.Empty(t, snapshot)
+	assert.Zero(t, size)
+}
+
+func TestEnhancedClusterService_ConfigureEtcdBackup(t *testing.T) {
+	ctx := context.Background()
+	stacked := withControlPlane(createTestCluster("kcp-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		"AWSCluster", "KubeadmControlPlane", "kcp-cluster-control-plane")
+	external := withControlPlane(createTestCluster("external-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		"AWSCluster", "KubeadmControlPlane", "external-cluster-control-plane")
+	managed := withControlPlane(createTestCluster("eks-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		"AWSManagedCluster", "AWSManagedControlPlane", "eks-cluster-control-plane")
+	objects := []client.Object{
+		stacked, external, managed,
+		createTestCluster("pending-cluster", testNamespace, clusterv1.ClusterPhaseProvisioning),
+		createTestControlPlane("KubeadmControlPlane", "kcp-cluster-control-plane", map[string]interface{}{"replicas": int64(3)}, nil),
+		createTestControlPlane("KubeadmControlPlane", "external-cluster-control-plane", map[string]interface{}{
+			"kubeadmConfigSpec": map[string]interface{}{
+				"clusterConfiguration": map[string]interface{}{
+					"etcd": map[string]interface{}{
+						"external": map[string]interface{}{"endpoints": []interface{}{"https://etcd.example.com:2379"}},
+					},
+				},
+			},
+		}, nil),
+		createTestControlPlane("AWSManagedControlPlane", "eks-cluster-control-plane", map[string]interface{}{}, nil),
+	}
+
+	tests := []struct {
+		name     string
+		input    api.ConfigureEtcdBackupInput
+		noImages bool
+		code     errors.ErrorCode
+	}{
+		{name: "missing cluster name", input: api.ConfigureEtcdBackupInput{}, code: errors.CodeInvalidInput},
+		{name: "invalid schedule", input: api.ConfigureEtcdBackupInput{ClusterName: "kcp-cluster", Schedule: "every day"}, code: errors.CodeInvalidInput},
+		{name: "unknown predefined schedule", input: api.ConfigureEtcdBackupInput{ClusterName: "kcp-cluster", Schedule: "@fortnightly"}, code: errors.CodeInvalidInput},
+		{name: "retention too high", input: api.ConfigureEtcdBackupInput{ClusterName: "kcp-cluster", Retention: 31}, code: errors.CodeInvalidInput},
+		{name: "invalid claim name", input: api.ConfigureEtcdBackupInput{ClusterName: "kcp-cluster", PersistentVolumeClaim: "etcd_backups"}, code: errors.CodeInvalidInput},
+		{name: "images not configured", input: api.ConfigureEtcdBackupInput{ClusterName: "kcp-cluster"}, noImages: true, code: errors.CodeUnavailable},
+		{name: "cluster not found", input: api.ConfigureEtcdBackupInput{ClusterName: "missing"}, code: errors.CodeNotFound},
+		{name: "no control plane yet", input: api.ConfigureEtcdBackupInput{ClusterName: "pending-cluster"}, code: errors.CodePreconditionFailed},
+		{name: "managed control plane", input: api.ConfigureEtcdBackupInput{ClusterName: "eks-cluster"}, code: errors.CodePreconditionFailed},
+		{name: "external etcd", input: api.ConfigureEtcdBackupInput{ClusterName: "external-cluster"}, code: errors.CodePreconditionFailed},
+		// Stacked etcd passes the checks; the test cluster has no kubeconfig to reach it
+		{name: "stacked etcd", input: api.ConfigureEtcdBackupInput{ClusterName: "kcp-cluster"}, code: errors.CodeDependencyFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, objects...)
+			if !tt.noImages {
+				svc.SetEtcdBackupImages("registry.k8s.io/etcd:3.5.16-0", "busybox:1.36")
+			}
+
+			_, err := svc.ConfigureEtcdBackup(ctx, tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/events"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+// SetEventBroker configures the broker the completion of operations and the
+// progress of long-running operations are published to. Without a broker,
+// nothing is published.
+func (s *EnhancedClusterService) SetEventBroker(broker *events.Broker) {
+	s.events = broker
+}
+
+// publishOperationCompleted publishes a recorded operation.
+func (s *EnhancedClusterService) publishOperationCompleted(op history.Operation) {
+	if s.events == nil {
+		return
+	}
+	s.events.Publish(events.Event{
+		Type:        events.OperationCompleted,
+		Namespace:   op.Namespace,
+		ClusterName: op.ClusterName,
+		Operation: &events.Operation{
+			Tool:          op.Tool,
+			Identity:      op.Identity,
+			CorrelationID: op.CorrelationID,
+			State:         op.Outcome,
+			Error:         op.Error,
+			DurationMs:    op.Duration.Milliseconds(),
+		},
+	})
+}
+
+// publishOperationProgress publishes the state of a long-running operation.
+func (s *EnhancedClusterService) publishOperationProgress(op async.Operation) {
+	if s.events == nil {
+		return
+	}
+	stages := make([]events.Stage, 0, len(op.Stages))
+	for _, stage := range op.Stages {
+		stages = append(stages, events.Stage{Name: stage.Name, State: stage.State, Message: stage.Message})
+	}
+	s.events.Publish(events.Event{
+		Type:        events.OperationProgress,
+		Namespace:   op.Namespace,
+		ClusterName: op.ClusterName,
+		Operation: &events.Operation{
+			ID:     op.ID,
+			Tool:   op.Kind,
+			State:  op.State,
+			Error:  op.Error,
+			Stages: stages,
+		},
+	})
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/events"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestEnhancedClusterService_PublishesOperations(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+	broker := events.NewBroker(16)
+	svc.SetEventBroker(broker)
+	svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	subscription := broker.Subscribe(events.Filter{Namespaces: []string{"team-a"}})
+	defer subscription.Close()
+
+	next := func() events.Event {
+		select {
+		case event := <-subscription.Events():
+			return event
+		case <-time.After(time.Second):
+			require.FailNow(t, "no event published")
+			return events.Event{}
+		}
+	}
+
+	svc.RecordOperation(ctx, history.Operation{Tool: "scale_cluster", ClusterName: "prod", Namespace: "team-a", Identity: "agent",
+		Outcome: history.OutcomeFailed, Error: "node pool not found", Duration: 1500 * time.Millisecond})
+	event := next()
+	assert.Equal(t, events.OperationCompleted, event.Type)
+	assert.Equal(t, "prod", event.ClusterName)
+	assert.Equal(t, &events.Operation{Tool: "scale_cluster", Identity: "agent", State: history.OutcomeFailed, Error: "node pool not found", DurationMs: 1500}, event.Operation)
+
+	run, err := svc.startAsyncOperation(kube.ContextWithNamespace(ctx, "team-a"), "restore_cluster", "prod", nil, "provision", "restore-workloads")
+	require.NoError(t, err)
+	assert.Equal(t, async.StatePending, next().Operation.State)
+
+	run.begin(ctx, "provision")
+	run.fail(ctx, "provision", fmt.Errorf("quota exceeded"))
+	assert.Equal(t, async.StateRunning, next().Operation.State)
+	event = next()
+	assert.Equal(t, events.OperationProgress, event.Type)
+	assert.Equal(t, run.op.ID, event.Operation.ID)
+	assert.Equal(t, "restore_cluster", event.Operation.Tool)
+	assert.Equal(t, async.StateFailed, event.Operation.State)
+	assert.Equal(t, []events.Stage{
+		{Name: "provision", State: async.StateFailed, Message: "quota exceeded"},
+		{Name: "restore-workloads", State: async.StatePending},
+	}, event.Operation.Stages)
+
+	// Operations in other namespaces are not delivered
+	svc.RecordOperation(ctx, history.Operation{Tool: "delete_cluster", ClusterName: "dev", Namespace: "team-b", Outcome: history.OutcomeSucceeded})
+	assert.Empty(t, subscription.Events())
+}
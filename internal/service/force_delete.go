@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/gc"
+)
+
+// forceDeleteMinAge is how long a cluster must have been deleting before its
+// finalizers may be removed, giving its controllers time to tear it down.
+const forceDeleteMinAge = 15 * time.Minute
+
+// ForceDeleteCluster reports the resources holding back the deletion of a
+// cluster. Called with RemoveFinalizers and the confirmation token of a
+// report, it deletes them and removes their finalizers, and then the
+// cluster's, leaving behind whatever cloud resources the finalizers
+// protected.
+func (s *EnhancedClusterService) ForceDeleteCluster(ctx context.Context, input api.ForceDeleteClusterInput) (*api.ForceDeleteClusterOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ForceDeleteCluster").WithCluster(input.ClusterName, "")
+	logger.Info("Checking stuck cluster deletion", "remove_finalizers", input.RemoveFinalizers)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if err := s.protectedClusterNames.ValidateClusterName("deletion", input.ClusterName); err != nil {
+		logger.WithError(err).Error("Cluster name is protected")
+		return nil, err
+	}
+	if input.RemoveFinalizers && input.ConfirmationToken == "" {
+		err := errors.New(errors.CodeInvalidInput,
+			"removing finalizers requires the confirmation token of a report; call without removeFinalizers first and review the blocking resources")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	forceCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(forceCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if cluster.DeletionTimestamp.IsZero() {
+		err := errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' is not being deleted; delete it with delete_cluster first", input.ClusterName))
+		logger.WithError(err).Error("Cluster not deleting")
+		return nil, err
+	}
+
+	collector := gc.NewCollector(s.kubeClient, gc.DefaultKinds, 0)
+	blockers, err := blockingResources(forceCtx, collector, cluster)
+	if err != nil {
+		logger.WithError(err).Error("Failed to find blocking resources")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to find the resources blocking deletion")
+	}
+
+	deletingFor := time.Since(cluster.DeletionTimestamp.Time).Round(time.Second)
+	output := &api.ForceDeleteClusterOutput{
+		ClusterName:   cluster.Name,
+		DeletingSince: cluster.DeletionTimestamp.UTC().Format(time.RFC3339),
+		Blockers:      make([]api.BlockingResource, 0, len(blockers)),
+	}
+	for _, blocker := range blockers {
+		output.Blockers = append(output.Blockers, toAPIBlockingResource(blocker))
+	}
+	if len(blockers) == 0 {
+		output.Message = fmt.Sprintf("Nothing is blocking the deletion of cluster '%s'; it should be gone shortly", cluster.Name)
+		return output, nil
+	}
+
+	token := gc.Token(blockers)
+	if !input.RemoveFinalizers {
+		output.ConfirmationToken = token
+		output.Message = fmt.Sprintf("Cluster '%s' has been deleting for %s, held back by %d resources with finalizers. "+
+			"Fix the failing teardown if possible; as a last resort, call again with removeFinalizers=true and confirmation token %s "+
+			"to remove the finalizers, which leaves the cloud resources they protect behind",
+			cluster.Name, deletingFor, len(blockers), token)
+		return output, nil
+	}
+
+	if input.ConfirmationToken != token {
+		err := errors.New(errors.CodePreconditionFailed,
+			"the resources blocking deletion changed since the confirmation token was issued; review them again and confirm with the new token").
+			WithDetails("confirmation_token", token)
+		logger.WithError(err).Error("Stale confirmation token")
+		return nil, err
+	}
+	if deletingFor < forceDeleteMinAge {
+		err := errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' has been deleting for only %s; finalizers can be removed after %s, once its controllers had time to tear it down",
+				cluster.Name, deletingFor, forceDeleteMinAge))
+		logger.WithError(err).Error("Cluster deletion too recent")
+		return nil, err
+	}
+
+	failed := 0
+	for i, blocker := range blockers {
+		if failed > 0 && blocker.Kind == "Cluster" {
+			output.Blockers[i].Error = "skipped until the finalizers of the other resources are removed"
+			continue
+		}
+		if err := collector.ForceDelete(forceCtx, blocker); err != nil {
+			logger.WithError(err).Warn("Failed to remove finalizers", "resource", blocker.String())
+			output.Blockers[i].Error = err.Error()
+			failed++
+		}
+	}
+	logger.Warn("Removed finalizers of stuck cluster", "resources", len(blockers)-failed, "failed", failed)
+
+	output.FinalizersRemoved = failed == 0
+	output.Message = fmt.Sprintf("Removed the finalizers of %d resources of cluster '%s'. Cloud resources they protected, "+
+		"such as instances, load balancers and VPCs, may be left behind; check the cloud account and delete them there",
+		len(blockers)-failed, cluster.Name)
+	if failed > 0 {
+		output.Message += fmt.Sprintf("; %d resources failed, call again to retry", failed)
+	}
+	return output, nil
+}
+
+// blockingResources returns the resources holding back the deletion of a
+// cluster, ending with the cluster itself: its own finalizer is removed last,
+// once nothing is left for its controller to wait for.
+func blockingResources(ctx context.Context, collector *gc.Collector, cluster *clusterv1.Cluster) ([]gc.Resource, error) {
+	blockers, err := collector.Blocking(ctx, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(cluster.Finalizers) > 0 {
+		blockers = append(blockers, clusterResource(cluster))
+	}
+	return blockers, nil
+}
+
+// clusterResource describes a cluster as a resource blocking its own
+// deletion.
+func clusterResource(cluster *clusterv1.Cluster) gc.Resource {
+	obj := &unstructured.Unstructured{}
+	if content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cluster); err == nil {
+		obj.Object = content
+	}
+	obj.SetAPIVersion(clusterv1.GroupVersion.String())
+	obj.SetKind("Cluster")
+
+	resource := gc.NewResource(obj)
+	resource.ClusterName = cluster.Name
+	return resource
+}
+
+// toAPIBlockingResource converts a blocking resource to its API representation.
+func toAPIBlockingResource(resource gc.Resource) api.BlockingResource {
+	return api.BlockingResource{
+		Kind:       resource.Kind,
+		APIVersion: resource.APIVersion,
+		Namespace:  resource.Namespace,
+		Name:       resource.Name,
+		Deleting:   resource.Deleting,
+		Finalizers: resource.Finalizers,
+		Reason:     resource.Reason,
+	}
+}
+
+// pendingDeletion describes a deletion that did not complete in time, with
+// the resources still holding it back: terminating Machines, infrastructure
+// objects whose teardown is failing, and so on.
+func (s *EnhancedClusterService) pendingDeletion(ctx context.Context, cluster *clusterv1.Cluster) *api.DeleteClusterOutput {
+	output := &api.DeleteClusterOutput{
+		Status:  "deleting",
+		Message: fmt.Sprintf("Cluster '%s' deletion initiated (may still be in progress)", cluster.Name),
+	}
+
+	reportCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+
+	// The cluster is re-read for its finalizers, which CAPI adds on creation
+	if current, err := s.kubeClient.GetClusterByName(reportCtx, cluster.Name); err == nil {
+		cluster = current
+	} else if apierrors.IsNotFound(err) {
+		output.Status = "deleted"
+		output.Message = fmt.Sprintf("Cluster '%s' deleted successfully", cluster.Name)
+		return output
+	}
+
+	blockers, err := blockingResources(reportCtx, gc.NewCollector(s.kubeClient, gc.DefaultKinds, 0), cluster)
+	if err != nil {
+		s.logger.WithContext(ctx).WithCluster(cluster.Name, "").WithError(err).Warn("Failed to find the resources blocking deletion")
+		return output
+	}
+	if len(blockers) == 0 {
+		return output
+	}
+
+	descriptions := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		output.Remaining = append(output.Remaining, toAPIBlockingResource(blocker))
+		description := blocker.String()
+		if blocker.Reason != "" {
+			description += " (" + blocker.Reason + ")"
+		}
+		descriptions = append(descriptions, description)
+	}
+	output.Message = fmt.Sprintf("Cluster '%s' is still deleting, held back by %d resources: %s. "+
+		"If the deletion does not progress, use force_delete_cluster to investigate",
+		cluster.Name, len(blockers), strings.Join(descriptions, "; "))
+	return output
+}
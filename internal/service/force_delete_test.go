@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_ForceDeleteCluster(t *testing.T) {
+	ctx := context.Background()
+
+	deletingCluster := func(name string, since time.Duration) *clusterv1.Cluster {
+		cluster := createTestCluster(name, testNamespace, clusterv1.ClusterPhaseDeleting)
+		deletionTimestamp := metav1.NewTime(time.Now().Add(-since))
+		cluster.DeletionTimestamp = &deletionTimestamp
+		cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+		return cluster
+	}
+	stuckMD := createTestMachineDeployment("stuck-md-0", testNamespace, "stuck", 2)
+	stuckMD.Finalizers = []string{"cluster.x-k8s.io/machinedeployment"}
+
+	svc, fakeClient := setupEnhancedTestService(t,
+		deletingCluster("stuck", time.Hour),
+		deletingCluster("recent", time.Minute),
+		createTestCluster("running", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		stuckMD,
+	)
+
+	t.Run("not deleting", func(t *testing.T) {
+		_, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{ClusterName: "running"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{ClusterName: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+
+	t.Run("token required", func(t *testing.T) {
+		_, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{ClusterName: "stuck", RemoveFinalizers: true})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+
+	t.Run("deleting too recently", func(t *testing.T) {
+		report, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{ClusterName: "recent"})
+		require.NoError(t, err)
+
+		_, err = svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{
+			ClusterName: "recent", RemoveFinalizers: true, ConfirmationToken: report.ConfirmationToken,
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "deleting for only")
+	})
+
+	report, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{ClusterName: "stuck"})
+	require.NoError(t, err)
+	require.Len(t, report.Blockers, 2)
+	assert.Equal(t, "MachineDeployment", report.Blockers[0].Kind)
+	assert.Equal(t, "stuck-md-0", report.Blockers[0].Name)
+	assert.Equal(t, "Cluster", report.Blockers[1].Kind)
+	assert.True(t, report.Blockers[1].Deleting)
+	assert.False(t, report.FinalizersRemoved)
+	require.NotEmpty(t, report.ConfirmationToken)
+	assert.Contains(t, report.Message, report.ConfirmationToken)
+
+	t.Run("stale token", func(t *testing.T) {
+		_, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{
+			ClusterName: "stuck", RemoveFinalizers: true, ConfirmationToken: "0123456789abcdef",
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+
+		var md clusterv1.MachineDeployment
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(stuckMD), &md))
+		assert.NotEmpty(t, md.Finalizers)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		output, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{
+			ClusterName: "stuck", RemoveFinalizers: true, ConfirmationToken: report.ConfirmationToken,
+		})
+		require.NoError(t, err)
+		assert.True(t, output.FinalizersRemoved)
+		assert.Contains(t, output.Message, "may be left behind")
+
+		var md clusterv1.MachineDeployment
+		err = fakeClient.Get(ctx, client.ObjectKeyFromObject(stuckMD), &md)
+		assert.True(t, apierrors.IsNotFound(err), "machine deployment should be gone, got %v", err)
+
+		var cluster clusterv1.Cluster
+		err = fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "stuck"}, &cluster)
+		assert.True(t, apierrors.IsNotFound(err), "cluster should be gone, got %v", err)
+	})
+}
+
+func TestEnhancedClusterService_PendingDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := createTestCluster("slow", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+	machine := createTestMachine("slow-md-0-abc12", "slow", "slow-md-0")
+	machine.Finalizers = []string{clusterv1.MachineFinalizer}
+
+	svc, fakeClient := setupEnhancedTestService(t, cluster, machine)
+	require.NoError(t, fakeClient.Delete(ctx, cluster))
+	require.NoError(t, fakeClient.Delete(ctx, machine))
+
+	output := svc.pendingDeletion(ctx, cluster)
+	assert.Equal(t, "deleting", output.Status)
+	require.Len(t, output.Remaining, 2)
+	assert.Equal(t, "Machine", output.Remaining[0].Kind)
+	assert.True(t, output.Remaining[0].Deleting)
+	assert.Equal(t, "Cluster", output.Remaining[1].Kind)
+	assert.Contains(t, output.Message, "held back by 2 resources: Machine "+testNamespace+"/slow-md-0-abc12")
+
+	t.Run("deleted meanwhile", func(t *testing.T) {
+		output := svc.pendingDeletion(ctx, createTestCluster("gone", testNamespace, clusterv1.ClusterPhaseDeleting))
+		assert.Equal(t, "deleted", output.Status)
+		assert.Empty(t, output.Remaining)
+	})
+}
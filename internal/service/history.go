@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+// maxListedOperations bounds the number of operations list_operations returns.
+const maxListedOperations = 500
+
+// SetOperationHistory configures the store operations are recorded in.
+// Without a store, operations are not recorded and list_operations is unavailable.
+func (s *EnhancedClusterService) SetOperationHistory(store history.Store) {
+	s.history = store
+}
+
+// RecordOperation persists an operation to the history store, publishes its
+// completion and counts successful operations on clusters as mutations of the
+// calling identity. Recording is best effort: failures are logged and never
+// fail the operation itself.
+func (s *EnhancedClusterService) RecordOperation(ctx context.Context, op history.Operation) {
+	s.publishOperationCompleted(op)
+	if s.accounting != nil && op.Outcome == history.OutcomeSucceeded && op.ClusterName != "" {
+		s.accounting.RecordMutation(op.Identity, op.Tool)
+	}
+	if s.history == nil {
+		return
+	}
+
+	// Record even when the request context was cancelled mid-operation
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if err := s.history.Record(recordCtx, op); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to record operation",
+			"tool", op.Tool,
+			"cluster", op.ClusterName,
+		)
+	}
+}
+
+// ListOperations returns recorded operations, newest first. When namespaces is
+// non-empty only operations in those namespaces are returned.
+func (s *EnhancedClusterService) ListOperations(ctx context.Context, input api.ListOperationsInput, namespaces []string) (*api.ListOperationsOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ListOperations")
+	logger.Info("Listing operations", "cluster", input.ClusterName)
+
+	if s.history == nil {
+		err := errors.New(errors.CodeUnavailable, "operation history is not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	filter, err := operationFilter(input, namespaces)
+	if err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	listCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	operations, err := s.history.List(listCtx, filter)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list operations")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list operations")
+	}
+
+	output := &api.ListOperationsOutput{Operations: make([]api.Operation, 0, len(operations))}
+	for _, op := range operations {
+		output.Operations = append(output.Operations, toAPIOperation(op))
+	}
+
+	logger.Info("Listed operations", "count", len(output.Operations))
+	return output, nil
+}
+
+// toAPIOperation converts a recorded operation to its API representation.
+func toAPIOperation(op history.Operation) api.Operation {
+	return api.Operation{
+		ID:            op.ID,
+		Tool:          op.Tool,
+		ClusterName:   op.ClusterName,
+		Namespace:     op.Namespace,
+		Identity:      op.Identity,
+		CorrelationID: op.CorrelationID,
+		Outcome:       op.Outcome,
+		Error:         op.Error,
+		StartedAt:     op.StartedAt.UTC().Format(time.RFC3339),
+		DurationMs:    op.Duration.Milliseconds(),
+		Parameters:    op.Parameters,
+	}
+}
+
+// operationFilter converts list_operations input to a history filter.
+func operationFilter(input api.ListOperationsInput, namespaces []string) (history.Filter, error) {
+	filter := history.Filter{
+		ClusterName: input.ClusterName,
+		Namespaces:  namespaces,
+		Limit:       input.Limit,
+	}
+
+	if input.Limit < 0 {
+		return filter, errors.New(errors.CodeInvalidInput, "limit cannot be negative")
+	}
+	if filter.Limit == 0 || filter.Limit > maxListedOperations {
+		filter.Limit = maxListedOperations
+	}
+
+	var err error
+	if input.Since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, input.Since); err != nil {
+			return filter, errors.New(errors.CodeInvalidInput, fmt.Sprintf("since must be an RFC 3339 timestamp, got '%s'", input.Since))
+		}
+	}
+	if input.Until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, input.Until); err != nil {
+			return filter, errors.New(errors.CodeInvalidInput, fmt.Sprintf("until must be an RFC 3339 timestamp, got '%s'", input.Until))
+		}
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+		return filter, errors.New(errors.CodeInvalidInput, "until must not be before since")
+	}
+	return filter, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+func TestEnhancedClusterService_ListOperations(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("history disabled", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+
+		_, err := svc.ListOperations(ctx, api.ListOperationsInput{}, nil)
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+	})
+
+	svc, _ := setupEnhancedTestService(t)
+	svc.SetOperationHistory(history.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "scale_cluster",
+		ClusterName: "alpha",
+		Namespace:   "team-a",
+		Identity:    "team-a-agent",
+		Outcome:     history.OutcomeSucceeded,
+		StartedAt:   base,
+		Duration:    1500 * time.Millisecond,
+		Parameters:  map[string]string{"replicas": "5"},
+	})
+	svc.RecordOperation(ctx, history.Operation{
+		Tool:        "delete_cluster",
+		ClusterName: "beta",
+		Namespace:   "team-b",
+		Outcome:     history.OutcomeFailed,
+		StartedAt:   base.Add(time.Hour),
+	})
+
+	t.Run("lists operations", func(t *testing.T) {
+		output, err := svc.ListOperations(ctx, api.ListOperationsInput{}, nil)
+		require.NoError(t, err)
+		require.Len(t, output.Operations, 2)
+		assert.Equal(t, "delete_cluster", output.Operations[0].Tool)
+
+		op := output.Operations[1]
+		assert.Equal(t, "scale_cluster", op.Tool)
+		assert.Equal(t, "team-a-agent", op.Identity)
+		assert.Equal(t, "2025-07-01T12:00:00Z", op.StartedAt)
+		assert.Equal(t, int64(1500), op.DurationMs)
+		assert.Equal(t, "5", op.Parameters["replicas"])
+	})
+
+	t.Run("filters by time range and namespace", func(t *testing.T) {
+		output, err := svc.ListOperations(ctx, api.ListOperationsInput{Since: "2025-07-01T12:30:00Z"}, nil)
+		require.NoError(t, err)
+		require.Len(t, output.Operations, 1)
+		assert.Equal(t, "beta", output.Operations[0].ClusterName)
+
+		output, err = svc.ListOperations(ctx, api.ListOperationsInput{}, []string{"team-a"})
+		require.NoError(t, err)
+		require.Len(t, output.Operations, 1)
+		assert.Equal(t, "alpha", output.Operations[0].ClusterName)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tests := []api.ListOperationsInput{
+			{Since: "yesterday"},
+			{Until: "2025-07-01"},
+			{Since: "2025-07-02T00:00:00Z", Until: "2025-07-01T00:00:00Z"},
+			{Limit: -1},
+		}
+		for _, input := range tests {
+			_, err := svc.ListOperations(ctx, input, nil)
+			require.Error(t, err)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		}
+	})
+}
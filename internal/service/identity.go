@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+const (
+	// IdentityRefVariable is the topology variable naming the CAPA identity a
+	// cluster's AWS resources are managed with, propagated to identityRef on
+	// the AWSCluster by the ClusterClass.
+	IdentityRefVariable = "identityRef"
+
+	// awsIdentityAPIVersion is the API version of the CAPA identity kinds.
+	awsIdentityAPIVersion = "infrastructure.cluster.x-k8s.io/v1beta2"
+)
+
+// identityRef is a reference to a CAPA identity.
+type identityRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// checkIdentityRef checks that the identity named by the identityRef variable
+// exists and may be used by clusters in the namespace, so that a typo or a
+// missing allowedNamespaces entry fails the request instead of leaving the
+// cluster stuck waiting for AWS credentials.
+func (s *EnhancedClusterService) checkIdentityRef(ctx context.Context, variables map[string]interface{}, namespace string) error {
+	value, ok := variables[IdentityRefVariable]
+	if !ok {
+		return nil
+	}
+	if err := validation.NewValidator().ValidateClusterVariables(map[string]interface{}{IdentityRefVariable: value}); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInvalidInput, "invalid identityRef")
+	}
+	var ref identityRef
+	if err := json.Unmarshal(raw, &ref); err != nil {
+		return errors.Wrap(err, errors.CodeInvalidInput, "invalid identityRef")
+	}
+
+	identity, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(awsIdentityAPIVersion, ref.Kind), "", ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return errors.New(errors.CodeNotFound,
+				fmt.Sprintf("%s '%s' not found, create it on the management cluster first", ref.Kind, ref.Name))
+		}
+		return errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to get %s", ref.Kind))
+	}
+
+	// The controller identity may be used from any namespace
+	if ref.Kind == "AWSClusterControllerIdentity" {
+		return nil
+	}
+	return s.checkIdentityAllowedNamespaces(ctx, identity, namespace)
+}
+
+// checkIdentityAllowedNamespaces checks the allowedNamespaces of a role or
+// static identity. CAPA treats a missing allowedNamespaces as allowing no
+// namespace and an empty one as allowing every namespace.
+func (s *EnhancedClusterService) checkIdentityAllowedNamespaces(ctx context.Context, identity *unstructured.Unstructured, namespace string) error {
+	allowed, found, _ := unstructured.NestedMap(identity.Object, "spec", "allowedNamespaces")
+	if !found {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("%s '%s' has no allowedNamespaces, so no cluster may use it", identity.GetKind(), identity.GetName()))
+	}
+
+	names, _, _ := unstructured.NestedStringSlice(allowed, "list")
+	selectorMap, hasSelector, _ := unstructured.NestedMap(allowed, "selector")
+	if len(names) == 0 && !hasSelector {
+		return nil
+	}
+	if slices.Contains(names, namespace) {
+		return nil
+	}
+	if hasSelector {
+		var labelSelector metav1.LabelSelector
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selectorMap, &labelSelector); err != nil {
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "invalid allowedNamespaces selector")
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "invalid allowedNamespaces selector")
+		}
+		ns, err := s.kubeClient.GetObject(ctx, schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, "", namespace)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get namespace")
+		}
+		if !selector.Empty() && selector.Matches(labels.Set(ns.GetLabels())) {
+			return nil
+		}
+	}
+	return errors.New(errors.CodeForbidden,
+		fmt.Sprintf("%s '%s' does not allow clusters in namespace '%s'", identity.GetKind(), identity.GetName(), namespace))
+}
+
+// getIdentity returns the CAPA identity a cluster is managed with. It is read
+// from the infrastructure cluster's identityRef, falling back to the
+// identityRef variable while the infrastructure cluster is unavailable.
+func (s *EnhancedClusterService) getIdentity(ctx context.Context, cluster *clusterv1.Cluster) *api.ClusterIdentity {
+	if clusterProvider(cluster) == "aws" && cluster.Spec.InfrastructureRef != nil {
+		ref := cluster.Spec.InfrastructureRef
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cluster.Namespace
+		}
+		infraCluster, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		if err == nil {
+			kind, _, _ := unstructured.NestedString(infraCluster.Object, "spec", "identityRef", "kind")
+			name, _, _ := unstructured.NestedString(infraCluster.Object, "spec", "identityRef", "name")
+			if kind == "" {
+				// CAPA defaults to the controller's own identity
+				kind, name = "AWSClusterControllerIdentity", validation.AWSControllerIdentityName
+			}
+			return s.describeIdentity(ctx, identityRef{Kind: kind, Name: name}, "infrastructure")
+		}
+		s.logger.WithContext(ctx).WithError(err).Debug("Failed to get infrastructure cluster",
+			logging.FieldClusterName, cluster.Name,
+		)
+	}
+
+	if ref := requestedIdentityRef(cluster); ref != nil {
+		return s.describeIdentity(ctx, *ref, "variables")
+	}
+	return nil
+}
+
+// describeIdentity reports an identity along with the role it assumes, if any.
+func (s *EnhancedClusterService) describeIdentity(ctx context.Context, ref identityRef, source string) *api.ClusterIdentity {
+	identity := &api.ClusterIdentity{Kind: ref.Kind, Name: ref.Name, Source: source}
+	if ref.Kind != "AWSClusterRoleIdentity" {
+		return identity
+	}
+	obj, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(awsIdentityAPIVersion, ref.Kind), "", ref.Name)
+	if err != nil {
+		return identity
+	}
+	identity.RoleARN, _, _ = unstructured.NestedString(obj.Object, "spec", "roleARN")
+	identity.SourceIdentity, _, _ = unstructured.NestedString(obj.Object, "spec", "sourceIdentityRef", "name")
+	return identity
+}
+
+// requestedIdentityRef returns the identityRef topology variable of a cluster.
+func requestedIdentityRef(cluster *clusterv1.Cluster) *identityRef {
+	if cluster.Spec.Topology == nil {
+		return nil
+	}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		if variable.Name != IdentityRefVariable {
+			continue
+		}
+		var ref identityRef
+		if err := json.Unmarshal(variable.Value.Raw, &ref); err == nil && ref.Kind != "" && ref.Name != "" {
+			return &ref
+		}
+	}
+	return nil
+}
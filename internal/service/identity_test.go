@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// createTestIdentity creates a CAPA identity with the given spec.
+func createTestIdentity(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	identity := &unstructured.Unstructured{}
+	identity.SetAPIVersion(awsIdentityAPIVersion)
+	identity.SetKind(kind)
+	identity.SetName(name)
+	identity.Object["spec"] = spec
+	return identity
+}
+
+func TestEnhancedClusterService_CheckIdentityRef(t *testing.T) {
+	ctx := context.Background()
+
+	teamNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   testNamespace,
+		Labels: map[string]string{"team": "a"},
+	}}
+	roleIdentity := func(allowedNamespaces interface{}) *unstructured.Unstructured {
+		spec := map[string]interface{}{"roleARN": "arn:aws:iam::111111111111:role/capa"}
+		if allowedNamespaces != nil {
+			spec["allowedNamespaces"] = allowedNamespaces
+		}
+		return createTestIdentity("AWSClusterRoleIdentity", "team-a", spec)
+	}
+	ref := map[string]interface{}{"kind": "AWSClusterRoleIdentity", "name": "team-a"}
+
+	tests := []struct {
+		name      string
+		variables map[string]interface{}
+		objects   []client.Object
+		code      errors.ErrorCode
+	}{
+		{
+			name:      "no identityRef",
+			variables: map[string]interface{}{"region": "us-west-2"},
+		},
+		{
+			name:      "any namespace allowed",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects:   []client.Object{roleIdentity(map[string]interface{}{})},
+		},
+		{
+			name:      "namespace listed",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects:   []client.Object{roleIdentity(map[string]interface{}{"list": []interface{}{"other", testNamespace}})},
+		},
+		{
+			name:      "namespace selected",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects: []client.Object{teamNamespace, roleIdentity(map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"team": "a"}},
+			})},
+		},
+		{
+			name:      "namespace not selected",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects: []client.Object{teamNamespace, roleIdentity(map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": map[string]interface{}{"team": "b"}},
+			})},
+			code: errors.CodeForbidden,
+		},
+		{
+			name:      "namespace not listed",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects:   []client.Object{roleIdentity(map[string]interface{}{"list": []interface{}{"other"}})},
+			code:      errors.CodeForbidden,
+		},
+		{
+			name:      "no allowedNamespaces",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects:   []client.Object{roleIdentity(nil)},
+			code:      errors.CodeForbidden,
+		},
+		{
+			name:      "controller identity",
+			variables: map[string]interface{}{IdentityRefVariable: map[string]interface{}{"kind": "AWSClusterControllerIdentity", "name": "default"}},
+			objects:   []client.Object{createTestIdentity("AWSClusterControllerIdentity", "default", map[string]interface{}{})},
+		},
+		{
+			name:      "identity not found",
+			variables: map[string]interface{}{IdentityRefVariable: ref},
+			objects:   []client.Object{createTestIdentity("AWSClusterRoleIdentity", "team-b", map[string]interface{}{})},
+			code:      errors.CodeNotFound,
+		},
+		{
+			name:      "invalid identityRef",
+			variables: map[string]interface{}{IdentityRefVariable: map[string]interface{}{"kind": "AWSClusterRoleIdentity"}},
+			code:      errors.CodeInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, tt.objects...)
+
+			err := svc.checkIdentityRef(ctx, tt.variables, testNamespace)
+			if tt.code == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			}
+		})
+	}
+}
+
+func TestEnhancedClusterService_GetIdentity(t *testing.T) {
+	ctx := context.Background()
+
+	awsCluster := func(spec map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+		obj.SetKind("AWSCluster")
+		obj.SetName("prod-abc12")
+		obj.SetNamespace(testNamespace)
+		obj.Object["spec"] = spec
+		return obj
+	}
+	withInfrastructureRef := func(cluster *clusterv1.Cluster) *clusterv1.Cluster {
+		cluster.Spec.InfrastructureRef = &corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+			Kind:       "AWSCluster",
+			Name:       "prod-abc12",
+		}
+		return cluster
+	}
+	roleIdentity := createTestIdentity("AWSClusterRoleIdentity", "team-a", map[string]interface{}{
+		"roleARN":           "arn:aws:iam::111111111111:role/capa",
+		"sourceIdentityRef": map[string]interface{}{"kind": "AWSClusterControllerIdentity", "name": "default"},
+	})
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		objects []client.Object
+		want    *api.ClusterIdentity
+	}{
+		{
+			name:    "read from infrastructure cluster",
+			cluster: withInfrastructureRef(createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned)),
+			objects: []client.Object{roleIdentity, awsCluster(map[string]interface{}{
+				"identityRef": map[string]interface{}{"kind": "AWSClusterRoleIdentity", "name": "team-a"},
+			})},
+			want: &api.ClusterIdentity{
+				Kind:           "AWSClusterRoleIdentity",
+				Name:           "team-a",
+				RoleARN:        "arn:aws:iam::111111111111:role/capa",
+				SourceIdentity: "default",
+				Source:         "infrastructure",
+			},
+		},
+		{
+			name:    "controller identity by default",
+			cluster: withInfrastructureRef(createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned)),
+			objects: []client.Object{awsCluster(map[string]interface{}{"region": "us-west-2"})},
+			want:    &api.ClusterIdentity{Kind: "AWSClusterControllerIdentity", Name: "default", Source: "infrastructure"},
+		},
+		{
+			name: "infrastructure cluster not created yet",
+			cluster: withInfrastructureRef(withTopologyVariables(createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioning),
+				map[string]string{"region": "us-west-2"})),
+		},
+		{
+			name:    "no identity",
+			cluster: createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, tt.objects...)
+			assert.Equal(t, tt.want, svc.getIdentity(ctx, tt.cluster))
+		})
+	}
+
+	t.Run("requested identityRef", func(t *testing.T) {
+		cluster := withInfrastructureRef(createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioning))
+		cluster.Spec.Topology.Variables = append(cluster.Spec.Topology.Variables, clusterv1.ClusterVariable{
+			Name:  IdentityRefVariable,
+			Value: apiextensionsv1.JSON{Raw: []byte(`{"kind":"AWSClusterStaticIdentity","name":"legacy"}`)},
+		})
+		svc, _ := setupEnhancedTestService(t)
+
+		assert.Equal(t, &api.ClusterIdentity{Kind: "AWSClusterStaticIdentity", Name: "legacy", Source: "variables"},
+			svc.getIdentity(ctx, cluster))
+	})
+
+	t.Run("get cluster", func(t *testing.T) {
+		cluster := withInfrastructureRef(createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned))
+		svc, _ := setupEnhancedTestService(t, cluster, roleIdentity, awsCluster(map[string]interface{}{
+			"identityRef": map[string]interface{}{"kind": "AWSClusterRoleIdentity", "name": "team-a"},
+		}))
+
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "prod"})
+		require.NoError(t, err)
+		require.NotNil(t, output.Cluster.Identity)
+		assert.Equal(t, "arn:aws:iam::111111111111:role/capa", output.Cluster.Identity.RoleARN)
+	})
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+const (
+	// controlPlaneInstanceTypeVariable and workerInstanceTypeVariable select
+	// the instance types of the control plane and worker nodes, falling back
+	// to instanceType.
+	controlPlaneInstanceTypeVariable = "controlPlaneInstanceType"
+	workerInstanceTypeVariable       = "workerInstanceType"
+
+	// nodeCountVariable is the number of worker nodes of a new cluster.
+	nodeCountVariable = "nodeCount"
+)
+
+// instanceTypeVariables are the variables selecting an instance type.
+var instanceTypeVariables = []string{
+	instanceTypeVariable,
+	controlPlaneInstanceTypeVariable,
+	workerInstanceTypeVariable,
+	validation.BastionInstanceTypeVariable,
+}
+
+// templateInstanceTypePaths are where infrastructure machine templates and
+// machine pools hold their instance type.
+var templateInstanceTypePaths = [][]string{
+	{"spec", "template", "spec", "instanceType"},  // AWSMachineTemplate, GCPMachineTemplate
+	{"spec", "awsLaunchTemplate", "instanceType"}, // AWSMachinePool
+	{"spec", "template", "spec", "vmSize"},        // AzureMachineTemplate
+	{"spec", "template", "vmSize"},                // AzureMachinePool
+}
+
+// InstanceTypeLimits restricts the instance types clusters may use and the
+// total size of their nodes.
+type InstanceTypeLimits struct {
+	Policy validation.InstanceTypePolicy
+
+	// MaxVCPUs and MaxMemoryMiB cap the total vCPUs and memory of a
+	// cluster's nodes. Zero means no limit.
+	MaxVCPUs     int
+	MaxMemoryMiB int64
+}
+
+// SetInstanceTypeLimits configures the instance type policy and cluster size
+// limits enforced when clusters are created and scaled.
+func (s *EnhancedClusterService) SetInstanceTypeLimits(limits InstanceTypeLimits) {
+	s.instanceTypeLimits = limits
+}
+
+// checkInstanceTypes checks the instance types set in variables against the
+// instance type policy.
+func (s *EnhancedClusterService) checkInstanceTypes(variables map[string]interface{}) error {
+	for _, name := range instanceTypeVariables {
+		if instanceType, ok := variables[name].(string); ok && instanceType != "" {
+			if err := s.instanceTypeLimits.Policy.ValidateInstanceType(name, instanceType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nodeGroup is a set of nodes of the same instance type, such as a node pool.
+type nodeGroup struct {
+	name         string
+	instanceType string
+	replicas     int32
+}
+
+// checkClusterSize checks the total vCPUs and memory of a cluster's node
+// groups against the limits. Instance types whose size cannot be looked up
+// fail the check rather than let an oversized cluster through.
+func (s *EnhancedClusterService) checkClusterSize(ctx context.Context, providerName, region string, groups []nodeGroup) error {
+	limits := s.instanceTypeLimits
+	if limits.MaxVCPUs == 0 && limits.MaxMemoryMiB == 0 {
+		return nil
+	}
+
+	var sizer provider.InstanceTypeSizer
+	if s.providerManager != nil {
+		if prov, exists := s.providerManager.GetProvider(providerName); exists {
+			sizer, _ = prov.(provider.InstanceTypeSizer)
+		}
+	}
+	if sizer == nil {
+		return errors.New(errors.CodeUnavailable,
+			fmt.Sprintf("cluster size limits cannot be checked: provider '%s' does not report instance type sizes", providerName))
+	}
+
+	var vcpus, memoryMiB int64
+	nodes := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if group.replicas == 0 {
+			continue
+		}
+		if group.instanceType == "" {
+			return errors.New(errors.CodeUnavailable,
+				fmt.Sprintf("cluster size limits cannot be checked: the instance type of %s is unknown", group.name))
+		}
+		size, err := sizer.InstanceTypeSize(ctx, region, group.instanceType)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeUnavailable,
+				fmt.Sprintf("cluster size limits cannot be checked: failed to look up instance type %s", group.instanceType))
+		}
+		vcpus += int64(group.replicas) * int64(size.VCPUs)
+		memoryMiB += int64(group.replicas) * size.MemoryMiB
+		nodes = append(nodes, fmt.Sprintf("%d x %s (%s)", group.replicas, group.instanceType, group.name))
+	}
+
+	if limits.MaxVCPUs > 0 && vcpus > int64(limits.MaxVCPUs) {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("cluster would have %d vCPUs, over the limit of %d per cluster: %s",
+				vcpus, limits.MaxVCPUs, strings.Join(nodes, ", "))).
+			WithDetails("vcpus", vcpus)
+	}
+	if limits.MaxMemoryMiB > 0 && memoryMiB > limits.MaxMemoryMiB {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("cluster would have %d GiB of memory, over the limit of %d GiB per cluster: %s",
+				memoryMiB/1024, limits.MaxMemoryMiB/1024, strings.Join(nodes, ", "))).
+			WithDetails("memory_mib", memoryMiB)
+	}
+	return nil
+}
+
+// newClusterNodeGroups returns the nodes a new cluster starts with: a single
+// control plane node, as the topology leaves its replicas to the control
+// plane's default, and nodeCount workers. Instance types and the node count
+// not set in variables are taken from the ClusterClass variable defaults.
+func newClusterNodeGroups(clusterClass *clusterv1.ClusterClass, variables map[string]interface{}) []nodeGroup {
+	value := func(name string) interface{} {
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		for _, variable := range clusterClass.Spec.Variables {
+			if variable.Name != name || variable.Schema.OpenAPIV3Schema.Default == nil {
+				continue
+			}
+			var value interface{}
+			if err := json.Unmarshal(variable.Schema.OpenAPIV3Schema.Default.Raw, &value); err == nil {
+				return value
+			}
+		}
+		return nil
+	}
+	instanceType := func(name string) string {
+		if instanceType, ok := value(name).(string); ok && instanceType != "" {
+			return instanceType
+		}
+		instanceType, _ := value(instanceTypeVariable).(string)
+		return instanceType
+	}
+
+	workers := int32(0)
+	if count, ok := value(nodeCountVariable).(float64); ok && count > 0 {
+		workers = int32(count)
+	}
+	return []nodeGroup{
+		{name: "control plane", instanceType: instanceType(controlPlaneInstanceTypeVariable), replicas: 1},
+		{name: "workers", instanceType: instanceType(workerInstanceTypeVariable), replicas: workers},
+	}
+}
+
+// clusterNodeGroups returns the node groups of an existing cluster: its
+// control plane, unless it is managed by the provider, and its node pools,
+// with their desired replicas and the instance types of their infrastructure
+// templates.
+func (s *EnhancedClusterService) clusterNodeGroups(ctx context.Context, cluster *clusterv1.Cluster) ([]nodeGroup, error) {
+	var groups []nodeGroup
+
+	if cluster.Spec.ControlPlaneRef != nil && s.getManagedControlPlaneProvider(cluster) == nil {
+		controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get control plane")
+		}
+		ref := &corev1.ObjectReference{}
+		ref.APIVersion, _, _ = unstructured.NestedString(controlPlane.Object, "spec", "machineTemplate", "infrastructureRef", "apiVersion")
+		ref.Kind, _, _ = unstructured.NestedString(controlPlane.Object, "spec", "machineTemplate", "infrastructureRef", "kind")
+		ref.Name, _, _ = unstructured.NestedString(controlPlane.Object, "spec", "machineTemplate", "infrastructureRef", "name")
+		groups = append(groups, nodeGroup{
+			name:         "control plane",
+			instanceType: s.templateInstanceType(ctx, cluster.Namespace, ref),
+			replicas:     s.getControlPlaneReplicas(controlPlane),
+		})
+	}
+
+	machineDeployments, err := s.kubeClient.ListMachineDeployments(ctx, cluster.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployments")
+	}
+	for _, md := range machineDeployments.Items {
+		groups = append(groups, nodeGroup{
+			name:         "MachineDeployment/" + md.Name,
+			instanceType: s.templateInstanceType(ctx, md.Namespace, &md.Spec.Template.Spec.InfrastructureRef),
+			replicas:     ptrValue(md.Spec.Replicas),
+		})
+	}
+
+	machinePools, err := s.kubeClient.ListMachinePools(ctx, cluster.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine pools")
+	}
+	for _, mp := range machinePools.Items {
+		groups = append(groups, nodeGroup{
+			name:         "MachinePool/" + mp.Name,
+			instanceType: s.templateInstanceType(ctx, mp.Namespace, &mp.Spec.Template.Spec.InfrastructureRef),
+			replicas:     ptrValue(mp.Spec.Replicas),
+		})
+	}
+	return groups, nil
+}
+
+// templateInstanceType returns the instance type of an infrastructure machine
+// template or machine pool, or "" if it cannot be read.
+func (s *EnhancedClusterService) templateInstanceType(ctx context.Context, namespace string, ref *corev1.ObjectReference) string {
+	if ref.Kind == "" || ref.Name == "" {
+		return ""
+	}
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	template, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+	if err != nil {
+		return ""
+	}
+	for _, path := range templateInstanceTypePaths {
+		if instanceType, _, _ := unstructured.NestedString(template.Object, path...); instanceType != "" {
+			return instanceType
+		}
+	}
+	return ""
+}
+
+// checkScaledClusterSize checks the size of a cluster with one of its node
+// pools scaled to the given replicas.
+func (s *EnhancedClusterService) checkScaledClusterSize(ctx context.Context, clusterName, kind, nodePoolName string, replicas int32) error {
+	limits := s.instanceTypeLimits
+	if limits.MaxVCPUs == 0 && limits.MaxMemoryMiB == 0 {
+		return nil
+	}
+
+	cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	groups, err := s.clusterNodeGroups(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	for i := range groups {
+		if groups[i].name == kind+"/"+nodePoolName {
+			groups[i].replicas = replicas
+		}
+	}
+	return s.checkClusterSize(ctx, clusterProvider(cluster), clusterRegion(cluster), groups)
+}
+
+// checkNodePoolClusterSize checks the size of a cluster with a node pool
+// added to it.
+func (s *EnhancedClusterService) checkNodePoolClusterSize(ctx context.Context, cluster *clusterv1.Cluster, nodePool nodeGroup) error {
+	limits := s.instanceTypeLimits
+	if limits.MaxVCPUs == 0 && limits.MaxMemoryMiB == 0 {
+		return nil
+	}
+
+	groups, err := s.clusterNodeGroups(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return s.checkClusterSize(ctx, clusterProvider(cluster), clusterRegion(cluster), append(groups, nodePool))
+}
+
+// nodePoolInstanceType returns the instance type a new node pool of a cluster
+// uses: its instanceType override, or else the worker instance type of the
+// cluster's topology.
+func nodePoolInstanceType(cluster *clusterv1.Cluster, overrides map[string]interface{}) string {
+	if instanceType, ok := overrides[instanceTypeVariable].(string); ok && instanceType != "" {
+		return instanceType
+	}
+	for _, name := range []string{workerInstanceTypeVariable, instanceTypeVariable} {
+		for _, variable := range cluster.Spec.Topology.Variables {
+			if variable.Name != name {
+				continue
+			}
+			var instanceType string
+			if err := json.Unmarshal(variable.Value.Raw, &instanceType); err == nil && instanceType != "" {
+				return instanceType
+			}
+		}
+	}
+	return ""
+}
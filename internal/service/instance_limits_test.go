@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider/aws"
+)
+
+type fakeInstanceTypeSource struct {
+	sizes map[string]*capiprovider.InstanceSize
+}
+
+func (f *fakeInstanceTypeSource) DescribeInstanceType(ctx context.Context, region, instanceType string) (*capiprovider.InstanceSize, error) {
+	if size, ok := f.sizes[instanceType]; ok {
+		return size, nil
+	}
+	return nil, errors.New(errors.CodeNotFound, "instance type "+instanceType+" not found")
+}
+
+// setupInstanceLimitsService creates a test service enforcing limits, whose
+// AWS provider knows the sizes of m5.large and m5.xlarge instances.
+func setupInstanceLimitsService(t *testing.T, limits InstanceTypeLimits, objects ...client.Object) (*EnhancedClusterService, client.Client) {
+	t.Helper()
+
+	svc, fakeClient := setupEnhancedTestService(t, objects...)
+	prov, ok := svc.providerManager.GetProvider("aws")
+	require.True(t, ok)
+	prov.(*aws.AWSProvider).SetInstanceTypeSource(&fakeInstanceTypeSource{sizes: map[string]*capiprovider.InstanceSize{
+		"m5.large":  {VCPUs: 2, MemoryMiB: 8192},
+		"m5.xlarge": {VCPUs: 4, MemoryMiB: 16384},
+	}})
+	svc.SetInstanceTypeLimits(limits)
+	return svc, fakeClient
+}
+
+func TestEnhancedClusterService_CheckClusterSize(t *testing.T) {
+	ctx := context.Background()
+	clusterClass := createTestClusterClassWithVariables("aws-standard")
+
+	tests := []struct {
+		name        string
+		limits      InstanceTypeLimits
+		variables   map[string]interface{}
+		expectCode  errors.ErrorCode
+		expectError string
+	}{
+		{
+			name:      "within limits",
+			limits:    InstanceTypeLimits{MaxVCPUs: 10, MaxMemoryMiB: 40 * 1024},
+			variables: map[string]interface{}{"workerInstanceType": "m5.xlarge", "nodeCount": float64(2)},
+		},
+		{
+			name:        "too many vCPUs",
+			limits:      InstanceTypeLimits{MaxVCPUs: 10},
+			variables:   map[string]interface{}{"workerInstanceType": "m5.xlarge", "nodeCount": float64(3)},
+			expectCode:  errors.CodeForbidden,
+			expectError: "cluster would have 14 vCPUs, over the limit of 10 per cluster: 1 x m5.large (control plane), 3 x m5.xlarge (workers)",
+		},
+		{
+			name:        "too much memory",
+			limits:      InstanceTypeLimits{MaxMemoryMiB: 32 * 1024},
+			variables:   map[string]interface{}{"instanceType": "m5.large", "controlPlaneInstanceType": "m5.xlarge", "nodeCount": float64(3)},
+			expectCode:  errors.CodeForbidden,
+			expectError: "cluster would have 40 GiB of memory, over the limit of 32 GiB per cluster",
+		},
+		{
+			name:        "unknown instance type",
+			limits:      InstanceTypeLimits{MaxVCPUs: 10},
+			variables:   map[string]interface{}{"workerInstanceType": "x9.huge", "nodeCount": float64(1)},
+			expectCode:  errors.CodeUnavailable,
+			expectError: "failed to look up instance type x9.huge",
+		},
+		{
+			name:      "no limits",
+			variables: map[string]interface{}{"workerInstanceType": "x9.huge", "nodeCount": float64(100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupInstanceLimitsService(t, tt.limits)
+
+			err := svc.checkClusterSize(ctx, "aws", "eu-west-1", newClusterNodeGroups(clusterClass, tt.variables))
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectCode, errors.GetErrorCode(err))
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEnhancedClusterService_InstanceTypeLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create_cluster rejects denied instance types", func(t *testing.T) {
+		svc, fakeClient := setupInstanceLimitsService(t, InstanceTypeLimits{
+			Policy: validation.InstanceTypePolicy{Allowed: []string{"m5.*"}},
+		}, createTestClusterClassWithVariables("aws-standard"))
+
+		_, err := svc.CreateCluster(ctx, api.CreateClusterInput{
+			ClusterName:       "new-cluster",
+			TemplateName:      "aws-standard",
+			KubernetesVersion: "v1.31.0",
+			Variables: map[string]interface{}{
+				"region": "eu-west-1", "osFamily": "ubuntu", "workerInstanceType": "p4d.24xlarge",
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "p4d.24xlarge")
+
+		var clusters clusterv1.ClusterList
+		require.NoError(t, fakeClient.List(ctx, &clusters))
+		assert.Empty(t, clusters.Items)
+	})
+
+	t.Run("create_cluster rejects oversized clusters", func(t *testing.T) {
+		svc, _ := setupInstanceLimitsService(t, InstanceTypeLimits{MaxVCPUs: 8}, createTestClusterClassWithVariables("aws-standard"))
+
+		_, err := svc.CreateCluster(ctx, api.CreateClusterInput{
+			ClusterName:       "new-cluster",
+			TemplateName:      "aws-standard",
+			KubernetesVersion: "v1.31.0",
+			Variables: map[string]interface{}{
+				"region": "eu-west-1", "osFamily": "ubuntu", "workerInstanceType": "m5.xlarge", "nodeCount": float64(2),
+			},
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "cluster would have 10 vCPUs")
+	})
+
+	newScalableCluster := func() []client.Object {
+		template := &unstructured.Unstructured{}
+		template.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+		template.SetKind("AWSMachineTemplate")
+		template.SetName("workers")
+		template.SetNamespace(testNamespace)
+		template.Object["spec"] = map[string]interface{}{
+			"template": map[string]interface{}{"spec": map[string]interface{}{"instanceType": "m5.xlarge"}},
+		}
+
+		md := createTestMachineDeployment("md-0", testNamespace, "test-cluster", 2)
+		md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+			Kind:       "AWSMachineTemplate",
+			Name:       "workers",
+		}
+		return []client.Object{createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned), md, template}
+	}
+
+	t.Run("scale_cluster rejects scaling past the limit", func(t *testing.T) {
+		svc, _ := setupInstanceLimitsService(t, InstanceTypeLimits{MaxVCPUs: 16}, newScalableCluster()...)
+
+		output, err := svc.ScaleCluster(ctx, api.ScaleClusterInput{ClusterName: "test-cluster", NodePoolName: "md-0", Replicas: 4})
+		require.NoError(t, err)
+		assert.Equal(t, 4, output.NewReplicas)
+
+		_, err = svc.ScaleCluster(ctx, api.ScaleClusterInput{ClusterName: "test-cluster", NodePoolName: "md-0", Replicas: 5})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "cluster would have 20 vCPUs, over the limit of 16 per cluster: 5 x m5.xlarge (MachineDeployment/md-0)")
+	})
+
+	t.Run("scale_cluster allows scaling down", func(t *testing.T) {
+		svc, _ := setupInstanceLimitsService(t, InstanceTypeLimits{MaxVCPUs: 4}, newScalableCluster()...)
+
+		_, err := svc.ScaleCluster(ctx, api.ScaleClusterInput{ClusterName: "test-cluster", NodePoolName: "md-0", Replicas: 1})
+		assert.NoError(t, err)
+	})
+
+	t.Run("create_node_pool counts the new pool", func(t *testing.T) {
+		objects := newScalableCluster()
+		objects = append(objects, createTestClusterClass("aws-cluster-class"))
+		svc, _ := setupInstanceLimitsService(t, InstanceTypeLimits{MaxVCPUs: 12}, objects...)
+
+		_, err := svc.CreateNodePool(ctx, api.CreateNodePoolInput{
+			ClusterName: "test-cluster", NodePoolName: "extra", Replicas: 3, InstanceType: "m5.large",
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "cluster would have 14 vCPUs")
+	})
+}
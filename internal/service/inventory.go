@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+// hoursPerMonth is the average number of hours in a month, as used by cloud
+// pricing calculators.
+const hoursPerMonth = 730
+
+// InventoryOptions configures fleet inventory reports.
+type InventoryOptions struct {
+	// HourlyPrices maps instance types to their hourly price.
+	HourlyPrices map[string]float64
+	// OwnerLabels are the cluster labels reported as its owners.
+	OwnerLabels []string
+}
+
+// SetInventoryOptions configures the cost estimates and owner labels of
+// inventory reports.
+func (s *EnhancedClusterService) SetInventoryOptions(options InventoryOptions) {
+	s.inventory = options
+}
+
+// ExportInventory reports the clusters in the caller's namespace with their
+// size, age, estimated cost and owners, as JSON or CSV, for compliance and
+// chargeback reporting.
+func (s *EnhancedClusterService) ExportInventory(ctx context.Context, input api.ExportInventoryInput) (*api.ExportInventoryOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ExportInventory")
+	logger.Info("Exporting cluster inventory", "format", input.Format)
+
+	format := input.Format
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		err := errors.New(errors.CodeInvalidInput, fmt.Sprintf("format must be json or csv, got '%s'", input.Format)).
+			WithDetails("field", "format")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	exportCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	clusters, err := s.kubeClient.ListClusters(exportCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list clusters")
+		if apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err) {
+			return nil, errors.Wrap(err, errors.CodeUnauthorized, "unauthorized to list clusters")
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
+	}
+
+	now := time.Now()
+	entries := make([]api.InventoryEntry, 0, len(clusters.Items))
+	unpriced := 0
+	var warnings []string
+	for i := range clusters.Items {
+		entry, entryWarnings := s.inventoryEntry(exportCtx, &clusters.Items[i], now)
+		if entry.HourlyCost == nil {
+			unpriced++
+		}
+		for _, warning := range entryWarnings {
+			warnings = append(warnings, fmt.Sprintf("cluster '%s' in namespace '%s': %s", entry.Name, entry.Namespace, warning))
+		}
+		entries = append(entries, entry)
+	}
+
+	output := &api.ExportInventoryOutput{
+		Format:      format,
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Message:     fmt.Sprintf("Exported %d clusters", len(entries)),
+		Warnings:    warnings,
+	}
+	if unpriced > 0 {
+		output.Message += fmt.Sprintf("; %d have no cost estimate, as the prices of their instance types are not configured", unpriced)
+	}
+	if format == "csv" {
+		output.CSV, err = s.inventoryCSV(entries)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInternal, "failed to write CSV report")
+		}
+	} else {
+		output.Clusters = entries
+	}
+
+	logger.Info("Exported cluster inventory", "clusters", len(entries), "unpriced", unpriced)
+	return output, nil
+}
+
+// inventoryEntry builds the inventory entry of a cluster. Failing to count
+// its nodes or estimate its cost leaves them out and is reported in the
+// returned warnings.
+func (s *EnhancedClusterService) inventoryEntry(ctx context.Context, cluster *clusterv1.Cluster, now time.Time) (api.InventoryEntry, []string) {
+	summary := s.summarizeCluster(ctx, cluster, now)
+	warnings := summary.Warnings
+	entry := api.InventoryEntry{
+		Name:              summary.Name,
+		Namespace:         summary.Namespace,
+		Provider:          summary.Provider,
+		Region:            summary.Region,
+		KubernetesVersion: summary.KubernetesVersion,
+		Status:            summary.Status,
+		NodeCount:         summary.NodeCount,
+		ReadyNodeCount:    summary.ReadyNodeCount,
+		CreatedAt:         summary.CreatedAt,
+	}
+	if !cluster.CreationTimestamp.IsZero() {
+		entry.AgeDays = int(now.Sub(cluster.CreationTimestamp.Time).Hours() / 24)
+	}
+
+	for _, label := range s.inventory.OwnerLabels {
+		if value, ok := cluster.Labels[label]; ok {
+			if entry.Owners == nil {
+				entry.Owners = make(map[string]string)
+			}
+			entry.Owners[label] = value
+		}
+	}
+
+	hourly, ok, err := s.estimateHourlyCost(ctx, cluster)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("cost estimate unavailable: %v", err))
+	}
+	if ok {
+		monthly := math.Round(hourly*hoursPerMonth*100) / 100
+		hourly = math.Round(hourly*10000) / 10000
+		entry.HourlyCost = &hourly
+		entry.MonthlyCost = &monthly
+	}
+	return entry, warnings
+}
+
+// estimateHourlyCost adds up the hourly prices of the nodes of a cluster. It
+// reports false if any node's instance type is unknown or has no price, and
+// an error if the nodes could not be listed.
+func (s *EnhancedClusterService) estimateHourlyCost(ctx context.Context, cluster *clusterv1.Cluster) (float64, bool, error) {
+	if len(s.inventory.HourlyPrices) == 0 {
+		return 0, false, nil
+	}
+
+	groups, err := s.clusterNodeGroups(kube.ContextWithNamespace(ctx, cluster.Namespace), cluster)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to get node groups for cost estimate",
+			logging.FieldClusterName, cluster.Name,
+		)
+		return 0, false, err
+	}
+
+	var hourly float64
+	for _, group := range groups {
+		if group.replicas == 0 {
+			continue
+		}
+		price, ok := s.inventory.HourlyPrices[group.instanceType]
+		if !ok {
+			return 0, false, nil
+		}
+		hourly += float64(group.replicas) * price
+	}
+	return hourly, true, nil
+}
+
+// inventoryCSV renders inventory entries as CSV, with a column per owner
+// label.
+func (s *EnhancedClusterService) inventoryCSV(entries []api.InventoryEntry) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{
+		"name", "namespace", "provider", "region", "kubernetes_version", "status",
+		"node_count", "ready_node_count", "created_at", "age_days", "hourly_cost", "monthly_cost",
+	}
+	header = append(header, s.inventory.OwnerLabels...)
+	if err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	formatCost := func(cost *float64) string {
+		if cost == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*cost, 'f', -1, 64)
+	}
+	for _, entry := range entries {
+		record := []string{
+			entry.Name, entry.Namespace, entry.Provider, entry.Region, entry.KubernetesVersion, string(entry.Status),
+			strconv.Itoa(entry.NodeCount), strconv.Itoa(entry.ReadyNodeCount), entry.CreatedAt,
+			strconv.Itoa(entry.AgeDays), formatCost(entry.HourlyCost), formatCost(entry.MonthlyCost),
+		}
+		for _, label := range s.inventory.OwnerLabels {
+			record = append(record, entry.Owners[label])
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_ExportInventory(t *testing.T) {
+	ctx := context.Background()
+
+	newCluster := func(name, instanceType string, labels map[string]string) []client.Object {
+		cluster := createTestCluster(name, testNamespace, clusterv1.ClusterPhaseProvisioned)
+		cluster.CreationTimestamp = metav1.NewTime(time.Now().Add(-10*24*time.Hour - time.Hour))
+		for key, value := range labels {
+			cluster.Labels[key] = value
+		}
+
+		template := &unstructured.Unstructured{}
+		template.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+		template.SetKind("AWSMachineTemplate")
+		template.SetName(name + "-workers")
+		template.SetNamespace(testNamespace)
+		template.Object["spec"] = map[string]interface{}{
+			"template": map[string]interface{}{"spec": map[string]interface{}{"instanceType": instanceType}},
+		}
+
+		md := createTestMachineDeployment(name+"-md-0", testNamespace, name, 2)
+		md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{
+			APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+			Kind:       "AWSMachineTemplate",
+			Name:       name + "-workers",
+		}
+		return []client.Object{cluster, md, template}
+	}
+
+	svc, _ := setupEnhancedTestService(t, append(
+		newCluster("priced", "m5.xlarge", map[string]string{"team": "payments", "owner": "alice"}),
+		newCluster("unpriced", "p4d.24xlarge", nil)...,
+	)...)
+	svc.SetInventoryOptions(InventoryOptions{
+		HourlyPrices: map[string]float64{"m5.large": 0.096, "m5.xlarge": 0.192},
+		OwnerLabels:  []string{"owner", "team"},
+	})
+
+	t.Run("json", func(t *testing.T) {
+		output, err := svc.ExportInventory(ctx, api.ExportInventoryInput{})
+		require.NoError(t, err)
+		assert.Equal(t, "json", output.Format)
+		assert.Empty(t, output.CSV)
+		require.Len(t, output.Clusters, 2)
+		assert.Contains(t, output.Message, "1 have no cost estimate")
+
+		priced := output.Clusters[0]
+		assert.Equal(t, "priced", priced.Name)
+		assert.Equal(t, "aws", priced.Provider)
+		assert.Equal(t, "v1.31.0", priced.KubernetesVersion)
+		assert.Equal(t, 2, priced.NodeCount)
+		assert.Equal(t, 10, priced.AgeDays)
+		require.NotNil(t, priced.HourlyCost)
+		assert.Equal(t, 0.384, *priced.HourlyCost)
+		assert.Equal(t, 280.32, *priced.MonthlyCost)
+		assert.Equal(t, map[string]string{"owner": "alice", "team": "payments"}, priced.Owners)
+
+		unpriced := output.Clusters[1]
+		assert.Nil(t, unpriced.HourlyCost)
+		assert.Nil(t, unpriced.Owners)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		output, err := svc.ExportInventory(ctx, api.ExportInventoryInput{Format: "csv"})
+		require.NoError(t, err)
+		assert.Empty(t, output.Clusters)
+
+		lines := strings.Split(strings.TrimSpace(output.CSV), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "name,namespace,provider,region,kubernetes_version,status,node_count,ready_node_count,"+
+			"created_at,age_days,hourly_cost,monthly_cost,owner,team", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "priced,"+testNamespace+",aws,"), lines[1])
+		assert.True(t, strings.HasSuffix(lines[1], ",10,0.384,280.32,alice,payments"), lines[1])
+		assert.True(t, strings.HasSuffix(lines[2], ",10,,,,"), lines[2])
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := svc.ExportInventory(ctx, api.ExportInventoryInput{Format: "xlsx"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+}
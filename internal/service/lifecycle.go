@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// LifecycleMetrics records how long clusters take to provision and delete.
+type LifecycleMetrics interface {
+	ObserveClusterProvisioningDuration(provider, template, outcome string, duration time.Duration)
+	ObserveClusterDeletionDuration(provider, template string, duration time.Duration)
+}
+
+const (
+	// ProvisioningOutcomeProvisioned labels clusters that reached the Provisioned phase.
+	ProvisioningOutcomeProvisioned = "provisioned"
+
+	// ProvisioningOutcomeFailed labels clusters that reached the Failed phase.
+	ProvisioningOutcomeFailed = "failed"
+
+	// defaultLifecycleTimeout bounds how long a cluster's provisioning or
+	// deletion is tracked after the tool call that started it.
+	defaultLifecycleTimeout = 30 * time.Minute
+)
+
+// SetLifecycleMetrics configures where cluster provisioning and deletion
+// durations are recorded. Clusters that take longer than timeout are not
+// recorded; a non-positive timeout uses the default.
+func (s *EnhancedClusterService) SetLifecycleMetrics(metrics LifecycleMetrics, timeout time.Duration) {
+	s.lifecycleMetrics = metrics
+	s.lifecycleTimeout = defaultLifecycleTimeout
+	if timeout > 0 {
+		s.lifecycleTimeout = timeout
+	}
+}
+
+// trackProvisioning waits in the background for a cluster created at
+// startedAt to become Provisioned or Failed and records how long it took.
+func (s *EnhancedClusterService) trackProvisioning(ctx context.Context, clusterName, provider, template string, startedAt time.Time) {
+	if s.lifecycleMetrics == nil {
+		return
+	}
+
+	s.goBackground(ctx, func(ctx context.Context) {
+		trackCtx, cancel := context.WithDeadline(ctx, startedAt.Add(s.lifecycleTimeout))
+		defer cancel()
+
+		logger := s.logger.WithContext(trackCtx).WithOperation("TrackProvisioning").WithCluster(clusterName, "")
+		cluster, err := s.waitForCluster(trackCtx, clusterName, func(cluster *clusterv1.Cluster) bool {
+			if cluster == nil {
+				return true
+			}
+			phase := clusterv1.ClusterPhase(cluster.Status.Phase)
+			return phase == clusterv1.ClusterPhaseProvisioned || phase == clusterv1.ClusterPhaseFailed
+		})
+		if err != nil {
+			logger.WithError(err).Warn("Stopped tracking cluster provisioning")
+			return
+		}
+		if cluster == nil {
+			logger.Debug("Cluster deleted before provisioning completed")
+			return
+		}
+
+		outcome := ProvisioningOutcomeProvisioned
+		if clusterv1.ClusterPhase(cluster.Status.Phase) == clusterv1.ClusterPhaseFailed {
+			outcome = ProvisioningOutcomeFailed
+		}
+		duration := time.Since(startedAt)
+		s.lifecycleMetrics.ObserveClusterProvisioningDuration(provider, template, outcome, duration)
+		logger.Info("Cluster provisioning completed", "outcome", outcome, "duration", duration)
+	})
+}
+
+// trackDeletion waits in the background for a cluster whose deletion started
+// at startedAt to be removed and records how long it took.
+func (s *EnhancedClusterService) trackDeletion(ctx context.Context, cluster *clusterv1.Cluster, startedAt time.Time) {
+	if s.lifecycleMetrics == nil {
+		return
+	}
+
+	s.goBackground(ctx, func(ctx context.Context) {
+		trackCtx, cancel := context.WithDeadline(ctx, startedAt.Add(s.lifecycleTimeout))
+		defer cancel()
+
+		if err := s.waitForClusterDeleted(trackCtx, cluster.Name, cluster.Namespace); err != nil {
+			s.logger.WithContext(trackCtx).WithOperation("TrackDeletion").WithCluster(cluster.Name, "").
+				WithError(err).Warn("Stopped tracking cluster deletion")
+			return
+		}
+		s.observeDeletion(cluster, startedAt)
+	})
+}
+
+// observeDeletion records the deletion duration of a cluster.
+func (s *EnhancedClusterService) observeDeletion(cluster *clusterv1.Cluster, startedAt time.Time) {
+	if s.lifecycleMetrics == nil {
+		return
+	}
+	template := s.getClusterClass(cluster)
+	if template == "" {
+		template = "unknown"
+	}
+	s.lifecycleMetrics.ObserveClusterDeletionDuration(clusterProvider(cluster), template, time.Since(startedAt))
+}
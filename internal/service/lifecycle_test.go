@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// lifecycleObservation is a duration recorded by fakeLifecycleMetrics.
+type lifecycleObservation struct {
+	kind, provider, template, outcome string
+}
+
+type fakeLifecycleMetrics struct {
+	mu           sync.Mutex
+	observations []lifecycleObservation
+}
+
+func (f *fakeLifecycleMetrics) ObserveClusterProvisioningDuration(provider, template, outcome string, duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.observations = append(f.observations, lifecycleObservation{"provisioning", provider, template, outcome})
+}
+
+func (f *fakeLifecycleMetrics) ObserveClusterDeletionDuration(provider, template string, duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.observations = append(f.observations, lifecycleObservation{"deletion", provider, template, ""})
+}
+
+func (f *fakeLifecycleMetrics) recorded() []lifecycleObservation {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]lifecycleObservation(nil), f.observations...)
+}
+
+func TestEnhancedClusterService_LifecycleMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*EnhancedClusterService, *fakeLifecycleMetrics, func(phase clusterv1.ClusterPhase)) {
+		svc, fakeClient := setupEnhancedTestService(t,
+			createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioning),
+		)
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+		recorder := &fakeLifecycleMetrics{}
+		svc.SetLifecycleMetrics(recorder, time.Minute)
+
+		setPhase := func(phase clusterv1.ClusterPhase) {
+			cluster := &clusterv1.Cluster{}
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "test-cluster"}, cluster))
+			cluster.Status.Phase = string(phase)
+			require.NoError(t, fakeClient.Update(ctx, cluster))
+		}
+		return svc, recorder, setPhase
+	}
+
+	t.Run("records provisioning once the cluster is provisioned", func(t *testing.T) {
+		svc, recorder, setPhase := setup(t)
+
+		svc.trackProvisioning(ctx, "test-cluster", "aws", "aws-cluster-class", time.Now())
+		time.Sleep(30 * time.Millisecond)
+		assert.Empty(t, recorder.recorded())
+
+		setPhase(clusterv1.ClusterPhaseProvisioned)
+		require.Eventually(t, func() bool { return len(recorder.recorded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, lifecycleObservation{"provisioning", "aws", "aws-cluster-class", ProvisioningOutcomeProvisioned}, recorder.recorded()[0])
+	})
+
+	t.Run("records failed provisioning", func(t *testing.T) {
+		svc, recorder, setPhase := setup(t)
+
+		svc.trackProvisioning(ctx, "test-cluster", "aws", "aws-cluster-class", time.Now())
+		setPhase(clusterv1.ClusterPhaseFailed)
+		require.Eventually(t, func() bool { return len(recorder.recorded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, ProvisioningOutcomeFailed, recorder.recorded()[0].outcome)
+	})
+
+	t.Run("records nothing past the timeout", func(t *testing.T) {
+		svc, recorder, _ := setup(t)
+
+		svc.trackProvisioning(ctx, "test-cluster", "aws", "aws-cluster-class", time.Now().Add(-2*time.Minute))
+		time.Sleep(50 * time.Millisecond)
+		assert.Empty(t, recorder.recorded())
+	})
+
+	t.Run("records deletion once the cluster is removed", func(t *testing.T) {
+		svc, recorder, _ := setup(t)
+
+		cluster, err := svc.kubeClient.GetClusterByName(ctx, "test-cluster")
+		require.NoError(t, err)
+		svc.trackDeletion(ctx, cluster, time.Now())
+
+		require.NoError(t, svc.kubeClient.DeleteCluster(ctx, "test-cluster"))
+		require.Eventually(t, func() bool { return len(recorder.recorded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, lifecycleObservation{"deletion", "aws", "aws-cluster-class", ""}, recorder.recorded()[0])
+	})
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// coreProviderLabel is the provider label value of the CAPI core controller.
+	coreProviderLabel = "cluster-api"
+
+	// coreProviderType is the clusterctl inventory type of the CAPI core provider.
+	coreProviderType = "CoreProvider"
+
+	// clusterCRDName is the CRD whose labels declare the supported CAPI contracts.
+	clusterCRDName = "clusters.cluster.x-k8s.io"
+
+	// contractLabelPrefix prefixes the contract labels on CAPI CRDs (e.g. cluster.x-k8s.io/v1beta1).
+	contractLabelPrefix = "cluster.x-k8s.io/v1"
+
+	// certManagerNamespace is the namespace clusterctl installs cert-manager into.
+	certManagerNamespace = "cert-manager"
+)
+
+// providerTypePrefixes maps clusterctl provider types to the prefix used in the
+// provider label of their components (e.g. infrastructure-aws).
+var providerTypePrefixes = map[string]string{
+	"BootstrapProvider":        "bootstrap-",
+	"ControlPlaneProvider":     "control-plane-",
+	"InfrastructureProvider":   "infrastructure-",
+	"IPAMProvider":             "ipam-",
+	"RuntimeExtensionProvider": "runtime-extension-",
+	"AddonProvider":            "addon-",
+}
+
+// GetManagementClusterInfo reports the CAPI core version, installed providers,
+// supported contract versions and cert-manager status of the management cluster,
+// similar to `clusterctl version` and `clusterctl upgrade plan`.
+func (s *EnhancedClusterService) GetManagementClusterInfo(ctx context.Context, input api.GetManagementClusterInfoInput) (*api.GetManagementClusterInfoOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetManagementClusterInfo")
+	logger.Debug("Getting management cluster info")
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	infoCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	deployments, err := s.kubeClient.ListProviderDeployments(infoCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list provider deployments")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list provider deployments")
+	}
+
+	inventory, err := s.kubeClient.ListClusterctlProviders(infoCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list clusterctl providers")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusterctl providers")
+	}
+
+	output := &api.GetManagementClusterInfoOutput{
+		Providers:        buildManagementProviders(inventory.Items, deployments.Items),
+		ContractVersions: []string{},
+	}
+
+	for _, prov := range output.Providers {
+		if prov.Type == coreProviderType {
+			output.CoreVersion = prov.Version
+		}
+	}
+
+	crd, err := s.kubeClient.GetCustomResourceDefinition(infoCtx, clusterCRDName)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get Cluster CRD, contract versions unavailable")
+	} else {
+		output.ContractVersions = contractVersions(crd.Labels)
+	}
+
+	output.CertManager = s.getCertManagerStatus(infoCtx)
+
+	logger.Info("Retrieved management cluster info",
+		"core_version", output.CoreVersion,
+		"providers", len(output.Providers),
+	)
+	return output, nil
+}
+
+// getCertManagerStatus reports on the cert-manager Deployments in the management cluster.
+func (s *EnhancedClusterService) getCertManagerStatus(ctx context.Context) api.CertManagerStatus {
+	deployments, err := s.kubeClient.ListDeployments(ctx, certManagerNamespace)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to list cert-manager deployments")
+		return api.CertManagerStatus{Message: "cert-manager status unavailable"}
+	}
+	if len(deployments.Items) == 0 {
+		return api.CertManagerStatus{Message: "cert-manager is not installed in the cert-manager namespace"}
+	}
+
+	status := api.CertManagerStatus{Installed: true, Ready: true}
+	var notReady []string
+	for _, deployment := range deployments.Items {
+		if deployment.Name == "cert-manager" {
+			status.Version = imageTag(deployment.Spec.Template.Spec.Containers)
+		}
+		if !isDeploymentAvailable(&deployment) {
+			status.Ready = false
+			notReady = append(notReady, deployment.Name)
+		}
+	}
+	if len(notReady) > 0 {
+		status.Message = "deployments not available: " + strings.Join(notReady, ", ")
+	}
+	return status
+}
+
+// buildManagementProviders merges the clusterctl inventory with the provider
+// Deployments. Providers installed without clusterctl are derived from the
+// Deployments alone, using the image tag as the version.
+func buildManagementProviders(inventory []unstructured.Unstructured, deployments []appsv1.Deployment) []api.ManagementProvider {
+	deploymentsByLabel := make(map[string][]appsv1.Deployment)
+	for _, deployment := range deployments {
+		label := deployment.Labels[clusterv1.ProviderNameLabel]
+		deploymentsByLabel[label] = append(deploymentsByLabel[label], deployment)
+	}
+
+	providers := make([]api.ManagementProvider, 0, len(inventory))
+	seen := make(map[string]bool)
+	for _, item := range inventory {
+		name, _, _ := unstructured.NestedString(item.Object, "providerName")
+		providerType, _, _ := unstructured.NestedString(item.Object, "type")
+		version, _, _ := unstructured.NestedString(item.Object, "version")
+
+		label := providerLabelValue(name, providerType)
+		seen[label] = true
+		providers = append(providers, api.ManagementProvider{
+			Name:      name,
+			Type:      providerType,
+			Version:   version,
+			Namespace: item.GetNamespace(),
+			Ready:     deploymentsAvailable(deploymentsByLabel[label]),
+		})
+	}
+
+	for label, group := range deploymentsByLabel {
+		if seen[label] {
+			continue
+		}
+		name, providerType := parseProviderLabel(label)
+		providers = append(providers, api.ManagementProvider{
+			Name:      name,
+			Type:      providerType,
+			Version:   imageTag(group[0].Spec.Template.Spec.Containers),
+			Namespace: group[0].Namespace,
+			Ready:     deploymentsAvailable(group),
+		})
+	}
+
+	sort.Slice(providers, func(i, j int) bool {
+		if providers[i].Type != providers[j].Type {
+			return providers[i].Type < providers[j].Type
+		}
+		return providers[i].Name < providers[j].Name
+	})
+	return providers
+}
+
+// providerLabelValue returns the provider label value clusterctl sets on a provider's components.
+func providerLabelValue(name, providerType string) string {
+	if providerType == coreProviderType {
+		return coreProviderLabel
+	}
+	return providerTypePrefixes[providerType] + name
+}
+
+// parseProviderLabel splits a provider label value into provider name and type.
+func parseProviderLabel(label string) (string, string) {
+	if label == coreProviderLabel {
+		return coreProviderLabel, coreProviderType
+	}
+	for providerType, prefix := range providerTypePrefixes {
+		if strings.HasPrefix(label, prefix) {
+			return strings.TrimPrefix(label, prefix), providerType
+		}
+	}
+	return label, "Unknown"
+}
+
+// contractVersions extracts the supported CAPI contract versions from CRD labels.
+func contractVersions(labels map[string]string) []string {
+	contracts := []string{}
+	for key := range labels {
+		if strings.HasPrefix(key, contractLabelPrefix) {
+			contracts = append(contracts, strings.TrimPrefix(key, "cluster.x-k8s.io/"))
+		}
+	}
+	sort.Strings(contracts)
+	return contracts
+}
+
+// imageTag returns the tag of the first container image, which for provider
+// controllers is the provider version.
+func imageTag(containers []corev1.Container) string {
+	if len(containers) == 0 {
+		return ""
+	}
+	return containerImageTag(containers[0].Image)
+}
+
+// containerImageTag returns the tag of a container image reference, or "" if
+// it has none, e.g. "v1.31.0" for "registry.k8s.io/kube-proxy:v1.31.0@sha256:...".
+func containerImageTag(image string) string {
+	if at := strings.Index(image, "@"); at >= 0 {
+		image = image[:at]
+	}
+	colon := strings.LastIndex(image, ":")
+	if colon < 0 || colon < strings.LastIndex(image, "/") {
+		return ""
+	}
+	return image[colon+1:]
+}
+
+// deploymentsAvailable reports whether all Deployments are available.
+func deploymentsAvailable(deployments []appsv1.Deployment) bool {
+	if len(deployments) == 0 {
+		return false
+	}
+	for _, deployment := range deployments {
+		if !isDeploymentAvailable(&deployment) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDeploymentAvailable reports whether a Deployment has all desired replicas available.
+func isDeploymentAvailable(deployment *appsv1.Deployment) bool {
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	return deployment.Status.AvailableReplicas >= desired
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func createTestDeployment(name, namespace, providerLabel, image string, available bool) *appsv1.Deployment {
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Image: image}},
+				},
+			},
+		},
+	}
+	if providerLabel != "" {
+		deployment.Labels[clusterv1.ProviderNameLabel] = providerLabel
+	}
+	if available {
+		deployment.Status.AvailableReplicas = 1
+	}
+	return deployment
+}
+
+func createTestInventoryProvider(name, namespace, providerType, version string) *unstructured.Unstructured {
+	provider := &unstructured.Unstructured{Object: map[string]interface{}{
+		"providerName": name,
+		"type":         providerType,
+		"version":      version,
+	}}
+	provider.SetAPIVersion("clusterctl.cluster.x-k8s.io/v1alpha3")
+	provider.SetKind("Provider")
+	provider.SetName(name)
+	provider.SetNamespace(namespace)
+	return provider
+}
+
+func TestEnhancedClusterService_GetManagementClusterInfo(t *testing.T) {
+	clusterCRD := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterCRDName,
+			Labels: map[string]string{
+				"cluster.x-k8s.io/v1beta1":    "v1beta1",
+				"cluster.x-k8s.io/v1alpha4":   "v1alpha4",
+				"clusterctl.cluster.x-k8s.io": "",
+			},
+		},
+	}
+
+	svc, _ := setupEnhancedTestService(t,
+		clusterCRD,
+		createTestInventoryProvider("cluster-api", "capi-system", "CoreProvider", "v1.6.8"),
+		createTestInventoryProvider("aws", "capa-system", "InfrastructureProvider", "v2.5.0"),
+		createTestDeployment("capi-controller-manager", "capi-system", "cluster-api", "registry.k8s.io/cluster-api/cluster-api-controller:v1.6.8", true),
+		createTestDeployment("capa-controller-manager", "capa-system", "infrastructure-aws", "registry.k8s.io/cluster-api-aws/cluster-api-aws-controller:v2.5.0", false),
+		createTestDeployment("capi-kubeadm-bootstrap-controller-manager", "capi-kubeadm-bootstrap-system", "bootstrap-kubeadm", "registry.k8s.io/cluster-api/kubeadm-bootstrap-controller:v1.6.8", true),
+		createTestDeployment("cert-manager", certManagerNamespace, "", "quay.io/jetstack/cert-manager-controller:v1.14.4", true),
+		createTestDeployment("cert-manager-webhook", certManagerNamespace, "", "quay.io/jetstack/cert-manager-webhook:v1.14.4", true),
+	)
+
+	output, err := svc.GetManagementClusterInfo(context.Background(), api.GetManagementClusterInfoInput{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.6.8", output.CoreVersion)
+	assert.Equal(t, []string{"v1alpha4", "v1beta1"}, output.ContractVersions)
+
+	require.Len(t, output.Providers, 3)
+	assert.Equal(t, api.ManagementProvider{Name: "kubeadm", Type: "BootstrapProvider", Version: "v1.6.8", Namespace: "capi-kubeadm-bootstrap-system", Ready: true}, output.Providers[0])
+	assert.Equal(t, api.ManagementProvider{Name: "cluster-api", Type: "CoreProvider", Version: "v1.6.8", Namespace: "capi-system", Ready: true}, output.Providers[1])
+	assert.Equal(t, api.ManagementProvider{Name: "aws", Type: "InfrastructureProvider", Version: "v2.5.0", Namespace: "capa-system", Ready: false}, output.Providers[2])
+
+	assert.True(t, output.CertManager.Installed)
+	assert.True(t, output.CertManager.Ready)
+	assert.Equal(t, "v1.14.4", output.CertManager.Version)
+}
+
+func TestEnhancedClusterService_GetManagementClusterInfoWithoutCertManager(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+
+	output, err := svc.GetManagementClusterInfo(context.Background(), api.GetManagementClusterInfoInput{})
+	require.NoError(t, err)
+
+	assert.Empty(t, output.CoreVersion)
+	assert.Empty(t, output.Providers)
+	assert.False(t, output.CertManager.Installed)
+	assert.NotEmpty(t, output.CertManager.Message)
+}
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "registry.k8s.io/cluster-api/cluster-api-controller:v1.6.8", want: "v1.6.8"},
+		{image: "localhost:5000/capi/controller", want: ""},
+		{image: "localhost:5000/capi/controller:dev@sha256:abcdef", want: "dev"},
+		{image: "controller", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.want, imageTag([]corev1.Container{{Image: tt.image}}))
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// upgradePlanTimeout bounds `clusterctl upgrade plan`, which queries provider repositories.
+	upgradePlanTimeout = 2 * time.Minute
+
+	// upgradeApplyTimeout bounds `clusterctl upgrade apply`, which waits for providers to roll out.
+	upgradeApplyTimeout = 15 * time.Minute
+
+	// upToDate is the NEXT VERSION value clusterctl prints for providers without upgrades.
+	upToDate = "Already up to date"
+)
+
+var (
+	contractPattern       = regexp.MustCompile(`^v1(alpha|beta)?[0-9]+$`)
+	contractHeaderPattern = regexp.MustCompile(`for the (v1(?:alpha|beta)?[0-9]+) API Version`)
+	providerRefPattern    = regexp.MustCompile(`^([a-z0-9-]+/)?[a-z0-9-]+(:v[0-9][0-9A-Za-z.+-]*)?$`)
+)
+
+// ProviderUpgradeOptions configures management cluster provider upgrades.
+type ProviderUpgradeOptions struct {
+	// Enabled allows upgrades to be applied. Planning is always permitted.
+	Enabled bool
+
+	// ClusterctlPath is the clusterctl binary used to plan and apply upgrades.
+	ClusterctlPath string
+
+	// Kubeconfig is the management cluster kubeconfig; empty uses in-cluster config.
+	Kubeconfig string
+}
+
+// clusterctlRunner runs clusterctl with the given arguments and returns its combined output.
+type clusterctlRunner func(ctx context.Context, args ...string) ([]byte, error)
+
+// SetProviderUpgradeOptions configures how upgrade_management_providers runs clusterctl.
+func (s *EnhancedClusterService) SetProviderUpgradeOptions(opts ProviderUpgradeOptions) {
+	if opts.ClusterctlPath == "" {
+		opts.ClusterctlPath = "clusterctl"
+	}
+	s.upgradeOptions = opts
+	s.runClusterctl = func(ctx context.Context, args ...string) ([]byte, error) {
+		if opts.Kubeconfig != "" {
+			args = append(args, "--kubeconfig", opts.Kubeconfig)
+		}
+		// #nosec G204 -- binary path comes from server configuration and arguments are validated
+		cmd := exec.CommandContext(ctx, opts.ClusterctlPath, args...)
+		return cmd.CombinedOutput()
+	}
+}
+
+// UpgradeManagementProviders plans upgrades of the CAPI providers installed on the
+// management cluster and, when requested and enabled, applies them. This is the
+// equivalent of `clusterctl upgrade plan` followed by `clusterctl upgrade apply`.
+// Cluster templates the new provider versions are incompatible with are
+// reported as warnings.
+func (s *EnhancedClusterService) UpgradeManagementProviders(ctx context.Context, input api.UpgradeManagementProvidersInput) (*api.UpgradeManagementProvidersOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("UpgradeManagementProviders")
+	logger.Info("Planning management provider upgrades", "apply", input.Apply)
+
+	// Validate input
+	if err := validateUpgradeInput(input); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	if input.Apply && !s.upgradeOptions.Enabled {
+		err := errors.New(errors.CodeForbidden, "applying provider upgrades is disabled on this server").
+			WithDetails("hint", "set ENABLE_PROVIDER_UPGRADES=true to allow upgrade_management_providers to apply upgrades")
+		logger.WithError(err).Warn("Provider upgrade rejected")
+		return nil, err
+	}
+
+	if s.runClusterctl == nil {
+		err := errors.New(errors.CodeUnavailable, "clusterctl is not configured")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	planCtx, cancel := budget.Sub(ctx, upgradePlanTimeout)
+	defer cancel()
+
+	planOutput, err := s.runClusterctl(planCtx, "upgrade", "plan")
+	if err != nil {
+		logger.WithError(err).Error("clusterctl upgrade plan failed")
+		return nil, clusterctlError(planCtx, err, planOutput, "failed to plan provider upgrades")
+	}
+
+	contract, providers := parseUpgradePlan(string(planOutput))
+	output := &api.UpgradeManagementProvidersOutput{
+		Contract:  contract,
+		Providers: providers,
+	}
+
+	output.Warnings = s.providerUpgradeWarnings(planCtx, providers, input)
+
+	pending := 0
+	for _, prov := range providers {
+		if prov.NextVersion != "" && prov.NextVersion != upToDate {
+			pending++
+		}
+	}
+
+	if !input.Apply {
+		output.Message = fmt.Sprintf("%d provider(s) can be upgraded; call again with apply=true to apply the plan", pending)
+		logger.Info("Planned provider upgrades", "pending", pending)
+		return output, nil
+	}
+
+	explicit := input.CoreProvider != "" || len(input.InfrastructureProviders) > 0
+	if pending == 0 && !explicit {
+		output.Message = "All providers are already up to date"
+		return output, nil
+	}
+
+	args := []string{"upgrade", "apply"}
+	if explicit {
+		if input.CoreProvider != "" {
+			args = append(args, "--core", input.CoreProvider)
+		}
+		for _, infra := range input.InfrastructureProviders {
+			args = append(args, "--infrastructure", infra)
+		}
+	} else {
+		if input.Contract != "" {
+			contract = input.Contract
+		}
+		if contract == "" {
+			return nil, errors.New(errors.CodePreconditionFailed, "could not determine the contract to upgrade to")
+		}
+		args = append(args, "--contract", contract)
+		output.Contract = contract
+	}
+
+	logger.Warn("Applying management provider upgrades", "args", strings.Join(args, " "))
+
+	applyCtx, applyCancel := budget.Sub(ctx, upgradeApplyTimeout)
+	defer applyCancel()
+
+	applyOutput, err := s.runClusterctl(applyCtx, args...)
+	if err != nil {
+		logger.WithError(err).Error("clusterctl upgrade apply failed")
+		return nil, clusterctlError(applyCtx, err, applyOutput, "failed to apply provider upgrades")
+	}
+
+	output.Applied = true
+	output.Message = "Provider upgrades applied successfully"
+	logger.Info("Applied management provider upgrades")
+	return output, nil
+}
+
+// validateUpgradeInput rejects contracts and provider references that are not
+// in clusterctl's expected format, so arbitrary flags cannot be passed through.
+func validateUpgradeInput(input api.UpgradeManagementProvidersInput) error {
+	if input.Contract != "" && !contractPattern.MatchString(input.Contract) {
+		return errors.New(errors.CodeInvalidInput, fmt.Sprintf("invalid contract '%s'; expected a version such as v1beta1", input.Contract))
+	}
+
+	refs := append([]string{}, input.InfrastructureProviders...)
+	if input.CoreProvider != "" {
+		refs = append(refs, input.CoreProvider)
+	}
+	for _, ref := range refs {
+		if !providerRefPattern.MatchString(ref) {
+			return errors.New(errors.CodeInvalidInput, fmt.Sprintf("invalid provider reference '%s'; expected [namespace/]name[:version]", ref))
+		}
+	}
+
+	if input.Contract != "" && (input.CoreProvider != "" || len(input.InfrastructureProviders) > 0) {
+		return errors.New(errors.CodeInvalidInput, "contract cannot be combined with explicit provider versions")
+	}
+	return nil
+}
+
+// parseUpgradePlan extracts the latest contract and its provider upgrade table
+// from `clusterctl upgrade plan` output.
+func parseUpgradePlan(output string) (string, []api.ProviderUpgrade) {
+	var contract string
+	var providers []api.ProviderUpgrade
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := contractHeaderPattern.FindStringSubmatch(line); match != nil {
+			// clusterctl prints one table per contract; keep only the latest
+			contract = match[1]
+			providers = nil
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 5 || !strings.HasSuffix(fields[2], "Provider") {
+			continue
+		}
+		providers = append(providers, api.ProviderUpgrade{
+			Name:           fields[0],
+			Namespace:      fields[1],
+			Type:           fields[2],
+			CurrentVersion: fields[3],
+			NextVersion:    strings.Join(fields[4:], " "),
+		})
+	}
+
+	if providers == nil {
+		providers = []api.ProviderUpgrade{}
+	}
+	return contract, providers
+}
+
+// clusterctlError converts a clusterctl failure into a structured error.
+func clusterctlError(ctx context.Context, err error, output []byte, message string) error {
+	if stderrors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errors.Wrap(err, errors.CodeTimeout, message)
+	}
+	if stderrors.Is(err, exec.ErrNotFound) || stderrors.Is(err, fs.ErrNotExist) {
+		return errors.Wrap(err, errors.CodeUnavailable, "clusterctl binary not found").
+			WithDetails("hint", "install clusterctl or set CLUSTERCTL_PATH")
+	}
+	return errors.Wrap(err, errors.CodeDependencyFailure, message).
+		WithDetails("output", lastLines(output, 20))
+}
+
+// lastLines returns the final n lines of command output.
+func lastLines(output []byte, n int) string {
+	lines := bytes.Split(bytes.TrimSpace(output), []byte("\n"))
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return string(bytes.Join(lines, []byte("\n")))
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const testUpgradePlan = `Checking cert-manager version...
+Cert-Manager is already up to date
+
+Checking new release availability...
+
+Latest release available for the v1beta1 API Version of Cluster API (contract):
+
+NAME                    NAMESPACE                           TYPE                     CURRENT VERSION   NEXT VERSION
+bootstrap-kubeadm       capi-kubeadm-bootstrap-system       BootstrapProvider        v1.6.0            v1.6.8
+control-plane-kubeadm   capi-kubeadm-control-plane-system   ControlPlaneProvider     v1.6.0            v1.6.8
+cluster-api             capi-system                         CoreProvider             v1.6.0            v1.6.8
+infrastructure-aws      capa-system                         InfrastructureProvider   v2.5.0            Already up to date
+
+You can now apply the upgrade by executing the following command:
+
+clusterctl upgrade apply --contract v1beta1
+`
+
+// fakeClusterctl records invocations and returns canned output per subcommand.
+type fakeClusterctl struct {
+	calls    [][]string
+	applyErr error
+	planOut  string
+}
+
+func (f *fakeClusterctl) run(ctx context.Context, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	if len(args) > 1 && args[1] == "apply" {
+		return []byte("Performing upgrade..."), f.applyErr
+	}
+	return []byte(f.planOut), nil
+}
+
+func setupUpgradeTestService(t *testing.T, enabled bool) (*EnhancedClusterService, *fakeClusterctl) {
+	svc, _ := setupEnhancedTestService(t)
+	svc.SetProviderUpgradeOptions(ProviderUpgradeOptions{Enabled: enabled})
+
+	fake := &fakeClusterctl{planOut: testUpgradePlan}
+	svc.runClusterctl = fake.run
+	return svc, fake
+}
+
+func TestParseUpgradePlan(t *testing.T) {
+	contract, providers := parseUpgradePlan(testUpgradePlan)
+
+	assert.Equal(t, "v1beta1", contract)
+	require.Len(t, providers, 4)
+	assert.Equal(t, api.ProviderUpgrade{
+		Name:           "cluster-api",
+		Namespace:      "capi-system",
+		Type:           "CoreProvider",
+		CurrentVersion: "v1.6.0",
+		NextVersion:    "v1.6.8",
+	}, providers[2])
+	assert.Equal(t, upToDate, providers[3].NextVersion)
+}
+
+func TestEnhancedClusterService_UpgradeManagementProviders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("plan only", func(t *testing.T) {
+		svc, fake := setupUpgradeTestService(t, false)
+
+		output, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Len(t, output.Providers, 4)
+		assert.Contains(t, output.Message, "3 provider(s)")
+		assert.Equal(t, [][]string{{"upgrade", "plan"}}, fake.calls)
+	})
+
+	t.Run("apply rejected when disabled", func(t *testing.T) {
+		svc, fake := setupUpgradeTestService(t, false)
+
+		_, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{Apply: true})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Empty(t, fake.calls)
+	})
+
+	t.Run("apply to planned contract", func(t *testing.T) {
+		svc, fake := setupUpgradeTestService(t, true)
+
+		output, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{Apply: true})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		require.Len(t, fake.calls, 2)
+		assert.Equal(t, "upgrade apply --contract v1beta1", strings.Join(fake.calls[1], " "))
+	})
+
+	t.Run("apply explicit provider versions", func(t *testing.T) {
+		svc, fake := setupUpgradeTestService(t, true)
+
+		_, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{
+			Apply:                   true,
+			CoreProvider:            "capi-system/cluster-api:v1.6.8",
+			InfrastructureProviders: []string{"capa-system/aws:v2.5.1"},
+		})
+		require.NoError(t, err)
+		require.Len(t, fake.calls, 2)
+		assert.Equal(t, "upgrade apply --core capi-system/cluster-api:v1.6.8 --infrastructure capa-system/aws:v2.5.1",
+			strings.Join(fake.calls[1], " "))
+	})
+
+	t.Run("apply failure", func(t *testing.T) {
+		svc, fake := setupUpgradeTestService(t, true)
+		fake.applyErr = fmt.Errorf("exit status 1")
+
+		_, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{Apply: true})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeDependencyFailure, errors.GetErrorCode(err))
+	})
+
+	t.Run("invalid provider reference", func(t *testing.T) {
+		svc, fake := setupUpgradeTestService(t, true)
+
+		_, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{
+			Apply:        true,
+			CoreProvider: "--config=/etc/passwd",
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		assert.Empty(t, fake.calls)
+	})
+
+	t.Run("missing clusterctl binary", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+		svc.SetProviderUpgradeOptions(ProviderUpgradeOptions{ClusterctlPath: "/nonexistent/clusterctl"})
+
+		_, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+	})
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// CIDROverlapPolicy selects how create_cluster handles network CIDRs that
+// overlap those of existing clusters in the same provider region.
+type CIDROverlapPolicy string
+
+const (
+	// CIDROverlapBlock rejects clusters whose CIDRs overlap an existing cluster.
+	CIDROverlapBlock CIDROverlapPolicy = "block"
+
+	// CIDROverlapWarn creates the cluster and reports the overlaps as warnings.
+	CIDROverlapWarn CIDROverlapPolicy = "warn"
+
+	// CIDROverlapIgnore skips the overlap check.
+	CIDROverlapIgnore CIDROverlapPolicy = "ignore"
+)
+
+// cidrVariables are the topology variables holding a cluster's network CIDRs.
+var cidrVariables = []string{"vpcCIDR", "subnetCIDR"}
+
+// SetCIDROverlapPolicy configures how create_cluster handles CIDR overlaps.
+// Clusters are blocked by default.
+func (s *EnhancedClusterService) SetCIDROverlapPolicy(policy CIDROverlapPolicy) {
+	s.cidrOverlapPolicy = policy
+}
+
+// checkCIDROverlaps compares the CIDRs requested for a new cluster with those
+// of existing clusters of the same provider and region, which would break VPC
+// peering or shared networking between them. Overlaps are returned as warnings
+// under the warn policy and as an error otherwise.
+func (s *EnhancedClusterService) checkCIDROverlaps(ctx context.Context, clusterName string, variables map[string]interface{}, providerName string) ([]string, error) {
+	if s.cidrOverlapPolicy == CIDROverlapIgnore {
+		return nil, nil
+	}
+
+	requested := make(map[string]string)
+	for _, name := range cidrVariables {
+		if cidr, ok := variables[name].(string); ok && cidr != "" {
+			requested[name] = cidr
+		}
+	}
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	region, _ := variables[regionVariable].(string)
+
+	// Networks are shared across namespaces, so compare against every cluster
+	clusters, err := s.kubeClient.ListAllClusters(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters for CIDR overlap check")
+	}
+	namespace := s.kubeClient.Namespace(ctx)
+
+	var overlaps []string
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if cluster.Name == clusterName && cluster.Namespace == namespace {
+			continue
+		}
+		if clusterProvider(cluster) != providerName || clusterRegion(cluster) != region {
+			continue
+		}
+
+		// Clusters of other tenants are not named
+		owner := fmt.Sprintf("cluster '%s'", cluster.Name)
+		if cluster.Namespace != namespace {
+			owner = "a cluster in another namespace"
+		}
+
+		for name, existing := range clusterCIDRs(cluster) {
+			for _, requestedName := range cidrVariables {
+				cidr, ok := requested[requestedName]
+				if !ok {
+					continue
+				}
+				overlap, err := validation.CIDRsOverlap(cidr, existing)
+				if err != nil || !overlap {
+					// Existing clusters with malformed CIDRs cannot be compared
+					continue
+				}
+				overlaps = append(overlaps, fmt.Sprintf("%s %s overlaps %s of %s (%s)", requestedName, cidr, name, owner, existing))
+			}
+		}
+	}
+	if len(overlaps) == 0 {
+		return nil, nil
+	}
+	sort.Strings(overlaps)
+
+	if s.cidrOverlapPolicy == CIDROverlapWarn {
+		return overlaps, nil
+	}
+	return nil, errors.New(errors.CodeValidationFailed,
+		fmt.Sprintf("requested network CIDRs overlap existing clusters in the same region, which breaks peering and shared networking: %s", strings.Join(overlaps, "; "))).
+		WithDetails("overlaps", overlaps)
+}
+
+// clusterCIDRs returns the network CIDRs set in a cluster's topology variables.
+func clusterCIDRs(cluster *clusterv1.Cluster) map[string]string {
+	cidrs := make(map[string]string)
+	if cluster.Spec.Topology == nil {
+		return cidrs
+	}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		for _, name := range cidrVariables {
+			if variable.Name != name {
+				continue
+			}
+			var cidr string
+			if err := json.Unmarshal(variable.Value.Raw, &cidr); err == nil && cidr != "" {
+				cidrs[name] = cidr
+			}
+		}
+	}
+	return cidrs
+}
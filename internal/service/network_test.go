@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// withTopologyVariables sets string topology variables on a test cluster.
+func withTopologyVariables(cluster *clusterv1.Cluster, variables map[string]string) *clusterv1.Cluster {
+	for name, value := range variables {
+		raw, _ := json.Marshal(value)
+		cluster.Spec.Topology.Variables = append(cluster.Spec.Topology.Variables, clusterv1.ClusterVariable{
+			Name:  name,
+			Value: apiextensionsv1.JSON{Raw: raw},
+		})
+	}
+	return cluster
+}
+
+func TestEnhancedClusterService_CheckCIDROverlaps(t *testing.T) {
+	ctx := context.Background()
+
+	existing := withTopologyVariables(createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		map[string]string{"region": "us-west-2", "vpcCIDR": "10.0.0.0/16"})
+	otherTenant := withTopologyVariables(createTestCluster("team-b-prod", "team-b", clusterv1.ClusterPhaseProvisioned),
+		map[string]string{"region": "us-west-2", "vpcCIDR": "10.1.0.0/16", "subnetCIDR": "10.1.1.0/24"})
+	otherRegion := withTopologyVariables(createTestCluster("eu", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		map[string]string{"region": "eu-west-1", "vpcCIDR": "10.2.0.0/16"})
+
+	tests := []struct {
+		name      string
+		policy    CIDROverlapPolicy
+		variables map[string]interface{}
+		wantErr   []string
+		warnings  int
+	}{
+		{
+			name:      "no CIDRs requested",
+			variables: map[string]interface{}{"region": "us-west-2"},
+		},
+		{
+			name:      "disjoint vpc",
+			variables: map[string]interface{}{"region": "us-west-2", "vpcCIDR": "10.3.0.0/16"},
+		},
+		{
+			name:      "overlap in another region is allowed",
+			variables: map[string]interface{}{"region": "us-west-2", "vpcCIDR": "10.2.0.0/16"},
+		},
+		{
+			name:      "overlapping vpc is blocked",
+			variables: map[string]interface{}{"region": "us-west-2", "vpcCIDR": "10.0.0.0/8"},
+			wantErr:   []string{"cluster 'prod'", "a cluster in another namespace"},
+		},
+		{
+			name:      "subnet inside existing vpc is blocked",
+			variables: map[string]interface{}{"region": "us-west-2", "subnetCIDR": "10.0.5.0/24"},
+			wantErr:   []string{"subnetCIDR 10.0.5.0/24 overlaps vpcCIDR of cluster 'prod'"},
+		},
+		{
+			name:      "warn policy reports overlaps",
+			policy:    CIDROverlapWarn,
+			variables: map[string]interface{}{"region": "us-west-2", "vpcCIDR": "10.1.0.0/16"},
+			warnings:  2,
+		},
+		{
+			name:      "ignore policy skips the check",
+			policy:    CIDROverlapIgnore,
+			variables: map[string]interface{}{"region": "us-west-2", "vpcCIDR": "10.0.0.0/16"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t, existing.DeepCopy(), otherTenant.DeepCopy(), otherRegion.DeepCopy())
+			svc.SetCIDROverlapPolicy(tt.policy)
+
+			warnings, err := svc.checkCIDROverlaps(ctx, "new-cluster", tt.variables, "aws")
+			if len(tt.wantErr) > 0 {
+				require.Error(t, err)
+				assert.Equal(t, errors.CodeValidationFailed, errors.GetErrorCode(err))
+				for _, want := range tt.wantErr {
+					assert.Contains(t, errors.GetUserMessage(err), want)
+				}
+				assert.NotContains(t, errors.GetUserMessage(err), "team-b-prod")
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, warnings, tt.warnings)
+		})
+	}
+}
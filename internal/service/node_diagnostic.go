@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// diagnosticNamespace is the workload cluster namespace debug pods run in.
+	diagnosticNamespace = "kube-system"
+
+	// diagnosticContainer is the name of the debug pod's container.
+	diagnosticContainer = "diagnostic"
+
+	// diagnosticDeadline bounds how long a debug pod may run.
+	diagnosticDeadline = 90 * time.Second
+
+	// diagnosticPollInterval is how often the debug pod is checked for completion.
+	diagnosticPollInterval = 2 * time.Second
+
+	// diagnosticMarker prefixes the line starting the output of each check.
+	diagnosticMarker = "### "
+)
+
+// diagnosticChecks are the fixed scripts run_node_diagnostic may run on a node.
+// They run in a privileged pod with the node's root filesystem at /host and
+// only read state. Callers pick checks by name and never supply commands.
+var diagnosticChecks = map[string]string{
+	"disk": `chroot /host df -h
+chroot /host df -i
+chroot /host du -sh /var/lib/kubelet /var/lib/containerd /var/log`,
+	"kubelet": `chroot /host systemctl status kubelet --no-pager -l | head -n 20
+chroot /host journalctl -u kubelet -n 200 --no-pager`,
+	"network": `chroot /host ip addr
+chroot /host ip route
+cat /host/etc/resolv.conf
+chroot /host ss -tlnp | head -n 50`,
+	"runtime": `chroot /host systemctl status containerd --no-pager -l | head -n 20
+chroot /host crictl ps -a | head -n 50`,
+}
+
+// diagnosticCheckOrder is the order checks are run and reported in.
+var diagnosticCheckOrder = []string{"disk", "kubelet", "network", "runtime"}
+
+// SetNodeDiagnostics enables run_node_diagnostic, running debug pods with the
+// given image. An empty image leaves node diagnostics disabled, which is the
+// default since they run privileged pods on workload cluster nodes.
+func (s *EnhancedClusterService) SetNodeDiagnostics(image string) {
+	s.diagnosticImage = image
+}
+
+// RunNodeDiagnostic launches a short-lived privileged debug pod on a workload
+// cluster node, runs fixed diagnostic checks of its disk, kubelet, network and
+// container runtime, and returns their output. The debug pod is deleted once
+// it completes or times out.
+func (s *EnhancedClusterService) RunNodeDiagnostic(ctx context.Context, input api.RunNodeDiagnosticInput) (*api.RunNodeDiagnosticOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RunNodeDiagnostic").WithCluster(input.ClusterName, "")
+	logger.Info("Running node diagnostic", "node", input.NodeName, "checks", input.Checks)
+
+	if s.diagnosticImage == "" {
+		err := errors.New(errors.CodeForbidden, "node diagnostics are disabled, set NODE_DIAGNOSTICS_ENABLED=true to allow them")
+		logger.WithError(err).Warn("Node diagnostics disabled")
+		return nil, err
+	}
+	if input.ClusterName == "" || input.NodeName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name and node name are required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	checks := input.Checks
+	if len(checks) == 0 {
+		checks = diagnosticCheckOrder
+	}
+	for _, check := range checks {
+		if _, ok := diagnosticChecks[check]; !ok {
+			err := errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("unknown check '%s', must be one of: %s", check, strings.Join(diagnosticCheckOrder, ", ")))
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	diagnosticCtx, cancel := budget.Sub(ctx, diagnosticDeadline+time.Minute)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(diagnosticCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := runDiagnosticPod(diagnosticCtx, workloadClient, s.diagnosticImage, input.NodeName, checks)
+	if err != nil {
+		logger.WithError(err).Error("Node diagnostic failed", "node", input.NodeName)
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Ran node diagnostic", "node", input.NodeName, "pod", output.Pod, "completed", output.Completed)
+	return output, nil
+}
+
+// runDiagnosticPod runs the checks in a debug pod on a node, waits for it to
+// finish and collects its output.
+func runDiagnosticPod(ctx context.Context, workloadClient *kube.WorkloadClient, image, nodeName string, checks []string) (*api.RunNodeDiagnosticOutput, error) {
+	if _, err := workloadClient.GetNode(ctx, nodeName); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("node '%s' not found in the workload cluster", nodeName))
+		}
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to get node")
+	}
+
+	pod, err := workloadClient.CreatePod(ctx, buildDiagnosticPod(image, nodeName, checks))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to create debug pod")
+	}
+	defer func() {
+		// The request context may have expired; clean up regardless
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = workloadClient.DeletePod(cleanupCtx, pod.Namespace, pod.Name)
+	}()
+
+	output := &api.RunNodeDiagnosticOutput{
+		NodeName: nodeName,
+		Pod:      pod.Name,
+		Results:  []api.DiagnosticResult{},
+	}
+	phase, waitErr := waitForDiagnosticPod(ctx, workloadClient, pod.Name)
+	output.Completed = phase == corev1.PodSucceeded || phase == corev1.PodFailed
+
+	logs, err := workloadClient.GetPodLogs(ctx, pod.Namespace, pod.Name, diagnosticContainer, 10000, 2*maxPodLogBytes, false)
+	if err != nil && !output.Completed {
+		return nil, errors.Wrap(waitErr, errors.CodeTimeout,
+			fmt.Sprintf("debug pod %s did not run on node '%s' (phase %s)", pod.Name, nodeName, phase))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to get debug pod output")
+	}
+	output.Results = parseDiagnosticOutput(logs)
+
+	output.Message = fmt.Sprintf("Ran %d diagnostic check(s) on node '%s'", len(output.Results), nodeName)
+	if !output.Completed {
+		output.Message += fmt.Sprintf("; the debug pod did not finish within %s, output may be partial", diagnosticDeadline)
+	}
+	return output, nil
+}
+
+// buildDiagnosticPod builds a privileged debug pod pinned to a node, sharing
+// its PID and network namespaces and mounting its root filesystem at /host.
+func buildDiagnosticPod(image, nodeName string, checks []string) *corev1.Pod {
+	var script strings.Builder
+	for _, check := range diagnosticCheckOrder {
+		if slices.Contains(checks, check) {
+			fmt.Fprintf(&script, "echo '%s%s'\n(\n%s\n) 2>&1\n", diagnosticMarker, check, diagnosticChecks[check])
+		}
+	}
+
+	privileged := true
+	deadline := int64(diagnosticDeadline.Seconds())
+	hostPathType := corev1.HostPathDirectory
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capi-mcp-diagnostic-" + uuid.NewString()[:8],
+			Namespace: diagnosticNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "node-diagnostic",
+				"app.kubernetes.io/managed-by": "capi-mcp-server",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:              nodeName,
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &deadline,
+			HostPID:               true,
+			HostNetwork:           true,
+			Tolerations:           []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+			Containers: []corev1.Container{{
+				Name:            diagnosticContainer,
+				Image:           image,
+				Command:         []string{"sh", "-c", script.String()},
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				VolumeMounts:    []corev1.VolumeMount{{Name: "host", MountPath: "/host", ReadOnly: true}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "host",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/", Type: &hostPathType},
+				},
+			}},
+		},
+	}
+}
+
+// waitForDiagnosticPod waits for a debug pod to finish and returns its last
+// observed phase.
+func waitForDiagnosticPod(ctx context.Context, workloadClient *kube.WorkloadClient, name string) (corev1.PodPhase, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, diagnosticDeadline)
+	defer cancel()
+
+	ticker := time.NewTicker(diagnosticPollInterval)
+	defer ticker.Stop()
+
+	phase := corev1.PodPending
+	for {
+		pod, err := workloadClient.GetPod(waitCtx, diagnosticNamespace, name)
+		if err == nil {
+			phase = pod.Status.Phase
+			if phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+				return phase, nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return phase, waitCtx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseDiagnosticOutput splits the debug pod output into the output of each
+// check, which starts with a marker line naming the check.
+func parseDiagnosticOutput(logs string) []api.DiagnosticResult {
+	results := []api.DiagnosticResult{}
+	var current *api.DiagnosticResult
+	var lines []string
+	flush := func() {
+		if current != nil {
+			current.Output = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+			results = append(results, *current)
+		}
+	}
+
+	for _, line := range strings.Split(logs, "\n") {
+		if check, ok := strings.CutPrefix(line, diagnosticMarker); ok {
+			if _, known := diagnosticChecks[check]; known {
+				flush()
+				current = &api.DiagnosticResult{Check: check}
+				lines = nil
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return results
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestBuildDiagnosticPod(t *testing.T) {
+	pod := buildDiagnosticPod("busybox:1.36", "node-1", []string{"network", "disk"})
+
+	assert.Equal(t, "node-1", pod.Spec.NodeName)
+	assert.Equal(t, diagnosticNamespace, pod.Namespace)
+	assert.True(t, pod.Spec.HostPID)
+	assert.True(t, pod.Spec.HostNetwork)
+	require.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	assert.True(t, *container.SecurityContext.Privileged)
+	assert.True(t, container.VolumeMounts[0].ReadOnly)
+
+	script := container.Command[2]
+	assert.Less(t, strings.Index(script, "### disk"), strings.Index(script, "### network"))
+	assert.NotContains(t, script, "### kubelet")
+}
+
+func TestParseDiagnosticOutput(t *testing.T) {
+	logs := `### disk
+Filesystem Size Used
+/dev/root  80G  79G
+### kubelet
+kubelet.service - kubelet
+### unknown-check
+still kubelet
+`
+
+	assert.Equal(t, []api.DiagnosticResult{
+		{Check: "disk", Output: "Filesystem Size Used\n/dev/root  80G  79G"},
+		{Check: "kubelet", Output: "kubelet.service - kubelet\n### unknown-check\nstill kubelet"},
+	}, parseDiagnosticOutput(logs))
+	assert.Empty(t, parseDiagnosticOutput(""))
+}
+
+func TestRunDiagnosticPod(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+	var created *corev1.Pod
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		created = action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		created.Status.Phase = corev1.PodSucceeded
+		return false, nil, nil
+	})
+	workloadClient := kube.NewWorkloadClient(clientset)
+
+	output, err := runDiagnosticPod(ctx, workloadClient, "busybox:1.36", "node-1", diagnosticCheckOrder)
+	require.NoError(t, err)
+	assert.True(t, output.Completed)
+	assert.Equal(t, created.Name, output.Pod)
+
+	// The debug pod is removed once done
+	_, err = workloadClient.GetPod(ctx, diagnosticNamespace, created.Name)
+	assert.Error(t, err)
+
+	_, err = runDiagnosticPod(ctx, workloadClient, "busybox:1.36", "node-2", diagnosticCheckOrder)
+	assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+}
+
+func TestEnhancedClusterService_RunNodeDiagnostic_Validation(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.RunNodeDiagnostic(ctx, api.RunNodeDiagnosticInput{ClusterName: "test", NodeName: "node-1"})
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+
+	svc.SetNodeDiagnostics("busybox:1.36")
+
+	tests := []struct {
+		name  string
+		input api.RunNodeDiagnosticInput
+	}{
+		{name: "missing cluster name", input: api.RunNodeDiagnosticInput{NodeName: "node-1"}},
+		{name: "missing node name", input: api.RunNodeDiagnosticInput{ClusterName: "test"}},
+		{name: "unknown check", input: api.RunNodeDiagnosticInput{ClusterName: "test", NodeName: "node-1", Checks: []string{"rm -rf /"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.RunNodeDiagnostic(ctx, tt.input)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		})
+	}
+}
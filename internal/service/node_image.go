@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+// NodeImageVariable is the topology variable holding the machine image nodes
+// boot from. When a ClusterClass declares it and create_cluster is not given
+// a value, the image is resolved for the cluster's Kubernetes version and
+// region.
+const NodeImageVariable = "amiID"
+
+// ResolveNodeImage looks up the machine image for a Kubernetes version and
+// region through the provider's image lookup.
+func (s *EnhancedClusterService) ResolveNodeImage(ctx context.Context, input api.ResolveNodeImageInput) (*api.ResolveNodeImageOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ResolveNodeImage")
+	logger.Debug("Resolving node image", "kubernetes_version", input.KubernetesVersion, "region", input.Region)
+
+	if input.KubernetesVersion == "" {
+		err := errors.New(errors.CodeInvalidInput, "kubernetes version is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	providerName := input.Provider
+	if providerName == "" {
+		providerName = "aws"
+	}
+
+	resolveCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	image, err := s.resolveNodeImage(resolveCtx, providerName, provider.NodeImageQuery{
+		KubernetesVersion: input.KubernetesVersion,
+		Region:            input.Region,
+		OS:                input.OS,
+		Lookup:            input.Lookup,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to resolve node image")
+		return nil, err
+	}
+
+	output := &api.ResolveNodeImageOutput{
+		Provider:          providerName,
+		ImageID:           image.ID,
+		Name:              image.Name,
+		Region:            image.Region,
+		OS:                image.OS,
+		KubernetesVersion: image.KubernetesVersion,
+		Lookup:            image.Lookup,
+		Message:           fmt.Sprintf("Resolved %s (%s) for kubernetes %s in %s", image.ID, image.Name, image.KubernetesVersion, image.Region),
+	}
+	if !image.CreatedAt.IsZero() {
+		output.CreatedAt = image.CreatedAt.Format(time.RFC3339)
+	}
+
+	logger.Info("Resolved node image", "image", image.ID, "region", image.Region)
+	return output, nil
+}
+
+// resolveNodeImage resolves a node image with a provider's image lookup.
+func (s *EnhancedClusterService) resolveNodeImage(ctx context.Context, providerName string, query provider.NodeImageQuery) (*provider.NodeImage, error) {
+	if s.providerManager == nil {
+		return nil, errors.New(errors.CodeUnavailable, "no providers registered")
+	}
+	prov, exists := s.providerManager.GetProvider(providerName)
+	if !exists {
+		return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("unknown provider '%s'", providerName))
+	}
+	resolver, ok := prov.(provider.NodeImageResolver)
+	if !ok {
+		return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("provider '%s' does not support node image lookup", providerName))
+	}
+
+	image, err := resolver.ResolveNodeImage(ctx, query)
+	if err != nil {
+		if stderrors.Is(err, provider.ErrNodeImageNotFound) {
+			return nil, errors.Wrap(err, errors.CodeNotFound, "no matching node image")
+		}
+		return nil, errors.Wrap(err, errors.CodeProviderError, "failed to resolve node image")
+	}
+	return image, nil
+}
+
+// resolveNodeImageVariable resolves the node image for a cluster whose
+// ClusterClass declares the amiID variable without the caller setting it. It
+// returns an empty string when there is nothing to resolve.
+func (s *EnhancedClusterService) resolveNodeImageVariable(ctx context.Context, clusterClass *clusterv1.ClusterClass, input api.CreateClusterInput, providerName string) (string, error) {
+	if _, ok := input.Variables[NodeImageVariable]; ok {
+		return "", nil
+	}
+	declared := slices.ContainsFunc(clusterClass.Spec.Variables, func(variable clusterv1.ClusterClassVariable) bool {
+		return variable.Name == NodeImageVariable
+	})
+	if !declared {
+		return "", nil
+	}
+
+	region, _ := input.Variables[regionVariable].(string)
+	image, err := s.resolveNodeImage(ctx, providerName, provider.NodeImageQuery{
+		KubernetesVersion: input.KubernetesVersion,
+		Region:            region,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errors.GetErrorCode(err),
+			fmt.Sprintf("failed to resolve the node image for cluster template '%s', set the %s variable", clusterClass.Name, NodeImageVariable))
+	}
+	return image.ID, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider/aws"
+)
+
+// fakeImageSource returns fixed AMIs regardless of the query.
+type fakeImageSource struct {
+	images []aws.Image
+}
+
+func (f *fakeImageSource) DescribeImages(ctx context.Context, region, owner, pattern string) ([]aws.Image, error) {
+	return f.images, nil
+}
+
+// setupNodeImageService creates a test service whose AWS provider resolves
+// node images from images.
+func setupNodeImageService(t *testing.T, images ...aws.Image) *EnhancedClusterService {
+	t.Helper()
+
+	svc, _ := setupEnhancedTestService(t)
+	prov, ok := svc.providerManager.GetProvider("aws")
+	require.True(t, ok)
+	prov.(*aws.AWSProvider).SetImageSource(&fakeImageSource{images: images})
+	return svc
+}
+
+func TestEnhancedClusterService_ResolveNodeImage(t *testing.T) {
+	ctx := context.Background()
+	image := aws.Image{
+		ID:        "ami-0123456789abcdef0",
+		Name:      "capa-ami-ubuntu-22.04-v1.30.2-1740000000",
+		CreatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		svc := setupNodeImageService(t, image)
+
+		output, err := svc.ResolveNodeImage(ctx, api.ResolveNodeImageInput{KubernetesVersion: "v1.30.2", Region: "eu-west-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "ami-0123456789abcdef0", output.ImageID)
+		assert.Equal(t, "eu-west-1", output.Region)
+		assert.Equal(t, "ubuntu-22.04", output.OS)
+		assert.Equal(t, aws.ImageLookupCAPA, output.Lookup)
+		assert.Equal(t, "2025-03-01T00:00:00Z", output.CreatedAt)
+	})
+
+	tests := []struct {
+		name  string
+		input api.ResolveNodeImageInput
+		code  errors.ErrorCode
+	}{
+		{name: "missing version", input: api.ResolveNodeImageInput{Region: "us-west-2"}, code: errors.CodeInvalidInput},
+		{name: "unknown provider", input: api.ResolveNodeImageInput{Provider: "gcp", KubernetesVersion: "v1.30.2"}, code: errors.CodeInvalidInput},
+		{name: "no matching image", input: api.ResolveNodeImageInput{KubernetesVersion: "v1.30.2"}, code: errors.CodeNotFound},
+		{name: "invalid lookup", input: api.ResolveNodeImageInput{KubernetesVersion: "v1.30.2", Lookup: "ssm"}, code: errors.CodeProviderError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := setupNodeImageService(t)
+
+			_, err := svc.ResolveNodeImage(ctx, tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
+
+func TestEnhancedClusterService_ResolveNodeImageVariable(t *testing.T) {
+	ctx := context.Background()
+	image := aws.Image{ID: "ami-0123456789abcdef0", Name: "capa-ami-ubuntu-22.04-v1.30.2-1740000000"}
+
+	withImageVariable := createTestClusterClass("aws-standard")
+	withImageVariable.Spec.Variables = []clusterv1.ClusterClassVariable{
+		{Name: NodeImageVariable, Required: true, Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}},
+	}
+
+	tests := []struct {
+		name         string
+		clusterClass *clusterv1.ClusterClass
+		variables    map[string]interface{}
+		images       []aws.Image
+		want         string
+		code         errors.ErrorCode
+	}{
+		{
+			name:         "resolved for template variable",
+			clusterClass: withImageVariable,
+			variables:    map[string]interface{}{"region": "us-east-1"},
+			images:       []aws.Image{image},
+			want:         "ami-0123456789abcdef0",
+		},
+		{
+			name:         "set by caller",
+			clusterClass: withImageVariable,
+			variables:    map[string]interface{}{NodeImageVariable: "ami-custom"},
+		},
+		{
+			name:         "template without image variable",
+			clusterClass: createTestClusterClass("aws-standard"),
+			images:       []aws.Image{image},
+		},
+		{
+			name:         "no matching image",
+			clusterClass: withImageVariable,
+			code:         errors.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := setupNodeImageService(t, tt.images...)
+
+			got, err := svc.resolveNodeImageVariable(ctx, tt.clusterClass, api.CreateClusterInput{
+				ClusterName:       "prod",
+				TemplateName:      tt.clusterClass.Name,
+				KubernetesVersion: "v1.30.2",
+				Variables:         tt.variables,
+			}, "aws")
+			if tt.code != "" {
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
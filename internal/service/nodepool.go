@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+const (
+	// SpotMarketOptionsVariable is the topology variable requesting spot
+	// capacity, passed through to the provider's machine templates.
+	SpotMarketOptionsVariable = "spotMarketOptions"
+
+	// instanceTypeVariable is the topology variable selecting the instance type.
+	instanceTypeVariable = "instanceType"
+
+	// instanceTerminatedReason is the reason CAPA reports on a Machine whose
+	// instance was terminated outside of Cluster API, as spot interruptions are.
+	instanceTerminatedReason = "InstanceTerminated"
+)
+
+// CreateNodePool adds a node pool to a cluster managed by a ClusterClass
+// topology. The pool may run on spot capacity.
+func (s *EnhancedClusterService) CreateNodePool(ctx context.Context, input api.CreateNodePoolInput) (*api.CreateNodePoolOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("CreateNodePool").WithCluster(input.ClusterName, "")
+	logger.Info("Creating node pool",
+		"node_pool", input.NodePoolName,
+		"replicas", input.Replicas,
+		"spot", input.Spot != nil,
+		"gpu_count", input.GPUCount,
+	)
+
+	overrides, err := validateCreateNodePoolInput(input)
+	if err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	createCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(createCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if cluster.Spec.Topology == nil {
+		return nil, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' is not managed by a ClusterClass, so node pools cannot be added through its topology", input.ClusterName))
+	}
+
+	// Validate the overrides against the provider, in the cluster's region
+	providerName := clusterProvider(cluster)
+	if s.providerManager != nil {
+		if prov, exists := s.providerManager.GetProvider(providerName); exists {
+			variables := make(map[string]interface{}, len(input.Variables)+2)
+			for name, value := range overrides {
+				variables[name] = value
+			}
+			if region := clusterRegion(cluster); region != "" {
+				variables[regionVariable] = region
+			}
+			if err := prov.ValidateClusterConfig(createCtx, variables); err != nil {
+				logger.WithError(err).Error("Provider validation failed")
+				return nil, errors.Wrap(err, errors.CodeProviderValidation, "provider validation failed")
+			}
+		}
+	}
+
+	if err := s.checkInstanceTypes(overrides); err != nil {
+		logger.WithError(err).Error("Instance type not permitted")
+		return nil, err
+	}
+
+	workers := cluster.Spec.Topology.Workers
+	if workers == nil {
+		workers = &clusterv1.WorkersTopology{}
+	}
+	for _, md := range workers.MachineDeployments {
+		if md.Name == input.NodePoolName {
+			return nil, errors.New(errors.CodeAlreadyExists,
+				fmt.Sprintf("node pool '%s' already exists in cluster '%s'", input.NodePoolName, input.ClusterName))
+		}
+	}
+
+	class, err := s.resolveWorkerClass(createCtx, cluster.Spec.Topology.Class, input.Class)
+	if err != nil {
+		logger.WithError(err).Error("Failed to resolve worker class")
+		return nil, err
+	}
+
+	replicas := int32(input.Replicas)
+	nodePool := nodeGroup{
+		name:         "MachineDeployment/" + input.NodePoolName,
+		instanceType: nodePoolInstanceType(cluster, overrides),
+		replicas:     replicas,
+	}
+	if err := s.checkNodePoolClusterSize(createCtx, cluster, nodePool); err != nil {
+		logger.WithError(err).Error("Cluster size limit exceeded")
+		return nil, err
+	}
+
+	topology := clusterv1.MachineDeploymentTopology{
+		Class:    class,
+		Name:     input.NodePoolName,
+		Replicas: &replicas,
+	}
+	if len(overrides) > 0 {
+		variables, err := toClusterVariables(overrides)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid node pool variables")
+		}
+		topology.Variables = &clusterv1.MachineDeploymentVariables{Overrides: variables}
+	}
+	workers.MachineDeployments = append(workers.MachineDeployments, topology)
+	cluster.Spec.Topology.Workers = workers
+
+	if err := s.kubeClient.UpdateCluster(createCtx, cluster); err != nil {
+		logger.WithError(err).Error("Failed to update cluster topology")
+		if apierrors.IsConflict(err) {
+			return nil, errors.Wrap(err, errors.CodePreconditionFailed, "cluster was modified concurrently, retry the request")
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to add node pool")
+	}
+
+	message := fmt.Sprintf("Node pool '%s' (%s) with %d replicas is being created", input.NodePoolName, class, input.Replicas)
+	if input.Spot != nil {
+		message += " on spot capacity; spot instances may be interrupted when AWS reclaims capacity"
+	}
+	logger.Info("Node pool creation initiated", "class", class)
+	return &api.CreateNodePoolOutput{
+		NodePoolName: input.NodePoolName,
+		Class:        class,
+		Status:       "creating",
+		Message:      message,
+	}, nil
+}
+
+// validateCreateNodePoolInput validates the create_node_pool parameters and
+// returns the topology variable overrides for the pool.
+func validateCreateNodePoolInput(input api.CreateNodePoolInput) (map[string]interface{}, error) {
+	validator := validation.NewValidator()
+	if err := validator.ValidateClusterName(input.ClusterName); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateNodePoolName(input.NodePoolName); err != nil {
+		return nil, err
+	}
+	if input.Replicas < 0 || input.Replicas > 100 {
+		return nil, errors.New(errors.CodeInvalidInput, "replicas must be between 0 and 100")
+	}
+
+	overrides := make(map[string]interface{}, len(input.Variables)+2)
+	for name, value := range input.Variables {
+		overrides[name] = value
+	}
+	if input.InstanceType != "" {
+		overrides[instanceTypeVariable] = input.InstanceType
+	}
+	if input.GPUCount != 0 {
+		overrides[GPUCountVariable] = input.GPUCount
+	}
+	if input.Spot != nil {
+		spot := map[string]interface{}{}
+		if input.Spot.MaxPrice != "" {
+			spot["maxPrice"] = input.Spot.MaxPrice
+		}
+		if input.Spot.AllocationStrategy != "" {
+			spot["allocationStrategy"] = input.Spot.AllocationStrategy
+		}
+		overrides[SpotMarketOptionsVariable] = spot
+	}
+
+	if err := validator.ValidateClusterVariables(overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// resolveWorkerClass checks that class is a MachineDeployment class of the
+// ClusterClass. An empty class selects the only class, if there is just one.
+func (s *EnhancedClusterService) resolveWorkerClass(ctx context.Context, clusterClassName, class string) (string, error) {
+	clusterClass, err := s.kubeClient.GetClusterClass(ctx, clusterClassName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errors.New(errors.CodeNotFound, fmt.Sprintf("cluster template '%s' not found", clusterClassName))
+		}
+		return "", errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+	}
+
+	classes := make([]string, 0, len(clusterClass.Spec.Workers.MachineDeployments))
+	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
+		if md.Class == class {
+			return class, nil
+		}
+		classes = append(classes, md.Class)
+	}
+
+	switch {
+	case len(classes) == 0:
+		return "", errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster template '%s' defines no worker classes", clusterClassName))
+	case class == "" && len(classes) == 1:
+		return classes[0], nil
+	case class == "":
+		return "", errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("cluster template '%s' defines several worker classes, specify one of: %s", clusterClassName, strings.Join(classes, ", ")))
+	default:
+		return "", errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("worker class '%s' not found in cluster template '%s' - available classes: %s", class, clusterClassName, strings.Join(classes, ", ")))
+	}
+}
+
+// toClusterVariables converts variables to CAPI cluster variables, sorted by
+// name so that repeated updates produce the same spec.
+func toClusterVariables(values map[string]interface{}) ([]clusterv1.ClusterVariable, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	variables := make([]clusterv1.ClusterVariable, 0, len(values))
+	for _, name := range names {
+		raw, err := json.Marshal(values[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal variable %s: %w", name, err)
+		}
+		variables = append(variables, clusterv1.ClusterVariable{
+			Name:  name,
+			Value: apiextensionsv1.JSON{Raw: raw},
+		})
+	}
+	return variables, nil
+}
+
+// isSpotMachineDeployment reports whether a MachineDeployment runs on spot
+// capacity, requested either by its topology overrides or for the whole cluster.
+func isSpotMachineDeployment(cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment) bool {
+	if cluster.Spec.Topology == nil {
+		return false
+	}
+
+	if topologyName := md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel]; topologyName != "" && cluster.Spec.Topology.Workers != nil {
+		for _, topology := range cluster.Spec.Topology.Workers.MachineDeployments {
+			if topology.Name != topologyName || topology.Variables == nil {
+				continue
+			}
+			for _, variable := range topology.Variables.Overrides {
+				if variable.Name == SpotMarketOptionsVariable {
+					return !isNullVariable(variable)
+				}
+			}
+		}
+	}
+
+	for _, variable := range cluster.Spec.Topology.Variables {
+		if variable.Name == SpotMarketOptionsVariable {
+			return !isNullVariable(variable)
+		}
+	}
+	return false
+}
+
+func isNullVariable(variable clusterv1.ClusterVariable) bool {
+	raw := strings.TrimSpace(string(variable.Value.Raw))
+	return raw == "" || raw == "null"
+}
+
+// countSpotInterruptions counts the Machines of a MachineDeployment whose
+// instances were reclaimed by a spot interruption and have not been replaced yet.
+func (s *EnhancedClusterService) countSpotInterruptions(ctx context.Context, clusterName, mdName string) (int, error) {
+	machines, err := s.kubeClient.ListMachineDeploymentMachines(ctx, clusterName, mdName)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range machines.Items {
+		if isSpotInterrupted(&machines.Items[i]) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// isSpotInterrupted reports whether a Machine's instance was reclaimed by a
+// spot interruption: its infrastructure reports the instance as terminated, or
+// its failure message mentions an interruption.
+func isSpotInterrupted(machine *clusterv1.Machine) bool {
+	if machine.DeletionTimestamp != nil {
+		return false
+	}
+
+	for _, condition := range machine.Status.Conditions {
+		if condition.Reason == instanceTerminatedReason || mentionsSpotInterruption(condition.Message) {
+			return true
+		}
+	}
+	return machine.Status.FailureMessage != nil && mentionsSpotInterruption(*machine.Status.FailureMessage)
+}
+
+func mentionsSpotInterruption(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "spot") && strings.Contains(message, "interrupt")
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func createTestMachine(name, clusterName, mdName string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           clusterName,
+				clusterv1.MachineDeploymentNameLabel: mdName,
+			},
+		},
+		Spec: clusterv1.MachineSpec{ClusterName: clusterName},
+	}
+}
+
+func TestEnhancedClusterService_CreateNodePool(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		input    api.CreateNodePoolInput
+		wantCode errors.ErrorCode
+		wantErr  string
+	}{
+		{
+			name: "spot pool with defaulted class",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "spot-workers",
+				Replicas:     3,
+				InstanceType: "m5.large",
+				Spot:         &api.SpotMarketOptions{MaxPrice: "0.05", AllocationStrategy: "capacity-optimized"},
+			},
+		},
+		{
+			name: "on-demand pool with explicit class",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "workers",
+				Class:        "default-worker",
+				Replicas:     1,
+			},
+		},
+		{
+			name: "unknown class",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "workers",
+				Class:        "gpu-worker",
+				Replicas:     1,
+			},
+			wantCode: errors.CodeInvalidInput,
+			wantErr:  "available classes: default-worker",
+		},
+		{
+			name: "GPU pool",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "gpu-workers",
+				Replicas:     2,
+				InstanceType: "g5.12xlarge",
+				GPUCount:     4,
+			},
+		},
+		{
+			name: "GPU count on a non-GPU instance type",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "gpu-workers",
+				Replicas:     1,
+				InstanceType: "m5.large",
+				GPUCount:     1,
+			},
+			wantCode: errors.CodeInvalidInput,
+			wantErr:  "GPU instance type",
+		},
+		{
+			name: "invalid spot max price",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "spot-workers",
+				Replicas:     1,
+				Spot:         &api.SpotMarketOptions{MaxPrice: "cheap"},
+			},
+			wantCode: errors.CodeInvalidInput,
+			wantErr:  "maxPrice",
+		},
+		{
+			name: "invalid spot allocation strategy",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "spot-workers",
+				Replicas:     1,
+				Spot:         &api.SpotMarketOptions{AllocationStrategy: "diversified"},
+			},
+			wantCode: errors.CodeInvalidInput,
+			wantErr:  "allocationStrategy",
+		},
+		{
+			name: "duplicate node pool",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "test-cluster",
+				NodePoolName: "md-0",
+				Replicas:     1,
+			},
+			wantCode: errors.CodeAlreadyExists,
+		},
+		{
+			name: "cluster not found",
+			input: api.CreateNodePoolInput{
+				ClusterName:  "missing",
+				NodePoolName: "workers",
+				Replicas:     1,
+			},
+			wantCode: errors.CodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+			cluster.Spec.Topology.Workers = &clusterv1.WorkersTopology{
+				MachineDeployments: []clusterv1.MachineDeploymentTopology{{Class: "default-worker", Name: "md-0"}},
+			}
+			svc, fakeClient := setupEnhancedTestService(t, cluster, createTestClusterClass("aws-cluster-class"))
+
+			output, err := svc.CreateNodePool(ctx, tt.input)
+			if tt.wantCode != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, errors.GetErrorCode(err))
+				if tt.wantErr != "" {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "creating", output.Status)
+			assert.Equal(t, "default-worker", output.Class)
+
+			var updated clusterv1.Cluster
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "test-cluster", Namespace: testNamespace}, &updated))
+			require.Len(t, updated.Spec.Topology.Workers.MachineDeployments, 2)
+			pool := updated.Spec.Topology.Workers.MachineDeployments[1]
+			assert.Equal(t, tt.input.NodePoolName, pool.Name)
+			assert.Equal(t, int32(tt.input.Replicas), *pool.Replicas)
+
+			if tt.input.InstanceType == "" {
+				assert.Nil(t, pool.Variables)
+				return
+			}
+			require.NotNil(t, pool.Variables)
+			overrides := map[string]string{}
+			for _, variable := range pool.Variables.Overrides {
+				overrides[variable.Name] = string(variable.Value.Raw)
+			}
+			assert.Equal(t, `"`+tt.input.InstanceType+`"`, overrides["instanceType"])
+			if tt.input.GPUCount != 0 {
+				assert.Equal(t, "4", overrides[GPUCountVariable])
+			}
+			if tt.input.Spot != nil {
+				assert.JSONEq(t, `{"maxPrice":"0.05","allocationStrategy":"capacity-optimized"}`, overrides[SpotMarketOptionsVariable])
+				assert.Contains(t, output.Message, "spot capacity")
+			}
+		})
+	}
+}
+
+func TestEnhancedClusterService_CreateNodePoolWithoutTopology(t *testing.T) {
+	cluster := createTestCluster("legacy", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Spec.Topology = nil
+	svc, _ := setupEnhancedTestService(t, cluster)
+
+	_, err := svc.CreateNodePool(context.Background(), api.CreateNodePoolInput{
+		ClusterName:  "legacy",
+		NodePoolName: "workers",
+		Replicas:     1,
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+}
+
+func TestEnhancedClusterService_SpotNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	spotOptions, err := json.Marshal(map[string]string{"maxPrice": "0.05"})
+	require.NoError(t, err)
+
+	cluster := createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Spec.Topology.Workers = &clusterv1.WorkersTopology{
+		MachineDeployments: []clusterv1.MachineDeploymentTopology{
+			{Class: "default-worker", Name: "on-demand"},
+			{Class: "default-worker", Name: "spot", Variables: &clusterv1.MachineDeploymentVariables{
+				Overrides: []clusterv1.ClusterVariable{{Name: SpotMarketOptionsVariable, Value: apiextensionsv1.JSON{Raw: spotOptions}}},
+			}},
+		},
+	}
+
+	onDemand := createTestMachineDeployment("test-cluster-on-demand-abc12", testNamespace, "test-cluster", 2)
+	onDemand.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = "on-demand"
+	spot := createTestMachineDeployment("test-cluster-spot-def34", testNamespace, "test-cluster", 3)
+	spot.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = "spot"
+
+	healthy := createTestMachine("spot-1", "test-cluster", spot.Name)
+	terminated := createTestMachine("spot-2", "test-cluster", spot.Name)
+	terminated.Status.Conditions = clusterv1.Conditions{{
+		Type:     clusterv1.InfrastructureReadyCondition,
+		Status:   corev1.ConditionFalse,
+		Severity: clusterv1.ConditionSeverityError,
+		Reason:   instanceTerminatedReason,
+	}}
+	interrupted := createTestMachine("spot-3", "test-cluster", spot.Name)
+	failureMessage := "EC2 instance i-0abc was stopped by a Spot Instance interruption"
+	interrupted.Status.FailureMessage = &failureMessage
+
+	svc, _ := setupEnhancedTestService(t, cluster, onDemand, spot, healthy, terminated, interrupted)
+
+	t.Run("get cluster node pools", func(t *testing.T) {
+		pools := map[string]api.NodePool{}
+		nodePools, _ := svc.getNodePools(ctx, cluster)
+		for _, pool := range nodePools {
+			pools[pool.Name] = pool
+		}
+		assert.False(t, pools[onDemand.Name].Spot)
+		assert.Zero(t, pools[onDemand.Name].SpotInterruptions)
+		assert.True(t, pools[spot.Name].Spot)
+		assert.Equal(t, 2, pools[spot.Name].SpotInterruptions)
+	})
+
+	t.Run("scale spot pool", func(t *testing.T) {
+		output, err := svc.ScaleCluster(ctx, api.ScaleClusterInput{
+			ClusterName:  "test-cluster",
+			NodePoolName: spot.Name,
+			Replicas:     5,
+		})
+		require.NoError(t, err)
+		assert.True(t, output.Spot)
+		assert.Contains(t, output.Message, "spot capacity")
+	})
+
+	t.Run("scale on-demand pool", func(t *testing.T) {
+		output, err := svc.ScaleCluster(ctx, api.ScaleClusterInput{
+			ClusterName:  "test-cluster",
+			NodePoolName: onDemand.Name,
+			Replicas:     4,
+		})
+		require.NoError(t, err)
+		assert.False(t, output.Spot)
+	})
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+// ScanOrphanedCloudResources finds the cloud resources owned by clusters the
+// management cluster no longer has, typically leaked by deletions that were
+// interrupted or forced. Resources are only reported: they may belong to
+// clusters of another management cluster sharing the account.
+func (s *EnhancedClusterService) ScanOrphanedCloudResources(ctx context.Context, input api.ScanOrphanedCloudResourcesInput) (*api.ScanOrphanedCloudResourcesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ScanOrphanedCloudResources")
+	logger.Info("Scanning for orphaned cloud resources", "provider", input.Provider, "region", input.Region)
+
+	providerName := input.Provider
+	if providerName == "" {
+		providerName = "aws"
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.providerManager == nil {
+		return nil, errors.New(errors.CodeUnavailable, "no providers registered")
+	}
+	prov, exists := s.providerManager.GetProvider(providerName)
+	if !exists {
+		return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("unknown provider '%s'", providerName))
+	}
+	scanner, ok := prov.(provider.CloudResourceScanner)
+	if !ok {
+		return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("provider '%s' does not support cloud resource scans", providerName))
+	}
+
+	scanCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	// Clusters are listed before the scan, so that resources of a cluster
+	// created meanwhile are not mistaken for orphans
+	clusters, err := s.kubeClient.ListAllClusters(scanCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list clusters")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
+	}
+	known := make(map[string]bool, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		known[cluster.Name] = true
+	}
+
+	resources, err := scanner.ScanClusterResources(scanCtx, input.Region)
+	if err != nil {
+		logger.WithError(err).Error("Failed to scan cloud resources")
+		return nil, errors.Wrap(err, errors.CodeProviderError, "failed to scan cloud resources")
+	}
+
+	output := &api.ScanOrphanedCloudResourcesOutput{
+		Provider:  providerName,
+		Region:    input.Region,
+		Clusters:  []string{},
+		Resources: []api.OrphanedCloudResource{},
+	}
+	for _, resource := range resources {
+		if known[resource.ClusterName] {
+			continue
+		}
+		orphan := api.OrphanedCloudResource{
+			Type:        resource.Type,
+			ID:          resource.ID,
+			Name:        resource.Name,
+			Region:      resource.Region,
+			ClusterName: resource.ClusterName,
+			State:       resource.State,
+		}
+		if !resource.CreatedAt.IsZero() {
+			orphan.CreatedAt = resource.CreatedAt.UTC().Format(time.RFC3339)
+		}
+		output.Resources = append(output.Resources, orphan)
+		if !slices.Contains(output.Clusters, resource.ClusterName) {
+			output.Clusters = append(output.Clusters, resource.ClusterName)
+		}
+	}
+	slices.Sort(output.Clusters)
+
+	if len(output.Resources) == 0 {
+		output.Message = fmt.Sprintf("No orphaned cloud resources found among %d resources owned by clusters", len(resources))
+	} else {
+		output.Message = fmt.Sprintf("Found %d cloud resources owned by %d clusters the management cluster does not have: %s. "+
+			"Check they do not belong to another management cluster before deleting them in the cloud account",
+			len(output.Resources), len(output.Clusters), strings.Join(output.Clusters, ", "))
+	}
+	logger.Info("Scanned for orphaned cloud resources", "resources", len(resources), "orphaned", len(output.Resources))
+	return output, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider/aws"
+)
+
+type fakeCloudResourceSource struct {
+	resources []capiprovider.CloudResource
+}
+
+func (f *fakeCloudResourceSource) ListOwnedResources(ctx context.Context, region string) ([]capiprovider.CloudResource, error) {
+	return f.resources, nil
+}
+
+func TestEnhancedClusterService_ScanOrphanedCloudResources(t *testing.T) {
+	ctx := context.Background()
+	launched := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	svc, _ := setupEnhancedTestService(t,
+		createTestCluster("live", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		createTestCluster("other", "team-b", clusterv1.ClusterPhaseProvisioned),
+	)
+
+	t.Run("scans not enabled", func(t *testing.T) {
+		_, err := svc.ScanOrphanedCloudResources(ctx, api.ScanOrphanedCloudResourcesInput{})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeProviderError, errors.GetErrorCode(err))
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, err := svc.ScanOrphanedCloudResources(ctx, api.ScanOrphanedCloudResourcesInput{Provider: "gcp"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+
+	prov, ok := svc.providerManager.GetProvider("aws")
+	require.True(t, ok)
+	prov.(*aws.AWSProvider).SetCloudResourceSource(&fakeCloudResourceSource{resources: []capiprovider.CloudResource{
+		{Type: "vpc", ID: "vpc-live", Region: "us-west-2", ClusterName: "live", State: "available"},
+		{Type: "instance", ID: "i-other", Region: "us-west-2", ClusterName: "other", State: "running"},
+		{Type: "vpc", ID: "vpc-gone", Region: "us-west-2", ClusterName: "gone", State: "available"},
+		{Type: "instance", ID: "i-gone", Region: "us-west-2", ClusterName: "gone", State: "running", CreatedAt: launched},
+		{Type: "load-balancer", ID: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/old-apiserver/abc", Region: "us-west-2", ClusterName: "old"},
+	}})
+
+	output, err := svc.ScanOrphanedCloudResources(ctx, api.ScanOrphanedCloudResourcesInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "aws", output.Provider)
+	assert.Equal(t, []string{"gone", "old"}, output.Clusters)
+	require.Len(t, output.Resources, 3)
+	assert.Equal(t, "vpc-gone", output.Resources[0].ID)
+	assert.Equal(t, api.OrphanedCloudResource{
+		Type: "instance", ID: "i-gone", Region: "us-west-2", ClusterName: "gone", State: "running", CreatedAt: "2026-09-01T12:00:00Z",
+	}, output.Resources[1])
+	assert.Equal(t, "load-balancer", output.Resources[2].Type)
+	assert.Contains(t, output.Message, "3 cloud resources owned by 2 clusters")
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/gc"
+)
+
+// CleanupOrphanedResources reports the Cluster API resources in the caller's
+// namespace left behind by clusters that no longer exist, and deletes them
+// when called with the confirmation token of the report.
+func (s *EnhancedClusterService) CleanupOrphanedResources(ctx context.Context, input api.CleanupOrphanedResourcesInput) (*api.CleanupOrphanedResourcesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("CleanupOrphanedResources")
+	logger.Info("Finding orphaned resources", "confirmed", input.ConfirmationToken != "")
+
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	cleanupCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	collector := gc.NewCollector(s.kubeClient, gc.DefaultKinds, gc.DefaultMinAge)
+	orphans, err := collector.Find(cleanupCtx, s.kubeClient.Namespace(ctx))
+	if err != nil {
+		logger.WithError(err).Error("Failed to find orphaned resources")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to find orphaned resources")
+	}
+
+	output := &api.CleanupOrphanedResourcesOutput{Resources: make([]api.OrphanedResource, 0, len(orphans))}
+	for _, orphan := range orphans {
+		output.Resources = append(output.Resources, toAPIOrphanedResource(orphan))
+	}
+	if len(orphans) == 0 {
+		output.Message = "No orphaned resources found"
+		return output, nil
+	}
+
+	token := gc.Token(orphans)
+	if input.ConfirmationToken == "" {
+		output.ConfirmationToken = token
+		output.Message = fmt.Sprintf("Found %d orphaned resources; review them and call again with confirmation token %s to delete them",
+			len(orphans), token)
+		return output, nil
+	}
+	if input.ConfirmationToken != token {
+		err := errors.New(errors.CodePreconditionFailed,
+			"the orphaned resources changed since the confirmation token was issued; review them again and confirm with the new token").
+			WithDetails("confirmation_token", token)
+		logger.WithError(err).Error("Stale confirmation token")
+		return nil, err
+	}
+
+	failed := 0
+	for i, orphan := range orphans {
+		if err := collector.Delete(cleanupCtx, orphan); err != nil {
+			logger.WithError(err).Warn("Failed to delete orphaned resource", "resource", orphan.String())
+			output.Resources[i].Error = err.Error()
+			failed++
+		}
+	}
+	output.Deleted = true
+	output.Message = fmt.Sprintf("Deleted %d orphaned resources", len(orphans)-failed)
+	if failed > 0 {
+		output.Message += fmt.Sprintf("; %d could not be deleted", failed)
+	}
+	logger.Info("Deleted orphaned resources", "deleted", len(orphans)-failed, "failed", failed)
+	return output, nil
+}
+
+// toAPIOrphanedResource converts an orphaned resource to its API representation.
+func toAPIOrphanedResource(resource gc.Resource) api.OrphanedResource {
+	return api.OrphanedResource{
+		Kind:        resource.Kind,
+		APIVersion:  resource.APIVersion,
+		Namespace:   resource.Namespace,
+		Name:        resource.Name,
+		ClusterName: resource.ClusterName,
+		CreatedAt:   resource.CreatedAt.UTC().Format(time.RFC3339),
+		Deleting:    resource.Deleting,
+		Finalizers:  resource.Finalizers,
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_CleanupOrphanedResources(t *testing.T) {
+	ctx := context.Background()
+
+	orphanedMD := createTestMachineDeployment("deleted-md-0", testNamespace, "deleted", 2)
+	orphanedMD.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	liveMD := createTestMachineDeployment("test-cluster-md-0", testNamespace, "test-cluster", 2)
+	liveMD.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+	svc, fakeClient := setupEnhancedTestService(t,
+		createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		liveMD,
+		orphanedMD,
+	)
+
+	report, err := svc.CleanupOrphanedResources(ctx, api.CleanupOrphanedResourcesInput{})
+	require.NoError(t, err)
+	require.Len(t, report.Resources, 1)
+	assert.Equal(t, "MachineDeployment", report.Resources[0].Kind)
+	assert.Equal(t, "deleted-md-0", report.Resources[0].Name)
+	assert.Equal(t, "deleted", report.Resources[0].ClusterName)
+	assert.False(t, report.Deleted)
+	require.NotEmpty(t, report.ConfirmationToken)
+	assert.Contains(t, report.Message, report.ConfirmationToken)
+
+	t.Run("stale token", func(t *testing.T) {
+		_, err := svc.CleanupOrphanedResources(ctx, api.CleanupOrphanedResourcesInput{ConfirmationToken: "0123456789abcdef"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+
+		var md clusterv1.MachineDeployment
+		assert.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(orphanedMD), &md))
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		output, err := svc.CleanupOrphanedResources(ctx, api.CleanupOrphanedResourcesInput{ConfirmationToken: report.ConfirmationToken})
+		require.NoError(t, err)
+		assert.True(t, output.Deleted)
+		assert.Equal(t, "Deleted 1 orphaned resources", output.Message)
+
+		var list clusterv1.MachineDeploymentList
+		require.NoError(t, fakeClient.List(ctx, &list))
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "test-cluster-md-0", list.Items[0].Name)
+	})
+
+	t.Run("nothing left", func(t *testing.T) {
+		output, err := svc.CleanupOrphanedResources(ctx, api.CleanupOrphanedResourcesInput{})
+		require.NoError(t, err)
+		assert.Empty(t, output.Resources)
+		assert.Empty(t, output.ConfirmationToken)
+	})
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// defaultPodLogTailLines is the number of log lines returned by default.
+	defaultPodLogTailLines = 100
+
+	// maxPodLogTailLines bounds the number of log lines returned.
+	maxPodLogTailLines = 2000
+
+	// maxPodLogBytes bounds the size of the logs returned.
+	maxPodLogBytes = 256 * 1024
+)
+
+// GetWorkloadPodLogs returns the tail of a container's logs from a workload
+// cluster, along with its restart count and state, so that a failing addon
+// or node-critical DaemonSet can be triaged without a port-forward or the
+// cluster's kubeconfig.
+func (s *EnhancedClusterService) GetWorkloadPodLogs(ctx context.Context, input api.GetWorkloadPodLogsInput) (*api.GetWorkloadPodLogsOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetWorkloadPodLogs").WithCluster(input.ClusterName, "")
+	logger.Debug("Getting workload pod logs", "pod_namespace", input.PodNamespace, "pod", input.Pod, "container", input.Container)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.PodNamespace == "" || input.Pod == "" {
+		err := errors.New(errors.CodeInvalidInput, "pod namespace and pod name are required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.TailLines < 0 || input.TailLines > maxPodLogTailLines {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("tail lines must be between 1 and %d, got %d", maxPodLogTailLines, input.TailLines))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	logsCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(logsCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := podLogs(logsCtx, workloadClient, input)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get workload pod logs")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Got workload pod logs", "pod", input.Pod, "container", output.Container, "bytes", len(output.Logs))
+	return output, nil
+}
+
+// podLogs resolves the container of a pod and returns the tail of its logs.
+func podLogs(ctx context.Context, workloadClient *kube.WorkloadClient, input api.GetWorkloadPodLogsInput) (*api.GetWorkloadPodLogsOutput, error) {
+	pod, err := workloadClient.GetPod(ctx, input.PodNamespace, input.Pod)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound,
+				fmt.Sprintf("pod '%s/%s' not found in the workload cluster", input.PodNamespace, input.Pod))
+		}
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to get pod")
+	}
+
+	container := input.Container
+	names := podContainerNames(pod)
+	if container == "" {
+		if len(names) != 1 {
+			return nil, errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("pod '%s' has %d containers, choose one of: %s", input.Pod, len(names), strings.Join(names, ", ")))
+		}
+		container = names[0]
+	}
+	if !slices.Contains(names, container) {
+		return nil, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("pod '%s' has no container '%s', choose one of: %s", input.Pod, container, strings.Join(names, ", ")))
+	}
+
+	tailLines := input.TailLines
+	if tailLines == 0 {
+		tailLines = defaultPodLogTailLines
+	}
+	logs, err := workloadClient.GetPodLogs(ctx, input.PodNamespace, input.Pod, container, int64(tailLines), maxPodLogBytes, input.Previous)
+	if err != nil {
+		if apierrors.IsBadRequest(err) && input.Previous {
+			return nil, errors.Wrap(err, errors.CodePreconditionFailed,
+				fmt.Sprintf("container '%s' has no previous instance", container))
+		}
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to get pod logs")
+	}
+
+	output := &api.GetWorkloadPodLogsOutput{
+		PodNamespace: input.PodNamespace,
+		Pod:          input.Pod,
+		Container:    container,
+		Previous:     input.Previous,
+		TailLines:    tailLines,
+		Logs:         logs,
+	}
+	if status, ok := podContainerStatus(pod, container); ok {
+		output.Restarts = int(status.RestartCount)
+		output.State = containerState(status.State)
+	}
+	output.Message = fmt.Sprintf("Last %d log lines of container '%s' in pod '%s/%s'", tailLines, container, input.PodNamespace, input.Pod)
+	if output.State != "" {
+		output.Message += fmt.Sprintf(" (%s, %d restarts)", output.State, output.Restarts)
+	}
+	if output.Restarts > 0 && !input.Previous {
+		output.Message += "; set previous to read the logs of the instance that last exited"
+	}
+	return output, nil
+}
+
+// podContainerNames returns the names of a pod's init and regular containers.
+func podContainerNames(pod *corev1.Pod) []string {
+	names := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, container := range pod.Spec.InitContainers {
+		names = append(names, container.Name)
+	}
+	for _, container := range pod.Spec.Containers {
+		names = append(names, container.Name)
+	}
+	return names
+}
+
+// podContainerStatus returns the status of a pod's init or regular container.
+func podContainerStatus(pod *corev1.Pod, container string) (corev1.ContainerStatus, bool) {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.Name == container {
+				return status, true
+			}
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// containerState describes a container state, e.g. "waiting: CrashLoopBackOff".
+func containerState(state corev1.ContainerState) string {
+	switch {
+	case state.Running != nil:
+		return "running"
+	case state.Waiting != nil:
+		return "waiting: " + state.Waiting.Reason
+	case state.Terminated != nil:
+		return "terminated: " + state.Terminated.Reason
+	default:
+		return ""
+	}
+}
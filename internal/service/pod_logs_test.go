@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func createTestPod(name, namespace string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func TestPodLogs(t *testing.T) {
+	ctx := context.Background()
+	crashing := createTestPod("calico-node-1", "calico-system", "calico-node")
+	crashing.Spec.InitContainers = []corev1.Container{{Name: "install-cni"}}
+	crashing.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:         "calico-node",
+		RestartCount: 4,
+		State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+	}}
+	workloadClient := newTestWorkloadClient(
+		createTestPod("coredns-1", "kube-system", "coredns"),
+		crashing,
+	)
+
+	t.Run("single container", func(t *testing.T) {
+		output, err := podLogs(ctx, workloadClient, api.GetWorkloadPodLogsInput{PodNamespace: "kube-system", Pod: "coredns-1"})
+		require.NoError(t, err)
+		assert.Equal(t, "coredns", output.Container)
+		assert.Equal(t, defaultPodLogTailLines, output.TailLines)
+		assert.NotEmpty(t, output.Logs)
+	})
+
+	t.Run("crash looping container", func(t *testing.T) {
+		output, err := podLogs(ctx, workloadClient, api.GetWorkloadPodLogsInput{PodNamespace: "calico-system", Pod: "calico-node-1", Container: "calico-node", TailLines: 50})
+		require.NoError(t, err)
+		assert.Equal(t, 4, output.Restarts)
+		assert.Equal(t, "waiting: CrashLoopBackOff", output.State)
+		assert.Equal(t, 50, output.TailLines)
+		assert.Contains(t, output.Message, "set previous")
+	})
+
+	t.Run("init container", func(t *testing.T) {
+		output, err := podLogs(ctx, workloadClient, api.GetWorkloadPodLogsInput{PodNamespace: "calico-system", Pod: "calico-node-1", Container: "install-cni"})
+		require.NoError(t, err)
+		assert.Equal(t, "install-cni", output.Container)
+	})
+
+	t.Run("ambiguous container", func(t *testing.T) {
+		_, err := podLogs(ctx, workloadClient, api.GetWorkloadPodLogsInput{PodNamespace: "calico-system", Pod: "calico-node-1"})
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "install-cni, calico-node")
+	})
+
+	t.Run("unknown container", func(t *testing.T) {
+		_, err := podLogs(ctx, workloadClient, api.GetWorkloadPodLogsInput{PodNamespace: "kube-system", Pod: "coredns-1", Container: "sidecar"})
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+
+	t.Run("pod not found", func(t *testing.T) {
+		_, err := podLogs(ctx, workloadClient, api.GetWorkloadPodLogsInput{PodNamespace: "kube-system", Pod: "missing"})
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
+
+func TestEnhancedClusterService_GetWorkloadPodLogs_Validation(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+
+	tests := []struct {
+		name  string
+		input api.GetWorkloadPodLogsInput
+	}{
+		{name: "missing cluster name", input: api.GetWorkloadPodLogsInput{PodNamespace: "kube-system", Pod: "coredns-1"}},
+		{name: "missing pod", input: api.GetWorkloadPodLogsInput{ClusterName: "test", PodNamespace: "kube-system"}},
+		{name: "missing pod namespace", input: api.GetWorkloadPodLogsInput{ClusterName: "test", Pod: "coredns-1"}},
+		{name: "too many lines", input: api.GetWorkloadPodLogsInput{ClusterName: "test", PodNamespace: "kube-system", Pod: "coredns-1", TailLines: maxPodLogTailLines + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetWorkloadPodLogs(context.Background(), tt.input)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// VariablePreset is a named set of template variables create_cluster callers
+// can start from instead of spelling out every variable.
+type VariablePreset struct {
+	Description string
+	Variables   map[string]interface{}
+}
+
+// SetVariablePresets configures the presets create_cluster accepts.
+func (s *EnhancedClusterService) SetVariablePresets(presets map[string]VariablePreset) {
+	s.presets = presets
+}
+
+// ListPresets returns the configured variable presets, sorted by name.
+func (s *EnhancedClusterService) ListPresets() *api.ListPresetsOutput {
+	output := &api.ListPresetsOutput{Presets: []api.VariablePreset{}}
+	for _, name := range slices.Sorted(maps.Keys(s.presets)) {
+		preset := s.presets[name]
+		output.Presets = append(output.Presets, api.VariablePreset{
+			Name:        name,
+			Description: preset.Description,
+			Variables:   preset.Variables,
+		})
+	}
+	return output
+}
+
+// ApplyPreset expands a preset into its variables with variables layered on
+// top, so callers can override any of them. Objects such as cloudTags are
+// merged key by key; other values, including lists, are replaced. The merged
+// variables are validated as a whole, since preset values are not checked
+// with the caller's.
+func (s *EnhancedClusterService) ApplyPreset(name string, variables map[string]interface{}) (map[string]interface{}, error) {
+	preset, ok := s.presets[name]
+	if !ok {
+		message := fmt.Sprintf("unknown preset %q", name)
+		if len(s.presets) > 0 {
+			message += "; available presets: " + strings.Join(slices.Sorted(maps.Keys(s.presets)), ", ")
+		}
+		return nil, errors.New(errors.CodeInvalidInput, message).WithDetails("field", "preset")
+	}
+
+	merged := mergeVariables(preset.Variables, variables)
+	if err := validation.NewValidator().ValidateClusterVariables(merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// mergeVariables returns base with overrides applied, merging nested objects
+// recursively. Neither argument is modified.
+func mergeVariables(base, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+	for name, value := range base {
+		if object, ok := value.(map[string]interface{}); ok {
+			value = mergeVariables(object, nil)
+		}
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		baseObject, baseIsObject := merged[name].(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		if baseIsObject && isObject {
+			value = mergeVariables(baseObject, object)
+		}
+		merged[name] = value
+	}
+	return merged
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func testVariablePresets() map[string]VariablePreset {
+	return map[string]VariablePreset{
+		"small-dev": {
+			Description: "Single control plane node for development",
+			Variables: map[string]interface{}{
+				"region":    "us-west-2",
+				"osFamily":  "ubuntu",
+				"cloudTags": map[string]interface{}{"environment": "dev", "team": "platform"},
+			},
+		},
+		"prod-ha": {
+			Variables: map[string]interface{}{"region": "us-east-1", "osFamily": "flatcar"},
+		},
+	}
+}
+
+func TestEnhancedClusterService_ApplyPreset(t *testing.T) {
+	tests := []struct {
+		name        string
+		preset      string
+		variables   map[string]interface{}
+		want        map[string]interface{}
+		expectError string
+	}{
+		{
+			name:   "preset only",
+			preset: "prod-ha",
+			want:   map[string]interface{}{"region": "us-east-1", "osFamily": "flatcar"},
+		},
+		{
+			name:      "explicit variables override the preset",
+			preset:    "prod-ha",
+			variables: map[string]interface{}{"region": "eu-west-1", "sshKeyName": "ops"},
+			want:      map[string]interface{}{"region": "eu-west-1", "osFamily": "flatcar", "sshKeyName": "ops"},
+		},
+		{
+			name:      "objects are merged",
+			preset:    "small-dev",
+			variables: map[string]interface{}{"cloudTags": map[string]interface{}{"team": "payments"}},
+			want: map[string]interface{}{
+				"region":    "us-west-2",
+				"osFamily":  "ubuntu",
+				"cloudTags": map[string]interface{}{"environment": "dev", "team": "payments"},
+			},
+		},
+		{
+			name:        "unknown preset",
+			preset:      "large",
+			expectError: `unknown preset "large"; available presets: prod-ha, small-dev`,
+		},
+		{
+			name:        "merged variables are validated",
+			preset:      "prod-ha",
+			variables:   map[string]interface{}{"bastionEnabled": true},
+			expectError: "bastionEnabled requires sshKeyName",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t)
+			presets := testVariablePresets()
+			svc.SetVariablePresets(presets)
+
+			got, err := svc.ApplyPreset(tt.preset, tt.variables)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, testVariablePresets(), presets, "presets must not be modified")
+		})
+	}
+}
+
+func TestEnhancedClusterService_ListPresets(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+	assert.Empty(t, svc.ListPresets().Presets)
+
+	svc.SetVariablePresets(testVariablePresets())
+	output := svc.ListPresets()
+	require.Len(t, output.Presets, 2)
+	assert.Equal(t, "prod-ha", output.Presets[0].Name)
+	assert.Equal(t, "small-dev", output.Presets[1].Name)
+	assert.Equal(t, "Single control plane node for development", output.Presets[1].Description)
+}
+
+func TestEnhancedClusterService_CreateCluster_Preset(t *testing.T) {
+	ctx := context.Background()
+	svc, fakeClient := setupEnhancedTestService(t, createTestClusterClassWithVariables("aws-standard"))
+	svc.SetVariablePresets(testVariablePresets())
+	svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+
+	// The new cluster never reports a phase; don't wait for one
+	createCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	_, err := svc.CreateCluster(createCtx, api.CreateClusterInput{
+		ClusterName:       "dev-cluster",
+		TemplateName:      "aws-standard",
+		KubernetesVersion: "v1.31.0",
+		Preset:            "small-dev",
+		Variables:         map[string]interface{}{"region": "eu-west-1"},
+	})
+	require.NoError(t, err)
+
+	var cluster clusterv1.Cluster
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-cluster"}, &cluster))
+	variables := make(map[string]interface{})
+	for _, variable := range cluster.Spec.Topology.Variables {
+		var value interface{}
+		require.NoError(t, json.Unmarshal(variable.Value.Raw, &value))
+		variables[variable.Name] = value
+	}
+	assert.Equal(t, "eu-west-1", variables["region"])
+	assert.Equal(t, "ubuntu", variables["osFamily"])
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// cniDaemonSets maps the DaemonSets of common CNI plugins to the plugin name.
+var cniDaemonSets = map[string]string{
+	"calico-node":     "calico",
+	"cilium":          "cilium",
+	"aws-node":        "aws-vpc-cni",
+	"kube-flannel-ds": "flannel",
+	"weave-net":       "weave",
+	"antrea-agent":    "antrea",
+	"kube-router":     "kube-router",
+}
+
+// ProbeClusterAPI connects to a workload cluster with its kubeconfig and
+// reports API server latency, the /readyz checks and the health of the core
+// addons: CoreDNS, kube-proxy and the CNI plugin. An unreachable API server is
+// reported in the output rather than as an error.
+func (s *EnhancedClusterService) ProbeClusterAPI(ctx context.Context, input api.ProbeClusterAPIInput) (*api.ProbeClusterAPIOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ProbeClusterAPI").WithCluster(input.ClusterName, "")
+	logger.Debug("Probing workload cluster API")
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	probeCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(probeCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output := probeWorkloadCluster(probeCtx, workloadClient)
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Probed workload cluster API",
+		"reachable", output.Reachable,
+		"latency_ms", output.LatencyMs,
+		"healthy", output.Healthy,
+	)
+	return output, nil
+}
+
+// probeWorkloadCluster checks the API server and core addons of a workload cluster.
+func probeWorkloadCluster(ctx context.Context, workloadClient *kube.WorkloadClient) *api.ProbeClusterAPIOutput {
+	output := &api.ProbeClusterAPIOutput{
+		ReadyzChecks: []api.HealthCheck{},
+		Addons:       []api.AddonHealth{},
+	}
+
+	startedAt := time.Now()
+	version, err := workloadClient.ServerVersion()
+	output.LatencyMs = time.Since(startedAt).Milliseconds()
+	if err != nil {
+		output.Message = fmt.Sprintf("API server is not reachable: %v", err)
+		return output
+	}
+	output.Reachable = true
+	output.ServerVersion = version
+
+	var problems []string
+	readyz, err := workloadClient.Readyz(ctx)
+	output.ReadyzChecks = parseReadyz(readyz)
+	output.Ready = err == nil
+	if err != nil {
+		failed := make([]string, 0, len(output.ReadyzChecks))
+		for _, check := range output.ReadyzChecks {
+			if !check.Healthy {
+				failed = append(failed, check.Name)
+			}
+		}
+		if len(failed) > 0 {
+			problems = append(problems, "failing readyz checks: "+strings.Join(failed, ", "))
+		} else {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	output.Addons = append(output.Addons, deploymentAddon(ctx, workloadClient, "coredns", "kube-system", "coredns"))
+	daemonSets, err := workloadClient.ListDaemonSets(ctx)
+	if err != nil {
+		output.Addons = append(output.Addons,
+			api.AddonHealth{Name: "kube-proxy", Message: err.Error()},
+			api.AddonHealth{Name: "cni", Message: err.Error()},
+		)
+	} else {
+		output.Addons = append(output.Addons, daemonSetAddon(daemonSets, "kube-proxy", "kube-system", "kube-proxy"), cniAddon(daemonSets))
+	}
+	cni := output.Addons[2]
+
+	// Cilium can replace kube-proxy entirely
+	if kubeProxy := &output.Addons[1]; !kubeProxy.Installed && cni.Component == "cilium" {
+		kubeProxy.Healthy = true
+		kubeProxy.Message = "not installed, replaced by cilium"
+	}
+
+	for _, addon := range output.Addons {
+		if !addon.Healthy {
+			problems = append(problems, fmt.Sprintf("%s: %s", addon.Name, addon.Message))
+		}
+	}
+
+	output.Healthy = len(problems) == 0
+	if output.Healthy {
+		output.Message = fmt.Sprintf("API server %s answered in %dms; readyz passed and core addons are healthy", version, output.LatencyMs)
+	} else {
+		output.Message = fmt.Sprintf("API server %s answered in %dms; %s", version, output.LatencyMs, strings.Join(problems, "; "))
+	}
+	return output
+}
+
+// parseReadyz parses the verbose /readyz output, e.g. "[+]ping ok" and
+// "[-]etcd failed: reason withheld".
+func parseReadyz(body string) []api.HealthCheck {
+	checks := []api.HealthCheck{}
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) < 4 || line[0] != '[' || line[2] != ']' {
+			continue
+		}
+		name, message, _ := strings.Cut(line[3:], " ")
+		check := api.HealthCheck{Name: name, Healthy: line[1] == '+'}
+		if !check.Healthy {
+			check.Message = message
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// deploymentAddon reports the health of an addon running as a Deployment.
+func deploymentAddon(ctx context.Context, workloadClient *kube.WorkloadClient, name, namespace, deploymentName string) api.AddonHealth {
+	addon := api.AddonHealth{Name: name, Kind: "Deployment", Namespace: namespace}
+	deployment, err := workloadClient.GetDeployment(ctx, namespace, deploymentName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			addon.Message = "not installed"
+		} else {
+			addon.Message = err.Error()
+		}
+		return addon
+	}
+
+	addon.Installed = true
+	if deployment.Spec.Replicas != nil {
+		addon.Desired = int(*deployment.Spec.Replicas)
+	}
+	addon.Ready = int(deployment.Status.ReadyReplicas)
+	addon.Healthy = addon.Desired > 0 && addon.Ready >= addon.Desired
+	if !addon.Healthy {
+		addon.Message = fmt.Sprintf("%d of %d replicas ready", addon.Ready, addon.Desired)
+	}
+	return addon
+}
+
+// daemonSetAddon reports the health of an addon running as a DaemonSet.
+func daemonSetAddon(daemonSets *appsv1.DaemonSetList, name, namespace, daemonSetName string) api.AddonHealth {
+	for i := range daemonSets.Items {
+		if ds := &daemonSets.Items[i]; ds.Namespace == namespace && ds.Name == daemonSetName {
+			return daemonSetHealth(api.AddonHealth{Name: name, Kind: "DaemonSet", Namespace: namespace}, ds)
+		}
+	}
+	return api.AddonHealth{Name: name, Kind: "DaemonSet", Namespace: namespace, Message: "not installed"}
+}
+
+// detectCNI finds the CNI plugin installed in a workload cluster and reports
+// its health.
+func detectCNI(ctx context.Context, workloadClient *kube.WorkloadClient) (api.AddonHealth, error) {
+	daemonSets, err := workloadClient.ListDaemonSets(ctx)
+	if err != nil {
+		return api.AddonHealth{}, err
+	}
+	return cniAddon(daemonSets), nil
+}
+
+// cniAddon identifies the CNI plugin from the DaemonSets of common plugins and
+// reports its health.
+func cniAddon(daemonSets *appsv1.DaemonSetList) api.AddonHealth {
+	for i := range daemonSets.Items {
+		ds := &daemonSets.Items[i]
+		if component, ok := cniDaemonSets[ds.Name]; ok {
+			return daemonSetHealth(api.AddonHealth{
+				Name:      "cni",
+				Component: component,
+				Kind:      "DaemonSet",
+				Namespace: ds.Namespace,
+			}, ds)
+		}
+	}
+	return api.AddonHealth{Name: "cni", Message: "no CNI plugin installed - nodes stay NotReady until one is, see install_cni"}
+}
+
+// daemonSetHealth fills in the health of an addon from its DaemonSet.
+func daemonSetHealth(addon api.AddonHealth, ds *appsv1.DaemonSet) api.AddonHealth {
+	addon.Installed = true
+	addon.Desired = int(ds.Status.DesiredNumberScheduled)
+	addon.Ready = int(ds.Status.NumberReady)
+	addon.Healthy = addon.Desired > 0 && addon.Ready >= addon.Desired
+	if !addon.Healthy {
+		addon.Message = fmt.Sprintf("%d of %d pods ready", addon.Ready, addon.Desired)
+	}
+	return addon
+}
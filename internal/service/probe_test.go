@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func createTestDaemonSet(name, namespace string, desired, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: desired,
+			NumberReady:            ready,
+		},
+	}
+}
+
+func createTestAddonDeployment(name, namespace string, replicas, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+	}
+}
+
+func newTestWorkloadClient(objects ...runtime.Object) *kube.WorkloadClient {
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.31.2"}
+	return kube.NewWorkloadClient(clientset)
+}
+
+func TestProbeWorkloadCluster(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("healthy addons", func(t *testing.T) {
+		output := probeWorkloadCluster(ctx, newTestWorkloadClient(
+			createTestAddonDeployment("coredns", "kube-system", 2, 2),
+			createTestDaemonSet("kube-proxy", "kube-system", 3, 3),
+			createTestDaemonSet("calico-node", "calico-system", 3, 3),
+		))
+
+		assert.True(t, output.Reachable)
+		assert.Equal(t, "v1.31.2", output.ServerVersion)
+		require.Len(t, output.Addons, 3)
+		for _, addon := range output.Addons {
+			assert.True(t, addon.Healthy, addon.Name)
+		}
+		assert.Equal(t, "calico", output.Addons[2].Component)
+		assert.Equal(t, "calico-system", output.Addons[2].Namespace)
+	})
+
+	t.Run("missing CNI and degraded CoreDNS", func(t *testing.T) {
+		output := probeWorkloadCluster(ctx, newTestWorkloadClient(
+			createTestAddonDeployment("coredns", "kube-system", 2, 0),
+			createTestDaemonSet("kube-proxy", "kube-system", 3, 3),
+		))
+
+		assert.False(t, output.Healthy)
+		assert.Equal(t, "0 of 2 replicas ready", output.Addons[0].Message)
+		assert.False(t, output.Addons[2].Installed)
+		assert.Contains(t, output.Message, "no CNI plugin installed")
+	})
+
+	t.Run("cilium replaces kube-proxy", func(t *testing.T) {
+		output := probeWorkloadCluster(ctx, newTestWorkloadClient(
+			createTestDaemonSet("cilium", "kube-system", 3, 3),
+		))
+
+		kubeProxy := output.Addons[1]
+		assert.False(t, kubeProxy.Installed)
+		assert.True(t, kubeProxy.Healthy)
+		assert.False(t, output.Addons[0].Installed)
+	})
+}
+
+func TestParseReadyz(t *testing.T) {
+	body := `[+]ping ok
+[+]log ok
+[-]etcd failed: reason withheld
+[+]informer-sync ok
+readyz check failed`
+
+	assert.Equal(t, []api.HealthCheck{
+		{Name: "ping", Healthy: true},
+		{Name: "log", Healthy: true},
+		{Name: "etcd", Healthy: false, Message: "failed: reason withheld"},
+		{Name: "informer-sync", Healthy: true},
+	}, parseReadyz(body))
+	assert.Empty(t, parseReadyz(""))
+}
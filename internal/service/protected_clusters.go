@@ -0,0 +1,11 @@
+package service
+
+import (
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// SetProtectedClusterNames configures the names of clusters that may neither
+// be created nor deleted, e.g. the management cluster's.
+func (s *EnhancedClusterService) SetProtectedClusterNames(names validation.ProtectedClusterNames) {
+	s.protectedClusterNames = names
+}
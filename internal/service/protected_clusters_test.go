@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+func TestEnhancedClusterService_ProtectedClusterNames(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		call        func(svc *EnhancedClusterService) error
+		expectError string
+	}{
+		{
+			name: "create_cluster",
+			call: func(svc *EnhancedClusterService) error {
+				_, err := svc.CreateCluster(ctx, api.CreateClusterInput{
+					ClusterName:       "prod-eu",
+					TemplateName:      "aws-standard",
+					KubernetesVersion: "v1.31.0",
+					DryRun:            true,
+				})
+				return err
+			},
+			expectError: "cluster name 'prod-eu' is protected (prod-*); creation is not permitted",
+		},
+		{
+			name: "restore_cluster",
+			call: func(svc *EnhancedClusterService) error {
+				_, err := svc.RestoreCluster(ctx, api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "dev-a"})
+				return err
+			},
+			expectError: "cluster name 'prod-restored' is protected (prod-*); creation is not permitted",
+		},
+		{
+			name: "delete_cluster",
+			call: func(svc *EnhancedClusterService) error {
+				_, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "management"})
+				return err
+			},
+			expectError: "cluster name 'management' is protected (management); deletion is not permitted",
+		},
+		{
+			name: "force_delete_cluster",
+			call: func(svc *EnhancedClusterService) error {
+				_, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{ClusterName: "management"})
+				return err
+			},
+			expectError: "deletion is not permitted",
+		},
+		{
+			name: "unprotected name",
+			call: func(svc *EnhancedClusterService) error {
+				_, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "dev-a"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, fakeClient := setupEnhancedTestService(t,
+				createTestClusterClassWithVariables("aws-standard"),
+				createTestCluster("management", testNamespace, clusterv1.ClusterPhaseProvisioned),
+				createTestCluster("dev-a", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			)
+			svc.SetProtectedClusterNames(validation.ProtectedClusterNames{"management", "prod-*"})
+
+			err := tt.call(svc)
+			if tt.expectError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+			assert.Contains(t, err.Error(), tt.expectError)
+
+			var cluster clusterv1.Cluster
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "management"}, &cluster))
+			assert.Nil(t, cluster.DeletionTimestamp)
+		})
+	}
+}
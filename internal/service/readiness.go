@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// Checks the readiness gate can require of a Provisioned cluster on top of a
+// reachable API server.
+const (
+	ReadinessCheckWorkers = "workers"
+	ReadinessCheckCNI     = "cni"
+)
+
+// ReadinessGate configures the checks a Provisioned cluster must pass before
+// it is reported Ready. Until then it is reported Provisioning, with a
+// warning naming the checks that failed, so Ready means the cluster can run
+// workloads rather than only that Cluster API finished provisioning it.
+type ReadinessGate struct {
+	// Enabled turns the gate on. The workload API server must answer for
+	// a cluster to pass.
+	Enabled bool
+
+	// Workers requires at least one Ready worker node.
+	Workers bool
+
+	// CNI requires a healthy CNI plugin.
+	CNI bool
+
+	// CacheTTL is how long the result for a cluster is reused, so listing
+	// clusters does not connect to every workload cluster each time.
+	CacheTTL time.Duration
+}
+
+// readinessGateTimeout bounds the checks of a single cluster, so clusters
+// whose API server does not respond do not hold up a listing.
+const readinessGateTimeout = 10 * time.Second
+
+// readinessResult is the outcome of the readiness gate for a cluster.
+type readinessResult struct {
+	problems  []string
+	checkedAt time.Time
+}
+
+// readinessCache holds the readiness gate results of recently checked
+// clusters.
+type readinessCache struct {
+	mu      sync.Mutex
+	results map[types.NamespacedName]readinessResult
+}
+
+// SetReadinessGate configures the checks a Provisioned cluster must pass to
+// be reported Ready.
+func (s *EnhancedClusterService) SetReadinessGate(gate ReadinessGate) {
+	s.readinessGate = gate
+	s.readiness = &readinessCache{results: map[types.NamespacedName]readinessResult{}}
+}
+
+// clusterStatus reports the status of a cluster. With the readiness gate
+// enabled, a Provisioned cluster failing it is reported Provisioning, with a
+// warning describing why.
+func (s *EnhancedClusterService) clusterStatus(ctx context.Context, cluster *clusterv1.Cluster) (api.ClusterStatus, []string) {
+	status := api.ClusterStatusFromPhase(cluster.Status.Phase)
+	if status != api.ClusterStatusReady || !s.readinessGate.Enabled || s.readiness == nil {
+		return status, nil
+	}
+
+	problems := s.readinessProblems(ctx, cluster)
+	if len(problems) == 0 {
+		return status, nil
+	}
+	return api.ClusterStatusProvisioning, []string{"not Ready yet: " + strings.Join(problems, "; ")}
+}
+
+// readinessProblems runs the readiness gate for a cluster, or returns its
+// cached result.
+func (s *EnhancedClusterService) readinessProblems(ctx context.Context, cluster *clusterv1.Cluster) []string {
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	now := time.Now()
+
+	s.readiness.mu.Lock()
+	cached, ok := s.readiness.results[key]
+	s.readiness.mu.Unlock()
+	if ok && now.Sub(cached.checkedAt) < s.readinessGate.CacheTTL {
+		return cached.problems
+	}
+
+	gateCtx, cancel := context.WithTimeout(kube.ContextWithNamespace(ctx, cluster.Namespace), readinessGateTimeout)
+	defer cancel()
+
+	var problems []string
+	workloadClient, err := s.newWorkloadClient(gateCtx, cluster.Name)
+	if err != nil {
+		problems = []string{fmt.Sprintf("workload API server is not reachable: %s", err.Error())}
+	} else {
+		problems = checkReadiness(gateCtx, workloadClient, s.readinessGate)
+	}
+	if ctx.Err() != nil {
+		// The caller gave up; do not remember a result the checks could
+		// not finish.
+		return problems
+	}
+
+	s.readiness.mu.Lock()
+	s.readiness.results[key] = readinessResult{problems: problems, checkedAt: now}
+	for other, result := range s.readiness.results {
+		if now.Sub(result.checkedAt) >= s.readinessGate.CacheTTL {
+			delete(s.readiness.results, other)
+		}
+	}
+	s.readiness.mu.Unlock()
+
+	if len(problems) > 0 {
+		s.logger.WithContext(ctx).WithOperation("ReadinessGate").WithCluster(cluster.Name, cluster.Namespace).
+			Debug("Cluster failed the readiness gate", "problems", problems)
+	}
+	return problems
+}
+
+// checkReadiness runs the checks of the readiness gate against a workload
+// cluster and returns the ones that failed.
+func checkReadiness(ctx context.Context, workloadClient *kube.WorkloadClient, gate ReadinessGate) []string {
+	if _, err := workloadClient.ServerVersion(); err != nil {
+		return []string{fmt.Sprintf("workload API server is not reachable: %v", err)}
+	}
+
+	var problems []string
+	if gate.Workers {
+		nodes, err := workloadClient.ListNodes(ctx)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("failed to list nodes: %v", err))
+		} else {
+			readyWorkers := 0
+			for i := range nodes.Items {
+				node := &nodes.Items[i]
+				if nodeReady(node) && !isControlPlaneNode(node.Labels) {
+					readyWorkers++
+				}
+			}
+			if readyWorkers == 0 {
+				problems = append(problems, "no worker node is Ready")
+			}
+		}
+	}
+	if gate.CNI {
+		cni, err := detectCNI(ctx, workloadClient)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("failed to check the CNI plugin: %v", err))
+		case !cni.Healthy:
+			problems = append(problems, "cni: "+cni.Message)
+		}
+	}
+	return problems
+}
+
+// isControlPlaneNode reports whether node labels mark a control plane node.
+func isControlPlaneNode(labels map[string]string) bool {
+	_, controlPlane := labels["node-role.kubernetes.io/control-plane"]
+	_, master := labels["node-role.kubernetes.io/master"]
+	return controlPlane || master
+}
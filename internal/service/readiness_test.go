@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func TestCheckReadiness(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: ready},
+			}},
+		}
+	}
+	controlPlane := map[string]string{"node-role.kubernetes.io/control-plane": ""}
+	gate := ReadinessGate{Enabled: true, Workers: true, CNI: true}
+
+	tests := []struct {
+		name    string
+		gate    ReadinessGate
+		objects []runtime.Object
+		want    []string
+	}{
+		{
+			name: "ready",
+			gate: gate,
+			objects: []runtime.Object{
+				node("cp-0", corev1.ConditionTrue, controlPlane),
+				node("worker-0", corev1.ConditionTrue, nil),
+				createTestDaemonSet("cilium", "kube-system", 2, 2),
+			},
+		},
+		{
+			name: "only the control plane is ready",
+			gate: gate,
+			objects: []runtime.Object{
+				node("cp-0", corev1.ConditionTrue, controlPlane),
+				node("worker-0", corev1.ConditionFalse, nil),
+				createTestDaemonSet("cilium", "kube-system", 2, 1),
+			},
+			want: []string{"no worker node is Ready", "cni: 1 of 2 pods ready"},
+		},
+		{
+			name: "no CNI plugin",
+			gate: gate,
+			objects: []runtime.Object{
+				node("worker-0", corev1.ConditionTrue, nil),
+			},
+			want: []string{"cni: no CNI plugin installed - nodes stay NotReady until one is, see install_cni"},
+		},
+		{
+			name: "API server only",
+			gate: ReadinessGate{Enabled: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := checkReadiness(context.Background(), newTestWorkloadClient(tt.objects...), tt.gate)
+			assert.Equal(t, tt.want, problems)
+		})
+	}
+}
+
+func TestEnhancedClusterService_ReadinessGate(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t,
+		createTestCluster("provisioned", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		createTestCluster("provisioning", testNamespace, clusterv1.ClusterPhaseProvisioning),
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "provisioned"})
+		require.NoError(t, err)
+		assert.Equal(t, api.ClusterStatusReady, output.Cluster.Status)
+	})
+
+	svc.SetReadinessGate(ReadinessGate{Enabled: true, Workers: true, CacheTTL: time.Minute})
+
+	t.Run("unreachable cluster is not Ready", func(t *testing.T) {
+		// No kubeconfig Secret, so the API server cannot be reached
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "provisioned"})
+		require.NoError(t, err)
+		assert.Equal(t, api.ClusterStatusProvisioning, output.Cluster.Status)
+		require.NotEmpty(t, output.Warnings)
+		assert.Contains(t, output.Warnings[len(output.Warnings)-1], "not Ready yet: workload API server is not reachable")
+	})
+
+	t.Run("list clusters", func(t *testing.T) {
+		output, err := svc.ListClusters(ctx)
+		require.NoError(t, err)
+
+		statuses := map[string]api.ClusterStatus{}
+		for _, cluster := range output.Clusters {
+			statuses[cluster.Name] = cluster.Status
+		}
+		assert.Equal(t, map[string]api.ClusterStatus{
+			"provisioned":  api.ClusterStatusProvisioning,
+			"provisioning": api.ClusterStatusProvisioning,
+		}, statuses)
+	})
+
+	t.Run("results are cached", func(t *testing.T) {
+		svc.readiness.mu.Lock()
+		svc.readiness.results[types.NamespacedName{Namespace: testNamespace, Name: "provisioned"}] = readinessResult{checkedAt: time.Now()}
+		svc.readiness.mu.Unlock()
+
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "provisioned"})
+		require.NoError(t, err)
+		assert.Equal(t, api.ClusterStatusReady, output.Cluster.Status)
+	})
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// SetRegionPolicy restricts the regions clusters may be created in.
+func (s *EnhancedClusterService) SetRegionPolicy(policy *validation.RegionPolicy) {
+	s.regionPolicy = policy
+}
+
+// checkRegionPolicy checks the region of a new cluster against the region
+// policy for its namespace and the identity creating it.
+func (s *EnhancedClusterService) checkRegionPolicy(ctx context.Context, region string) error {
+	if s.regionPolicy == nil {
+		return nil
+	}
+	var name string
+	var groups []string
+	if identity := auth.IdentityFromContext(ctx); identity != nil {
+		name, groups = identity.Name, identity.Groups
+	}
+	return s.regionPolicy.ValidateRegion(region, s.kubeClient.Namespace(ctx), name, groups)
+}
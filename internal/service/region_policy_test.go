@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+func TestEnhancedClusterService_CheckRegionPolicy(t *testing.T) {
+	policy := &validation.RegionPolicy{
+		Namespaces: map[string]validation.RegionRule{testNamespace: {Allowed: []string{"eu-*"}}},
+		Identities: map[string]validation.RegionRule{"eu-agent": {Allowed: []string{"eu-central-1"}}},
+	}
+
+	tests := []struct {
+		name        string
+		identity    *auth.Identity
+		region      string
+		expectError string
+	}{
+		{name: "allowed in namespace", region: "eu-west-1"},
+		{name: "outside namespace allowlist", region: "us-east-1", expectError: "region us-east-1 is not permitted"},
+		{name: "allowed for identity", identity: &auth.Identity{Name: "eu-agent"}, region: "eu-central-1"},
+		{name: "outside identity allowlist", identity: &auth.Identity{Name: "eu-agent"}, region: "eu-west-1", expectError: "identity 'eu-agent'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := setupEnhancedTestService(t)
+			svc.SetRegionPolicy(policy)
+
+			ctx := context.Background()
+			if tt.identity != nil {
+				ctx = auth.ContextWithIdentity(ctx, tt.identity)
+			}
+
+			err := svc.checkRegionPolicy(ctx, tt.region)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("create_cluster applies the policy to default variables", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t, createTestClusterClassWithVariables("aws-standard"))
+		svc.SetRegionPolicy(policy)
+		svc.SetVariableDefaults([]VariableDefaults{{Variables: map[string]interface{}{"region": "us-west-2"}}}, nil)
+
+		_, err := svc.CreateCluster(context.Background(), api.CreateClusterInput{
+			ClusterName:       "new-cluster",
+			TemplateName:      "aws-standard",
+			KubernetesVersion: "v1.31.0",
+			Variables:         map[string]interface{}{"osFamily": "ubuntu"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "region us-west-2 is not permitted by the region policy for namespace 'default'")
+
+		var clusters clusterv1.ClusterList
+		require.NoError(t, fakeClient.List(context.Background(), &clusters))
+		assert.Empty(t, clusters.Items)
+	})
+}
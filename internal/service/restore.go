@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/snapshot"
+)
+
+const (
+	// RestoreClusterKind is the kind of async operation restores run as.
+	RestoreClusterKind = "restore_cluster"
+
+	// Stages of a restore.
+	restoreStageProvision = "provision"
+	restoreStageVelero    = "velero"
+	restoreStageWorkloads = "restore-workloads"
+
+	// restoredFromAnnotation records the cluster and snapshot a cluster was restored from.
+	restoredFromAnnotation = "capi-mcp.io/restored-from"
+
+	// defaultVeleroNamespace is where Velero is installed unless told otherwise.
+	defaultVeleroNamespace = "velero"
+
+	// veleroWaitTimeout bounds how long a restored cluster may take to run
+	// Velero and sync the backup from its storage location.
+	veleroWaitTimeout = 15 * time.Minute
+
+	// veleroRestoreTimeout bounds how long the Velero restore may run.
+	veleroRestoreTimeout = time.Hour
+)
+
+var (
+	veleroBackupsGVR  = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backups"}
+	veleroRestoresGVR = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "restores"}
+)
+
+// RestoreCluster recreates a lost cluster as a new cluster from a stored
+// snapshot of its spec, then restores its workloads from a Velero backup. The
+// restore runs in the background as an async operation whose stages are
+// reported by get_operation_status: provisioning the cluster, waiting for
+// Velero in it to see the backup, and the Velero restore itself.
+func (s *EnhancedClusterService) RestoreCluster(ctx context.Context, input api.RestoreClusterInput) (*api.RestoreClusterOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RestoreCluster").WithCluster(input.ClusterName, "")
+	logger.Info("Restoring cluster", "source_cluster", input.SourceCluster, "snapshot", input.Snapshot, "velero_backup", input.VeleroBackup)
+
+	if input.ClusterName == "" || input.SourceCluster == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name and source cluster are required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if !isValidClusterName(input.ClusterName) {
+		err := errors.New(errors.CodeInvalidInput,
+			"cluster name must be lowercase alphanumeric characters or '-', and must start and end with an alphanumeric character")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if err := s.protectedClusterNames.ValidateClusterName("creation", input.ClusterName); err != nil {
+		logger.WithError(err).Error("Cluster name is protected")
+		return nil, err
+	}
+	if input.VeleroBackup == "" && len(input.IncludedNamespaces) > 0 {
+		err := errors.New(errors.CodeInvalidInput, "included namespaces require a Velero backup")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.VeleroNamespace == "" {
+		input.VeleroNamespace = defaultVeleroNamespace
+	}
+	if s.snapshots == nil {
+		err := errors.New(errors.CodeUnavailable, "cluster snapshots are not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.asyncOperations == nil {
+		err := errors.New(errors.CodeUnavailable, "async operations are not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	restoreCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	snapshots, err := s.snapshots.List(restoreCtx, s.kubeClient.Namespace(restoreCtx), input.SourceCluster)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list snapshots")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list cluster snapshots")
+	}
+	if len(snapshots) == 0 {
+		err := errors.New(errors.CodeNotFound,
+			fmt.Sprintf("no snapshot of cluster '%s'; snapshots are saved by diff_cluster_state", input.SourceCluster))
+		logger.WithError(err).Error("No snapshot to restore")
+		return nil, err
+	}
+	snap := snapshots[0]
+	if input.Snapshot != "" {
+		if snap, err = resolveSnapshot(snapshots, input.SourceCluster, input.Snapshot); err != nil {
+			logger.WithError(err).Error("Failed to resolve snapshot", "ref", input.Snapshot)
+			return nil, err
+		}
+	}
+
+	cluster, err := restoredCluster(snap, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Snapshot cannot be restored", "snapshot_id", snap.ID)
+		return nil, err
+	}
+	if err := s.checkRegionPolicy(ctx, clusterRegion(cluster)); err != nil {
+		logger.WithError(err).Error("Region not permitted", "region", clusterRegion(cluster))
+		return nil, err
+	}
+	if _, err := s.kubeClient.GetClusterClass(restoreCtx, cluster.Spec.Topology.Class); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodePreconditionFailed,
+				fmt.Sprintf("cluster template '%s' of the snapshot no longer exists", cluster.Spec.Topology.Class))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+	}
+	if _, err := s.kubeClient.GetClusterByName(restoreCtx, input.ClusterName); err == nil {
+		err := errors.New(errors.CodeAlreadyExists, fmt.Sprintf("cluster '%s' already exists", input.ClusterName))
+		logger.WithError(err).Error("Cluster already exists")
+		return nil, err
+	}
+
+	if err := s.kubeClient.CreateCluster(restoreCtx, cluster); err != nil {
+		logger.WithError(err).Error("Failed to create cluster resource")
+		if apierrors.IsAlreadyExists(err) {
+			return nil, errors.New(errors.CodeAlreadyExists, fmt.Sprintf("cluster '%s' already exists", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to create cluster")
+	}
+
+	run, err := s.startAsyncOperation(restoreCtx, RestoreClusterKind, input.ClusterName, map[string]string{
+		"sourceCluster": input.SourceCluster,
+		"snapshotId":    snap.ID,
+		"veleroBackup":  input.VeleroBackup,
+	}, restoreStageProvision, restoreStageVelero, restoreStageWorkloads)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start restore operation")
+		return nil, err
+	}
+	run.begin(restoreCtx, restoreStageProvision)
+
+	s.goBackground(ctx, func(ctx context.Context) { s.runRestore(ctx, run, input) })
+
+	output := &api.RestoreClusterOutput{
+		ClusterName:     input.ClusterName,
+		SourceCluster:   input.SourceCluster,
+		SnapshotID:      snap.ID,
+		SnapshotTakenAt: snap.TakenAt.UTC().Format(time.RFC3339),
+		OperationID:     run.op.ID,
+		Stages:          toAPIStages(run.op.Stages),
+	}
+	output.Message = fmt.Sprintf("Restoring cluster '%s' from the snapshot of '%s' taken at %s; poll get_operation_status with operation %s",
+		input.ClusterName, input.SourceCluster, output.SnapshotTakenAt, run.op.ID)
+	if input.VeleroBackup == "" {
+		output.Message += "; workloads will not be restored without a Velero backup"
+	}
+
+	logger.Info("Started cluster restore", "operation_id", run.op.ID, "snapshot_id", snap.ID)
+	return output, nil
+}
+
+// runRestore carries out the stages of a restore after the cluster was created.
+func (s *EnhancedClusterService) runRestore(ctx context.Context, run *asyncRun, input api.RestoreClusterInput) {
+	logger := s.logger.WithContext(ctx).WithOperation("RestoreCluster").WithCluster(input.ClusterName, "")
+
+	timeout := s.lifecycleTimeout
+	if timeout <= 0 {
+		timeout = defaultLifecycleTimeout
+	}
+	provisionCtx, cancel := context.WithTimeout(ctx, timeout)
+	cluster, err := s.waitForCluster(provisionCtx, input.ClusterName, func(cluster *clusterv1.Cluster) bool {
+		if cluster == nil {
+			return true
+		}
+		phase := clusterv1.ClusterPhase(cluster.Status.Phase)
+		return phase == clusterv1.ClusterPhaseProvisioned || phase == clusterv1.ClusterPhaseFailed
+	})
+	cancel()
+	switch {
+	case err != nil:
+		err = errors.Wrap(err, errors.CodeTimeout, fmt.Sprintf("cluster was not provisioned within %s", timeout))
+	case cluster == nil:
+		err = errors.New(errors.CodeNotFound, "cluster was deleted while being provisioned")
+	case cluster.Status.Phase == string(clusterv1.ClusterPhaseFailed):
+		message := "cluster provisioning failed"
+		if cluster.Status.FailureMessage != nil {
+			message += ": " + *cluster.Status.FailureMessage
+		}
+		err = errors.New(errors.CodeProviderError, message)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Restored cluster was not provisioned")
+		run.fail(ctx, restoreStageProvision, err)
+		return
+	}
+	run.complete(ctx, restoreStageProvision, "cluster provisioned")
+
+	if input.VeleroBackup == "" {
+		run.skip(ctx, restoreStageVelero, "no Velero backup given")
+		run.skip(ctx, restoreStageWorkloads, "no Velero backup given")
+		run.succeed(ctx)
+		logger.Info("Restored cluster without workloads", "operation_id", run.op.ID)
+		return
+	}
+
+	run.begin(ctx, restoreStageVelero)
+	veleroCtx, cancel := context.WithTimeout(ctx, veleroWaitTimeout)
+	defer cancel()
+	workloadClient, err := s.waitForWorkloadClient(veleroCtx, input.ClusterName)
+	if err == nil {
+		err = waitForVeleroBackup(veleroCtx, workloadClient, input.VeleroNamespace, input.VeleroBackup, s.pollInterval, func(message string) {
+			run.progress(ctx, restoreStageVelero, message)
+		})
+	}
+	if err != nil {
+		logger.WithError(err).Error("Velero is not ready to restore")
+		run.fail(ctx, restoreStageVelero, err)
+		return
+	}
+	run.complete(ctx, restoreStageVelero, fmt.Sprintf("backup '%s' is available", input.VeleroBackup))
+
+	run.begin(ctx, restoreStageWorkloads)
+	workloadsCtx, cancel := context.WithTimeout(ctx, veleroRestoreTimeout)
+	defer cancel()
+	message, err := runVeleroRestore(workloadsCtx, workloadClient, input, s.pollInterval, func(message string) {
+		run.progress(ctx, restoreStageWorkloads, message)
+	})
+	if err != nil {
+		logger.WithError(err).Error("Velero restore failed")
+		run.fail(ctx, restoreStageWorkloads, err)
+		return
+	}
+	run.complete(ctx, restoreStageWorkloads, message)
+	run.succeed(ctx)
+	logger.Info("Restored cluster", "operation_id", run.op.ID)
+}
+
+// restoredCluster builds a new cluster from the Cluster spec in a snapshot.
+// References to the source cluster's control plane and infrastructure and
+// its API endpoint are dropped so the topology controller creates new ones.
+func restoredCluster(snap snapshot.Snapshot, clusterName string) (*clusterv1.Cluster, error) {
+	spec, ok := snap.Objects["Cluster/"+snap.ClusterName]
+	if !ok {
+		return nil, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("snapshot %s holds no Cluster spec of '%s'", snap.ID, snap.ClusterName))
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to encode snapshot spec")
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+			Labels: map[string]string{
+				"cluster.x-k8s.io/cluster-name": clusterName,
+			},
+			Annotations: map[string]string{
+				restoredFromAnnotation: snap.ClusterName + "@" + snap.ID,
+			},
+		},
+	}
+	if err := json.Unmarshal(data, &cluster.Spec); err != nil {
+		return nil, errors.Wrap(err, errors.CodePreconditionFailed, fmt.Sprintf("snapshot %s holds an invalid Cluster spec", snap.ID))
+	}
+	if cluster.Spec.Topology == nil {
+		return nil, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' was not created from a cluster template; only template-based clusters can be restored", snap.ClusterName))
+	}
+
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{}
+	cluster.Spec.ControlPlaneRef = nil
+	cluster.Spec.InfrastructureRef = nil
+	cluster.Spec.Paused = false
+	return cluster, nil
+}
+
+// waitForWorkloadClient retries connecting to a newly provisioned cluster
+// until its kubeconfig is available.
+func (s *EnhancedClusterService) waitForWorkloadClient(ctx context.Context, clusterName string) (*kube.WorkloadClient, error) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		workloadClient, err := s.newWorkloadClient(ctx, clusterName)
+		if err == nil {
+			return workloadClient, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(err, errors.CodeTimeout, "workload cluster did not become reachable")
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForVeleroBackup waits for Velero in the workload cluster to have synced
+// a completed backup from its backup storage location, which requires Velero
+// to be installed with the location of the source cluster's backups.
+func waitForVeleroBackup(ctx context.Context, workloadClient *kube.WorkloadClient, namespace, backup string, interval time.Duration, progress func(message string)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	reported := ""
+	for {
+		message := ""
+		obj, err := workloadClient.GetResource(ctx, veleroBackupsGVR, namespace, backup)
+		switch {
+		case err == nil:
+			phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+			if phase == "Completed" {
+				return nil
+			}
+			if phase == "Failed" || phase == "PartiallyFailed" || phase == "FailedValidation" {
+				return errors.New(errors.CodePreconditionFailed, fmt.Sprintf("Velero backup '%s' is %s and cannot be restored", backup, phase))
+			}
+			message = fmt.Sprintf("waiting for Velero backup '%s' to complete (phase %s)", backup, phase)
+		case apierrors.IsNotFound(err):
+			message = fmt.Sprintf("waiting for Velero in namespace '%s' to sync backup '%s' from its storage location", namespace, backup)
+		default:
+			message = "waiting for Velero to be installed in the cluster"
+		}
+		if message != reported {
+			progress(message)
+			reported = message
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.New(errors.CodeTimeout, message+"; install Velero with the backup storage location of the source cluster")
+		case <-ticker.C:
+		}
+	}
+}
+
+// runVeleroRestore creates a Velero Restore of a backup and waits for it to
+// finish, describing its outcome.
+func runVeleroRestore(ctx context.Context, workloadClient *kube.WorkloadClient, input api.RestoreClusterInput, interval time.Duration, progress func(message string)) (string, error) {
+	spec := map[string]interface{}{"backupName": input.VeleroBackup}
+	if len(input.IncludedNamespaces) > 0 {
+		namespaces := make([]interface{}, 0, len(input.IncludedNamespaces))
+		for _, namespace := range input.IncludedNamespaces {
+			namespaces = append(namespaces, namespace)
+		}
+		spec["includedNamespaces"] = namespaces
+	}
+	restore := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       "Restore",
+		"metadata": map[string]interface{}{
+			"name":      input.VeleroBackup + "-" + uuid.NewString()[:8],
+			"namespace": input.VeleroNamespace,
+			"labels":    map[string]interface{}{"app.kubernetes.io/managed-by": "capi-mcp-server"},
+		},
+		"spec": spec,
+	}}
+
+	created, err := workloadClient.CreateResource(ctx, veleroRestoresGVR, input.VeleroNamespace, restore)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodeWorkloadCluster, "failed to create Velero restore")
+	}
+	name := created.GetName()
+	progress(fmt.Sprintf("Velero restore '%s' started", name))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		obj, err := workloadClient.GetResource(ctx, veleroRestoresGVR, input.VeleroNamespace, name)
+		if err == nil {
+			phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+			warnings, _, _ := unstructured.NestedInt64(obj.Object, "status", "warnings")
+			restoreErrors, _, _ := unstructured.NestedInt64(obj.Object, "status", "errors")
+			switch phase {
+			case "Completed":
+				return fmt.Sprintf("Velero restore '%s' completed with %d warning(s)", name, warnings), nil
+			case "PartiallyFailed", "Failed", "FailedValidation":
+				reason := fmt.Sprintf("Velero restore '%s' %s with %d error(s) and %d warning(s)",
+					name, strings.ToLower(phase), restoreErrors, warnings)
+				if validationErrors, _, _ := unstructured.NestedStringSlice(obj.Object, "status", "validationErrors"); len(validationErrors) > 0 {
+					reason += ": " + strings.Join(validationErrors, "; ")
+				}
+				return "", errors.New(errors.CodeWorkloadCluster, reason+"; see velero restore describe "+name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", errors.New(errors.CodeTimeout, fmt.Sprintf("Velero restore '%s' did not finish in time", name))
+		case <-ticker.C:
+		}
+	}
+}
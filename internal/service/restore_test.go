@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/async"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/snapshot"
+)
+
+func TestEnhancedClusterService_RestoreCluster(t *testing.T) {
+	ctx := context.Background()
+
+	source := createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	source.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "prod.example.com", Port: 6443}
+	source.Spec.InfrastructureRef = nil
+
+	setup := func(t *testing.T) (*EnhancedClusterService, client.Client, string) {
+		svc, fakeClient := setupEnhancedTestService(t, source, createTestClusterClass("aws-cluster-class"))
+		svc.SetSnapshotStore(snapshot.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetAsyncOperationStore(async.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		svc.SetWaitStrategy(WaitStrategyPoll, 10*time.Millisecond)
+
+		// Reading the current state saves a snapshot
+		baseline, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "prod"})
+		require.NoError(t, err)
+		return svc, fakeClient, baseline.To.SnapshotID
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input api.RestoreClusterInput
+			code  errors.ErrorCode
+		}{
+			{name: "missing source", input: api.RestoreClusterInput{ClusterName: "prod-restored"}, code: errors.CodeInvalidInput},
+			{name: "invalid name", input: api.RestoreClusterInput{ClusterName: "Prod_Restored", SourceCluster: "prod"}, code: errors.CodeInvalidInput},
+			{name: "namespaces without backup", input: api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "prod", IncludedNamespaces: []string{"app"}}, code: errors.CodeInvalidInput},
+			{name: "no snapshots", input: api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "staging"}, code: errors.CodeNotFound},
+			{name: "unknown snapshot", input: api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "prod", Snapshot: "missing"}, code: errors.CodeNotFound},
+			{name: "cluster exists", input: api.RestoreClusterInput{ClusterName: "prod", SourceCluster: "prod"}, code: errors.CodeAlreadyExists},
+		}
+
+		svc, _, _ := setup(t)
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.RestoreCluster(ctx, tt.input)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			})
+		}
+	})
+
+	t.Run("async operations not enabled", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t, source)
+		svc.SetSnapshotStore(snapshot.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+
+		_, err := svc.RestoreCluster(ctx, api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "prod"})
+		assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+	})
+
+	t.Run("cluster template removed", func(t *testing.T) {
+		svc, fakeClient, _ := setup(t)
+		require.NoError(t, fakeClient.Delete(ctx, createTestClusterClass("aws-cluster-class")))
+
+		_, err := svc.RestoreCluster(ctx, api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "prod"})
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+	})
+
+	t.Run("recreates the cluster without a backup", func(t *testing.T) {
+		svc, fakeClient, snapshotID := setup(t)
+
+		output, err := svc.RestoreCluster(ctx, api.RestoreClusterInput{ClusterName: "prod-restored", SourceCluster: "prod"})
+		require.NoError(t, err)
+		assert.Equal(t, snapshotID, output.SnapshotID)
+		assert.NotEmpty(t, output.OperationID)
+		require.Len(t, output.Stages, 3)
+		assert.Equal(t, restoreStageProvision, output.Stages[0].Name)
+		assert.Equal(t, async.StateRunning, output.Stages[0].State)
+		assert.Contains(t, output.Message, "will not be restored")
+
+		restored := &clusterv1.Cluster{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "prod-restored"}, restored))
+		assert.Equal(t, "prod@"+snapshotID, restored.Annotations[restoredFromAnnotation])
+		assert.Equal(t, "aws-cluster-class", restored.Spec.Topology.Class)
+		assert.Equal(t, source.Spec.Topology.Version, restored.Spec.Topology.Version)
+		assert.Empty(t, restored.Spec.ControlPlaneEndpoint.Host)
+
+		restored.Status.Phase = string(clusterv1.ClusterPhaseProvisioned)
+		require.NoError(t, fakeClient.Update(ctx, restored))
+
+		require.Eventually(t, func() bool {
+			status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+			return err == nil && status.State == async.StateSucceeded
+		}, 5*time.Second, 20*time.Millisecond)
+
+		status, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: output.OperationID}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, RestoreClusterKind, status.Kind)
+		assert.Equal(t, async.StateSucceeded, status.Stages[0].State)
+		assert.Equal(t, async.StateSkipped, status.Stages[1].State)
+		assert.Equal(t, async.StateSkipped, status.Stages[2].State)
+	})
+}
+
+func TestRestoredCluster(t *testing.T) {
+	snap := snapshot.Snapshot{
+		ID:          "abc",
+		ClusterName: "prod",
+		Objects: map[string]map[string]interface{}{
+			"Cluster/prod": {
+				"paused":               true,
+				"controlPlaneEndpoint": map[string]interface{}{"host": "prod.example.com", "port": float64(6443)},
+				"controlPlaneRef":      map[string]interface{}{"kind": "KubeadmControlPlane", "name": "prod-cp"},
+				"topology":             map[string]interface{}{"class": "aws", "version": "v1.30.2"},
+			},
+		},
+	}
+
+	cluster, err := restoredCluster(snap, "prod-2")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-2", cluster.Name)
+	assert.Equal(t, "prod-2", cluster.Labels["cluster.x-k8s.io/cluster-name"])
+	assert.Equal(t, "v1.30.2", cluster.Spec.Topology.Version)
+	assert.False(t, cluster.Spec.Paused)
+	assert.Nil(t, cluster.Spec.ControlPlaneRef)
+	assert.Empty(t, cluster.Spec.ControlPlaneEndpoint.Host)
+
+	delete(snap.Objects["Cluster/prod"], "topology")
+	_, err = restoredCluster(snap, "prod-2")
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+
+	_, err = restoredCluster(snapshot.Snapshot{ID: "abc", ClusterName: "prod"}, "prod-2")
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+}
+
+func newVeleroObject(resource, name string, status map[string]interface{}) *unstructured.Unstructured {
+	kind := map[string]string{"backups": "Backup", "restores": "Restore"}[resource]
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "velero.io/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": defaultVeleroNamespace},
+		"status":     status,
+	}}
+}
+
+func TestWaitForVeleroBackup(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		objects []k8sruntime.Object
+		code    errors.ErrorCode
+	}{
+		{name: "completed", objects: []k8sruntime.Object{newVeleroObject("backups", "nightly", map[string]interface{}{"phase": "Completed"})}},
+		{name: "failed backup", objects: []k8sruntime.Object{newVeleroObject("backups", "nightly", map[string]interface{}{"phase": "PartiallyFailed"})}, code: errors.CodePreconditionFailed},
+		{name: "not synced", code: errors.CodeTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workloadClient := kube.NewWorkloadClientWithDynamic(fake.NewSimpleClientset(), dynamicfake.NewSimpleDynamicClient(scheme.Scheme, tt.objects...))
+			waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+
+			var messages []string
+			err := waitForVeleroBackup(waitCtx, workloadClient, defaultVeleroNamespace, "nightly", 10*time.Millisecond, func(message string) {
+				messages = append(messages, message)
+			})
+			if tt.code == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			if tt.code == errors.CodeTimeout {
+				// Unchanged progress is reported once
+				assert.Len(t, messages, 1)
+			}
+		})
+	}
+}
+
+func TestRunVeleroRestore(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		status  map[string]interface{}
+		code    errors.ErrorCode
+		message string
+	}{
+		{name: "completed", status: map[string]interface{}{"phase": "Completed", "warnings": int64(2)}, message: "completed with 2 warning(s)"},
+		{name: "partially failed", status: map[string]interface{}{"phase": "PartiallyFailed", "errors": int64(3)}, code: errors.CodeWorkloadCluster, message: "partiallyfailed with 3 error(s)"},
+		{name: "failed validation", status: map[string]interface{}{"phase": "FailedValidation", "validationErrors": []interface{}{"backup not found"}}, code: errors.CodeWorkloadCluster, message: "backup not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+			var created *unstructured.Unstructured
+			dynamicClient.PrependReactor("create", "restores", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+				created = action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured)
+				return false, nil, nil
+			})
+			// Velero would set the status; report it on every read
+			dynamicClient.PrependReactor("get", "restores", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+				return true, newVeleroObject("restores", action.(k8stesting.GetAction).GetName(), tt.status), nil
+			})
+			workloadClient := kube.NewWorkloadClientWithDynamic(fake.NewSimpleClientset(), dynamicClient)
+
+			message, err := runVeleroRestore(ctx, workloadClient, api.RestoreClusterInput{
+				VeleroBackup:       "nightly",
+				VeleroNamespace:    defaultVeleroNamespace,
+				IncludedNamespaces: []string{"app"},
+			}, 10*time.Millisecond, func(string) {})
+
+			require.NotNil(t, created)
+			backupName, _, _ := unstructured.NestedString(created.Object, "spec", "backupName")
+			assert.Equal(t, "nightly", backupName)
+			namespaces, _, _ := unstructured.NestedStringSlice(created.Object, "spec", "includedNamespaces")
+			assert.Equal(t, []string{"app"}, namespaces)
+
+			if tt.code == "" {
+				require.NoError(t, err)
+				assert.Contains(t, message, tt.message)
+				return
+			}
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// RollbackTool is the tool name rollbacks are recorded under.
+	RollbackTool = "rollback_operation"
+
+	// RolledBackOperationParameter is the rollback parameter holding the ID of
+	// the operation that was reversed.
+	RolledBackOperationParameter = "operationId"
+)
+
+// rollbackFunc reverses a recorded operation and describes what was restored.
+type rollbackFunc func(s *EnhancedClusterService, ctx context.Context, op history.Operation) (string, error)
+
+// reversibleOperations maps tools to the functions that reverse them.
+// Operations of any other tool cannot be rolled back.
+var reversibleOperations = map[string]rollbackFunc{
+	"scale_cluster":            (*EnhancedClusterService).rollbackScale,
+	"update_cluster_variables": (*EnhancedClusterService).rollbackVariables,
+}
+
+// RollbackOperation reverses the most recent change to a cluster that has not
+// already been rolled back. Rolling back repeatedly walks back through the
+// cluster's history. The change is refused when it is not reversible.
+func (s *EnhancedClusterService) RollbackOperation(ctx context.Context, input api.RollbackOperationInput) (*api.RollbackOperationOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RollbackOperation").WithCluster(input.ClusterName, "")
+	logger.Info("Rolling back last operation")
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	if s.history == nil {
+		err := errors.New(errors.CodeUnavailable, "operation history is not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	rollbackCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	op, err := s.lastReversibleCandidate(rollbackCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to find operation to roll back")
+		return nil, err
+	}
+
+	rollback, ok := reversibleOperations[op.Tool]
+	if !ok {
+		err := errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("the last operation on cluster '%s' was %s (%s), which cannot be rolled back; only %s operations are reversible",
+				input.ClusterName, op.Tool, op.ID, strings.Join(slices.Sorted(maps.Keys(reversibleOperations)), " and ")))
+		logger.WithError(err).Warn("Operation is not reversible", "operation_id", op.ID, "tool", op.Tool)
+		return nil, err
+	}
+
+	message, err := rollback(s, kube.ContextWithNamespace(rollbackCtx, op.Namespace), op)
+	if err != nil {
+		logger.WithError(err).Error("Failed to roll back operation", "operation_id", op.ID, "tool", op.Tool)
+		return nil, err
+	}
+
+	logger.Info("Operation rolled back", "operation_id", op.ID, "tool", op.Tool)
+	return &api.RollbackOperationOutput{
+		RolledBack: toAPIOperation(op),
+		Message:    message,
+	}, nil
+}
+
+// lastReversibleCandidate returns the most recent successful change to a cluster
+// that has not been rolled back. Rollbacks themselves are never candidates.
+func (s *EnhancedClusterService) lastReversibleCandidate(ctx context.Context, clusterName string) (history.Operation, error) {
+	filter := history.Filter{ClusterName: clusterName}
+	if namespace, ok := kube.NamespaceFromContext(ctx); ok {
+		filter.Namespaces = []string{namespace}
+	}
+
+	operations, err := s.history.List(ctx, filter)
+	if err != nil {
+		return history.Operation{}, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list operations")
+	}
+
+	rolledBack := make(map[string]bool)
+	for _, op := range operations {
+		if op.Tool == RollbackTool && op.Outcome == history.OutcomeSucceeded {
+			rolledBack[op.Parameters[RolledBackOperationParameter]] = true
+		}
+	}
+
+	for _, op := range operations {
+		if op.Tool == RollbackTool || op.Outcome != history.OutcomeSucceeded || rolledBack[op.ID] {
+			continue
+		}
+		return op, nil
+	}
+	return history.Operation{}, errors.New(errors.CodeNotFound,
+		fmt.Sprintf("no operations on cluster '%s' left to roll back", clusterName))
+}
+
+// rollbackScale restores the replica count a node pool had before a scale_cluster
+// operation, provided nothing has changed the node pool since.
+func (s *EnhancedClusterService) rollbackScale(ctx context.Context, op history.Operation) (string, error) {
+	nodePoolName := op.Parameters["nodePoolName"]
+	oldReplicas, oldErr := strconv.Atoi(op.Parameters["oldReplicas"])
+	newReplicas, newErr := strconv.Atoi(op.Parameters["replicas"])
+	if nodePoolName == "" || oldErr != nil || newErr != nil {
+		return "", errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("operation %s did not record the previous replica count and cannot be rolled back", op.ID))
+	}
+
+	pool, err := s.getScalableNodePool(ctx, op.ClusterName, nodePoolName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errors.New(errors.CodeNotFound,
+				fmt.Sprintf("node pool '%s' no longer exists in cluster '%s'", nodePoolName, op.ClusterName))
+		}
+		return "", errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get node pool")
+	}
+
+	current := 0
+	if pool.replicas != nil {
+		current = int(*pool.replicas)
+	}
+	if current != newReplicas {
+		return "", errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("node pool '%s' has %d replicas but operation %s scaled it to %d; it was changed since and will not be rolled back",
+				nodePoolName, current, op.ID, newReplicas))
+	}
+
+	output, err := s.ScaleCluster(ctx, api.ScaleClusterInput{
+		ClusterName:  op.ClusterName,
+		NodePoolName: nodePoolName,
+		Replicas:     oldReplicas,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Rolled back %s: %s", op.ID, output.Message), nil
+}
+
+// rollbackVariables restores the values the variables changed by an
+// update_cluster_variables operation had before, provided nothing has changed
+// them since.
+func (s *EnhancedClusterService) rollbackVariables(ctx context.Context, op history.Operation) (string, error) {
+	var previous map[string]interface{}
+	if err := json.Unmarshal([]byte(op.Parameters["previousVariables"]), &previous); err != nil || len(previous) == 0 {
+		return "", errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("operation %s did not record the previous variable values and cannot be rolled back", op.ID))
+	}
+
+	cluster, err := s.kubeClient.GetClusterByName(ctx, op.ClusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' no longer exists", op.ClusterName))
+		}
+		return "", errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	current := map[string]interface{}{}
+	if cluster.Spec.Topology != nil {
+		for _, variable := range cluster.Spec.Topology.Variables {
+			current[variable.Name] = rawJSONValue(variable.Value.Raw)
+		}
+	}
+
+	input := api.UpdateClusterVariablesInput{ClusterName: op.ClusterName, Variables: map[string]interface{}{}}
+	for _, name := range slices.Sorted(maps.Keys(previous)) {
+		// The operation either set the variable, recording its value, or unset it
+		var applied interface{}
+		if encoded, ok := op.Parameters[name]; ok {
+			applied = rawJSONValue([]byte(encoded))
+		}
+		if value, ok := current[name]; !reflect.DeepEqual(value, applied) || (applied == nil && ok) {
+			return "", errors.New(errors.CodePreconditionFailed,
+				fmt.Sprintf("variable %s of cluster '%s' was changed since operation %s and will not be rolled back",
+					name, op.ClusterName, op.ID))
+		}
+
+		if previous[name] == nil {
+			input.Unset = append(input.Unset, name)
+		} else {
+			input.Variables[name] = previous[name]
+		}
+	}
+
+	output, err := s.UpdateClusterVariables(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Rolled back %s: %s", op.ID, output.Message), nil
+}
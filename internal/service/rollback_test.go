@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+func TestEnhancedClusterService_RollbackOperation(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	scaleOperation := func(id string, startedAt time.Time, oldReplicas, replicas string) history.Operation {
+		return history.Operation{
+			ID:          id,
+			Tool:        "scale_cluster",
+			ClusterName: "test-cluster",
+			Namespace:   testNamespace,
+			Outcome:     history.OutcomeSucceeded,
+			StartedAt:   startedAt,
+			Parameters:  map[string]string{"nodePoolName": "test-md", "oldReplicas": oldReplicas, "replicas": replicas},
+		}
+	}
+
+	setup := func(t *testing.T, replicas int32, operations ...history.Operation) (*EnhancedClusterService, func() int32) {
+		svc, fakeClient := setupEnhancedTestService(t,
+			createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			createTestMachineDeployment("test-md", testNamespace, "test-cluster", replicas),
+		)
+		svc.SetOperationHistory(history.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		for _, op := range operations {
+			svc.RecordOperation(ctx, op)
+		}
+
+		currentReplicas := func() int32 {
+			md := &clusterv1.MachineDeployment{}
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "test-md"}, md))
+			return *md.Spec.Replicas
+		}
+		return svc, currentReplicas
+	}
+
+	t.Run("restores previous replica count", func(t *testing.T) {
+		svc, currentReplicas := setup(t, 5, scaleOperation("op-1", base, "3", "5"))
+
+		output, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "test-cluster"})
+		require.NoError(t, err)
+		assert.Equal(t, "op-1", output.RolledBack.ID)
+		assert.Contains(t, output.Message, "from 5 to 3")
+		assert.Equal(t, int32(3), currentReplicas())
+	})
+
+	t.Run("walks back past rolled back operations", func(t *testing.T) {
+		svc, currentReplicas := setup(t, 3,
+			scaleOperation("op-1", base, "2", "3"),
+			scaleOperation("op-2", base.Add(time.Minute), "3", "5"),
+			history.Operation{
+				Tool:        RollbackTool,
+				ClusterName: "test-cluster",
+				Namespace:   testNamespace,
+				Outcome:     history.OutcomeSucceeded,
+				StartedAt:   base.Add(2 * time.Minute),
+				Parameters:  map[string]string{RolledBackOperationParameter: "op-2"},
+			},
+		)
+
+		output, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "test-cluster"})
+		require.NoError(t, err)
+		assert.Equal(t, "op-1", output.RolledBack.ID)
+		assert.Equal(t, int32(2), currentReplicas())
+	})
+
+	t.Run("refuses when node pool changed since", func(t *testing.T) {
+		svc, currentReplicas := setup(t, 4, scaleOperation("op-1", base, "3", "5"))
+
+		_, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "test-cluster"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+		assert.Equal(t, int32(4), currentReplicas())
+	})
+
+	t.Run("refuses non-reversible operations", func(t *testing.T) {
+		svc, _ := setup(t, 5,
+			scaleOperation("op-1", base, "3", "5"),
+			history.Operation{
+				ID:          "op-2",
+				Tool:        "create_cluster",
+				ClusterName: "test-cluster",
+				Namespace:   testNamespace,
+				Outcome:     history.OutcomeSucceeded,
+				StartedAt:   base.Add(time.Minute),
+			},
+		)
+
+		_, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "test-cluster"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+		assert.Contains(t, errors.GetUserMessage(err), "create_cluster")
+	})
+
+	t.Run("ignores failed operations", func(t *testing.T) {
+		failed := scaleOperation("op-1", base, "3", "5")
+		failed.Outcome = history.OutcomeFailed
+		svc, _ := setup(t, 3, failed)
+
+		_, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "test-cluster"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+
+	t.Run("requires cluster name", func(t *testing.T) {
+		svc, _ := setup(t, 3)
+
+		_, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+}
+
+func TestEnhancedClusterService_RollbackVariables(t *testing.T) {
+	ctx := context.Background()
+
+	// update applies a variable update and records it as update_cluster_variables does
+	update := func(t *testing.T, svc *EnhancedClusterService, input api.UpdateClusterVariablesInput) {
+		output, err := svc.UpdateClusterVariables(ctx, input)
+		require.NoError(t, err)
+		parameters := map[string]string{}
+		for name, value := range input.Variables {
+			encoded, err := json.Marshal(value)
+			require.NoError(t, err)
+			parameters[name] = string(encoded)
+		}
+		if len(input.Unset) > 0 {
+			parameters["unset"] = strings.Join(input.Unset, ",")
+		}
+		previous, err := json.Marshal(output.PreviousVariables)
+		require.NoError(t, err)
+		parameters["previousVariables"] = string(previous)
+		svc.RecordOperation(ctx, history.Operation{
+			ID:          "op-1",
+			Tool:        "update_cluster_variables",
+			ClusterName: "dev",
+			Namespace:   testNamespace,
+			Outcome:     history.OutcomeSucceeded,
+			StartedAt:   time.Now(),
+			Parameters:  parameters,
+		})
+	}
+	variables := func(t *testing.T, fakeClient client.Client) map[string]string {
+		var cluster clusterv1.Cluster
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev"}, &cluster))
+		values := map[string]string{}
+		for _, variable := range cluster.Spec.Topology.Variables {
+			values[variable.Name] = string(variable.Value.Raw)
+		}
+		return values
+	}
+	input := api.UpdateClusterVariablesInput{
+		ClusterName: "dev",
+		Variables:   map[string]interface{}{"instanceType": "m5.xlarge", "auditLogging": map[string]interface{}{"enabled": true}},
+		Unset:       []string{"owner"},
+	}
+
+	t.Run("restores previous values", func(t *testing.T) {
+		svc, fakeClient := setupClusterVariablesTest(t)
+		svc.SetOperationHistory(history.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		before := variables(t, fakeClient)
+		update(t, svc, input)
+
+		output, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "dev"})
+		require.NoError(t, err)
+		assert.Equal(t, "op-1", output.RolledBack.ID)
+		assert.Equal(t, before, variables(t, fakeClient))
+	})
+
+	t.Run("refuses when a variable changed since", func(t *testing.T) {
+		svc, fakeClient := setupClusterVariablesTest(t)
+		svc.SetOperationHistory(history.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+		update(t, svc, input)
+		_, err := svc.UpdateClusterVariables(ctx, api.UpdateClusterVariablesInput{ClusterName: "dev", Variables: map[string]interface{}{"owner": "ops"}})
+		require.NoError(t, err)
+		changed := variables(t, fakeClient)
+
+		_, err = svc.RollbackOperation(ctx, api.RollbackOperationInput{ClusterName: "dev"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+		assert.Contains(t, errors.GetUserMessage(err), "variable owner")
+		assert.Equal(t, changed, variables(t, fakeClient))
+	})
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// Rollout actions, mirroring the clusterctl alpha rollout subcommands.
+const (
+	RolloutPause   = "pause"
+	RolloutResume  = "resume"
+	RolloutRestart = "restart"
+	RolloutUndo    = "undo"
+)
+
+// rolloutTarget is a control plane or MachineDeployment whose machines are
+// rolled out, abstracting over the two kinds.
+type rolloutTarget struct {
+	name         string // ControlPlane or MachineDeployment/<name>
+	paused       bool
+	rolloutAfter *time.Time
+	inProgress   bool // some machines are not on the current template yet
+	machines     []string
+	setPaused    func(ctx context.Context, paused bool) error
+	md           *clusterv1.MachineDeployment // nil for the control plane
+}
+
+// PauseRollout pauses the rollouts of the control plane or a MachineDeployment
+// of a cluster, so template changes are not rolled out until it is resumed.
+func (s *EnhancedClusterService) PauseRollout(ctx context.Context, input api.RolloutInput) (*api.RolloutOutput, error) {
+	return s.rollout(ctx, RolloutPause, input)
+}
+
+// ResumeRollout resumes the paused rollouts of the control plane or a
+// MachineDeployment of a cluster.
+func (s *EnhancedClusterService) ResumeRollout(ctx context.Context, input api.RolloutInput) (*api.RolloutOutput, error) {
+	return s.rollout(ctx, RolloutResume, input)
+}
+
+// RestartRollout replaces every machine of the control plane or a
+// MachineDeployment of a cluster, one at a time as its rollout strategy
+// allows.
+func (s *EnhancedClusterService) RestartRollout(ctx context.Context, input api.RolloutInput) (*api.RolloutOutput, error) {
+	return s.rollout(ctx, RolloutRestart, input)
+}
+
+// UndoRollout rolls a MachineDeployment back to an earlier revision by
+// restoring the machine template of that revision's MachineSet.
+func (s *EnhancedClusterService) UndoRollout(ctx context.Context, input api.RolloutInput) (*api.RolloutOutput, error) {
+	return s.rollout(ctx, RolloutUndo, input)
+}
+
+func (s *EnhancedClusterService) rollout(ctx context.Context, action string, input api.RolloutInput) (*api.RolloutOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("Rollout").WithCluster(input.ClusterName, "")
+	logger.Info("Changing rollout", "action", action, "target", input.Target, "dry_run", input.DryRun)
+
+	if err := validateRolloutInput(action, input); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	rolloutCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	cluster, err := s.getRotationCluster(rolloutCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		return nil, err
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		err := errors.New(errors.CodePreconditionFailed, fmt.Sprintf("cluster '%s' is being deleted", cluster.Name))
+		logger.WithError(err).Error("Cluster deleting")
+		return nil, err
+	}
+	if cluster.Spec.Paused && (action == RolloutRestart || action == RolloutUndo) {
+		err := errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' is paused, so its controllers would not roll out any machines; unpause the cluster first", cluster.Name))
+		logger.WithError(err).Error("Cluster paused")
+		return nil, err
+	}
+
+	var target *rolloutTarget
+	if input.Target == patchTargetControlPlane {
+		target, err = s.controlPlaneRolloutTarget(rolloutCtx, cluster, action)
+	} else {
+		target, err = s.machineDeploymentRolloutTarget(rolloutCtx, cluster, strings.TrimPrefix(input.Target, patchTargetMachineDeployment+"/"))
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to get rollout target")
+		return nil, err
+	}
+
+	output := &api.RolloutOutput{
+		ClusterName: cluster.Name,
+		Action:      action,
+		Target:      target.name,
+		DryRun:      input.DryRun,
+		Paused:      target.paused,
+	}
+	switch action {
+	case RolloutPause, RolloutResume:
+		err = s.setRolloutPaused(rolloutCtx, cluster, target, action == RolloutPause, output)
+	case RolloutRestart:
+		err = s.restartRollout(rolloutCtx, cluster, target, output)
+	case RolloutUndo:
+		err = s.undoRollout(rolloutCtx, cluster, target, input.ToRevision, output)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to change rollout")
+		return nil, err
+	}
+
+	logger.Info("Changed rollout", "applied", output.Applied, "machines", len(output.Machines))
+	return output, nil
+}
+
+// validateRolloutInput validates the parameters of the rollout tools.
+func validateRolloutInput(action string, input api.RolloutInput) error {
+	if err := validation.NewValidator().ValidateClusterName(input.ClusterName); err != nil {
+		return err
+	}
+	name, isMachineDeployment := strings.CutPrefix(input.Target, patchTargetMachineDeployment+"/")
+	if input.Target != patchTargetControlPlane && (!isMachineDeployment || name == "") {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("target must be %s or %s/<name>, got '%s'", patchTargetControlPlane, patchTargetMachineDeployment, input.Target)).
+			WithDetails("field", "target")
+	}
+	if input.Target == patchTargetControlPlane && action == RolloutUndo {
+		return errors.New(errors.CodeInvalidInput,
+			"undo_rollout only supports node pools (MachineDeployment/<name>); Cluster API keeps no revisions of control planes").
+			WithDetails("field", "target")
+	}
+	if input.ToRevision < 0 {
+		return errors.New(errors.CodeInvalidInput, "toRevision cannot be negative").WithDetails("field", "toRevision")
+	}
+	if input.ToRevision != 0 && action != RolloutUndo {
+		return errors.New(errors.CodeInvalidInput, "toRevision is only supported by undo_rollout").WithDetails("field", "toRevision")
+	}
+	return nil
+}
+
+// controlPlaneRolloutTarget returns the control plane of a cluster, which is
+// paused through the Cluster API paused annotation.
+func (s *EnhancedClusterService) controlPlaneRolloutTarget(ctx context.Context, cluster *clusterv1.Cluster, action string) (*rolloutTarget, error) {
+	if cluster.Spec.ControlPlaneRef == nil {
+		return nil, errors.New(errors.CodePreconditionFailed, fmt.Sprintf("cluster '%s' has no control plane resource", cluster.Name))
+	}
+	if action == RolloutRestart && s.getManagedControlPlaneProvider(cluster) != nil {
+		return nil, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("the control plane of cluster '%s' is a managed %s, which has no machines to restart", cluster.Name, cluster.Spec.ControlPlaneRef.Kind))
+	}
+
+	controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("control plane of cluster '%s' not found", cluster.Name))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get control plane")
+	}
+	_, paused := controlPlane.GetAnnotations()[clusterv1.PausedAnnotation]
+	target := &rolloutTarget{name: patchTargetControlPlane, paused: paused}
+	if value, _, _ := unstructured.NestedString(controlPlane.Object, "spec", "rolloutAfter"); value != "" {
+		if rolloutAfter, err := time.Parse(time.RFC3339, value); err == nil {
+			target.rolloutAfter = &rolloutAfter
+		}
+	}
+	replicas, _, _ := unstructured.NestedInt64(controlPlane.Object, "status", "replicas")
+	updatedReplicas, _, _ := unstructured.NestedInt64(controlPlane.Object, "status", "updatedReplicas")
+	target.inProgress = updatedReplicas < replicas
+
+	machines, err := s.kubeClient.ListControlPlaneMachines(ctx, cluster.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list control plane machines")
+	}
+	for _, machine := range machines.Items {
+		target.machines = append(target.machines, machine.Name)
+	}
+	slices.Sort(target.machines)
+
+	target.setPaused = func(ctx context.Context, paused bool) error {
+		annotations := controlPlane.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		if paused {
+			annotations[clusterv1.PausedAnnotation] = ""
+		} else {
+			delete(annotations, clusterv1.PausedAnnotation)
+		}
+		controlPlane.SetAnnotations(annotations)
+		if err := s.kubeClient.UpdateObject(ctx, controlPlane); err != nil {
+			if apierrors.IsConflict(err) {
+				return errors.Wrap(err, errors.CodePreconditionFailed, "control plane was modified concurrently, retry the request")
+			}
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to update control plane")
+		}
+		return nil
+	}
+	return target, nil
+}
+
+// machineDeploymentRolloutTarget returns a MachineDeployment of a cluster by
+// its name or, for clusters managed by a ClusterClass, its topology name.
+func (s *EnhancedClusterService) machineDeploymentRolloutTarget(ctx context.Context, cluster *clusterv1.Cluster, name string) (*rolloutTarget, error) {
+	md, err := s.kubeClient.GetMachineDeployment(ctx, cluster.Name, name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get machine deployment")
+	}
+	if err != nil {
+		mds, err := s.kubeClient.ListMachineDeployments(ctx, cluster.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployments")
+		}
+		for i := range mds.Items {
+			if mds.Items[i].Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] == name {
+				md = &mds.Items[i]
+				break
+			}
+		}
+		if md == nil {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("node pool '%s' not found in cluster '%s'", name, cluster.Name))
+		}
+	}
+
+	target := &rolloutTarget{
+		name:       patchTargetMachineDeployment + "/" + md.Name,
+		paused:     md.Spec.Paused,
+		inProgress: md.Status.UpdatedReplicas < md.Status.Replicas,
+		md:         md,
+	}
+	if md.Spec.RolloutAfter != nil {
+		target.rolloutAfter = &md.Spec.RolloutAfter.Time
+	}
+
+	machines, err := s.kubeClient.ListMachineDeploymentMachines(ctx, cluster.Name, md.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployment machines")
+	}
+	for _, machine := range machines.Items {
+		target.machines = append(target.machines, machine.Name)
+	}
+	slices.Sort(target.machines)
+
+	target.setPaused = func(ctx context.Context, paused bool) error {
+		md.Spec.Paused = paused
+		return s.updateRolloutMachineDeployment(ctx, md)
+	}
+	return target, nil
+}
+
+// updateRolloutMachineDeployment updates a MachineDeployment, reporting
+// concurrent modifications as a failed precondition.
+func (s *EnhancedClusterService) updateRolloutMachineDeployment(ctx context.Context, md *clusterv1.MachineDeployment) error {
+	if err := s.kubeClient.UpdateMachineDeployment(ctx, md); err != nil {
+		if apierrors.IsConflict(err) {
+			return errors.Wrap(err, errors.CodePreconditionFailed, fmt.Sprintf("machine deployment '%s' was modified concurrently, retry the request", md.Name))
+		}
+		return errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to update machine deployment '%s'", md.Name))
+	}
+	return nil
+}
+
+// setRolloutPaused pauses or resumes the rollouts of a target.
+func (s *EnhancedClusterService) setRolloutPaused(ctx context.Context, cluster *clusterv1.Cluster, target *rolloutTarget, paused bool, output *api.RolloutOutput) error {
+	if target.paused == paused {
+		if paused {
+			output.Message = fmt.Sprintf("The rollouts of %s of cluster '%s' are already paused", target.name, cluster.Name)
+		} else {
+			output.Message = fmt.Sprintf("The rollouts of %s of cluster '%s' are not paused", target.name, cluster.Name)
+		}
+		return nil
+	}
+
+	verb := "resume"
+	if paused {
+		verb = "pause"
+	}
+	if output.DryRun {
+		output.Message = fmt.Sprintf("Dry run: would %s the rollouts of %s of cluster '%s'", verb, target.name, cluster.Name)
+		return nil
+	}
+	if err := target.setPaused(ctx, paused); err != nil {
+		return err
+	}
+
+	output.Paused = paused
+	output.Applied = true
+	if paused {
+		output.Message = fmt.Sprintf("Paused the rollouts of %s of cluster '%s'; template changes are not rolled out until resume_rollout", target.name, cluster.Name)
+	} else {
+		output.Message = fmt.Sprintf("Resumed the rollouts of %s of cluster '%s'; pending template changes roll out now", target.name, cluster.Name)
+	}
+	return nil
+}
+
+// restartRollout replaces every machine of a target, refusing while its
+// rollouts are paused or an earlier rollout has not finished.
+func (s *EnhancedClusterService) restartRollout(ctx context.Context, cluster *clusterv1.Cluster, target *rolloutTarget, output *api.RolloutOutput) error {
+	if target.paused {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("the rollouts of %s of cluster '%s' are paused; resume them with resume_rollout first", target.name, cluster.Name))
+	}
+	now := metav1.Now()
+	if target.rolloutAfter != nil && target.rolloutAfter.After(now.Time) {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("a rollout of %s of cluster '%s' is already scheduled for %s", target.name, cluster.Name, target.rolloutAfter.UTC().Format(time.RFC3339)))
+	}
+	if target.inProgress {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("a rollout of %s of cluster '%s' is still in progress; wait for it to finish before restarting", target.name, cluster.Name))
+	}
+	if len(target.machines) == 0 {
+		output.Message = fmt.Sprintf("%s of cluster '%s' has no machines, nothing to restart", target.name, cluster.Name)
+		return nil
+	}
+
+	output.Machines = target.machines
+	if output.DryRun {
+		output.Message = fmt.Sprintf("Dry run: restarting %s of cluster '%s' would replace %d machine(s)", target.name, cluster.Name, len(target.machines))
+		return nil
+	}
+	if err := s.startRollout(ctx, cluster, target.name, now); err != nil {
+		return err
+	}
+
+	output.Applied = true
+	output.Message = fmt.Sprintf("Started a rollout of %s of cluster '%s', replacing %d machine(s)", target.name, cluster.Name, len(target.machines))
+	return nil
+}
+
+// undoRollout restores the machine template of an earlier revision of a
+// MachineDeployment; revision 0 is the one before the current revision.
+func (s *EnhancedClusterService) undoRollout(ctx context.Context, cluster *clusterv1.Cluster, target *rolloutTarget, toRevision int64, output *api.RolloutOutput) error {
+	md := target.md
+	if _, ok := md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel]; ok {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("%s is managed by the cluster template of cluster '%s', which would revert an undo; "+
+				"change the cluster variables or template instead", target.name, cluster.Name))
+	}
+
+	machineSets, err := s.kubeClient.ListMachineSets(ctx, cluster.Name, md.Name)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine sets")
+	}
+	revisions := map[int64]*clusterv1.MachineSet{}
+	for i := range machineSets.Items {
+		revision, err := strconv.ParseInt(machineSets.Items[i].Annotations[clusterv1.RevisionAnnotation], 10, 64)
+		if err == nil {
+			revisions[revision] = &machineSets.Items[i]
+		}
+	}
+	var available []int64
+	for revision := range revisions {
+		available = append(available, revision)
+	}
+	slices.Sort(available)
+	if len(available) < 2 {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("%s of cluster '%s' has no earlier revision to roll back to", target.name, cluster.Name))
+	}
+
+	current := available[len(available)-1]
+	if toRevision == 0 {
+		toRevision = available[len(available)-2]
+	}
+	machineSet, ok := revisions[toRevision]
+	if !ok {
+		return errors.New(errors.CodeNotFound,
+			fmt.Sprintf("revision %d of %s not found", toRevision, target.name)).
+			WithDetails("revisions", available)
+	}
+	if toRevision == current {
+		return errors.New(errors.CodeInvalidInput, fmt.Sprintf("revision %d is the current revision of %s", toRevision, target.name)).
+			WithDetails("field", "toRevision")
+	}
+
+	output.Revision = toRevision
+	machines, err := s.kubeClient.ListMachineDeploymentMachines(ctx, cluster.Name, md.Name)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployment machines")
+	}
+	for _, machine := range machines.Items {
+		if machine.Labels[clusterv1.MachineSetNameLabel] != machineSet.Name {
+			output.Machines = append(output.Machines, machine.Name)
+		}
+	}
+	slices.Sort(output.Machines)
+	if output.DryRun {
+		output.Message = fmt.Sprintf("Dry run: rolling %s of cluster '%s' back to revision %d would replace %d machine(s)",
+			target.name, cluster.Name, toRevision, len(output.Machines))
+		return nil
+	}
+
+	// Like clusterctl, restore the revision's template without the hash
+	// label the MachineDeployment controller adds to its MachineSets
+	template := *machineSet.Spec.Template.DeepCopy()
+	delete(template.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	md.Spec.Template = template
+	if err := s.updateRolloutMachineDeployment(ctx, md); err != nil {
+		return err
+	}
+
+	output.Applied = true
+	output.Message = fmt.Sprintf("Rolled %s of cluster '%s' back to revision %d, replacing %d machine(s)",
+		target.name, cluster.Name, toRevision, len(output.Machines))
+	if target.paused {
+		output.Message += "; its rollouts are paused, so the machines are replaced once they are resumed"
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// createTestMachineSet creates a MachineSet of a MachineDeployment at a
+// revision, whose template uses the given AWSMachineTemplate.
+func createTestMachineSet(name, clusterName, mdName, revision, infrastructure string) *clusterv1.MachineSet {
+	return &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           clusterName,
+				clusterv1.MachineDeploymentNameLabel: mdName,
+			},
+			Annotations: map[string]string{clusterv1.RevisionAnnotation: revision},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: clusterName,
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{Labels: map[string]string{clusterv1.MachineDeploymentUniqueLabel: name}},
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       clusterName,
+					InfrastructureRef: corev1.ObjectReference{Kind: "AWSMachineTemplate", Name: infrastructure},
+				},
+			},
+		},
+	}
+}
+
+// setupRolloutTest creates cluster dev with a control plane of two machines,
+// node pool md-0 at revision 2 with one machine of each revision, and node
+// pool md-1 managed by the cluster's template.
+func setupRolloutTest(t *testing.T, objs ...client.Object) (*EnhancedClusterService, client.Client) {
+	t.Helper()
+	cluster := withControlPlane(createTestCluster("dev", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		"AWSCluster", "KubeadmControlPlane", "dev-control-plane")
+	controlPlane := createTestControlPlane("KubeadmControlPlane", "dev-control-plane",
+		map[string]interface{}{"replicas": int64(2)},
+		map[string]interface{}{"replicas": int64(2), "updatedReplicas": int64(2)},
+	)
+
+	md := createTestMachineDeployment("md-0", testNamespace, "dev", 2)
+	md.Annotations = map[string]string{clusterv1.RevisionAnnotation: "2"}
+	md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{Kind: "AWSMachineTemplate", Name: "md-0-infra-2"}
+	oldMachine := createTestMachine("md-0-a-x1", "dev", "md-0")
+	oldMachine.Labels[clusterv1.MachineSetNameLabel] = "md-0-a"
+	newMachine := createTestMachine("md-0-b-x1", "dev", "md-0")
+	newMachine.Labels[clusterv1.MachineSetNameLabel] = "md-0-b"
+
+	managed := createTestMachineDeployment("dev-md-1-k8w2q", testNamespace, "dev", 1)
+	managed.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = "md-1"
+
+	objs = append([]client.Object{
+		cluster, controlPlane, md, managed, oldMachine, newMachine,
+		createTestMachineSet("md-0-a", "dev", "md-0", "1", "md-0-infra-1"),
+		createTestMachineSet("md-0-b", "dev", "md-0", "2", "md-0-infra-2"),
+		createTestControlPlaneMachine("dev-control-plane-b", "dev", true),
+		createTestControlPlaneMachine("dev-control-plane-a", "dev", true),
+	}, objs...)
+	return setupEnhancedTestService(t, objs...)
+}
+
+func TestEnhancedClusterService_PauseResumeRollout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("machine deployment", func(t *testing.T) {
+		svc, fakeClient := setupRolloutTest(t)
+		output, err := svc.PauseRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.True(t, output.Paused)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "md-0"}, md))
+		assert.True(t, md.Spec.Paused)
+
+		output, err = svc.PauseRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0"})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Contains(t, output.Message, "already paused")
+
+		output, err = svc.ResumeRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.False(t, output.Paused)
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "md-0"}, md))
+		assert.False(t, md.Spec.Paused)
+	})
+
+	t.Run("control plane", func(t *testing.T) {
+		svc, fakeClient := setupRolloutTest(t)
+		output, err := svc.PauseRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "ControlPlane"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+
+		controlPlane := createTestControlPlane("KubeadmControlPlane", "dev-control-plane", nil, nil)
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane))
+		assert.Contains(t, controlPlane.GetAnnotations(), clusterv1.PausedAnnotation)
+
+		_, err = svc.ResumeRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "ControlPlane"})
+		require.NoError(t, err)
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane))
+		assert.NotContains(t, controlPlane.GetAnnotations(), clusterv1.PausedAnnotation)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		svc, fakeClient := setupRolloutTest(t)
+		output, err := svc.PauseRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-1", DryRun: true})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Equal(t, "MachineDeployment/dev-md-1-k8w2q", output.Target)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-md-1-k8w2q"}, md))
+		assert.False(t, md.Spec.Paused)
+	})
+}
+
+func TestEnhancedClusterService_RestartRollout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("control plane", func(t *testing.T) {
+		svc, fakeClient := setupRolloutTest(t)
+		output, err := svc.RestartRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "ControlPlane"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.Equal(t, []string{"dev-control-plane-a", "dev-control-plane-b"}, output.Machines)
+
+		controlPlane := createTestControlPlane("KubeadmControlPlane", "dev-control-plane", nil, nil)
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane))
+		assert.Contains(t, controlPlane.Object["spec"], "rolloutAfter")
+	})
+
+	t.Run("machine deployment by topology name", func(t *testing.T) {
+		svc, fakeClient := setupRolloutTest(t,
+			createTestMachine("dev-md-1-k8w2q-x1", "dev", "dev-md-1-k8w2q"))
+		output, err := svc.RestartRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-1"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.Equal(t, []string{"dev-md-1-k8w2q-x1"}, output.Machines)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-md-1-k8w2q"}, md))
+		assert.NotNil(t, md.Spec.RolloutAfter)
+	})
+
+	t.Run("no machines", func(t *testing.T) {
+		svc, _ := setupRolloutTest(t)
+		output, err := svc.RestartRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-1"})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Contains(t, output.Message, "nothing to restart")
+	})
+
+	t.Run("guardrails", func(t *testing.T) {
+		paused := createTestMachineDeployment("md-paused", testNamespace, "dev", 1)
+		paused.Spec.Paused = true
+		scheduled := createTestMachineDeployment("md-scheduled", testNamespace, "dev", 1)
+		scheduled.Spec.RolloutAfter = &metav1.Time{Time: time.Now().Add(time.Hour)}
+		rolling := createTestMachineDeployment("md-rolling", testNamespace, "dev", 3)
+		rolling.Status.Replicas = 4
+		rolling.Status.UpdatedReplicas = 1
+		svc, _ := setupRolloutTest(t, paused, scheduled, rolling)
+
+		tests := []struct {
+			target  string
+			message string
+		}{
+			{target: "MachineDeployment/md-paused", message: "resume them with resume_rollout first"},
+			{target: "MachineDeployment/md-scheduled", message: "is already scheduled"},
+			{target: "MachineDeployment/md-rolling", message: "is still in progress"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.target, func(t *testing.T) {
+				_, err := svc.RestartRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: tt.target})
+				require.Error(t, err)
+				assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+				assert.Contains(t, errors.GetUserMessage(err), tt.message)
+			})
+		}
+	})
+}
+
+func TestEnhancedClusterService_UndoRollout(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run", func(t *testing.T) {
+		svc, _ := setupRolloutTest(t)
+		output, err := svc.UndoRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0", DryRun: true})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Equal(t, int64(1), output.Revision)
+		assert.Equal(t, []string{"md-0-b-x1"}, output.Machines)
+	})
+
+	t.Run("restores the previous template", func(t *testing.T) {
+		svc, fakeClient := setupRolloutTest(t)
+		output, err := svc.UndoRollout(ctx, api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "md-0"}, md))
+		assert.Equal(t, "md-0-infra-1", md.Spec.Template.Spec.InfrastructureRef.Name)
+		assert.NotContains(t, md.Spec.Template.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		svc, _ := setupRolloutTest(t)
+		tests := []struct {
+			name    string
+			input   api.RolloutInput
+			code    errors.ErrorCode
+			message string
+		}{
+			{
+				name:    "control plane",
+				input:   api.RolloutInput{ClusterName: "dev", Target: "ControlPlane"},
+				code:    errors.CodeInvalidInput,
+				message: "only supports node pools",
+			},
+			{
+				name:    "current revision",
+				input:   api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0", ToRevision: 2},
+				code:    errors.CodeInvalidInput,
+				message: "revision 2 is the current revision",
+			},
+			{
+				name:    "unknown revision",
+				input:   api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-0", ToRevision: 7},
+				code:    errors.CodeNotFound,
+				message: "revision 7 of MachineDeployment/md-0 not found",
+			},
+			{
+				name:    "managed by the cluster template",
+				input:   api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-1"},
+				code:    errors.CodePreconditionFailed,
+				message: "managed by the cluster template",
+			},
+			{
+				name:    "unknown node pool",
+				input:   api.RolloutInput{ClusterName: "dev", Target: "MachineDeployment/md-9"},
+				code:    errors.CodeNotFound,
+				message: "node pool 'md-9' not found",
+			},
+			{
+				name:    "malformed target",
+				input:   api.RolloutInput{ClusterName: "dev", Target: "md-0"},
+				code:    errors.CodeInvalidInput,
+				message: "target must be ControlPlane or MachineDeployment/<name>",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.UndoRollout(ctx, tt.input)
+				require.Error(t, err)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+				assert.Contains(t, errors.GetUserMessage(err), tt.message)
+			})
+		}
+	})
+}
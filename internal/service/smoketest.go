@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// Checks smoke_test_cluster can run.
+const (
+	smokeTestCheckScheduling = "scheduling"
+	smokeTestCheckDNS        = "dns"
+	smokeTestCheckStorage    = "storage"
+)
+
+// smokeTestCheckOrder is the order checks are run and reported in.
+var smokeTestCheckOrder = []string{smokeTestCheckScheduling, smokeTestCheckDNS, smokeTestCheckStorage}
+
+const (
+	// smokeTestNamespacePrefix prefixes the workload cluster namespace each
+	// smoke test creates its workload in.
+	smokeTestNamespacePrefix = "capi-mcp-smoke-"
+
+	// smokeTestName names the test Deployment, Service and
+	// PersistentVolumeClaim.
+	smokeTestName = "smoke-test"
+
+	// smokeTestTimeout bounds how long the checks may take to pass. A fresh
+	// volume and image pull usually take well under a minute.
+	smokeTestTimeout = 3 * time.Minute
+
+	// smokeTestPollInterval is how often the test workload is checked.
+	smokeTestPollInterval = 2 * time.Second
+
+	// smokeTestVolumeSize is the size of the test PersistentVolumeClaim.
+	smokeTestVolumeSize = "1Gi"
+
+	// defaultServiceDomain is the cluster DNS domain when the Cluster does
+	// not set one.
+	defaultServiceDomain = "cluster.local"
+)
+
+// SetSmokeTestImage sets the image of the smoke_test_cluster workload. It
+// must provide a shell, httpd and nslookup, as busybox does.
+func (s *EnhancedClusterService) SetSmokeTestImage(image string) {
+	s.smokeTestImage = image
+}
+
+// SmokeTestCluster checks that a workload cluster can run workloads by
+// deploying a small test workload to it in a namespace of its own: a
+// Deployment whose pod must be scheduled and become ready, a Service whose
+// name must resolve in cluster DNS, and a PersistentVolumeClaim a pod must
+// write to. The namespace is deleted once the checks finish.
+func (s *EnhancedClusterService) SmokeTestCluster(ctx context.Context, input api.SmokeTestClusterInput) (*api.SmokeTestClusterOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("SmokeTestCluster").WithCluster(input.ClusterName, "")
+	logger.Info("Smoke testing cluster", "checks", input.Checks, "storage_class", input.StorageClass)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	checks := input.Checks
+	if len(checks) == 0 {
+		checks = smokeTestCheckOrder
+	}
+	for _, check := range checks {
+		if !slices.Contains(smokeTestCheckOrder, check) {
+			err := errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("unknown check '%s', must be one of: %s", check, strings.Join(smokeTestCheckOrder, ", ")))
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+	if input.StorageClass != "" {
+		if err := validation.NewValidator().ValidateDNSName(input.StorageClass); err != nil {
+			err := errors.Wrap(err, errors.CodeInvalidInput, "invalid storage class name")
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+	if s.smokeTestImage == "" {
+		err := errors.New(errors.CodeUnavailable, "smoke test image is not configured")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	testCtx, cancel := budget.Sub(ctx, smokeTestTimeout+time.Minute)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(testCtx, input.ClusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	serviceDomain := defaultServiceDomain
+	if cluster.Spec.ClusterNetwork != nil && cluster.Spec.ClusterNetwork.ServiceDomain != "" {
+		serviceDomain = cluster.Spec.ClusterNetwork.ServiceDomain
+	}
+
+	workloadClient, err := s.newWorkloadClient(testCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := runSmokeTest(testCtx, workloadClient, smokeTestSpec{
+		image:         s.smokeTestImage,
+		checks:        checks,
+		storageClass:  input.StorageClass,
+		serviceDomain: serviceDomain,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Smoke test failed to start")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Smoke tested cluster", "namespace", output.Namespace, "passed", output.Passed, "cleaned_up", output.CleanedUp)
+	return output, nil
+}
+
+// smokeTestSpec describes the smoke test to run against a workload cluster.
+type smokeTestSpec struct {
+	image         string
+	checks        []string
+	storageClass  string
+	serviceDomain string
+}
+
+// runSmokeTest creates the test workload for the checks in a new namespace,
+// waits for each check to pass or fail, and deletes the namespace.
+func runSmokeTest(ctx context.Context, workloadClient *kube.WorkloadClient, spec smokeTestSpec) (*api.SmokeTestClusterOutput, error) {
+	namespace := smokeTestNamespacePrefix + uuid.NewString()[:8]
+	_, err := workloadClient.CreateNamespace(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "capi-mcp-server"},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeWorkloadCluster, "failed to create smoke test namespace")
+	}
+
+	output := &api.SmokeTestClusterOutput{
+		Namespace: namespace,
+		Checks:    []api.SmokeTestCheck{},
+	}
+	defer func() {
+		// The request context may have expired; clean up regardless
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		output.CleanedUp = workloadClient.DeleteNamespace(cleanupCtx, namespace) == nil
+	}()
+
+	// Create the workload of every check first, so they make progress
+	// together while each is waited for in turn
+	startedAt := time.Now()
+	createErrs := map[string]error{}
+	for _, check := range smokeTestCheckOrder {
+		if slices.Contains(spec.checks, check) {
+			createErrs[check] = createSmokeTestWorkload(ctx, workloadClient, namespace, check, spec)
+		}
+	}
+
+	output.Passed = true
+	for _, check := range smokeTestCheckOrder {
+		if !slices.Contains(spec.checks, check) {
+			continue
+		}
+		result := api.SmokeTestCheck{Check: check}
+		if err := createErrs[check]; err != nil {
+			result.Message = err.Error()
+		} else {
+			result.Passed, result.Message = waitForSmokeTestCheck(ctx, workloadClient, namespace, check, spec)
+		}
+		result.DurationMs = time.Since(startedAt).Milliseconds()
+		output.Passed = output.Passed && result.Passed
+		output.Checks = append(output.Checks, result)
+	}
+
+	passed := 0
+	for _, result := range output.Checks {
+		if result.Passed {
+			passed++
+		}
+	}
+	output.Message = fmt.Sprintf("%d of %d smoke test check(s) passed", passed, len(output.Checks))
+	return output, nil
+}
+
+// createSmokeTestWorkload creates the resources a check waits for.
+func createSmokeTestWorkload(ctx context.Context, workloadClient *kube.WorkloadClient, namespace, check string, spec smokeTestSpec) error {
+	switch check {
+	case smokeTestCheckScheduling:
+		_, err := workloadClient.CreateDeployment(ctx, buildSmokeTestDeployment(namespace, spec.image))
+		return err
+	case smokeTestCheckDNS:
+		if _, err := workloadClient.CreateService(ctx, buildSmokeTestService(namespace)); err != nil {
+			return err
+		}
+		host := fmt.Sprintf("%s.%s.svc.%s", smokeTestName, namespace, spec.serviceDomain)
+		_, err := workloadClient.CreatePod(ctx, buildSmokeTestPod(namespace, smokeTestName+"-dns", spec.image, "nslookup "+host, ""))
+		return err
+	case smokeTestCheckStorage:
+		if _, err := workloadClient.CreatePersistentVolumeClaim(ctx, buildSmokeTestClaim(namespace, spec.storageClass)); err != nil {
+			return err
+		}
+		_, err := workloadClient.CreatePod(ctx, buildSmokeTestPod(namespace, smokeTestName+"-storage", spec.image,
+			"echo ok > /data/smoke-test && sync && cat /data/smoke-test", smokeTestName))
+		return err
+	}
+	return fmt.Errorf("unknown check %s", check)
+}
+
+// waitForSmokeTestCheck waits for a check to pass or fail and describes the
+// outcome. A check still pending when ctx ends fails with what it was
+// waiting for.
+func waitForSmokeTestCheck(ctx context.Context, workloadClient *kube.WorkloadClient, namespace, check string, spec smokeTestSpec) (bool, string) {
+	waitCtx, cancel := context.WithTimeout(ctx, smokeTestTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(smokeTestPollInterval)
+	defer ticker.Stop()
+
+	for {
+		done, passed, message := smokeTestCheckStatus(waitCtx, workloadClient, namespace, check, spec)
+		if done {
+			return passed, message
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return false, fmt.Sprintf("timed out: %s", message)
+		case <-ticker.C:
+		}
+	}
+}
+
+// smokeTestCheckStatus reports whether a check is done, whether it passed,
+// and a message describing its outcome or what it is waiting for.
+func smokeTestCheckStatus(ctx context.Context, workloadClient *kube.WorkloadClient, namespace, check string, spec smokeTestSpec) (done, passed bool, message string) {
+	switch check {
+	case smokeTestCheckScheduling:
+		deployment, err := workloadClient.GetDeployment(ctx, namespace, smokeTestName)
+		if err != nil {
+			return false, false, err.Error()
+		}
+		if deployment.Status.AvailableReplicas > 0 {
+			return true, true, "test pod was scheduled and became ready"
+		}
+		pods, err := workloadClient.ListPods(ctx, namespace, "app.kubernetes.io/name="+smokeTestName)
+		if err != nil || len(pods.Items) == 0 {
+			return false, false, "waiting for the test pod to be created"
+		}
+		return false, false, smokeTestPodProblem(&pods.Items[0])
+
+	case smokeTestCheckDNS:
+		host := fmt.Sprintf("%s.%s.svc.%s", smokeTestName, namespace, spec.serviceDomain)
+		return smokeTestPodStatus(ctx, workloadClient, namespace, smokeTestName+"-dns",
+			fmt.Sprintf("resolved %s in cluster DNS", host))
+
+	case smokeTestCheckStorage:
+		claim, err := workloadClient.GetPersistentVolumeClaim(ctx, namespace, smokeTestName)
+		if err != nil {
+			return false, false, err.Error()
+		}
+		storageClass := "the default storage class"
+		if claim.Spec.StorageClassName != nil {
+			storageClass = "storage class " + *claim.Spec.StorageClassName
+		}
+		if claim.Status.Phase != corev1.ClaimBound {
+			return false, false, fmt.Sprintf("volume claim is %s with %s", claim.Status.Phase, storageClass)
+		}
+		return smokeTestPodStatus(ctx, workloadClient, namespace, smokeTestName+"-storage",
+			fmt.Sprintf("wrote to volume %s provisioned with %s", claim.Spec.VolumeName, storageClass))
+	}
+	return true, false, fmt.Sprintf("unknown check %s", check)
+}
+
+// smokeTestPodStatus reports the outcome of a check run by a pod: passed
+// once it succeeds, failed with its logs if it fails.
+func smokeTestPodStatus(ctx context.Context, workloadClient *kube.WorkloadClient, namespace, name, success string) (done, passed bool, message string) {
+	pod, err := workloadClient.GetPod(ctx, namespace, name)
+	if err != nil {
+		return false, false, err.Error()
+	}
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, true, success
+	case corev1.PodFailed:
+		logs, err := workloadClient.GetPodLogs(ctx, namespace, name, "", 20, maxPodLogBytes, false)
+		if err != nil || strings.TrimSpace(logs) == "" {
+			return true, false, fmt.Sprintf("test pod %s failed", name)
+		}
+		return true, false, fmt.Sprintf("test pod %s failed: %s", name, strings.TrimSpace(logs))
+	}
+	return false, false, smokeTestPodProblem(pod)
+}
+
+// smokeTestPodProblem describes what a test pod that has not finished is
+// waiting for.
+func smokeTestPodProblem(pod *corev1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			return fmt.Sprintf("test pod %s is not scheduled: %s", pod.Name, condition.Message)
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" {
+			return fmt.Sprintf("test pod %s is waiting: %s %s", pod.Name, waiting.Reason, waiting.Message)
+		}
+	}
+	return fmt.Sprintf("test pod %s is %s", pod.Name, pod.Status.Phase)
+}
+
+// smokeTestLabels labels the test workload.
+func smokeTestLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       smokeTestName,
+		"app.kubernetes.io/managed-by": "capi-mcp-server",
+	}
+}
+
+// smokeTestResources are the small requests of every test container, so the
+// workload fits on any node.
+func smokeTestResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("16Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+	}
+}
+
+// buildSmokeTestDeployment builds a single replica web server Deployment,
+// ready once it accepts connections.
+func buildSmokeTestDeployment(namespace, image string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      smokeTestName,
+			Namespace: namespace,
+			Labels:    smokeTestLabels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": smokeTestName}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: smokeTestLabels()},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:      "web",
+						Image:     image,
+						Command:   []string{"httpd", "-f", "-p", "8080"},
+						Ports:     []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+						Resources: smokeTestResources(),
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")},
+							},
+							PeriodSeconds: 2,
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// buildSmokeTestService builds the Service in front of the test Deployment.
+func buildSmokeTestService(namespace string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      smokeTestName,
+			Namespace: namespace,
+			Labels:    smokeTestLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app.kubernetes.io/name": smokeTestName},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+}
+
+// buildSmokeTestClaim builds the test PersistentVolumeClaim, using the
+// cluster's default StorageClass unless one is named.
+func buildSmokeTestClaim(namespace, storageClass string) *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      smokeTestName,
+			Namespace: namespace,
+			Labels:    smokeTestLabels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(smokeTestVolumeSize)},
+			},
+		},
+	}
+	if storageClass != "" {
+		claim.Spec.StorageClassName = &storageClass
+	}
+	return claim
+}
+
+// buildSmokeTestPod builds a pod running a shell command once, mounting the
+// named PersistentVolumeClaim at /data if one is given.
+func buildSmokeTestPod(namespace, name, image, command, claim string) *corev1.Pod {
+	deadline := int64(smokeTestTimeout.Seconds())
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       name,
+				"app.kubernetes.io/managed-by": "capi-mcp-server",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &deadline,
+			Containers: []corev1.Container{{
+				Name:      "check",
+				Image:     image,
+				Command:   []string{"sh", "-c", command},
+				Resources: smokeTestResources(),
+			}},
+		},
+	}
+	if claim != "" {
+		pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}
+		pod.Spec.Volumes = []corev1.Volume{{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+			},
+		}}
+	}
+	return pod
+}
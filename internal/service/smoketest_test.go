@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// newSmokeTestClientset returns a fake clientset where the test workload
+// makes progress as soon as it is created: Deployments become available,
+// claims bind and pods finish in the given phase.
+func newSmokeTestClientset(podPhase corev1.PodPhase) *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		action.(k8stesting.CreateAction).GetObject().(*appsv1.Deployment).Status.AvailableReplicas = 1
+		return false, nil, nil
+	})
+	clientset.PrependReactor("create", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		claim := action.(k8stesting.CreateAction).GetObject().(*corev1.PersistentVolumeClaim)
+		claim.Spec.VolumeName = "pvc-1234"
+		claim.Status.Phase = corev1.ClaimBound
+		return false, nil, nil
+	})
+	clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		action.(k8stesting.CreateAction).GetObject().(*corev1.Pod).Status.Phase = podPhase
+		return false, nil, nil
+	})
+	return clientset
+}
+
+func TestRunSmokeTest(t *testing.T) {
+	ctx := context.Background()
+	spec := smokeTestSpec{image: "busybox:1.36", checks: smokeTestCheckOrder, serviceDomain: "cluster.local"}
+
+	t.Run("passed", func(t *testing.T) {
+		workloadClient := kube.NewWorkloadClient(newSmokeTestClientset(corev1.PodSucceeded))
+
+		output, err := runSmokeTest(ctx, workloadClient, spec)
+		require.NoError(t, err)
+		assert.True(t, output.Passed)
+		assert.True(t, output.CleanedUp)
+		require.Len(t, output.Checks, 3)
+		for i, check := range smokeTestCheckOrder {
+			assert.Equal(t, check, output.Checks[i].Check)
+			assert.True(t, output.Checks[i].Passed, output.Checks[i].Message)
+		}
+		assert.Contains(t, output.Checks[1].Message, "smoke-test."+output.Namespace+".svc.cluster.local")
+		assert.Contains(t, output.Checks[2].Message, "pvc-1234")
+
+		// The test namespace is removed once done
+		assert.Error(t, workloadClient.DeleteNamespace(ctx, output.Namespace))
+	})
+
+	t.Run("failed pods", func(t *testing.T) {
+		workloadClient := kube.NewWorkloadClient(newSmokeTestClientset(corev1.PodFailed))
+
+		output, err := runSmokeTest(ctx, workloadClient, smokeTestSpec{image: "busybox:1.36", checks: []string{smokeTestCheckDNS}, serviceDomain: "cluster.local"})
+		require.NoError(t, err)
+		assert.False(t, output.Passed)
+		require.Len(t, output.Checks, 1)
+		assert.Contains(t, output.Checks[0].Message, "test pod smoke-test-dns failed")
+		assert.Equal(t, "0 of 1 smoke test check(s) passed", output.Message)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		workloadClient := kube.NewWorkloadClient(fake.NewSimpleClientset())
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		output, err := runSmokeTest(timeoutCtx, workloadClient, smokeTestSpec{image: "busybox:1.36", checks: []string{smokeTestCheckScheduling}})
+		require.NoError(t, err)
+		assert.False(t, output.Passed)
+		assert.Equal(t, "timed out: waiting for the test pod to be created", output.Checks[0].Message)
+		assert.True(t, output.CleanedUp)
+	})
+}
+
+func TestSmokeTestPodProblem(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web"}, Status: corev1.PodStatus{Phase: corev1.PodPending}}
+	assert.Equal(t, "test pod web is Pending", smokeTestPodProblem(pod))
+
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "pull access denied"}},
+	}}
+	assert.Equal(t, "test pod web is waiting: ImagePullBackOff pull access denied", smokeTestPodProblem(pod))
+
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: "0/3 nodes are available: 3 node(s) had untolerated taint",
+	}}
+	assert.Equal(t, "test pod web is not scheduled: 0/3 nodes are available: 3 node(s) had untolerated taint", smokeTestPodProblem(pod))
+}
+
+func TestBuildSmokeTestClaim(t *testing.T) {
+	assert.Nil(t, buildSmokeTestClaim("ns", "").Spec.StorageClassName)
+	assert.Equal(t, "gp3", *buildSmokeTestClaim("ns", "gp3").Spec.StorageClassName)
+
+	pod := buildSmokeTestPod("ns", "smoke-test-storage", "busybox:1.36", "true", smokeTestName)
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, smokeTestName, pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Empty(t, buildSmokeTestPod("ns", "smoke-test-dns", "busybox:1.36", "true", "").Spec.Volumes)
+}
+
+func TestEnhancedClusterService_SmokeTestCluster_Validation(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+
+	_, err := svc.SmokeTestCluster(ctx, api.SmokeTestClusterInput{ClusterName: "test-cluster"})
+	assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+
+	svc.SetSmokeTestImage("busybox:1.36")
+	tests := []struct {
+		name  string
+		input api.SmokeTestClusterInput
+		code  errors.ErrorCode
+	}{
+		{name: "missing cluster name", input: api.SmokeTestClusterInput{}, code: errors.CodeInvalidInput},
+		{name: "unknown check", input: api.SmokeTestClusterInput{ClusterName: "test-cluster", Checks: []string{"network"}}, code: errors.CodeInvalidInput},
+		{name: "invalid storage class", input: api.SmokeTestClusterInput{ClusterName: "test-cluster", StorageClass: "GP3!"}, code: errors.CodeInvalidInput},
+		{name: "cluster not found", input: api.SmokeTestClusterInput{ClusterName: "missing"}, code: errors.CodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SmokeTestCluster(ctx, tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/snapshot"
+)
+
+// Change types reported by diff_cluster_state.
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+// SetSnapshotStore configures the store cluster snapshots are saved in.
+// Without a store, diff_cluster_state is unavailable.
+func (s *EnhancedClusterService) SetSnapshotStore(store snapshot.Store) {
+	s.snapshots = store
+}
+
+// DiffClusterState compares the desired state of a cluster's Cluster,
+// MachineDeployment and MachinePool objects between two snapshots, or between a
+// snapshot and the current state. Comparing against the current state saves it
+// as a new snapshot so later calls can compare against it.
+func (s *EnhancedClusterService) DiffClusterState(ctx context.Context, input api.DiffClusterStateInput) (*api.DiffClusterStateOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("DiffClusterState").WithCluster(input.ClusterName, "")
+	logger.Info("Diffing cluster state", "from", input.From, "to", input.To)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	if s.snapshots == nil {
+		err := errors.New(errors.CodeUnavailable, "cluster snapshots are not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	diffCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	namespace := s.kubeClient.Namespace(diffCtx)
+	snapshots, err := s.snapshots.List(diffCtx, namespace, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list snapshots")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list cluster snapshots")
+	}
+
+	// Resolve the newer side first; the older side defaults to the snapshot before it
+	var to snapshot.Snapshot
+	current := input.To == ""
+	if current {
+		to, err = s.captureClusterState(diffCtx, input.ClusterName, namespace)
+		if err != nil {
+			logger.WithError(err).Error("Failed to capture cluster state")
+			return nil, err
+		}
+		if to, err = s.snapshots.Save(diffCtx, to); err != nil {
+			logger.WithError(err).Error("Failed to save snapshot")
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to save cluster snapshot")
+		}
+	} else if to, err = resolveSnapshot(snapshots, input.ClusterName, input.To); err != nil {
+		logger.WithError(err).Error("Failed to resolve snapshot", "ref", input.To)
+		return nil, err
+	}
+
+	output := &api.DiffClusterStateOutput{
+		ClusterName: input.ClusterName,
+		To:          snapshotRef(to, current),
+		Changes:     []api.ClusterStateDiff{},
+	}
+
+	var from snapshot.Snapshot
+	if input.From != "" {
+		if from, err = resolveSnapshot(snapshots, input.ClusterName, input.From); err != nil {
+			logger.WithError(err).Error("Failed to resolve snapshot", "ref", input.From)
+			return nil, err
+		}
+	} else if previous, ok := previousSnapshot(snapshots, to); ok {
+		from = previous
+	} else {
+		output.Message = fmt.Sprintf("No earlier snapshot of cluster '%s'; saved the current state as baseline snapshot %s", input.ClusterName, to.ID)
+		logger.Info("Saved baseline snapshot", "snapshot_id", to.ID)
+		return output, nil
+	}
+
+	fromRef := snapshotRef(from, false)
+	output.From = &fromRef
+	output.Changes = diffClusterObjects(from.Objects, to.Objects)
+	output.Diff = formatStateDiff(output.Changes)
+	if len(output.Changes) == 0 {
+		output.Message = fmt.Sprintf("No changes to cluster '%s' between %s and %s", input.ClusterName, output.From.TakenAt, output.To.TakenAt)
+	} else {
+		output.Message = fmt.Sprintf("%d change(s) to cluster '%s' between %s and %s", len(output.Changes), input.ClusterName, output.From.TakenAt, output.To.TakenAt)
+	}
+
+	logger.Info("Cluster state diffed", "changes", len(output.Changes))
+	return output, nil
+}
+
+// captureClusterState snapshots the specs of a cluster and its node pools.
+func (s *EnhancedClusterService) captureClusterState(ctx context.Context, clusterName, namespace string) (snapshot.Snapshot, error) {
+	snap := snapshot.Snapshot{
+		ClusterName: clusterName,
+		Namespace:   namespace,
+		TakenAt:     time.Now().UTC(),
+		Objects:     make(map[string]map[string]interface{}),
+	}
+
+	cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return snap, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", clusterName))
+		}
+		return snap, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if err := addSnapshotObject(snap, "Cluster", cluster.Name, cluster.Spec); err != nil {
+		return snap, err
+	}
+
+	machineDeployments, err := s.kubeClient.ListMachineDeployments(ctx, clusterName)
+	if err != nil {
+		return snap, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployments")
+	}
+	for _, md := range machineDeployments.Items {
+		if err := addSnapshotObject(snap, "MachineDeployment", md.Name, md.Spec); err != nil {
+			return snap, err
+		}
+	}
+
+	machinePools, err := s.kubeClient.ListMachinePools(ctx, clusterName)
+	if err != nil {
+		return snap, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine pools")
+	}
+	for _, mp := range machinePools.Items {
+		if err := addSnapshotObject(snap, "MachinePool", mp.Name, mp.Spec); err != nil {
+			return snap, err
+		}
+	}
+
+	return snap, nil
+}
+
+// addSnapshotObject records an object's spec in generic form so snapshots
+// round-trip through JSON unchanged.
+func addSnapshotObject(snap snapshot.Snapshot, kind, name string, spec interface{}) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to encode %s/%s", kind, name))
+	}
+	var generic map[string]interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("failed to decode %s/%s", kind, name))
+	}
+	snap.Objects[kind+"/"+name] = generic
+	return nil
+}
+
+// resolveSnapshot finds a snapshot by ID, or the latest snapshot taken at or
+// before an RFC 3339 time. Snapshots are ordered newest first.
+func resolveSnapshot(snapshots []snapshot.Snapshot, clusterName, ref string) (snapshot.Snapshot, error) {
+	for _, snap := range snapshots {
+		if snap.ID == ref {
+			return snap, nil
+		}
+	}
+
+	at, err := time.Parse(time.RFC3339, ref)
+	if err != nil {
+		return snapshot.Snapshot{}, errors.New(errors.CodeNotFound,
+			fmt.Sprintf("no snapshot '%s' of cluster '%s'; use a snapshot ID or an RFC 3339 time", ref, clusterName))
+	}
+	for _, snap := range snapshots {
+		if !snap.TakenAt.After(at) {
+			return snap, nil
+		}
+	}
+	return snapshot.Snapshot{}, errors.New(errors.CodeNotFound,
+		fmt.Sprintf("no snapshot of cluster '%s' taken at or before %s", clusterName, ref))
+}
+
+// previousSnapshot returns the latest snapshot taken before the given one.
+func previousSnapshot(snapshots []snapshot.Snapshot, to snapshot.Snapshot) (snapshot.Snapshot, bool) {
+	for _, snap := range snapshots {
+		if snap.ID != to.ID && snap.TakenAt.Before(to.TakenAt) {
+			return snap, true
+		}
+	}
+	return snapshot.Snapshot{}, false
+}
+
+func snapshotRef(snap snapshot.Snapshot, current bool) api.ClusterStateRef {
+	return api.ClusterStateRef{
+		SnapshotID: snap.ID,
+		TakenAt:    snap.TakenAt.UTC().Format(time.RFC3339),
+		Current:    current,
+	}
+}
+
+// diffClusterObjects compares two sets of object specs keyed by Kind/name.
+func diffClusterObjects(from, to map[string]map[string]interface{}) []api.ClusterStateDiff {
+	changes := []api.ClusterStateDiff{}
+	for _, object := range sortedKeys(from, to) {
+		oldSpec, hadObject := from[object]
+		newSpec, hasObject := to[object]
+		switch {
+		case !hadObject:
+			changes = append(changes, api.ClusterStateDiff{Object: object, Change: changeAdded})
+		case !hasObject:
+			changes = append(changes, api.ClusterStateDiff{Object: object, Change: changeRemoved})
+		default:
+			changes = diffValues(changes, object, "spec", oldSpec, newSpec)
+		}
+	}
+	return changes
+}
+
+// diffValues appends the differences between two generic JSON values.
+// Maps are compared key by key and equal-length lists element by element;
+// anything else that differs is reported as a whole.
+func diffValues(changes []api.ClusterStateDiff, object, path string, oldValue, newValue interface{}) []api.ClusterStateDiff {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		for _, key := range sortedKeys(oldMap, newMap) {
+			changes = diffValues(changes, object, path+"."+key, oldMap[key], newMap[key])
+		}
+		return changes
+	}
+
+	oldList, oldIsList := oldValue.([]interface{})
+	newList, newIsList := newValue.([]interface{})
+	if oldIsList && newIsList && len(oldList) == len(newList) {
+		for i := range oldList {
+			changes = diffValues(changes, object, fmt.Sprintf("%s[%d]", path, i), oldList[i], newList[i])
+		}
+		return changes
+	}
+
+	if reflect.DeepEqual(oldValue, newValue) {
+		return changes
+	}
+
+	change := changeModified
+	switch {
+	case oldValue == nil:
+		change = changeAdded
+	case newValue == nil:
+		change = changeRemoved
+	}
+	return append(changes, api.ClusterStateDiff{
+		Object: object,
+		Path:   path,
+		Change: change,
+		Old:    oldValue,
+		New:    newValue,
+	})
+}
+
+// formatStateDiff renders changes as one human-readable line each.
+func formatStateDiff(changes []api.ClusterStateDiff) string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		switch {
+		case change.Path == "":
+			lines = append(lines, fmt.Sprintf("%s: %s", change.Object, change.Change))
+		case change.Change == changeAdded:
+			lines = append(lines, fmt.Sprintf("%s: %s set to %s", change.Object, change.Path, formatStateValue(change.New)))
+		case change.Change == changeRemoved:
+			lines = append(lines, fmt.Sprintf("%s: %s removed (was %s)", change.Object, change.Path, formatStateValue(change.Old)))
+		default:
+			lines = append(lines, fmt.Sprintf("%s: %s changed from %s to %s", change.Object, change.Path,
+				formatStateValue(change.Old), formatStateValue(change.New)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatStateValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// sortedKeys returns the union of the keys of two maps in sorted order.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
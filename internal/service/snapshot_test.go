@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/snapshot"
+)
+
+func TestEnhancedClusterService_DiffClusterState(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*EnhancedClusterService, func(replicas int32)) {
+		svc, fakeClient := setupEnhancedTestService(t,
+			createTestCluster("test-cluster", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			createTestMachineDeployment("test-md", testNamespace, "test-cluster", 3),
+		)
+		svc.SetSnapshotStore(snapshot.NewConfigMapStore(svc.kubeClient, testNamespace, 0))
+
+		scale := func(replicas int32) {
+			md := &clusterv1.MachineDeployment{}
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: testNamespace, Name: "test-md"}, md))
+			md.Spec.Replicas = &replicas
+			require.NoError(t, fakeClient.Update(ctx, md))
+		}
+		return svc, scale
+	}
+
+	t.Run("first call saves a baseline", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		output, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "test-cluster"})
+		require.NoError(t, err)
+		assert.Nil(t, output.From)
+		assert.True(t, output.To.Current)
+		assert.NotEmpty(t, output.To.SnapshotID)
+		assert.Empty(t, output.Changes)
+		assert.Contains(t, output.Message, "baseline")
+	})
+
+	t.Run("reports changes since the previous snapshot", func(t *testing.T) {
+		svc, scale := setup(t)
+
+		baseline, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "test-cluster"})
+		require.NoError(t, err)
+
+		time.Sleep(10 * time.Millisecond)
+		scale(5)
+
+		output, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "test-cluster"})
+		require.NoError(t, err)
+		require.NotNil(t, output.From)
+		assert.Equal(t, baseline.To.SnapshotID, output.From.SnapshotID)
+		require.Len(t, output.Changes, 1)
+		assert.Equal(t, api.ClusterStateDiff{
+			Object: "MachineDeployment/test-md",
+			Path:   "spec.replicas",
+			Change: "modified",
+			Old:    float64(3),
+			New:    float64(5),
+		}, output.Changes[0])
+		assert.Equal(t, "MachineDeployment/test-md: spec.replicas changed from 3 to 5", output.Diff)
+
+		// Diffing the two saved snapshots explicitly gives the same result
+		between, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{
+			ClusterName: "test-cluster",
+			From:        baseline.To.SnapshotID,
+			To:          output.To.SnapshotID,
+		})
+		require.NoError(t, err)
+		assert.False(t, between.To.Current)
+		assert.Equal(t, output.Changes, between.Changes)
+	})
+
+	t.Run("unknown snapshot reference", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		_, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "test-cluster", From: "2000-01-01T00:00:00Z"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+
+		_, err = svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "test-cluster", To: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+
+	t.Run("cluster not found", func(t *testing.T) {
+		svc, _ := setup(t)
+
+		_, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{ClusterName: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
+
+func TestDiffClusterObjects(t *testing.T) {
+	from := map[string]map[string]interface{}{
+		"Cluster/test": {
+			"topology": map[string]interface{}{
+				"version": "v1.30.0",
+				"variables": []interface{}{
+					map[string]interface{}{"name": "region", "value": "us-west-2"},
+				},
+			},
+		},
+		"MachineDeployment/old": {"replicas": float64(1)},
+	}
+	to := map[string]map[string]interface{}{
+		"Cluster/test": {
+			"topology": map[string]interface{}{
+				"version": "v1.31.0",
+				"variables": []interface{}{
+					map[string]interface{}{"name": "region", "value": "us-west-2"},
+				},
+				"paused": true,
+			},
+		},
+		"MachineDeployment/new": {"replicas": float64(2)},
+	}
+
+	changes := diffClusterObjects(from, to)
+	require.Len(t, changes, 4)
+	assert.Equal(t, api.ClusterStateDiff{Object: "Cluster/test", Path: "spec.topology.paused", Change: "added", New: true}, changes[0])
+	assert.Equal(t, api.ClusterStateDiff{Object: "Cluster/test", Path: "spec.topology.version", Change: "modified", Old: "v1.30.0", New: "v1.31.0"}, changes[1])
+	assert.Equal(t, api.ClusterStateDiff{Object: "MachineDeployment/new", Change: "added"}, changes[2])
+	assert.Equal(t, api.ClusterStateDiff{Object: "MachineDeployment/old", Change: "removed"}, changes[3])
+
+	assert.Equal(t, `Cluster/test: spec.topology.paused set to true
+Cluster/test: spec.topology.version changed from "v1.30.0" to "v1.31.0"
+MachineDeployment/new: added
+MachineDeployment/old: removed`, formatStateDiff(changes))
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// pendingDeletionLabel labels the clusters delete_cluster soft-deleted,
+	// which are paused until their retention window ends.
+	pendingDeletionLabel = "capi-mcp.io/pending-deletion"
+
+	// Annotations of a soft-deleted cluster.
+	deleteAfterAnnotation          = "capi-mcp.io/delete-after"
+	deletionRequestedByAnnotation  = "capi-mcp.io/deletion-requested-by"
+	pausedBeforeDeletionAnnotation = "capi-mcp.io/paused-before-deletion"
+
+	// deletionReapInterval is how often soft-deleted clusters are checked for
+	// the end of their retention window.
+	deletionReapInterval = time.Minute
+)
+
+// SetDeletionRetention configures how long delete_cluster keeps a cluster
+// paused before tearing it down, during which undelete_cluster can cancel the
+// deletion. Zero deletes clusters immediately.
+func (s *EnhancedClusterService) SetDeletionRetention(retention time.Duration) {
+	s.deletionRetention = retention
+}
+
+// isPendingDeletion reports whether a cluster was soft-deleted.
+func isPendingDeletion(cluster *clusterv1.Cluster) bool {
+	return cluster.Labels[pendingDeletionLabel] == "true"
+}
+
+// pendingDeletionWarning returns the warning reported for a soft-deleted
+// cluster, or nil if it is not one.
+func pendingDeletionWarning(cluster *clusterv1.Cluster) []string {
+	if !isPendingDeletion(cluster) {
+		return nil
+	}
+	return []string{fmt.Sprintf("cluster is paused and scheduled for deletion at %s; undelete_cluster cancels the deletion",
+		cluster.Annotations[deleteAfterAnnotation])}
+}
+
+// softDeleteCluster pauses a cluster and schedules its deletion for the end
+// of the retention window. Deleting a cluster pending deletion again keeps
+// its schedule.
+func (s *EnhancedClusterService) softDeleteCluster(ctx context.Context, cluster *clusterv1.Cluster, warnings []string) (*api.DeleteClusterOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("DeleteCluster").WithCluster(cluster.Name, "")
+
+	if !isPendingDeletion(cluster) {
+		if cluster.Labels == nil {
+			cluster.Labels = map[string]string{}
+		}
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Labels[pendingDeletionLabel] = "true"
+		cluster.Annotations[deleteAfterAnnotation] = time.Now().Add(s.deletionRetention).UTC().Format(time.RFC3339)
+		if identity := auth.IdentityFromContext(ctx); identity != nil {
+			cluster.Annotations[deletionRequestedByAnnotation] = identity.Name
+		}
+		if cluster.Spec.Paused {
+			cluster.Annotations[pausedBeforeDeletionAnnotation] = "true"
+		}
+		cluster.Spec.Paused = true
+
+		if err := s.updatePendingDeletion(ctx, cluster); err != nil {
+			logger.WithError(err).Error("Failed to soft-delete cluster")
+			return nil, err
+		}
+		logger.Info("Cluster soft-deleted", "delete_after", cluster.Annotations[deleteAfterAnnotation])
+	}
+
+	deleteAfter := cluster.Annotations[deleteAfterAnnotation]
+	return &api.DeleteClusterOutput{
+		Status: "pending_deletion",
+		Message: fmt.Sprintf("Cluster '%s' is paused and will be deleted at %s; call undelete_cluster before then to keep it",
+			cluster.Name, deleteAfter),
+		DeleteAfter: deleteAfter,
+		Warnings:    warnings,
+	}, nil
+}
+
+// UndeleteCluster cancels the pending deletion of a soft-deleted cluster and
+// resumes its reconciliation, unless it was paused before it was deleted.
+func (s *EnhancedClusterService) UndeleteCluster(ctx context.Context, input api.UndeleteClusterInput) (*api.UndeleteClusterOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("UndeleteCluster").WithCluster(input.ClusterName, "")
+	logger.Info("Cancelling cluster deletion")
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	undeleteCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	cluster, err := s.kubeClient.GetClusterByName(undeleteCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", input.ClusterName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	if cluster.DeletionTimestamp != nil {
+		err := errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster '%s' is already being torn down and cannot be undeleted", cluster.Name))
+		logger.WithError(err).Error("Cluster is deleting")
+		return nil, err
+	}
+	if !isPendingDeletion(cluster) {
+		err := errors.New(errors.CodePreconditionFailed, fmt.Sprintf("cluster '%s' is not pending deletion", cluster.Name))
+		logger.WithError(err).Error("Cluster is not pending deletion")
+		return nil, err
+	}
+
+	paused := cluster.Annotations[pausedBeforeDeletionAnnotation] == "true"
+	delete(cluster.Labels, pendingDeletionLabel)
+	delete(cluster.Annotations, deleteAfterAnnotation)
+	delete(cluster.Annotations, deletionRequestedByAnnotation)
+	delete(cluster.Annotations, pausedBeforeDeletionAnnotation)
+	cluster.Spec.Paused = paused
+
+	if err := s.updatePendingDeletion(undeleteCtx, cluster); err != nil {
+		logger.WithError(err).Error("Failed to undelete cluster")
+		return nil, err
+	}
+
+	message := fmt.Sprintf("Deletion of cluster '%s' cancelled; it is reconciled again", cluster.Name)
+	if paused {
+		message = fmt.Sprintf("Deletion of cluster '%s' cancelled; it stays paused, as it was before it was deleted", cluster.Name)
+	}
+	logger.Info("Cluster deletion cancelled", "paused", paused)
+	return &api.UndeleteClusterOutput{
+		ClusterName: cluster.Name,
+		Paused:      paused,
+		Message:     message,
+	}, nil
+}
+
+// updatePendingDeletion updates a cluster whose pending deletion changed,
+// reporting concurrent modifications as a failed precondition.
+func (s *EnhancedClusterService) updatePendingDeletion(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if err := s.kubeClient.UpdateCluster(ctx, cluster); err != nil {
+		if apierrors.IsConflict(err) {
+			return errors.Wrap(err, errors.CodePreconditionFailed, fmt.Sprintf("cluster '%s' was modified concurrently, retry the request", cluster.Name))
+		}
+		return errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to update cluster '%s'", cluster.Name))
+	}
+	return nil
+}
+
+// resumeForDeletion unpauses a soft-deleted cluster about to be deleted, as
+// Cluster API does not tear down paused clusters.
+func (s *EnhancedClusterService) resumeForDeletion(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if !isPendingDeletion(cluster) || !cluster.Spec.Paused {
+		return nil
+	}
+	cluster.Spec.Paused = false
+	return s.updatePendingDeletion(ctx, cluster)
+}
+
+// RunDeletionReaper deletes soft-deleted clusters once their retention window
+// ends, until ctx is cancelled. It returns immediately when deletions are not
+// retained.
+func (s *EnhancedClusterService) RunDeletionReaper(ctx context.Context) {
+	if s.deletionRetention <= 0 || s.kubeClient == nil {
+		return
+	}
+
+	logger := s.logger.WithContext(ctx).WithOperation("ReapDeletions")
+	logger.Info("Starting soft-deleted cluster reaper", "retention", s.deletionRetention)
+
+	ticker := time.NewTicker(deletionReapInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.reapDeletions(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			logger.WithError(err).Warn("Failed to list soft-deleted clusters")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reapDeletions deletes the soft-deleted clusters whose retention window has
+// ended by now. Clusters that fail to be deleted are retried on the next run.
+func (s *EnhancedClusterService) reapDeletions(ctx context.Context, now time.Time) error {
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	clusters, err := s.kubeClient.ListAllClusters(listCtx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !isPendingDeletion(cluster) || cluster.DeletionTimestamp != nil {
+			continue
+		}
+
+		logger := s.logger.WithContext(ctx).WithOperation("ReapDeletions").WithCluster(cluster.Name, cluster.Namespace)
+		deleteAfter, err := time.Parse(time.RFC3339, cluster.Annotations[deleteAfterAnnotation])
+		if err != nil {
+			logger.WithError(err).Warn("Soft-deleted cluster has an invalid deletion time, not deleting it")
+			continue
+		}
+		if now.Before(deleteAfter) {
+			continue
+		}
+
+		deleteCtx, cancel := context.WithTimeout(kube.ContextWithNamespace(ctx, cluster.Namespace), 30*time.Second)
+		startedAt := time.Now()
+		err = s.resumeForDeletion(deleteCtx, cluster)
+		if err == nil {
+			err = s.kubeClient.DeleteCluster(deleteCtx, cluster.Name)
+		}
+		cancel()
+		if err != nil {
+			logger.WithError(err).Warn("Failed to delete soft-deleted cluster")
+			continue
+		}
+		logger.Info("Deleting soft-deleted cluster after its retention window",
+			"delete_after", cluster.Annotations[deleteAfterAnnotation],
+			"requested_by", cluster.Annotations[deletionRequestedByAnnotation],
+		)
+		s.trackDeletion(ctx, cluster, startedAt)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedClusterService_SoftDelete(t *testing.T) {
+	ctx := auth.ContextWithIdentity(context.Background(), &auth.Identity{Name: "agent"})
+
+	paused := createTestCluster("paused", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	paused.Spec.Paused = true
+	svc, fakeClient := setupEnhancedTestService(t,
+		createTestCluster("dev-a", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		createTestCluster("dev-b", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		paused,
+	)
+	svc.SetDeletionRetention(72 * time.Hour)
+
+	getCluster := func(t *testing.T, name string) *clusterv1.Cluster {
+		var cluster clusterv1.Cluster
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, &cluster))
+		return &cluster
+	}
+
+	output, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "dev-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "pending_deletion", output.Status)
+	assert.Contains(t, output.Message, "call undelete_cluster before then")
+	deleteAfter, err := time.Parse(time.RFC3339, output.DeleteAfter)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(72*time.Hour), deleteAfter, time.Minute)
+
+	cluster := getCluster(t, "dev-a")
+	assert.Nil(t, cluster.DeletionTimestamp)
+	assert.True(t, cluster.Spec.Paused)
+	assert.Equal(t, "true", cluster.Labels[pendingDeletionLabel])
+	assert.Equal(t, "agent", cluster.Annotations[deletionRequestedByAnnotation])
+
+	t.Run("deleting again keeps the schedule", func(t *testing.T) {
+		again, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "dev-a"})
+		require.NoError(t, err)
+		assert.Equal(t, output.DeleteAfter, again.DeleteAfter)
+	})
+
+	t.Run("get_cluster warns", func(t *testing.T) {
+		details, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "dev-a"})
+		require.NoError(t, err)
+		assert.Contains(t, details.Warnings, "cluster is paused and scheduled for deletion at "+output.DeleteAfter+"; undelete_cluster cancels the deletion")
+	})
+
+	t.Run("undelete", func(t *testing.T) {
+		undeleted, err := svc.UndeleteCluster(ctx, api.UndeleteClusterInput{ClusterName: "dev-a"})
+		require.NoError(t, err)
+		assert.False(t, undeleted.Paused)
+		assert.Equal(t, "Deletion of cluster 'dev-a' cancelled; it is reconciled again", undeleted.Message)
+
+		cluster := getCluster(t, "dev-a")
+		assert.False(t, cluster.Spec.Paused)
+		assert.NotContains(t, cluster.Labels, pendingDeletionLabel)
+		assert.NotContains(t, cluster.Annotations, deleteAfterAnnotation)
+	})
+
+	t.Run("undelete keeps a paused cluster paused", func(t *testing.T) {
+		_, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "paused"})
+		require.NoError(t, err)
+
+		undeleted, err := svc.UndeleteCluster(ctx, api.UndeleteClusterInput{ClusterName: "paused"})
+		require.NoError(t, err)
+		assert.True(t, undeleted.Paused)
+		assert.True(t, getCluster(t, "paused").Spec.Paused)
+	})
+
+	t.Run("not pending deletion", func(t *testing.T) {
+		_, err := svc.UndeleteCluster(ctx, api.UndeleteClusterInput{ClusterName: "dev-a"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := svc.UndeleteCluster(ctx, api.UndeleteClusterInput{ClusterName: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+
+	t.Run("immediate deletion resumes a soft-deleted cluster", func(t *testing.T) {
+		_, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "dev-a"})
+		require.NoError(t, err)
+
+		svc.SetDeletionRetention(0)
+		defer svc.SetDeletionRetention(72 * time.Hour)
+		deleteCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		_, err = svc.DeleteCluster(deleteCtx, api.DeleteClusterInput{ClusterName: "dev-a"})
+		require.NoError(t, err)
+		err = fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-a"}, &clusterv1.Cluster{})
+		assert.True(t, apierrors.IsNotFound(err), "cluster was not deleted: %v", err)
+	})
+
+	t.Run("reaper waits for the retention window", func(t *testing.T) {
+		_, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "dev-b"})
+		require.NoError(t, err)
+
+		require.NoError(t, svc.reapDeletions(ctx, time.Now()))
+		assert.Nil(t, getCluster(t, "dev-b").DeletionTimestamp)
+
+		require.NoError(t, svc.reapDeletions(ctx, time.Now().Add(73*time.Hour)))
+		err = fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-b"}, &clusterv1.Cluster{})
+		assert.True(t, apierrors.IsNotFound(err), "soft-deleted cluster was not deleted: %v", err)
+		assert.Nil(t, getCluster(t, "paused").DeletionTimestamp, "undeleted cluster was deleted")
+	})
+}
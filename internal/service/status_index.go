@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// StatusIndexOptions configures the background cluster status index that
+// list_clusters serves from on large fleets.
+type StatusIndexOptions struct {
+	// Enabled starts the background refresher.
+	Enabled bool
+
+	// MaxStaleness bounds how old served summaries may be. list_clusters
+	// falls back to reading the API directly when the last complete refresh
+	// started longer ago than this.
+	MaxStaleness time.Duration
+
+	// RefreshInterval is how often a refresh starts, unless the previous one
+	// is still running. It defaults to half of MaxStaleness.
+	RefreshInterval time.Duration
+
+	// BatchSize is the number of clusters summarized before their results are
+	// published to the index.
+	BatchSize int
+
+	// QPS limits how many clusters are summarized per second, bounding the
+	// load the refresher puts on the management cluster API server.
+	QPS int
+}
+
+// statusIndex holds cluster summaries refreshed in the background.
+type statusIndex struct {
+	options StatusIndexOptions
+
+	mu          sync.RWMutex
+	summaries   map[types.NamespacedName]api.ClusterSummary
+	refreshedAt time.Time // start of the last complete refresh
+}
+
+// SetStatusIndexOptions configures the background status index. It must be
+// called before RunStatusRefresher.
+func (s *EnhancedClusterService) SetStatusIndexOptions(options StatusIndexOptions) {
+	if !options.Enabled {
+		s.statusIndex = nil
+		return
+	}
+	s.statusIndex = &statusIndex{
+		options:   options,
+		summaries: make(map[types.NamespacedName]api.ClusterSummary),
+	}
+}
+
+// RunStatusRefresher keeps the status index up to date until ctx is cancelled.
+// It returns immediately when the index is disabled.
+func (s *EnhancedClusterService) RunStatusRefresher(ctx context.Context) {
+	if s.statusIndex == nil || s.kubeClient == nil {
+		return
+	}
+
+	logger := s.logger.WithContext(ctx).WithOperation("RefreshStatusIndex")
+	options := s.statusIndex.options
+	limiter := flowcontrol.NewTokenBucketRateLimiter(float32(options.QPS), options.QPS)
+	defer limiter.Stop()
+
+	interval := options.refreshInterval()
+	logger.Info("Starting cluster status refresher",
+		"max_staleness", options.MaxStaleness,
+		"refresh_interval", interval,
+		"batch_size", options.BatchSize,
+		"qps", options.QPS,
+	)
+
+	for {
+		started := time.Now()
+		if err := s.refreshStatusIndex(ctx, limiter); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.WithError(err).Warn("Failed to refresh cluster status index")
+		} else if duration := time.Since(started); options.goesStale(duration) {
+			logger.Warn("Cluster status refresh is too slow for the staleness bound; list_clusters reads the API directly while the index is stale",
+				"duration", duration,
+				"max_staleness", options.MaxStaleness,
+				"refresh_interval", interval,
+				"hint", "raise STATUS_INDEX_MAX_STALENESS or STATUS_INDEX_QPS",
+			)
+		} else {
+			logger.Debug("Refreshed cluster status index", "duration", duration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(started.Add(interval))):
+		}
+	}
+}
+
+// refreshInterval returns how often a refresh starts.
+func (o StatusIndexOptions) refreshInterval() time.Duration {
+	if o.RefreshInterval > 0 {
+		return o.RefreshInterval
+	}
+	return o.MaxStaleness / 2
+}
+
+// goesStale reports whether refreshes taking duration let the index grow older
+// than MaxStaleness. The index dates from the start of the last complete
+// refresh, so it is oldest just before the next refresh, which starts an
+// interval later or once this one ends, completes.
+func (o StatusIndexOptions) goesStale(duration time.Duration) bool {
+	return max(o.refreshInterval(), duration)+duration > o.MaxStaleness
+}
+
+// refreshStatusIndex summarizes every cluster, publishing results batch by
+// batch, and drops clusters that no longer exist.
+func (s *EnhancedClusterService) refreshStatusIndex(ctx context.Context, limiter flowcontrol.RateLimiter) error {
+	started := time.Now()
+
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	clusters, err := s.kubeClient.ListAllClusters(listCtx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	index := s.statusIndex
+	batchSize := index.options.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(clusters.Items)
+	}
+
+	seen := make(map[types.NamespacedName]bool, len(clusters.Items))
+	for start := 0; start < len(clusters.Items); start += batchSize {
+		end := min(start+batchSize, len(clusters.Items))
+
+		batch := make([]api.ClusterSummary, 0, end-start)
+		for i := start; i < end; i++ {
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+			cluster := &clusters.Items[i]
+			summaryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			batch = append(batch, s.summarizeCluster(summaryCtx, cluster, time.Now()))
+			cancel()
+		}
+
+		index.mu.Lock()
+		for _, summary := range batch {
+			key := types.NamespacedName{Namespace: summary.Namespace, Name: summary.Name}
+			index.summaries[key] = summary
+			seen[key] = true
+		}
+		index.mu.Unlock()
+	}
+
+	index.mu.Lock()
+	for key := range index.summaries {
+		if !seen[key] {
+			delete(index.summaries, key)
+		}
+	}
+	index.refreshedAt = started
+	index.mu.Unlock()
+	return nil
+}
+
+// indexedClusters returns the indexed summaries of the clusters in a namespace,
+// or false when the index is disabled or older than its staleness bound.
+func (s *EnhancedClusterService) indexedClusters(namespace string) (*api.ListClustersOutput, bool) {
+	index := s.statusIndex
+	if index == nil {
+		return nil, false
+	}
+
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	if index.refreshedAt.IsZero() || time.Since(index.refreshedAt) > index.options.MaxStaleness {
+		return nil, false
+	}
+
+	summaries := make([]api.ClusterSummary, 0)
+	for key, summary := range index.summaries {
+		if key.Namespace == namespace {
+			summaries = append(summaries, summary)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+
+	return &api.ListClustersOutput{
+		Clusters:    summaries,
+		LastUpdated: index.refreshedAt.UTC().Format(time.RFC3339),
+	}, true
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestEnhancedClusterService_StatusIndex(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, maxStaleness time.Duration) (*EnhancedClusterService, func()) {
+		svc, _ := setupEnhancedTestService(t,
+			createTestCluster("cluster-b", testNamespace, clusterv1.ClusterPhaseProvisioned),
+			createTestCluster("cluster-a", testNamespace, clusterv1.ClusterPhaseProvisioning),
+			createTestCluster("other-cluster", "team-a", clusterv1.ClusterPhaseProvisioned),
+			createTestMachineDeployment("cluster-a-md", testNamespace, "cluster-a", 3),
+		)
+		svc.SetStatusIndexOptions(StatusIndexOptions{Enabled: true, MaxStaleness: maxStaleness, BatchSize: 1, QPS: 1000})
+
+		refresh := func() {
+			limiter := flowcontrol.NewFakeAlwaysRateLimiter()
+			require.NoError(t, svc.refreshStatusIndex(ctx, limiter))
+		}
+		return svc, refresh
+	}
+
+	t.Run("serves summaries from the index", func(t *testing.T) {
+		svc, refresh := setup(t, time.Minute)
+		refresh()
+
+		output, err := svc.ListClusters(ctx)
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 2)
+		assert.Equal(t, "cluster-a", output.Clusters[0].Name)
+		assert.Equal(t, 3, output.Clusters[0].NodeCount)
+		assert.Equal(t, "cluster-b", output.Clusters[1].Name)
+		assert.NotEmpty(t, output.LastUpdated)
+		assert.NotEmpty(t, output.Clusters[0].LastUpdated)
+	})
+
+	t.Run("drops clusters that no longer exist", func(t *testing.T) {
+		svc, refresh := setup(t, time.Minute)
+		refresh()
+
+		cluster := &clusterv1.Cluster{}
+		cluster.Name, cluster.Namespace = "cluster-b", testNamespace
+		require.NoError(t, svc.kubeClient.DeleteObject(ctx, cluster))
+		refresh()
+
+		output, err := svc.ListClusters(ctx)
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, "cluster-a", output.Clusters[0].Name)
+	})
+
+	t.Run("falls back to a live list when stale", func(t *testing.T) {
+		svc, refresh := setup(t, time.Minute)
+		refresh()
+
+		svc.statusIndex.mu.Lock()
+		svc.statusIndex.refreshedAt = time.Now().Add(-2 * time.Minute)
+		delete(svc.statusIndex.summaries, types.NamespacedName{Namespace: testNamespace, Name: "cluster-a"})
+		svc.statusIndex.mu.Unlock()
+
+		output, err := svc.ListClusters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, output.Clusters, 2)
+	})
+
+	t.Run("lists live before the first refresh", func(t *testing.T) {
+		svc, _ := setup(t, time.Minute)
+
+		_, ok := svc.indexedClusters(testNamespace)
+		assert.False(t, ok)
+
+		output, err := svc.ListClusters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, output.Clusters, 2)
+	})
+
+	t.Run("refresher stops with its context", func(t *testing.T) {
+		svc, _ := setup(t, time.Minute)
+
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			svc.RunStatusRefresher(runCtx)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool {
+			_, ok := svc.indexedClusters(testNamespace)
+			return ok
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("status refresher did not stop")
+		}
+	})
+}
+
+func TestStatusIndexOptions_GoesStale(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  StatusIndexOptions
+		duration time.Duration
+		stale    bool
+	}{
+		{name: "fast refresh", options: StatusIndexOptions{MaxStaleness: time.Minute}, duration: 10 * time.Second},
+		{name: "refresh of half the bound", options: StatusIndexOptions{MaxStaleness: time.Minute}, duration: 30 * time.Second},
+		{name: "refresh longer than the interval", options: StatusIndexOptions{MaxStaleness: time.Minute}, duration: 35 * time.Second, stale: true},
+		{name: "refresh longer than the bound", options: StatusIndexOptions{MaxStaleness: time.Minute}, duration: 2 * time.Minute, stale: true},
+		{name: "refresh plus a long interval", options: StatusIndexOptions{MaxStaleness: time.Minute, RefreshInterval: 50 * time.Second}, duration: 15 * time.Second, stale: true},
+		{name: "refresh plus a short interval", options: StatusIndexOptions{MaxStaleness: time.Minute, RefreshInterval: 10 * time.Second}, duration: 25 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.stale, tt.options.goesStale(tt.duration))
+		})
+	}
+}
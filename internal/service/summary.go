@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// providerLabel is the label used to record the infrastructure provider of a cluster.
+	providerLabel = "cluster.x-k8s.io/provider"
+
+	// regionLabel is the label used to record the region of a cluster.
+	regionLabel = "topology.cluster.x-k8s.io/region"
+
+	// regionVariable is the ClusterClass topology variable holding the region.
+	regionVariable = "region"
+)
+
+// infrastructureKindPrefixes maps infrastructure kind prefixes to provider names.
+var infrastructureKindPrefixes = []struct {
+	prefix   string
+	provider string
+}{
+	{"AWS", "aws"},
+	{"ROSA", "aws"},
+	{"Azure", "azure"},
+	{"GCP", "gcp"},
+	{"VSphere", "vsphere"},
+	{"Docker", "docker"},
+}
+
+// clusterProvider determines the infrastructure provider of a cluster from its
+// infrastructure reference, falling back to the provider label.
+func clusterProvider(cluster *clusterv1.Cluster) string {
+	if cluster.Spec.InfrastructureRef != nil {
+		kind := cluster.Spec.InfrastructureRef.Kind
+		for _, entry := range infrastructureKindPrefixes {
+			if strings.HasPrefix(kind, entry.prefix) {
+				return entry.provider
+			}
+		}
+	}
+
+	if provider, ok := cluster.Labels[providerLabel]; ok && provider != "" {
+		return provider
+	}
+	return "unknown"
+}
+
+// clusterRegion determines the region of a cluster from its topology variables,
+// falling back to the region label.
+func clusterRegion(cluster *clusterv1.Cluster) string {
+	if cluster.Spec.Topology != nil {
+		for _, variable := range cluster.Spec.Topology.Variables {
+			if variable.Name != regionVariable {
+				continue
+			}
+			var region string
+			if err := json.Unmarshal(variable.Value.Raw, &region); err == nil && region != "" {
+				return region
+			}
+		}
+	}
+
+	return cluster.Labels[regionLabel]
+}
+
+// clusterEndpoint returns the control plane endpoint URL of a cluster, or an
+// empty string if it has not been assigned yet.
+func clusterEndpoint(cluster *clusterv1.Cluster) string {
+	if cluster.Spec.ControlPlaneEndpoint.Host != "" {
+		return fmt.Sprintf("https://%s:%d", cluster.Spec.ControlPlaneEndpoint.Host, cluster.Spec.ControlPlaneEndpoint.Port)
+	}
+	return ""
+}
+
+// clusterAge returns the age of a cluster in kubectl's human-readable format (e.g. "3d4h").
+func clusterAge(cluster *clusterv1.Cluster, now time.Time) string {
+	if cluster.CreationTimestamp.IsZero() {
+		return ""
+	}
+	return duration.HumanDuration(now.Sub(cluster.CreationTimestamp.Time))
+}
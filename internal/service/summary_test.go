@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestClusterProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		infraKind string
+		labels    map[string]string
+		want      string
+	}{
+		{name: "aws cluster", infraKind: "AWSCluster", want: "aws"},
+		{name: "aws managed cluster", infraKind: "AWSManagedCluster", want: "aws"},
+		{name: "azure cluster", infraKind: "AzureCluster", want: "azure"},
+		{name: "label fallback", labels: map[string]string{providerLabel: "openstack"}, want: "openstack"},
+		{name: "unknown", infraKind: "MysteryCluster", want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if tt.infraKind != "" {
+				cluster.Spec.InfrastructureRef = &corev1.ObjectReference{Kind: tt.infraKind}
+			}
+			assert.Equal(t, tt.want, clusterProvider(cluster))
+		})
+	}
+}
+
+func TestClusterRegion(t *testing.T) {
+	t.Run("from topology variable", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{
+			Spec: clusterv1.ClusterSpec{
+				Topology: &clusterv1.Topology{
+					Variables: []clusterv1.ClusterVariable{
+						{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"m5.large"`)}},
+						{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"eu-west-1"`)}},
+					},
+				},
+			},
+		}
+		assert.Equal(t, "eu-west-1", clusterRegion(cluster))
+	})
+
+	t.Run("from label", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{regionLabel: "us-east-1"}},
+		}
+		assert.Equal(t, "us-east-1", clusterRegion(cluster))
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		assert.Empty(t, clusterRegion(&clusterv1.Cluster{}))
+	})
+}
+
+func TestClusterEndpointAndAge(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(now.Add(-50 * time.Hour)),
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "api.example.com", Port: 6443},
+		},
+	}
+
+	assert.Equal(t, "https://api.example.com:6443", clusterEndpoint(cluster))
+	assert.Equal(t, "2d2h", clusterAge(cluster, now))
+	assert.Empty(t, clusterEndpoint(&clusterv1.Cluster{}))
+	assert.Empty(t, clusterAge(&clusterv1.Cluster{}, now))
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+)
+
+// catalogListTimeout bounds listing the versions of all catalog templates.
+const catalogListTimeout = 30 * time.Second
+
+// clusterTemplateDescriptionAnnotation describes what a ClusterClass is for.
+const clusterTemplateDescriptionAnnotation = "cluster.x-k8s.io/description"
+
+// templateCatalog is an OCI repository whose tags are the versions of a
+// cluster template, named by the last element of the repository.
+type templateCatalog struct {
+	name       string
+	repository oci.Reference
+}
+
+// SetTemplateCatalogs configures the OCI repositories list_cluster_templates
+// offers cluster templates from and publish_cluster_template installs them
+// from. Their registry must be reachable with the template registry client.
+func (s *EnhancedClusterService) SetTemplateCatalogs(repositories []oci.Reference) {
+	s.templateCatalogs = make([]templateCatalog, 0, len(repositories))
+	for _, repository := range repositories {
+		s.templateCatalogs = append(s.templateCatalogs, templateCatalog{
+			name:       path.Base(repository.Repository),
+			repository: repository,
+		})
+	}
+}
+
+// ListClusterTemplates lists the ClusterClasses in the namespace with the
+// versions they work with, and the templates available from the configured
+// catalogs with their versions, noting which versions are installed.
+// Catalogs that cannot be read are reported as warnings.
+func (s *EnhancedClusterService) ListClusterTemplates(ctx context.Context) (*api.ListClusterTemplatesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ListClusterTemplates")
+	logger.Debug("Listing cluster templates")
+
+	output := &api.ListClusterTemplatesOutput{
+		Templates: []api.ClusterTemplate{},
+		Catalog:   []api.CatalogTemplate{},
+	}
+	if s.kubeClient == nil {
+		logger.Warn("Kubernetes client not initialized")
+		return output, nil
+	}
+
+	listCtx, cancel := budget.Sub(ctx, catalogListTimeout)
+	defer cancel()
+
+	clusterClasses, err := s.kubeClient.ListClusterClasses(listCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list ClusterClasses")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list cluster templates")
+	}
+
+	// Templates are checked against the installed providers when they can be read
+	providerVersions, err := s.installedProviderVersions(listCtx)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to read provider versions, template compatibility not checked")
+		output.Warnings = append(output.Warnings, "cluster template compatibility with the installed providers could not be checked")
+	}
+
+	installed := map[string][]string{}
+	for i := range clusterClasses.Items {
+		template := clusterTemplate(&clusterClasses.Items[i])
+		template.Version = templateVersion(&clusterClasses.Items[i])
+		if requirements, err := s.templateRequirements(&clusterClasses.Items[i]); err != nil {
+			output.Warnings = append(output.Warnings, errors.GetUserMessage(err))
+		} else {
+			template.KubernetesVersions = requirements.kubernetes
+			if len(requirements.providers) > 0 {
+				template.ProviderVersions = requirements.providers
+			}
+			template.Incompatibilities = providerIncompatibilities(requirements, providerVersions)
+		}
+		if catalog, tag, ok := s.catalogOf(template.PublishedFrom); ok {
+			template.CatalogTemplate, template.CatalogVersion = catalog.name, tag
+			if !slices.Contains(installed[catalog.name], tag) {
+				installed[catalog.name] = append(installed[catalog.name], tag)
+			}
+		}
+		output.Templates = append(output.Templates, template)
+	}
+	sort.Slice(output.Templates, func(i, j int) bool {
+		return output.Templates[i].Name < output.Templates[j].Name
+	})
+
+	for _, catalog := range s.templateCatalogs {
+		template := api.CatalogTemplate{
+			Name:              catalog.name,
+			Repository:        catalog.repository.String(),
+			Versions:          []string{},
+			InstalledVersions: sortVersions(installed[catalog.name]),
+		}
+		versions, err := s.catalogVersions(listCtx, catalog)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to list catalog template versions", "catalog", catalog.name)
+			output.Warnings = append(output.Warnings, fmt.Sprintf("versions of catalog template '%s' could not be listed: %v", catalog.name, err))
+		} else {
+			template.Versions = versions
+		}
+		if len(template.Versions) > 0 {
+			template.LatestVersion = template.Versions[0]
+		}
+		output.Catalog = append(output.Catalog, template)
+	}
+
+	logger.Debug("Listed cluster templates", "templates", len(output.Templates), "catalog", len(output.Catalog))
+	return output, nil
+}
+
+// clusterTemplate describes a ClusterClass: its provider, taken from the
+// cluster.x-k8s.io/provider label or the kind of its infrastructure
+// template, the Kubernetes versions it supports and its variables.
+func clusterTemplate(clusterClass *clusterv1.ClusterClass) api.ClusterTemplate {
+	template := api.ClusterTemplate{
+		Name:               clusterClass.Name,
+		Namespace:          clusterClass.Namespace,
+		Description:        clusterClass.Annotations[clusterTemplateDescriptionAnnotation],
+		Provider:           strings.TrimPrefix(clusterClass.Labels[clusterv1.ProviderNameLabel], "infrastructure-"),
+		KubernetesVersions: []string{},
+		Variables:          make([]api.TemplateVariable, 0, len(clusterClass.Spec.Variables)),
+		Labels:             clusterClass.Labels,
+		Annotations:        clusterClass.Annotations,
+		PublishedFrom:      clusterClass.Annotations[publishedFromAnnotation],
+	}
+	if template.Provider == "" && clusterClass.Spec.Infrastructure.Ref != nil {
+		template.Provider = strings.ToLower(strings.TrimSuffix(clusterClass.Spec.Infrastructure.Ref.Kind, "ClusterTemplate"))
+	}
+	if constraint := clusterClass.Annotations[KubernetesVersionsAnnotation]; constraint != "" {
+		template.KubernetesVersions = append(template.KubernetesVersions, constraint)
+	}
+
+	// Values that are not valid JSON are left out
+	decode := func(raw []byte) interface{} {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil
+		}
+		return value
+	}
+	for _, variable := range clusterClass.Spec.Variables {
+		schema := variable.Schema.OpenAPIV3Schema
+		templateVariable := api.TemplateVariable{
+			Name:        variable.Name,
+			Required:    variable.Required,
+			Type:        schema.Type,
+			Description: schema.Description,
+		}
+		if schema.Default != nil {
+			templateVariable.Default = decode(schema.Default.Raw)
+		}
+		if schema.Example != nil {
+			templateVariable.Example = decode(schema.Example.Raw)
+		}
+		for _, value := range schema.Enum {
+			templateVariable.Enum = append(templateVariable.Enum, decode(value.Raw))
+		}
+		template.Variables = append(template.Variables, templateVariable)
+	}
+	return template
+}
+
+// resolveCatalogTemplate returns the artifact reference of a version of a
+// catalog template, by default its latest version.
+func (s *EnhancedClusterService) resolveCatalogTemplate(ctx context.Context, name, requested string) (string, error) {
+	index := slices.IndexFunc(s.templateCatalogs, func(catalog templateCatalog) bool { return catalog.name == name })
+	if index < 0 {
+		return "", errors.New(errors.CodeNotFound,
+			fmt.Sprintf("catalog template '%s' not found; list_cluster_templates lists the available ones", name)).
+			WithDetails("field", "catalog_template")
+	}
+	catalog := s.templateCatalogs[index]
+	if s.templateRegistry == nil {
+		return "", errors.New(errors.CodeUnavailable, "template registry client not configured")
+	}
+
+	versions, err := s.catalogVersions(ctx, catalog)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodeDependencyFailure,
+			fmt.Sprintf("failed to list versions of catalog template '%s'", name))
+	}
+	switch {
+	case len(versions) == 0:
+		return "", errors.New(errors.CodeNotFound, fmt.Sprintf("catalog template '%s' has no versions", name))
+	case requested == "":
+		requested = versions[0]
+	case !slices.Contains(versions, requested):
+		return "", errors.New(errors.CodeNotFound,
+			fmt.Sprintf("version %s of catalog template '%s' not found; available versions: %s", requested, name, strings.Join(versions, ", "))).
+			WithDetails("field", "version")
+	}
+
+	ref := catalog.repository
+	ref.Tag = requested
+	return ref.String(), nil
+}
+
+// catalogVersions lists the versions of a catalog template, newest first.
+// Only tags that are semantic versions, such as v1.2.0, count as versions.
+func (s *EnhancedClusterService) catalogVersions(ctx context.Context, catalog templateCatalog) ([]string, error) {
+	if s.templateRegistry == nil {
+		return nil, fmt.Errorf("template registry client not configured")
+	}
+	tags, err := s.templateRegistry.ListTags(ctx, catalog.repository)
+	if err != nil {
+		return nil, err
+	}
+	return sortVersions(slices.DeleteFunc(tags, func(tag string) bool {
+		_, err := version.ParseSemantic(tag)
+		return err != nil
+	})), nil
+}
+
+// catalogOf returns the catalog and version a ClusterClass was installed
+// from, given its published-from annotation.
+func (s *EnhancedClusterService) catalogOf(publishedFrom string) (templateCatalog, string, bool) {
+	if publishedFrom == "" || publishedFrom == "manifest" {
+		return templateCatalog{}, "", false
+	}
+	ref, err := oci.ParseReference(publishedFrom)
+	if err != nil || ref.Tag == "" {
+		return templateCatalog{}, "", false
+	}
+	for _, catalog := range s.templateCatalogs {
+		if catalog.repository.Registry == ref.Registry && catalog.repository.Repository == ref.Repository {
+			return catalog, ref.Tag, true
+		}
+	}
+	return templateCatalog{}, "", false
+}
+
+// sortVersions sorts semantic version tags newest first, leaving any other
+// tags at the end in name order.
+func sortVersions(tags []string) []string {
+	sorted := slices.Clone(tags)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, errA := version.ParseSemantic(sorted[i])
+		b, errB := version.ParseSemantic(sorted[j])
+		switch {
+		case errA != nil || errB != nil:
+			return errA == nil || (errB != nil && sorted[i] < sorted[j])
+		default:
+			return b.LessThan(a)
+		}
+	})
+	return sorted
+}
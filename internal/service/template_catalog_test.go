@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+)
+
+func TestEnhancedClusterService_TemplateCatalog(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t, createTestClusterClass("aws-standard"))
+	svc.templateRegistry = &fakeTemplateRegistry{tags: map[string][]string{
+		"org/cluster-templates/aws-dev": {"v1.0.0", "v1.10.0", "latest", "v1.2.0", "sha256-1234.sig"},
+	}}
+	var repositories []oci.Reference
+	for _, repository := range []string{"ghcr.io/org/cluster-templates/aws-dev", "ghcr.io/org/cluster-templates/aws-prod"} {
+		ref, err := oci.ParseRepository(repository)
+		require.NoError(t, err)
+		repositories = append(repositories, ref)
+	}
+	svc.SetTemplateCatalogs(repositories)
+
+	t.Run("list", func(t *testing.T) {
+		output, err := svc.ListClusterTemplates(ctx)
+		require.NoError(t, err)
+		require.Len(t, output.Templates, 1)
+		assert.Equal(t, "aws-standard", output.Templates[0].Name)
+		assert.Equal(t, "aws", output.Templates[0].Provider)
+		assert.Empty(t, output.Templates[0].CatalogTemplate)
+
+		assert.Equal(t, []api.CatalogTemplate{
+			{
+				Name:          "aws-dev",
+				Repository:    "ghcr.io/org/cluster-templates/aws-dev",
+				Versions:      []string{"v1.10.0", "v1.2.0", "v1.0.0"},
+				LatestVersion: "v1.10.0",
+			},
+			{
+				Name:       "aws-prod",
+				Repository: "ghcr.io/org/cluster-templates/aws-prod",
+				Versions:   []string{},
+			},
+		}, output.Catalog)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "versions of catalog template 'aws-prod' could not be listed")
+	})
+
+	t.Run("install latest version", func(t *testing.T) {
+		output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{CatalogTemplate: "aws-dev"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.True(t, strings.HasPrefix(output.Source, "ghcr.io/org/cluster-templates/aws-dev:v1.10.0@sha256:"), output.Source)
+
+		list, err := svc.ListClusterTemplates(ctx)
+		require.NoError(t, err)
+		require.Len(t, list.Templates, 2)
+		assert.Equal(t, "aws-dev", list.Templates[0].Name)
+		assert.Equal(t, "aws-dev", list.Templates[0].CatalogTemplate)
+		assert.Equal(t, "v1.10.0", list.Templates[0].CatalogVersion)
+		assert.Equal(t, []string{"v1.10.0"}, list.Catalog[0].InstalledVersions)
+	})
+
+	t.Run("install version", func(t *testing.T) {
+		output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{CatalogTemplate: "aws-dev", Version: "v1.2.0", DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(output.Source, "ghcr.io/org/cluster-templates/aws-dev:v1.2.0@"), output.Source)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input api.PublishClusterTemplateInput
+			code  errors.ErrorCode
+		}{
+			{name: "unknown catalog template", input: api.PublishClusterTemplateInput{CatalogTemplate: "gcp-dev"}, code: errors.CodeNotFound},
+			{name: "unknown version", input: api.PublishClusterTemplateInput{CatalogTemplate: "aws-dev", Version: "v3.0.0"}, code: errors.CodeNotFound},
+			{name: "catalog unavailable", input: api.PublishClusterTemplateInput{CatalogTemplate: "aws-prod"}, code: errors.CodeDependencyFailure},
+			{name: "version without catalog template", input: api.PublishClusterTemplateInput{Manifest: testTemplateBundle, Version: "v1.2.0"}, code: errors.CodeInvalidInput},
+			{name: "catalog template and artifact", input: api.PublishClusterTemplateInput{CatalogTemplate: "aws-dev", Artifact: "ghcr.io/org/templates:v1"}, code: errors.CodeInvalidInput},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.PublishClusterTemplate(ctx, tt.input)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			})
+		}
+	})
+}
+
+func TestClusterTemplate(t *testing.T) {
+	clusterClass, _, err := parseClusterClassManifest(testClusterClassManifest, &templateIssues{})
+	require.NoError(t, err)
+	clusterClass.Annotations = map[string]string{
+		KubernetesVersionsAnnotation:         ">=v1.29.0 <v1.32",
+		clusterTemplateDescriptionAnnotation: "AWS development clusters",
+	}
+
+	template := clusterTemplate(clusterClass)
+	assert.Equal(t, "aws", template.Provider)
+	assert.Equal(t, "AWS development clusters", template.Description)
+	assert.Equal(t, []string{">=v1.29.0 <v1.32"}, template.KubernetesVersions)
+	assert.Equal(t, []api.TemplateVariable{
+		{Name: "region", Required: true, Type: "string", Default: "us-east-1", Description: "The AWS region"},
+		{
+			Name: "workerMachineType", Type: "string", Default: "m5.large",
+			Description: "The EC2 instance type of worker nodes", Enum: []interface{}{"m5.large", "m5.xlarge"},
+		},
+	}, template.Variables)
+
+	clusterClass.Labels = map[string]string{"cluster.x-k8s.io/provider": "infrastructure-vsphere"}
+	assert.Equal(t, "vsphere", clusterTemplate(clusterClass).Provider)
+}
+
+func TestSortVersions(t *testing.T) {
+	assert.Equal(t, []string{"v2.0.0", "v1.10.0", "v1.2.0-rc.1", "1.1.0", "latest", "main"},
+		sortVersions([]string{"main", "1.1.0", "v1.2.0-rc.1", "latest", "v2.0.0", "v1.10.0"}))
+	assert.Empty(t, sortVersions(nil))
+}
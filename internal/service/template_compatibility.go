@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// ProviderVersionsAnnotation declares the versions of the management cluster
+// providers a ClusterClass works with, as provider: constraint pairs
+// separated by semicolons, such as
+// "infrastructure-aws: >=v2.4.0 <v3.0; cluster-api: >=v1.6.0". Providers are
+// named by their cluster.x-k8s.io/provider label.
+const ProviderVersionsAnnotation = "capi-mcp.io/provider-versions"
+
+// templateVersionAnnotation records on a Cluster the version of the cluster
+// template it was created from.
+const templateVersionAnnotation = "capi-mcp.io/template-version"
+
+// TemplateCompatibility declares the Kubernetes and provider versions the
+// versions of a cluster template matching Versions work with; an empty
+// Versions matches every version, including templates without one.
+type TemplateCompatibility struct {
+	Template           string
+	Versions           string
+	KubernetesVersions string
+	Providers          map[string]string // constraints by provider label
+}
+
+// SetTemplateCompatibility configures the compatibility matrix checked in
+// addition to the annotations of cluster templates.
+func (s *EnhancedClusterService) SetTemplateCompatibility(matrix []TemplateCompatibility) {
+	s.templateCompatibility = matrix
+}
+
+// templateRequirements are the versions a cluster template works with. Every
+// Kubernetes constraint must hold; the constraints on a provider are joined
+// into one.
+type templateRequirements struct {
+	kubernetes []string
+	providers  map[string]string
+}
+
+// templateVersion returns the version of a ClusterClass: the tag of the OCI
+// artifact publish_cluster_template installed it from, if any.
+func templateVersion(clusterClass *clusterv1.ClusterClass) string {
+	publishedFrom := clusterClass.Annotations[publishedFromAnnotation]
+	if publishedFrom == "" || publishedFrom == "manifest" {
+		return ""
+	}
+	ref, err := oci.ParseReference(publishedFrom)
+	if err != nil {
+		return ""
+	}
+	return ref.Tag
+}
+
+// templateRequirements collects the versions a ClusterClass works with from
+// its annotations and the matrix entries matching its name and version.
+func (s *EnhancedClusterService) templateRequirements(clusterClass *clusterv1.ClusterClass) (templateRequirements, error) {
+	requirements := templateRequirements{kubernetes: []string{}, providers: map[string]string{}}
+	require := func(kubernetes string, providers map[string]string) {
+		if kubernetes != "" {
+			requirements.kubernetes = append(requirements.kubernetes, kubernetes)
+		}
+		for _, provider := range slices.Sorted(maps.Keys(providers)) {
+			requirements.providers[provider] = strings.TrimSpace(requirements.providers[provider] + " " + providers[provider])
+		}
+	}
+
+	providers, err := parseProviderVersions(clusterClass.Annotations[ProviderVersionsAnnotation])
+	if err != nil {
+		return templateRequirements{}, errors.Wrap(err, errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster template '%s' has an invalid %s annotation", clusterClass.Name, ProviderVersionsAnnotation))
+	}
+	require(clusterClass.Annotations[KubernetesVersionsAnnotation], providers)
+
+	version := templateVersion(clusterClass)
+	for _, entry := range s.templateCompatibility {
+		if entry.Template != clusterClass.Name {
+			continue
+		}
+		if entry.Versions != "" {
+			// Templates without a semantic version only match entries for every version
+			if matches, err := validation.VersionSatisfies(version, entry.Versions); err != nil || !matches {
+				continue
+			}
+		}
+		require(entry.KubernetesVersions, entry.Providers)
+	}
+	return requirements, nil
+}
+
+// parseProviderVersions parses a provider versions annotation into the
+// constraint of each provider.
+func parseProviderVersions(annotation string) (map[string]string, error) {
+	providers := map[string]string{}
+	for _, pair := range strings.Split(annotation, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		provider, constraint, ok := strings.Cut(pair, ":")
+		provider, constraint = strings.TrimSpace(provider), strings.TrimSpace(constraint)
+		if !ok || provider == "" || constraint == "" {
+			return nil, fmt.Errorf("expected provider: constraint, got %q", strings.TrimSpace(pair))
+		}
+		if err := validation.ValidateVersionConstraint(constraint); err != nil {
+			return nil, err
+		}
+		if _, exists := providers[provider]; exists {
+			return nil, fmt.Errorf("provider %s is listed more than once", provider)
+		}
+		providers[provider] = constraint
+	}
+	return providers, nil
+}
+
+// installedProviderVersions returns the version of each provider installed on
+// the management cluster by provider label.
+func (s *EnhancedClusterService) installedProviderVersions(ctx context.Context) (map[string]string, error) {
+	deployments, err := s.kubeClient.ListProviderDeployments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inventory, err := s.kubeClient.ListClusterctlProviders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+	for _, provider := range buildManagementProviders(inventory.Items, deployments.Items) {
+		versions[providerLabelValue(provider.Name, provider.Type)] = provider.Version
+	}
+	return versions, nil
+}
+
+// providerIncompatibilities describes the providers whose version does not
+// satisfy a template's requirements. Providers that are not installed or
+// whose version is not a semantic version are not checked.
+func providerIncompatibilities(requirements templateRequirements, versions map[string]string) []string {
+	var problems []string
+	for _, provider := range slices.Sorted(maps.Keys(requirements.providers)) {
+		constraint := requirements.providers[provider]
+		installed, ok := versions[provider]
+		if !ok {
+			continue
+		}
+		if satisfied, err := validation.VersionSatisfies(installed, constraint); err == nil && !satisfied {
+			problems = append(problems, fmt.Sprintf("%s %s is outside the supported range %q", provider, installed, constraint))
+		}
+	}
+	return problems
+}
+
+// checkTemplateCompatibility checks that a new cluster gets the template
+// version it asked for, if any, and that the management cluster providers
+// are versions the template works with. Provider versions that cannot be
+// read are not checked.
+func (s *EnhancedClusterService) checkTemplateCompatibility(ctx context.Context, clusterClass *clusterv1.ClusterClass, requestedVersion string) error {
+	if requestedVersion != "" {
+		if installed := templateVersion(clusterClass); installed != requestedVersion {
+			if installed == "" {
+				installed = "unversioned"
+			}
+			return errors.New(errors.CodePreconditionFailed,
+				fmt.Sprintf("cluster template '%s' is %s, not version %s; publish_cluster_template installs other versions", clusterClass.Name, installed, requestedVersion)).
+				WithDetails("field", "template_version")
+		}
+	}
+
+	requirements, err := s.templateRequirements(clusterClass)
+	if err != nil {
+		return err
+	}
+	if len(requirements.providers) == 0 {
+		return nil
+	}
+	versions, err := s.installedProviderVersions(ctx)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to read provider versions, template provider versions not checked")
+		return nil
+	}
+	if problems := providerIncompatibilities(requirements, versions); len(problems) > 0 {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("cluster template '%s' does not support the installed providers: %s", clusterClass.Name, strings.Join(problems, "; "))).
+			WithDetails("providers", problems)
+	}
+	return nil
+}
+
+// providerUpgradeWarnings advises which cluster templates in the namespace
+// the planned provider versions leave incompatible. Explicitly requested
+// provider versions take the place of the plan's.
+func (s *EnhancedClusterService) providerUpgradeWarnings(ctx context.Context, providers []api.ProviderUpgrade, input api.UpgradeManagementProvidersInput) []string {
+	explicit := map[string]string{} // provider types by reference
+	for _, ref := range input.InfrastructureProviders {
+		explicit[ref] = "InfrastructureProvider"
+	}
+	if input.CoreProvider != "" {
+		explicit[input.CoreProvider] = coreProviderType
+	}
+
+	targets := map[string]string{}
+	if len(explicit) == 0 {
+		for _, provider := range providers {
+			if provider.NextVersion != "" && provider.NextVersion != upToDate {
+				targets[provider.Name] = provider.NextVersion
+			}
+		}
+	}
+	for ref, providerType := range explicit {
+		// References are [namespace/]name[:version]; without a version clusterctl picks the latest
+		_, nameVersion, found := strings.Cut(ref, "/")
+		if !found {
+			nameVersion = ref
+		}
+		if name, version, ok := strings.Cut(nameVersion, ":"); ok {
+			targets[providerLabelValue(name, providerType)] = version
+		}
+	}
+	if len(targets) == 0 || s.kubeClient == nil {
+		return nil
+	}
+
+	clusterClasses, err := s.kubeClient.ListClusterClasses(ctx)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to list ClusterClasses, template compatibility not checked")
+		return []string{"cluster template compatibility could not be checked: failed to list cluster templates"}
+	}
+	sort.Slice(clusterClasses.Items, func(i, j int) bool {
+		return clusterClasses.Items[i].Name < clusterClasses.Items[j].Name
+	})
+
+	var warnings []string
+	for i := range clusterClasses.Items {
+		clusterClass := &clusterClasses.Items[i]
+		requirements, err := s.templateRequirements(clusterClass)
+		if err != nil {
+			warnings = append(warnings, errors.GetUserMessage(err))
+			continue
+		}
+		for _, problem := range providerIncompatibilities(requirements, targets) {
+			warnings = append(warnings, fmt.Sprintf("upgrading leaves cluster template '%s' incompatible: %s", clusterClass.Name, problem))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// testPublishedFrom is the source of a ClusterClass installed from version
+// v1.2.0 of a catalog template.
+var testPublishedFrom = "ghcr.io/org/cluster-templates/aws-dev:v1.2.0@sha256:" + strings.Repeat("1", 64)
+
+// testCompatibilityMatrix restricts aws-dev v1.x to Kubernetes v1.30 and
+// newer, and every version to AWS provider v2.
+var testCompatibilityMatrix = []TemplateCompatibility{
+	{Template: "aws-dev", Versions: ">=v1.0.0 <v2.0", KubernetesVersions: ">=v1.30.0"},
+	{Template: "aws-dev", Providers: map[string]string{"infrastructure-aws": ">=v2.0 <v3.0"}},
+	{Template: "aws-prod", KubernetesVersions: ">=v1.31.0"},
+}
+
+func TestEnhancedClusterService_TemplateRequirements(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+	svc.SetTemplateCompatibility(testCompatibilityMatrix)
+
+	versioned := createTestClusterClass("aws-dev")
+	versioned.Annotations = map[string]string{
+		publishedFromAnnotation:      testPublishedFrom,
+		KubernetesVersionsAnnotation: "<v1.33",
+		ProviderVersionsAnnotation:   "infrastructure-aws: >=v2.4.0; cluster-api: >=v1.6.0",
+	}
+	requirements, err := svc.templateRequirements(versioned)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"<v1.33", ">=v1.30.0"}, requirements.kubernetes)
+	assert.Equal(t, map[string]string{
+		"infrastructure-aws": ">=v2.4.0 >=v2.0 <v3.0",
+		"cluster-api":        ">=v1.6.0",
+	}, requirements.providers)
+
+	// Entries for some versions do not match templates without a version
+	requirements, err = svc.templateRequirements(createTestClusterClass("aws-dev"))
+	require.NoError(t, err)
+	assert.Empty(t, requirements.kubernetes)
+	assert.Equal(t, map[string]string{"infrastructure-aws": ">=v2.0 <v3.0"}, requirements.providers)
+
+	invalid := createTestClusterClass("aws-invalid")
+	invalid.Annotations = map[string]string{ProviderVersionsAnnotation: "infrastructure-aws >=v2.4.0"}
+	_, err = svc.templateRequirements(invalid)
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+}
+
+func TestParseProviderVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		expected   map[string]string
+		wantErr    bool
+	}{
+		{name: "empty", annotation: "", expected: map[string]string{}},
+		{
+			name:       "providers",
+			annotation: "infrastructure-aws: >=v2.4.0 <v3.0; cluster-api: >=v1.6.0;",
+			expected:   map[string]string{"infrastructure-aws": ">=v2.4.0 <v3.0", "cluster-api": ">=v1.6.0"},
+		},
+		{name: "missing constraint", annotation: "infrastructure-aws:", wantErr: true},
+		{name: "invalid constraint", annotation: "infrastructure-aws: >=two", wantErr: true},
+		{name: "duplicate provider", annotation: "cluster-api: >=v1.6.0; cluster-api: <v1.8.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers, err := parseProviderVersions(tt.annotation)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, providers)
+		})
+	}
+}
+
+func TestEnhancedClusterService_CheckTemplateCompatibility(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t,
+		createTestInventoryProvider("cluster-api", "capi-system", "CoreProvider", "v1.6.8"),
+		createTestInventoryProvider("aws", "capa-system", "InfrastructureProvider", "v2.5.0"),
+	)
+	svc.SetTemplateCompatibility(testCompatibilityMatrix)
+
+	versioned := createTestClusterClass("aws-dev")
+	versioned.Annotations = map[string]string{publishedFromAnnotation: testPublishedFrom}
+	incompatible := createTestClusterClass("aws-next")
+	incompatible.Annotations = map[string]string{ProviderVersionsAnnotation: "infrastructure-aws: >=v3.0"}
+
+	tests := []struct {
+		name         string
+		clusterClass string
+		version      string
+		wantErr      string
+	}{
+		{name: "compatible", clusterClass: "aws-dev"},
+		{name: "pinned version", clusterClass: "aws-dev", version: "v1.2.0"},
+		{name: "other pinned version", clusterClass: "aws-dev", version: "v1.1.0", wantErr: "is v1.2.0, not version v1.1.0"},
+		{name: "pinned unversioned template", clusterClass: "aws-next", version: "v1.2.0", wantErr: "is unversioned"},
+		{name: "incompatible provider", clusterClass: "aws-next", wantErr: `infrastructure-aws v2.5.0 is outside the supported range ">=v3.0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterClass := versioned
+			if tt.clusterClass == "aws-next" {
+				clusterClass = incompatible
+			}
+			err := svc.checkTemplateCompatibility(ctx, clusterClass, tt.version)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+			assert.Contains(t, errors.GetUserMessage(err), tt.wantErr)
+		})
+	}
+
+	t.Run("matrix kubernetes versions", func(t *testing.T) {
+		err := svc.validateKubernetesVersionSupport(ctx, "v1.29.4", versioned, "aws")
+		require.Error(t, err)
+		assert.Contains(t, errors.GetUserMessage(err), "does not support kubernetes version v1.29.4")
+		assert.NoError(t, svc.validateKubernetesVersionSupport(ctx, "v1.30.2", versioned, "aws"))
+	})
+
+	t.Run("pins the cluster to the template version", func(t *testing.T) {
+		cluster := svc.buildClusterResource(api.CreateClusterInput{ClusterName: "dev", KubernetesVersion: "v1.30.2"}, versioned)
+		assert.Equal(t, "v1.2.0", cluster.Annotations[templateVersionAnnotation])
+		assert.Empty(t, svc.buildClusterResource(api.CreateClusterInput{ClusterName: "next"}, incompatible).Annotations)
+	})
+}
+
+func TestEnhancedClusterService_ListClusterTemplates_Compatibility(t *testing.T) {
+	incompatible := createTestClusterClass("aws-next")
+	incompatible.Annotations = map[string]string{ProviderVersionsAnnotation: "infrastructure-aws: >=v3.0"}
+	svc, _ := setupEnhancedTestService(t,
+		createTestClusterClass("aws-dev"),
+		incompatible,
+		createTestInventoryProvider("aws", "capa-system", "InfrastructureProvider", "v2.5.0"),
+	)
+	svc.SetTemplateCompatibility(testCompatibilityMatrix)
+
+	output, err := svc.ListClusterTemplates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, output.Templates, 2)
+	assert.Equal(t, map[string]string{"infrastructure-aws": ">=v2.0 <v3.0"}, output.Templates[0].ProviderVersions)
+	assert.Empty(t, output.Templates[0].Incompatibilities)
+	assert.Equal(t, []string{`infrastructure-aws v2.5.0 is outside the supported range ">=v3.0"`}, output.Templates[1].Incompatibilities)
+	assert.Empty(t, output.Warnings)
+}
+
+func TestEnhancedClusterService_UpgradeManagementProviders_Compatibility(t *testing.T) {
+	ctx := context.Background()
+	pinned := createTestClusterClass("aws-pinned")
+	pinned.Annotations = map[string]string{ProviderVersionsAnnotation: "cluster-api: <v1.6.5; infrastructure-aws: <v3.0"}
+	svc, _ := setupEnhancedTestService(t, pinned, createTestClusterClass("aws-dev"))
+	svc.SetProviderUpgradeOptions(ProviderUpgradeOptions{Enabled: true})
+	fake := &fakeClusterctl{planOut: testUpgradePlan}
+	svc.runClusterctl = fake.run
+
+	output, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`upgrading leaves cluster template 'aws-pinned' incompatible: cluster-api v1.6.8 is outside the supported range "<v1.6.5"`,
+	}, output.Warnings)
+
+	output, err = svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{
+		Apply:                   true,
+		InfrastructureProviders: []string{"capa-system/aws:v3.1.0"},
+	})
+	require.NoError(t, err)
+	assert.True(t, output.Applied)
+	assert.Equal(t, []string{
+		`upgrading leaves cluster template 'aws-pinned' incompatible: infrastructure-aws v3.1.0 is outside the supported range "<v3.0"`,
+	}, output.Warnings)
+	assert.Len(t, fake.calls, 3)
+}
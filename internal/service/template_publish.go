@@ -0,0 +1,370 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+)
+
+// publishedFromAnnotation records where the objects of a published cluster
+// template came from: "manifest", or the artifact reference with its digest.
+const publishedFromAnnotation = "capi-mcp.io/published-from"
+
+// Actions publish_cluster_template takes on the objects of a template.
+const (
+	publishActionCreate    = "create"
+	publishActionUpdate    = "update"
+	publishActionUnchanged = "unchanged"
+)
+
+// publishTimeout bounds pulling, diffing and applying a cluster template.
+const publishTimeout = 2 * time.Minute
+
+// templateRegistry lists and pulls the OCI artifacts cluster templates are
+// distributed as.
+type templateRegistry interface {
+	ListTags(ctx context.Context, repository oci.Reference) ([]string, error)
+	Pull(ctx context.Context, ref oci.Reference) (*oci.Artifact, error)
+}
+
+// SetTemplateRegistry configures the OCI client publish_cluster_template
+// pulls template artifacts with. Without it only manifests can be published.
+func (s *EnhancedClusterService) SetTemplateRegistry(client *oci.Client) {
+	s.templateRegistry = client
+}
+
+// PublishClusterTemplate applies a ClusterClass and the infrastructure,
+// bootstrap and control plane templates it references to the management
+// cluster, from a manifest, an OCI artifact or a version of a catalog
+// template. The bundle must pass the
+// validate_cluster_template checks, and each object is diffed against the
+// version already in the namespace, so a dry run shows exactly what
+// publishing would change. Templates are applied before the ClusterClass
+// that references them.
+func (s *EnhancedClusterService) PublishClusterTemplate(ctx context.Context, input api.PublishClusterTemplateInput) (*api.PublishClusterTemplateOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("PublishClusterTemplate").WithCluster("", "")
+	logger.Info("Publishing cluster template", "artifact", input.Artifact, "manifest_bytes", len(input.Manifest), "dry_run", input.DryRun)
+
+	sources := 0
+	for _, source := range []string{input.Manifest, input.Artifact, input.CatalogTemplate} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		err := errors.New(errors.CodeInvalidInput, "exactly one of manifest, artifact and catalog template is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Version != "" && input.CatalogTemplate == "" {
+		err := errors.New(errors.CodeInvalidInput, "version requires a catalog template").
+			WithDetails("field", "version")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if len(input.Manifest) > maxTemplateManifestBytes {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("manifest is larger than %d bytes", maxTemplateManifestBytes)).
+			WithDetails("field", "manifest")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	publishCtx, cancel := budget.Sub(ctx, publishTimeout)
+	defer cancel()
+
+	manifest, source, artifact := input.Manifest, "manifest", input.Artifact
+	if input.CatalogTemplate != "" {
+		var err error
+		artifact, err = s.resolveCatalogTemplate(publishCtx, input.CatalogTemplate, input.Version)
+		if err != nil {
+			logger.WithError(err).Error("Failed to resolve catalog template")
+			return nil, err
+		}
+	}
+	if artifact != "" {
+		var err error
+		manifest, source, err = s.pullTemplateArtifact(publishCtx, artifact)
+		if err != nil {
+			logger.WithError(err).Error("Failed to pull cluster template artifact")
+			return nil, err
+		}
+	}
+
+	namespace := s.kubeClient.Namespace(publishCtx)
+	objects, err := parseTemplateBundle(manifest, namespace)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid cluster template bundle")
+		return nil, err
+	}
+
+	issues := &templateIssues{}
+	clusterClass, provided, err := parseClusterClassManifest(manifest, issues)
+	if err != nil {
+		logger.WithError(err).Warn("Invalid cluster template bundle")
+		return nil, err
+	}
+	clusterClass.Namespace = namespace
+	validateClusterClassStructure(clusterClass, issues)
+	validateClusterClassVariables(clusterClass, issues)
+	validateClusterClassPatches(clusterClass, issues)
+	s.validateClusterClassTemplates(publishCtx, clusterClass, provided, issues)
+	if issues.errors() > 0 {
+		err := errors.New(errors.CodeValidationFailed,
+			fmt.Sprintf("cluster template '%s' has %d error(s) and was not published; run validate_cluster_template for details",
+				clusterClass.Name, issues.errors())).
+			WithDetails("issues", issues.list)
+		logger.WithError(err).Warn("Cluster template failed validation")
+		return nil, err
+	}
+
+	output := &api.PublishClusterTemplateOutput{
+		TemplateName: clusterClass.Name,
+		Source:       source,
+		DryRun:       input.DryRun,
+		Objects:      make([]api.PublishedObject, 0, len(objects)),
+		Changes:      []api.ClusterStateDiff{},
+		Issues:       issues.list,
+	}
+
+	// Diff each object against the version in the namespace
+	existing := make([]*unstructured.Unstructured, len(objects))
+	for i, obj := range objects {
+		key := obj.GetKind() + "/" + obj.GetName()
+		current, err := s.kubeClient.GetObject(publishCtx, obj.GroupVersionKind(), namespace, obj.GetName())
+		switch {
+		case apierrors.IsNotFound(err):
+			output.Objects = append(output.Objects, api.PublishedObject{Object: key, Action: publishActionCreate})
+			output.Changes = append(output.Changes, api.ClusterStateDiff{Object: key, Change: changeAdded})
+			continue
+		case meta.IsNoMatchError(err):
+			err := errors.New(errors.CodePreconditionFailed,
+				fmt.Sprintf("%s %s is not installed in the management cluster; install its provider before publishing templates for it",
+					obj.GetKind(), obj.GetAPIVersion()))
+			logger.WithError(err).Error("Template kind not installed")
+			return nil, err
+		case err != nil:
+			logger.WithError(err).Error("Failed to get existing object", "object", key)
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to get %s", key))
+		}
+		existing[i] = current
+
+		changed := len(output.Changes)
+		for _, field := range []string{"spec", "metadata.labels"} {
+			fields := strings.Split(field, ".")
+			oldValue, _, _ := unstructured.NestedFieldNoCopy(current.Object, fields...)
+			newValue, _, _ := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+			output.Changes = diffValues(output.Changes, key, field, pruneEmpty(oldValue), pruneEmpty(newValue))
+		}
+		if len(output.Changes) == changed {
+			output.Objects = append(output.Objects, api.PublishedObject{Object: key, Action: publishActionUnchanged})
+			continue
+		}
+		output.Objects = append(output.Objects, api.PublishedObject{Object: key, Action: publishActionUpdate})
+		if obj.GetKind() != "ClusterClass" {
+			output.Warnings = append(output.Warnings, fmt.Sprintf(
+				"%s is changed in place, which most providers reject and which affects every cluster using it; publish the changed template under a new name and reference that from the ClusterClass instead", key))
+		}
+	}
+	output.Diff = formatStateDiff(output.Changes)
+
+	counts := map[string]int{}
+	for _, object := range output.Objects {
+		counts[object.Action]++
+	}
+	switch {
+	case counts[publishActionUnchanged] == len(objects):
+		output.Message = fmt.Sprintf("Cluster template '%s' is already published and unchanged", clusterClass.Name)
+		logger.Info("Cluster template unchanged", "template", clusterClass.Name)
+		return output, nil
+	case input.DryRun:
+		output.Message = fmt.Sprintf("Dry run: publishing cluster template '%s' would create %d and update %d object(s)",
+			clusterClass.Name, counts[publishActionCreate], counts[publishActionUpdate])
+		logger.Info("Dry run of cluster template publish", "template", clusterClass.Name, "changes", len(output.Changes))
+		return output, nil
+	}
+
+	for i, obj := range objects {
+		action := output.Objects[i].Action
+		if action == publishActionUnchanged {
+			continue
+		}
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[publishedFromAnnotation] = source
+		obj.SetAnnotations(annotations)
+
+		if action == publishActionCreate {
+			err = s.kubeClient.CreateObject(publishCtx, obj)
+		} else {
+			obj.SetResourceVersion(existing[i].GetResourceVersion())
+			obj.SetFinalizers(existing[i].GetFinalizers())
+			obj.SetOwnerReferences(existing[i].GetOwnerReferences())
+			err = s.kubeClient.UpdateObject(publishCtx, obj)
+		}
+		if err != nil {
+			logger.WithError(err).Error("Failed to apply cluster template object", "object", output.Objects[i].Object)
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI,
+				fmt.Sprintf("failed to %s %s; objects before it in the bundle were applied", action, output.Objects[i].Object)).
+				WithDetails("object", output.Objects[i].Object)
+		}
+	}
+
+	output.Applied = true
+	output.Message = fmt.Sprintf("Published cluster template '%s' from %s: created %d and updated %d object(s)",
+		clusterClass.Name, source, counts[publishActionCreate], counts[publishActionUpdate])
+	logger.Info("Published cluster template", "template", clusterClass.Name, "source", source, "changes", len(output.Changes))
+	return output, nil
+}
+
+// pullTemplateArtifact pulls an OCI artifact of cluster templates and joins
+// its YAML files into one manifest. It returns the manifest and the artifact
+// reference pinned to the digest that was pulled, keeping its tag.
+func (s *EnhancedClusterService) pullTemplateArtifact(ctx context.Context, reference string) (string, string, error) {
+	if s.templateRegistry == nil {
+		return "", "", errors.New(errors.CodeUnavailable, "publishing cluster templates from OCI artifacts is not configured")
+	}
+	ref, err := oci.ParseReference(reference)
+	if err != nil {
+		return "", "", errors.Wrap(err, errors.CodeInvalidInput, "invalid artifact reference").
+			WithDetails("field", "artifact")
+	}
+
+	artifact, err := s.templateRegistry.Pull(ctx, ref)
+	if err != nil {
+		if stderrors.Is(err, oci.ErrRegistryNotAllowed) {
+			return "", "", errors.Wrap(err, errors.CodeForbidden,
+				fmt.Sprintf("registry %s is not in the allowed template registries", ref.Registry)).
+				WithDetails("field", "artifact")
+		}
+		return "", "", errors.Wrap(err, errors.CodeDependencyFailure, fmt.Sprintf("failed to pull artifact %s", ref))
+	}
+
+	var documents []string
+	for _, file := range artifact.Files {
+		if ext := path.Ext(file.Name); ext == ".yaml" || ext == ".yml" {
+			documents = append(documents, strings.TrimSpace(string(file.Data)))
+		}
+	}
+	if len(documents) == 0 {
+		return "", "", errors.New(errors.CodeInvalidInput, fmt.Sprintf("artifact %s contains no YAML files", ref)).
+			WithDetails("field", "artifact")
+	}
+	manifest := strings.Join(documents, "\n---\n")
+	if len(manifest) > maxTemplateManifestBytes {
+		return "", "", errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("artifact %s holds more than %d bytes of manifests", ref, maxTemplateManifestBytes)).
+			WithDetails("field", "artifact")
+	}
+
+	pinned := ref
+	pinned.Digest = artifact.Digest
+	return manifest, pinned.String(), nil
+}
+
+// pruneEmpty drops empty maps and lists from a generic JSON value, which the
+// API server adds when it round-trips an object through its Go types, so
+// they do not show up as changes.
+func pruneEmpty(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		pruned := map[string]interface{}{}
+		for key, item := range typed {
+			if item = pruneEmpty(item); item != nil {
+				pruned[key] = item
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []interface{}:
+		if len(typed) == 0 {
+			return nil
+		}
+		pruned := make([]interface{}, len(typed))
+		for i, item := range typed {
+			pruned[i] = pruneEmpty(item)
+		}
+		return pruned
+	}
+	return value
+}
+
+// parseTemplateBundle decodes the objects of a cluster template bundle into
+// the namespace they are published to, templates first and the ClusterClass
+// last. A bundle holds only a ClusterClass and templates, so publishing
+// cannot be used to apply arbitrary objects.
+func parseTemplateBundle(manifest, namespace string) ([]*unstructured.Unstructured, error) {
+	var templates, clusterClasses []*unstructured.Unstructured
+	seen := map[string]bool{}
+	for i, document := range strings.Split("\n"+manifest, "\n---") {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+		// Decoding through JSON keeps integers as int64, as the API server returns them
+		data, err := yaml.YAMLToJSON([]byte(document))
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInvalidInput, fmt.Sprintf("document %d of the manifest is not valid YAML", i+1)).
+				WithDetails("field", "manifest")
+		}
+		if strings.TrimSpace(string(data)) == "null" {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return nil, errors.Wrap(err, errors.CodeInvalidInput, fmt.Sprintf("document %d of the manifest is not a Kubernetes object", i+1)).
+				WithDetails("field", "manifest")
+		}
+
+		key := obj.GetKind() + "/" + obj.GetName()
+		switch {
+		case obj.GetName() == "":
+			return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("%s in document %d has no name", obj.GetKind(), i+1)).
+				WithDetails("field", "manifest")
+		case obj.GetKind() != "ClusterClass" && !strings.HasSuffix(obj.GetKind(), "Template"):
+			return nil, errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s is not a ClusterClass or template; a cluster template bundle holds only those", key)).
+				WithDetails("field", "manifest")
+		case obj.GetNamespace() != "" && obj.GetNamespace() != namespace:
+			return nil, errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s is in namespace %s but templates are published to %s", key, obj.GetNamespace(), namespace)).
+				WithDetails("field", "manifest")
+		case seen[key]:
+			return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("manifest contains %s more than once", key)).
+				WithDetails("field", "manifest")
+		}
+		seen[key] = true
+
+		obj.SetNamespace(namespace)
+		obj.SetResourceVersion("")
+		obj.SetUID("")
+		delete(obj.Object, "status")
+		if obj.GetKind() == "ClusterClass" {
+			clusterClasses = append(clusterClasses, obj)
+		} else {
+			templates = append(templates, obj)
+		}
+	}
+	return append(templates, clusterClasses...), nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/oci"
+)
+
+// testTemplateBundle is the test ClusterClass with all of its templates.
+const testTemplateBundle = testClusterClassManifest + `---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: AWSMachineTemplate
+metadata:
+  name: aws-dev-worker
+spec:
+  template:
+    spec:
+      instanceType: m5.large
+`
+
+// fakeTemplateRegistry serves the test template bundle under every tag of
+// its repositories; some registries fail.
+type fakeTemplateRegistry struct {
+	tags map[string][]string // by repository
+}
+
+func (f *fakeTemplateRegistry) ListTags(ctx context.Context, repository oci.Reference) ([]string, error) {
+	tags, ok := f.tags[repository.Repository]
+	if !ok {
+		return nil, fmt.Errorf("GET https://%s/v2/%s/tags/list returned 404 Not Found", repository.Registry, repository.Repository)
+	}
+	return tags, nil
+}
+
+func (f *fakeTemplateRegistry) Pull(ctx context.Context, ref oci.Reference) (*oci.Artifact, error) {
+	switch ref.Registry {
+	case "blocked.example.com":
+		return nil, fmt.Errorf("%w: %s", oci.ErrRegistryNotAllowed, ref.Registry)
+	case "unreachable.example.com":
+		return nil, fmt.Errorf("connection refused")
+	}
+	files := []oci.File{{Name: "README.md", Data: []byte("# Templates")}}
+	if ref.Tag != "empty" {
+		files = append(files, oci.File{Name: "bundle/aws-dev.yaml", Data: []byte(testTemplateBundle)})
+	}
+	return &oci.Artifact{Reference: ref.String(), Digest: "sha256:" + strings.Repeat("1", 64), Files: files}, nil
+}
+
+func TestEnhancedClusterService_PublishClusterTemplate(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+
+	actions := func(output *api.PublishClusterTemplateOutput) map[string]string {
+		byObject := map[string]string{}
+		for _, object := range output.Objects {
+			byObject[object.Object] = object.Action
+		}
+		return byObject
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Manifest: testTemplateBundle, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, "aws-dev", output.TemplateName)
+		assert.False(t, output.Applied)
+		require.Len(t, output.Objects, 5)
+		assert.Equal(t, api.PublishedObject{Object: "ClusterClass/aws-dev", Action: publishActionCreate}, output.Objects[4])
+		assert.Contains(t, output.Diff, "AWSMachineTemplate/aws-dev-worker: added")
+		assert.Equal(t, "Dry run: publishing cluster template 'aws-dev' would create 5 and update 0 object(s)", output.Message)
+
+		_, err = svc.kubeClient.GetClusterClass(ctx, "aws-dev")
+		assert.Error(t, err)
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Manifest: testTemplateBundle})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.Equal(t, "Published cluster template 'aws-dev' from manifest: created 5 and updated 0 object(s)", output.Message)
+
+		clusterClass, err := svc.kubeClient.GetClusterClass(ctx, "aws-dev")
+		require.NoError(t, err)
+		assert.Equal(t, "manifest", clusterClass.Annotations[publishedFromAnnotation])
+		template, err := svc.kubeClient.GetObject(ctx,
+			schema.FromAPIVersionAndKind("infrastructure.cluster.x-k8s.io/v1beta2", "AWSMachineTemplate"), testNamespace, "aws-dev-worker")
+		require.NoError(t, err)
+		assert.Equal(t, "manifest", template.GetAnnotations()[publishedFromAnnotation])
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Manifest: testTemplateBundle})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Empty(t, output.Changes)
+		for object, action := range actions(output) {
+			assert.Equal(t, publishActionUnchanged, action, object)
+		}
+		assert.Equal(t, "Cluster template 'aws-dev' is already published and unchanged", output.Message)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		manifest := strings.Replace(testTemplateBundle, "default: us-east-1", "default: eu-west-1", 1)
+		manifest = strings.Replace(manifest, "instanceType: m5.large", "instanceType: m5.xlarge", 1)
+
+		output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Manifest: manifest, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"ClusterClass/aws-dev":                              publishActionUpdate,
+			"AWSMachineTemplate/aws-dev-worker":                 publishActionUpdate,
+			"AWSClusterTemplate/aws-dev-cluster":                publishActionUnchanged,
+			"KubeadmControlPlaneTemplate/aws-dev-control-plane": publishActionUnchanged,
+			"KubeadmConfigTemplate/aws-dev-worker":              publishActionUnchanged,
+		}, actions(output))
+		assert.Contains(t, output.Diff, `ClusterClass/aws-dev: spec.variables[0].schema.openAPIV3Schema.default changed from "us-east-1" to "eu-west-1"`)
+		assert.Contains(t, output.Diff, `AWSMachineTemplate/aws-dev-worker: spec.template.spec.instanceType changed from "m5.large" to "m5.xlarge"`)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "AWSMachineTemplate/aws-dev-worker is changed in place")
+
+		output, err = svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Manifest: manifest})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		clusterClass, err := svc.kubeClient.GetClusterClass(ctx, "aws-dev")
+		require.NoError(t, err)
+		assert.JSONEq(t, `"eu-west-1"`, string(clusterClass.Spec.Variables[0].Schema.OpenAPIV3Schema.Default.Raw))
+	})
+
+	t.Run("validation failure", func(t *testing.T) {
+		_, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Manifest: testBrokenClusterClassManifest})
+		assert.Equal(t, errors.CodeValidationFailed, errors.GetErrorCode(err))
+
+		_, err = svc.kubeClient.GetClusterClass(ctx, "aws-broken")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input api.PublishClusterTemplateInput
+			code  errors.ErrorCode
+		}{
+			{name: "neither", input: api.PublishClusterTemplateInput{}, code: errors.CodeInvalidInput},
+			{name: "both", input: api.PublishClusterTemplateInput{Manifest: testTemplateBundle, Artifact: "ghcr.io/org/templates:v1"}, code: errors.CodeInvalidInput},
+			{name: "other kind", input: api.PublishClusterTemplateInput{Manifest: testTemplateBundle + "---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: x\n"}, code: errors.CodeInvalidInput},
+			{name: "other namespace", input: api.PublishClusterTemplateInput{Manifest: strings.Replace(testTemplateBundle, "name: aws-dev\n", "name: aws-dev\n  namespace: other\n", 1)}, code: errors.CodeInvalidInput},
+			{name: "duplicate", input: api.PublishClusterTemplateInput{Manifest: testTemplateBundle + "---\napiVersion: bootstrap.cluster.x-k8s.io/v1beta1\nkind: KubeadmConfigTemplate\nmetadata:\n  name: aws-dev-worker\n"}, code: errors.CodeInvalidInput},
+			{name: "artifacts not configured", input: api.PublishClusterTemplateInput{Artifact: "ghcr.io/org/templates:v1"}, code: errors.CodeUnavailable},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.PublishClusterTemplate(ctx, tt.input)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			})
+		}
+	})
+}
+
+func TestEnhancedClusterService_PublishClusterTemplate_Artifact(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+	digest := "sha256:" + strings.Repeat("1", 64)
+	svc.templateRegistry = &fakeTemplateRegistry{}
+
+	output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Artifact: "ghcr.io/org/templates:v1.0.0"})
+	require.NoError(t, err)
+	assert.True(t, output.Applied)
+	assert.Equal(t, "ghcr.io/org/templates:v1.0.0@"+digest, output.Source)
+
+	clusterClass, err := svc.kubeClient.GetClusterClass(ctx, "aws-dev")
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/org/templates:v1.0.0@"+digest, clusterClass.Annotations[publishedFromAnnotation])
+
+	tests := []struct {
+		name     string
+		artifact string
+		code     errors.ErrorCode
+	}{
+		{name: "invalid reference", artifact: "templates:v1", code: errors.CodeInvalidInput},
+		{name: "registry not allowed", artifact: "blocked.example.com/templates:v1", code: errors.CodeForbidden},
+		{name: "pull failure", artifact: "unreachable.example.com/templates:v1", code: errors.CodeDependencyFailure},
+		{name: "no manifests", artifact: "ghcr.io/org/templates:empty", code: errors.CodeInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{Artifact: tt.artifact})
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
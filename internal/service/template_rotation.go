@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+// templateGenerationsAnnotation records on a Cluster the generations of its
+// ClusterClass and the templates the ClusterClass references when the cluster
+// was created or last refreshed, as a JSON object keyed by Kind/name.
+const templateGenerationsAnnotation = "capi-mcp.io/template-generations"
+
+// templateGenerations returns the generations of a ClusterClass and the
+// templates it references by Kind/name. Templates that cannot be read are left
+// out and reported as deleted once they are compared.
+func (s *EnhancedClusterService) templateGenerations(ctx context.Context, clusterClass *clusterv1.ClusterClass) map[string]int64 {
+	generations := map[string]int64{"ClusterClass/" + clusterClass.Name: clusterClass.Generation}
+	for _, ref := range clusterClassTemplateRefs(&clusterClass.Spec) {
+		key := ref.Kind + "/" + ref.Name
+		if _, ok := generations[key]; ok || ref.APIVersion == "" || ref.Name == "" {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = clusterClass.Namespace
+		}
+		template, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		if err != nil {
+			continue
+		}
+		generations[key] = template.GetGeneration()
+	}
+	return generations
+}
+
+// recordTemplateGenerations annotates a cluster with the current generations
+// of its ClusterClass and templates.
+func (s *EnhancedClusterService) recordTemplateGenerations(ctx context.Context, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) {
+	encoded, err := json.Marshal(s.templateGenerations(ctx, clusterClass))
+	if err != nil {
+		return
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[templateGenerationsAnnotation] = string(encoded)
+}
+
+// CheckTemplateRotation reports the clusters running stale template
+// generations: those whose ClusterClass or referenced templates changed since
+// they were created or last refreshed, and those with machines created from an
+// earlier machine template than their control plane or MachineDeployment now
+// uses. Without a cluster name every cluster in the namespace is checked and
+// only stale clusters are listed.
+func (s *EnhancedClusterService) CheckTemplateRotation(ctx context.Context, input api.CheckTemplateRotationInput) (*api.CheckTemplateRotationOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("CheckTemplateRotation").WithCluster(input.ClusterName, "")
+	logger.Info("Checking template rotation")
+
+	if input.ClusterName != "" {
+		if err := validation.NewValidator().ValidateClusterName(input.ClusterName); err != nil {
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	checkCtx, cancel := budget.Sub(ctx, 60*time.Second)
+	defer cancel()
+
+	output := &api.CheckTemplateRotationOutput{Clusters: []api.TemplateRotation{}}
+	if input.ClusterName != "" {
+		cluster, err := s.getRotationCluster(checkCtx, input.ClusterName)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get cluster")
+			return nil, err
+		}
+		rotation, err := s.templateRotation(checkCtx, cluster)
+		if err != nil {
+			logger.WithError(err).Error("Failed to check template rotation")
+			return nil, err
+		}
+		output.Clusters = append(output.Clusters, rotation)
+		output.Checked = 1
+		return output, nil
+	}
+
+	clusters, err := s.kubeClient.ListClusters(checkCtx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list clusters")
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list clusters")
+	}
+	sort.Slice(clusters.Items, func(i, j int) bool {
+		return clusters.Items[i].Name < clusters.Items[j].Name
+	})
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		rotation, err := s.templateRotation(checkCtx, cluster)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to check template rotation", "cluster", cluster.Name)
+			output.Warnings = append(output.Warnings, fmt.Sprintf("cluster '%s' could not be checked: %s", cluster.Name, errors.GetUserMessage(err)))
+			continue
+		}
+		output.Checked++
+		if rotation.Stale {
+			output.Clusters = append(output.Clusters, rotation)
+		}
+	}
+	logger.Info("Template rotation checked", "checked", output.Checked, "stale", len(output.Clusters))
+	return output, nil
+}
+
+// RefreshClusterTemplates rolls out the control plane and MachineDeployments
+// of a cluster that run stale template generations, so that their machines
+// are recreated from the current templates, and records the current template
+// generations on the cluster.
+func (s *EnhancedClusterService) RefreshClusterTemplates(ctx context.Context, input api.RefreshClusterTemplatesInput) (*api.RefreshClusterTemplatesOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RefreshClusterTemplates").WithCluster(input.ClusterName, "")
+	logger.Info("Refreshing cluster templates", "dry_run", input.DryRun)
+
+	if err := validation.NewValidator().ValidateClusterName(input.ClusterName); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	refreshCtx, cancel := budget.Sub(ctx, 60*time.Second)
+	defer cancel()
+
+	cluster, err := s.getRotationCluster(refreshCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get cluster")
+		return nil, err
+	}
+	rotation, err := s.templateRotation(refreshCtx, cluster)
+	if err != nil {
+		logger.WithError(err).Error("Failed to check template rotation")
+		return nil, err
+	}
+
+	output := &api.RefreshClusterTemplatesOutput{
+		ClusterName: cluster.Name,
+		DryRun:      input.DryRun,
+		Rotation:    rotation,
+		RolledOut:   []string{},
+	}
+	for _, target := range rotation.Targets {
+		output.RolledOut = append(output.RolledOut, target.Target)
+	}
+	switch {
+	case !rotation.Stale && (rotation.Tracked || cluster.Spec.Topology == nil):
+		output.Message = fmt.Sprintf("Cluster '%s' runs the current templates, nothing to refresh", cluster.Name)
+		return output, nil
+	case input.DryRun:
+		output.Message = fmt.Sprintf("Dry run: refreshing cluster '%s' would roll out %d target(s)", cluster.Name, len(output.RolledOut))
+		return output, nil
+	}
+
+	now := metav1.Now()
+	for _, target := range output.RolledOut {
+		if err := s.startRollout(refreshCtx, cluster, target, now); err != nil {
+			logger.WithError(err).Error("Failed to start rollout", "target", target)
+			return nil, err
+		}
+	}
+
+	// Record the templates the cluster now rolls out to
+	if cluster.Spec.Topology != nil {
+		clusterClass, err := s.kubeClient.GetClusterClass(refreshCtx, cluster.Spec.Topology.Class)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get ClusterClass")
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+		}
+		s.recordTemplateGenerations(refreshCtx, cluster, clusterClass)
+		if err := s.kubeClient.UpdateCluster(refreshCtx, cluster); err != nil {
+			logger.WithError(err).Error("Failed to record template generations")
+			if apierrors.IsConflict(err) {
+				return nil, errors.Wrap(err, errors.CodePreconditionFailed, "cluster was modified concurrently, retry the request")
+			}
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to record template generations")
+		}
+	}
+
+	output.Applied = true
+	output.Message = fmt.Sprintf("Started a rollout of %d target(s) of cluster '%s' to its current templates", len(output.RolledOut), cluster.Name)
+	if len(output.RolledOut) == 0 {
+		output.Message = fmt.Sprintf("Recorded the current template generations of cluster '%s'; no machines needed a rollout", cluster.Name)
+	}
+	logger.Info("Cluster templates refreshed", "rolled_out", output.RolledOut)
+	return output, nil
+}
+
+// getRotationCluster gets a cluster by name, mapping a missing cluster to a
+// not found error.
+func (s *EnhancedClusterService) getRotationCluster(ctx context.Context, name string) (*clusterv1.Cluster, error) {
+	cluster, err := s.kubeClient.GetClusterByName(ctx, name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster '%s' not found", name))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster")
+	}
+	return cluster, nil
+}
+
+// templateRotation compares a cluster with its current templates. Targets are
+// stale when they were patched from a template that changed since the
+// generations were recorded or when some of their machines were cloned from
+// an earlier machine template.
+func (s *EnhancedClusterService) templateRotation(ctx context.Context, cluster *clusterv1.Cluster) (api.TemplateRotation, error) {
+	rotation := api.TemplateRotation{ClusterName: cluster.Name, Template: s.getClusterClass(cluster)}
+	reasons := map[string][]string{}
+	staleMachines := map[string][]string{}
+
+	// Templates changed since they were recorded affect the targets the
+	// ClusterClass creates from them
+	var clusterClass *clusterv1.ClusterClass
+	changed := map[string]bool{}
+	if cluster.Spec.Topology != nil {
+		var err error
+		clusterClass, err = s.kubeClient.GetClusterClass(ctx, cluster.Spec.Topology.Class)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return rotation, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster template '%s' not found", cluster.Spec.Topology.Class))
+			}
+			return rotation, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+		}
+
+		var recorded map[string]int64
+		if err := json.Unmarshal([]byte(cluster.Annotations[templateGenerationsAnnotation]), &recorded); err == nil {
+			rotation.Tracked = true
+			current := s.templateGenerations(ctx, clusterClass)
+			for _, object := range slices.Sorted(maps.Keys(recorded)) {
+				if recorded[object] != current[object] {
+					changed[object] = true
+					rotation.ChangedTemplates = append(rotation.ChangedTemplates, api.TemplateGenerationChange{
+						Object:             object,
+						RecordedGeneration: recorded[object],
+						Generation:         current[object],
+					})
+				}
+			}
+		}
+	}
+	changedTemplates := func(target string, refs ...*corev1.ObjectReference) {
+		for _, ref := range refs {
+			if ref != nil && changed[ref.Kind+"/"+ref.Name] {
+				reasons[target] = append(reasons[target], fmt.Sprintf("template %s/%s changed since the cluster was created or last refreshed", ref.Kind, ref.Name))
+			}
+		}
+	}
+
+	// Machines cloned from an earlier machine template are waiting to be
+	// replaced, or their rollout is stuck
+	if cluster.Spec.ControlPlaneRef != nil && s.getManagedControlPlaneProvider(cluster) == nil {
+		controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return rotation, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get control plane")
+		}
+		if err == nil {
+			template, _, _ := unstructured.NestedString(controlPlane.Object, "spec", "machineTemplate", "infrastructureRef", "name")
+			if clusterClass != nil {
+				changedTemplates(patchTargetControlPlane, clusterClass.Spec.ControlPlane.Ref)
+				if clusterClass.Spec.ControlPlane.MachineInfrastructure != nil {
+					changedTemplates(patchTargetControlPlane, clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
+				}
+			}
+			machines, err := s.kubeClient.ListControlPlaneMachines(ctx, cluster.Name)
+			if err != nil {
+				return rotation, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list control plane machines")
+			}
+			for _, machine := range machines.Items {
+				if template != "" && s.clonedFromOther(ctx, machine.Namespace, &machine.Spec.InfrastructureRef, template) {
+					staleMachines[patchTargetControlPlane] = append(staleMachines[patchTargetControlPlane], machine.Name)
+				}
+			}
+		}
+	}
+
+	mds, err := s.kubeClient.ListMachineDeployments(ctx, cluster.Name)
+	if err != nil {
+		return rotation, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployments")
+	}
+	for _, md := range mds.Items {
+		target := patchTargetMachineDeployment + "/" + md.Name
+		if class := mdTopologyClass(cluster, &md); clusterClass != nil && class != "" {
+			for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+				if mdClass.Class == class {
+					changedTemplates(target, mdClass.Template.Bootstrap.Ref, mdClass.Template.Infrastructure.Ref)
+				}
+			}
+		}
+
+		machines, err := s.kubeClient.ListMachineDeploymentMachines(ctx, cluster.Name, md.Name)
+		if err != nil {
+			return rotation, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list machine deployment machines")
+		}
+		infrastructure := md.Spec.Template.Spec.InfrastructureRef.Name
+		bootstrap := ""
+		if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
+			bootstrap = md.Spec.Template.Spec.Bootstrap.ConfigRef.Name
+		}
+		for _, machine := range machines.Items {
+			stale := s.clonedFromOther(ctx, machine.Namespace, &machine.Spec.InfrastructureRef, infrastructure)
+			if !stale && bootstrap != "" && machine.Spec.Bootstrap.ConfigRef != nil {
+				stale = s.clonedFromOther(ctx, machine.Namespace, machine.Spec.Bootstrap.ConfigRef, bootstrap)
+			}
+			if stale {
+				staleMachines[target] = append(staleMachines[target], machine.Name)
+			}
+		}
+	}
+
+	for target, machines := range staleMachines {
+		sort.Strings(machines)
+		reasons[target] = append(reasons[target], fmt.Sprintf("%d machine(s) were created from an earlier machine template", len(machines)))
+	}
+	for _, target := range slices.Sorted(maps.Keys(reasons)) {
+		rotation.Targets = append(rotation.Targets, api.StaleTemplateTarget{
+			Target:        target,
+			Reasons:       reasons[target],
+			StaleMachines: staleMachines[target],
+		})
+	}
+	rotation.Stale = len(rotation.ChangedTemplates) > 0 || len(rotation.Targets) > 0
+	return rotation, nil
+}
+
+// mdTopologyClass returns the ClusterClass worker class of a MachineDeployment
+// managed by a cluster's topology, or "" if it is not managed by one.
+func mdTopologyClass(cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment) string {
+	topologyName := md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel]
+	if topologyName == "" || cluster.Spec.Topology == nil || cluster.Spec.Topology.Workers == nil {
+		return ""
+	}
+	for _, topology := range cluster.Spec.Topology.Workers.MachineDeployments {
+		if topology.Name == topologyName {
+			return topology.Class
+		}
+	}
+	return ""
+}
+
+// clonedFromOther reports whether the object a machine references was cloned
+// from a template other than the given one. Objects that cannot be read or do
+// not record their template are not reported.
+func (s *EnhancedClusterService) clonedFromOther(ctx context.Context, namespace string, ref *corev1.ObjectReference, template string) bool {
+	if ref.APIVersion == "" || ref.Kind == "" || ref.Name == "" {
+		return false
+	}
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	obj, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+	if err != nil {
+		return false
+	}
+	clonedFrom := obj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+	return clonedFrom != "" && clonedFrom != template
+}
+
+// startRollout starts a rollout of the control plane or a MachineDeployment
+// of a cluster by setting its rolloutAfter, which replaces every machine
+// created before that time.
+func (s *EnhancedClusterService) startRollout(ctx context.Context, cluster *clusterv1.Cluster, target string, at metav1.Time) error {
+	if target == patchTargetControlPlane {
+		controlPlane, err := s.kubeClient.GetControlPlane(ctx, cluster)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get control plane")
+		}
+		if err := unstructured.SetNestedField(controlPlane.Object, at.UTC().Format(time.RFC3339), "spec", "rolloutAfter"); err != nil {
+			return errors.Wrap(err, errors.CodeInternal, "failed to set control plane rolloutAfter")
+		}
+		if err := s.kubeClient.UpdateObject(ctx, controlPlane); err != nil {
+			if apierrors.IsConflict(err) {
+				return errors.Wrap(err, errors.CodePreconditionFailed, "control plane was modified concurrently, retry the request")
+			}
+			return errors.Wrap(err, errors.CodeKubernetesAPI, "failed to roll out the control plane")
+		}
+		return nil
+	}
+
+	name := target[len(patchTargetMachineDeployment)+1:]
+	md, err := s.kubeClient.GetMachineDeployment(ctx, cluster.Name, name)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to get machine deployment '%s'", name))
+	}
+	md.Spec.RolloutAfter = &at
+	if err := s.kubeClient.UpdateMachineDeployment(ctx, md); err != nil {
+		if apierrors.IsConflict(err) {
+			return errors.Wrap(err, errors.CodePreconditionFailed, fmt.Sprintf("machine deployment '%s' was modified concurrently, retry the request", name))
+		}
+		return errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to roll out machine deployment '%s'", name))
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// createTestClonedMachine creates a worker Machine whose AWSMachine was cloned
+// from the given machine template.
+func createTestClonedMachine(name, clusterName, mdName, clonedFrom string) (*clusterv1.Machine, *unstructured.Unstructured) {
+	machine := createTestMachine(name, clusterName, mdName)
+	machine.Spec.InfrastructureRef = corev1.ObjectReference{
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+		Kind:       "AWSMachine",
+		Name:       name,
+	}
+	awsMachine := &unstructured.Unstructured{}
+	awsMachine.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+	awsMachine.SetKind("AWSMachine")
+	awsMachine.SetName(name)
+	awsMachine.SetNamespace(testNamespace)
+	awsMachine.SetAnnotations(map[string]string{clusterv1.TemplateClonedFromNameAnnotation: clonedFrom})
+	return machine, awsMachine
+}
+
+// setupTemplateRotationTest creates cluster dev, recorded at generation 1 of
+// its templates, whose worker template has since changed and one of whose
+// two workers was cloned from the previous worker template.
+func setupTemplateRotationTest(t *testing.T) (*EnhancedClusterService, client.Client) {
+	t.Helper()
+	clusterClass := createTestClusterClass("aws-dev")
+	clusterClass.Generation = 1
+	clusterTemplate := createTestTemplate("infrastructure.cluster.x-k8s.io/v1beta2", "AWSClusterTemplate", "aws-dev-cluster")
+	clusterTemplate.SetGeneration(1)
+	workerTemplate := createTestTemplate("infrastructure.cluster.x-k8s.io/v1beta2", "AWSMachineTemplate", "aws-dev-worker")
+	workerTemplate.SetGeneration(2)
+
+	cluster := createTestCluster("dev", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	cluster.Annotations = map[string]string{
+		templateGenerationsAnnotation: `{"ClusterClass/aws-dev":1,"AWSClusterTemplate/aws-dev-cluster":1,"AWSMachineTemplate/aws-dev-worker":1}`,
+	}
+	cluster.Spec.Topology.Class = "aws-dev"
+	cluster.Spec.Topology.Workers = &clusterv1.WorkersTopology{
+		MachineDeployments: []clusterv1.MachineDeploymentTopology{{Class: "default-worker", Name: "md-0"}},
+	}
+
+	md := createTestMachineDeployment("dev-md-0-x7k2p", testNamespace, "dev", 2)
+	md.Labels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = "md-0"
+	md.Spec.Template.Spec.InfrastructureRef = corev1.ObjectReference{
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+		Kind:       "AWSMachineTemplate",
+		Name:       "dev-md-0-infra-2",
+	}
+	staleMachine, staleAWSMachine := createTestClonedMachine("dev-md-0-x7k2p-a", "dev", md.Name, "dev-md-0-infra-1")
+	currentMachine, currentAWSMachine := createTestClonedMachine("dev-md-0-x7k2p-b", "dev", md.Name, "dev-md-0-infra-2")
+
+	current := createTestCluster("prod", testNamespace, clusterv1.ClusterPhaseProvisioned)
+	current.Annotations = map[string]string{
+		templateGenerationsAnnotation: `{"ClusterClass/aws-dev":1,"AWSClusterTemplate/aws-dev-cluster":1,"AWSMachineTemplate/aws-dev-worker":2}`,
+	}
+	current.Spec.Topology.Class = "aws-dev"
+
+	return setupEnhancedTestService(t,
+		clusterClass, clusterTemplate, workerTemplate, cluster, current, md,
+		staleMachine, staleAWSMachine, currentMachine, currentAWSMachine,
+	)
+}
+
+func TestEnhancedClusterService_CheckTemplateRotation(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupTemplateRotationTest(t)
+
+	t.Run("cluster", func(t *testing.T) {
+		output, err := svc.CheckTemplateRotation(ctx, api.CheckTemplateRotationInput{ClusterName: "dev"})
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, api.TemplateRotation{
+			ClusterName: "dev",
+			Template:    "aws-dev",
+			Stale:       true,
+			Tracked:     true,
+			ChangedTemplates: []api.TemplateGenerationChange{
+				{Object: "AWSMachineTemplate/aws-dev-worker", RecordedGeneration: 1, Generation: 2},
+			},
+			Targets: []api.StaleTemplateTarget{{
+				Target: "MachineDeployment/dev-md-0-x7k2p",
+				Reasons: []string{
+					"template AWSMachineTemplate/aws-dev-worker changed since the cluster was created or last refreshed",
+					"1 machine(s) were created from an earlier machine template",
+				},
+				StaleMachines: []string{"dev-md-0-x7k2p-a"},
+			}},
+		}, output.Clusters[0])
+	})
+
+	t.Run("namespace lists stale clusters", func(t *testing.T) {
+		output, err := svc.CheckTemplateRotation(ctx, api.CheckTemplateRotationInput{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, output.Checked)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, "dev", output.Clusters[0].ClusterName)
+	})
+
+	t.Run("missing cluster", func(t *testing.T) {
+		_, err := svc.CheckTemplateRotation(ctx, api.CheckTemplateRotationInput{ClusterName: "staging"})
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
+
+func TestEnhancedClusterService_RefreshClusterTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run", func(t *testing.T) {
+		svc, fakeClient := setupTemplateRotationTest(t)
+		output, err := svc.RefreshClusterTemplates(ctx, api.RefreshClusterTemplatesInput{ClusterName: "dev", DryRun: true})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Equal(t, []string{"MachineDeployment/dev-md-0-x7k2p"}, output.RolledOut)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-md-0-x7k2p"}, md))
+		assert.Nil(t, md.Spec.RolloutAfter)
+	})
+
+	t.Run("rolls out stale targets and records the generations", func(t *testing.T) {
+		svc, fakeClient := setupTemplateRotationTest(t)
+		output, err := svc.RefreshClusterTemplates(ctx, api.RefreshClusterTemplatesInput{ClusterName: "dev"})
+		require.NoError(t, err)
+		assert.True(t, output.Applied)
+		assert.Equal(t, []string{"MachineDeployment/dev-md-0-x7k2p"}, output.RolledOut)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: "dev-md-0-x7k2p"}, md))
+		assert.NotNil(t, md.Spec.RolloutAfter)
+
+		check, err := svc.CheckTemplateRotation(ctx, api.CheckTemplateRotationInput{ClusterName: "dev"})
+		require.NoError(t, err)
+		assert.Empty(t, check.Clusters[0].ChangedTemplates)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		svc, _ := setupTemplateRotationTest(t)
+		output, err := svc.RefreshClusterTemplates(ctx, api.RefreshClusterTemplatesInput{ClusterName: "prod"})
+		require.NoError(t, err)
+		assert.False(t, output.Applied)
+		assert.Empty(t, output.RolledOut)
+		assert.Contains(t, output.Message, "nothing to refresh")
+	})
+}
+
+func TestEnhancedClusterService_CheckTemplateRotation_ControlPlane(t *testing.T) {
+	ctx := context.Background()
+	cluster := withControlPlane(createTestCluster("dev", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		"AWSCluster", "KubeadmControlPlane", "dev-control-plane")
+	cluster.Spec.Topology = nil
+	controlPlane := createTestControlPlane("KubeadmControlPlane", "dev-control-plane", map[string]interface{}{
+		"machineTemplate": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta2",
+				"kind":       "AWSMachineTemplate",
+				"name":       "dev-control-plane-2",
+			},
+		},
+	}, map[string]interface{}{})
+	machine, awsMachine := createTestClonedMachine("dev-control-plane-a", "dev", "", "dev-control-plane-1")
+	machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "dev", clusterv1.MachineControlPlaneLabel: ""}
+	svc, fakeClient := setupEnhancedTestService(t, cluster, controlPlane, machine, awsMachine)
+
+	output, err := svc.RefreshClusterTemplates(ctx, api.RefreshClusterTemplatesInput{ClusterName: "dev"})
+	require.NoError(t, err)
+	assert.False(t, output.Rotation.Tracked)
+	assert.Equal(t, []api.StaleTemplateTarget{{
+		Target:        "ControlPlane",
+		Reasons:       []string{"1 machine(s) were created from an earlier machine template"},
+		StaleMachines: []string{"dev-control-plane-a"},
+	}}, output.Rotation.Targets)
+	assert.Equal(t, []string{"ControlPlane"}, output.RolledOut)
+
+	updated := createTestControlPlane("KubeadmControlPlane", "dev-control-plane", nil, nil)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(updated), updated))
+	rolloutAfter, _, _ := unstructured.NestedString(updated.Object, "spec", "rolloutAfter")
+	assert.NotEmpty(t, rolloutAfter)
+}
@@ -0,0 +1,628 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// Checks validate_cluster_template reports issues under.
+const (
+	templateCheckStructure = "structure"
+	templateCheckVariables = "variables"
+	templateCheckPatches   = "patches"
+	templateCheckTemplates = "templates"
+)
+
+// Severities of template issues. Only errors make a template invalid.
+const (
+	templateIssueError   = "error"
+	templateIssueWarning = "warning"
+)
+
+// maxTemplateManifestBytes bounds the size of a ClusterClass manifest.
+const maxTemplateManifestBytes = 1 << 20
+
+var (
+	// templateAction matches the actions of a Go template.
+	templateAction = regexp.MustCompile(`(?s)\{\{(.*?)\}\}`)
+
+	// templateVariableRef matches the variables an action reads, such as
+	// .region in {{ .region }} or {{ if and .bastion .region }}, but not the
+	// fields of values, such as .name in .builtin.cluster.name.
+	templateVariableRef = regexp.MustCompile(`(?:^|[^\w)\]$])\.([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// ValidateClusterTemplate runs structural checks on a ClusterClass, either an
+// existing one or a manifest being authored: that its variables have complete
+// schemas, that its patches target templates it uses and read variables it
+// declares, and that the templates it references exist. Templates defined in
+// the same manifest count as existing, so a ClusterClass can be checked
+// together with its templates before any of them is applied.
+func (s *EnhancedClusterService) ValidateClusterTemplate(ctx context.Context, input api.ValidateClusterTemplateInput) (*api.ValidateClusterTemplateOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("ValidateClusterTemplate").WithCluster("", "")
+	logger.Info("Validating cluster template", "template", input.TemplateName, "manifest_bytes", len(input.Manifest))
+
+	if (input.TemplateName == "") == (input.Manifest == "") {
+		err := errors.New(errors.CodeInvalidInput, "exactly one of template name and manifest is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if len(input.Manifest) > maxTemplateManifestBytes {
+		err := errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("manifest is larger than %d bytes", maxTemplateManifestBytes)).
+			WithDetails("field", "manifest")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	validateCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	issues := &templateIssues{}
+	var clusterClass *clusterv1.ClusterClass
+	var provided map[string]bool
+	if input.TemplateName != "" {
+		var err error
+		clusterClass, err = s.kubeClient.GetClusterClass(validateCtx, input.TemplateName)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get ClusterClass")
+			if apierrors.IsNotFound(err) {
+				return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster template '%s' not found", input.TemplateName))
+			}
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+		}
+	} else {
+		var err error
+		clusterClass, provided, err = parseClusterClassManifest(input.Manifest, issues)
+		if err != nil {
+			logger.WithError(err).Warn("Invalid manifest")
+			return nil, err
+		}
+	}
+	if clusterClass.Namespace == "" {
+		clusterClass.Namespace = s.kubeClient.Namespace(validateCtx)
+	}
+
+	validateClusterClassStructure(clusterClass, issues)
+	validateClusterClassVariables(clusterClass, issues)
+	validateClusterClassPatches(clusterClass, issues)
+	s.validateClusterClassTemplates(validateCtx, clusterClass, provided, issues)
+
+	output := &api.ValidateClusterTemplateOutput{
+		TemplateName: clusterClass.Name,
+		Valid:        issues.errors() == 0,
+		Issues:       issues.list,
+	}
+	if output.Issues == nil {
+		output.Issues = []api.TemplateIssue{}
+	}
+	switch {
+	case len(output.Issues) == 0:
+		output.Message = fmt.Sprintf("Cluster template '%s' passed all checks", clusterClass.Name)
+	case output.Valid:
+		output.Message = fmt.Sprintf("Cluster template '%s' is valid with %d warning(s)", clusterClass.Name, len(output.Issues))
+	default:
+		output.Message = fmt.Sprintf("Cluster template '%s' has %d error(s) and %d warning(s)",
+			clusterClass.Name, issues.errors(), len(output.Issues)-issues.errors())
+	}
+
+	logger.Info("Validated cluster template", "template", clusterClass.Name, "valid", output.Valid, "issues", len(output.Issues))
+	return output, nil
+}
+
+// templateIssues collects the issues found in a cluster template.
+type templateIssues struct {
+	list []api.TemplateIssue
+}
+
+func (t *templateIssues) errorf(check, field, format string, args ...interface{}) {
+	t.list = append(t.list, api.TemplateIssue{Severity: templateIssueError, Check: check, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (t *templateIssues) warnf(check, field, format string, args ...interface{}) {
+	t.list = append(t.list, api.TemplateIssue{Severity: templateIssueWarning, Check: check, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (t *templateIssues) errors() int {
+	count := 0
+	for _, issue := range t.list {
+		if issue.Severity == templateIssueError {
+			count++
+		}
+	}
+	return count
+}
+
+// parseClusterClassManifest finds the ClusterClass in a YAML manifest of one
+// or more documents. It returns the kind/name keys of the other documents,
+// the templates defined alongside it, and reports fields the ClusterClass
+// API does not know as structure errors.
+func parseClusterClassManifest(manifest string, issues *templateIssues) (*clusterv1.ClusterClass, map[string]bool, error) {
+	var clusterClass *clusterv1.ClusterClass
+	provided := map[string]bool{}
+	for i, document := range strings.Split("\n"+manifest, "\n---") {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+		var meta struct {
+			metav1.TypeMeta   `json:",inline"`
+			metav1.ObjectMeta `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(document), &meta); err != nil {
+			return nil, nil, errors.Wrap(err, errors.CodeInvalidInput, fmt.Sprintf("document %d of the manifest is not valid YAML", i+1)).
+				WithDetails("field", "manifest")
+		}
+		if meta.Kind != "ClusterClass" {
+			provided[meta.Kind+"/"+meta.Name] = true
+			continue
+		}
+		if clusterClass != nil {
+			return nil, nil, errors.New(errors.CodeInvalidInput, "manifest contains more than one ClusterClass").
+				WithDetails("field", "manifest")
+		}
+
+		clusterClass = &clusterv1.ClusterClass{}
+		if err := yaml.UnmarshalStrict([]byte(document), clusterClass); err != nil {
+			if err := yaml.Unmarshal([]byte(document), clusterClass); err != nil {
+				return nil, nil, errors.Wrap(err, errors.CodeInvalidInput, "manifest is not a valid ClusterClass").
+					WithDetails("field", "manifest")
+			}
+			// Strip the decoder's prefixes, leaving e.g. unknown field "workers"
+			message := err.Error()
+			if i := strings.LastIndex(message, "json: "); i >= 0 {
+				message = message[i+len("json: "):]
+			}
+			issues.errorf(templateCheckStructure, "", "%s", message)
+		}
+		if meta.APIVersion != clusterv1.GroupVersion.String() {
+			issues.errorf(templateCheckStructure, "apiVersion", "must be %s, got '%s'", clusterv1.GroupVersion.String(), meta.APIVersion)
+		}
+	}
+	if clusterClass == nil {
+		return nil, nil, errors.New(errors.CodeInvalidInput, "manifest contains no ClusterClass").
+			WithDetails("field", "manifest")
+	}
+	return clusterClass, provided, nil
+}
+
+// validateClusterClassStructure checks that a ClusterClass references the
+// templates Cluster API requires and that its worker classes are named.
+func validateClusterClassStructure(clusterClass *clusterv1.ClusterClass, issues *templateIssues) {
+	if clusterClass.Name == "" {
+		issues.errorf(templateCheckStructure, "metadata.name", "is required")
+	}
+
+	checkRef := func(field string, ref *clusterv1.LocalObjectTemplate, required bool) {
+		if ref == nil || ref.Ref == nil {
+			if required {
+				issues.errorf(templateCheckStructure, field, "is required")
+			}
+			return
+		}
+		if ref.Ref.APIVersion == "" || ref.Ref.Kind == "" || ref.Ref.Name == "" {
+			issues.errorf(templateCheckStructure, field, "must set apiVersion, kind and name")
+		} else if !strings.HasSuffix(ref.Ref.Kind, "Template") {
+			issues.errorf(templateCheckStructure, field, "must reference a template, got kind %s", ref.Ref.Kind)
+		}
+	}
+
+	spec := &clusterClass.Spec
+	checkRef("spec.infrastructure.ref", &spec.Infrastructure, true)
+	checkRef("spec.controlPlane.ref", &spec.ControlPlane.LocalObjectTemplate, true)
+	if spec.ControlPlane.MachineInfrastructure != nil {
+		checkRef("spec.controlPlane.machineInfrastructure.ref", spec.ControlPlane.MachineInfrastructure, true)
+	}
+
+	checkClass := func(field, class string, seen map[string]bool) {
+		switch {
+		case class == "":
+			issues.errorf(templateCheckStructure, field+".class", "is required")
+		case seen[class]:
+			issues.errorf(templateCheckStructure, field+".class", "duplicates class '%s'", class)
+		}
+		seen[class] = true
+	}
+	seen := map[string]bool{}
+	for i := range spec.Workers.MachineDeployments {
+		class := &spec.Workers.MachineDeployments[i]
+		field := fmt.Sprintf("spec.workers.machineDeployments[%d]", i)
+		checkClass(field, class.Class, seen)
+		checkRef(field+".template.bootstrap.ref", &class.Template.Bootstrap, true)
+		checkRef(field+".template.infrastructure.ref", &class.Template.Infrastructure, true)
+	}
+	seen = map[string]bool{}
+	for i := range spec.Workers.MachinePools {
+		class := &spec.Workers.MachinePools[i]
+		field := fmt.Sprintf("spec.workers.machinePools[%d]", i)
+		checkClass(field, class.Class, seen)
+		checkRef(field+".template.bootstrap.ref", &class.Template.Bootstrap, true)
+		checkRef(field+".template.infrastructure.ref", &class.Template.Infrastructure, true)
+	}
+}
+
+// validateClusterClassVariables checks that every variable is uniquely named
+// and has a complete schema whose default is valid.
+func validateClusterClassVariables(clusterClass *clusterv1.ClusterClass, issues *templateIssues) {
+	seen := map[string]bool{}
+	for i, variable := range clusterClass.Spec.Variables {
+		field := fmt.Sprintf("spec.variables[%d]", i)
+		switch {
+		case variable.Name == "":
+			issues.errorf(templateCheckVariables, field+".name", "is required")
+		case variable.Name == "builtin":
+			issues.errorf(templateCheckVariables, field+".name", "'builtin' is reserved for the variables Cluster API provides")
+		case seen[variable.Name]:
+			issues.errorf(templateCheckVariables, field+".name", "duplicates variable '%s'", variable.Name)
+		}
+		seen[variable.Name] = true
+
+		schema := variable.Schema.OpenAPIV3Schema
+		if schema.Description == "" {
+			issues.warnf(templateCheckVariables, field+".schema.openAPIV3Schema.description",
+				"variable '%s' has no description, so callers cannot tell what to set it to", variable.Name)
+		}
+		validateVariableSchema(field+".schema.openAPIV3Schema", schema, issues)
+	}
+}
+
+// validateVariableSchema checks a variable schema and the schemas nested in it.
+func validateVariableSchema(field string, schema clusterv1.JSONSchemaProps, issues *templateIssues) {
+	switch schema.Type {
+	case "":
+		if !schema.XPreserveUnknownFields {
+			issues.errorf(templateCheckVariables, field+".type", "is required")
+		}
+	case "string", "integer", "number", "boolean", "array":
+	case "object":
+		if len(schema.Properties) == 0 && schema.AdditionalProperties == nil && !schema.XPreserveUnknownFields {
+			issues.errorf(templateCheckVariables, field+".properties",
+				"object schema declares no properties, additionalProperties or x-kubernetes-preserve-unknown-fields")
+		}
+	default:
+		issues.errorf(templateCheckVariables, field+".type", "unknown type '%s'", schema.Type)
+	}
+	if schema.Type == "array" && schema.Items == nil {
+		issues.errorf(templateCheckVariables, field+".items", "array schema declares no items schema")
+	}
+	for _, name := range schema.Required {
+		if _, ok := schema.Properties[name]; !ok && len(schema.Properties) > 0 {
+			issues.errorf(templateCheckVariables, field+".required", "required property '%s' is not declared", name)
+		}
+	}
+
+	if schema.Default != nil {
+		var value interface{}
+		if err := json.Unmarshal(schema.Default.Raw, &value); err != nil {
+			issues.errorf(templateCheckVariables, field+".default", "is not valid JSON: %v", err)
+		} else {
+			if !jsonValueHasType(value, schema.Type) {
+				issues.errorf(templateCheckVariables, field+".default", "default %s is not of type %s", schema.Default.Raw, schema.Type)
+			}
+			if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(allowed apiextensionsv1.JSON) bool {
+				return slices.Equal(compactJSON(allowed.Raw), compactJSON(schema.Default.Raw))
+			}) {
+				issues.errorf(templateCheckVariables, field+".default", "default %s is not one of the enum values", schema.Default.Raw)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		validateVariableSchema(field+".properties."+name, schema.Properties[name], issues)
+	}
+	if schema.AdditionalProperties != nil {
+		validateVariableSchema(field+".additionalProperties", *schema.AdditionalProperties, issues)
+	}
+	if schema.Items != nil {
+		validateVariableSchema(field+".items", *schema.Items, issues)
+	}
+}
+
+// jsonValueHasType reports whether a decoded JSON value has a schema type.
+// Values of schemas without a type are accepted.
+func jsonValueHasType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return true
+}
+
+// compactJSON normalizes JSON for comparison, returning it as is if invalid.
+func compactJSON(raw []byte) []byte {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return raw
+	}
+	compacted, _ := json.Marshal(value)
+	return compacted
+}
+
+// validateClusterClassPatches checks that every patch selects templates the
+// ClusterClass uses, applies well-formed JSON patches under /spec and reads
+// only declared variables, and warns about variables no patch reads.
+func validateClusterClassPatches(clusterClass *clusterv1.ClusterClass, issues *templateIssues) {
+	spec := &clusterClass.Spec
+	declared := map[string]bool{}
+	for _, variable := range spec.Variables {
+		declared[variable.Name] = true
+	}
+	used := map[string]bool{}
+	readVariables := func(field, text string, template bool) {
+		for _, name := range patchVariables(text, template) {
+			used[name] = true
+			if name != "builtin" && !declared[name] {
+				issues.errorf(templateCheckPatches, field, "reads undeclared variable '%s'", name)
+			}
+		}
+	}
+
+	external := false
+	seen := map[string]bool{}
+	for i, patch := range spec.Patches {
+		field := fmt.Sprintf("spec.patches[%d]", i)
+		switch {
+		case patch.Name == "":
+			issues.errorf(templateCheckPatches, field+".name", "is required")
+		case seen[patch.Name]:
+			issues.errorf(templateCheckPatches, field+".name", "duplicates patch '%s'", patch.Name)
+		}
+		seen[patch.Name] = true
+
+		if patch.EnabledIf != nil {
+			readVariables(field+".enabledIf", *patch.EnabledIf, true)
+		}
+		switch {
+		case len(patch.Definitions) > 0 && patch.External != nil:
+			issues.errorf(templateCheckPatches, field, "must set either definitions or external, not both")
+		case len(patch.Definitions) == 0 && patch.External == nil:
+			issues.errorf(templateCheckPatches, field, "must set definitions or external")
+		case patch.External != nil:
+			external = true
+			if patch.External.GenerateExtension == nil && patch.External.ValidateExtension == nil {
+				issues.errorf(templateCheckPatches, field+".external", "names neither a generate nor a validate extension")
+			}
+		}
+
+		for j, definition := range patch.Definitions {
+			definitionField := fmt.Sprintf("%s.definitions[%d]", field, j)
+			validatePatchSelector(definitionField+".selector", spec, definition.Selector, issues)
+
+			if len(definition.JSONPatches) == 0 {
+				issues.errorf(templateCheckPatches, definitionField+".jsonPatches", "is empty")
+			}
+			for k, jsonPatch := range definition.JSONPatches {
+				patchField := fmt.Sprintf("%s.jsonPatches[%d]", definitionField, k)
+				if jsonPatch.Path != "/spec" && !strings.HasPrefix(jsonPatch.Path, "/spec/") {
+					issues.errorf(templateCheckPatches, patchField+".path", "must be under /spec, got '%s'", jsonPatch.Path)
+				}
+				hasValue := jsonPatch.Value != nil || jsonPatch.ValueFrom != nil
+				switch jsonPatch.Op {
+				case "add", "replace":
+					if jsonPatch.Value != nil && jsonPatch.ValueFrom != nil || !hasValue {
+						issues.errorf(templateCheckPatches, patchField, "%s must set exactly one of value and valueFrom", jsonPatch.Op)
+					}
+				case "remove":
+					if hasValue {
+						issues.errorf(templateCheckPatches, patchField, "remove must not set a value")
+					}
+				default:
+					issues.errorf(templateCheckPatches, patchField+".op", "must be add, replace or remove, got '%s'", jsonPatch.Op)
+				}
+
+				valueFrom := jsonPatch.ValueFrom
+				if valueFrom == nil {
+					continue
+				}
+				switch {
+				case (valueFrom.Variable == nil) == (valueFrom.Template == nil):
+					issues.errorf(templateCheckPatches, patchField+".valueFrom", "must set exactly one of variable and template")
+				case valueFrom.Variable != nil && *valueFrom.Variable == "":
+					issues.errorf(templateCheckPatches, patchField+".valueFrom.variable", "is empty")
+				case valueFrom.Variable != nil:
+					readVariables(patchField+".valueFrom.variable", *valueFrom.Variable, false)
+				default:
+					readVariables(patchField+".valueFrom.template", *valueFrom.Template, true)
+				}
+			}
+		}
+	}
+
+	// External patches read variables this check cannot see
+	if external {
+		return
+	}
+	for i, variable := range spec.Variables {
+		if variable.Name != "" && !used[variable.Name] {
+			issues.warnf(templateCheckPatches, fmt.Sprintf("spec.variables[%d]", i),
+				"variable '%s' is not read by any patch, so setting it has no effect", variable.Name)
+		}
+	}
+}
+
+// patchVariables returns the variables a patch reads from a variable path
+// such as instanceType or network.cidr[0], or from a Go template such as an
+// enabledIf condition.
+func patchVariables(text string, template bool) []string {
+	if !template {
+		path := strings.FieldsFunc(text, func(r rune) bool { return r == '.' || r == '[' })
+		if len(path) == 0 {
+			return nil
+		}
+		return path[:1]
+	}
+	var names []string
+	for _, action := range templateAction.FindAllStringSubmatch(text, -1) {
+		for _, match := range templateVariableRef.FindAllStringSubmatch(action[1], -1) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// validatePatchSelector checks that a patch selector matches at least one
+// template of the ClusterClass.
+func validatePatchSelector(field string, spec *clusterv1.ClusterClassSpec, selector clusterv1.PatchSelector, issues *templateIssues) {
+	if selector.APIVersion == "" || selector.Kind == "" {
+		issues.errorf(templateCheckPatches, field, "must set apiVersion and kind")
+		return
+	}
+
+	var targets []*clusterv1.LocalObjectTemplate
+	match := selector.MatchResources
+	if match.ControlPlane {
+		targets = append(targets, &spec.ControlPlane.LocalObjectTemplate)
+		if spec.ControlPlane.MachineInfrastructure != nil {
+			targets = append(targets, spec.ControlPlane.MachineInfrastructure)
+		}
+	}
+	if match.InfrastructureCluster {
+		targets = append(targets, &spec.Infrastructure)
+	}
+	if match.MachineDeploymentClass != nil {
+		for _, name := range match.MachineDeploymentClass.Names {
+			matched := false
+			for i := range spec.Workers.MachineDeployments {
+				class := &spec.Workers.MachineDeployments[i]
+				if ok, _ := path.Match(name, class.Class); ok {
+					matched = true
+					targets = append(targets, &class.Template.Bootstrap, &class.Template.Infrastructure)
+				}
+			}
+			if !matched {
+				issues.errorf(templateCheckPatches, field+".matchResources.machineDeploymentClass.names",
+					"'%s' matches no machine deployment class", name)
+			}
+		}
+	}
+	if match.MachinePoolClass != nil {
+		for _, name := range match.MachinePoolClass.Names {
+			matched := false
+			for i := range spec.Workers.MachinePools {
+				class := &spec.Workers.MachinePools[i]
+				if ok, _ := path.Match(name, class.Class); ok {
+					matched = true
+					targets = append(targets, &class.Template.Bootstrap, &class.Template.Infrastructure)
+				}
+			}
+			if !matched {
+				issues.errorf(templateCheckPatches, field+".matchResources.machinePoolClass.names",
+					"'%s' matches no machine pool class", name)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		issues.errorf(templateCheckPatches, field+".matchResources", "selects no templates")
+		return
+	}
+
+	var kindMatches []string
+	for _, target := range targets {
+		if target.Ref == nil || target.Ref.Kind != selector.Kind {
+			continue
+		}
+		if target.Ref.APIVersion == selector.APIVersion {
+			return
+		}
+		kindMatches = append(kindMatches, target.Ref.APIVersion)
+	}
+	if len(kindMatches) > 0 {
+		issues.errorf(templateCheckPatches, field+".apiVersion",
+			"is '%s' but the selected %s templates are %s", selector.APIVersion, selector.Kind, strings.Join(kindMatches, ", "))
+		return
+	}
+	issues.errorf(templateCheckPatches, field+".kind",
+		"none of the templates selected by matchResources is a %s, so the patch never applies", selector.Kind)
+}
+
+// validateClusterClassTemplates checks that the templates a ClusterClass
+// references exist, either in the management cluster or in the manifest
+// being validated.
+func (s *EnhancedClusterService) validateClusterClassTemplates(ctx context.Context, clusterClass *clusterv1.ClusterClass, provided map[string]bool, issues *templateIssues) {
+	spec := &clusterClass.Spec
+	fields := map[*corev1.ObjectReference]string{
+		spec.Infrastructure.Ref: "spec.infrastructure.ref",
+		spec.ControlPlane.Ref:   "spec.controlPlane.ref",
+	}
+	if spec.ControlPlane.MachineInfrastructure != nil {
+		fields[spec.ControlPlane.MachineInfrastructure.Ref] = "spec.controlPlane.machineInfrastructure.ref"
+	}
+	for i, class := range spec.Workers.MachineDeployments {
+		fields[class.Template.Bootstrap.Ref] = fmt.Sprintf("spec.workers.machineDeployments[%d].template.bootstrap.ref", i)
+		fields[class.Template.Infrastructure.Ref] = fmt.Sprintf("spec.workers.machineDeployments[%d].template.infrastructure.ref", i)
+	}
+	for i, class := range spec.Workers.MachinePools {
+		fields[class.Template.Bootstrap.Ref] = fmt.Sprintf("spec.workers.machinePools[%d].template.bootstrap.ref", i)
+		fields[class.Template.Infrastructure.Ref] = fmt.Sprintf("spec.workers.machinePools[%d].template.infrastructure.ref", i)
+	}
+
+	checked := map[string]bool{}
+	for _, ref := range clusterClassTemplateRefs(spec) {
+		// References that are not templates are reported by the structure checks
+		if ref.APIVersion == "" || ref.Name == "" || !strings.HasSuffix(ref.Kind, "Template") {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = clusterClass.Namespace
+		}
+		key := ref.Kind + "/" + ref.Name
+		if checked[namespace+"/"+key] || provided[key] {
+			continue
+		}
+		checked[namespace+"/"+key] = true
+
+		_, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), namespace, ref.Name)
+		switch {
+		case err == nil:
+		case apierrors.IsNotFound(err):
+			issues.errorf(templateCheckTemplates, fields[ref], "template %s not found in namespace %s", key, namespace)
+		default:
+			issues.warnf(templateCheckTemplates, fields[ref], "could not check template %s: %v", key, err)
+		}
+	}
+}
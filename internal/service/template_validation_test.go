@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const testClusterClassManifest = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: ClusterClass
+metadata:
+  name: aws-dev
+spec:
+  infrastructure:
+    ref:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+      kind: AWSClusterTemplate
+      name: aws-dev-cluster
+  controlPlane:
+    ref:
+      apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+      kind: KubeadmControlPlaneTemplate
+      name: aws-dev-control-plane
+  workers:
+    machineDeployments:
+    - class: default-worker
+      template:
+        bootstrap:
+          ref:
+            apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+            kind: KubeadmConfigTemplate
+            name: aws-dev-worker
+        infrastructure:
+          ref:
+            apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+            kind: AWSMachineTemplate
+            name: aws-dev-worker
+  variables:
+  - name: region
+    required: true
+    schema:
+      openAPIV3Schema:
+        type: string
+        description: The AWS region
+        default: us-east-1
+  - name: workerMachineType
+    required: false
+    schema:
+      openAPIV3Schema:
+        type: string
+        description: The EC2 instance type of worker nodes
+        enum: ["m5.large", "m5.xlarge"]
+        default: m5.large
+  patches:
+  - name: region
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+        kind: AWSClusterTemplate
+        matchResources:
+          infrastructureCluster: true
+      jsonPatches:
+      - op: add
+        path: /spec/template/spec/region
+        valueFrom:
+          variable: region
+  - name: workerMachineType
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+        kind: AWSMachineTemplate
+        matchResources:
+          machineDeploymentClass:
+            names: ["*-worker"]
+      jsonPatches:
+      - op: replace
+        path: /spec/template/spec/instanceType
+        valueFrom:
+          template: "{{ if .workerMachineType }}{{ .workerMachineType }}{{ else }}m5.large{{ end }}"
+---
+apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+kind: AWSClusterTemplate
+metadata:
+  name: aws-dev-cluster
+---
+apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+kind: KubeadmControlPlaneTemplate
+metadata:
+  name: aws-dev-control-plane
+---
+apiVersion: bootstrap.cluster.x-k8s.io/v1beta1
+kind: KubeadmConfigTemplate
+metadata:
+  name: aws-dev-worker
+`
+
+const testBrokenClusterClassManifest = `apiVersion: cluster.x-k8s.io/v1beta1
+kind: ClusterClass
+metadata:
+  name: aws-broken
+spec:
+  infrastructure:
+    ref:
+      apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+      kind: AWSClusterTemplate
+      name: aws-broken-cluster
+  controlPlane:
+    ref:
+      apiVersion: controlplane.cluster.x-k8s.io/v1beta1
+      kind: KubeadmControlPlane
+      name: aws-broken-control-plane
+  variables:
+  - name: region
+    required: true
+    schema:
+      openAPIV3Schema:
+        description: The AWS region
+  - name: nodeCount
+    required: false
+    schema:
+      openAPIV3Schema:
+        type: integer
+        default: "3"
+  - name: tags
+    required: false
+    schema:
+      openAPIV3Schema:
+        type: object
+        description: Extra cloud tags
+  patches:
+  - name: region
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta1
+        kind: AWSClusterTemplate
+        matchResources:
+          infrastructureCluster: true
+      jsonPatches:
+      - op: add
+        path: /metadata/labels/region
+        valueFrom:
+          variable: regoin
+  - name: workers
+    definitions:
+    - selector:
+        apiVersion: infrastructure.cluster.x-k8s.io/v1beta2
+        kind: AWSMachineTemplate
+        matchResources:
+          machineDeploymentClass:
+            names: ["gpu-worker"]
+      jsonPatches:
+      - op: upsert
+        path: /spec/template/spec/instanceType
+        value: g5.xlarge
+`
+
+func TestEnhancedClusterService_ValidateClusterTemplate(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t,
+		createTestTemplate("infrastructure.cluster.x-k8s.io/v1beta2", "AWSMachineTemplate", "aws-dev-worker"),
+		createTestClusterClass("aws-standard"),
+		createTestTemplate("infrastructure.cluster.x-k8s.io/v1beta2", "AWSClusterTemplate", "aws-standard-cluster"),
+	)
+
+	issuesByField := func(output *api.ValidateClusterTemplateOutput) map[string]string {
+		issues := map[string]string{}
+		for _, issue := range output.Issues {
+			issues[issue.Field] = issue.Severity + " " + issue.Check + ": " + issue.Message
+		}
+		return issues
+	}
+
+	t.Run("valid manifest", func(t *testing.T) {
+		output, err := svc.ValidateClusterTemplate(ctx, api.ValidateClusterTemplateInput{Manifest: testClusterClassManifest})
+		require.NoError(t, err)
+		assert.Equal(t, "aws-dev", output.TemplateName)
+		assert.Empty(t, output.Issues)
+		assert.True(t, output.Valid)
+	})
+
+	t.Run("broken manifest", func(t *testing.T) {
+		output, err := svc.ValidateClusterTemplate(ctx, api.ValidateClusterTemplateInput{Manifest: testBrokenClusterClassManifest})
+		require.NoError(t, err)
+		assert.False(t, output.Valid)
+
+		issues := issuesByField(output)
+		assert.Equal(t, map[string]string{
+			"spec.controlPlane.ref": "error structure: must reference a template, got kind KubeadmControlPlane",
+
+			"spec.variables[0].schema.openAPIV3Schema.type":        "error variables: is required",
+			"spec.variables[1].schema.openAPIV3Schema.description": "warning variables: variable 'nodeCount' has no description, so callers cannot tell what to set it to",
+			"spec.variables[1].schema.openAPIV3Schema.default":     `error variables: default "3" is not of type integer`,
+			"spec.variables[2].schema.openAPIV3Schema.properties":  "error variables: object schema declares no properties, additionalProperties or x-kubernetes-preserve-unknown-fields",
+			"spec.variables[1]": "warning patches: variable 'nodeCount' is not read by any patch, so setting it has no effect",
+			"spec.variables[2]": "warning patches: variable 'tags' is not read by any patch, so setting it has no effect",
+			"spec.variables[0]": "warning patches: variable 'region' is not read by any patch, so setting it has no effect",
+			"spec.patches[0].definitions[0].selector.apiVersion":                                  "error patches: is 'infrastructure.cluster.x-k8s.io/v1beta1' but the selected AWSClusterTemplate templates are infrastructure.cluster.x-k8s.io/v1beta2",
+			"spec.patches[0].definitions[0].jsonPatches[0].path":                                  "error patches: must be under /spec, got '/metadata/labels/region'",
+			"spec.patches[0].definitions[0].jsonPatches[0].valueFrom.variable":                    "error patches: reads undeclared variable 'regoin'",
+			"spec.patches[1].definitions[0].selector.matchResources.machineDeploymentClass.names": "error patches: 'gpu-worker' matches no machine deployment class",
+			"spec.patches[1].definitions[0].selector.matchResources":                              "error patches: selects no templates",
+			"spec.patches[1].definitions[0].jsonPatches[0].op":                                    "error patches: must be add, replace or remove, got 'upsert'",
+			"spec.infrastructure.ref":                                                             "error templates: template AWSClusterTemplate/aws-broken-cluster not found in namespace " + testNamespace,
+		}, issues)
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		manifest := "apiVersion: cluster.x-k8s.io/v1alpha4\nkind: ClusterClass\nmetadata:\n  name: typo\nspec:\n  workerClasses: {}\n"
+		output, err := svc.ValidateClusterTemplate(ctx, api.ValidateClusterTemplateInput{Manifest: manifest})
+		require.NoError(t, err)
+
+		issues := issuesByField(output)
+		assert.Equal(t, `error structure: unknown field "workerClasses"`, issues[""])
+		assert.Equal(t, "error structure: must be cluster.x-k8s.io/v1beta1, got 'cluster.x-k8s.io/v1alpha4'", issues["apiVersion"])
+		assert.Equal(t, "error structure: is required", issues["spec.infrastructure.ref"])
+	})
+
+	t.Run("existing template", func(t *testing.T) {
+		output, err := svc.ValidateClusterTemplate(ctx, api.ValidateClusterTemplateInput{TemplateName: "aws-standard"})
+		require.NoError(t, err)
+		assert.False(t, output.Valid)
+
+		issues := issuesByField(output)
+		assert.Equal(t, "error structure: is required", issues["spec.controlPlane.ref"])
+		assert.Equal(t, "error structure: is required", issues["spec.workers.machineDeployments[0].template.bootstrap.ref"])
+		assert.Equal(t, "error templates: template AWSMachineTemplate/aws-standard-worker not found in namespace "+testNamespace,
+			issues["spec.workers.machineDeployments[0].template.infrastructure.ref"])
+		assert.NotContains(t, issues, "spec.infrastructure.ref")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input api.ValidateClusterTemplateInput
+			code  errors.ErrorCode
+		}{
+			{name: "neither", input: api.ValidateClusterTemplateInput{}, code: errors.CodeInvalidInput},
+			{name: "both", input: api.ValidateClusterTemplateInput{TemplateName: "aws-dev", Manifest: testClusterClassManifest}, code: errors.CodeInvalidInput},
+			{name: "not found", input: api.ValidateClusterTemplateInput{TemplateName: "missing"}, code: errors.CodeNotFound},
+			{name: "no cluster class", input: api.ValidateClusterTemplateInput{Manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: x\n"}, code: errors.CodeInvalidInput},
+			{name: "not YAML", input: api.ValidateClusterTemplateInput{Manifest: "kind: [ClusterClass"}, code: errors.CodeInvalidInput},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := svc.ValidateClusterTemplate(ctx, tt.input)
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+			})
+		}
+	})
+}
+
+func TestTemplateVariableRef(t *testing.T) {
+	read := func(template string) []string {
+		var names []string
+		for _, action := range templateAction.FindAllStringSubmatch(template, -1) {
+			for _, match := range templateVariableRef.FindAllStringSubmatch(action[1], -1) {
+				names = append(names, match[1])
+			}
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"region"}, read("{{ .region }}"))
+	assert.Equal(t, []string{"bastion", "region"}, read("{{- if and .bastion .region -}}on{{ end }}"))
+	assert.Equal(t, []string{"builtin"}, read("{{ .builtin.cluster.name }}-{{ $x.field }}"))
+	assert.Equal(t, []string{"tags"}, read(`{{ index .tags "owner" }}`))
+	assert.Empty(t, read("plain.text"))
+}
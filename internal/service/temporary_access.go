@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/validation"
+)
+
+const (
+	// temporaryAccessLabel labels the ServiceAccount of a temporary access
+	// grant in the workload cluster with its access ID.
+	temporaryAccessLabel = "capi-mcp.io/temporary-access"
+
+	// Annotations of the ServiceAccount of a temporary access grant.
+	temporaryAccessUserAnnotation    = "capi-mcp.io/temporary-access-user"
+	temporaryAccessExpiresAnnotation = "capi-mcp.io/temporary-access-expires-at"
+	temporaryAccessReasonAnnotation  = "capi-mcp.io/temporary-access-reason"
+
+	// temporaryAccessNamePrefix prefixes the names of the ServiceAccount and
+	// RoleBinding of a temporary access grant.
+	temporaryAccessNamePrefix = "capi-mcp-access-"
+
+	// defaultTemporaryAccessDuration is how long temporary access lasts
+	// unless asked otherwise.
+	defaultTemporaryAccessDuration = time.Hour
+
+	// minTemporaryAccessDuration is the shortest token lifetime the
+	// Kubernetes TokenRequest API issues.
+	minTemporaryAccessDuration = 10 * time.Minute
+
+	// DefaultTemporaryAccessMaxDuration is the longest temporary access may
+	// last unless configured otherwise.
+	DefaultTemporaryAccessMaxDuration = 8 * time.Hour
+)
+
+// temporaryAccessRoles are the ClusterRoles temporary access may bind in a
+// namespace, the user-facing roles every Kubernetes cluster has.
+var temporaryAccessRoles = []string{"view", "edit", "admin"}
+
+// temporaryAccess is a temporary access grant to a namespace of a workload
+// cluster.
+type temporaryAccess struct {
+	id        string
+	namespace string
+	user      string
+	role      string
+	reason    string
+	duration  time.Duration
+}
+
+// SetTemporaryAccessMaxDuration sets the longest temporary access
+// create_temporary_access grants.
+func (s *EnhancedClusterService) SetTemporaryAccessMaxDuration(duration time.Duration) {
+	s.temporaryAccessMaxDuration = duration
+}
+
+// CreateTemporaryAccess grants a human operator time-limited access to one
+// namespace of a workload cluster. A ServiceAccount is created in the
+// namespace, bound to a view, edit or admin ClusterRole there, and a token
+// for it is issued that expires with the access. The returned kubeconfig
+// holds the token; the server does not keep it. Grants whose time has passed
+// are removed from the workload cluster.
+func (s *EnhancedClusterService) CreateTemporaryAccess(ctx context.Context, input api.CreateTemporaryAccessInput) (*api.CreateTemporaryAccessOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("CreateTemporaryAccess").WithCluster(input.ClusterName, "")
+	logger.Info("Creating temporary access", "access_namespace", input.Namespace, "user", input.User, "role", input.Role, "duration", input.Duration)
+
+	access, err := s.parseTemporaryAccess(input)
+	if err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	accessCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	kubeconfig, err := s.GetClusterKubeconfig(accessCtx, api.GetClusterKubeconfigInput{ClusterName: input.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	workloadClient, err := s.kubeClient.WorkloadClient([]byte(kubeconfig.Kubeconfig))
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to create workload cluster client")
+	}
+
+	if removed, err := pruneTemporaryAccess(accessCtx, workloadClient, time.Now()); err != nil {
+		logger.WithError(err).Warn("Failed to remove expired temporary access")
+	} else if removed > 0 {
+		logger.Info("Removed expired temporary access", "count", removed)
+	}
+
+	output, err := grantTemporaryAccess(accessCtx, workloadClient, []byte(kubeconfig.Kubeconfig), access, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("Failed to grant temporary access")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Created temporary access", "access_id", output.AccessID, "expires_at", output.ExpiresAt)
+	return output, nil
+}
+
+// parseTemporaryAccess validates the parameters of create_temporary_access.
+func (s *EnhancedClusterService) parseTemporaryAccess(input api.CreateTemporaryAccessInput) (temporaryAccess, error) {
+	access := temporaryAccess{
+		id:        uuid.NewString()[:8],
+		namespace: input.Namespace,
+		user:      strings.TrimSpace(input.User),
+		role:      input.Role,
+		reason:    input.Reason,
+		duration:  defaultTemporaryAccessDuration,
+	}
+
+	if input.ClusterName == "" {
+		return access, errors.New(errors.CodeInvalidInput, "cluster name is required")
+	}
+	if err := validation.NewValidator().ValidateDNSName(input.Namespace); err != nil {
+		return access, errors.Wrap(err, errors.CodeInvalidInput, "invalid namespace").WithDetails("field", "namespace")
+	}
+	if access.user == "" {
+		return access, errors.New(errors.CodeInvalidInput, "user is required").WithDetails("field", "user")
+	}
+	if access.role == "" {
+		access.role = temporaryAccessRoles[0]
+	}
+	if !slices.Contains(temporaryAccessRoles, access.role) {
+		return access, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("unknown role '%s', must be one of: %s", access.role, strings.Join(temporaryAccessRoles, ", "))).WithDetails("field", "role")
+	}
+
+	maxDuration := s.temporaryAccessMaxDuration
+	if maxDuration <= 0 {
+		maxDuration = DefaultTemporaryAccessMaxDuration
+	}
+	if input.Duration != "" {
+		duration, err := time.ParseDuration(input.Duration)
+		if err != nil {
+			return access, errors.Wrap(err, errors.CodeInvalidInput, "invalid duration").WithDetails("field", "duration")
+		}
+		access.duration = duration
+	}
+	if access.duration < minTemporaryAccessDuration || access.duration > maxDuration {
+		return access, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("duration must be between %s and %s", minTemporaryAccessDuration, maxDuration)).WithDetails("field", "duration")
+	}
+	return access, nil
+}
+
+// grantTemporaryAccess creates the ServiceAccount and RoleBinding of a
+// temporary access grant and a kubeconfig with a token for it, pointing at
+// the API server of adminKubeconfig. The RoleBinding is owned by the
+// ServiceAccount, so it is garbage collected if only the ServiceAccount is
+// deleted.
+func grantTemporaryAccess(ctx context.Context, workloadClient *kube.WorkloadClient, adminKubeconfig []byte, access temporaryAccess, now time.Time) (*api.CreateTemporaryAccessOutput, error) {
+	name := temporaryAccessNamePrefix + access.id
+	expiresAt := now.Add(access.duration).UTC()
+
+	serviceAccount, err := workloadClient.CreateServiceAccount(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: access.namespace,
+			Labels:    map[string]string{temporaryAccessLabel: access.id},
+			Annotations: map[string]string{
+				temporaryAccessUserAnnotation:    access.user,
+				temporaryAccessExpiresAnnotation: expiresAt.Format(time.RFC3339),
+				temporaryAccessReasonAnnotation:  access.reason,
+			},
+		},
+	})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("namespace '%s' not found in the workload cluster", access.namespace))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to create service account")
+	}
+
+	output, err := func() (*api.CreateTemporaryAccessOutput, error) {
+		_, err := workloadClient.CreateRoleBinding(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: access.namespace,
+				Labels:    map[string]string{temporaryAccessLabel: access.id},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "ServiceAccount",
+					Name:       serviceAccount.Name,
+					UID:        serviceAccount.UID,
+				}},
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: access.role},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      name,
+				Namespace: access.namespace,
+			}},
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to bind role")
+		}
+
+		token, err := workloadClient.CreateServiceAccountToken(ctx, access.namespace, name, int64(access.duration.Seconds()))
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to issue token")
+		}
+		if !token.Status.ExpirationTimestamp.IsZero() {
+			expiresAt = token.Status.ExpirationTimestamp.UTC()
+		}
+
+		kubeconfig, err := temporaryAccessKubeconfig(adminKubeconfig, access, token.Status.Token)
+		if err != nil {
+			return nil, err
+		}
+		return &api.CreateTemporaryAccessOutput{
+			AccessID:   access.id,
+			Namespace:  access.namespace,
+			User:       access.user,
+			Role:       access.role,
+			ExpiresAt:  expiresAt.Format(time.RFC3339),
+			Kubeconfig: kubeconfig,
+			Message: fmt.Sprintf("Granted %s %s access to namespace '%s' until %s",
+				access.user, access.role, access.namespace, expiresAt.Format(time.RFC3339)),
+		}, nil
+	}()
+	if err != nil {
+		// Do not leave a grant behind that no one holds a token for; if this
+		// fails, it is pruned once it expires
+		_ = workloadClient.DeleteServiceAccount(context.WithoutCancel(ctx), access.namespace, name)
+		return nil, err
+	}
+	return output, nil
+}
+
+// temporaryAccessKubeconfig builds a kubeconfig for the API server of the
+// current context of adminKubeconfig that authenticates with token and
+// defaults to the namespace of the access.
+func temporaryAccessKubeconfig(adminKubeconfig []byte, access temporaryAccess, token string) (string, error) {
+	admin, err := clientcmd.Load(adminKubeconfig)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodeInternal, "failed to parse cluster kubeconfig")
+	}
+	adminContext, ok := admin.Contexts[admin.CurrentContext]
+	if !ok {
+		return "", errors.New(errors.CodeInternal, "cluster kubeconfig has no current context")
+	}
+	cluster, ok := admin.Clusters[adminContext.Cluster]
+	if !ok {
+		return "", errors.New(errors.CodeInternal, fmt.Sprintf("cluster kubeconfig has no cluster '%s'", adminContext.Cluster))
+	}
+
+	contextName := access.user + "@" + adminContext.Cluster
+	config := clientcmdapi.NewConfig()
+	config.Clusters[adminContext.Cluster] = cluster
+	config.AuthInfos[access.user] = &clientcmdapi.AuthInfo{Token: token}
+	config.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:   adminContext.Cluster,
+		AuthInfo:  access.user,
+		Namespace: access.namespace,
+	}
+	config.CurrentContext = contextName
+
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", errors.Wrap(err, errors.CodeInternal, "failed to write kubeconfig")
+	}
+	return string(data), nil
+}
+
+// pruneTemporaryAccess deletes the ServiceAccounts of temporary access grants
+// that expired before now, and with them their RoleBindings. It returns how
+// many grants were removed.
+func pruneTemporaryAccess(ctx context.Context, workloadClient *kube.WorkloadClient, now time.Time) (int, error) {
+	serviceAccounts, err := workloadClient.ListServiceAccounts(ctx, "", temporaryAccessLabel)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, serviceAccount := range serviceAccounts.Items {
+		expiresAt, err := time.Parse(time.RFC3339, serviceAccount.Annotations[temporaryAccessExpiresAnnotation])
+		if err != nil || expiresAt.After(now) {
+			continue
+		}
+		if err := revokeServiceAccount(ctx, workloadClient, &serviceAccount); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// revokeServiceAccount deletes the RoleBinding and ServiceAccount of a
+// temporary access grant. The RoleBinding is deleted explicitly so access
+// ends at once rather than when it is garbage collected.
+func revokeServiceAccount(ctx context.Context, workloadClient *kube.WorkloadClient, serviceAccount *corev1.ServiceAccount) error {
+	if err := workloadClient.DeleteRoleBinding(ctx, serviceAccount.Namespace, serviceAccount.Name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := workloadClient.DeleteServiceAccount(ctx, serviceAccount.Namespace, serviceAccount.Name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// RevokeTemporaryAccess ends a temporary access grant before it expires by
+// deleting its ServiceAccount and RoleBinding from the workload cluster,
+// which invalidates the token handed out for it.
+func (s *EnhancedClusterService) RevokeTemporaryAccess(ctx context.Context, input api.RevokeTemporaryAccessInput) (*api.RevokeTemporaryAccessOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("RevokeTemporaryAccess").WithCluster(input.ClusterName, "")
+	logger.Info("Revoking temporary access", "access_id", input.AccessID)
+
+	if input.ClusterName == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "cluster name is required")
+	}
+	if input.AccessID == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "access ID is required").WithDetails("field", "accessId")
+	}
+	if s.kubeClient == nil {
+		return nil, errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+	}
+
+	revokeCtx, cancel := budget.Sub(ctx, time.Minute)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(revokeCtx, input.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	output, err := revokeTemporaryAccess(revokeCtx, workloadClient, input.AccessID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to revoke temporary access")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Revoked temporary access", "access_id", input.AccessID, "user", output.User)
+	return output, nil
+}
+
+// revokeTemporaryAccess deletes the grant with the given access ID from the
+// workload cluster.
+func revokeTemporaryAccess(ctx context.Context, workloadClient *kube.WorkloadClient, accessID string) (*api.RevokeTemporaryAccessOutput, error) {
+	if err := validation.NewValidator().ValidateDNSName(accessID); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid access ID").WithDetails("field", "accessId")
+	}
+	serviceAccounts, err := workloadClient.ListServiceAccounts(ctx, "", temporaryAccessLabel+"="+accessID)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to find temporary access")
+	}
+	if len(serviceAccounts.Items) == 0 {
+		return nil, errors.New(errors.CodeNotFound,
+			fmt.Sprintf("temporary access '%s' not found; it may have expired and been removed", accessID))
+	}
+
+	serviceAccount := &serviceAccounts.Items[0]
+	if err := revokeServiceAccount(ctx, workloadClient, serviceAccount); err != nil {
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to revoke temporary access")
+	}
+	user := serviceAccount.Annotations[temporaryAccessUserAnnotation]
+	return &api.RevokeTemporaryAccessOutput{
+		AccessID:  accessID,
+		Namespace: serviceAccount.Namespace,
+		User:      user,
+		Message:   fmt.Sprintf("Revoked the access of %s to namespace '%s'", user, serviceAccount.Namespace),
+	}, nil
+}
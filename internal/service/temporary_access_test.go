@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// newTemporaryAccessClientset returns a fake clientset that issues tokens
+// for ServiceAccounts, or fails to if tokenErr is set.
+func newTemporaryAccessClientset(tokenErr error, objects ...runtime.Object) *fake.Clientset {
+	clientset := fake.NewSimpleClientset(objects...)
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		if tokenErr != nil {
+			return true, nil, tokenErr
+		}
+		request := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		request.Status.Token = "temporary-token"
+		return true, request, nil
+	})
+	return clientset
+}
+
+func TestEnhancedClusterService_ParseTemporaryAccess(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+	svc.SetTemporaryAccessMaxDuration(4 * time.Hour)
+
+	tests := []struct {
+		name         string
+		input        api.CreateTemporaryAccessInput
+		wantRole     string
+		wantDuration time.Duration
+		wantErr      bool
+	}{
+		{name: "defaults", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "team-a", User: "alice"}, wantRole: "view", wantDuration: time.Hour},
+		{name: "role and duration", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "team-a", User: "alice", Role: "edit", Duration: "2h"}, wantRole: "edit", wantDuration: 2 * time.Hour},
+		{name: "missing user", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "team-a"}, wantErr: true},
+		{name: "invalid namespace", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "Team A", User: "alice"}, wantErr: true},
+		{name: "unknown role", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "team-a", User: "alice", Role: "cluster-admin"}, wantErr: true},
+		{name: "too short", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "team-a", User: "alice", Duration: "5m"}, wantErr: true},
+		{name: "over the maximum", input: api.CreateTemporaryAccessInput{ClusterName: "prod", Namespace: "team-a", User: "alice", Duration: "5h"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access, err := svc.parseTemporaryAccess(tt.input)
+			if tt.wantErr {
+				assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantRole, access.role)
+			assert.Equal(t, tt.wantDuration, access.duration)
+			assert.Len(t, access.id, 8)
+		})
+	}
+}
+
+func TestGrantTemporaryAccess(t *testing.T) {
+	ctx := context.Background()
+	adminKubeconfig := createTestKubeconfigSecret("prod", testNamespace).Data["value"]
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	access := temporaryAccess{id: "abc12345", namespace: "team-a", user: "alice", role: "edit", reason: "INC-42", duration: 2 * time.Hour}
+
+	t.Run("granted", func(t *testing.T) {
+		clientset := newTemporaryAccessClientset(nil)
+		workloadClient := kube.NewWorkloadClient(clientset)
+
+		output, err := grantTemporaryAccess(ctx, workloadClient, adminKubeconfig, access, now)
+		require.NoError(t, err)
+		assert.Equal(t, "abc12345", output.AccessID)
+		assert.Equal(t, "2026-03-02T12:00:00Z", output.ExpiresAt)
+
+		config, err := clientcmd.Load([]byte(output.Kubeconfig))
+		require.NoError(t, err)
+		current := config.Contexts[config.CurrentContext]
+		assert.Equal(t, "team-a", current.Namespace)
+		assert.Equal(t, "temporary-token", config.AuthInfos[current.AuthInfo].Token)
+		assert.Equal(t, "https://test-cluster-api.example.com:6443", config.Clusters[current.Cluster].Server)
+
+		serviceAccount, err := clientset.CoreV1().ServiceAccounts("team-a").Get(ctx, "capi-mcp-access-abc12345", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", serviceAccount.Annotations[temporaryAccessUserAnnotation])
+		roleBinding, err := clientset.RbacV1().RoleBindings("team-a").Get(ctx, "capi-mcp-access-abc12345", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "edit", roleBinding.RoleRef.Name)
+		assert.Equal(t, "capi-mcp-access-abc12345", roleBinding.Subjects[0].Name)
+		assert.Equal(t, "ServiceAccount", roleBinding.OwnerReferences[0].Kind)
+	})
+
+	t.Run("token refused", func(t *testing.T) {
+		clientset := newTemporaryAccessClientset(apierrors.NewForbidden(authenticationv1.Resource("tokenrequests"), "", nil))
+		workloadClient := kube.NewWorkloadClient(clientset)
+
+		_, err := grantTemporaryAccess(ctx, workloadClient, adminKubeconfig, access, now)
+		assert.Equal(t, errors.CodeKubernetesAPI, errors.GetErrorCode(err))
+
+		// The grant no one holds a token for is removed
+		_, err = clientset.CoreV1().ServiceAccounts("team-a").Get(ctx, "capi-mcp-access-abc12345", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestRevokeTemporaryAccess(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	newServiceAccount := func(id, expiresAt string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name:      temporaryAccessNamePrefix + id,
+			Namespace: "team-a",
+			Labels:    map[string]string{temporaryAccessLabel: id},
+			Annotations: map[string]string{
+				temporaryAccessUserAnnotation:    "alice",
+				temporaryAccessExpiresAnnotation: expiresAt,
+			},
+		}}
+	}
+	clientset := newTemporaryAccessClientset(nil,
+		newServiceAccount("expired1", "2026-03-02T09:00:00Z"),
+		newServiceAccount("current1", "2026-03-02T11:00:00Z"),
+		newServiceAccount("current2", "2026-03-02T12:00:00Z"),
+	)
+	workloadClient := kube.NewWorkloadClient(clientset)
+
+	t.Run("prune expired", func(t *testing.T) {
+		removed, err := pruneTemporaryAccess(ctx, workloadClient, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		serviceAccounts, err := clientset.CoreV1().ServiceAccounts("").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, serviceAccounts.Items, 2)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		output, err := revokeTemporaryAccess(ctx, workloadClient, "current1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", output.User)
+		assert.Equal(t, "team-a", output.Namespace)
+
+		_, err = revokeTemporaryAccess(ctx, workloadClient, "current1")
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+
+	t.Run("invalid access ID", func(t *testing.T) {
+		_, err := revokeTemporaryAccess(ctx, workloadClient, "a,b")
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+}
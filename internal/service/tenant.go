@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// tenantLabel marks resources provisioned for a tenant.
+	tenantLabel = "capi-mcp.io/tenant"
+
+	// managedByLabel marks resources created by this server.
+	managedByLabel = "app.kubernetes.io/managed-by"
+
+	// managedByValue is the managedByLabel value used by this server.
+	managedByValue = "capi-mcp-server"
+
+	// defaultTenantMaxClusters is the cluster quota applied when none is requested.
+	defaultTenantMaxClusters = 5
+
+	// tenantRoleName is the name of the Role and RoleBinding granted to tenant groups.
+	tenantRoleName = "capi-mcp-tenant"
+
+	// tenantQuotaName is the name of the ResourceQuota created for a tenant.
+	tenantQuotaName = "capi-mcp-tenant-quota"
+)
+
+// tenantRoleRules are the permissions granted to a tenant's groups in their namespace.
+var tenantRoleRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{clusterv1.GroupVersion.Group},
+		Resources: []string{"clusters", "machinedeployments", "machinepools", "machines", "machinehealthchecks"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{clusterv1.GroupVersion.Group},
+		Resources: []string{"clusterclasses"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"infrastructure.cluster.x-k8s.io", "controlplane.cluster.x-k8s.io", "bootstrap.cluster.x-k8s.io"},
+		Resources: []string{"*"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{""},
+		Resources: []string{"secrets"},
+		Verbs:     []string{"get", "list"},
+	},
+}
+
+// CreateTenant onboards a team by provisioning a namespace with copies of the
+// default ClusterClasses, a cluster quota, and RBAC for the team's groups.
+// Existing resources are left untouched so the operation can be safely retried.
+func (s *EnhancedClusterService) CreateTenant(ctx context.Context, input api.CreateTenantInput) (*api.CreateTenantOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("CreateTenant").WithResource("Namespace", input.TenantName, "")
+	logger.Info("Creating tenant")
+
+	// Validate input
+	if err := validateCreateTenantInput(input); err != nil {
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	// Check if kube client is available
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	tenantCtx, cancel := budget.Sub(ctx, 2*time.Minute)
+	defer cancel()
+
+	// Resolve the ClusterClasses to bind before creating anything
+	clusterClasses, err := s.resolveTenantClusterClasses(tenantCtx, input.ClusterClasses)
+	if err != nil {
+		logger.WithError(err).Error("Failed to resolve cluster classes")
+		return nil, err
+	}
+
+	namespace := input.TenantName
+	objects := []client.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: tenantObjectMeta(namespace, namespace, ""),
+		},
+		buildTenantQuota(namespace, input.MaxClusters),
+		&rbacv1.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+			ObjectMeta: tenantObjectMeta(namespace, tenantRoleName, namespace),
+			Rules:      tenantRoleRules,
+		},
+		buildTenantRoleBinding(namespace, input.Groups),
+	}
+
+	for _, clusterClass := range clusterClasses {
+		copies, err := s.copyClusterClass(tenantCtx, clusterClass, namespace)
+		if err != nil {
+			logger.WithError(err).Error("Failed to copy cluster class", "cluster_class", clusterClass.Name)
+			return nil, err
+		}
+		objects = append(objects, copies...)
+	}
+
+	output := &api.CreateTenantOutput{
+		Namespace:      namespace,
+		Created:        []string{},
+		Existing:       []string{},
+		ClusterClasses: []string{},
+	}
+	for _, clusterClass := range clusterClasses {
+		output.ClusterClasses = append(output.ClusterClasses, clusterClass.Name)
+	}
+
+	for _, obj := range objects {
+		ref := fmt.Sprintf("%s/%s", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName())
+		if err := s.kubeClient.CreateObject(tenantCtx, obj); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				output.Existing = append(output.Existing, ref)
+				continue
+			}
+			logger.WithError(err).Error("Failed to create tenant resource", "resource", ref)
+			if apierrors.IsForbidden(err) {
+				return nil, errors.Wrap(err, errors.CodeForbidden, fmt.Sprintf("not permitted to create %s", ref))
+			}
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, fmt.Sprintf("failed to create %s", ref)).
+				WithDetails("created", output.Created)
+		}
+		output.Created = append(output.Created, ref)
+	}
+
+	output.Message = fmt.Sprintf("Tenant '%s' provisioned: %d resource(s) created, %d already existed",
+		input.TenantName, len(output.Created), len(output.Existing))
+	logger.Info("Tenant created", "created", len(output.Created), "existing", len(output.Existing))
+	return output, nil
+}
+
+// validateCreateTenantInput validates the create_tenant parameters.
+func validateCreateTenantInput(input api.CreateTenantInput) error {
+	if input.TenantName == "" {
+		return errors.New(errors.CodeInvalidInput, "tenant name is required")
+	}
+	if !isValidClusterName(input.TenantName) {
+		return errors.New(errors.CodeInvalidInput, "tenant name must be a valid DNS label (lowercase alphanumeric and '-', max 63 characters)")
+	}
+	if len(input.Groups) == 0 {
+		return errors.New(errors.CodeInvalidInput, "at least one group is required to grant tenant access")
+	}
+	for _, group := range input.Groups {
+		if group == "" {
+			return errors.New(errors.CodeInvalidInput, "group names cannot be empty")
+		}
+	}
+	if input.MaxClusters < 0 {
+		return errors.New(errors.CodeInvalidInput, "max clusters cannot be negative")
+	}
+	return nil
+}
+
+// resolveTenantClusterClasses returns the requested ClusterClasses from the server
+// namespace, or all of them when none are requested.
+func (s *EnhancedClusterService) resolveTenantClusterClasses(ctx context.Context, names []string) ([]clusterv1.ClusterClass, error) {
+	if len(names) == 0 {
+		list, err := s.kubeClient.ListClusterClasses(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to list cluster classes")
+		}
+		sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].Name < list.Items[j].Name })
+		return list.Items, nil
+	}
+
+	classes := make([]clusterv1.ClusterClass, 0, len(names))
+	for _, name := range names {
+		clusterClass, err := s.kubeClient.GetClusterClass(ctx, name)
+		if err != nil {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster class '%s' not found", name))
+		}
+		classes = append(classes, *clusterClass)
+	}
+	return classes, nil
+}
+
+// copyClusterClass returns copies of a ClusterClass and the templates it references,
+// retargeted at the tenant namespace. CAPI requires a Cluster's ClusterClass and
+// templates to live in the Cluster's namespace.
+func (s *EnhancedClusterService) copyClusterClass(ctx context.Context, clusterClass clusterv1.ClusterClass, namespace string) ([]client.Object, error) {
+	classCopy := &clusterv1.ClusterClass{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "ClusterClass"},
+		ObjectMeta: tenantObjectMeta(namespace, clusterClass.Name, namespace),
+		Spec:       *clusterClass.Spec.DeepCopy(),
+	}
+
+	var objects []client.Object
+	seen := make(map[string]bool)
+	for _, ref := range clusterClassTemplateRefs(&classCopy.Spec) {
+		sourceNamespace := ref.Namespace
+		if sourceNamespace == "" {
+			sourceNamespace = clusterClass.Namespace
+		}
+		key := ref.Kind + "/" + ref.Name
+		if !seen[key] {
+			seen[key] = true
+			template, err := s.kubeClient.GetObject(ctx, schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), sourceNamespace, ref.Name)
+			if err != nil {
+				return nil, errors.Wrap(err, errors.CodeKubernetesAPI,
+					fmt.Sprintf("failed to get template %s referenced by cluster class '%s'", key, clusterClass.Name))
+			}
+
+			templateCopy := template.DeepCopy()
+			templateCopy.SetNamespace(namespace)
+			templateCopy.SetLabels(mergeLabels(template.GetLabels(), tenantObjectMeta(namespace, "", "").Labels))
+			templateCopy.SetResourceVersion("")
+			templateCopy.SetUID("")
+			templateCopy.SetOwnerReferences(nil)
+			templateCopy.SetManagedFields(nil)
+			templateCopy.SetCreationTimestamp(metav1.Time{})
+			objects = append(objects, templateCopy)
+		}
+		ref.Namespace = namespace
+	}
+
+	// Create templates before the ClusterClass that references them
+	return append(objects, classCopy), nil
+}
+
+// clusterClassTemplateRefs returns pointers to every template reference in a ClusterClass spec.
+func clusterClassTemplateRefs(spec *clusterv1.ClusterClassSpec) []*corev1.ObjectReference {
+	var refs []*corev1.ObjectReference
+	add := func(ref *corev1.ObjectReference) {
+		if ref != nil {
+			refs = append(refs, ref)
+		}
+	}
+
+	add(spec.Infrastructure.Ref)
+	add(spec.ControlPlane.Ref)
+	if spec.ControlPlane.MachineInfrastructure != nil {
+		add(spec.ControlPlane.MachineInfrastructure.Ref)
+	}
+	for i := range spec.Workers.MachineDeployments {
+		add(spec.Workers.MachineDeployments[i].Template.Bootstrap.Ref)
+		add(spec.Workers.MachineDeployments[i].Template.Infrastructure.Ref)
+	}
+	for i := range spec.Workers.MachinePools {
+		add(spec.Workers.MachinePools[i].Template.Bootstrap.Ref)
+		add(spec.Workers.MachinePools[i].Template.Infrastructure.Ref)
+	}
+	return refs
+}
+
+// buildTenantQuota limits the number of clusters a tenant can create.
+func buildTenantQuota(namespace string, maxClusters int) *corev1.ResourceQuota {
+	if maxClusters == 0 {
+		maxClusters = defaultTenantMaxClusters
+	}
+	return &corev1.ResourceQuota{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"},
+		ObjectMeta: tenantObjectMeta(namespace, tenantQuotaName, namespace),
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourceName("count/clusters." + clusterv1.GroupVersion.Group): *resource.NewQuantity(int64(maxClusters), resource.DecimalSI),
+			},
+		},
+	}
+}
+
+// buildTenantRoleBinding binds the tenant Role to the tenant's groups.
+func buildTenantRoleBinding(namespace string, groups []string) *rbacv1.RoleBinding {
+	subjects := make([]rbacv1.Subject, 0, len(groups))
+	for _, group := range groups {
+		subjects = append(subjects, rbacv1.Subject{
+			Kind:     rbacv1.GroupKind,
+			APIGroup: rbacv1.GroupName,
+			Name:     group,
+		})
+	}
+	return &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+		ObjectMeta: tenantObjectMeta(namespace, tenantRoleName, namespace),
+		Subjects:   subjects,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     tenantRoleName,
+		},
+	}
+}
+
+// tenantObjectMeta returns object metadata labelled as belonging to a tenant.
+func tenantObjectMeta(tenant, name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			tenantLabel:    tenant,
+			managedByLabel: managedByValue,
+		},
+	}
+}
+
+// mergeLabels returns the union of two label sets, with overrides taking precedence.
+func mergeLabels(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
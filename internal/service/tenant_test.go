@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func createTestTemplate(apiVersion, kind, name string) *unstructured.Unstructured {
+	template := &unstructured.Unstructured{}
+	template.SetAPIVersion(apiVersion)
+	template.SetKind(kind)
+	template.SetName(name)
+	template.SetNamespace(testNamespace)
+	template.Object["spec"] = map[string]interface{}{
+		"template": map[string]interface{}{
+			"spec": map[string]interface{}{"instanceType": "m5.large"},
+		},
+	}
+	return template
+}
+
+func createTestClusterClass(name string) *clusterv1.ClusterClass {
+	return &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: clusterv1.ClusterClassSpec{
+			Infrastructure: clusterv1.LocalObjectTemplate{
+				Ref: &corev1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+					Kind:       "AWSClusterTemplate",
+					Name:       name + "-cluster",
+					Namespace:  testNamespace,
+				},
+			},
+			Workers: clusterv1.WorkersClass{
+				MachineDeployments: []clusterv1.MachineDeploymentClass{
+					{
+						Class: "default-worker",
+						Template: clusterv1.MachineDeploymentClassTemplate{
+							Infrastructure: clusterv1.LocalObjectTemplate{
+								Ref: &corev1.ObjectReference{
+									APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+									Kind:       "AWSMachineTemplate",
+									Name:       name + "-worker",
+									Namespace:  testNamespace,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEnhancedClusterService_CreateTenant(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("provisions namespace, cluster classes, quota and RBAC", func(t *testing.T) {
+		svc, fakeClient := setupEnhancedTestService(t,
+			createTestClusterClass("aws-standard"),
+			createTestTemplate("infrastructure.cluster.x-k8s.io/v1beta2", "AWSClusterTemplate", "aws-standard-cluster"),
+			createTestTemplate("infrastructure.cluster.x-k8s.io/v1beta2", "AWSMachineTemplate", "aws-standard-worker"),
+		)
+
+		output, err := svc.CreateTenant(ctx, api.CreateTenantInput{
+			TenantName:  "team-a",
+			Groups:      []string{"team-a-admins"},
+			MaxClusters: 3,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "team-a", output.Namespace)
+		assert.Equal(t, []string{"aws-standard"}, output.ClusterClasses)
+		assert.Empty(t, output.Existing)
+		assert.Contains(t, output.Created, "Namespace/team-a")
+		assert.Contains(t, output.Created, "ClusterClass/aws-standard")
+		assert.Contains(t, output.Created, "AWSMachineTemplate/aws-standard-worker")
+
+		namespace := &corev1.Namespace{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "team-a"}, namespace))
+		assert.Equal(t, "team-a", namespace.Labels[tenantLabel])
+
+		quota := &corev1.ResourceQuota{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: tenantQuotaName}, quota))
+		clusters := quota.Spec.Hard[corev1.ResourceName("count/clusters.cluster.x-k8s.io")]
+		assert.Equal(t, int64(3), clusters.Value())
+
+		binding := &rbacv1.RoleBinding{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: tenantRoleName}, binding))
+		require.Len(t, binding.Subjects, 1)
+		assert.Equal(t, rbacv1.GroupKind, binding.Subjects[0].Kind)
+		assert.Equal(t, "team-a-admins", binding.Subjects[0].Name)
+
+		clusterClass := &clusterv1.ClusterClass{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "aws-standard"}, clusterClass))
+		assert.Equal(t, "team-a", clusterClass.Spec.Infrastructure.Ref.Namespace)
+		assert.Equal(t, "team-a", clusterClass.Spec.Workers.MachineDeployments[0].Template.Infrastructure.Ref.Namespace)
+
+		template := &unstructured.Unstructured{}
+		template.SetAPIVersion("infrastructure.cluster.x-k8s.io/v1beta2")
+		template.SetKind("AWSMachineTemplate")
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "aws-standard-worker"}, template))
+		instanceType, _, _ := unstructured.NestedString(template.Object, "spec", "template", "spec", "instanceType")
+		assert.Equal(t, "m5.large", instanceType)
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+		input := api.CreateTenantInput{TenantName: "team-b", Groups: []string{"team-b"}}
+
+		_, err := svc.CreateTenant(ctx, input)
+		require.NoError(t, err)
+
+		output, err := svc.CreateTenant(ctx, input)
+		require.NoError(t, err)
+		assert.Empty(t, output.Created)
+		assert.Contains(t, output.Existing, "Namespace/team-b")
+		assert.Contains(t, output.Existing, "ResourceQuota/"+tenantQuotaName)
+	})
+
+	t.Run("unknown cluster class", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+
+		_, err := svc.CreateTenant(ctx, api.CreateTenantInput{
+			TenantName:     "team-c",
+			Groups:         []string{"team-c"},
+			ClusterClasses: []string{"missing"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+
+		tests := []api.CreateTenantInput{
+			{TenantName: "", Groups: []string{"team"}},
+			{TenantName: "Team_A", Groups: []string{"team"}},
+			{TenantName: "team-a"},
+			{TenantName: "team-a", Groups: []string{""}},
+			{TenantName: "team-a", Groups: []string{"team"}, MaxClusters: -1},
+		}
+		for _, input := range tests {
+			_, err := svc.CreateTenant(ctx, input)
+			require.Error(t, err)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/accounting"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// SetToolAccounting configures the ledger tool calls are counted in. Without
+// a ledger, calls are not counted and get_tool_usage is unavailable.
+func (s *EnhancedClusterService) SetToolAccounting(ledger *accounting.Ledger) {
+	s.accounting = ledger
+}
+
+// RecordToolCall counts a tool call of an identity and whether it failed.
+func (s *EnhancedClusterService) RecordToolCall(identity, tool string, err error) {
+	if s.accounting == nil {
+		return
+	}
+	var errorCode string
+	if err != nil {
+		errorCode = string(errors.GetErrorCode(err))
+	}
+	s.accounting.RecordCall(identity, tool, errorCode)
+}
+
+// GetToolUsage returns the tool calls of each identity within the retention
+// period of the ledger, aggregated per tool.
+func (s *EnhancedClusterService) GetToolUsage(ctx context.Context, input api.GetToolUsageInput) (*api.GetToolUsageOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetToolUsage")
+	logger.Info("Getting tool usage", "identity", input.Identity, "tool", input.Tool)
+
+	if s.accounting == nil {
+		err := errors.New(errors.CodeUnavailable, "tool accounting is not enabled")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	now := time.Now()
+	filter := accounting.Filter{
+		Identity: input.Identity,
+		Tool:     input.Tool,
+		Since:    now.Add(-s.accounting.Retention()),
+		Until:    now,
+	}
+	var err error
+	if input.Since != "" {
+		if filter.Since, err = time.Parse(time.RFC3339, input.Since); err != nil {
+			err := errors.New(errors.CodeInvalidInput, fmt.Sprintf("since must be an RFC 3339 timestamp, got '%s'", input.Since))
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+	if input.Until != "" {
+		if filter.Until, err = time.Parse(time.RFC3339, input.Until); err != nil {
+			err := errors.New(errors.CodeInvalidInput, fmt.Sprintf("until must be an RFC 3339 timestamp, got '%s'", input.Until))
+			logger.WithError(err).Error("Invalid input")
+			return nil, err
+		}
+	}
+	if filter.Until.Before(filter.Since) {
+		err := errors.New(errors.CodeInvalidInput, "until must not be before since")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+
+	output := &api.GetToolUsageOutput{
+		Since:      filter.Since.Truncate(accounting.BucketSize).UTC().Format(time.RFC3339),
+		Until:      filter.Until.UTC().Format(time.RFC3339),
+		Identities: []api.IdentityUsage{},
+	}
+	for _, usage := range s.accounting.Usage(filter) {
+		identity := api.IdentityUsage{
+			Identity:    usage.Identity,
+			Calls:       usage.Calls,
+			Failures:    usage.Failures,
+			FailureRate: failureRate(usage.Counts),
+			Mutations:   usage.Mutations,
+			Errors:      usage.Errors,
+			Tools:       make([]api.ToolUsage, 0, len(usage.Tools)),
+		}
+		for _, tool := range slices.Sorted(maps.Keys(usage.Tools)) {
+			counts := usage.Tools[tool]
+			identity.Tools = append(identity.Tools, api.ToolUsage{
+				Tool:        tool,
+				Calls:       counts.Calls,
+				Failures:    counts.Failures,
+				FailureRate: failureRate(counts),
+				Mutations:   counts.Mutations,
+				Errors:      counts.Errors,
+			})
+		}
+		sort.SliceStable(identity.Tools, func(i, j int) bool {
+			return identity.Tools[i].Calls > identity.Tools[j].Calls
+		})
+		output.Identities = append(output.Identities, identity)
+	}
+
+	logger.Info("Got tool usage", "identities", len(output.Identities))
+	return output, nil
+}
+
+// failureRate returns the fraction of calls that failed, rounded to three
+// decimals.
+func failureRate(counts accounting.Counts) float64 {
+	if counts.Calls == 0 {
+		return 0
+	}
+	return math.Round(float64(counts.Failures)/float64(counts.Calls)*1000) / 1000
+}
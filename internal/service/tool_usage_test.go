@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/accounting"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+)
+
+func TestEnhancedClusterService_GetToolUsage(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t)
+
+	_, err := svc.GetToolUsage(ctx, api.GetToolUsageInput{})
+	assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+
+	svc.SetToolAccounting(accounting.NewLedger(24*time.Hour, nil))
+	svc.RecordToolCall("ci", "scale_cluster", nil)
+	svc.RecordOperation(ctx, history.Operation{Tool: "scale_cluster", ClusterName: "dev", Identity: "ci", Outcome: history.OutcomeSucceeded})
+	svc.RecordToolCall("ci", "get_cluster", nil)
+	svc.RecordToolCall("ci", "get_cluster", nil)
+	svc.RecordToolCall("ci", "delete_cluster", errors.New(errors.CodeForbidden, "denied by policy"))
+	svc.RecordOperation(ctx, history.Operation{Tool: "delete_cluster", ClusterName: "dev", Identity: "ci", Outcome: history.OutcomeFailed})
+	svc.RecordToolCall("viewer", "list_clusters", nil)
+
+	t.Run("all identities", func(t *testing.T) {
+		output, err := svc.GetToolUsage(ctx, api.GetToolUsageInput{})
+		require.NoError(t, err)
+		require.Len(t, output.Identities, 2)
+		assert.Equal(t, api.IdentityUsage{
+			Identity:    "ci",
+			Calls:       4,
+			Failures:    1,
+			FailureRate: 0.25,
+			Mutations:   1,
+			Errors:      map[string]int64{"FORBIDDEN": 1},
+			Tools: []api.ToolUsage{
+				{Tool: "get_cluster", Calls: 2},
+				{Tool: "delete_cluster", Calls: 1, Failures: 1, FailureRate: 1, Errors: map[string]int64{"FORBIDDEN": 1}},
+				{Tool: "scale_cluster", Calls: 1, Mutations: 1},
+			},
+		}, output.Identities[0])
+		assert.Equal(t, "viewer", output.Identities[1].Identity)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		output, err := svc.GetToolUsage(ctx, api.GetToolUsageInput{Identity: "viewer", Tool: "get_cluster"})
+		require.NoError(t, err)
+		assert.Empty(t, output.Identities)
+
+		output, err = svc.GetToolUsage(ctx, api.GetToolUsageInput{Until: time.Now().Add(-2 * time.Hour).Format(time.RFC3339)})
+		require.NoError(t, err)
+		assert.Empty(t, output.Identities)
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		tests := []api.GetToolUsageInput{
+			{Since: "yesterday"},
+			{Until: "now"},
+			{Since: "2026-03-02T10:00:00Z", Until: "2026-03-01T10:00:00Z"},
+		}
+		for _, input := range tests {
+			_, err := svc.GetToolUsage(ctx, input)
+			assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// MissingClusterVariables returns the required variables of a cluster
+// template that have no default and are not set in variables or by the
+// server's default variables, with their schema, so callers can ask for the
+// values before creating a cluster.
+func (s *EnhancedClusterService) MissingClusterVariables(ctx context.Context, templateName string, variables map[string]interface{}) ([]api.TemplateVariable, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("MissingClusterVariables")
+
+	if templateName == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "template name is required")
+	}
+	if s.kubeClient == nil {
+		return nil, errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+	}
+
+	getCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	clusterClass, err := s.kubeClient.GetClusterClass(getCtx, templateName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get ClusterClass")
+		if apierrors.IsNotFound(err) {
+			return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("cluster template '%s' not found", templateName))
+		}
+		return nil, errors.Wrap(err, errors.CodeKubernetesAPI, "failed to get cluster template")
+	}
+
+	// Variables set by default are not missing; conflicts with enforced
+	// variables are reported by create_cluster
+	providerName := s.extractProviderName(variables, templateName)
+	if defaulted, err := s.applyVariableDefaults(s.kubeClient.Namespace(ctx), providerName, templateName, variables); err == nil {
+		variables = defaulted
+	}
+
+	return missingRequiredVariables(clusterClass, variables), nil
+}
+
+// missingRequiredVariables returns the required variables of a ClusterClass
+// that have no default and are not set in variables. Required variables with
+// a default are filled in by Cluster API.
+func missingRequiredVariables(clusterClass *clusterv1.ClusterClass, variables map[string]interface{}) []api.TemplateVariable {
+	var missing []api.TemplateVariable
+	for _, variable := range clusterClass.Spec.Variables {
+		if !variable.Required || variable.Schema.OpenAPIV3Schema.Default != nil {
+			continue
+		}
+		if _, ok := variables[variable.Name]; ok {
+			continue
+		}
+		missing = append(missing, templateVariable(variable))
+	}
+	return missing
+}
+
+// templateVariable describes a ClusterClass variable from its schema.
+func templateVariable(variable clusterv1.ClusterClassVariable) api.TemplateVariable {
+	schema := variable.Schema.OpenAPIV3Schema
+	described := api.TemplateVariable{
+		Name:        variable.Name,
+		Required:    variable.Required,
+		Type:        schema.Type,
+		Description: schema.Description,
+	}
+	if schema.Default != nil {
+		described.Default = rawJSONValue(schema.Default.Raw)
+	}
+	if schema.Example != nil {
+		described.Example = rawJSONValue(schema.Example.Raw)
+	}
+	for _, value := range schema.Enum {
+		described.Enum = append(described.Enum, rawJSONValue(value.Raw))
+	}
+	return described
+}
+
+// rawJSONValue decodes a raw JSON schema value, returning nil if it is invalid.
+func rawJSONValue(raw []byte) interface{} {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// missingVariablesError reports required template variables absent from a
+// create_cluster request, with their schema as a hint for the caller.
+func missingVariablesError(templateName string, missing []api.TemplateVariable) error {
+	names := make([]string, 0, len(missing))
+	for _, variable := range missing {
+		names = append(names, variable.Name)
+	}
+	return errors.New(errors.CodeInvalidInput,
+		fmt.Sprintf("cluster template '%s' requires variables that were not provided: %s", templateName, strings.Join(names, ", "))).
+		WithDetails("missing_variables", missing)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func createTestClusterClassWithVariables(name string) *clusterv1.ClusterClass {
+	clusterClass := createTestClusterClass(name)
+	clusterClass.Spec.Variables = []clusterv1.ClusterClassVariable{
+		{
+			Name:     "region",
+			Required: true,
+			Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type:        "string",
+				Description: "AWS region to deploy the cluster in",
+				Example:     &apiextensionsv1.JSON{Raw: []byte(`"us-west-2"`)},
+			}},
+		},
+		{
+			Name:     "controlPlaneInstanceType",
+			Required: true,
+			Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type:    "string",
+				Default: &apiextensionsv1.JSON{Raw: []byte(`"m5.large"`)},
+			}},
+		},
+		{
+			Name:     "osFamily",
+			Required: true,
+			Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type: "string",
+				Enum: []apiextensionsv1.JSON{{Raw: []byte(`"ubuntu"`)}, {Raw: []byte(`"flatcar"`)}},
+			}},
+		},
+		{
+			Name: "sshKeyName",
+			Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type: "string",
+			}},
+		},
+	}
+	return clusterClass
+}
+
+func TestEnhancedClusterService_MissingClusterVariables(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t, createTestClusterClassWithVariables("aws-standard"))
+
+	t.Run("reports required variables without a default", func(t *testing.T) {
+		missing, err := svc.MissingClusterVariables(ctx, "aws-standard", map[string]interface{}{"sshKeyName": "ops"})
+		require.NoError(t, err)
+		require.Len(t, missing, 2)
+
+		assert.Equal(t, "region", missing[0].Name)
+		assert.True(t, missing[0].Required)
+		assert.Equal(t, "AWS region to deploy the cluster in", missing[0].Description)
+		assert.Equal(t, "us-west-2", missing[0].Example)
+
+		assert.Equal(t, "osFamily", missing[1].Name)
+		assert.Equal(t, []interface{}{"ubuntu", "flatcar"}, missing[1].Enum)
+	})
+
+	t.Run("nothing missing", func(t *testing.T) {
+		missing, err := svc.MissingClusterVariables(ctx, "aws-standard", map[string]interface{}{
+			"region":   "us-east-1",
+			"osFamily": "ubuntu",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		_, err := svc.MissingClusterVariables(ctx, "missing-template", nil)
+		require.Error(t, err)
+	})
+}
+
+func TestEnhancedClusterService_CreateCluster_MissingVariables(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t, createTestClusterClassWithVariables("aws-standard"))
+
+	_, err := svc.CreateCluster(context.Background(), api.CreateClusterInput{
+		ClusterName:       "new-cluster",
+		TemplateName:      "aws-standard",
+		KubernetesVersion: "v1.31.0",
+		Variables:         map[string]interface{}{"region": "us-east-1"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	assert.Contains(t, err.Error(), "osFamily")
+	assert.NotContains(t, err.Error(), "region")
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+)
+
+// WaitStrategy selects how the service waits for cluster state changes.
+type WaitStrategy string
+
+const (
+	// WaitStrategyWatch waits on watch events, falling back to polling if the watch fails.
+	WaitStrategyWatch WaitStrategy = "watch"
+
+	// WaitStrategyPoll polls the cluster at a fixed interval.
+	WaitStrategyPoll WaitStrategy = "poll"
+
+	// defaultPollInterval is the interval used when polling for cluster state.
+	defaultPollInterval = 10 * time.Second
+)
+
+// SetWaitStrategy configures how long-running operations wait for cluster
+// state changes. A non-positive poll interval keeps the current interval.
+func (s *EnhancedClusterService) SetWaitStrategy(strategy WaitStrategy, pollInterval time.Duration) {
+	s.waitStrategy = strategy
+	if pollInterval > 0 {
+		s.pollInterval = pollInterval
+	}
+}
+
+// clusterCondition reports whether the wait is complete. A nil cluster means
+// the cluster does not exist.
+type clusterCondition func(cluster *clusterv1.Cluster) bool
+
+// waitForClusterPhase waits for a newly created cluster to report a phase
+func (s *EnhancedClusterService) waitForClusterPhase(ctx context.Context, clusterName, namespace string, timeout time.Duration) (*clusterv1.Cluster, error) {
+	waitCtx, cancel := budget.Sub(ctx, timeout)
+	defer cancel()
+
+	return s.waitForCluster(waitCtx, clusterName, func(cluster *clusterv1.Cluster) bool {
+		// Return cluster regardless of phase after initial creation
+		return cluster != nil && cluster.Status.Phase != ""
+	})
+}
+
+// waitForClusterDeleted waits for a cluster to be fully deleted
+func (s *EnhancedClusterService) waitForClusterDeleted(ctx context.Context, clusterName, namespace string) error {
+	_, err := s.waitForCluster(ctx, clusterName, func(cluster *clusterv1.Cluster) bool {
+		return cluster == nil
+	})
+	return err
+}
+
+// waitForCluster blocks until done reports true for the named cluster or the
+// context is cancelled. Watch-based waiting falls back to polling if the watch
+// cannot be established or is closed by the server.
+func (s *EnhancedClusterService) waitForCluster(ctx context.Context, clusterName string, done clusterCondition) (*clusterv1.Cluster, error) {
+	logger := s.logger.WithContext(ctx).WithCluster(clusterName, "")
+
+	if s.waitStrategy != WaitStrategyPoll {
+		cluster, err := s.watchCluster(ctx, clusterName, done)
+		if err == nil {
+			return cluster, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		logger.WithError(err).Debug("Watch failed, falling back to polling")
+	}
+
+	return s.pollCluster(ctx, clusterName, done)
+}
+
+// watchCluster waits on cluster watch events until done reports true.
+func (s *EnhancedClusterService) watchCluster(ctx context.Context, clusterName string, done clusterCondition) (*clusterv1.Cluster, error) {
+	w, err := s.kubeClient.WatchClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Stop()
+
+	// Check the current state after the watch is open so no change is missed
+	cluster, err := s.getClusterForWait(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if done(cluster) {
+		return cluster, nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil, fmt.Errorf("watch closed")
+			}
+
+			if event.Type == watch.Error {
+				return nil, apierrors.FromObject(event.Object)
+			}
+
+			cluster, ok := event.Object.(*clusterv1.Cluster)
+			if !ok || cluster.Name != clusterName {
+				continue
+			}
+
+			if event.Type == watch.Deleted {
+				cluster = nil
+			}
+			if done(cluster) {
+				return cluster, nil
+			}
+		}
+	}
+}
+
+// pollCluster checks the cluster at the configured interval until done reports true.
+func (s *EnhancedClusterService) pollCluster(ctx context.Context, clusterName string, done clusterCondition) (*clusterv1.Cluster, error) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		cluster, err := s.getClusterForWait(ctx, clusterName)
+		if err == nil && done(cluster) {
+			return cluster, nil
+		}
+		// Keep trying on transient errors
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// getClusterForWait returns the named cluster, or nil if it does not exist.
+func (s *EnhancedClusterService) getClusterForWait(ctx context.Context, clusterName string) (*clusterv1.Cluster, error) {
+	cluster, err := s.kubeClient.GetClusterByName(ctx, clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
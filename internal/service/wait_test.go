@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestWaitForClusterDeleted(t *testing.T) {
+	for _, strategy := range []WaitStrategy{WaitStrategyWatch, WaitStrategyPoll} {
+		t.Run(string(strategy), func(t *testing.T) {
+			cluster := createTestCluster("doomed", testNamespace, clusterv1.ClusterPhaseDeleting)
+			svc, fakeClient := setupEnhancedTestService(t, cluster)
+			svc.SetWaitStrategy(strategy, 10*time.Millisecond)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				_ = fakeClient.Delete(context.Background(), cluster.DeepCopy())
+			}()
+
+			require.NoError(t, svc.waitForClusterDeleted(ctx, "doomed", testNamespace))
+		})
+	}
+
+	t.Run("already deleted", func(t *testing.T) {
+		svc, _ := setupEnhancedTestService(t)
+
+		require.NoError(t, svc.waitForClusterDeleted(context.Background(), "missing", testNamespace))
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		cluster := createTestCluster("stuck", testNamespace, clusterv1.ClusterPhaseDeleting)
+		svc, _ := setupEnhancedTestService(t, cluster)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := svc.waitForClusterDeleted(ctx, "stuck", testNamespace)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestWaitForClusterPhase(t *testing.T) {
+	cluster := createTestCluster("new-cluster", testNamespace, "")
+	svc, fakeClient := setupEnhancedTestService(t, cluster)
+	svc.SetWaitStrategy(WaitStrategyWatch, 10*time.Millisecond)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		updated := cluster.DeepCopy()
+		updated.Status.Phase = string(clusterv1.ClusterPhaseProvisioning)
+		_ = fakeClient.Update(context.Background(), updated)
+	}()
+
+	result, err := svc.waitForClusterPhase(context.Background(), "new-cluster", testNamespace, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, string(clusterv1.ClusterPhaseProvisioning), result.Status.Phase)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// WorkloadHealthMetrics records the health of workload clusters for fleet
+// dashboards.
+type WorkloadHealthMetrics interface {
+	SetWorkloadAPIHealth(cluster, namespace string, up bool, latency time.Duration)
+	SetWorkloadNodes(cluster, namespace string, nodes, ready int)
+	DeleteWorkloadNodes(cluster, namespace string)
+	DeleteWorkloadHealth(cluster, namespace string)
+}
+
+// WorkloadHealthOptions configures the background scrape of workload cluster
+// health.
+type WorkloadHealthOptions struct {
+	// Interval is how often every Provisioned cluster is scraped.
+	Interval time.Duration
+
+	// Concurrency is how many clusters are scraped at once.
+	Concurrency int
+}
+
+// workloadHealthTimeout bounds the scrape of a single cluster, so clusters
+// whose API server does not respond do not hold up the others.
+const workloadHealthTimeout = 10 * time.Second
+
+// SetWorkloadHealthMetrics configures where workload cluster health is
+// recorded by RunWorkloadHealthCollector.
+func (s *EnhancedClusterService) SetWorkloadHealthMetrics(metrics WorkloadHealthMetrics, options WorkloadHealthOptions) {
+	s.workloadHealthMetrics = metrics
+	s.workloadHealthOptions = options
+}
+
+// RunWorkloadHealthCollector scrapes the API availability and node readiness
+// of every Provisioned cluster through its kubeconfig until ctx is cancelled.
+// It returns immediately when no metrics are configured.
+func (s *EnhancedClusterService) RunWorkloadHealthCollector(ctx context.Context) {
+	if s.workloadHealthMetrics == nil || s.kubeClient == nil || s.workloadHealthOptions.Interval <= 0 {
+		return
+	}
+
+	logger := s.logger.WithContext(ctx).WithOperation("CollectWorkloadHealth")
+	logger.Info("Starting workload cluster health collector",
+		"interval", s.workloadHealthOptions.Interval,
+		"concurrency", s.workloadHealthOptions.Concurrency,
+	)
+
+	scraped := map[types.NamespacedName]bool{}
+	for {
+		started := time.Now()
+		current, err := s.collectWorkloadHealth(ctx, scraped)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logger.WithError(err).Warn("Failed to collect workload cluster health")
+		} else {
+			scraped = current
+			logger.Debug("Collected workload cluster health", "clusters", len(scraped), "duration", time.Since(started))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(started.Add(s.workloadHealthOptions.Interval))):
+		}
+	}
+}
+
+// collectWorkloadHealth scrapes every Provisioned cluster and removes the
+// metrics of previously scraped clusters that are gone or no longer
+// Provisioned. It returns the clusters scraped.
+func (s *EnhancedClusterService) collectWorkloadHealth(ctx context.Context, previous map[types.NamespacedName]bool) (map[types.NamespacedName]bool, error) {
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	clusters, err := s.kubeClient.ListAllClusters(listCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := max(s.workloadHealthOptions.Concurrency, 1)
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	scraped := make(map[types.NamespacedName]bool, len(clusters.Items))
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if clusterv1.ClusterPhase(cluster.Status.Phase) != clusterv1.ClusterPhaseProvisioned || !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+		scraped[types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}] = true
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			s.scrapeWorkloadHealth(ctx, cluster)
+		}()
+	}
+	wg.Wait()
+
+	for key := range previous {
+		if !scraped[key] {
+			s.workloadHealthMetrics.DeleteWorkloadHealth(key.Name, key.Namespace)
+		}
+	}
+	return scraped, nil
+}
+
+// scrapeWorkloadHealth records the API availability and node readiness of a
+// cluster. A cluster that cannot be reached is recorded as down.
+func (s *EnhancedClusterService) scrapeWorkloadHealth(ctx context.Context, cluster *clusterv1.Cluster) {
+	scrapeCtx, cancel := context.WithTimeout(kube.ContextWithNamespace(ctx, cluster.Namespace), workloadHealthTimeout)
+	defer cancel()
+
+	logger := s.logger.WithContext(scrapeCtx).WithOperation("ScrapeWorkloadHealth").WithCluster(cluster.Name, cluster.Namespace)
+	workloadClient, err := s.newWorkloadClient(scrapeCtx, cluster.Name)
+	if err != nil {
+		logger.WithError(err).Debug("Failed to create workload client")
+		s.workloadHealthMetrics.SetWorkloadAPIHealth(cluster.Name, cluster.Namespace, false, 0)
+		s.workloadHealthMetrics.DeleteWorkloadNodes(cluster.Name, cluster.Namespace)
+		return
+	}
+	s.recordWorkloadHealth(scrapeCtx, cluster.Name, cluster.Namespace, workloadClient)
+}
+
+// recordWorkloadHealth checks that a workload cluster's API server answers
+// and counts its Ready nodes.
+func (s *EnhancedClusterService) recordWorkloadHealth(ctx context.Context, clusterName, namespace string, workloadClient *kube.WorkloadClient) {
+	startedAt := time.Now()
+	_, err := workloadClient.ServerVersion()
+	s.workloadHealthMetrics.SetWorkloadAPIHealth(clusterName, namespace, err == nil, time.Since(startedAt))
+	if err != nil {
+		s.workloadHealthMetrics.DeleteWorkloadNodes(clusterName, namespace)
+		return
+	}
+
+	nodes, err := workloadClient.ListNodes(ctx)
+	if err != nil {
+		s.workloadHealthMetrics.DeleteWorkloadNodes(clusterName, namespace)
+		return
+	}
+	ready := 0
+	for i := range nodes.Items {
+		if nodeReady(&nodes.Items[i]) {
+			ready++
+		}
+	}
+	s.workloadHealthMetrics.SetWorkloadNodes(clusterName, namespace, len(nodes.Items), ready)
+}
+
+// nodeReady reports whether a node's Ready condition is True.
+func nodeReady(node *corev1.Node) bool {
+	return getNodeStatus(node) == "Ready"
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// workloadHealth is the health recorded for a cluster by fakeWorkloadHealthMetrics.
+type workloadHealth struct {
+	up           bool
+	nodes, ready int
+	hasNodes     bool
+}
+
+type fakeWorkloadHealthMetrics struct {
+	mu       sync.Mutex
+	clusters map[string]workloadHealth
+}
+
+func newFakeWorkloadHealthMetrics() *fakeWorkloadHealthMetrics {
+	return &fakeWorkloadHealthMetrics{clusters: map[string]workloadHealth{}}
+}
+
+func (f *fakeWorkloadHealthMetrics) SetWorkloadAPIHealth(cluster, namespace string, up bool, latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	health := f.clusters[namespace+"/"+cluster]
+	health.up = up
+	f.clusters[namespace+"/"+cluster] = health
+}
+
+func (f *fakeWorkloadHealthMetrics) SetWorkloadNodes(cluster, namespace string, nodes, ready int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	health := f.clusters[namespace+"/"+cluster]
+	health.nodes, health.ready, health.hasNodes = nodes, ready, true
+	f.clusters[namespace+"/"+cluster] = health
+}
+
+func (f *fakeWorkloadHealthMetrics) DeleteWorkloadNodes(cluster, namespace string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	health := f.clusters[namespace+"/"+cluster]
+	health.nodes, health.ready, health.hasNodes = 0, 0, false
+	f.clusters[namespace+"/"+cluster] = health
+}
+
+func (f *fakeWorkloadHealthMetrics) DeleteWorkloadHealth(cluster, namespace string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.clusters, namespace+"/"+cluster)
+}
+
+func TestEnhancedClusterService_RecordWorkloadHealth(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: ready},
+			}},
+		}
+	}
+
+	svc, _ := setupEnhancedTestService(t)
+	metrics := newFakeWorkloadHealthMetrics()
+	svc.SetWorkloadHealthMetrics(metrics, WorkloadHealthOptions{Interval: time.Minute})
+
+	svc.recordWorkloadHealth(context.Background(), "test-cluster", testNamespace, newTestWorkloadClient(
+		node("cp-0", corev1.ConditionTrue),
+		node("worker-0", corev1.ConditionTrue),
+		node("worker-1", corev1.ConditionFalse),
+	))
+
+	assert.Equal(t, workloadHealth{up: true, nodes: 3, ready: 2, hasNodes: true}, metrics.clusters[testNamespace+"/test-cluster"])
+}
+
+func TestEnhancedClusterService_CollectWorkloadHealth(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupEnhancedTestService(t,
+		createTestCluster("provisioned", testNamespace, clusterv1.ClusterPhaseProvisioned),
+		createTestCluster("provisioning", testNamespace, clusterv1.ClusterPhaseProvisioning),
+	)
+	metrics := newFakeWorkloadHealthMetrics()
+	metrics.clusters[testNamespace+"/deleted"] = workloadHealth{up: true}
+	svc.SetWorkloadHealthMetrics(metrics, WorkloadHealthOptions{Interval: time.Minute, Concurrency: 2})
+
+	previous := map[types.NamespacedName]bool{{Namespace: testNamespace, Name: "deleted"}: true}
+	scraped, err := svc.collectWorkloadHealth(ctx, previous)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[types.NamespacedName]bool{{Namespace: testNamespace, Name: "provisioned"}: true}, scraped)
+	assert.Equal(t, map[string]workloadHealth{
+		// No kubeconfig Secret, so the API server cannot be reached
+		testNamespace + "/provisioned": {up: false},
+	}, metrics.clusters)
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// maxWorkloadResources bounds the number of resources returned by a single
+// get_workload_resource call.
+const maxWorkloadResources = 200
+
+// readableKind is a workload cluster resource kind get_workload_resource may read.
+type readableKind struct {
+	kind       string
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// readableKinds lists the workload cluster resources agents may read. Secrets
+// and anything else holding credentials are deliberately left out.
+var readableKinds = []readableKind{
+	{kind: "Pod", gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, namespaced: true},
+	{kind: "Node", gvr: schema.GroupVersionResource{Version: "v1", Resource: "nodes"}},
+	{kind: "Namespace", gvr: schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}},
+	{kind: "Service", gvr: schema.GroupVersionResource{Version: "v1", Resource: "services"}, namespaced: true},
+	{kind: "Endpoints", gvr: schema.GroupVersionResource{Version: "v1", Resource: "endpoints"}, namespaced: true},
+	{kind: "ConfigMap", gvr: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, namespaced: true},
+	{kind: "Event", gvr: schema.GroupVersionResource{Version: "v1", Resource: "events"}, namespaced: true},
+	{kind: "PersistentVolume", gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumes"}},
+	{kind: "PersistentVolumeClaim", gvr: schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, namespaced: true},
+	{kind: "Deployment", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, namespaced: true},
+	{kind: "DaemonSet", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, namespaced: true},
+	{kind: "StatefulSet", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, namespaced: true},
+	{kind: "ReplicaSet", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "replicasets"}, namespaced: true},
+	{kind: "Job", gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, namespaced: true},
+	{kind: "CronJob", gvr: schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, namespaced: true},
+	{kind: "Ingress", gvr: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}, namespaced: true},
+	{kind: "NetworkPolicy", gvr: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"}, namespaced: true},
+	{kind: "PodDisruptionBudget", gvr: schema.GroupVersionResource{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}, namespaced: true},
+	{kind: "StorageClass", gvr: schema.GroupVersionResource{Group: "storage.k8s.io", Version: "v1", Resource: "storageclasses"}},
+}
+
+// lookupReadableKind finds an allowed kind, matched case-insensitively, and
+// checks the API version if one is given.
+func lookupReadableKind(apiVersion, kind string) (readableKind, error) {
+	for _, readable := range readableKinds {
+		if !strings.EqualFold(readable.kind, kind) {
+			continue
+		}
+		if apiVersion != "" && apiVersion != readable.gvr.GroupVersion().String() {
+			return readableKind{}, errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s can only be read as %s", readable.kind, readable.gvr.GroupVersion().String()))
+		}
+		return readable, nil
+	}
+
+	allowed := make([]string, 0, len(readableKinds))
+	for _, readable := range readableKinds {
+		allowed = append(allowed, readable.kind)
+	}
+	return readableKind{}, errors.New(errors.CodeForbidden,
+		fmt.Sprintf("kind '%s' is not readable, allowed kinds: %s", kind, strings.Join(allowed, ", ")))
+}
+
+// GetWorkloadResource reads resources of an allowed kind from a workload
+// cluster, either a single resource by name or a list filtered by a label
+// selector. Agents get read access to what they need for triage without being
+// handed the cluster's kubeconfig.
+func (s *EnhancedClusterService) GetWorkloadResource(ctx context.Context, input api.GetWorkloadResourceInput) (*api.GetWorkloadResourceOutput, error) {
+	logger := s.logger.WithContext(ctx).WithOperation("GetWorkloadResource").WithCluster(input.ClusterName, "")
+	logger.Debug("Reading workload cluster resource", "kind", input.Kind, "name", input.Name)
+
+	if input.ClusterName == "" {
+		err := errors.New(errors.CodeInvalidInput, "cluster name is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Kind == "" {
+		err := errors.New(errors.CodeInvalidInput, "kind is required")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	readable, err := lookupReadableKind(input.APIVersion, input.Kind)
+	if err != nil {
+		logger.WithError(err).Warn("Kind not readable")
+		return nil, err
+	}
+	if input.Name != "" && input.LabelSelector != "" {
+		err := errors.New(errors.CodeInvalidInput, "name and label selector are mutually exclusive")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if _, err := labels.Parse(input.LabelSelector); err != nil {
+		err := errors.Wrap(err, errors.CodeInvalidInput, "invalid label selector")
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if input.Name != "" && readable.namespaced && input.ResourceNamespace == "" {
+		err := errors.New(errors.CodeInvalidInput, fmt.Sprintf("a namespace is required to get a %s by name", readable.kind))
+		logger.WithError(err).Error("Invalid input")
+		return nil, err
+	}
+	if s.kubeClient == nil {
+		err := errors.New(errors.CodeUnavailable, "Kubernetes client not initialized")
+		logger.WithError(err).Error("Service unavailable")
+		return nil, err
+	}
+
+	readCtx, cancel := budget.Sub(ctx, 30*time.Second)
+	defer cancel()
+
+	workloadClient, err := s.newWorkloadClient(readCtx, input.ClusterName)
+	if err != nil {
+		logger.WithError(err).Error("Failed to create workload client")
+		return nil, err
+	}
+
+	output, err := readWorkloadResource(readCtx, workloadClient, readable, input)
+	if err != nil {
+		logger.WithError(err).Error("Failed to read workload cluster resource")
+		return nil, err
+	}
+	output.ClusterName = input.ClusterName
+
+	logger.Info("Read workload cluster resource", "kind", readable.kind, "count", output.Count)
+	return output, nil
+}
+
+// readWorkloadResource gets or lists resources of an allowed kind.
+func readWorkloadResource(ctx context.Context, workloadClient *kube.WorkloadClient, readable readableKind, input api.GetWorkloadResourceInput) (*api.GetWorkloadResourceOutput, error) {
+	namespace := ""
+	if readable.namespaced {
+		namespace = input.ResourceNamespace
+	}
+	output := &api.GetWorkloadResourceOutput{
+		APIVersion: readable.gvr.GroupVersion().String(),
+		Kind:       readable.kind,
+		Items:      []map[string]interface{}{},
+	}
+
+	if input.Name != "" {
+		obj, err := workloadClient.GetResource(ctx, readable.gvr, namespace, input.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("%s '%s' not found in the workload cluster", readable.kind, input.Name))
+			}
+			return nil, errors.Wrap(err, errors.CodeWorkloadCluster, fmt.Sprintf("failed to get %s", readable.kind))
+		}
+		output.Items = append(output.Items, readableObject(obj))
+	} else {
+		list, err := workloadClient.ListResources(ctx, readable.gvr, namespace, input.LabelSelector, maxWorkloadResources)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeWorkloadCluster, fmt.Sprintf("failed to list %s", readable.gvr.Resource))
+		}
+		for i := range list.Items {
+			output.Items = append(output.Items, readableObject(&list.Items[i]))
+		}
+		output.Truncated = list.GetContinue() != ""
+	}
+
+	output.Count = len(output.Items)
+	output.Message = fmt.Sprintf("Found %d %s resource(s)", output.Count, readable.kind)
+	if output.Truncated {
+		output.Message += fmt.Sprintf("; more matched than the %d returned, narrow the namespace or label selector", maxWorkloadResources)
+	}
+	return output, nil
+}
+
+// readableObject returns the content of a resource without its managed fields,
+// which are noise for triage.
+func readableObject(obj *unstructured.Unstructured) map[string]interface{} {
+	obj.SetManagedFields(nil)
+	return obj.Object
+}
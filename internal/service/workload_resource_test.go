@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestLookupReadableKind(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiVersion string
+		kind       string
+		want       string
+		code       errors.ErrorCode
+	}{
+		{name: "core kind", kind: "Pod", want: "pods"},
+		{name: "case insensitive", kind: "daemonset", want: "daemonsets"},
+		{name: "matching api version", apiVersion: "apps/v1", kind: "Deployment", want: "deployments"},
+		{name: "wrong api version", apiVersion: "v1", kind: "Deployment", code: errors.CodeInvalidInput},
+		{name: "secrets are not readable", kind: "Secret", code: errors.CodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readable, err := lookupReadableKind(tt.apiVersion, tt.kind)
+			if tt.code != "" {
+				assert.Equal(t, tt.code, errors.GetErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, readable.gvr.Resource)
+		})
+	}
+}
+
+func TestReadWorkloadResource(t *testing.T) {
+	ctx := context.Background()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:          "coredns-1",
+		Namespace:     "kube-system",
+		Labels:        map[string]string{"k8s-app": "kube-dns"},
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+	}}
+	workloadClient := kube.NewWorkloadClientWithDynamic(fake.NewSimpleClientset(), dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+		pod,
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "calico-node-1", Namespace: "calico-system"}},
+	))
+	pods, err := lookupReadableKind("", "Pod")
+	require.NoError(t, err)
+
+	t.Run("get by name", func(t *testing.T) {
+		output, err := readWorkloadResource(ctx, workloadClient, pods, api.GetWorkloadResourceInput{ResourceNamespace: "kube-system", Name: "coredns-1"})
+		require.NoError(t, err)
+		require.Equal(t, 1, output.Count)
+		assert.Equal(t, "v1", output.APIVersion)
+		metadata := output.Items[0]["metadata"].(map[string]interface{})
+		assert.Equal(t, "coredns-1", metadata["name"])
+		assert.NotContains(t, metadata, "managedFields")
+	})
+
+	t.Run("list by selector across namespaces", func(t *testing.T) {
+		output, err := readWorkloadResource(ctx, workloadClient, pods, api.GetWorkloadResourceInput{LabelSelector: "k8s-app=kube-dns"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.Count)
+		assert.False(t, output.Truncated)
+	})
+
+	t.Run("list namespace", func(t *testing.T) {
+		output, err := readWorkloadResource(ctx, workloadClient, pods, api.GetWorkloadResourceInput{ResourceNamespace: "calico-system"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.Count)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := readWorkloadResource(ctx, workloadClient, pods, api.GetWorkloadResourceInput{ResourceNamespace: "kube-system", Name: "missing"})
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
+
+func TestEnhancedClusterService_GetWorkloadResource_Validation(t *testing.T) {
+	svc, _ := setupEnhancedTestService(t)
+
+	tests := []struct {
+		name  string
+		input api.GetWorkloadResourceInput
+		code  errors.ErrorCode
+	}{
+		{name: "missing cluster name", input: api.GetWorkloadResourceInput{Kind: "Pod"}, code: errors.CodeInvalidInput},
+		{name: "missing kind", input: api.GetWorkloadResourceInput{ClusterName: "test"}, code: errors.CodeInvalidInput},
+		{name: "forbidden kind", input: api.GetWorkloadResourceInput{ClusterName: "test", Kind: "Secret"}, code: errors.CodeForbidden},
+		{name: "name and selector", input: api.GetWorkloadResourceInput{ClusterName: "test", Kind: "Pod", ResourceNamespace: "default", Name: "a", LabelSelector: "app=a"}, code: errors.CodeInvalidInput},
+		{name: "invalid selector", input: api.GetWorkloadResourceInput{ClusterName: "test", Kind: "Pod", LabelSelector: "app in ("}, code: errors.CodeInvalidInput},
+		{name: "name without namespace", input: api.GetWorkloadResourceInput{ClusterName: "test", Kind: "Pod", Name: "a"}, code: errors.CodeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetWorkloadResource(context.Background(), tt.input)
+			assert.Equal(t, tt.code, errors.GetErrorCode(err))
+		})
+	}
+}
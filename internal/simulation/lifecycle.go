@@ -0,0 +1,482 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// workerClass is the worker class of the cluster template.
+	workerClass = "default-worker"
+
+	// endpointDomain and endpointPort make up the API server endpoints of
+	// simulated clusters, <cluster>.<namespace>.<endpointDomain>.
+	endpointDomain = "simulated.capi-mcp.local"
+	endpointPort   = 6443
+
+	// providerLabel records the infrastructure provider of a cluster.
+	providerLabel = "cluster.x-k8s.io/provider"
+)
+
+// step advances every cluster by one step.
+func (s *Simulator) step(ctx context.Context) error {
+	clusters := &clusterv1.ClusterList{}
+	if err := s.client.List(ctx, clusters); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+
+	var errs []error
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if err := s.advance(ctx, cluster); err != nil {
+			errs = append(errs, fmt.Errorf("cluster %s/%s: %w", cluster.Namespace, cluster.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// advance moves a cluster one step further through its lifecycle.
+func (s *Simulator) advance(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if cluster.DeletionTimestamp != nil {
+		return s.tearDown(ctx, cluster)
+	}
+
+	switch clusterv1.ClusterPhase(cluster.Status.Phase) {
+	case "":
+		accept(cluster)
+		return s.client.Update(ctx, cluster)
+
+	case clusterv1.ClusterPhasePending:
+		// The infrastructure is up and the control plane starts
+		if err := s.createControlPlane(ctx, cluster); err != nil {
+			return err
+		}
+		if _, err := s.convergeControlPlane(ctx, cluster); err != nil {
+			return err
+		}
+		cluster.Status.InfrastructureReady = true
+		setCondition(cluster, clusterv1.InfrastructureReadyCondition)
+		cluster.Status.Phase = string(clusterv1.ClusterPhaseProvisioning)
+		return s.client.Update(ctx, cluster)
+
+	case clusterv1.ClusterPhaseProvisioning:
+		ready, err := s.convergeControlPlane(ctx, cluster)
+		if err != nil || !ready {
+			return err
+		}
+		// The control plane is up: publish the kubeconfig and start the workers
+		if err := s.createKubeconfig(ctx, cluster); err != nil {
+			return err
+		}
+		if err := s.createWorkers(ctx, cluster); err != nil {
+			return err
+		}
+		if err := s.convergeWorkers(ctx, cluster); err != nil {
+			return err
+		}
+		cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: endpointHost(cluster), Port: endpointPort}
+		cluster.Status.ControlPlaneReady = true
+		setCondition(cluster, clusterv1.ControlPlaneInitializedCondition)
+		setCondition(cluster, clusterv1.ControlPlaneReadyCondition)
+		setCondition(cluster, clusterv1.ReadyCondition)
+		cluster.Status.Phase = string(clusterv1.ClusterPhaseProvisioned)
+		return s.client.Update(ctx, cluster)
+
+	case clusterv1.ClusterPhaseProvisioned:
+		if _, err := s.convergeControlPlane(ctx, cluster); err != nil {
+			return err
+		}
+		return s.convergeWorkers(ctx, cluster)
+	}
+	return nil
+}
+
+// accept takes a new cluster in as Pending, with the finalizer that keeps it
+// until it is torn down.
+func accept(cluster *clusterv1.Cluster) {
+	if cluster.CreationTimestamp.IsZero() {
+		cluster.CreationTimestamp = metav1.Now()
+	}
+	if cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+	cluster.Labels[clusterv1.ClusterNameLabel] = cluster.Name
+	cluster.Labels[providerLabel] = "aws"
+	if !slices.Contains(cluster.Finalizers, clusterv1.ClusterFinalizer) {
+		cluster.Finalizers = append(cluster.Finalizers, clusterv1.ClusterFinalizer)
+	}
+	cluster.Spec.ControlPlaneRef = &corev1.ObjectReference{
+		APIVersion: controlplanev1.GroupVersion.String(),
+		Kind:       "KubeadmControlPlane",
+		Name:       cluster.Name + "-control-plane",
+		Namespace:  cluster.Namespace,
+	}
+	cluster.Status.Phase = string(clusterv1.ClusterPhasePending)
+}
+
+// tearDown deletes a cluster: it is Deleting for a step, then its objects
+// are removed and the cluster is gone.
+func (s *Simulator) tearDown(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if cluster.Status.Phase != string(clusterv1.ClusterPhaseDeleting) {
+		cluster.Status.Phase = string(clusterv1.ClusterPhaseDeleting)
+		return s.client.Update(ctx, cluster)
+	}
+
+	owned := []client.DeleteAllOfOption{
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	}
+	for _, obj := range []client.Object{&clusterv1.Machine{}, &clusterv1.MachineDeployment{}, &controlplanev1.KubeadmControlPlane{}, &corev1.Secret{}} {
+		if err := s.client.DeleteAllOf(ctx, obj, owned...); err != nil {
+			return fmt.Errorf("failed to delete the objects of the cluster: %w", err)
+		}
+	}
+	cluster.Finalizers = slices.DeleteFunc(cluster.Finalizers, func(finalizer string) bool {
+		return finalizer == clusterv1.ClusterFinalizer
+	})
+	return s.client.Update(ctx, cluster)
+}
+
+// createControlPlane creates the KubeadmControlPlane of a cluster with the
+// replicas of its topology.
+func (s *Simulator) createControlPlane(ctx context.Context, cluster *clusterv1.Cluster) error {
+	replicas := int32(1)
+	if cluster.Spec.Topology != nil && cluster.Spec.Topology.ControlPlane.Replicas != nil {
+		replicas = *cluster.Spec.Topology.ControlPlane.Replicas
+	}
+	controlPlane := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: s.objectMeta(cluster, cluster.Spec.ControlPlaneRef.Name, nil),
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: &replicas,
+			Version:  topologyVersion(cluster),
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+					Kind:       "AWSMachineTemplate",
+					Name:       cluster.Name + "-control-plane",
+					Namespace:  cluster.Namespace,
+				},
+			},
+		},
+	}
+	if err := s.client.Create(ctx, controlPlane); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create control plane: %w", err)
+	}
+	return nil
+}
+
+// convergeControlPlane advances the control plane Machines of a cluster one
+// step towards the replicas of its KubeadmControlPlane and reports whether
+// they are all ready.
+func (s *Simulator) convergeControlPlane(ctx context.Context, cluster *clusterv1.Cluster) (bool, error) {
+	controlPlane := &controlplanev1.KubeadmControlPlane{}
+	if err := s.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, controlPlane); err != nil {
+		return false, fmt.Errorf("failed to get control plane: %w", err)
+	}
+
+	desired := ptrValue(controlPlane.Spec.Replicas)
+	replicas, ready, err := s.converge(ctx, cluster, machinePool{
+		name:     controlPlane.Name,
+		labels:   map[string]string{clusterv1.MachineControlPlaneLabel: ""},
+		replicas: desired,
+		version:  controlPlane.Spec.Version,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	controlPlane.Status.Replicas = replicas
+	controlPlane.Status.UpdatedReplicas = replicas
+	controlPlane.Status.ReadyReplicas = ready
+	controlPlane.Status.UnavailableReplicas = replicas - ready
+	controlPlane.Status.Initialized = controlPlane.Status.Initialized || ready > 0
+	controlPlane.Status.Ready = ready > 0
+	controlPlane.Status.Version = &controlPlane.Spec.Version
+	if err := s.client.Update(ctx, controlPlane); err != nil {
+		return false, fmt.Errorf("failed to update control plane: %w", err)
+	}
+	return replicas == desired && ready == desired, nil
+}
+
+// createWorkers creates the MachineDeployments of the workers of a cluster:
+// those of its topology or, without any, one of the template's worker class
+// with nodeCount replicas.
+func (s *Simulator) createWorkers(ctx context.Context, cluster *clusterv1.Cluster) error {
+	var topologies []clusterv1.MachineDeploymentTopology
+	if cluster.Spec.Topology != nil && cluster.Spec.Topology.Workers != nil {
+		topologies = cluster.Spec.Topology.Workers.MachineDeployments
+	}
+	if len(topologies) == 0 {
+		replicas := int32(intVariable(cluster, "nodeCount", 2))
+		topologies = []clusterv1.MachineDeploymentTopology{{Class: workerClass, Name: "md-0", Replicas: &replicas}}
+	}
+
+	for _, topology := range topologies {
+		replicas := ptrValue(topology.Replicas)
+		name := cluster.Name + "-" + topology.Name
+		md := &clusterv1.MachineDeployment{
+			ObjectMeta: s.objectMeta(cluster, name, map[string]string{
+				clusterv1.ClusterTopologyOwnedLabel:                 "",
+				clusterv1.ClusterTopologyMachineDeploymentNameLabel: topology.Name,
+			}),
+			Spec: clusterv1.MachineDeploymentSpec{
+				ClusterName: cluster.Name,
+				Replicas:    &replicas,
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						ClusterName: cluster.Name,
+						Version:     ptr(topologyVersion(cluster)),
+						InfrastructureRef: corev1.ObjectReference{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+							Kind:       "AWSMachineTemplate",
+							Name:       name,
+							Namespace:  cluster.Namespace,
+						},
+					},
+				},
+			},
+		}
+		if err := s.client.Create(ctx, md); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create machine deployment %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// convergeWorkers advances the Machines of each MachineDeployment of a
+// cluster one step towards its replicas.
+func (s *Simulator) convergeWorkers(ctx context.Context, cluster *clusterv1.Cluster) error {
+	mds := &clusterv1.MachineDeploymentList{}
+	if err := s.client.List(ctx, mds, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return fmt.Errorf("failed to list machine deployments: %w", err)
+	}
+
+	for i := range mds.Items {
+		md := &mds.Items[i]
+		desired := ptrValue(md.Spec.Replicas)
+		replicas, ready, err := s.converge(ctx, cluster, machinePool{
+			name:     md.Name,
+			labels:   map[string]string{clusterv1.MachineDeploymentNameLabel: md.Name},
+			replicas: desired,
+			version:  ptrValue(md.Spec.Template.Spec.Version),
+		})
+		if err != nil {
+			return err
+		}
+
+		md.Status.Replicas = replicas
+		md.Status.UpdatedReplicas = replicas
+		md.Status.ReadyReplicas = ready
+		md.Status.AvailableReplicas = ready
+		md.Status.UnavailableReplicas = replicas - ready
+		switch {
+		case replicas > desired:
+			md.Status.Phase = string(clusterv1.MachineDeploymentPhaseScalingDown)
+		case ready < desired:
+			md.Status.Phase = string(clusterv1.MachineDeploymentPhaseScalingUp)
+		default:
+			md.Status.Phase = string(clusterv1.MachineDeploymentPhaseRunning)
+		}
+		if err := s.client.Update(ctx, md); err != nil {
+			return fmt.Errorf("failed to update machine deployment %s: %w", md.Name, err)
+		}
+	}
+	return nil
+}
+
+// machinePool is a set of Machines of a cluster kept at a number of
+// replicas, such as the control plane or a MachineDeployment.
+type machinePool struct {
+	name     string
+	labels   map[string]string
+	replicas int32
+	version  string
+}
+
+// converge advances the Machines of a pool one step towards its replicas:
+// Machines created on the last step join the cluster as nodes, missing ones
+// are created and extra ones, the newest first, are deleted. It returns the
+// number of Machines left and how many of them are ready.
+func (s *Simulator) converge(ctx context.Context, cluster *clusterv1.Cluster, pool machinePool) (int32, int32, error) {
+	labels := client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}
+	for key, value := range pool.labels {
+		labels[key] = value
+	}
+	machines := &clusterv1.MachineList{}
+	if err := s.client.List(ctx, machines, client.InNamespace(cluster.Namespace), labels); err != nil {
+		return 0, 0, fmt.Errorf("failed to list machines of %s: %w", pool.name, err)
+	}
+	slices.SortFunc(machines.Items, func(a, b clusterv1.Machine) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	var replicas, ready int32
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if replicas >= pool.replicas {
+			if err := s.client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+				return 0, 0, fmt.Errorf("failed to delete machine %s: %w", machine.Name, err)
+			}
+			continue
+		}
+		replicas++
+		if machine.Status.Phase == string(clusterv1.MachinePhaseProvisioning) {
+			s.join(machine)
+			if err := s.client.Update(ctx, machine); err != nil {
+				return 0, 0, fmt.Errorf("failed to update machine %s: %w", machine.Name, err)
+			}
+		}
+		ready++
+	}
+
+	for ; replicas < pool.replicas; replicas++ {
+		machine := &clusterv1.Machine{
+			ObjectMeta: s.objectMeta(cluster, pool.name+"-"+rand.String(5), labels),
+			Spec: clusterv1.MachineSpec{
+				ClusterName: cluster.Name,
+				Version:     ptr(pool.version),
+			},
+			Status: clusterv1.MachineStatus{
+				Phase:          string(clusterv1.MachinePhaseProvisioning),
+				BootstrapReady: true,
+			},
+		}
+		if err := s.client.Create(ctx, machine); err != nil {
+			return 0, 0, fmt.Errorf("failed to create machine of %s: %w", pool.name, err)
+		}
+	}
+	return replicas, ready, nil
+}
+
+// join makes a Machine a running node of its cluster.
+func (s *Simulator) join(machine *clusterv1.Machine) {
+	s.machines++
+	machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+	machine.Status.InfrastructureReady = true
+	machine.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", APIVersion: "v1", Name: machine.Name}
+	machine.Status.Addresses = clusterv1.MachineAddresses{{
+		Type:    clusterv1.MachineInternalIP,
+		Address: fmt.Sprintf("10.0.%d.%d", 1+s.machines/250, 4+s.machines%250),
+	}}
+	machine.Status.NodeInfo = &corev1.NodeSystemInfo{KubeletVersion: ptrValue(machine.Spec.Version)}
+}
+
+// createKubeconfig publishes the kubeconfig secret of a cluster.
+func (s *Simulator) createKubeconfig(ctx context.Context, cluster *clusterv1.Cluster) error {
+	config := clientcmdapi.NewConfig()
+	config.Clusters[cluster.Name] = &clientcmdapi.Cluster{
+		Server:                fmt.Sprintf("https://%s:%d", endpointHost(cluster), endpointPort),
+		InsecureSkipTLSVerify: true,
+	}
+	config.AuthInfos[cluster.Name+"-admin"] = &clientcmdapi.AuthInfo{Token: "simulated"}
+	config.Contexts[cluster.Name] = &clientcmdapi.Context{Cluster: cluster.Name, AuthInfo: cluster.Name + "-admin"}
+	config.CurrentContext = cluster.Name
+	data, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: s.objectMeta(cluster, cluster.Name+"-kubeconfig", nil),
+		Type:       clusterv1.ClusterSecretType,
+		Data:       map[string][]byte{"value": data},
+	}
+	if err := s.client.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create kubeconfig secret: %w", err)
+	}
+	return nil
+}
+
+// objectMeta is the metadata of an object of a cluster.
+func (s *Simulator) objectMeta(cluster *clusterv1.Cluster, name string, labels map[string]string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         cluster.Namespace,
+		Labels:            map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		CreationTimestamp: metav1.Now(),
+	}
+	for key, value := range labels {
+		meta.Labels[key] = value
+	}
+	return meta
+}
+
+// endpointHost is the API server host of a simulated cluster.
+func endpointHost(cluster *clusterv1.Cluster) string {
+	return strings.Join([]string{cluster.Name, cluster.Namespace, endpointDomain}, ".")
+}
+
+// setCondition marks a condition of a cluster true.
+func setCondition(cluster *clusterv1.Cluster, conditionType clusterv1.ConditionType) {
+	condition := clusterv1.Condition{
+		Type:               conditionType,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}
+	for i := range cluster.Status.Conditions {
+		if cluster.Status.Conditions[i].Type == conditionType {
+			cluster.Status.Conditions[i] = condition
+			return
+		}
+	}
+	cluster.Status.Conditions = append(cluster.Status.Conditions, condition)
+}
+
+func topologyVersion(cluster *clusterv1.Cluster) string {
+	if cluster.Spec.Topology != nil && cluster.Spec.Topology.Version != "" {
+		return cluster.Spec.Topology.Version
+	}
+	return DefaultKubernetesVersion
+}
+
+// stringVariable returns a string variable of the topology of a cluster.
+func stringVariable(cluster *clusterv1.Cluster, name, fallback string) string {
+	var value string
+	if decodeVariable(cluster, name, &value) && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// intVariable returns a number variable of the topology of a cluster.
+func intVariable(cluster *clusterv1.Cluster, name string, fallback int) int {
+	var value float64
+	if decodeVariable(cluster, name, &value) {
+		return int(value)
+	}
+	return fallback
+}
+
+func decodeVariable(cluster *clusterv1.Cluster, name string, value interface{}) bool {
+	if cluster.Spec.Topology == nil {
+		return false
+	}
+	for _, variable := range cluster.Spec.Topology.Variables {
+		if variable.Name == name {
+			return json.Unmarshal(variable.Value.Raw, value) == nil
+		}
+	}
+	return false
+}
+
+func ptrValue[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,206 @@
+// Package simulation serves an in-memory management cluster whose clusters
+// move through their phases over time as if CAPI controllers provisioned
+// them, so demos, client development and tests need no management cluster.
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+const (
+	// DefaultStepInterval is how long clusters stay in each phase by
+	// default.
+	DefaultStepInterval = 10 * time.Second
+
+	// TemplateName is the cluster template the simulated management cluster
+	// offers.
+	TemplateName = "aws-cluster-template"
+
+	// DefaultKubernetesVersion is the version of the demo clusters.
+	DefaultKubernetesVersion = "v1.30.2"
+)
+
+// Simulator is an in-memory management cluster. Clusters created in it are
+// Pending at once and then advance one step every interval: Pending,
+// Provisioning with their control plane coming up, and Provisioned with
+// their workers joining. Node pools converge on their replicas and deleted
+// clusters go through Deleting before they are gone.
+type Simulator struct {
+	client    client.WithWatch
+	kube      *kube.Client
+	namespace string
+	interval  time.Duration
+
+	// machines counts the Machines created, to give their nodes addresses.
+	machines int
+}
+
+// New creates a simulated management cluster serving namespace, with the
+// cluster template and two provisioned demo clusters, demo-prod and
+// demo-dev. A non-positive interval uses DefaultStepInterval.
+func New(namespace string, interval time.Duration) (*Simulator, error) {
+	if interval <= 0 {
+		interval = DefaultStepInterval
+	}
+	scheme, err := kube.NewScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{
+		namespace: namespace,
+		interval:  interval,
+	}
+	s.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+		clusterClass(namespace),
+	).WithInterceptorFuncs(interceptor.Funcs{Create: s.create}).Build()
+	s.kube = kube.NewClientFromClient(s.client, namespace)
+	s.kube.SetWorkloadClientFactory(s.workloadClient)
+
+	ctx := context.Background()
+	for _, demo := range []struct {
+		name    string
+		workers int
+	}{
+		{name: "demo-prod", workers: 3},
+		{name: "demo-dev", workers: 1},
+	} {
+		if err := s.client.Create(ctx, demoCluster(namespace, demo.name, demo.workers)); err != nil {
+			return nil, fmt.Errorf("failed to create demo cluster %s: %w", demo.name, err)
+		}
+	}
+	// Provision the demo clusters up front: start the control plane, let it
+	// join and start the workers, let them join
+	for range 3 {
+		if err := s.step(ctx); err != nil {
+			return nil, fmt.Errorf("failed to provision the demo clusters: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Client returns the client of the simulated management cluster. Clients of
+// its workload clusters serve the nodes of their Machines.
+func (s *Simulator) Client() *kube.Client {
+	return s.kube
+}
+
+// Run advances the simulated clusters every step interval until ctx is
+// cancelled.
+func (s *Simulator) Run(ctx context.Context, logger *logging.Logger) {
+	logger = logger.WithOperation("Simulate")
+	logger.Info("Simulating the management cluster", "namespace", s.namespace, "step_interval", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.step(ctx); err != nil {
+				logger.WithError(err).Warn("Failed to advance clusters")
+			}
+		}
+	}
+}
+
+// create stores new objects, accepting new clusters as Pending on the spot
+// like the CAPI controllers would within moments.
+func (s *Simulator) create(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	if cluster, ok := obj.(*clusterv1.Cluster); ok && cluster.Status.Phase == "" {
+		accept(cluster)
+	}
+	return c.Create(ctx, obj, opts...)
+}
+
+// clusterClass is the cluster template of the simulated management cluster,
+// with the variables of the AWS template.
+func clusterClass(namespace string) *clusterv1.ClusterClass {
+	variable := func(name string, required bool, schema clusterv1.JSONSchemaProps, value interface{}) clusterv1.ClusterClassVariable {
+		if value != nil {
+			raw, _ := json.Marshal(value)
+			schema.Default = &apiextensionsv1.JSON{Raw: raw}
+		}
+		return clusterv1.ClusterClassVariable{
+			Name:     name,
+			Required: required,
+			Schema:   clusterv1.VariableSchema{OpenAPIV3Schema: schema},
+		}
+	}
+	str := clusterv1.JSONSchemaProps{Type: "string"}
+	return &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Name: TemplateName, Namespace: namespace},
+		Spec: clusterv1.ClusterClassSpec{
+			ControlPlane: clusterv1.ControlPlaneClass{
+				LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: &corev1.ObjectReference{
+					APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+					Kind:       "KubeadmControlPlaneTemplate",
+					Name:       "aws-control-plane-template",
+					Namespace:  namespace,
+				}},
+			},
+			Infrastructure: clusterv1.LocalObjectTemplate{Ref: &corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+				Kind:       "AWSClusterTemplate",
+				Name:       "aws-cluster-template",
+				Namespace:  namespace,
+			}},
+			Workers: clusterv1.WorkersClass{
+				MachineDeployments: []clusterv1.MachineDeploymentClass{{Class: workerClass}},
+			},
+			Variables: []clusterv1.ClusterClassVariable{
+				variable("region", true, str, nil),
+				variable("nodeCount", false, clusterv1.JSONSchemaProps{Type: "integer", Minimum: ptr(int64(0))}, 2),
+				variable("controlPlaneInstanceType", false, str, "t3.medium"),
+				variable("workerInstanceType", false, str, "t3.small"),
+				variable("vpcCIDR", false, str, "10.0.0.0/16"),
+				variable("subnetCIDR", false, str, "10.0.1.0/24"),
+			},
+		},
+	}
+}
+
+// demoCluster is a cluster of the template in us-west-2 with workers.
+func demoCluster(namespace, name string, workers int) *clusterv1.Cluster {
+	variable := func(name string, value interface{}) clusterv1.ClusterVariable {
+		raw, _ := json.Marshal(value)
+		return clusterv1.ClusterVariable{Name: name, Value: apiextensionsv1.JSON{Raw: raw}}
+	}
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: name},
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Class:   TemplateName,
+				Version: DefaultKubernetesVersion,
+				Variables: []clusterv1.ClusterVariable{
+					variable("region", "us-west-2"),
+					variable("nodeCount", workers),
+				},
+			},
+		},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
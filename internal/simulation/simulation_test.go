@@ -0,0 +1,134 @@
+package simulation
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+)
+
+func TestNew(t *testing.T) {
+	sim, err := New("default", 0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultStepInterval, sim.interval)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		workers int32
+	}{
+		{name: "demo-prod", workers: 3},
+		{name: "demo-dev", workers: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster, err := sim.Client().GetClusterByName(ctx, tt.name)
+			require.NoError(t, err)
+			assert.Equal(t, string(clusterv1.ClusterPhaseProvisioned), cluster.Status.Phase)
+			assert.Equal(t, tt.name+".default."+endpointDomain, cluster.Spec.ControlPlaneEndpoint.Host)
+
+			mds, err := sim.Client().ListMachineDeployments(ctx, tt.name)
+			require.NoError(t, err)
+			require.Len(t, mds.Items, 1)
+			assert.Equal(t, tt.name+"-md-0", mds.Items[0].Name)
+			assert.Equal(t, tt.workers, mds.Items[0].Status.ReadyReplicas)
+
+			secret, err := sim.Client().GetSecret(ctx, tt.name+"-kubeconfig")
+			require.NoError(t, err)
+			workload, err := sim.Client().WorkloadClient(secret.Data["value"])
+			require.NoError(t, err)
+			nodes, err := workload.ListNodes(ctx)
+			require.NoError(t, err)
+			assert.Len(t, nodes.Items, int(tt.workers)+1)
+		})
+	}
+}
+
+func TestClusterFromKubeconfig(t *testing.T) {
+	_, err := clusterFromKubeconfig([]byte("apiVersion: v1\nkind: Config\nclusters:\n- name: a\n  cluster:\n    server: https://api.example.com:6443\ncontexts:\n- name: a\n  context:\n    cluster: a\ncurrent-context: a\n"))
+	assert.Error(t, err)
+	_, err = clusterFromKubeconfig([]byte("{"))
+	assert.Error(t, err)
+}
+
+// TestSimulator_Lifecycle drives a cluster through its lifecycle with the
+// cluster service, as the MCP tools do.
+func TestSimulator_Lifecycle(t *testing.T) {
+	sim, err := New("team-a", time.Hour)
+	require.NoError(t, err)
+	logger := logging.NewLogger(slog.LevelError, "text")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go sim.Run(ctx, logger)
+	svc := service.NewEnhancedClusterService(sim.Client(), logger, nil)
+
+	status := func() api.ClusterStatus {
+		output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "prod-b"})
+		require.NoError(t, err)
+		return output.Cluster.Status
+	}
+	nodes := func() int {
+		output, err := svc.GetClusterNodes(ctx, api.GetClusterNodesInput{ClusterName: "prod-b"})
+		require.NoError(t, err)
+		return len(output.Nodes)
+	}
+
+	created, err := svc.CreateCluster(ctx, api.CreateClusterInput{
+		ClusterName:       "prod-b",
+		TemplateName:      TemplateName,
+		KubernetesVersion: "v1.30.2",
+		Variables:         map[string]interface{}{"region": "eu-west-1", "nodeCount": 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.ClusterStatusPending, created.Status)
+
+	require.NoError(t, sim.step(ctx))
+	assert.Equal(t, api.ClusterStatusProvisioning, status())
+	require.NoError(t, sim.step(ctx))
+	assert.Equal(t, api.ClusterStatusReady, status())
+	assert.Equal(t, 1, nodes(), "the workers are still provisioning")
+	require.NoError(t, sim.step(ctx))
+	assert.Equal(t, 3, nodes())
+
+	scaled, err := svc.ScaleCluster(ctx, api.ScaleClusterInput{ClusterName: "prod-b", NodePoolName: "prod-b-md-0", Replicas: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, scaled.OldReplicas)
+	require.NoError(t, sim.step(ctx))
+	assert.Equal(t, 2, nodes())
+	output, err := svc.GetCluster(ctx, api.GetClusterInput{ClusterName: "prod-b"})
+	require.NoError(t, err)
+	require.Len(t, output.Cluster.NodePools, 1)
+	assert.Equal(t, 1, output.Cluster.NodePools[0].ReadyReplicas)
+
+	deleted := make(chan *api.DeleteClusterOutput)
+	go func() {
+		output, err := svc.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "prod-b"})
+		assert.NoError(t, err)
+		deleted <- output
+	}()
+	require.Eventually(t, func() bool {
+		require.NoError(t, sim.step(ctx))
+		cluster, err := sim.Client().GetClusterByName(ctx, "prod-b")
+		return err != nil || cluster.Status.Phase == string(clusterv1.ClusterPhaseDeleting)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, sim.step(ctx))
+	select {
+	case output := <-deleted:
+		assert.Equal(t, "deleted", output.Status)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "deletion did not complete")
+	}
+
+	machines := &clusterv1.MachineList{}
+	require.NoError(t, sim.client.List(ctx, machines, client.MatchingLabels{clusterv1.ClusterNameLabel: "prod-b"}))
+	assert.Empty(t, machines.Items)
+}
@@ -0,0 +1,107 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+// workloadClient builds the client of the simulated workload cluster a
+// kubeconfig of the simulator grants access to. It serves a node for each
+// Machine of the cluster that joined it; everything else starts empty.
+func (s *Simulator) workloadClient(kubeconfig []byte, _ kube.NetworkOptions) (*kube.WorkloadClient, error) {
+	key, err := clusterFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	cluster := &clusterv1.Cluster{}
+	if err := s.client.Get(ctx, key, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get simulated cluster %s: %w", key, err)
+	}
+	machines := &clusterv1.MachineList{}
+	if err := s.client.List(ctx, machines, client.InNamespace(key.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: key.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list machines of simulated cluster %s: %w", key, err)
+	}
+
+	var nodes []runtime.Object
+	for i := range machines.Items {
+		if machines.Items[i].Status.NodeRef != nil {
+			nodes = append(nodes, node(cluster, &machines.Items[i]))
+		}
+	}
+	return kube.NewWorkloadClient(kubefake.NewSimpleClientset(nodes...)), nil
+}
+
+// clusterFromKubeconfig identifies the simulated cluster a kubeconfig is for
+// by the host of its API server endpoint.
+func clusterFromKubeconfig(kubeconfig []byte) (client.ObjectKey, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	var server string
+	if context, ok := config.Contexts[config.CurrentContext]; ok {
+		if cluster, ok := config.Clusters[context.Cluster]; ok {
+			server = cluster.Server
+		}
+	}
+	endpoint, err := url.Parse(server)
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("invalid API server endpoint %q: %w", server, err)
+	}
+
+	name, namespace, ok := strings.Cut(strings.TrimSuffix(endpoint.Hostname(), "."+endpointDomain), ".")
+	if !ok || strings.Contains(namespace, ".") {
+		return client.ObjectKey{}, fmt.Errorf("API server endpoint %q is not one of a simulated cluster", server)
+	}
+	return client.ObjectKey{Namespace: namespace, Name: name}, nil
+}
+
+// node is the node a Machine of a cluster joined as.
+func node(cluster *clusterv1.Cluster, machine *clusterv1.Machine) *corev1.Node {
+	region := stringVariable(cluster, "region", "us-west-2")
+	labels := map[string]string{
+		"kubernetes.io/hostname":        machine.Status.NodeRef.Name,
+		"kubernetes.io/os":              "linux",
+		"topology.kubernetes.io/region": region,
+		"topology.kubernetes.io/zone":   region + "a",
+	}
+	if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+		labels["node-role.kubernetes.io/control-plane"] = ""
+		labels["node.kubernetes.io/instance-type"] = stringVariable(cluster, "controlPlaneInstanceType", "t3.medium")
+	} else {
+		labels["node.kubernetes.io/instance-type"] = stringVariable(cluster, "workerInstanceType", "t3.small")
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              machine.Status.NodeRef.Name,
+			Labels:            labels,
+			CreationTimestamp: machine.CreationTimestamp,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: ptrValue(machine.Spec.Version)},
+		},
+	}
+	for _, address := range machine.Status.Addresses {
+		node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{
+			Type:    corev1.NodeAddressType(address.Type),
+			Address: address.Address,
+		})
+	}
+	return node
+}
@@ -0,0 +1,163 @@
+// Package snapshot persists point-in-time captures of a cluster's desired state
+// so later states can be compared against them.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+const (
+	// snapshotLabel marks ConfigMaps holding cluster snapshots.
+	snapshotLabel = "capi-mcp.io/cluster-snapshot"
+
+	// clusterLabel records the cluster a snapshot was taken of.
+	clusterLabel = "capi-mcp.io/cluster"
+
+	// clusterNamespaceLabel records the namespace of the cluster a snapshot was taken of.
+	clusterNamespaceLabel = "capi-mcp.io/cluster-namespace"
+
+	// snapshotKey is the ConfigMap data key holding the JSON-encoded snapshot.
+	snapshotKey = "snapshot"
+)
+
+// Snapshot is the desired state of a cluster's objects at a point in time.
+type Snapshot struct {
+	ID          string    `json:"id"`
+	ClusterName string    `json:"clusterName"`
+	Namespace   string    `json:"namespace"`
+	TakenAt     time.Time `json:"takenAt"`
+
+	// Objects maps "Kind/name" to the object's spec.
+	Objects map[string]map[string]interface{} `json:"objects"`
+}
+
+// Store persists cluster snapshots.
+type Store interface {
+	// Save persists a snapshot, assigning an ID if it has none.
+	Save(ctx context.Context, snap Snapshot) (Snapshot, error)
+
+	// List returns the snapshots of a cluster, newest first.
+	List(ctx context.Context, namespace, clusterName string) ([]Snapshot, error)
+}
+
+// ConfigMapStore stores each snapshot as a labelled ConfigMap in a single namespace.
+type ConfigMapStore struct {
+	kubeClient    *kube.Client
+	namespace     string
+	maxPerCluster int
+}
+
+// NewConfigMapStore creates a store in the given namespace that keeps at most
+// maxPerCluster snapshots of each cluster, pruning the oldest. Zero keeps all.
+func NewConfigMapStore(kubeClient *kube.Client, namespace string, maxPerCluster int) *ConfigMapStore {
+	return &ConfigMapStore{
+		kubeClient:    kubeClient,
+		namespace:     namespace,
+		maxPerCluster: maxPerCluster,
+	}
+}
+
+// Save persists a snapshot.
+func (s *ConfigMapStore) Save(ctx context.Context, snap Snapshot) (Snapshot, error) {
+	if snap.ID == "" {
+		snap.ID = uuid.New().String()
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return snap, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capi-mcp-snapshot-" + snap.ID,
+			Namespace: s.namespace,
+			Labels:    clusterLabels(snap.Namespace, snap.ClusterName),
+		},
+		Data: map[string]string{snapshotKey: string(data)},
+	}
+	if err := s.kubeClient.CreateObject(ctx, configMap); err != nil {
+		return snap, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	return snap, s.prune(ctx, snap.Namespace, snap.ClusterName)
+}
+
+// List returns the snapshots of a cluster, newest first.
+func (s *ConfigMapStore) List(ctx context.Context, namespace, clusterName string) ([]Snapshot, error) {
+	snapshots, _, err := s.list(ctx, namespace, clusterName)
+	return snapshots, err
+}
+
+// list returns decoded snapshots and their ConfigMaps, newest first.
+// ConfigMaps that cannot be decoded are skipped.
+func (s *ConfigMapStore) list(ctx context.Context, namespace, clusterName string) ([]Snapshot, []*corev1.ConfigMap, error) {
+	configMaps, err := s.kubeClient.ListConfigMaps(ctx, s.namespace, clusterLabels(namespace, clusterName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	entries := make([]entry, 0, len(configMaps.Items))
+	for i := range configMaps.Items {
+		var snap Snapshot
+		if err := json.Unmarshal([]byte(configMaps.Items[i].Data[snapshotKey]), &snap); err != nil {
+			continue
+		}
+		entries = append(entries, entry{snap, &configMaps.Items[i]})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].snapshot.TakenAt.After(entries[j].snapshot.TakenAt)
+	})
+
+	snapshots := make([]Snapshot, len(entries))
+	sources := make([]*corev1.ConfigMap, len(entries))
+	for i, e := range entries {
+		snapshots[i] = e.snapshot
+		sources[i] = e.source
+	}
+	return snapshots, sources, nil
+}
+
+// prune deletes the oldest snapshots of a cluster beyond maxPerCluster.
+func (s *ConfigMapStore) prune(ctx context.Context, namespace, clusterName string) error {
+	if s.maxPerCluster <= 0 {
+		return nil
+	}
+
+	_, sources, err := s.list(ctx, namespace, clusterName)
+	if err != nil {
+		return err
+	}
+	for i := s.maxPerCluster; i < len(sources); i++ {
+		if err := s.kubeClient.DeleteObject(ctx, sources[i]); err != nil {
+			return fmt.Errorf("failed to prune snapshots: %w", err)
+		}
+	}
+	return nil
+}
+
+// entry pairs a decoded snapshot with the ConfigMap it was read from.
+type entry struct {
+	snapshot Snapshot
+	source   *corev1.ConfigMap
+}
+
+// clusterLabels returns the labels identifying a cluster's snapshots.
+func clusterLabels(namespace, clusterName string) map[string]string {
+	return map[string]string{
+		snapshotLabel:         "true",
+		clusterLabel:          clusterName,
+		clusterNamespaceLabel: namespace,
+	}
+}
@@ -0,0 +1,56 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	store := NewConfigMapStore(kube.NewClientFromClient(fakeClient, "default"), "capi-system", 2)
+
+	for i := 0; i < 3; i++ {
+		_, err := store.Save(ctx, Snapshot{
+			ClusterName: "alpha",
+			Namespace:   "team-a",
+			TakenAt:     base.Add(time.Duration(i) * time.Hour),
+			Objects: map[string]map[string]interface{}{
+				"MachineDeployment/workers": {"replicas": float64(i)},
+			},
+		})
+		require.NoError(t, err)
+	}
+	saved, err := store.Save(ctx, Snapshot{ClusterName: "alpha", Namespace: "team-b", TakenAt: base})
+	require.NoError(t, err)
+	assert.NotEmpty(t, saved.ID)
+
+	t.Run("lists newest first and prunes per cluster", func(t *testing.T) {
+		snapshots, err := store.List(ctx, "team-a", "alpha")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.True(t, base.Add(2*time.Hour).Equal(snapshots[0].TakenAt))
+		assert.Equal(t, float64(2), snapshots[0].Objects["MachineDeployment/workers"]["replicas"])
+		assert.True(t, base.Add(time.Hour).Equal(snapshots[1].TakenAt))
+	})
+
+	t.Run("clusters are scoped by namespace", func(t *testing.T) {
+		snapshots, err := store.List(ctx, "team-b", "alpha")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, saved.ID, snapshots[0].ID)
+	})
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// BastionEnabledVariable is the cluster variable enabling a bastion host,
+	// propagated to the AWSCluster's spec.bastion.enabled by the ClusterClass.
+	BastionEnabledVariable = "bastionEnabled"
+
+	// BastionAllowedCIDRBlocksVariable lists the CIDR blocks allowed to reach
+	// the bastion over SSH.
+	BastionAllowedCIDRBlocksVariable = "bastionAllowedCIDRBlocks"
+
+	// BastionInstanceTypeVariable is the instance type of the bastion host.
+	BastionInstanceTypeVariable = "bastionInstanceType"
+)
+
+// validateBastion checks that the bastion variables are consistent: a bastion
+// is reached with the cluster's SSH key pair, and its settings have no effect
+// unless it is enabled.
+func (v *Validator) validateBastion(variables map[string]interface{}) error {
+	enabled, _ := variables[BastionEnabledVariable].(bool)
+	if !enabled {
+		for _, key := range []string{BastionAllowedCIDRBlocksVariable, BastionInstanceTypeVariable} {
+			if _, ok := variables[key]; ok {
+				return errors.New(errors.CodeInvalidInput,
+					fmt.Sprintf("%s has no effect unless %s is true", key, BastionEnabledVariable)).
+					WithDetails("field", key)
+			}
+		}
+		return nil
+	}
+
+	if keyName, _ := variables["sshKeyName"].(string); keyName == "" {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("%s requires sshKeyName, the EC2 key pair used to log in to the bastion", BastionEnabledVariable)).
+			WithDetails("field", BastionEnabledVariable)
+	}
+	return nil
+}
+
+// validateBastionEnabled validates the bastionEnabled cluster variable.
+func (v *Validator) validateBastionEnabled(value interface{}) error {
+	if _, ok := value.(bool); !ok {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("%s must be true or false", BastionEnabledVariable)).
+			WithDetails("field", BastionEnabledVariable).
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+	return nil
+}
+
+// validateBastionAllowedCIDRBlocks validates the bastionAllowedCIDRBlocks
+// cluster variable, a list of CIDR blocks.
+func (v *Validator) validateBastionAllowedCIDRBlocks(value interface{}) error {
+	list, ok := value.([]interface{})
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("%s must be a list of CIDR blocks, e.g. [\"203.0.113.0/24\"]", BastionAllowedCIDRBlocksVariable)).
+			WithDetails("field", BastionAllowedCIDRBlocksVariable).
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+	for _, item := range list {
+		if err := v.validateCIDR(BastionAllowedCIDRBlocksVariable, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// featureGatesRegex matches a feature-gates flag value, e.g.
+// "InPlacePodVerticalScaling=true,SidecarContainers=false".
+var featureGatesRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*=(true|false)(,[A-Z][A-Za-z0-9]*=(true|false))*$`)
+
+// controlPlaneFlags are the control plane component flags that may be set
+// through the controlPlaneConfig variable, by component. Flags affecting
+// authentication, authorization, certificates or networking are deliberately
+// absent, as are flags the ClusterClass templates set themselves.
+var controlPlaneFlags = map[string]map[string]bool{
+	"apiServer": {
+		"audit-log-path":                         true,
+		"audit-log-format":                       true,
+		"audit-log-maxage":                       true,
+		"audit-log-maxbackup":                    true,
+		"audit-log-maxsize":                      true,
+		"audit-policy-file":                      true,
+		"feature-gates":                          true,
+		"enable-admission-plugins":               true,
+		"disable-admission-plugins":              true,
+		"default-not-ready-toleration-seconds":   true,
+		"default-unreachable-toleration-seconds": true,
+		"max-requests-inflight":                  true,
+		"max-mutating-requests-inflight":         true,
+		"request-timeout":                        true,
+		"event-ttl":                              true,
+		"runtime-config":                         true,
+	},
+	"controllerManager": {
+		"feature-gates":                         true,
+		"node-monitor-grace-period":             true,
+		"terminated-pod-gc-threshold":           true,
+		"horizontal-pod-autoscaler-sync-period": true,
+		"concurrent-deployment-syncs":           true,
+		"concurrent-replicaset-syncs":           true,
+	},
+	"scheduler": {
+		"feature-gates": true,
+	},
+}
+
+// kubeletFlags are the kubelet flags that may be set through the
+// kubeletConfig variable.
+var kubeletFlags = map[string]bool{
+	"feature-gates":              true,
+	"max-pods":                   true,
+	"pod-max-pids":               true,
+	"kube-reserved":              true,
+	"system-reserved":            true,
+	"eviction-hard":              true,
+	"eviction-soft":              true,
+	"eviction-soft-grace-period": true,
+	"image-gc-high-threshold":    true,
+	"image-gc-low-threshold":     true,
+	"container-log-max-size":     true,
+	"container-log-max-files":    true,
+	"serialize-image-pulls":      true,
+	"cpu-manager-policy":         true,
+	"topology-manager-policy":    true,
+}
+
+// ValidateComponentFlags validates the flags requested for a Kubernetes
+// component: apiServer, controllerManager, scheduler or kubelet. Only
+// allowlisted flags are accepted.
+func (v *Validator) ValidateComponentFlags(component string, flags map[string]string) error {
+	allowed := kubeletFlags
+	if component != "kubelet" {
+		var ok bool
+		if allowed, ok = controlPlaneFlags[component]; !ok {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("unknown component '%s' - supported components are %s", component, strings.Join(sortedNames(controlPlaneFlags), ", "))).
+				WithDetails("field", "controlPlaneConfig")
+		}
+	}
+
+	for flag, value := range flags {
+		if !allowed[flag] {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s flag '%s' is not allowed - allowed flags are %s", component, flag, strings.Join(sortedNames(allowed), ", "))).
+				WithDetails("component", component).
+				WithDetails("flag", flag)
+		}
+		if value == "" {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s flag '%s' cannot be empty", component, flag)).
+				WithDetails("component", component).
+				WithDetails("flag", flag)
+		}
+		if flag == "feature-gates" && !featureGatesRegex.MatchString(value) {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s feature-gates '%s' must be a comma-separated list of Gate=true|false", component, value)).
+				WithDetails("component", component).
+				WithDetails("flag", flag)
+		}
+	}
+
+	return nil
+}
+
+// validateControlPlaneConfig validates the controlPlaneConfig cluster
+// variable, an object mapping control plane components to their flags, e.g.
+// {"apiServer": {"audit-log-path": "/var/log/kubernetes/audit.log"}}.
+func (v *Validator) validateControlPlaneConfig(value interface{}) error {
+	components, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"controlPlaneConfig must be an object mapping apiServer, controllerManager or scheduler to flags").
+			WithDetails("field", "controlPlaneConfig").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	for component, raw := range components {
+		flags, err := toFlags("controlPlaneConfig."+component, raw)
+		if err != nil {
+			return err
+		}
+		if err := v.ValidateComponentFlags(component, flags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateKubeletConfig validates the kubeletConfig cluster variable, an
+// object of kubelet flags, e.g. {"max-pods": "110"}.
+func (v *Validator) validateKubeletConfig(value interface{}) error {
+	flags, err := toFlags("kubeletConfig", value)
+	if err != nil {
+		return err
+	}
+	return v.ValidateComponentFlags("kubelet", flags)
+}
+
+// toFlags converts a variable value to flags. Flag values must be strings, as
+// kubeadm passes them to the components verbatim.
+func toFlags(field string, value interface{}) (map[string]string, error) {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("%s must be an object of flag names to string values", field)).
+			WithDetails("field", field).
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	flags := make(map[string]string, len(object))
+	for flag, raw := range object {
+		str, ok := raw.(string)
+		if !ok {
+			return nil, errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("%s flag '%s' must be a string, e.g. \"110\" or \"true\"", field, flag)).
+				WithDetails("field", field).
+				WithDetails("flag", flag)
+		}
+		flags[flag] = str
+	}
+	return flags, nil
+}
+
+func sortedNames[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package validation
+
+import (
+	stderrors "errors"
+	"regexp"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// SuggestedFixDetail is the error detail with corrected arguments for a
+// rejected call, in the structure of the tool input, e.g.
+// {"clusterName": "my-cluster", "variables": {"region": "us-west-2"}}. Callers
+// can merge it into their arguments and retry without asking the user.
+const SuggestedFixDetail = "suggestedFix"
+
+// maxRegionDistance is the most edits a region may be away from the region
+// suggested for it.
+const maxRegionDistance = 3
+
+// knownAWSRegions are the regions suggested for a misspelled region when the
+// AWS catalog cannot list its regions.
+var knownAWSRegions = []string{
+	"us-east-1", "us-east-2", "us-west-1", "us-west-2", "ca-central-1",
+	"eu-west-1", "eu-west-2", "eu-west-3", "eu-central-1", "eu-north-1",
+	"ap-northeast-1", "ap-northeast-2", "ap-southeast-1", "ap-southeast-2", "ap-south-1",
+	"sa-east-1",
+}
+
+// looseKubernetesVersionRegex matches Kubernetes versions missing the v
+// prefix or the patch release, e.g. 1.31 or V1.31.2.
+var looseKubernetesVersionRegex = regexp.MustCompile(`^[vV]?(\d+)\.(\d+)(\.\d+)?$`)
+
+// regionLister is implemented by AWS catalogs that can list their regions.
+type regionLister interface {
+	Regions() []string
+}
+
+// SuggestedFix returns the suggested fix of a validation error, or nil if it
+// has none.
+func SuggestedFix(err error) map[string]interface{} {
+	var e *errors.Error
+	if !stderrors.As(err, &e) {
+		return nil
+	}
+	fix, _ := e.Details[SuggestedFixDetail].(map[string]interface{})
+	return fix
+}
+
+// withFix suggests a value for an argument of a rejected call.
+func withFix(err *errors.Error, argument string, value interface{}) *errors.Error {
+	return err.WithDetails(SuggestedFixDetail, map[string]interface{}{argument: value})
+}
+
+// nestFix moves the suggested fix of an error under an argument holding
+// nested arguments, such as variables.
+func nestFix(err error, argument string) error {
+	var e *errors.Error
+	if fix := SuggestedFix(err); fix != nil && stderrors.As(err, &e) {
+		e.Details[SuggestedFixDetail] = map[string]interface{}{argument: fix}
+	}
+	return err
+}
+
+// mergeFixes merges the suggested fix src into dst, merging nested arguments.
+func mergeFixes(dst, src map[string]interface{}) {
+	for argument, value := range src {
+		nested, ok := value.(map[string]interface{})
+		existing, exists := dst[argument].(map[string]interface{})
+		if ok && exists {
+			mergeFixes(existing, nested)
+			continue
+		}
+		if ok {
+			copied := make(map[string]interface{}, len(nested))
+			mergeFixes(copied, nested)
+			value = copied
+		}
+		dst[argument] = value
+	}
+}
+
+// clampReplicas returns the replica count closest to replicas that is valid.
+func clampReplicas(replicas int32) int32 {
+	return min(max(replicas, 0), 100)
+}
+
+// suggestKubernetesVersion returns version in the format 'vX.Y.Z', e.g.
+// v1.31.0 for 1.31, or "" if it is not recognizably a version.
+func suggestKubernetesVersion(version string) string {
+	match := looseKubernetesVersionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return ""
+	}
+	patch := match[3]
+	if patch == "" {
+		patch = ".0"
+	}
+	return "v" + match[1] + "." + match[2] + patch
+}
+
+// suggestAWSRegion returns the available region closest to a misspelled
+// region, e.g. us-west-2 for us-wset-2 or US_WEST_2, or "" if none is close.
+func (v *Validator) suggestAWSRegion(region string) string {
+	normalized := strings.NewReplacer("_", "-", " ", "-").Replace(strings.ToLower(strings.TrimSpace(region)))
+
+	candidates := knownAWSRegions
+	if lister, ok := v.awsCatalog.(regionLister); ok {
+		candidates = lister.Regions()
+	}
+
+	var closest string
+	best := maxRegionDistance + 1
+	for _, candidate := range candidates {
+		if candidate == region || (v.awsCatalog != nil && !v.awsCatalog.IsRegion(candidate)) {
+			continue
+		}
+		if distance := editDistance(normalized, candidate); distance < best {
+			closest, best = candidate, distance
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
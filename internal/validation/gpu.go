@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// maxGPUCount is the largest number of GPUs a single EC2 instance provides.
+const maxGPUCount = 16
+
+// gpuInstanceFamilies maps the EC2 GPU instance families to the number of GPUs
+// in their largest size.
+var gpuInstanceFamilies = map[string]int32{
+	"p2":      16,
+	"p3":      8,
+	"p3dn":    8,
+	"p4d":     8,
+	"p4de":    8,
+	"p5":      8,
+	"p5e":     8,
+	"p5en":    8,
+	"p6-b200": 8,
+	"g3":      4,
+	"g3s":     1,
+	"g4dn":    8,
+	"g4ad":    4,
+	"g5":      8,
+	"g5g":     2,
+	"g6":      8,
+	"g6e":     8,
+	"gr6":     1,
+}
+
+// IsGPUInstanceType reports whether instanceType belongs to an EC2 GPU
+// instance family, e.g. g5.xlarge or p4d.24xlarge.
+func IsGPUInstanceType(instanceType string) bool {
+	_, ok := gpuInstanceFamilies[instanceFamily(instanceType)]
+	return ok
+}
+
+func instanceFamily(instanceType string) string {
+	family, _, _ := strings.Cut(instanceType, ".")
+	return family
+}
+
+// validateGPUCount validates the gpuCount cluster variable, the number of GPUs
+// per worker node. A non-zero count requires a GPU instance type, if one is set.
+func (v *Validator) validateGPUCount(value interface{}, instanceType string) error {
+	count, ok := toInt32(value)
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"gpuCount must be an integer (e.g., 1, 4, 8)").
+			WithDetails("field", "gpuCount").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	if count < 0 || count > maxGPUCount {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("gpuCount %d is out of range - EC2 instances provide between 0 and %d GPUs", count, maxGPUCount)).
+			WithDetails("field", "gpuCount").
+			WithDetails("provided_value", count)
+	}
+
+	if count == 0 || instanceType == "" {
+		return nil
+	}
+
+	maxCount, ok := gpuInstanceFamilies[instanceFamily(instanceType)]
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("gpuCount requires a GPU instance type, but '%s' has no GPUs - use a GPU family such as g5, g6 or p4d", instanceType)).
+			WithDetails("field", "gpuCount").
+			WithDetails("instance_type", instanceType)
+	}
+	if count > maxCount {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("gpuCount %d exceeds the %d GPUs available in the %s instance family", count, maxCount, instanceFamily(instanceType))).
+			WithDetails("field", "gpuCount").
+			WithDetails("instance_type", instanceType)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// AWSControllerIdentityName is the only name an AWSClusterControllerIdentity,
+// the identity of the CAPA controller itself, may have.
+const AWSControllerIdentityName = "default"
+
+// AWSIdentityKinds are the CAPA identity kinds a cluster may reference.
+var AWSIdentityKinds = []string{
+	"AWSClusterControllerIdentity",
+	"AWSClusterRoleIdentity",
+	"AWSClusterStaticIdentity",
+}
+
+// validateIdentityRef validates the identityRef cluster variable, an object
+// naming the CAPA identity a cluster's AWS resources are managed with, e.g.
+// {"kind": "AWSClusterRoleIdentity", "name": "team-a-account"}.
+func (v *Validator) validateIdentityRef(value interface{}) error {
+	ref, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"identityRef must be an object, e.g. {\"kind\": \"AWSClusterRoleIdentity\", \"name\": \"team-a-account\"}").
+			WithDetails("field", "identityRef").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	for key := range ref {
+		if key != "kind" && key != "name" {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("identityRef has unknown field '%s' - only kind and name are supported", key)).
+				WithDetails("field", "identityRef")
+		}
+	}
+
+	kind, _ := ref["kind"].(string)
+	if !slices.Contains(AWSIdentityKinds, kind) {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("identityRef kind '%s' is not supported - use one of %s", kind, strings.Join(AWSIdentityKinds, ", "))).
+			WithDetails("field", "identityRef")
+	}
+
+	name, _ := ref["name"].(string)
+	if name == "" || len(name) > 253 || !dnsSubdomainRegex.MatchString(name) {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("identityRef name '%s' must be a valid DNS subdomain", name)).
+			WithDetails("field", "identityRef")
+	}
+	if kind == "AWSClusterControllerIdentity" && name != AWSControllerIdentityName {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("an AWSClusterControllerIdentity must be named '%s'", AWSControllerIdentityName)).
+			WithDetails("field", "identityRef")
+	}
+
+	return nil
+}
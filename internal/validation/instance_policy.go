@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// InstanceTypePolicy restricts instance types to those matching one of
+// Allowed, if any is set, and none of Denied. Patterns use path.Match syntax,
+// so "m6i.*" matches a family and "*.metal" a size.
+type InstanceTypePolicy struct {
+	Allowed []string
+	Denied  []string
+}
+
+// ValidateInstanceType checks the instance type set in a field against the
+// policy.
+func (p InstanceTypePolicy) ValidateInstanceType(field, instanceType string) error {
+	if pattern, ok := matchPattern(p.Denied, instanceType); ok {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("%s %s is not permitted by the instance type policy (denied: %s)", field, instanceType, pattern)).
+			WithDetails("field", field)
+	}
+	if _, ok := matchPattern(p.Allowed, instanceType); len(p.Allowed) > 0 && !ok {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("%s %s is not permitted by the instance type policy; allowed instance types: %s",
+				field, instanceType, strings.Join(p.Allowed, ", "))).
+			WithDetails("field", field)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// ProtectedClusterNames are names of clusters that must not be created or
+// deleted through the server, such as the management cluster or production
+// clusters. Patterns use path.Match syntax, so "prod-*" protects every
+// cluster named with the prefix.
+type ProtectedClusterNames []string
+
+// ValidateClusterName checks that an operation does not create or delete a
+// cluster with a protected name.
+func (p ProtectedClusterNames) ValidateClusterName(operation, name string) error {
+	if pattern, ok := matchPattern(p, name); ok {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("cluster name '%s' is protected (%s); %s is not permitted", name, pattern, operation)).
+			WithDetails("field", "clusterName")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// RegionRule restricts regions to those matching one of Allowed, if any is
+// set, and none of Denied. Patterns use path.Match syntax, e.g. "eu-*".
+type RegionRule struct {
+	Allowed []string
+	Denied  []string
+}
+
+// RegionPolicy restricts the regions clusters may be created in, e.g. to meet
+// data residency requirements. A region must satisfy the default rule as well
+// as the rules for the cluster's namespace, the calling identity and each of
+// the identity's groups.
+type RegionPolicy struct {
+	Default    RegionRule
+	Namespaces map[string]RegionRule
+	Identities map[string]RegionRule
+	Groups     map[string]RegionRule
+}
+
+// ValidateRegion checks the region of a cluster in a namespace created by an
+// identity, which may be empty, against the policy. A region must be given
+// when any applicable rule has an allowlist.
+func (p *RegionPolicy) ValidateRegion(region, namespace, identity string, groups []string) error {
+	type scopedRule struct {
+		scope string
+		rule  RegionRule
+	}
+	rules := []scopedRule{{"the organization", p.Default}}
+	if rule, ok := p.Namespaces[namespace]; ok {
+		rules = append(rules, scopedRule{fmt.Sprintf("namespace '%s'", namespace), rule})
+	}
+	if rule, ok := p.Identities[identity]; ok && identity != "" {
+		rules = append(rules, scopedRule{fmt.Sprintf("identity '%s'", identity), rule})
+	}
+	for _, group := range groups {
+		if rule, ok := p.Groups[group]; ok {
+			rules = append(rules, scopedRule{fmt.Sprintf("group '%s'", group), rule})
+		}
+	}
+
+	for _, scoped := range rules {
+		if err := scoped.rule.validate(region, scoped.scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks a region against the rule for a scope, naming the scope in
+// the error.
+func (r RegionRule) validate(region, scope string) error {
+	if region == "" {
+		if len(r.Allowed) == 0 {
+			return nil
+		}
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("region is required: the region policy for %s only allows clusters in %s",
+				scope, strings.Join(r.Allowed, ", "))).
+			WithDetails("field", "region")
+	}
+
+	if pattern, ok := matchPattern(r.Denied, region); ok {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("region %s is not permitted by the region policy for %s (denied: %s)", region, scope, pattern)).
+			WithDetails("field", "region")
+	}
+	if _, ok := matchPattern(r.Allowed, region); len(r.Allowed) > 0 && !ok {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("region %s is not permitted by the region policy for %s; allowed regions: %s",
+				region, scope, strings.Join(r.Allowed, ", "))).
+			WithDetails("field", "region")
+	}
+	return nil
+}
+
+// matchRegion returns the first pattern matching a region.
+func matchPattern(patterns []string, region string) (string, bool) {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, region); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
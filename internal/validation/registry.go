@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+var (
+	// registryHostRegex matches a registry host name with an optional port,
+	// e.g. registry.example.com:5000.
+	registryHostRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*(:[0-9]{1,5})?$`)
+
+	// repositoryPathRegex matches the path components of an image repository.
+	repositoryPathRegex = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+)
+
+// ValidateImageRepository validates the repository cluster images are pulled
+// from in air-gapped environments, a registry host with an optional path and
+// no scheme, e.g. registry.example.com/k8s.
+func (v *Validator) ValidateImageRepository(repository string) error {
+	host, path, hasPath := strings.Cut(repository, "/")
+	if !registryHostRegex.MatchString(host) || (hasPath && !repositoryPathRegex.MatchString(path)) {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("imageRepository '%s' must be a registry host with an optional path and no scheme, e.g. 'registry.example.com/k8s'", repository)).
+			WithDetails("field", "imageRepository")
+	}
+	return nil
+}
+
+// ValidateRegistryMirrors validates the mirrors the container runtime pulls
+// images through, keyed by the upstream registry host, e.g.
+// {"docker.io": ["https://mirror.example.com"]}.
+func (v *Validator) ValidateRegistryMirrors(mirrors map[string][]string) error {
+	for registry, endpoints := range mirrors {
+		if !registryHostRegex.MatchString(registry) {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("registryMirrors key '%s' must be a registry host, e.g. 'docker.io'", registry)).
+				WithDetails("field", "registryMirrors")
+		}
+		if len(endpoints) == 0 {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("registryMirrors for '%s' must list at least one mirror", registry)).
+				WithDetails("field", "registryMirrors")
+		}
+		for _, endpoint := range endpoints {
+			mirror, err := url.Parse(endpoint)
+			if err != nil || (mirror.Scheme != "http" && mirror.Scheme != "https") || !registryHostRegex.MatchString(mirror.Host) {
+				return errors.New(errors.CodeInvalidInput,
+					fmt.Sprintf("registry mirror '%s' for '%s' must be an http or https URL, e.g. 'https://mirror.example.com'", endpoint, registry)).
+					WithDetails("field", "registryMirrors")
+			}
+		}
+	}
+	return nil
+}
+
+// validateImageRepository validates the imageRepository cluster variable.
+func (v *Validator) validateImageRepository(value interface{}) error {
+	repository, ok := value.(string)
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"imageRepository must be a string, e.g. 'registry.example.com/k8s'").
+			WithDetails("field", "imageRepository").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+	return v.ValidateImageRepository(repository)
+}
+
+// validateRegistryMirrors validates the registryMirrors cluster variable, an
+// object of registry hosts to lists of mirror URLs.
+func (v *Validator) validateRegistryMirrors(value interface{}) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"registryMirrors must be an object of registry hosts to mirror URLs, e.g. {\"docker.io\": [\"https://mirror.example.com\"]}").
+			WithDetails("field", "registryMirrors").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	mirrors := make(map[string][]string, len(object))
+	for registry, raw := range object {
+		list, ok := raw.([]interface{})
+		if !ok {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("registryMirrors for '%s' must be a list of mirror URLs", registry)).
+				WithDetails("field", "registryMirrors")
+		}
+		mirrors[registry] = make([]string, 0, len(list))
+		for _, item := range list {
+			endpoint, ok := item.(string)
+			if !ok {
+				return errors.New(errors.CodeInvalidInput,
+					fmt.Sprintf("registryMirrors for '%s' must be a list of mirror URLs", registry)).
+					WithDetails("field", "registryMirrors")
+			}
+			mirrors[registry] = append(mirrors[registry], endpoint)
+		}
+	}
+	return v.ValidateRegistryMirrors(mirrors)
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// spotAllocationStrategies are the EC2 Auto Scaling spot allocation strategies
+// accepted for spot node pools.
+var spotAllocationStrategies = map[string]bool{
+	"capacity-optimized":             true,
+	"capacity-optimized-prioritized": true,
+	"price-capacity-optimized":       true,
+	"lowest-price":                   true,
+}
+
+// ValidateSpotMarketOptions validates the spot options of a node pool. An
+// empty maxPrice caps the price at the on-demand price; an empty allocation
+// strategy uses the provider's default.
+func (v *Validator) ValidateSpotMarketOptions(maxPrice, allocationStrategy string) error {
+	if maxPrice != "" {
+		price, err := strconv.ParseFloat(maxPrice, 64)
+		if err != nil || price <= 0 {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("spot maxPrice '%s' must be a positive hourly price in USD, e.g. '0.05'", maxPrice)).
+				WithDetails("field", "maxPrice")
+		}
+	}
+
+	if allocationStrategy != "" && !spotAllocationStrategies[allocationStrategy] {
+		strategies := make([]string, 0, len(spotAllocationStrategies))
+		for strategy := range spotAllocationStrategies {
+			strategies = append(strategies, strategy)
+		}
+		sort.Strings(strategies)
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("spot allocationStrategy '%s' is not supported - use one of %v", allocationStrategy, strategies)).
+			WithDetails("field", "allocationStrategy")
+	}
+
+	return nil
+}
+
+// validateSpotMarketOptions validates the spotMarketOptions cluster variable,
+// an object with optional maxPrice and allocationStrategy strings.
+func (v *Validator) validateSpotMarketOptions(value interface{}) error {
+	options, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"spotMarketOptions must be an object, e.g. {\"maxPrice\": \"0.05\", \"allocationStrategy\": \"capacity-optimized\"}").
+			WithDetails("field", "spotMarketOptions").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	fields := make(map[string]string, len(options))
+	for key, raw := range options {
+		if key != "maxPrice" && key != "allocationStrategy" {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("spotMarketOptions has unknown field '%s' - supported fields are maxPrice and allocationStrategy", key)).
+				WithDetails("field", "spotMarketOptions")
+		}
+		str, ok := raw.(string)
+		if !ok {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("spotMarketOptions.%s must be a string", key)).
+				WithDetails("field", "spotMarketOptions")
+		}
+		fields[key] = str
+	}
+
+	return v.ValidateSpotMarketOptions(fields["maxPrice"], fields["allocationStrategy"])
+}
@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// maxCloudTags leaves room within AWS's limit of 50 tags per resource for
+	// the tags CAPA adds itself.
+	maxCloudTags = 40
+
+	maxCloudTagKeyLength   = 128
+	maxCloudTagValueLength = 256
+)
+
+// cloudTagRegex matches the characters AWS allows in tag keys and values.
+var cloudTagRegex = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]*$`)
+
+// reservedCloudTagPrefixes are tag key prefixes reserved by AWS or managed by
+// CAPA and the Kubernetes cloud provider.
+var reservedCloudTagPrefixes = []string{
+	"aws:",
+	"sigs.k8s.io/cluster-api-provider-aws/",
+	"kubernetes.io/cluster/",
+}
+
+// ValidateCloudTags validates the tags applied to a cluster's cloud resources
+// against the AWS tagging rules.
+func (v *Validator) ValidateCloudTags(tags map[string]string) error {
+	if len(tags) > maxCloudTags {
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("cloudTags has %d tags - at most %d are allowed", len(tags), maxCloudTags)).
+			WithDetails("field", "cloudTags")
+	}
+
+	for key, value := range tags {
+		if key == "" || utf8.RuneCountInString(key) > maxCloudTagKeyLength {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("cloud tag key '%s' must be between 1 and %d characters", key, maxCloudTagKeyLength)).
+				WithDetails("field", "cloudTags")
+		}
+		if utf8.RuneCountInString(value) > maxCloudTagValueLength {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("value of cloud tag '%s' exceeds %d characters", key, maxCloudTagValueLength)).
+				WithDetails("field", "cloudTags")
+		}
+		if !cloudTagRegex.MatchString(key) || !cloudTagRegex.MatchString(value) {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("cloud tag '%s' contains invalid characters - use letters, numbers, spaces and _ . : / = + - @", key)).
+				WithDetails("field", "cloudTags")
+		}
+		for _, prefix := range reservedCloudTagPrefixes {
+			if strings.HasPrefix(strings.ToLower(key), prefix) {
+				return errors.New(errors.CodeInvalidInput,
+					fmt.Sprintf("cloud tag key '%s' uses the reserved prefix '%s'", key, prefix)).
+					WithDetails("field", "cloudTags")
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCloudTags validates the cloudTags cluster variable, an object of tag
+// keys to string values, e.g. {"cost-center": "1234", "team": "platform"}.
+func (v *Validator) validateCloudTags(value interface{}) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New(errors.CodeInvalidInput,
+			"cloudTags must be an object of tag keys to values, e.g. {\"cost-center\": \"1234\"}").
+			WithDetails("field", "cloudTags").
+			WithDetails("provided_type", fmt.Sprintf("%T", value))
+	}
+
+	tags := make(map[string]string, len(object))
+	for key, raw := range object {
+		str, ok := raw.(string)
+		if !ok {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("value of cloud tag '%s' must be a string", key)).
+				WithDetails("field", "cloudTags")
+		}
+		tags[key] = str
+	}
+	return v.ValidateCloudTags(tags)
+}
@@ -18,29 +18,56 @@ var (
 
 	// Resource name regex
 	resourceNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+	// AWS region format: area + dash + direction + dash + number
+	awsRegionRegex = regexp.MustCompile(`^[a-z]{2,3}-[a-z]+-\d+$`)
+
+	// AWS instance type format: family (e.g. m5, c7gn, m7i-flex, u-6tb1) + dot + size
+	awsInstanceTypeRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*\.[a-z0-9-]+$`)
 )
 
+// AWSCatalog reports the AWS regions and instance types currently offered.
+type AWSCatalog interface {
+	// IsRegion reports whether region is an available AWS region.
+	IsRegion(region string) bool
+
+	// IsInstanceType reports whether instanceType is offered in region, or in
+	// any region when region is empty.
+	IsInstanceType(region, instanceType string) bool
+}
+
 // Validator provides input validation functions
-type Validator struct{}
+type Validator struct {
+	awsCatalog AWSCatalog
+}
 
 // NewValidator creates a new validator instance
 func NewValidator() *Validator {
 	return &Validator{}
 }
 
+// SetAWSCatalog configures the catalog AWS regions and instance types are
+// checked against. Without one, only their format is validated.
+func (v *Validator) SetAWSCatalog(catalog AWSCatalog) {
+	v.awsCatalog = catalog
+}
+
 // ValidateClusterName validates a cluster name
 func (v *Validator) ValidateClusterName(name string) error {
 	if name == "" {
-		return errors.New(errors.CodeInvalidInput, "cluster name cannot be empty")
+		return errors.New(errors.CodeInvalidInput, "cluster name cannot be empty").
+			WithMessageKey("validation.cluster_name.empty")
 	}
 
 	if len(name) > 63 {
-		return errors.New(errors.CodeInvalidInput, "cluster name must be 63 characters or less")
+		return withFix(errors.New(errors.CodeInvalidInput, "cluster name must be 63 characters or less").
+			WithMessageKey("validation.cluster_name.too_long"), "clusterName", SanitizeClusterName(name))
 	}
 
 	if !resourceNameRegex.MatchString(name) {
-		return errors.New(errors.CodeInvalidInput,
-			"cluster name must consist of lowercase alphanumeric characters or '-', and must start and end with an alphanumeric character")
+		return withFix(errors.New(errors.CodeInvalidInput,
+			"cluster name must consist of lowercase alphanumeric characters or '-', and must start and end with an alphanumeric character").
+			WithMessageKey("validation.cluster_name.invalid"), "clusterName", SanitizeClusterName(name))
 	}
 
 	return nil
@@ -49,16 +76,19 @@ func (v *Validator) ValidateClusterName(name string) error {
 // ValidateNamespace validates a namespace name
 func (v *Validator) ValidateNamespace(namespace string) error {
 	if namespace == "" {
-		return errors.New(errors.CodeInvalidInput, "namespace cannot be empty")
+		return errors.New(errors.CodeInvalidInput, "namespace cannot be empty").
+			WithMessageKey("validation.namespace.empty")
 	}
 
 	if len(namespace) > 63 {
-		return errors.New(errors.CodeInvalidInput, "namespace must be 63 characters or less")
+		return errors.New(errors.CodeInvalidInput, "namespace must be 63 characters or less").
+			WithMessageKey("validation.namespace.too_long")
 	}
 
 	if !resourceNameRegex.MatchString(namespace) {
 		return errors.New(errors.CodeInvalidInput,
-			"namespace must consist of lowercase alphanumeric characters or '-', and must start and end with an alphanumeric character")
+			"namespace must consist of lowercase alphanumeric characters or '-', and must start and end with an alphanumeric character").
+			WithMessageKey("validation.namespace.invalid")
 	}
 
 	return nil
@@ -67,12 +97,18 @@ func (v *Validator) ValidateNamespace(namespace string) error {
 // ValidateKubernetesVersion validates a Kubernetes version string
 func (v *Validator) ValidateKubernetesVersion(version string) error {
 	if version == "" {
-		return errors.New(errors.CodeInvalidInput, "kubernetes version cannot be empty")
+		return errors.New(errors.CodeInvalidInput, "kubernetes version cannot be empty").
+			WithMessageKey("validation.kubernetes_version.empty")
 	}
 
 	if !kubernetesVersionRegex.MatchString(version) {
-		return errors.New(errors.CodeInvalidInput,
-			"kubernetes version must be in format 'vX.Y.Z' (e.g., v1.28.0)")
+		err := errors.New(errors.CodeInvalidInput,
+			"kubernetes version must be in format 'vX.Y.Z' (e.g., v1.28.0)").
+			WithMessageKey("validation.kubernetes_version.invalid")
+		if suggested := suggestKubernetesVersion(version); suggested != "" {
+			withFix(err, "kubernetesVersion", suggested)
+		}
+		return err
 	}
 
 	// Extract major and minor version
@@ -105,11 +141,13 @@ func (v *Validator) ValidateMachineDeploymentName(name string) error {
 // ValidateReplicaCount validates the number of replicas
 func (v *Validator) ValidateReplicaCount(replicas int32) error {
 	if replicas < 0 {
-		return errors.New(errors.CodeInvalidInput, "replica count cannot be negative")
+		return withFix(errors.New(errors.CodeInvalidInput, "replica count cannot be negative").
+			WithMessageKey("validation.replicas.negative"), "replicas", clampReplicas(replicas))
 	}
 
 	if replicas > 100 {
-		return errors.New(errors.CodeInvalidInput, "replica count cannot exceed 100")
+		return withFix(errors.New(errors.CodeInvalidInput, "replica count cannot exceed 100").
+			WithMessageKey("validation.replicas.too_many"), "replicas", clampReplicas(replicas))
 	}
 
 	return nil
@@ -143,6 +181,15 @@ func (v *Validator) ValidateClusterVariables(variables map[string]interface{}) e
 	// Track validation errors for comprehensive feedback
 	var validationErrors []error
 
+	// Instance types are checked against the offerings of the requested region
+	region, _ := variables["region"].(string)
+
+	// GPU counts are checked against the worker instance type
+	workerInstanceType, _ := variables["workerInstanceType"].(string)
+	if workerInstanceType == "" {
+		workerInstanceType, _ = variables["instanceType"].(string)
+	}
+
 	// Check for required common variables
 	for key, value := range variables {
 		switch key {
@@ -156,8 +203,8 @@ func (v *Validator) ValidateClusterVariables(variables map[string]interface{}) e
 				validationErrors = append(validationErrors, err)
 			}
 
-		case "instanceType", "controlPlaneInstanceType", "workerInstanceType":
-			if err := v.validateInstanceType(key, value); err != nil {
+		case "instanceType", "controlPlaneInstanceType", "workerInstanceType", BastionInstanceTypeVariable:
+			if err := v.validateInstanceType(key, value, region); err != nil {
 				validationErrors = append(validationErrors, err)
 			}
 
@@ -171,6 +218,56 @@ func (v *Validator) ValidateClusterVariables(variables map[string]interface{}) e
 				validationErrors = append(validationErrors, err)
 			}
 
+		case BastionEnabledVariable:
+			if err := v.validateBastionEnabled(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case BastionAllowedCIDRBlocksVariable:
+			if err := v.validateBastionAllowedCIDRBlocks(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "spotMarketOptions":
+			if err := v.validateSpotMarketOptions(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "gpuCount":
+			if err := v.validateGPUCount(value, workerInstanceType); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "controlPlaneConfig":
+			if err := v.validateControlPlaneConfig(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "kubeletConfig":
+			if err := v.validateKubeletConfig(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "cloudTags":
+			if err := v.validateCloudTags(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "identityRef":
+			if err := v.validateIdentityRef(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "imageRepository":
+			if err := v.validateImageRepository(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
+		case "registryMirrors":
+			if err := v.validateRegistryMirrors(value); err != nil {
+				validationErrors = append(validationErrors, err)
+			}
+
 		// Additional variables that should be validated
 		case "kubernetesVersion":
 			if version, ok := value.(string); ok {
@@ -181,6 +278,10 @@ func (v *Validator) ValidateClusterVariables(variables map[string]interface{}) e
 		}
 	}
 
+	if err := v.validateBastion(variables); err != nil {
+		validationErrors = append(validationErrors, err)
+	}
+
 	// Return combined validation errors if any
 	if len(validationErrors) > 0 {
 		return v.combineValidationErrors(validationErrors)
@@ -195,6 +296,7 @@ func (v *Validator) validateNodeCount(value interface{}) error {
 	if !ok {
 		return errors.New(errors.CodeInvalidInput,
 			"nodeCount must be a positive integer (e.g., 2, 5, 10)").
+			WithMessageKey("validation.node_count.type").
 			WithDetails("field", "nodeCount").
 			WithDetails("provided_type", fmt.Sprintf("%T", value))
 	}
@@ -204,15 +306,19 @@ func (v *Validator) validateNodeCount(value interface{}) error {
 		if count < 0 {
 			return errors.New(errors.CodeInvalidInput,
 				"nodeCount cannot be negative - clusters need at least 0 worker nodes").
+				WithMessageKey("validation.node_count.negative").
 				WithDetails("field", "nodeCount").
-				WithDetails("provided_value", count)
+				WithDetails("provided_value", count).
+				WithDetails(SuggestedFixDetail, map[string]interface{}{"nodeCount": clampReplicas(count)})
 		}
 		if count > 100 {
 			return errors.New(errors.CodeInvalidInput,
 				"nodeCount cannot exceed 100 - this limit prevents excessive resource usage").
+				WithMessageKey("validation.node_count.too_many").
 				WithDetails("field", "nodeCount").
 				WithDetails("provided_value", count).
-				WithDetails("max_allowed", 100)
+				WithDetails("max_allowed", 100).
+				WithDetails(SuggestedFixDetail, map[string]interface{}{"nodeCount": clampReplicas(count)})
 		}
 	}
 
@@ -225,6 +331,7 @@ func (v *Validator) validateRegion(value interface{}) error {
 	if !ok {
 		return errors.New(errors.CodeInvalidInput,
 			"region must be a string (e.g., 'us-west-2', 'eu-central-1')").
+			WithMessageKey("validation.region.type").
 			WithDetails("field", "region").
 			WithDetails("provided_type", fmt.Sprintf("%T", value))
 	}
@@ -232,6 +339,7 @@ func (v *Validator) validateRegion(value interface{}) error {
 	if region == "" {
 		return errors.New(errors.CodeInvalidInput,
 			"region cannot be empty - specify an AWS region like 'us-west-2' or 'eu-central-1'").
+			WithMessageKey("validation.region.empty").
 			WithDetails("field", "region")
 	}
 
@@ -244,11 +352,12 @@ func (v *Validator) validateRegion(value interface{}) error {
 }
 
 // validateInstanceType validates instance types with helpful examples
-func (v *Validator) validateInstanceType(fieldName string, value interface{}) error {
+func (v *Validator) validateInstanceType(fieldName string, value interface{}, region string) error {
 	instanceType, ok := value.(string)
 	if !ok {
 		return errors.New(errors.CodeInvalidInput,
 			fmt.Sprintf("%s must be a string (e.g., 't3.medium', 'm5.large')", fieldName)).
+			WithMessageKey("validation.instance_type.type", fieldName).
 			WithDetails("field", fieldName).
 			WithDetails("provided_type", fmt.Sprintf("%T", value))
 	}
@@ -256,13 +365,15 @@ func (v *Validator) validateInstanceType(fieldName string, value interface{}) er
 	if instanceType == "" {
 		return errors.New(errors.CodeInvalidInput,
 			fmt.Sprintf("%s cannot be empty - specify an EC2 instance type like 't3.medium' or 'm5.large'", fieldName)).
+			WithMessageKey("validation.instance_type.empty", fieldName).
 			WithDetails("field", fieldName)
 	}
 
-	// Validate AWS instance type format
-	if err := v.ValidateAWSInstanceType(instanceType); err != nil {
+	// Validate AWS instance type format and availability
+	if err := v.ValidateAWSInstanceType(region, instanceType); err != nil {
 		return errors.New(errors.CodeInvalidInput,
-			fmt.Sprintf("%s '%s' is not a valid EC2 instance type - use formats like 't3.medium', 'm5.large', 'c5.xlarge'", fieldName, instanceType)).
+			fmt.Sprintf("%s: %s", fieldName, errors.GetUserMessage(err))).
+			WithMessageKey("validation.field", fieldName, err).
 			WithDetails("field", fieldName).
 			WithDetails("provided_value", instanceType)
 	}
@@ -276,6 +387,7 @@ func (v *Validator) validateCIDR(fieldName string, value interface{}) error {
 	if !ok {
 		return errors.New(errors.CodeInvalidInput,
 			fmt.Sprintf("%s must be a string in CIDR format (e.g., '10.0.0.0/16')", fieldName)).
+			WithMessageKey("validation.cidr.type", fieldName).
 			WithDetails("field", fieldName).
 			WithDetails("provided_type", fmt.Sprintf("%T", value))
 	}
@@ -283,12 +395,14 @@ func (v *Validator) validateCIDR(fieldName string, value interface{}) error {
 	if cidr == "" {
 		return errors.New(errors.CodeInvalidInput,
 			fmt.Sprintf("%s cannot be empty - specify a CIDR block like '10.0.0.0/16' or '192.168.1.0/24'", fieldName)).
+			WithMessageKey("validation.cidr.empty", fieldName).
 			WithDetails("field", fieldName)
 	}
 
 	if err := v.ValidateCIDR(cidr); err != nil {
 		return errors.New(errors.CodeInvalidInput,
 			fmt.Sprintf("%s '%s' is not a valid CIDR block - use format like '10.0.0.0/16' or '192.168.1.0/24'", fieldName, cidr)).
+			WithMessageKey("validation.cidr.invalid", fieldName, cidr).
 			WithDetails("field", fieldName).
 			WithDetails("provided_value", cidr)
 	}
@@ -333,6 +447,7 @@ func (v *Validator) combineValidationErrors(validationErrors []error) error {
 
 	var errorMessages []string
 	var allDetails = make(map[string]interface{})
+	var fix = make(map[string]interface{})
 
 	for i, err := range validationErrors {
 		errorMessages = append(errorMessages, fmt.Sprintf("%d. %s", i+1, err.Error()))
@@ -343,11 +458,18 @@ func (v *Validator) combineValidationErrors(validationErrors []error) error {
 				allDetails[k] = v
 			}
 		}
+		// Suggest a fix for every argument that has one
+		mergeFixes(fix, SuggestedFix(err))
+	}
+	delete(allDetails, SuggestedFixDetail)
+	if len(fix) > 0 {
+		allDetails[SuggestedFixDetail] = fix
 	}
 
 	combinedMessage := fmt.Sprintf("Multiple validation errors:\n%s", strings.Join(errorMessages, "\n"))
 
 	return errors.New(errors.CodeInvalidInput, combinedMessage).
+		WithMessageKey("validation.multiple", validationErrors).
 		WithDetailsMap(allDetails)
 }
 
@@ -456,60 +578,57 @@ func SanitizeClusterName(name string) string {
 	return result
 }
 
-// ValidateAWSRegion validates AWS region format and known regions
+// ValidateAWSRegion validates AWS region format and, if a catalog is
+// configured, that the region is available
 func (v *Validator) ValidateAWSRegion(region string) error {
 	if region == "" {
 		return errors.New(errors.CodeInvalidInput, "AWS region cannot be empty")
 	}
 
-	// AWS region format: 2-3 letter region + dash + direction + dash + number
-	awsRegionRegex := regexp.MustCompile(`^[a-z]{2,3}-[a-z]+-\d+$`)
-	if !awsRegionRegex.MatchString(region) {
-		return errors.New(errors.CodeInvalidInput,
-			fmt.Sprintf("'%s' is not a valid AWS region format - use format like 'us-west-2' or 'eu-central-1'", region))
-	}
-
-	// List of common valid AWS regions (not exhaustive, but covers most cases)
-	validRegions := map[string]bool{
-		"us-east-1":      true, // US East (N. Virginia)
-		"us-east-2":      true, // US East (Ohio)
-		"us-west-1":      true, // US West (N. California)
-		"us-west-2":      true, // US West (Oregon)
-		"eu-west-1":      true, // Europe (Ireland)
-		"eu-west-2":      true, // Europe (London)
-		"eu-west-3":      true, // Europe (Paris)
-		"eu-central-1":   true, // Europe (Frankfurt)
-		"eu-north-1":     true, // Europe (Stockholm)
-		"ap-south-1":     true, // Asia Pacific (Mumbai)
-		"ap-southeast-1": true, // Asia Pacific (Singapore)
-		"ap-southeast-2": true, // Asia Pacific (Sydney)
-		"ap-northeast-1": true, // Asia Pacific (Tokyo)
-		"ap-northeast-2": true, // Asia Pacific (Seoul)
-		"ap-northeast-3": true, // Asia Pacific (Osaka)
-		"ca-central-1":   true, // Canada (Central)
-		"sa-east-1":      true, // South America (São Paulo)
-	}
-
-	if !validRegions[region] {
-		return errors.New(errors.CodeInvalidInput,
-			fmt.Sprintf("'%s' is not a known AWS region - common regions include us-west-2, eu-central-1, ap-southeast-1", region))
+	var err *errors.Error
+	switch {
+	case !awsRegionRegex.MatchString(region):
+		err = errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("'%s' is not a valid AWS region format - use format like 'us-west-2' or 'eu-central-1'", region)).
+			WithMessageKey("validation.aws_region.invalid", region)
+	case v.awsCatalog != nil && !v.awsCatalog.IsRegion(region):
+		err = errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("'%s' is not an available AWS region - common regions include us-west-2, eu-central-1, ap-southeast-1", region)).
+			WithMessageKey("validation.aws_region.unavailable", region)
+	default:
+		return nil
 	}
-
-	return nil
+	if suggested := v.suggestAWSRegion(region); suggested != "" {
+		withFix(err, "region", suggested)
+	}
+	return err
 }
 
-// ValidateAWSInstanceType validates AWS EC2 instance type format
-func (v *Validator) ValidateAWSInstanceType(instanceType string) error {
+// ValidateAWSInstanceType validates EC2 instance type format and, if a catalog
+// is configured, that the instance type is offered in region (or in any
+// region when region is empty)
+func (v *Validator) ValidateAWSInstanceType(region, instanceType string) error {
 	if instanceType == "" {
-		return errors.New(errors.CodeInvalidInput, "instance type cannot be empty")
+		return errors.New(errors.CodeInvalidInput, "instance type cannot be empty").
+			WithMessageKey("validation.aws_instance_type.empty")
 	}
 
-	// AWS instance type format: family + generation + size
-	// Examples: t3.micro, m5.large, c5.4xlarge, r5d.24xlarge
-	instanceTypeRegex := regexp.MustCompile(`^[a-z][0-9]+[a-z]*\.(nano|micro|small|medium|large|xlarge|[0-9]+xlarge)$`)
-	if !instanceTypeRegex.MatchString(instanceType) {
+	// Examples: t3.micro, m5.large, c5.4xlarge, r5d.24xlarge, m7i-flex.large
+	if !awsInstanceTypeRegex.MatchString(instanceType) {
 		return errors.New(errors.CodeInvalidInput,
-			fmt.Sprintf("'%s' is not a valid EC2 instance type format - use formats like 't3.medium', 'm5.large'", instanceType))
+			fmt.Sprintf("'%s' is not a valid EC2 instance type format - use formats like 't3.medium', 'm5.large'", instanceType)).
+			WithMessageKey("validation.aws_instance_type.invalid", instanceType)
+	}
+
+	if v.awsCatalog != nil && !v.awsCatalog.IsInstanceType(region, instanceType) {
+		if region != "" {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("EC2 instance type '%s' is not offered in %s", instanceType, region)).
+				WithMessageKey("validation.aws_instance_type.not_offered", instanceType, region)
+		}
+		return errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("'%s' is not an available EC2 instance type - use types like 't3.medium', 'm5.large'", instanceType)).
+			WithMessageKey("validation.aws_instance_type.unavailable", instanceType)
 	}
 
 	return nil
@@ -549,6 +668,21 @@ func (v *Validator) ValidateCIDR(cidr string) error {
 	return nil
 }
 
+// CIDRsOverlap reports whether two CIDR blocks share any addresses.
+func CIDRsOverlap(a, b string) (bool, error) {
+	_, netA, err := net.ParseCIDR(a)
+	if err != nil {
+		return false, errors.New(errors.CodeInvalidInput, fmt.Sprintf("'%s' is not a valid CIDR block", a))
+	}
+	_, netB, err := net.ParseCIDR(b)
+	if err != nil {
+		return false, errors.New(errors.CodeInvalidInput, fmt.Sprintf("'%s' is not a valid CIDR block", b))
+	}
+
+	// Two blocks overlap exactly when one contains the other's network address
+	return netA.Contains(netB.IP) || netB.Contains(netA.IP), nil
+}
+
 // ValidateEC2KeyName validates EC2 key pair name format
 func (v *Validator) ValidateEC2KeyName(keyName string) error {
 	if keyName == "" {
@@ -572,16 +706,19 @@ func (v *Validator) ValidateEC2KeyName(keyName string) error {
 // ValidateTemplateName validates ClusterClass template name
 func (v *Validator) ValidateTemplateName(templateName string) error {
 	if templateName == "" {
-		return errors.New(errors.CodeInvalidInput, "template name cannot be empty")
+		return errors.New(errors.CodeInvalidInput, "template name cannot be empty").
+			WithMessageKey("validation.template_name.empty")
 	}
 
 	if len(templateName) > 253 {
-		return errors.New(errors.CodeInvalidInput, "template name must be 253 characters or less")
+		return errors.New(errors.CodeInvalidInput, "template name must be 253 characters or less").
+			WithMessageKey("validation.template_name.too_long")
 	}
 
 	if !dnsSubdomainRegex.MatchString(templateName) {
 		return errors.New(errors.CodeInvalidInput,
-			"template name must be a valid DNS subdomain (lowercase letters, numbers, dots, and hyphens)")
+			"template name must be a valid DNS subdomain (lowercase letters, numbers, dots, and hyphens)").
+			WithMessageKey("validation.template_name.invalid")
 	}
 
 	return nil
@@ -591,17 +728,20 @@ func (v *Validator) ValidateTemplateName(templateName string) error {
 func (v *Validator) ValidateNodePoolName(nodePoolName string) error {
 	if nodePoolName == "" {
 		return errors.New(errors.CodeInvalidInput,
-			"node pool name cannot be empty - specify a name like 'workers' or 'default-worker'")
+			"node pool name cannot be empty - specify a name like 'workers' or 'default-worker'").
+			WithMessageKey("validation.node_pool_name.empty")
 	}
 
 	if len(nodePoolName) > 253 {
 		return errors.New(errors.CodeInvalidInput,
-			"node pool name must be 253 characters or less")
+			"node pool name must be 253 characters or less").
+			WithMessageKey("validation.node_pool_name.too_long")
 	}
 
 	if !dnsSubdomainRegex.MatchString(nodePoolName) {
 		return errors.New(errors.CodeInvalidInput,
-			"node pool name must be a valid DNS subdomain - use lowercase letters, numbers, dots, and hyphens only")
+			"node pool name must be a valid DNS subdomain - use lowercase letters, numbers, dots, and hyphens only").
+			WithMessageKey("validation.node_pool_name.invalid")
 	}
 
 	return nil
@@ -619,6 +759,7 @@ func (v *Validator) ValidateCreateClusterInput(input map[string]interface{}) err
 	} else {
 		validationErrors = append(validationErrors,
 			errors.New(errors.CodeInvalidInput, "clusterName is required and must be a string").
+				WithMessageKey("validation.required", "clusterName").
 				WithDetails("field", "clusterName"))
 	}
 
@@ -630,6 +771,7 @@ func (v *Validator) ValidateCreateClusterInput(input map[string]interface{}) err
 	} else {
 		validationErrors = append(validationErrors,
 			errors.New(errors.CodeInvalidInput, "templateName is required and must be a string").
+				WithMessageKey("validation.required", "templateName").
 				WithDetails("field", "templateName"))
 	}
 
@@ -641,13 +783,24 @@ func (v *Validator) ValidateCreateClusterInput(input map[string]interface{}) err
 	} else {
 		validationErrors = append(validationErrors,
 			errors.New(errors.CodeInvalidInput, "kubernetesVersion is required and must be a string in format 'vX.Y.Z'").
+				WithMessageKey("validation.required.kubernetes_version").
 				WithDetails("field", "kubernetesVersion"))
 	}
 
 	// Validate variables if present
 	if variables, ok := input["variables"].(map[string]interface{}); ok {
 		if err := v.ValidateClusterVariables(variables); err != nil {
-			validationErrors = append(validationErrors, err)
+			validationErrors = append(validationErrors, nestFix(err, "variables"))
+		}
+	}
+
+	// Validate dry run flag if present
+	if dryRun, ok := input["dryRun"]; ok {
+		if _, ok := dryRun.(bool); !ok {
+			validationErrors = append(validationErrors,
+				errors.New(errors.CodeInvalidInput, "dryRun must be a boolean").
+					WithMessageKey("validation.dry_run.type").
+					WithDetails("field", "dryRun"))
 		}
 	}
 
@@ -671,6 +824,7 @@ func (v *Validator) ValidateScaleClusterInput(input map[string]interface{}) erro
 	} else {
 		validationErrors = append(validationErrors,
 			errors.New(errors.CodeInvalidInput, "clusterName is required and must be a string").
+				WithMessageKey("validation.required", "clusterName").
 				WithDetails("field", "clusterName"))
 	}
 
@@ -682,6 +836,7 @@ func (v *Validator) ValidateScaleClusterInput(input map[string]interface{}) erro
 	} else {
 		validationErrors = append(validationErrors,
 			errors.New(errors.CodeInvalidInput, "nodePoolName is required and must be a string").
+				WithMessageKey("validation.required", "nodePoolName").
 				WithDetails("field", "nodePoolName"))
 	}
 
@@ -693,6 +848,7 @@ func (v *Validator) ValidateScaleClusterInput(input map[string]interface{}) erro
 	} else {
 		validationErrors = append(validationErrors,
 			errors.New(errors.CodeInvalidInput, "replicas is required and must be a number between 0 and 100").
+				WithMessageKey("validation.required.replicas").
 				WithDetails("field", "replicas"))
 	}
 
@@ -1,6 +1,9 @@
 package validation
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/capi-mcp/capi-mcp-server/internal/errors"
@@ -498,6 +501,88 @@ func TestSanitizeClusterName(t *testing.T) {
 	}
 }
 
+func TestValidator_SuggestedFix(t *testing.T) {
+	v := NewValidator()
+	catalogValidator := NewValidator()
+	catalogValidator.SetAWSCatalog(staticAWSCatalog{"us-west-2": nil, "eu-central-1": nil})
+
+	tests := []struct {
+		name     string
+		validate func() error
+		expected map[string]interface{}
+	}{
+		{
+			name: "create cluster",
+			validate: func() error {
+				return v.ValidateCreateClusterInput(map[string]interface{}{
+					"clusterName":       "Prod_Cluster",
+					"templateName":      "aws-standard",
+					"kubernetesVersion": "1.31",
+					"variables":         map[string]interface{}{"region": "US_WEST_2", "nodeCount": 150},
+				})
+			},
+			expected: map[string]interface{}{
+				"clusterName":       "prod-cluster",
+				"kubernetesVersion": "v1.31.0",
+				"variables":         map[string]interface{}{"region": "us-west-2", "nodeCount": int32(100)},
+			},
+		},
+		{
+			name: "single variable",
+			validate: func() error {
+				return v.ValidateCreateClusterInput(map[string]interface{}{
+					"clusterName":       "prod-a",
+					"templateName":      "aws-standard",
+					"kubernetesVersion": "v1.31.0",
+					"variables":         map[string]interface{}{"region": "euwest1"},
+				})
+			},
+			expected: map[string]interface{}{"variables": map[string]interface{}{"region": "eu-west-1"}},
+		},
+		{
+			name: "scale cluster",
+			validate: func() error {
+				return v.ValidateScaleClusterInput(map[string]interface{}{"clusterName": "prod-a", "nodePoolName": "workers", "replicas": -3})
+			},
+			expected: map[string]interface{}{"replicas": int32(0)},
+		},
+		{
+			name:     "too many replicas",
+			validate: func() error { return v.ValidateReplicaCount(250) },
+			expected: map[string]interface{}{"replicas": int32(100)},
+		},
+		{
+			name:     "region not in catalog",
+			validate: func() error { return catalogValidator.ValidateAWSRegion("eu-central-2") },
+			expected: map[string]interface{}{"region": "eu-central-1"},
+		},
+		{
+			name:     "no region close enough",
+			validate: func() error { return v.ValidateAWSRegion("mars-north-9") },
+		},
+		{
+			name:     "no valid version",
+			validate: func() error { return v.ValidateKubernetesVersion("latest") },
+		},
+		{
+			name:     "no name to sanitize",
+			validate: func() error { return v.ValidateClusterName("") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate()
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if got := SuggestedFix(err); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SuggestedFix() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestToInt32(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -569,3 +654,989 @@ func TestToInt32(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_ValidateKubernetesVersionRange(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		version     string
+		constraint  string
+		expectError bool
+		errorCode   errors.ErrorCode
+	}{
+		{
+			name:       "within range",
+			version:    "v1.30.2",
+			constraint: ">=v1.28.0 <v1.32",
+		},
+		{
+			name:       "latest patch of upper minor",
+			version:    "v1.31.9",
+			constraint: ">=v1.28 <v1.32",
+		},
+		{
+			name:       "empty constraint",
+			version:    "v1.30.2",
+			constraint: "",
+		},
+		{
+			name:        "below range",
+			version:     "v1.27.3",
+			constraint:  ">=v1.28.0 <v1.32",
+			expectError: true,
+			errorCode:   errors.CodeInvalidInput,
+		},
+		{
+			name:        "above range",
+			version:     "v1.32.0",
+			constraint:  ">=v1.28.0 <v1.32",
+			expectError: true,
+			errorCode:   errors.CodeInvalidInput,
+		},
+		{
+			name:        "invalid version",
+			version:     "1.30",
+			constraint:  ">=v1.28.0",
+			expectError: true,
+			errorCode:   errors.CodeInvalidInput,
+		},
+		{
+			name:        "invalid constraint",
+			version:     "v1.30.2",
+			constraint:  ">=latest",
+			expectError: true,
+			errorCode:   errors.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateKubernetesVersionRange(tt.version, tt.constraint)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+					return
+				}
+				if customErr, ok := err.(*errors.Error); ok {
+					if customErr.Code != tt.errorCode {
+						t.Errorf("Expected error code %v, got %v", tt.errorCode, customErr.Code)
+					}
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateKubernetesVersionSupported(t *testing.T) {
+	v := NewValidator()
+	supported := []string{"v1.31.0", "v1.30.5", "v1.29.9"}
+
+	tests := []struct {
+		name        string
+		version     string
+		expectError bool
+	}{
+		{
+			name:    "supported minor with other patch",
+			version: "v1.30.1",
+		},
+		{
+			name:    "exact supported version",
+			version: "v1.31.0",
+		},
+		{
+			name:        "unsupported minor",
+			version:     "v1.27.3",
+			expectError: true,
+		},
+		{
+			name:        "invalid version",
+			version:     "latest",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateKubernetesVersionSupported(tt.version, supported)
+
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCIDRsOverlap(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:     "identical blocks",
+			a:        "10.0.0.0/16",
+			b:        "10.0.0.0/16",
+			expected: true,
+		},
+		{
+			name:     "subnet inside vpc",
+			a:        "10.0.0.0/16",
+			b:        "10.0.4.0/24",
+			expected: true,
+		},
+		{
+			name:     "vpc containing other block",
+			a:        "10.0.4.0/24",
+			b:        "10.0.0.0/8",
+			expected: true,
+		},
+		{
+			name:     "adjacent blocks",
+			a:        "10.0.0.0/16",
+			b:        "10.1.0.0/16",
+			expected: false,
+		},
+		{
+			name:        "invalid block",
+			a:           "10.0.0.0/16",
+			b:           "not-a-cidr",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CIDRsOverlap(tt.a, tt.b)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("CIDRsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVersionSatisfies(t *testing.T) {
+	tests := []struct {
+		name        string
+		version     string
+		constraint  string
+		expected    bool
+		expectError bool
+	}{
+		{
+			name:       "within range",
+			version:    "v2.5.1",
+			constraint: ">=v2.4.0 <v3.0",
+			expected:   true,
+		},
+		{
+			name:       "empty constraint",
+			version:    "v2.5.1",
+			constraint: "",
+			expected:   true,
+		},
+		{
+			name:       "above range",
+			version:    "v3.0.0",
+			constraint: ">=v2.4.0 <v3.0",
+			expected:   false,
+		},
+		{
+			name:       "exact version",
+			version:    "v1.2.0",
+			constraint: "v1.2.0",
+			expected:   true,
+		},
+		{
+			name:        "invalid version",
+			version:     "latest",
+			constraint:  ">=v2.4.0",
+			expectError: true,
+		},
+		{
+			name:        "invalid constraint",
+			version:     "v2.5.1",
+			constraint:  ">=two",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VersionSatisfies(tt.version, tt.constraint)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("VersionSatisfies(%q, %q) = %v, want %v", tt.version, tt.constraint, got, tt.expected)
+			}
+		})
+	}
+}
+
+// staticAWSCatalog offers a fixed set of instance types in each region.
+type staticAWSCatalog map[string][]string
+
+func (c staticAWSCatalog) IsRegion(region string) bool {
+	_, ok := c[region]
+	return ok
+}
+
+func (c staticAWSCatalog) IsInstanceType(region, instanceType string) bool {
+	for offeredRegion, instanceTypes := range c {
+		if region != "" && offeredRegion != region {
+			continue
+		}
+		for _, offered := range instanceTypes {
+			if offered == instanceType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestValidator_AWSCatalog(t *testing.T) {
+	v := NewValidator()
+	v.SetAWSCatalog(staticAWSCatalog{
+		"us-west-2":    {"m7i-flex.large", "t3.medium"},
+		"eu-central-1": {"t3.medium"},
+	})
+
+	tests := []struct {
+		name        string
+		input       map[string]interface{}
+		expectError bool
+	}{
+		{
+			name:  "instance type offered in region",
+			input: map[string]interface{}{"region": "us-west-2", "instanceType": "m7i-flex.large"},
+		},
+		{
+			name:        "instance type not offered in region",
+			input:       map[string]interface{}{"region": "eu-central-1", "instanceType": "m7i-flex.large"},
+			expectError: true,
+		},
+		{
+			name:  "instance type offered in any region",
+			input: map[string]interface{}{"workerInstanceType": "m7i-flex.large"},
+		},
+		{
+			name:        "region not in catalog",
+			input:       map[string]interface{}{"region": "us-east-1"},
+			expectError: true,
+		},
+		{
+			name:        "malformed instance type",
+			input:       map[string]interface{}{"instanceType": "large"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(tt.input)
+
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateSpotMarketOptions(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expectError bool
+	}{
+		{
+			name:        "empty options",
+			input:       map[string]interface{}{},
+			expectError: false,
+		},
+		{
+			name:        "max price and allocation strategy",
+			input:       map[string]interface{}{"maxPrice": "0.05", "allocationStrategy": "capacity-optimized"},
+			expectError: false,
+		},
+		{
+			name:        "non-numeric max price",
+			input:       map[string]interface{}{"maxPrice": "cheap"},
+			expectError: true,
+		},
+		{
+			name:        "zero max price",
+			input:       map[string]interface{}{"maxPrice": "0"},
+			expectError: true,
+		},
+		{
+			name:        "numeric max price",
+			input:       map[string]interface{}{"maxPrice": 0.05},
+			expectError: true,
+		},
+		{
+			name:        "unsupported allocation strategy",
+			input:       map[string]interface{}{"allocationStrategy": "diversified"},
+			expectError: true,
+		},
+		{
+			name:        "unknown field",
+			input:       map[string]interface{}{"blockDuration": "60"},
+			expectError: true,
+		},
+		{
+			name:        "not an object",
+			input:       "capacity-optimized",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(map[string]interface{}{"spotMarketOptions": tt.input})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateGPUCount(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		variables   map[string]interface{}
+		expectError bool
+	}{
+		{
+			name:        "GPU instance type",
+			variables:   map[string]interface{}{"instanceType": "g5.12xlarge", "gpuCount": 4},
+			expectError: false,
+		},
+		{
+			name:        "worker instance type takes precedence",
+			variables:   map[string]interface{}{"instanceType": "m5.large", "workerInstanceType": "p4d.24xlarge", "gpuCount": 8},
+			expectError: false,
+		},
+		{
+			name:        "without instance type",
+			variables:   map[string]interface{}{"gpuCount": float64(1)},
+			expectError: false,
+		},
+		{
+			name:        "zero GPUs on a non-GPU instance type",
+			variables:   map[string]interface{}{"instanceType": "m5.large", "gpuCount": 0},
+			expectError: false,
+		},
+		{
+			name:        "non-GPU instance type",
+			variables:   map[string]interface{}{"instanceType": "m5.large", "gpuCount": 1},
+			expectError: true,
+		},
+		{
+			name:        "more GPUs than the family provides",
+			variables:   map[string]interface{}{"instanceType": "g4ad.xlarge", "gpuCount": 8},
+			expectError: true,
+		},
+		{
+			name:        "negative count",
+			variables:   map[string]interface{}{"gpuCount": -1},
+			expectError: true,
+		},
+		{
+			name:        "not a number",
+			variables:   map[string]interface{}{"gpuCount": "two"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(tt.variables)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestIsGPUInstanceType(t *testing.T) {
+	for instanceType, want := range map[string]bool{
+		"g5.xlarge":        true,
+		"p4d.24xlarge":     true,
+		"p6-b200.48xlarge": true,
+		"g4dn.metal":       true,
+		"m5.large":         false,
+		"inf2.xlarge":      false,
+		"gpu":              false,
+	} {
+		if got := IsGPUInstanceType(instanceType); got != want {
+			t.Errorf("IsGPUInstanceType(%q) = %v, want %v", instanceType, got, want)
+		}
+	}
+}
+
+func TestValidator_ValidateComponentConfig(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		variables   map[string]interface{}
+		expectError bool
+	}{
+		{
+			name: "audit logging and feature gates",
+			variables: map[string]interface{}{
+				"controlPlaneConfig": map[string]interface{}{
+					"apiServer": map[string]interface{}{
+						"audit-log-path":   "/var/log/kubernetes/audit.log",
+						"audit-log-maxage": "30",
+						"feature-gates":    "InPlacePodVerticalScaling=true,SidecarContainers=false",
+					},
+					"scheduler": map[string]interface{}{"feature-gates": "SchedulerQueueingHints=true"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:        "kubelet flags",
+			variables:   map[string]interface{}{"kubeletConfig": map[string]interface{}{"max-pods": "110", "eviction-hard": "memory.available<500Mi"}},
+			expectError: false,
+		},
+		{
+			name: "flag outside the allowlist",
+			variables: map[string]interface{}{
+				"controlPlaneConfig": map[string]interface{}{
+					"apiServer": map[string]interface{}{"anonymous-auth": "true"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown component",
+			variables: map[string]interface{}{
+				"controlPlaneConfig": map[string]interface{}{
+					"etcd": map[string]interface{}{"quota-backend-bytes": "8589934592"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name:        "malformed feature gates",
+			variables:   map[string]interface{}{"kubeletConfig": map[string]interface{}{"feature-gates": "all"}},
+			expectError: true,
+		},
+		{
+			name:        "kubelet flag outside the allowlist",
+			variables:   map[string]interface{}{"kubeletConfig": map[string]interface{}{"cloud-provider": "external"}},
+			expectError: true,
+		},
+		{
+			name:        "non-string flag value",
+			variables:   map[string]interface{}{"kubeletConfig": map[string]interface{}{"max-pods": float64(110)}},
+			expectError: true,
+		},
+		{
+			name:        "not an object",
+			variables:   map[string]interface{}{"controlPlaneConfig": "--audit-log-path=/var/log/audit.log"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(tt.variables)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateCloudTags(t *testing.T) {
+	v := NewValidator()
+
+	tooMany := map[string]interface{}{}
+	for i := 0; i <= maxCloudTags; i++ {
+		tooMany[fmt.Sprintf("tag-%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expectError bool
+	}{
+		{
+			name:        "cost allocation tags",
+			input:       map[string]interface{}{"cost-center": "1234", "team": "platform", "owner": "ops@example.com"},
+			expectError: false,
+		},
+		{
+			name:        "empty value",
+			input:       map[string]interface{}{"scratch": ""},
+			expectError: false,
+		},
+		{
+			name:        "reserved aws prefix",
+			input:       map[string]interface{}{"AWS:createdBy": "me"},
+			expectError: true,
+		},
+		{
+			name:        "reserved CAPA prefix",
+			input:       map[string]interface{}{"sigs.k8s.io/cluster-api-provider-aws/role": "node"},
+			expectError: true,
+		},
+		{
+			name:        "invalid characters",
+			input:       map[string]interface{}{"team": "platform;drop"},
+			expectError: true,
+		},
+		{
+			name:        "key too long",
+			input:       map[string]interface{}{strings.Repeat("k", 129): "value"},
+			expectError: true,
+		},
+		{
+			name:        "too many tags",
+			input:       tooMany,
+			expectError: true,
+		},
+		{
+			name:        "non-string value",
+			input:       map[string]interface{}{"cost-center": float64(1234)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(map[string]interface{}{"cloudTags": tt.input})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateIdentityRef(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		input       interface{}
+		expectError bool
+	}{
+		{
+			name:        "role identity",
+			input:       map[string]interface{}{"kind": "AWSClusterRoleIdentity", "name": "team-a-account"},
+			expectError: false,
+		},
+		{
+			name:        "static identity",
+			input:       map[string]interface{}{"kind": "AWSClusterStaticIdentity", "name": "legacy"},
+			expectError: false,
+		},
+		{
+			name:        "controller identity",
+			input:       map[string]interface{}{"kind": "AWSClusterControllerIdentity", "name": "default"},
+			expectError: false,
+		},
+		{
+			name:        "controller identity not named default",
+			input:       map[string]interface{}{"kind": "AWSClusterControllerIdentity", "name": "other"},
+			expectError: true,
+		},
+		{
+			name:        "unsupported kind",
+			input:       map[string]interface{}{"kind": "AzureClusterIdentity", "name": "team-a"},
+			expectError: true,
+		},
+		{
+			name:        "missing name",
+			input:       map[string]interface{}{"kind": "AWSClusterRoleIdentity"},
+			expectError: true,
+		},
+		{
+			name:        "invalid name",
+			input:       map[string]interface{}{"kind": "AWSClusterRoleIdentity", "name": "Team_A"},
+			expectError: true,
+		},
+		{
+			name:        "unknown field",
+			input:       map[string]interface{}{"kind": "AWSClusterRoleIdentity", "name": "team-a", "roleARN": "arn:aws:iam::123456789012:role/x"},
+			expectError: true,
+		},
+		{
+			name:        "not an object",
+			input:       "team-a-account",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(map[string]interface{}{"identityRef": tt.input})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateRegistryVariables(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		variables   map[string]interface{}
+		expectError bool
+	}{
+		{
+			name:        "image repository with path",
+			variables:   map[string]interface{}{"imageRepository": "registry.example.com:5000/k8s"},
+			expectError: false,
+		},
+		{
+			name:        "image repository host only",
+			variables:   map[string]interface{}{"imageRepository": "registry.internal"},
+			expectError: false,
+		},
+		{
+			name:        "image repository with scheme",
+			variables:   map[string]interface{}{"imageRepository": "https://registry.example.com/k8s"},
+			expectError: true,
+		},
+		{
+			name:        "image repository not a string",
+			variables:   map[string]interface{}{"imageRepository": []interface{}{"registry.example.com"}},
+			expectError: true,
+		},
+		{
+			name: "registry mirrors",
+			variables: map[string]interface{}{"registryMirrors": map[string]interface{}{
+				"docker.io":   []interface{}{"https://mirror.example.com", "http://10.0.0.5:5000"},
+				"ghcr.io":     []interface{}{"https://mirror.example.com/ghcr"},
+				"quay.io:443": []interface{}{"https://mirror.example.com"},
+			}},
+			expectError: false,
+		},
+		{
+			name:        "registry mirror without scheme",
+			variables:   map[string]interface{}{"registryMirrors": map[string]interface{}{"docker.io": []interface{}{"mirror.example.com"}}},
+			expectError: true,
+		},
+		{
+			name:        "registry mirrors with invalid registry",
+			variables:   map[string]interface{}{"registryMirrors": map[string]interface{}{"https://docker.io": []interface{}{"https://mirror.example.com"}}},
+			expectError: true,
+		},
+		{
+			name:        "registry with no mirrors",
+			variables:   map[string]interface{}{"registryMirrors": map[string]interface{}{"docker.io": []interface{}{}}},
+			expectError: true,
+		},
+		{
+			name:        "registry mirror not a list",
+			variables:   map[string]interface{}{"registryMirrors": map[string]interface{}{"docker.io": "https://mirror.example.com"}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(tt.variables)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateBastionVariables(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		variables   map[string]interface{}
+		expectError bool
+	}{
+		{
+			name: "bastion with key pair",
+			variables: map[string]interface{}{
+				"sshKeyName":               "ops",
+				"bastionEnabled":           true,
+				"bastionInstanceType":      "t3.micro",
+				"bastionAllowedCIDRBlocks": []interface{}{"203.0.113.0/24"},
+			},
+			expectError: false,
+		},
+		{
+			name:        "bastion disabled",
+			variables:   map[string]interface{}{"bastionEnabled": false},
+			expectError: false,
+		},
+		{
+			name:        "bastion without key pair",
+			variables:   map[string]interface{}{"bastionEnabled": true},
+			expectError: true,
+		},
+		{
+			name:        "bastion enabled not a boolean",
+			variables:   map[string]interface{}{"sshKeyName": "ops", "bastionEnabled": "yes"},
+			expectError: true,
+		},
+		{
+			name:        "bastion settings without bastion",
+			variables:   map[string]interface{}{"sshKeyName": "ops", "bastionInstanceType": "t3.micro"},
+			expectError: true,
+		},
+		{
+			name: "invalid allowed CIDR block",
+			variables: map[string]interface{}{
+				"sshKeyName":               "ops",
+				"bastionEnabled":           true,
+				"bastionAllowedCIDRBlocks": []interface{}{"203.0.113.0"},
+			},
+			expectError: true,
+		},
+		{
+			name: "allowed CIDR blocks not a list",
+			variables: map[string]interface{}{
+				"sshKeyName":               "ops",
+				"bastionEnabled":           true,
+				"bastionAllowedCIDRBlocks": "203.0.113.0/24",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid bastion instance type",
+			variables: map[string]interface{}{
+				"sshKeyName":          "ops",
+				"bastionEnabled":      true,
+				"bastionInstanceType": "huge",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateClusterVariables(tt.variables)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRegionPolicy_ValidateRegion(t *testing.T) {
+	policy := &RegionPolicy{
+		Default: RegionRule{Denied: []string{"cn-*"}},
+		Namespaces: map[string]RegionRule{
+			"team-eu": {Allowed: []string{"eu-*"}},
+		},
+		Identities: map[string]RegionRule{
+			"eu-agent": {Allowed: []string{"eu-central-1"}},
+		},
+		Groups: map[string]RegionRule{
+			"contractors": {Denied: []string{"eu-west-1"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		region      string
+		namespace   string
+		identity    string
+		groups      []string
+		expectError string
+	}{
+		{name: "unrestricted namespace", region: "us-east-1", namespace: "default"},
+		{name: "denied everywhere", region: "cn-north-1", namespace: "default", expectError: "region cn-north-1 is not permitted by the region policy for the organization (denied: cn-*)"},
+		{name: "allowed in namespace", region: "eu-west-1", namespace: "team-eu"},
+		{name: "outside namespace allowlist", region: "us-east-1", namespace: "team-eu", expectError: "region policy for namespace 'team-eu'; allowed regions: eu-*"},
+		{name: "missing region", namespace: "team-eu", expectError: "region is required"},
+		{name: "missing region without allowlist", namespace: "default"},
+		{name: "identity narrows namespace", region: "eu-west-1", namespace: "team-eu", identity: "eu-agent", expectError: "identity 'eu-agent'"},
+		{name: "allowed for identity", region: "eu-central-1", namespace: "team-eu", identity: "eu-agent"},
+		{name: "denied for group", region: "eu-west-1", namespace: "team-eu", groups: []string{"contractors"}, expectError: "group 'contractors'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.ValidateRegion(tt.region, tt.namespace, tt.identity, tt.groups)
+
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got: %v", tt.expectError, err)
+			}
+			if code := errors.GetErrorCode(err); code != errors.CodeForbidden {
+				t.Errorf("Expected code %s, got %s", errors.CodeForbidden, code)
+			}
+		})
+	}
+}
+
+func TestInstanceTypePolicy_ValidateInstanceType(t *testing.T) {
+	policy := InstanceTypePolicy{
+		Allowed: []string{"t3.*", "m6i.*"},
+		Denied:  []string{"*.metal", "*.24xlarge"},
+	}
+
+	tests := []struct {
+		name         string
+		instanceType string
+		expectError  string
+	}{
+		{name: "allowed family", instanceType: "m6i.xlarge"},
+		{name: "other family", instanceType: "p5.48xlarge", expectError: "allowed instance types: t3.*, m6i.*"},
+		{name: "denied size", instanceType: "m6i.24xlarge", expectError: "denied: *.24xlarge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.ValidateInstanceType("workerInstanceType", tt.instanceType)
+
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestProtectedClusterNames_ValidateClusterName(t *testing.T) {
+	protected := ProtectedClusterNames{"management", "prod-*"}
+
+	tests := []struct {
+		name        string
+		clusterName string
+		expectError string
+	}{
+		{name: "unprotected", clusterName: "dev-a"},
+		{name: "exact name", clusterName: "management", expectError: "cluster name 'management' is protected (management); deletion is not permitted"},
+		{name: "pattern", clusterName: "prod-eu", expectError: "(prod-*)"},
+		{name: "pattern does not match a suffix", clusterName: "my-prod-eu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := protected.ValidateClusterName("deletion", tt.clusterName)
+
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got: %v", tt.expectError, err)
+			}
+			if code := errors.GetErrorCode(err); code != errors.CodeForbidden {
+				t.Errorf("Expected code %s, got %s", errors.CodeForbidden, code)
+			}
+		})
+	}
+}
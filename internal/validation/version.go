@@ -0,0 +1,145 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// versionComparisons maps the operators accepted in version constraints to
+// the comparison results that satisfy them.
+var versionComparisons = map[string][]int{
+	">=": {0, 1},
+	">":  {1},
+	"<=": {-1, 0},
+	"<":  {-1},
+	"=":  {0},
+}
+
+// ValidateKubernetesVersionRange checks that a Kubernetes version satisfies a
+// constraint: space-separated comparisons that must all hold, such as
+// ">=v1.28.0 <v1.32". Versions in the constraint may omit the patch number;
+// v1.31.5 is greater than v1.31, so "<v1.32" admits every v1.31 patch release.
+func (v *Validator) ValidateKubernetesVersionRange(kubernetesVersion, constraint string) error {
+	requested, err := version.ParseSemantic(kubernetesVersion)
+	if err != nil {
+		return errors.New(errors.CodeInvalidInput, "kubernetes version must be in format 'vX.Y.Z' (e.g., v1.28.0)").
+			WithDetails("field", "kubernetesVersion")
+	}
+
+	for _, term := range strings.Fields(constraint) {
+		operator, bound, err := parseVersionTerm(term)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInternal, fmt.Sprintf("invalid kubernetes version constraint %q", constraint))
+		}
+
+		if !containsInt(versionComparisons[operator], compareVersions(requested, bound)) {
+			return errors.New(errors.CodeInvalidInput,
+				fmt.Sprintf("kubernetes version %s is outside the supported range %q", kubernetesVersion, constraint)).
+				WithDetails("field", "kubernetesVersion")
+		}
+	}
+
+	return nil
+}
+
+// ValidateKubernetesVersionSupported checks that the minor release of a
+// Kubernetes version is one of the supported versions.
+func (v *Validator) ValidateKubernetesVersionSupported(kubernetesVersion string, supported []string) error {
+	requested, err := version.ParseSemantic(kubernetesVersion)
+	if err != nil {
+		return errors.New(errors.CodeInvalidInput, "kubernetes version must be in format 'vX.Y.Z' (e.g., v1.28.0)").
+			WithDetails("field", "kubernetesVersion")
+	}
+
+	minors := make([]string, 0, len(supported))
+	for _, s := range supported {
+		candidate, err := version.ParseGeneric(s)
+		if err != nil {
+			continue
+		}
+		if candidate.Major() == requested.Major() && candidate.Minor() == requested.Minor() {
+			return nil
+		}
+		minors = append(minors, fmt.Sprintf("v%d.%d", candidate.Major(), candidate.Minor()))
+	}
+
+	return errors.New(errors.CodeInvalidInput,
+		fmt.Sprintf("kubernetes version %s is not supported; supported versions: %s", kubernetesVersion, strings.Join(minors, ", "))).
+		WithDetails("field", "kubernetesVersion")
+}
+
+// ValidateVersionConstraint checks that a constraint is in the form
+// ValidateKubernetesVersionRange accepts, such as ">=v2.4.0 <v3".
+func ValidateVersionConstraint(constraint string) error {
+	for _, term := range strings.Fields(constraint) {
+		if _, _, err := parseVersionTerm(term); err != nil {
+			return fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+	}
+	return nil
+}
+
+// VersionSatisfies reports whether a version such as v2.5.1 satisfies a
+// constraint in the form ValidateKubernetesVersionRange accepts. An empty
+// constraint is satisfied by every version.
+func VersionSatisfies(v, constraint string) (bool, error) {
+	if err := ValidateVersionConstraint(constraint); err != nil {
+		return false, err
+	}
+	parsed, err := version.ParseGeneric(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q: %w", v, err)
+	}
+
+	for _, term := range strings.Fields(constraint) {
+		operator, bound, _ := parseVersionTerm(term)
+		if !containsInt(versionComparisons[operator], compareVersions(parsed, bound)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// parseVersionTerm splits a constraint term such as ">=v1.28" into its
+// operator and version. A term without an operator means equality.
+func parseVersionTerm(term string) (string, *version.Version, error) {
+	operator := "="
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(term, op) {
+			operator = op
+			term = strings.TrimPrefix(term, op)
+			break
+		}
+	}
+
+	bound, err := version.ParseGeneric(term)
+	if err != nil {
+		return "", nil, err
+	}
+	return operator, bound, nil
+}
+
+// compareVersions returns -1, 0 or 1 as a is less than, equal to or greater than b.
+func compareVersions(a, b *version.Version) int {
+	switch {
+	case a.LessThan(b):
+		return -1
+	case a.GreaterThan(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
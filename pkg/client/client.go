@@ -0,0 +1,268 @@
+// Package client is a Go client of the CAPI MCP server. It calls the server's
+// tools over the MCP streamable HTTP transport with the typed inputs and
+// outputs of api/v1, authenticates with an API key and retries calls that
+// failed on the way to the server, so Go services can drive the server
+// without implementing MCP themselves.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+const (
+	// DefaultMaxRetries is how often a failed call is retried by default.
+	DefaultMaxRetries = 3
+	// DefaultBackoff is the wait before the first retry by default; it
+	// doubles with each further retry.
+	DefaultBackoff = 500 * time.Millisecond
+)
+
+// readOnlyTools are the tools that change nothing, so calls of them are
+// retried even when the server may have received them.
+var readOnlyTools = map[string]bool{
+	"list_clusters":          true,
+	"get_cluster":            true,
+	"get_cluster_kubeconfig": true,
+	"get_cluster_nodes":      true,
+	"get_autoscaler_status":  true,
+	"list_environments":      true,
+	"list_operations":        true,
+	"get_output_chunk":       true,
+}
+
+// Client calls the tools of a CAPI MCP server. It opens an MCP session with
+// the first call and is safe for concurrent use.
+type Client struct {
+	endpoint   string
+	apiKey     string
+	http       *http.Client
+	namespace  string
+	locale     string
+	maxRetries int
+	backoff    time.Duration
+
+	nextID atomic.Int64
+
+	mu      sync.Mutex
+	session *session
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client calls are sent with, e.g. to configure
+// TLS or a proxy. Its timeout, if any, limits each attempt of a call.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithNamespace sets the namespace of the clusters the cluster methods
+// manage, for identities with access to several. By default the server uses
+// the identity's namespace.
+func WithNamespace(namespace string) Option {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// WithLocale asks for error messages in a language, as language tags in the
+// format of the Accept-Language header, e.g. "de". Error codes are the same
+// in every language.
+func WithLocale(locale string) Option {
+	return func(c *Client) {
+		c.locale = locale
+	}
+}
+
+// WithRetries sets how often a failed call is retried and the wait before
+// the first retry, which doubles with each further retry. Zero maxRetries
+// disables retries.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the server whose MCP endpoint is at endpoint, e.g.
+// https://capi-mcp.example.com/, authenticating with apiKey.
+func New(endpoint, apiKey string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: must be an http or https URL", endpoint)
+	}
+
+	c := &Client{
+		endpoint:   endpoint,
+		apiKey:     apiKey,
+		http:       http.DefaultClient,
+		maxRetries: DefaultMaxRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxRetries < 0 {
+		return nil, fmt.Errorf("invalid max retries %d: must not be negative", c.maxRetries)
+	}
+	return c, nil
+}
+
+// CallOption configures a single call.
+type CallOption func(arguments map[string]interface{})
+
+// InNamespace calls the tool for the clusters of namespace instead of the
+// client's.
+func InNamespace(namespace string) CallOption {
+	return func(arguments map[string]interface{}) {
+		arguments["namespace"] = namespace
+	}
+}
+
+// WithApprovalID runs an operation that requires approval with the ID of its
+// approved approval, see Error.ApprovalID.
+func WithApprovalID(approvalID string) CallOption {
+	return func(arguments map[string]interface{}) {
+		arguments["approvalId"] = approvalID
+	}
+}
+
+// CallTool calls a tool with arguments named as in the tool's input schema
+// and decodes its result into out, unless out is nil. Results the server
+// returned in chunks are reassembled first. A call the tool failed returns an
+// *Error.
+//
+// A call is retried when the server was unreachable or overloaded; calls of
+// tools that change nothing also when the connection failed or a gateway
+// timed out after sending them.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}, out interface{}, opts ...CallOption) error {
+	args := make(map[string]interface{}, len(arguments)+1)
+	for key, value := range arguments {
+		args[key] = value
+	}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	text, err := c.callWithRetries(ctx, name, args)
+	if err != nil {
+		return err
+	}
+
+	var reference api.ChunkedOutput
+	if json.Unmarshal([]byte(text), &reference) == nil && reference.PayloadID != "" && reference.TotalChunks > 0 {
+		if text, err = c.readChunks(ctx, reference); err != nil {
+			return err
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(text), out); err != nil {
+		return fmt.Errorf("failed to decode result of %s: %w", name, err)
+	}
+	return nil
+}
+
+// Tool is a tool the server offers.
+type Tool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// InputSchema is the JSON schema of the tool's arguments.
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// ListTools lists the tools the server offers the client's API key.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var tools []Tool
+	params := map[string]interface{}{}
+	for {
+		result, err := c.sessionRequest(ctx, "tools/list", params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+
+		var page struct {
+			Tools      []Tool `json:"tools"`
+			NextCursor string `json:"nextCursor"`
+		}
+		if err := json.Unmarshal(result, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode tool list: %w", err)
+		}
+		tools = append(tools, page.Tools...)
+		if page.NextCursor == "" {
+			return tools, nil
+		}
+		params["cursor"] = page.NextCursor
+	}
+}
+
+// callWithRetries calls a tool, retrying failures that retryable allows, and
+// returns the text of its result.
+func (c *Client) callWithRetries(ctx context.Context, name string, arguments map[string]interface{}) (string, error) {
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		text, err := c.call(ctx, name, arguments)
+		if err == nil || attempt >= c.maxRetries || !retryable(err, readOnlyTools[name]) {
+			return text, err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", fmt.Errorf("%s: %w (last error: %v)", name, ctx.Err(), err)
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// readChunks fetches the chunks of a result the server returned as a
+// reference and concatenates them.
+func (c *Client) readChunks(ctx context.Context, reference api.ChunkedOutput) (string, error) {
+	var whole strings.Builder
+	for chunk := 0; chunk < reference.TotalChunks; chunk++ {
+		text, err := c.callWithRetries(ctx, "get_output_chunk", map[string]interface{}{
+			"payloadId": reference.PayloadID,
+			"chunk":     chunk,
+		})
+		if err != nil {
+			return "", err
+		}
+		var output api.GetOutputChunkOutput
+		if err := json.Unmarshal([]byte(text), &output); err != nil {
+			return "", fmt.Errorf("failed to decode chunk %d of payload %s: %w", chunk, reference.PayloadID, err)
+		}
+		whole.WriteString(output.Data)
+	}
+	return whole.String(), nil
+}
+
+// Close ends the client's MCP session, if it opened one. The client opens a
+// new session when it is used again.
+func (c *Client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	current := c.session
+	c.session = nil
+	c.mu.Unlock()
+	if current == nil {
+		return nil
+	}
+	return c.terminate(ctx, current)
+}
@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// fakeServer answers MCP requests like the server's streamable HTTP
+// endpoint, calling tool for each tools/call.
+type fakeServer struct {
+	t      *testing.T
+	apiKey string
+	tool   func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{}
+
+	mu       sync.Mutex
+	sessions map[string]bool
+	calls    []map[string]interface{}
+	methods  []string
+}
+
+func newFakeServer(t *testing.T, tool func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{}) (*fakeServer, *httptest.Server) {
+	f := &fakeServer{t: t, apiKey: "secret", tool: tool, sessions: map[string]bool{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+f.apiKey {
+		http.Error(w, "Bad Request: no server available", http.StatusBadRequest)
+		return
+	}
+	accept := r.Header.Get("Accept")
+	if !strings.Contains(accept, "application/json") || !strings.Contains(accept, "text/event-stream") {
+		http.Error(w, "Bad Request: Accept must contain both 'application/json' and 'text/event-stream'", http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sessionID := r.Header.Get(sessionHeader)
+	if r.Method == http.MethodDelete {
+		delete(f.sessions, sessionID)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var request struct {
+		ID     int64  `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments"`
+			Meta      map[string]interface{} `json:"_meta"`
+			Cursor    string                 `json:"cursor"`
+		} `json:"params"`
+	}
+	data, _ := io.ReadAll(r.Body)
+	require.NoError(f.t, json.Unmarshal(data, &request))
+	f.methods = append(f.methods, request.Method)
+
+	var result interface{}
+	switch request.Method {
+	case "initialize":
+		sessionID = fmt.Sprintf("session-%d", len(f.sessions)+1)
+		f.sessions[sessionID] = true
+		w.Header().Set(sessionHeader, sessionID)
+		result = map[string]interface{}{"protocolVersion": protocolVersion, "capabilities": map[string]interface{}{}}
+	case "notifications/initialized":
+		w.WriteHeader(http.StatusAccepted)
+		return
+	case "tools/call":
+		if !f.sessions[sessionID] {
+			http.Error(w, "session not found", http.StatusNotFound)
+			return
+		}
+		f.calls = append(f.calls, map[string]interface{}{"name": request.Params.Name, "arguments": request.Params.Arguments, "meta": request.Params.Meta})
+		if result = f.tool(w, request.Params.Name, request.Params.Arguments); result == nil {
+			return
+		}
+	case "tools/list":
+		// Two pages of one tool each
+		schema := map[string]interface{}{"type": "object"}
+		if request.Params.Cursor == "" {
+			result = map[string]interface{}{"tools": []map[string]interface{}{{"name": "list_clusters", "inputSchema": schema}}, "nextCursor": "page-2"}
+		} else {
+			result = map[string]interface{}{"tools": []map[string]interface{}{{"name": "get_cluster", "inputSchema": schema}}}
+		}
+	}
+
+	// Answer over an event stream, after a notification
+	response, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": request.ID, "result": result})
+	w.Header().Set("Content-Type", "text/event-stream")
+	fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+	fmt.Fprintf(w, "event: message\ndata: %s\n\n", response)
+}
+
+// textResult is the result of a tool call with the JSON of output and a
+// correlation ID.
+func textResult(output interface{}) map[string]interface{} {
+	data, _ := json.Marshal(output)
+	return map[string]interface{}{"content": []map[string]interface{}{
+		{"type": "text", "text": string(data)},
+		{"type": "text", "text": "Correlation ID: c0ffee"},
+	}}
+}
+
+func errorResult(text string) map[string]interface{} {
+	return map[string]interface{}{"isError": true, "content": []map[string]interface{}{{"type": "text", "text": text}}}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		opts     []Option
+		wantErr  bool
+	}{
+		{name: "http", endpoint: "http://localhost:8080"},
+		{name: "https with options", endpoint: "https://capi-mcp.example.com/", opts: []Option{WithNamespace("team-a"), WithRetries(0, 0)}},
+		{name: "no scheme", endpoint: "localhost:8080", wantErr: true},
+		{name: "other scheme", endpoint: "ftp://localhost", wantErr: true},
+		{name: "negative retries", endpoint: "http://localhost:8080", opts: []Option{WithRetries(-1, time.Second)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.endpoint, "secret", tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestClient_CallTool(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("session", func(t *testing.T) {
+		fake, server := newFakeServer(t, func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{} {
+			return textResult(map[string]interface{}{"tool": name})
+		})
+		c, err := New(server.URL, "secret")
+		require.NoError(t, err)
+
+		var out map[string]interface{}
+		require.NoError(t, c.CallTool(ctx, "list_environments", nil, &out))
+		assert.Equal(t, "list_environments", out["tool"])
+		require.NoError(t, c.CallTool(ctx, "list_environments", nil, nil))
+		assert.Equal(t, []string{"initialize", "notifications/initialized", "tools/call", "tools/call"}, fake.methods)
+
+		// A session the server forgot is replaced
+		fake.sessions = map[string]bool{}
+		require.NoError(t, c.CallTool(ctx, "list_environments", nil, nil))
+		assert.Len(t, fake.calls, 3)
+
+		require.NoError(t, c.Close(ctx))
+		assert.Empty(t, fake.sessions)
+	})
+
+	t.Run("tool error", func(t *testing.T) {
+		_, server := newFakeServer(t, func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{} {
+			return errorResult("NOT_FOUND: cluster 'prod-a' not found (correlation ID: c0ffee)")
+		})
+		c, err := New(server.URL, "secret")
+		require.NoError(t, err)
+
+		err = c.CallTool(ctx, "get_cluster", map[string]interface{}{"clusterName": "prod-a"}, nil)
+		var failure *Error
+		require.ErrorAs(t, err, &failure)
+		assert.Equal(t, &Error{Tool: "get_cluster", Code: "NOT_FOUND", Message: "cluster 'prod-a' not found", CorrelationID: "c0ffee"}, failure)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("localized tool error", func(t *testing.T) {
+		fake, server := newFakeServer(t, func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{} {
+			return errorResult("INVALID_INPUT: Der Clustername darf nicht leer sein (correlation ID: c0ffee)")
+		})
+		c, err := New(server.URL, "secret", WithLocale("de"))
+		require.NoError(t, err)
+
+		err = c.CallTool(ctx, "get_cluster", map[string]interface{}{"clusterName": ""}, nil)
+		assert.Equal(t, map[string]interface{}{"capi-mcp.io/locale": "de"}, fake.calls[0]["meta"])
+		var failure *Error
+		require.ErrorAs(t, err, &failure)
+		assert.Equal(t, &Error{Tool: "get_cluster", Code: "INVALID_INPUT", Message: "Der Clustername darf nicht leer sein", CorrelationID: "c0ffee"}, failure)
+	})
+
+	t.Run("rejected API key", func(t *testing.T) {
+		fake, server := newFakeServer(t, nil)
+		c, err := New(server.URL, "wrong")
+		require.NoError(t, err)
+
+		err = c.CallTool(ctx, "list_clusters", nil, nil)
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.Empty(t, fake.methods, "no retry")
+	})
+
+	t.Run("chunked result", func(t *testing.T) {
+		whole := `{"kubeconfig": "apiVersion: v1"}`
+		_, server := newFakeServer(t, func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{} {
+			if name == "get_output_chunk" {
+				chunk := int(arguments["chunk"].(float64))
+				return textResult(api.GetOutputChunkOutput{PayloadID: "p1", Chunk: chunk, TotalChunks: 2, Data: whole[chunk*16 : min(len(whole), (chunk+1)*16)]})
+			}
+			return textResult(api.ChunkedOutput{PayloadID: "p1", TotalChunks: 2, TotalBytes: len(whole)})
+		})
+		c, err := New(server.URL, "secret")
+		require.NoError(t, err)
+
+		output, err := c.GetClusterKubeconfig(ctx, api.GetClusterKubeconfigInput{ClusterName: "prod-a"})
+		require.NoError(t, err)
+		assert.Equal(t, "apiVersion: v1", output.Kubeconfig)
+	})
+}
+
+func TestClient_ListTools(t *testing.T) {
+	fake, server := newFakeServer(t, nil)
+	c, err := New(server.URL, "secret")
+	require.NoError(t, err)
+
+	tools, err := c.ListTools(context.Background())
+	require.NoError(t, err)
+	require.Len(t, tools, 2)
+	assert.Equal(t, "list_clusters", tools[0].Name)
+	assert.Equal(t, "get_cluster", tools[1].Name)
+	assert.JSONEq(t, `{"type": "object"}`, string(tools[0].InputSchema))
+	assert.Equal(t, []string{"initialize", "notifications/initialized", "tools/list", "tools/list"}, fake.methods)
+}
+
+func TestClient_Retries(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		tool      string
+		status    int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "overloaded", tool: "scale_cluster", status: http.StatusServiceUnavailable, wantCalls: 3},
+		{name: "rate limited", tool: "create_cluster", status: http.StatusTooManyRequests, wantCalls: 3},
+		{name: "gateway timeout of read-only tool", tool: "get_cluster", status: http.StatusGatewayTimeout, wantCalls: 3},
+		{name: "gateway timeout of mutating tool", tool: "delete_cluster", status: http.StatusGatewayTimeout, wantCalls: 1, wantErr: true},
+		{name: "internal error", tool: "get_cluster", status: http.StatusInternalServerError, wantCalls: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, server := newFakeServer(t, func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{} {
+				return textResult(map[string]interface{}{})
+			})
+			failures := 2
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(sessionHeader) != "" && failures > 0 {
+					failures--
+					fake.calls = append(fake.calls, nil)
+					w.WriteHeader(tt.status)
+					return
+				}
+				fake.ServeHTTP(w, r)
+			})
+			c, err := New(server.URL, "secret", WithRetries(2, time.Millisecond))
+			require.NoError(t, err)
+
+			err = c.CallTool(ctx, tt.tool, nil, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, fake.calls, tt.wantCalls)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+// apiVersion is the output schema version the typed methods request.
+const apiVersion = "v1"
+
+// ListClusters lists the clusters of the namespace. Pass the NextToken of a
+// previous result as SinceToken to get only the clusters changed since.
+func (c *Client) ListClusters(ctx context.Context, input api.ListClustersInput, opts ...CallOption) (*api.ListClustersOutput, error) {
+	arguments := map[string]interface{}{"apiVersion": apiVersion}
+	setIfNotEmpty(arguments, "status", input.Status)
+	setIfNotEmpty(arguments, "environment", input.Environment)
+	setIfNotEmpty(arguments, "sinceToken", input.SinceToken)
+
+	var output api.ListClustersOutput
+	if err := c.callClusterTool(ctx, "list_clusters", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetCluster returns the details of a cluster.
+func (c *Client) GetCluster(ctx context.Context, input api.GetClusterInput, opts ...CallOption) (*api.GetClusterOutput, error) {
+	arguments := map[string]interface{}{"clusterName": input.ClusterName, "apiVersion": apiVersion}
+
+	var output api.GetClusterOutput
+	if err := c.callClusterTool(ctx, "get_cluster", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// CreateCluster creates a cluster of a Kubernetes version from a template,
+// or with DryRun only checks that it could be created.
+func (c *Client) CreateCluster(ctx context.Context, input api.CreateClusterInput, opts ...CallOption) (*api.CreateClusterOutput, error) {
+	arguments := map[string]interface{}{
+		"clusterName":       input.ClusterName,
+		"templateName":      input.TemplateName,
+		"kubernetesVersion": input.KubernetesVersion,
+	}
+	if len(input.Variables) > 0 {
+		arguments["variables"] = input.Variables
+	}
+	setIfNotEmpty(arguments, "preset", input.Preset)
+	setIfNotEmpty(arguments, "templateVersion", input.TemplateVersion)
+	if input.DryRun {
+		arguments["dryRun"] = true
+	}
+
+	var output api.CreateClusterOutput
+	if err := c.callClusterTool(ctx, "create_cluster", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// DeleteCluster deletes a cluster. If deletions require approval, the first
+// call fails with an *Error carrying the ApprovalID to approve and pass
+// WithApprovalID.
+func (c *Client) DeleteCluster(ctx context.Context, input api.DeleteClusterInput, opts ...CallOption) (*api.DeleteClusterOutput, error) {
+	arguments := map[string]interface{}{"clusterName": input.ClusterName}
+
+	var output api.DeleteClusterOutput
+	if err := c.callClusterTool(ctx, "delete_cluster", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// ScaleCluster sets the number of nodes of a node pool.
+func (c *Client) ScaleCluster(ctx context.Context, input api.ScaleClusterInput, opts ...CallOption) (*api.ScaleClusterOutput, error) {
+	arguments := map[string]interface{}{
+		"clusterName":  input.ClusterName,
+		"nodePoolName": input.NodePoolName,
+		"replicas":     input.Replicas,
+	}
+
+	var output api.ScaleClusterOutput
+	if err := c.callClusterTool(ctx, "scale_cluster", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetClusterKubeconfig returns the kubeconfig of a cluster.
+func (c *Client) GetClusterKubeconfig(ctx context.Context, input api.GetClusterKubeconfigInput, opts ...CallOption) (*api.GetClusterKubeconfigOutput, error) {
+	arguments := map[string]interface{}{"clusterName": input.ClusterName}
+
+	var output api.GetClusterKubeconfigOutput
+	if err := c.callClusterTool(ctx, "get_cluster_kubeconfig", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetClusterNodes lists the nodes of a cluster.
+func (c *Client) GetClusterNodes(ctx context.Context, input api.GetClusterNodesInput, opts ...CallOption) (*api.GetClusterNodesOutput, error) {
+	arguments := map[string]interface{}{"clusterName": input.ClusterName, "apiVersion": apiVersion}
+
+	var output api.GetClusterNodesOutput
+	if err := c.callClusterTool(ctx, "get_cluster_nodes", arguments, &output, opts); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// ApproveOperation approves an operation another identity requested.
+func (c *Client) ApproveOperation(ctx context.Context, input api.ApproveOperationInput) (*api.ApproveOperationOutput, error) {
+	arguments := map[string]interface{}{"approvalId": input.ApprovalID}
+
+	var output api.ApproveOperationOutput
+	if err := c.CallTool(ctx, "approve_operation", arguments, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// callClusterTool calls a tool managing the clusters of the client's
+// namespace, unless the options choose another.
+func (c *Client) callClusterTool(ctx context.Context, name string, arguments map[string]interface{}, out interface{}, opts []CallOption) error {
+	if c.namespace != "" {
+		opts = append([]CallOption{InNamespace(c.namespace)}, opts...)
+	}
+	return c.CallTool(ctx, name, arguments, out, opts...)
+}
+
+func setIfNotEmpty(arguments map[string]interface{}, name, value string) {
+	if value != "" {
+		arguments[name] = value
+	}
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+	"github.com/capi-mcp/capi-mcp-server/pkg/tools"
+)
+
+func TestClient_ClusterMethods(t *testing.T) {
+	ctx := context.Background()
+	fake, server := newFakeServer(t, func(w http.ResponseWriter, name string, arguments map[string]interface{}) interface{} {
+		switch name {
+		case "list_clusters":
+			return textResult(api.ListClustersOutput{Clusters: []api.ClusterSummary{{Name: "prod-a", Namespace: "team-a"}}, NextToken: "t1"})
+		case "delete_cluster":
+			if arguments["approvalId"] == nil {
+				return errorResult("PRECONDITION_FAILED: delete_cluster of cluster 'prod-a' requires the approval of a second identity: have approval '1a2b3c4d' approved with approve_operation before 2026-03-02T10:00:00Z, then call delete_cluster again with approvalId '1a2b3c4d' (correlation ID: c0ffee)")
+			}
+			return textResult(api.DeleteClusterOutput{Status: "Deleting"})
+		case "scale_cluster":
+			return textResult(api.ScaleClusterOutput{Status: "Scaling", OldReplicas: 3, NewReplicas: 5})
+		}
+		return errorResult("unknown tool " + name)
+	})
+	c, err := New(server.URL, "secret", WithNamespace("team-a"))
+	require.NoError(t, err)
+	lastArguments := func() map[string]interface{} {
+		return fake.calls[len(fake.calls)-1]["arguments"].(map[string]interface{})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		output, err := c.ListClusters(ctx, api.ListClustersInput{Status: "Ready"})
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, "prod-a", output.Clusters[0].Name)
+		assert.Equal(t, "t1", output.NextToken)
+		assert.Equal(t, map[string]interface{}{"status": "Ready", "apiVersion": "v1", "namespace": "team-a"}, lastArguments())
+	})
+
+	t.Run("scale in another namespace", func(t *testing.T) {
+		output, err := c.ScaleCluster(ctx, api.ScaleClusterInput{ClusterName: "prod-a", NodePoolName: "workers", Replicas: 5}, InNamespace("team-b"))
+		require.NoError(t, err)
+		assert.Equal(t, 5, output.NewReplicas)
+		assert.Equal(t, map[string]interface{}{"clusterName": "prod-a", "nodePoolName": "workers", "replicas": float64(5), "namespace": "team-b"}, lastArguments())
+	})
+
+	t.Run("delete with approval", func(t *testing.T) {
+		_, err := c.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "prod-a"})
+		require.True(t, IsApprovalRequired(err))
+		var failure *Error
+		require.ErrorAs(t, err, &failure)
+
+		output, err := c.DeleteCluster(ctx, api.DeleteClusterInput{ClusterName: "prod-a"}, WithApprovalID(failure.ApprovalID))
+		require.NoError(t, err)
+		assert.Equal(t, "Deleting", output.Status)
+		assert.Equal(t, "1a2b3c4d", lastArguments()["approvalId"])
+	})
+}
+
+// TestClient_Server runs the cluster methods against the MCP handler of the
+// server, whose results the fake server above only imitates.
+func TestClient_Server(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	replicas := int32(3)
+	fakeClient := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "prod-a", Namespace: "default"}},
+		&clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "prod-a"}},
+			Spec:       clusterv1.MachineDeploymentSpec{ClusterName: "prod-a", Replicas: &replicas},
+		},
+	).Build()
+
+	logger := logging.NewLogger(slog.LevelError, "text")
+	clusterService := service.NewEnhancedClusterService(kube.NewClientFromClient(fakeClient, "default"), logger, provider.NewProviderManager())
+	mcpServer := mcp.NewServer("capi-mcp-server", "test", nil)
+	require.NoError(t, tools.NewEnhancedProvider(mcpServer, logger, clusterService).RegisterTools())
+	server := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return mcpServer }, nil))
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, "secret")
+	require.NoError(t, err)
+
+	t.Run("scale", func(t *testing.T) {
+		output, err := c.ScaleCluster(ctx, api.ScaleClusterInput{ClusterName: "prod-a", NodePoolName: "workers", Replicas: 5})
+		require.NoError(t, err)
+		assert.Equal(t, &api.ScaleClusterOutput{
+			Status:      "scaling",
+			Message:     "Scaling node pool 'workers' from 3 to 5 replicas",
+			OldReplicas: 3,
+			NewReplicas: 5,
+		}, output)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		output, err := c.ListClusters(ctx, api.ListClustersInput{})
+		require.NoError(t, err)
+		require.Len(t, output.Clusters, 1)
+		assert.Equal(t, "prod-a", output.Clusters[0].Name)
+	})
+
+	require.NoError(t, c.Close(ctx))
+}
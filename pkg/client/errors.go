@@ -0,0 +1,100 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	// errorCodePattern matches the error codes the server prefixes failures
+	// with, e.g. NOT_FOUND.
+	errorCodePattern = regexp.MustCompile(`^[A-Z][A-Z_]*$`)
+	// correlationIDPattern matches the correlation ID the server appends to
+	// failures.
+	correlationIDPattern = regexp.MustCompile(`\s*\(correlation ID: ([^)]+)\)$`)
+	// approvalIDPattern matches the ID of the approval an operation awaits.
+	approvalIDPattern = regexp.MustCompile(`with approvalId '([^']+)'`)
+	// suggestedFixPattern matches the corrected arguments the server appends
+	// to rejected calls.
+	suggestedFixPattern = regexp.MustCompile(`\nsuggestedFix: (\{.*\})$`)
+)
+
+// Error is a tool call the server failed.
+type Error struct {
+	// Tool is the name of the tool called.
+	Tool string
+	// Code is the error code, e.g. NOT_FOUND or PRECONDITION_FAILED. It is
+	// empty for failures of the MCP protocol, such as unknown tools.
+	Code string
+	// Message describes the failure.
+	Message string
+	// CorrelationID identifies the call in the server's logs and audit trail.
+	CorrelationID string
+	// ApprovalID is the ID of the approval an operation that requires one
+	// awaits. Once approved, call the tool again with WithApprovalID.
+	ApprovalID string
+	// SuggestedFix holds corrected values for the arguments the server
+	// rejected, in the structure of the tool arguments, e.g.
+	// {"clusterName": "my-cluster"}. Retry with them to self-correct.
+	SuggestedFix map[string]interface{}
+}
+
+func (e *Error) Error() string {
+	message := e.Tool + ": "
+	if e.Code != "" {
+		message += e.Code + ": "
+	}
+	message += e.Message
+	if e.CorrelationID != "" {
+		message += " (correlation ID: " + e.CorrelationID + ")"
+	}
+	return message
+}
+
+// newError parses the "CODE: message (correlation ID: id)" text of a failed
+// tool call, whose message may end with a line with the suggested fix.
+func newError(tool, text string) *Error {
+	failure := &Error{Tool: tool, Message: strings.TrimSpace(text)}
+	if code, message, ok := strings.Cut(failure.Message, ": "); ok && errorCodePattern.MatchString(code) {
+		failure.Code = code
+		failure.Message = message
+	}
+	if match := correlationIDPattern.FindStringSubmatch(failure.Message); match != nil {
+		failure.CorrelationID = match[1]
+		failure.Message = strings.TrimSuffix(failure.Message, match[0])
+	}
+	if match := suggestedFixPattern.FindStringSubmatch(failure.Message); match != nil {
+		if err := json.Unmarshal([]byte(match[1]), &failure.SuggestedFix); err == nil {
+			failure.Message = strings.TrimSuffix(failure.Message, match[0])
+		}
+	}
+	if match := approvalIDPattern.FindStringSubmatch(failure.Message); match != nil {
+		failure.ApprovalID = match[1]
+	}
+	return failure
+}
+
+// ErrorCode returns the error code of a failed tool call, or an empty string
+// if err is not one.
+func ErrorCode(err error) string {
+	var failure *Error
+	if errors.As(err, &failure) {
+		return failure.Code
+	}
+	return ""
+}
+
+// IsNotFound reports whether a call failed because the cluster or another
+// resource it names does not exist.
+func IsNotFound(err error) bool {
+	return ErrorCode(err) == "NOT_FOUND"
+}
+
+// IsApprovalRequired reports whether a call awaits the approval of a second
+// identity, whose ID the *Error carries in ApprovalID.
+func IsApprovalRequired(err error) bool {
+	var failure *Error
+	return errors.As(err, &failure) && failure.ApprovalID != ""
+}
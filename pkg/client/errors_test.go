@@ -0,0 +1,58 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want *Error
+	}{
+		{
+			name: "code and correlation ID",
+			text: "INVALID_INPUT: replicas must not be negative (correlation ID: c0ffee)",
+			want: &Error{Tool: "scale_cluster", Code: "INVALID_INPUT", Message: "replicas must not be negative", CorrelationID: "c0ffee"},
+		},
+		{
+			name: "approval required",
+			text: "PRECONDITION_FAILED: scale_cluster of cluster 'prod-a' requires the approval of a second identity: have approval '1a2b3c4d' approved with approve_operation before 2026-03-02T10:00:00Z, then call scale_cluster again with approvalId '1a2b3c4d' (correlation ID: c0ffee)",
+			want: &Error{Tool: "scale_cluster", Code: "PRECONDITION_FAILED",
+				Message:       "scale_cluster of cluster 'prod-a' requires the approval of a second identity: have approval '1a2b3c4d' approved with approve_operation before 2026-03-02T10:00:00Z, then call scale_cluster again with approvalId '1a2b3c4d'",
+				CorrelationID: "c0ffee", ApprovalID: "1a2b3c4d"},
+		},
+		{
+			name: "suggested fix",
+			text: "INVALID_INPUT: Multiple validation errors:\n1. INVALID_INPUT: replica count cannot exceed 100\n2. INVALID_INPUT: cluster name must be 63 characters or less\nsuggestedFix: {\"clusterName\":\"prod-a\",\"replicas\":100} (correlation ID: c0ffee)",
+			want: &Error{Tool: "scale_cluster", Code: "INVALID_INPUT",
+				Message:       "Multiple validation errors:\n1. INVALID_INPUT: replica count cannot exceed 100\n2. INVALID_INPUT: cluster name must be 63 characters or less",
+				CorrelationID: "c0ffee", SuggestedFix: map[string]interface{}{"clusterName": "prod-a", "replicas": float64(100)}},
+		},
+		{
+			name: "protocol error",
+			text: "unknown tool \"scale_clusters\"",
+			want: &Error{Tool: "scale_cluster", Message: "unknown tool \"scale_clusters\""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newError("scale_cluster", tt.text))
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	failure := &Error{Tool: "get_cluster", Code: "NOT_FOUND", Message: "cluster 'prod-a' not found", CorrelationID: "c0ffee"}
+	assert.Equal(t, "get_cluster: NOT_FOUND: cluster 'prod-a' not found (correlation ID: c0ffee)", failure.Error())
+
+	wrapped := fmt.Errorf("sync failed: %w", failure)
+	assert.Equal(t, "NOT_FOUND", ErrorCode(wrapped))
+	assert.True(t, IsNotFound(wrapped))
+	assert.False(t, IsApprovalRequired(wrapped))
+	assert.Empty(t, ErrorCode(errors.New("connection refused")))
+}
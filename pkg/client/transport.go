@@ -0,0 +1,363 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// protocolVersion is the MCP protocol version the client speaks.
+	protocolVersion = "2025-03-26"
+	// sessionHeader carries the ID of the MCP session.
+	sessionHeader = "Mcp-Session-Id"
+	// clientName identifies the client to the server.
+	clientName = "capi-mcp-go-client"
+)
+
+// ErrUnauthorized is returned when the server rejected the API key.
+var ErrUnauthorized = errors.New("the server rejected the API key")
+
+// errSessionExpired is returned when the server no longer knows the session,
+// e.g. after a restart.
+var errSessionExpired = errors.New("the MCP session expired")
+
+// session is an initialized MCP session.
+type session struct {
+	id string
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// statusError is an HTTP response the server or a gateway in front of it
+// answered with instead of a JSON-RPC message.
+type statusError struct {
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %d %s: %s", e.status, http.StatusText(e.status), e.body)
+}
+
+type toolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	IsError bool `json:"isError"`
+}
+
+// call calls a tool once, opening a session first if needed, and returns the
+// text of its result. A session the server no longer knows is replaced.
+func (c *Client) call(ctx context.Context, name string, arguments map[string]interface{}) (string, error) {
+	params := map[string]interface{}{"name": name, "arguments": arguments}
+	if c.locale != "" {
+		params["_meta"] = map[string]interface{}{"capi-mcp.io/locale": c.locale}
+	}
+	result, err := c.sessionRequest(ctx, "tools/call", params)
+	if err != nil {
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			return "", newError(name, rpcErr.Message)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+
+	var decoded toolResult
+	if err := json.Unmarshal(result, &decoded); err != nil {
+		return "", fmt.Errorf("%s: failed to decode result: %w", name, err)
+	}
+	return toolText(name, decoded)
+}
+
+// sessionRequest sends a JSON-RPC request, opening a session first if
+// needed, and returns its result. A session the server no longer knows is
+// replaced.
+func (c *Client) sessionRequest(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	for renewed := false; ; renewed = true {
+		current, err := c.currentSession(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := c.request(ctx, current, method, params)
+		if errors.Is(err, errSessionExpired) && !renewed {
+			c.dropSession(current)
+			continue
+		}
+		return result, err
+	}
+}
+
+// toolText returns the text of a tool result, or the error it reports. The
+// server appends the correlation ID of the call to each result as a separate
+// text; failures carry it in their message.
+func toolText(name string, result toolResult) (string, error) {
+	var texts []string
+	correlationID := ""
+	for _, content := range result.Content {
+		if content.Type != "text" {
+			continue
+		}
+		if id, ok := strings.CutPrefix(content.Text, "Correlation ID: "); ok {
+			correlationID = id
+			continue
+		}
+		texts = append(texts, content.Text)
+	}
+	if result.IsError {
+		failure := newError(name, strings.Join(texts, "\n"))
+		if failure.CorrelationID == "" {
+			failure.CorrelationID = correlationID
+		}
+		return "", failure
+	}
+	if len(texts) == 0 {
+		return "", fmt.Errorf("%s: result has no text content", name)
+	}
+	return texts[0], nil
+}
+
+// currentSession returns the client's session, initializing one if it has
+// none.
+func (c *Client) currentSession(ctx context.Context) (*session, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session != nil {
+		return c.session, nil
+	}
+
+	opened := &session{}
+	_, err := c.request(ctx, opened, "initialize", map[string]interface{}{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": clientName, "version": "v1"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize MCP session: %w", err)
+	}
+	if err := c.notify(ctx, opened, "notifications/initialized"); err != nil {
+		return nil, fmt.Errorf("failed to initialize MCP session: %w", err)
+	}
+	c.session = opened
+	return opened, nil
+}
+
+// dropSession forgets a session unless another call already replaced it.
+func (c *Client) dropSession(expired *session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.session == expired {
+		c.session = nil
+	}
+}
+
+// request sends a JSON-RPC request within a session and returns its result.
+// The session ID the server assigns in its response to initialize is
+// recorded in current.
+func (c *Client) request(ctx context.Context, current *session, method string, params interface{}) (json.RawMessage, error) {
+	id := c.nextID.Add(1)
+	resp, err := c.post(ctx, current, rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if current.id == "" {
+		current.id = resp.Header.Get(sessionHeader)
+	}
+
+	var response *rpcResponse
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		response, err = readEventStream(resp.Body, id)
+	} else {
+		response = &rpcResponse{}
+		err = json.NewDecoder(resp.Body).Decode(response)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response to %s: %w", method, err)
+	}
+	if response.Error != nil {
+		return nil, response.Error
+	}
+	return response.Result, nil
+}
+
+// notify sends a JSON-RPC notification within a session.
+func (c *Client) notify(ctx context.Context, current *session, method string) error {
+	resp, err := c.post(ctx, current, rpcRequest{JSONRPC: "2.0", Method: method})
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// terminate ends a session on the server.
+func (c *Client) terminate(ctx context.Context, current *session) error {
+	if current.id == "" {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req, current)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to end MCP session: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusMethodNotAllowed {
+		return fmt.Errorf("failed to end MCP session: %w", readStatusError(resp))
+	}
+	return nil
+}
+
+// post sends a JSON-RPC message and returns the response if the server
+// accepted it.
+func (c *Client) post(ctx context.Context, current *session, message rpcRequest) (*http.Response, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", message.Method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.setHeaders(req, current)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		resp.Body.Close()
+		return nil, ErrUnauthorized
+	case resp.StatusCode == http.StatusBadRequest && message.Method == "initialize":
+		// Without a valid API key the server has no MCP server for the
+		// request and refuses to open a session
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, readStatusError(resp))
+	case resp.StatusCode == http.StatusNotFound && current.id != "":
+		resp.Body.Close()
+		return nil, errSessionExpired
+	case resp.StatusCode >= http.StatusBadRequest:
+		defer resp.Body.Close()
+		return nil, readStatusError(resp)
+	}
+	return resp, nil
+}
+
+// setHeaders sets the headers of every request of a session. The server
+// refuses requests, including the DELETE ending a session, that do not
+// accept both JSON and event streams.
+func (c *Client) setHeaders(req *http.Request, current *session) {
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if current.id != "" {
+		req.Header.Set(sessionHeader, current.id)
+	}
+}
+
+// readEventStream reads server-sent events until the response to the
+// request with the given ID. Notifications and requests the server sends
+// before it are skipped.
+func readEventStream(body io.Reader, id int64) (*rpcResponse, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(value, " "))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		var response rpcResponse
+		err := json.Unmarshal([]byte(data.String()), &response)
+		data.Reset()
+		if err == nil && response.ID != nil && *response.ID == id {
+			return &response, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func readStatusError(resp *http.Response) *statusError {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return &statusError{status: resp.StatusCode, body: strings.TrimSpace(string(data))}
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+// retryable reports whether a failed call may be retried. Overloaded or
+// unavailable servers did not run the call, and neither did one that could
+// not be connected to. Gateway errors and broken connections leave open
+// whether the server ran it, so only calls of read-only tools are retried
+// then.
+func retryable(err error, readOnly bool) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		switch status.status {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return true
+		case http.StatusBadGateway, http.StatusGatewayTimeout:
+			return readOnly
+		}
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrUnauthorized) || errors.Is(err, errSessionExpired) {
+		return false
+	}
+	var failure *Error
+	if errors.As(err, &failure) {
+		return false
+	}
+	var netErr net.Error
+	return readOnly && (errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF))
+}
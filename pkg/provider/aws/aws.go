@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"sort"
+	"sync"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
 )
 
 // AWSProvider implements the Provider interface for Amazon Web Services.
@@ -16,6 +20,24 @@ import (
 type AWSProvider struct {
 	// region is the default AWS region for operations
 	region string
+
+	// catalog lists the available regions and instance types
+	catalog *Catalog
+
+	// images finds node images, if image lookups are enabled
+	images ImageSource
+
+	// keyPairs looks up SSH key pairs, if key pair checks are enabled
+	keyPairs KeyPairSource
+
+	// instanceTypes looks up instance type sizes, cached in sizes
+	instanceTypes InstanceTypeSource
+	sizesMu       sync.Mutex
+	sizes         map[string]*capiprovider.InstanceSize
+
+	// cloudResources lists the resources owned by clusters, if scans are
+	// enabled
+	cloudResources CloudResourceSource
 }
 
 // NewAWSProvider creates a new AWS provider instance.
@@ -25,10 +47,17 @@ func NewAWSProvider(region string) *AWSProvider {
 	}
 
 	return &AWSProvider{
-		region: region,
+		region:  region,
+		catalog: NewCatalog(nil, ""),
 	}
 }
 
+// SetCatalog replaces the built-in region and instance type lists with a
+// catalog refreshed from AWS.
+func (p *AWSProvider) SetCatalog(catalog *Catalog) {
+	p.catalog = catalog
+}
+
 // Name returns the provider name.
 func (p *AWSProvider) Name() string {
 	return "aws"
@@ -37,20 +66,24 @@ func (p *AWSProvider) Name() string {
 // ValidateClusterConfig validates AWS-specific cluster configuration.
 func (p *AWSProvider) ValidateClusterConfig(ctx context.Context, variables map[string]interface{}) error {
 	// Validate required AWS-specific variables
+	var regionStr string
 	if region, ok := variables["region"]; ok {
-		if regionStr, ok := region.(string); ok {
-			if !p.isValidAWSRegion(regionStr) {
-				return fmt.Errorf("invalid AWS region: %s", regionStr)
-			}
-		} else {
+		regionStr, ok = region.(string)
+		if !ok {
 			return fmt.Errorf("region must be a string")
 		}
+		if !p.isValidAWSRegion(regionStr) {
+			return fmt.Errorf("invalid AWS region: %s", regionStr)
+		}
 	}
 
 	// Validate instance type if provided
 	if instanceType, ok := variables["instanceType"]; ok {
 		if instanceTypeStr, ok := instanceType.(string); ok {
-			if !p.isValidInstanceType(instanceTypeStr) {
+			if !p.catalog.IsInstanceType(regionStr, instanceTypeStr) {
+				if regionStr != "" {
+					return fmt.Errorf("invalid AWS instance type: %s is not offered in %s", instanceTypeStr, regionStr)
+				}
 				return fmt.Errorf("invalid AWS instance type: %s", instanceTypeStr)
 			}
 		} else {
@@ -58,6 +91,13 @@ func (p *AWSProvider) ValidateClusterConfig(ctx context.Context, variables map[s
 		}
 	}
 
+	// Validate that the SSH key pair exists in the cluster's region
+	if sshKeyName, ok := variables["sshKeyName"].(string); ok {
+		if err := p.validateKeyPair(ctx, regionStr, sshKeyName); err != nil {
+			return err
+		}
+	}
+
 	// Validate node count
 	if nodeCount, ok := variables["nodeCount"]; ok {
 		switch v := nodeCount.(type) {
@@ -129,152 +169,184 @@ func (p *AWSProvider) ValidateInfrastructureReadiness(ctx context.Context, clust
 	return nil
 }
 
-// GetProviderSpecificStatus extracts AWS-specific status information.
-func (p *AWSProvider) GetProviderSpecificStatus(ctx context.Context, cluster *clusterv1.Cluster) (map[string]interface{}, error) {
-	status := make(map[string]interface{})
-
-	// Extract basic AWS information
-	if cluster.Spec.InfrastructureRef != nil {
-		status["infrastructureKind"] = cluster.Spec.InfrastructureRef.Kind
-		status["infrastructureName"] = cluster.Spec.InfrastructureRef.Name
+// GetProviderSpecificStatus extracts AWS-specific status information. The
+// network is ready once CAPA reports the VPC and its subnets ready on the
+// AWSCluster, and the VPC, subnets, API server load balancer and bastion
+// host it manages are reported as cloud resources.
+func (p *AWSProvider) GetProviderSpecificStatus(ctx context.Context, cluster *clusterv1.Cluster, infraCluster *unstructured.Unstructured) (*capiprovider.ProviderStatus, error) {
+	status := &capiprovider.ProviderStatus{
+		Provider:                 "aws",
+		Region:                   p.region,
+		InfrastructureReady:      cluster.Status.InfrastructureReady,
+		NetworkReady:             cluster.Status.InfrastructureReady,
+		ControlPlaneLoadBalancer: cluster.Spec.ControlPlaneEndpoint.Host,
 	}
 
 	// Extract region information from cluster variables or use default
-	if cluster.Spec.Topology != nil && cluster.Spec.Topology.Variables != nil {
+	if cluster.Spec.Topology != nil {
 		for _, variable := range cluster.Spec.Topology.Variables {
-			if variable.Name == "region" {
-				if variable.Value.Raw != nil {
-					var region string
-					if err := json.Unmarshal(variable.Value.Raw, &region); err == nil {
-						status["region"] = region
-					}
-				}
+			if variable.Name != "region" || variable.Value.Raw == nil {
+				continue
+			}
+			var region string
+			if err := json.Unmarshal(variable.Value.Raw, &region); err == nil && region != "" {
+				status.Region = region
 			}
 		}
 	}
 
-	// Use default region if not found in cluster
-	if _, hasRegion := status["region"]; !hasRegion {
-		status["region"] = p.region
+	for name := range cluster.Status.FailureDomains {
+		status.FailureDomains = append(status.FailureDomains, name)
 	}
+	sort.Strings(status.FailureDomains)
 
-	// Add provider-specific status
-	status["provider"] = "aws"
-	status["ready"] = cluster.Status.InfrastructureReady
+	if infraCluster == nil {
+		return status, nil
+	}
 
-	return status, nil
-}
+	if region, _, _ := unstructured.NestedString(infraCluster.Object, "spec", "region"); region != "" {
+		status.Region = region
+	}
+	if !status.NetworkReady {
+		status.NetworkReady = conditionTrue(infraCluster, "VpcReady") && conditionTrue(infraCluster, "SubnetsReady")
+	}
 
-// GetRegions returns a list of AWS regions.
-func (p *AWSProvider) GetRegions(ctx context.Context) ([]string, error) {
-	// In a real implementation, this would query the AWS API for available regions
-	// For now, return a static list of common AWS regions
-	return []string{
-		"us-east-1",      // N. Virginia
-		"us-east-2",      // Ohio
-		"us-west-1",      // N. California
-		"us-west-2",      // Oregon
-		"ca-central-1",   // Canada
-		"eu-west-1",      // Ireland
-		"eu-west-2",      // London
-		"eu-west-3",      // Paris
-		"eu-central-1",   // Frankfurt
-		"eu-north-1",     // Stockholm
-		"ap-northeast-1", // Tokyo
-		"ap-northeast-2", // Seoul
-		"ap-southeast-1", // Singapore
-		"ap-southeast-2", // Sydney
-		"ap-south-1",     // Mumbai
-		"sa-east-1",      // São Paulo
-	}, nil
-}
+	resource := func(resourceType, id string) {
+		if id != "" {
+			status.CloudResources = append(status.CloudResources, capiprovider.CloudResource{Type: resourceType, ID: id, Region: status.Region})
+		}
+	}
+	extras := map[string]interface{}{}
+
+	// CAPA records the VPC and subnets it creates, or the ones it was given,
+	// in the spec of the AWSCluster
+	vpcID, _, _ := unstructured.NestedString(infraCluster.Object, "spec", "network", "vpc", "id")
+	resource("vpc", vpcID)
+	if cidr, _, _ := unstructured.NestedString(infraCluster.Object, "spec", "network", "vpc", "cidrBlock"); cidr != "" {
+		extras["vpcCidrBlock"] = cidr
+	}
+	subnets, _, _ := unstructured.NestedSlice(infraCluster.Object, "spec", "network", "subnets")
+	for _, item := range subnets {
+		subnet, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Since CAPA v2.4 id may be a name and resourceID is the subnet ID
+		id, _ := subnet["resourceID"].(string)
+		if id == "" {
+			id, _ = subnet["id"].(string)
+		}
+		resource("subnet", id)
+	}
 
-// GetInstanceTypes returns AWS instance types for a given region.
-func (p *AWSProvider) GetInstanceTypes(ctx context.Context, region string) ([]string, error) {
-	// Validate region
-	if !p.isValidAWSRegion(region) {
-		return nil, fmt.Errorf("invalid AWS region: %s", region)
+	// The API server load balancer is in status.networkStatus from the
+	// v1beta2 API on, and in status.network before
+	elb, found, _ := unstructured.NestedMap(infraCluster.Object, "status", "networkStatus", "apiServerElb")
+	if !found {
+		elb, _, _ = unstructured.NestedMap(infraCluster.Object, "status", "network", "apiServerElb")
+	}
+	if name, _ := elb["name"].(string); name != "" {
+		resource("load-balancer", name)
+	}
+	if dnsName, _ := elb["dnsName"].(string); dnsName != "" {
+		status.ControlPlaneLoadBalancer = dnsName
 	}
 
-	// In a real implementation, this would query the AWS EC2 API for available instance types
-	// For now, return a static list of common instance types
-	return []string{
-		// General Purpose
-		"t3.micro", "t3.small", "t3.medium", "t3.large", "t3.xlarge", "t3.2xlarge",
-		"m5.large", "m5.xlarge", "m5.2xlarge", "m5.4xlarge", "m5.8xlarge", "m5.12xlarge",
-		"m6i.large", "m6i.xlarge", "m6i.2xlarge", "m6i.4xlarge", "m6i.8xlarge",
-
-		// Compute Optimized
-		"c5.large", "c5.xlarge", "c5.2xlarge", "c5.4xlarge", "c5.9xlarge", "c5.18xlarge",
-		"c6i.large", "c6i.xlarge", "c6i.2xlarge", "c6i.4xlarge", "c6i.8xlarge",
-
-		// Memory Optimized
-		"r5.large", "r5.xlarge", "r5.2xlarge", "r5.4xlarge", "r5.8xlarge", "r5.12xlarge",
-		"r6i.large", "r6i.xlarge", "r6i.2xlarge", "r6i.4xlarge", "r6i.8xlarge",
-	}, nil
-}
+	bastionID, _, _ := unstructured.NestedString(infraCluster.Object, "status", "bastion", "id")
+	resource("instance", bastionID)
+	bastionIP, _, _ := unstructured.NestedString(infraCluster.Object, "status", "bastion", "publicIp")
+	if bastionIP == "" {
+		bastionIP, _, _ = unstructured.NestedString(infraCluster.Object, "status", "bastion", "privateIp")
+	}
+	if bastionIP != "" {
+		extras["bastionIp"] = bastionIP
+	}
 
-// isValidAWSRegion checks if the provided region is a valid AWS region.
-func (p *AWSProvider) isValidAWSRegion(region string) bool {
-	// Simple validation - check if it matches AWS region pattern
-	// AWS regions follow the pattern: {region}-{direction}-{number}
-	// e.g., us-west-2, eu-central-1, ap-southeast-1
-	parts := strings.Split(region, "-")
-	if len(parts) != 3 { // Must be exactly 3 parts
-		return false
+	if len(extras) > 0 {
+		status.Extras = extras
 	}
+	return status, nil
+}
 
-	// Additional validation could be added here to check against
-	// an authoritative list of AWS regions
-	validPrefixes := []string{"us", "eu", "ap", "ca", "sa", "af", "me"}
-	for _, prefix := range validPrefixes {
-		if parts[0] == prefix {
-			return true
+// conditionTrue reports whether a condition of a Cluster API object is True.
+func conditionTrue(obj *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == conditionType {
+			return condition["status"] == "True"
 		}
 	}
-
 	return false
 }
 
-// isValidInstanceType checks if the provided instance type is valid.
-func (p *AWSProvider) isValidInstanceType(instanceType string) bool {
-	// Simple validation - check if it matches AWS instance type pattern
-	// AWS instance types follow the pattern: {family}{generation}.{size}
-	// e.g., m5.large, c5.xlarge, t3.micro
-	parts := strings.Split(instanceType, ".")
-	if len(parts) != 2 {
+// IsManagedControlPlane reports whether the control plane kind is an AWS-managed
+// control plane (EKS or ROSA).
+func (p *AWSProvider) IsManagedControlPlane(kind string) bool {
+	switch kind {
+	case "AWSManagedControlPlane", "ROSAControlPlane":
+		return true
+	default:
 		return false
 	}
+}
 
-	// Check if family part contains both letters and numbers
-	family := parts[0]
-	if len(family) < 2 {
-		return false
+// GetKubeconfigSecretNames returns the kubeconfig secret names for an EKS cluster.
+// CAPA publishes a user-facing kubeconfig that authenticates through the AWS CLI
+// alongside the short-lived token kubeconfig used by the CAPI controllers.
+func (p *AWSProvider) GetKubeconfigSecretNames(clusterName string) []string {
+	return []string{
+		clusterName + "-user-kubeconfig",
+		clusterName + "-kubeconfig",
 	}
+}
 
-	// Validate family format: letters followed by numbers (e.g., m5, c6i, t3)
-	hasLetter := false
-	hasNumber := false
-	for _, char := range family {
-		if char >= 'a' && char <= 'z' {
-			hasLetter = true
-		} else if char >= '0' && char <= '9' {
-			hasNumber = true
-		}
+// GetManagedControlPlaneStatus interprets the status of an AWSManagedControlPlane.
+func (p *AWSProvider) GetManagedControlPlaneStatus(ctx context.Context, controlPlane *unstructured.Unstructured) (bool, string, error) {
+	if controlPlane == nil {
+		return false, "", fmt.Errorf("control plane is nil")
 	}
-	if !hasLetter || !hasNumber {
-		return false
+
+	if failure, found, _ := unstructured.NestedString(controlPlane.Object, "status", "failureMessage"); found && failure != "" {
+		return false, failure, nil
 	}
 
-	// Basic validation - first part should be family+generation, second part should be size
-	validSizes := []string{"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge",
-		"3xlarge", "4xlarge", "8xlarge", "9xlarge", "12xlarge", "16xlarge", "18xlarge", "24xlarge"}
+	ready, _, err := unstructured.NestedBool(controlPlane.Object, "status", "ready")
+	if err != nil {
+		return false, "", fmt.Errorf("failed to read control plane readiness: %w", err)
+	}
+	if !ready {
+		return false, fmt.Sprintf("%s %s is not ready", controlPlane.GetKind(), controlPlane.GetName()), nil
+	}
 
-	for _, size := range validSizes {
-		if parts[1] == size {
-			return true
-		}
+	message := fmt.Sprintf("%s %s is ready", controlPlane.GetKind(), controlPlane.GetName())
+	if version, found, _ := unstructured.NestedString(controlPlane.Object, "status", "version"); found && version != "" {
+		message = fmt.Sprintf("%s (version %s)", message, version)
 	}
+	return true, message, nil
+}
 
-	return false
+// GetRegions returns a list of AWS regions.
+func (p *AWSProvider) GetRegions(ctx context.Context) ([]string, error) {
+	return p.catalog.Regions(), nil
+}
+
+// GetInstanceTypes returns AWS instance types for a given region.
+func (p *AWSProvider) GetInstanceTypes(ctx context.Context, region string) ([]string, error) {
+	// Validate region
+	if !p.isValidAWSRegion(region) {
+		return nil, fmt.Errorf("invalid AWS region: %s", region)
+	}
+
+	return p.catalog.InstanceTypes(region), nil
+}
+
+// isValidAWSRegion checks if the provided region is a valid AWS region.
+func (p *AWSProvider) isValidAWSRegion(region string) bool {
+	return p.catalog.IsRegion(region)
+}
+
+// isValidInstanceType checks if the provided instance type is offered in any region.
+func (p *AWSProvider) isValidInstanceType(instanceType string) bool {
+	return p.catalog.IsInstanceType("", instanceType)
 }
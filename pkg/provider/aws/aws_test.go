@@ -9,7 +9,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
 )
 
 func TestNewAWSProvider(t *testing.T) {
@@ -285,17 +288,25 @@ func TestAWSProvider_GetProviderSpecificStatus(t *testing.T) {
 			},
 			Status: clusterv1.ClusterStatus{
 				InfrastructureReady: true,
+				FailureDomains: clusterv1.FailureDomains{
+					"us-east-1b": clusterv1.FailureDomainSpec{ControlPlane: true},
+					"us-east-1a": clusterv1.FailureDomainSpec{ControlPlane: true},
+				},
 			},
 		}
+		cluster.Spec.ControlPlaneEndpoint.Host = "test-apiserver-1234.us-east-1.elb.amazonaws.com"
 
-		status, err := provider.GetProviderSpecificStatus(ctx, cluster)
+		status, err := provider.GetProviderSpecificStatus(ctx, cluster, nil)
 		require.NoError(t, err)
 
-		assert.Equal(t, "AWSCluster", status["infrastructureKind"])
-		assert.Equal(t, "test-aws-cluster", status["infrastructureName"])
-		assert.Equal(t, "us-east-1", status["region"])
-		assert.Equal(t, "aws", status["provider"])
-		assert.Equal(t, true, status["ready"])
+		assert.Equal(t, &capiprovider.ProviderStatus{
+			Provider:                 "aws",
+			Region:                   "us-east-1",
+			InfrastructureReady:      true,
+			NetworkReady:             true,
+			ControlPlaneLoadBalancer: "test-apiserver-1234.us-east-1.elb.amazonaws.com",
+			FailureDomains:           []string{"us-east-1a", "us-east-1b"},
+		}, status)
 	})
 
 	t.Run("cluster without region variable", func(t *testing.T) {
@@ -314,15 +325,146 @@ func TestAWSProvider_GetProviderSpecificStatus(t *testing.T) {
 			},
 		}
 
-		status, err := provider.GetProviderSpecificStatus(ctx, cluster)
+		status, err := provider.GetProviderSpecificStatus(ctx, cluster, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "us-west-2", status.Region) // Should use provider default
+		assert.Equal(t, "aws", status.Provider)
+		assert.False(t, status.InfrastructureReady)
+		assert.False(t, status.NetworkReady)
+		assert.Empty(t, status.CloudResources)
+	})
+
+	t.Run("infrastructure cluster", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		}
+		awsCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"region": "eu-west-1",
+				"network": map[string]interface{}{
+					"vpc": map[string]interface{}{"id": "vpc-0a1b2c3d", "cidrBlock": "10.0.0.0/16"},
+					"subnets": []interface{}{
+						map[string]interface{}{"id": "test-cluster-subnet-private-eu-west-1a", "resourceID": "subnet-0aaa1111"},
+						map[string]interface{}{"id": "subnet-0bbb2222"},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"networkStatus": map[string]interface{}{
+					"apiServerElb": map[string]interface{}{
+						"name":    "test-cluster-apiserver",
+						"dnsName": "test-cluster-apiserver-1234.eu-west-1.elb.amazonaws.com",
+					},
+				},
+				"bastion": map[string]interface{}{
+					"id":        "i-0ccc3333",
+					"privateIp": "10.0.0.10",
+					"publicIp":  "203.0.113.10",
+				},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "VpcReady", "status": "True"},
+					map[string]interface{}{"type": "SubnetsReady", "status": "True"},
+					map[string]interface{}{"type": "LoadBalancerReady", "status": "False"},
+				},
+			},
+		}}
+
+		status, err := provider.GetProviderSpecificStatus(ctx, cluster, awsCluster)
 		require.NoError(t, err)
 
-		assert.Equal(t, "AWSCluster", status["infrastructureKind"])
-		assert.Equal(t, "test-aws-cluster", status["infrastructureName"])
-		assert.Equal(t, "us-west-2", status["region"]) // Should use provider default
-		assert.Equal(t, "aws", status["provider"])
-		assert.Equal(t, false, status["ready"])
+		assert.Equal(t, "eu-west-1", status.Region)
+		assert.False(t, status.InfrastructureReady)
+		assert.True(t, status.NetworkReady)
+		assert.Equal(t, "test-cluster-apiserver-1234.eu-west-1.elb.amazonaws.com", status.ControlPlaneLoadBalancer)
+		assert.Equal(t, []capiprovider.CloudResource{
+			{Type: "vpc", ID: "vpc-0a1b2c3d", Region: "eu-west-1"},
+			{Type: "subnet", ID: "subnet-0aaa1111", Region: "eu-west-1"},
+			{Type: "subnet", ID: "subnet-0bbb2222", Region: "eu-west-1"},
+			{Type: "load-balancer", ID: "test-cluster-apiserver", Region: "eu-west-1"},
+			{Type: "instance", ID: "i-0ccc3333", Region: "eu-west-1"},
+		}, status.CloudResources)
+		assert.Equal(t, map[string]interface{}{"vpcCidrBlock": "10.0.0.0/16", "bastionIp": "203.0.113.10"}, status.Extras)
 	})
+
+	t.Run("v1beta1 infrastructure cluster", func(t *testing.T) {
+		cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"}}
+		cluster.Spec.ControlPlaneEndpoint.Host = "api.example.com"
+		awsCluster := &unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"network": map[string]interface{}{
+					"apiServerElb": map[string]interface{}{"dnsName": "test-cluster-apiserver-5678.us-west-2.elb.amazonaws.com"},
+				},
+			},
+		}}
+
+		status, err := provider.GetProviderSpecificStatus(ctx, cluster, awsCluster)
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-cluster-apiserver-5678.us-west-2.elb.amazonaws.com", status.ControlPlaneLoadBalancer)
+		assert.Empty(t, status.CloudResources)
+		assert.Nil(t, status.Extras)
+	})
+}
+
+func TestAWSProvider_ManagedControlPlane(t *testing.T) {
+	provider := NewAWSProvider("us-west-2")
+	ctx := context.Background()
+
+	var _ capiprovider.ManagedControlPlaneProvider = provider
+
+	t.Run("managed kinds", func(t *testing.T) {
+		assert.True(t, provider.IsManagedControlPlane("AWSManagedControlPlane"))
+		assert.True(t, provider.IsManagedControlPlane("ROSAControlPlane"))
+		assert.False(t, provider.IsManagedControlPlane("KubeadmControlPlane"))
+	})
+
+	t.Run("kubeconfig secret names", func(t *testing.T) {
+		names := provider.GetKubeconfigSecretNames("eks-cluster")
+		assert.Equal(t, []string{"eks-cluster-user-kubeconfig", "eks-cluster-kubeconfig"}, names)
+	})
+
+	newControlPlane := func(status map[string]interface{}) *unstructured.Unstructured {
+		cp := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+		cp.SetKind("AWSManagedControlPlane")
+		cp.SetName("eks-cluster-control-plane")
+		return cp
+	}
+
+	tests := []struct {
+		name        string
+		status      map[string]interface{}
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:        "ready",
+			status:      map[string]interface{}{"ready": true, "version": "v1.31.0"},
+			wantReady:   true,
+			wantMessage: "AWSManagedControlPlane eks-cluster-control-plane is ready (version v1.31.0)",
+		},
+		{
+			name:        "not ready",
+			status:      map[string]interface{}{"ready": false},
+			wantReady:   false,
+			wantMessage: "AWSManagedControlPlane eks-cluster-control-plane is not ready",
+		},
+		{
+			name:        "failed",
+			status:      map[string]interface{}{"ready": false, "failureMessage": "EKS cluster creation failed"},
+			wantReady:   false,
+			wantMessage: "EKS cluster creation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, message, err := provider.GetManagedControlPlaneStatus(ctx, newControlPlane(tt.status))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantReady, ready)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
 }
 
 func TestAWSProvider_GetRegions(t *testing.T) {
@@ -0,0 +1,363 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CatalogSource lists the regions and instance types AWS currently offers.
+type CatalogSource interface {
+	// DescribeRegions returns the regions enabled for the account.
+	DescribeRegions(ctx context.Context) ([]string, error)
+
+	// DescribeInstanceTypeOfferings returns the instance types offered in a region.
+	DescribeInstanceTypeOfferings(ctx context.Context, region string) ([]string, error)
+}
+
+// Catalog caches the AWS regions and instance types fetched from a
+// CatalogSource so that new regions and instance types are accepted without a
+// release. Until the first successful refresh, and for regions whose
+// offerings could not be fetched, it falls back to built-in lists and format
+// checks.
+type Catalog struct {
+	source    CatalogSource
+	cacheFile string
+
+	mu            sync.RWMutex
+	regions       map[string]bool
+	instanceTypes map[string]map[string]bool // by region
+	refreshedAt   time.Time
+}
+
+// catalogCache is the on-disk format of the catalog cache file.
+type catalogCache struct {
+	Regions       []string            `json:"regions"`
+	InstanceTypes map[string][]string `json:"instanceTypes"`
+	RefreshedAt   time.Time           `json:"refreshedAt"`
+}
+
+// NewCatalog creates a catalog backed by source. A nil source keeps the
+// catalog offline, serving only the built-in fallback. If cacheFile is set,
+// the last refreshed catalog is loaded from it and saved to it after each
+// refresh, so a restart without AWS access keeps serving it.
+func NewCatalog(source CatalogSource, cacheFile string) *Catalog {
+	c := &Catalog{source: source, cacheFile: cacheFile}
+	if cacheFile != "" {
+		// A missing or unreadable cache only means starting from the fallback
+		_ = c.loadCache()
+	}
+	return c
+}
+
+// Refresh fetches the current regions and instance type offerings. Regions
+// whose offerings cannot be fetched keep their previously cached offerings.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	if c.source == nil {
+		return nil
+	}
+
+	regionList, err := c.source.DescribeRegions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe regions: %w", err)
+	}
+	if len(regionList) == 0 {
+		return fmt.Errorf("no regions returned")
+	}
+
+	c.mu.RLock()
+	previous := c.instanceTypes
+	c.mu.RUnlock()
+
+	regions := make(map[string]bool, len(regionList))
+	instanceTypes := make(map[string]map[string]bool, len(regionList))
+	var failed []string
+	for _, region := range regionList {
+		regions[region] = true
+
+		offerings, err := c.source.DescribeInstanceTypeOfferings(ctx, region)
+		if err != nil || len(offerings) == 0 {
+			failed = append(failed, region)
+			if cached, ok := previous[region]; ok {
+				instanceTypes[region] = cached
+			}
+			continue
+		}
+		instanceTypes[region] = toSet(offerings)
+	}
+
+	c.mu.Lock()
+	c.regions = regions
+	c.instanceTypes = instanceTypes
+	c.refreshedAt = time.Now()
+	c.mu.Unlock()
+
+	if c.cacheFile != "" {
+		if err := c.saveCache(); err != nil {
+			return err
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to describe instance type offerings in %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Run refreshes the catalog every interval until ctx is cancelled, reporting
+// failed refreshes to onError.
+func (c *Catalog) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	if c.source == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshedAt returns when the catalog was last refreshed from AWS, or the
+// zero time if it is serving the built-in fallback.
+func (c *Catalog) RefreshedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshedAt
+}
+
+// Regions returns the available regions in sorted order.
+func (c *Catalog) Regions() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.regions == nil {
+		return append([]string(nil), fallbackRegions...)
+	}
+	return sortedKeys(c.regions)
+}
+
+// InstanceTypes returns the instance types offered in a region in sorted order.
+func (c *Catalog) InstanceTypes(region string) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if offerings, ok := c.instanceTypes[region]; ok {
+		return sortedKeys(offerings)
+	}
+	return append([]string(nil), fallbackInstanceTypes...)
+}
+
+// IsRegion reports whether region is an available AWS region.
+func (c *Catalog) IsRegion(region string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.regions == nil {
+		return plausibleRegion(region)
+	}
+	return c.regions[region]
+}
+
+// IsInstanceType reports whether instanceType is offered in region, or in any
+// region when region is empty.
+func (c *Catalog) IsInstanceType(region, instanceType string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if region == "" {
+		for _, offerings := range c.instanceTypes {
+			if offerings[instanceType] {
+				return true
+			}
+		}
+	} else if offerings, ok := c.instanceTypes[region]; ok {
+		return offerings[instanceType]
+	}
+	return plausibleInstanceType(instanceType)
+}
+
+func (c *Catalog) loadCache() error {
+	data, err := os.ReadFile(c.cacheFile)
+	if err != nil {
+		return err
+	}
+	var cache catalogCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return fmt.Errorf("failed to parse catalog cache: %w", err)
+	}
+	if len(cache.Regions) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.regions = toSet(cache.Regions)
+	c.instanceTypes = make(map[string]map[string]bool, len(cache.InstanceTypes))
+	for region, offerings := range cache.InstanceTypes {
+		c.instanceTypes[region] = toSet(offerings)
+	}
+	c.refreshedAt = cache.RefreshedAt
+	return nil
+}
+
+func (c *Catalog) saveCache() error {
+	c.mu.RLock()
+	cache := catalogCache{
+		Regions:       sortedKeys(c.regions),
+		InstanceTypes: make(map[string][]string, len(c.instanceTypes)),
+		RefreshedAt:   c.refreshedAt,
+	}
+	for region, offerings := range c.instanceTypes {
+		cache.InstanceTypes[region] = sortedKeys(offerings)
+	}
+	c.mu.RUnlock()
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to encode catalog cache: %w", err)
+	}
+	// Write to a temporary file first so a crash never leaves a partial cache
+	tmp := c.cacheFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write catalog cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.cacheFile); err != nil {
+		return fmt.Errorf("failed to write catalog cache: %w", err)
+	}
+	return nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fallbackRegions are served until the catalog is refreshed from AWS.
+var fallbackRegions = []string{
+	"us-east-1",      // N. Virginia
+	"us-east-2",      // Ohio
+	"us-west-1",      // N. California
+	"us-west-2",      // Oregon
+	"ca-central-1",   // Canada
+	"eu-west-1",      // Ireland
+	"eu-west-2",      // London
+	"eu-west-3",      // Paris
+	"eu-central-1",   // Frankfurt
+	"eu-north-1",     // Stockholm
+	"ap-northeast-1", // Tokyo
+	"ap-northeast-2", // Seoul
+	"ap-southeast-1", // Singapore
+	"ap-southeast-2", // Sydney
+	"ap-south-1",     // Mumbai
+	"sa-east-1",      // São Paulo
+}
+
+// fallbackInstanceTypes are served for regions whose offerings have not been
+// fetched from AWS.
+var fallbackInstanceTypes = []string{
+	// General Purpose
+	"t3.micro", "t3.small", "t3.medium", "t3.large", "t3.xlarge", "t3.2xlarge",
+	"m5.large", "m5.xlarge", "m5.2xlarge", "m5.4xlarge", "m5.8xlarge", "m5.12xlarge",
+	"m6i.large", "m6i.xlarge", "m6i.2xlarge", "m6i.4xlarge", "m6i.8xlarge",
+
+	// Compute Optimized
+	"c5.large", "c5.xlarge", "c5.2xlarge", "c5.4xlarge", "c5.9xlarge", "c5.18xlarge",
+	"c6i.large", "c6i.xlarge", "c6i.2xlarge", "c6i.4xlarge", "c6i.8xlarge",
+
+	// Memory Optimized
+	"r5.large", "r5.xlarge", "r5.2xlarge", "r5.4xlarge", "r5.8xlarge", "r5.12xlarge",
+	"r6i.large", "r6i.xlarge", "r6i.2xlarge", "r6i.4xlarge", "r6i.8xlarge",
+
+	// Accelerated Computing (GPU)
+	"g4dn.xlarge", "g4dn.2xlarge", "g4dn.4xlarge", "g4dn.8xlarge", "g4dn.12xlarge",
+	"g5.xlarge", "g5.2xlarge", "g5.4xlarge", "g5.8xlarge", "g5.12xlarge", "g5.48xlarge",
+	"p3.2xlarge", "p3.8xlarge", "p3.16xlarge",
+	"p4d.24xlarge", "p5.48xlarge",
+}
+
+// plausibleRegion checks the format of a region when the catalog has not been
+// refreshed. AWS regions follow the pattern {area}-{direction}-{number}, e.g.
+// us-west-2, eu-central-1, ap-southeast-1.
+func plausibleRegion(region string) bool {
+	parts := strings.Split(region, "-")
+	if len(parts) != 3 { // Must be exactly 3 parts
+		return false
+	}
+
+	validPrefixes := []string{"us", "eu", "ap", "ca", "sa", "af", "me", "il", "mx"}
+	for _, prefix := range validPrefixes {
+		if parts[0] == prefix {
+			return true
+		}
+	}
+
+	return false
+}
+
+// plausibleInstanceType checks the format of an instance type when the
+// catalog has no offerings for its region. AWS instance types follow the
+// pattern {family}{generation}.{size}, e.g. m5.large, c5.xlarge, t3.micro.
+func plausibleInstanceType(instanceType string) bool {
+	parts := strings.Split(instanceType, ".")
+	if len(parts) != 2 {
+		return false
+	}
+
+	// Check if family part contains both letters and numbers
+	family := parts[0]
+	if len(family) < 2 {
+		return false
+	}
+
+	// Validate family format: letters followed by numbers (e.g., m5, c6i, t3)
+	hasLetter := false
+	hasNumber := false
+	for _, char := range family {
+		if char >= 'a' && char <= 'z' {
+			hasLetter = true
+		} else if char >= '0' && char <= '9' {
+			hasNumber = true
+		}
+	}
+	if !hasLetter || !hasNumber {
+		return false
+	}
+
+	// Basic validation - first part should be family+generation, second part should be size
+	validSizes := []string{"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge",
+		"3xlarge", "4xlarge", "8xlarge", "9xlarge", "12xlarge", "16xlarge", "18xlarge", "24xlarge",
+		"32xlarge", "48xlarge", "metal"}
+
+	for _, size := range validSizes {
+		if parts[1] == size {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCatalogSource serves a fixed catalog, failing the regions in failing.
+type fakeCatalogSource struct {
+	regions       []string
+	instanceTypes map[string][]string
+	failing       map[string]bool
+	err           error
+}
+
+func (f *fakeCatalogSource) DescribeRegions(ctx context.Context) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.regions, nil
+}
+
+func (f *fakeCatalogSource) DescribeInstanceTypeOfferings(ctx context.Context, region string) ([]string, error) {
+	if f.failing[region] {
+		return nil, fmt.Errorf("throttled")
+	}
+	return f.instanceTypes[region], nil
+}
+
+func TestCatalog_Fallback(t *testing.T) {
+	catalog := NewCatalog(nil, "")
+	require.NoError(t, catalog.Refresh(context.Background()))
+
+	assert.True(t, catalog.RefreshedAt().IsZero())
+	assert.Contains(t, catalog.Regions(), "us-west-2")
+	assert.Contains(t, catalog.InstanceTypes("us-west-2"), "m5.large")
+	assert.True(t, catalog.IsRegion("ap-southeast-3"))
+	assert.False(t, catalog.IsRegion("xx-west-1"))
+	assert.True(t, catalog.IsInstanceType("us-west-2", "m5.large"))
+	assert.False(t, catalog.IsInstanceType("us-west-2", "m5"))
+}
+
+func TestCatalog_Refresh(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeCatalogSource{
+		regions: []string{"us-west-2", "eu-central-1"},
+		instanceTypes: map[string][]string{
+			"us-west-2":    {"m7i-flex.large", "t3.medium"},
+			"eu-central-1": {"t3.medium"},
+		},
+	}
+	catalog := NewCatalog(source, "")
+	require.NoError(t, catalog.Refresh(ctx))
+
+	assert.False(t, catalog.RefreshedAt().IsZero())
+	assert.Equal(t, []string{"eu-central-1", "us-west-2"}, catalog.Regions())
+	assert.True(t, catalog.IsRegion("eu-central-1"))
+	assert.False(t, catalog.IsRegion("us-east-1"), "regions not enabled for the account are rejected")
+
+	assert.True(t, catalog.IsInstanceType("us-west-2", "m7i-flex.large"), "new instance types are accepted")
+	assert.False(t, catalog.IsInstanceType("eu-central-1", "m7i-flex.large"), "offerings are per region")
+	assert.True(t, catalog.IsInstanceType("", "m7i-flex.large"))
+	assert.Equal(t, []string{"m7i-flex.large", "t3.medium"}, catalog.InstanceTypes("us-west-2"))
+
+	t.Run("failed region keeps cached offerings", func(t *testing.T) {
+		source.instanceTypes["us-west-2"] = []string{"t3.medium"}
+		source.failing = map[string]bool{"us-west-2": true}
+
+		err := catalog.Refresh(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "us-west-2")
+		assert.True(t, catalog.IsInstanceType("us-west-2", "m7i-flex.large"))
+	})
+
+	t.Run("failed refresh keeps catalog", func(t *testing.T) {
+		source.err = fmt.Errorf("unreachable")
+
+		require.Error(t, catalog.Refresh(ctx))
+		assert.Equal(t, []string{"eu-central-1", "us-west-2"}, catalog.Regions())
+	})
+}
+
+func TestCatalog_CacheFile(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "aws-catalog.json")
+	source := &fakeCatalogSource{
+		regions:       []string{"il-central-1"},
+		instanceTypes: map[string][]string{"il-central-1": {"c7g.large"}},
+	}
+	require.NoError(t, NewCatalog(source, cacheFile).Refresh(context.Background()))
+
+	// A restart without AWS access serves the cached catalog
+	offline := NewCatalog(&fakeCatalogSource{err: fmt.Errorf("unreachable")}, cacheFile)
+	assert.Equal(t, []string{"il-central-1"}, offline.Regions())
+	assert.True(t, offline.IsInstanceType("il-central-1", "c7g.large"))
+	assert.False(t, offline.RefreshedAt().IsZero())
+}
+
+func TestAWSProvider_ValidateClusterConfigWithCatalog(t *testing.T) {
+	provider := NewAWSProvider("us-west-2")
+	catalog := NewCatalog(&fakeCatalogSource{
+		regions:       []string{"us-west-2", "eu-central-1"},
+		instanceTypes: map[string][]string{"us-west-2": {"m7i-flex.large"}, "eu-central-1": {"t3.medium"}},
+	}, "")
+	require.NoError(t, catalog.Refresh(context.Background()))
+	provider.SetCatalog(catalog)
+
+	ctx := context.Background()
+	assert.NoError(t, provider.ValidateClusterConfig(ctx, map[string]interface{}{
+		"region": "us-west-2", "instanceType": "m7i-flex.large",
+	}))
+
+	err := provider.ValidateClusterConfig(ctx, map[string]interface{}{
+		"region": "eu-central-1", "instanceType": "m7i-flex.large",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not offered in eu-central-1")
+
+	err = provider.ValidateClusterConfig(ctx, map[string]interface{}{"region": "us-east-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid AWS region")
+}
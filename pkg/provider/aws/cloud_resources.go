@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+// ClusterTagPrefix prefixes the tag CAPA puts on the resources it creates for
+// a cluster, followed by the cluster name. Resources a cluster owns, and
+// deletes with it, have the value "owned"; those it only uses have "shared".
+const ClusterTagPrefix = "sigs.k8s.io/cluster-api-provider-aws/cluster/"
+
+// CloudResourceSource lists the AWS resources owned by clusters.
+type CloudResourceSource interface {
+	// ListOwnedResources returns the resources in a region tagged as owned
+	// by a cluster.
+	ListOwnedResources(ctx context.Context, region string) ([]capiprovider.CloudResource, error)
+}
+
+// SetCloudResourceSource enables scanning for the resources owned by clusters.
+func (p *AWSProvider) SetCloudResourceSource(source CloudResourceSource) {
+	p.cloudResources = source
+}
+
+// ScanClusterResources returns the VPCs, security groups, instances and load
+// balancers in a region owned by any cluster.
+func (p *AWSProvider) ScanClusterResources(ctx context.Context, region string) ([]capiprovider.CloudResource, error) {
+	if p.cloudResources == nil {
+		return nil, fmt.Errorf("cloud resource scans are not enabled")
+	}
+	if region == "" {
+		region = p.region
+	}
+	return p.cloudResources.ListOwnedResources(ctx, region)
+}
+
+// ownerCluster returns the name of the cluster owning a resource with the
+// given tag, or "" if the tag does not mark a resource owned by a cluster.
+func ownerCluster(key, value string) string {
+	if value != "owned" || !strings.HasPrefix(key, ClusterTagPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(key, ClusterTagPrefix)
+}
+
+// ec2OwnerCluster returns the name of the cluster owning an EC2 resource.
+func ec2OwnerCluster(tags []types.Tag) string {
+	for _, tag := range tags {
+		if cluster := ownerCluster(aws.ToString(tag.Key), aws.ToString(tag.Value)); cluster != "" {
+			return cluster
+		}
+	}
+	return ""
+}
+
+// ec2Name returns the Name tag of an EC2 resource.
+func ec2Name(tags []types.Tag) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == "Name" {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
+
+// ec2CloudResourceSource lists cluster resources with the EC2 and ELBv2 APIs.
+type ec2CloudResourceSource struct {
+	ec2 *ec2.Client
+	elb *elasticloadbalancingv2.Client
+}
+
+// NewEC2CloudResourceSource creates a cloud resource source using the default
+// AWS credential chain.
+func NewEC2CloudResourceSource(ctx context.Context, region string) (CloudResourceSource, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return &ec2CloudResourceSource{
+		ec2: ec2.NewFromConfig(cfg),
+		elb: elasticloadbalancingv2.NewFromConfig(cfg),
+	}, nil
+}
+
+// ListOwnedResources returns the VPCs, security groups, instances and load
+// balancers in a region owned by a cluster. Terminated instances, which EC2
+// keeps listing for a while, are left out.
+func (s *ec2CloudResourceSource) ListOwnedResources(ctx context.Context, region string) ([]capiprovider.CloudResource, error) {
+	inRegion := func(o *ec2.Options) { o.Region = region }
+	// EC2 filters accept wildcards, so one filter matches the tags of all clusters
+	clusterTagged := []types.Filter{
+		{Name: aws.String("tag-key"), Values: []string{ClusterTagPrefix + "*"}},
+	}
+
+	var resources []capiprovider.CloudResource
+	add := func(resource capiprovider.CloudResource, tags []types.Tag) {
+		if resource.ClusterName = ec2OwnerCluster(tags); resource.ClusterName != "" {
+			resource.Region = region
+			resources = append(resources, resource)
+		}
+	}
+
+	vpcs := ec2.NewDescribeVpcsPaginator(s.ec2, &ec2.DescribeVpcsInput{Filters: clusterTagged})
+	for vpcs.HasMorePages() {
+		page, err := vpcs.NextPage(ctx, inRegion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe VPCs: %w", err)
+		}
+		for _, vpc := range page.Vpcs {
+			add(capiprovider.CloudResource{
+				Type:  "vpc",
+				ID:    aws.ToString(vpc.VpcId),
+				Name:  ec2Name(vpc.Tags),
+				State: string(vpc.State),
+			}, vpc.Tags)
+		}
+	}
+
+	securityGroups := ec2.NewDescribeSecurityGroupsPaginator(s.ec2, &ec2.DescribeSecurityGroupsInput{Filters: clusterTagged})
+	for securityGroups.HasMorePages() {
+		page, err := securityGroups.NextPage(ctx, inRegion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe security groups: %w", err)
+		}
+		for _, group := range page.SecurityGroups {
+			add(capiprovider.CloudResource{
+				Type: "security-group",
+				ID:   aws.ToString(group.GroupId),
+				Name: aws.ToString(group.GroupName),
+			}, group.Tags)
+		}
+	}
+
+	instances := ec2.NewDescribeInstancesPaginator(s.ec2, &ec2.DescribeInstancesInput{Filters: clusterTagged})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(ctx, inRegion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe instances: %w", err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				resource := capiprovider.CloudResource{
+					Type: "instance",
+					ID:   aws.ToString(instance.InstanceId),
+					Name: ec2Name(instance.Tags),
+				}
+				if instance.State != nil {
+					if instance.State.Name == types.InstanceStateNameTerminated {
+						continue
+					}
+					resource.State = string(instance.State.Name)
+				}
+				if instance.LaunchTime != nil {
+					resource.CreatedAt = *instance.LaunchTime
+				}
+				add(resource, instance.Tags)
+			}
+		}
+	}
+
+	loadBalancers, err := s.listOwnedLoadBalancers(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	return append(resources, loadBalancers...), nil
+}
+
+// elbTagsBatchSize is the most load balancers DescribeTags accepts at once.
+const elbTagsBatchSize = 20
+
+// listOwnedLoadBalancers returns the load balancers in a region owned by a
+// cluster. ELBv2 cannot filter by tag, so the tags of every load balancer
+// are read, in batches.
+func (s *ec2CloudResourceSource) listOwnedLoadBalancers(ctx context.Context, region string) ([]capiprovider.CloudResource, error) {
+	inRegion := func(o *elasticloadbalancingv2.Options) { o.Region = region }
+
+	byARN := make(map[string]elbv2types.LoadBalancer)
+	var arns []string
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(s.elb, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, inRegion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancers: %w", err)
+		}
+		for _, lb := range page.LoadBalancers {
+			arn := aws.ToString(lb.LoadBalancerArn)
+			byARN[arn] = lb
+			arns = append(arns, arn)
+		}
+	}
+
+	var resources []capiprovider.CloudResource
+	for start := 0; start < len(arns); start += elbTagsBatchSize {
+		end := min(start+elbTagsBatchSize, len(arns))
+		output, err := s.elb.DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: arns[start:end]}, inRegion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancer tags: %w", err)
+		}
+		for _, description := range output.TagDescriptions {
+			var cluster string
+			for _, tag := range description.Tags {
+				if cluster = ownerCluster(aws.ToString(tag.Key), aws.ToString(tag.Value)); cluster != "" {
+					break
+				}
+			}
+			if cluster == "" {
+				continue
+			}
+
+			lb := byARN[aws.ToString(description.ResourceArn)]
+			resource := capiprovider.CloudResource{
+				Type:        "load-balancer",
+				ID:          aws.ToString(lb.LoadBalancerArn),
+				Name:        aws.ToString(lb.LoadBalancerName),
+				Region:      region,
+				ClusterName: cluster,
+			}
+			if lb.State != nil {
+				resource.State = string(lb.State.Code)
+			}
+			if lb.CreatedTime != nil {
+				resource.CreatedAt = *lb.CreatedTime
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+type fakeCloudResourceSource struct {
+	regions []string
+}
+
+func (f *fakeCloudResourceSource) ListOwnedResources(ctx context.Context, region string) ([]capiprovider.CloudResource, error) {
+	f.regions = append(f.regions, region)
+	return []capiprovider.CloudResource{{Type: "vpc", ID: "vpc-0123", Region: region, ClusterName: "gone"}}, nil
+}
+
+func TestAWSProvider_ScanClusterResources(t *testing.T) {
+	ctx := context.Background()
+	provider := NewAWSProvider("us-west-2")
+
+	_, err := provider.ScanClusterResources(ctx, "")
+	assert.Error(t, err, "scans are not enabled without a source")
+
+	source := &fakeCloudResourceSource{}
+	provider.SetCloudResourceSource(source)
+
+	resources, err := provider.ScanClusterResources(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	_, err = provider.ScanClusterResources(ctx, "eu-west-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"us-west-2", "eu-west-1"}, source.regions)
+}
+
+func TestOwnerCluster(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string
+	}{
+		{key: ClusterTagPrefix + "prod", value: "owned", want: "prod"},
+		{key: ClusterTagPrefix + "prod", value: "shared"},
+		{key: "kubernetes.io/cluster/prod", value: "owned"},
+		{key: "Name", value: "prod-vpc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.want, ownerCluster(tt.key, tt.value))
+		})
+	}
+}
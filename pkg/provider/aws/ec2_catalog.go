@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ec2CatalogSource fetches the catalog from the EC2 API.
+type ec2CatalogSource struct {
+	client *ec2.Client
+}
+
+// NewEC2CatalogSource creates a catalog source using the default AWS
+// credential chain, querying region for the list of enabled regions.
+func NewEC2CatalogSource(ctx context.Context, region string) (CatalogSource, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return &ec2CatalogSource{client: ec2.NewFromConfig(cfg)}, nil
+}
+
+// DescribeRegions returns the regions enabled for the account.
+func (s *ec2CatalogSource) DescribeRegions(ctx context.Context) ([]string, error) {
+	output, err := s.client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		if name := aws.ToString(region.RegionName); name != "" {
+			regions = append(regions, name)
+		}
+	}
+	return regions, nil
+}
+
+// DescribeInstanceTypeOfferings returns the instance types offered in a region.
+func (s *ec2CatalogSource) DescribeInstanceTypeOfferings(ctx context.Context, region string) ([]string, error) {
+	paginator := ec2.NewDescribeInstanceTypeOfferingsPaginator(s.client, &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: types.LocationTypeRegion,
+	})
+	inRegion := func(o *ec2.Options) { o.Region = region }
+
+	var instanceTypes []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, inRegion)
+		if err != nil {
+			return nil, err
+		}
+		for _, offering := range page.InstanceTypeOfferings {
+			instanceTypes = append(instanceTypes, string(offering.InstanceType))
+		}
+	}
+	return instanceTypes, nil
+}
@@ -0,0 +1,178 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+const (
+	// ImageLookupCAPA finds the AMIs CAPA publishes with image-builder, named
+	// capa-ami-<os>-v<kubernetes version>-<build>.
+	ImageLookupCAPA = "capa"
+
+	// ImageLookupEKS finds the EKS optimized AMIs for EKS managed node groups
+	// and self-managed EKS nodes, named after their Kubernetes minor version.
+	ImageLookupEKS = "eks"
+
+	// capaAMIOwner is the AWS account CAPA publishes its AMIs from.
+	capaAMIOwner = "258751437250"
+
+	// eksAMIOwner is the AWS account EKS optimized AMIs are published from.
+	eksAMIOwner = "602401143452"
+)
+
+// defaultImageOS is the base OS used by each lookup when none is given.
+var defaultImageOS = map[string]string{
+	ImageLookupCAPA: "ubuntu-22.04",
+	ImageLookupEKS:  "amazon-linux-2023",
+}
+
+// eksImageNames are the name patterns of EKS optimized AMIs by base OS, with
+// %s standing for the Kubernetes minor version.
+var eksImageNames = map[string]string{
+	"amazon-linux-2":    "amazon-eks-node-%s-v*",
+	"amazon-linux-2023": "amazon-eks-node-al2023-x86_64-standard-%s-v*",
+}
+
+// Image is a machine image found by an ImageSource.
+type Image struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+}
+
+// ImageSource finds machine images by owner and name.
+type ImageSource interface {
+	// DescribeImages returns the available images in a region owned by owner
+	// whose names match pattern, in which * and ? are wildcards.
+	DescribeImages(ctx context.Context, region, owner, pattern string) ([]Image, error)
+}
+
+// SetImageSource enables node image lookups through source.
+func (p *AWSProvider) SetImageSource(source ImageSource) {
+	p.images = source
+}
+
+// ResolveNodeImage returns the newest AMI for a Kubernetes version in a
+// region, found by the CAPA image-builder naming convention or, with the eks
+// lookup, among the EKS optimized AMIs.
+func (p *AWSProvider) ResolveNodeImage(ctx context.Context, query capiprovider.NodeImageQuery) (*capiprovider.NodeImage, error) {
+	if p.images == nil {
+		return nil, fmt.Errorf("AWS image lookup is not configured")
+	}
+
+	kubernetesVersion, err := version.ParseSemantic(query.KubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes version must be a full version such as v1.30.2, got %q", query.KubernetesVersion)
+	}
+	fullVersion := fmt.Sprintf("%d.%d.%d", kubernetesVersion.Major(), kubernetesVersion.Minor(), kubernetesVersion.Patch())
+	minorVersion := fmt.Sprintf("%d.%d", kubernetesVersion.Major(), kubernetesVersion.Minor())
+	region := query.Region
+	if region == "" {
+		region = p.region
+	}
+	if !p.catalog.IsRegion(region) {
+		return nil, fmt.Errorf("invalid AWS region: %s", region)
+	}
+	lookup := query.Lookup
+	if lookup == "" {
+		lookup = ImageLookupCAPA
+	}
+	baseOS := query.OS
+	if baseOS == "" {
+		baseOS = defaultImageOS[lookup]
+	}
+
+	var owner, pattern string
+	switch lookup {
+	case ImageLookupCAPA:
+		// The ? matches the v CAPA has not always put before the version
+		owner, pattern = capaAMIOwner, fmt.Sprintf("capa-ami-%s-?%s-*", baseOS, fullVersion)
+	case ImageLookupEKS:
+		format, ok := eksImageNames[baseOS]
+		if !ok {
+			return nil, fmt.Errorf("EKS optimized AMIs are available for amazon-linux-2 and amazon-linux-2023, not %q", baseOS)
+		}
+		owner, pattern = eksAMIOwner, fmt.Sprintf(format, minorVersion)
+	default:
+		return nil, fmt.Errorf("unknown image lookup %q, must be %s or %s", lookup, ImageLookupCAPA, ImageLookupEKS)
+	}
+
+	images, err := p.images.DescribeImages(ctx, region, owner, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe images: %w", err)
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("%w: no %s image for kubernetes %s and %s in %s", capiprovider.ErrNodeImageNotFound, lookup, "v"+fullVersion, baseOS, region)
+	}
+
+	newest := images[0]
+	for _, image := range images[1:] {
+		if image.CreatedAt.After(newest.CreatedAt) {
+			newest = image
+		}
+	}
+	return &capiprovider.NodeImage{
+		ID:                newest.ID,
+		Name:              newest.Name,
+		Region:            region,
+		OS:                baseOS,
+		KubernetesVersion: "v" + fullVersion,
+		Lookup:            lookup,
+		CreatedAt:         newest.CreatedAt,
+	}, nil
+}
+
+// ec2ImageSource finds images with the EC2 API.
+type ec2ImageSource struct {
+	client *ec2.Client
+}
+
+// NewEC2ImageSource creates an image source using the default AWS credential
+// chain.
+func NewEC2ImageSource(ctx context.Context, region string) (ImageSource, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return &ec2ImageSource{client: ec2.NewFromConfig(cfg)}, nil
+}
+
+// DescribeImages returns the available images matching owner and pattern.
+func (s *ec2ImageSource) DescribeImages(ctx context.Context, region, owner, pattern string) ([]Image, error) {
+	paginator := ec2.NewDescribeImagesPaginator(s.client, &ec2.DescribeImagesInput{
+		Owners: []string{owner},
+		Filters: []types.Filter{
+			{Name: aws.String("name"), Values: []string{pattern}},
+			{Name: aws.String("state"), Values: []string{string(types.ImageStateAvailable)}},
+			{Name: aws.String("architecture"), Values: []string{string(types.ArchitectureValuesX8664)}},
+		},
+	})
+	inRegion := func(o *ec2.Options) { o.Region = region }
+
+	var images []Image
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx, inRegion)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range page.Images {
+			createdAt, _ := time.Parse(time.RFC3339, aws.ToString(image.CreationDate))
+			images = append(images, Image{
+				ID:        aws.ToString(image.ImageId),
+				Name:      aws.ToString(image.Name),
+				CreatedAt: createdAt,
+			})
+		}
+	}
+	return images, nil
+}
@@ -0,0 +1,134 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+type fakeImageSource struct {
+	images []Image
+	err    error
+
+	region, owner, pattern string
+}
+
+func (f *fakeImageSource) DescribeImages(ctx context.Context, region, owner, pattern string) ([]Image, error) {
+	f.region, f.owner, f.pattern = region, owner, pattern
+	return f.images, f.err
+}
+
+func TestAWSProvider_ResolveNodeImage(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	images := []Image{
+		{ID: "ami-old", Name: "capa-ami-ubuntu-22.04-v1.30.2-1700000000", CreatedAt: created},
+		{ID: "ami-new", Name: "capa-ami-ubuntu-22.04-v1.30.2-1740000000", CreatedAt: created.Add(24 * time.Hour)},
+	}
+
+	var _ capiprovider.NodeImageResolver = NewAWSProvider("us-west-2")
+
+	tests := []struct {
+		name        string
+		query       capiprovider.NodeImageQuery
+		owner       string
+		pattern     string
+		region      string
+		wantImage   string
+		expectError bool
+	}{
+		{
+			name:      "image-builder naming by default",
+			query:     capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2"},
+			owner:     capaAMIOwner,
+			pattern:   "capa-ami-ubuntu-22.04-?1.30.2-*",
+			region:    "us-west-2",
+			wantImage: "ami-new",
+		},
+		{
+			name:      "image-builder naming with OS and region",
+			query:     capiprovider.NodeImageQuery{KubernetesVersion: "1.31.0", Region: "eu-west-1", OS: "flatcar-stable"},
+			owner:     capaAMIOwner,
+			pattern:   "capa-ami-flatcar-stable-?1.31.0-*",
+			region:    "eu-west-1",
+			wantImage: "ami-new",
+		},
+		{
+			name:      "EKS optimized",
+			query:     capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2", Lookup: ImageLookupEKS},
+			owner:     eksAMIOwner,
+			pattern:   "amazon-eks-node-al2023-x86_64-standard-1.30-v*",
+			region:    "us-west-2",
+			wantImage: "ami-new",
+		},
+		{
+			name:        "EKS optimized for unsupported OS",
+			query:       capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2", Lookup: ImageLookupEKS, OS: "ubuntu-22.04"},
+			expectError: true,
+		},
+		{
+			name:        "unknown lookup",
+			query:       capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2", Lookup: "ssm"},
+			expectError: true,
+		},
+		{
+			name:        "partial version",
+			query:       capiprovider.NodeImageQuery{KubernetesVersion: "v1.30"},
+			expectError: true,
+		},
+		{
+			name:        "invalid region",
+			query:       capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2", Region: "mars-1"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &fakeImageSource{images: images}
+			provider := NewAWSProvider("us-west-2")
+			provider.SetImageSource(source)
+
+			image, err := provider.ResolveNodeImage(ctx, tt.query)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantImage, image.ID)
+			assert.Equal(t, tt.owner, source.owner)
+			assert.Equal(t, tt.pattern, source.pattern)
+			assert.Equal(t, tt.region, source.region)
+			assert.Equal(t, tt.region, image.Region)
+		})
+	}
+
+	t.Run("no matching image", func(t *testing.T) {
+		provider := NewAWSProvider("us-west-2")
+		provider.SetImageSource(&fakeImageSource{})
+
+		_, err := provider.ResolveNodeImage(ctx, capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2"})
+		assert.ErrorIs(t, err, capiprovider.ErrNodeImageNotFound)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		provider := NewAWSProvider("us-west-2")
+		provider.SetImageSource(&fakeImageSource{err: errors.New("UnauthorizedOperation")})
+
+		_, err := provider.ResolveNodeImage(ctx, capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2"})
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, capiprovider.ErrNodeImageNotFound)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := NewAWSProvider("us-west-2").ResolveNodeImage(ctx, capiprovider.NodeImageQuery{KubernetesVersion: "v1.30.2"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+// InstanceTypeSource looks up the capacity of EC2 instance types.
+type InstanceTypeSource interface {
+	// DescribeInstanceType returns the vCPUs and memory of an instance type
+	// offered in a region.
+	DescribeInstanceType(ctx context.Context, region, instanceType string) (*capiprovider.InstanceSize, error)
+}
+
+// SetInstanceTypeSource enables looking up the capacity of instance types.
+func (p *AWSProvider) SetInstanceTypeSource(source InstanceTypeSource) {
+	p.instanceTypes = source
+}
+
+// InstanceTypeSize returns the vCPUs and memory of an instance type. Sizes are
+// cached, as an instance type has the same capacity in every region.
+func (p *AWSProvider) InstanceTypeSize(ctx context.Context, region, instanceType string) (*capiprovider.InstanceSize, error) {
+	if p.instanceTypes == nil {
+		return nil, fmt.Errorf("instance type lookups are not enabled")
+	}
+	if region == "" {
+		region = p.region
+	}
+
+	p.sizesMu.Lock()
+	size, ok := p.sizes[instanceType]
+	p.sizesMu.Unlock()
+	if ok {
+		return size, nil
+	}
+
+	size, err := p.instanceTypes.DescribeInstanceType(ctx, region, instanceType)
+	if err != nil {
+		return nil, err
+	}
+
+	p.sizesMu.Lock()
+	if p.sizes == nil {
+		p.sizes = make(map[string]*capiprovider.InstanceSize)
+	}
+	p.sizes[instanceType] = size
+	p.sizesMu.Unlock()
+	return size, nil
+}
+
+// ec2InstanceTypeSource looks up instance types with the EC2 API.
+type ec2InstanceTypeSource struct {
+	client *ec2.Client
+}
+
+// NewEC2InstanceTypeSource creates an instance type source using the default
+// AWS credential chain.
+func NewEC2InstanceTypeSource(ctx context.Context, region string) (InstanceTypeSource, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return &ec2InstanceTypeSource{client: ec2.NewFromConfig(cfg)}, nil
+}
+
+// DescribeInstanceType returns the default vCPU count and memory of an
+// instance type.
+func (s *ec2InstanceTypeSource) DescribeInstanceType(ctx context.Context, region, instanceType string) (*capiprovider.InstanceSize, error) {
+	output, err := s.client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []types.InstanceType{types.InstanceType(instanceType)},
+	}, func(o *ec2.Options) { o.Region = region })
+	if err != nil {
+		return nil, err
+	}
+	if len(output.InstanceTypes) == 0 {
+		return nil, fmt.Errorf("instance type %s is not offered in %s", instanceType, region)
+	}
+
+	info := output.InstanceTypes[0]
+	size := &capiprovider.InstanceSize{}
+	if info.VCpuInfo != nil {
+		size.VCPUs = aws.ToInt32(info.VCpuInfo.DefaultVCpus)
+	}
+	if info.MemoryInfo != nil {
+		size.MemoryMiB = aws.ToInt64(info.MemoryInfo.SizeInMiB)
+	}
+	return size, nil
+}
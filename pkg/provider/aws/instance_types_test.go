@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	capiprovider "github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+type fakeInstanceTypeSource struct {
+	sizes   map[string]*capiprovider.InstanceSize
+	lookups int
+}
+
+func (f *fakeInstanceTypeSource) DescribeInstanceType(ctx context.Context, region, instanceType string) (*capiprovider.InstanceSize, error) {
+	f.lookups++
+	return f.sizes[region+"/"+instanceType], nil
+}
+
+func TestAWSProvider_InstanceTypeSize(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cached across regions", func(t *testing.T) {
+		source := &fakeInstanceTypeSource{sizes: map[string]*capiprovider.InstanceSize{
+			"us-west-2/m5.xlarge": {VCPUs: 4, MemoryMiB: 16384},
+		}}
+		provider := NewAWSProvider("us-west-2")
+		provider.SetInstanceTypeSource(source)
+
+		size, err := provider.InstanceTypeSize(ctx, "", "m5.xlarge")
+		require.NoError(t, err)
+		assert.Equal(t, &capiprovider.InstanceSize{VCPUs: 4, MemoryMiB: 16384}, size)
+
+		size, err = provider.InstanceTypeSize(ctx, "eu-west-1", "m5.xlarge")
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), size.VCPUs)
+		assert.Equal(t, 1, source.lookups)
+	})
+
+	t.Run("not available without a source", func(t *testing.T) {
+		_, err := NewAWSProvider("us-west-2").InstanceTypeSize(ctx, "", "m5.xlarge")
+		assert.Error(t, err)
+	})
+}
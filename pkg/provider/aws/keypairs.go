@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// KeyPairSource looks up EC2 key pairs.
+type KeyPairSource interface {
+	// KeyPairExists reports whether a key pair with the given name exists in
+	// a region.
+	KeyPairExists(ctx context.Context, region, name string) (bool, error)
+}
+
+// SetKeyPairSource enables checking that the sshKeyName of a cluster names
+// an existing key pair in its region.
+func (p *AWSProvider) SetKeyPairSource(source KeyPairSource) {
+	p.keyPairs = source
+}
+
+// validateKeyPair checks that a key pair exists in a region. A key pair that
+// cannot be looked up, e.g. without AWS access, is not rejected: CAPA reports
+// a missing key pair on the AWSMachine once it launches instances.
+func (p *AWSProvider) validateKeyPair(ctx context.Context, region, name string) error {
+	if p.keyPairs == nil || name == "" {
+		return nil
+	}
+	if region == "" {
+		region = p.region
+	}
+
+	exists, err := p.keyPairs.KeyPairExists(ctx, region, name)
+	if err != nil || exists {
+		return nil
+	}
+	return fmt.Errorf("SSH key pair %q does not exist in %s; create or import it there first", name, region)
+}
+
+// ec2KeyPairSource looks up key pairs with the EC2 API.
+type ec2KeyPairSource struct {
+	client *ec2.Client
+}
+
+// NewEC2KeyPairSource creates a key pair source using the default AWS
+// credential chain.
+func NewEC2KeyPairSource(ctx context.Context, region string) (KeyPairSource, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	return &ec2KeyPairSource{client: ec2.NewFromConfig(cfg)}, nil
+}
+
+// KeyPairExists reports whether a key pair exists. It filters by name rather
+// than passing KeyNames, which fails with InvalidKeyPair.NotFound for missing
+// key pairs.
+func (s *ec2KeyPairSource) KeyPairExists(ctx context.Context, region, name string) (bool, error) {
+	output, err := s.client.DescribeKeyPairs(ctx, &ec2.DescribeKeyPairsInput{
+		Filters: []types.Filter{
+			{Name: aws.String("key-name"), Values: []string{name}},
+		},
+	}, func(o *ec2.Options) { o.Region = region })
+	if err != nil {
+		return false, err
+	}
+	return len(output.KeyPairs) > 0, nil
+}
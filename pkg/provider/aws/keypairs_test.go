@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeKeyPairSource struct {
+	keyPairs map[string][]string
+	err      error
+}
+
+func (f *fakeKeyPairSource) KeyPairExists(ctx context.Context, region, name string) (bool, error) {
+	for _, keyPair := range f.keyPairs[region] {
+		if keyPair == name {
+			return true, f.err
+		}
+	}
+	return false, f.err
+}
+
+func TestAWSProvider_ValidateKeyPair(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		source      *fakeKeyPairSource
+		variables   map[string]interface{}
+		expectError string
+	}{
+		{
+			name:      "key pair in cluster region",
+			source:    &fakeKeyPairSource{keyPairs: map[string][]string{"eu-west-1": {"ops"}}},
+			variables: map[string]interface{}{"region": "eu-west-1", "sshKeyName": "ops"},
+		},
+		{
+			name:        "key pair in another region",
+			source:      &fakeKeyPairSource{keyPairs: map[string][]string{"us-west-2": {"ops"}}},
+			variables:   map[string]interface{}{"region": "eu-west-1", "sshKeyName": "ops"},
+			expectError: `SSH key pair "ops" does not exist in eu-west-1`,
+		},
+		{
+			name:      "provider region by default",
+			source:    &fakeKeyPairSource{keyPairs: map[string][]string{"us-west-2": {"ops"}}},
+			variables: map[string]interface{}{"sshKeyName": "ops"},
+		},
+		{
+			name:      "no key pair",
+			source:    &fakeKeyPairSource{},
+			variables: map[string]interface{}{"sshKeyName": ""},
+		},
+		{
+			name:      "lookup failure is not a rejection",
+			source:    &fakeKeyPairSource{err: errors.New("no credentials")},
+			variables: map[string]interface{}{"sshKeyName": "ops"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewAWSProvider("us-west-2")
+			provider.SetKeyPairSource(tt.source)
+
+			err := provider.ValidateClusterConfig(ctx, tt.variables)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("not checked without a source", func(t *testing.T) {
+		err := NewAWSProvider("us-west-2").ValidateClusterConfig(ctx, map[string]interface{}{"sshKeyName": "missing"})
+		assert.NoError(t, err)
+	})
+}
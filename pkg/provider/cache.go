@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCapabilityCacheTTL is how long provider capability queries are
+// cached unless configured otherwise.
+const DefaultCapabilityCacheTTL = 5 * time.Minute
+
+// Capability queries, as reported to CapabilityCacheMetrics.
+const (
+	QueryRegions            = "regions"
+	QueryInstanceTypes      = "instance_types"
+	QueryKubernetesVersions = "kubernetes_versions"
+)
+
+// Capability cache results, as reported to CapabilityCacheMetrics.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// CapabilityCacheMetrics records lookups in the capability cache.
+type CapabilityCacheMetrics interface {
+	IncProviderCapabilityCache(provider, query, result string)
+}
+
+// capabilityCache caches the regions, instance types and Kubernetes versions
+// providers report, which may be remote calls. Errors are not cached.
+type capabilityCache struct {
+	ttl     time.Duration
+	metrics CapabilityCacheMetrics
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[capabilityKey]capabilityEntry
+}
+
+type capabilityKey struct {
+	provider string
+	query    string
+	region   string
+}
+
+type capabilityEntry struct {
+	values    []string
+	expiresAt time.Time
+}
+
+func newCapabilityCache(ttl time.Duration) *capabilityCache {
+	return &capabilityCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[capabilityKey]capabilityEntry),
+	}
+}
+
+// get returns the cached values for key, calling fetch on a miss. A
+// non-positive TTL disables caching.
+func (c *capabilityCache) get(key capabilityKey, fetch func() ([]string, error)) ([]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	fresh := ok && c.now().Before(entry.expiresAt)
+	ttl, metrics := c.ttl, c.metrics
+	c.mu.Unlock()
+
+	if fresh {
+		if metrics != nil {
+			metrics.IncProviderCapabilityCache(key.provider, key.query, CacheHit)
+		}
+		return append([]string(nil), entry.values...), nil
+	}
+	if metrics != nil {
+		metrics.IncProviderCapabilityCache(key.provider, key.query, CacheMiss)
+	}
+
+	values, err := fetch()
+	if err != nil || ttl <= 0 {
+		return values, err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = capabilityEntry{values: append([]string(nil), values...), expiresAt: c.now().Add(ttl)}
+	c.mu.Unlock()
+	return values, nil
+}
+
+// invalidate drops the cached values of a provider, or of every provider
+// when provider is empty.
+func (c *capabilityCache) invalidate(provider string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if provider == "" || key.provider == provider {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// SetCapabilityCache configures how long capability queries are cached and
+// where cache lookups are recorded. A non-positive ttl disables caching.
+func (pm *ProviderManager) SetCapabilityCache(ttl time.Duration, metrics CapabilityCacheMetrics) {
+	pm.cache.mu.Lock()
+	defer pm.cache.mu.Unlock()
+	pm.cache.ttl = ttl
+	pm.cache.metrics = metrics
+	pm.cache.entries = make(map[capabilityKey]capabilityEntry)
+}
+
+// InvalidateCapabilities drops the cached capabilities of a provider, e.g.
+// after its credentials change, or of every provider when name is empty.
+func (pm *ProviderManager) InvalidateCapabilities(name string) {
+	pm.cache.invalidate(name)
+}
+
+// GetRegions returns the regions of a provider, cached.
+func (pm *ProviderManager) GetRegions(ctx context.Context, name string) ([]string, error) {
+	provider, err := pm.registered(name)
+	if err != nil {
+		return nil, err
+	}
+	return pm.cache.get(capabilityKey{provider: name, query: QueryRegions}, func() ([]string, error) {
+		return provider.GetRegions(ctx)
+	})
+}
+
+// GetInstanceTypes returns the instance types of a provider in a region, cached.
+func (pm *ProviderManager) GetInstanceTypes(ctx context.Context, name, region string) ([]string, error) {
+	provider, err := pm.registered(name)
+	if err != nil {
+		return nil, err
+	}
+	return pm.cache.get(capabilityKey{provider: name, query: QueryInstanceTypes, region: region}, func() ([]string, error) {
+		return provider.GetInstanceTypes(ctx, region)
+	})
+}
+
+// GetSupportedKubernetesVersions returns the Kubernetes versions a provider
+// supports, cached.
+func (pm *ProviderManager) GetSupportedKubernetesVersions(ctx context.Context, name string) ([]string, error) {
+	provider, err := pm.registered(name)
+	if err != nil {
+		return nil, err
+	}
+	return pm.cache.get(capabilityKey{provider: name, query: QueryKubernetesVersions}, func() ([]string, error) {
+		return provider.GetSupportedKubernetesVersions(ctx)
+	})
+}
+
+func (pm *ProviderManager) registered(name string) (Provider, error) {
+	provider, exists := pm.GetProvider(name)
+	if !exists {
+		return nil, fmt.Errorf("provider %q is not registered", name)
+	}
+	return provider, nil
+}
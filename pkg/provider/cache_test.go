@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider counts the capability queries reaching the provider.
+type countingProvider struct {
+	mockProvider
+	calls map[string]int
+	err   error
+}
+
+func newCountingProvider(name string) *countingProvider {
+	return &countingProvider{mockProvider: mockProvider{name: name}, calls: map[string]int{}}
+}
+
+func (c *countingProvider) GetRegions(ctx context.Context) ([]string, error) {
+	c.calls[QueryRegions]++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.mockProvider.GetRegions(ctx)
+}
+
+func (c *countingProvider) GetInstanceTypes(ctx context.Context, region string) ([]string, error) {
+	c.calls[QueryInstanceTypes+"/"+region]++
+	return c.mockProvider.GetInstanceTypes(ctx, region)
+}
+
+func (c *countingProvider) GetSupportedKubernetesVersions(ctx context.Context) ([]string, error) {
+	c.calls[QueryKubernetesVersions]++
+	return c.mockProvider.GetSupportedKubernetesVersions(ctx)
+}
+
+type fakeCacheMetrics struct {
+	lookups map[string]int
+}
+
+func (f *fakeCacheMetrics) IncProviderCapabilityCache(provider, query, result string) {
+	f.lookups[provider+"/"+query+"/"+result]++
+}
+
+func TestProviderManager_CapabilityCache(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*ProviderManager, *countingProvider, *fakeCacheMetrics, *time.Time) {
+		pm := NewProviderManager()
+		provider := newCountingProvider("test-provider")
+		pm.RegisterProvider(provider)
+		metrics := &fakeCacheMetrics{lookups: map[string]int{}}
+		pm.SetCapabilityCache(time.Minute, metrics)
+		now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+		pm.cache.now = func() time.Time { return now }
+		return pm, provider, metrics, &now
+	}
+
+	t.Run("queries are cached until the TTL expires", func(t *testing.T) {
+		pm, provider, metrics, now := setup()
+
+		for range 3 {
+			versions, err := pm.GetSupportedKubernetesVersions(ctx, "test-provider")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"v1.31.0", "v1.30.5"}, versions)
+		}
+		assert.Equal(t, 1, provider.calls[QueryKubernetesVersions])
+		assert.Equal(t, 1, metrics.lookups["test-provider/kubernetes_versions/miss"])
+		assert.Equal(t, 2, metrics.lookups["test-provider/kubernetes_versions/hit"])
+
+		*now = now.Add(time.Minute)
+		_, err := pm.GetSupportedKubernetesVersions(ctx, "test-provider")
+		require.NoError(t, err)
+		assert.Equal(t, 2, provider.calls[QueryKubernetesVersions])
+	})
+
+	t.Run("instance types are cached per region", func(t *testing.T) {
+		pm, provider, _, _ := setup()
+
+		for _, region := range []string{"region-1", "region-2", "region-1"} {
+			_, err := pm.GetInstanceTypes(ctx, "test-provider", region)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, provider.calls[QueryInstanceTypes+"/region-1"])
+		assert.Equal(t, 1, provider.calls[QueryInstanceTypes+"/region-2"])
+	})
+
+	t.Run("cached values cannot be modified by callers", func(t *testing.T) {
+		pm, _, _, _ := setup()
+
+		regions, err := pm.GetRegions(ctx, "test-provider")
+		require.NoError(t, err)
+		regions[0] = "modified"
+
+		regions, err = pm.GetRegions(ctx, "test-provider")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"region-1", "region-2"}, regions)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		pm, provider, _, _ := setup()
+		provider.err = errors.New("throttled")
+
+		_, err := pm.GetRegions(ctx, "test-provider")
+		assert.Error(t, err)
+
+		provider.err = nil
+		regions, err := pm.GetRegions(ctx, "test-provider")
+		require.NoError(t, err)
+		assert.Len(t, regions, 2)
+		assert.Equal(t, 2, provider.calls[QueryRegions])
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		pm, provider, _, _ := setup()
+		other := newCountingProvider("other-provider")
+		pm.RegisterProvider(other)
+
+		query := func() {
+			_, err := pm.GetRegions(ctx, "test-provider")
+			require.NoError(t, err)
+			_, err = pm.GetRegions(ctx, "other-provider")
+			require.NoError(t, err)
+		}
+
+		query()
+		pm.InvalidateCapabilities("test-provider")
+		query()
+		assert.Equal(t, 2, provider.calls[QueryRegions])
+		assert.Equal(t, 1, other.calls[QueryRegions])
+
+		pm.InvalidateCapabilities("")
+		query()
+		assert.Equal(t, 3, provider.calls[QueryRegions])
+		assert.Equal(t, 2, other.calls[QueryRegions])
+	})
+
+	t.Run("disabled with a zero TTL", func(t *testing.T) {
+		pm, provider, _, _ := setup()
+		pm.SetCapabilityCache(0, nil)
+
+		for range 2 {
+			_, err := pm.GetRegions(ctx, "test-provider")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, provider.calls[QueryRegions])
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		pm, _, _, _ := setup()
+
+		_, err := pm.GetRegions(ctx, "missing")
+		assert.Error(t, err)
+	})
+}
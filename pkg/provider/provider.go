@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -44,9 +47,11 @@ type Provider interface {
 	// is ready and functional. This allows provider-specific health checks.
 	ValidateInfrastructureReadiness(ctx context.Context, cluster *clusterv1.Cluster) error
 
-	// GetProviderSpecificStatus extracts and formats provider-specific status
-	// information from the cluster and its infrastructure resources.
-	GetProviderSpecificStatus(ctx context.Context, cluster *clusterv1.Cluster) (map[string]interface{}, error)
+	// GetProviderSpecificStatus extracts the status of the cluster's
+	// infrastructure from the cluster and its infrastructure cluster (e.g. an
+	// AWSCluster), which is nil if it could not be read. The status is
+	// normalized so clients get the same fields from every provider.
+	GetProviderSpecificStatus(ctx context.Context, cluster *clusterv1.Cluster, infraCluster *unstructured.Unstructured) (*ProviderStatus, error)
 
 	// GetRegions returns a list of available regions/zones for this provider.
 	// This can be used for validation and to provide region options to users.
@@ -57,22 +62,135 @@ type Provider interface {
 	GetInstanceTypes(ctx context.Context, region string) ([]string, error)
 }
 
+// ManagedControlPlaneProvider is an optional interface implemented by providers
+// that support cloud-hosted control planes (e.g. EKS, AKS, GKE). Managed control
+// planes have no control-plane Machines, publish kubeconfigs under provider-specific
+// secret names, and report readiness differently from KubeadmControlPlane.
+type ManagedControlPlaneProvider interface {
+	// IsManagedControlPlane reports whether the given control plane kind
+	// (from Cluster.Spec.ControlPlaneRef) is hosted by this provider.
+	IsManagedControlPlane(kind string) bool
+
+	// GetKubeconfigSecretNames returns the candidate kubeconfig secret names for
+	// a cluster with a managed control plane, in order of preference.
+	GetKubeconfigSecretNames(clusterName string) []string
+
+	// GetManagedControlPlaneStatus interprets the status of a managed control plane
+	// object, returning whether it is ready and a human-readable message.
+	GetManagedControlPlaneStatus(ctx context.Context, controlPlane *unstructured.Unstructured) (bool, string, error)
+}
+
+// NodeImageResolver is an optional interface implemented by providers that
+// can look up the machine image nodes boot from, so callers need not hardcode
+// image IDs per Kubernetes version and region.
+type NodeImageResolver interface {
+	// ResolveNodeImage returns the newest image matching the query, or an
+	// error wrapping ErrNodeImageNotFound if there is none.
+	ResolveNodeImage(ctx context.Context, query NodeImageQuery) (*NodeImage, error)
+}
+
+// ErrNodeImageNotFound is returned by ResolveNodeImage when no image matches.
+var ErrNodeImageNotFound = errors.New("node image not found")
+
+// NodeImageQuery selects a node image. Empty fields use provider defaults.
+type NodeImageQuery struct {
+	KubernetesVersion string
+	Region            string
+	// OS is the base operating system of the image, e.g. "ubuntu-22.04".
+	OS string
+	// Lookup selects the provider-specific lookup, e.g. the image-builder
+	// naming convention or the provider's managed node images.
+	Lookup string
+}
+
+// NodeImage is a machine image resolved for a Kubernetes version and region.
+type NodeImage struct {
+	ID                string
+	Name              string
+	Region            string
+	OS                string
+	KubernetesVersion string
+	Lookup            string
+	CreatedAt         time.Time
+}
+
+// InstanceTypeSizer is an optional interface implemented by providers that
+// can report the compute capacity of an instance type, so that limits on the
+// total size of a cluster can be enforced.
+type InstanceTypeSizer interface {
+	// InstanceTypeSize returns the vCPUs and memory of an instance type
+	// offered in a region.
+	InstanceTypeSize(ctx context.Context, region, instanceType string) (*InstanceSize, error)
+}
+
+// InstanceSize is the compute capacity of an instance type.
+type InstanceSize struct {
+	VCPUs     int32
+	MemoryMiB int64
+}
+
+// CloudResourceScanner is an optional interface implemented by providers that
+// can list the cloud resources they create for clusters, so that resources
+// leaked by clusters that no longer exist can be found.
+type CloudResourceScanner interface {
+	// ScanClusterResources returns the cloud resources in a region owned by
+	// any cluster.
+	ScanClusterResources(ctx context.Context, region string) ([]CloudResource, error)
+}
+
+// CloudResource is a cloud resource created for a cluster.
+type CloudResource struct {
+	// Type is the kind of resource, e.g. "vpc", "instance" or "load-balancer".
+	Type        string
+	ID          string
+	Name        string
+	Region      string
+	ClusterName string
+	State       string
+	CreatedAt   time.Time
+}
+
+// ProviderStatus is the status of a cluster's infrastructure, in the same
+// fields for every provider.
+type ProviderStatus struct {
+	Provider string
+	Region   string
+	// InfrastructureReady reports whether the provider reports the
+	// infrastructure of the cluster as provisioned.
+	InfrastructureReady bool
+	// NetworkReady reports whether the cluster network, such as the VPC and
+	// its subnets, is provisioned.
+	NetworkReady bool
+	// ControlPlaneLoadBalancer is the address of the load balancer in front
+	// of the API servers, if there is one.
+	ControlPlaneLoadBalancer string
+	FailureDomains           []string
+	// CloudResources identifies the cloud resources backing the cluster.
+	// Only Type, ID and Region are set.
+	CloudResources []CloudResource
+	// Extras holds the status only this provider reports.
+	Extras map[string]interface{}
+}
+
 // ProviderManager manages multiple provider implementations and provides
 // a unified interface for accessing provider-specific functionality.
 type ProviderManager struct {
 	providers map[string]Provider
+	cache     *capabilityCache
 }
 
 // NewProviderManager creates a new provider manager instance.
 func NewProviderManager() *ProviderManager {
 	return &ProviderManager{
 		providers: make(map[string]Provider),
+		cache:     newCapabilityCache(DefaultCapabilityCacheTTL),
 	}
 }
 
 // RegisterProvider adds a provider implementation to the manager.
 func (pm *ProviderManager) RegisterProvider(provider Provider) {
 	pm.providers[provider.Name()] = provider
+	pm.cache.invalidate(provider.Name())
 }
 
 // GetProvider retrieves a provider by name.
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -39,8 +40,8 @@ func (m *mockProvider) ValidateInfrastructureReadiness(ctx context.Context, clus
 	return nil
 }
 
-func (m *mockProvider) GetProviderSpecificStatus(ctx context.Context, cluster *clusterv1.Cluster) (map[string]interface{}, error) {
-	return map[string]interface{}{"provider": m.name}, nil
+func (m *mockProvider) GetProviderSpecificStatus(ctx context.Context, cluster *clusterv1.Cluster, infraCluster *unstructured.Unstructured) (*ProviderStatus, error) {
+	return &ProviderStatus{Provider: m.name}, nil
 }
 
 func (m *mockProvider) GetRegions(ctx context.Context) ([]string, error) {
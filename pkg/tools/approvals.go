@@ -0,0 +1,315 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/approval"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+)
+
+// Approval statuses.
+const (
+	approvalPending  = "pending"
+	approvalApproved = "approved"
+)
+
+// Approvals holds the two-party approvals of high-risk operations, shared by
+// the providers of all identities. The first call of a high-risk operation
+// creates a pending approval; once a second identity approves it with
+// approve_operation, the requesting identity calls the operation again with
+// the approval ID and it runs. An approval is used once and expires after its
+// time to live, whether approved or not. Pending approvals can also be
+// forwarded to approvers in Slack or PagerDuty and approved there.
+type Approvals struct {
+	now          func() time.Time
+	ttl          time.Duration
+	environments []string
+	notifier     approval.Notifier
+
+	mu        sync.Mutex
+	approvals map[string]*storedApproval
+}
+
+type storedApproval struct {
+	api.Approval
+	expiresAt time.Time
+}
+
+// NewApprovals creates the approvals of high-risk operations on clusters of
+// the given environments, or of all clusters if none are given. Approvals
+// expire after ttl.
+func NewApprovals(ttl time.Duration, environments []string) *Approvals {
+	return &Approvals{
+		now:          time.Now,
+		ttl:          ttl,
+		environments: environments,
+		approvals:    make(map[string]*storedApproval),
+	}
+}
+
+// SetNotifier forwards new pending approvals to approvers through notifier.
+func (a *Approvals) SetNotifier(notifier approval.Notifier) {
+	a.notifier = notifier
+}
+
+// covers reports whether operations on a cluster of an environment need
+// approval.
+func (a *Approvals) covers(environment string) bool {
+	return len(a.environments) == 0 || slices.Contains(a.environments, environment)
+}
+
+// request returns the approval of an operation an identity requested,
+// creating a pending one unless the identity already requested it. It reports
+// whether the approval was created.
+func (a *Approvals) request(tool, namespace, clusterName, requester string) (api.Approval, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pruneLocked()
+	for _, existing := range a.approvals {
+		if existing.Tool == tool && existing.Namespace == namespace && existing.ClusterName == clusterName && existing.RequestedBy == requester {
+			return existing.Approval, false
+		}
+	}
+
+	now := a.now()
+	created := &storedApproval{
+		Approval: api.Approval{
+			ID:          uuid.NewString()[:8],
+			Tool:        tool,
+			Namespace:   namespace,
+			ClusterName: clusterName,
+			Status:      approvalPending,
+			RequestedBy: requester,
+			RequestedAt: now.UTC().Format(time.RFC3339),
+			ExpiresAt:   now.Add(a.ttl).UTC().Format(time.RFC3339),
+		},
+		expiresAt: now.Add(a.ttl),
+	}
+	a.approvals[created.ID] = created
+	return created.Approval, true
+}
+
+// get returns a pending or approved approval.
+func (a *Approvals) get(id string) (api.Approval, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pruneLocked()
+	existing, ok := a.approvals[id]
+	if !ok {
+		return api.Approval{}, approvalNotFound(id)
+	}
+	return existing.Approval, nil
+}
+
+// Approve approves a pending approval on behalf of an identity other than the
+// one that requested it, such as an approver in Slack.
+func (a *Approvals) Approve(id, approver string) (api.Approval, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pruneLocked()
+	existing, ok := a.approvals[id]
+	if !ok {
+		return api.Approval{}, approvalNotFound(id)
+	}
+	if existing.RequestedBy == approver {
+		return api.Approval{}, errors.New(errors.CodeForbidden,
+			fmt.Sprintf("approval '%s' must be approved by an identity other than the one that requested it", id))
+	}
+	if existing.Status == approvalApproved {
+		return api.Approval{}, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("approval '%s' was already approved by '%s'", id, existing.ApprovedBy))
+	}
+
+	existing.Status = approvalApproved
+	existing.ApprovedBy = approver
+	existing.ApprovedAt = a.now().UTC().Format(time.RFC3339)
+	return existing.Approval, nil
+}
+
+// use consumes the approval of an operation, which must have been approved
+// for the same tool, cluster and requesting identity.
+func (a *Approvals) use(id, tool, namespace, clusterName, requester string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pruneLocked()
+	existing, ok := a.approvals[id]
+	if !ok {
+		return approvalNotFound(id)
+	}
+	if existing.Tool != tool || existing.Namespace != namespace || existing.ClusterName != clusterName || existing.RequestedBy != requester {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("approval '%s' was requested by '%s' for %s of cluster '%s', not for this call", id, existing.RequestedBy, existing.Tool, existing.ClusterName))
+	}
+	if existing.Status != approvalApproved {
+		return errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("approval '%s' is still pending; a second identity must approve it with approve_operation before %s", id, existing.ExpiresAt)).
+			WithDetails("approval_id", id)
+	}
+
+	delete(a.approvals, id)
+	return nil
+}
+
+// pending returns the approval requests still pending.
+func (a *Approvals) pending() []approval.Request {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pruneLocked()
+	var requests []approval.Request
+	for _, existing := range a.approvals {
+		if existing.Status == approvalPending {
+			requests = append(requests, approvalRequest(existing.Approval))
+		}
+	}
+	return requests
+}
+
+// Run polls an integration for the pending approvals approved there every
+// interval until ctx is done.
+func (a *Approvals) Run(ctx context.Context, poller approval.Poller, interval time.Duration, logger *logging.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		requests := a.pending()
+		if len(requests) == 0 {
+			continue
+		}
+		approved, err := poller.Approved(ctx, requests)
+		if err != nil && ctx.Err() == nil {
+			logger.WithError(err).Warn("Failed to poll approvals")
+		}
+		for id, approver := range approved {
+			approved, err := a.Approve(id, approver)
+			if err != nil {
+				logger.WithError(err).Warn("Failed to approve operation", "approval_id", id, "approved_by", approver)
+				continue
+			}
+			logger.WithCluster(approved.ClusterName, approved.Namespace).Warn("Operation approved",
+				"tool", approved.Tool, "approval_id", approved.ID, "requested_by", approved.RequestedBy, "approved_by", approved.ApprovedBy)
+		}
+	}
+}
+
+// pruneLocked removes expired approvals.
+func (a *Approvals) pruneLocked() {
+	now := a.now()
+	for id, existing := range a.approvals {
+		if !now.Before(existing.expiresAt) {
+			delete(a.approvals, id)
+		}
+	}
+}
+
+// approvalRequest returns the request forwarded to approvers for an approval.
+func approvalRequest(pending api.Approval) approval.Request {
+	return approval.Request{
+		ID:          pending.ID,
+		Tool:        pending.Tool,
+		Namespace:   pending.Namespace,
+		ClusterName: pending.ClusterName,
+		RequestedBy: pending.RequestedBy,
+		ExpiresAt:   pending.ExpiresAt,
+	}
+}
+
+func approvalNotFound(id string) error {
+	return errors.New(errors.CodeNotFound,
+		fmt.Sprintf("approval '%s' not found; it may have expired or already been used", id)).WithDetails("field", "approvalId")
+}
+
+// SetApprovals configures the approvals high-risk tools such as
+// delete_cluster and get_cluster_kubeconfig are held back for.
+func (p *EnhancedProvider) SetApprovals(approvals *Approvals) {
+	p.approvals = approvals
+}
+
+// withApproval holds back a high-risk tool call until a second identity has
+// approved it. Without approvalID, the call fails with the ID of a pending
+// approval to have approved; with the ID of an approved approval, handler is
+// called.
+func (p *EnhancedProvider) withApproval(tool, approvalID string, handler func(context.Context, map[string]interface{}) (interface{}, error)) func(context.Context, map[string]interface{}) (interface{}, error) {
+	return func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+		clusterName, _ := input["clusterName"].(string)
+		if p.approvals == nil || clusterName == "" {
+			return handler(ctx, input)
+		}
+
+		required, err := p.approvalRequired(ctx, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if !required {
+			return handler(ctx, input)
+		}
+
+		namespace, _ := kube.NamespaceFromContext(ctx)
+		var requester string
+		if p.identity != nil {
+			requester = p.identity.Name
+		}
+		logger := p.logger.WithContext(ctx).WithCluster(clusterName, namespace)
+		if approvalID != "" {
+			if err := p.approvals.use(approvalID, tool, namespace, clusterName, requester); err != nil {
+				return nil, err
+			}
+			logger.Info("Running approved operation", "tool", tool, "approval_id", approvalID)
+			return handler(ctx, input)
+		}
+
+		pending, created := p.approvals.request(tool, namespace, clusterName, requester)
+		logger.Info("Operation awaits approval", "tool", tool, "approval_id", pending.ID)
+		if created && p.approvals.notifier != nil {
+			if err := p.approvals.notifier.Notify(ctx, approvalRequest(pending)); err != nil {
+				logger.WithError(err).Warn("Failed to forward approval request", "approval_id", pending.ID)
+			}
+		}
+		return nil, errors.New(errors.CodePreconditionFailed,
+			fmt.Sprintf("%s of cluster '%s' requires the approval of a second identity: have approval '%s' approved with approve_operation before %s, then call %s again with approvalId '%s'",
+				tool, clusterName, pending.ID, pending.ExpiresAt, tool, pending.ID)).
+			WithDetails("approval_id", pending.ID).
+			WithDetails("expires_at", pending.ExpiresAt)
+	}
+}
+
+// approvalRequired reports whether operations on a cluster need approval,
+// depending on its environment. Clusters that do not exist need none; the
+// operation fails on its own.
+func (p *EnhancedProvider) approvalRequired(ctx context.Context, clusterName string) (bool, error) {
+	if len(p.approvals.environments) == 0 {
+		return true, nil
+	}
+	svc, ok := p.clusterService.(*service.EnhancedClusterService)
+	if !ok {
+		return true, nil
+	}
+	environment, err := svc.GetClusterEnvironment(ctx, clusterName)
+	if err != nil {
+		if errors.GetErrorCode(err) == errors.CodeNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return p.approvals.covers(environment), nil
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/approval"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+)
+
+// recordingNotifier records the approval requests forwarded to it.
+type recordingNotifier struct {
+	requests []approval.Request
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, request approval.Request) error {
+	n.requests = append(n.requests, request)
+	return nil
+}
+
+// resolvedPoller approves every request it is asked about.
+type resolvedPoller struct{}
+
+func (resolvedPoller) Approved(ctx context.Context, requests []approval.Request) (map[string]string, error) {
+	approved := make(map[string]string)
+	for _, request := range requests {
+		approved[request.ID] = "pagerduty:Bob"
+	}
+	return approved, nil
+}
+
+func TestApprovals(t *testing.T) {
+	approvals := NewApprovals(time.Hour, nil)
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	approvals.now = func() time.Time { return now }
+
+	requested, created := approvals.request("delete_cluster", "default", "prod-a", "alice")
+	assert.True(t, created)
+	assert.Equal(t, approvalPending, requested.Status)
+	assert.Equal(t, "2026-03-02T11:00:00Z", requested.ExpiresAt)
+	repeated, created := approvals.request("delete_cluster", "default", "prod-a", "alice")
+	assert.False(t, created)
+	assert.Equal(t, requested, repeated, "repeated requests share an approval")
+	assert.Len(t, approvals.pending(), 1)
+
+	err := approvals.use(requested.ID, "delete_cluster", "default", "prod-a", "alice")
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+
+	_, err = approvals.Approve(requested.ID, "alice")
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+
+	approved, err := approvals.Approve(requested.ID, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, approvalApproved, approved.Status)
+	assert.Equal(t, "bob", approved.ApprovedBy)
+
+	_, err = approvals.Approve(requested.ID, "carol")
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+
+	err = approvals.use(requested.ID, "get_cluster_kubeconfig", "default", "prod-a", "alice")
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+	err = approvals.use(requested.ID, "delete_cluster", "default", "prod-a", "bob")
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+
+	require.NoError(t, approvals.use(requested.ID, "delete_cluster", "default", "prod-a", "alice"))
+	err = approvals.use(requested.ID, "delete_cluster", "default", "prod-a", "alice")
+	assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err), "approvals are used once")
+
+	t.Run("expires", func(t *testing.T) {
+		requested, _ := approvals.request("get_cluster_kubeconfig", "default", "prod-a", "alice")
+		_, err := approvals.Approve(requested.ID, "bob")
+		require.NoError(t, err)
+
+		now = now.Add(time.Hour)
+		err = approvals.use(requested.ID, "get_cluster_kubeconfig", "default", "prod-a", "alice")
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
+
+func TestEnhancedProvider_WithApproval(t *testing.T) {
+	prod := newTestCluster("prod-a", "Provisioned")
+	prod.Labels = map[string]string{service.DefaultEnvironmentLabel: "prod"}
+	requester := newTestEnhancedProvider(t, prod, newTestCluster("dev-a", "Provisioned"))
+	approver := NewEnhancedProvider(requester.mcpServer, requester.logger, requester.clusterService)
+	outsider := NewEnhancedProvider(requester.mcpServer, requester.logger, requester.clusterService)
+	requester.SetIdentity(&auth.Identity{Name: "alice", DefaultNamespace: "default", Namespaces: []string{"default"}})
+	approver.SetIdentity(&auth.Identity{Name: "bob", DefaultNamespace: "default", Namespaces: []string{"default"}})
+	outsider.SetIdentity(&auth.Identity{Name: "carol", DefaultNamespace: "team-b", Namespaces: []string{"team-b"}})
+	approvals := NewApprovals(time.Hour, []string{"prod"})
+	notifier := &recordingNotifier{}
+	approvals.SetNotifier(notifier)
+	for _, p := range []*EnhancedProvider{requester, approver, outsider} {
+		p.SetApprovals(approvals)
+	}
+
+	ctx, err := requester.namespaceContext(context.Background(), "")
+	require.NoError(t, err)
+	calls := 0
+	handler := func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+		calls++
+		return map[string]interface{}{}, nil
+	}
+
+	// Clusters outside the approval environments need no approval
+	_, err = requester.withApproval("delete_cluster", "", handler)(ctx, map[string]interface{}{"clusterName": "dev-a"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	_, err = requester.withApproval("delete_cluster", "", handler)(ctx, map[string]interface{}{"clusterName": "prod-a"})
+	require.Error(t, err)
+	assert.Equal(t, errors.CodePreconditionFailed, errors.GetErrorCode(err))
+	assert.Equal(t, 1, calls)
+	approvalID := err.(*errors.Error).Details["approval_id"].(string)
+	require.Len(t, notifier.requests, 1)
+	assert.Equal(t, approval.Request{
+		ID: approvalID, Tool: "delete_cluster", Namespace: "default", ClusterName: "prod-a", RequestedBy: "alice",
+		ExpiresAt: notifier.requests[0].ExpiresAt,
+	}, notifier.requests[0])
+
+	_, err = outsider.handleApproveOperation(context.Background(), map[string]interface{}{"approvalId": approvalID})
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+
+	result, err := approver.handleApproveOperation(context.Background(), map[string]interface{}{"approvalId": approvalID})
+	require.NoError(t, err)
+	assert.Contains(t, result.(map[string]interface{})["message"], "Approved delete_cluster of cluster 'prod-a' for 'alice'")
+
+	_, err = requester.withApproval("delete_cluster", approvalID, handler)(ctx, map[string]interface{}{"clusterName": "prod-a"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestApprovals_Run(t *testing.T) {
+	approvals := NewApprovals(time.Hour, nil)
+	requested, _ := approvals.request("get_cluster_kubeconfig", "default", "prod-a", "alice")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go approvals.Run(ctx, resolvedPoller{}, 10*time.Millisecond, logging.NewLogger(slog.LevelError, "text"))
+
+	require.Eventually(t, func() bool {
+		approved, err := approvals.get(requested.ID)
+		return err == nil && approved.ApprovedBy == "pagerduty:Bob"
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, approvals.use(requested.ID, "get_cluster_kubeconfig", "default", "prod-a", "alice"))
+}
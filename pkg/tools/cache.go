@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// cacheMetaKey is the result metadata key reporting whether a result
+	// came from the result cache.
+	cacheMetaKey = "capi-mcp.io/cache"
+
+	// noCacheMetaKey is the request metadata key with which a caller asks for
+	// a fresh result.
+	noCacheMetaKey = "capi-mcp.io/no-cache"
+
+	// maxCachedResults bounds the number of results cached at once; the
+	// oldest are dropped first.
+	maxCachedResults = 500
+)
+
+// cachedResult is a tool result held for repeated identical calls.
+type cachedResult struct {
+	result    interface{} // *mcp.CallToolResultFor[Out] of the tool
+	storedAt  time.Time
+	expiresAt time.Time
+}
+
+// resultCache holds the results of read tools for a short time, so that
+// clients calling the same tool with the same arguments again within seconds
+// do not query the API server again.
+type resultCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	results map[string]*cachedResult
+}
+
+// newResultCache creates a cache holding results for ttl.
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{
+		ttl:     ttl,
+		now:     time.Now,
+		results: make(map[string]*cachedResult),
+	}
+}
+
+// get returns a cached result that has not expired.
+func (c *resultCache) get(key string) (*cachedResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.results[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(cached.expiresAt) {
+		delete(c.results, key)
+		return nil, false
+	}
+	return cached, true
+}
+
+// put caches a result.
+func (c *resultCache) put(key string, result interface{}) *cachedResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, cached := range c.results {
+		if !now.Before(cached.expiresAt) {
+			delete(c.results, key)
+		}
+	}
+	for len(c.results) >= maxCachedResults {
+		var oldest string
+		for key, cached := range c.results {
+			if oldest == "" || cached.storedAt.Before(c.results[oldest].storedAt) {
+				oldest = key
+			}
+		}
+		delete(c.results, oldest)
+	}
+
+	cached := &cachedResult{result: result, storedAt: now, expiresAt: now.Add(c.ttl)}
+	c.results[key] = cached
+	return cached
+}
+
+// clear drops every cached result.
+func (c *resultCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.results)
+}
+
+// metadata describes a cached result for the result metadata.
+func (c *resultCache) metadata(cached *cachedResult, hit bool) map[string]interface{} {
+	status := "miss"
+	if hit {
+		status = "hit"
+	}
+	return map[string]interface{}{
+		"status":        status,
+		"cachedAt":      cached.storedAt.UTC().Format(time.RFC3339),
+		"ageSeconds":    int(c.now().Sub(cached.storedAt).Seconds()),
+		"maxAgeSeconds": int(c.ttl.Seconds()),
+	}
+}
+
+// SetResultCache caches the results of read tools such as list_clusters and
+// get_cluster for ttl. Zero disables caching.
+func (p *EnhancedProvider) SetResultCache(ttl time.Duration) {
+	if ttl <= 0 {
+		p.cache = nil
+		return
+	}
+	p.cache = newResultCache(ttl)
+}
+
+// withCache serves repeated calls of a read tool with the same arguments by
+// the same identity from the result cache. The result metadata reports
+// whether the result was cached and how old it is; callers wanting a fresh
+// result set capi-mcp.io/no-cache in the request metadata.
+func withCache[In, Out any](p *EnhancedProvider, handler func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error)) func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+		cache := p.cache
+		if cache == nil {
+			return handler(ctx, session, params)
+		}
+
+		arguments, err := json.Marshal(params.Arguments)
+		if err != nil {
+			return handler(ctx, session, params)
+		}
+		var identity string
+		if p.identity != nil {
+			identity = p.identity.Name
+		}
+		key := identity + "\x00" + params.Name + "\x00" + string(arguments)
+
+		if noCache, _ := params.Meta[noCacheMetaKey].(bool); !noCache {
+			if cached, ok := cache.get(key); ok {
+				if result, ok := cached.result.(*mcp.CallToolResultFor[Out]); ok {
+					return cachedCopy(result, cache.metadata(cached, true)), nil
+				}
+			}
+		}
+
+		result, err := handler(ctx, session, params)
+		if err != nil || result == nil {
+			return result, err
+		}
+		cached := cache.put(key, cachedCopy(result, nil))
+		return cachedCopy(result, cache.metadata(cached, false)), nil
+	}
+}
+
+// cachedCopy copies a result so that wrappers appending content to it, such
+// as withCorrelationID, leave the cached result alone.
+func cachedCopy[Out any](result *mcp.CallToolResultFor[Out], metadata map[string]interface{}) *mcp.CallToolResultFor[Out] {
+	copied := *result
+	copied.Content = slices.Clone(result.Content)
+	if metadata != nil {
+		copied.Meta = mcp.Meta{cacheMetaKey: metadata}
+	}
+	return &copied
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestWithCache(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	provider.SetResultCache(10 * time.Second)
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	provider.cache.now = func() time.Time { return now }
+
+	calls := 0
+	handler := withCorrelationID(provider, withCache(provider, func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterArgs]) (*mcp.CallToolResultFor[api.GetClusterOutput], error) {
+		calls++
+		return &mcp.CallToolResultFor[api.GetClusterOutput]{
+			Content: []mcp.Content{&mcp.TextContent{Text: params.Arguments.ClusterName}},
+		}, nil
+	}))
+	call := func(clusterName string, meta mcp.Meta) *mcp.CallToolResultFor[api.GetClusterOutput] {
+		result, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[EnhancedGetClusterArgs]{
+			Name:      "get_cluster",
+			Meta:      meta,
+			Arguments: EnhancedGetClusterArgs{ClusterName: clusterName},
+		})
+		require.NoError(t, err)
+		return result
+	}
+	cacheStatus := func(result *mcp.CallToolResultFor[api.GetClusterOutput]) string {
+		return result.Meta[cacheMetaKey].(map[string]interface{})["status"].(string)
+	}
+
+	first := call("prod-a", nil)
+	assert.Equal(t, "miss", cacheStatus(first))
+	now = now.Add(4 * time.Second)
+	second := call("prod-a", nil)
+	assert.Equal(t, "hit", cacheStatus(second))
+	assert.Equal(t, 4, second.Meta[cacheMetaKey].(map[string]interface{})["ageSeconds"])
+	assert.Equal(t, 1, calls)
+	// Only the correlation ID of this call is appended to the cached content
+	assert.Len(t, second.Content, 2)
+
+	assert.Equal(t, "miss", cacheStatus(call("prod-b", nil)), "other arguments")
+	assert.Equal(t, "miss", cacheStatus(call("prod-a", mcp.Meta{noCacheMetaKey: true})), "fresh result requested")
+	assert.Equal(t, 3, calls)
+
+	t.Run("expires", func(t *testing.T) {
+		now = now.Add(10 * time.Second)
+		assert.Equal(t, "miss", cacheStatus(call("prod-a", nil)))
+	})
+
+	t.Run("mutating calls clear the cache", func(t *testing.T) {
+		calls = 0
+		call("prod-a", nil)
+		_, err := provider.admitted(context.Background(), "delete_cluster", map[string]interface{}{}, func(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "miss", cacheStatus(call("prod-a", nil)))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		provider.SetResultCache(0)
+		calls = 0
+		assert.Nil(t, call("prod-a", nil).Meta)
+		call("prod-a", nil)
+		assert.Equal(t, 2, calls)
+	})
+}
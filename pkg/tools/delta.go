@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+const (
+	// listSnapshotTTL is how long the token of a list_clusters call can be
+	// passed as sinceToken.
+	listSnapshotTTL = time.Hour
+
+	// maxListSnapshots bounds the number of list_clusters snapshots kept at
+	// once; the oldest are dropped first.
+	maxListSnapshots = 100
+)
+
+// listSnapshot is what a list_clusters call returned, so that a later call
+// can list only what changed since.
+type listSnapshot struct {
+	scope        string                        // namespace and filters of the call
+	fingerprints map[string]clusterFingerprint // by namespace/name of each cluster
+	storedAt     time.Time
+}
+
+// listSnapshots holds the snapshots of the list_clusters calls of an
+// identity, by the token returned with each.
+type listSnapshots struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	snapshots map[string]*listSnapshot
+}
+
+// newListSnapshots creates an empty snapshot store.
+func newListSnapshots() *listSnapshots {
+	return &listSnapshots{
+		now:       time.Now,
+		snapshots: make(map[string]*listSnapshot),
+	}
+}
+
+// get returns the snapshot of a token, which must have been returned by a
+// call with the same scope and not have expired.
+func (s *listSnapshots) get(token, scope string) (*listSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked()
+	snapshot, ok := s.snapshots[token]
+	if !ok || snapshot.scope != scope {
+		return nil, false
+	}
+	return snapshot, true
+}
+
+// put stores a snapshot and returns its token. A snapshot identical to one
+// already stored for the scope reuses its token, so that clients polling an
+// unchanged fleet do not fill the store.
+func (s *listSnapshots) put(scope string, fingerprints map[string]clusterFingerprint) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked()
+	now := s.now()
+	for token, snapshot := range s.snapshots {
+		if snapshot.scope == scope && maps.Equal(snapshot.fingerprints, fingerprints) {
+			snapshot.storedAt = now
+			return token
+		}
+	}
+	for len(s.snapshots) >= maxListSnapshots {
+		var oldest string
+		for token, snapshot := range s.snapshots {
+			if oldest == "" || snapshot.storedAt.Before(s.snapshots[oldest].storedAt) {
+				oldest = token
+			}
+		}
+		delete(s.snapshots, oldest)
+	}
+
+	token := uuid.NewString()
+	s.snapshots[token] = &listSnapshot{scope: scope, fingerprints: fingerprints, storedAt: now}
+	return token
+}
+
+// pruneLocked removes expired snapshots.
+func (s *listSnapshots) pruneLocked() {
+	now := s.now()
+	for token, snapshot := range s.snapshots {
+		if now.Sub(snapshot.storedAt) >= listSnapshotTTL {
+			delete(s.snapshots, token)
+		}
+	}
+}
+
+// clusterFingerprint is what a cluster summary is listed from: the resource
+// version of its Cluster object, which changes with everything read from it,
+// and what is derived from other objects. It leaves out the age and retrieval
+// time of the summary, which change on every call.
+type clusterFingerprint struct {
+	resourceVersion string
+	nodeCount       int
+	readyNodeCount  int
+	advisory        string // status of the version advisory
+	warnings        string
+}
+
+// clusterFingerprints fingerprints the summaries of a cluster list by
+// namespace/name.
+func clusterFingerprints(clusters []api.ClusterSummary) map[string]clusterFingerprint {
+	fingerprints := make(map[string]clusterFingerprint, len(clusters))
+	for _, cluster := range clusters {
+		fingerprint := clusterFingerprint{
+			resourceVersion: cluster.ResourceVersion,
+			nodeCount:       cluster.NodeCount,
+			readyNodeCount:  cluster.ReadyNodeCount,
+			warnings:        strings.Join(cluster.Warnings, "\n"),
+		}
+		if cluster.Advisory != nil {
+			fingerprint.advisory = cluster.Advisory.Status
+		}
+		fingerprints[clusterKey(cluster)] = fingerprint
+	}
+	return fingerprints
+}
+
+// clusterDelta narrows a cluster list to the clusters added or changed since
+// a snapshot and returns the clusters removed since, sorted.
+func clusterDelta(clusters []api.ClusterSummary, fingerprints map[string]clusterFingerprint, since *listSnapshot) ([]api.ClusterSummary, []string) {
+	changed := slices.DeleteFunc(clusters, func(cluster api.ClusterSummary) bool {
+		previous, ok := since.fingerprints[clusterKey(cluster)]
+		return ok && previous == fingerprints[clusterKey(cluster)]
+	})
+	var removed []string
+	for key := range since.fingerprints {
+		if _, ok := fingerprints[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	slices.Sort(removed)
+	return changed, removed
+}
+
+func clusterKey(cluster api.ClusterSummary) string {
+	return cluster.Namespace + "/" + cluster.Name
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+func TestClusterDelta(t *testing.T) {
+	summaries := func() []api.ClusterSummary {
+		return []api.ClusterSummary{
+			{Name: "prod-a", Namespace: "team-a", ResourceVersion: "10", Age: "2d", Status: api.ClusterStatusReady},
+			{Name: "prod-b", Namespace: "team-a", ResourceVersion: "11", Age: "1d", Status: api.ClusterStatusReady},
+			{Name: "prod-c", Namespace: "team-a", ResourceVersion: "12", Age: "1h", Status: api.ClusterStatusProvisioning},
+		}
+	}
+	since := &listSnapshot{fingerprints: clusterFingerprints(summaries())}
+
+	current := summaries()[1:]    // prod-a removed
+	current[0].ReadyNodeCount = 3 // derived from other objects
+	current[1].ResourceVersion = "13"
+	current = append(current, api.ClusterSummary{Name: "prod-d", Namespace: "team-a", ResourceVersion: "14"})
+
+	changed, removed := clusterDelta(current, clusterFingerprints(current), since)
+	names := []string{}
+	for _, cluster := range changed {
+		names = append(names, cluster.Name)
+	}
+	assert.Equal(t, []string{"prod-b", "prod-c", "prod-d"}, names)
+	assert.Equal(t, []string{"team-a/prod-a"}, removed)
+
+	derived := summaries()
+	derived[0].Advisory = &api.VersionAdvisory{Status: "end-of-life"}
+	derived[1].Warnings = []string{"failed to count nodes"}
+	changed, removed = clusterDelta(derived, clusterFingerprints(derived), since)
+	require.Len(t, changed, 2, "advisories and warnings are derived from other sources")
+	assert.Equal(t, "prod-a", changed[0].Name)
+	assert.Equal(t, "prod-b", changed[1].Name)
+	assert.Empty(t, removed)
+
+	aged := summaries()
+	for i := range aged {
+		aged[i].Age = "30d"
+		aged[i].LastUpdated = "2026-03-02T10:00:00Z"
+	}
+	unchanged, removed := clusterDelta(aged, clusterFingerprints(aged), since)
+	assert.Empty(t, unchanged)
+	assert.Empty(t, removed)
+}
+
+func TestListSnapshots(t *testing.T) {
+	snapshots := newListSnapshots()
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	snapshots.now = func() time.Time { return now }
+
+	fingerprints := map[string]clusterFingerprint{"team-a/prod-a": {resourceVersion: "1"}}
+	token := snapshots.put("team-a", fingerprints)
+	assert.Equal(t, token, snapshots.put("team-a", map[string]clusterFingerprint{"team-a/prod-a": {resourceVersion: "1"}}), "unchanged list reuses the token")
+	assert.NotEqual(t, token, snapshots.put("team-a", map[string]clusterFingerprint{"team-a/prod-a": {resourceVersion: "2"}}))
+
+	_, ok := snapshots.get(token, "team-a")
+	assert.True(t, ok)
+	_, ok = snapshots.get(token, "team-b")
+	assert.False(t, ok, "other scope")
+	_, ok = snapshots.get("unknown", "team-a")
+	assert.False(t, ok)
+
+	now = now.Add(listSnapshotTTL)
+	_, ok = snapshots.get(token, "team-a")
+	assert.False(t, ok, "expired")
+
+	for i := range maxListSnapshots + 10 {
+		snapshots.put("team-a", map[string]clusterFingerprint{"team-a/prod-a": {nodeCount: i}})
+	}
+	assert.Len(t, snapshots.snapshots, maxListSnapshots)
+}
+
+func TestEnhancedProvider_ListClustersSinceToken(t *testing.T) {
+	p := newTestEnhancedProvider(t, newTestCluster("ready", "Provisioned"), newTestCluster("building", "Provisioning"))
+	ctx := context.Background()
+
+	result, err := p.handleListClusters(ctx, map[string]interface{}{})
+	require.NoError(t, err)
+	full := result.(map[string]interface{})
+	assert.Len(t, full["clusters"], 2)
+	assert.NotContains(t, full, "incremental")
+	token, _ := full["next_token"].(string)
+	require.NotEmpty(t, token)
+
+	result, err = p.handleListClusters(ctx, map[string]interface{}{"since_token": token})
+	require.NoError(t, err)
+	delta := result.(map[string]interface{})
+	assert.Equal(t, true, delta["incremental"])
+	assert.Empty(t, delta["clusters"])
+	assert.Equal(t, token, delta["next_token"])
+
+	t.Run("other filters", func(t *testing.T) {
+		result, err := p.handleListClusters(ctx, map[string]interface{}{"status": "Ready", "since_token": token})
+		require.NoError(t, err)
+		output := result.(map[string]interface{})
+		assert.NotContains(t, output, "incremental")
+		assert.Len(t, output["clusters"], 1)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		result, err := p.handleListClusters(ctx, map[string]interface{}{"since_token": "expired"})
+		require.NoError(t, err)
+		output := result.(map[string]interface{})
+		assert.NotContains(t, output, "incremental")
+		assert.Len(t, output["clusters"], 2)
+	})
+}
@@ -0,0 +1,261 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// toolDoc is the description of a core cluster tool as models read it: what
+// the tool does, typical arguments and the ways it fails. Provider and
+// EnhancedProvider both render their descriptions of these tools from
+// toolDocs, so the two cannot drift apart.
+type toolDoc struct {
+	summary string
+	details string
+	// enhancedDetails describes behavior only EnhancedProvider has, such as
+	// approvals, dry runs and namespaces.
+	enhancedDetails string
+	examples        []toolExample
+	failures        []toolFailure
+}
+
+// toolExample is a typical call of a tool. Arguments are keyed by their
+// camelCase names.
+type toolExample struct {
+	arguments map[string]interface{}
+	purpose   string
+}
+
+// toolFailure is a way a call of a tool fails.
+type toolFailure struct {
+	code  errors.ErrorCode
+	cause string
+	// enhanced marks failures only EnhancedProvider raises: from its input
+	// validation, namespaces, maintenance mode, admission policy or approvals.
+	enhanced bool
+}
+
+// toolDialect is how a provider names tool arguments and reports failures.
+type toolDialect struct {
+	// arguments maps the camelCase names of the arguments the provider
+	// accepts to its own names; nil accepts all arguments as they are.
+	arguments map[string]string
+	// enhanced reports failures with their error codes, and includes the
+	// behavior and failures only EnhancedProvider has.
+	enhanced bool
+}
+
+var (
+	enhancedDialect = toolDialect{enhanced: true}
+	basicDialect    = toolDialect{arguments: map[string]string{
+		"clusterName":       "cluster_name",
+		"templateName":      "template_name",
+		"kubernetesVersion": "kubernetes_version",
+		"variables":         "variables",
+		"nodePoolName":      "node_pool_name",
+		"replicas":          "replicas",
+	}}
+)
+
+// toolDocs are the descriptions of the core cluster tools, by tool name.
+var toolDocs = map[string]toolDoc{
+	"list_clusters": {
+		summary: "List all managed workload clusters and their current status.",
+		details: `Returns a summary of each cluster: its status (Pending, Provisioning, Ready, Deleting, Failed, Queued
+or Unknown), Kubernetes version, provider and ready and total node counts. Use it for an overview of the
+fleet or to find the name of a cluster before calling get_cluster.`,
+		enhancedDetails: `Lists the caller's namespace unless namespace is given. With sinceToken, only the clusters added or
+changed since the call that returned the token are listed, with the names of the removed ones.`,
+		examples: []toolExample{
+			{arguments: map[string]interface{}{}, purpose: "lists every cluster"},
+			{arguments: map[string]interface{}{"status": "Failed"}, purpose: "lists the clusters that failed to provision"},
+			{arguments: map[string]interface{}{"environment": "prod", "namespace": "team-a"}, purpose: "lists the production clusters of team-a"},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeInvalidInput, cause: "status is not one of the statuses above", enhanced: true},
+			{code: errors.CodeForbidden, cause: "the caller may not use the namespace", enhanced: true},
+			{code: errors.CodeKubernetesAPI, cause: "the management cluster cannot be reached; retry later"},
+		},
+	},
+	"get_cluster": {
+		summary: "Get detailed information for a specific cluster.",
+		details: `Returns the cluster's status and conditions, Kubernetes version, control plane endpoint, node pools with
+their replicas, and infrastructure details such as the provider and region. Use it to check on a cluster
+after create_cluster or scale_cluster, or to find the node pool names scale_cluster takes.`,
+		examples: []toolExample{
+			{arguments: map[string]interface{}{"clusterName": "prod-a"}, purpose: "describes prod-a"},
+			{arguments: map[string]interface{}{"clusterName": "prod-a", "namespace": "team-a"}, purpose: "describes prod-a in namespace team-a"},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeInvalidInput, cause: "clusterName is not a valid cluster name", enhanced: true},
+			{code: errors.CodeNotFound, cause: "no cluster of that name exists; check the name with list_clusters"},
+			{code: errors.CodeForbidden, cause: "the caller may not use the namespace", enhanced: true},
+		},
+	},
+	"create_cluster": {
+		summary: "Create a new workload cluster from a ClusterClass template.",
+		details: `Creates the cluster from an administrator-approved template with the given Kubernetes version and
+template variables, after checking the variables against the infrastructure provider. Provisioning takes
+several minutes; follow it with get_cluster.`,
+		enhancedDetails: `When more clusters are provisioning than the server allows, the creation may be Queued until one is
+done. Use dryRun to run the checks without creating anything, and list_presets for variable presets.`,
+		examples: []toolExample{
+			{
+				arguments: map[string]interface{}{"clusterName": "dev-a", "templateName": "aws-standard", "kubernetesVersion": "v1.31.0"},
+				purpose:   "creates dev-a with the template's default variables",
+			},
+			{
+				arguments: map[string]interface{}{"clusterName": "prod-b", "templateName": "aws-standard", "kubernetesVersion": "v1.31.0",
+					"variables": map[string]interface{}{"region": "eu-west-1", "workerCount": 3}},
+				purpose: "creates prod-b in eu-west-1 with three workers",
+			},
+			{
+				arguments: map[string]interface{}{"clusterName": "prod-b", "templateName": "aws-standard", "kubernetesVersion": "v1.31.0", "dryRun": true},
+				purpose:   "only checks whether prod-b could be created",
+			},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeInvalidInput, cause: "an argument is missing or malformed, e.g. a kubernetesVersion without the v prefix; the message lists each problem, and its suggestedFix line gives corrected arguments where possible", enhanced: true},
+			{code: errors.CodeNotFound, cause: "the template does not exist; list_cluster_templates lists them"},
+			{code: errors.CodeAlreadyExists, cause: "a cluster of that name exists; pick another name"},
+			{code: errors.CodeProviderValidation, cause: "the provider rejected the variables, e.g. an unknown region or instance type"},
+			{code: errors.CodeForbidden, cause: "the admission policy denied the creation, or the caller may not use the namespace", enhanced: true},
+			{code: errors.CodeForbidden, cause: "the cluster name is protected by the server, e.g. the management cluster's; pick another name", enhanced: true},
+			{code: errors.CodeUnavailable, cause: "the server is in maintenance mode and read-only", enhanced: true},
+		},
+	},
+	"delete_cluster": {
+		summary: "Delete a workload cluster and all its infrastructure.",
+		details: `Deletes the cluster and the machines, networks and load balancers of its provider. This cannot be
+undone and destroys every workload running on the cluster; confirm the cluster name with the user first.`,
+		enhancedDetails: `preview_delete_cluster lists what the deletion destroys to confirm with the user. Warns if other clusters depend on it (see get_cluster_dependencies). If approvals are enabled for the
+cluster's environment, the first call fails with the ID of a pending approval; once another identity
+approves it with approve_operation, call again with approvalId. If the server retains deletions, the
+cluster is only paused and torn down at the reported delete_after; until then undelete_cluster cancels
+the deletion.`,
+		examples: []toolExample{
+			{arguments: map[string]interface{}{"clusterName": "dev-a"}, purpose: "deletes dev-a"},
+			{arguments: map[string]interface{}{"clusterName": "prod-a", "approvalId": "1a2b3c4d"}, purpose: "deletes prod-a once approval 1a2b3c4d is approved"},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeNotFound, cause: "no cluster of that name exists"},
+			{code: errors.CodePreconditionFailed, cause: "the deletion awaits approval; the message and the approval_id detail give the approval ID", enhanced: true},
+			{code: errors.CodeForbidden, cause: "the admission policy denied the deletion, or the caller may not use the namespace", enhanced: true},
+			{code: errors.CodeForbidden, cause: "the cluster is protected by the server and cannot be deleted through it", enhanced: true},
+			{code: errors.CodeUnavailable, cause: "the server is in maintenance mode and read-only", enhanced: true},
+		},
+	},
+	"scale_cluster": {
+		summary: "Scale the worker nodes of a node pool in a cluster.",
+		details: `Sets the replica count of a node pool, a MachineDeployment or MachinePool, to add or remove worker
+nodes. The call returns once the new count is set; the nodes join or leave over the following minutes, as
+get_cluster_nodes shows. get_cluster lists the node pools of a cluster.`,
+		examples: []toolExample{
+			{arguments: map[string]interface{}{"clusterName": "prod-a", "nodePoolName": "workers", "replicas": 5}, purpose: "scales the workers pool of prod-a to five nodes"},
+			{arguments: map[string]interface{}{"clusterName": "dev-a", "nodePoolName": "workers", "replicas": 0}, purpose: "removes every worker of dev-a"},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeInvalidInput, cause: "replicas is negative or above the allowed maximum; the suggestedFix line of the message gives the nearest valid count", enhanced: true},
+			{code: errors.CodeNotFound, cause: "the cluster or node pool does not exist; get_cluster lists the node pools"},
+			{code: errors.CodeForbidden, cause: "the admission policy denied the change, or the caller may not use the namespace", enhanced: true},
+			{code: errors.CodeUnavailable, cause: "the server is in maintenance mode and read-only", enhanced: true},
+		},
+	},
+	"get_cluster_kubeconfig": {
+		summary: "Retrieve the kubeconfig to access a workload cluster.",
+		details: `Returns the admin kubeconfig of the cluster for kubectl or other Kubernetes clients. It holds
+credentials: never show it in full or store it. Prefer create_temporary_access to give a person access.`,
+		enhancedDetails: `If approvals are enabled for the cluster's environment, the first call fails with the ID of a pending
+approval; once another identity approves it with approve_operation, call again with approvalId.`,
+		examples: []toolExample{
+			{arguments: map[string]interface{}{"clusterName": "dev-a"}, purpose: "returns the kubeconfig of dev-a"},
+			{arguments: map[string]interface{}{"clusterName": "prod-a", "approvalId": "1a2b3c4d"}, purpose: "returns it once approval 1a2b3c4d is approved"},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeNotFound, cause: "the cluster or its kubeconfig does not exist yet; wait until the cluster is Provisioned"},
+			{code: errors.CodePreconditionFailed, cause: "the request awaits approval; the message and the approval_id detail give the approval ID", enhanced: true},
+		},
+	},
+	"get_cluster_nodes": {
+		summary: "List the nodes within a workload cluster.",
+		details: `Connects to the cluster's API server and lists its nodes with their status, roles, Kubernetes version,
+addresses and the GPUs and other accelerators each node advertises. The cluster must be provisioned.`,
+		examples: []toolExample{
+			{arguments: map[string]interface{}{"clusterName": "prod-a"}, purpose: "lists the nodes of prod-a"},
+		},
+		failures: []toolFailure{
+			{code: errors.CodeNotFound, cause: "no cluster of that name exists"},
+			{code: errors.CodeWorkloadCluster, cause: "the cluster's API server cannot be reached, e.g. while it is still provisioning"},
+			{code: errors.CodeTimeout, cause: "the cluster's API server did not answer in time; retry later"},
+		},
+	},
+}
+
+// describeTool renders the description of a core cluster tool in the
+// dialect of a provider. Examples with arguments the provider does not
+// accept are left out.
+func describeTool(name string, dialect toolDialect) string {
+	doc, ok := toolDocs[name]
+	if !ok {
+		panic(fmt.Sprintf("no description of tool %s", name))
+	}
+
+	var b strings.Builder
+	b.WriteString(doc.summary)
+	b.WriteString("\n")
+	b.WriteString(doc.details)
+	if dialect.enhanced && doc.enhancedDetails != "" {
+		b.WriteString("\n")
+		b.WriteString(doc.enhancedDetails)
+	}
+
+	var examples []string
+	for _, example := range doc.examples {
+		if arguments, ok := dialect.rename(example.arguments); ok {
+			data, err := json.Marshal(arguments)
+			if err != nil {
+				panic(fmt.Sprintf("example of tool %s cannot be encoded: %v", name, err))
+			}
+			examples = append(examples, fmt.Sprintf("- %s %s", data, example.purpose))
+		}
+	}
+	if len(examples) > 0 {
+		b.WriteString("\n\nExamples:\n")
+		b.WriteString(strings.Join(examples, "\n"))
+	}
+
+	var failures []string
+	for _, failure := range doc.failures {
+		switch {
+		case dialect.enhanced:
+			failures = append(failures, fmt.Sprintf("- %s: %s", failure.code, failure.cause))
+		case !failure.enhanced:
+			failures = append(failures, "- "+failure.cause)
+		}
+	}
+	if len(failures) > 0 {
+		b.WriteString("\n\nFails when:\n")
+		b.WriteString(strings.Join(failures, "\n"))
+	}
+	return b.String()
+}
+
+// rename returns example arguments with the names of the dialect, or false
+// if it does not accept one of them.
+func (d toolDialect) rename(arguments map[string]interface{}) (map[string]interface{}, bool) {
+	if d.arguments == nil {
+		return arguments, true
+	}
+	renamed := make(map[string]interface{}, len(arguments))
+	for argument, value := range arguments {
+		name, ok := d.arguments[argument]
+		if !ok {
+			return nil, false
+		}
+		renamed[name] = value
+	}
+	return renamed, true
+}
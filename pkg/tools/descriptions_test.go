@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestToolDocs(t *testing.T) {
+	provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logging.NewLogger(slog.LevelError, "text"), nil)
+	require.NoError(t, provider.RegisterTools())
+
+	for name, doc := range toolDocs {
+		t.Run(name, func(t *testing.T) {
+			tool, ok := provider.tools[name]
+			require.True(t, ok, "%s is not registered", name)
+			assert.Equal(t, describeTool(name, enhancedDialect), tool.Tool.Description)
+			assert.NotEmpty(t, doc.examples)
+			assert.NotEmpty(t, doc.failures)
+
+			for _, example := range doc.examples {
+				for argument := range example.arguments {
+					assert.True(t, hasArgument(tool, argument), "example of %s: %s has no argument %s", example.purpose, name, argument)
+				}
+			}
+		})
+	}
+}
+
+func TestDescribeTool(t *testing.T) {
+	tests := []struct {
+		name        string
+		dialect     toolDialect
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:    "enhanced provider",
+			dialect: enhancedDialect,
+			contains: []string{
+				"Delete a workload cluster and all its infrastructure.\n",
+				"call again with approvalId",
+				`- {"approvalId":"1a2b3c4d","clusterName":"prod-a"} deletes prod-a once approval 1a2b3c4d is approved`,
+				"- NOT_FOUND: no cluster of that name exists",
+				"- PRECONDITION_FAILED: the deletion awaits approval",
+			},
+		},
+		{
+			name:    "basic provider",
+			dialect: basicDialect,
+			contains: []string{
+				"Delete a workload cluster and all its infrastructure.\n",
+				`- {"cluster_name":"dev-a"} deletes dev-a`,
+				"Fails when:\n- no cluster of that name exists",
+			},
+			notContains: []string{"approvalId", "approval", "NOT_FOUND", "maintenance mode"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			description := describeTool("delete_cluster", tt.dialect)
+			for _, s := range tt.contains {
+				assert.Contains(t, description, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, description, s)
+			}
+		})
+	}
+
+	assert.Panics(t, func() { describeTool("upgrade_cluster", enhancedDialect) })
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// elicitationTimeout bounds how long create_cluster waits for the client
+	// to supply missing variables, which may involve asking a person.
+	elicitationTimeout = 2 * time.Minute
+
+	// elicitationMaxTokens bounds the client's reply to an elicitation request.
+	elicitationMaxTokens = 1024
+
+	elicitationSystemPrompt = `You are helping create a Kubernetes cluster from a Cluster API template.
+Some required template variables are missing. Ask the user for them if needed, then reply with only a
+JSON object mapping each variable name to its value, matching the variable's type. Omit variables you
+cannot provide.`
+)
+
+// SetElicitation enables asking the client, through MCP sampling, for required
+// template variables missing from create_cluster requests instead of failing
+// validation straight away.
+func (p *EnhancedProvider) SetElicitation(enabled bool) {
+	p.elicitation = enabled
+}
+
+// elicitVariables asks the client for the required template variables missing
+// from a create_cluster request and returns variables with the supplied values
+// added. Variables set by the request's preset are not asked for. Variables are returned unchanged when elicitation is disabled,
+// nothing is missing or the client cannot answer; create_cluster then reports
+// the missing variables as usual.
+func (p *EnhancedProvider) elicitVariables(ctx context.Context, session *mcp.ServerSession, templateName, preset string, variables map[string]interface{}) map[string]interface{} {
+	if !p.elicitation || session == nil || templateName == "" {
+		return variables
+	}
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return variables
+	}
+
+	logger := p.logger.WithContext(ctx)
+	requested := variables
+	if preset != "" {
+		// An invalid preset is reported by create_cluster
+		if requested, err = svc.ApplyPreset(preset, variables); err != nil {
+			return variables
+		}
+	}
+	missing, err := svc.MissingClusterVariables(ctx, templateName, requested)
+	if err != nil || len(missing) == 0 {
+		return variables
+	}
+
+	elicitCtx, cancel := context.WithTimeout(ctx, elicitationTimeout)
+	defer cancel()
+
+	result, err := session.CreateMessage(elicitCtx, &mcp.CreateMessageParams{
+		SystemPrompt: elicitationSystemPrompt,
+		Messages: []*mcp.SamplingMessage{{
+			Role:    "user",
+			Content: &mcp.TextContent{Text: elicitationPrompt(templateName, missing)},
+		}},
+		MaxTokens: elicitationMaxTokens,
+	})
+	if err != nil {
+		logger.WithError(err).Debug("Client did not supply missing template variables", "template", templateName)
+		return variables
+	}
+
+	values, err := parseElicitedValues(result.Content, missing)
+	if err != nil {
+		logger.WithError(err).Warn("Ignoring invalid elicitation response", "template", templateName)
+		return variables
+	}
+
+	merged := make(map[string]interface{}, len(variables)+len(values))
+	for name, value := range variables {
+		merged[name] = value
+	}
+	names := make([]string, 0, len(values))
+	for name, value := range values {
+		merged[name] = value
+		names = append(names, name)
+	}
+	logger.Info("Elicited missing template variables", "template", templateName, "variables", names)
+	return merged
+}
+
+// elicitationPrompt describes the missing variables, with their schema as a
+// hint for the values expected.
+func elicitationPrompt(templateName string, missing []api.TemplateVariable) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cluster template '%s' requires these variables:\n", templateName)
+	for _, variable := range missing {
+		fmt.Fprintf(&b, "- %s (%s)", variable.Name, variable.Type)
+		if variable.Description != "" {
+			fmt.Fprintf(&b, ": %s", variable.Description)
+		}
+		if len(variable.Enum) > 0 {
+			data, _ := json.Marshal(variable.Enum)
+			fmt.Fprintf(&b, " Allowed values: %s.", data)
+		}
+		if variable.Example != nil {
+			data, _ := json.Marshal(variable.Example)
+			fmt.Fprintf(&b, " Example: %s.", data)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// parseElicitedValues extracts the values of the missing variables from the
+// client's reply, a JSON object optionally wrapped in a Markdown code block.
+func parseElicitedValues(content mcp.Content, missing []api.TemplateVariable) (map[string]interface{}, error) {
+	text, ok := content.(*mcp.TextContent)
+	if !ok {
+		return nil, errors.New(errors.CodeInvalidInput, "elicitation response is not text")
+	}
+
+	reply := strings.TrimSpace(text.Text)
+	reply = strings.TrimPrefix(reply, "```json")
+	reply = strings.TrimPrefix(reply, "```")
+	reply = strings.TrimSuffix(reply, "```")
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(reply)), &object); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "elicitation response is not a JSON object")
+	}
+
+	values := make(map[string]interface{}, len(missing))
+	for _, variable := range missing {
+		if value, ok := object[variable.Name]; ok && value != nil {
+			values[variable.Name] = value
+		}
+	}
+	return values, nil
+}
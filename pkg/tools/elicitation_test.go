@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+)
+
+var testMissingVariables = []api.TemplateVariable{
+	{Name: "region", Type: "string", Description: "AWS region to deploy the cluster in", Example: "us-west-2"},
+	{Name: "osFamily", Type: "string", Enum: []interface{}{"ubuntu", "flatcar"}},
+}
+
+func TestElicitationPrompt(t *testing.T) {
+	prompt := elicitationPrompt("aws-standard", testMissingVariables)
+
+	assert.Contains(t, prompt, "Cluster template 'aws-standard' requires")
+	assert.Contains(t, prompt, `- region (string): AWS region to deploy the cluster in Example: "us-west-2".`)
+	assert.Contains(t, prompt, `- osFamily (string) Allowed values: ["ubuntu","flatcar"].`)
+}
+
+func TestParseElicitedValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		content mcp.Content
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "JSON object",
+			content: &mcp.TextContent{Text: `{"region": "us-east-1", "osFamily": "flatcar"}`},
+			want:    map[string]interface{}{"region": "us-east-1", "osFamily": "flatcar"},
+		},
+		{
+			name:    "code block",
+			content: &mcp.TextContent{Text: "```json\n{\"region\": \"eu-west-1\"}\n```"},
+			want:    map[string]interface{}{"region": "eu-west-1"},
+		},
+		{
+			name:    "ignores unrequested and null values",
+			content: &mcp.TextContent{Text: `{"region": null, "osFamily": "ubuntu", "nodeCount": 100}`},
+			want:    map[string]interface{}{"osFamily": "ubuntu"},
+		},
+		{
+			name:    "not JSON",
+			content: &mcp.TextContent{Text: "Please use us-east-1."},
+			wantErr: true,
+		},
+		{
+			name:    "not text",
+			content: nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := parseElicitedValues(tt.content, testMissingVariables)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, values)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+)
+
+// localeMetaKey is the request metadata key with which a caller asks for
+// error messages in a language, as language tags in the format of the
+// Accept-Language header, e.g. "de" or "es-MX, es;q=0.9".
+const localeMetaKey = "capi-mcp.io/locale"
+
+// remediationDetail is the error detail with the remediation text of the
+// error code in the caller's language.
+const remediationDetail = "remediation"
+
+// SetMessageCatalog configures the catalog user-facing error messages are
+// localized with. Without one, errors are reported in English and without
+// remediation text.
+func (p *EnhancedProvider) SetMessageCatalog(catalog *i18n.Catalog) {
+	p.messages = catalog
+}
+
+// callLocale returns the locale a tool call asked for in its metadata, or the
+// default locale.
+func (p *EnhancedProvider) callLocale(meta mcp.Meta) string {
+	if p.messages == nil {
+		return i18n.DefaultLocale
+	}
+	tags, _ := meta[localeMetaKey].(string)
+	return p.messages.Match(tags)
+}
+
+// requestLocale returns the locale a REST request asked for in its
+// Accept-Language header, or the default locale.
+func (p *EnhancedProvider) requestLocale(r *http.Request) string {
+	if p.messages == nil {
+		return i18n.DefaultLocale
+	}
+	return p.messages.Match(r.Header.Get("Accept-Language"))
+}
+
+// localizedError returns the error of a failed call as reported to the
+// caller: its code and details, its user message in the caller's locale and
+// the remediation text of its code.
+func (p *EnhancedProvider) localizedError(locale string, err error) *errors.Error {
+	localized := errors.New(errors.GetErrorCode(err), errors.GetUserMessage(err))
+	if e, ok := err.(*errors.Error); ok && e.Details != nil {
+		localized.WithDetailsMap(e.Details)
+	}
+	if p.messages == nil {
+		return localized
+	}
+
+	localized.Message = p.messages.Localize(locale, err)
+	if remediation := p.messages.Remediation(locale, localized.Code); remediation != "" {
+		localized.WithDetails(remediationDetail, remediation)
+	}
+	return localized
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestWithCorrelationID_Locale(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	catalog, err := i18n.NewCatalog("de")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		catalog     *i18n.Catalog
+		meta        mcp.Meta
+		message     string
+		remediation string
+	}{
+		{
+			name:    "no catalog",
+			message: "cluster name cannot be empty (correlation ID: ",
+		},
+		{
+			name:        "default locale",
+			catalog:     catalog,
+			message:     "Der Clustername darf nicht leer sein (correlation ID: ",
+			remediation: "Korrigieren Sie die in der Meldung genannten Argumente und wiederholen Sie den Aufruf.",
+		},
+		{
+			name:        "locale of the call",
+			catalog:     catalog,
+			meta:        mcp.Meta{localeMetaKey: "es-ES, en;q=0.5"},
+			message:     "el nombre del clúster no puede estar vacío (correlation ID: ",
+			remediation: "Corrija los argumentos indicados en el mensaje y repita la llamada.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+			if tt.catalog != nil {
+				provider.SetMessageCatalog(tt.catalog)
+			}
+			handler := withCorrelationID(provider, func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterArgs]) (*mcp.CallToolResultFor[api.GetClusterOutput], error) {
+				err := provider.validator.ValidateClusterName(params.Arguments.ClusterName)
+				return nil, provider.sanitizeError(err.(*errors.Error).WithDetails("field", "clusterName"))
+			})
+
+			_, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[EnhancedGetClusterArgs]{Name: "get_cluster", Meta: tt.meta})
+			require.Error(t, err)
+			var e *errors.Error
+			require.ErrorAs(t, err, &e)
+			assert.Equal(t, errors.CodeInvalidInput, e.Code, "codes are not localized")
+			assert.True(t, strings.HasPrefix(e.Message, tt.message), e.Message)
+			assert.Equal(t, "clusterName", e.Details["field"])
+			assert.NotEmpty(t, e.Details[logging.FieldCorrelationID])
+			if tt.remediation == "" {
+				assert.NotContains(t, e.Details, remediationDetail)
+			} else {
+				assert.Equal(t, tt.remediation, e.Details[remediationDetail])
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// maintenanceRetryInterval is how long callers are asked to wait when a
+// maintenance window has no planned end.
+const maintenanceRetryInterval = 5 * time.Minute
+
+// Maintenance is the maintenance mode of the server, shared by the providers
+// of all identities. While it is active, mutating tools fail with a retry
+// hint and read tools keep working. A window with a planned end ends by
+// itself at that time.
+type Maintenance struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	active bool
+	since  time.Time
+	until  time.Time // zero if the end is not planned
+	reason string
+}
+
+// NewMaintenance creates the maintenance mode, initially inactive.
+func NewMaintenance() *Maintenance {
+	return &Maintenance{now: time.Now}
+}
+
+// Start activates maintenance mode until the given time, or until End is
+// called if until is zero. Starting it while active updates the window.
+func (m *Maintenance) Start(until time.Time, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.activeLocked() {
+		m.since = m.now()
+	}
+	m.active = true
+	m.until = until
+	m.reason = reason
+}
+
+// End deactivates maintenance mode. It reports whether it was active.
+func (m *Maintenance) End() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wasActive := m.activeLocked()
+	m.active = false
+	return wasActive
+}
+
+// Status returns the current maintenance window.
+func (m *Maintenance) Status() api.MaintenanceOutput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.activeLocked() {
+		return api.MaintenanceOutput{}
+	}
+	status := api.MaintenanceOutput{
+		Active: true,
+		Since:  m.since.UTC().Format(time.RFC3339),
+		Reason: m.reason,
+	}
+	if !m.until.IsZero() {
+		status.Until = m.until.UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// check returns an error with a retry hint if maintenance mode is active.
+func (m *Maintenance) check(tool string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.activeLocked() {
+		return nil
+	}
+
+	retryAfter := m.until
+	if retryAfter.IsZero() {
+		retryAfter = m.now().Add(maintenanceRetryInterval)
+	}
+	message := "maintenance in progress"
+	if m.reason != "" {
+		message += " (" + m.reason + ")"
+	}
+	message = fmt.Sprintf("%s: %s and other changes are unavailable, read-only tools keep working; try again after %s",
+		message, tool, retryAfter.UTC().Format(time.RFC3339))
+	return errors.New(errors.CodeUnavailable, message).
+		WithDetails("retry_after", retryAfter.UTC().Format(time.RFC3339)).
+		WithDetails("retry_after_seconds", int(retryAfter.Sub(m.now()).Round(time.Second).Seconds()))
+}
+
+// activeLocked reports whether maintenance mode is active, ending a window
+// whose planned end has passed.
+func (m *Maintenance) activeLocked() bool {
+	if m.active && !m.until.IsZero() && !m.now().Before(m.until) {
+		m.active = false
+	}
+	return m.active
+}
+
+// SetMaintenance configures the maintenance mode that holds back the
+// provider's mutating tools.
+func (p *EnhancedProvider) SetMaintenance(maintenance *Maintenance) {
+	p.maintenance = maintenance
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestMaintenance(t *testing.T) {
+	maintenance := NewMaintenance()
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	maintenance.now = func() time.Time { return now }
+
+	assert.NoError(t, maintenance.check("delete_cluster"))
+	assert.False(t, maintenance.End())
+
+	maintenance.Start(now.Add(time.Hour), "management cluster upgrade")
+	assert.Equal(t, api.MaintenanceOutput{
+		Active: true,
+		Since:  "2026-03-02T10:00:00Z",
+		Until:  "2026-03-02T11:00:00Z",
+		Reason: "management cluster upgrade",
+	}, maintenance.Status())
+
+	err := maintenance.check("delete_cluster")
+	require.Error(t, err)
+	assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+	assert.Contains(t, errors.GetUserMessage(err), "maintenance in progress (management cluster upgrade)")
+	assert.Contains(t, errors.GetUserMessage(err), "try again after 2026-03-02T11:00:00Z")
+	details := err.(*errors.Error).Details
+	assert.Equal(t, "2026-03-02T11:00:00Z", details["retry_after"])
+	assert.Equal(t, 3600, details["retry_after_seconds"])
+
+	t.Run("ends at the planned time", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		assert.NoError(t, maintenance.check("delete_cluster"))
+		assert.False(t, maintenance.Status().Active)
+	})
+
+	t.Run("without a planned end", func(t *testing.T) {
+		maintenance.Start(time.Time{}, "")
+		err := maintenance.check("scale_cluster")
+		require.Error(t, err)
+		assert.Equal(t, int(maintenanceRetryInterval.Seconds()), err.(*errors.Error).Details["retry_after_seconds"])
+		assert.Empty(t, maintenance.Status().Until)
+
+		assert.True(t, maintenance.End())
+		assert.NoError(t, maintenance.check("scale_cluster"))
+	})
+}
+
+func TestEnhancedProvider_StartMaintenance(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	maintenance := NewMaintenance()
+	provider.SetMaintenance(maintenance)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		input     map[string]interface{}
+		wantErr   bool
+		wantUntil bool
+	}{
+		{name: "until end_maintenance", input: map[string]interface{}{"reason": "upgrade"}},
+		{name: "duration", input: map[string]interface{}{"duration": "30m"}, wantUntil: true},
+		{name: "until", input: map[string]interface{}{"until": time.Now().Add(time.Hour).Format(time.RFC3339)}, wantUntil: true},
+		{name: "until and duration", input: map[string]interface{}{"until": time.Now().Add(time.Hour).Format(time.RFC3339), "duration": "30m"}, wantErr: true},
+		{name: "until in the past", input: map[string]interface{}{"until": "2020-01-01T00:00:00Z"}, wantErr: true},
+		{name: "invalid duration", input: map[string]interface{}{"duration": "-5m"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maintenance.End()
+
+			result, err := provider.handleStartMaintenance(ctx, tt.input)
+			if tt.wantErr {
+				assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+				assert.False(t, maintenance.Status().Active)
+				return
+			}
+			require.NoError(t, err)
+			output := result.(map[string]interface{})
+			assert.Equal(t, true, output["active"])
+			_, hasUntil := output["until"]
+			assert.Equal(t, tt.wantUntil, hasUntil)
+
+			// Mutating tools are refused with a retry hint
+			err = provider.admit(ctx, "create_cluster", nil)
+			assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+			sanitized := provider.sanitizeError(err).(*errors.Error)
+			assert.Contains(t, sanitized.Details, "retry_after")
+		})
+	}
+
+	result, err := provider.handleEndMaintenance(ctx, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, false, result.(map[string]interface{})["active"])
+	assert.NoError(t, provider.admit(ctx, "create_cluster", nil))
+}
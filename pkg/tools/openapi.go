@@ -0,0 +1,186 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAPISpec returns the OpenAPI 3 document describing the REST API. The
+// schemas of request bodies are generated from the tool arguments and those
+// of responses from the api/v1 types, so the document cannot drift from what
+// the tools accept and return.
+func OpenAPISpec(version string) ([]byte, error) {
+	generator := &schemaGenerator{schemas: make(map[string]interface{})}
+	errorSchema := generator.schema(reflect.TypeFor[RESTError]())
+
+	paths := make(map[string]map[string]interface{})
+	for _, route := range restRoutes() {
+		parameters := []interface{}{}
+		excluded := make(map[string]bool)
+		for _, param := range route.params {
+			excluded[param.name] = true
+			parameters = append(parameters, map[string]interface{}{
+				"name":     param.name,
+				"in":       param.in,
+				"required": param.required,
+				"schema":   kindSchema(param.kind),
+			})
+		}
+		excluded["apiVersion"] = true
+
+		operation := map[string]interface{}{
+			"operationId": route.tool,
+			"summary":     route.summary,
+			"description": "Calls the " + route.tool + " tool.",
+			"parameters":  parameters,
+			"responses": map[string]interface{}{
+				strconv.Itoa(route.status): map[string]interface{}{
+					"description": http.StatusText(route.status),
+					"content":     jsonContent(generator.schema(route.output)),
+				},
+				"default": map[string]interface{}{
+					"description": "The error of the tool call",
+					"content": jsonContent(map[string]interface{}{
+						"type":       "object",
+						"properties": map[string]interface{}{"error": errorSchema},
+						"required":   []string{"error"},
+					}),
+				},
+			},
+		}
+		if route.body != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(generator.object(route.body, excluded)),
+			}
+		}
+
+		path := RESTPathPrefix + route.pattern
+		if paths[path] == nil {
+			paths[path] = make(map[string]interface{})
+		}
+		paths[path][strings.ToLower(route.method)] = operation
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "CAPI MCP Server REST API",
+			"description": "Cluster API workload cluster provisioning, backed by the same handlers as the MCP tools.",
+			"version":     version,
+		},
+		"security": []interface{}{map[string]interface{}{"bearerAuth": []string{}}},
+		"paths":    paths,
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": generator.schemas,
+		},
+	}, "", "  ")
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+// kindSchema returns the schema of a path or query parameter.
+func kindSchema(kind reflect.Kind) map[string]interface{} {
+	switch kind {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// schemaGenerator generates JSON schemas from Go types by their JSON
+// encoding. Named structs are added to the component schemas and referenced.
+type schemaGenerator struct {
+	schemas map[string]interface{}
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t == reflect.TypeFor[time.Time]() {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return g.object(t, nil)
+		}
+		if _, ok := g.schemas[t.Name()]; !ok {
+			// Reserve the name first, for types referring to themselves
+			g.schemas[t.Name()] = nil
+			g.schemas[t.Name()] = g.object(t, nil)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		// interface{}: any JSON value
+		return map[string]interface{}{}
+	}
+}
+
+// object returns the schema of a struct, leaving out the excluded fields.
+// Fields without omitempty are required.
+func (g *schemaGenerator) object(t reflect.Type, excluded map[string]bool) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	g.addFields(t, excluded, properties, &required)
+
+	object := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		slices.Sort(required)
+		object["required"] = required
+	}
+	return object
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, excluded map[string]bool, properties map[string]interface{}, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(field.Type, excluded, properties, required)
+			continue
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if excluded[name] {
+			continue
+		}
+		properties[name] = g.schema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
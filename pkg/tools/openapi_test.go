@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPISpec(t *testing.T) {
+	data, err := OpenAPISpec("v1.2.3")
+	require.NoError(t, err)
+
+	var spec struct {
+		Info  map[string]interface{} `json:"info"`
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Parameters  []struct {
+				Name string `json:"name"`
+				In   string `json:"in"`
+			} `json:"parameters"`
+			RequestBody struct {
+				Content map[string]struct {
+					Schema struct {
+						Properties map[string]interface{} `json:"properties"`
+						Required   []string               `json:"required"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.Equal(t, "v1.2.3", spec.Info["version"])
+
+	clusters := spec.Paths["/api/v1/clusters"]
+	assert.Equal(t, "list_clusters", clusters["get"].OperationID)
+	assert.Equal(t, "create_cluster", clusters["post"].OperationID)
+	create := clusters["post"].RequestBody.Content["application/json"].Schema
+	assert.Contains(t, create.Required, "clusterName")
+	assert.Contains(t, create.Required, "templateName")
+	assert.NotContains(t, create.Properties, "namespace", "namespace is a query parameter")
+
+	scale := spec.Paths["/api/v1/clusters/{clusterName}/scale"]["post"]
+	assert.Equal(t, "clusterName", scale.Parameters[0].Name)
+	assert.Equal(t, "path", scale.Parameters[0].In)
+	assert.NotContains(t, scale.RequestBody.Content["application/json"].Schema.Properties, "clusterName")
+	assert.Contains(t, scale.RequestBody.Content["application/json"].Schema.Properties, "replicas")
+
+	for _, name := range []string{"ListClustersOutput", "ClusterSummary", "GetClusterOutput", "CreateClusterOutput", "RESTError"} {
+		assert.Contains(t, spec.Components.Schemas, name)
+	}
+}
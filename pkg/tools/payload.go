@@ -0,0 +1,239 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+const (
+	// payloadURIPrefix prefixes the URIs of the resources large results are
+	// published as.
+	payloadURIPrefix = "capi-mcp://payloads/"
+
+	// maxPayloads bounds the number of large results held at once; the oldest
+	// are dropped first.
+	maxPayloads = 100
+)
+
+// payload is a large tool result held for chunked retrieval.
+type payload struct {
+	id        string
+	chunks    []string
+	size      int
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+// payloadStore holds tool results too large to return in a single message,
+// split into chunks no larger than chunkSize bytes.
+type payloadStore struct {
+	chunkSize int
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	payloads map[string]*payload
+}
+
+// newPayloadStore creates a store splitting results into chunks of chunkSize
+// bytes, each held for ttl.
+func newPayloadStore(chunkSize int, ttl time.Duration) *payloadStore {
+	return &payloadStore{
+		chunkSize: chunkSize,
+		ttl:       ttl,
+		now:       time.Now,
+		payloads:  make(map[string]*payload),
+	}
+}
+
+// put stores data and returns the stored payload along with the IDs of the
+// payloads that expired or were evicted to make room.
+func (s *payloadStore) put(data string) (*payload, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := s.expireLocked()
+	for len(s.payloads) >= maxPayloads {
+		oldest := s.oldestLocked()
+		delete(s.payloads, oldest)
+		removed = append(removed, oldest)
+	}
+
+	now := s.now()
+	stored := &payload{
+		id:        uuid.NewString(),
+		chunks:    splitChunks(data, s.chunkSize),
+		size:      len(data),
+		createdAt: now,
+		expiresAt: now.Add(s.ttl),
+	}
+	s.payloads[stored.id] = stored
+	return stored, removed
+}
+
+// get returns a stored payload, or nil if it does not exist or has expired.
+func (s *payloadStore) get(id string) *payload {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.payloads[id]
+	if !ok || !s.now().Before(stored.expiresAt) {
+		return nil
+	}
+	return stored
+}
+
+// expireLocked drops expired payloads and returns their IDs.
+func (s *payloadStore) expireLocked() []string {
+	var expired []string
+	now := s.now()
+	for id, stored := range s.payloads {
+		if !now.Before(stored.expiresAt) {
+			delete(s.payloads, id)
+			expired = append(expired, id)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+// oldestLocked returns the ID of the oldest payload.
+func (s *payloadStore) oldestLocked() string {
+	var oldest *payload
+	for _, stored := range s.payloads {
+		if oldest == nil || stored.createdAt.Before(oldest.createdAt) {
+			oldest = stored
+		}
+	}
+	return oldest.id
+}
+
+// splitChunks splits data into chunks of at most size bytes without splitting
+// UTF-8 encoded characters.
+func splitChunks(data string, size int) []string {
+	var chunks []string
+	for len(data) > size {
+		end := size
+		for end > 0 && !utf8.RuneStart(data[end]) {
+			end--
+		}
+		if end == 0 {
+			end = size
+		}
+		chunks = append(chunks, data[:end])
+		data = data[end:]
+	}
+	return append(chunks, data)
+}
+
+// SetOutputChunking configures how results larger than chunkSize bytes are
+// delivered. Such results are held for ttl and returned as a reference to a
+// resource holding the whole result and to chunks retrievable with the
+// get_output_chunk tool, so clients with message size limits can still read
+// them. A non-positive chunkSize returns every result in a single message.
+func (p *EnhancedProvider) SetOutputChunking(chunkSize int, ttl time.Duration) {
+	if chunkSize <= 0 {
+		p.payloads = nil
+		return
+	}
+	p.payloads = newPayloadStore(chunkSize, ttl)
+}
+
+// chunkedContent renders a handler result like resultContent, except that
+// results larger than the configured chunk size are held for chunked
+// retrieval and replaced by a reference to them.
+func (p *EnhancedProvider) chunkedContent(result interface{}) []mcp.Content {
+	content := resultContent(result)
+	text, ok := content[0].(*mcp.TextContent)
+	if p.payloads == nil || !ok || len(text.Text) <= p.payloads.chunkSize {
+		return content
+	}
+
+	stored, removed := p.payloads.put(text.Text)
+	p.publishPayload(stored, removed)
+
+	reference := api.ChunkedOutput{
+		PayloadID:   stored.id,
+		TotalChunks: len(stored.chunks),
+		TotalBytes:  stored.size,
+		ResourceURI: payloadURIPrefix + stored.id,
+		ExpiresAt:   stored.expiresAt.UTC().Format(time.RFC3339),
+		Message: fmt.Sprintf("The result is %d bytes, too large to return at once. Read the resource %s, or call get_output_chunk with payloadId %s for chunks 0 to %d, and concatenate them.",
+			stored.size, payloadURIPrefix+stored.id, stored.id, len(stored.chunks)-1),
+	}
+	referenceData, _ := json.MarshalIndent(reference, "", "  ")
+	return []mcp.Content{&mcp.TextContent{Text: string(referenceData)}}
+}
+
+// publishPayload publishes a stored result as an MCP resource and withdraws
+// the resources of expired results.
+func (p *EnhancedProvider) publishPayload(stored *payload, removed []string) {
+	if p.mcpServer == nil {
+		return
+	}
+	if len(removed) > 0 {
+		uris := make([]string, 0, len(removed))
+		for _, id := range removed {
+			uris = append(uris, payloadURIPrefix+id)
+		}
+		p.mcpServer.RemoveResources(uris...)
+	}
+
+	uri := payloadURIPrefix + stored.id
+	p.mcpServer.AddResources(&mcp.ServerResource{
+		Resource: &mcp.Resource{
+			URI:         uri,
+			Name:        "payload-" + stored.id,
+			Description: "Tool result held until " + stored.expiresAt.UTC().Format(time.RFC3339),
+			MIMEType:    "application/json",
+		},
+		Handler: func(ctx context.Context, session *mcp.ServerSession, params *mcp.ReadResourceParams) (*mcp.ReadResourceResult, error) {
+			current := p.payloads.get(stored.id)
+			if current == nil {
+				return nil, errors.New(errors.CodeNotFound, fmt.Sprintf("payload '%s' has expired", stored.id))
+			}
+			return &mcp.ReadResourceResult{
+				Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "application/json", Text: strings.Join(current.chunks, "")}},
+			}, nil
+		},
+	})
+}
+
+// outputChunk returns a chunk of a large tool result.
+func (p *EnhancedProvider) outputChunk(input api.GetOutputChunkInput) (*api.GetOutputChunkOutput, error) {
+	if p.payloads == nil {
+		return nil, errors.New(errors.CodeUnavailable, "output chunking is not enabled")
+	}
+	if input.PayloadID == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "payload ID is required")
+	}
+
+	stored := p.payloads.get(input.PayloadID)
+	if stored == nil {
+		return nil, errors.New(errors.CodeNotFound,
+			fmt.Sprintf("payload '%s' not found or expired - call the original tool again", input.PayloadID))
+	}
+	if input.Chunk < 0 || input.Chunk >= len(stored.chunks) {
+		return nil, errors.New(errors.CodeInvalidInput,
+			fmt.Sprintf("chunk must be between 0 and %d, got %d", len(stored.chunks)-1, input.Chunk))
+	}
+
+	return &api.GetOutputChunkOutput{
+		PayloadID:   stored.id,
+		Chunk:       input.Chunk,
+		TotalChunks: len(stored.chunks),
+		Data:        stored.chunks[input.Chunk],
+	}, nil
+}
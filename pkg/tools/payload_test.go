@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestSplitChunks(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		size int
+		want []string
+	}{
+		{name: "fits", data: "abc", size: 4, want: []string{"abc"}},
+		{name: "exact multiple", data: "abcdef", size: 3, want: []string{"abc", "def"}},
+		{name: "remainder", data: "abcdefg", size: 3, want: []string{"abc", "def", "g"}},
+		{name: "keeps characters whole", data: "aé€b", size: 3, want: []string{"aé", "€", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := splitChunks(tt.data, tt.size)
+			assert.Equal(t, tt.want, chunks)
+			assert.Equal(t, tt.data, strings.Join(chunks, ""))
+		})
+	}
+}
+
+func TestPayloadStore(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	store := newPayloadStore(4, time.Minute)
+	store.now = func() time.Time { return now }
+
+	stored, removed := store.put("0123456789")
+	assert.Empty(t, removed)
+	assert.Equal(t, []string{"0123", "4567", "89"}, stored.chunks)
+	assert.Same(t, stored, store.get(stored.id))
+
+	now = now.Add(time.Minute)
+	assert.Nil(t, store.get(stored.id))
+
+	_, removed = store.put("abcdef")
+	assert.Equal(t, []string{stored.id}, removed)
+}
+
+func TestEnhancedProvider_ChunkedContent(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	output := &api.GetClusterKubeconfigOutput{Kubeconfig: strings.Repeat("x", 5000)}
+
+	t.Run("disabled", func(t *testing.T) {
+		content := provider.chunkedContent(output)
+		require.Len(t, content, 1)
+		assert.Contains(t, content[0].(*mcp.TextContent).Text, output.Kubeconfig)
+	})
+
+	provider.SetOutputChunking(2048, time.Minute)
+
+	t.Run("small result", func(t *testing.T) {
+		content := provider.chunkedContent(&api.GetClusterKubeconfigOutput{Kubeconfig: "small"})
+		assert.Contains(t, content[0].(*mcp.TextContent).Text, `"kubeconfig": "small"`)
+	})
+
+	t.Run("large result", func(t *testing.T) {
+		content := provider.chunkedContent(output)
+		require.Len(t, content, 1)
+
+		var reference api.ChunkedOutput
+		require.NoError(t, json.Unmarshal([]byte(content[0].(*mcp.TextContent).Text), &reference))
+		assert.Equal(t, 3, reference.TotalChunks)
+		assert.Equal(t, payloadURIPrefix+reference.PayloadID, reference.ResourceURI)
+
+		var data strings.Builder
+		for i := 0; i < reference.TotalChunks; i++ {
+			chunk, err := provider.outputChunk(api.GetOutputChunkInput{PayloadID: reference.PayloadID, Chunk: i})
+			require.NoError(t, err)
+			data.WriteString(chunk.Data)
+		}
+		assert.Equal(t, reference.TotalBytes, data.Len())
+
+		var rebuilt api.GetClusterKubeconfigOutput
+		require.NoError(t, json.Unmarshal([]byte(data.String()), &rebuilt))
+		assert.Equal(t, output.Kubeconfig, rebuilt.Kubeconfig)
+
+		_, err := provider.outputChunk(api.GetOutputChunkInput{PayloadID: reference.PayloadID, Chunk: 3})
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+
+	t.Run("unknown payload", func(t *testing.T) {
+		_, err := provider.outputChunk(api.GetOutputChunkInput{PayloadID: "missing"})
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err))
+	})
+}
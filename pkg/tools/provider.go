@@ -31,20 +31,14 @@ func (p *Provider) RegisterTools() error {
 	// Register list_clusters tool
 	p.server.AddTools(mcp.NewServerTool(
 		"list_clusters",
-		`Lists all managed workload clusters and their current status.
-Returns a summary of all clusters managed by this CAPI management cluster, including their
-current phase (e.g., Provisioned, Provisioning, Failed), Kubernetes version, and node count.
-This tool is useful for getting an overview of the infrastructure under management.`,
+		describeTool("list_clusters", basicDialect),
 		p.handleListClusters,
 	))
 
 	// Register get_cluster tool
 	p.server.AddTools(mcp.NewServerTool(
 		"get_cluster",
-		`Gets detailed information for a specific cluster.
-Retrieves comprehensive details about a single cluster including its status, conditions,
-node pools, endpoint information, and infrastructure-specific details. Use this tool
-when you need in-depth information about a particular cluster's configuration and state.`,
+		describeTool("get_cluster", basicDialect),
 		p.handleGetCluster,
 		mcp.Input(
 			mcp.Property("cluster_name", mcp.Required(true), mcp.Description("The name of the cluster to retrieve")),
@@ -54,10 +48,7 @@ when you need in-depth information about a particular cluster's configuration an
 	// Register create_cluster tool
 	p.server.AddTools(mcp.NewServerTool(
 		"create_cluster",
-		`Creates a new workload cluster from a pre-defined ClusterClass template.
-This tool initiates the creation of a new Kubernetes cluster using a safe, administrator-approved
-template. The operation is asynchronous and will wait for the cluster to be fully provisioned
-before returning (or timeout after 10 minutes).`,
+		describeTool("create_cluster", basicDialect),
 		p.handleCreateCluster,
 		mcp.Input(
 			mcp.Property("cluster_name", mcp.Required(true), mcp.Description("Unique name for the new cluster")),
@@ -70,10 +61,7 @@ before returning (or timeout after 10 minutes).`,
 	// Register delete_cluster tool
 	p.server.AddTools(mcp.NewServerTool(
 		"delete_cluster",
-		`Deletes a specified workload cluster and all its associated resources.
-This tool initiates the deletion of a cluster and all its infrastructure. The operation
-is asynchronous and will wait for complete deletion before returning.
-WARNING: This operation is irreversible and will delete all workloads running on the cluster.`,
+		describeTool("delete_cluster", basicDialect),
 		p.handleDeleteCluster,
 		mcp.Input(
 			mcp.Property("cluster_name", mcp.Required(true), mcp.Description("Name of the cluster to delete")),
@@ -83,9 +71,7 @@ WARNING: This operation is irreversible and will delete all workloads running on
 	// Register scale_cluster tool
 	p.server.AddTools(mcp.NewServerTool(
 		"scale_cluster",
-		`Scales the number of worker nodes in a specific node pool (MachineDeployment).
-Adjusts the replica count for a node pool, allowing you to scale the cluster capacity
-up or down. The operation waits for the scaling to complete before returning.`,
+		describeTool("scale_cluster", basicDialect),
 		p.handleScaleCluster,
 		mcp.Input(
 			mcp.Property("cluster_name", mcp.Required(true), mcp.Description("Name of the cluster containing the node pool")),
@@ -97,10 +83,7 @@ up or down. The operation waits for the scaling to complete before returning.`,
 	// Register get_cluster_kubeconfig tool
 	p.server.AddTools(mcp.NewServerTool(
 		"get_cluster_kubeconfig",
-		`Retrieves the kubeconfig file needed to access a workload cluster.
-Returns the kubeconfig data that can be used to connect to and manage the specified
-cluster using kubectl or other Kubernetes clients.
-SECURITY: The returned kubeconfig contains sensitive credentials. Handle with care.`,
+		describeTool("get_cluster_kubeconfig", basicDialect),
 		p.handleGetClusterKubeconfig,
 		mcp.Input(
 			mcp.Property("cluster_name", mcp.Required(true), mcp.Description("Name of the cluster to get kubeconfig for")),
@@ -110,10 +93,7 @@ SECURITY: The returned kubeconfig contains sensitive credentials. Handle with ca
 	// Register get_cluster_nodes tool
 	p.server.AddTools(mcp.NewServerTool(
 		"get_cluster_nodes",
-		`Lists the nodes within a specific workload cluster.
-Retrieves information about all nodes in the cluster, including their status,
-roles, IP addresses, and other metadata. This tool connects to the workload
-cluster's API server to gather node information.`,
+		describeTool("get_cluster_nodes", basicDialect),
 		p.handleGetClusterNodes,
 		mcp.Input(
 			mcp.Property("cluster_name", mcp.Required(true), mcp.Description("Name of the cluster to list nodes from")),
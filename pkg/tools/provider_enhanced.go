@@ -3,13 +3,27 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	apiv2 "github.com/capi-mcp/capi-mcp-server/api/v2"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/budget"
 	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/history"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
 	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/policy"
 	"github.com/capi-mcp/capi-mcp-server/internal/service"
 	"github.com/capi-mcp/capi-mcp-server/internal/validation"
 )
@@ -20,6 +34,27 @@ type EnhancedProvider struct {
 	logger         *logging.Logger
 	clusterService interface{} // Can be either ClusterService or EnhancedClusterService
 	validator      *validation.Validator
+	identity       *auth.Identity
+	policy         policy.Evaluator
+	policyFailOpen bool
+	elicitation    bool
+	payloads       *payloadStore
+	metrics        OperationMetrics
+	callTimeout    time.Duration
+	outputVersion  string
+	tools          map[string]*mcp.ServerTool
+	toolSwitch     *ToolSwitch
+	maintenance    *Maintenance
+	approvals      *Approvals
+	cache          *resultCache
+	snapshots      *listSnapshots
+	serverInfo     ServerInfo
+	messages       *i18n.Catalog
+}
+
+// OperationMetrics records the duration of tool operations on clusters.
+type OperationMetrics interface {
+	ObserveClusterOperation(tool, namespace, cluster, status string, duration time.Duration, traceID, correlationID string)
 }
 
 // NewEnhancedProvider creates a new enhanced tool provider instance.
@@ -29,19 +64,105 @@ func NewEnhancedProvider(mcpServer *mcp.Server, logger *logging.Logger, clusterS
 		logger:         logger.WithComponent("tools"),
 		clusterService: clusterService,
 		validator:      validation.NewValidator(),
+		outputVersion:  OutputVersionV2,
+		snapshots:      newListSnapshots(),
 	}
 }
 
+// SetIdentity scopes the provider's tools to an authenticated identity. Cluster
+// tools then default to the identity's namespace and reject namespaces outside
+// its mapping, and management cluster tools require an unrestricted identity.
+func (p *EnhancedProvider) SetIdentity(identity *auth.Identity) {
+	p.identity = identity
+}
+
+// SetAWSCatalog configures the catalog AWS regions and instance types in tool
+// arguments are validated against.
+func (p *EnhancedProvider) SetAWSCatalog(catalog validation.AWSCatalog) {
+	p.validator.SetAWSCatalog(catalog)
+}
+
+// SetOperationMetrics configures where the durations of recorded operations
+// are observed, labelled with their cluster and namespace.
+func (p *EnhancedProvider) SetOperationMetrics(metrics OperationMetrics) {
+	p.metrics = metrics
+}
+
+// SetCallTimeout sets the budget of each tool call. The operations a call
+// makes take their timeouts from what is left of it, so no call outlasts it.
+// A non-positive timeout leaves calls without an overall deadline.
+func (p *EnhancedProvider) SetCallTimeout(timeout time.Duration) {
+	p.callTimeout = timeout
+}
+
+// SetAdmissionPolicy configures the policy evaluated before mutating tool
+// calls. If the policy cannot be evaluated, calls are denied unless failOpen
+// is set.
+func (p *EnhancedProvider) SetAdmissionPolicy(evaluator policy.Evaluator, failOpen bool) {
+	p.policy = evaluator
+	p.policyFailOpen = failOpen
+}
+
 // GetSupportedTools returns a list of supported tools for this provider.
 func (p *EnhancedProvider) GetSupportedTools() []string {
 	return []string{
+		"get_server_info",
 		"list_clusters",
+		"export_inventory",
+		"list_environments",
 		"get_cluster",
+		"get_cluster_dependencies",
 		"create_cluster",
+		"list_presets",
 		"delete_cluster",
+		"preview_delete_cluster",
+		"undelete_cluster",
 		"scale_cluster",
+		"create_node_pool",
+		"update_cluster_variables",
+		"check_template_rotation",
+		"refresh_cluster_templates",
+		"pause_rollout",
+		"resume_rollout",
+		"restart_rollout",
+		"undo_rollout",
+		"bulk_scale",
+		"bulk_upgrade",
 		"get_cluster_kubeconfig",
+		"approve_operation",
+		"create_temporary_access",
+		"revoke_temporary_access",
 		"get_cluster_nodes",
+		"get_autoscaler_status",
+		"probe_cluster_api",
+		"get_cluster_component_versions",
+		"get_workload_resource",
+		"get_workload_pod_logs",
+		"run_node_diagnostic",
+		"install_cni",
+		"resolve_node_image",
+		"configure_etcd_backup",
+		"list_etcd_backups",
+		"restore_cluster",
+		"run_conformance",
+		"smoke_test_cluster",
+		"list_cluster_templates",
+		"validate_cluster_template",
+		"publish_cluster_template",
+		"get_operation_status",
+		"cleanup_orphaned_resources",
+		"force_delete_cluster",
+		"scan_orphaned_cloud_resources",
+		"get_management_cluster_info",
+		"upgrade_management_providers",
+		"rotate_provider_credentials",
+		"create_tenant",
+		"get_tool_usage",
+		"list_operations",
+		"get_recent_changes",
+		"rollback_operation",
+		"diff_cluster_state",
+		"get_output_chunk",
 	}
 }
 
@@ -52,609 +173,4718 @@ func (p *EnhancedProvider) RegisterTools() error {
 	}
 
 	// Register tools using proper typed MCP handlers
-	p.mcpServer.AddTools(mcp.NewServerTool(
+	p.addTool(mcp.NewServerTool(
+		"get_server_info",
+		`Report the server version, the infrastructure providers clusters can be created with, the tools
+enabled for the caller, the output schema versions (apiVersion) and the policies in effect: whether the
+server is read-only for a maintenance window, which tools accept dryRun, admission policy, approvals of
+high-risk operations and the namespaces the caller may use. Call it first to adapt to the server.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetServerInfoTyped))),
+	))
+
+	p.addTool(mcp.NewServerTool(
 		"list_clusters",
-		"List all managed workload clusters and their current status",
-		p.handleListClustersTyped,
+		describeTool("list_clusters", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, withCache(p, p.handleListClustersTyped)))),
+		mcp.Input(
+			mcp.Property("namespace", mcp.Description("The namespace to list clusters in (default: the caller's namespace)")),
+			mcp.Property("status", mcp.Description("List only clusters with this status: Pending, Provisioning, Ready, Deleting, Failed, Queued or Unknown")),
+			mcp.Property("environment", mcp.Description("List only clusters of this environment, e.g. prod (see list_environments)")),
+			mcp.Property("sinceToken", mcp.Description("The nextToken of a previous call with the same namespace and filters; lists only the clusters added or changed since, and the removed ones. An expired token lists all clusters")),
+			mcp.Property("apiVersion", mcp.Description("The output schema version, v1 (snake_case) or v2 (camelCase, the server default unless configured otherwise)")),
+		),
 	))
 
-	p.mcpServer.AddTools(mcp.NewServerTool(
+	p.addTool(mcp.NewServerTool(
 		"get_cluster",
-		"Get detailed information for a specific cluster",
-		p.handleGetClusterTyped,
+		describeTool("get_cluster", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, withCache(p, p.handleGetClusterTyped)))),
 		mcp.Input(
 			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to retrieve")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+			mcp.Property("apiVersion", mcp.Description("The output schema version, v1 (snake_case) or v2 (camelCase, the server default unless configured otherwise)")),
 		),
 	))
 
-	p.mcpServer.AddTools(mcp.NewServerTool(
+	p.addTool(mcp.NewServerTool(
+		"get_cluster_dependencies",
+		`Get the clusters a cluster depends on and the clusters depending on it, with their status. Dependencies are
+declared with the capi-mcp.io/depends-on annotation of the depending cluster, a comma-separated list of cluster
+names in its namespace. delete_cluster and bulk_upgrade warn when they affect a cluster others depend on.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetClusterDependenciesTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
 		"create_cluster",
-		"Create a new workload cluster from templates",
-		p.handleCreateClusterTyped,
+		describeTool("create_cluster", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleCreateClusterTyped))),
 		mcp.Input(
 			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name for the new cluster")),
 			mcp.Property("templateName", mcp.Required(true), mcp.Description("The cluster template to use")),
+			mcp.Property("kubernetesVersion", mcp.Required(true), mcp.Description("The Kubernetes version of the cluster, e.g. v1.31.0")),
 			mcp.Property("variables", mcp.Description("Variables to use with the template")),
+			mcp.Property("preset", mcp.Description("A server-defined variable preset to start from (see list_presets); variables override its values")),
+			mcp.Property("templateVersion", mcp.Description("The template version to pin the cluster to; creation fails if another version of the template is installed")),
+			mcp.Property("dryRun", mcp.Description("Only run the checks of the creation without creating the cluster (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace to create the cluster in (default: the caller's namespace)")),
 		),
 	))
 
-	p.mcpServer.AddTools(mcp.NewServerTool(
+	p.addTool(mcp.NewServerTool(
+		"export_inventory",
+		`Export a fleet inventory report of the clusters in the namespace for compliance and chargeback:
+name, namespace, provider, region, Kubernetes version, status, node counts, creation time and age,
+estimated hourly and monthly cost from the configured instance prices, and owner labels. Returns the
+report as JSON entries or as CSV text.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleExportInventoryTyped))),
+		mcp.Input(
+			mcp.Property("format", mcp.Description("The report format: json or csv (default: json)")),
+			mcp.Property("namespace", mcp.Description("The namespace to report on (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"list_environments",
+		`List the environments of the clusters in the namespace, such as dev, staging or prod: the groups of
+clusters sharing a value of the environment label, with their statuses, Kubernetes versions and node counts.
+Pass an environment to list_clusters, bulk_scale or bulk_upgrade to act on its clusters.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, withCache(p, p.handleListEnvironmentsTyped)))),
+		mcp.Input(
+			mcp.Property("namespace", mcp.Description("The namespace of the clusters (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"list_presets",
+		"List the server-defined variable presets create_cluster accepts, with the variables each expands to",
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleListPresetsTyped))),
+	))
+
+	p.addTool(mcp.NewServerTool(
 		"delete_cluster",
-		"Delete a workload cluster",
-		p.handleDeleteClusterTyped,
+		describeTool("delete_cluster", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleDeleteClusterTyped))),
 		mcp.Input(
 			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to delete")),
+			mcp.Property("approvalId", mcp.Description("The ID of the approved approval of this deletion")),
 		),
 	))
 
-	p.mcpServer.AddTools(mcp.NewServerTool(
+	p.addTool(mcp.NewServerTool(
+		"preview_delete_cluster",
+		`Preview what deleting a cluster destroys, without changing anything: its control plane and worker
+machines by node pool, the cloud resources its infrastructure provider reports, the persistent volumes of
+the workload cluster, whose data is deleted or left orphaned depending on their reclaim policy, and the
+load balancers its LoadBalancer Services provisioned. Also reports the clusters depending on it and whether
+delete_cluster would be refused. Show the result to the user and get their confirmation before calling
+delete_cluster.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handlePreviewDeleteClusterTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to preview the deletion of")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"undelete_cluster",
+		`Cancel the deletion of a soft-deleted cluster. When the server retains deletions, delete_cluster only
+pauses a cluster and schedules its teardown for the end of the retention window, reported as
+delete_after; until then this tool removes the schedule and resumes the cluster's reconciliation, or
+leaves it paused if it was paused before it was deleted.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleUndeleteClusterTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster pending deletion")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
 		"scale_cluster",
-		"Scale worker nodes in a cluster",
-		p.handleScaleClusterTyped,
+		describeTool("scale_cluster", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleScaleClusterTyped))),
 		mcp.Input(
 			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to scale")),
-			mcp.Property("nodePoolName", mcp.Required(true), mcp.Description("The node pool to scale")),
+			mcp.Property("nodePoolName", mcp.Required(true), mcp.Description("The node pool (MachineDeployment or MachinePool) to scale")),
 			mcp.Property("replicas", mcp.Required(true), mcp.Description("The desired number of replicas")),
 		),
 	))
 
-	p.mcpServer.AddTools(mcp.NewServerTool(
-		"get_cluster_kubeconfig",
-		"Retrieve cluster access credentials",
-		p.handleGetClusterKubeconfigTyped,
+	p.addTool(mcp.NewServerTool(
+		"create_node_pool",
+		`Add a worker node pool to a cluster managed by a ClusterClass.
+The pool is added to the cluster topology as a MachineDeployment of one of the ClusterClass's worker
+classes. Set spot to run the pool on spot capacity, which is cheaper but may be interrupted when AWS
+reclaims it; get_cluster reports the pool's spot interruptions.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleCreateNodePoolTyped))),
 		mcp.Input(
-			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to add the node pool to")),
+			mcp.Property("nodePoolName", mcp.Required(true), mcp.Description("The name for the new node pool")),
+			mcp.Property("replicas", mcp.Required(true), mcp.Description("The number of replicas")),
+			mcp.Property("class", mcp.Description("The ClusterClass worker class to use (default: the only worker class)")),
+			mcp.Property("instanceType", mcp.Description("The instance type for the pool's machines")),
+			mcp.Property("gpuCount", mcp.Description("GPUs per node, passed to the templates as the gpuCount variable; requires a GPU instance type such as g5.xlarge")),
+			mcp.Property("spot", mcp.Description("Spot market options, e.g. {\"maxPrice\": \"0.05\", \"allocationStrategy\": \"capacity-optimized\"}; {} uses spot capacity capped at the on-demand price")),
+			mcp.Property("variables", mcp.Description("Additional topology variable overrides for the pool")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
 		),
 	))
 
-	p.mcpServer.AddTools(mcp.NewServerTool(
-		"get_cluster_nodes",
-		"List nodes within a cluster",
-		p.handleGetClusterNodesTyped,
+	p.addTool(mcp.NewServerTool(
+		"update_cluster_variables",
+		`Change the topology variables of a cluster managed by a ClusterClass, such as its instance type or a
+feature flag. Values are checked against the variable schemas of the ClusterClass (type, enum, properties,
+ranges and patterns) and the server's instance type and default variable policies. The response lists each
+changed variable as a diff and previews the machines that roll: those of the control plane and node pools
+whose templates are patched from a changed variable. Node pools overriding a variable are not affected by
+its cluster value. Use dryRun to review the diff and rollouts before applying.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleUpdateClusterVariablesTyped))),
 		mcp.Input(
-			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to update")),
+			mcp.Property("variables", mcp.Description("Variables to set, e.g. {\"instanceType\": \"m5.xlarge\"}")),
+			mcp.Property("unset", mcp.Description("Names of variables to remove, reverting them to their defaults")),
+			mcp.Property("dryRun", mcp.Description("Only report the diff and the machines that would roll without applying it (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
 		),
 	))
 
-	p.logger.Info("Registered all MCP tools", "count", 7)
-	return nil
-}
+	p.addTool(mcp.NewServerTool(
+		"check_template_rotation",
+		`Report clusters running stale template generations: clusters whose ClusterClass or referenced
+machine, bootstrap or infrastructure templates changed since the cluster was created or last refreshed,
+and machines cloned from an earlier machine template than their control plane or node pool now uses.
+Each stale cluster lists the changed templates with their recorded and current generations and the control
+plane and node pools to roll. Without clusterName every cluster in the namespace is checked and only stale
+clusters are returned.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleCheckTemplateRotationTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Description("The name of the cluster to check (default: all clusters in the namespace)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the clusters (default: the caller's namespace)")),
+		),
+	))
 
-// Define argument types for enhanced provider (avoid naming conflicts)
-type EnhancedEmptyArgs struct{}
-type EnhancedListClustersArgs = EnhancedEmptyArgs
+	p.addTool(mcp.NewServerTool(
+		"refresh_cluster_templates",
+		`Roll the stale control plane and node pools reported by check_template_rotation onto the current
+templates and record the current template generations on the cluster. Rollouts replace machines one at a
+time according to each rollout strategy. Use dryRun to review what would roll first.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRefreshClusterTemplatesTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to refresh")),
+			mcp.Property("dryRun", mcp.Description("Only report the control plane and node pools that would roll (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-type EnhancedGetClusterArgs struct {
-	ClusterName string `json:"clusterName"`
-}
+	p.addTool(mcp.NewServerTool(
+		"pause_rollout",
+		`Pause the rollouts of a cluster's control plane or a node pool, like clusterctl alpha rollout pause.
+While paused, changes to its templates are not rolled out; scaling still works. Resume with resume_rollout.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handlePauseRolloutTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("target", mcp.Required(true), mcp.Description("ControlPlane or MachineDeployment/<name>, where name is the MachineDeployment or its topology name")),
+			mcp.Property("dryRun", mcp.Description("Only report what would change (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-type EnhancedCreateClusterArgs struct {
-	ClusterName  string                 `json:"clusterName"`
-	TemplateName string                 `json:"templateName"`
-	Variables    map[string]interface{} `json:"variables,omitempty"`
-}
+	p.addTool(mcp.NewServerTool(
+		"resume_rollout",
+		`Resume the paused rollouts of a cluster's control plane or a node pool, like clusterctl alpha rollout
+resume. Template changes made while paused roll out now.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleResumeRolloutTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("target", mcp.Required(true), mcp.Description("ControlPlane or MachineDeployment/<name>, where name is the MachineDeployment or its topology name")),
+			mcp.Property("dryRun", mcp.Description("Only report what would change (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-type EnhancedDeleteClusterArgs struct {
-	ClusterName string `json:"clusterName"`
-}
+	p.addTool(mcp.NewServerTool(
+		"restart_rollout",
+		`Replace every machine of a cluster's control plane or a node pool with a new one, like clusterctl alpha
+rollout restart, e.g. to recover from node drift. Machines are replaced according to the rollout strategy.
+Refused while the target's rollouts are paused, a rollout is already scheduled or an earlier rollout is
+still in progress. Use dryRun to list the machines that would be replaced.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRestartRolloutTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("target", mcp.Required(true), mcp.Description("ControlPlane or MachineDeployment/<name>, where name is the MachineDeployment or its topology name")),
+			mcp.Property("dryRun", mcp.Description("Only report the machines that would be replaced (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-type EnhancedScaleClusterArgs struct {
-	ClusterName  string `json:"clusterName"`
-	NodePoolName string `json:"nodePoolName"`
-	Replicas     int    `json:"replicas"`
-}
+	p.addTool(mcp.NewServerTool(
+		"undo_rollout",
+		`Roll a node pool back to an earlier revision, like clusterctl alpha rollout undo, by restoring the machine
+template of that revision's MachineSet. Only node pools not managed by a ClusterClass can be rolled back;
+change the cluster variables or templates of the others instead. Use dryRun to list the machines that would
+be replaced.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleUndoRolloutTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("target", mcp.Required(true), mcp.Description("MachineDeployment/<name>")),
+			mcp.Property("toRevision", mcp.Description("The revision to roll back to (default: the previous revision)")),
+			mcp.Property("dryRun", mcp.Description("Only report the machines that would be replaced (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-type EnhancedGetClusterKubeconfigArgs struct {
-	ClusterName string `json:"clusterName"`
-}
+	p.addTool(mcp.NewServerTool(
+		"bulk_scale",
+		`Scale a node pool of every cluster of an environment and/or matching a label selector, e.g. region=eu. The node pool is
+found by its name or its topology name. Clusters without the node pool, already at the replica count, paused
+or being deleted are skipped. Clusters are scaled in the background a few at a time; poll
+get_operation_status with the returned operation for per-cluster progress. With canary, the first clusters are
+scaled alone and the others only once these pass the health gates. Use dryRun to review the plan.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleBulkScaleTyped))),
+		mcp.Input(
+			mcp.Property("labelSelector", mcp.Description("Label selector choosing the clusters, e.g. region=eu; required unless environment is set")),
+			mcp.Property("environment", mcp.Description("Choose the clusters of this environment, e.g. prod, that also match the label selector (see list_environments)")),
+			mcp.Property("nodePoolName", mcp.Required(true), mcp.Description("The name or topology name of the node pool to scale")),
+			mcp.Property("replicas", mcp.Required(true), mcp.Description("Target number of replicas")),
+			mcp.Property("concurrency", mcp.Description("How many clusters to scale at once, at most 20 (default: 5)")),
+			mcp.Property("maxFailures", mcp.Description("Stop starting clusters after this many failed (default: never stop)")),
+			mcp.Property("canary", mcp.Description("Change this many clusters first and continue only once they pass the health gates (default: 0, no canary)")),
+			mcp.Property("canaryWait", mcp.Description("How long the canaries must keep passing the health gates before the others are changed, e.g. 10m (default: 0)")),
+			mcp.Property("healthGates", mcp.Description("Health gates of the canaries: provisioned, nodes and/or api (default: provisioned and nodes)")),
+			mcp.Property("dryRun", mcp.Description("Only report which clusters would be scaled (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the clusters (default: the caller's namespace)")),
+		),
+	))
 
-type EnhancedGetClusterNodesArgs struct {
-	ClusterName string `json:"clusterName"`
-}
+	p.addTool(mcp.NewServerTool(
+		"bulk_upgrade",
+		`Upgrade every cluster of an environment and/or matching a label selector to a Kubernetes version by setting the version of its
+topology. Clusters not managed by a ClusterClass, already at the version, that would be downgraded or skip a
+minor version, or whose template or provider does not support the version are skipped. Clusters are upgraded
+in the background a few at a time; each completes once its control plane and node pools run the version.
+Poll get_operation_status with the returned operation for per-cluster progress. With canary, the first clusters
+are upgraded alone and the others only once these pass the health gates. Use dryRun to review the plan.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleBulkUpgradeTyped))),
+		mcp.Input(
+			mcp.Property("labelSelector", mcp.Description("Label selector choosing the clusters, e.g. region=eu; required unless environment is set")),
+			mcp.Property("environment", mcp.Description("Choose the clusters of this environment, e.g. prod, that also match the label selector (see list_environments)")),
+			mcp.Property("kubernetesVersion", mcp.Required(true), mcp.Description("The Kubernetes version to upgrade to, e.g. v1.31.0")),
+			mcp.Property("concurrency", mcp.Description("How many clusters to upgrade at once, at most 20 (default: 5)")),
+			mcp.Property("maxFailures", mcp.Description("Stop starting clusters after this many failed (default: never stop)")),
+			mcp.Property("canary", mcp.Description("Change this many clusters first and continue only once they pass the health gates (default: 0, no canary)")),
+			mcp.Property("canaryWait", mcp.Description("How long the canaries must keep passing the health gates before the others are changed, e.g. 10m (default: 0)")),
+			mcp.Property("healthGates", mcp.Description("Health gates of the canaries: provisioned, nodes and/or api (default: provisioned and nodes)")),
+			mcp.Property("dryRun", mcp.Description("Only report which clusters would be upgraded (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the clusters (default: the caller's namespace)")),
+		),
+	))
 
-// Typed MCP tool handlers
+	p.addTool(mcp.NewServerTool(
+		"get_cluster_kubeconfig",
+		describeTool("get_cluster_kubeconfig", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetClusterKubeconfigTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("approvalId", mcp.Description("The ID of the approved approval of this request")),
+		),
+	))
 
-func (p *EnhancedProvider) handleListClustersTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedListClustersArgs]) (*mcp.CallToolResultFor[api.ListClustersOutput], error) {
-	p.logger.Info("handling list_clusters")
+	p.addTool(mcp.NewServerTool(
+		"approve_operation",
+		`Approve a high-risk operation another identity requested, such as delete_cluster on a production
+cluster or get_cluster_kubeconfig. The requesting identity then calls the operation again with the
+approval ID to run it. The identity that requested an operation cannot approve it.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleApproveOperationTyped))),
+		mcp.Input(
+			mcp.Property("approvalId", mcp.Required(true), mcp.Description("The ID of the pending approval")),
+		),
+	))
 
-	// Convert to internal map format and call existing handler
-	arguments := make(map[string]interface{})
-	result, err := p.handleListClusters(ctx, arguments)
-	if err != nil {
-		return nil, p.sanitizeError(err)
-	}
+	p.addTool(mcp.NewServerTool(
+		"create_temporary_access",
+		`Grant a human operator time-limited access to one namespace of a workload cluster, for incident
+response or debugging without handing out the admin kubeconfig. Binds the view, edit or admin role in
+the namespace to a new ServiceAccount and returns a kubeconfig with a token that expires with the
+access. The grant is recorded in the operation history; revoke it early with revoke_temporary_access.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleCreateTemporaryAccessTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("accessNamespace", mcp.Required(true), mcp.Description("The namespace in the workload cluster to grant access to")),
+			mcp.Property("user", mcp.Required(true), mcp.Description("The operator the access is for")),
+			mcp.Property("role", mcp.Description("The role in the namespace: view, edit or admin (default: view)")),
+			mcp.Property("duration", mcp.Description("How long the access lasts, e.g. 2h (default: 1h, at least 10m and at most the configured maximum)")),
+			mcp.Property("reason", mcp.Description("Why the access is needed, such as an incident ticket")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert result to API type - for now just ignore the output data
-	// TODO: Figure out proper way to return structured data through MCP SDK
-	_ = result // Ignore the result for now
+	p.addTool(mcp.NewServerTool(
+		"revoke_temporary_access",
+		"Revoke temporary access granted by create_temporary_access before it expires, invalidating its token",
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRevokeTemporaryAccessTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("accessId", mcp.Required(true), mcp.Description("The access ID returned by create_temporary_access")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	return &mcp.CallToolResultFor[api.ListClustersOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully listed clusters",
-			},
-		},
-	}, nil
-}
+	p.addTool(mcp.NewServerTool(
+		"get_cluster_nodes",
+		describeTool("get_cluster_nodes", enhancedDialect),
+		withCorrelationID(p, withAccounting(p, withBudget(p, withCache(p, p.handleGetClusterNodesTyped)))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster")),
+			mcp.Property("apiVersion", mcp.Description("The output schema version, v1 (snake_case) or v2 (camelCase, the server default unless configured otherwise)")),
+		),
+	))
 
-func (p *EnhancedProvider) handleGetClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterArgs]) (*mcp.CallToolResultFor[api.GetClusterOutput], error) {
-	p.logger.Info("handling get_cluster", "cluster", params.Arguments.ClusterName)
+	p.addTool(mcp.NewServerTool(
+		"get_autoscaler_status",
+		`Summarize cluster-autoscaler activity for a workload cluster.
+Reads the cluster-autoscaler status ConfigMap (kube-system/cluster-autoscaler-status) from the
+workload cluster and reports cluster-wide health plus, for each node pool, its current/min/max
+size, scale-up and scale-down activity, and any blockers (backoff, size limits, unregistered
+nodes). Returns installed=false when cluster-autoscaler is not running in the cluster.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetAutoscalerStatusTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster to inspect")),
+		),
+	))
 
-	// Convert to internal map format and call existing handler
-	arguments := map[string]interface{}{
-		"clusterName": params.Arguments.ClusterName,
-	}
-	result, err := p.handleGetCluster(ctx, arguments)
-	if err != nil {
-		return nil, p.sanitizeError(err)
-	}
+	p.addTool(mcp.NewServerTool(
+		"probe_cluster_api",
+		`Probe a workload cluster's API server using its kubeconfig.
+Reports whether the API server is reachable and its latency, each /readyz check (etcd, informers,
+admission, ...), and whether the core addons are healthy: CoreDNS, kube-proxy and the CNI plugin
+(Calico, Cilium, AWS VPC CNI, Flannel, ...). Use it to tell a broken control plane from missing addons.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleProbeClusterAPITyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster to probe")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert result to API type - for now just ignore the output data
-	_ = result
+	p.addTool(mcp.NewServerTool(
+		"get_cluster_component_versions",
+		`Report the versions of the software in a workload cluster, like a bill of materials: the kubelet,
+container runtime, kernel and OS image of each node, the control plane components (API server,
+controller manager, scheduler, etcd) and the CNI, CSI, DNS and other addons in kube-system with their
+images. Flags version skew outside the Kubernetes support policy and releases at or near end of life.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetClusterComponentVersionsTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	return &mcp.CallToolResultFor[api.GetClusterOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully retrieved cluster information",
-			},
-		},
-	}, nil
-}
+	p.addTool(mcp.NewServerTool(
+		"get_workload_resource",
+		`Read resources from a workload cluster, like kubectl get, without handing out its kubeconfig.
+Gets a single resource by name, or lists resources filtered by namespace and label selector (at most
+200 per call). Only an allowlist of kinds is readable: Pod, Node, Namespace, Service, Endpoints,
+ConfigMap, Event, PersistentVolume, PersistentVolumeClaim, Deployment, DaemonSet, StatefulSet,
+ReplicaSet, Job, CronJob, Ingress, NetworkPolicy, PodDisruptionBudget and StorageClass. Secrets are not readable.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetWorkloadResourceTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("kind", mcp.Required(true), mcp.Description("The resource kind, e.g. Pod or DaemonSet")),
+			mcp.Property("apiVersion", mcp.Description("The resource API version, e.g. v1 or apps/v1 (default: inferred from the kind)")),
+			mcp.Property("resourceNamespace", mcp.Description("The namespace in the workload cluster (default: all namespaces; required with name for namespaced kinds)")),
+			mcp.Property("name", mcp.Description("The name of a single resource to get")),
+			mcp.Property("labelSelector", mcp.Description("A label selector to filter listed resources, e.g. k8s-app=kube-dns")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-func (p *EnhancedProvider) handleCreateClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCreateClusterArgs]) (*mcp.CallToolResultFor[api.CreateClusterOutput], error) {
-	p.logger.Info("handling create_cluster", "cluster", params.Arguments.ClusterName, "template", params.Arguments.TemplateName)
+	p.addTool(mcp.NewServerTool(
+		"get_workload_pod_logs",
+		`Read the tail of a container's logs from a pod in a workload cluster, without a port-forward or kubeconfig.
+Also reports the container's restart count and state (e.g. waiting: CrashLoopBackOff). Use it to find
+out why an addon or node-critical DaemonSet (CNI, kube-proxy, CoreDNS, CSI drivers) is failing during
+provisioning. Set previous to read the logs of a crashed container's last instance.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetWorkloadPodLogsTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("podNamespace", mcp.Required(true), mcp.Description("The namespace of the pod in the workload cluster")),
+			mcp.Property("pod", mcp.Required(true), mcp.Description("The name of the pod")),
+			mcp.Property("container", mcp.Description("The container, required for pods with several containers (init containers included)")),
+			mcp.Property("tailLines", mcp.Description("The number of lines to return from the end of the logs (default: 100, max: 2000)")),
+			mcp.Property("previous", mcp.Description("Return the logs of the previous, crashed instance of the container")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert to internal map format and call existing handler
-	arguments := map[string]interface{}{
-		"clusterName":  params.Arguments.ClusterName,
-		"templateName": params.Arguments.TemplateName,
-	}
-	if params.Arguments.Variables != nil {
-		arguments["variables"] = params.Arguments.Variables
-	}
+	p.addTool(mcp.NewServerTool(
+		"run_node_diagnostic",
+		`Diagnose a workload cluster node by running a short-lived privileged debug pod on it.
+The pod runs fixed, read-only checks and is deleted afterwards: disk (df, inode and kubelet/containerd
+usage), kubelet (service status and the last 200 journal lines), network (addresses, routes, resolv.conf,
+listening sockets) and runtime (containerd status and containers). Only available when the server
+enables node diagnostics, and only to identities allowed to manage the management cluster.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRunNodeDiagnosticTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("nodeName", mcp.Required(true), mcp.Description("The name of the node to diagnose")),
+			mcp.Property("checks", mcp.Description("The checks to run: disk, kubelet, network, runtime (default: all)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	result, err := p.handleCreateCluster(ctx, arguments)
-	if err != nil {
-		return nil, p.sanitizeError(err)
-	}
+	p.addTool(mcp.NewServerTool(
+		"install_cni",
+		`Install a CNI plugin into a workload cluster whose nodes sit NotReady without one.
+Supports Calico and Cilium. With method "helm" the plugin chart is installed by a HelmChartProxy of the
+Cluster API Add-on Provider for Helm (CAAPH); with method "manifest" a ClusterResourceSet applies the
+upstream Calico manifest. By default Helm is used when CAAPH is installed on the management cluster.
+Fails if the cluster already runs a CNI plugin. Follow progress with probe_cluster_api.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleInstallCNITyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("plugin", mcp.Required(true), mcp.Description("The CNI plugin to install: calico or cilium")),
+			mcp.Property("method", mcp.Description("How to install the plugin: helm (CAAPH) or manifest (ClusterResourceSet, Calico only)")),
+			mcp.Property("version", mcp.Description("The plugin version (default: v3.28.2 for Calico, 1.16.3 for Cilium)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert result to API type - for now just ignore the output data
-	_ = result
+	p.addTool(mcp.NewServerTool(
+		"resolve_node_image",
+		`Resolve the machine image (AMI) nodes of a given Kubernetes version boot from in a region.
+The default capa lookup finds the newest AMI CAPA publishes with image-builder (named
+capa-ami-<os>-v<version>-*, default OS ubuntu-22.04); the eks lookup finds the newest EKS optimized
+AMI (amazon-linux-2023 or amazon-linux-2). create_cluster resolves the image this way for templates
+with an amiID variable when none is given.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleResolveNodeImageTyped))),
+		mcp.Input(
+			mcp.Property("kubernetesVersion", mcp.Required(true), mcp.Description("The Kubernetes version, e.g. v1.30.2")),
+			mcp.Property("region", mcp.Description("The region (default: the provider's region)")),
+			mcp.Property("os", mcp.Description("The base OS of the image, e.g. ubuntu-22.04, ubuntu-24.04, flatcar-stable, amazon-linux-2023")),
+			mcp.Property("lookup", mcp.Description("How to find the image: capa (image-builder naming, default) or eks")),
+			mcp.Property("provider", mcp.Description("The infrastructure provider (default: aws)")),
+		),
+	))
 
-	return &mcp.CallToolResultFor[api.CreateClusterOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully initiated cluster creation",
-			},
-		},
-	}, nil
-}
+	p.addTool(mcp.NewServerTool(
+		"configure_etcd_backup",
+		`Schedule periodic etcd snapshots of a workload cluster with a self-managed control plane.
+Creates or updates a CronJob in the workload cluster's kube-system namespace that runs on a control
+plane node, saves a snapshot of the local etcd member with etcdctl and keeps the last retention
+snapshots, on the node's disk under /var/lib/etcd-backups or on a given persistent volume claim.
+Managed control planes (EKS, ROSA) and kubeadm control planes using external etcd are rejected.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleConfigureEtcdBackupTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("schedule", mcp.Description("The cron schedule of the snapshots (default: 0 */6 * * *)")),
+			mcp.Property("retention", mcp.Description("The number of snapshots to keep, 1-30 (default: 7)")),
+			mcp.Property("persistentVolumeClaim", mcp.Description("A persistent volume claim in kube-system to store snapshots on instead of the node's disk")),
+			mcp.Property("suspend", mcp.Description("Pause the backups without removing the schedule")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-func (p *EnhancedProvider) handleDeleteClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedDeleteClusterArgs]) (*mcp.CallToolResultFor[api.DeleteClusterOutput], error) {
-	p.logger.Info("handling delete_cluster", "cluster", params.Arguments.ClusterName)
+	p.addTool(mcp.NewServerTool(
+		"list_etcd_backups",
+		`List the etcd backups of a workload cluster configured with configure_etcd_backup.
+Returns the schedule, retention and destination, and each recent backup run newest first with
+its status, the control plane node it ran on and the snapshot file it saved with its size.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleListEtcdBackupsTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert to internal map format and call existing handler
-	arguments := map[string]interface{}{
-		"clusterName": params.Arguments.ClusterName,
-	}
-	result, err := p.handleDeleteCluster(ctx, arguments)
-	if err != nil {
-		return nil, p.sanitizeError(err)
-	}
+	p.addTool(mcp.NewServerTool(
+		"restore_cluster",
+		`Recreate a lost or broken cluster as a new cluster and restore its workloads.
+The new cluster is created from a stored snapshot of the source cluster's spec (the latest by default;
+snapshots are saved by diff_cluster_state), so it gets the same template, version, variables and node
+pools. Once it is provisioned, workloads are restored from the given Velero backup, which requires Velero
+in the new cluster configured with the backup storage location of the source cluster. The restore runs in
+the background; poll get_operation_status with the returned operationId to follow its stages.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRestoreClusterTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to create")),
+			mcp.Property("sourceCluster", mcp.Required(true), mcp.Description("The cluster whose stored spec to restore")),
+			mcp.Property("snapshot", mcp.Description("Snapshot ID or RFC 3339 time of the spec to restore (default: the latest snapshot)")),
+			mcp.Property("veleroBackup", mcp.Description("The Velero backup to restore workloads from (default: none, only the cluster is recreated)")),
+			mcp.Property("veleroNamespace", mcp.Description("The namespace Velero runs in (default: velero)")),
+			mcp.Property("includedNamespaces", mcp.Description("Only restore these namespaces from the backup (default: all)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the clusters (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert result to API type - for now just ignore the output data
-	_ = result
+	p.addTool(mcp.NewServerTool(
+		"run_conformance",
+		`Run the Kubernetes conformance tests against a Ready workload cluster with Sonobuoy, to validate it
+after provisioning. The quick mode runs a single test in a few minutes and checks the cluster can schedule
+and run workloads; the certified-conformance mode runs the full suite required for certification, which
+takes one to two hours; non-disruptive-conformance skips the tests that disrupt running workloads. The run happens in the background; poll get_operation_status with the returned
+operationId to follow its progress and get the results, including the names of failed tests.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRunConformanceTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("mode", mcp.Description("The Sonobuoy mode: quick, non-disruptive-conformance or certified-conformance (default: quick)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	return &mcp.CallToolResultFor[api.DeleteClusterOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully initiated cluster deletion",
-			},
-		},
-	}, nil
-}
+	p.addTool(mcp.NewServerTool(
+		"smoke_test_cluster",
+		`Check that a workload cluster can run workloads by deploying a small test workload to a temporary
+namespace and deleting it afterwards. Reports pass or fail for each check: scheduling (a Deployment's pod
+is scheduled and becomes ready), dns (a Service name resolves in cluster DNS) and storage (a
+PersistentVolumeClaim is provisioned and a pod writes to it). Takes up to a few minutes; a failed check
+reports what it was waiting for, such as an unschedulable pod or a claim left Pending.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleSmokeTestClusterTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the workload cluster")),
+			mcp.Property("checks", mcp.Description("The checks to run: scheduling, dns, storage (default: all)")),
+			mcp.Property("storageClass", mcp.Description("The StorageClass of the test volume (default: the cluster's default StorageClass)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-func (p *EnhancedProvider) handleScaleClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedScaleClusterArgs]) (*mcp.CallToolResultFor[api.ScaleClusterOutput], error) {
-	p.logger.Info("handling scale_cluster", "cluster", params.Arguments.ClusterName, "nodePool", params.Arguments.NodePoolName, "replicas", params.Arguments.Replicas)
+	p.addTool(mcp.NewServerTool(
+		"list_cluster_templates",
+		`List the cluster templates (ClusterClasses) clusters can be created from in the namespace, with their
+provider, supported Kubernetes versions and variables, and the templates available from the configured OCI
+catalogs with their versions, newest first, and the versions already installed. Install a catalog template
+with publish_cluster_template.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleListClusterTemplatesTyped))),
+		mcp.Input(
+			mcp.Property("namespace", mcp.Description("The namespace of the templates (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert to internal map format and call existing handler
-	arguments := map[string]interface{}{
-		"clusterName":  params.Arguments.ClusterName,
-		"nodePoolName": params.Arguments.NodePoolName,
-		"replicas":     params.Arguments.Replicas,
-	}
-	result, err := p.handleScaleCluster(ctx, arguments)
-	if err != nil {
-		return nil, p.sanitizeError(err)
-	}
+	p.addTool(mcp.NewServerTool(
+		"validate_cluster_template",
+		`Check a cluster template (ClusterClass) for authoring mistakes, either an existing one by name or
+ClusterClass YAML being written, optionally with its templates as further documents. Reports each issue with
+its severity, field and the check that found it: structure (required references, unknown fields), variables
+(schema types, object properties, array items, defaults matching their type and enum), patches (selectors
+matching templates the ClusterClass uses, JSON patches under /spec, variables read but not declared or declared
+but never read) and templates (referenced templates missing from the management cluster and the YAML).`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleValidateClusterTemplateTyped))),
+		mcp.Input(
+			mcp.Property("templateName", mcp.Description("The name of an existing ClusterClass to check")),
+			mcp.Property("manifest", mcp.Description("ClusterClass YAML to check instead, optionally followed by its templates as further documents")),
+			mcp.Property("namespace", mcp.Description("The namespace of the ClusterClass and its templates (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert result to API type - for now just ignore the output data
-	_ = result
+	p.addTool(mcp.NewServerTool(
+		"publish_cluster_template",
+		`Publish a cluster template to the management cluster: apply a ClusterClass and the infrastructure,
+bootstrap and control plane templates it references, from YAML, from an OCI artifact such as
+ghcr.io/org/templates:v1.2.0 or from a version of a catalog template (see list_cluster_templates). The bundle must hold only the ClusterClass and templates and pass the
+validate_cluster_template checks. Each object is compared with the version already published and reported as
+create, update or unchanged, with a field-by-field diff; use dryRun to review the diff before applying.
+Templates are applied before the ClusterClass, and changing a template in place is warned about, since
+providers usually reject it and it affects every cluster using the template.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handlePublishClusterTemplateTyped))),
+		mcp.Input(
+			mcp.Property("manifest", mcp.Description("ClusterClass YAML followed by its templates as further documents")),
+			mcp.Property("artifact", mcp.Description("An OCI artifact holding the YAML instead, as registry/repository:tag or @digest")),
+			mcp.Property("catalogTemplate", mcp.Description("A catalog template to install instead, by name")),
+			mcp.Property("version", mcp.Description("The version of the catalog template (default: the latest)")),
+			mcp.Property("dryRun", mcp.Description("Only report the diff against the published version without applying it (default: false)")),
+			mcp.Property("namespace", mcp.Description("The namespace to publish to (default: the caller's namespace)")),
+		),
+	))
 
-	return &mcp.CallToolResultFor[api.ScaleClusterOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully initiated cluster scaling",
-			},
-		},
-	}, nil
-}
+	p.addTool(mcp.NewServerTool(
+		"get_operation_status",
+		`Get the progress of a long-running operation started by a tool such as restore_cluster or run_conformance.
+Returns the operation's state (pending, running, succeeded or failed), each stage with its state,
+latest message and timing, and the error if it failed.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetOperationStatusTyped))),
+		mcp.Input(
+			mcp.Property("operationId", mcp.Required(true), mcp.Description("The operation ID returned when the operation was started")),
+		),
+	))
 
-func (p *EnhancedProvider) handleGetClusterKubeconfigTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterKubeconfigArgs]) (*mcp.CallToolResultFor[api.GetClusterKubeconfigOutput], error) {
-	p.logger.Info("handling get_cluster_kubeconfig", "cluster", params.Arguments.ClusterName)
+	p.addTool(mcp.NewServerTool(
+		"cleanup_orphaned_resources",
+		`Find and delete Cluster API resources left behind by clusters that no longer exist, which is common
+after failed or interrupted deletions: MachineDeployments, MachineSets, Machines, MachinePools, control
+planes, bootstrap configs, AWS infrastructure objects and secrets labelled with a missing cluster's name.
+Resources created in the last 10 minutes are ignored. Without a confirmationToken the resources are only
+reported, with a token; call again with that token to delete exactly the reported resources.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleCleanupOrphanedResourcesTyped))),
+		mcp.Input(
+			mcp.Property("confirmationToken", mcp.Description("The token of a previous report, confirming deletion of its resources (default: report only)")),
+			mcp.Property("namespace", mcp.Description("The namespace to clean up (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert to internal map format and call existing handler
-	arguments := map[string]interface{}{
-		"clusterName": params.Arguments.ClusterName,
-	}
-	result, err := p.handleGetClusterKubeconfig(ctx, arguments)
-	if err != nil {
-		return nil, p.sanitizeError(err)
-	}
+	p.addTool(mcp.NewServerTool(
+		"force_delete_cluster",
+		`Recover a cluster stuck deleting. Reports the resources holding back its deletion: those still
+carrying finalizers, with the condition explaining why their teardown is failing. As a last resort,
+once the cluster has been deleting for 15 minutes, call again with removeFinalizers=true and the
+confirmationToken of the report to remove their finalizers, and then the cluster's. Cloud resources
+the finalizers protected, such as instances, load balancers and VPCs, are left behind.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleForceDeleteClusterTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster stuck deleting")),
+			mcp.Property("removeFinalizers", mcp.Description("Remove the finalizers of the reported resources (default: report only)")),
+			mcp.Property("confirmationToken", mcp.Description("The token of a previous report, required with removeFinalizers")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
 
-	// Convert result to API type - for now just ignore the output data
-	_ = result
+	p.addTool(mcp.NewServerTool(
+		"scan_orphaned_cloud_resources",
+		`Scan the cloud account for resources owned by clusters the management cluster does not have, as leaked
+by interrupted or forced deletions: VPCs, security groups, instances and load balancers tagged as owned by a
+cluster. Resources are only reported, grouped by the cluster they were created for; they may belong to another
+management cluster sharing the account, so check before deleting them.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleScanOrphanedCloudResourcesTyped))),
+		mcp.Input(
+			mcp.Property("provider", mcp.Description("The infrastructure provider (default: aws)")),
+			mcp.Property("region", mcp.Description("The region to scan (default: the provider's region)")),
+		),
+	))
 
-	return &mcp.CallToolResultFor[api.GetClusterKubeconfigOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully retrieved cluster kubeconfig",
-			},
-		},
-	}, nil
-}
+	p.addTool(mcp.NewServerTool(
+		"get_management_cluster_info",
+		`Report the state of the CAPI management cluster, similar to clusterctl version and upgrade plan.
+Returns the Cluster API core version, installed providers with their types, versions and readiness
+(from the clusterctl inventory and provider Deployments), the supported contract versions, and
+cert-manager status.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetManagementClusterInfoTyped))),
+	))
 
-func (p *EnhancedProvider) handleGetClusterNodesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterNodesArgs]) (*mcp.CallToolResultFor[api.GetClusterNodesOutput], error) {
-	p.logger.Info("handling get_cluster_nodes", "cluster", params.Arguments.ClusterName)
+	p.addTool(mcp.NewServerTool(
+		"upgrade_management_providers",
+		`Plan and optionally apply upgrades of the Cluster API providers on the management cluster,
+equivalent to clusterctl upgrade plan / clusterctl upgrade apply.
+By default only the plan is returned. Set apply=true to perform the upgrade; this rolls out new
+CAPI controllers and is only permitted when the server runs with ENABLE_PROVIDER_UPGRADES=true.
+Either upgrade everything to a contract (default: latest contract from the plan) or pin explicit
+provider versions with coreProvider and infrastructureProviders.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleUpgradeManagementProvidersTyped))),
+		mcp.Input(
+			mcp.Property("apply", mcp.Description("Apply the upgrade plan instead of only returning it (default: false)")),
+			mcp.Property("contract", mcp.Description("Contract to upgrade all providers to, e.g. v1beta1")),
+			mcp.Property("coreProvider", mcp.Description("Core provider version, e.g. capi-system/cluster-api:v1.6.8")),
+			mcp.Property("infrastructureProviders", mcp.Description("Infrastructure provider versions, e.g. [\"capa-system/aws:v2.5.0\"]")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"rotate_provider_credentials",
+		`Rotate the cloud credentials an infrastructure provider uses on the management cluster.
+Supports CAPA (provider "aws": accessKeyId, secretAccessKey, optional sessionToken and region) and CAPZ
+(provider "azure": clientId, clientSecret, optional tenantId and subscriptionId). The new credentials are
+verified with the cloud API where possible, written to the provider's bootstrap credentials secret, and
+the provider controllers are restarted. If the controllers do not become available again, the previous
+credentials are restored. Requires an identity allowed to manage the management cluster.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRotateProviderCredentialsTyped))),
+		mcp.Input(
+			mcp.Property("provider", mcp.Required(true), mcp.Description("The infrastructure provider: aws or azure")),
+			mcp.Property("credentials", mcp.Required(true), mcp.Description("The new credential fields, e.g. {\"accessKeyId\": \"...\", \"secretAccessKey\": \"...\"}")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"create_tenant",
+		`Onboard a team by provisioning a tenant namespace on the management cluster.
+Creates the namespace, copies of the ClusterClasses (and their templates) the tenant may use,
+a ResourceQuota limiting the number of clusters, and a Role and RoleBinding granting the team's
+groups access to Cluster API resources in the namespace. Existing resources are left unchanged,
+so the tool can be re-run safely.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleCreateTenantTyped))),
+		mcp.Input(
+			mcp.Property("tenantName", mcp.Required(true), mcp.Description("Name of the tenant; used as the namespace name")),
+			mcp.Property("groups", mcp.Required(true), mcp.Description("Identity provider groups granted access to the tenant namespace")),
+			mcp.Property("clusterClasses", mcp.Description("ClusterClasses to make available to the tenant (default: all)")),
+			mcp.Property("maxClusters", mcp.Description("Maximum number of clusters the tenant may create (default: 5)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"get_tool_usage",
+		`Get the tool calls of each identity over time: how often each tool was called, how many calls
+failed (by error code) and how many changed a cluster. Use it for chargeback and to spot identities
+that misbehave, such as an agent retrying a denied call in a loop. Usage is aggregated per hour and
+kept in memory for the accounting retention period; it is also exported as Prometheus metrics.
+Requires an unrestricted identity.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetToolUsageTyped))),
+		mcp.Input(
+			mcp.Property("identity", mcp.Description("Only report calls of this identity")),
+			mcp.Property("tool", mcp.Description("Only report calls of this tool")),
+			mcp.Property("since", mcp.Description("Report calls at or after this RFC 3339 time (default: the start of the retention period)")),
+			mcp.Property("until", mcp.Description("Report calls at or before this RFC 3339 time (default: now)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"list_operations",
+		`List the operations performed through this server, newest first.
+Each entry records who ran which tool against which cluster, when, how long it took, the outcome,
+and the parameters used. History is persisted on the management cluster and survives restarts.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleListOperationsTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Description("Only list operations on this cluster")),
+			mcp.Property("since", mcp.Description("Only list operations started at or after this RFC 3339 time")),
+			mcp.Property("until", mcp.Description("Only list operations started at or before this RFC 3339 time")),
+			mcp.Property("limit", mcp.Description("Maximum number of operations to return (default and maximum: 500)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"get_recent_changes",
+		`Get a digest of cluster lifecycle changes (created, scaled, upgraded, deleted, failed) since a given time,
+oldest first. Call it at the start of a session to catch up on what happened since the last one.
+Changes come from the operation history and from the current cluster state, so failures and clusters
+created outside this server are included.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetRecentChangesTyped))),
+		mcp.Input(
+			mcp.Property("since", mcp.Required(true), mcp.Description("Report changes at or after this RFC 3339 time")),
+			mcp.Property("clusterName", mcp.Description("Only report changes to this cluster")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"rollback_operation",
+		`Roll back the most recent change to a cluster recorded in the operation history.
+A scale is reversed by restoring the node pool's previous replica count, and an update_cluster_variables
+call by restoring the previous values of the variables it changed, provided they have not been changed
+since. Calling the tool again rolls back the change before that.
+Non-reversible operations such as create_cluster and delete_cluster are refused.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleRollbackOperationTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster whose last change to roll back")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"diff_cluster_state",
+		`Show what changed in a cluster's desired state (Cluster, MachineDeployment and MachinePool specs)
+between two points in time. Returns structured changes plus a human-readable diff.
+By default the current state is compared with the previous snapshot; each call that reads the current
+state saves it as a new snapshot. from and to accept a snapshot ID or an RFC 3339 time, which selects
+the latest snapshot taken at or before that time (e.g. yesterday's date to see what changed since).`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleDiffClusterStateTyped))),
+		mcp.Input(
+			mcp.Property("clusterName", mcp.Required(true), mcp.Description("The name of the cluster to diff")),
+			mcp.Property("from", mcp.Description("Snapshot ID or RFC 3339 time of the older state (default: the previous snapshot)")),
+			mcp.Property("to", mcp.Description("Snapshot ID or RFC 3339 time of the newer state (default: the current state)")),
+			mcp.Property("namespace", mcp.Description("The namespace of the cluster (default: the caller's namespace)")),
+		),
+	))
+
+	p.addTool(mcp.NewServerTool(
+		"get_output_chunk",
+		`Get a chunk of a tool result that was too large to return at once.
+Such results are replaced by a reference with a payloadId and the number of chunks; fetch chunks 0 to
+total_chunks-1 and concatenate their data to rebuild the result. Results expire after a while.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleGetOutputChunkTyped))),
+		mcp.Input(
+			mcp.Property("payloadId", mcp.Required(true), mcp.Description("The payload ID from the chunked result reference")),
+			mcp.Property("chunk", mcp.Description("Zero-based index of the chunk to return (default: 0)")),
+		),
+	))
+
+	p.logger.Info("Registered all MCP tools", "count", len(p.GetSupportedTools()))
+	return nil
+}
+
+// RegisterToolAdminTools registers the tools that disable and re-enable the
+// tools of all identities, and start and end maintenance mode, with the MCP
+// server. They are only registered for the tool admin, and SetToolSwitch must
+// have been called.
+func (p *EnhancedProvider) RegisterToolAdminTools() error {
+	if p.mcpServer == nil {
+		return errors.New(errors.CodeInternal, "MCP server not initialized")
+	}
+	if p.toolSwitch == nil {
+		return errors.New(errors.CodeInternal, "tool switch not configured")
+	}
+
+	p.mcpServer.AddTools(mcp.NewServerTool(
+		"disable_tool",
+		`Disable a tool for all identities at runtime, e.g. freeze delete_cluster during an incident.
+The tool is removed from every session's tool list, clients are notified that the list changed,
+and calls to it are rejected until enable_tool is called. Calls in progress are not interrupted.
+Disabled tools are enabled again when the server restarts.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleDisableToolTyped))),
+		mcp.Input(
+			mcp.Property("tool", mcp.Required(true), mcp.Description("The name of the tool to disable, e.g. delete_cluster")),
+			mcp.Property("reason", mcp.Description("Why the tool is disabled, e.g. an incident reference")),
+		),
+	))
+
+	p.mcpServer.AddTools(mcp.NewServerTool(
+		"enable_tool",
+		"Enable a tool disabled with disable_tool again for all identities. Clients are notified that the tool list changed.",
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleEnableToolTyped))),
+		mcp.Input(
+			mcp.Property("tool", mcp.Required(true), mcp.Description("The name of the tool to enable")),
+		),
+	))
+
+	p.mcpServer.AddTools(mcp.NewServerTool(
+		"list_disabled_tools",
+		"List the tools disabled with disable_tool, with the reason and time each was disabled.",
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleListDisabledToolsTyped))),
+	))
+
+	p.mcpServer.AddTools(mcp.NewServerTool(
+		"start_maintenance",
+		`Put the server in maintenance mode, e.g. while the management cluster is upgraded.
+Until it ends, mutating tools of all identities fail with a "maintenance in progress" error telling the
+caller when to try again (retry_after), while read tools keep working. Give until or duration to plan
+the end: maintenance then ends by itself at that time. Calling it again updates the window.`,
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleStartMaintenanceTyped))),
+		mcp.Input(
+			mcp.Property("until", mcp.Description("RFC 3339 time maintenance ends at")),
+			mcp.Property("duration", mcp.Description("How long maintenance lasts, e.g. 30m; alternative to until")),
+			mcp.Property("reason", mcp.Description("Why the server is in maintenance; included in the errors callers receive")),
+		),
+	))
+
+	p.mcpServer.AddTools(mcp.NewServerTool(
+		"end_maintenance",
+		"End maintenance mode, so mutating tools work again.",
+		withCorrelationID(p, withAccounting(p, withBudget(p, p.handleEndMaintenanceTyped))),
+	))
+
+	p.logger.Info("Registered tool admin tools")
+	return nil
+}
+
+// Define argument types for enhanced provider (avoid naming conflicts)
+type EnhancedEmptyArgs struct{}
+
+type EnhancedListClustersArgs struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Environment string `json:"environment,omitempty"`
+	SinceToken  string `json:"sinceToken,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+}
+
+type EnhancedGetClusterArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+}
+
+type EnhancedCreateClusterArgs struct {
+	ClusterName       string                 `json:"clusterName"`
+	TemplateName      string                 `json:"templateName"`
+	KubernetesVersion string                 `json:"kubernetesVersion"`
+	Variables         map[string]interface{} `json:"variables,omitempty"`
+	Preset            string                 `json:"preset,omitempty"`
+	TemplateVersion   string                 `json:"templateVersion,omitempty"`
+	DryRun            bool                   `json:"dryRun,omitempty"`
+	Namespace         string                 `json:"namespace,omitempty"`
+}
+
+type EnhancedDeleteClusterArgs struct {
+	ClusterName string `json:"clusterName"`
+	ApprovalID  string `json:"approvalId,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedPreviewDeleteClusterArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedUndeleteClusterArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedScaleClusterArgs struct {
+	ClusterName  string `json:"clusterName"`
+	NodePoolName string `json:"nodePoolName"`
+	Replicas     int    `json:"replicas"`
+	Namespace    string `json:"namespace,omitempty"`
+}
+
+type EnhancedCreateNodePoolArgs struct {
+	ClusterName  string                 `json:"clusterName"`
+	NodePoolName string                 `json:"nodePoolName"`
+	Replicas     int                    `json:"replicas"`
+	Class        string                 `json:"class,omitempty"`
+	InstanceType string                 `json:"instanceType,omitempty"`
+	GPUCount     int                    `json:"gpuCount,omitempty"`
+	Spot         *api.SpotMarketOptions `json:"spot,omitempty"`
+	Variables    map[string]interface{} `json:"variables,omitempty"`
+	Namespace    string                 `json:"namespace,omitempty"`
+}
+
+type EnhancedUpdateClusterVariablesArgs struct {
+	ClusterName string                 `json:"clusterName"`
+	Variables   map[string]interface{} `json:"variables,omitempty"`
+	Unset       []string               `json:"unset,omitempty"`
+	DryRun      bool                   `json:"dryRun,omitempty"`
+	Namespace   string                 `json:"namespace,omitempty"`
+}
+
+type EnhancedCheckTemplateRotationArgs struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedRefreshClusterTemplatesArgs struct {
+	ClusterName string `json:"clusterName"`
+	DryRun      bool   `json:"dryRun,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedRolloutArgs struct {
+	ClusterName string `json:"clusterName"`
+	Target      string `json:"target"`
+	ToRevision  int64  `json:"toRevision,omitempty"`
+	DryRun      bool   `json:"dryRun,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedBulkScaleArgs struct {
+	LabelSelector string   `json:"labelSelector,omitempty"`
+	Environment   string   `json:"environment,omitempty"`
+	NodePoolName  string   `json:"nodePoolName"`
+	Replicas      int      `json:"replicas"`
+	Concurrency   int      `json:"concurrency,omitempty"`
+	MaxFailures   int      `json:"maxFailures,omitempty"`
+	Canary        int      `json:"canary,omitempty"`
+	CanaryWait    string   `json:"canaryWait,omitempty"`
+	HealthGates   []string `json:"healthGates,omitempty"`
+	DryRun        bool     `json:"dryRun,omitempty"`
+	Namespace     string   `json:"namespace,omitempty"`
+}
+
+type EnhancedBulkUpgradeArgs struct {
+	LabelSelector     string   `json:"labelSelector,omitempty"`
+	Environment       string   `json:"environment,omitempty"`
+	KubernetesVersion string   `json:"kubernetesVersion"`
+	Concurrency       int      `json:"concurrency,omitempty"`
+	MaxFailures       int      `json:"maxFailures,omitempty"`
+	Canary            int      `json:"canary,omitempty"`
+	CanaryWait        string   `json:"canaryWait,omitempty"`
+	HealthGates       []string `json:"healthGates,omitempty"`
+	DryRun            bool     `json:"dryRun,omitempty"`
+	Namespace         string   `json:"namespace,omitempty"`
+}
+
+type EnhancedGetClusterKubeconfigArgs struct {
+	ClusterName string `json:"clusterName"`
+	ApprovalID  string `json:"approvalId,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedApproveOperationArgs struct {
+	ApprovalID string `json:"approvalId"`
+}
+
+type EnhancedCreateTemporaryAccessArgs struct {
+	ClusterName     string `json:"clusterName"`
+	AccessNamespace string `json:"accessNamespace"`
+	User            string `json:"user"`
+	Role            string `json:"role,omitempty"`
+	Duration        string `json:"duration,omitempty"`
+	Reason          string `json:"reason,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+}
+
+type EnhancedRevokeTemporaryAccessArgs struct {
+	ClusterName string `json:"clusterName"`
+	AccessID    string `json:"accessId"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedGetClusterNodesArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+}
+
+type EnhancedGetAutoscalerStatusArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedUpgradeManagementProvidersArgs struct {
+	Apply                   bool     `json:"apply,omitempty"`
+	Contract                string   `json:"contract,omitempty"`
+	CoreProvider            string   `json:"coreProvider,omitempty"`
+	InfrastructureProviders []string `json:"infrastructureProviders,omitempty"`
+}
+
+type EnhancedCreateTenantArgs struct {
+	TenantName     string   `json:"tenantName"`
+	Groups         []string `json:"groups"`
+	ClusterClasses []string `json:"clusterClasses,omitempty"`
+	MaxClusters    int      `json:"maxClusters,omitempty"`
+}
+
+type EnhancedListOperationsArgs struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	Since       string `json:"since,omitempty"`
+	Until       string `json:"until,omitempty"`
+	Limit       int    `json:"limit,omitempty"`
+}
+
+type EnhancedSetToolEnabledArgs struct {
+	Tool   string `json:"tool"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type EnhancedStartMaintenanceArgs struct {
+	Until    string `json:"until,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type EnhancedGetToolUsageArgs struct {
+	Identity string `json:"identity,omitempty"`
+	Tool     string `json:"tool,omitempty"`
+	Since    string `json:"since,omitempty"`
+	Until    string `json:"until,omitempty"`
+}
+
+type EnhancedGetRecentChangesArgs struct {
+	Since       string `json:"since"`
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+type EnhancedGetOutputChunkArgs struct {
+	PayloadID string `json:"payloadId"`
+	Chunk     int    `json:"chunk,omitempty"`
+}
+
+type EnhancedProbeClusterAPIArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedGetClusterDependenciesArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedGetClusterComponentVersionsArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedGetWorkloadResourceArgs struct {
+	ClusterName       string `json:"clusterName"`
+	APIVersion        string `json:"apiVersion,omitempty"`
+	Kind              string `json:"kind"`
+	ResourceNamespace string `json:"resourceNamespace,omitempty"`
+	Name              string `json:"name,omitempty"`
+	LabelSelector     string `json:"labelSelector,omitempty"`
+	Namespace         string `json:"namespace,omitempty"`
+}
+
+type EnhancedGetWorkloadPodLogsArgs struct {
+	ClusterName  string `json:"clusterName"`
+	PodNamespace string `json:"podNamespace"`
+	Pod          string `json:"pod"`
+	Container    string `json:"container,omitempty"`
+	TailLines    int    `json:"tailLines,omitempty"`
+	Previous     bool   `json:"previous,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+}
+
+type EnhancedRunNodeDiagnosticArgs struct {
+	ClusterName string   `json:"clusterName"`
+	NodeName    string   `json:"nodeName"`
+	Checks      []string `json:"checks,omitempty"`
+	Namespace   string   `json:"namespace,omitempty"`
+}
+
+type EnhancedResolveNodeImageArgs struct {
+	Provider          string `json:"provider"`
+	KubernetesVersion string `json:"kubernetesVersion"`
+	Region            string `json:"region"`
+	OS                string `json:"os"`
+	Lookup            string `json:"lookup"`
+}
+
+type EnhancedConfigureEtcdBackupArgs struct {
+	ClusterName           string `json:"clusterName"`
+	Schedule              string `json:"schedule,omitempty"`
+	Retention             int    `json:"retention,omitempty"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	Suspend               bool   `json:"suspend,omitempty"`
+	Namespace             string `json:"namespace,omitempty"`
+}
+
+type EnhancedListEtcdBackupsArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedRestoreClusterArgs struct {
+	ClusterName        string   `json:"clusterName"`
+	SourceCluster      string   `json:"sourceCluster"`
+	Snapshot           string   `json:"snapshot,omitempty"`
+	VeleroBackup       string   `json:"veleroBackup,omitempty"`
+	VeleroNamespace    string   `json:"veleroNamespace,omitempty"`
+	IncludedNamespaces []string `json:"includedNamespaces,omitempty"`
+	Namespace          string   `json:"namespace,omitempty"`
+}
+
+type EnhancedRunConformanceArgs struct {
+	ClusterName string `json:"clusterName"`
+	Mode        string `json:"mode,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedSmokeTestClusterArgs struct {
+	ClusterName  string   `json:"clusterName"`
+	Checks       []string `json:"checks,omitempty"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	Namespace    string   `json:"namespace,omitempty"`
+}
+
+type EnhancedValidateClusterTemplateArgs struct {
+	TemplateName string `json:"templateName,omitempty"`
+	Manifest     string `json:"manifest,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+}
+
+type EnhancedListClusterTemplatesArgs struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type EnhancedPublishClusterTemplateArgs struct {
+	Manifest        string `json:"manifest,omitempty"`
+	Artifact        string `json:"artifact,omitempty"`
+	CatalogTemplate string `json:"catalogTemplate,omitempty"`
+	Version         string `json:"version,omitempty"`
+	DryRun          bool   `json:"dryRun,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+}
+
+type EnhancedGetOperationStatusArgs struct {
+	OperationID string `json:"operationId"`
+}
+
+type EnhancedCleanupOrphanedResourcesArgs struct {
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+	Namespace         string `json:"namespace,omitempty"`
+}
+
+type EnhancedForceDeleteClusterArgs struct {
+	ClusterName       string `json:"clusterName"`
+	RemoveFinalizers  bool   `json:"removeFinalizers,omitempty"`
+	ConfirmationToken string `json:"confirmationToken,omitempty"`
+	Namespace         string `json:"namespace,omitempty"`
+}
+
+type EnhancedScanOrphanedCloudResourcesArgs struct {
+	Provider string `json:"provider,omitempty"`
+	Region   string `json:"region,omitempty"`
+}
+
+type EnhancedExportInventoryArgs struct {
+	Format    string `json:"format,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type EnhancedListEnvironmentsArgs struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type EnhancedRotateProviderCredentialsArgs struct {
+	Provider    string            `json:"provider"`
+	Credentials map[string]string `json:"credentials"`
+}
+
+type EnhancedInstallCNIArgs struct {
+	ClusterName string `json:"clusterName"`
+	Plugin      string `json:"plugin"`
+	Method      string `json:"method,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedRollbackOperationArgs struct {
+	ClusterName string `json:"clusterName"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+type EnhancedDiffClusterStateArgs struct {
+	ClusterName string `json:"clusterName"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+// Typed MCP tool handlers
+
+func (p *EnhancedProvider) handleListClustersTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedListClustersArgs]) (*mcp.CallToolResultFor[api.ListClustersOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_clusters")
+
+	version, err := p.resolveOutputVersion(params.Arguments.APIVersion)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	ctx, err = p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Convert to internal map format and call existing handler
+	arguments := make(map[string]interface{})
+	if params.Arguments.Status != "" {
+		arguments["status"] = params.Arguments.Status
+	}
+	if params.Arguments.Environment != "" {
+		arguments["environment"] = params.Arguments.Environment
+	}
+	if params.Arguments.SinceToken != "" {
+		arguments["since_token"] = params.Arguments.SinceToken
+	}
+	result, err := p.handleListClusters(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	content, err := versionedContent(p, version, result, apiv2.FromV1ListClusters)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	return &mcp.CallToolResultFor[api.ListClustersOutput]{Content: content}, nil
+}
+
+func (p *EnhancedProvider) handleGetClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterArgs]) (*mcp.CallToolResultFor[api.GetClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_cluster", "cluster", params.Arguments.ClusterName)
+
+	version, err := p.resolveOutputVersion(params.Arguments.APIVersion)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	ctx, err = p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
 
 	// Convert to internal map format and call existing handler
 	arguments := map[string]interface{}{
 		"clusterName": params.Arguments.ClusterName,
 	}
-	result, err := p.handleGetClusterNodes(ctx, arguments)
+	result, err := p.handleGetCluster(ctx, arguments)
 	if err != nil {
 		return nil, p.sanitizeError(err)
 	}
 
-	// Convert result to API type - for now just ignore the output data
-	_ = result
-
-	return &mcp.CallToolResultFor[api.GetClusterNodesOutput]{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "Successfully retrieved cluster nodes",
-			},
-		},
-	}, nil
+	content, err := versionedContent(p, version, result, apiv2.FromV1GetCluster)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	return &mcp.CallToolResultFor[api.GetClusterOutput]{Content: content}, nil
 }
 
-// wrapToolHandler wraps a tool handler with logging and error handling
-func (p *EnhancedProvider) wrapToolHandler(toolName string, handler func(context.Context, map[string]interface{}) (interface{}, error)) func(context.Context, map[string]interface{}) (map[string]interface{}, error) {
-	return func(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
-		// Add tool context to logger
-		toolLogger := p.logger.WithContext(ctx).With(
-			logging.FieldTool, toolName,
-		)
+func (p *EnhancedProvider) handleCreateClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCreateClusterArgs]) (*mcp.CallToolResultFor[api.CreateClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling create_cluster", "cluster", params.Arguments.ClusterName, "template", params.Arguments.TemplateName,
+		"preset", params.Arguments.Preset, "dry_run", params.Arguments.DryRun)
 
-		// Log tool invocation
-		toolLogger.Info("Tool invocation started")
-		result, err := handler(ctx, input)
-		if err != nil {
-			p.logger.WithError(err).Error("Tool invocation failed", logging.FieldTool, toolName)
-		} else {
-			toolLogger.Info("Tool invocation completed")
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Convert to internal map format and call existing handler
+	arguments := map[string]interface{}{
+		"clusterName":       params.Arguments.ClusterName,
+		"templateName":      params.Arguments.TemplateName,
+		"kubernetesVersion": params.Arguments.KubernetesVersion,
+	}
+	if params.Arguments.Preset != "" {
+		arguments["preset"] = params.Arguments.Preset
+	}
+	if params.Arguments.TemplateVersion != "" {
+		arguments["templateVersion"] = params.Arguments.TemplateVersion
+	}
+	if params.Arguments.DryRun {
+		arguments["dryRun"] = true
+	}
+	if variables := p.elicitVariables(ctx, session, params.Arguments.TemplateName, params.Arguments.Preset, params.Arguments.Variables); variables != nil {
+		arguments["variables"] = variables
+	}
+
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, "create_cluster", arguments, params.Arguments.DryRun, p.handleCreateCluster)
+	if !params.Arguments.DryRun {
+		p.recordOperation(ctx, "create_cluster", params.Arguments.ClusterName, startedAt, map[string]string{
+			"templateName": params.Arguments.TemplateName,
+			"preset":       params.Arguments.Preset,
+		}, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.CreateClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleDeleteClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedDeleteClusterArgs]) (*mcp.CallToolResultFor[api.DeleteClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling delete_cluster", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Convert to internal map format and call existing handler
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "delete_cluster", arguments, p.withApproval("delete_cluster", params.Arguments.ApprovalID, p.handleDeleteCluster))
+	var parameters map[string]string
+	if params.Arguments.ApprovalID != "" {
+		parameters = map[string]string{"approvalId": params.Arguments.ApprovalID}
+	}
+	p.recordOperation(ctx, "delete_cluster", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.DeleteClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handlePreviewDeleteClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedPreviewDeleteClusterArgs]) (*mcp.CallToolResultFor[api.PreviewDeleteClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling preview_delete_cluster", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handlePreviewDeleteCluster(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.PreviewDeleteClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleUndeleteClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedUndeleteClusterArgs]) (*mcp.CallToolResultFor[api.UndeleteClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling undelete_cluster", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "undelete_cluster", arguments, p.handleUndeleteCluster)
+	p.recordOperation(ctx, "undelete_cluster", params.Arguments.ClusterName, startedAt, nil, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.UndeleteClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleScaleClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedScaleClusterArgs]) (*mcp.CallToolResultFor[api.ScaleClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling scale_cluster", "cluster", params.Arguments.ClusterName, "nodePool", params.Arguments.NodePoolName, "replicas", params.Arguments.Replicas)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Convert to internal map format and call existing handler
+	arguments := map[string]interface{}{
+		"clusterName":  params.Arguments.ClusterName,
+		"nodePoolName": params.Arguments.NodePoolName,
+		"replicas":     params.Arguments.Replicas,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "scale_cluster", arguments, p.handleScaleCluster)
+	parameters := map[string]string{
+		"nodePoolName": params.Arguments.NodePoolName,
+		"replicas":     strconv.Itoa(params.Arguments.Replicas),
+	}
+	if output, ok := result.(map[string]interface{}); ok {
+		if oldReplicas, ok := output["old_replicas"]; ok {
+			parameters["oldReplicas"] = fmt.Sprint(oldReplicas)
 		}
+	}
+	p.recordOperation(ctx, "scale_cluster", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
 
-		if err != nil {
-			// Return user-friendly error
-			userErr := p.sanitizeError(err)
-			return nil, userErr
+	return &mcp.CallToolResultFor[api.ScaleClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetClusterKubeconfigTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterKubeconfigArgs]) (*mcp.CallToolResultFor[api.GetClusterKubeconfigOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_cluster_kubeconfig", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Convert to internal map format and call existing handler
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.withApproval("get_cluster_kubeconfig", params.Arguments.ApprovalID, p.handleGetClusterKubeconfig)(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetClusterKubeconfigOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleApproveOperationTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedApproveOperationArgs]) (*mcp.CallToolResultFor[api.ApproveOperationOutput], error) {
+	p.logger.WithContext(ctx).Info("handling approve_operation", "approval_id", params.Arguments.ApprovalID)
+
+	arguments := map[string]interface{}{
+		"approvalId": params.Arguments.ApprovalID,
+	}
+	startedAt := time.Now()
+	result, err := p.handleApproveOperation(ctx, arguments)
+	parameters := map[string]string{
+		"approvalId": params.Arguments.ApprovalID,
+	}
+	var clusterName string
+	if output, ok := result.(map[string]interface{}); ok {
+		if approval, ok := output["approval"].(api.Approval); ok {
+			clusterName = approval.ClusterName
+			parameters["tool"] = approval.Tool
+			parameters["requestedBy"] = approval.RequestedBy
+			if approval.Namespace != "" {
+				ctx = kube.ContextWithNamespace(ctx, approval.Namespace)
+			}
 		}
+	}
+	p.recordOperation(ctx, "approve_operation", clusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
 
-		// Convert result to map
-		resultMap, ok := result.(map[string]interface{})
-		if !ok {
-			// Try to convert using JSON marshaling
-			return convertToMap(result)
+	return &mcp.CallToolResultFor[api.ApproveOperationOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleCreateTemporaryAccessTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCreateTemporaryAccessArgs]) (*mcp.CallToolResultFor[api.CreateTemporaryAccessOutput], error) {
+	p.logger.WithContext(ctx).Info("handling create_temporary_access", "cluster", params.Arguments.ClusterName,
+		"access_namespace", params.Arguments.AccessNamespace, "user", params.Arguments.User, "role", params.Arguments.Role)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":     params.Arguments.ClusterName,
+		"accessNamespace": params.Arguments.AccessNamespace,
+		"user":            params.Arguments.User,
+		"role":            params.Arguments.Role,
+		"duration":        params.Arguments.Duration,
+		"reason":          params.Arguments.Reason,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "create_temporary_access", arguments, p.handleCreateTemporaryAccess)
+	parameters := map[string]string{
+		"user":            params.Arguments.User,
+		"accessNamespace": params.Arguments.AccessNamespace,
+		"role":            params.Arguments.Role,
+		"duration":        params.Arguments.Duration,
+		"reason":          params.Arguments.Reason,
+	}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["accessId"] = fmt.Sprint(output["access_id"])
+		parameters["expiresAt"] = fmt.Sprint(output["expires_at"])
+	}
+	p.recordOperation(ctx, "create_temporary_access", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.CreateTemporaryAccessOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRevokeTemporaryAccessTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRevokeTemporaryAccessArgs]) (*mcp.CallToolResultFor[api.RevokeTemporaryAccessOutput], error) {
+	p.logger.WithContext(ctx).Info("handling revoke_temporary_access", "cluster", params.Arguments.ClusterName, "access_id", params.Arguments.AccessID)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"accessId":    params.Arguments.AccessID,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "revoke_temporary_access", arguments, p.handleRevokeTemporaryAccess)
+	parameters := map[string]string{
+		"accessId": params.Arguments.AccessID,
+	}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["user"] = fmt.Sprint(output["user"])
+		parameters["accessNamespace"] = fmt.Sprint(output["namespace"])
+	}
+	p.recordOperation(ctx, "revoke_temporary_access", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RevokeTemporaryAccessOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetClusterNodesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterNodesArgs]) (*mcp.CallToolResultFor[api.GetClusterNodesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_cluster_nodes", "cluster", params.Arguments.ClusterName)
+
+	version, err := p.resolveOutputVersion(params.Arguments.APIVersion)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	ctx, err = p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Convert to internal map format and call existing handler
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleGetClusterNodes(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	content, err := versionedContent(p, version, result, apiv2.FromV1GetClusterNodes)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	return &mcp.CallToolResultFor[api.GetClusterNodesOutput]{Content: content}, nil
+}
+
+func (p *EnhancedProvider) handleGetAutoscalerStatusTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetAutoscalerStatusArgs]) (*mcp.CallToolResultFor[api.GetAutoscalerStatusOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_autoscaler_status", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleGetAutoscalerStatus(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetAutoscalerStatusOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleProbeClusterAPITyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedProbeClusterAPIArgs]) (*mcp.CallToolResultFor[api.ProbeClusterAPIOutput], error) {
+	p.logger.WithContext(ctx).Info("handling probe_cluster_api", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleProbeClusterAPI(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ProbeClusterAPIOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetClusterDependenciesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterDependenciesArgs]) (*mcp.CallToolResultFor[api.GetClusterDependenciesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_cluster_dependencies", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleGetClusterDependencies(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetClusterDependenciesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetClusterComponentVersionsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetClusterComponentVersionsArgs]) (*mcp.CallToolResultFor[api.GetClusterComponentVersionsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_cluster_component_versions", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleGetClusterComponentVersions(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetClusterComponentVersionsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetWorkloadResourceTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetWorkloadResourceArgs]) (*mcp.CallToolResultFor[api.GetWorkloadResourceOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_workload_resource", "cluster", params.Arguments.ClusterName, "kind", params.Arguments.Kind, "name", params.Arguments.Name)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":       params.Arguments.ClusterName,
+		"apiVersion":        params.Arguments.APIVersion,
+		"kind":              params.Arguments.Kind,
+		"resourceNamespace": params.Arguments.ResourceNamespace,
+		"name":              params.Arguments.Name,
+		"labelSelector":     params.Arguments.LabelSelector,
+	}
+	result, err := p.handleGetWorkloadResource(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetWorkloadResourceOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetWorkloadPodLogsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetWorkloadPodLogsArgs]) (*mcp.CallToolResultFor[api.GetWorkloadPodLogsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_workload_pod_logs", "cluster", params.Arguments.ClusterName, "pod", params.Arguments.Pod, "container", params.Arguments.Container)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":  params.Arguments.ClusterName,
+		"podNamespace": params.Arguments.PodNamespace,
+		"pod":          params.Arguments.Pod,
+		"container":    params.Arguments.Container,
+		"tailLines":    params.Arguments.TailLines,
+		"previous":     params.Arguments.Previous,
+	}
+	result, err := p.handleGetWorkloadPodLogs(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetWorkloadPodLogsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRunNodeDiagnosticTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRunNodeDiagnosticArgs]) (*mcp.CallToolResultFor[api.RunNodeDiagnosticOutput], error) {
+	p.logger.WithContext(ctx).Info("handling run_node_diagnostic", "cluster", params.Arguments.ClusterName, "node", params.Arguments.NodeName, "checks", params.Arguments.Checks)
+
+	// Debug pods run privileged on the node, so they are reserved for
+	// identities trusted with the management cluster
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"nodeName":    params.Arguments.NodeName,
+		"checks":      params.Arguments.Checks,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "run_node_diagnostic", arguments, p.handleRunNodeDiagnostic)
+	p.recordOperation(ctx, "run_node_diagnostic", params.Arguments.ClusterName, startedAt, map[string]string{
+		"nodeName": params.Arguments.NodeName,
+		"checks":   strings.Join(params.Arguments.Checks, ","),
+	}, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RunNodeDiagnosticOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleInstallCNITyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedInstallCNIArgs]) (*mcp.CallToolResultFor[api.InstallCNIOutput], error) {
+	p.logger.WithContext(ctx).Info("handling install_cni", "cluster", params.Arguments.ClusterName, "plugin", params.Arguments.Plugin, "method", params.Arguments.Method)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"plugin":      params.Arguments.Plugin,
+		"method":      params.Arguments.Method,
+		"version":     params.Arguments.Version,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "install_cni", arguments, p.handleInstallCNI)
+	parameters := map[string]string{"plugin": params.Arguments.Plugin}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["method"] = fmt.Sprint(output["method"])
+		parameters["version"] = fmt.Sprint(output["version"])
+	}
+	p.recordOperation(ctx, "install_cni", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.InstallCNIOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleResolveNodeImageTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedResolveNodeImageArgs]) (*mcp.CallToolResultFor[api.ResolveNodeImageOutput], error) {
+	p.logger.WithContext(ctx).Info("handling resolve_node_image", "kubernetes_version", params.Arguments.KubernetesVersion, "region", params.Arguments.Region)
+
+	arguments := map[string]interface{}{
+		"provider":          params.Arguments.Provider,
+		"kubernetesVersion": params.Arguments.KubernetesVersion,
+		"region":            params.Arguments.Region,
+		"os":                params.Arguments.OS,
+		"lookup":            params.Arguments.Lookup,
+	}
+	result, err := p.handleResolveNodeImage(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ResolveNodeImageOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleConfigureEtcdBackupTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedConfigureEtcdBackupArgs]) (*mcp.CallToolResultFor[api.ConfigureEtcdBackupOutput], error) {
+	p.logger.WithContext(ctx).Info("handling configure_etcd_backup", "cluster", params.Arguments.ClusterName, "schedule", params.Arguments.Schedule, "retention", params.Arguments.Retention)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":           params.Arguments.ClusterName,
+		"schedule":              params.Arguments.Schedule,
+		"retention":             params.Arguments.Retention,
+		"persistentVolumeClaim": params.Arguments.PersistentVolumeClaim,
+		"suspend":               params.Arguments.Suspend,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "configure_etcd_backup", arguments, p.handleConfigureEtcdBackup)
+	parameters := map[string]string{"suspend": strconv.FormatBool(params.Arguments.Suspend)}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["schedule"] = fmt.Sprint(output["schedule"])
+		parameters["retention"] = fmt.Sprint(output["retention"])
+		parameters["destination"] = fmt.Sprint(output["destination"])
+	}
+	p.recordOperation(ctx, "configure_etcd_backup", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ConfigureEtcdBackupOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleListEtcdBackupsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedListEtcdBackupsArgs]) (*mcp.CallToolResultFor[api.ListEtcdBackupsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_etcd_backups", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleListEtcdBackups(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ListEtcdBackupsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRestoreClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRestoreClusterArgs]) (*mcp.CallToolResultFor[api.RestoreClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling restore_cluster", "cluster", params.Arguments.ClusterName, "source_cluster", params.Arguments.SourceCluster)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":        params.Arguments.ClusterName,
+		"sourceCluster":      params.Arguments.SourceCluster,
+		"snapshot":           params.Arguments.Snapshot,
+		"veleroBackup":       params.Arguments.VeleroBackup,
+		"veleroNamespace":    params.Arguments.VeleroNamespace,
+		"includedNamespaces": params.Arguments.IncludedNamespaces,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "restore_cluster", arguments, p.handleRestoreCluster)
+	parameters := map[string]string{
+		"sourceCluster": params.Arguments.SourceCluster,
+		"veleroBackup":  params.Arguments.VeleroBackup,
+	}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["snapshotId"] = fmt.Sprint(output["snapshot_id"])
+		parameters["operationId"] = fmt.Sprint(output["operation_id"])
+	}
+	p.recordOperation(ctx, "restore_cluster", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RestoreClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRunConformanceTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRunConformanceArgs]) (*mcp.CallToolResultFor[api.RunConformanceOutput], error) {
+	p.logger.WithContext(ctx).Info("handling run_conformance", "cluster", params.Arguments.ClusterName, "mode", params.Arguments.Mode)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"mode":        params.Arguments.Mode,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "run_conformance", arguments, p.handleRunConformance)
+	parameters := map[string]string{}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["mode"] = fmt.Sprint(output["mode"])
+		parameters["operationId"] = fmt.Sprint(output["operation_id"])
+	}
+	p.recordOperation(ctx, "run_conformance", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RunConformanceOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleSmokeTestClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedSmokeTestClusterArgs]) (*mcp.CallToolResultFor[api.SmokeTestClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling smoke_test_cluster", "cluster", params.Arguments.ClusterName, "checks", params.Arguments.Checks)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":  params.Arguments.ClusterName,
+		"checks":       params.Arguments.Checks,
+		"storageClass": params.Arguments.StorageClass,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "smoke_test_cluster", arguments, p.handleSmokeTestCluster)
+	parameters := map[string]string{
+		"checks": strings.Join(params.Arguments.Checks, ","),
+	}
+	if output, ok := result.(map[string]interface{}); ok {
+		parameters["passed"] = fmt.Sprint(output["passed"])
+	}
+	p.recordOperation(ctx, "smoke_test_cluster", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.SmokeTestClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleValidateClusterTemplateTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedValidateClusterTemplateArgs]) (*mcp.CallToolResultFor[api.ValidateClusterTemplateOutput], error) {
+	p.logger.WithContext(ctx).Info("handling validate_cluster_template", "template", params.Arguments.TemplateName, "manifest_bytes", len(params.Arguments.Manifest))
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"templateName": params.Arguments.TemplateName,
+		"manifest":     params.Arguments.Manifest,
+	}
+	result, err := p.handleValidateClusterTemplate(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ValidateClusterTemplateOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleListClusterTemplatesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedListClusterTemplatesArgs]) (*mcp.CallToolResultFor[api.ListClusterTemplatesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_cluster_templates")
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	result, err := p.handleListClusterTemplates(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ListClusterTemplatesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handlePublishClusterTemplateTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedPublishClusterTemplateArgs]) (*mcp.CallToolResultFor[api.PublishClusterTemplateOutput], error) {
+	p.logger.WithContext(ctx).Info("handling publish_cluster_template", "artifact", params.Arguments.Artifact, "catalog_template", params.Arguments.CatalogTemplate,
+		"version", params.Arguments.Version, "manifest_bytes", len(params.Arguments.Manifest), "dry_run", params.Arguments.DryRun)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"manifest":        params.Arguments.Manifest,
+		"artifact":        params.Arguments.Artifact,
+		"catalogTemplate": params.Arguments.CatalogTemplate,
+		"version":         params.Arguments.Version,
+		"dryRun":          params.Arguments.DryRun,
+	}
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, "publish_cluster_template", arguments, params.Arguments.DryRun, p.handlePublishClusterTemplate)
+	if !params.Arguments.DryRun {
+		parameters := map[string]string{
+			"artifact":        params.Arguments.Artifact,
+			"catalogTemplate": params.Arguments.CatalogTemplate,
+			"version":         params.Arguments.Version,
+		}
+		if output, ok := result.(map[string]interface{}); ok {
+			parameters["template"] = fmt.Sprint(output["template_name"])
+			parameters["source"] = fmt.Sprint(output["source"])
+		}
+		p.recordOperation(ctx, "publish_cluster_template", "", startedAt, parameters, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.PublishClusterTemplateOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetOperationStatusTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetOperationStatusArgs]) (*mcp.CallToolResultFor[api.GetOperationStatusOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_operation_status", "operation_id", params.Arguments.OperationID)
+
+	arguments := map[string]interface{}{
+		"operationId": params.Arguments.OperationID,
+	}
+	result, err := p.handleGetOperationStatus(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetOperationStatusOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleCleanupOrphanedResourcesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCleanupOrphanedResourcesArgs]) (*mcp.CallToolResultFor[api.CleanupOrphanedResourcesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling cleanup_orphaned_resources", "confirmed", params.Arguments.ConfirmationToken != "")
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"confirmationToken": params.Arguments.ConfirmationToken,
+	}
+	startedAt := time.Now()
+	var result interface{}
+	if params.Arguments.ConfirmationToken != "" {
+		result, err = p.admitted(ctx, "cleanup_orphaned_resources", arguments, p.handleCleanupOrphanedResources)
+		p.recordOperation(ctx, "cleanup_orphaned_resources", "", startedAt, map[string]string{
+			"confirmationToken": params.Arguments.ConfirmationToken,
+		}, err)
+	} else {
+		// Reports do not change anything, so no admission is needed
+		result, err = p.handleCleanupOrphanedResources(ctx, arguments)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.CleanupOrphanedResourcesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleForceDeleteClusterTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedForceDeleteClusterArgs]) (*mcp.CallToolResultFor[api.ForceDeleteClusterOutput], error) {
+	p.logger.WithContext(ctx).Info("handling force_delete_cluster", "cluster", params.Arguments.ClusterName, "remove_finalizers", params.Arguments.RemoveFinalizers)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":       params.Arguments.ClusterName,
+		"removeFinalizers":  params.Arguments.RemoveFinalizers,
+		"confirmationToken": params.Arguments.ConfirmationToken,
+	}
+	startedAt := time.Now()
+	var result interface{}
+	if params.Arguments.RemoveFinalizers {
+		result, err = p.admitted(ctx, "force_delete_cluster", arguments, p.handleForceDeleteCluster)
+		p.recordOperation(ctx, "force_delete_cluster", params.Arguments.ClusterName, startedAt, map[string]string{
+			"confirmationToken": params.Arguments.ConfirmationToken,
+		}, err)
+	} else {
+		// Reports do not change anything, so no admission is needed
+		result, err = p.handleForceDeleteCluster(ctx, arguments)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ForceDeleteClusterOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleExportInventoryTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedExportInventoryArgs]) (*mcp.CallToolResultFor[api.ExportInventoryOutput], error) {
+	p.logger.WithContext(ctx).Info("handling export_inventory", "format", params.Arguments.Format)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	result, err := p.handleExportInventory(ctx, map[string]interface{}{
+		"format": params.Arguments.Format,
+	})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ExportInventoryOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleListEnvironmentsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedListEnvironmentsArgs]) (*mcp.CallToolResultFor[api.ListEnvironmentsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_environments")
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	result, err := p.handleListEnvironments(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ListEnvironmentsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleListPresetsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedEmptyArgs]) (*mcp.CallToolResultFor[api.ListPresetsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_presets")
+
+	result, err := p.handleListPresets(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ListPresetsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleScanOrphanedCloudResourcesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedScanOrphanedCloudResourcesArgs]) (*mcp.CallToolResultFor[api.ScanOrphanedCloudResourcesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling scan_orphaned_cloud_resources", "provider", params.Arguments.Provider, "region", params.Arguments.Region)
+
+	// The scan spans the clusters of every namespace
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	result, err := p.handleScanOrphanedCloudResources(ctx, map[string]interface{}{
+		"provider": params.Arguments.Provider,
+		"region":   params.Arguments.Region,
+	})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ScanOrphanedCloudResourcesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetManagementClusterInfoTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedEmptyArgs]) (*mcp.CallToolResultFor[api.GetManagementClusterInfoOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_management_cluster_info")
+
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	result, err := p.handleGetManagementClusterInfo(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetManagementClusterInfoOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleUpgradeManagementProvidersTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedUpgradeManagementProvidersArgs]) (*mcp.CallToolResultFor[api.UpgradeManagementProvidersOutput], error) {
+	p.logger.WithContext(ctx).Info("handling upgrade_management_providers", "apply", params.Arguments.Apply)
+
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"apply":                   params.Arguments.Apply,
+		"contract":                params.Arguments.Contract,
+		"coreProvider":            params.Arguments.CoreProvider,
+		"infrastructureProviders": params.Arguments.InfrastructureProviders,
+	}
+	startedAt := time.Now()
+	var result interface{}
+	var err error
+	if params.Arguments.Apply {
+		result, err = p.admitted(ctx, "upgrade_management_providers", arguments, p.handleUpgradeManagementProviders)
+	} else {
+		// Plans do not change the management cluster, so no admission is needed
+		result, err = p.handleUpgradeManagementProviders(ctx, arguments)
+	}
+	if params.Arguments.Apply {
+		p.recordOperation(ctx, "upgrade_management_providers", "", startedAt, map[string]string{
+			"contract":                params.Arguments.Contract,
+			"coreProvider":            params.Arguments.CoreProvider,
+			"infrastructureProviders": strings.Join(params.Arguments.InfrastructureProviders, ","),
+		}, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.UpgradeManagementProvidersOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRotateProviderCredentialsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRotateProviderCredentialsArgs]) (*mcp.CallToolResultFor[api.RotateProviderCredentialsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling rotate_provider_credentials", "provider", params.Arguments.Provider)
+
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// The admission policy sees which credential fields are set, never their values
+	fields := make([]string, 0, len(params.Arguments.Credentials))
+	for field := range params.Arguments.Credentials {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	startedAt := time.Now()
+	err := p.admit(ctx, "rotate_provider_credentials", map[string]interface{}{
+		"provider":         params.Arguments.Provider,
+		"credentialFields": fields,
+	})
+	var result interface{}
+	if err == nil {
+		result, err = p.handleRotateProviderCredentials(ctx, map[string]interface{}{
+			"provider":    params.Arguments.Provider,
+			"credentials": params.Arguments.Credentials,
+		})
+	}
+	p.recordOperation(ctx, "rotate_provider_credentials", "", startedAt, map[string]string{
+		"provider":         params.Arguments.Provider,
+		"credentialFields": strings.Join(fields, ","),
+	}, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RotateProviderCredentialsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleCreateNodePoolTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCreateNodePoolArgs]) (*mcp.CallToolResultFor[api.CreateNodePoolOutput], error) {
+	p.logger.WithContext(ctx).Info("handling create_node_pool", "cluster", params.Arguments.ClusterName, "nodePool", params.Arguments.NodePoolName, "spot", params.Arguments.Spot != nil)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName":  params.Arguments.ClusterName,
+		"nodePoolName": params.Arguments.NodePoolName,
+		"replicas":     params.Arguments.Replicas,
+		"class":        params.Arguments.Class,
+		"instanceType": params.Arguments.InstanceType,
+		"gpuCount":     params.Arguments.GPUCount,
+		"spot":         params.Arguments.Spot,
+		"variables":    params.Arguments.Variables,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "create_node_pool", arguments, p.handleCreateNodePool)
+	parameters := map[string]string{
+		"nodePoolName": params.Arguments.NodePoolName,
+		"replicas":     strconv.Itoa(params.Arguments.Replicas),
+		"spot":         strconv.FormatBool(params.Arguments.Spot != nil),
+	}
+	if params.Arguments.InstanceType != "" {
+		parameters["instanceType"] = params.Arguments.InstanceType
+	}
+	if params.Arguments.GPUCount != 0 {
+		parameters["gpuCount"] = strconv.Itoa(params.Arguments.GPUCount)
+	}
+	p.recordOperation(ctx, "create_node_pool", params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.CreateNodePoolOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleUpdateClusterVariablesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedUpdateClusterVariablesArgs]) (*mcp.CallToolResultFor[api.UpdateClusterVariablesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling update_cluster_variables", "cluster", params.Arguments.ClusterName,
+		"variables", len(params.Arguments.Variables), "unset", len(params.Arguments.Unset), "dry_run", params.Arguments.DryRun)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"variables":   params.Arguments.Variables,
+		"unset":       params.Arguments.Unset,
+		"dryRun":      params.Arguments.DryRun,
+	}
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, "update_cluster_variables", arguments, params.Arguments.DryRun, p.handleUpdateClusterVariables)
+	if !params.Arguments.DryRun {
+		parameters := map[string]string{}
+		for name, value := range params.Arguments.Variables {
+			if encoded, err := json.Marshal(value); err == nil {
+				parameters[name] = string(encoded)
+			}
+		}
+		if len(params.Arguments.Unset) > 0 {
+			parameters["unset"] = strings.Join(params.Arguments.Unset, ",")
+		}
+		if output, ok := result.(map[string]interface{}); ok {
+			if previous, ok := output["previous_variables"]; ok {
+				if encoded, err := json.Marshal(previous); err == nil {
+					parameters["previousVariables"] = string(encoded)
+				}
+			}
+		}
+		p.recordOperation(ctx, "update_cluster_variables", params.Arguments.ClusterName, startedAt, parameters, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.UpdateClusterVariablesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleCheckTemplateRotationTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCheckTemplateRotationArgs]) (*mcp.CallToolResultFor[api.CheckTemplateRotationOutput], error) {
+	p.logger.WithContext(ctx).Info("handling check_template_rotation", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleCheckTemplateRotation(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.CheckTemplateRotationOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRefreshClusterTemplatesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRefreshClusterTemplatesArgs]) (*mcp.CallToolResultFor[api.RefreshClusterTemplatesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling refresh_cluster_templates", "cluster", params.Arguments.ClusterName,
+		"dry_run", params.Arguments.DryRun)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"dryRun":      params.Arguments.DryRun,
+	}
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, "refresh_cluster_templates", arguments, params.Arguments.DryRun, p.handleRefreshClusterTemplates)
+	if !params.Arguments.DryRun {
+		p.recordOperation(ctx, "refresh_cluster_templates", params.Arguments.ClusterName, startedAt, nil, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RefreshClusterTemplatesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handlePauseRolloutTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRolloutArgs]) (*mcp.CallToolResultFor[api.RolloutOutput], error) {
+	return p.handleRolloutTyped(ctx, "pause_rollout", params.Arguments, p.handlePauseRollout)
+}
+
+func (p *EnhancedProvider) handleResumeRolloutTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRolloutArgs]) (*mcp.CallToolResultFor[api.RolloutOutput], error) {
+	return p.handleRolloutTyped(ctx, "resume_rollout", params.Arguments, p.handleResumeRollout)
+}
+
+func (p *EnhancedProvider) handleRestartRolloutTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRolloutArgs]) (*mcp.CallToolResultFor[api.RolloutOutput], error) {
+	return p.handleRolloutTyped(ctx, "restart_rollout", params.Arguments, p.handleRestartRollout)
+}
+
+func (p *EnhancedProvider) handleUndoRolloutTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRolloutArgs]) (*mcp.CallToolResultFor[api.RolloutOutput], error) {
+	return p.handleRolloutTyped(ctx, "undo_rollout", params.Arguments, p.handleUndoRollout)
+}
+
+// handleRolloutTyped handles the rollout tools, which share their arguments.
+func (p *EnhancedProvider) handleRolloutTyped(ctx context.Context, tool string, args EnhancedRolloutArgs, handler func(context.Context, map[string]interface{}) (interface{}, error)) (*mcp.CallToolResultFor[api.RolloutOutput], error) {
+	p.logger.WithContext(ctx).Info("handling "+tool, "cluster", args.ClusterName, "target", args.Target,
+		"to_revision", args.ToRevision, "dry_run", args.DryRun)
+
+	ctx, err := p.namespaceContext(ctx, args.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": args.ClusterName,
+		"target":      args.Target,
+		"toRevision":  args.ToRevision,
+		"dryRun":      args.DryRun,
+	}
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, tool, arguments, args.DryRun, handler)
+	if !args.DryRun {
+		parameters := map[string]string{"target": args.Target}
+		if args.ToRevision != 0 {
+			parameters["toRevision"] = strconv.FormatInt(args.ToRevision, 10)
+		}
+		p.recordOperation(ctx, tool, args.ClusterName, startedAt, parameters, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RolloutOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleBulkScaleTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedBulkScaleArgs]) (*mcp.CallToolResultFor[api.BulkOperationOutput], error) {
+	args := params.Arguments
+	p.logger.WithContext(ctx).Info("handling bulk_scale", "label_selector", args.LabelSelector, "environment", args.Environment,
+		"node_pool", args.NodePoolName, "replicas", args.Replicas, "dry_run", args.DryRun)
+
+	ctx, err := p.namespaceContext(ctx, args.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"labelSelector": args.LabelSelector,
+		"environment":   args.Environment,
+		"nodePoolName":  args.NodePoolName,
+		"replicas":      args.Replicas,
+		"concurrency":   args.Concurrency,
+		"maxFailures":   args.MaxFailures,
+		"canary":        args.Canary,
+		"canaryWait":    args.CanaryWait,
+		"healthGates":   args.HealthGates,
+		"dryRun":        args.DryRun,
+	}
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, "bulk_scale", arguments, args.DryRun, p.handleBulkScale)
+	if !args.DryRun {
+		parameters := map[string]string{
+			"labelSelector": args.LabelSelector,
+			"nodePoolName":  args.NodePoolName,
+			"replicas":      strconv.Itoa(args.Replicas),
+		}
+		if args.Environment != "" {
+			parameters["environment"] = args.Environment
+		}
+		if args.Canary > 0 {
+			parameters["canary"] = strconv.Itoa(args.Canary)
+		}
+		p.recordOperation(ctx, "bulk_scale", "", startedAt, parameters, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.BulkOperationOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleBulkUpgradeTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedBulkUpgradeArgs]) (*mcp.CallToolResultFor[api.BulkOperationOutput], error) {
+	args := params.Arguments
+	p.logger.WithContext(ctx).Info("handling bulk_upgrade", "label_selector", args.LabelSelector, "environment", args.Environment,
+		"kubernetes_version", args.KubernetesVersion, "dry_run", args.DryRun)
+
+	ctx, err := p.namespaceContext(ctx, args.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"labelSelector":     args.LabelSelector,
+		"environment":       args.Environment,
+		"kubernetesVersion": args.KubernetesVersion,
+		"concurrency":       args.Concurrency,
+		"maxFailures":       args.MaxFailures,
+		"canary":            args.Canary,
+		"canaryWait":        args.CanaryWait,
+		"healthGates":       args.HealthGates,
+		"dryRun":            args.DryRun,
+	}
+	startedAt := time.Now()
+	result, err := p.dryRunAdmitted(ctx, "bulk_upgrade", arguments, args.DryRun, p.handleBulkUpgrade)
+	if !args.DryRun {
+		parameters := map[string]string{
+			"labelSelector":     args.LabelSelector,
+			"kubernetesVersion": args.KubernetesVersion,
+		}
+		if args.Environment != "" {
+			parameters["environment"] = args.Environment
+		}
+		if args.Canary > 0 {
+			parameters["canary"] = strconv.Itoa(args.Canary)
+		}
+		p.recordOperation(ctx, "bulk_upgrade", "", startedAt, parameters, err)
+	}
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.BulkOperationOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleCreateTenantTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedCreateTenantArgs]) (*mcp.CallToolResultFor[api.CreateTenantOutput], error) {
+	p.logger.WithContext(ctx).Info("handling create_tenant", "tenant", params.Arguments.TenantName)
+
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"tenantName":     params.Arguments.TenantName,
+		"groups":         params.Arguments.Groups,
+		"clusterClasses": params.Arguments.ClusterClasses,
+		"maxClusters":    params.Arguments.MaxClusters,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, "create_tenant", arguments, p.handleCreateTenant)
+	p.recordOperation(ctx, "create_tenant", "", startedAt, map[string]string{
+		"tenantName": params.Arguments.TenantName,
+		"groups":     strings.Join(params.Arguments.Groups, ","),
+	}, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.CreateTenantOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleDisableToolTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedSetToolEnabledArgs]) (*mcp.CallToolResultFor[api.SetToolEnabledOutput], error) {
+	p.logger.WithContext(ctx).Info("handling disable_tool", "tool", params.Arguments.Tool, "reason", params.Arguments.Reason)
+
+	if err := p.requireToolAdmin(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"tool":   params.Arguments.Tool,
+		"reason": params.Arguments.Reason,
+	}
+	// Disabling a tool is an emergency control, so the admission policy does
+	// not apply to it
+	startedAt := time.Now()
+	result, err := p.handleDisableTool(ctx, arguments)
+	p.recordOperation(ctx, "disable_tool", "", startedAt, map[string]string{
+		"tool":   params.Arguments.Tool,
+		"reason": params.Arguments.Reason,
+	}, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.SetToolEnabledOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleEnableToolTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedSetToolEnabledArgs]) (*mcp.CallToolResultFor[api.SetToolEnabledOutput], error) {
+	p.logger.WithContext(ctx).Info("handling enable_tool", "tool", params.Arguments.Tool)
+
+	if err := p.requireToolAdmin(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"tool": params.Arguments.Tool,
+	}
+	startedAt := time.Now()
+	result, err := p.handleEnableTool(ctx, arguments)
+	p.recordOperation(ctx, "enable_tool", "", startedAt, map[string]string{
+		"tool": params.Arguments.Tool,
+	}, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.SetToolEnabledOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleListDisabledToolsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedEmptyArgs]) (*mcp.CallToolResultFor[api.ListDisabledToolsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_disabled_tools")
+
+	if err := p.requireToolAdmin(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	result, err := p.handleListDisabledTools(ctx, map[string]interface{}{})
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ListDisabledToolsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleStartMaintenanceTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedStartMaintenanceArgs]) (*mcp.CallToolResultFor[api.MaintenanceOutput], error) {
+	p.logger.WithContext(ctx).Info("handling start_maintenance", "until", params.Arguments.Until, "duration", params.Arguments.Duration)
+
+	if err := p.requireToolAdmin(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"until":    params.Arguments.Until,
+		"duration": params.Arguments.Duration,
+		"reason":   params.Arguments.Reason,
+	}
+	startedAt := time.Now()
+	result, err := p.handleStartMaintenance(ctx, arguments)
+	p.recordOperation(ctx, "start_maintenance", "", startedAt, map[string]string{
+		"until":    params.Arguments.Until,
+		"duration": params.Arguments.Duration,
+		"reason":   params.Arguments.Reason,
+	}, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.MaintenanceOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleEndMaintenanceTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedEmptyArgs]) (*mcp.CallToolResultFor[api.MaintenanceOutput], error) {
+	p.logger.WithContext(ctx).Info("handling end_maintenance")
+
+	if err := p.requireToolAdmin(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	startedAt := time.Now()
+	result, err := p.handleEndMaintenance(ctx, map[string]interface{}{})
+	p.recordOperation(ctx, "end_maintenance", "", startedAt, nil, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.MaintenanceOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetToolUsageTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetToolUsageArgs]) (*mcp.CallToolResultFor[api.GetToolUsageOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_tool_usage", "identity", params.Arguments.Identity, "tool", params.Arguments.Tool)
+
+	if err := p.requireUnrestricted(); err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"identity": params.Arguments.Identity,
+		"tool":     params.Arguments.Tool,
+		"since":    params.Arguments.Since,
+		"until":    params.Arguments.Until,
+	}
+	result, err := p.handleGetToolUsage(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetToolUsageOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleListOperationsTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedListOperationsArgs]) (*mcp.CallToolResultFor[api.ListOperationsOutput], error) {
+	p.logger.WithContext(ctx).Info("handling list_operations", "cluster", params.Arguments.ClusterName)
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"since":       params.Arguments.Since,
+		"until":       params.Arguments.Until,
+		"limit":       params.Arguments.Limit,
+	}
+	result, err := p.handleListOperations(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.ListOperationsOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetRecentChangesTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetRecentChangesArgs]) (*mcp.CallToolResultFor[api.GetRecentChangesOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_recent_changes", "since", params.Arguments.Since, "cluster", params.Arguments.ClusterName)
+
+	arguments := map[string]interface{}{
+		"since":       params.Arguments.Since,
+		"clusterName": params.Arguments.ClusterName,
+	}
+	result, err := p.handleGetRecentChanges(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.GetRecentChangesOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleGetOutputChunkTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedGetOutputChunkArgs]) (*mcp.CallToolResultFor[api.GetOutputChunkOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_output_chunk", "payload_id", params.Arguments.PayloadID, "chunk", params.Arguments.Chunk)
+
+	arguments := map[string]interface{}{
+		"payloadId": params.Arguments.PayloadID,
+		"chunk":     params.Arguments.Chunk,
+	}
+	result, err := p.handleGetOutputChunk(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	// Chunks are sized to be returned whole
+	return &mcp.CallToolResultFor[api.GetOutputChunkOutput]{
+		Content: resultContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleRollbackOperationTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedRollbackOperationArgs]) (*mcp.CallToolResultFor[api.RollbackOperationOutput], error) {
+	p.logger.WithContext(ctx).Info("handling rollback_operation", "cluster", params.Arguments.ClusterName)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+	}
+	startedAt := time.Now()
+	result, err := p.admitted(ctx, service.RollbackTool, arguments, p.handleRollbackOperation)
+	parameters := map[string]string{}
+	if output, ok := result.(map[string]interface{}); ok {
+		if rolledBack, ok := output["rolled_back"].(api.Operation); ok {
+			parameters[service.RolledBackOperationParameter] = rolledBack.ID
+			parameters["tool"] = rolledBack.Tool
+		}
+	}
+	p.recordOperation(ctx, service.RollbackTool, params.Arguments.ClusterName, startedAt, parameters, err)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.RollbackOperationOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+func (p *EnhancedProvider) handleDiffClusterStateTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedDiffClusterStateArgs]) (*mcp.CallToolResultFor[api.DiffClusterStateOutput], error) {
+	p.logger.WithContext(ctx).Info("handling diff_cluster_state", "cluster", params.Arguments.ClusterName, "from", params.Arguments.From, "to", params.Arguments.To)
+
+	ctx, err := p.namespaceContext(ctx, params.Arguments.Namespace)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	arguments := map[string]interface{}{
+		"clusterName": params.Arguments.ClusterName,
+		"from":        params.Arguments.From,
+		"to":          params.Arguments.To,
+	}
+	result, err := p.handleDiffClusterState(ctx, arguments)
+	if err != nil {
+		return nil, p.sanitizeError(err)
+	}
+
+	return &mcp.CallToolResultFor[api.DiffClusterStateOutput]{
+		Content: p.chunkedContent(result),
+	}, nil
+}
+
+// recordOperation records a mutating tool call in the operation history and
+// its duration in the operation metrics.
+func (p *EnhancedProvider) recordOperation(ctx context.Context, tool, clusterName string, startedAt time.Time, parameters map[string]string, err error) {
+	svc, ok := p.clusterService.(*service.EnhancedClusterService)
+	if !ok {
+		return
+	}
+
+	op := history.Operation{
+		Tool:          tool,
+		ClusterName:   clusterName,
+		CorrelationID: logging.GetCorrelationID(ctx),
+		Outcome:       history.OutcomeSucceeded,
+		StartedAt:     startedAt,
+		Duration:      time.Since(startedAt),
+		Parameters:    parameters,
+	}
+	if namespace, ok := kube.NamespaceFromContext(ctx); ok && clusterName != "" {
+		op.Namespace = namespace
+	}
+	if p.identity != nil {
+		op.Identity = p.identity.Name
+	}
+	if err != nil {
+		op.Outcome = history.OutcomeFailed
+		op.Error = errors.GetUserMessage(err)
+	}
+	svc.RecordOperation(ctx, op)
+
+	if p.metrics != nil {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		namespace, _ := kube.NamespaceFromContext(ctx)
+		p.metrics.ObserveClusterOperation(tool, namespace, clusterName, status, op.Duration, logging.GetTraceID(ctx), op.CorrelationID)
+	}
+}
+
+// namespaceContext resolves the namespace a cluster tool operates in for the
+// calling identity and scopes Kubernetes operations in ctx to it. The
+// identity is recorded in ctx for policies applied by the cluster service.
+func (p *EnhancedProvider) namespaceContext(ctx context.Context, namespace string) (context.Context, error) {
+	if p.identity != nil {
+		resolved, err := p.identity.ResolveNamespace(namespace)
+		if err != nil {
+			p.logger.Warn("Namespace access denied", "identity", p.identity.Name, "namespace", namespace)
+			return nil, err
+		}
+		namespace = resolved
+		ctx = auth.ContextWithIdentity(ctx, p.identity)
+	}
+	if namespace == "" {
+		return ctx, nil
+	}
+	return kube.ContextWithNamespace(ctx, namespace), nil
+}
+
+// requireUnrestricted denies management cluster tools to namespace-scoped identities.
+func (p *EnhancedProvider) requireUnrestricted() error {
+	if p.identity != nil && !p.identity.Unrestricted() {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("identity '%s' is not permitted to manage the management cluster", p.identity.Name))
+	}
+	return nil
+}
+
+// requireToolAdmin limits the tools that control which tools are available,
+// such as disable_tool and start_maintenance, to the tool admin.
+func (p *EnhancedProvider) requireToolAdmin() error {
+	if p.identity != nil && !p.identity.ToolAdmin() {
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("identity '%s' is not permitted to administer tools", p.identity.Name))
+	}
+	return nil
+}
+
+// admitted evaluates the admission policy for a mutating tool call and calls
+// handler only if the policy allows it.
+func (p *EnhancedProvider) admitted(ctx context.Context, tool string, arguments map[string]interface{}, handler func(context.Context, map[string]interface{}) (interface{}, error)) (interface{}, error) {
+	if err := p.admit(ctx, tool, arguments); err != nil {
+		return nil, err
+	}
+	if p.cache != nil {
+		// Cached results may no longer reflect the clusters
+		defer p.cache.clear()
+	}
+	return handler(ctx, arguments)
+}
+
+// dryRunAdmitted calls the handler of a tool with a dryRun argument once the
+// call is admitted. Dry runs are evaluated by the admission policy like the
+// calls they preview, but as they change nothing, they keep working during
+// maintenance and cached results are kept.
+func (p *EnhancedProvider) dryRunAdmitted(ctx context.Context, tool string, arguments map[string]interface{}, dryRun bool, handler func(context.Context, map[string]interface{}) (interface{}, error)) (interface{}, error) {
+	if !dryRun {
+		return p.admitted(ctx, tool, arguments, handler)
+	}
+	if err := p.evaluatePolicy(ctx, tool, arguments); err != nil {
+		return nil, err
+	}
+	return handler(ctx, arguments)
+}
+
+// admit evaluates the admission policy for a tool call. Mutating tool calls
+// are refused while the server is in maintenance mode.
+func (p *EnhancedProvider) admit(ctx context.Context, tool string, arguments map[string]interface{}) error {
+	if p.maintenance != nil {
+		if err := p.maintenance.check(tool); err != nil {
+			p.logger.WithContext(ctx).Info("Tool call refused during maintenance", "tool", tool)
+			return err
+		}
+	}
+	return p.evaluatePolicy(ctx, tool, arguments)
+}
+
+// evaluatePolicy refuses a tool call the admission policy denies.
+func (p *EnhancedProvider) evaluatePolicy(ctx context.Context, tool string, arguments map[string]interface{}) error {
+	if p.policy == nil {
+		return nil
+	}
+
+	input := policy.Input{Tool: tool, Arguments: arguments}
+	if namespace, ok := kube.NamespaceFromContext(ctx); ok {
+		input.Namespace = namespace
+	}
+	if p.identity != nil {
+		input.Identity = policy.Identity{
+			Name:         p.identity.Name,
+			Groups:       p.identity.Groups,
+			Namespaces:   p.identity.Namespaces,
+			Unrestricted: p.identity.Unrestricted(),
+		}
+	}
+
+	logger := p.logger.WithContext(ctx)
+	decision, err := p.policy.Evaluate(ctx, input)
+	if err != nil {
+		if p.policyFailOpen {
+			logger.WithError(err).Warn("Admission policy evaluation failed, allowing call", "tool", tool)
+			return nil
+		}
+		logger.WithError(err).Error("Admission policy evaluation failed", "tool", tool)
+		return errors.Wrap(err, errors.CodeUnavailable, "admission policy could not be evaluated")
+	}
+	if !decision.Allowed {
+		logger.Warn("Tool call denied by admission policy", "tool", tool, "reasons", decision.Reasons)
+		return errors.New(errors.CodeForbidden,
+			fmt.Sprintf("%s denied by admission policy: %s", tool, strings.Join(decision.Reasons, "; "))).
+			WithDetails("policy_reasons", decision.Reasons)
+	}
+	return nil
+}
+
+// resultContent renders a handler result as JSON text so the agent receives the
+// full structured payload rather than a summary line.
+func resultContent(result interface{}) []mcp.Content {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return []mcp.Content{&mcp.TextContent{Text: "Tool completed but the result could not be encoded"}}
+	}
+	return []mcp.Content{&mcp.TextContent{Text: string(data)}}
+}
+
+// withCorrelationID assigns each call of a typed tool handler a correlation ID.
+// The ID is attached to the context, so it appears in logs, operation history and
+// the User-Agent of Kubernetes API requests, and is returned to the caller to
+// quote when escalating a problem. Errors are reported in the locale the call
+// asks for in its metadata, with the suggested fix of rejected arguments.
+func withCorrelationID[In, Out any](p *EnhancedProvider, handler func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error)) func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+		correlationID := logging.NewCorrelationID()
+		ctx = logging.ContextWithCorrelationID(ctx, correlationID)
+
+		result, err := handler(ctx, session, params)
+		if err != nil {
+			// The correlation ID suffix is not localized, so that clients can parse it
+			correlated := p.localizedError(p.callLocale(params.Meta), err)
+			if fix, ok := correlated.Details[validation.SuggestedFixDetail]; ok {
+				// Details do not reach MCP clients, so agents read the fix from the message
+				if data, err := json.Marshal(fix); err == nil {
+					correlated.Message = fmt.Sprintf("%s\n%s: %s", correlated.Message, validation.SuggestedFixDetail, data)
+				}
+			}
+			correlated.Message = fmt.Sprintf("%s (correlation ID: %s)", correlated.Message, correlationID)
+			return nil, correlated.WithDetails(logging.FieldCorrelationID, correlationID)
+		}
+
+		if result != nil {
+			result.Content = append(result.Content, &mcp.TextContent{Text: "Correlation ID: " + correlationID})
+		}
+		return result, nil
+	}
+}
+
+// withBudget runs a tool handler within the provider's call timeout. A call
+// that runs out of time is reported as a timeout.
+func withBudget[In, Out any](p *EnhancedProvider, handler func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error)) func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+		ctx, cancel := budget.WithBudget(ctx, p.callTimeout)
+		defer cancel()
+
+		result, err := handler(ctx, session, params)
+		if err != nil && p.callTimeout > 0 && ctx.Err() == context.DeadlineExceeded && !errors.IsTimeout(err) {
+			return nil, errors.Wrap(err, errors.CodeTimeout,
+				fmt.Sprintf("the call did not complete within its time budget of %s: %s", p.callTimeout, errors.GetUserMessage(err)))
+		}
+		return result, err
+	}
+}
+
+// withAccounting counts each call of a tool handler, and whether it failed,
+// towards the calling identity's tool usage.
+func withAccounting[In, Out any](p *EnhancedProvider, handler func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error)) func(context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+	return func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+		result, err := handler(ctx, session, params)
+		if svc, ok := p.clusterService.(*service.EnhancedClusterService); ok {
+			var identity string
+			if p.identity != nil {
+				identity = p.identity.Name
+			}
+			svc.RecordToolCall(identity, params.Name, err)
+		}
+		return result, err
+	}
+}
+
+// wrapToolHandler wraps a tool handler with logging and error handling
+func (p *EnhancedProvider) wrapToolHandler(toolName string, handler func(context.Context, map[string]interface{}) (interface{}, error)) func(context.Context, map[string]interface{}) (map[string]interface{}, error) {
+	return func(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
+		// Add tool context to logger
+		toolLogger := p.logger.WithContext(ctx).With(
+			logging.FieldTool, toolName,
+		)
+
+		// Log tool invocation
+		toolLogger.Info("Tool invocation started")
+		result, err := handler(ctx, input)
+		if err != nil {
+			p.logger.WithError(err).Error("Tool invocation failed", logging.FieldTool, toolName)
+		} else {
+			toolLogger.Info("Tool invocation completed")
+		}
+
+		if err != nil {
+			// Return user-friendly error
+			userErr := p.sanitizeError(err)
+			return nil, userErr
+		}
+
+		// Convert result to map
+		resultMap, ok := result.(map[string]interface{})
+		if !ok {
+			// Try to convert using JSON marshaling
+			return convertToMap(result)
+		}
+
+		return resultMap, nil
+	}
+}
+
+// sanitizeError converts internal errors to user-friendly errors
+func (p *EnhancedProvider) sanitizeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Get error code and user message
+	code := errors.GetErrorCode(err)
+	userMsg := errors.GetUserMessage(err)
+
+	// Create sanitized error with code, keeping the catalog key of the message
+	sanitized := errors.New(code, userMsg)
+	var e *errors.Error
+	if stderrors.As(err, &e) && e.MessageKey != "" {
+		sanitized.WithMessageKey(e.MessageKey, e.MessageArgs...)
+	}
+
+	// Add selected details if available
+	if e, ok := err.(*errors.Error); ok && e.Details != nil {
+		// Only include safe details
+		safeDetails := make(map[string]interface{})
+		for key, value := range e.Details {
+			switch key {
+			case "field", "resource", "operation", "retry_after", "retry_after_seconds", validation.SuggestedFixDetail:
+				safeDetails[key] = value
+			}
+		}
+		if len(safeDetails) > 0 {
+			sanitized.WithDetailsMap(safeDetails)
+		}
+	}
+
+	return sanitized
+}
+
+// Tool handler implementations
+
+func (p *EnhancedProvider) handleListClusters(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Validate input (list_clusters has no required parameters)
+	// But we still parse it to ensure it's valid
+	var listInput api.ListClustersInput
+	if err := parseInput(input, &listInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	var status api.ClusterStatus
+	if listInput.Status != "" {
+		parsed, err := api.ParseClusterStatus(listInput.Status)
+		if err != nil {
+			return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid status filter").
+				WithDetails("field", "status")
+		}
+		status = parsed
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	var output *api.ListClustersOutput
+	var err error
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err = svc.ListClusters(ctx)
+	case *service.EnhancedClusterService:
+		output, err = svc.ListClusters(ctx)
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if status != "" {
+		output.Clusters = slices.DeleteFunc(output.Clusters, func(cluster api.ClusterSummary) bool {
+			return cluster.Status != status
+		})
+	}
+	if listInput.Environment != "" {
+		output.Clusters = slices.DeleteFunc(output.Clusters, func(cluster api.ClusterSummary) bool {
+			return cluster.Environment != listInput.Environment
+		})
+	}
+
+	// Remember what this call lists, so that the next call passing the
+	// returned token lists only what changed since
+	if p.snapshots != nil {
+		namespace, _ := kube.NamespaceFromContext(ctx)
+		scope := namespace + "\x00" + string(status) + "\x00" + listInput.Environment
+		fingerprints := clusterFingerprints(output.Clusters)
+		if listInput.SinceToken != "" {
+			if since, ok := p.snapshots.get(listInput.SinceToken, scope); ok {
+				output.Clusters, output.Removed = clusterDelta(output.Clusters, fingerprints, since)
+				output.Incremental = true
+			}
+		}
+		output.NextToken = p.snapshots.put(scope, fingerprints)
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Validate cluster name from input
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	// Parse input after validation
+	var getInput api.GetClusterInput
+	if err := parseInput(input, &getInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err := svc.GetCluster(ctx, getInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	case *service.EnhancedClusterService:
+		output, err := svc.GetCluster(ctx, getInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+}
+
+func (p *EnhancedProvider) handleCreateCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Comprehensive input validation using the enhanced validator
+	if err := p.validator.ValidateCreateClusterInput(input); err != nil {
+		return nil, err
+	}
+
+	// Parse input after validation
+	var createInput api.CreateClusterInput
+	if err := parseInput(input, &createInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err := svc.CreateCluster(ctx, createInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	case *service.EnhancedClusterService:
+		output, err := svc.CreateCluster(ctx, createInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+}
+
+func (p *EnhancedProvider) handleDeleteCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Validate cluster name from input
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	// Parse input after validation
+	var deleteInput api.DeleteClusterInput
+	if err := parseInput(input, &deleteInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err := svc.DeleteCluster(ctx, deleteInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	case *service.EnhancedClusterService:
+		output, err := svc.DeleteCluster(ctx, deleteInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+}
+
+func (p *EnhancedProvider) handlePreviewDeleteCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedPreviewDeleteClusterArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.PreviewDeleteCluster(ctx, api.PreviewDeleteClusterInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleUndeleteCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedUndeleteClusterArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.UndeleteCluster(ctx, api.UndeleteClusterInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleScaleCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Comprehensive input validation using the enhanced validator
+	if err := p.validator.ValidateScaleClusterInput(input); err != nil {
+		return nil, err
+	}
+
+	// Parse input after validation
+	var scaleInput api.ScaleClusterInput
+	if err := parseInput(input, &scaleInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err := svc.ScaleCluster(ctx, scaleInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	case *service.EnhancedClusterService:
+		output, err := svc.ScaleCluster(ctx, scaleInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+}
+
+func (p *EnhancedProvider) handleGetClusterKubeconfig(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Validate cluster name from input
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	// Parse input after validation
+	var kubeconfigInput api.GetClusterKubeconfigInput
+	if err := parseInput(input, &kubeconfigInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err := svc.GetClusterKubeconfig(ctx, kubeconfigInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	case *service.EnhancedClusterService:
+		output, err := svc.GetClusterKubeconfig(ctx, kubeconfigInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+}
+
+func (p *EnhancedProvider) handleApproveOperation(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedApproveOperationArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+	if args.ApprovalID == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "approval ID is required").WithDetails("field", "approvalId")
+	}
+	if p.approvals == nil {
+		return nil, errors.New(errors.CodeUnavailable, "approvals are not enabled")
+	}
+
+	// The approver must have access to the namespace of the cluster
+	pending, err := p.approvals.get(args.ApprovalID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.namespaceContext(ctx, pending.Namespace); err != nil {
+		return nil, err
+	}
+
+	var approver string
+	if p.identity != nil {
+		approver = p.identity.Name
+	}
+	approved, err := p.approvals.Approve(args.ApprovalID, approver)
+	if err != nil {
+		return nil, err
+	}
+	p.logger.WithContext(ctx).WithCluster(approved.ClusterName, approved.Namespace).Warn("Operation approved",
+		"tool", approved.Tool, "approval_id", approved.ID, "requested_by", approved.RequestedBy, "approved_by", approved.ApprovedBy)
+
+	return convertToMap(&api.ApproveOperationOutput{
+		Approval: approved,
+		Message: fmt.Sprintf("Approved %s of cluster '%s' for '%s', who can call it with approvalId '%s' until %s",
+			approved.Tool, approved.ClusterName, approved.RequestedBy, approved.ID, approved.ExpiresAt),
+	})
+}
+
+func (p *EnhancedProvider) handleCreateTemporaryAccess(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedCreateTemporaryAccessArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.CreateTemporaryAccess(ctx, api.CreateTemporaryAccessInput{
+		ClusterName: args.ClusterName,
+		Namespace:   args.AccessNamespace,
+		User:        args.User,
+		Role:        args.Role,
+		Duration:    args.Duration,
+		Reason:      args.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleRevokeTemporaryAccess(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedRevokeTemporaryAccessArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.RevokeTemporaryAccess(ctx, api.RevokeTemporaryAccessInput{
+		ClusterName: args.ClusterName,
+		AccessID:    args.AccessID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetClusterNodes(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Validate cluster name from input
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	// Parse input after validation
+	var nodesInput api.GetClusterNodesInput
+	if err := parseInput(input, &nodesInput); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	}
+
+	// Check if cluster service is available
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
+
+	// Call the appropriate service method
+	switch svc := p.clusterService.(type) {
+	case *service.ClusterService:
+		output, err := svc.GetClusterNodes(ctx, nodesInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	case *service.EnhancedClusterService:
+		output, err := svc.GetClusterNodes(ctx, nodesInput)
+		if err != nil {
+			return nil, err
+		}
+		return convertToMap(output)
+
+	default:
+		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	}
+}
+
+func (p *EnhancedProvider) handleGetAutoscalerStatus(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	// Validate cluster name from input
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var statusInput api.GetAutoscalerStatusInput
+	statusInput.ClusterName = input["clusterName"].(string)
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.GetAutoscalerStatus(ctx, statusInput)
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleProbeClusterAPI(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedProbeClusterAPIArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ProbeClusterAPI(ctx, api.ProbeClusterAPIInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetClusterDependencies(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedGetClusterDependenciesArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.GetClusterDependencies(ctx, api.GetClusterDependenciesInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetClusterComponentVersions(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedGetClusterComponentVersionsArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.GetClusterComponentVersions(ctx, api.GetClusterComponentVersionsInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetWorkloadResource(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedGetWorkloadResourceArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.GetWorkloadResource(ctx, api.GetWorkloadResourceInput{
+		ClusterName:       args.ClusterName,
+		APIVersion:        args.APIVersion,
+		Kind:              args.Kind,
+		ResourceNamespace: args.ResourceNamespace,
+		Name:              args.Name,
+		LabelSelector:     args.LabelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetWorkloadPodLogs(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedGetWorkloadPodLogsArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.GetWorkloadPodLogs(ctx, api.GetWorkloadPodLogsInput{
+		ClusterName:  args.ClusterName,
+		PodNamespace: args.PodNamespace,
+		Pod:          args.Pod,
+		Container:    args.Container,
+		TailLines:    args.TailLines,
+		Previous:     args.Previous,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleRunNodeDiagnostic(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedRunNodeDiagnosticArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.RunNodeDiagnostic(ctx, api.RunNodeDiagnosticInput{
+		ClusterName: args.ClusterName,
+		NodeName:    args.NodeName,
+		Checks:      args.Checks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleRotateProviderCredentials(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedRotateProviderCredentialsArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.RotateProviderCredentials(ctx, api.RotateProviderCredentialsInput{
+		Provider:    args.Provider,
+		Credentials: args.Credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleInstallCNI(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedInstallCNIArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.InstallCNI(ctx, api.InstallCNIInput{
+		ClusterName: args.ClusterName,
+		Plugin:      args.Plugin,
+		Method:      args.Method,
+		Version:     args.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleResolveNodeImage(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedResolveNodeImageArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ResolveNodeImage(ctx, api.ResolveNodeImageInput{
+		Provider:          args.Provider,
+		KubernetesVersion: args.KubernetesVersion,
+		Region:            args.Region,
+		OS:                args.OS,
+		Lookup:            args.Lookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleConfigureEtcdBackup(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedConfigureEtcdBackupArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ConfigureEtcdBackup(ctx, api.ConfigureEtcdBackupInput{
+		ClusterName:           args.ClusterName,
+		Schedule:              args.Schedule,
+		Retention:             args.Retention,
+		PersistentVolumeClaim: args.PersistentVolumeClaim,
+		Suspend:               args.Suspend,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleListEtcdBackups(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedListEtcdBackupsArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ListEtcdBackups(ctx, api.ListEtcdBackupsInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleRestoreCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedRestoreClusterArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.RestoreCluster(ctx, api.RestoreClusterInput{
+		ClusterName:        args.ClusterName,
+		SourceCluster:      args.SourceCluster,
+		Snapshot:           args.Snapshot,
+		VeleroBackup:       args.VeleroBackup,
+		VeleroNamespace:    args.VeleroNamespace,
+		IncludedNamespaces: args.IncludedNamespaces,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleRunConformance(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedRunConformanceArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.RunConformance(ctx, api.RunConformanceInput{
+		ClusterName: args.ClusterName,
+		Mode:        args.Mode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleSmokeTestCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedSmokeTestClusterArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.SmokeTestCluster(ctx, api.SmokeTestClusterInput{
+		ClusterName:  args.ClusterName,
+		Checks:       args.Checks,
+		StorageClass: args.StorageClass,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleValidateClusterTemplate(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedValidateClusterTemplateArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ValidateClusterTemplate(ctx, api.ValidateClusterTemplateInput{
+		TemplateName: args.TemplateName,
+		Manifest:     args.Manifest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleListClusterTemplates(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ListClusterTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handlePublishClusterTemplate(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedPublishClusterTemplateArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.PublishClusterTemplate(ctx, api.PublishClusterTemplateInput{
+		Manifest:        args.Manifest,
+		Artifact:        args.Artifact,
+		CatalogTemplate: args.CatalogTemplate,
+		Version:         args.Version,
+		DryRun:          args.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleCleanupOrphanedResources(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedCleanupOrphanedResourcesArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.CleanupOrphanedResources(ctx, api.CleanupOrphanedResourcesInput{
+		ConfirmationToken: args.ConfirmationToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleExportInventory(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedExportInventoryArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ExportInventory(ctx, api.ExportInventoryInput{
+		Format: args.Format,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleListEnvironments(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ListEnvironments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleScanOrphanedCloudResources(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedScanOrphanedCloudResourcesArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ScanOrphanedCloudResources(ctx, api.ScanOrphanedCloudResourcesInput{
+		Provider: args.Provider,
+		Region:   args.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleForceDeleteCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedForceDeleteClusterArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.ForceDeleteCluster(ctx, api.ForceDeleteClusterInput{
+		ClusterName:       args.ClusterName,
+		RemoveFinalizers:  args.RemoveFinalizers,
+		ConfirmationToken: args.ConfirmationToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleGetOperationStatus(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedGetOperationStatusArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	// Scoped identities only see operations in their own namespaces
+	var namespaces []string
+	if p.identity != nil && !p.identity.Unrestricted() {
+		namespaces = p.identity.Namespaces
+	}
+
+	output, err := svc.GetOperationStatus(ctx, api.GetOperationStatusInput{OperationID: args.OperationID}, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleListPresets(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToMap(svc.ListPresets())
+}
+
+func (p *EnhancedProvider) handleGetManagementClusterInfo(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.GetManagementClusterInfo(ctx, api.GetManagementClusterInfoInput{})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleUpgradeManagementProviders(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedUpgradeManagementProvidersArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.UpgradeManagementProviders(ctx, api.UpgradeManagementProvidersInput{
+		Apply:                   args.Apply,
+		Contract:                args.Contract,
+		CoreProvider:            args.CoreProvider,
+		InfrastructureProviders: args.InfrastructureProviders,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleCreateNodePool(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedCreateNodePoolArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.CreateNodePool(ctx, api.CreateNodePoolInput{
+		ClusterName:  args.ClusterName,
+		NodePoolName: args.NodePoolName,
+		Class:        args.Class,
+		Replicas:     args.Replicas,
+		InstanceType: args.InstanceType,
+		GPUCount:     args.GPUCount,
+		Spot:         args.Spot,
+		Variables:    args.Variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleUpdateClusterVariables(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedUpdateClusterVariablesArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.UpdateClusterVariables(ctx, api.UpdateClusterVariablesInput{
+		ClusterName: args.ClusterName,
+		Variables:   args.Variables,
+		Unset:       args.Unset,
+		DryRun:      args.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
+
+func (p *EnhancedProvider) handleCheckTemplateRotation(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedCheckTemplateRotationArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+	if args.ClusterName != "" {
+		if err := p.validateClusterNameFromInput(input); err != nil {
+			return nil, err
 		}
+	}
 
-		return resultMap, nil
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.CheckTemplateRotation(ctx, api.CheckTemplateRotationInput{ClusterName: args.ClusterName})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
 }
 
-// sanitizeError converts internal errors to user-friendly errors
-func (p *EnhancedProvider) sanitizeError(err error) error {
-	if err == nil {
-		return nil
+func (p *EnhancedProvider) handleRefreshClusterTemplates(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
 	}
 
-	// Get error code and user message
-	code := errors.GetErrorCode(err)
-	userMsg := errors.GetUserMessage(err)
+	var args EnhancedRefreshClusterTemplatesArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
 
-	// Create sanitized error with code
-	sanitized := errors.New(code, userMsg)
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
 
-	// Add selected details if available
-	if e, ok := err.(*errors.Error); ok && e.Details != nil {
-		// Only include safe details
-		safeDetails := make(map[string]interface{})
-		for key, value := range e.Details {
-			switch key {
-			case "field", "resource", "operation":
-				safeDetails[key] = value
-			}
-		}
-		if len(safeDetails) > 0 {
-			sanitized.WithDetailsMap(safeDetails)
-		}
+	output, err := svc.RefreshClusterTemplates(ctx, api.RefreshClusterTemplatesInput{
+		ClusterName: args.ClusterName,
+		DryRun:      args.DryRun,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
+}
 
-	return sanitized
+func (p *EnhancedProvider) handlePauseRollout(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	return p.handleRollout(ctx, service.RolloutPause, input)
 }
 
-// Tool handler implementations
+func (p *EnhancedProvider) handleResumeRollout(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	return p.handleRollout(ctx, service.RolloutResume, input)
+}
 
-func (p *EnhancedProvider) handleListClusters(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Validate input (list_clusters has no required parameters)
-	// But we still parse it to ensure it's valid
-	var listInput api.ListClustersInput
-	if err := parseInput(input, &listInput); err != nil {
+func (p *EnhancedProvider) handleRestartRollout(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	return p.handleRollout(ctx, service.RolloutRestart, input)
+}
+
+func (p *EnhancedProvider) handleUndoRollout(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	return p.handleRollout(ctx, service.RolloutUndo, input)
+}
+
+func (p *EnhancedProvider) handleRollout(ctx context.Context, action string, input map[string]interface{}) (interface{}, error) {
+	if err := p.validateClusterNameFromInput(input); err != nil {
+		return nil, err
+	}
+
+	var args EnhancedRolloutArgs
+	if err := parseInput(input, &args); err != nil {
 		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
 	}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
 	}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.ListClusters(ctx)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+	rolloutInput := api.RolloutInput{
+		ClusterName: args.ClusterName,
+		Target:      args.Target,
+		ToRevision:  args.ToRevision,
+		DryRun:      args.DryRun,
+	}
+	var output *api.RolloutOutput
+	switch action {
+	case service.RolloutPause:
+		output, err = svc.PauseRollout(ctx, rolloutInput)
+	case service.RolloutResume:
+		output, err = svc.ResumeRollout(ctx, rolloutInput)
+	case service.RolloutRestart:
+		output, err = svc.RestartRollout(ctx, rolloutInput)
+	default:
+		output, err = svc.UndoRollout(ctx, rolloutInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
 
-	case *service.EnhancedClusterService:
-		output, err := svc.ListClusters(ctx)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+func (p *EnhancedProvider) handleBulkScale(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedBulkScaleArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
 
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := svc.BulkScale(ctx, api.BulkScaleInput{
+		LabelSelector: args.LabelSelector,
+		Environment:   args.Environment,
+		NodePoolName:  args.NodePoolName,
+		Replicas:      args.Replicas,
+		Concurrency:   args.Concurrency,
+		MaxFailures:   args.MaxFailures,
+		Canary:        args.Canary,
+		CanaryWait:    args.CanaryWait,
+		HealthGates:   args.HealthGates,
+		DryRun:        args.DryRun,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
 }
 
-func (p *EnhancedProvider) handleGetCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Validate cluster name from input
-	if err := p.validateClusterNameFromInput(input); err != nil {
+func (p *EnhancedProvider) handleBulkUpgrade(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedBulkUpgradeArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
 		return nil, err
 	}
 
-	// Parse input after validation
-	var getInput api.GetClusterInput
-	if err := parseInput(input, &getInput); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	output, err := svc.BulkUpgrade(ctx, api.BulkUpgradeInput{
+		LabelSelector:     args.LabelSelector,
+		Environment:       args.Environment,
+		KubernetesVersion: args.KubernetesVersion,
+		Concurrency:       args.Concurrency,
+		MaxFailures:       args.MaxFailures,
+		Canary:            args.Canary,
+		CanaryWait:        args.CanaryWait,
+		HealthGates:       args.HealthGates,
+		DryRun:            args.DryRun,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
+}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+func (p *EnhancedProvider) handleCreateTenant(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedCreateTenantArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
 	}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.GetCluster(ctx, getInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
 
-	case *service.EnhancedClusterService:
-		output, err := svc.GetCluster(ctx, getInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+	output, err := svc.CreateTenant(ctx, api.CreateTenantInput{
+		TenantName:     args.TenantName,
+		Groups:         args.Groups,
+		ClusterClasses: args.ClusterClasses,
+		MaxClusters:    args.MaxClusters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
 
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+func (p *EnhancedProvider) handleDisableTool(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedSetToolEnabledArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+	if p.toolSwitch == nil {
+		return nil, errors.New(errors.CodeUnavailable, "tool switch not available")
+	}
+	if args.Tool == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "tool is required").WithDetails("field", "tool")
+	}
+
+	changed, err := p.toolSwitch.Disable(args.Tool, args.Reason)
+	if err != nil {
+		return nil, err
 	}
+
+	output := &api.SetToolEnabledOutput{
+		Tool:     args.Tool,
+		Changed:  changed,
+		Disabled: p.toolSwitch.Disabled(),
+		Message:  fmt.Sprintf("Tool '%s' was already disabled", args.Tool),
+	}
+	if changed {
+		p.logger.WithContext(ctx).Warn("Tool disabled", logging.FieldTool, args.Tool, "reason", args.Reason)
+		output.Message = fmt.Sprintf("Tool '%s' disabled for all identities; clients were notified that the tool list changed", args.Tool)
+	}
+	return convertToMap(output)
 }
 
-func (p *EnhancedProvider) handleCreateCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Comprehensive input validation using the enhanced validator
-	if err := p.validator.ValidateCreateClusterInput(input); err != nil {
+func (p *EnhancedProvider) handleEnableTool(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedSetToolEnabledArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+	if p.toolSwitch == nil {
+		return nil, errors.New(errors.CodeUnavailable, "tool switch not available")
+	}
+	if args.Tool == "" {
+		return nil, errors.New(errors.CodeInvalidInput, "tool is required").WithDetails("field", "tool")
+	}
+
+	changed, err := p.toolSwitch.Enable(args.Tool)
+	if err != nil {
 		return nil, err
 	}
 
-	// Parse input after validation
-	var createInput api.CreateClusterInput
-	if err := parseInput(input, &createInput); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	output := &api.SetToolEnabledOutput{
+		Tool:     args.Tool,
+		Enabled:  true,
+		Changed:  changed,
+		Disabled: p.toolSwitch.Disabled(),
+		Message:  fmt.Sprintf("Tool '%s' was already enabled", args.Tool),
 	}
+	if changed {
+		p.logger.WithContext(ctx).Warn("Tool enabled", logging.FieldTool, args.Tool)
+		output.Message = fmt.Sprintf("Tool '%s' enabled for all identities; clients were notified that the tool list changed", args.Tool)
+	}
+	return convertToMap(output)
+}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+func (p *EnhancedProvider) handleListDisabledTools(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if p.toolSwitch == nil {
+		return nil, errors.New(errors.CodeUnavailable, "tool switch not available")
 	}
+	return convertToMap(&api.ListDisabledToolsOutput{Disabled: p.toolSwitch.Disabled()})
+}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.CreateCluster(ctx, createInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+func (p *EnhancedProvider) handleStartMaintenance(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedStartMaintenanceArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+	if p.maintenance == nil {
+		return nil, errors.New(errors.CodeUnavailable, "maintenance mode not available")
+	}
 
-	case *service.EnhancedClusterService:
-		output, err := svc.CreateCluster(ctx, createInput)
-		if err != nil {
-			return nil, err
+	var until time.Time
+	switch {
+	case args.Until != "" && args.Duration != "":
+		return nil, errors.New(errors.CodeInvalidInput, "until and duration are mutually exclusive").WithDetails("field", "duration")
+	case args.Until != "":
+		var err error
+		if until, err = time.Parse(time.RFC3339, args.Until); err != nil {
+			return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("until must be an RFC 3339 timestamp, got '%s'", args.Until)).
+				WithDetails("field", "until")
 		}
-		return convertToMap(output)
+		if !until.After(time.Now()) {
+			return nil, errors.New(errors.CodeInvalidInput, "until must be in the future").WithDetails("field", "until")
+		}
+	case args.Duration != "":
+		duration, err := time.ParseDuration(args.Duration)
+		if err != nil || duration <= 0 {
+			return nil, errors.New(errors.CodeInvalidInput, fmt.Sprintf("duration must be a positive duration such as 30m, got '%s'", args.Duration)).
+				WithDetails("field", "duration")
+		}
+		until = time.Now().Add(duration)
+	}
 
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	p.maintenance.Start(until, args.Reason)
+	p.logger.WithContext(ctx).Warn("Maintenance mode started", "until", until, "reason", args.Reason)
+
+	output := p.maintenance.Status()
+	output.Message = "Maintenance in progress: mutating tools are unavailable until end_maintenance is called"
+	if output.Until != "" {
+		output.Message = fmt.Sprintf("Maintenance in progress: mutating tools are unavailable until %s", output.Until)
 	}
+	return convertToMap(&output)
 }
 
-func (p *EnhancedProvider) handleDeleteCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Validate cluster name from input
-	if err := p.validateClusterNameFromInput(input); err != nil {
-		return nil, err
+func (p *EnhancedProvider) handleEndMaintenance(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	if p.maintenance == nil {
+		return nil, errors.New(errors.CodeUnavailable, "maintenance mode not available")
 	}
 
-	// Parse input after validation
-	var deleteInput api.DeleteClusterInput
-	if err := parseInput(input, &deleteInput); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	output := &api.MaintenanceOutput{Message: "Maintenance was not in progress"}
+	if p.maintenance.End() {
+		p.logger.WithContext(ctx).Warn("Maintenance mode ended")
+		output.Message = "Maintenance ended: mutating tools are available again"
 	}
+	return convertToMap(output)
+}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+func (p *EnhancedProvider) handleGetToolUsage(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedGetToolUsageArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
 	}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.DeleteCluster(ctx, deleteInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
-
-	case *service.EnhancedClusterService:
-		output, err := svc.DeleteCluster(ctx, deleteInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	output, err := svc.GetToolUsage(ctx, api.GetToolUsageInput{
+		Identity: args.Identity,
+		Tool:     args.Tool,
+		Since:    args.Since,
+		Until:    args.Until,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
 }
 
-func (p *EnhancedProvider) handleScaleCluster(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Comprehensive input validation using the enhanced validator
-	if err := p.validator.ValidateScaleClusterInput(input); err != nil {
+func (p *EnhancedProvider) handleListOperations(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedListOperationsArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	svc, err := p.enhancedClusterService()
+	if err != nil {
 		return nil, err
 	}
 
-	// Parse input after validation
-	var scaleInput api.ScaleClusterInput
-	if err := parseInput(input, &scaleInput); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	// Scoped identities only see operations in their own namespaces
+	var namespaces []string
+	if p.identity != nil && !p.identity.Unrestricted() {
+		namespaces = p.identity.Namespaces
 	}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	output, err := svc.ListOperations(ctx, api.ListOperationsInput{
+		ClusterName: args.ClusterName,
+		Since:       args.Since,
+		Until:       args.Until,
+		Limit:       args.Limit,
+	}, namespaces)
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
+}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.ScaleCluster(ctx, scaleInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+func (p *EnhancedProvider) handleGetRecentChanges(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedGetRecentChangesArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
 
-	case *service.EnhancedClusterService:
-		output, err := svc.ScaleCluster(ctx, scaleInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
+	}
+
+	// Scoped identities only see changes in their own namespaces
+	var namespaces []string
+	if p.identity != nil && !p.identity.Unrestricted() {
+		namespaces = p.identity.Namespaces
+	}
+
+	output, err := svc.GetRecentChanges(ctx, api.GetRecentChangesInput{
+		Since:       args.Since,
+		ClusterName: args.ClusterName,
+	}, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
 
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+func (p *EnhancedProvider) handleGetOutputChunk(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var args EnhancedGetOutputChunkArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
+	}
+
+	output, err := p.outputChunk(api.GetOutputChunkInput{
+		PayloadID: args.PayloadID,
+		Chunk:     args.Chunk,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
 }
 
-func (p *EnhancedProvider) handleGetClusterKubeconfig(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Validate cluster name from input
+func (p *EnhancedProvider) handleRollbackOperation(ctx context.Context, input map[string]interface{}) (interface{}, error) {
 	if err := p.validateClusterNameFromInput(input); err != nil {
 		return nil, err
 	}
 
-	// Parse input after validation
-	var kubeconfigInput api.GetClusterKubeconfigInput
-	if err := parseInput(input, &kubeconfigInput); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	var args EnhancedRollbackOperationArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
 	}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
 	}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.GetClusterKubeconfig(ctx, kubeconfigInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
-
-	case *service.EnhancedClusterService:
-		output, err := svc.GetClusterKubeconfig(ctx, kubeconfigInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
-
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	output, err := svc.RollbackOperation(ctx, api.RollbackOperationInput{
+		ClusterName: args.ClusterName,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return convertToMap(output)
 }
 
-func (p *EnhancedProvider) handleGetClusterNodes(ctx context.Context, input map[string]interface{}) (interface{}, error) {
-	// Validate cluster name from input
+func (p *EnhancedProvider) handleDiffClusterState(ctx context.Context, input map[string]interface{}) (interface{}, error) {
 	if err := p.validateClusterNameFromInput(input); err != nil {
 		return nil, err
 	}
 
-	// Parse input after validation
-	var nodesInput api.GetClusterNodesInput
-	if err := parseInput(input, &nodesInput); err != nil {
-		return nil, errors.Wrap(err, errors.CodeInvalidInput, "failed to parse validated input")
+	var args EnhancedDiffClusterStateArgs
+	if err := parseInput(input, &args); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInvalidInput, "invalid input parameters")
 	}
 
-	// Check if cluster service is available
-	if p.clusterService == nil {
-		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	svc, err := p.enhancedClusterService()
+	if err != nil {
+		return nil, err
 	}
 
-	// Call the appropriate service method
-	switch svc := p.clusterService.(type) {
-	case *service.ClusterService:
-		output, err := svc.GetClusterNodes(ctx, nodesInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+	output, err := svc.DiffClusterState(ctx, api.DiffClusterStateInput{
+		ClusterName: args.ClusterName,
+		From:        args.From,
+		To:          args.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToMap(output)
+}
 
-	case *service.EnhancedClusterService:
-		output, err := svc.GetClusterNodes(ctx, nodesInput)
-		if err != nil {
-			return nil, err
-		}
-		return convertToMap(output)
+// enhancedClusterService returns the cluster service for tools that are only
+// implemented by EnhancedClusterService.
+func (p *EnhancedProvider) enhancedClusterService() (*service.EnhancedClusterService, error) {
+	if p.clusterService == nil {
+		return nil, errors.New(errors.CodeUnavailable, "cluster service not available")
+	}
 
-	default:
-		return nil, errors.New(errors.CodeInternal, "unknown cluster service type")
+	svc, ok := p.clusterService.(*service.EnhancedClusterService)
+	if !ok {
+		return nil, errors.New(errors.CodeUnavailable, "this tool is not supported by the configured cluster service")
 	}
+	return svc, nil
 }
 
 // Helper validation functions
@@ -684,50 +4914,586 @@ func convertToMap(v interface{}) (map[string]interface{}, error) {
 	case map[string]interface{}:
 		return val, nil
 	case *api.ListClustersOutput:
-		return map[string]interface{}{
-			"clusters": val.Clusters,
-		}, nil
+		result := map[string]interface{}{
+			"clusters":     val.Clusters,
+			"last_updated": val.LastUpdated,
+		}
+		if val.Incremental {
+			result["incremental"] = true
+		}
+		if len(val.Removed) > 0 {
+			result["removed"] = val.Removed
+		}
+		if val.NextToken != "" {
+			result["next_token"] = val.NextToken
+		}
+		return result, nil
 	case *api.GetClusterOutput:
-		return map[string]interface{}{
+		result := map[string]interface{}{
 			"cluster": val.Cluster,
-			// Note: ProviderStatus removed from API structure
-		}, nil
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
 	case *api.CreateClusterOutput:
-		return map[string]interface{}{
+		result := map[string]interface{}{
 			"cluster_name": val.ClusterName,
 			"status":       val.Status,
 			"message":      val.Message,
-		}, nil
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		if val.NodeImage != "" {
+			result["node_image"] = val.NodeImage
+		}
+		if val.OperationID != "" {
+			result["operation_id"] = val.OperationID
+		}
+		return result, nil
 	case *api.DeleteClusterOutput:
-		return map[string]interface{}{
+		result := map[string]interface{}{
 			"status":  val.Status,
 			"message": val.Message,
+		}
+		if len(val.Remaining) > 0 {
+			result["remaining"] = val.Remaining
+		}
+		if val.DeleteAfter != "" {
+			result["delete_after"] = val.DeleteAfter
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.PreviewDeleteClusterOutput:
+		result := map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"namespace":    val.Namespace,
+			"provider":     val.Provider,
+			"machines":     val.Machines,
+			"message":      val.Message,
+		}
+		if val.Region != "" {
+			result["region"] = val.Region
+		}
+		if len(val.CloudResources) > 0 {
+			result["cloud_resources"] = val.CloudResources
+		}
+		if len(val.PersistentVolumes) > 0 {
+			result["persistent_volumes"] = val.PersistentVolumes
+		}
+		if len(val.LoadBalancers) > 0 {
+			result["load_balancers"] = val.LoadBalancers
+		}
+		if len(val.Dependents) > 0 {
+			result["dependents"] = val.Dependents
+		}
+		if val.RetentionWindow != "" {
+			result["retention_window"] = val.RetentionWindow
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.UndeleteClusterOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"paused":       val.Paused,
+			"message":      val.Message,
 		}, nil
 	case *api.ScaleClusterOutput:
 		return map[string]interface{}{
-			"status":      val.Status,
-			"message":     val.Message,
-			"oldReplicas": val.OldReplicas,
-			"newReplicas": val.NewReplicas,
+			"status":       val.Status,
+			"message":      val.Message,
+			"old_replicas": val.OldReplicas,
+			"new_replicas": val.NewReplicas,
+			"spot":         val.Spot,
+		}, nil
+	case *api.CreateNodePoolOutput:
+		return map[string]interface{}{
+			"node_pool_name": val.NodePoolName,
+			"class":          val.Class,
+			"status":         val.Status,
+			"message":        val.Message,
+		}, nil
+	case *api.UpdateClusterVariablesOutput:
+		result := map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"dry_run":      val.DryRun,
+			"changes":      val.Changes,
+			"diff":         val.Diff,
+			"rollouts":     val.Rollouts,
+			"applied":      val.Applied,
+			"message":      val.Message,
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		if len(val.PreviousVariables) > 0 {
+			result["previous_variables"] = val.PreviousVariables
+		}
+		return result, nil
+	case *api.CheckTemplateRotationOutput:
+		result := map[string]interface{}{
+			"clusters": val.Clusters,
+			"checked":  val.Checked,
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.RefreshClusterTemplatesOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"dry_run":      val.DryRun,
+			"rotation":     val.Rotation,
+			"rolled_out":   val.RolledOut,
+			"applied":      val.Applied,
+			"message":      val.Message,
 		}, nil
+	case *api.RolloutOutput:
+		result := map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"action":       val.Action,
+			"target":       val.Target,
+			"dry_run":      val.DryRun,
+			"paused":       val.Paused,
+			"applied":      val.Applied,
+			"message":      val.Message,
+		}
+		if val.Revision != 0 {
+			result["revision"] = val.Revision
+		}
+		if len(val.Machines) > 0 {
+			result["machines"] = val.Machines
+		}
+		return result, nil
+	case *api.BulkOperationOutput:
+		result := map[string]interface{}{
+			"action":         val.Action,
+			"label_selector": val.LabelSelector,
+			"dry_run":        val.DryRun,
+			"concurrency":    val.Concurrency,
+			"clusters":       val.Clusters,
+			"planned":        val.Planned,
+			"skipped":        val.Skipped,
+			"message":        val.Message,
+		}
+		if len(val.HealthGates) > 0 {
+			result["health_gates"] = val.HealthGates
+		}
+		if val.OperationID != "" {
+			result["operation_id"] = val.OperationID
+		}
+		return result, nil
 	case *api.GetClusterKubeconfigOutput:
 		return map[string]interface{}{
 			"kubeconfig": val.Kubeconfig,
 		}, nil
+	case *api.ApproveOperationOutput:
+		return map[string]interface{}{
+			"approval": val.Approval,
+			"message":  val.Message,
+		}, nil
+	case *api.CreateTemporaryAccessOutput:
+		return map[string]interface{}{
+			"access_id":    val.AccessID,
+			"cluster_name": val.ClusterName,
+			"namespace":    val.Namespace,
+			"user":         val.User,
+			"role":         val.Role,
+			"expires_at":   val.ExpiresAt,
+			"kubeconfig":   val.Kubeconfig,
+			"message":      val.Message,
+		}, nil
+	case *api.RevokeTemporaryAccessOutput:
+		return map[string]interface{}{
+			"access_id":    val.AccessID,
+			"cluster_name": val.ClusterName,
+			"namespace":    val.Namespace,
+			"user":         val.User,
+			"message":      val.Message,
+		}, nil
 	case *api.GetClusterNodesOutput:
 		return map[string]interface{}{
 			"nodes": val.Nodes,
 		}, nil
+	case *api.ResolveNodeImageOutput:
+		return map[string]interface{}{
+			"provider":           val.Provider,
+			"image_id":           val.ImageID,
+			"name":               val.Name,
+			"region":             val.Region,
+			"os":                 val.OS,
+			"kubernetes_version": val.KubernetesVersion,
+			"lookup":             val.Lookup,
+			"created_at":         val.CreatedAt,
+			"message":            val.Message,
+		}, nil
+	case *api.ConfigureEtcdBackupOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"cron_job":     val.CronJob,
+			"schedule":     val.Schedule,
+			"retention":    val.Retention,
+			"destination":  val.Destination,
+			"suspended":    val.Suspended,
+			"created":      val.Created,
+			"message":      val.Message,
+		}, nil
+	case *api.ListEtcdBackupsOutput:
+		return map[string]interface{}{
+			"cluster_name":       val.ClusterName,
+			"configured":         val.Configured,
+			"schedule":           val.Schedule,
+			"retention":          val.Retention,
+			"destination":        val.Destination,
+			"suspended":          val.Suspended,
+			"last_schedule_time": val.LastScheduleTime,
+			"backups":            val.Backups,
+			"message":            val.Message,
+		}, nil
+	case *api.RestoreClusterOutput:
+		return map[string]interface{}{
+			"cluster_name":      val.ClusterName,
+			"source_cluster":    val.SourceCluster,
+			"snapshot_id":       val.SnapshotID,
+			"snapshot_taken_at": val.SnapshotTakenAt,
+			"operation_id":      val.OperationID,
+			"stages":            val.Stages,
+			"message":           val.Message,
+		}, nil
+	case *api.RunConformanceOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"mode":         val.Mode,
+			"operation_id": val.OperationID,
+			"stages":       val.Stages,
+			"message":      val.Message,
+		}, nil
+	case *api.SmokeTestClusterOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"namespace":    val.Namespace,
+			"passed":       val.Passed,
+			"checks":       val.Checks,
+			"cleaned_up":   val.CleanedUp,
+			"message":      val.Message,
+		}, nil
+	case *api.ValidateClusterTemplateOutput:
+		return map[string]interface{}{
+			"template_name": val.TemplateName,
+			"valid":         val.Valid,
+			"issues":        val.Issues,
+			"message":       val.Message,
+		}, nil
+	case *api.ListClusterTemplatesOutput:
+		result := map[string]interface{}{
+			"templates": val.Templates,
+			"catalog":   val.Catalog,
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.PublishClusterTemplateOutput:
+		result := map[string]interface{}{
+			"template_name": val.TemplateName,
+			"source":        val.Source,
+			"dry_run":       val.DryRun,
+			"objects":       val.Objects,
+			"changes":       val.Changes,
+			"diff":          val.Diff,
+			"applied":       val.Applied,
+			"message":       val.Message,
+		}
+		if len(val.Issues) > 0 {
+			result["issues"] = val.Issues
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.GetOperationStatusOutput:
+		return map[string]interface{}{
+			"operation_id": val.OperationID,
+			"kind":         val.Kind,
+			"cluster_name": val.ClusterName,
+			"state":        val.State,
+			"stages":       val.Stages,
+			"error":        val.Error,
+			"created_at":   val.CreatedAt,
+			"updated_at":   val.UpdatedAt,
+			"message":      val.Message,
+		}, nil
+	case *api.CleanupOrphanedResourcesOutput:
+		result := map[string]interface{}{
+			"resources": val.Resources,
+			"deleted":   val.Deleted,
+			"message":   val.Message,
+		}
+		if val.ConfirmationToken != "" {
+			result["confirmation_token"] = val.ConfirmationToken
+		}
+		return result, nil
+	case *api.ExportInventoryOutput:
+		result := map[string]interface{}{
+			"format":       val.Format,
+			"generated_at": val.GeneratedAt,
+			"message":      val.Message,
+		}
+		if val.Format == "csv" {
+			result["csv"] = val.CSV
+		} else {
+			result["clusters"] = val.Clusters
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.ScanOrphanedCloudResourcesOutput:
+		result := map[string]interface{}{
+			"provider":  val.Provider,
+			"clusters":  val.Clusters,
+			"resources": val.Resources,
+			"message":   val.Message,
+		}
+		if val.Region != "" {
+			result["region"] = val.Region
+		}
+		return result, nil
+	case *api.ForceDeleteClusterOutput:
+		result := map[string]interface{}{
+			"cluster_name":       val.ClusterName,
+			"deleting_since":     val.DeletingSince,
+			"blockers":           val.Blockers,
+			"finalizers_removed": val.FinalizersRemoved,
+			"message":            val.Message,
+		}
+		if val.ConfirmationToken != "" {
+			result["confirmation_token"] = val.ConfirmationToken
+		}
+		return result, nil
+	case *api.ListEnvironmentsOutput:
+		result := map[string]interface{}{
+			"label":        val.Label,
+			"environments": val.Environments,
+			"last_updated": val.LastUpdated,
+		}
+		if len(val.Unassigned) > 0 {
+			result["unassigned"] = val.Unassigned
+		}
+		return result, nil
+	case *api.ListPresetsOutput:
+		return map[string]interface{}{
+			"presets": val.Presets,
+		}, nil
+	case *api.GetManagementClusterInfoOutput:
+		return map[string]interface{}{
+			"core_version":      val.CoreVersion,
+			"contract_versions": val.ContractVersions,
+			"providers":         val.Providers,
+			"cert_manager":      val.CertManager,
+		}, nil
+	case *api.UpgradeManagementProvidersOutput:
+		result := map[string]interface{}{
+			"contract":  val.Contract,
+			"providers": val.Providers,
+			"applied":   val.Applied,
+			"message":   val.Message,
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.CreateTenantOutput:
+		return map[string]interface{}{
+			"namespace":       val.Namespace,
+			"created":         val.Created,
+			"existing":        val.Existing,
+			"cluster_classes": val.ClusterClasses,
+			"message":         val.Message,
+		}, nil
+	case *api.DiffClusterStateOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"from":         val.From,
+			"to":           val.To,
+			"changes":      val.Changes,
+			"diff":         val.Diff,
+			"message":      val.Message,
+		}, nil
+	case *api.RollbackOperationOutput:
+		return map[string]interface{}{
+			"rolled_back": val.RolledBack,
+			"message":     val.Message,
+		}, nil
+	case *api.MaintenanceOutput:
+		result := map[string]interface{}{
+			"active":  val.Active,
+			"message": val.Message,
+		}
+		if val.Since != "" {
+			result["since"] = val.Since
+		}
+		if val.Until != "" {
+			result["until"] = val.Until
+		}
+		if val.Reason != "" {
+			result["reason"] = val.Reason
+		}
+		return result, nil
+	case *api.SetToolEnabledOutput:
+		return map[string]interface{}{
+			"tool":     val.Tool,
+			"enabled":  val.Enabled,
+			"changed":  val.Changed,
+			"disabled": val.Disabled,
+			"message":  val.Message,
+		}, nil
+	case *api.ListDisabledToolsOutput:
+		return map[string]interface{}{
+			"disabled": val.Disabled,
+		}, nil
+	case *api.GetToolUsageOutput:
+		return map[string]interface{}{
+			"since":      val.Since,
+			"until":      val.Until,
+			"identities": val.Identities,
+		}, nil
+	case *api.ListOperationsOutput:
+		return map[string]interface{}{
+			"operations": val.Operations,
+		}, nil
+	case *api.GetRecentChangesOutput:
+		return map[string]interface{}{
+			"since":   val.Since,
+			"until":   val.Until,
+			"changes": val.Changes,
+			"message": val.Message,
+		}, nil
+	case *api.GetOutputChunkOutput:
+		return map[string]interface{}{
+			"payload_id":   val.PayloadID,
+			"chunk":        val.Chunk,
+			"total_chunks": val.TotalChunks,
+			"data":         val.Data,
+		}, nil
+	case *api.ProbeClusterAPIOutput:
+		return map[string]interface{}{
+			"cluster_name":   val.ClusterName,
+			"reachable":      val.Reachable,
+			"server_version": val.ServerVersion,
+			"latency_ms":     val.LatencyMs,
+			"ready":          val.Ready,
+			"readyz_checks":  val.ReadyzChecks,
+			"addons":         val.Addons,
+			"healthy":        val.Healthy,
+			"message":        val.Message,
+		}, nil
+	case *api.GetClusterDependenciesOutput:
+		result := map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"depends_on":   val.DependsOn,
+			"dependents":   val.Dependents,
+		}
+		if len(val.Warnings) > 0 {
+			result["warnings"] = val.Warnings
+		}
+		return result, nil
+	case *api.GetClusterComponentVersionsOutput:
+		return map[string]interface{}{
+			"cluster_name":   val.ClusterName,
+			"server_version": val.ServerVersion,
+			"nodes":          val.Nodes,
+			"control_plane":  val.ControlPlane,
+			"addons":         val.Addons,
+			"findings":       val.Findings,
+			"advisory":       val.Advisory,
+			"message":        val.Message,
+		}, nil
+	case *api.GetWorkloadResourceOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"api_version":  val.APIVersion,
+			"kind":         val.Kind,
+			"items":        val.Items,
+			"count":        val.Count,
+			"truncated":    val.Truncated,
+			"message":      val.Message,
+		}, nil
+	case *api.GetWorkloadPodLogsOutput:
+		return map[string]interface{}{
+			"cluster_name":  val.ClusterName,
+			"pod_namespace": val.PodNamespace,
+			"pod":           val.Pod,
+			"container":     val.Container,
+			"previous":      val.Previous,
+			"tail_lines":    val.TailLines,
+			"restarts":      val.Restarts,
+			"state":         val.State,
+			"logs":          val.Logs,
+			"message":       val.Message,
+		}, nil
+	case *api.RunNodeDiagnosticOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"node_name":    val.NodeName,
+			"pod":          val.Pod,
+			"completed":    val.Completed,
+			"results":      val.Results,
+			"message":      val.Message,
+		}, nil
+	case *api.RotateProviderCredentialsOutput:
+		return map[string]interface{}{
+			"provider":              val.Provider,
+			"secret":                val.Secret,
+			"identity":              val.Identity,
+			"restarted_deployments": val.RestartedDeployments,
+			"message":               val.Message,
+		}, nil
+	case *api.InstallCNIOutput:
+		return map[string]interface{}{
+			"cluster_name": val.ClusterName,
+			"plugin":       val.Plugin,
+			"method":       val.Method,
+			"version":      val.Version,
+			"resources":    val.Resources,
+			"message":      val.Message,
+		}, nil
+	case *api.GetAutoscalerStatusOutput:
+		return map[string]interface{}{
+			"installed":         val.Installed,
+			"autoscaler_status": val.AutoscalerStatus,
+			"last_updated":      val.LastUpdated,
+			"cluster_wide":      val.ClusterWide,
+			"node_pools":        val.NodePools,
+			"message":           val.Message,
+		}, nil
 	default:
 		return nil, errors.New(errors.CodeInternal, "unsupported output type")
 	}
 }
 
-// parseInput parses the input map into a target struct
+// parseInput parses the input map into a target struct. Tool arguments are
+// camelCase while the api/v1 inputs are snake_case, so each argument is also
+// offered under its snake_case name.
 func parseInput(input map[string]interface{}, target interface{}) error {
+	arguments := make(map[string]interface{}, len(input))
+	for name, value := range input {
+		arguments[name] = value
+		if snake := snakeCase(name); snake != name {
+			if _, ok := input[snake]; !ok {
+				arguments[snake] = value
+			}
+		}
+	}
+
 	// Simple approach: marshal to JSON then unmarshal to struct
 	// This handles type conversions automatically
-	jsonData, err := json.Marshal(input)
+	jsonData, err := json.Marshal(arguments)
 	if err != nil {
 		return fmt.Errorf("failed to marshal input: %w", err)
 	}
@@ -738,3 +5504,21 @@ func parseInput(input map[string]interface{}, target interface{}) error {
 
 	return nil
 }
+
+// snakeCase converts a camelCase name to snake_case, e.g. nodePoolName to
+// node_pool_name and approvalID to approval_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			wordStart := i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+			if wordStart {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
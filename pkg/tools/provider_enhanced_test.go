@@ -0,0 +1,249 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/policy"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+)
+
+// newTestEnhancedProvider returns a provider backed by an enhanced cluster
+// service on a fake client holding objects.
+func newTestEnhancedProvider(t *testing.T, objects ...client.Object) *EnhancedProvider {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, expv1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		Build()
+
+	logger := logging.NewLogger(slog.LevelError, "text")
+	clusterService := service.NewEnhancedClusterService(kube.NewClientFromClient(fakeClient, "default"), logger, provider.NewProviderManager())
+	return NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, clusterService)
+}
+
+func newTestCluster(name, phase string) *clusterv1.Cluster {
+	return &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     clusterv1.ClusterStatus{Phase: phase},
+	}
+}
+
+func TestEnhancedProvider_ListClustersStatusFilter(t *testing.T) {
+	p := newTestEnhancedProvider(t, newTestCluster("ready", "Provisioned"), newTestCluster("building", "Provisioning"))
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		status string
+		want   []string
+	}{
+		{name: "no filter", want: []string{"building", "ready"}},
+		{name: "status", status: "Ready", want: []string{"ready"}},
+		{name: "phase", status: "provisioning", want: []string{"building"}},
+		{name: "no match", status: "Failed", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]interface{}{}
+			if tt.status != "" {
+				input["status"] = tt.status
+			}
+
+			result, err := p.handleListClusters(ctx, input)
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, cluster := range result.(map[string]interface{})["clusters"].([]api.ClusterSummary) {
+				names = append(names, cluster.Name)
+			}
+			assert.ElementsMatch(t, tt.want, names)
+		})
+	}
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, err := p.handleListClusters(ctx, map[string]interface{}{"status": "running"})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+		assert.Contains(t, err.Error(), "must be one of")
+	})
+}
+
+func TestEnhancedProvider_ListClustersEnvironmentFilter(t *testing.T) {
+	prod := newTestCluster("prod-a", "Provisioned")
+	prod.Labels = map[string]string{service.DefaultEnvironmentLabel: "prod"}
+	dev := newTestCluster("dev-a", "Provisioned")
+	dev.Labels = map[string]string{service.DefaultEnvironmentLabel: "dev"}
+	p := newTestEnhancedProvider(t, prod, dev, newTestCluster("sandbox", "Provisioned"))
+	ctx := context.Background()
+
+	result, err := p.handleListClusters(ctx, map[string]interface{}{"environment": "prod"})
+	require.NoError(t, err)
+	clusters := result.(map[string]interface{})["clusters"].([]api.ClusterSummary)
+	require.Len(t, clusters, 1)
+	assert.Equal(t, "prod-a", clusters[0].Name)
+	assert.Equal(t, "prod", clusters[0].Environment)
+
+	result, err = p.handleListEnvironments(ctx, map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(map[string]interface{})
+	assert.Equal(t, service.DefaultEnvironmentLabel, output["label"])
+	assert.Len(t, output["environments"], 2)
+	assert.Equal(t, []string{"sandbox"}, output["unassigned"])
+}
+
+func TestEnhancedProvider_SuggestedFix(t *testing.T) {
+	p := newTestEnhancedProvider(t)
+	handler := withCorrelationID(p, p.handleScaleClusterTyped)
+
+	_, err := handler(context.Background(), nil, &mcp.CallToolParamsFor[EnhancedScaleClusterArgs]{
+		Name:      "scale_cluster",
+		Arguments: EnhancedScaleClusterArgs{ClusterName: "Prod_A", NodePoolName: "workers", Replicas: 250},
+	})
+	require.Error(t, err)
+	var e *errors.Error
+	require.ErrorAs(t, err, &e)
+	assert.Equal(t, errors.CodeInvalidInput, e.Code)
+	assert.Equal(t, map[string]interface{}{"clusterName": "prod-a", "replicas": int32(100)}, e.Details["suggestedFix"])
+	assert.Contains(t, e.Message, "\nsuggestedFix: {\"clusterName\":\"prod-a\",\"replicas\":100} (correlation ID: ",
+		"MCP clients only see the message")
+}
+
+func TestParseInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  api.ScaleClusterInput
+	}{
+		{
+			name:  "camelCase arguments",
+			input: map[string]interface{}{"clusterName": "prod-a", "nodePoolName": "prod-a-md-0", "replicas": 3},
+			want:  api.ScaleClusterInput{ClusterName: "prod-a", NodePoolName: "prod-a-md-0", Replicas: 3},
+		},
+		{
+			name:  "snake_case arguments",
+			input: map[string]interface{}{"cluster_name": "prod-a", "node_pool_name": "prod-a-md-0", "replicas": 3},
+			want:  api.ScaleClusterInput{ClusterName: "prod-a", NodePoolName: "prod-a-md-0", Replicas: 3},
+		},
+		{
+			name:  "snake_case takes precedence",
+			input: map[string]interface{}{"clusterName": "prod-a", "cluster_name": "prod-b"},
+			want:  api.ScaleClusterInput{ClusterName: "prod-b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got api.ScaleClusterInput
+			require.NoError(t, parseInput(tt.input, &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"clusterName":       "cluster_name",
+		"kubernetesVersion": "kubernetes_version",
+		"approvalID":        "approval_id",
+		"vpcCIDR":           "vpc_cidr",
+		"replicas":          "replicas",
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, want, snakeCase(name))
+		})
+	}
+}
+
+// policyFunc is an admission policy deciding with a function.
+type policyFunc func(input policy.Input) *policy.Decision
+
+func (f policyFunc) Evaluate(ctx context.Context, input policy.Input) (*policy.Decision, error) {
+	return f(input), nil
+}
+
+func TestEnhancedProvider_DryRunAdmission(t *testing.T) {
+	p := newTestEnhancedProvider(t)
+	var inputs []policy.Input
+	p.SetAdmissionPolicy(policyFunc(func(input policy.Input) *policy.Decision {
+		inputs = append(inputs, input)
+		return &policy.Decision{Reasons: []string{"clusters need an owner label"}}
+	}), false)
+
+	_, err := p.handleCreateClusterTyped(context.Background(), nil, &mcp.CallToolParamsFor[EnhancedCreateClusterArgs]{
+		Name:      "create_cluster",
+		Arguments: EnhancedCreateClusterArgs{ClusterName: "prod-a", TemplateName: "aws", KubernetesVersion: "v1.31.0", DryRun: true},
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+	require.Len(t, inputs, 1)
+	assert.Equal(t, true, inputs[0].Arguments["dryRun"], "the policy can tell dry runs apart")
+
+	_, err = p.handleBulkScaleTyped(context.Background(), nil, &mcp.CallToolParamsFor[EnhancedBulkScaleArgs]{
+		Name:      "bulk_scale",
+		Arguments: EnhancedBulkScaleArgs{LabelSelector: "env=prod", NodePoolName: "workers", Replicas: 5, DryRun: true},
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+	require.Len(t, inputs, 2)
+	assert.Equal(t, "bulk_scale", inputs[1].Tool)
+}
+
+func TestEnhancedProvider_DryRunAdmitted(t *testing.T) {
+	ctx := context.Background()
+	p := newTestEnhancedProvider(t)
+	maintenance := NewMaintenance()
+	maintenance.Start(time.Time{}, "management cluster upgrade")
+	p.SetMaintenance(maintenance)
+	denied := false
+	p.SetAdmissionPolicy(policyFunc(func(input policy.Input) *policy.Decision {
+		if denied {
+			return &policy.Decision{Reasons: []string{"frozen"}}
+		}
+		return &policy.Decision{Allowed: true}
+	}), false)
+
+	calls := 0
+	handler := func(context.Context, map[string]interface{}) (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+
+	// Dry runs keep working during maintenance, the calls they preview do not
+	_, err := p.dryRunAdmitted(ctx, "bulk_scale", map[string]interface{}{}, true, handler)
+	require.NoError(t, err)
+	_, err = p.dryRunAdmitted(ctx, "bulk_scale", map[string]interface{}{}, false, handler)
+	assert.Equal(t, errors.CodeUnavailable, errors.GetErrorCode(err))
+	assert.Equal(t, 1, calls)
+
+	// but are still refused by the admission policy
+	denied = true
+	_, err = p.dryRunAdmitted(ctx, "bulk_scale", map[string]interface{}{}, true, handler)
+	assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,276 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+// RESTPathPrefix prefixes the paths of the REST API.
+const RESTPathPrefix = "/api/v1"
+
+// maxRESTBodySize bounds the size of REST request bodies.
+const maxRESTBodySize = 1 << 20
+
+// restParam is a tool argument taken from the path or query of a REST
+// request.
+type restParam struct {
+	name     string // of the tool argument
+	in       string // path or query
+	kind     reflect.Kind
+	required bool
+}
+
+// restRoute exposes a tool as a REST operation. Arguments named in the path
+// pattern are path parameters; the others are query parameters of GET and
+// DELETE operations, and the JSON request body of POST operations, except for
+// the namespace, which is always a query parameter.
+type restRoute struct {
+	method  string
+	pattern string // below RESTPathPrefix, with {argument} path parameters
+	tool    string
+	summary string
+	status  int // of successful calls
+
+	params []restParam
+	body   reflect.Type // tool arguments, or nil if the operation takes no body
+	output reflect.Type // api/v1 output of the tool
+
+	serve func(p *EnhancedProvider, w http.ResponseWriter, r *http.Request)
+}
+
+// restRoutes lists the operations of the REST API. They call the same
+// handlers as the MCP tools, and answer with the api/v1 output of the tools.
+func restRoutes() []restRoute {
+	return []restRoute{
+		newRESTRoute(http.MethodGet, "/clusters", "list_clusters", "List workload clusters", http.StatusOK,
+			(*EnhancedProvider).handleListClustersTyped),
+		newRESTRoute(http.MethodPost, "/clusters", "create_cluster", "Create a workload cluster from a template", http.StatusAccepted,
+			(*EnhancedProvider).handleCreateClusterTyped),
+		newRESTRoute(http.MethodGet, "/clusters/{clusterName}", "get_cluster", "Get a workload cluster", http.StatusOK,
+			(*EnhancedProvider).handleGetClusterTyped),
+		newRESTRoute(http.MethodDelete, "/clusters/{clusterName}", "delete_cluster", "Delete a workload cluster", http.StatusAccepted,
+			(*EnhancedProvider).handleDeleteClusterTyped),
+		newRESTRoute(http.MethodPost, "/clusters/{clusterName}/scale", "scale_cluster", "Scale a node pool of a workload cluster", http.StatusOK,
+			(*EnhancedProvider).handleScaleClusterTyped),
+		newRESTRoute(http.MethodGet, "/clusters/{clusterName}/kubeconfig", "get_cluster_kubeconfig", "Get the kubeconfig of a workload cluster", http.StatusOK,
+			(*EnhancedProvider).handleGetClusterKubeconfigTyped),
+		newRESTRoute(http.MethodGet, "/clusters/{clusterName}/nodes", "get_cluster_nodes", "List the nodes of a workload cluster", http.StatusOK,
+			(*EnhancedProvider).handleGetClusterNodesTyped),
+	}
+}
+
+// newRESTRoute creates the route of a tool from its typed handler, deriving
+// the parameters and request body from the tool arguments In.
+func newRESTRoute[In, Out any](method, pattern, tool, summary string, status int, handler func(*EnhancedProvider, context.Context, *mcp.ServerSession, *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error)) restRoute {
+	route := restRoute{
+		method:  method,
+		pattern: pattern,
+		tool:    tool,
+		summary: summary,
+		status:  status,
+		output:  reflect.TypeFor[Out](),
+	}
+
+	var bodyFields []string
+	for _, field := range argumentFields(reflect.TypeFor[In]()) {
+		switch {
+		case field.name == "apiVersion":
+			// Answers always use the v1 schema the API is described in
+		case strings.Contains(pattern, "{"+field.name+"}"):
+			route.params = append(route.params, restParam{name: field.name, in: "path", kind: field.kind, required: true})
+		case field.name == "namespace" || method != http.MethodPost:
+			route.params = append(route.params, restParam{name: field.name, in: "query", kind: field.kind})
+		default:
+			bodyFields = append(bodyFields, field.name)
+		}
+	}
+	if len(bodyFields) > 0 {
+		route.body = reflect.TypeFor[In]()
+	}
+
+	route.serve = func(p *EnhancedProvider, w http.ResponseWriter, r *http.Request) {
+		correlationID := logging.NewCorrelationID()
+		ctx := logging.ContextWithCorrelationID(r.Context(), correlationID)
+		w.Header().Set("X-Correlation-ID", correlationID)
+		locale := p.requestLocale(r)
+
+		if p.toolSwitch != nil && p.toolSwitch.isDisabled(tool) {
+			writeRESTError(ctx, w, p, locale, errors.New(errors.CodeUnavailable, fmt.Sprintf("tool '%s' is disabled", tool)).
+				WithMessageKey("tool.disabled", tool))
+			return
+		}
+		var args In
+		if err := route.bind(r, &args); err != nil {
+			writeRESTError(ctx, w, p, locale, err)
+			return
+		}
+
+		call := withAccounting(p, withBudget(p, func(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[In]) (*mcp.CallToolResultFor[Out], error) {
+			return handler(p, ctx, session, params)
+		}))
+		result, err := call(ctx, nil, &mcp.CallToolParamsFor[In]{Name: tool, Arguments: args})
+		if err != nil {
+			writeRESTError(ctx, w, p, locale, err)
+			return
+		}
+		writeRESTResult(ctx, w, p, status, result.Content)
+	}
+	return route
+}
+
+// argumentField is a tool argument, by its JSON name.
+type argumentField struct {
+	name string
+	kind reflect.Kind
+}
+
+// argumentFields returns the arguments of a tool arguments struct.
+func argumentFields(t reflect.Type) []argumentField {
+	var fields []argumentField
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" || name == "" {
+			continue
+		}
+		fields = append(fields, argumentField{name: name, kind: field.Type.Kind()})
+	}
+	return fields
+}
+
+// bind decodes the tool arguments of a request from its body, path and query.
+func (route restRoute) bind(r *http.Request, args interface{}) error {
+	arguments := make(map[string]interface{})
+	if route.body != nil {
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxRESTBodySize))
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInvalidInput, "failed to read the request body")
+		}
+		if len(strings.TrimSpace(string(data))) > 0 {
+			if err := json.Unmarshal(data, &arguments); err != nil {
+				return errors.Wrap(err, errors.CodeInvalidInput, "the request body must be a JSON object")
+			}
+		}
+	}
+
+	for _, param := range route.params {
+		raw := r.URL.Query().Get(param.name)
+		if param.in == "path" {
+			raw = r.PathValue(param.name)
+		}
+		if raw == "" {
+			continue
+		}
+		value, err := param.parse(raw)
+		if err != nil {
+			return errors.Wrap(err, errors.CodeInvalidInput, fmt.Sprintf("invalid %s parameter '%s'", param.in, param.name)).
+				WithDetails("field", param.name)
+		}
+		arguments[param.name] = value
+	}
+	if _, ok := reflect.TypeOf(args).Elem().FieldByName("APIVersion"); ok {
+		arguments["apiVersion"] = OutputVersionV1
+	}
+
+	data, err := json.Marshal(arguments)
+	if err != nil {
+		return errors.Wrap(err, errors.CodeInvalidInput, "invalid arguments")
+	}
+	if err := json.Unmarshal(data, args); err != nil {
+		return errors.Wrap(err, errors.CodeInvalidInput, "invalid arguments: "+err.Error())
+	}
+	return nil
+}
+
+// parse converts a path or query parameter to the type of its argument.
+func (param restParam) parse(raw string) (interface{}, error) {
+	switch param.kind {
+	case reflect.Bool:
+		return strconv.ParseBool(raw)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(raw, 10, 64)
+	default:
+		return raw, nil
+	}
+}
+
+// RESTError is the error of a failed REST call, returned as {"error": ...}.
+type RESTError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// writeRESTError answers a REST call with the error of a tool, in the locale
+// of the request.
+func writeRESTError(ctx context.Context, w http.ResponseWriter, p *EnhancedProvider, locale string, err error) {
+	logger := p.logger.WithContext(ctx)
+	localized := p.localizedError(locale, err)
+	body := RESTError{
+		Code:    string(localized.Code),
+		Message: localized.Message,
+	}
+	if len(localized.Details) > 0 {
+		body.Details = localized.Details
+	}
+	data, marshalErr := json.Marshal(map[string]interface{}{"error": body})
+	if marshalErr != nil {
+		logger.WithError(marshalErr).Warn("Failed to encode REST error details", "code", body.Code)
+		body.Details = nil
+		// Without details the error is only strings, which always encode
+		data, _ = json.Marshal(map[string]interface{}{"error": body})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errors.HTTPStatus(err))
+	if _, err := w.Write(data); err != nil {
+		logger.WithError(err).Warn("Failed to write REST response")
+	}
+}
+
+// writeRESTResult answers a REST call with the result of a tool, reading
+// results too large for a single MCP message back from the payload store.
+func writeRESTResult(ctx context.Context, w http.ResponseWriter, p *EnhancedProvider, status int, content []mcp.Content) {
+	var text string
+	if len(content) > 0 {
+		if textContent, ok := content[0].(*mcp.TextContent); ok {
+			text = textContent.Text
+		}
+	}
+	var reference api.ChunkedOutput
+	if p.payloads != nil && json.Unmarshal([]byte(text), &reference) == nil && reference.PayloadID != "" {
+		if stored := p.payloads.get(reference.PayloadID); stored != nil {
+			text = strings.Join(stored.chunks, "")
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := io.WriteString(w, text); err != nil {
+		p.logger.WithContext(ctx).WithError(err).Warn("Failed to write REST response")
+	}
+}
+
+// RESTHandler serves the tools of the provider's identity as a REST API
+// below RESTPathPrefix, described by OpenAPISpec.
+func (p *EnhancedProvider) RESTHandler() http.Handler {
+	mux := http.NewServeMux()
+	for _, route := range restRoutes() {
+		mux.HandleFunc(route.method+" "+RESTPathPrefix+route.pattern, func(w http.ResponseWriter, r *http.Request) {
+			p.logger.WithContext(r.Context()).Info("handling REST call", "tool", route.tool, "method", r.Method, "path", r.URL.Path)
+			route.serve(p, w, r)
+		})
+	}
+	return mux
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+)
+
+func TestEnhancedProvider_RESTHandler(t *testing.T) {
+	replicas := int32(2)
+	workers := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "default", Labels: map[string]string{clusterv1.ClusterNameLabel: "ready"}},
+		Spec:       clusterv1.MachineDeploymentSpec{ClusterName: "ready", Replicas: &replicas},
+	}
+	p := newTestEnhancedProvider(t, newTestCluster("ready", "Provisioned"), newTestCluster("building", "Provisioning"), workers)
+	handler := p.RESTHandler()
+
+	call := func(method, path, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		var decoded map[string]interface{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &decoded), recorder.Body.String())
+		return recorder, decoded
+	}
+
+	t.Run("list clusters", func(t *testing.T) {
+		recorder, body := call(http.MethodGet, "/api/v1/clusters?status=Ready", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.NotEmpty(t, recorder.Header().Get("X-Correlation-ID"))
+		clusters := body["clusters"].([]interface{})
+		require.Len(t, clusters, 1)
+		cluster := clusters[0].(map[string]interface{})
+		assert.Equal(t, "ready", cluster["name"])
+		assert.Contains(t, cluster, "kubernetes_version", "answers in the v1 schema")
+	})
+
+	t.Run("scale cluster", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/clusters/ready/scale", strings.NewReader(`{"nodePoolName":"workers","replicas":3}`))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		var output api.ScaleClusterOutput
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &output), recorder.Body.String())
+		assert.Equal(t, "scaling", output.Status)
+		assert.Equal(t, 2, output.OldReplicas)
+		assert.Equal(t, 3, output.NewReplicas)
+	})
+
+	t.Run("tool errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			method string
+			path   string
+			body   string
+			status int
+			code   string
+		}{
+			{name: "invalid query", method: http.MethodGet, path: "/api/v1/clusters?status=running", status: http.StatusBadRequest, code: "INVALID_INPUT"},
+			{name: "invalid body", method: http.MethodPost, path: "/api/v1/clusters/ready/scale", body: "[1]", status: http.StatusBadRequest, code: "INVALID_INPUT"},
+			{name: "invalid body field", method: http.MethodPost, path: "/api/v1/clusters/ready/scale", body: `{"replicas":"three"}`, status: http.StatusBadRequest, code: "INVALID_INPUT"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				recorder, body := call(tt.method, tt.path, tt.body)
+				assert.Equal(t, tt.status, recorder.Code)
+				assert.Equal(t, tt.code, body["error"].(map[string]interface{})["code"])
+			})
+		}
+	})
+
+	t.Run("localized errors", func(t *testing.T) {
+		catalog, err := i18n.NewCatalog(i18n.DefaultLocale)
+		require.NoError(t, err)
+		p.SetMessageCatalog(catalog)
+		defer p.SetMessageCatalog(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/clusters", strings.NewReader(`{"clusterName":"ready","templateName":"aws","kubernetesVersion":"1.31"}`))
+		req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		var body map[string]RESTError
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body), recorder.Body.String())
+		assert.Equal(t, "INVALID_INPUT", body["error"].Code)
+		assert.Contains(t, body["error"].Message, "Die Kubernetes-Version muss das Format 'vX.Y.Z' haben")
+		assert.Equal(t, catalog.Remediation("de", errors.CodeInvalidInput), body["error"].Details["remediation"])
+		assert.Equal(t, map[string]interface{}{"kubernetesVersion": "v1.31.0"}, body["error"].Details["suggestedFix"])
+	})
+
+	t.Run("disabled tool", func(t *testing.T) {
+		toolSwitch := NewToolSwitch()
+		p.SetToolSwitch(toolSwitch)
+		require.NoError(t, p.RegisterTools())
+		_, err := toolSwitch.Disable("get_cluster", "maintenance")
+		require.NoError(t, err)
+
+		recorder, body := call(http.MethodGet, "/api/v1/clusters/ready", "")
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Contains(t, body["error"].(map[string]interface{})["message"], "disabled")
+	})
+}
+
+func TestRESTRoute_Bind(t *testing.T) {
+	var scale restRoute
+	for _, route := range restRoutes() {
+		if route.tool == "scale_cluster" {
+			scale = route
+		}
+	}
+	require.NotNil(t, scale.body)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/clusters/prod-a/scale?namespace=team-a",
+		strings.NewReader(`{"clusterName": "other", "nodePoolName": "workers", "replicas": 5}`))
+	req.SetPathValue("clusterName", "prod-a")
+	var args EnhancedScaleClusterArgs
+	require.NoError(t, scale.bind(req, &args))
+	assert.Equal(t, EnhancedScaleClusterArgs{ClusterName: "prod-a", NodePoolName: "workers", Replicas: 5, Namespace: "team-a"}, args)
+
+	var list restRoute
+	for _, route := range restRoutes() {
+		if route.tool == "list_clusters" {
+			list = route
+		}
+	}
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/clusters?environment=prod&apiVersion=v2", nil)
+	var listArgs EnhancedListClustersArgs
+	require.NoError(t, list.bind(req, &listArgs))
+	assert.Equal(t, EnhancedListClustersArgs{Environment: "prod", APIVersion: OutputVersionV1}, listArgs)
+}
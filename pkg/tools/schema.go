@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	apiv2 "github.com/capi-mcp/capi-mcp-server/api/v2"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// Output schema versions of the cluster tools. v1 is the snake_case schema of
+// the api/v1 types, kept for consumers written against it.
+const (
+	OutputVersionV1 = "v1"
+	OutputVersionV2 = apiv2.Version
+)
+
+// SetOutputVersion sets the output schema version list_clusters, get_cluster
+// and get_cluster_nodes answer in when a call does not ask for one.
+func (p *EnhancedProvider) SetOutputVersion(version string) {
+	p.outputVersion = version
+}
+
+// resolveOutputVersion returns the output schema version a call asked for, or
+// the provider's default.
+func (p *EnhancedProvider) resolveOutputVersion(requested string) (string, error) {
+	if requested == "" {
+		requested = p.outputVersion
+	}
+	switch requested {
+	case OutputVersionV1, OutputVersionV2:
+		return requested, nil
+	case "":
+		return OutputVersionV2, nil
+	default:
+		return "", errors.New(errors.CodeInvalidInput, "apiVersion must be \"v1\" or \"v2\"").
+			WithDetails("field", "apiVersion").
+			WithDetails("value", requested)
+	}
+}
+
+// versionedContent renders the result of a map handler in an output schema
+// version. v1 results are returned as the handler produced them; for v2 the
+// result is decoded into its v1 type V1 and converted with toV2.
+func versionedContent[V1, V2 any](p *EnhancedProvider, version string, result interface{}, toV2 func(*V1) *V2) ([]mcp.Content, error) {
+	if version == OutputVersionV1 {
+		return p.chunkedContent(result), nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to encode the result")
+	}
+	var output V1
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, errors.Wrap(err, errors.CodeInternal, "failed to convert the result to the v2 schema")
+	}
+	return p.chunkedContent(toV2(&output)), nil
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+func TestEnhancedProvider_OutputVersion(t *testing.T) {
+	p := newTestEnhancedProvider(t, newTestCluster("test-cluster", "Provisioned"))
+	ctx := context.Background()
+	session := &mcp.ServerSession{}
+
+	listClusters := func(t *testing.T, version string) map[string]interface{} {
+		t.Helper()
+		result, err := p.handleListClustersTyped(ctx, session, &mcp.CallToolParamsFor[EnhancedListClustersArgs]{
+			Arguments: EnhancedListClustersArgs{APIVersion: version},
+		})
+		require.NoError(t, err)
+
+		var output struct {
+			Clusters []map[string]interface{} `json:"clusters"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output))
+		require.Len(t, output.Clusters, 1)
+		return output.Clusters[0]
+	}
+
+	t.Run("v2 by default", func(t *testing.T) {
+		cluster := listClusters(t, "")
+		assert.Contains(t, cluster, "kubernetesVersion")
+		assert.Contains(t, cluster, "creationTimestamp")
+		assert.Contains(t, cluster, "nodeCount")
+		assert.Equal(t, "Ready", cluster["status"])
+	})
+
+	t.Run("v1 on request", func(t *testing.T) {
+		cluster := listClusters(t, OutputVersionV1)
+		assert.Contains(t, cluster, "kubernetes_version")
+		assert.Contains(t, cluster, "created_at")
+		assert.NotContains(t, cluster, "creationTimestamp")
+	})
+
+	t.Run("v1 by default", func(t *testing.T) {
+		p.SetOutputVersion(OutputVersionV1)
+		defer p.SetOutputVersion(OutputVersionV2)
+
+		assert.Contains(t, listClusters(t, ""), "node_count")
+		assert.Contains(t, listClusters(t, OutputVersionV2), "nodeCount")
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := p.handleGetClusterTyped(ctx, session, &mcp.CallToolParamsFor[EnhancedGetClusterArgs]{
+			Arguments: EnhancedGetClusterArgs{ClusterName: "test-cluster", APIVersion: "v3"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+}
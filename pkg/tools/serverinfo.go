@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+)
+
+// ServerName is the name the server reports to MCP clients.
+const ServerName = "capi-mcp-server"
+
+// ServerInfo describes the server build and the providers it is configured
+// with, for get_server_info.
+type ServerInfo struct {
+	Version   string
+	BuildDate string
+	Providers []string
+}
+
+// ServerInstructions are the instructions the server gives MCP clients on
+// initialization, pointing them to get_server_info to adapt to the server.
+func ServerInstructions(version string) string {
+	return fmt.Sprintf(`%s %s manages Kubernetes workload clusters with Cluster API.
+Call get_server_info first for the enabled providers and tools, the output schema versions and the
+policies in effect, such as maintenance windows making the server read-only and approvals of
+high-risk operations. Tools with a dryRun argument only report their changes when called with dryRun.
+Error messages are in the language asked for with the capi-mcp.io/locale request metadata; error
+codes are the same in every language.`,
+		ServerName, version)
+}
+
+// SetServerInfo sets the build and providers get_server_info reports.
+func (p *EnhancedProvider) SetServerInfo(info ServerInfo) {
+	p.serverInfo = info
+}
+
+func (p *EnhancedProvider) handleGetServerInfoTyped(ctx context.Context, session *mcp.ServerSession, params *mcp.CallToolParamsFor[EnhancedEmptyArgs]) (*mcp.CallToolResultFor[api.GetServerInfoOutput], error) {
+	p.logger.WithContext(ctx).Info("handling get_server_info")
+
+	return &mcp.CallToolResultFor[api.GetServerInfoOutput]{
+		Content: p.chunkedContent(p.serverInfoOutput()),
+	}, nil
+}
+
+// serverInfoOutput reports the server, the tools enabled for the caller and
+// the policies in effect.
+func (p *EnhancedProvider) serverInfoOutput() *api.GetServerInfoOutput {
+	outputVersion := p.outputVersion
+	if outputVersion == "" {
+		outputVersion = OutputVersionV2
+	}
+	output := &api.GetServerInfoOutput{
+		Name:        ServerName,
+		Version:     p.serverInfo.Version,
+		BuildDate:   p.serverInfo.BuildDate,
+		APIVersion:  outputVersion,
+		APIVersions: []string{OutputVersionV1, OutputVersionV2},
+		Providers:   slices.Sorted(slices.Values(p.serverInfo.Providers)),
+		Tools:       []string{},
+		Locale:      i18n.DefaultLocale,
+		Locales:     []string{i18n.DefaultLocale},
+		Policy: api.ServerPolicy{
+			DryRunTools:       []string{},
+			AdmissionPolicy:   p.policy != nil,
+			AdmissionFailOpen: p.policy != nil && p.policyFailOpen,
+			Approvals:         p.approvals != nil,
+		},
+	}
+	if output.Providers == nil {
+		output.Providers = []string{}
+	}
+	if p.messages != nil {
+		output.Locale = p.messages.DefaultLocale()
+		output.Locales = p.messages.Locales()
+	}
+
+	for name, tool := range p.tools {
+		if p.toolSwitch != nil && p.toolSwitch.isDisabled(name) {
+			continue
+		}
+		output.Tools = append(output.Tools, name)
+		if hasArgument(tool, "dryRun") {
+			output.Policy.DryRunTools = append(output.Policy.DryRunTools, name)
+		}
+	}
+	sort.Strings(output.Tools)
+	sort.Strings(output.Policy.DryRunTools)
+
+	if p.maintenance != nil {
+		if status := p.maintenance.Status(); status.Active {
+			output.Policy.ReadOnly = true
+			output.Policy.Maintenance = &status
+		}
+	}
+	if p.approvals != nil {
+		output.Policy.ApprovalEnvironments = p.approvals.environments
+	}
+	if p.identity != nil && !p.identity.Unrestricted() {
+		output.Policy.Namespaces = p.identity.Namespaces
+	}
+	return output
+}
+
+// hasArgument reports whether the input schema of a tool has an argument.
+func hasArgument(tool *mcp.ServerTool, argument string) bool {
+	data, err := json.Marshal(tool.Tool.InputSchema)
+	if err != nil {
+		return false
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return false
+	}
+	_, ok := schema.Properties[argument]
+	return ok
+}
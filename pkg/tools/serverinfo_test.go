@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/i18n"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestEnhancedProvider_GetServerInfo(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	newProvider := func() *EnhancedProvider {
+		provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+		provider.SetServerInfo(ServerInfo{Version: "v1.2.3", BuildDate: "2026-10-01", Providers: []string{"gcp", "aws"}})
+		return provider
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		provider := newProvider()
+		require.NoError(t, provider.RegisterTools())
+
+		result, err := provider.handleGetServerInfoTyped(context.Background(), nil, &mcp.CallToolParamsFor[EnhancedEmptyArgs]{})
+		require.NoError(t, err)
+		var output api.GetServerInfoOutput
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &output))
+
+		assert.Equal(t, ServerName, output.Name)
+		assert.Equal(t, "v1.2.3", output.Version)
+		assert.Equal(t, "2026-10-01", output.BuildDate)
+		assert.Equal(t, OutputVersionV2, output.APIVersion)
+		assert.Equal(t, []string{OutputVersionV1, OutputVersionV2}, output.APIVersions)
+		assert.Equal(t, []string{"aws", "gcp"}, output.Providers)
+		assert.ElementsMatch(t, provider.GetSupportedTools(), output.Tools)
+		assert.Equal(t, "en", output.Locale)
+		assert.Equal(t, []string{"en"}, output.Locales)
+
+		assert.False(t, output.Policy.ReadOnly)
+		assert.False(t, output.Policy.DryRunDefault)
+		assert.Contains(t, output.Policy.DryRunTools, "create_cluster")
+		assert.Contains(t, output.Policy.DryRunTools, "update_cluster_variables")
+		assert.NotContains(t, output.Policy.DryRunTools, "delete_cluster")
+		assert.False(t, output.Policy.AdmissionPolicy)
+		assert.False(t, output.Policy.Approvals)
+		assert.Empty(t, output.Policy.Namespaces)
+	})
+
+	t.Run("policies in effect", func(t *testing.T) {
+		provider := newProvider()
+		toolSwitch := NewToolSwitch()
+		provider.SetToolSwitch(toolSwitch)
+		maintenance := NewMaintenance()
+		provider.SetMaintenance(maintenance)
+		provider.SetApprovals(NewApprovals(time.Hour, []string{"prod"}))
+		provider.SetIdentity(&auth.Identity{Name: "team-a", DefaultNamespace: "team-a", Namespaces: []string{"team-a"}})
+		catalog, err := i18n.NewCatalog("de")
+		require.NoError(t, err)
+		provider.SetMessageCatalog(catalog)
+		require.NoError(t, provider.RegisterTools())
+
+		_, err = toolSwitch.Disable("delete_cluster", "INC-42")
+		require.NoError(t, err)
+		maintenance.Start(time.Time{}, "management cluster upgrade")
+
+		output := provider.serverInfoOutput()
+		assert.NotContains(t, output.Tools, "delete_cluster")
+		assert.Contains(t, output.Tools, "get_cluster")
+		assert.True(t, output.Policy.ReadOnly)
+		require.NotNil(t, output.Policy.Maintenance)
+		assert.Equal(t, "management cluster upgrade", output.Policy.Maintenance.Reason)
+		assert.True(t, output.Policy.Approvals)
+		assert.Equal(t, []string{"prod"}, output.Policy.ApprovalEnvironments)
+		assert.Equal(t, []string{"team-a"}, output.Policy.Namespaces)
+		assert.Equal(t, "de", output.Locale)
+		assert.Equal(t, []string{"de", "en", "es"}, output.Locales)
+	})
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+)
+
+// ToolSwitch disables and re-enables tools at runtime on the MCP servers of
+// all identities, e.g. to freeze delete_cluster during an incident. A
+// disabled tool is removed from the servers, so clients are notified that the
+// tool list changed and calls to the tool are rejected. Disabled tools are
+// kept in memory and enabled again on restart.
+type ToolSwitch struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	providers []*EnhancedProvider
+	disabled  map[string]api.DisabledTool
+}
+
+// NewToolSwitch creates a switch with every tool enabled.
+func NewToolSwitch() *ToolSwitch {
+	return &ToolSwitch{
+		now:      time.Now,
+		disabled: make(map[string]api.DisabledTool),
+	}
+}
+
+// attach adds a provider whose tools the switch disables and re-enables.
+func (s *ToolSwitch) attach(p *EnhancedProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.providers = append(s.providers, p)
+}
+
+// isDisabled reports whether a tool is disabled.
+func (s *ToolSwitch) isDisabled(tool string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.disabled[tool]
+	return ok
+}
+
+// Disable removes a tool from the MCP servers of all identities. It reports
+// whether the tool was enabled before.
+func (s *ToolSwitch) Disable(tool, reason string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.knownLocked(tool); err != nil {
+		return false, err
+	}
+	if _, ok := s.disabled[tool]; ok {
+		return false, nil
+	}
+
+	s.disabled[tool] = api.DisabledTool{
+		Tool:       tool,
+		Reason:     reason,
+		DisabledAt: s.now().UTC().Format(time.RFC3339),
+	}
+	for _, p := range s.providers {
+		if _, ok := p.tools[tool]; ok {
+			p.mcpServer.RemoveTools(tool)
+		}
+	}
+	return true, nil
+}
+
+// Enable adds a disabled tool back to the MCP servers of all identities that
+// registered it. It reports whether the tool was disabled before.
+func (s *ToolSwitch) Enable(tool string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.knownLocked(tool); err != nil {
+		return false, err
+	}
+	if _, ok := s.disabled[tool]; !ok {
+		return false, nil
+	}
+
+	delete(s.disabled, tool)
+	for _, p := range s.providers {
+		if serverTool, ok := p.tools[tool]; ok {
+			p.mcpServer.AddTools(serverTool)
+		}
+	}
+	return true, nil
+}
+
+// Disabled returns the disabled tools ordered by name.
+func (s *ToolSwitch) Disabled() []api.DisabledTool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	disabled := make([]api.DisabledTool, 0, len(s.disabled))
+	for _, tool := range s.disabled {
+		disabled = append(disabled, tool)
+	}
+	sort.Slice(disabled, func(i, j int) bool {
+		return disabled[i].Tool < disabled[j].Tool
+	})
+	return disabled
+}
+
+// knownLocked returns an error unless a provider registered the tool. Tools
+// that control the switch are registered apart and cannot be disabled.
+func (s *ToolSwitch) knownLocked(tool string) error {
+	for _, p := range s.providers {
+		if _, ok := p.tools[tool]; ok {
+			return nil
+		}
+	}
+	return errors.New(errors.CodeNotFound, fmt.Sprintf("tool '%s' does not exist or cannot be disabled", tool)).
+		WithDetails("field", "tool")
+}
+
+// SetToolSwitch configures the switch that disables and re-enables the
+// provider's tools. It must be called before the tools are registered.
+func (p *EnhancedProvider) SetToolSwitch(toolSwitch *ToolSwitch) {
+	p.toolSwitch = toolSwitch
+	toolSwitch.attach(p)
+}
+
+// addTool registers a tool with the MCP server, unless the tool switch has
+// disabled it, and remembers it so the switch can re-enable it.
+func (p *EnhancedProvider) addTool(tool *mcp.ServerTool) {
+	if p.tools == nil {
+		p.tools = make(map[string]*mcp.ServerTool)
+	}
+	p.tools[tool.Tool.Name] = tool
+	if p.toolSwitch != nil && p.toolSwitch.isDisabled(tool.Tool.Name) {
+		return
+	}
+	p.mcpServer.AddTools(tool)
+}
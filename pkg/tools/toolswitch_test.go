@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/auth"
+	"github.com/capi-mcp/capi-mcp-server/internal/config"
+	"github.com/capi-mcp/capi-mcp-server/internal/errors"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+)
+
+func TestToolSwitch(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	toolSwitch := NewToolSwitch()
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	toolSwitch.now = func() time.Time { return now }
+
+	provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	provider.SetToolSwitch(toolSwitch)
+	require.NoError(t, provider.RegisterTools())
+	assert.Len(t, provider.tools, len(provider.GetSupportedTools()))
+
+	admin := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	admin.SetToolSwitch(toolSwitch)
+	require.NoError(t, admin.RegisterToolAdminTools())
+
+	changed, err := toolSwitch.Disable("delete_cluster", "INC-42")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = toolSwitch.Disable("delete_cluster", "again")
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, []api.DisabledTool{{Tool: "delete_cluster", Reason: "INC-42", DisabledAt: "2026-03-02T10:00:00Z"}}, toolSwitch.Disabled())
+
+	for _, tool := range []string{"no_such_tool", "disable_tool", "enable_tool"} {
+		_, err = toolSwitch.Disable(tool, "")
+		assert.Equal(t, errors.CodeNotFound, errors.GetErrorCode(err), tool)
+	}
+
+	changed, err = toolSwitch.Enable("delete_cluster")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	changed, err = toolSwitch.Enable("delete_cluster")
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, toolSwitch.Disabled())
+}
+
+func TestEnhancedProvider_ToolAdminTools(t *testing.T) {
+	logger := logging.NewLogger(slog.LevelError, "text")
+	toolSwitch := NewToolSwitch()
+	authenticator := auth.NewAuthenticator(&config.Config{APIKey: "admin-key", AdminAPIKey: "tool-admin-key"})
+
+	provider := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	provider.SetIdentity(authenticator.Authenticate("admin-key"))
+	provider.SetToolSwitch(toolSwitch)
+	require.NoError(t, provider.RegisterTools())
+
+	admin := NewEnhancedProvider(mcp.NewServer("test-server", "v1.0.0", nil), logger, nil)
+	admin.SetIdentity(authenticator.Authenticate("tool-admin-key"))
+	admin.SetToolSwitch(toolSwitch)
+	require.NoError(t, admin.RegisterToolAdminTools())
+
+	ctx := context.Background()
+	disable := &mcp.CallToolParamsFor[EnhancedSetToolEnabledArgs]{Arguments: EnhancedSetToolEnabledArgs{Tool: "delete_cluster", Reason: "INC-42"}}
+
+	t.Run("requires the tool admin", func(t *testing.T) {
+		_, err := provider.handleDisableToolTyped(ctx, nil, disable)
+		assert.Equal(t, errors.CodeForbidden, errors.GetErrorCode(err))
+		assert.Empty(t, toolSwitch.Disabled())
+	})
+
+	t.Run("disable and enable", func(t *testing.T) {
+		result, err := admin.handleDisableTool(ctx, map[string]interface{}{"tool": "delete_cluster", "reason": "INC-42"})
+		require.NoError(t, err)
+		output := result.(map[string]interface{})
+		assert.Equal(t, true, output["changed"])
+		assert.Equal(t, false, output["enabled"])
+		require.Len(t, toolSwitch.Disabled(), 1)
+
+		result, err = admin.handleEnableTool(ctx, map[string]interface{}{"tool": "delete_cluster"})
+		require.NoError(t, err)
+		output = result.(map[string]interface{})
+		assert.Equal(t, true, output["changed"])
+		assert.Equal(t, true, output["enabled"])
+		assert.Empty(t, toolSwitch.Disabled())
+	})
+
+	t.Run("tool is required", func(t *testing.T) {
+		_, err := admin.handleDisableTool(ctx, map[string]interface{}{"tool": ""})
+		assert.Equal(t, errors.CodeInvalidInput, errors.GetErrorCode(err))
+	})
+}
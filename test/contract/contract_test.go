@@ -0,0 +1,324 @@
+// Package contract checks the schemas the server advertises for its MCP
+// tools: against golden files, so that any change to a tool's arguments is
+// deliberate, and against the api/v1 input structs the arguments are parsed
+// into, so that every input field can be passed to its tool.
+//
+// Run with -update to rewrite the golden files after a deliberate change:
+//
+//	go test ./test/contract/ -update
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/config"
+	"github.com/capi-mcp/capi-mcp-server/internal/server"
+	"github.com/capi-mcp/capi-mcp-server/pkg/client"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the tool schemas")
+
+const goldenDir = "testdata"
+
+// toolSchema is the part of a tool's input schema under contract: the type
+// of each argument and which arguments are required. Descriptions are free
+// to change.
+type toolSchema struct {
+	Properties map[string]string `json:"properties"`
+	Required   []string          `json:"required"`
+}
+
+// schemas are the schemas of the tools the server advertises, by tool name.
+var schemas map[string]toolSchema
+
+// TestMain lists the tools once, since the server registers its metrics
+// globally and so can only be created once per process.
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	var err error
+	schemas, err = listTools()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to list the tools: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// listTools launches the server in-process on a simulated management cluster
+// and lists the tools of the operator and of the tool admin over MCP.
+func listTools() (map[string]toolSchema, error) {
+	for name, value := range map[string]string{
+		"API_KEY":            "contract-test-key",
+		"ADMIN_API_KEY":      "contract-test-admin-key",
+		"SIMULATION_ENABLED": "true",
+		"LOG_LEVEL":          "error",
+	} {
+		if err := os.Setenv(name, value); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	s, err := server.NewEnhanced(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpServer := httptest.NewServer(s.Handler())
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	schemas := map[string]toolSchema{}
+	for _, apiKey := range []string{cfg.APIKey, cfg.AdminAPIKey} {
+		c, err := client.New(httpServer.URL, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		tools, err := c.ListTools(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The schemas are listed; failing to end the session only leaves it
+		// to expire on the server
+		if err := c.Close(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to end the MCP session: %v\n", err)
+		}
+
+		for _, tool := range tools {
+			schema, err := normalize(tool.InputSchema)
+			if err != nil {
+				return nil, fmt.Errorf("tool %s: %w", tool.Name, err)
+			}
+			schemas[tool.Name] = schema
+		}
+	}
+	return schemas, nil
+}
+
+// normalize reduces an input schema to its toolSchema. Arguments of several
+// types have them joined with |, and untyped arguments are "any".
+func normalize(raw json.RawMessage) (toolSchema, error) {
+	var schema struct {
+		Properties map[string]struct {
+			Type interface{} `json:"type"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return toolSchema{}, err
+	}
+
+	normalized := toolSchema{Properties: map[string]string{}, Required: []string{}}
+	for name, property := range schema.Properties {
+		switch typ := property.Type.(type) {
+		case string:
+			normalized.Properties[name] = typ
+		case []interface{}:
+			types := make([]string, 0, len(typ))
+			for _, t := range typ {
+				types = append(types, t.(string))
+			}
+			sort.Strings(types)
+			normalized.Properties[name] = strings.Join(types, "|")
+		default:
+			normalized.Properties[name] = "any"
+		}
+	}
+	normalized.Required = append(normalized.Required, schema.Required...)
+	sort.Strings(normalized.Required)
+	return normalized, nil
+}
+
+// TestToolSchemas_Golden compares the schema of every tool to its golden
+// file, and fails for golden files of tools the server no longer has.
+func TestToolSchemas_Golden(t *testing.T) {
+	require.NotEmpty(t, schemas)
+
+	if *update {
+		require.NoError(t, os.RemoveAll(goldenDir))
+		require.NoError(t, os.MkdirAll(goldenDir, 0o755))
+	}
+
+	for name, schema := range schemas {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(goldenDir, name+".golden.json")
+			got, err := json.MarshalIndent(schema, "", "  ")
+			require.NoError(t, err)
+			got = append(got, '\n')
+
+			if *update {
+				require.NoError(t, os.WriteFile(path, got, 0o644))
+				return
+			}
+			want, err := os.ReadFile(path)
+			require.NoError(t, err, "no golden file for tool %s, run with -update", name)
+			assert.JSONEq(t, string(want), string(got), "schema of tool %s changed, run with -update if deliberate", name)
+		})
+	}
+
+	goldens, err := filepath.Glob(filepath.Join(goldenDir, "*.golden.json"))
+	require.NoError(t, err)
+	for _, path := range goldens {
+		name := strings.TrimSuffix(filepath.Base(path), ".golden.json")
+		assert.Contains(t, schemas, name, "golden file of removed tool %s", name)
+	}
+}
+
+// TestToolSchemas_APIv1 checks that each tool advertises every field of the
+// api/v1 input its arguments are parsed into, under the camelCase name of
+// the field, with a matching type, and requires the required fields.
+func TestToolSchemas_APIv1(t *testing.T) {
+	require.NotEmpty(t, schemas)
+
+	tests := []struct {
+		tool  string
+		input interface{}
+		// renamed maps fields to the arguments they are passed as, where
+		// the tool's argument differs from the field name
+		renamed map[string]string
+	}{
+		{tool: "get_cluster", input: api.GetClusterInput{}},
+		{tool: "get_cluster_dependencies", input: api.GetClusterDependenciesInput{}},
+		{tool: "create_cluster", input: api.CreateClusterInput{}},
+		{tool: "export_inventory", input: api.ExportInventoryInput{}},
+		{tool: "delete_cluster", input: api.DeleteClusterInput{}},
+		{tool: "preview_delete_cluster", input: api.PreviewDeleteClusterInput{}},
+		{tool: "undelete_cluster", input: api.UndeleteClusterInput{}},
+		{tool: "scale_cluster", input: api.ScaleClusterInput{}},
+		{tool: "create_node_pool", input: api.CreateNodePoolInput{}},
+		{tool: "update_cluster_variables", input: api.UpdateClusterVariablesInput{}},
+		{tool: "check_template_rotation", input: api.CheckTemplateRotationInput{}},
+		{tool: "refresh_cluster_templates", input: api.RefreshClusterTemplatesInput{}},
+		{tool: "pause_rollout", input: api.RolloutInput{}},
+		{tool: "resume_rollout", input: api.RolloutInput{}},
+		{tool: "restart_rollout", input: api.RolloutInput{}},
+		{tool: "undo_rollout", input: api.RolloutInput{}},
+		{tool: "bulk_scale", input: api.BulkScaleInput{}},
+		{tool: "bulk_upgrade", input: api.BulkUpgradeInput{}},
+		{tool: "get_cluster_kubeconfig", input: api.GetClusterKubeconfigInput{}},
+		{tool: "approve_operation", input: api.ApproveOperationInput{}},
+		{
+			tool:  "create_temporary_access",
+			input: api.CreateTemporaryAccessInput{},
+			// namespace selects the management cluster namespace of every tool
+			renamed: map[string]string{"namespace": "accessNamespace"},
+		},
+		{tool: "revoke_temporary_access", input: api.RevokeTemporaryAccessInput{}},
+		{tool: "get_cluster_nodes", input: api.GetClusterNodesInput{}},
+		{tool: "get_autoscaler_status", input: api.GetAutoscalerStatusInput{}},
+		{tool: "probe_cluster_api", input: api.ProbeClusterAPIInput{}},
+		{tool: "get_cluster_component_versions", input: api.GetClusterComponentVersionsInput{}},
+		{tool: "get_workload_resource", input: api.GetWorkloadResourceInput{}},
+		{tool: "get_workload_pod_logs", input: api.GetWorkloadPodLogsInput{}},
+		{tool: "run_node_diagnostic", input: api.RunNodeDiagnosticInput{}},
+		{tool: "install_cni", input: api.InstallCNIInput{}},
+		{tool: "resolve_node_image", input: api.ResolveNodeImageInput{}},
+		{tool: "configure_etcd_backup", input: api.ConfigureEtcdBackupInput{}},
+		{tool: "list_etcd_backups", input: api.ListEtcdBackupsInput{}},
+		{tool: "restore_cluster", input: api.RestoreClusterInput{}},
+		{tool: "run_conformance", input: api.RunConformanceInput{}},
+		{tool: "smoke_test_cluster", input: api.SmokeTestClusterInput{}},
+		{tool: "validate_cluster_template", input: api.ValidateClusterTemplateInput{}},
+		{tool: "publish_cluster_template", input: api.PublishClusterTemplateInput{}},
+		{tool: "get_operation_status", input: api.GetOperationStatusInput{}},
+		{tool: "cleanup_orphaned_resources", input: api.CleanupOrphanedResourcesInput{}},
+		{tool: "force_delete_cluster", input: api.ForceDeleteClusterInput{}},
+		{tool: "scan_orphaned_cloud_resources", input: api.ScanOrphanedCloudResourcesInput{}},
+		{tool: "upgrade_management_providers", input: api.UpgradeManagementProvidersInput{}},
+		{tool: "rotate_provider_credentials", input: api.RotateProviderCredentialsInput{}},
+		{tool: "create_tenant", input: api.CreateTenantInput{}},
+		{tool: "get_tool_usage", input: api.GetToolUsageInput{}},
+		{tool: "list_operations", input: api.ListOperationsInput{}},
+		{tool: "get_recent_changes", input: api.GetRecentChangesInput{}},
+		{tool: "rollback_operation", input: api.RollbackOperationInput{}},
+		{tool: "diff_cluster_state", input: api.DiffClusterStateInput{}},
+		{tool: "get_output_chunk", input: api.GetOutputChunkInput{}},
+		{tool: "disable_tool", input: api.SetToolEnabledInput{}},
+		{tool: "enable_tool", input: api.SetToolEnabledInput{}},
+		{tool: "start_maintenance", input: api.StartMaintenanceInput{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			schema, ok := schemas[tt.tool]
+			require.True(t, ok, "tool %s is not advertised", tt.tool)
+
+			typ := reflect.TypeOf(tt.input)
+			for i := 0; i < typ.NumField(); i++ {
+				field := typ.Field(i)
+				name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if name == "" || name == "-" {
+					continue
+				}
+				argument, ok := tt.renamed[name]
+				if !ok {
+					argument = camelCase(name)
+				}
+
+				advertised, ok := schema.Properties[argument]
+				if !assert.True(t, ok, "field %s of %s is not advertised as argument %s", field.Name, typ.Name(), argument) {
+					continue
+				}
+				if want := jsonType(field.Type); want != "" && advertised != "any" {
+					assert.Contains(t, strings.Split(advertised, "|"), want, "argument %s has type %s, field %s of %s is %s", argument, advertised, field.Name, typ.Name(), want)
+				}
+				if slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required") {
+					assert.Contains(t, schema.Required, argument, "field %s of %s is required, argument %s is not", field.Name, typ.Name(), argument)
+				}
+			}
+		})
+	}
+}
+
+// camelCase converts the snake_case name of an api/v1 field to the
+// camelCase name of its argument, e.g. node_pool_name to nodePoolName.
+func camelCase(name string) string {
+	words := strings.Split(name, "_")
+	for i := 1; i < len(words); i++ {
+		if words[i] != "" {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// jsonType is the JSON schema type of a Go type, or "" if it has none in
+// particular.
+func jsonType(typ reflect.Type) string {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return ""
+}
@@ -89,7 +89,7 @@ metadata:
 rules:
 # Cluster API permissions
 - apiGroups: ["cluster.x-k8s.io"]
-  resources: ["clusters", "clusterclasses", "machinedeployments", "machines"]
+  resources: ["clusters", "clusterclasses", "machinedeployments", "machinepools", "machines"]
   verbs: ["get", "list", "create", "update", "patch", "delete", "watch"]
 # AWS Infrastructure permissions  
 - apiGroups: ["infrastructure.cluster.x-k8s.io"]
@@ -97,7 +97,7 @@ rules:
   verbs: ["get", "list", "create", "update", "patch", "delete", "watch"]
 # Control plane permissions
 - apiGroups: ["controlplane.cluster.x-k8s.io"]
-  resources: ["kubeadmcontrolplanes", "kubeadmcontrolplanetemplates"]
+  resources: ["kubeadmcontrolplanes", "kubeadmcontrolplanetemplates", "awsmanagedcontrolplanes"]
   verbs: ["get", "list", "create", "update", "patch", "delete", "watch"]
 # Bootstrap permissions
 - apiGroups: ["bootstrap.cluster.x-k8s.io"]
@@ -111,6 +111,27 @@ rules:
 - apiGroups: [""]
   resources: ["events"]
   verbs: ["get", "list", "watch"]
+# Management cluster inventory (provider controllers, CRD contracts, clusterctl)
+- apiGroups: ["apps"]
+  resources: ["deployments"]
+  verbs: ["get", "list"]
+- apiGroups: ["apiextensions.k8s.io"]
+  resources: ["customresourcedefinitions"]
+  verbs: ["get"]
+- apiGroups: ["clusterctl.cluster.x-k8s.io"]
+  resources: ["providers"]
+  verbs: ["get", "list"]
+# Operation history records
+- apiGroups: [""]
+  resources: ["configmaps"]
+  verbs: ["get", "list", "create", "delete"]
+# Tenant onboarding (namespace, quota and RBAC for create_tenant)
+- apiGroups: [""]
+  resources: ["namespaces", "resourcequotas"]
+  verbs: ["get", "create"]
+- apiGroups: ["rbac.authorization.k8s.io"]
+  resources: ["roles", "rolebindings"]
+  verbs: ["get", "create", "bind", "escalate"]
 ---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: ClusterRoleBinding
@@ -68,9 +68,8 @@ func (s *CAPIOperationsTestSuite) SetupWithResources(t *testing.T, objects ...cl
 		WithStatusSubresource(&clusterv1.Cluster{}, &clusterv1.MachineDeployment{}).
 		Build()
 
-	// Create enhanced kube client that uses our fake client
-	// Note: In real integration tests, we would create a wrapper that injects the fake client
-	// For now, we'll test the operations that can work with the fake client directly
+	// These tests exercise the CAPI resources directly; EnvtestSuite runs the
+	// service layer against an API server
 }
 
 // TestCAPIResourceOperations tests CAPI resource operations with fake clients.
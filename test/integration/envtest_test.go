@@ -0,0 +1,265 @@
+package integration
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/capi-mcp/capi-mcp-server/api/v1"
+	"github.com/capi-mcp/capi-mcp-server/internal/kube"
+	"github.com/capi-mcp/capi-mcp-server/internal/logging"
+	"github.com/capi-mcp/capi-mcp-server/internal/service"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider"
+	"github.com/capi-mcp/capi-mcp-server/pkg/provider/aws"
+)
+
+// EnvtestSuite runs the service layer against a real API server started by
+// envtest with the CAPI CRDs installed. A stand-in for the CAPI controllers
+// accepts and finalizes clusters, so full operations run without AWS.
+type EnvtestSuite struct {
+	client         client.WithWatch
+	kubeClient     *kube.Client
+	clusterService *service.EnhancedClusterService
+	namespace      string
+}
+
+// NewEnvtestSuite starts an API server for the test, skipping the test when
+// the envtest binaries are not installed (see make test-envtest).
+func NewEnvtestSuite(t *testing.T) *EnvtestSuite {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS not set, run with make test-envtest")
+	}
+
+	env := &envtest.Environment{
+		CRDDirectoryPaths:     []string{capiCRDPath(t)},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := env.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, env.Stop())
+	})
+
+	scheme, err := kube.NewScheme()
+	require.NoError(t, err)
+	c, err := client.NewWithWatch(cfg, client.Options{Scheme: scheme})
+	require.NoError(t, err)
+
+	suite := &EnvtestSuite{client: c, namespace: "envtest"}
+	require.NoError(t, c.Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: suite.namespace}}))
+
+	suite.kubeClient, err = kube.NewClientFromConfig(cfg, suite.namespace, kube.NetworkOptions{})
+	require.NoError(t, err)
+
+	providerManager := provider.NewProviderManager()
+	providerManager.RegisterProvider(aws.NewAWSProvider("us-west-2"))
+	suite.clusterService = service.NewEnhancedClusterService(suite.kubeClient, logging.NewLogger(slog.LevelError, "text"), providerManager)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		suite.runClusterController(ctx, t)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return suite
+}
+
+// capiCRDPath returns the directory of the CAPI CRDs in the module cache.
+func capiCRDPath(t *testing.T) string {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "sigs.k8s.io/cluster-api").Output()
+	require.NoError(t, err, "failed to locate the cluster-api module")
+	return filepath.Join(strings.TrimSpace(string(out)), "config", "crd", "bases")
+}
+
+// runClusterController stands in for the CAPI cluster controller: new
+// clusters get its finalizer and go Provisioning, and deleted clusters lose
+// the finalizer so that they are gone.
+func (s *EnvtestSuite) runClusterController(ctx context.Context, t *testing.T) {
+	w, err := s.client.Watch(ctx, &clusterv1.ClusterList{}, client.InNamespace(s.namespace))
+	if err != nil {
+		t.Errorf("failed to watch clusters: %v", err)
+		return
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			cluster, isCluster := event.Object.(*clusterv1.Cluster)
+			if !isCluster || event.Type == watch.Deleted {
+				continue
+			}
+			if err := s.reconcileCluster(ctx, cluster); err != nil && ctx.Err() == nil && !apierrors.IsNotFound(err) {
+				t.Errorf("failed to reconcile cluster %s: %v", cluster.Name, err)
+			}
+		}
+	}
+}
+
+func (s *EnvtestSuite) reconcileCluster(ctx context.Context, cluster *clusterv1.Cluster) error {
+	if !cluster.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(cluster, clusterv1.ClusterFinalizer) {
+			return nil
+		}
+		patch := client.MergeFrom(cluster.DeepCopy())
+		controllerutil.RemoveFinalizer(cluster, clusterv1.ClusterFinalizer)
+		return s.client.Patch(ctx, cluster, patch)
+	}
+	if cluster.Status.Phase != "" {
+		return nil
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	controllerutil.AddFinalizer(cluster, clusterv1.ClusterFinalizer)
+	if err := s.client.Patch(ctx, cluster, patch); err != nil {
+		return err
+	}
+	patch = client.MergeFrom(cluster.DeepCopy())
+	cluster.Status.Phase = string(clusterv1.ClusterPhaseProvisioning)
+	return s.client.Status().Patch(ctx, cluster, patch)
+}
+
+// provision completes the provisioning of a cluster as the CAPI controllers
+// would, with a worker MachineDeployment of replicas ready machines.
+func (s *EnvtestSuite) provision(t *testing.T, clusterName string, replicas int32) {
+	ctx := context.Background()
+
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName + "-md-0",
+			Namespace: s.namespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterName},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: clusterName,
+			Replicas:    &replicas,
+			Selector:    metav1.LabelSelector{MatchLabels: map[string]string{clusterv1.ClusterNameLabel: clusterName}},
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{Labels: map[string]string{clusterv1.ClusterNameLabel: clusterName}},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: clusterName,
+					Version:     stringPtr("v1.31.0"),
+					Bootstrap:   clusterv1.Bootstrap{DataSecretName: stringPtr(clusterName + "-bootstrap")},
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+						Kind:       "AWSMachineTemplate",
+						Name:       clusterName + "-md-0",
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, s.client.Create(ctx, md))
+	patch := client.MergeFrom(md.DeepCopy())
+	md.Status.Replicas = replicas
+	md.Status.UpdatedReplicas = replicas
+	md.Status.ReadyReplicas = replicas
+	require.NoError(t, s.client.Status().Patch(ctx, md, patch))
+
+	cluster := &clusterv1.Cluster{}
+	require.NoError(t, s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: clusterName}, cluster))
+	patch = client.MergeFrom(cluster.DeepCopy())
+	cluster.Status.Phase = string(clusterv1.ClusterPhaseProvisioned)
+	cluster.Status.ControlPlaneReady = true
+	cluster.Status.InfrastructureReady = true
+	require.NoError(t, s.client.Status().Patch(ctx, cluster, patch))
+}
+
+// TestEnvtestClusterLifecycle creates, waits for, scales and deletes a
+// cluster through the service layer against a real API server.
+func TestEnvtestClusterLifecycle(t *testing.T) {
+	suite := NewEnvtestSuite(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	clusterClass := createTestClusterClass()
+	clusterClass.Namespace = suite.namespace
+	require.NoError(t, suite.client.Create(ctx, clusterClass))
+
+	t.Run("create waits for the cluster to be accepted", func(t *testing.T) {
+		output, err := suite.clusterService.CreateCluster(ctx, v1.CreateClusterInput{
+			ClusterName:       "envtest-cluster",
+			TemplateName:      clusterClass.Name,
+			KubernetesVersion: "v1.31.0",
+			Variables:         map[string]interface{}{"region": "us-west-2"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "envtest-cluster", output.ClusterName)
+		assert.Equal(t, v1.ClusterStatusProvisioning, output.Status)
+
+		_, err = suite.clusterService.CreateCluster(ctx, v1.CreateClusterInput{
+			ClusterName:       "envtest-cluster",
+			TemplateName:      clusterClass.Name,
+			KubernetesVersion: "v1.31.0",
+			Variables:         map[string]interface{}{"region": "us-west-2"},
+		})
+		assert.Error(t, err, "the cluster already exists")
+	})
+
+	t.Run("get reports the provisioned cluster", func(t *testing.T) {
+		suite.provision(t, "envtest-cluster", 2)
+
+		output, err := suite.clusterService.GetCluster(ctx, v1.GetClusterInput{ClusterName: "envtest-cluster"})
+		require.NoError(t, err)
+		assert.Equal(t, v1.ClusterStatusReady, output.Cluster.Status)
+		require.Len(t, output.Cluster.NodePools, 1)
+		assert.Equal(t, 2, output.Cluster.NodePools[0].ReadyReplicas)
+
+		clusters, err := suite.clusterService.ListClusters(ctx)
+		require.NoError(t, err)
+		require.Len(t, clusters.Clusters, 1)
+		assert.Equal(t, "envtest-cluster", clusters.Clusters[0].Name)
+	})
+
+	t.Run("scale updates the node pool", func(t *testing.T) {
+		output, err := suite.clusterService.ScaleCluster(ctx, v1.ScaleClusterInput{
+			ClusterName:  "envtest-cluster",
+			NodePoolName: "envtest-cluster-md-0",
+			Replicas:     4,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, output.OldReplicas)
+		assert.Equal(t, 4, output.NewReplicas)
+
+		md := &clusterv1.MachineDeployment{}
+		require.NoError(t, suite.client.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: "envtest-cluster-md-0"}, md))
+		assert.Equal(t, int32(4), *md.Spec.Replicas)
+	})
+
+	t.Run("delete waits for the cluster to be gone", func(t *testing.T) {
+		output, err := suite.clusterService.DeleteCluster(ctx, v1.DeleteClusterInput{ClusterName: "envtest-cluster"})
+		require.NoError(t, err)
+		assert.Equal(t, "deleted", output.Status)
+
+		err = suite.client.Get(ctx, client.ObjectKey{Namespace: suite.namespace, Name: "envtest-cluster"}, &clusterv1.Cluster{})
+		assert.True(t, apierrors.IsNotFound(err))
+
+		_, err = suite.clusterService.GetCluster(ctx, v1.GetClusterInput{ClusterName: "envtest-cluster"})
+		assert.Error(t, err)
+	})
+}
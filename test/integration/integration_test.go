@@ -299,13 +299,12 @@ func TestProviderSpecificIntegration(t *testing.T) {
 		// Test cluster with region variable
 		cluster := createTestAWSClusterWithRegion("aws-cluster-with-region", "default", "us-east-1")
 
-		status, err := awsProvider.GetProviderSpecificStatus(ctx, cluster)
+		status, err := awsProvider.GetProviderSpecificStatus(ctx, cluster, nil)
 		require.NoError(t, err)
 
-		assert.Equal(t, "aws", status["provider"])
-		assert.Equal(t, "AWSCluster", status["infrastructureKind"])
-		assert.Equal(t, "us-east-1", status["region"])
-		assert.Equal(t, true, status["ready"])
+		assert.Equal(t, "aws", status.Provider)
+		assert.Equal(t, "us-east-1", status.Region)
+		assert.True(t, status.InfrastructureReady)
 	})
 
 	t.Run("multi-provider support", func(t *testing.T) {
@@ -60,9 +60,8 @@ func (s *MCPToolsTestSuite) SetupToolProvider(t *testing.T, objects ...client.Ob
 	awsProvider := aws.NewAWSProvider("us-west-2")
 	providerManager.RegisterProvider(awsProvider)
 
-	// For integration testing, we would need to inject the fake client
-	// into the kube client wrapper. For now, we'll test with nil client
-	// to focus on the tool provider and service layer integration.
+	// Test with a nil client to focus on the tool provider and service layer
+	// integration; EnvtestSuite covers the operations against an API server.
 	clusterService := service.NewClusterService(nil, s.logger, providerManager)
 
 	// Create tool provider